@@ -3,8 +3,10 @@ package client
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/pachyderm/pachyderm/src/client/admin"
+	"github.com/pachyderm/pachyderm/src/client/auth"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pkg/pbutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
@@ -89,6 +91,106 @@ func (c APIClient) ExtractPipeline(pipelineName string) (*pps.CreatePipelineRequ
 	return op.Op1_8.Pipeline, nil
 }
 
+// ExportPipeline exports a single pipeline, including its spec, the ACL of its
+// output repo and whether it's stopped, so that it can be recreated on another
+// cluster with ImportPipeline.
+func (c APIClient) ExportPipeline(pipelineName string) (*admin.PipelineExport, error) {
+	spec, err := c.ExtractPipeline(pipelineName)
+	if err != nil {
+		return nil, err
+	}
+	pipelineInfo, err := c.InspectPipeline(pipelineName)
+	if err != nil {
+		return nil, err
+	}
+	result := &admin.PipelineExport{
+		Pipeline: spec,
+		Stopped:  pipelineInfo.Stopped,
+	}
+	resp, err := c.AuthAPIClient.GetACL(c.Ctx(), &auth.GetACLRequest{Repo: pipelineName})
+	if err != nil && !auth.IsErrNotActivated(err) {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	if err == nil {
+		for _, entry := range resp.Entries {
+			if !strings.HasPrefix(entry.Username, auth.PipelinePrefix) {
+				result.ACL = append(result.ACL, entry)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ImportPipeline recreates a pipeline exported by ExportPipeline. 'repos' maps
+// the names of the pipeline's input repos to the names of the repos that
+// they're replaced with on this cluster, if they're named differently. The
+// pipeline's input repos must already exist. The pipeline is created stopped,
+// and only started (unless it was exported stopped) once its ACL is restored,
+// so that it doesn't process any data before then.
+func (c APIClient) ImportPipeline(export *admin.PipelineExport, repos map[string]string) error {
+	spec := export.Pipeline
+	if spec == nil || spec.Pipeline == nil {
+		return fmt.Errorf("malformed pipeline export is missing pipeline")
+	}
+	var missing []string
+	// remap renames the input repo '*repo', whose input is named '*name', and
+	// checks that it exists
+	remap := func(repo *string, name *string) {
+		if newRepo, ok := repos[*repo]; ok {
+			if *name == "" {
+				// keep the input's name, which is used by the pipeline's code
+				*name = *repo
+			}
+			*repo = newRepo
+		}
+		if _, err := c.InspectRepo(*repo); err != nil {
+			missing = append(missing, *repo)
+		}
+	}
+	pps.VisitInput(spec.Input, func(input *pps.Input) {
+		switch {
+		case input.Pfs != nil:
+			remap(&input.Pfs.Repo, &input.Pfs.Name)
+		case input.Atom != nil:
+			remap(&input.Atom.Repo, &input.Atom.Name)
+		}
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("pipeline %s can't be imported because its input repo(s) %s don't exist on this cluster", spec.Pipeline.Name, strings.Join(missing, ", "))
+	}
+	spec.Stopped = true
+	if _, err := c.PpsAPIClient.CreatePipeline(c.Ctx(), spec); err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	if len(export.ACL) > 0 {
+		// Keep the entries of pipelines (including the new pipeline), which
+		// were added by PPS
+		resp, err := c.AuthAPIClient.GetACL(c.Ctx(), &auth.GetACLRequest{Repo: spec.Pipeline.Name})
+		if err != nil {
+			if auth.IsErrNotActivated(err) {
+				return fmt.Errorf("pipeline %s was imported stopped, but its ACL wasn't, as auth isn't active on this cluster; start it with 'pachctl start-pipeline %[1]s' if that's intended", spec.Pipeline.Name)
+			}
+			return grpcutil.ScrubGRPC(err)
+		}
+		entries := export.ACL
+		for _, entry := range resp.Entries {
+			if strings.HasPrefix(entry.Username, auth.PipelinePrefix) {
+				entries = append(entries, entry)
+			}
+		}
+		if _, err := c.AuthAPIClient.SetACL(c.Ctx(), &auth.SetACLRequest{
+			Repo:    spec.Pipeline.Name,
+			Entries: entries,
+		}); err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+	}
+	if export.Stopped {
+		return nil
+	}
+	return c.StartPipeline(spec.Pipeline.Name)
+}
+
 // Restore cluster state from an extract series of operations.
 func (c APIClient) Restore(ops []*admin.Op) (retErr error) {
 	restoreClient, err := c.AdminAPIClient.Restore(c.Ctx())
@@ -165,3 +267,26 @@ func (c APIClient) RestoreURL(url string) (retErr error) {
 	}()
 	return grpcutil.ScrubGRPC(restoreClient.Send(&admin.RestoreRequest{URL: url}))
 }
+
+// MigrateStorage copies all of the cluster's objects to the object store at
+// url, verifies them and then switches the cluster to that object store. f is
+// called with the migration's progress as it runs. An interrupted migration
+// can be resumed by calling MigrateStorage again with the same url.
+func (c APIClient) MigrateStorage(url string, f func(progress *admin.MigrateStorageProgress) error) error {
+	migrateClient, err := c.AdminAPIClient.MigrateStorage(c.Ctx(), &admin.MigrateStorageRequest{URL: url})
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	for {
+		progress, err := migrateClient.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		if err := f(progress); err != nil {
+			return err
+		}
+	}
+}
@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 import types "github.com/gogo/protobuf/types"
+import auth "github.com/pachyderm/pachyderm/src/client/auth"
 import pfs "github.com/pachyderm/pachyderm/src/client/pfs"
 import pps "github.com/pachyderm/pachyderm/src/client/pps"
 
@@ -45,7 +46,7 @@ func (m *Op1_7) Reset()         { *m = Op1_7{} }
 func (m *Op1_7) String() string { return proto.CompactTextString(m) }
 func (*Op1_7) ProtoMessage()    {}
 func (*Op1_7) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{0}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{0}
 }
 func (m *Op1_7) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -132,7 +133,7 @@ func (m *Op1_8) Reset()         { *m = Op1_8{} }
 func (m *Op1_8) String() string { return proto.CompactTextString(m) }
 func (*Op1_8) ProtoMessage()    {}
 func (*Op1_8) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{1}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{1}
 }
 func (m *Op1_8) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -215,7 +216,7 @@ func (m *Op) Reset()         { *m = Op{} }
 func (m *Op) String() string { return proto.CompactTextString(m) }
 func (*Op) ProtoMessage()    {}
 func (*Op) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{2}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{2}
 }
 func (m *Op) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,7 +278,7 @@ func (m *ExtractRequest) Reset()         { *m = ExtractRequest{} }
 func (m *ExtractRequest) String() string { return proto.CompactTextString(m) }
 func (*ExtractRequest) ProtoMessage()    {}
 func (*ExtractRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{3}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{3}
 }
 func (m *ExtractRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -345,7 +346,7 @@ func (m *ExtractPipelineRequest) Reset()         { *m = ExtractPipelineRequest{}
 func (m *ExtractPipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*ExtractPipelineRequest) ProtoMessage()    {}
 func (*ExtractPipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{4}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{4}
 }
 func (m *ExtractPipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -381,6 +382,75 @@ func (m *ExtractPipelineRequest) GetPipeline() *pps.Pipeline {
 	return nil
 }
 
+// PipelineExport is a single pipeline, in a form that can be recreated on
+// another cluster (e.g. to promote a pipeline from a dev cluster to a prod
+// cluster). Unlike an extract, it doesn't include the pipeline's data.
+type PipelineExport struct {
+	Pipeline *pps.CreatePipelineRequest `protobuf:"bytes,1,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	// ACL is the ACL of the pipeline's output repo, if auth was active. It
+	// doesn't include the entries of pipelines, which PPS manages.
+	ACL []*auth.ACLEntry `protobuf:"bytes,2,rep,name=acl,proto3" json:"acl,omitempty"`
+	// Stopped is set if the pipeline was stopped when it was exported.
+	Stopped              bool     `protobuf:"varint,3,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PipelineExport) Reset()         { *m = PipelineExport{} }
+func (m *PipelineExport) String() string { return proto.CompactTextString(m) }
+func (*PipelineExport) ProtoMessage()    {}
+func (*PipelineExport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{5}
+}
+func (m *PipelineExport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PipelineExport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PipelineExport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *PipelineExport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineExport.Merge(dst, src)
+}
+func (m *PipelineExport) XXX_Size() int {
+	return m.Size()
+}
+func (m *PipelineExport) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineExport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PipelineExport proto.InternalMessageInfo
+
+func (m *PipelineExport) GetPipeline() *pps.CreatePipelineRequest {
+	if m != nil {
+		return m.Pipeline
+	}
+	return nil
+}
+
+func (m *PipelineExport) GetACL() []*auth.ACLEntry {
+	if m != nil {
+		return m.ACL
+	}
+	return nil
+}
+
+func (m *PipelineExport) GetStopped() bool {
+	if m != nil {
+		return m.Stopped
+	}
+	return false
+}
+
 type RestoreRequest struct {
 	Op *Op `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
 	// URL is an object storage URL, if it's not "" data will be restored from
@@ -395,7 +465,7 @@ func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
 func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
 func (*RestoreRequest) ProtoMessage()    {}
 func (*RestoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{5}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{6}
 }
 func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -438,6 +508,133 @@ func (m *RestoreRequest) GetURL() string {
 	return ""
 }
 
+type MigrateStorageRequest struct {
+	// URL is the object store (e.g. "s3://bucket") to migrate the cluster's
+	// data to.
+	URL                  string   `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigrateStorageRequest) Reset()         { *m = MigrateStorageRequest{} }
+func (m *MigrateStorageRequest) String() string { return proto.CompactTextString(m) }
+func (*MigrateStorageRequest) ProtoMessage()    {}
+func (*MigrateStorageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{7}
+}
+func (m *MigrateStorageRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MigrateStorageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MigrateStorageRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *MigrateStorageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateStorageRequest.Merge(dst, src)
+}
+func (m *MigrateStorageRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MigrateStorageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateStorageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateStorageRequest proto.InternalMessageInfo
+
+func (m *MigrateStorageRequest) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+type MigrateStorageProgress struct {
+	// Objects is the number of objects that have been migrated so far.
+	Objects int64 `protobuf:"varint,1,opt,name=objects,proto3" json:"objects,omitempty"`
+	// Copied is the number of those objects that had to be copied, rather
+	// than already being in the destination from an earlier migration.
+	Copied int64 `protobuf:"varint,2,opt,name=copied,proto3" json:"copied,omitempty"`
+	// Bytes is the total size of the objects that were copied.
+	Bytes int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Switched is set in the last message, once every object has been
+	// migrated and verified and the cluster has been switched to the new
+	// object store.
+	Switched             bool     `protobuf:"varint,4,opt,name=switched,proto3" json:"switched,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigrateStorageProgress) Reset()         { *m = MigrateStorageProgress{} }
+func (m *MigrateStorageProgress) String() string { return proto.CompactTextString(m) }
+func (*MigrateStorageProgress) ProtoMessage()    {}
+func (*MigrateStorageProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{8}
+}
+func (m *MigrateStorageProgress) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MigrateStorageProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MigrateStorageProgress.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *MigrateStorageProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateStorageProgress.Merge(dst, src)
+}
+func (m *MigrateStorageProgress) XXX_Size() int {
+	return m.Size()
+}
+func (m *MigrateStorageProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateStorageProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateStorageProgress proto.InternalMessageInfo
+
+func (m *MigrateStorageProgress) GetObjects() int64 {
+	if m != nil {
+		return m.Objects
+	}
+	return 0
+}
+
+func (m *MigrateStorageProgress) GetCopied() int64 {
+	if m != nil {
+		return m.Copied
+	}
+	return 0
+}
+
+func (m *MigrateStorageProgress) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *MigrateStorageProgress) GetSwitched() bool {
+	if m != nil {
+		return m.Switched
+	}
+	return false
+}
+
 type ClusterInfo struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -449,7 +646,7 @@ func (m *ClusterInfo) Reset()         { *m = ClusterInfo{} }
 func (m *ClusterInfo) String() string { return proto.CompactTextString(m) }
 func (*ClusterInfo) ProtoMessage()    {}
 func (*ClusterInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_9d2f08281d18ec9f, []int{6}
+	return fileDescriptor_admin_00fe5bc86df0d44d, []int{9}
 }
 func (m *ClusterInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -491,7 +688,10 @@ func init() {
 	proto.RegisterType((*Op)(nil), "admin.Op")
 	proto.RegisterType((*ExtractRequest)(nil), "admin.ExtractRequest")
 	proto.RegisterType((*ExtractPipelineRequest)(nil), "admin.ExtractPipelineRequest")
+	proto.RegisterType((*PipelineExport)(nil), "admin.PipelineExport")
 	proto.RegisterType((*RestoreRequest)(nil), "admin.RestoreRequest")
+	proto.RegisterType((*MigrateStorageRequest)(nil), "admin.MigrateStorageRequest")
+	proto.RegisterType((*MigrateStorageProgress)(nil), "admin.MigrateStorageProgress")
 	proto.RegisterType((*ClusterInfo)(nil), "admin.ClusterInfo")
 }
 
@@ -511,6 +711,7 @@ type APIClient interface {
 	ExtractPipeline(ctx context.Context, in *ExtractPipelineRequest, opts ...grpc.CallOption) (*Op, error)
 	Restore(ctx context.Context, opts ...grpc.CallOption) (API_RestoreClient, error)
 	InspectCluster(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*ClusterInfo, error)
+	MigrateStorage(ctx context.Context, in *MigrateStorageRequest, opts ...grpc.CallOption) (API_MigrateStorageClient, error)
 }
 
 type aPIClient struct {
@@ -605,12 +806,45 @@ func (c *aPIClient) InspectCluster(ctx context.Context, in *types.Empty, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) MigrateStorage(ctx context.Context, in *MigrateStorageRequest, opts ...grpc.CallOption) (API_MigrateStorageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[2], "/admin.API/MigrateStorage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIMigrateStorageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_MigrateStorageClient interface {
+	Recv() (*MigrateStorageProgress, error)
+	grpc.ClientStream
+}
+
+type aPIMigrateStorageClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIMigrateStorageClient) Recv() (*MigrateStorageProgress, error) {
+	m := new(MigrateStorageProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // APIServer is the server API for API service.
 type APIServer interface {
 	Extract(*ExtractRequest, API_ExtractServer) error
 	ExtractPipeline(context.Context, *ExtractPipelineRequest) (*Op, error)
 	Restore(API_RestoreServer) error
 	InspectCluster(context.Context, *types.Empty) (*ClusterInfo, error)
+	MigrateStorage(*MigrateStorageRequest, API_MigrateStorageServer) error
 }
 
 func RegisterAPIServer(s *grpc.Server, srv APIServer) {
@@ -700,6 +934,27 @@ func _API_InspectCluster_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_MigrateStorage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateStorageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).MigrateStorage(m, &aPIMigrateStorageServer{stream})
+}
+
+type API_MigrateStorageServer interface {
+	Send(*MigrateStorageProgress) error
+	grpc.ServerStream
+}
+
+type aPIMigrateStorageServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIMigrateStorageServer) Send(m *MigrateStorageProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _API_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "admin.API",
 	HandlerType: (*APIServer)(nil),
@@ -724,6 +979,11 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			Handler:       _API_Restore_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "MigrateStorage",
+			Handler:       _API_MigrateStorage_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "client/admin/admin.proto",
 }
@@ -1019,6 +1279,59 @@ func (m *ExtractPipelineRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PipelineExport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PipelineExport) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Pipeline.Size()))
+		n16, err := m.Pipeline.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if len(m.ACL) > 0 {
+		for _, msg := range m.ACL {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Stopped {
+		dAtA[i] = 0x18
+		i++
+		if m.Stopped {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *RestoreRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1038,11 +1351,11 @@ func (m *RestoreRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintAdmin(dAtA, i, uint64(m.Op.Size()))
-		n16, err := m.Op.MarshalTo(dAtA[i:])
+		n17, err := m.Op.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n17
 	}
 	if len(m.URL) > 0 {
 		dAtA[i] = 0x12
@@ -1056,6 +1369,79 @@ func (m *RestoreRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *MigrateStorageRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MigrateStorageRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.URL) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.URL)))
+		i += copy(dAtA[i:], m.URL)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *MigrateStorageProgress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MigrateStorageProgress) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Objects != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Objects))
+	}
+	if m.Copied != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Copied))
+	}
+	if m.Bytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Bytes))
+	}
+	if m.Switched {
+		dAtA[i] = 0x20
+		i++
+		if m.Switched {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *ClusterInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1225,14 +1611,39 @@ func (m *ExtractPipelineRequest) Size() (n int) {
 	return n
 }
 
-func (m *RestoreRequest) Size() (n int) {
+func (m *PipelineExport) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Op != nil {
-		l = m.Op.Size()
+	if m.Pipeline != nil {
+		l = m.Pipeline.Size()
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if len(m.ACL) > 0 {
+		for _, e := range m.ACL {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	if m.Stopped {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RestoreRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Op != nil {
+		l = m.Op.Size()
 		n += 1 + l + sovAdmin(uint64(l))
 	}
 	l = len(m.URL)
@@ -1245,6 +1656,46 @@ func (m *RestoreRequest) Size() (n int) {
 	return n
 }
 
+func (m *MigrateStorageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MigrateStorageProgress) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Objects != 0 {
+		n += 1 + sovAdmin(uint64(m.Objects))
+	}
+	if m.Copied != 0 {
+		n += 1 + sovAdmin(uint64(m.Copied))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovAdmin(uint64(m.Bytes))
+	}
+	if m.Switched {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ClusterInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2113,6 +2564,141 @@ func (m *ExtractPipelineRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PipelineExport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PipelineExport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PipelineExport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pipeline == nil {
+				m.Pipeline = &pps.CreatePipelineRequest{}
+			}
+			if err := m.Pipeline.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ACL", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ACL = append(m.ACL, &auth.ACLEntry{})
+			if err := m.ACL[len(m.ACL)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stopped", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stopped = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *RestoreRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2226,6 +2812,214 @@ func (m *RestoreRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MigrateStorageRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MigrateStorageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MigrateStorageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MigrateStorageProgress) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MigrateStorageProgress: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MigrateStorageProgress: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+			}
+			m.Objects = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Objects |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Copied", wireType)
+			}
+			m.Copied = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Copied |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Switched", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Switched = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ClusterInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2411,46 +3205,57 @@ var (
 	ErrIntOverflowAdmin   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("client/admin/admin.proto", fileDescriptor_admin_9d2f08281d18ec9f) }
-
-var fileDescriptor_admin_9d2f08281d18ec9f = []byte{
-	// 608 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x94, 0xd1, 0x6e, 0xd3, 0x3e,
-	0x14, 0xc6, 0x9b, 0x74, 0x4d, 0xbb, 0xb3, 0xfd, 0xf7, 0x47, 0x16, 0x2b, 0x59, 0xd0, 0x0a, 0x8b,
-	0x84, 0x18, 0x48, 0x24, 0xdb, 0x90, 0x58, 0x2f, 0x18, 0xd2, 0x5a, 0x76, 0x51, 0x84, 0xd4, 0xca,
-	0x82, 0x1b, 0x6e, 0xaa, 0x34, 0x75, 0xd3, 0xa0, 0xd6, 0x36, 0xb1, 0x23, 0xb1, 0x2b, 0x5e, 0x83,
-	0x27, 0xe0, 0x59, 0xb8, 0xe4, 0x09, 0x10, 0x2a, 0x8f, 0xc0, 0x0b, 0xa0, 0xd8, 0x49, 0x96, 0x56,
-	0x82, 0x27, 0xe0, 0x22, 0x95, 0x7b, 0xf2, 0xfb, 0x8e, 0xfd, 0x7d, 0xb1, 0x0e, 0xd8, 0xe1, 0x22,
-	0x26, 0x54, 0xfa, 0xc1, 0x74, 0x19, 0x53, 0xfd, 0xeb, 0xf1, 0x84, 0x49, 0x86, 0x1a, 0xea, 0x8f,
-	0x73, 0x37, 0x62, 0x2c, 0x5a, 0x10, 0x5f, 0x15, 0x27, 0xe9, 0xcc, 0x27, 0x4b, 0x2e, 0xaf, 0x35,
-	0xe3, 0xdc, 0x8e, 0x58, 0xc4, 0xd4, 0xd2, 0xcf, 0x56, 0x45, 0x35, 0xef, 0xc9, 0x67, 0x22, 0x7b,
-	0x36, 0xab, 0x5c, 0x64, 0x8f, 0xae, 0xba, 0x5f, 0x4c, 0x68, 0x0c, 0xf9, 0xe9, 0xf8, 0x1c, 0x3d,
-	0x01, 0x8b, 0x4d, 0xde, 0x93, 0x50, 0xda, 0xe6, 0x7d, 0xe3, 0x78, 0xe7, 0x6c, 0xdf, 0xcb, 0xb4,
-	0xa3, 0x54, 0x0e, 0x55, 0x15, 0x93, 0x0f, 0x29, 0x11, 0x12, 0xe7, 0x10, 0x7a, 0x08, 0x75, 0x19,
-	0x44, 0x76, 0xbd, 0xc2, 0xbe, 0x09, 0xa2, 0x75, 0x36, 0x23, 0xd0, 0x63, 0xd8, 0x4a, 0x08, 0x67,
-	0xf6, 0x96, 0x22, 0xdb, 0x8a, 0xec, 0x27, 0x24, 0x90, 0x04, 0x13, 0xce, 0x0a, 0x54, 0x31, 0xc8,
-	0x07, 0x2b, 0x64, 0xcb, 0x65, 0x2c, 0xed, 0x86, 0xa2, 0xef, 0x28, 0xba, 0x97, 0xc6, 0x8b, 0x69,
-	0x5f, 0xd5, 0xcb, 0x53, 0x68, 0x0c, 0x9d, 0x80, 0x35, 0x49, 0x02, 0x1a, 0xce, 0x6d, 0x4b, 0x09,
-	0xec, 0x4a, 0xfb, 0x9e, 0x7a, 0x51, 0x2a, 0x34, 0x87, 0x9e, 0x41, 0x8b, 0xc7, 0x9c, 0x2c, 0x62,
-	0x4a, 0xec, 0xa6, 0xd2, 0x38, 0x5e, 0x16, 0x87, 0xd6, 0x8c, 0xf2, 0x57, 0x85, 0xaa, 0x64, 0xcb,
-	0xa0, 0xba, 0xff, 0x82, 0xfa, 0x7b, 0x50, 0xaf, 0xc0, 0x1c, 0x72, 0x74, 0x04, 0x0d, 0x96, 0x5d,
-	0x2b, 0xdb, 0x50, 0xd2, 0x5d, 0x4f, 0x5f, 0x6d, 0x75, 0xd5, 0xf0, 0x16, 0xe3, 0xa7, 0xe7, 0x05,
-	0xd2, 0xcd, 0x63, 0xac, 0x22, 0x5d, 0x85, 0x74, 0xdd, 0x4f, 0xb0, 0x77, 0xf5, 0x51, 0x26, 0x41,
-	0x99, 0x14, 0xba, 0x05, 0xf5, 0xb7, 0xf8, 0xb5, 0xea, 0xba, 0x8d, 0xb3, 0x25, 0x3a, 0x04, 0xa0,
-	0x6c, 0xac, 0xc3, 0x16, 0xaa, 0x57, 0x0b, 0x6f, 0x53, 0xa6, 0x03, 0x16, 0xe8, 0x00, 0x5a, 0x94,
-	0x8d, 0xb3, 0xd0, 0x84, 0xfa, 0x06, 0x2d, 0xdc, 0xa4, 0x2c, 0x0b, 0x54, 0xa0, 0x23, 0xd8, 0xa5,
-	0x6c, 0x5c, 0x1c, 0x5c, 0xa8, 0xe0, 0x5b, 0x78, 0x87, 0xb2, 0xc2, 0x9c, 0x70, 0xfb, 0xd0, 0xce,
-	0x0f, 0xb0, 0x61, 0x18, 0x3d, 0xaa, 0xc4, 0xa3, 0x3d, 0xfe, 0xa7, 0xe2, 0x29, 0xb9, 0x9b, 0x44,
-	0x2e, 0x60, 0x0f, 0x13, 0x21, 0x59, 0x52, 0x8a, 0x0f, 0xc0, 0x64, 0x3c, 0x97, 0x6d, 0x97, 0xbe,
-	0xb1, 0xc9, 0x78, 0x61, 0xd0, 0x2c, 0x0d, 0xba, 0x0f, 0x60, 0xa7, 0xbf, 0x48, 0x85, 0x24, 0xc9,
-	0x80, 0xce, 0x18, 0x6a, 0x83, 0x19, 0x4f, 0x75, 0x00, 0x3d, 0x6b, 0xf5, 0xfd, 0x9e, 0x39, 0x78,
-	0x89, 0xcd, 0x78, 0x7a, 0xf6, 0xcb, 0x80, 0xfa, 0xe5, 0x68, 0x80, 0x7c, 0x68, 0xe6, 0x47, 0x46,
-	0xfb, 0x79, 0xeb, 0xf5, 0x0c, 0x9d, 0x9b, 0x1d, 0xdd, 0xda, 0x89, 0x81, 0x2e, 0xe0, 0xff, 0x0d,
-	0x8f, 0xe8, 0x70, 0x5d, 0xb8, 0xe1, 0x7d, 0xad, 0x01, 0x7a, 0x0e, 0xcd, 0xdc, 0x5d, 0xb9, 0xdf,
-	0xba, 0x5b, 0xa7, 0xed, 0xe9, 0x19, 0xe6, 0x15, 0x33, 0xcc, 0xbb, 0xca, 0x66, 0x98, 0x5b, 0x3b,
-	0x36, 0xd0, 0x0b, 0xd8, 0x1b, 0x50, 0xc1, 0x49, 0x28, 0x73, 0x8f, 0xe8, 0x0f, 0xb4, 0x83, 0xf2,
-	0xe6, 0x95, 0x2c, 0xdc, 0x5a, 0xef, 0xf2, 0xeb, 0xaa, 0x63, 0x7c, 0x5b, 0x75, 0x8c, 0x1f, 0xab,
-	0x8e, 0xf1, 0xf9, 0x67, 0xa7, 0xf6, 0xce, 0x8f, 0x62, 0x39, 0x4f, 0x27, 0x5e, 0xc8, 0x96, 0x3e,
-	0x0f, 0xc2, 0xf9, 0xf5, 0x94, 0x24, 0xd5, 0x95, 0x48, 0x42, 0xbf, 0x3a, 0x74, 0x27, 0x96, 0xda,
-	0xe8, 0xe9, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x05, 0x8b, 0x60, 0xcb, 0x8b, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("client/admin/admin.proto", fileDescriptor_admin_00fe5bc86df0d44d) }
+
+var fileDescriptor_admin_00fe5bc86df0d44d = []byte{
+	// 775 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x95, 0x4d, 0x6e, 0xdb, 0x46,
+	0x14, 0xc7, 0x45, 0xd2, 0xfa, 0xf0, 0x93, 0xab, 0x16, 0x03, 0x5b, 0xa5, 0xd9, 0x5a, 0xb6, 0x09,
+	0x14, 0xb5, 0x0b, 0x94, 0xb4, 0x5d, 0xc0, 0xd6, 0xa2, 0x2e, 0x20, 0xa9, 0x5a, 0xa8, 0x70, 0x61,
+	0x65, 0x92, 0x6c, 0xb2, 0x11, 0x28, 0x72, 0x4c, 0x31, 0x90, 0x38, 0x13, 0xce, 0x08, 0xb1, 0x80,
+	0x00, 0x39, 0x41, 0xf6, 0x39, 0x41, 0x8e, 0x12, 0x64, 0x99, 0x13, 0x18, 0x81, 0x72, 0x91, 0x80,
+	0xc3, 0x0f, 0x4b, 0xb2, 0x1d, 0xe4, 0x00, 0x59, 0x88, 0x98, 0x79, 0xfc, 0xbd, 0xe1, 0x7b, 0xff,
+	0xff, 0x13, 0x06, 0x74, 0x77, 0x1c, 0x90, 0x50, 0xd8, 0x8e, 0x37, 0x09, 0xc2, 0xe4, 0x69, 0xb1,
+	0x88, 0x0a, 0x8a, 0x8a, 0x72, 0x63, 0xfc, 0xe2, 0x53, 0xea, 0x8f, 0x89, 0x2d, 0x83, 0xc3, 0xe9,
+	0x95, 0x4d, 0x26, 0x4c, 0xcc, 0x12, 0xc6, 0xd8, 0xf4, 0xa9, 0x4f, 0xe5, 0xd2, 0x8e, 0x57, 0x69,
+	0xb4, 0x9e, 0x9d, 0x39, 0x15, 0x23, 0xf9, 0xc8, 0xe8, 0x34, 0xce, 0xae, 0x78, 0xfc, 0x5b, 0x8d,
+	0x32, 0x1e, 0xff, 0x92, 0xa8, 0xf9, 0x4e, 0x85, 0xe2, 0x25, 0x3b, 0x1e, 0x9c, 0xa1, 0x3f, 0xa1,
+	0x44, 0x87, 0xcf, 0x89, 0x2b, 0x74, 0x75, 0x4f, 0x39, 0xa8, 0x9e, 0x6c, 0x59, 0x71, 0x6e, 0x7f,
+	0x2a, 0x2e, 0x65, 0x14, 0x93, 0x17, 0x53, 0xc2, 0x05, 0x4e, 0x21, 0xf4, 0x3b, 0x68, 0xc2, 0xf1,
+	0x75, 0x6d, 0x81, 0x7d, 0xe2, 0xf8, 0xcb, 0x6c, 0x4c, 0xa0, 0x3f, 0x60, 0x2d, 0x22, 0x8c, 0xea,
+	0x6b, 0x92, 0xac, 0x4b, 0xb2, 0x13, 0x11, 0x47, 0x10, 0x4c, 0x18, 0xcd, 0x50, 0xc9, 0x20, 0x1b,
+	0x4a, 0x2e, 0x9d, 0x4c, 0x02, 0xa1, 0x17, 0x25, 0xfd, 0xb3, 0xa4, 0xdb, 0xd3, 0x60, 0xec, 0x75,
+	0x64, 0x3c, 0xaf, 0x22, 0xc1, 0xd0, 0x11, 0x94, 0x86, 0x91, 0x13, 0xba, 0x23, 0xbd, 0x24, 0x13,
+	0xf4, 0x85, 0xe3, 0xdb, 0xf2, 0x45, 0x9e, 0x91, 0x70, 0xe8, 0x14, 0x2a, 0x2c, 0x60, 0x64, 0x1c,
+	0x84, 0x44, 0x2f, 0xcb, 0x1c, 0xc3, 0x62, 0x2c, 0xcb, 0xe9, 0xa7, 0xaf, 0xb2, 0xac, 0x9c, 0xcd,
+	0x85, 0x6a, 0x7e, 0x17, 0xea, 0xeb, 0x42, 0xfd, 0x07, 0xea, 0x25, 0x43, 0xfb, 0x50, 0xa4, 0xf1,
+	0x58, 0xe9, 0x8a, 0x4c, 0xdd, 0xb0, 0x92, 0x91, 0x97, 0xa3, 0x86, 0xd7, 0x28, 0x3b, 0x3e, 0xcb,
+	0x90, 0xa6, 0xae, 0xde, 0x41, 0x9a, 0x12, 0x69, 0x9a, 0xaf, 0xa1, 0xd6, 0xbd, 0x16, 0x91, 0x93,
+	0x2b, 0x85, 0x7e, 0x02, 0xed, 0x29, 0xbe, 0x90, 0xa7, 0xae, 0xe3, 0x78, 0x89, 0x76, 0x00, 0x42,
+	0x3a, 0x48, 0xc4, 0xe6, 0xf2, 0xac, 0x0a, 0x5e, 0x0f, 0x69, 0x22, 0x30, 0x47, 0xdb, 0x50, 0x09,
+	0xe9, 0x20, 0x16, 0x8d, 0x4b, 0x0f, 0x2a, 0xb8, 0x1c, 0xd2, 0x58, 0x50, 0x8e, 0xf6, 0x61, 0x23,
+	0xa4, 0x83, 0xac, 0x70, 0x2e, 0x85, 0xaf, 0xe0, 0x6a, 0x48, 0xb3, 0xe6, 0xb8, 0xd9, 0x81, 0x7a,
+	0x5a, 0xc0, 0x4a, 0xc3, 0xe8, 0x70, 0x41, 0x9e, 0xa4, 0xc7, 0x1f, 0xa4, 0x3c, 0x39, 0x77, 0xab,
+	0xc8, 0x1b, 0x05, 0x6a, 0x59, 0xb8, 0x7b, 0xcd, 0x68, 0x24, 0xd0, 0xe9, 0x9d, 0xec, 0x6f, 0x12,
+	0x17, 0x1d, 0x82, 0xe6, 0xb8, 0x63, 0x5d, 0xdd, 0xd3, 0x0e, 0xaa, 0x27, 0x35, 0x4b, 0xfe, 0xe9,
+	0x5b, 0x9d, 0x8b, 0x6e, 0x28, 0xa2, 0x59, 0xbb, 0x3c, 0xbf, 0xd9, 0xd5, 0x5a, 0x9d, 0x0b, 0x1c,
+	0x33, 0x48, 0x87, 0x32, 0x17, 0x94, 0x31, 0xe2, 0x65, 0x7d, 0xa7, 0x5b, 0xf3, 0x1c, 0x6a, 0x98,
+	0x70, 0x41, 0xa3, 0xbc, 0x99, 0x6d, 0x50, 0x29, 0x4b, 0x0b, 0x59, 0xcf, 0x7d, 0xc0, 0x2a, 0x65,
+	0x99, 0xe0, 0x6a, 0x2e, 0xb8, 0x79, 0x08, 0x5b, 0xff, 0x07, 0x7e, 0xe4, 0x08, 0xf2, 0x58, 0xd0,
+	0xc8, 0xf1, 0xc9, 0x83, 0xde, 0x98, 0xaf, 0xa0, 0xbe, 0x8c, 0xf6, 0x23, 0xea, 0x47, 0x84, 0xf3,
+	0xb8, 0xba, 0xcc, 0xb2, 0x98, 0xd7, 0x70, 0xb6, 0x45, 0xf5, 0x78, 0xb4, 0x59, 0x40, 0x3c, 0xf9,
+	0x4d, 0x0d, 0xa7, 0x3b, 0xb4, 0x09, 0xc5, 0xe1, 0x4c, 0x90, 0xc4, 0x45, 0x0d, 0x27, 0x1b, 0x64,
+	0x40, 0x85, 0xbf, 0x0c, 0x84, 0x3b, 0x22, 0x5e, 0xea, 0x5f, 0xbe, 0x37, 0x7f, 0x83, 0x6a, 0x67,
+	0x3c, 0xe5, 0x82, 0x44, 0xbd, 0xf0, 0x8a, 0xa2, 0x3a, 0xa8, 0x81, 0x97, 0x54, 0xd7, 0x2e, 0xcd,
+	0x6f, 0x76, 0xd5, 0xde, 0xbf, 0x58, 0x0d, 0xbc, 0x93, 0xf7, 0x2a, 0x68, 0xad, 0x7e, 0x0f, 0xd9,
+	0x50, 0x4e, 0xbd, 0x46, 0x5b, 0xa9, 0x06, 0xcb, 0xc3, 0x67, 0xdc, 0x4a, 0x63, 0x16, 0x8e, 0x14,
+	0x74, 0x0e, 0x3f, 0xae, 0x0c, 0x07, 0xda, 0x59, 0x4e, 0x5c, 0x31, 0x72, 0xe9, 0x00, 0xf4, 0x37,
+	0x94, 0x53, 0x1b, 0xf2, 0xef, 0x2d, 0xdb, 0x62, 0xd4, 0xad, 0xe4, 0x52, 0xb0, 0xb2, 0x4b, 0xc1,
+	0xea, 0xc6, 0x97, 0x82, 0x59, 0x38, 0x50, 0xd0, 0x3f, 0x50, 0xeb, 0x85, 0x9c, 0x11, 0x57, 0xa4,
+	0x3d, 0xa2, 0x07, 0x68, 0x03, 0xa5, 0x87, 0x2f, 0x68, 0x61, 0x16, 0xd0, 0x23, 0xa8, 0x2d, 0x5b,
+	0x83, 0x7e, 0x4d, 0xb9, 0x7b, 0xcd, 0x35, 0x76, 0xee, 0x7d, 0x9b, 0xf9, 0x19, 0xeb, 0xd1, 0x6e,
+	0x7d, 0x98, 0x37, 0x94, 0x8f, 0xf3, 0x86, 0xf2, 0x69, 0xde, 0x50, 0xde, 0x7e, 0x6e, 0x14, 0x9e,
+	0xd9, 0x7e, 0x20, 0x46, 0xd3, 0xa1, 0xe5, 0xd2, 0x89, 0xcd, 0x1c, 0x77, 0x34, 0xf3, 0x48, 0xb4,
+	0xb8, 0xe2, 0x91, 0x6b, 0x2f, 0x5e, 0x8c, 0xc3, 0x92, 0xac, 0xfd, 0xaf, 0x2f, 0x03, 0x00, 0x00,
+	0x51, 0xe0, 0x9d, 0x2f, 0x07, 0x00, 0x00,
 }
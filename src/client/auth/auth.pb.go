@@ -56,7 +56,7 @@ func (x Scope) String() string {
 	return proto.EnumName(Scope_name, int32(x))
 }
 func (Scope) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{0}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{0}
 }
 
 type TokenInfo_TokenSource int32
@@ -82,7 +82,7 @@ func (x TokenInfo_TokenSource) String() string {
 	return proto.EnumName(TokenInfo_TokenSource_name, int32(x))
 }
 func (TokenInfo_TokenSource) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{15, 0}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{15, 0}
 }
 
 // ActivateRequest mirrors AuthenticateRequest. The caller is authenticated via
@@ -112,7 +112,7 @@ func (m *ActivateRequest) Reset()         { *m = ActivateRequest{} }
 func (m *ActivateRequest) String() string { return proto.CompactTextString(m) }
 func (*ActivateRequest) ProtoMessage()    {}
 func (*ActivateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{0}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{0}
 }
 func (m *ActivateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -169,7 +169,7 @@ func (m *ActivateResponse) Reset()         { *m = ActivateResponse{} }
 func (m *ActivateResponse) String() string { return proto.CompactTextString(m) }
 func (*ActivateResponse) ProtoMessage()    {}
 func (*ActivateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{1}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{1}
 }
 func (m *ActivateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -215,7 +215,7 @@ func (m *DeactivateRequest) Reset()         { *m = DeactivateRequest{} }
 func (m *DeactivateRequest) String() string { return proto.CompactTextString(m) }
 func (*DeactivateRequest) ProtoMessage()    {}
 func (*DeactivateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{2}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{2}
 }
 func (m *DeactivateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -254,7 +254,7 @@ func (m *DeactivateResponse) Reset()         { *m = DeactivateResponse{} }
 func (m *DeactivateResponse) String() string { return proto.CompactTextString(m) }
 func (*DeactivateResponse) ProtoMessage()    {}
 func (*DeactivateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{3}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{3}
 }
 func (m *DeactivateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -302,7 +302,7 @@ func (m *IDProvider) Reset()         { *m = IDProvider{} }
 func (m *IDProvider) String() string { return proto.CompactTextString(m) }
 func (*IDProvider) ProtoMessage()    {}
 func (*IDProvider) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{4}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{4}
 }
 func (m *IDProvider) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -379,7 +379,7 @@ func (m *IDProvider_SAMLOptions) Reset()         { *m = IDProvider_SAMLOptions{}
 func (m *IDProvider_SAMLOptions) String() string { return proto.CompactTextString(m) }
 func (*IDProvider_SAMLOptions) ProtoMessage()    {}
 func (*IDProvider_SAMLOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{4, 0}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{4, 0}
 }
 func (m *IDProvider_SAMLOptions) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -450,7 +450,7 @@ func (m *AuthConfig) Reset()         { *m = AuthConfig{} }
 func (m *AuthConfig) String() string { return proto.CompactTextString(m) }
 func (*AuthConfig) ProtoMessage()    {}
 func (*AuthConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{5}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{5}
 }
 func (m *AuthConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -537,7 +537,7 @@ func (m *AuthConfig_SAMLServiceOptions) Reset()         { *m = AuthConfig_SAMLSe
 func (m *AuthConfig_SAMLServiceOptions) String() string { return proto.CompactTextString(m) }
 func (*AuthConfig_SAMLServiceOptions) ProtoMessage()    {}
 func (*AuthConfig_SAMLServiceOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{5, 0}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{5, 0}
 }
 func (m *AuthConfig_SAMLServiceOptions) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -611,7 +611,7 @@ func (m *GetConfigurationRequest) Reset()         { *m = GetConfigurationRequest
 func (m *GetConfigurationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetConfigurationRequest) ProtoMessage()    {}
 func (*GetConfigurationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{6}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{6}
 }
 func (m *GetConfigurationRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -651,7 +651,7 @@ func (m *GetConfigurationResponse) Reset()         { *m = GetConfigurationRespon
 func (m *GetConfigurationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetConfigurationResponse) ProtoMessage()    {}
 func (*GetConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{7}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{7}
 }
 func (m *GetConfigurationResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -698,7 +698,7 @@ func (m *SetConfigurationRequest) Reset()         { *m = SetConfigurationRequest
 func (m *SetConfigurationRequest) String() string { return proto.CompactTextString(m) }
 func (*SetConfigurationRequest) ProtoMessage()    {}
 func (*SetConfigurationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{8}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{8}
 }
 func (m *SetConfigurationRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -744,7 +744,7 @@ func (m *SetConfigurationResponse) Reset()         { *m = SetConfigurationRespon
 func (m *SetConfigurationResponse) String() string { return proto.CompactTextString(m) }
 func (*SetConfigurationResponse) ProtoMessage()    {}
 func (*SetConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{9}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{9}
 }
 func (m *SetConfigurationResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -784,7 +784,7 @@ func (m *GetAdminsRequest) Reset()         { *m = GetAdminsRequest{} }
 func (m *GetAdminsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAdminsRequest) ProtoMessage()    {}
 func (*GetAdminsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{10}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{10}
 }
 func (m *GetAdminsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -825,7 +825,7 @@ func (m *GetAdminsResponse) Reset()         { *m = GetAdminsResponse{} }
 func (m *GetAdminsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAdminsResponse) ProtoMessage()    {}
 func (*GetAdminsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{11}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{11}
 }
 func (m *GetAdminsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -874,7 +874,7 @@ func (m *ModifyAdminsRequest) Reset()         { *m = ModifyAdminsRequest{} }
 func (m *ModifyAdminsRequest) String() string { return proto.CompactTextString(m) }
 func (*ModifyAdminsRequest) ProtoMessage()    {}
 func (*ModifyAdminsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{12}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{12}
 }
 func (m *ModifyAdminsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -927,7 +927,7 @@ func (m *ModifyAdminsResponse) Reset()         { *m = ModifyAdminsResponse{} }
 func (m *ModifyAdminsResponse) String() string { return proto.CompactTextString(m) }
 func (*ModifyAdminsResponse) ProtoMessage()    {}
 func (*ModifyAdminsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{13}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{13}
 }
 func (m *ModifyAdminsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -977,7 +977,7 @@ func (m *OTPInfo) Reset()         { *m = OTPInfo{} }
 func (m *OTPInfo) String() string { return proto.CompactTextString(m) }
 func (*OTPInfo) ProtoMessage()    {}
 func (*OTPInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{14}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{14}
 }
 func (m *OTPInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1036,7 +1036,7 @@ func (m *TokenInfo) Reset()         { *m = TokenInfo{} }
 func (m *TokenInfo) String() string { return proto.CompactTextString(m) }
 func (*TokenInfo) ProtoMessage()    {}
 func (*TokenInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{15}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{15}
 }
 func (m *TokenInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1099,7 +1099,7 @@ func (m *AuthenticateRequest) Reset()         { *m = AuthenticateRequest{} }
 func (m *AuthenticateRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthenticateRequest) ProtoMessage()    {}
 func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{16}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{16}
 }
 func (m *AuthenticateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1156,7 +1156,7 @@ func (m *AuthenticateResponse) Reset()         { *m = AuthenticateResponse{} }
 func (m *AuthenticateResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthenticateResponse) ProtoMessage()    {}
 func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{17}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{17}
 }
 func (m *AuthenticateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1202,7 +1202,7 @@ func (m *WhoAmIRequest) Reset()         { *m = WhoAmIRequest{} }
 func (m *WhoAmIRequest) String() string { return proto.CompactTextString(m) }
 func (*WhoAmIRequest) ProtoMessage()    {}
 func (*WhoAmIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{18}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{18}
 }
 func (m *WhoAmIRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1244,7 +1244,7 @@ func (m *WhoAmIResponse) Reset()         { *m = WhoAmIResponse{} }
 func (m *WhoAmIResponse) String() string { return proto.CompactTextString(m) }
 func (*WhoAmIResponse) ProtoMessage()    {}
 func (*WhoAmIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{19}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{19}
 }
 func (m *WhoAmIResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1299,17 +1299,23 @@ type ACL struct {
 	// subject (i.e. all keys in this map are strings prefixed with either
 	// "github:" or "robot:", followed by the name of a GitHub user, all of whom
 	// are Pachyderm subjects, or a Pachyderm robot user)
-	Entries              map[string]Scope `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=auth.Scope"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Entries map[string]Scope `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=auth.Scope"`
+	// branches maps the name of a branch in the ACL's repo to the ACL entries
+	// that apply only to that branch. A principal's access to a branch is the
+	// greater of its access to the repo and its access to the branch, so these
+	// can grant more access to a branch (e.g. WRITER on "dev" for a principal
+	// that's a READER of the repo) but never less.
+	Branches             map[string]*BranchACL `protobuf:"bytes,2,rep,name=branches,proto3" json:"branches,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ACL) Reset()         { *m = ACL{} }
 func (m *ACL) String() string { return proto.CompactTextString(m) }
 func (*ACL) ProtoMessage()    {}
 func (*ACL) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{20}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{20}
 }
 func (m *ACL) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1345,6 +1351,62 @@ func (m *ACL) GetEntries() map[string]Scope {
 	return nil
 }
 
+func (m *ACL) GetBranches() map[string]*BranchACL {
+	if m != nil {
+		return m.Branches
+	}
+	return nil
+}
+
+// BranchACL holds the ACL entries that apply to a single branch of a repo.
+type BranchACL struct {
+	// principal -> scope, as in ACL.entries
+	Entries              map[string]Scope `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=auth.Scope"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BranchACL) Reset()         { *m = BranchACL{} }
+func (m *BranchACL) String() string { return proto.CompactTextString(m) }
+func (*BranchACL) ProtoMessage()    {}
+func (*BranchACL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{21}
+}
+func (m *BranchACL) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BranchACL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BranchACL.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *BranchACL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BranchACL.Merge(dst, src)
+}
+func (m *BranchACL) XXX_Size() int {
+	return m.Size()
+}
+func (m *BranchACL) XXX_DiscardUnknown() {
+	xxx_messageInfo_BranchACL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BranchACL proto.InternalMessageInfo
+
+func (m *BranchACL) GetEntries() map[string]Scope {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
 type Users struct {
 	Usernames            map[string]bool `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
@@ -1356,7 +1418,7 @@ func (m *Users) Reset()         { *m = Users{} }
 func (m *Users) String() string { return proto.CompactTextString(m) }
 func (*Users) ProtoMessage()    {}
 func (*Users) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{21}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{22}
 }
 func (m *Users) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1403,7 +1465,7 @@ func (m *Groups) Reset()         { *m = Groups{} }
 func (m *Groups) String() string { return proto.CompactTextString(m) }
 func (*Groups) ProtoMessage()    {}
 func (*Groups) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{22}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{23}
 }
 func (m *Groups) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1443,7 +1505,11 @@ type AuthorizeRequest struct {
 	// repo is the object that the caller wants to access
 	Repo string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
 	// scope is the access level that the caller needs to perform an action
-	Scope                Scope    `protobuf:"varint,2,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`
+	Scope Scope `protobuf:"varint,2,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`
+	// branch, if set, is the branch of 'repo' that the caller wants to access.
+	// The caller is authorized if either the repo's ACL or the branch's
+	// entries in it grant 'scope'.
+	Branch               string   `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1453,7 +1519,7 @@ func (m *AuthorizeRequest) Reset()         { *m = AuthorizeRequest{} }
 func (m *AuthorizeRequest) String() string { return proto.CompactTextString(m) }
 func (*AuthorizeRequest) ProtoMessage()    {}
 func (*AuthorizeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{23}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{24}
 }
 func (m *AuthorizeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1496,6 +1562,13 @@ func (m *AuthorizeRequest) GetScope() Scope {
 	return Scope_NONE
 }
 
+func (m *AuthorizeRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
 type AuthorizeResponse struct {
 	// authorized is true if the caller has at least
 	// 'AuthorizeRequest.scope'-level access to 'AuthorizeRequest.repo', and false
@@ -1510,7 +1583,7 @@ func (m *AuthorizeResponse) Reset()         { *m = AuthorizeResponse{} }
 func (m *AuthorizeResponse) String() string { return proto.CompactTextString(m) }
 func (*AuthorizeResponse) ProtoMessage()    {}
 func (*AuthorizeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{24}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{25}
 }
 func (m *AuthorizeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1565,7 +1638,7 @@ func (m *GetScopeRequest) Reset()         { *m = GetScopeRequest{} }
 func (m *GetScopeRequest) String() string { return proto.CompactTextString(m) }
 func (*GetScopeRequest) ProtoMessage()    {}
 func (*GetScopeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{25}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{26}
 }
 func (m *GetScopeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1622,7 +1695,7 @@ func (m *GetScopeResponse) Reset()         { *m = GetScopeResponse{} }
 func (m *GetScopeResponse) String() string { return proto.CompactTextString(m) }
 func (*GetScopeResponse) ProtoMessage()    {}
 func (*GetScopeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{26}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{27}
 }
 func (m *GetScopeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1670,7 +1743,11 @@ type SetScopeRequest struct {
 	Repo string `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
 	// scope (actually a "role"--see "Scope") is the access level that the owner
 	// of 'principal' will now have
-	Scope                Scope    `protobuf:"varint,3,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`
+	Scope Scope `protobuf:"varint,3,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`
+	// branch, if set, makes this a branch-scoped ACL entry: 'scope' applies
+	// only to the branch 'branch' of 'repo'. Branch-scoped entries may grant
+	// READER or WRITER access, but not OWNER.
+	Branch               string   `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1680,7 +1757,7 @@ func (m *SetScopeRequest) Reset()         { *m = SetScopeRequest{} }
 func (m *SetScopeRequest) String() string { return proto.CompactTextString(m) }
 func (*SetScopeRequest) ProtoMessage()    {}
 func (*SetScopeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{27}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{28}
 }
 func (m *SetScopeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1730,6 +1807,13 @@ func (m *SetScopeRequest) GetScope() Scope {
 	return Scope_NONE
 }
 
+func (m *SetScopeRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
 type SetScopeResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1740,7 +1824,7 @@ func (m *SetScopeResponse) Reset()         { *m = SetScopeResponse{} }
 func (m *SetScopeResponse) String() string { return proto.CompactTextString(m) }
 func (*SetScopeResponse) ProtoMessage()    {}
 func (*SetScopeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{28}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{29}
 }
 func (m *SetScopeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1780,7 +1864,7 @@ func (m *GetACLRequest) Reset()         { *m = GetACLRequest{} }
 func (m *GetACLRequest) String() string { return proto.CompactTextString(m) }
 func (*GetACLRequest) ProtoMessage()    {}
 func (*GetACLRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{29}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{30}
 }
 func (m *GetACLRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1823,7 +1907,10 @@ type ACLEntry struct {
 	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	// scope is the level of access that the owner of 'principal' has to this
 	// ACL's repo (actually a role in typical security terminology)
-	Scope                Scope    `protobuf:"varint,2,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`
+	Scope Scope `protobuf:"varint,2,opt,name=scope,proto3,enum=auth.Scope" json:"scope,omitempty"`
+	// branch, if set, means that 'scope' applies only to this branch of the
+	// ACL's repo, rather than to the whole repo
+	Branch               string   `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1833,7 +1920,7 @@ func (m *ACLEntry) Reset()         { *m = ACLEntry{} }
 func (m *ACLEntry) String() string { return proto.CompactTextString(m) }
 func (*ACLEntry) ProtoMessage()    {}
 func (*ACLEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{30}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{31}
 }
 func (m *ACLEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1876,6 +1963,13 @@ func (m *ACLEntry) GetScope() Scope {
 	return Scope_NONE
 }
 
+func (m *ACLEntry) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
 // GetACLReponse contains the list of entries on a Pachyderm ACL.
 //
 // To avoid migration pain with the Pachyderm dash the list of user principal
@@ -1900,7 +1994,7 @@ func (m *GetACLResponse) Reset()         { *m = GetACLResponse{} }
 func (m *GetACLResponse) String() string { return proto.CompactTextString(m) }
 func (*GetACLResponse) ProtoMessage()    {}
 func (*GetACLResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{31}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{32}
 }
 func (m *GetACLResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1955,7 +2049,7 @@ func (m *SetACLRequest) Reset()         { *m = SetACLRequest{} }
 func (m *SetACLRequest) String() string { return proto.CompactTextString(m) }
 func (*SetACLRequest) ProtoMessage()    {}
 func (*SetACLRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{32}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{33}
 }
 func (m *SetACLRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2008,7 +2102,7 @@ func (m *SetACLResponse) Reset()         { *m = SetACLResponse{} }
 func (m *SetACLResponse) String() string { return proto.CompactTextString(m) }
 func (*SetACLResponse) ProtoMessage()    {}
 func (*SetACLResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{33}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{34}
 }
 func (m *SetACLResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2052,7 +2146,7 @@ func (m *GetAuthTokenRequest) Reset()         { *m = GetAuthTokenRequest{} }
 func (m *GetAuthTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAuthTokenRequest) ProtoMessage()    {}
 func (*GetAuthTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{34}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{35}
 }
 func (m *GetAuthTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2109,7 +2203,7 @@ func (m *GetAuthTokenResponse) Reset()         { *m = GetAuthTokenResponse{} }
 func (m *GetAuthTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAuthTokenResponse) ProtoMessage()    {}
 func (*GetAuthTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{35}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{36}
 }
 func (m *GetAuthTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2166,7 +2260,7 @@ func (m *ExtendAuthTokenRequest) Reset()         { *m = ExtendAuthTokenRequest{}
 func (m *ExtendAuthTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*ExtendAuthTokenRequest) ProtoMessage()    {}
 func (*ExtendAuthTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{36}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{37}
 }
 func (m *ExtendAuthTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2219,7 +2313,7 @@ func (m *ExtendAuthTokenResponse) Reset()         { *m = ExtendAuthTokenResponse
 func (m *ExtendAuthTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*ExtendAuthTokenResponse) ProtoMessage()    {}
 func (*ExtendAuthTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{37}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{38}
 }
 func (m *ExtendAuthTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2259,7 +2353,7 @@ func (m *RevokeAuthTokenRequest) Reset()         { *m = RevokeAuthTokenRequest{}
 func (m *RevokeAuthTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*RevokeAuthTokenRequest) ProtoMessage()    {}
 func (*RevokeAuthTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{38}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{39}
 }
 func (m *RevokeAuthTokenRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2305,7 +2399,7 @@ func (m *RevokeAuthTokenResponse) Reset()         { *m = RevokeAuthTokenResponse
 func (m *RevokeAuthTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*RevokeAuthTokenResponse) ProtoMessage()    {}
 func (*RevokeAuthTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{39}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{40}
 }
 func (m *RevokeAuthTokenResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2346,7 +2440,7 @@ func (m *SetGroupsForUserRequest) Reset()         { *m = SetGroupsForUserRequest
 func (m *SetGroupsForUserRequest) String() string { return proto.CompactTextString(m) }
 func (*SetGroupsForUserRequest) ProtoMessage()    {}
 func (*SetGroupsForUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{40}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{41}
 }
 func (m *SetGroupsForUserRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2399,7 +2493,7 @@ func (m *SetGroupsForUserResponse) Reset()         { *m = SetGroupsForUserRespon
 func (m *SetGroupsForUserResponse) String() string { return proto.CompactTextString(m) }
 func (*SetGroupsForUserResponse) ProtoMessage()    {}
 func (*SetGroupsForUserResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{41}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{42}
 }
 func (m *SetGroupsForUserResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2441,7 +2535,7 @@ func (m *ModifyMembersRequest) Reset()         { *m = ModifyMembersRequest{} }
 func (m *ModifyMembersRequest) String() string { return proto.CompactTextString(m) }
 func (*ModifyMembersRequest) ProtoMessage()    {}
 func (*ModifyMembersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{42}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{43}
 }
 func (m *ModifyMembersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2501,7 +2595,7 @@ func (m *ModifyMembersResponse) Reset()         { *m = ModifyMembersResponse{} }
 func (m *ModifyMembersResponse) String() string { return proto.CompactTextString(m) }
 func (*ModifyMembersResponse) ProtoMessage()    {}
 func (*ModifyMembersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{43}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{44}
 }
 func (m *ModifyMembersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2541,7 +2635,7 @@ func (m *GetGroupsRequest) Reset()         { *m = GetGroupsRequest{} }
 func (m *GetGroupsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGroupsRequest) ProtoMessage()    {}
 func (*GetGroupsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{44}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{45}
 }
 func (m *GetGroupsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2588,7 +2682,7 @@ func (m *GetGroupsResponse) Reset()         { *m = GetGroupsResponse{} }
 func (m *GetGroupsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGroupsResponse) ProtoMessage()    {}
 func (*GetGroupsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{45}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{46}
 }
 func (m *GetGroupsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2635,7 +2729,7 @@ func (m *GetUsersRequest) Reset()         { *m = GetUsersRequest{} }
 func (m *GetUsersRequest) String() string { return proto.CompactTextString(m) }
 func (*GetUsersRequest) ProtoMessage()    {}
 func (*GetUsersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{46}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{47}
 }
 func (m *GetUsersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2682,7 +2776,7 @@ func (m *GetUsersResponse) Reset()         { *m = GetUsersResponse{} }
 func (m *GetUsersResponse) String() string { return proto.CompactTextString(m) }
 func (*GetUsersResponse) ProtoMessage()    {}
 func (*GetUsersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{47}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{48}
 }
 func (m *GetUsersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2738,7 +2832,7 @@ func (m *GetOneTimePasswordRequest) Reset()         { *m = GetOneTimePasswordReq
 func (m *GetOneTimePasswordRequest) String() string { return proto.CompactTextString(m) }
 func (*GetOneTimePasswordRequest) ProtoMessage()    {}
 func (*GetOneTimePasswordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{48}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{49}
 }
 func (m *GetOneTimePasswordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2785,7 +2879,7 @@ func (m *GetOneTimePasswordResponse) Reset()         { *m = GetOneTimePasswordRe
 func (m *GetOneTimePasswordResponse) String() string { return proto.CompactTextString(m) }
 func (*GetOneTimePasswordResponse) ProtoMessage()    {}
 func (*GetOneTimePasswordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_auth_88ed6f4e558dc5e3, []int{49}
+	return fileDescriptor_auth_f7f53158b7fa4423, []int{50}
 }
 func (m *GetOneTimePasswordResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2845,7 +2939,10 @@ func init() {
 	proto.RegisterType((*WhoAmIRequest)(nil), "auth.WhoAmIRequest")
 	proto.RegisterType((*WhoAmIResponse)(nil), "auth.WhoAmIResponse")
 	proto.RegisterType((*ACL)(nil), "auth.ACL")
+	proto.RegisterMapType((map[string]*BranchACL)(nil), "auth.ACL.BranchesEntry")
 	proto.RegisterMapType((map[string]Scope)(nil), "auth.ACL.EntriesEntry")
+	proto.RegisterType((*BranchACL)(nil), "auth.BranchACL")
+	proto.RegisterMapType((map[string]Scope)(nil), "auth.BranchACL.EntriesEntry")
 	proto.RegisterType((*Users)(nil), "auth.Users")
 	proto.RegisterMapType((map[string]bool)(nil), "auth.Users.UsernamesEntry")
 	proto.RegisterType((*Groups)(nil), "auth.Groups")
@@ -4346,6 +4443,71 @@ func (m *ACL) Marshal() (dAtA []byte, err error) {
 }
 
 func (m *ACL) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for k, _ := range m.Entries {
+			dAtA[i] = 0xa
+			i++
+			v := m.Entries[k]
+			mapSize := 1 + len(k) + sovAuth(uint64(len(k))) + 1 + sovAuth(uint64(v))
+			i = encodeVarintAuth(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAuth(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x10
+			i++
+			i = encodeVarintAuth(dAtA, i, uint64(v))
+		}
+	}
+	if len(m.Branches) > 0 {
+		for k, _ := range m.Branches {
+			dAtA[i] = 0x12
+			i++
+			v := m.Branches[k]
+			msgSize := 0
+			if v != nil {
+				msgSize = v.Size()
+				msgSize += 1 + sovAuth(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovAuth(uint64(len(k))) + msgSize
+			i = encodeVarintAuth(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAuth(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if v != nil {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintAuth(dAtA, i, uint64(v.Size()))
+				n6, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
+				}
+				i += n6
+			}
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BranchACL) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchACL) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -4482,6 +4644,12 @@ func (m *AuthorizeRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintAuth(dAtA, i, uint64(m.Scope))
 	}
+	if len(m.Branch) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Branch)))
+		i += copy(dAtA[i:], m.Branch)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4577,21 +4745,21 @@ func (m *GetScopeResponse) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Scopes) > 0 {
-		dAtA7 := make([]byte, len(m.Scopes)*10)
-		var j6 int
+		dAtA8 := make([]byte, len(m.Scopes)*10)
+		var j7 int
 		for _, num := range m.Scopes {
 			for num >= 1<<7 {
-				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j6++
+				j7++
 			}
-			dAtA7[j6] = uint8(num)
-			j6++
+			dAtA8[j7] = uint8(num)
+			j7++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintAuth(dAtA, i, uint64(j6))
-		i += copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintAuth(dAtA, i, uint64(j7))
+		i += copy(dAtA[i:], dAtA8[:j7])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4631,6 +4799,12 @@ func (m *SetScopeRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintAuth(dAtA, i, uint64(m.Scope))
 	}
+	if len(m.Branch) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Branch)))
+		i += copy(dAtA[i:], m.Branch)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4711,6 +4885,12 @@ func (m *ACLEntry) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintAuth(dAtA, i, uint64(m.Scope))
 	}
+	if len(m.Branch) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Branch)))
+		i += copy(dAtA[i:], m.Branch)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5714,6 +5894,39 @@ func (m *WhoAmIResponse) Size() (n int) {
 }
 
 func (m *ACL) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for k, v := range m.Entries {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovAuth(uint64(len(k))) + 1 + sovAuth(uint64(v))
+			n += mapEntrySize + 1 + sovAuth(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Branches) > 0 {
+		for k, v := range m.Branches {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovAuth(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovAuth(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovAuth(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BranchACL) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -5786,6 +5999,10 @@ func (m *AuthorizeRequest) Size() (n int) {
 	if m.Scope != 0 {
 		n += 1 + sovAuth(uint64(m.Scope))
 	}
+	l = len(m.Branch)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5865,6 +6082,10 @@ func (m *SetScopeRequest) Size() (n int) {
 	if m.Scope != 0 {
 		n += 1 + sovAuth(uint64(m.Scope))
 	}
+	l = len(m.Branch)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5912,6 +6133,10 @@ func (m *ACLEntry) Size() (n int) {
 	if m.Scope != 0 {
 		n += 1 + sovAuth(uint64(m.Scope))
 	}
+	l = len(m.Branch)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8423,6 +8648,287 @@ func (m *ACL) Unmarshal(dAtA []byte) error {
 			}
 			m.Entries[mapkey] = mapvalue
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Branches == nil {
+				m.Branches = make(map[string]*BranchACL)
+			}
+			var mapkey string
+			var mapvalue *BranchACL
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAuth
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAuth
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthAuth
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAuth
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= (int(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthAuth
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if mapmsglen < 0 {
+						return ErrInvalidLengthAuth
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &BranchACL{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipAuth(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthAuth
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Branches[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchACL) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchACL: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchACL: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Entries == nil {
+				m.Entries = make(map[string]Scope)
+			}
+			var mapkey string
+			var mapvalue Scope
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAuth
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAuth
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthAuth
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAuth
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= (Scope(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipAuth(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthAuth
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Entries[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -8842,6 +9348,35 @@ func (m *AuthorizeRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Branch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -9267,6 +9802,35 @@ func (m *SetScopeRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Branch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -9497,6 +10061,35 @@ func (m *ACLEntry) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Branch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -11217,126 +11810,129 @@ var (
 	ErrIntOverflowAuth   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("client/auth/auth.proto", fileDescriptor_auth_88ed6f4e558dc5e3) }
-
-var fileDescriptor_auth_88ed6f4e558dc5e3 = []byte{
-	// 1873 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x5b, 0x73, 0xe3, 0x48,
-	0x15, 0x8e, 0xed, 0xc4, 0x97, 0x63, 0x27, 0x76, 0x3a, 0x5e, 0xc7, 0xd1, 0xee, 0x24, 0x41, 0xa9,
-	0x62, 0xc3, 0x52, 0xe5, 0x0c, 0x19, 0x06, 0x96, 0x1d, 0x0a, 0x70, 0x12, 0xaf, 0xd7, 0x8b, 0x73,
-	0x41, 0xf2, 0xcc, 0x2c, 0xbc, 0xb8, 0x64, 0xa9, 0xc7, 0x11, 0x63, 0x5b, 0x46, 0x17, 0x33, 0xe1,
-	0x05, 0xfe, 0x05, 0x3c, 0xc1, 0xbf, 0xa1, 0x78, 0x84, 0x3f, 0x90, 0xa2, 0x4c, 0xf1, 0x3f, 0xa8,
-	0xbe, 0xc9, 0x2d, 0x59, 0xce, 0x64, 0xd8, 0x97, 0xa4, 0xfb, 0x5c, 0xbe, 0x3e, 0x7d, 0xba, 0xfb,
-	0x7c, 0x47, 0x86, 0x9a, 0x39, 0xb2, 0xf1, 0xc4, 0x3f, 0x31, 0x02, 0xff, 0x96, 0xfe, 0x69, 0x4c,
-	0x5d, 0xc7, 0x77, 0xd0, 0x3a, 0x19, 0x2b, 0xd5, 0xa1, 0x33, 0x74, 0xa8, 0xe0, 0x84, 0x8c, 0x98,
-	0x4e, 0x39, 0x18, 0x3a, 0xce, 0x70, 0x84, 0x4f, 0xe8, 0x6c, 0x10, 0xbc, 0x39, 0xf1, 0xed, 0x31,
-	0xf6, 0x7c, 0x63, 0x3c, 0x65, 0x06, 0x6a, 0x1f, 0xca, 0x4d, 0xd3, 0xb7, 0x67, 0x86, 0x8f, 0x35,
-	0xfc, 0xbb, 0x00, 0x7b, 0x3e, 0x3a, 0x85, 0xd2, 0xd0, 0xf6, 0x6f, 0x83, 0x41, 0xdf, 0x77, 0xde,
-	0xe2, 0x49, 0x3d, 0x75, 0x98, 0x3a, 0x2e, 0x9c, 0x95, 0xe7, 0xf7, 0x07, 0xc5, 0xb6, 0xed, 0x7f,
-	0x15, 0x0c, 0x7a, 0x44, 0xac, 0x15, 0x99, 0x11, 0x9d, 0xa0, 0x3a, 0xe4, 0xbc, 0x60, 0xf0, 0x5b,
-	0x6c, 0xfa, 0xf5, 0x34, 0x31, 0xd7, 0xc4, 0x54, 0xfd, 0x01, 0x54, 0x16, 0x0b, 0x78, 0x53, 0x67,
-	0xe2, 0x61, 0xf4, 0x04, 0x60, 0x6a, 0x98, 0xb7, 0x32, 0xbe, 0x56, 0x20, 0x12, 0x0a, 0xa6, 0xee,
-	0xc0, 0xf6, 0x05, 0x36, 0xa2, 0x51, 0xa9, 0x55, 0x40, 0xb2, 0x90, 0x21, 0xa9, 0x7f, 0x4b, 0x03,
-	0x74, 0x2e, 0x6e, 0x5c, 0x67, 0x66, 0x5b, 0xd8, 0x45, 0x08, 0xd6, 0x27, 0xc6, 0x18, 0x73, 0x48,
-	0x3a, 0x46, 0x87, 0x50, 0xb4, 0xb0, 0x67, 0xba, 0xf6, 0xd4, 0xb7, 0x9d, 0x09, 0x0f, 0x4f, 0x16,
-	0xa1, 0x2f, 0x60, 0xdd, 0x33, 0xc6, 0xa3, 0x7a, 0xe6, 0x30, 0x75, 0x5c, 0x3c, 0xfd, 0xa4, 0x41,
-	0x73, 0xbb, 0x40, 0x6d, 0xe8, 0xcd, 0xcb, 0xee, 0x35, 0x35, 0xf5, 0xce, 0xf2, 0xf3, 0xfb, 0x83,
-	0x75, 0x22, 0xd0, 0xa8, 0x8f, 0xf2, 0xd7, 0x14, 0x14, 0x25, 0x3d, 0x49, 0xde, 0x18, 0xfb, 0x86,
-	0x65, 0xf8, 0x46, 0x3f, 0x70, 0x47, 0x72, 0xf2, 0x2e, 0xb9, 0xfc, 0xa5, 0xd6, 0xd5, 0x8a, 0xc2,
-	0xe8, 0xa5, 0x3b, 0x8a, 0xf8, 0xbc, 0x1b, 0x8f, 0x68, 0x88, 0xa5, 0xa8, 0xcf, 0x37, 0x97, 0x92,
-	0xcf, 0x37, 0xe3, 0x11, 0xfa, 0x14, 0xca, 0x43, 0xd7, 0x09, 0xa6, 0x7d, 0xc3, 0xf7, 0x5d, 0x7b,
-	0x10, 0xf8, 0x98, 0x86, 0x5f, 0xd0, 0xb6, 0xa8, 0xb8, 0x29, 0xa4, 0xea, 0xbf, 0x32, 0x00, 0xcd,
-	0xc0, 0xbf, 0x3d, 0x77, 0x26, 0x6f, 0xec, 0x21, 0x6a, 0xc0, 0xce, 0xc8, 0x9e, 0xe1, 0xbe, 0x49,
-	0xa7, 0xfd, 0x19, 0x76, 0x3d, 0x92, 0x15, 0x12, 0x66, 0x46, 0xdb, 0x26, 0x2a, 0x66, 0xf8, 0x8a,
-	0x29, 0xd0, 0x05, 0x94, 0x6c, 0xab, 0x3f, 0xe5, 0xa9, 0xf0, 0xea, 0xe9, 0xc3, 0xcc, 0x71, 0xf1,
-	0xb4, 0x12, 0xcf, 0x11, 0x8b, 0x76, 0x31, 0xf7, 0xb4, 0xa2, 0x6d, 0x85, 0x13, 0x84, 0xa1, 0x42,
-	0xb2, 0xd5, 0xf7, 0x66, 0x66, 0xdf, 0x61, 0x99, 0xe2, 0xd9, 0x3e, 0x62, 0x48, 0x8b, 0x08, 0x69,
-	0xb6, 0x75, 0xec, 0xce, 0x6c, 0x13, 0x8b, 0xa4, 0xd7, 0xe6, 0xf7, 0x07, 0x68, 0x59, 0xae, 0x6d,
-	0x11, 0x50, 0x7d, 0x66, 0xf2, 0xb9, 0xf2, 0xdf, 0x14, 0x24, 0x98, 0xa1, 0x23, 0xc8, 0x19, 0xa6,
-	0x27, 0x1d, 0x07, 0xcc, 0xef, 0x0f, 0xb2, 0xcd, 0x73, 0x9d, 0x9c, 0x44, 0xd6, 0x30, 0xbd, 0xf8,
-	0x21, 0x10, 0xcb, 0xf4, 0x23, 0x0e, 0xee, 0xbb, 0x90, 0xb7, 0x0c, 0xef, 0x96, 0xda, 0xd3, 0xec,
-	0x9f, 0x15, 0xe7, 0xf7, 0x07, 0xb9, 0x0b, 0xc3, 0xbb, 0x25, 0xb6, 0x39, 0xa2, 0x24, 0x76, 0xdf,
-	0x83, 0x8a, 0x87, 0x3d, 0x92, 0xcf, 0xbe, 0x15, 0xb8, 0x06, 0xbd, 0x87, 0xeb, 0xf4, 0xb4, 0xca,
-	0x5c, 0x7e, 0xc1, 0xc5, 0xe8, 0x08, 0x36, 0x2d, 0x3c, 0x08, 0x86, 0xfd, 0x91, 0x33, 0x1c, 0xda,
-	0x93, 0x61, 0x7d, 0xe3, 0x30, 0x75, 0x9c, 0xd7, 0x4a, 0x54, 0xd8, 0x65, 0x32, 0x75, 0x0f, 0x76,
-	0xdb, 0xd8, 0x67, 0xf9, 0xe2, 0x8e, 0xe2, 0x99, 0x68, 0x50, 0x5f, 0x56, 0xf1, 0x67, 0xf7, 0x23,
-	0xd8, 0x34, 0x65, 0x05, 0xcd, 0x46, 0x78, 0x98, 0x8b, 0x23, 0xd0, 0xa2, 0x66, 0xea, 0xaf, 0x60,
-	0x57, 0x4f, 0x5e, 0xee, 0xff, 0x86, 0x54, 0xa0, 0xae, 0xaf, 0x08, 0x53, 0x45, 0x50, 0x69, 0x63,
-	0xbf, 0x69, 0x8d, 0xed, 0x89, 0x27, 0xb6, 0xf5, 0x7d, 0xd8, 0x96, 0x64, 0x7c, 0x3f, 0x35, 0xc8,
-	0x1a, 0x54, 0x52, 0x4f, 0x1d, 0x66, 0x8e, 0x0b, 0x1a, 0x9f, 0xa9, 0x3f, 0x87, 0x9d, 0x4b, 0xc7,
-	0xb2, 0xdf, 0xdc, 0x45, 0x30, 0x50, 0x05, 0x32, 0x86, 0x65, 0x71, 0x5b, 0x32, 0x24, 0x00, 0x2e,
-	0x1e, 0x3b, 0x33, 0x4c, 0xaf, 0x75, 0x41, 0xe3, 0x33, 0xb5, 0x06, 0xd5, 0x28, 0x00, 0x8f, 0x6c,
-	0x02, 0xb9, 0xeb, 0xde, 0x4d, 0x67, 0xf2, 0xc6, 0x91, 0x0b, 0x5e, 0x2a, 0x52, 0xf0, 0x50, 0x07,
-	0x90, 0x38, 0x6c, 0xfc, 0x6e, 0x6a, 0xf3, 0xbc, 0xa4, 0x69, 0x5e, 0x94, 0x06, 0xab, 0xc7, 0x0d,
-	0x51, 0x8f, 0x1b, 0x3d, 0x51, 0x8f, 0xb5, 0x6d, 0xee, 0xd5, 0x0a, 0x9d, 0xd4, 0x3f, 0xa7, 0xa0,
-	0x40, 0x4b, 0xe2, 0x7b, 0x96, 0x7c, 0x06, 0x59, 0xcf, 0x09, 0x5c, 0x13, 0xd3, 0x65, 0xb6, 0x4e,
-	0x3f, 0x66, 0xe9, 0x0f, 0x5d, 0xd9, 0x48, 0xa7, 0x26, 0x1a, 0x37, 0x55, 0x5f, 0x40, 0x51, 0x12,
-	0xa3, 0x22, 0xe4, 0x3a, 0x57, 0xaf, 0x9a, 0xdd, 0xce, 0x45, 0x65, 0x0d, 0x55, 0xa0, 0xd4, 0x7c,
-	0xd9, 0xfb, 0xaa, 0x75, 0xd5, 0xeb, 0x9c, 0x37, 0x7b, 0xad, 0x4a, 0x0a, 0x6d, 0x42, 0xa1, 0xdd,
-	0xea, 0xf5, 0x7b, 0xd7, 0xbf, 0x6c, 0x5d, 0x55, 0xd2, 0x6a, 0x00, 0x3b, 0xe4, 0x70, 0xf1, 0xc4,
-	0xb7, 0xcd, 0x6f, 0x49, 0x1d, 0x9f, 0xc1, 0xb6, 0x33, 0xc1, 0x7d, 0x42, 0x4c, 0xfd, 0xa9, 0xe1,
-	0x79, 0xbf, 0x77, 0x5c, 0x8b, 0x57, 0xe9, 0xb2, 0x33, 0xc1, 0x24, 0x41, 0x37, 0x5c, 0xac, 0x3e,
-	0x87, 0x6a, 0x74, 0xd9, 0xc7, 0x11, 0x4a, 0x19, 0x36, 0x5f, 0xdf, 0x3a, 0xcd, 0x71, 0x47, 0x5c,
-	0xa7, 0x01, 0x6c, 0x09, 0x01, 0x47, 0x50, 0x20, 0x1f, 0x78, 0xd8, 0x95, 0xd8, 0x23, 0x9c, 0xa3,
-	0x3d, 0xc8, 0xdb, 0x5e, 0x9f, 0x5e, 0x2e, 0x1a, 0x58, 0x5e, 0xcb, 0xd9, 0x1e, 0xbd, 0x1a, 0x68,
-	0x0f, 0x32, 0xbe, 0xcf, 0x1e, 0x7f, 0xe6, 0x2c, 0x37, 0xbf, 0x3f, 0xc8, 0xf4, 0x7a, 0x5d, 0x8d,
-	0xc8, 0xd4, 0x3f, 0xa5, 0x20, 0xd3, 0x3c, 0xef, 0xa2, 0xa7, 0x90, 0xc3, 0x13, 0xdf, 0xb5, 0x31,
-	0xbb, 0xa6, 0xc5, 0xd3, 0x1a, 0x7f, 0x1c, 0xe7, 0xdd, 0x46, 0x8b, 0x29, 0xc8, 0xbf, 0x3b, 0x4d,
-	0x98, 0x29, 0x6d, 0x28, 0xc9, 0x0a, 0x72, 0x71, 0xdf, 0xe2, 0x3b, 0x1e, 0x16, 0x19, 0xa2, 0xef,
-	0xc0, 0xc6, 0xcc, 0x18, 0x05, 0xe2, 0xbc, 0x8b, 0x0c, 0x51, 0x37, 0x9d, 0x29, 0xd6, 0x98, 0xe6,
-	0x8b, 0xf4, 0xe7, 0x29, 0xf5, 0x8f, 0xb0, 0xf1, 0xd2, 0x23, 0xf5, 0xf7, 0x73, 0x28, 0x88, 0xdd,
-	0x88, 0x28, 0x14, 0xe6, 0x43, 0xf5, 0xf4, 0x2f, 0x55, 0xb2, 0x48, 0x16, 0xc6, 0xca, 0x4f, 0x61,
-	0x2b, 0xaa, 0x4c, 0x88, 0xa6, 0x2a, 0x47, 0x93, 0x97, 0x03, 0x08, 0x20, 0xdb, 0x26, 0x74, 0xe4,
-	0xa1, 0xa7, 0x90, 0xa5, 0xc4, 0x24, 0x96, 0xaf, 0xb3, 0xe5, 0x99, 0x96, 0xff, 0x63, 0x8b, 0x73,
-	0x3b, 0xe5, 0x27, 0x50, 0x94, 0xc4, 0x1f, 0xb4, 0x6c, 0x07, 0x2a, 0xe4, 0x9a, 0x38, 0xae, 0xfd,
-	0x87, 0xf0, 0x6a, 0x22, 0x58, 0x77, 0xf1, 0xd4, 0x11, 0xad, 0x01, 0x19, 0x93, 0x34, 0x7a, 0x24,
-	0x67, 0x89, 0x69, 0xa4, 0x1a, 0xf5, 0x19, 0x6c, 0x4b, 0x50, 0xfc, 0xb2, 0xec, 0x03, 0x18, 0x42,
-	0x68, 0x51, 0xc4, 0xbc, 0x26, 0x49, 0xd4, 0x73, 0x28, 0xb7, 0xb1, 0xcf, 0x70, 0xf8, 0xf2, 0x0f,
-	0xdd, 0xaf, 0x2a, 0x6c, 0x90, 0x70, 0x3c, 0x5e, 0x85, 0xd8, 0x44, 0xfd, 0x31, 0x2d, 0x83, 0x1c,
-	0x84, 0x2f, 0x7c, 0x04, 0x59, 0x1a, 0x16, 0xcb, 0x62, 0x2c, 0x62, 0xae, 0x52, 0x2d, 0x28, 0xeb,
-	0x1f, 0xb0, 0xba, 0x48, 0x4c, 0x3a, 0x29, 0x31, 0x99, 0x95, 0x89, 0x41, 0x50, 0xd1, 0x63, 0xe1,
-	0xa9, 0x47, 0xb0, 0x49, 0xaa, 0xf4, 0x79, 0xf7, 0x81, 0xa4, 0xab, 0x1d, 0xc8, 0x37, 0xcf, 0xbb,
-	0xec, 0x50, 0x1f, 0x8a, 0xeb, 0x11, 0x87, 0xe3, 0xc0, 0x96, 0x58, 0x8f, 0x27, 0xe8, 0x38, 0xfe,
-	0xd8, 0xb6, 0xc2, 0xc7, 0x16, 0x7d, 0x64, 0xe8, 0x19, 0x6c, 0xba, 0xce, 0xc0, 0xf1, 0xfb, 0xc2,
-	0x3e, 0x9d, 0x68, 0x5f, 0xa2, 0x46, 0xfc, 0x39, 0xaa, 0x97, 0xb0, 0xa9, 0xbf, 0x6f, 0x83, 0x72,
-	0x0c, 0xe9, 0x07, 0x63, 0x50, 0x2b, 0xb0, 0xa5, 0x47, 0xe2, 0x57, 0xbf, 0x86, 0x1d, 0xb2, 0xa3,
-	0xc0, 0x67, 0x95, 0x4b, 0x2c, 0xb3, 0xba, 0xf4, 0xf3, 0x02, 0x94, 0x4e, 0x28, 0x40, 0x5f, 0x42,
-	0x35, 0x8a, 0xc5, 0x73, 0x54, 0x85, 0x0d, 0xb9, 0x4e, 0xb2, 0xc9, 0x03, 0x1d, 0x7c, 0x07, 0x6a,
-	0xad, 0x77, 0x3e, 0x9e, 0x58, 0x4b, 0x61, 0x25, 0x23, 0x3d, 0x10, 0xd2, 0x1e, 0xec, 0x2e, 0x41,
-	0xf1, 0x9d, 0x37, 0xa0, 0xa6, 0xe1, 0x99, 0xf3, 0x16, 0x3f, 0x6e, 0x15, 0x02, 0xb5, 0x64, 0xcf,
-	0xa1, 0x2e, 0x69, 0xbf, 0xc2, 0x8a, 0xc7, 0x97, 0x8e, 0x4b, 0xea, 0xd7, 0x63, 0x1e, 0x42, 0x2d,
-	0x2c, 0x51, 0xbc, 0x1b, 0x60, 0x33, 0xde, 0xab, 0xc4, 0xe0, 0xf8, 0x52, 0xaf, 0x44, 0xa7, 0x70,
-	0x89, 0xc7, 0x03, 0xd2, 0xf6, 0x2e, 0x62, 0xa6, 0xde, 0x22, 0x66, 0x3a, 0x11, 0x1d, 0x48, 0x3a,
-	0xa9, 0x03, 0xc9, 0x44, 0x3a, 0x90, 0x5d, 0xf8, 0x28, 0x86, 0x1b, 0xa6, 0x89, 0x54, 0x05, 0x16,
-	0xcc, 0x23, 0x36, 0xc5, 0x1b, 0x27, 0x61, 0xbf, 0x68, 0x9c, 0xa4, 0x62, 0xbc, 0xd8, 0xe9, 0xa7,
-	0xb4, 0x6e, 0x51, 0x4a, 0x78, 0x70, 0x23, 0xea, 0x53, 0x1a, 0x05, 0x37, 0xe4, 0xa0, 0x9f, 0xc4,
-	0x39, 0xa6, 0x20, 0xf1, 0x88, 0xfa, 0x1c, 0xf6, 0xda, 0xd8, 0xbf, 0x8e, 0xf2, 0xf9, 0x7b, 0xaf,
-	0xb7, 0xfa, 0x14, 0x94, 0x24, 0x37, 0xbe, 0x24, 0x82, 0x75, 0xd3, 0xb1, 0xc2, 0xcf, 0x3d, 0x32,
-	0xfe, 0xec, 0x87, 0xb0, 0x41, 0x6b, 0x04, 0xca, 0xc3, 0xfa, 0xd5, 0xf5, 0x55, 0xab, 0xb2, 0x86,
-	0x00, 0xb2, 0x5a, 0xab, 0x79, 0xd1, 0xd2, 0x2a, 0x29, 0x32, 0x7e, 0xad, 0x75, 0x7a, 0x2d, 0xad,
-	0x92, 0x46, 0x05, 0xd8, 0xb8, 0x7e, 0x7d, 0xd5, 0xd2, 0x2a, 0x99, 0xd3, 0xbf, 0x17, 0x21, 0xd3,
-	0xbc, 0xe9, 0xa0, 0x17, 0x90, 0x17, 0x5f, 0xab, 0xe8, 0x23, 0xfe, 0x6c, 0xa3, 0x1f, 0xa2, 0x4a,
-	0x2d, 0x2e, 0xe6, 0x27, 0xb3, 0x86, 0x9a, 0x00, 0x8b, 0x4f, 0x54, 0xb4, 0xcb, 0xec, 0x96, 0xbe,
-	0x64, 0x95, 0xfa, 0xb2, 0x22, 0x84, 0xd0, 0x69, 0x62, 0x23, 0x7d, 0x31, 0x7a, 0xc2, 0xa9, 0x32,
-	0xb9, 0x05, 0x57, 0xf6, 0x57, 0xa9, 0x65, 0x50, 0x7d, 0x05, 0xa8, 0xfe, 0x30, 0xa8, 0xbe, 0x1a,
-	0xf4, 0x67, 0x50, 0x08, 0x3b, 0x72, 0x54, 0x0b, 0x63, 0x88, 0xb4, 0xdc, 0xca, 0xee, 0x92, 0x3c,
-	0xf4, 0x6f, 0x43, 0x49, 0xee, 0xb1, 0xd1, 0x1e, 0x33, 0x4d, 0x68, 0xdc, 0x15, 0x25, 0x49, 0x25,
-	0x03, 0xc9, 0x3d, 0xa1, 0x00, 0x4a, 0x68, 0x4f, 0x05, 0x50, 0x52, 0x0b, 0xc9, 0x76, 0x14, 0x52,
-	0xbd, 0xd8, 0x51, 0xbc, 0x8d, 0x10, 0x3b, 0x5a, 0xea, 0x09, 0xd4, 0x35, 0xf4, 0x1c, 0xb2, 0xac,
-	0xa9, 0x44, 0x3b, 0xcc, 0x28, 0xd2, 0x73, 0x2a, 0xd5, 0xa8, 0x30, 0x74, 0x7b, 0x01, 0x79, 0xc1,
-	0xf3, 0xe2, 0xca, 0xc5, 0x9a, 0x07, 0xa5, 0x16, 0x17, 0xcb, 0xce, 0x7a, 0xcc, 0x59, 0x4f, 0x76,
-	0xd6, 0x97, 0x9d, 0x9f, 0x43, 0x96, 0xd1, 0xa7, 0x08, 0x38, 0x42, 0xde, 0x22, 0xe0, 0x28, 0xc3,
-	0x32, 0x37, 0x3d, 0xe2, 0xa6, 0x27, 0xb9, 0xe9, 0x71, 0xb7, 0x36, 0x94, 0x64, 0x3a, 0x12, 0xe7,
-	0x94, 0x40, 0x77, 0xe2, 0x9c, 0x92, 0xd8, 0x4b, 0x5d, 0x43, 0x37, 0x50, 0x8e, 0x91, 0x08, 0xe2,
-	0xbf, 0xd9, 0x24, 0xd3, 0x94, 0xf2, 0x64, 0x85, 0x56, 0x46, 0x8c, 0x71, 0x89, 0x40, 0x4c, 0xa6,
-	0x24, 0x81, 0xb8, 0x8a, 0x80, 0xc4, 0x93, 0x8b, 0x70, 0x86, 0xf4, 0xe4, 0x92, 0xa8, 0x49, 0x7a,
-	0x72, 0xc9, 0x54, 0xb3, 0x86, 0xbe, 0x86, 0xcd, 0x08, 0x29, 0xa0, 0xc8, 0xc3, 0x88, 0x32, 0x90,
-	0xf2, 0x71, 0xa2, 0x2e, 0xf6, 0x7c, 0x79, 0x73, 0xbe, 0xb8, 0x5f, 0x11, 0x62, 0x91, 0x9e, 0x6f,
-	0x94, 0x40, 0xc2, 0x5b, 0xcb, 0xbe, 0x2e, 0x16, 0xb7, 0x56, 0xa6, 0x0e, 0xe9, 0xd6, 0x46, 0x88,
-	0x42, 0x5d, 0x43, 0xbf, 0x06, 0xb4, 0x5c, 0xd5, 0xd1, 0x41, 0x68, 0x9f, 0x4c, 0x13, 0xca, 0xe1,
-	0x6a, 0x03, 0x01, 0x7d, 0xf6, 0x8b, 0x7f, 0xcc, 0xf7, 0x53, 0xff, 0x9c, 0xef, 0xa7, 0xfe, 0x3d,
-	0xdf, 0x4f, 0xfd, 0xe5, 0x3f, 0xfb, 0x6b, 0xbf, 0x69, 0xb0, 0x8f, 0xcd, 0x86, 0xe9, 0x8c, 0x4f,
-	0xc8, 0x27, 0xe1, 0x9d, 0x85, 0x5d, 0x79, 0xe4, 0xb9, 0xe6, 0x89, 0xf4, 0xcb, 0xea, 0x20, 0x4b,
-	0xbf, 0xcd, 0x9f, 0xfd, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xc5, 0xb4, 0xe4, 0xe8, 0x6f, 0x15, 0x00,
-	0x00,
+func init() { proto.RegisterFile("client/auth/auth.proto", fileDescriptor_auth_f7f53158b7fa4423) }
+
+var fileDescriptor_auth_f7f53158b7fa4423 = []byte{
+	// 1936 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x5b, 0x73, 0xe3, 0x48,
+	0xf5, 0x1f, 0xdb, 0x89, 0x63, 0x1f, 0xc7, 0xb1, 0xd2, 0xf1, 0x3a, 0x8e, 0x76, 0x26, 0xc9, 0x5f,
+	0xa9, 0x3f, 0x1b, 0x96, 0x2a, 0x67, 0xc8, 0x30, 0xcb, 0xb2, 0x43, 0x01, 0x8e, 0xe3, 0xf5, 0x7a,
+	0x71, 0x2e, 0x48, 0xce, 0xcc, 0xc2, 0x8b, 0x4b, 0x96, 0x7b, 0x1c, 0x31, 0xb6, 0x65, 0x74, 0x31,
+	0x33, 0x14, 0x55, 0x3c, 0xf0, 0xca, 0x3b, 0x3c, 0xc1, 0xb7, 0xa1, 0x78, 0x84, 0x2f, 0x90, 0xa2,
+	0x4c, 0xf1, 0x3d, 0xa8, 0xbe, 0xc9, 0x2d, 0x59, 0xce, 0x64, 0x59, 0x5e, 0x12, 0xf5, 0xb9, 0xfc,
+	0xce, 0xe9, 0xd3, 0x7d, 0x2e, 0x6d, 0xa8, 0x58, 0x23, 0x1b, 0x4f, 0xfc, 0x13, 0x33, 0xf0, 0x6f,
+	0xe9, 0x9f, 0xda, 0xd4, 0x75, 0x7c, 0x07, 0xad, 0x91, 0x6f, 0xb5, 0x3c, 0x74, 0x86, 0x0e, 0x25,
+	0x9c, 0x90, 0x2f, 0xc6, 0x53, 0x0f, 0x86, 0x8e, 0x33, 0x1c, 0xe1, 0x13, 0xba, 0xea, 0x07, 0xaf,
+	0x4f, 0x7c, 0x7b, 0x8c, 0x3d, 0xdf, 0x1c, 0x4f, 0x99, 0x80, 0xd6, 0x83, 0x52, 0xdd, 0xf2, 0xed,
+	0x99, 0xe9, 0x63, 0x1d, 0xff, 0x2a, 0xc0, 0x9e, 0x8f, 0x4e, 0x61, 0x73, 0x68, 0xfb, 0xb7, 0x41,
+	0xbf, 0xe7, 0x3b, 0x6f, 0xf0, 0xa4, 0x9a, 0x3a, 0x4c, 0x1d, 0xe7, 0xcf, 0x4a, 0xf3, 0xbb, 0x83,
+	0x42, 0xcb, 0xf6, 0xbf, 0x08, 0xfa, 0x5d, 0x42, 0xd6, 0x0b, 0x4c, 0x88, 0x2e, 0x50, 0x15, 0x36,
+	0xbc, 0xa0, 0xff, 0x4b, 0x6c, 0xf9, 0xd5, 0x34, 0x11, 0xd7, 0xc5, 0x52, 0xfb, 0x2e, 0x28, 0x0b,
+	0x03, 0xde, 0xd4, 0x99, 0x78, 0x18, 0x3d, 0x01, 0x98, 0x9a, 0xd6, 0xad, 0x8c, 0xaf, 0xe7, 0x09,
+	0x85, 0x82, 0x69, 0x3b, 0xb0, 0x7d, 0x8e, 0xcd, 0xa8, 0x57, 0x5a, 0x19, 0x90, 0x4c, 0x64, 0x48,
+	0xda, 0x5f, 0xd2, 0x00, 0xed, 0xf3, 0x6b, 0xd7, 0x99, 0xd9, 0x03, 0xec, 0x22, 0x04, 0x6b, 0x13,
+	0x73, 0x8c, 0x39, 0x24, 0xfd, 0x46, 0x87, 0x50, 0x18, 0x60, 0xcf, 0x72, 0xed, 0xa9, 0x6f, 0x3b,
+	0x13, 0xee, 0x9e, 0x4c, 0x42, 0x9f, 0xc1, 0x9a, 0x67, 0x8e, 0x47, 0xd5, 0xcc, 0x61, 0xea, 0xb8,
+	0x70, 0xfa, 0xb8, 0x46, 0x63, 0xbb, 0x40, 0xad, 0x19, 0xf5, 0x8b, 0xce, 0x15, 0x15, 0xf5, 0xce,
+	0x72, 0xf3, 0xbb, 0x83, 0x35, 0x42, 0xd0, 0xa9, 0x8e, 0xfa, 0xe7, 0x14, 0x14, 0x24, 0x3e, 0x09,
+	0xde, 0x18, 0xfb, 0xe6, 0xc0, 0xf4, 0xcd, 0x5e, 0xe0, 0x8e, 0xe4, 0xe0, 0x5d, 0x70, 0xfa, 0x8d,
+	0xde, 0xd1, 0x0b, 0x42, 0xe8, 0xc6, 0x1d, 0x45, 0x74, 0xde, 0x8e, 0x47, 0xd4, 0xc5, 0xcd, 0xa8,
+	0xce, 0x57, 0x17, 0x92, 0xce, 0x57, 0xe3, 0x11, 0xfa, 0x08, 0x4a, 0x43, 0xd7, 0x09, 0xa6, 0x3d,
+	0xd3, 0xf7, 0x5d, 0xbb, 0x1f, 0xf8, 0x98, 0xba, 0x9f, 0xd7, 0xb7, 0x28, 0xb9, 0x2e, 0xa8, 0xda,
+	0x3f, 0x32, 0x00, 0xf5, 0xc0, 0xbf, 0x6d, 0x38, 0x93, 0xd7, 0xf6, 0x10, 0xd5, 0x60, 0x67, 0x64,
+	0xcf, 0x70, 0xcf, 0xa2, 0xcb, 0xde, 0x0c, 0xbb, 0x1e, 0x89, 0x0a, 0x71, 0x33, 0xa3, 0x6f, 0x13,
+	0x16, 0x13, 0x7c, 0xc9, 0x18, 0xe8, 0x1c, 0x36, 0xed, 0x41, 0x6f, 0xca, 0x43, 0xe1, 0x55, 0xd3,
+	0x87, 0x99, 0xe3, 0xc2, 0xa9, 0x12, 0x8f, 0x11, 0xf3, 0x76, 0xb1, 0xf6, 0xf4, 0x82, 0x3d, 0x08,
+	0x17, 0x08, 0x83, 0x42, 0xa2, 0xd5, 0xf3, 0x66, 0x56, 0xcf, 0x61, 0x91, 0xe2, 0xd1, 0x3e, 0x62,
+	0x48, 0x0b, 0x0f, 0x69, 0xb4, 0x0d, 0xec, 0xce, 0x6c, 0x0b, 0x8b, 0xa0, 0x57, 0xe6, 0x77, 0x07,
+	0x68, 0x99, 0xae, 0x6f, 0x11, 0x50, 0x63, 0x66, 0xf1, 0xb5, 0xfa, 0xef, 0x14, 0x24, 0x88, 0xa1,
+	0x23, 0xd8, 0x30, 0x2d, 0x4f, 0x3a, 0x0e, 0x98, 0xdf, 0x1d, 0x64, 0xeb, 0x0d, 0x83, 0x9c, 0x44,
+	0xd6, 0xb4, 0xbc, 0xf8, 0x21, 0x04, 0x2e, 0x3b, 0x84, 0xf7, 0x1d, 0xdc, 0xb7, 0x20, 0x37, 0x30,
+	0xbd, 0x5b, 0x2a, 0x4f, 0xa3, 0x7f, 0x56, 0x98, 0xdf, 0x1d, 0x6c, 0x9c, 0x9b, 0xde, 0x2d, 0x91,
+	0xdd, 0x20, 0x4c, 0x22, 0xf7, 0x6d, 0x50, 0x3c, 0xec, 0x91, 0x78, 0xf6, 0x06, 0x81, 0x6b, 0xd2,
+	0x7b, 0xb8, 0x46, 0x4f, 0xab, 0xc4, 0xe9, 0xe7, 0x9c, 0x8c, 0x8e, 0xa0, 0x38, 0xc0, 0xfd, 0x60,
+	0xd8, 0x1b, 0x39, 0xc3, 0xa1, 0x3d, 0x19, 0x56, 0xd7, 0x0f, 0x53, 0xc7, 0x39, 0x7d, 0x93, 0x12,
+	0x3b, 0x8c, 0xa6, 0xed, 0xc1, 0x6e, 0x0b, 0xfb, 0x2c, 0x5e, 0x5c, 0x51, 0xa4, 0x89, 0x0e, 0xd5,
+	0x65, 0x16, 0x4f, 0xbb, 0x4f, 0xa0, 0x68, 0xc9, 0x0c, 0x1a, 0x8d, 0xf0, 0x30, 0x17, 0x47, 0xa0,
+	0x47, 0xc5, 0xb4, 0x9f, 0xc1, 0xae, 0x91, 0x6c, 0xee, 0xbf, 0x86, 0x54, 0xa1, 0x6a, 0xac, 0x70,
+	0x53, 0x43, 0xa0, 0xb4, 0xb0, 0x5f, 0x1f, 0x8c, 0xed, 0x89, 0x27, 0xb6, 0xf5, 0x1d, 0xd8, 0x96,
+	0x68, 0x7c, 0x3f, 0x15, 0xc8, 0x9a, 0x94, 0x52, 0x4d, 0x1d, 0x66, 0x8e, 0xf3, 0x3a, 0x5f, 0x69,
+	0x3f, 0x86, 0x9d, 0x0b, 0x67, 0x60, 0xbf, 0x7e, 0x17, 0xc1, 0x40, 0x0a, 0x64, 0xcc, 0xc1, 0x80,
+	0xcb, 0x92, 0x4f, 0x02, 0xe0, 0xe2, 0xb1, 0x33, 0xc3, 0xf4, 0x5a, 0xe7, 0x75, 0xbe, 0xd2, 0x2a,
+	0x50, 0x8e, 0x02, 0x70, 0xcf, 0x26, 0xb0, 0x71, 0xd5, 0xbd, 0x6e, 0x4f, 0x5e, 0x3b, 0x72, 0xc1,
+	0x4b, 0x45, 0x0a, 0x1e, 0x6a, 0x03, 0x12, 0x87, 0x8d, 0xdf, 0x4e, 0x6d, 0x1e, 0x97, 0x34, 0x8d,
+	0x8b, 0x5a, 0x63, 0xf5, 0xb8, 0x26, 0xea, 0x71, 0xad, 0x2b, 0xea, 0xb1, 0xbe, 0xcd, 0xb5, 0x9a,
+	0xa1, 0x92, 0xf6, 0xc7, 0x14, 0xe4, 0x69, 0x49, 0x7c, 0x8f, 0xc9, 0x67, 0x90, 0xf5, 0x9c, 0xc0,
+	0xb5, 0x30, 0x35, 0xb3, 0x75, 0xfa, 0x21, 0x0b, 0x7f, 0xa8, 0xca, 0xbe, 0x0c, 0x2a, 0xa2, 0x73,
+	0x51, 0xed, 0x05, 0x14, 0x24, 0x32, 0x2a, 0xc0, 0x46, 0xfb, 0xf2, 0x65, 0xbd, 0xd3, 0x3e, 0x57,
+	0x1e, 0x21, 0x05, 0x36, 0xeb, 0x37, 0xdd, 0x2f, 0x9a, 0x97, 0xdd, 0x76, 0xa3, 0xde, 0x6d, 0x2a,
+	0x29, 0x54, 0x84, 0x7c, 0xab, 0xd9, 0xed, 0x75, 0xaf, 0x7e, 0xda, 0xbc, 0x54, 0xd2, 0x5a, 0x00,
+	0x3b, 0xe4, 0x70, 0xf1, 0xc4, 0xb7, 0xad, 0x6f, 0xd8, 0x3a, 0x3e, 0x86, 0x6d, 0x67, 0x82, 0x7b,
+	0xa4, 0x31, 0xf5, 0xa6, 0xa6, 0xe7, 0xfd, 0xda, 0x71, 0x07, 0xbc, 0x4a, 0x97, 0x9c, 0x09, 0x26,
+	0x01, 0xba, 0xe6, 0x64, 0xed, 0x39, 0x94, 0xa3, 0x66, 0x1f, 0xd6, 0x50, 0x4a, 0x50, 0x7c, 0x75,
+	0xeb, 0xd4, 0xc7, 0x6d, 0x71, 0x9d, 0xfa, 0xb0, 0x25, 0x08, 0x1c, 0x41, 0x85, 0x5c, 0xe0, 0x61,
+	0x57, 0xea, 0x1e, 0xe1, 0x1a, 0xed, 0x41, 0xce, 0xf6, 0x7a, 0xf4, 0x72, 0x51, 0xc7, 0x72, 0xfa,
+	0x86, 0xed, 0xd1, 0xab, 0x81, 0xf6, 0x20, 0xe3, 0xfb, 0x2c, 0xf9, 0x33, 0x67, 0x1b, 0xf3, 0xbb,
+	0x83, 0x4c, 0xb7, 0xdb, 0xd1, 0x09, 0x4d, 0xfb, 0x7d, 0x1a, 0x32, 0xf5, 0x46, 0x07, 0x3d, 0x85,
+	0x0d, 0x3c, 0xf1, 0x5d, 0x1b, 0xb3, 0x6b, 0x5a, 0x38, 0xad, 0xf0, 0xe4, 0x68, 0x74, 0x6a, 0x4d,
+	0xc6, 0x20, 0xff, 0xde, 0xe9, 0x42, 0x0c, 0x3d, 0x83, 0x5c, 0xdf, 0x35, 0x27, 0xd6, 0x2d, 0x16,
+	0xf5, 0x76, 0x77, 0xa1, 0x72, 0xc6, 0x39, 0x4c, 0x27, 0x14, 0x54, 0x5b, 0xb0, 0x29, 0xa3, 0x91,
+	0xdb, 0xfe, 0x06, 0xbf, 0xe3, 0x7b, 0x21, 0x9f, 0xe8, 0xff, 0x60, 0x7d, 0x66, 0x8e, 0x02, 0x71,
+	0x49, 0x0a, 0x0c, 0xd3, 0xb0, 0x9c, 0x29, 0xd6, 0x19, 0xe7, 0xb3, 0xf4, 0xa7, 0x29, 0xb5, 0x03,
+	0xc5, 0x88, 0x8d, 0x04, 0xa4, 0xff, 0x97, 0x91, 0x0a, 0xa7, 0x25, 0x86, 0xc4, 0xb4, 0xea, 0x8d,
+	0x8e, 0x84, 0xa6, 0xfd, 0x21, 0x05, 0xf9, 0x90, 0x81, 0x3e, 0x89, 0xc7, 0xe2, 0x71, 0x4c, 0x35,
+	0x39, 0x22, 0xff, 0xb3, 0xcd, 0x69, 0xbf, 0x83, 0xf5, 0x1b, 0x8f, 0x74, 0xa4, 0x4f, 0x21, 0x2f,
+	0xce, 0x57, 0xf8, 0xa2, 0x32, 0x1d, 0xca, 0xaf, 0xdd, 0x08, 0x26, 0xf3, 0x64, 0x21, 0xac, 0xfe,
+	0x10, 0xb6, 0xa2, 0xcc, 0x04, 0x6f, 0xca, 0xb2, 0x37, 0x39, 0xd9, 0x81, 0x00, 0xb2, 0x2d, 0xd2,
+	0xa0, 0x3d, 0xf4, 0x14, 0xb2, 0xb4, 0x55, 0x0b, 0xf3, 0x55, 0x66, 0x9e, 0x71, 0xf9, 0x3f, 0x66,
+	0x9c, 0xcb, 0xa9, 0x3f, 0x80, 0x82, 0x44, 0xfe, 0x5a, 0x66, 0x4d, 0x50, 0x48, 0xe2, 0x38, 0xae,
+	0xfd, 0x9b, 0x30, 0x59, 0x11, 0xac, 0xb9, 0x78, 0xea, 0x88, 0x61, 0x89, 0x7c, 0x93, 0x30, 0x7a,
+	0x24, 0x66, 0x89, 0x61, 0xa4, 0x1c, 0x52, 0x34, 0xd9, 0xa5, 0xe3, 0x03, 0x07, 0x5f, 0x69, 0xcf,
+	0x60, 0x5b, 0x32, 0xc1, 0xd3, 0x6a, 0x1f, 0xc0, 0x14, 0xc4, 0x01, 0xb5, 0x94, 0xd3, 0x25, 0x8a,
+	0xd6, 0x80, 0x52, 0x0b, 0xfb, 0x0c, 0x9f, 0xbb, 0x75, 0x5f, 0x26, 0x96, 0x61, 0x9d, 0xb8, 0xe9,
+	0xf1, 0x7a, 0xcd, 0x16, 0xda, 0xf7, 0x41, 0x59, 0x80, 0x70, 0xc3, 0x47, 0x90, 0xa5, 0xee, 0xb2,
+	0xe8, 0xc6, 0x76, 0xc2, 0x59, 0xda, 0x6f, 0xa1, 0x64, 0x7c, 0x0d, 0xeb, 0x22, 0x60, 0xe9, 0xa4,
+	0x80, 0x65, 0x1e, 0x10, 0xb0, 0xb5, 0x48, 0xc0, 0x10, 0x28, 0x46, 0xcc, 0x6d, 0xed, 0x08, 0x8a,
+	0xa4, 0xcf, 0x35, 0x3a, 0xf7, 0x1c, 0x92, 0x66, 0x42, 0xae, 0xde, 0xe8, 0xb0, 0x4b, 0x70, 0x9f,
+	0xbf, 0xdf, 0xe0, 0x30, 0x1d, 0xd8, 0x12, 0x7e, 0xf0, 0x80, 0x1e, 0xc7, 0x53, 0x77, 0x2b, 0xac,
+	0x49, 0x4b, 0xe5, 0xab, 0xe8, 0x3a, 0x7d, 0xc7, 0xef, 0x09, 0xf9, 0x74, 0xa2, 0xfc, 0x26, 0x15,
+	0xe2, 0x69, 0xad, 0x5d, 0x40, 0xd1, 0x78, 0xdf, 0xc6, 0x65, 0x1f, 0xd2, 0xf7, 0xfa, 0xa0, 0x29,
+	0xb0, 0x65, 0x44, 0xfc, 0xd7, 0xbe, 0x84, 0x1d, 0xb2, 0xa3, 0xc0, 0x67, 0x3d, 0x41, 0x98, 0x59,
+	0xdd, 0x54, 0x79, 0x69, 0x4f, 0x27, 0x94, 0xf6, 0xcf, 0xa1, 0x1c, 0xc5, 0xe2, 0x31, 0x2a, 0xc3,
+	0xba, 0xdc, 0x81, 0xd8, 0xe2, 0x9e, 0xb7, 0x51, 0x1b, 0x2a, 0xcd, 0xb7, 0x3e, 0x9e, 0x0c, 0x96,
+	0xdc, 0x4a, 0x46, 0xba, 0xc7, 0xa5, 0x3d, 0xd8, 0x5d, 0x82, 0xe2, 0x3b, 0xaf, 0x41, 0x45, 0xc7,
+	0x33, 0xe7, 0x0d, 0x7e, 0x98, 0x15, 0x02, 0xb5, 0x24, 0xcf, 0xa1, 0x2e, 0xe8, 0x24, 0xc8, 0x8a,
+	0xd0, 0xe7, 0x8e, 0x4b, 0xea, 0xe0, 0x43, 0x12, 0xa7, 0x12, 0x96, 0x3a, 0x3e, 0x67, 0xb1, 0x15,
+	0x9f, 0x02, 0x63, 0x70, 0xdc, 0xd4, 0x4b, 0x31, 0x83, 0x5d, 0xe0, 0x71, 0x9f, 0x3c, 0x28, 0x16,
+	0x3e, 0x53, 0x6d, 0xe1, 0x33, 0x5d, 0x88, 0xd9, 0x2e, 0x9d, 0x34, 0xdb, 0x65, 0x22, 0xb3, 0xdd,
+	0x2e, 0x7c, 0x10, 0xc3, 0x0d, 0xc3, 0xa4, 0xb4, 0x84, 0x33, 0x0f, 0xd8, 0x14, 0x1f, 0x49, 0x85,
+	0xfc, 0x62, 0x24, 0x95, 0x8a, 0xfa, 0x62, 0xa7, 0x1f, 0xd1, 0x3a, 0x47, 0x5b, 0xcb, 0xbd, 0x1b,
+	0xd1, 0x9e, 0x82, 0xb2, 0x10, 0xe4, 0xa0, 0x8f, 0xe3, 0xbd, 0x2a, 0x2f, 0xf5, 0x23, 0xed, 0x39,
+	0xec, 0xb5, 0xb0, 0x7f, 0x15, 0x9d, 0x94, 0xde, 0x7b, 0xbd, 0xb5, 0xa7, 0xa0, 0x26, 0xa9, 0x71,
+	0x93, 0x08, 0xd6, 0x2c, 0x67, 0x10, 0x3e, 0xa4, 0xc9, 0xf7, 0xc7, 0xdf, 0x83, 0x75, 0x5a, 0x3b,
+	0x50, 0x0e, 0xd6, 0x2e, 0xaf, 0x2e, 0x9b, 0xca, 0x23, 0x04, 0x90, 0xd5, 0x9b, 0xf5, 0xf3, 0xa6,
+	0xae, 0xa4, 0xc8, 0xf7, 0x2b, 0xbd, 0xdd, 0x6d, 0xea, 0x4a, 0x1a, 0xe5, 0x61, 0xfd, 0xea, 0xd5,
+	0x65, 0x53, 0x57, 0x32, 0xa7, 0x7f, 0x2d, 0x40, 0xa6, 0x7e, 0xdd, 0x46, 0x2f, 0x20, 0x27, 0x7e,
+	0x07, 0x40, 0x1f, 0xf0, 0xb4, 0x8d, 0x3e, 0xf1, 0xd5, 0x4a, 0x9c, 0xcc, 0x4f, 0xe6, 0x11, 0xaa,
+	0x03, 0x2c, 0x1e, 0xff, 0x88, 0x4f, 0x43, 0x4b, 0xbf, 0x11, 0xa8, 0xd5, 0x65, 0x46, 0x08, 0x61,
+	0xd0, 0xc0, 0x46, 0x5e, 0x1c, 0xe8, 0x09, 0x6f, 0xb9, 0xc9, 0x8f, 0x1b, 0x75, 0x7f, 0x15, 0x5b,
+	0x06, 0x35, 0x56, 0x80, 0x1a, 0xf7, 0x83, 0x1a, 0xab, 0x41, 0x7f, 0x04, 0xf9, 0xf0, 0xad, 0x83,
+	0x2a, 0xa1, 0x0f, 0x91, 0xc7, 0x8c, 0xba, 0xbb, 0x44, 0x0f, 0xf5, 0x5b, 0xb0, 0x29, 0xbf, 0x5e,
+	0xd0, 0x1e, 0x13, 0x4d, 0x78, 0x12, 0xa9, 0x6a, 0x12, 0x4b, 0x06, 0x92, 0xa7, 0x6d, 0x01, 0x94,
+	0x30, 0xf8, 0xab, 0x6a, 0x12, 0x4b, 0xde, 0x51, 0x38, 0x1a, 0x88, 0x1d, 0xc5, 0xc7, 0x11, 0x75,
+	0x77, 0x89, 0x1e, 0xea, 0x3f, 0x87, 0x2c, 0x1b, 0xd7, 0xd1, 0x0e, 0x13, 0x8a, 0x4c, 0xf3, 0x6a,
+	0x39, 0x4a, 0x0c, 0xd5, 0x5e, 0x40, 0x4e, 0xcc, 0x05, 0xe2, 0xca, 0xc5, 0x86, 0x0d, 0xb5, 0x12,
+	0x27, 0xcb, 0xca, 0x46, 0x4c, 0xd9, 0x48, 0x56, 0x36, 0x96, 0x95, 0x9f, 0x43, 0x96, 0xb5, 0x4f,
+	0xe1, 0x70, 0xa4, 0xa9, 0xab, 0xe5, 0x28, 0x51, 0x56, 0x33, 0x22, 0x6a, 0x46, 0x92, 0x9a, 0x11,
+	0x57, 0x6b, 0xc1, 0xa6, 0xdc, 0x8e, 0xc4, 0x39, 0x25, 0xb4, 0x3b, 0x55, 0x4d, 0x62, 0x85, 0x40,
+	0xd7, 0x50, 0x8a, 0x35, 0x11, 0xc4, 0x07, 0xf4, 0xe4, 0x36, 0xa5, 0x3e, 0x59, 0xc1, 0x95, 0x11,
+	0x63, 0xbd, 0x44, 0x20, 0x26, 0xb7, 0x24, 0xf5, 0xc9, 0x0a, 0x6e, 0x2c, 0xe5, 0x22, 0x3d, 0x43,
+	0x4a, 0xb9, 0xa4, 0xd6, 0xa4, 0xee, 0xaf, 0x62, 0x87, 0xa0, 0x5f, 0x42, 0x31, 0xd2, 0x14, 0x50,
+	0x24, 0x31, 0xa2, 0x1d, 0x48, 0xfd, 0x30, 0x91, 0x17, 0x4b, 0x5f, 0x66, 0x49, 0x4a, 0xdf, 0x48,
+	0x63, 0x51, 0x77, 0x97, 0xe8, 0xb1, 0x5b, 0xcb, 0x5e, 0x29, 0x8b, 0x5b, 0x2b, 0xb7, 0x0e, 0xb5,
+	0x12, 0x27, 0x87, 0xca, 0x3f, 0x07, 0xb4, 0x5c, 0xd5, 0xd1, 0x41, 0x28, 0x9f, 0xdc, 0x26, 0xd4,
+	0xc3, 0xd5, 0x02, 0x02, 0xfa, 0xec, 0x27, 0x7f, 0x9b, 0xef, 0xa7, 0xfe, 0x3e, 0xdf, 0x4f, 0xfd,
+	0x73, 0xbe, 0x9f, 0xfa, 0xd3, 0xbf, 0xf6, 0x1f, 0xfd, 0xa2, 0xc6, 0x9e, 0xf1, 0x35, 0xcb, 0x19,
+	0x9f, 0x90, 0xc7, 0xf6, 0xbb, 0x01, 0x76, 0xe5, 0x2f, 0xcf, 0xb5, 0x4e, 0xa4, 0xdf, 0xac, 0xfb,
+	0x59, 0xfa, 0xab, 0xc7, 0xb3, 0xff, 0x0c, 0x00, 0xf6, 0x6e, 0x7f, 0x4a, 0xc9, 0x16, 0x00, 0x00,
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/config"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/client/version"
 	"github.com/pachyderm/pachyderm/src/client/version/versionpb"
 )
 
@@ -93,6 +95,13 @@ type APIClient struct {
 	// The trusted CAs, for authenticating a pachd server over TLS
 	caCerts *x509.CertPool
 
+	// circuitBreaker, if set, makes calls fail fast while pachd is down
+	circuitBreaker *grpcutil.CircuitBreaker
+
+	// compression is the name of the compressor that requests to pachd are
+	// compressed with, or "" if they aren't compressed
+	compression string
+
 	// clientConn is a cached grpc connection to 'addr'
 	clientConn *grpc.ClientConn
 
@@ -134,10 +143,50 @@ const DefaultMaxConcurrentStreams = 100
 // for a connection to be established unless overridden by WithDialTimeout()
 const DefaultDialTimeout = 30 * time.Second
 
+// DefaultCircuitBreakerThreshold is the number of consecutive calls that must
+// fail because pachd is unavailable before the circuit breaker of a client
+// created by NewOnUserMachine opens (see WithCircuitBreaker)
+const DefaultCircuitBreakerThreshold = 5
+
+// DefaultCircuitBreakerCooldown is how long a client's circuit breaker stays
+// open before probing pachd again, if $PACH_CIRCUIT_BREAKER_COOLDOWN is unset
+// (see WithCircuitBreaker)
+const DefaultCircuitBreakerCooldown = 10 * time.Second
+
+// CompressionEnv is the env var that sets how clients created by
+// NewOnUserMachine or NewInCluster compress their requests to pachd (see
+// WithCompression)
+const CompressionEnv = "PACH_GRPC_COMPRESSION"
+
+// WorkerCompressionEnv is the env var that sets how workers compress their
+// requests to their pachd sidecars, independently of CompressionEnv, so that
+// transfers inside the cluster can be compressed (or not) differently than
+// transfers with external clients. pachd passes it on to the workers that it
+// creates.
+const WorkerCompressionEnv = "PACH_WORKER_GRPC_COMPRESSION"
+
+// VersionCheck determines how the New* functions check that pachd's version
+// is compatible with the client's
+type VersionCheck int
+
+const (
+	// VersionCheckNone doesn't check pachd's version
+	VersionCheckNone VersionCheck = iota
+	// VersionCheckRelaxed returns an error if pachd's major version differs
+	// from the client's, and logs a warning if its minor version differs
+	VersionCheckRelaxed
+	// VersionCheckStrict returns an error if pachd's major or minor version
+	// differs from the client's
+	VersionCheckStrict
+)
+
 type clientSettings struct {
 	maxConcurrentStreams int
 	dialTimeout          time.Duration
 	caCerts              *x509.CertPool
+	versionCheck         VersionCheck
+	circuitBreaker       *grpcutil.CircuitBreaker
+	compression          string
 }
 
 // NewFromAddress constructs a new APIClient for the server at addr.
@@ -153,13 +202,21 @@ func NewFromAddress(addr string, options ...Option) (*APIClient, error) {
 		}
 	}
 	c := &APIClient{
-		addr:    addr,
-		caCerts: settings.caCerts,
-		limiter: limit.New(settings.maxConcurrentStreams),
+		addr:           addr,
+		caCerts:        settings.caCerts,
+		circuitBreaker: settings.circuitBreaker,
+		compression:    settings.compression,
+		limiter:        limit.New(settings.maxConcurrentStreams),
 	}
 	if err := c.connect(settings.dialTimeout); err != nil {
 		return nil, err
 	}
+	if settings.versionCheck != VersionCheckNone {
+		if err := c.checkVersion(settings.versionCheck, settings.dialTimeout); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
@@ -175,6 +232,113 @@ func WithMaxConcurrentStreams(streams int) Option {
 	}
 }
 
+// WithVersionCheck instructs the New* functions to check that pachd's version
+// is compatible with the client's, as described by 'check'. NewOnUserMachine
+// uses VersionCheckStrict by default (or the value of $PACH_VERSION_CHECK,
+// which may be "strict", "relaxed" or "none"), and the other New* functions
+// use VersionCheckNone.
+func WithVersionCheck(check VersionCheck) Option {
+	return func(settings *clientSettings) error {
+		settings.versionCheck = check
+		return nil
+	}
+}
+
+// WithCircuitBreaker instructs the New* functions to create a client that,
+// after 'threshold' consecutive calls fail because pachd is unavailable, fails
+// calls fast for 'cooldown' before letting a call through to probe pachd
+// again, rather than adding to pachd's load while it recovers. A threshold of
+// 0 disables the circuit breaker. NewOnUserMachine uses
+// DefaultCircuitBreakerThreshold and DefaultCircuitBreakerCooldown by default
+// (or the values of $PACH_CIRCUIT_BREAKER_THRESHOLD and
+// $PACH_CIRCUIT_BREAKER_COOLDOWN). NewInCluster only uses a circuit breaker if
+// $PACH_CIRCUIT_BREAKER_THRESHOLD is set, as in-pod clients (e.g. workers
+// talking to their sidecar) have nothing to fall back on while pachd is down,
+// and the other New* functions don't use a circuit breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(settings *clientSettings) error {
+		if threshold < 0 {
+			return fmt.Errorf("invalid circuit breaker threshold %d (must be >= 0)", threshold)
+		}
+		if threshold == 0 {
+			settings.circuitBreaker = nil
+			return nil
+		}
+		if cooldown <= 0 {
+			return fmt.Errorf("invalid circuit breaker cooldown %v (must be > 0)", cooldown)
+		}
+		settings.circuitBreaker = grpcutil.NewCircuitBreaker(threshold, cooldown)
+		return nil
+	}
+}
+
+// CircuitBreakerFromEnv returns the circuit breaker option set by
+// $PACH_CIRCUIT_BREAKER_THRESHOLD and $PACH_CIRCUIT_BREAKER_COOLDOWN, using
+// 'defaultThreshold' and DefaultCircuitBreakerCooldown for any that are unset
+// (so a 'defaultThreshold' of 0 disables the circuit breaker unless the env
+// enables it)
+func CircuitBreakerFromEnv(defaultThreshold int) (Option, error) {
+	threshold, cooldown := defaultThreshold, DefaultCircuitBreakerCooldown
+	if s, ok := os.LookupEnv("PACH_CIRCUIT_BREAKER_THRESHOLD"); ok {
+		var err error
+		if threshold, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("invalid PACH_CIRCUIT_BREAKER_THRESHOLD %q: %v", s, err)
+		}
+	}
+	if s, ok := os.LookupEnv("PACH_CIRCUIT_BREAKER_COOLDOWN"); ok {
+		var err error
+		if cooldown, err = time.ParseDuration(s); err != nil {
+			return nil, fmt.Errorf("invalid PACH_CIRCUIT_BREAKER_COOLDOWN %q: %v", s, err)
+		}
+	}
+	return WithCircuitBreaker(threshold, cooldown), nil
+}
+
+// WithCompression instructs the New* functions to create a client that
+// compresses its requests to pachd with 'compression', which may be
+// grpcutil.CompressionGzip or grpcutil.CompressionNone (or "", which is the
+// same as CompressionNone). pachd compresses its responses the same way as the
+// requests that they answer. NewOnUserMachine and NewInCluster use the value of
+// $PACH_GRPC_COMPRESSION by default, and the other New* functions don't
+// compress requests.
+func WithCompression(compression string) Option {
+	return func(settings *clientSettings) error {
+		if err := grpcutil.ValidateCompression(compression); err != nil {
+			return err
+		}
+		if compression == grpcutil.CompressionNone {
+			compression = ""
+		}
+		settings.compression = compression
+		return nil
+	}
+}
+
+// CompressionFromEnv returns the compression option set by
+// $PACH_GRPC_COMPRESSION
+func CompressionFromEnv() (Option, error) {
+	compression := os.Getenv(CompressionEnv)
+	if err := grpcutil.ValidateCompression(compression); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", CompressionEnv, err)
+	}
+	return WithCompression(compression), nil
+}
+
+// getVersionCheckFromEnv returns the version check set by $PACH_VERSION_CHECK,
+// or VersionCheckStrict if it's unset
+func getVersionCheckFromEnv() (VersionCheck, error) {
+	switch check := os.Getenv("PACH_VERSION_CHECK"); check {
+	case "", "strict":
+		return VersionCheckStrict, nil
+	case "relaxed":
+		return VersionCheckRelaxed, nil
+	case "none":
+		return VersionCheckNone, nil
+	default:
+		return VersionCheckNone, fmt.Errorf("invalid PACH_VERSION_CHECK %q (must be \"strict\", \"relaxed\" or \"none\")", check)
+	}
+}
+
 func addCertFromFile(pool *x509.CertPool, path string) error {
 	bytes, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -322,6 +486,21 @@ func NewOnUserMachine(reportMetrics bool, prefix string, options ...Option) (*AP
 	if err != nil {
 		return nil, err
 	}
+	versionCheck, err := getVersionCheckFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	circuitBreaker, err := CircuitBreakerFromEnv(DefaultCircuitBreakerThreshold)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := CompressionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	// Options passed by the caller override the version check, circuit
+	// breaker and compression set in the env
+	options = append([]Option{WithVersionCheck(versionCheck), circuitBreaker, compression}, options...)
 	client, err := NewFromAddress(addr, append(options, cfgOptions...)...)
 	if err != nil {
 		if strings.Contains(err.Error(), "context deadline exceeded") {
@@ -375,6 +554,17 @@ func NewInCluster(options ...Option) (*APIClient, error) {
 	if !ok {
 		return nil, fmt.Errorf("PACHD_SERVICE_PORT not set")
 	}
+	circuitBreaker, err := CircuitBreakerFromEnv(0)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := CompressionFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	// Options passed by the caller override the circuit breaker and
+	// compression set in the env
+	options = append([]Option{circuitBreaker, compression}, options...)
 	// create new pachctl client
 	return NewFromAddress(fmt.Sprintf("%s:%s", host, port), options...)
 }
@@ -447,6 +637,15 @@ func (c *APIClient) connect(timeout time.Duration) error {
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(tlsCreds))
 	}
 	dialOptions = append(dialOptions, grpc.WithTimeout(timeout))
+	if c.circuitBreaker != nil {
+		dialOptions = append(dialOptions,
+			grpc.WithUnaryInterceptor(c.circuitBreaker.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(c.circuitBreaker.StreamClientInterceptor()),
+		)
+	}
+	if c.compression != "" {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(c.compression)))
+	}
 	// TODO(msteffen) switch to grpc.DialContext instead
 	clientConn, err := grpc.Dial(c.addr, dialOptions...)
 	if err != nil {
@@ -466,6 +665,24 @@ func (c *APIClient) connect(timeout time.Duration) error {
 	return nil
 }
 
+// checkVersion returns an error if pachd's version isn't compatible with the
+// client's, according to 'check'
+func (c *APIClient) checkVersion(check VersionCheck, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	serverVersion, err := c.VersionAPIClient.GetVersion(ctx, &types.Empty{})
+	if err != nil {
+		return fmt.Errorf("could not get pachd's version: %v", grpcutil.ScrubGRPC(err))
+	}
+	if err := version.CheckCompatible(version.Version, serverVersion, check == VersionCheckRelaxed); err != nil {
+		return fmt.Errorf("%v (set PACH_VERSION_CHECK=relaxed to allow a different minor version)", err)
+	}
+	if serverVersion.Minor != version.Version.Minor {
+		log.Warnf("client version %s differs from pachd version %s", version.PrettyVersion(), version.PrettyPrintVersion(serverVersion))
+	}
+	return nil
+}
+
 // AddMetadata adds necessary metadata (including authentication credentials)
 // to the context 'ctx', preserving any metadata that is present in either the
 // incoming or outgoing metadata of 'ctx'.
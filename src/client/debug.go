@@ -15,3 +15,13 @@ func (c APIClient) Dump(w io.Writer) error {
 	}
 	return grpcutil.ScrubGRPC(grpcutil.WriteFromStreamingBytesClient(goroClient, w))
 }
+
+// InspectCache returns the stats of the object caches of each pod that has
+// them: the pachd that serves the request, and every worker's pachd sidecar.
+func (c APIClient) InspectCache() ([]*debug.PodCacheInfo, error) {
+	response, err := c.DebugClient.InspectCache(c.Ctx(), &debug.InspectCacheRequest{})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response.Pods, nil
+}
@@ -7,6 +7,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import types "github.com/gogo/protobuf/types"
+import pfs "github.com/pachyderm/pachyderm/src/client/pfs"
 
 import (
 	context "golang.org/x/net/context"
@@ -40,7 +41,7 @@ func (m *DumpRequest) Reset()         { *m = DumpRequest{} }
 func (m *DumpRequest) String() string { return proto.CompactTextString(m) }
 func (*DumpRequest) ProtoMessage()    {}
 func (*DumpRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_debug_2f0a5dd65a8cf14b, []int{0}
+	return fileDescriptor_debug_eb033b99600b5ea7, []int{0}
 }
 func (m *DumpRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -76,8 +77,164 @@ func (m *DumpRequest) GetRecursed() bool {
 	return false
 }
 
+type InspectCacheRequest struct {
+	// Recursed is true if this request is a recursive call from another request
+	// (see DumpRequest).
+	Recursed             bool     `protobuf:"varint,1,opt,name=recursed,proto3" json:"recursed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectCacheRequest) Reset()         { *m = InspectCacheRequest{} }
+func (m *InspectCacheRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectCacheRequest) ProtoMessage()    {}
+func (*InspectCacheRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_debug_eb033b99600b5ea7, []int{1}
+}
+func (m *InspectCacheRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InspectCacheRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectCacheRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *InspectCacheRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCacheRequest.Merge(dst, src)
+}
+func (m *InspectCacheRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InspectCacheRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCacheRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCacheRequest proto.InternalMessageInfo
+
+func (m *InspectCacheRequest) GetRecursed() bool {
+	if m != nil {
+		return m.Recursed
+	}
+	return false
+}
+
+// PodCacheInfo holds the stats of the object caches of one pod: a pachd, or
+// the pachd sidecar of a worker.
+type PodCacheInfo struct {
+	Pod                  string           `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Caches               []*pfs.CacheInfo `protobuf:"bytes,2,rep,name=caches,proto3" json:"caches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PodCacheInfo) Reset()         { *m = PodCacheInfo{} }
+func (m *PodCacheInfo) String() string { return proto.CompactTextString(m) }
+func (*PodCacheInfo) ProtoMessage()    {}
+func (*PodCacheInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_debug_eb033b99600b5ea7, []int{2}
+}
+func (m *PodCacheInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PodCacheInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PodCacheInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *PodCacheInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodCacheInfo.Merge(dst, src)
+}
+func (m *PodCacheInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *PodCacheInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodCacheInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodCacheInfo proto.InternalMessageInfo
+
+func (m *PodCacheInfo) GetPod() string {
+	if m != nil {
+		return m.Pod
+	}
+	return ""
+}
+
+func (m *PodCacheInfo) GetCaches() []*pfs.CacheInfo {
+	if m != nil {
+		return m.Caches
+	}
+	return nil
+}
+
+type InspectCacheResponse struct {
+	Pods                 []*PodCacheInfo `protobuf:"bytes,1,rep,name=pods,proto3" json:"pods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *InspectCacheResponse) Reset()         { *m = InspectCacheResponse{} }
+func (m *InspectCacheResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectCacheResponse) ProtoMessage()    {}
+func (*InspectCacheResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_debug_eb033b99600b5ea7, []int{3}
+}
+func (m *InspectCacheResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InspectCacheResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectCacheResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *InspectCacheResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCacheResponse.Merge(dst, src)
+}
+func (m *InspectCacheResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *InspectCacheResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCacheResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCacheResponse proto.InternalMessageInfo
+
+func (m *InspectCacheResponse) GetPods() []*PodCacheInfo {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DumpRequest)(nil), "debug.DumpRequest")
+	proto.RegisterType((*InspectCacheRequest)(nil), "debug.InspectCacheRequest")
+	proto.RegisterType((*PodCacheInfo)(nil), "debug.PodCacheInfo")
+	proto.RegisterType((*InspectCacheResponse)(nil), "debug.InspectCacheResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -93,6 +250,10 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type DebugClient interface {
 	Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (Debug_DumpClient, error)
+	// InspectCache returns the stats of the object caches of the pachd that
+	// serves the request and of every worker's pachd sidecar, as each pod has
+	// its own caches.
+	InspectCache(ctx context.Context, in *InspectCacheRequest, opts ...grpc.CallOption) (*InspectCacheResponse, error)
 }
 
 type debugClient struct {
@@ -135,9 +296,22 @@ func (x *debugDumpClient) Recv() (*types.BytesValue, error) {
 	return m, nil
 }
 
+func (c *debugClient) InspectCache(ctx context.Context, in *InspectCacheRequest, opts ...grpc.CallOption) (*InspectCacheResponse, error) {
+	out := new(InspectCacheResponse)
+	err := c.cc.Invoke(ctx, "/debug.Debug/InspectCache", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	Dump(*DumpRequest, Debug_DumpServer) error
+	// InspectCache returns the stats of the object caches of the pachd that
+	// serves the request and of every worker's pachd sidecar, as each pod has
+	// its own caches.
+	InspectCache(context.Context, *InspectCacheRequest) (*InspectCacheResponse, error)
 }
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
@@ -165,10 +339,33 @@ func (x *debugDumpServer) Send(m *types.BytesValue) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Debug_InspectCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).InspectCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/debug.Debug/InspectCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).InspectCache(ctx, req.(*InspectCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "debug.Debug",
 	HandlerType: (*DebugServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InspectCache",
+			Handler:    _Debug_InspectCache_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Dump",
@@ -210,6 +407,109 @@ func (m *DumpRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *InspectCacheRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectCacheRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Recursed {
+		dAtA[i] = 0x8
+		i++
+		if m.Recursed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PodCacheInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PodCacheInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Pod) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDebug(dAtA, i, uint64(len(m.Pod)))
+		i += copy(dAtA[i:], m.Pod)
+	}
+	if len(m.Caches) > 0 {
+		for _, msg := range m.Caches {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintDebug(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InspectCacheResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectCacheResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Pods) > 0 {
+		for _, msg := range m.Pods {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDebug(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeVarintDebug(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -234,6 +534,61 @@ func (m *DumpRequest) Size() (n int) {
 	return n
 }
 
+func (m *InspectCacheRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Recursed {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PodCacheInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pod)
+	if l > 0 {
+		n += 1 + l + sovDebug(uint64(l))
+	}
+	if len(m.Caches) > 0 {
+		for _, e := range m.Caches {
+			l = e.Size()
+			n += 1 + l + sovDebug(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectCacheResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Pods) > 0 {
+		for _, e := range m.Pods {
+			l = e.Size()
+			n += 1 + l + sovDebug(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovDebug(x uint64) (n int) {
 	for {
 		n++
@@ -318,6 +673,270 @@ func (m *DumpRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *InspectCacheRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectCacheRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectCacheRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recursed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Recursed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PodCacheInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PodCacheInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PodCacheInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pod", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pod = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Caches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Caches = append(m.Caches, &pfs.CacheInfo{})
+			if err := m.Caches[len(m.Caches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectCacheResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDebug
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectCacheResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectCacheResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDebug
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDebug
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pods = append(m.Pods, &PodCacheInfo{})
+			if err := m.Pods[len(m.Pods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDebug(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDebug
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipDebug(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -423,21 +1042,29 @@ var (
 	ErrIntOverflowDebug   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("client/debug/debug.proto", fileDescriptor_debug_2f0a5dd65a8cf14b) }
-
-var fileDescriptor_debug_2f0a5dd65a8cf14b = []byte{
-	// 207 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x48, 0xce, 0xc9, 0x4c,
-	0xcd, 0x2b, 0xd1, 0x4f, 0x49, 0x4d, 0x2a, 0x4d, 0x87, 0x90, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9,
-	0x42, 0xac, 0x60, 0x8e, 0x94, 0x5c, 0x7a, 0x7e, 0x7e, 0x7a, 0x4e, 0xaa, 0x3e, 0x58, 0x30, 0xa9,
-	0x34, 0x4d, 0xbf, 0xbc, 0x28, 0xb1, 0xa0, 0x20, 0xb5, 0xa8, 0x18, 0xa2, 0x4c, 0x49, 0x93, 0x8b,
-	0xdb, 0xa5, 0x34, 0xb7, 0x20, 0x28, 0xb5, 0xb0, 0x34, 0xb5, 0xb8, 0x44, 0x48, 0x8a, 0x8b, 0xa3,
-	0x28, 0x35, 0xb9, 0xb4, 0xa8, 0x38, 0x35, 0x45, 0x82, 0x51, 0x81, 0x51, 0x83, 0x23, 0x08, 0xce,
-	0x37, 0x72, 0xe1, 0x62, 0x75, 0x01, 0x99, 0x29, 0x64, 0xcd, 0xc5, 0x02, 0xd2, 0x23, 0x24, 0xa4,
-	0x07, 0xb1, 0x10, 0xc9, 0x00, 0x29, 0x69, 0x3d, 0x88, 0x85, 0x7a, 0x30, 0x0b, 0xf5, 0x9c, 0x2a,
-	0x4b, 0x52, 0x8b, 0xc3, 0x12, 0x73, 0x4a, 0x53, 0x95, 0x18, 0x0c, 0x18, 0x9d, 0x1c, 0x4f, 0x3c,
-	0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6, 0x19, 0x8f, 0xe5, 0x18, 0xa2,
-	0xf4, 0xd3, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0x0b, 0x12, 0x93, 0x33,
-	0x2a, 0x53, 0x52, 0x8b, 0x90, 0x59, 0xc5, 0x45, 0xc9, 0xfa, 0xc8, 0xde, 0x4c, 0x62, 0x03, 0x9b,
-	0x6d, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0x12, 0xce, 0xdc, 0x52, 0xfd, 0x00, 0x00, 0x00,
+func init() { proto.RegisterFile("client/debug/debug.proto", fileDescriptor_debug_eb033b99600b5ea7) }
+
+var fileDescriptor_debug_eb033b99600b5ea7 = []byte{
+	// 330 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xbd, 0x4e, 0xc3, 0x30,
+	0x10, 0xae, 0xe9, 0x8f, 0x8a, 0x5b, 0x21, 0xe4, 0x76, 0x88, 0x52, 0x29, 0xaa, 0x32, 0x40, 0x59,
+	0x1c, 0x28, 0x23, 0x03, 0xa2, 0x74, 0xa0, 0x1b, 0xca, 0xc0, 0xc0, 0x96, 0x38, 0xd7, 0xb4, 0x52,
+	0x1b, 0x1b, 0x3b, 0x16, 0xea, 0x53, 0xb0, 0xf2, 0x48, 0x8c, 0x3c, 0x02, 0x0a, 0x2f, 0x82, 0x62,
+	0x87, 0x2a, 0x48, 0x1d, 0x18, 0x12, 0xdd, 0xdd, 0xf7, 0xdd, 0xdd, 0x77, 0x9f, 0x8c, 0x1d, 0xb6,
+	0x59, 0x43, 0x96, 0x07, 0x09, 0xc4, 0x3a, 0xb5, 0x7f, 0x2a, 0x24, 0xcf, 0x39, 0x69, 0x9b, 0xc4,
+	0xf5, 0x52, 0xce, 0xd3, 0x0d, 0x04, 0xa6, 0x18, 0xeb, 0x65, 0xf0, 0x2a, 0x23, 0x21, 0x40, 0x2a,
+	0x4b, 0x73, 0x87, 0xd5, 0x00, 0xb1, 0x54, 0xe5, 0x67, 0xab, 0xfe, 0x05, 0xee, 0xcd, 0xf5, 0x56,
+	0x84, 0xf0, 0xa2, 0x41, 0xe5, 0xc4, 0xc5, 0x5d, 0x09, 0x4c, 0x4b, 0x05, 0x89, 0x83, 0xc6, 0x68,
+	0xd2, 0x0d, 0xf7, 0xb9, 0x7f, 0x85, 0x07, 0x8b, 0x4c, 0x09, 0x60, 0xf9, 0x7d, 0xc4, 0x56, 0xf0,
+	0x9f, 0x96, 0x07, 0xdc, 0x7f, 0xe4, 0x89, 0xa1, 0x2f, 0xb2, 0x25, 0x27, 0xa7, 0xb8, 0x29, 0xb8,
+	0xa5, 0x1d, 0x87, 0x65, 0x48, 0xce, 0x70, 0x87, 0x95, 0xb0, 0x72, 0x8e, 0xc6, 0xcd, 0x49, 0x6f,
+	0x7a, 0x42, 0x4b, 0x6d, 0xfb, 0x8e, 0xb0, 0x42, 0xfd, 0x5b, 0x3c, 0xfc, 0xbb, 0x5c, 0x09, 0x9e,
+	0x29, 0x20, 0xe7, 0xb8, 0x25, 0x78, 0xa2, 0x1c, 0x64, 0xba, 0x07, 0xd4, 0x1a, 0x53, 0x5f, 0x1a,
+	0x1a, 0xc2, 0xf4, 0x0d, 0xe1, 0xf6, 0xbc, 0x04, 0xc9, 0x0d, 0x6e, 0x95, 0x27, 0x13, 0x52, 0x91,
+	0x6b, 0xf7, 0xbb, 0x23, 0x6a, 0x5d, 0xa4, 0xbf, 0x2e, 0xd2, 0xd9, 0x2e, 0x07, 0xf5, 0x14, 0x6d,
+	0x34, 0xf8, 0x8d, 0x4b, 0x44, 0x16, 0xb8, 0x5f, 0xd7, 0x41, 0xdc, 0x6a, 0xc8, 0x01, 0x67, 0xdc,
+	0xd1, 0x41, 0xcc, 0x0a, 0xf7, 0x1b, 0xb3, 0xbb, 0x8f, 0xc2, 0x43, 0x9f, 0x85, 0x87, 0xbe, 0x0a,
+	0x0f, 0xbd, 0x7f, 0x7b, 0x8d, 0xe7, 0x20, 0x5d, 0xe7, 0x2b, 0x1d, 0x53, 0xc6, 0xb7, 0x81, 0x88,
+	0xd8, 0x6a, 0x97, 0x80, 0xac, 0x47, 0x4a, 0xb2, 0xa0, 0xfe, 0x0c, 0xe2, 0x8e, 0x91, 0x79, 0xfd,
+	0x33, 0x00, 0xdb, 0x8d, 0x08, 0x3c, 0x1d, 0x02, 0x00, 0x00,
 }
@@ -3,14 +3,36 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"hash"
 	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/gogo/protobuf/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/src/client/limit"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+const (
+	// PFSDedupScopeEnv is the env var that sets the scope within which pachd
+	// stores identical file content once. pachd passes it on to the workers
+	// that it creates, so that their sidecars, and the content that they put
+	// in their output repos, use the same scope.
+	PFSDedupScopeEnv = "DEDUP_SCOPE"
+	// PFSDedupScopeRepo is the value of PFSDedupScopeEnv that makes pachd only
+	// deduplicate content within each repo (see pfs.RepoScope).
+	PFSDedupScopeRepo = "repo"
 )
 
 // NewRepo creates a pfs.Repo.
@@ -91,6 +113,31 @@ func (c APIClient) InspectRepo(repoName string) (*pfs.RepoInfo, error) {
 	return resp, nil
 }
 
+// InspectRepos returns info about the repos named 'repoNames', in the same
+// order, in one call. Repos that couldn't be inspected (e.g. because they
+// don't exist) are included with 'Error' (and, if they don't exist,
+// 'NotFound') set. At most 1000 repos may be inspected at once.
+func (c APIClient) InspectRepos(repoNames []string) ([]*pfs.InspectRepoResult, error) {
+	request := &pfs.InspectReposRequest{}
+	for _, repoName := range repoNames {
+		request.Repos = append(request.Repos, NewRepo(repoName))
+	}
+	stream, err := c.PfsAPIClient.InspectRepos(c.Ctx(), request)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	var results []*pfs.InspectRepoResult
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return nil, grpcutil.ScrubGRPC(err)
+		}
+		results = append(results, result)
+	}
+}
+
 // ListRepo returns info about all Repos.
 // provenance specifies a set of provenance repos, only repos which have ALL of
 // the specified repos as provenance will be returned unless provenance is nil
@@ -107,6 +154,24 @@ func (c APIClient) ListRepo() ([]*pfs.RepoInfo, error) {
 	return repoInfos.RepoInfo, nil
 }
 
+// DiskUsage returns the storage used by the repo 'repoName', or by every repo
+// that the caller can read if 'repoName' is empty. See pfs.RepoDiskUsage for
+// how data that's shared between repos is attributed to them.
+func (c APIClient) DiskUsage(repoName string) ([]*pfs.RepoDiskUsage, error) {
+	request := &pfs.DiskUsageRequest{}
+	if repoName != "" {
+		request.Repo = NewRepo(repoName)
+	}
+	response, err := c.PfsAPIClient.DiskUsage(
+		c.Ctx(),
+		request,
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response.Repos, nil
+}
+
 // DeleteRepo deletes a repo and reclaims the storage space it was using. Note
 // that as of 1.0 we do not reclaim the blocks that the Repo was referencing,
 // this is because they may also be referenced by other Repos and deleting them
@@ -203,6 +268,27 @@ func (c APIClient) StartCommitParent(repoName string, branch string, parentCommi
 	return commit, nil
 }
 
+// StartCommitLabels is the same as StartCommit, but attaches 'labels' to the
+// new commit. Labels can be used to select commits in ListCommitByLabel.
+func (c APIClient) StartCommitLabels(repoName string, branch string, labels map[string]string) (*pfs.Commit, error) {
+	commit, err := c.PfsAPIClient.StartCommit(
+		c.Ctx(),
+		&pfs.StartCommitRequest{
+			Parent: &pfs.Commit{
+				Repo: &pfs.Repo{
+					Name: repoName,
+				},
+			},
+			Branch: branch,
+			Labels: labels,
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return commit, nil
+}
+
 // FinishCommit ends the process of committing data to a Repo and persists the
 // Commit. Once a Commit is finished the data becomes immutable and future
 // attempts to write to it with PutFile will error.
@@ -216,11 +302,46 @@ func (c APIClient) FinishCommit(repoName string, commitID string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// FinishCommitLabels is the same as FinishCommit, but adds 'labels' to the
+// commit's labels (overwriting any existing labels with the same keys).
+func (c APIClient) FinishCommitLabels(repoName string, commitID string, labels map[string]string) error {
+	_, err := c.PfsAPIClient.FinishCommit(
+		c.Ctx(),
+		&pfs.FinishCommitRequest{
+			Commit: NewCommit(repoName, commitID),
+			Labels: labels,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // InspectCommit returns info about a specific Commit.
 func (c APIClient) InspectCommit(repoName string, commitID string) (*pfs.CommitInfo, error) {
 	return c.inspectCommit(repoName, commitID, pfs.CommitState_STARTED)
 }
 
+// InspectCommits returns info about 'commits' (whose IDs may be branch
+// names), in the same order, in one call. Commits that couldn't be inspected
+// (e.g. because they don't exist) are included with 'Error' (and, if they
+// don't exist, 'NotFound') set. At most 1000 commits may be inspected at
+// once.
+func (c APIClient) InspectCommits(commits []*pfs.Commit) ([]*pfs.InspectCommitResult, error) {
+	stream, err := c.PfsAPIClient.InspectCommits(c.Ctx(), &pfs.InspectCommitsRequest{Commits: commits})
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	var results []*pfs.InspectCommitResult
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return nil, grpcutil.ScrubGRPC(err)
+		}
+		results = append(results, result)
+	}
+}
+
 // BlockCommit returns info about a specific Commit, but blocks until that
 // commit has been finished.
 func (c APIClient) BlockCommit(repoName string, commitID string) (*pfs.CommitInfo, error) {
@@ -269,9 +390,17 @@ func (c APIClient) ListCommit(repoName string, to string, from string, number ui
 // `number` determines how many commits are returned.  If `number` is 0,
 // all commits that match the aforementioned criteria are returned.
 func (c APIClient) ListCommitF(repoName string, to string, from string, number uint64, f func(*pfs.CommitInfo) error) error {
+	return c.ListCommitLabelF(repoName, to, from, number, "", f)
+}
+
+// ListCommitLabelF is the same as ListCommitF, but only considers commits
+// whose labels match 'labelSelector' (if it's set). `number` then
+// determines how many matching commits are returned.
+func (c APIClient) ListCommitLabelF(repoName string, to string, from string, number uint64, labelSelector string, f func(*pfs.CommitInfo) error) error {
 	req := &pfs.ListCommitRequest{
-		Repo:   NewRepo(repoName),
-		Number: number,
+		Repo:          NewRepo(repoName),
+		Number:        number,
+		LabelSelector: labelSelector,
 	}
 	if from != "" {
 		req.From = NewCommit(repoName, from)
@@ -305,6 +434,20 @@ func (c APIClient) ListCommitByRepo(repoName string) ([]*pfs.CommitInfo, error)
 	return c.ListCommit(repoName, "", "", 0)
 }
 
+// ListCommitByLabel lists the commits in a repo whose labels match
+// 'labelSelector'. The selector uses the syntax of Kubernetes label
+// selectors, e.g. "release=v2" or "release in (v1,v2),env!=dev".
+func (c APIClient) ListCommitByLabel(repoName string, labelSelector string) ([]*pfs.CommitInfo, error) {
+	var result []*pfs.CommitInfo
+	if err := c.ListCommitLabelF(repoName, "", "", 0, labelSelector, func(ci *pfs.CommitInfo) error {
+		result = append(result, ci)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // CreateBranch creates a new branch
 func (c APIClient) CreateBranch(repoName string, branch string, commit string, provenance []*pfs.Branch) error {
 	var head *pfs.Commit
@@ -347,12 +490,95 @@ func (c APIClient) ListBranch(repoName string) ([]*pfs.BranchInfo, error) {
 	return branchInfos.BranchInfo, nil
 }
 
+// ListCommitBranches returns info about the branches whose head is the
+// commit 'commitID' in the repo 'repoName'. If 'history' is set, it also
+// returns the branches that have the commit in their history: branches in the
+// same repo whose head descends from it, and branches in downstream repos
+// whose history includes a commit that's provenant on it.
+func (c APIClient) ListCommitBranches(repoName string, commitID string, history bool) ([]*pfs.BranchInfo, error) {
+	branchInfos, err := c.PfsAPIClient.ListCommitBranches(
+		c.Ctx(),
+		&pfs.ListCommitBranchesRequest{
+			Commit:  NewCommit(repoName, commitID),
+			History: history,
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return branchInfos.BranchInfo, nil
+}
+
+// ProvenanceGraph calls 'f' with each commit in the provenance graph of the
+// commit 'commitID' in the repo 'repoName', along with its edges to the
+// commits it's directly provenant on, starting with the commit itself. If
+// 'maxDepth' is positive, commits more than 'maxDepth' edges away from it
+// are left out.
+func (c APIClient) ProvenanceGraph(repoName string, commitID string, maxDepth int64, f func(*pfs.ProvenanceGraphNode) error) error {
+	nodes, err := c.PfsAPIClient.ProvenanceGraph(
+		c.Ctx(),
+		&pfs.ProvenanceGraphRequest{
+			Commit:   NewCommit(repoName, commitID),
+			MaxDepth: maxDepth,
+		},
+	)
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	for {
+		node, err := nodes.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		if err := f(node); err != nil {
+			if err == errutil.ErrBreak {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 // SetBranch sets a commit and its ancestors as a branch.
 // SetBranch is deprecated in favor of CommitBranch.
 func (c APIClient) SetBranch(repoName string, commit string, branch string) error {
 	return c.CreateBranch(repoName, branch, commit, nil)
 }
 
+// SetMaxHistoryDepth limits the history of a branch to its 'depth' most
+// recent commits. Older commits are deleted as new commits are finished on
+// the branch, unless they're still needed (e.g. because they're the head of
+// another branch or have downstream commits, such as a pipeline's output
+// commits). A depth of 0 removes the limit.
+func (c APIClient) SetMaxHistoryDepth(repoName string, branch string, depth int64) error {
+	_, err := c.PfsAPIClient.SetMaxHistoryDepth(
+		c.Ctx(),
+		&pfs.SetMaxHistoryDepthRequest{
+			Branch: NewBranch(repoName, branch),
+			Depth:  depth,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// SwapBranch atomically swaps the heads (and provenance) of 'branch' and
+// 'otherBranch', both in the repo 'repoName'. For example, swapping a
+// "staging" branch with a "production" branch promotes staging's head to
+// production, while keeping production's previous head in staging.
+// Downstream pipelines see both branches swapped at once.
+func (c APIClient) SwapBranch(repoName string, branch string, otherBranch string) error {
+	_, err := c.PfsAPIClient.SwapBranch(
+		c.Ctx(),
+		&pfs.SwapBranchRequest{
+			Branch: NewBranch(repoName, branch),
+			Other:  NewBranch(repoName, otherBranch),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // DeleteBranch deletes a branch, but leaves the commits themselves intact.
 // In other words, those commits can still be accessed via commit IDs and
 // other branches they happen to be on.
@@ -379,6 +605,20 @@ func (c APIClient) DeleteCommit(repoName string, commitID string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// SquashCommit deletes the ancestors of a commit, along with their downstream
+// commits, so that its history starts with it. Ancestors that are still
+// needed (because they or their downstream commits are open or are the head
+// of a branch) are kept. The content of the commit itself isn't changed.
+func (c APIClient) SquashCommit(repoName string, commitID string) error {
+	_, err := c.PfsAPIClient.SquashCommit(
+		c.Ctx(),
+		&pfs.SquashCommitRequest{
+			Commit: NewCommit(repoName, commitID),
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // FlushCommit returns an iterator that returns commits that have the
 // specified `commits` as provenance.  Note that the iterator can block if
 // jobs have not successfully completed. This in effect waits for all of the
@@ -525,9 +765,16 @@ func (c APIClient) PutObjectAsync(tags []*pfs.Tag) (*PutObjectWriteCloserAsync,
 }
 
 // PutObject puts a value into the object store and tags it with 0 or more tags.
-func (c APIClient) PutObject(_r io.Reader, tags ...string) (object *pfs.Object, _ int64, retErr error) {
+func (c APIClient) PutObject(r io.Reader, tags ...string) (*pfs.Object, int64, error) {
+	return c.PutObjectInScope("", r, tags...)
+}
+
+// PutObjectInScope is the same as PutObject except that the object is only
+// deduplicated with other objects put in 'scope' (see
+// pfs.PutObjectRequest.DedupScope).
+func (c APIClient) PutObjectInScope(scope string, _r io.Reader, tags ...string) (object *pfs.Object, _ int64, retErr error) {
 	r := grpcutil.ReaderWrapper{_r}
-	w, err := c.newPutObjectWriteCloser(tags...)
+	w, err := c.newPutObjectWriteCloser(scope, tags...)
 	if err != nil {
 		return nil, 0, grpcutil.ScrubGRPC(err)
 	}
@@ -552,9 +799,16 @@ func (c APIClient) PutObject(_r io.Reader, tags ...string) (object *pfs.Object,
 // PutObjectSplit is the same as PutObject except that the data is splitted
 // into several smaller objects.  This is primarily useful if you'd like to
 // be able to resume upload.
-func (c APIClient) PutObjectSplit(_r io.Reader) (objects []*pfs.Object, _ int64, retErr error) {
+func (c APIClient) PutObjectSplit(r io.Reader) ([]*pfs.Object, int64, error) {
+	return c.PutObjectSplitInScope("", r)
+}
+
+// PutObjectSplitInScope is the same as PutObjectSplit except that the objects
+// are only deduplicated with other objects put in 'scope' (see
+// pfs.PutObjectRequest.DedupScope).
+func (c APIClient) PutObjectSplitInScope(scope string, _r io.Reader) (objects []*pfs.Object, _ int64, retErr error) {
 	r := grpcutil.ReaderWrapper{_r}
-	w, err := c.newPutObjectSplitWriteCloser()
+	w, err := c.newPutObjectSplitWriteCloser(scope)
 	if err != nil {
 		return nil, 0, grpcutil.ScrubGRPC(err)
 	}
@@ -965,6 +1219,89 @@ func (c APIClient) PutFileOverwrite(repoName string, commitID string, path strin
 	return pfc.PutFileOverwrite(repoName, commitID, path, reader, overwriteIndex)
 }
 
+// DefaultPutFileChunkSize is the size of the chunks that PutFileParallel
+// uploads, unless it's given another size.
+const DefaultPutFileChunkSize = 64 * 1024 * 1024 // 64 MB
+
+// PutFileParallel writes the first 'size' bytes of 'reader' to a file,
+// uploading chunks of 'chunkSize' bytes (DefaultPutFileChunkSize, if it's 0)
+// over up to 'concurrency' streams at once (0 means no limit). This can be
+// much faster than PutFile for a single large file, as one stream rarely
+// saturates the network. The file's existing content is replaced if
+// 'overwrite' is set.
+func (c APIClient) PutFileParallel(repoName string, commitID string, path string, reader io.ReaderAt, size int64, chunkSize int64, concurrency int, overwrite bool) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultPutFileChunkSize
+	}
+	file := NewFile(repoName, commitID, path)
+	uploadID := uuid.NewWithoutDashes()
+	numChunks := (size + chunkSize - 1) / chunkSize
+	if numChunks == 0 {
+		numChunks = 1 // an empty file is one empty chunk
+	}
+	chunks := make([]*pfs.FileChunk, numChunks)
+	limiter := limit.New(concurrency)
+	var eg errgroup.Group
+	for i := int64(0); i < numChunks; i++ {
+		i, offset := i, i*chunkSize
+		n := chunkSize
+		if size-offset < n {
+			n = size - offset
+		}
+		limiter.Acquire()
+		eg.Go(func() error {
+			defer limiter.Release()
+			chunk, err := c.putFileChunk(file, uploadID, io.NewSectionReader(reader, offset, n))
+			if err != nil {
+				return err
+			}
+			chunk.OffsetBytes = offset
+			chunks[i] = chunk
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	_, err := c.PfsAPIClient.PutFileChunks(c.Ctx(), &pfs.PutFileChunksRequest{
+		File:      file,
+		Chunks:    chunks,
+		Overwrite: overwrite,
+		UploadId:  uploadID,
+	})
+	return grpcutil.ScrubGRPC(err)
+}
+
+// putFileChunk uploads the content of 'reader' as a chunk of 'file', as part
+// of the upload 'uploadID' (see PutFileParallel)
+func (c APIClient) putFileChunk(file *pfs.File, uploadID string, reader io.Reader) (*pfs.FileChunk, error) {
+	client, err := c.PfsAPIClient.PutFileChunk(c.Ctx())
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	request := &pfs.PutFileChunkRequest{File: file, UploadId: uploadID}
+	buf := grpcutil.GetBuffer()
+	defer grpcutil.PutBuffer(buf)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 || request.File != nil {
+			request.Value = buf[:n]
+			if err := client.Send(request); err != nil {
+				return nil, grpcutil.ScrubGRPC(err)
+			}
+			request.File = nil
+			request.UploadId = ""
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	chunk, err := client.CloseAndRecv()
+	return chunk, grpcutil.ScrubGRPC(err)
+}
+
 //PutFileSplit writes a file to PFS from a reader
 // delimiter is used to tell PFS how to break the input into blocks
 func (c APIClient) PutFileSplit(repoName string, commitID string, path string, delimiter pfs.Delimiter, targetFileDatums int64, targetFileBytes int64, headerRecords int64, overwrite bool, reader io.Reader) (_ int, retErr error) {
@@ -1218,6 +1555,54 @@ func (c APIClient) Walk(repoName string, commitID string, path string, f WalkFn)
 	}
 }
 
+// GetCommitTree calls 'f' with the path and hash of every file and directory
+// in a commit, in order of path. A directory's hash includes the hashes of its
+// children, so trees can be diffed by descending only into the directories
+// whose hashes differ, without reading any files' content.
+func (c APIClient) GetCommitTree(repoName string, commitID string, f func(*pfs.TreeNode) error) error {
+	nodes, err := c.PfsAPIClient.GetCommitTree(
+		c.Ctx(),
+		&pfs.GetCommitTreeRequest{Commit: NewCommit(repoName, commitID)})
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	for {
+		node, err := nodes.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		if err := f(node); err != nil {
+			if err == errutil.ErrBreak {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// GetManifest writes the manifest of the files under 'path' (or all files,
+// if it's empty) in a commit to 'writer', as newline-delimited JSON: a
+// pfs.ManifestEntry with the path, size and hashes of each file, in order of
+// path. Copies of the files kept outside of pachyderm can be verified by
+// comparing their sha256 digests with the manifest's.
+func (c APIClient) GetManifest(repoName string, commitID string, path string, writer io.Writer) error {
+	manifestClient, err := c.PfsAPIClient.GetManifest(
+		c.Ctx(),
+		&pfs.GetManifestRequest{
+			Commit: NewCommit(repoName, commitID),
+			Path:   path,
+		})
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	if err := grpcutil.WriteFromStreamingBytesClient(manifestClient, writer); err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	return nil
+}
+
 // DeleteFile deletes a file from a Commit.
 // DeleteFile leaves a tombstone in the Commit, assuming the file isn't written
 // to later attempting to get the file from the finished commit will result in
@@ -1233,6 +1618,185 @@ func (c APIClient) DeleteFile(repoName string, commitID string, path string) err
 	return err
 }
 
+// SyncDir makes the contents of a branch exactly match the local directory
+// 'localPath', in a single commit: files that differ from the branch's head
+// are uploaded, and files and directories that aren't in 'localPath' are
+// deleted. Files are compared by hashing them locally and looking up their
+// hashes in PFS, so files that are unchanged aren't uploaded again. (A file
+// that was written to PFS in several pieces, e.g. by appending to it, doesn't
+// hash the same as its local copy, and is uploaded again.) Only regular files
+// are synced. If nothing changed, no commit is made, and the branch's head is
+// returned. If the sync fails, its commit is deleted.
+func (c APIClient) SyncDir(repoName string, branch string, localPath string) (_ *pfs.Commit, retErr error) {
+	// Read the local directory
+	localFiles := make(map[string]string)   // PFS path -> local path
+	localDirs := map[string]bool{"/": true} // PFS paths
+	if err := filepath.Walk(localPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(localPath, filePath)
+		if err != nil {
+			return err
+		}
+		pfsPath := path.Join("/", filepath.ToSlash(relPath))
+		if info.IsDir() {
+			localDirs[pfsPath] = true
+		} else if info.Mode().IsRegular() {
+			localFiles[pfsPath] = filePath
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Compare it with the branch's head, whose nodes are walked in order of
+	// path, so directories come before their children
+	branchInfos, err := c.ListBranch(repoName)
+	if err != nil {
+		return nil, err
+	}
+	var head *pfs.Commit
+	for _, branchInfo := range branchInfos {
+		if branchInfo.Branch.Name == branch {
+			head = branchInfo.Head
+		}
+	}
+	var deletes []string
+	unchanged := make(map[string]bool)
+	if head != nil {
+		var deleted string // the last directory that's deleted
+		if err := c.Walk(repoName, head.ID, "/", func(fileInfo *pfs.FileInfo) error {
+			p := fileInfo.File.Path
+			if p == "/" || (deleted != "" && strings.HasPrefix(p, deleted+"/")) {
+				return nil
+			}
+			if fileInfo.FileType == pfs.FileType_DIR {
+				if !localDirs[p] {
+					deletes = append(deletes, p)
+					deleted = p
+				}
+				return nil
+			}
+			localFile, ok := localFiles[p]
+			if !ok {
+				deletes = append(deletes, p)
+				return nil
+			}
+			same, err := localFileMatches(repoName, localFile, fileInfo)
+			if err != nil {
+				return err
+			}
+			unchanged[p] = same
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	var puts []string
+	for p := range localFiles {
+		if !unchanged[p] {
+			puts = append(puts, p)
+		}
+	}
+	if len(puts) == 0 && len(deletes) == 0 {
+		return head, nil
+	}
+	sort.Strings(puts)
+
+	// Apply the differences in one commit
+	commit, err := c.StartCommit(repoName, branch)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := c.DeleteCommit(repoName, commit.ID); err != nil {
+				retErr = fmt.Errorf("%v (and could not delete commit %s: %v)", retErr, commit.ID, err)
+			}
+		}
+	}()
+	for _, p := range deletes {
+		if err := c.DeleteFile(repoName, commit.ID, p); err != nil {
+			return nil, grpcutil.ScrubGRPC(err)
+		}
+	}
+	pfc, err := c.NewPutFileClient()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range puts {
+		if err := func() error {
+			f, err := os.Open(localFiles[p])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = pfc.PutFileOverwrite(repoName, commit.ID, p, f, 0)
+			return err
+		}(); err != nil {
+			pfc.Close()
+			return nil, err
+		}
+	}
+	if err := pfc.Close(); err != nil {
+		return nil, err
+	}
+	if err := c.FinishCommit(repoName, commit.ID); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+// localFileMatches returns true if the local file 'localFile' has the same
+// content as the file 'fileInfo' in the repo 'repoName', as far as can be
+// told from their sizes and hashes. A file's hash in PFS is a hash of the
+// hashes of its objects (see hashtree.HashFileNode), and a file put in one
+// piece has an object per pfs.ChunkSize bytes of its content, named by the
+// hash of the content in the dedup scope that it was put in (see
+// pfs.ScopeHash).
+func localFileMatches(repoName string, localFile string, fileInfo *pfs.FileInfo) (bool, error) {
+	f, err := os.Open(localFile)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if uint64(info.Size()) != fileInfo.SizeBytes {
+		return false, nil
+	}
+	scopes := []string{"", pfs.RepoScope(repoName)}
+	fileHashes := make([]hash.Hash, len(scopes))
+	for i := range fileHashes {
+		fileHashes[i] = sha256.New()
+	}
+	for {
+		chunkHash := pfs.NewHash()
+		n, err := io.CopyN(chunkHash, f, pfs.ChunkSize)
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		if n == 0 && info.Size() > 0 {
+			break
+		}
+		for i, scope := range scopes {
+			fileHashes[i].Write([]byte(pfs.EncodeHash(pfs.ScopeHash(scope, chunkHash.Sum(nil)))))
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	for _, fileHash := range fileHashes {
+		if bytes.Equal(fileHash.Sum(nil), fileInfo.Hash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 type putFileWriteCloser struct {
 	request *pfs.PutFileRequest
 	sent    bool
@@ -1306,9 +1870,12 @@ type putObjectWriteCloser struct {
 	request *pfs.PutObjectRequest
 	client  pfs.ObjectAPI_PutObjectClient
 	object  *pfs.Object
+	// sent is true once 'request' has been sent, so that the tags and dedup
+	// scope of empty objects are still sent
+	sent bool
 }
 
-func (c APIClient) newPutObjectWriteCloser(tags ...string) (*putObjectWriteCloser, error) {
+func (c APIClient) newPutObjectWriteCloser(scope string, tags ...string) (*putObjectWriteCloser, error) {
 	client, err := c.ObjectAPIClient.PutObject(c.Ctx())
 	if err != nil {
 		return nil, grpcutil.ScrubGRPC(err)
@@ -1319,7 +1886,8 @@ func (c APIClient) newPutObjectWriteCloser(tags ...string) (*putObjectWriteClose
 	}
 	return &putObjectWriteCloser{
 		request: &pfs.PutObjectRequest{
-			Tags: _tags,
+			Tags:       _tags,
+			DedupScope: scope,
 		},
 		client: client,
 	}, nil
@@ -1330,11 +1898,17 @@ func (w *putObjectWriteCloser) Write(p []byte) (int, error) {
 	if err := w.client.Send(w.request); err != nil {
 		return 0, grpcutil.ScrubGRPC(err)
 	}
+	w.sent = true
 	w.request.Tags = nil
 	return len(p), nil
 }
 
 func (w *putObjectWriteCloser) Close() error {
+	if !w.sent {
+		if err := w.client.Send(w.request); err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+	}
 	var err error
 	w.object, err = w.client.CloseAndRecv()
 	return grpcutil.ScrubGRPC(err)
@@ -1429,13 +2003,13 @@ type putObjectSplitWriteCloser struct {
 	objects []*pfs.Object
 }
 
-func (c APIClient) newPutObjectSplitWriteCloser() (*putObjectSplitWriteCloser, error) {
+func (c APIClient) newPutObjectSplitWriteCloser(scope string) (*putObjectSplitWriteCloser, error) {
 	client, err := c.ObjectAPIClient.PutObjectSplit(c.Ctx())
 	if err != nil {
 		return nil, grpcutil.ScrubGRPC(err)
 	}
 	return &putObjectSplitWriteCloser{
-		request: &pfs.PutObjectRequest{},
+		request: &pfs.PutObjectRequest{DedupScope: scope},
 		client:  client,
 	}, nil
 }
@@ -18,11 +18,54 @@ func (c *Commit) FullID() string {
 	return fmt.Sprintf("%s/%s", c.Repo.Name, c.ID)
 }
 
+// ManifestEntry is a line of the manifest that GetManifest returns, which
+// describes a file in a commit. SHA256 is the hex-encoded sha256 digest of the
+// file's content, which can be checked against copies of the file kept
+// outside of pachyderm (e.g. with sha256sum). NodeHash is the hash of the
+// file's node in the commit's hash tree (the same as its FileInfo's Hash),
+// encoded with EncodeHash: a sha256 digest of the hashes of the objects that
+// hold the file's content, so it depends on how the content was split into
+// objects when it was written, and the same content may have different node
+// hashes in different commits.
+type ManifestEntry struct {
+	Path      string `json:"path"`
+	SizeBytes uint64 `json:"size_bytes"`
+	SHA256    string `json:"sha256"`
+	NodeHash  string `json:"node_hash"`
+}
+
 // NewHash returns a hash that PFS uses internally to compute checksums.
 func NewHash() hash.Hash {
 	return sha512.New()
 }
 
+// ScopeHash returns the hash of an object whose content has the hash 'hash',
+// and that was put in the dedup scope 'scope' (see
+// PutObjectRequest.DedupScope). Objects in the global scope ("") are named by
+// the hash of their content.
+func ScopeHash(scope string, hash []byte) []byte {
+	if scope == "" {
+		return hash
+	}
+	h := NewHash()
+	h.Write([]byte(scope))
+	h.Write([]byte{0})
+	h.Write(hash)
+	return h.Sum(nil)
+}
+
+// RepoScope returns the dedup scope of the content of files in 'repo', when
+// pachd only deduplicates content within each repo.
+func RepoScope(repo string) string {
+	return "repo/" + repo
+}
+
+// HasContent returns true if 'object' holds content whose hash is 'hash',
+// either in the global dedup scope or in the dedup scope of 'repo'.
+func HasContent(object *Object, repo string, hash []byte) bool {
+	return object.Hash == EncodeHash(hash) || object.Hash == EncodeHash(ScopeHash(RepoScope(repo), hash))
+}
+
 // EncodeHash encodes a hash into a readable format.
 func EncodeHash(bytes []byte) string {
 	return hex.EncodeToString(bytes)
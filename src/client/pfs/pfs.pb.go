@@ -51,7 +51,7 @@ func (x FileType) String() string {
 	return proto.EnumName(FileType_name, int32(x))
 }
 func (FileType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{0}
+	return fileDescriptor_pfs_daa13a627571e170, []int{0}
 }
 
 // CommitState describes the states a commit can be in.
@@ -79,7 +79,7 @@ func (x CommitState) String() string {
 	return proto.EnumName(CommitState_name, int32(x))
 }
 func (CommitState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{1}
+	return fileDescriptor_pfs_daa13a627571e170, []int{1}
 }
 
 type Delimiter int32
@@ -111,7 +111,7 @@ func (x Delimiter) String() string {
 	return proto.EnumName(Delimiter_name, int32(x))
 }
 func (Delimiter) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{2}
+	return fileDescriptor_pfs_daa13a627571e170, []int{2}
 }
 
 type Repo struct {
@@ -125,7 +125,7 @@ func (m *Repo) Reset()         { *m = Repo{} }
 func (m *Repo) String() string { return proto.CompactTextString(m) }
 func (*Repo) ProtoMessage()    {}
 func (*Repo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{0}
+	return fileDescriptor_pfs_daa13a627571e170, []int{0}
 }
 func (m *Repo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -173,7 +173,7 @@ func (m *Branch) Reset()         { *m = Branch{} }
 func (m *Branch) String() string { return proto.CompactTextString(m) }
 func (*Branch) ProtoMessage()    {}
 func (*Branch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{1}
+	return fileDescriptor_pfs_daa13a627571e170, []int{1}
 }
 func (m *Branch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -222,6 +222,10 @@ type BranchInfo struct {
 	Provenance       []*Branch `protobuf:"bytes,3,rep,name=provenance,proto3" json:"provenance,omitempty"`
 	Subvenance       []*Branch `protobuf:"bytes,5,rep,name=subvenance,proto3" json:"subvenance,omitempty"`
 	DirectProvenance []*Branch `protobuf:"bytes,6,rep,name=direct_provenance,json=directProvenance,proto3" json:"direct_provenance,omitempty"`
+	// max_history_depth is the number of commits in the branch's history that
+	// are kept. Older commits are deleted automatically, unless they're still
+	// needed. If it's 0, no commits are deleted.
+	MaxHistoryDepth int64 `protobuf:"varint,7,opt,name=max_history_depth,json=maxHistoryDepth,proto3" json:"max_history_depth,omitempty"`
 	// Deprecated field left for backward compatibility.
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -233,7 +237,7 @@ func (m *BranchInfo) Reset()         { *m = BranchInfo{} }
 func (m *BranchInfo) String() string { return proto.CompactTextString(m) }
 func (*BranchInfo) ProtoMessage()    {}
 func (*BranchInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{2}
+	return fileDescriptor_pfs_daa13a627571e170, []int{2}
 }
 func (m *BranchInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -297,6 +301,13 @@ func (m *BranchInfo) GetDirectProvenance() []*Branch {
 	return nil
 }
 
+func (m *BranchInfo) GetMaxHistoryDepth() int64 {
+	if m != nil {
+		return m.MaxHistoryDepth
+	}
+	return 0
+}
+
 func (m *BranchInfo) GetName() string {
 	if m != nil {
 		return m.Name
@@ -315,7 +326,7 @@ func (m *BranchInfos) Reset()         { *m = BranchInfos{} }
 func (m *BranchInfos) String() string { return proto.CompactTextString(m) }
 func (*BranchInfos) ProtoMessage()    {}
 func (*BranchInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{3}
+	return fileDescriptor_pfs_daa13a627571e170, []int{3}
 }
 func (m *BranchInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -363,7 +374,7 @@ func (m *File) Reset()         { *m = File{} }
 func (m *File) String() string { return proto.CompactTextString(m) }
 func (*File) ProtoMessage()    {}
 func (*File) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{4}
+	return fileDescriptor_pfs_daa13a627571e170, []int{4}
 }
 func (m *File) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -417,7 +428,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{5}
+	return fileDescriptor_pfs_daa13a627571e170, []int{5}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -464,7 +475,7 @@ func (m *Object) Reset()         { *m = Object{} }
 func (m *Object) String() string { return proto.CompactTextString(m) }
 func (*Object) ProtoMessage()    {}
 func (*Object) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{6}
+	return fileDescriptor_pfs_daa13a627571e170, []int{6}
 }
 func (m *Object) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -511,7 +522,7 @@ func (m *Tag) Reset()         { *m = Tag{} }
 func (m *Tag) String() string { return proto.CompactTextString(m) }
 func (*Tag) ProtoMessage()    {}
 func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{7}
+	return fileDescriptor_pfs_daa13a627571e170, []int{7}
 }
 func (m *Tag) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -554,6 +565,17 @@ type RepoInfo struct {
 	SizeBytes   uint64           `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	Description string           `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	Branches    []*Branch        `protobuf:"bytes,7,rep,name=branches,proto3" json:"branches,omitempty"`
+	// recommended_glob is the glob that pipelines reading from this repo should
+	// usually use. It's suggested to users who create a pipeline with an input
+	// from this repo that doesn't specify a glob.
+	RecommendedGlob string `protobuf:"bytes,8,opt,name=recommended_glob,json=recommendedGlob,proto3" json:"recommended_glob,omitempty"`
+	// max_commit_files and max_commit_bytes, if positive, are the most files
+	// and bytes that a commit in this repo may add or change, relative to its
+	// parent: commits that add or change more can't be finished. If they're 0,
+	// the cluster's limits apply, and if they're negative, the repo's commits
+	// aren't limited.
+	MaxCommitFiles int64 `protobuf:"varint,9,opt,name=max_commit_files,json=maxCommitFiles,proto3" json:"max_commit_files,omitempty"`
+	MaxCommitBytes int64 `protobuf:"varint,10,opt,name=max_commit_bytes,json=maxCommitBytes,proto3" json:"max_commit_bytes,omitempty"`
 	// Set by ListRepo and InspectRepo if Pachyderm's auth system is active, but
 	// not stored in etcd. To set a user's auth scope for a repo, use the
 	// Pachyderm Auth API (in src/client/auth/auth.proto)
@@ -567,7 +589,7 @@ func (m *RepoInfo) Reset()         { *m = RepoInfo{} }
 func (m *RepoInfo) String() string { return proto.CompactTextString(m) }
 func (*RepoInfo) ProtoMessage()    {}
 func (*RepoInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{8}
+	return fileDescriptor_pfs_daa13a627571e170, []int{8}
 }
 func (m *RepoInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -631,6 +653,27 @@ func (m *RepoInfo) GetBranches() []*Branch {
 	return nil
 }
 
+func (m *RepoInfo) GetRecommendedGlob() string {
+	if m != nil {
+		return m.RecommendedGlob
+	}
+	return ""
+}
+
+func (m *RepoInfo) GetMaxCommitFiles() int64 {
+	if m != nil {
+		return m.MaxCommitFiles
+	}
+	return 0
+}
+
+func (m *RepoInfo) GetMaxCommitBytes() int64 {
+	if m != nil {
+		return m.MaxCommitBytes
+	}
+	return 0
+}
+
 func (m *RepoInfo) GetAuthInfo() *RepoAuthInfo {
 	if m != nil {
 		return m.AuthInfo
@@ -655,7 +698,7 @@ func (m *RepoAuthInfo) Reset()         { *m = RepoAuthInfo{} }
 func (m *RepoAuthInfo) String() string { return proto.CompactTextString(m) }
 func (*RepoAuthInfo) ProtoMessage()    {}
 func (*RepoAuthInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{9}
+	return fileDescriptor_pfs_daa13a627571e170, []int{9}
 }
 func (m *RepoAuthInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -706,7 +749,7 @@ func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{10}
+	return fileDescriptor_pfs_daa13a627571e170, []int{10}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -763,7 +806,7 @@ func (m *CommitRange) Reset()         { *m = CommitRange{} }
 func (m *CommitRange) String() string { return proto.CompactTextString(m) }
 func (*CommitRange) ProtoMessage()    {}
 func (*CommitRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{11}
+	return fileDescriptor_pfs_daa13a627571e170, []int{11}
 }
 func (m *CommitRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -831,19 +874,22 @@ type CommitInfo struct {
 	// If this is nil, then the commit is either open (in which case 'finished'
 	// will also be nil) or is the output commit of a failed job (in which case
 	// 'finished' will have a value -- the end time of the job)
-	Tree                 *Object   `protobuf:"bytes,7,opt,name=tree,proto3" json:"tree,omitempty"`
-	Trees                []*Object `protobuf:"bytes,13,rep,name=trees,proto3" json:"trees,omitempty"`
-	Datums               *Object   `protobuf:"bytes,14,opt,name=datums,proto3" json:"datums,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Tree   *Object   `protobuf:"bytes,7,opt,name=tree,proto3" json:"tree,omitempty"`
+	Trees  []*Object `protobuf:"bytes,13,rep,name=trees,proto3" json:"trees,omitempty"`
+	Datums *Object   `protobuf:"bytes,14,opt,name=datums,proto3" json:"datums,omitempty"`
+	// labels are user-provided key/value pairs that can be used to select
+	// commits in ListCommit
+	Labels               map[string]string `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CommitInfo) Reset()         { *m = CommitInfo{} }
 func (m *CommitInfo) String() string { return proto.CompactTextString(m) }
 func (*CommitInfo) ProtoMessage()    {}
 func (*CommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{12}
+	return fileDescriptor_pfs_daa13a627571e170, []int{12}
 }
 func (m *CommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -970,27 +1016,44 @@ func (m *CommitInfo) GetDatums() *Object {
 	return nil
 }
 
+func (m *CommitInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type FileInfo struct {
-	File      *File            `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	FileType  FileType         `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=pfs.FileType" json:"file_type,omitempty"`
-	SizeBytes uint64           `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	File      *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	FileType  FileType `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=pfs.FileType" json:"file_type,omitempty"`
+	SizeBytes uint64   `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Committed is when the file's commit was finished. It's unset if the
+	// commit (which must be in an input repo) is still open, in which case the
+	// file reflects the writes to the commit that have completed so far (i.e.
+	// PutFile calls that have returned), and may still change.
 	Committed *types.Timestamp `protobuf:"bytes,10,opt,name=committed,proto3" json:"committed,omitempty"`
 	// the base names (i.e. just the filenames, not the full paths) of
 	// the children
-	Children             []string    `protobuf:"bytes,6,rep,name=children,proto3" json:"children,omitempty"`
-	Objects              []*Object   `protobuf:"bytes,8,rep,name=objects,proto3" json:"objects,omitempty"`
-	BlockRefs            []*BlockRef `protobuf:"bytes,9,rep,name=blockRefs,proto3" json:"blockRefs,omitempty"`
-	Hash                 []byte      `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Children  []string    `protobuf:"bytes,6,rep,name=children,proto3" json:"children,omitempty"`
+	Objects   []*Object   `protobuf:"bytes,8,rep,name=objects,proto3" json:"objects,omitempty"`
+	BlockRefs []*BlockRef `protobuf:"bytes,9,rep,name=blockRefs,proto3" json:"blockRefs,omitempty"`
+	Hash      []byte      `protobuf:"bytes,7,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Mode is the file's permission bits (e.g. 0755), which are applied when
+	// it's downloaded. 0 means the default.
+	Mode uint32 `protobuf:"varint,11,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Owner, if set, is the owner that the file is given when it's downloaded
+	// by a process that's allowed to set it.
+	Owner                *FileOwner `protobuf:"bytes,12,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *FileInfo) Reset()         { *m = FileInfo{} }
 func (m *FileInfo) String() string { return proto.CompactTextString(m) }
 func (*FileInfo) ProtoMessage()    {}
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{13}
+	return fileDescriptor_pfs_daa13a627571e170, []int{13}
 }
 func (m *FileInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1075,6 +1138,76 @@ func (m *FileInfo) GetHash() []byte {
 	return nil
 }
 
+func (m *FileInfo) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileInfo) GetOwner() *FileOwner {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+// FileOwner is the owner of a file, by its numeric user and group IDs
+type FileOwner struct {
+	Uid                  uint32   `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid                  uint32   `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileOwner) Reset()         { *m = FileOwner{} }
+func (m *FileOwner) String() string { return proto.CompactTextString(m) }
+func (*FileOwner) ProtoMessage()    {}
+func (*FileOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{14}
+}
+func (m *FileOwner) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FileOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FileOwner.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *FileOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileOwner.Merge(dst, src)
+}
+func (m *FileOwner) XXX_Size() int {
+	return m.Size()
+}
+func (m *FileOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileOwner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileOwner proto.InternalMessageInfo
+
+func (m *FileOwner) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *FileOwner) GetGid() uint32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
 type ByteRange struct {
 	Lower                uint64   `protobuf:"varint,1,opt,name=lower,proto3" json:"lower,omitempty"`
 	Upper                uint64   `protobuf:"varint,2,opt,name=upper,proto3" json:"upper,omitempty"`
@@ -1087,7 +1220,7 @@ func (m *ByteRange) Reset()         { *m = ByteRange{} }
 func (m *ByteRange) String() string { return proto.CompactTextString(m) }
 func (*ByteRange) ProtoMessage()    {}
 func (*ByteRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{14}
+	return fileDescriptor_pfs_daa13a627571e170, []int{15}
 }
 func (m *ByteRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1142,7 +1275,7 @@ func (m *BlockRef) Reset()         { *m = BlockRef{} }
 func (m *BlockRef) String() string { return proto.CompactTextString(m) }
 func (*BlockRef) ProtoMessage()    {}
 func (*BlockRef) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{15}
+	return fileDescriptor_pfs_daa13a627571e170, []int{16}
 }
 func (m *BlockRef) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1197,7 +1330,7 @@ func (m *ObjectInfo) Reset()         { *m = ObjectInfo{} }
 func (m *ObjectInfo) String() string { return proto.CompactTextString(m) }
 func (*ObjectInfo) ProtoMessage()    {}
 func (*ObjectInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{16}
+	return fileDescriptor_pfs_daa13a627571e170, []int{17}
 }
 func (m *ObjectInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1241,9 +1374,14 @@ func (m *ObjectInfo) GetBlockRef() *BlockRef {
 }
 
 type CreateRepoRequest struct {
-	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Update               bool     `protobuf:"varint,4,opt,name=update,proto3" json:"update,omitempty"`
+	Repo            *Repo  `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Description     string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Update          bool   `protobuf:"varint,4,opt,name=update,proto3" json:"update,omitempty"`
+	RecommendedGlob string `protobuf:"bytes,5,opt,name=recommended_glob,json=recommendedGlob,proto3" json:"recommended_glob,omitempty"`
+	// See RepoInfo.max_commit_files and RepoInfo.max_commit_bytes. If 'update'
+	// is set, 0 keeps the repo's existing limits.
+	MaxCommitFiles       int64    `protobuf:"varint,6,opt,name=max_commit_files,json=maxCommitFiles,proto3" json:"max_commit_files,omitempty"`
+	MaxCommitBytes       int64    `protobuf:"varint,7,opt,name=max_commit_bytes,json=maxCommitBytes,proto3" json:"max_commit_bytes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1253,7 +1391,7 @@ func (m *CreateRepoRequest) Reset()         { *m = CreateRepoRequest{} }
 func (m *CreateRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRepoRequest) ProtoMessage()    {}
 func (*CreateRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{17}
+	return fileDescriptor_pfs_daa13a627571e170, []int{18}
 }
 func (m *CreateRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1303,6 +1441,27 @@ func (m *CreateRepoRequest) GetUpdate() bool {
 	return false
 }
 
+func (m *CreateRepoRequest) GetRecommendedGlob() string {
+	if m != nil {
+		return m.RecommendedGlob
+	}
+	return ""
+}
+
+func (m *CreateRepoRequest) GetMaxCommitFiles() int64 {
+	if m != nil {
+		return m.MaxCommitFiles
+	}
+	return 0
+}
+
+func (m *CreateRepoRequest) GetMaxCommitBytes() int64 {
+	if m != nil {
+		return m.MaxCommitBytes
+	}
+	return 0
+}
+
 type InspectRepoRequest struct {
 	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1314,7 +1473,7 @@ func (m *InspectRepoRequest) Reset()         { *m = InspectRepoRequest{} }
 func (m *InspectRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectRepoRequest) ProtoMessage()    {}
 func (*InspectRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{18}
+	return fileDescriptor_pfs_daa13a627571e170, []int{19}
 }
 func (m *InspectRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1350,6 +1509,128 @@ func (m *InspectRepoRequest) GetRepo() *Repo {
 	return nil
 }
 
+// InspectReposRequest inspects up to 1000 repos in one call.
+type InspectReposRequest struct {
+	Repos                []*Repo  `protobuf:"bytes,1,rep,name=repos,proto3" json:"repos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectReposRequest) Reset()         { *m = InspectReposRequest{} }
+func (m *InspectReposRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectReposRequest) ProtoMessage()    {}
+func (*InspectReposRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{20}
+}
+func (m *InspectReposRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InspectReposRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectReposRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *InspectReposRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectReposRequest.Merge(dst, src)
+}
+func (m *InspectReposRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InspectReposRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectReposRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectReposRequest proto.InternalMessageInfo
+
+func (m *InspectReposRequest) GetRepos() []*Repo {
+	if m != nil {
+		return m.Repos
+	}
+	return nil
+}
+
+// InspectRepoResult is the result of inspecting one of the repos in an
+// InspectReposRequest. If the repo couldn't be inspected, 'info' is unset and
+// 'error' says why, and 'not_found' is set if that's because it doesn't exist.
+type InspectRepoResult struct {
+	Repo                 *Repo     `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Info                 *RepoInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	NotFound             bool      `protobuf:"varint,3,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	Error                string    `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *InspectRepoResult) Reset()         { *m = InspectRepoResult{} }
+func (m *InspectRepoResult) String() string { return proto.CompactTextString(m) }
+func (*InspectRepoResult) ProtoMessage()    {}
+func (*InspectRepoResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{21}
+}
+func (m *InspectRepoResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InspectRepoResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectRepoResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *InspectRepoResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectRepoResult.Merge(dst, src)
+}
+func (m *InspectRepoResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *InspectRepoResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectRepoResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectRepoResult proto.InternalMessageInfo
+
+func (m *InspectRepoResult) GetRepo() *Repo {
+	if m != nil {
+		return m.Repo
+	}
+	return nil
+}
+
+func (m *InspectRepoResult) GetInfo() *RepoInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *InspectRepoResult) GetNotFound() bool {
+	if m != nil {
+		return m.NotFound
+	}
+	return false
+}
+
+func (m *InspectRepoResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type ListRepoRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1360,7 +1641,7 @@ func (m *ListRepoRequest) Reset()         { *m = ListRepoRequest{} }
 func (m *ListRepoRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRepoRequest) ProtoMessage()    {}
 func (*ListRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{19}
+	return fileDescriptor_pfs_daa13a627571e170, []int{22}
 }
 func (m *ListRepoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1400,7 +1681,7 @@ func (m *ListRepoResponse) Reset()         { *m = ListRepoResponse{} }
 func (m *ListRepoResponse) String() string { return proto.CompactTextString(m) }
 func (*ListRepoResponse) ProtoMessage()    {}
 func (*ListRepoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{20}
+	return fileDescriptor_pfs_daa13a627571e170, []int{23}
 }
 func (m *ListRepoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1436,27 +1717,27 @@ func (m *ListRepoResponse) GetRepoInfo() []*RepoInfo {
 	return nil
 }
 
-type DeleteRepoRequest struct {
+type DiskUsageRequest struct {
+	// If unset, the disk usage of every repo that the caller can read is
+	// returned. Otherwise, the caller must be able to read 'repo'.
 	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
-	All                  bool     `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteRepoRequest) Reset()         { *m = DeleteRepoRequest{} }
-func (m *DeleteRepoRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRepoRequest) ProtoMessage()    {}
-func (*DeleteRepoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{21}
+func (m *DiskUsageRequest) Reset()         { *m = DiskUsageRequest{} }
+func (m *DiskUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*DiskUsageRequest) ProtoMessage()    {}
+func (*DiskUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{24}
 }
-func (m *DeleteRepoRequest) XXX_Unmarshal(b []byte) error {
+func (m *DiskUsageRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteRepoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DiskUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteRepoRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DiskUsageRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1466,64 +1747,57 @@ func (m *DeleteRepoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *DeleteRepoRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRepoRequest.Merge(dst, src)
+func (dst *DiskUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskUsageRequest.Merge(dst, src)
 }
-func (m *DeleteRepoRequest) XXX_Size() int {
+func (m *DiskUsageRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteRepoRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRepoRequest.DiscardUnknown(m)
+func (m *DiskUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskUsageRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteRepoRequest proto.InternalMessageInfo
+var xxx_messageInfo_DiskUsageRequest proto.InternalMessageInfo
 
-func (m *DeleteRepoRequest) GetRepo() *Repo {
+func (m *DiskUsageRequest) GetRepo() *Repo {
 	if m != nil {
 		return m.Repo
 	}
 	return nil
 }
 
-func (m *DeleteRepoRequest) GetForce() bool {
-	if m != nil {
-		return m.Force
-	}
-	return false
-}
-
-func (m *DeleteRepoRequest) GetAll() bool {
-	if m != nil {
-		return m.All
-	}
-	return false
-}
-
-type StartCommitRequest struct {
-	// Parent.ID may be empty in which case the commit that Branch points to will be used as the parent.
-	// If branch is empty, or if branch does not exist, the commit will have no parent.
-	Parent *Commit `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
-	// description is a user-provided string describing this commit
-	Description          string    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Branch               string    `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
-	Provenance           []*Commit `protobuf:"bytes,2,rep,name=provenance,proto3" json:"provenance,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+// RepoDiskUsage describes how much storage a repo uses. Files' data is stored
+// once per distinct content, and may be shared between commits and between
+// repos, so it's reported in three ways.
+type RepoDiskUsage struct {
+	Repo *Repo `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// logical_bytes is the total size of the repo's finished commits, counting
+	// each file in each commit in full.
+	LogicalBytes uint64 `protobuf:"varint,2,opt,name=logical_bytes,json=logicalBytes,proto3" json:"logical_bytes,omitempty"`
+	// physical_bytes is the size of the distinct data that the repo's commits
+	// reference, counting data that's shared by several files or commits in
+	// the repo once.
+	PhysicalBytes uint64 `protobuf:"varint,3,opt,name=physical_bytes,json=physicalBytes,proto3" json:"physical_bytes,omitempty"`
+	// exclusive_bytes is the part of physical_bytes that no other repo
+	// references, i.e. roughly the storage that deleting the repo would free.
+	ExclusiveBytes       uint64   `protobuf:"varint,4,opt,name=exclusive_bytes,json=exclusiveBytes,proto3" json:"exclusive_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StartCommitRequest) Reset()         { *m = StartCommitRequest{} }
-func (m *StartCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*StartCommitRequest) ProtoMessage()    {}
-func (*StartCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{22}
+func (m *RepoDiskUsage) Reset()         { *m = RepoDiskUsage{} }
+func (m *RepoDiskUsage) String() string { return proto.CompactTextString(m) }
+func (*RepoDiskUsage) ProtoMessage()    {}
+func (*RepoDiskUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{25}
 }
-func (m *StartCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *RepoDiskUsage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *StartCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RepoDiskUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_StartCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RepoDiskUsage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1533,70 +1807,65 @@ func (m *StartCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *StartCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartCommitRequest.Merge(dst, src)
+func (dst *RepoDiskUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepoDiskUsage.Merge(dst, src)
 }
-func (m *StartCommitRequest) XXX_Size() int {
+func (m *RepoDiskUsage) XXX_Size() int {
 	return m.Size()
 }
-func (m *StartCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartCommitRequest.DiscardUnknown(m)
+func (m *RepoDiskUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepoDiskUsage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StartCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_RepoDiskUsage proto.InternalMessageInfo
 
-func (m *StartCommitRequest) GetParent() *Commit {
+func (m *RepoDiskUsage) GetRepo() *Repo {
 	if m != nil {
-		return m.Parent
+		return m.Repo
 	}
 	return nil
 }
 
-func (m *StartCommitRequest) GetDescription() string {
+func (m *RepoDiskUsage) GetLogicalBytes() uint64 {
 	if m != nil {
-		return m.Description
+		return m.LogicalBytes
 	}
-	return ""
+	return 0
 }
 
-func (m *StartCommitRequest) GetBranch() string {
+func (m *RepoDiskUsage) GetPhysicalBytes() uint64 {
 	if m != nil {
-		return m.Branch
+		return m.PhysicalBytes
 	}
-	return ""
+	return 0
 }
 
-func (m *StartCommitRequest) GetProvenance() []*Commit {
+func (m *RepoDiskUsage) GetExclusiveBytes() uint64 {
 	if m != nil {
-		return m.Provenance
+		return m.ExclusiveBytes
 	}
-	return nil
+	return 0
 }
 
-type BuildCommitRequest struct {
-	Parent     *Commit   `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
-	Branch     string    `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
-	Provenance []*Commit `protobuf:"bytes,2,rep,name=provenance,proto3" json:"provenance,omitempty"`
-	Tree       *Object   `protobuf:"bytes,3,opt,name=tree,proto3" json:"tree,omitempty"`
-	// ID sets the ID of the created commit.
-	ID                   string   `protobuf:"bytes,5,opt,name=ID,proto3" json:"ID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type DiskUsageResponse struct {
+	Repos                []*RepoDiskUsage `protobuf:"bytes,1,rep,name=repos,proto3" json:"repos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *BuildCommitRequest) Reset()         { *m = BuildCommitRequest{} }
-func (m *BuildCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*BuildCommitRequest) ProtoMessage()    {}
-func (*BuildCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{23}
+func (m *DiskUsageResponse) Reset()         { *m = DiskUsageResponse{} }
+func (m *DiskUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*DiskUsageResponse) ProtoMessage()    {}
+func (*DiskUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{26}
 }
-func (m *BuildCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *DiskUsageResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BuildCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DiskUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BuildCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DiskUsageResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1606,82 +1875,115 @@ func (m *BuildCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *BuildCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BuildCommitRequest.Merge(dst, src)
+func (dst *DiskUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskUsageResponse.Merge(dst, src)
 }
-func (m *BuildCommitRequest) XXX_Size() int {
+func (m *DiskUsageResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *BuildCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BuildCommitRequest.DiscardUnknown(m)
+func (m *DiskUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskUsageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BuildCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_DiskUsageResponse proto.InternalMessageInfo
 
-func (m *BuildCommitRequest) GetParent() *Commit {
+func (m *DiskUsageResponse) GetRepos() []*RepoDiskUsage {
 	if m != nil {
-		return m.Parent
+		return m.Repos
 	}
 	return nil
 }
 
-func (m *BuildCommitRequest) GetBranch() string {
-	if m != nil {
-		return m.Branch
+type DeleteRepoRequest struct {
+	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	All                  bool     `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRepoRequest) Reset()         { *m = DeleteRepoRequest{} }
+func (m *DeleteRepoRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRepoRequest) ProtoMessage()    {}
+func (*DeleteRepoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{27}
+}
+func (m *DeleteRepoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteRepoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteRepoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (dst *DeleteRepoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRepoRequest.Merge(dst, src)
+}
+func (m *DeleteRepoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteRepoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRepoRequest.DiscardUnknown(m)
 }
 
-func (m *BuildCommitRequest) GetProvenance() []*Commit {
+var xxx_messageInfo_DeleteRepoRequest proto.InternalMessageInfo
+
+func (m *DeleteRepoRequest) GetRepo() *Repo {
 	if m != nil {
-		return m.Provenance
+		return m.Repo
 	}
 	return nil
 }
 
-func (m *BuildCommitRequest) GetTree() *Object {
+func (m *DeleteRepoRequest) GetForce() bool {
 	if m != nil {
-		return m.Tree
+		return m.Force
 	}
-	return nil
+	return false
 }
 
-func (m *BuildCommitRequest) GetID() string {
+func (m *DeleteRepoRequest) GetAll() bool {
 	if m != nil {
-		return m.ID
+		return m.All
 	}
-	return ""
+	return false
 }
 
-type FinishCommitRequest struct {
-	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
-	// description is a user-provided string describing this commit. Setting this
-	// will overwrite the description set in StartCommit
-	Description string    `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Tree        *Object   `protobuf:"bytes,3,opt,name=tree,proto3" json:"tree,omitempty"`
-	Trees       []*Object `protobuf:"bytes,5,rep,name=trees,proto3" json:"trees,omitempty"`
-	Datums      *Object   `protobuf:"bytes,7,opt,name=datums,proto3" json:"datums,omitempty"`
-	SizeBytes   uint64    `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	// If set, 'commit' will be closed (its 'finished' field will be set to the
-	// current time) but its 'tree' will be left nil.
-	Empty                bool     `protobuf:"varint,4,opt,name=empty,proto3" json:"empty,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type StartCommitRequest struct {
+	// Parent.ID may be empty in which case the commit that Branch points to will be used as the parent.
+	// If branch is empty, or if branch does not exist, the commit will have no parent.
+	Parent *Commit `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// description is a user-provided string describing this commit
+	Description string    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Branch      string    `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	Provenance  []*Commit `protobuf:"bytes,2,rep,name=provenance,proto3" json:"provenance,omitempty"`
+	// labels are user-provided key/value pairs to attach to this commit
+	Labels               map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *FinishCommitRequest) Reset()         { *m = FinishCommitRequest{} }
-func (m *FinishCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*FinishCommitRequest) ProtoMessage()    {}
-func (*FinishCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{24}
+func (m *StartCommitRequest) Reset()         { *m = StartCommitRequest{} }
+func (m *StartCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*StartCommitRequest) ProtoMessage()    {}
+func (*StartCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{28}
 }
-func (m *FinishCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *StartCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FinishCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StartCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FinishCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StartCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1691,88 +1993,76 @@ func (m *FinishCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *FinishCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FinishCommitRequest.Merge(dst, src)
+func (dst *StartCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartCommitRequest.Merge(dst, src)
 }
-func (m *FinishCommitRequest) XXX_Size() int {
+func (m *StartCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *FinishCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FinishCommitRequest.DiscardUnknown(m)
+func (m *StartCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FinishCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_StartCommitRequest proto.InternalMessageInfo
 
-func (m *FinishCommitRequest) GetCommit() *Commit {
+func (m *StartCommitRequest) GetParent() *Commit {
 	if m != nil {
-		return m.Commit
+		return m.Parent
 	}
 	return nil
 }
 
-func (m *FinishCommitRequest) GetDescription() string {
+func (m *StartCommitRequest) GetDescription() string {
 	if m != nil {
 		return m.Description
 	}
 	return ""
 }
 
-func (m *FinishCommitRequest) GetTree() *Object {
+func (m *StartCommitRequest) GetBranch() string {
 	if m != nil {
-		return m.Tree
+		return m.Branch
 	}
-	return nil
+	return ""
 }
 
-func (m *FinishCommitRequest) GetTrees() []*Object {
+func (m *StartCommitRequest) GetProvenance() []*Commit {
 	if m != nil {
-		return m.Trees
+		return m.Provenance
 	}
 	return nil
 }
 
-func (m *FinishCommitRequest) GetDatums() *Object {
+func (m *StartCommitRequest) GetLabels() map[string]string {
 	if m != nil {
-		return m.Datums
+		return m.Labels
 	}
 	return nil
 }
 
-func (m *FinishCommitRequest) GetSizeBytes() uint64 {
-	if m != nil {
-		return m.SizeBytes
-	}
-	return 0
-}
-
-func (m *FinishCommitRequest) GetEmpty() bool {
-	if m != nil {
-		return m.Empty
-	}
-	return false
-}
-
-type InspectCommitRequest struct {
+// ProvenanceGraphRequest streams the provenance graph of 'commit'.
+type ProvenanceGraphRequest struct {
 	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
-	// BlockState causes inspect commit to block until the commit is in the desired state.
-	BlockState           CommitState `protobuf:"varint,2,opt,name=block_state,json=blockState,proto3,enum=pfs.CommitState" json:"block_state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	// max_depth, if positive, is the most provenance edges between 'commit'
+	// and the commits that are streamed. If it's 0, the whole graph is streamed.
+	MaxDepth             int64    `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *InspectCommitRequest) Reset()         { *m = InspectCommitRequest{} }
-func (m *InspectCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*InspectCommitRequest) ProtoMessage()    {}
-func (*InspectCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{25}
+func (m *ProvenanceGraphRequest) Reset()         { *m = ProvenanceGraphRequest{} }
+func (m *ProvenanceGraphRequest) String() string { return proto.CompactTextString(m) }
+func (*ProvenanceGraphRequest) ProtoMessage()    {}
+func (*ProvenanceGraphRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{29}
 }
-func (m *InspectCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *ProvenanceGraphRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InspectCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ProvenanceGraphRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InspectCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ProvenanceGraphRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1782,54 +2072,64 @@ func (m *InspectCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (dst *InspectCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InspectCommitRequest.Merge(dst, src)
+func (dst *ProvenanceGraphRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProvenanceGraphRequest.Merge(dst, src)
 }
-func (m *InspectCommitRequest) XXX_Size() int {
+func (m *ProvenanceGraphRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *InspectCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_InspectCommitRequest.DiscardUnknown(m)
+func (m *ProvenanceGraphRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProvenanceGraphRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InspectCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_ProvenanceGraphRequest proto.InternalMessageInfo
 
-func (m *InspectCommitRequest) GetCommit() *Commit {
+func (m *ProvenanceGraphRequest) GetCommit() *Commit {
 	if m != nil {
 		return m.Commit
 	}
 	return nil
 }
 
-func (m *InspectCommitRequest) GetBlockState() CommitState {
+func (m *ProvenanceGraphRequest) GetMaxDepth() int64 {
 	if m != nil {
-		return m.BlockState
+		return m.MaxDepth
 	}
-	return CommitState_STARTED
+	return 0
 }
 
-type ListCommitRequest struct {
-	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	From                 *Commit  `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	To                   *Commit  `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
-	Number               uint64   `protobuf:"varint,4,opt,name=number,proto3" json:"number,omitempty"`
+// ProvenanceGraphNode is a commit in the provenance graph of a commit, along
+// with its edges to the commits that it's directly provenant on.
+type ProvenanceGraphNode struct {
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// branch is the branch that the commit is in, if it's known
+	Branch *Branch `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	// depth is the number of edges between the requested commit and this one
+	Depth int64 `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	// provenance holds the commits that this commit is directly provenant on
+	// (e.g. a job's input commits, for its output commit). Each of them is
+	// streamed after this node, unless it was streamed already.
+	Provenance []*Commit `protobuf:"bytes,4,rep,name=provenance,proto3" json:"provenance,omitempty"`
+	// truncated is true if the commit is provenant on commits that weren't
+	// streamed because they're deeper than max_depth
+	Truncated            bool     `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListCommitRequest) Reset()         { *m = ListCommitRequest{} }
-func (m *ListCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*ListCommitRequest) ProtoMessage()    {}
-func (*ListCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{26}
+func (m *ProvenanceGraphNode) Reset()         { *m = ProvenanceGraphNode{} }
+func (m *ProvenanceGraphNode) String() string { return proto.CompactTextString(m) }
+func (*ProvenanceGraphNode) ProtoMessage()    {}
+func (*ProvenanceGraphNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{30}
 }
-func (m *ListCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *ProvenanceGraphNode) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ProvenanceGraphNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ProvenanceGraphNode.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1839,65 +2139,77 @@ func (m *ListCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *ListCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListCommitRequest.Merge(dst, src)
+func (dst *ProvenanceGraphNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProvenanceGraphNode.Merge(dst, src)
 }
-func (m *ListCommitRequest) XXX_Size() int {
+func (m *ProvenanceGraphNode) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListCommitRequest.DiscardUnknown(m)
+func (m *ProvenanceGraphNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProvenanceGraphNode.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_ProvenanceGraphNode proto.InternalMessageInfo
 
-func (m *ListCommitRequest) GetRepo() *Repo {
+func (m *ProvenanceGraphNode) GetCommit() *Commit {
 	if m != nil {
-		return m.Repo
+		return m.Commit
 	}
 	return nil
 }
 
-func (m *ListCommitRequest) GetFrom() *Commit {
+func (m *ProvenanceGraphNode) GetBranch() *Branch {
 	if m != nil {
-		return m.From
+		return m.Branch
 	}
 	return nil
 }
 
-func (m *ListCommitRequest) GetTo() *Commit {
+func (m *ProvenanceGraphNode) GetDepth() int64 {
 	if m != nil {
-		return m.To
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *ProvenanceGraphNode) GetProvenance() []*Commit {
+	if m != nil {
+		return m.Provenance
 	}
 	return nil
 }
 
-func (m *ListCommitRequest) GetNumber() uint64 {
+func (m *ProvenanceGraphNode) GetTruncated() bool {
 	if m != nil {
-		return m.Number
+		return m.Truncated
 	}
-	return 0
+	return false
 }
 
-type CommitInfos struct {
-	CommitInfo           []*CommitInfo `protobuf:"bytes,1,rep,name=commit_info,json=commitInfo,proto3" json:"commit_info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type BuildCommitRequest struct {
+	Parent     *Commit   `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Branch     string    `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	Provenance []*Commit `protobuf:"bytes,2,rep,name=provenance,proto3" json:"provenance,omitempty"`
+	Tree       *Object   `protobuf:"bytes,3,opt,name=tree,proto3" json:"tree,omitempty"`
+	// ID sets the ID of the created commit.
+	ID                   string   `protobuf:"bytes,5,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommitInfos) Reset()         { *m = CommitInfos{} }
-func (m *CommitInfos) String() string { return proto.CompactTextString(m) }
-func (*CommitInfos) ProtoMessage()    {}
-func (*CommitInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{27}
+func (m *BuildCommitRequest) Reset()         { *m = BuildCommitRequest{} }
+func (m *BuildCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*BuildCommitRequest) ProtoMessage()    {}
+func (*BuildCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{31}
 }
-func (m *CommitInfos) XXX_Unmarshal(b []byte) error {
+func (m *BuildCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CommitInfos) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BuildCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CommitInfos.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BuildCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1907,50 +2219,85 @@ func (m *CommitInfos) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (dst *CommitInfos) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitInfos.Merge(dst, src)
+func (dst *BuildCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildCommitRequest.Merge(dst, src)
 }
-func (m *CommitInfos) XXX_Size() int {
+func (m *BuildCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CommitInfos) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitInfos.DiscardUnknown(m)
+func (m *BuildCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitInfos proto.InternalMessageInfo
+var xxx_messageInfo_BuildCommitRequest proto.InternalMessageInfo
 
-func (m *CommitInfos) GetCommitInfo() []*CommitInfo {
+func (m *BuildCommitRequest) GetParent() *Commit {
 	if m != nil {
-		return m.CommitInfo
+		return m.Parent
 	}
 	return nil
 }
 
-type CreateBranchRequest struct {
-	Head *Commit `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
-	// s_branch matches the field number and type of SetBranchRequest.Branch in
-	// Pachyderm 1.6--so that operations (generated by pachyderm 1.6's
-	// Admin.Export) can be deserialized by pachyderm 1.7 correctly
-	SBranch              string    `protobuf:"bytes,2,opt,name=s_branch,json=sBranch,proto3" json:"s_branch,omitempty"`
-	Branch               *Branch   `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
-	Provenance           []*Branch `protobuf:"bytes,4,rep,name=provenance,proto3" json:"provenance,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *BuildCommitRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
 }
 
-func (m *CreateBranchRequest) Reset()         { *m = CreateBranchRequest{} }
-func (m *CreateBranchRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateBranchRequest) ProtoMessage()    {}
-func (*CreateBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{28}
+func (m *BuildCommitRequest) GetProvenance() []*Commit {
+	if m != nil {
+		return m.Provenance
+	}
+	return nil
 }
-func (m *CreateBranchRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *BuildCommitRequest) GetTree() *Object {
+	if m != nil {
+		return m.Tree
+	}
+	return nil
+}
+
+func (m *BuildCommitRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type FinishCommitRequest struct {
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// description is a user-provided string describing this commit. Setting this
+	// will overwrite the description set in StartCommit
+	Description string    `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Tree        *Object   `protobuf:"bytes,3,opt,name=tree,proto3" json:"tree,omitempty"`
+	Trees       []*Object `protobuf:"bytes,5,rep,name=trees,proto3" json:"trees,omitempty"`
+	Datums      *Object   `protobuf:"bytes,7,opt,name=datums,proto3" json:"datums,omitempty"`
+	SizeBytes   uint64    `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// If set, 'commit' will be closed (its 'finished' field will be set to the
+	// current time) but its 'tree' will be left nil.
+	Empty bool `protobuf:"varint,4,opt,name=empty,proto3" json:"empty,omitempty"`
+	// labels are added to the labels set in StartCommit, overwriting any that
+	// have the same key
+	Labels               map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *FinishCommitRequest) Reset()         { *m = FinishCommitRequest{} }
+func (m *FinishCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*FinishCommitRequest) ProtoMessage()    {}
+func (*FinishCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{32}
+}
+func (m *FinishCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FinishCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateBranchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FinishCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1960,65 +2307,95 @@ func (m *CreateBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *CreateBranchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateBranchRequest.Merge(dst, src)
+func (dst *FinishCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinishCommitRequest.Merge(dst, src)
 }
-func (m *CreateBranchRequest) XXX_Size() int {
+func (m *FinishCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateBranchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateBranchRequest.DiscardUnknown(m)
+func (m *FinishCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinishCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateBranchRequest proto.InternalMessageInfo
+var xxx_messageInfo_FinishCommitRequest proto.InternalMessageInfo
 
-func (m *CreateBranchRequest) GetHead() *Commit {
+func (m *FinishCommitRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.Head
+		return m.Commit
 	}
 	return nil
 }
 
-func (m *CreateBranchRequest) GetSBranch() string {
+func (m *FinishCommitRequest) GetDescription() string {
 	if m != nil {
-		return m.SBranch
+		return m.Description
 	}
 	return ""
 }
 
-func (m *CreateBranchRequest) GetBranch() *Branch {
+func (m *FinishCommitRequest) GetTree() *Object {
 	if m != nil {
-		return m.Branch
+		return m.Tree
 	}
 	return nil
 }
 
-func (m *CreateBranchRequest) GetProvenance() []*Branch {
+func (m *FinishCommitRequest) GetTrees() []*Object {
 	if m != nil {
-		return m.Provenance
+		return m.Trees
 	}
 	return nil
 }
 
-type InspectBranchRequest struct {
-	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *FinishCommitRequest) GetDatums() *Object {
+	if m != nil {
+		return m.Datums
+	}
+	return nil
 }
 
-func (m *InspectBranchRequest) Reset()         { *m = InspectBranchRequest{} }
-func (m *InspectBranchRequest) String() string { return proto.CompactTextString(m) }
-func (*InspectBranchRequest) ProtoMessage()    {}
-func (*InspectBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{29}
+func (m *FinishCommitRequest) GetSizeBytes() uint64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
 }
-func (m *InspectBranchRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *FinishCommitRequest) GetEmpty() bool {
+	if m != nil {
+		return m.Empty
+	}
+	return false
+}
+
+func (m *FinishCommitRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type InspectCommitRequest struct {
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// BlockState causes inspect commit to block until the commit is in the desired state.
+	BlockState           CommitState `protobuf:"varint,2,opt,name=block_state,json=blockState,proto3,enum=pfs.CommitState" json:"block_state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *InspectCommitRequest) Reset()         { *m = InspectCommitRequest{} }
+func (m *InspectCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectCommitRequest) ProtoMessage()    {}
+func (*InspectCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{33}
+}
+func (m *InspectCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InspectBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InspectCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InspectBranchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InspectCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2028,44 +2405,53 @@ func (m *InspectBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (dst *InspectBranchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InspectBranchRequest.Merge(dst, src)
+func (dst *InspectCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCommitRequest.Merge(dst, src)
 }
-func (m *InspectBranchRequest) XXX_Size() int {
+func (m *InspectCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *InspectBranchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_InspectBranchRequest.DiscardUnknown(m)
+func (m *InspectCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InspectBranchRequest proto.InternalMessageInfo
+var xxx_messageInfo_InspectCommitRequest proto.InternalMessageInfo
 
-func (m *InspectBranchRequest) GetBranch() *Branch {
+func (m *InspectCommitRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.Branch
+		return m.Commit
 	}
 	return nil
 }
 
-type ListBranchRequest struct {
-	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *InspectCommitRequest) GetBlockState() CommitState {
+	if m != nil {
+		return m.BlockState
+	}
+	return CommitState_STARTED
 }
 
-func (m *ListBranchRequest) Reset()         { *m = ListBranchRequest{} }
-func (m *ListBranchRequest) String() string { return proto.CompactTextString(m) }
-func (*ListBranchRequest) ProtoMessage()    {}
-func (*ListBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{30}
+// InspectCommitsRequest inspects up to 1000 commits (which, like in
+// InspectCommitRequest, may be branch names) in one call, without blocking.
+type InspectCommitsRequest struct {
+	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
-func (m *ListBranchRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *InspectCommitsRequest) Reset()         { *m = InspectCommitsRequest{} }
+func (m *InspectCommitsRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectCommitsRequest) ProtoMessage()    {}
+func (*InspectCommitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{34}
+}
+func (m *InspectCommitsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InspectCommitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListBranchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InspectCommitsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2075,45 +2461,52 @@ func (m *ListBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *ListBranchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListBranchRequest.Merge(dst, src)
+func (dst *InspectCommitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCommitsRequest.Merge(dst, src)
 }
-func (m *ListBranchRequest) XXX_Size() int {
+func (m *InspectCommitsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListBranchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListBranchRequest.DiscardUnknown(m)
+func (m *InspectCommitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCommitsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListBranchRequest proto.InternalMessageInfo
+var xxx_messageInfo_InspectCommitsRequest proto.InternalMessageInfo
 
-func (m *ListBranchRequest) GetRepo() *Repo {
+func (m *InspectCommitsRequest) GetCommits() []*Commit {
 	if m != nil {
-		return m.Repo
+		return m.Commits
 	}
 	return nil
 }
 
-type DeleteBranchRequest struct {
-	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
-	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// InspectCommitResult is the result of inspecting one of the commits in an
+// InspectCommitsRequest. 'commit' is the commit as it was requested. If it
+// couldn't be inspected, 'info' is unset and 'error' says why, and
+// 'not_found' is set if that's because it (or its repo or branch) doesn't
+// exist.
+type InspectCommitResult struct {
+	Commit               *Commit     `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Info                 *CommitInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	NotFound             bool        `protobuf:"varint,3,opt,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+	Error                string      `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *DeleteBranchRequest) Reset()         { *m = DeleteBranchRequest{} }
-func (m *DeleteBranchRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteBranchRequest) ProtoMessage()    {}
-func (*DeleteBranchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{31}
+func (m *InspectCommitResult) Reset()         { *m = InspectCommitResult{} }
+func (m *InspectCommitResult) String() string { return proto.CompactTextString(m) }
+func (*InspectCommitResult) ProtoMessage()    {}
+func (*InspectCommitResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{35}
 }
-func (m *DeleteBranchRequest) XXX_Unmarshal(b []byte) error {
+func (m *InspectCommitResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InspectCommitResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteBranchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InspectCommitResult.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2123,51 +2516,72 @@ func (m *DeleteBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *DeleteBranchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteBranchRequest.Merge(dst, src)
+func (dst *InspectCommitResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCommitResult.Merge(dst, src)
 }
-func (m *DeleteBranchRequest) XXX_Size() int {
+func (m *InspectCommitResult) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteBranchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteBranchRequest.DiscardUnknown(m)
+func (m *InspectCommitResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCommitResult.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteBranchRequest proto.InternalMessageInfo
+var xxx_messageInfo_InspectCommitResult proto.InternalMessageInfo
 
-func (m *DeleteBranchRequest) GetBranch() *Branch {
+func (m *InspectCommitResult) GetCommit() *Commit {
 	if m != nil {
-		return m.Branch
+		return m.Commit
 	}
 	return nil
 }
 
-func (m *DeleteBranchRequest) GetForce() bool {
+func (m *InspectCommitResult) GetInfo() *CommitInfo {
 	if m != nil {
-		return m.Force
+		return m.Info
+	}
+	return nil
+}
+
+func (m *InspectCommitResult) GetNotFound() bool {
+	if m != nil {
+		return m.NotFound
 	}
 	return false
 }
 
-type DeleteCommitRequest struct {
-	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+func (m *InspectCommitResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListCommitRequest struct {
+	Repo   *Repo   `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	From   *Commit `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To     *Commit `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Number uint64  `protobuf:"varint,4,opt,name=number,proto3" json:"number,omitempty"`
+	// label_selector, if set, restricts the result to commits whose labels
+	// match it. It uses the same syntax as Kubernetes label selectors, e.g.
+	// "release=v2,env in (prod,staging)"
+	LabelSelector        string   `protobuf:"bytes,5,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteCommitRequest) Reset()         { *m = DeleteCommitRequest{} }
-func (m *DeleteCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteCommitRequest) ProtoMessage()    {}
-func (*DeleteCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{32}
+func (m *ListCommitRequest) Reset()         { *m = ListCommitRequest{} }
+func (m *ListCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommitRequest) ProtoMessage()    {}
+func (*ListCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{36}
 }
-func (m *DeleteCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *ListCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2177,45 +2591,72 @@ func (m *DeleteCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *DeleteCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteCommitRequest.Merge(dst, src)
+func (dst *ListCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommitRequest.Merge(dst, src)
 }
-func (m *DeleteCommitRequest) XXX_Size() int {
+func (m *ListCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteCommitRequest.DiscardUnknown(m)
+func (m *ListCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListCommitRequest proto.InternalMessageInfo
 
-func (m *DeleteCommitRequest) GetCommit() *Commit {
+func (m *ListCommitRequest) GetRepo() *Repo {
 	if m != nil {
-		return m.Commit
+		return m.Repo
 	}
 	return nil
 }
 
-type FlushCommitRequest struct {
-	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
-	ToRepos              []*Repo   `protobuf:"bytes,2,rep,name=to_repos,json=toRepos,proto3" json:"to_repos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *ListCommitRequest) GetFrom() *Commit {
+	if m != nil {
+		return m.From
+	}
+	return nil
 }
 
-func (m *FlushCommitRequest) Reset()         { *m = FlushCommitRequest{} }
-func (m *FlushCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*FlushCommitRequest) ProtoMessage()    {}
-func (*FlushCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{33}
+func (m *ListCommitRequest) GetTo() *Commit {
+	if m != nil {
+		return m.To
+	}
+	return nil
 }
-func (m *FlushCommitRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *ListCommitRequest) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *ListCommitRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+type CommitInfos struct {
+	CommitInfo           []*CommitInfo `protobuf:"bytes,1,rep,name=commit_info,json=commitInfo,proto3" json:"commit_info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CommitInfos) Reset()         { *m = CommitInfos{} }
+func (m *CommitInfos) String() string { return proto.CompactTextString(m) }
+func (*CommitInfos) ProtoMessage()    {}
+func (*CommitInfos) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{37}
+}
+func (m *CommitInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FlushCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CommitInfos) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FlushCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CommitInfos.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2225,56 +2666,50 @@ func (m *FlushCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *FlushCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FlushCommitRequest.Merge(dst, src)
+func (dst *CommitInfos) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitInfos.Merge(dst, src)
 }
-func (m *FlushCommitRequest) XXX_Size() int {
+func (m *CommitInfos) XXX_Size() int {
 	return m.Size()
 }
-func (m *FlushCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FlushCommitRequest.DiscardUnknown(m)
+func (m *CommitInfos) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitInfos.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FlushCommitRequest proto.InternalMessageInfo
-
-func (m *FlushCommitRequest) GetCommits() []*Commit {
-	if m != nil {
-		return m.Commits
-	}
-	return nil
-}
+var xxx_messageInfo_CommitInfos proto.InternalMessageInfo
 
-func (m *FlushCommitRequest) GetToRepos() []*Repo {
+func (m *CommitInfos) GetCommitInfo() []*CommitInfo {
 	if m != nil {
-		return m.ToRepos
+		return m.CommitInfo
 	}
 	return nil
 }
 
-type SubscribeCommitRequest struct {
-	Repo   *Repo  `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Branch string `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
-	// only commits created since this commit are returned
-	From *Commit `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
-	// Don't return commits until they're in (at least) the desired state.
-	State                CommitState `protobuf:"varint,4,opt,name=state,proto3,enum=pfs.CommitState" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type CreateBranchRequest struct {
+	Head *Commit `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	// s_branch matches the field number and type of SetBranchRequest.Branch in
+	// Pachyderm 1.6--so that operations (generated by pachyderm 1.6's
+	// Admin.Export) can be deserialized by pachyderm 1.7 correctly
+	SBranch              string    `protobuf:"bytes,2,opt,name=s_branch,json=sBranch,proto3" json:"s_branch,omitempty"`
+	Branch               *Branch   `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	Provenance           []*Branch `protobuf:"bytes,4,rep,name=provenance,proto3" json:"provenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *SubscribeCommitRequest) Reset()         { *m = SubscribeCommitRequest{} }
-func (m *SubscribeCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*SubscribeCommitRequest) ProtoMessage()    {}
-func (*SubscribeCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{34}
+func (m *CreateBranchRequest) Reset()         { *m = CreateBranchRequest{} }
+func (m *CreateBranchRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateBranchRequest) ProtoMessage()    {}
+func (*CreateBranchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{38}
 }
-func (m *SubscribeCommitRequest) XXX_Unmarshal(b []byte) error {
+func (m *CreateBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SubscribeCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CreateBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SubscribeCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CreateBranchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2284,67 +2719,65 @@ func (m *SubscribeCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (dst *SubscribeCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SubscribeCommitRequest.Merge(dst, src)
+func (dst *CreateBranchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateBranchRequest.Merge(dst, src)
 }
-func (m *SubscribeCommitRequest) XXX_Size() int {
+func (m *CreateBranchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SubscribeCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SubscribeCommitRequest.DiscardUnknown(m)
+func (m *CreateBranchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateBranchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SubscribeCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_CreateBranchRequest proto.InternalMessageInfo
 
-func (m *SubscribeCommitRequest) GetRepo() *Repo {
+func (m *CreateBranchRequest) GetHead() *Commit {
 	if m != nil {
-		return m.Repo
+		return m.Head
 	}
 	return nil
 }
 
-func (m *SubscribeCommitRequest) GetBranch() string {
+func (m *CreateBranchRequest) GetSBranch() string {
 	if m != nil {
-		return m.Branch
+		return m.SBranch
 	}
 	return ""
 }
 
-func (m *SubscribeCommitRequest) GetFrom() *Commit {
+func (m *CreateBranchRequest) GetBranch() *Branch {
 	if m != nil {
-		return m.From
+		return m.Branch
 	}
 	return nil
 }
 
-func (m *SubscribeCommitRequest) GetState() CommitState {
+func (m *CreateBranchRequest) GetProvenance() []*Branch {
 	if m != nil {
-		return m.State
+		return m.Provenance
 	}
-	return CommitState_STARTED
+	return nil
 }
 
-type GetFileRequest struct {
-	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	OffsetBytes          int64    `protobuf:"varint,2,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
-	SizeBytes            int64    `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+type InspectBranchRequest struct {
+	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFileRequest) Reset()         { *m = GetFileRequest{} }
-func (m *GetFileRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFileRequest) ProtoMessage()    {}
-func (*GetFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{35}
+func (m *InspectBranchRequest) Reset()         { *m = InspectBranchRequest{} }
+func (m *InspectBranchRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectBranchRequest) ProtoMessage()    {}
+func (*InspectBranchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{39}
 }
-func (m *GetFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *InspectBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InspectBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InspectBranchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2354,62 +2787,94 @@ func (m *GetFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (dst *GetFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFileRequest.Merge(dst, src)
+func (dst *InspectBranchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectBranchRequest.Merge(dst, src)
 }
-func (m *GetFileRequest) XXX_Size() int {
+func (m *InspectBranchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFileRequest.DiscardUnknown(m)
+func (m *InspectBranchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectBranchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_InspectBranchRequest proto.InternalMessageInfo
 
-func (m *GetFileRequest) GetFile() *File {
+func (m *InspectBranchRequest) GetBranch() *Branch {
 	if m != nil {
-		return m.File
+		return m.Branch
 	}
 	return nil
 }
 
-func (m *GetFileRequest) GetOffsetBytes() int64 {
-	if m != nil {
-		return m.OffsetBytes
+type ListBranchRequest struct {
+	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBranchRequest) Reset()         { *m = ListBranchRequest{} }
+func (m *ListBranchRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBranchRequest) ProtoMessage()    {}
+func (*ListBranchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{40}
+}
+func (m *ListBranchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListBranchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (dst *ListBranchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBranchRequest.Merge(dst, src)
+}
+func (m *ListBranchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListBranchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBranchRequest.DiscardUnknown(m)
 }
 
-func (m *GetFileRequest) GetSizeBytes() int64 {
+var xxx_messageInfo_ListBranchRequest proto.InternalMessageInfo
+
+func (m *ListBranchRequest) GetRepo() *Repo {
 	if m != nil {
-		return m.SizeBytes
+		return m.Repo
 	}
-	return 0
+	return nil
 }
 
-// An OverwriteIndex specifies the index of objects from which new writes
-// are applied to.  Existing objects starting from the index are deleted.
-// We want a separate message for ObjectIndex because we want to be able to
-// distinguish between a zero index and a non-existent index.
-type OverwriteIndex struct {
-	Index                int64    `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+type ListCommitBranchesRequest struct {
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// If set, branches that have 'commit' in their history (rather than at
+	// their head) are returned too.
+	History              bool     `protobuf:"varint,2,opt,name=history,proto3" json:"history,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OverwriteIndex) Reset()         { *m = OverwriteIndex{} }
-func (m *OverwriteIndex) String() string { return proto.CompactTextString(m) }
-func (*OverwriteIndex) ProtoMessage()    {}
-func (*OverwriteIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{36}
+func (m *ListCommitBranchesRequest) Reset()         { *m = ListCommitBranchesRequest{} }
+func (m *ListCommitBranchesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCommitBranchesRequest) ProtoMessage()    {}
+func (*ListCommitBranchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{41}
 }
-func (m *OverwriteIndex) XXX_Unmarshal(b []byte) error {
+func (m *ListCommitBranchesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *OverwriteIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListCommitBranchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_OverwriteIndex.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListCommitBranchesRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2419,78 +2884,52 @@ func (m *OverwriteIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (dst *OverwriteIndex) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OverwriteIndex.Merge(dst, src)
+func (dst *ListCommitBranchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCommitBranchesRequest.Merge(dst, src)
 }
-func (m *OverwriteIndex) XXX_Size() int {
+func (m *ListCommitBranchesRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *OverwriteIndex) XXX_DiscardUnknown() {
-	xxx_messageInfo_OverwriteIndex.DiscardUnknown(m)
+func (m *ListCommitBranchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCommitBranchesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OverwriteIndex proto.InternalMessageInfo
+var xxx_messageInfo_ListCommitBranchesRequest proto.InternalMessageInfo
 
-func (m *OverwriteIndex) GetIndex() int64 {
+func (m *ListCommitBranchesRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.Index
+		return m.Commit
 	}
-	return 0
+	return nil
 }
 
-type PutFileRequest struct {
-	File  *File  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	Url   string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
-	// applies only to URLs that can be recursively walked, for example s3:// URLs
-	Recursive bool `protobuf:"varint,6,opt,name=recursive,proto3" json:"recursive,omitempty"`
-	// Delimiter causes data to be broken up into separate files with File.Path
-	// as a prefix.
-	Delimiter Delimiter `protobuf:"varint,7,opt,name=delimiter,proto3,enum=pfs.Delimiter" json:"delimiter,omitempty"`
-	// TargetFileDatums specifies the target number of datums in each written
-	// file it may be lower if data does not split evenly, but will never be
-	// higher, unless the value is 0.
-	TargetFileDatums int64 `protobuf:"varint,8,opt,name=target_file_datums,json=targetFileDatums,proto3" json:"target_file_datums,omitempty"`
-	// TargetFileBytes specifies the target number of bytes in each written
-	// file, files may have more or fewer bytes than the target.
-	TargetFileBytes int64 `protobuf:"varint,9,opt,name=target_file_bytes,json=targetFileBytes,proto3" json:"target_file_bytes,omitempty"`
-	// header_records is an option for splitting data when 'delimiter' is not NONE
-	// (or SQL). It specifies the number of records that are converted to a
-	// header and applied to all file shards.
-	//
-	// This is particularly useful for CSV files, where the first row often
-	// contains column titles; if 'header_records' is set to one in that case,
-	// the first row will be associated with the directory that contains the rest
-	// of the split-up csv rows as files, and if any data is retrieved from that
-	// directory by GetFile, it will appear to begin with that first row of
-	// column labels (including in pipeline workers).
-	//
-	// Note that SQL files have their own logic for determining headers (their
-	// header is not a number of records, but a collection of SQL commands that
-	// create the relevant tables and such). This way, SQL files retrieved by
-	// GetFile can be passed to psql, and they will set up the appropriate tables
-	// before inserting the records in the files that were retrieved.
-	HeaderRecords int64 `protobuf:"varint,11,opt,name=header_records,json=headerRecords,proto3" json:"header_records,omitempty"`
-	// overwrite_index is the object index where the write starts from.  All
-	// existing objects starting from the index are deleted.
-	OverwriteIndex       *OverwriteIndex `protobuf:"bytes,10,opt,name=overwrite_index,json=overwriteIndex,proto3" json:"overwrite_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *ListCommitBranchesRequest) GetHistory() bool {
+	if m != nil {
+		return m.History
+	}
+	return false
 }
 
-func (m *PutFileRequest) Reset()         { *m = PutFileRequest{} }
-func (m *PutFileRequest) String() string { return proto.CompactTextString(m) }
-func (*PutFileRequest) ProtoMessage()    {}
-func (*PutFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{37}
+type SetMaxHistoryDepthRequest struct {
+	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	Depth                int64    `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *PutFileRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *SetMaxHistoryDepthRequest) Reset()         { *m = SetMaxHistoryDepthRequest{} }
+func (m *SetMaxHistoryDepthRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMaxHistoryDepthRequest) ProtoMessage()    {}
+func (*SetMaxHistoryDepthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{42}
+}
+func (m *SetMaxHistoryDepthRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetMaxHistoryDepthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetMaxHistoryDepthRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2500,103 +2939,52 @@ func (m *PutFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (dst *PutFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutFileRequest.Merge(dst, src)
+func (dst *SetMaxHistoryDepthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMaxHistoryDepthRequest.Merge(dst, src)
 }
-func (m *PutFileRequest) XXX_Size() int {
+func (m *SetMaxHistoryDepthRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutFileRequest.DiscardUnknown(m)
+func (m *SetMaxHistoryDepthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMaxHistoryDepthRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutFileRequest proto.InternalMessageInfo
-
-func (m *PutFileRequest) GetFile() *File {
-	if m != nil {
-		return m.File
-	}
-	return nil
-}
+var xxx_messageInfo_SetMaxHistoryDepthRequest proto.InternalMessageInfo
 
-func (m *PutFileRequest) GetValue() []byte {
+func (m *SetMaxHistoryDepthRequest) GetBranch() *Branch {
 	if m != nil {
-		return m.Value
+		return m.Branch
 	}
 	return nil
 }
 
-func (m *PutFileRequest) GetUrl() string {
-	if m != nil {
-		return m.Url
-	}
-	return ""
-}
-
-func (m *PutFileRequest) GetRecursive() bool {
-	if m != nil {
-		return m.Recursive
-	}
-	return false
-}
-
-func (m *PutFileRequest) GetDelimiter() Delimiter {
-	if m != nil {
-		return m.Delimiter
-	}
-	return Delimiter_NONE
-}
-
-func (m *PutFileRequest) GetTargetFileDatums() int64 {
-	if m != nil {
-		return m.TargetFileDatums
-	}
-	return 0
-}
-
-func (m *PutFileRequest) GetTargetFileBytes() int64 {
-	if m != nil {
-		return m.TargetFileBytes
-	}
-	return 0
-}
-
-func (m *PutFileRequest) GetHeaderRecords() int64 {
+func (m *SetMaxHistoryDepthRequest) GetDepth() int64 {
 	if m != nil {
-		return m.HeaderRecords
+		return m.Depth
 	}
 	return 0
 }
 
-func (m *PutFileRequest) GetOverwriteIndex() *OverwriteIndex {
-	if m != nil {
-		return m.OverwriteIndex
-	}
-	return nil
-}
-
-// PutFileRecord is used to record PutFile requests in etcd temporarily.
-type PutFileRecord struct {
-	SizeBytes            int64           `protobuf:"varint,1,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	ObjectHash           string          `protobuf:"bytes,2,opt,name=object_hash,json=objectHash,proto3" json:"object_hash,omitempty"`
-	OverwriteIndex       *OverwriteIndex `protobuf:"bytes,3,opt,name=overwrite_index,json=overwriteIndex,proto3" json:"overwrite_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type SwapBranchRequest struct {
+	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	Other                *Branch  `protobuf:"bytes,2,opt,name=other,proto3" json:"other,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PutFileRecord) Reset()         { *m = PutFileRecord{} }
-func (m *PutFileRecord) String() string { return proto.CompactTextString(m) }
-func (*PutFileRecord) ProtoMessage()    {}
-func (*PutFileRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{38}
+func (m *SwapBranchRequest) Reset()         { *m = SwapBranchRequest{} }
+func (m *SwapBranchRequest) String() string { return proto.CompactTextString(m) }
+func (*SwapBranchRequest) ProtoMessage()    {}
+func (*SwapBranchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{43}
 }
-func (m *PutFileRecord) XXX_Unmarshal(b []byte) error {
+func (m *SwapBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutFileRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SwapBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutFileRecord.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SwapBranchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2606,62 +2994,52 @@ func (m *PutFileRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (dst *PutFileRecord) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutFileRecord.Merge(dst, src)
+func (dst *SwapBranchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapBranchRequest.Merge(dst, src)
 }
-func (m *PutFileRecord) XXX_Size() int {
+func (m *SwapBranchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutFileRecord) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutFileRecord.DiscardUnknown(m)
+func (m *SwapBranchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapBranchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutFileRecord proto.InternalMessageInfo
-
-func (m *PutFileRecord) GetSizeBytes() int64 {
-	if m != nil {
-		return m.SizeBytes
-	}
-	return 0
-}
+var xxx_messageInfo_SwapBranchRequest proto.InternalMessageInfo
 
-func (m *PutFileRecord) GetObjectHash() string {
+func (m *SwapBranchRequest) GetBranch() *Branch {
 	if m != nil {
-		return m.ObjectHash
+		return m.Branch
 	}
-	return ""
+	return nil
 }
 
-func (m *PutFileRecord) GetOverwriteIndex() *OverwriteIndex {
+func (m *SwapBranchRequest) GetOther() *Branch {
 	if m != nil {
-		return m.OverwriteIndex
+		return m.Other
 	}
 	return nil
 }
 
-type PutFileRecords struct {
-	Split                bool             `protobuf:"varint,1,opt,name=split,proto3" json:"split,omitempty"`
-	Records              []*PutFileRecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
-	Tombstone            bool             `protobuf:"varint,3,opt,name=tombstone,proto3" json:"tombstone,omitempty"`
-	Header               *PutFileRecord   `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
-	Footer               *PutFileRecord   `protobuf:"bytes,5,opt,name=footer,proto3" json:"footer,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type DeleteBranchRequest struct {
+	Branch               *Branch  `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PutFileRecords) Reset()         { *m = PutFileRecords{} }
-func (m *PutFileRecords) String() string { return proto.CompactTextString(m) }
-func (*PutFileRecords) ProtoMessage()    {}
-func (*PutFileRecords) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{39}
+func (m *DeleteBranchRequest) Reset()         { *m = DeleteBranchRequest{} }
+func (m *DeleteBranchRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteBranchRequest) ProtoMessage()    {}
+func (*DeleteBranchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{44}
 }
-func (m *PutFileRecords) XXX_Unmarshal(b []byte) error {
+func (m *DeleteBranchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutFileRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteBranchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutFileRecords.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteBranchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2671,74 +3049,51 @@ func (m *PutFileRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (dst *PutFileRecords) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutFileRecords.Merge(dst, src)
+func (dst *DeleteBranchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBranchRequest.Merge(dst, src)
 }
-func (m *PutFileRecords) XXX_Size() int {
+func (m *DeleteBranchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutFileRecords) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutFileRecords.DiscardUnknown(m)
+func (m *DeleteBranchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBranchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutFileRecords proto.InternalMessageInfo
-
-func (m *PutFileRecords) GetSplit() bool {
-	if m != nil {
-		return m.Split
-	}
-	return false
-}
+var xxx_messageInfo_DeleteBranchRequest proto.InternalMessageInfo
 
-func (m *PutFileRecords) GetRecords() []*PutFileRecord {
+func (m *DeleteBranchRequest) GetBranch() *Branch {
 	if m != nil {
-		return m.Records
+		return m.Branch
 	}
 	return nil
 }
 
-func (m *PutFileRecords) GetTombstone() bool {
+func (m *DeleteBranchRequest) GetForce() bool {
 	if m != nil {
-		return m.Tombstone
+		return m.Force
 	}
 	return false
 }
 
-func (m *PutFileRecords) GetHeader() *PutFileRecord {
-	if m != nil {
-		return m.Header
-	}
-	return nil
-}
-
-func (m *PutFileRecords) GetFooter() *PutFileRecord {
-	if m != nil {
-		return m.Footer
-	}
-	return nil
-}
-
-type CopyFileRequest struct {
-	Src                  *File    `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
-	Dst                  *File    `protobuf:"bytes,2,opt,name=dst,proto3" json:"dst,omitempty"`
-	Overwrite            bool     `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+type DeleteCommitRequest struct {
+	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CopyFileRequest) Reset()         { *m = CopyFileRequest{} }
-func (m *CopyFileRequest) String() string { return proto.CompactTextString(m) }
-func (*CopyFileRequest) ProtoMessage()    {}
-func (*CopyFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{40}
+func (m *DeleteCommitRequest) Reset()         { *m = DeleteCommitRequest{} }
+func (m *DeleteCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCommitRequest) ProtoMessage()    {}
+func (*DeleteCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{45}
 }
-func (m *CopyFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *DeleteCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CopyFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CopyFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2748,58 +3103,44 @@ func (m *CopyFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *CopyFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CopyFileRequest.Merge(dst, src)
+func (dst *DeleteCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCommitRequest.Merge(dst, src)
 }
-func (m *CopyFileRequest) XXX_Size() int {
+func (m *DeleteCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CopyFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CopyFileRequest.DiscardUnknown(m)
+func (m *DeleteCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CopyFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteCommitRequest proto.InternalMessageInfo
 
-func (m *CopyFileRequest) GetSrc() *File {
+func (m *DeleteCommitRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.Src
+		return m.Commit
 	}
 	return nil
 }
 
-func (m *CopyFileRequest) GetDst() *File {
-	if m != nil {
-		return m.Dst
-	}
-	return nil
-}
-
-func (m *CopyFileRequest) GetOverwrite() bool {
-	if m != nil {
-		return m.Overwrite
-	}
-	return false
-}
-
-type InspectFileRequest struct {
-	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+type SquashCommitRequest struct {
+	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *InspectFileRequest) Reset()         { *m = InspectFileRequest{} }
-func (m *InspectFileRequest) String() string { return proto.CompactTextString(m) }
-func (*InspectFileRequest) ProtoMessage()    {}
-func (*InspectFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{41}
+func (m *SquashCommitRequest) Reset()         { *m = SquashCommitRequest{} }
+func (m *SquashCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*SquashCommitRequest) ProtoMessage()    {}
+func (*SquashCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{46}
 }
-func (m *InspectFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *SquashCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InspectFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SquashCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InspectFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SquashCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2809,64 +3150,45 @@ func (m *InspectFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *InspectFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InspectFileRequest.Merge(dst, src)
+func (dst *SquashCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SquashCommitRequest.Merge(dst, src)
 }
-func (m *InspectFileRequest) XXX_Size() int {
+func (m *SquashCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *InspectFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_InspectFileRequest.DiscardUnknown(m)
+func (m *SquashCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SquashCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InspectFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_SquashCommitRequest proto.InternalMessageInfo
 
-func (m *InspectFileRequest) GetFile() *File {
+func (m *SquashCommitRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.File
+		return m.Commit
 	}
 	return nil
 }
 
-type ListFileRequest struct {
-	// File is the parent directory of the files we want to list. This sets the
-	// repo, the commit/branch, and path prefix of files we're interested in
-	// If the "path" field is omitted, a list of files at the top level of the repo
-	// is returned
-	File *File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	// Full indicates whether the result should include file contents, which may
-	// be large (i.e. the list of children for directories, and the list of object
-	// references for regular files)
-	Full bool `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
-	// History indicates how many historical versions you want returned. Its
-	// semantics are:
-	// 0: Return the files as they are at the commit in `file`. FileInfo.File
-	//    will equal File in this request.
-	// 1: Return the files as they are in the last commit they were modified in.
-	//    (This will have the same hash as if you'd passed 0, but
-	//    FileInfo.File.Commit will be different.
-	// 2: Return the above and the files as they are in the next-last commit they
-	//    were modified in.
-	// 3: etc.
-	// -1: Return all historical versions.
-	History              int64    `protobuf:"varint,3,opt,name=history,proto3" json:"history,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type FlushCommitRequest struct {
+	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	ToRepos              []*Repo   `protobuf:"bytes,2,rep,name=to_repos,json=toRepos,proto3" json:"to_repos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *ListFileRequest) Reset()         { *m = ListFileRequest{} }
-func (m *ListFileRequest) String() string { return proto.CompactTextString(m) }
-func (*ListFileRequest) ProtoMessage()    {}
-func (*ListFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{42}
+func (m *FlushCommitRequest) Reset()         { *m = FlushCommitRequest{} }
+func (m *FlushCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*FlushCommitRequest) ProtoMessage()    {}
+func (*FlushCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{47}
 }
-func (m *ListFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *FlushCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FlushCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FlushCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2876,58 +3198,56 @@ func (m *ListFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *ListFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListFileRequest.Merge(dst, src)
+func (dst *FlushCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlushCommitRequest.Merge(dst, src)
 }
-func (m *ListFileRequest) XXX_Size() int {
+func (m *FlushCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListFileRequest.DiscardUnknown(m)
+func (m *FlushCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlushCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_FlushCommitRequest proto.InternalMessageInfo
 
-func (m *ListFileRequest) GetFile() *File {
+func (m *FlushCommitRequest) GetCommits() []*Commit {
 	if m != nil {
-		return m.File
+		return m.Commits
 	}
 	return nil
 }
 
-func (m *ListFileRequest) GetFull() bool {
-	if m != nil {
-		return m.Full
-	}
-	return false
-}
-
-func (m *ListFileRequest) GetHistory() int64 {
+func (m *FlushCommitRequest) GetToRepos() []*Repo {
 	if m != nil {
-		return m.History
+		return m.ToRepos
 	}
-	return 0
+	return nil
 }
 
-type WalkFileRequest struct {
-	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type SubscribeCommitRequest struct {
+	Repo   *Repo  `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Branch string `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	// only commits created since this commit are returned
+	From *Commit `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// Don't return commits until they're in (at least) the desired state.
+	State                CommitState `protobuf:"varint,4,opt,name=state,proto3,enum=pfs.CommitState" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *WalkFileRequest) Reset()         { *m = WalkFileRequest{} }
-func (m *WalkFileRequest) String() string { return proto.CompactTextString(m) }
-func (*WalkFileRequest) ProtoMessage()    {}
-func (*WalkFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{43}
+func (m *SubscribeCommitRequest) Reset()         { *m = SubscribeCommitRequest{} }
+func (m *SubscribeCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeCommitRequest) ProtoMessage()    {}
+func (*SubscribeCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{48}
 }
-func (m *WalkFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *SubscribeCommitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *WalkFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SubscribeCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_WalkFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SubscribeCommitRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2937,45 +3257,70 @@ func (m *WalkFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *WalkFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WalkFileRequest.Merge(dst, src)
+func (dst *SubscribeCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeCommitRequest.Merge(dst, src)
 }
-func (m *WalkFileRequest) XXX_Size() int {
+func (m *SubscribeCommitRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *WalkFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WalkFileRequest.DiscardUnknown(m)
+func (m *SubscribeCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeCommitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WalkFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_SubscribeCommitRequest proto.InternalMessageInfo
 
-func (m *WalkFileRequest) GetFile() *File {
+func (m *SubscribeCommitRequest) GetRepo() *Repo {
 	if m != nil {
-		return m.File
+		return m.Repo
 	}
 	return nil
 }
 
-type GlobFileRequest struct {
-	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
-	Pattern              string   `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+func (m *SubscribeCommitRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *SubscribeCommitRequest) GetFrom() *Commit {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *SubscribeCommitRequest) GetState() CommitState {
+	if m != nil {
+		return m.State
+	}
+	return CommitState_STARTED
+}
+
+// GetFileRequest reads a file. Files in open commits of input repos can be
+// read before the commits are finished, as they are so far (see
+// FileInfo.committed), while those in open output commits can't.
+type GetFileRequest struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	OffsetBytes          int64    `protobuf:"varint,2,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
+	SizeBytes            int64    `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GlobFileRequest) Reset()         { *m = GlobFileRequest{} }
-func (m *GlobFileRequest) String() string { return proto.CompactTextString(m) }
-func (*GlobFileRequest) ProtoMessage()    {}
-func (*GlobFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{44}
+func (m *GetFileRequest) Reset()         { *m = GetFileRequest{} }
+func (m *GetFileRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFileRequest) ProtoMessage()    {}
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{49}
 }
-func (m *GlobFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GlobFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GlobFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2985,52 +3330,62 @@ func (m *GlobFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *GlobFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GlobFileRequest.Merge(dst, src)
+func (dst *GetFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileRequest.Merge(dst, src)
 }
-func (m *GlobFileRequest) XXX_Size() int {
+func (m *GetFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GlobFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GlobFileRequest.DiscardUnknown(m)
+func (m *GetFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GlobFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetFileRequest proto.InternalMessageInfo
 
-func (m *GlobFileRequest) GetCommit() *Commit {
+func (m *GetFileRequest) GetFile() *File {
 	if m != nil {
-		return m.Commit
+		return m.File
 	}
 	return nil
 }
 
-func (m *GlobFileRequest) GetPattern() string {
+func (m *GetFileRequest) GetOffsetBytes() int64 {
 	if m != nil {
-		return m.Pattern
+		return m.OffsetBytes
 	}
-	return ""
+	return 0
 }
 
-// FileInfos is the result of both ListFile and GlobFile
-type FileInfos struct {
-	FileInfo             []*FileInfo `protobuf:"bytes,1,rep,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+func (m *GetFileRequest) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
 }
 
-func (m *FileInfos) Reset()         { *m = FileInfos{} }
-func (m *FileInfos) String() string { return proto.CompactTextString(m) }
-func (*FileInfos) ProtoMessage()    {}
-func (*FileInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{45}
+// An OverwriteIndex specifies the index of objects from which new writes
+// are applied to.  Existing objects starting from the index are deleted.
+// We want a separate message for ObjectIndex because we want to be able to
+// distinguish between a zero index and a non-existent index.
+type OverwriteIndex struct {
+	Index                int64    `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *FileInfos) XXX_Unmarshal(b []byte) error {
+
+func (m *OverwriteIndex) Reset()         { *m = OverwriteIndex{} }
+func (m *OverwriteIndex) String() string { return proto.CompactTextString(m) }
+func (*OverwriteIndex) ProtoMessage()    {}
+func (*OverwriteIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{50}
+}
+func (m *OverwriteIndex) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FileInfos) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *OverwriteIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FileInfos.Marshal(b, m, deterministic)
+		return xxx_messageInfo_OverwriteIndex.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3040,48 +3395,78 @@ func (m *FileInfos) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *FileInfos) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FileInfos.Merge(dst, src)
+func (dst *OverwriteIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OverwriteIndex.Merge(dst, src)
 }
-func (m *FileInfos) XXX_Size() int {
+func (m *OverwriteIndex) XXX_Size() int {
 	return m.Size()
 }
-func (m *FileInfos) XXX_DiscardUnknown() {
-	xxx_messageInfo_FileInfos.DiscardUnknown(m)
+func (m *OverwriteIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_OverwriteIndex.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FileInfos proto.InternalMessageInfo
+var xxx_messageInfo_OverwriteIndex proto.InternalMessageInfo
 
-func (m *FileInfos) GetFileInfo() []*FileInfo {
+func (m *OverwriteIndex) GetIndex() int64 {
 	if m != nil {
-		return m.FileInfo
+		return m.Index
 	}
-	return nil
+	return 0
 }
 
-type DiffFileRequest struct {
-	NewFile *File `protobuf:"bytes,1,opt,name=new_file,json=newFile,proto3" json:"new_file,omitempty"`
-	// OldFile may be left nil in which case the same path in the parent of
-	// NewFile's commit will be used.
-	OldFile              *File    `protobuf:"bytes,2,opt,name=old_file,json=oldFile,proto3" json:"old_file,omitempty"`
-	Shallow              bool     `protobuf:"varint,3,opt,name=shallow,proto3" json:"shallow,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type PutFileRequest struct {
+	File  *File  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Url   string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	// applies only to URLs that can be recursively walked, for example s3:// URLs
+	Recursive bool `protobuf:"varint,6,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// Delimiter causes data to be broken up into separate files with File.Path
+	// as a prefix.
+	Delimiter Delimiter `protobuf:"varint,7,opt,name=delimiter,proto3,enum=pfs.Delimiter" json:"delimiter,omitempty"`
+	// TargetFileDatums specifies the target number of datums in each written
+	// file it may be lower if data does not split evenly, but will never be
+	// higher, unless the value is 0.
+	TargetFileDatums int64 `protobuf:"varint,8,opt,name=target_file_datums,json=targetFileDatums,proto3" json:"target_file_datums,omitempty"`
+	// TargetFileBytes specifies the target number of bytes in each written
+	// file, files may have more or fewer bytes than the target.
+	TargetFileBytes int64 `protobuf:"varint,9,opt,name=target_file_bytes,json=targetFileBytes,proto3" json:"target_file_bytes,omitempty"`
+	// header_records is an option for splitting data when 'delimiter' is not NONE
+	// (or SQL). It specifies the number of records that are converted to a
+	// header and applied to all file shards.
+	//
+	// This is particularly useful for CSV files, where the first row often
+	// contains column titles; if 'header_records' is set to one in that case,
+	// the first row will be associated with the directory that contains the rest
+	// of the split-up csv rows as files, and if any data is retrieved from that
+	// directory by GetFile, it will appear to begin with that first row of
+	// column labels (including in pipeline workers).
+	//
+	// Note that SQL files have their own logic for determining headers (their
+	// header is not a number of records, but a collection of SQL commands that
+	// create the relevant tables and such). This way, SQL files retrieved by
+	// GetFile can be passed to psql, and they will set up the appropriate tables
+	// before inserting the records in the files that were retrieved.
+	HeaderRecords int64 `protobuf:"varint,11,opt,name=header_records,json=headerRecords,proto3" json:"header_records,omitempty"`
+	// overwrite_index is the object index where the write starts from.  All
+	// existing objects starting from the index are deleted.
+	OverwriteIndex       *OverwriteIndex `protobuf:"bytes,10,opt,name=overwrite_index,json=overwriteIndex,proto3" json:"overwrite_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *DiffFileRequest) Reset()         { *m = DiffFileRequest{} }
-func (m *DiffFileRequest) String() string { return proto.CompactTextString(m) }
-func (*DiffFileRequest) ProtoMessage()    {}
-func (*DiffFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{46}
+func (m *PutFileRequest) Reset()         { *m = PutFileRequest{} }
+func (m *PutFileRequest) String() string { return proto.CompactTextString(m) }
+func (*PutFileRequest) ProtoMessage()    {}
+func (*PutFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{51}
 }
-func (m *DiffFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *PutFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DiffFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DiffFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3091,113 +3476,107 @@ func (m *DiffFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *DiffFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DiffFileRequest.Merge(dst, src)
+func (dst *PutFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileRequest.Merge(dst, src)
 }
-func (m *DiffFileRequest) XXX_Size() int {
+func (m *PutFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DiffFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DiffFileRequest.DiscardUnknown(m)
+func (m *PutFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DiffFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_PutFileRequest proto.InternalMessageInfo
 
-func (m *DiffFileRequest) GetNewFile() *File {
+func (m *PutFileRequest) GetFile() *File {
 	if m != nil {
-		return m.NewFile
+		return m.File
 	}
 	return nil
 }
 
-func (m *DiffFileRequest) GetOldFile() *File {
+func (m *PutFileRequest) GetValue() []byte {
 	if m != nil {
-		return m.OldFile
+		return m.Value
 	}
 	return nil
 }
 
-func (m *DiffFileRequest) GetShallow() bool {
+func (m *PutFileRequest) GetUrl() string {
 	if m != nil {
-		return m.Shallow
+		return m.Url
 	}
-	return false
+	return ""
 }
 
-type DiffFileResponse struct {
-	NewFiles             []*FileInfo `protobuf:"bytes,1,rep,name=new_files,json=newFiles,proto3" json:"new_files,omitempty"`
-	OldFiles             []*FileInfo `protobuf:"bytes,2,rep,name=old_files,json=oldFiles,proto3" json:"old_files,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+func (m *PutFileRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
 }
 
-func (m *DiffFileResponse) Reset()         { *m = DiffFileResponse{} }
-func (m *DiffFileResponse) String() string { return proto.CompactTextString(m) }
-func (*DiffFileResponse) ProtoMessage()    {}
-func (*DiffFileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{47}
-}
-func (m *DiffFileResponse) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *DiffFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_DiffFileResponse.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *PutFileRequest) GetDelimiter() Delimiter {
+	if m != nil {
+		return m.Delimiter
 	}
+	return Delimiter_NONE
 }
-func (dst *DiffFileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DiffFileResponse.Merge(dst, src)
-}
-func (m *DiffFileResponse) XXX_Size() int {
-	return m.Size()
-}
-func (m *DiffFileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DiffFileResponse.DiscardUnknown(m)
+
+func (m *PutFileRequest) GetTargetFileDatums() int64 {
+	if m != nil {
+		return m.TargetFileDatums
+	}
+	return 0
 }
 
-var xxx_messageInfo_DiffFileResponse proto.InternalMessageInfo
+func (m *PutFileRequest) GetTargetFileBytes() int64 {
+	if m != nil {
+		return m.TargetFileBytes
+	}
+	return 0
+}
 
-func (m *DiffFileResponse) GetNewFiles() []*FileInfo {
+func (m *PutFileRequest) GetHeaderRecords() int64 {
 	if m != nil {
-		return m.NewFiles
+		return m.HeaderRecords
 	}
-	return nil
+	return 0
 }
 
-func (m *DiffFileResponse) GetOldFiles() []*FileInfo {
+func (m *PutFileRequest) GetOverwriteIndex() *OverwriteIndex {
 	if m != nil {
-		return m.OldFiles
+		return m.OverwriteIndex
 	}
 	return nil
 }
 
-type DeleteFileRequest struct {
-	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+type PutFileChunkRequest struct {
+	// file is the file that the chunk is part of. Only the first request of a
+	// stream needs to set it.
+	File  *File  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// upload_id identifies the upload that the chunk is part of, which
+	// PutFileChunks must be given to write the chunk to its file. Only the
+	// first request of a stream needs to set it.
+	UploadId             string   `protobuf:"bytes,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteFileRequest) Reset()         { *m = DeleteFileRequest{} }
-func (m *DeleteFileRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteFileRequest) ProtoMessage()    {}
-func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{48}
+func (m *PutFileChunkRequest) Reset()         { *m = PutFileChunkRequest{} }
+func (m *PutFileChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*PutFileChunkRequest) ProtoMessage()    {}
+func (*PutFileChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{52}
 }
-func (m *DeleteFileRequest) XXX_Unmarshal(b []byte) error {
+func (m *PutFileChunkRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutFileChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteFileRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutFileChunkRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3207,46 +3586,63 @@ func (m *DeleteFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *DeleteFileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFileRequest.Merge(dst, src)
+func (dst *PutFileChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileChunkRequest.Merge(dst, src)
 }
-func (m *DeleteFileRequest) XXX_Size() int {
+func (m *PutFileChunkRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteFileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFileRequest.DiscardUnknown(m)
+func (m *PutFileChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileChunkRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFileRequest proto.InternalMessageInfo
+var xxx_messageInfo_PutFileChunkRequest proto.InternalMessageInfo
 
-func (m *DeleteFileRequest) GetFile() *File {
+func (m *PutFileChunkRequest) GetFile() *File {
 	if m != nil {
 		return m.File
 	}
 	return nil
 }
 
-type PutObjectRequest struct {
-	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Tags                 []*Tag   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
-	Block                *Block   `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+func (m *PutFileChunkRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *PutFileChunkRequest) GetUploadId() string {
+	if m != nil {
+		return m.UploadId
+	}
+	return ""
+}
+
+// FileChunk is a chunk of a file's content, stored by PutFileChunk
+type FileChunk struct {
+	// offset_bytes is where the chunk starts in the file. It's set by the
+	// client, as PutFileChunk doesn't know where its chunk goes.
+	OffsetBytes          int64    `protobuf:"varint,1,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
+	SizeBytes            int64    `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Object               *Object  `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PutObjectRequest) Reset()         { *m = PutObjectRequest{} }
-func (m *PutObjectRequest) String() string { return proto.CompactTextString(m) }
-func (*PutObjectRequest) ProtoMessage()    {}
-func (*PutObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{49}
+func (m *FileChunk) Reset()         { *m = FileChunk{} }
+func (m *FileChunk) String() string { return proto.CompactTextString(m) }
+func (*FileChunk) ProtoMessage()    {}
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{53}
 }
-func (m *PutObjectRequest) XXX_Unmarshal(b []byte) error {
+func (m *FileChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutObjectRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FileChunk.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3256,65 +3652,68 @@ func (m *PutObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (dst *PutObjectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutObjectRequest.Merge(dst, src)
+func (dst *FileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChunk.Merge(dst, src)
 }
-func (m *PutObjectRequest) XXX_Size() int {
+func (m *FileChunk) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutObjectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutObjectRequest.DiscardUnknown(m)
+func (m *FileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChunk.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutObjectRequest proto.InternalMessageInfo
+var xxx_messageInfo_FileChunk proto.InternalMessageInfo
 
-func (m *PutObjectRequest) GetValue() []byte {
+func (m *FileChunk) GetOffsetBytes() int64 {
 	if m != nil {
-		return m.Value
+		return m.OffsetBytes
 	}
-	return nil
+	return 0
 }
 
-func (m *PutObjectRequest) GetTags() []*Tag {
+func (m *FileChunk) GetSizeBytes() int64 {
 	if m != nil {
-		return m.Tags
+		return m.SizeBytes
 	}
-	return nil
+	return 0
 }
 
-func (m *PutObjectRequest) GetBlock() *Block {
+func (m *FileChunk) GetObject() *Object {
 	if m != nil {
-		return m.Block
+		return m.Object
 	}
 	return nil
 }
 
-type GetObjectsRequest struct {
-	Objects     []*Object `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
-	OffsetBytes uint64    `protobuf:"varint,2,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
-	// The number of bytes requested.
-	SizeBytes uint64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	// The total amount of bytes in these objects.  It's OK if it's not
-	// entirely accurate or if it's unknown (in which case it'd be set to 0).
-	// It's used primarily as a hint for cache eviction.
-	TotalSize            uint64   `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+type PutFileChunksRequest struct {
+	File *File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// chunks may be in any order, but their offsets and sizes must cover the
+	// file's content exactly, from offset 0. They must have been stored by
+	// PutFileChunk in the file's repo.
+	Chunks []*FileChunk `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	// overwrite, if set, replaces the file's existing content rather than
+	// appending to it
+	Overwrite bool `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	// upload_id is the upload that the chunks were stored in by
+	// PutFileChunk
+	UploadId             string   `protobuf:"bytes,4,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetObjectsRequest) Reset()         { *m = GetObjectsRequest{} }
-func (m *GetObjectsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetObjectsRequest) ProtoMessage()    {}
-func (*GetObjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{50}
+func (m *PutFileChunksRequest) Reset()         { *m = PutFileChunksRequest{} }
+func (m *PutFileChunksRequest) String() string { return proto.CompactTextString(m) }
+func (*PutFileChunksRequest) ProtoMessage()    {}
+func (*PutFileChunksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{54}
 }
-func (m *GetObjectsRequest) XXX_Unmarshal(b []byte) error {
+func (m *PutFileChunksRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutFileChunksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetObjectsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutFileChunksRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3324,72 +3723,68 @@ func (m *GetObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *GetObjectsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetObjectsRequest.Merge(dst, src)
+func (dst *PutFileChunksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileChunksRequest.Merge(dst, src)
 }
-func (m *GetObjectsRequest) XXX_Size() int {
+func (m *PutFileChunksRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetObjectsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetObjectsRequest.DiscardUnknown(m)
+func (m *PutFileChunksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileChunksRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetObjectsRequest proto.InternalMessageInfo
+var xxx_messageInfo_PutFileChunksRequest proto.InternalMessageInfo
 
-func (m *GetObjectsRequest) GetObjects() []*Object {
+func (m *PutFileChunksRequest) GetFile() *File {
 	if m != nil {
-		return m.Objects
+		return m.File
 	}
 	return nil
 }
 
-func (m *GetObjectsRequest) GetOffsetBytes() uint64 {
+func (m *PutFileChunksRequest) GetChunks() []*FileChunk {
 	if m != nil {
-		return m.OffsetBytes
+		return m.Chunks
 	}
-	return 0
+	return nil
 }
 
-func (m *GetObjectsRequest) GetSizeBytes() uint64 {
+func (m *PutFileChunksRequest) GetOverwrite() bool {
 	if m != nil {
-		return m.SizeBytes
+		return m.Overwrite
 	}
-	return 0
+	return false
 }
 
-func (m *GetObjectsRequest) GetTotalSize() uint64 {
+func (m *PutFileChunksRequest) GetUploadId() string {
 	if m != nil {
-		return m.TotalSize
+		return m.UploadId
 	}
-	return 0
+	return ""
 }
 
-type GetBlocksRequest struct {
-	BlockRefs   []*BlockRef `protobuf:"bytes,1,rep,name=blockRefs,proto3" json:"blockRefs,omitempty"`
-	OffsetBytes uint64      `protobuf:"varint,2,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
-	// The number of bytes requested.
-	SizeBytes uint64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	// The total amount of bytes in these blocks.  It's OK if it's not
-	// entirely accurate or if it's unknown (in which case it'd be set to 0).
-	// It's used primarily as a hint for cache eviction.
-	TotalSize            uint64   `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// PutFileRecord is used to record PutFile requests in etcd temporarily.
+type PutFileRecord struct {
+	SizeBytes            int64           `protobuf:"varint,1,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	ObjectHash           string          `protobuf:"bytes,2,opt,name=object_hash,json=objectHash,proto3" json:"object_hash,omitempty"`
+	OverwriteIndex       *OverwriteIndex `protobuf:"bytes,3,opt,name=overwrite_index,json=overwriteIndex,proto3" json:"overwrite_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetBlocksRequest) Reset()         { *m = GetBlocksRequest{} }
-func (m *GetBlocksRequest) String() string { return proto.CompactTextString(m) }
-func (*GetBlocksRequest) ProtoMessage()    {}
-func (*GetBlocksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{51}
+func (m *PutFileRecord) Reset()         { *m = PutFileRecord{} }
+func (m *PutFileRecord) String() string { return proto.CompactTextString(m) }
+func (*PutFileRecord) ProtoMessage()    {}
+func (*PutFileRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{55}
 }
-func (m *GetBlocksRequest) XXX_Unmarshal(b []byte) error {
+func (m *PutFileRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutFileRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetBlocksRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutFileRecord.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3399,66 +3794,62 @@ func (m *GetBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (dst *GetBlocksRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBlocksRequest.Merge(dst, src)
+func (dst *PutFileRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileRecord.Merge(dst, src)
 }
-func (m *GetBlocksRequest) XXX_Size() int {
+func (m *PutFileRecord) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetBlocksRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBlocksRequest.DiscardUnknown(m)
+func (m *PutFileRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBlocksRequest proto.InternalMessageInfo
-
-func (m *GetBlocksRequest) GetBlockRefs() []*BlockRef {
-	if m != nil {
-		return m.BlockRefs
-	}
-	return nil
-}
+var xxx_messageInfo_PutFileRecord proto.InternalMessageInfo
 
-func (m *GetBlocksRequest) GetOffsetBytes() uint64 {
+func (m *PutFileRecord) GetSizeBytes() int64 {
 	if m != nil {
-		return m.OffsetBytes
+		return m.SizeBytes
 	}
 	return 0
 }
 
-func (m *GetBlocksRequest) GetSizeBytes() uint64 {
+func (m *PutFileRecord) GetObjectHash() string {
 	if m != nil {
-		return m.SizeBytes
+		return m.ObjectHash
 	}
-	return 0
+	return ""
 }
 
-func (m *GetBlocksRequest) GetTotalSize() uint64 {
+func (m *PutFileRecord) GetOverwriteIndex() *OverwriteIndex {
 	if m != nil {
-		return m.TotalSize
+		return m.OverwriteIndex
 	}
-	return 0
+	return nil
 }
 
-type TagObjectRequest struct {
-	Object               *Object  `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
-	Tags                 []*Tag   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type PutFileRecords struct {
+	Split                bool             `protobuf:"varint,1,opt,name=split,proto3" json:"split,omitempty"`
+	Records              []*PutFileRecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	Tombstone            bool             `protobuf:"varint,3,opt,name=tombstone,proto3" json:"tombstone,omitempty"`
+	Header               *PutFileRecord   `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
+	Footer               *PutFileRecord   `protobuf:"bytes,5,opt,name=footer,proto3" json:"footer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *TagObjectRequest) Reset()         { *m = TagObjectRequest{} }
-func (m *TagObjectRequest) String() string { return proto.CompactTextString(m) }
-func (*TagObjectRequest) ProtoMessage()    {}
-func (*TagObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{52}
+func (m *PutFileRecords) Reset()         { *m = PutFileRecords{} }
+func (m *PutFileRecords) String() string { return proto.CompactTextString(m) }
+func (*PutFileRecords) ProtoMessage()    {}
+func (*PutFileRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{56}
 }
-func (m *TagObjectRequest) XXX_Unmarshal(b []byte) error {
+func (m *PutFileRecords) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TagObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutFileRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TagObjectRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutFileRecords.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3468,91 +3859,74 @@ func (m *TagObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (dst *TagObjectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TagObjectRequest.Merge(dst, src)
+func (dst *PutFileRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileRecords.Merge(dst, src)
 }
-func (m *TagObjectRequest) XXX_Size() int {
+func (m *PutFileRecords) XXX_Size() int {
 	return m.Size()
 }
-func (m *TagObjectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TagObjectRequest.DiscardUnknown(m)
+func (m *PutFileRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileRecords.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TagObjectRequest proto.InternalMessageInfo
+var xxx_messageInfo_PutFileRecords proto.InternalMessageInfo
 
-func (m *TagObjectRequest) GetObject() *Object {
+func (m *PutFileRecords) GetSplit() bool {
 	if m != nil {
-		return m.Object
+		return m.Split
 	}
-	return nil
+	return false
 }
 
-func (m *TagObjectRequest) GetTags() []*Tag {
+func (m *PutFileRecords) GetRecords() []*PutFileRecord {
 	if m != nil {
-		return m.Tags
+		return m.Records
 	}
 	return nil
 }
 
-type ListObjectsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PutFileRecords) GetTombstone() bool {
+	if m != nil {
+		return m.Tombstone
+	}
+	return false
 }
 
-func (m *ListObjectsRequest) Reset()         { *m = ListObjectsRequest{} }
-func (m *ListObjectsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListObjectsRequest) ProtoMessage()    {}
-func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{53}
-}
-func (m *ListObjectsRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ListObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ListObjectsRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *PutFileRecords) GetHeader() *PutFileRecord {
+	if m != nil {
+		return m.Header
 	}
-}
-func (dst *ListObjectsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListObjectsRequest.Merge(dst, src)
-}
-func (m *ListObjectsRequest) XXX_Size() int {
-	return m.Size()
-}
-func (m *ListObjectsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListObjectsRequest.DiscardUnknown(m)
+	return nil
 }
 
-var xxx_messageInfo_ListObjectsRequest proto.InternalMessageInfo
+func (m *PutFileRecords) GetFooter() *PutFileRecord {
+	if m != nil {
+		return m.Footer
+	}
+	return nil
+}
 
-type ListTagsRequest struct {
-	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	IncludeObject        bool     `protobuf:"varint,2,opt,name=include_object,json=includeObject,proto3" json:"include_object,omitempty"`
+type CopyFileRequest struct {
+	Src                  *File    `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
+	Dst                  *File    `protobuf:"bytes,2,opt,name=dst,proto3" json:"dst,omitempty"`
+	Overwrite            bool     `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListTagsRequest) Reset()         { *m = ListTagsRequest{} }
-func (m *ListTagsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListTagsRequest) ProtoMessage()    {}
-func (*ListTagsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{54}
+func (m *CopyFileRequest) Reset()         { *m = CopyFileRequest{} }
+func (m *CopyFileRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyFileRequest) ProtoMessage()    {}
+func (*CopyFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{57}
 }
-func (m *ListTagsRequest) XXX_Unmarshal(b []byte) error {
+func (m *CopyFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CopyFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListTagsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CopyFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3562,52 +3936,58 @@ func (m *ListTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *ListTagsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListTagsRequest.Merge(dst, src)
+func (dst *CopyFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyFileRequest.Merge(dst, src)
 }
-func (m *ListTagsRequest) XXX_Size() int {
+func (m *CopyFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListTagsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListTagsRequest.DiscardUnknown(m)
+func (m *CopyFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListTagsRequest proto.InternalMessageInfo
+var xxx_messageInfo_CopyFileRequest proto.InternalMessageInfo
 
-func (m *ListTagsRequest) GetPrefix() string {
+func (m *CopyFileRequest) GetSrc() *File {
 	if m != nil {
-		return m.Prefix
+		return m.Src
 	}
-	return ""
+	return nil
 }
 
-func (m *ListTagsRequest) GetIncludeObject() bool {
+func (m *CopyFileRequest) GetDst() *File {
 	if m != nil {
-		return m.IncludeObject
+		return m.Dst
+	}
+	return nil
+}
+
+func (m *CopyFileRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
 	}
 	return false
 }
 
-type ListTagsResponse struct {
-	Tag                  *Tag     `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Object               *Object  `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+type InspectFileRequest struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListTagsResponse) Reset()         { *m = ListTagsResponse{} }
-func (m *ListTagsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListTagsResponse) ProtoMessage()    {}
-func (*ListTagsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{55}
+func (m *InspectFileRequest) Reset()         { *m = InspectFileRequest{} }
+func (m *InspectFileRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectFileRequest) ProtoMessage()    {}
+func (*InspectFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{58}
 }
-func (m *ListTagsResponse) XXX_Unmarshal(b []byte) error {
+func (m *InspectFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ListTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InspectFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ListTagsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InspectFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3617,51 +3997,64 @@ func (m *ListTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (dst *ListTagsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListTagsResponse.Merge(dst, src)
+func (dst *InspectFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectFileRequest.Merge(dst, src)
 }
-func (m *ListTagsResponse) XXX_Size() int {
+func (m *InspectFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ListTagsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListTagsResponse.DiscardUnknown(m)
+func (m *InspectFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListTagsResponse proto.InternalMessageInfo
+var xxx_messageInfo_InspectFileRequest proto.InternalMessageInfo
 
-func (m *ListTagsResponse) GetTag() *Tag {
+func (m *InspectFileRequest) GetFile() *File {
 	if m != nil {
-		return m.Tag
+		return m.File
 	}
 	return nil
 }
 
-func (m *ListTagsResponse) GetObject() *Object {
-	if m != nil {
-		return m.Object
-	}
-	return nil
-}
-
-type DeleteObjectsRequest struct {
-	Objects              []*Object `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ListFileRequest struct {
+	// File is the parent directory of the files we want to list. This sets the
+	// repo, the commit/branch, and path prefix of files we're interested in
+	// If the "path" field is omitted, a list of files at the top level of the repo
+	// is returned
+	File *File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// Full indicates whether the result should include file contents, which may
+	// be large (i.e. the list of children for directories, and the list of object
+	// references for regular files)
+	Full bool `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	// History indicates how many historical versions you want returned. Its
+	// semantics are:
+	// 0: Return the files as they are at the commit in `file`. FileInfo.File
+	//    will equal File in this request.
+	// 1: Return the files as they are in the last commit they were modified in.
+	//    (This will have the same hash as if you'd passed 0, but
+	//    FileInfo.File.Commit will be different.
+	// 2: Return the above and the files as they are in the next-last commit they
+	//    were modified in.
+	// 3: etc.
+	// -1: Return all historical versions.
+	History              int64    `protobuf:"varint,3,opt,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteObjectsRequest) Reset()         { *m = DeleteObjectsRequest{} }
-func (m *DeleteObjectsRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteObjectsRequest) ProtoMessage()    {}
-func (*DeleteObjectsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{56}
+func (m *ListFileRequest) Reset()         { *m = ListFileRequest{} }
+func (m *ListFileRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFileRequest) ProtoMessage()    {}
+func (*ListFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{59}
 }
-func (m *DeleteObjectsRequest) XXX_Unmarshal(b []byte) error {
+func (m *ListFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteObjectsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3671,43 +4064,58 @@ func (m *DeleteObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (dst *DeleteObjectsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteObjectsRequest.Merge(dst, src)
+func (dst *ListFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFileRequest.Merge(dst, src)
 }
-func (m *DeleteObjectsRequest) XXX_Size() int {
+func (m *ListFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteObjectsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteObjectsRequest.DiscardUnknown(m)
+func (m *ListFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteObjectsRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListFileRequest proto.InternalMessageInfo
 
-func (m *DeleteObjectsRequest) GetObjects() []*Object {
+func (m *ListFileRequest) GetFile() *File {
 	if m != nil {
-		return m.Objects
+		return m.File
 	}
 	return nil
 }
 
-type DeleteObjectsResponse struct {
+func (m *ListFileRequest) GetFull() bool {
+	if m != nil {
+		return m.Full
+	}
+	return false
+}
+
+func (m *ListFileRequest) GetHistory() int64 {
+	if m != nil {
+		return m.History
+	}
+	return 0
+}
+
+type WalkFileRequest struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteObjectsResponse) Reset()         { *m = DeleteObjectsResponse{} }
-func (m *DeleteObjectsResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteObjectsResponse) ProtoMessage()    {}
-func (*DeleteObjectsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{57}
+func (m *WalkFileRequest) Reset()         { *m = WalkFileRequest{} }
+func (m *WalkFileRequest) String() string { return proto.CompactTextString(m) }
+func (*WalkFileRequest) ProtoMessage()    {}
+func (*WalkFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{60}
 }
-func (m *DeleteObjectsResponse) XXX_Unmarshal(b []byte) error {
+func (m *WalkFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteObjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *WalkFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteObjectsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_WalkFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3717,37 +4125,45 @@ func (m *DeleteObjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (dst *DeleteObjectsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteObjectsResponse.Merge(dst, src)
+func (dst *WalkFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalkFileRequest.Merge(dst, src)
 }
-func (m *DeleteObjectsResponse) XXX_Size() int {
+func (m *WalkFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteObjectsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteObjectsResponse.DiscardUnknown(m)
+func (m *WalkFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalkFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteObjectsResponse proto.InternalMessageInfo
+var xxx_messageInfo_WalkFileRequest proto.InternalMessageInfo
 
-type DeleteTagsRequest struct {
-	Tags                 []*Tag   `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+func (m *WalkFileRequest) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+type GlobFileRequest struct {
+	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Pattern              string   `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteTagsRequest) Reset()         { *m = DeleteTagsRequest{} }
-func (m *DeleteTagsRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteTagsRequest) ProtoMessage()    {}
-func (*DeleteTagsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{58}
+func (m *GlobFileRequest) Reset()         { *m = GlobFileRequest{} }
+func (m *GlobFileRequest) String() string { return proto.CompactTextString(m) }
+func (*GlobFileRequest) ProtoMessage()    {}
+func (*GlobFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{61}
 }
-func (m *DeleteTagsRequest) XXX_Unmarshal(b []byte) error {
+func (m *GlobFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GlobFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteTagsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GlobFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3757,43 +4173,52 @@ func (m *DeleteTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *DeleteTagsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteTagsRequest.Merge(dst, src)
+func (dst *GlobFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GlobFileRequest.Merge(dst, src)
 }
-func (m *DeleteTagsRequest) XXX_Size() int {
+func (m *GlobFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteTagsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteTagsRequest.DiscardUnknown(m)
+func (m *GlobFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GlobFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteTagsRequest proto.InternalMessageInfo
+var xxx_messageInfo_GlobFileRequest proto.InternalMessageInfo
 
-func (m *DeleteTagsRequest) GetTags() []*Tag {
+func (m *GlobFileRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.Tags
+		return m.Commit
 	}
 	return nil
 }
 
-type DeleteTagsResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GlobFileRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
 }
 
-func (m *DeleteTagsResponse) Reset()         { *m = DeleteTagsResponse{} }
-func (m *DeleteTagsResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteTagsResponse) ProtoMessage()    {}
-func (*DeleteTagsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{59}
+// FileInfos is the result of both ListFile and GlobFile
+type FileInfos struct {
+	FileInfo             []*FileInfo `protobuf:"bytes,1,rep,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
-func (m *DeleteTagsResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *FileInfos) Reset()         { *m = FileInfos{} }
+func (m *FileInfos) String() string { return proto.CompactTextString(m) }
+func (*FileInfos) ProtoMessage()    {}
+func (*FileInfos) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{62}
+}
+func (m *FileInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FileInfos) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteTagsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FileInfos.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3803,37 +4228,48 @@ func (m *DeleteTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *DeleteTagsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteTagsResponse.Merge(dst, src)
+func (dst *FileInfos) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileInfos.Merge(dst, src)
 }
-func (m *DeleteTagsResponse) XXX_Size() int {
+func (m *FileInfos) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteTagsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteTagsResponse.DiscardUnknown(m)
+func (m *FileInfos) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileInfos.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteTagsResponse proto.InternalMessageInfo
+var xxx_messageInfo_FileInfos proto.InternalMessageInfo
 
-type CheckObjectRequest struct {
-	Object               *Object  `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+func (m *FileInfos) GetFileInfo() []*FileInfo {
+	if m != nil {
+		return m.FileInfo
+	}
+	return nil
+}
+
+type DiffFileRequest struct {
+	NewFile *File `protobuf:"bytes,1,opt,name=new_file,json=newFile,proto3" json:"new_file,omitempty"`
+	// OldFile may be left nil in which case the same path in the parent of
+	// NewFile's commit will be used.
+	OldFile              *File    `protobuf:"bytes,2,opt,name=old_file,json=oldFile,proto3" json:"old_file,omitempty"`
+	Shallow              bool     `protobuf:"varint,3,opt,name=shallow,proto3" json:"shallow,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CheckObjectRequest) Reset()         { *m = CheckObjectRequest{} }
-func (m *CheckObjectRequest) String() string { return proto.CompactTextString(m) }
-func (*CheckObjectRequest) ProtoMessage()    {}
-func (*CheckObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{60}
+func (m *DiffFileRequest) Reset()         { *m = DiffFileRequest{} }
+func (m *DiffFileRequest) String() string { return proto.CompactTextString(m) }
+func (*DiffFileRequest) ProtoMessage()    {}
+func (*DiffFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{63}
 }
-func (m *CheckObjectRequest) XXX_Unmarshal(b []byte) error {
+func (m *DiffFileRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CheckObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DiffFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CheckObjectRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DiffFileRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3843,44 +4279,59 @@ func (m *CheckObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *CheckObjectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckObjectRequest.Merge(dst, src)
+func (dst *DiffFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffFileRequest.Merge(dst, src)
 }
-func (m *CheckObjectRequest) XXX_Size() int {
+func (m *DiffFileRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CheckObjectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckObjectRequest.DiscardUnknown(m)
+func (m *DiffFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckObjectRequest proto.InternalMessageInfo
+var xxx_messageInfo_DiffFileRequest proto.InternalMessageInfo
 
-func (m *CheckObjectRequest) GetObject() *Object {
+func (m *DiffFileRequest) GetNewFile() *File {
 	if m != nil {
-		return m.Object
+		return m.NewFile
 	}
 	return nil
 }
 
-type CheckObjectResponse struct {
-	Exists               bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *DiffFileRequest) GetOldFile() *File {
+	if m != nil {
+		return m.OldFile
+	}
+	return nil
 }
 
-func (m *CheckObjectResponse) Reset()         { *m = CheckObjectResponse{} }
-func (m *CheckObjectResponse) String() string { return proto.CompactTextString(m) }
-func (*CheckObjectResponse) ProtoMessage()    {}
-func (*CheckObjectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{61}
+func (m *DiffFileRequest) GetShallow() bool {
+	if m != nil {
+		return m.Shallow
+	}
+	return false
 }
-func (m *CheckObjectResponse) XXX_Unmarshal(b []byte) error {
+
+type DiffFileResponse struct {
+	NewFiles             []*FileInfo `protobuf:"bytes,1,rep,name=new_files,json=newFiles,proto3" json:"new_files,omitempty"`
+	OldFiles             []*FileInfo `protobuf:"bytes,2,rep,name=old_files,json=oldFiles,proto3" json:"old_files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DiffFileResponse) Reset()         { *m = DiffFileResponse{} }
+func (m *DiffFileResponse) String() string { return proto.CompactTextString(m) }
+func (*DiffFileResponse) ProtoMessage()    {}
+func (*DiffFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{64}
+}
+func (m *DiffFileResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CheckObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DiffFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CheckObjectResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DiffFileResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3890,44 +4341,51 @@ func (m *CheckObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *CheckObjectResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckObjectResponse.Merge(dst, src)
+func (dst *DiffFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffFileResponse.Merge(dst, src)
 }
-func (m *CheckObjectResponse) XXX_Size() int {
+func (m *DiffFileResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *CheckObjectResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckObjectResponse.DiscardUnknown(m)
+func (m *DiffFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffFileResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckObjectResponse proto.InternalMessageInfo
+var xxx_messageInfo_DiffFileResponse proto.InternalMessageInfo
 
-func (m *CheckObjectResponse) GetExists() bool {
+func (m *DiffFileResponse) GetNewFiles() []*FileInfo {
 	if m != nil {
-		return m.Exists
+		return m.NewFiles
 	}
-	return false
+	return nil
 }
 
-type Objects struct {
-	Objects              []*Object `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *DiffFileResponse) GetOldFiles() []*FileInfo {
+	if m != nil {
+		return m.OldFiles
+	}
+	return nil
 }
 
-func (m *Objects) Reset()         { *m = Objects{} }
-func (m *Objects) String() string { return proto.CompactTextString(m) }
-func (*Objects) ProtoMessage()    {}
-func (*Objects) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{62}
+type GetCommitTreeRequest struct {
+	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *Objects) XXX_Unmarshal(b []byte) error {
+
+func (m *GetCommitTreeRequest) Reset()         { *m = GetCommitTreeRequest{} }
+func (m *GetCommitTreeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCommitTreeRequest) ProtoMessage()    {}
+func (*GetCommitTreeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{65}
+}
+func (m *GetCommitTreeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Objects) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetCommitTreeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Objects.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetCommitTreeRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3937,45 +4395,50 @@ func (m *Objects) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Objects) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Objects.Merge(dst, src)
+func (dst *GetCommitTreeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCommitTreeRequest.Merge(dst, src)
 }
-func (m *Objects) XXX_Size() int {
+func (m *GetCommitTreeRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Objects) XXX_DiscardUnknown() {
-	xxx_messageInfo_Objects.DiscardUnknown(m)
+func (m *GetCommitTreeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCommitTreeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Objects proto.InternalMessageInfo
+var xxx_messageInfo_GetCommitTreeRequest proto.InternalMessageInfo
 
-func (m *Objects) GetObjects() []*Object {
+func (m *GetCommitTreeRequest) GetCommit() *Commit {
 	if m != nil {
-		return m.Objects
+		return m.Commit
 	}
 	return nil
 }
 
-type ObjectIndex struct {
-	Objects              map[string]*BlockRef `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Tags                 map[string]*Object   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+// TreeNode is a file or directory in the merkle tree of a commit's files
+type TreeNode struct {
+	Path     string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	FileType FileType `protobuf:"varint,2,opt,name=file_type,json=fileType,proto3,enum=pfs.FileType" json:"file_type,omitempty"`
+	// hash is a hash of the node's contents, which, for a directory, includes
+	// the hashes of its children, so subtrees with the same hash are the same
+	Hash                 []byte   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	SizeBytes            uint64   `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ObjectIndex) Reset()         { *m = ObjectIndex{} }
-func (m *ObjectIndex) String() string { return proto.CompactTextString(m) }
-func (*ObjectIndex) ProtoMessage()    {}
-func (*ObjectIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pfs_01fe324dfe67e43e, []int{63}
+func (m *TreeNode) Reset()         { *m = TreeNode{} }
+func (m *TreeNode) String() string { return proto.CompactTextString(m) }
+func (*TreeNode) ProtoMessage()    {}
+func (*TreeNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{66}
 }
-func (m *ObjectIndex) XXX_Unmarshal(b []byte) error {
+func (m *TreeNode) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ObjectIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TreeNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ObjectIndex.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TreeNode.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3985,1544 +4448,1570 @@ func (m *ObjectIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (dst *ObjectIndex) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ObjectIndex.Merge(dst, src)
+func (dst *TreeNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TreeNode.Merge(dst, src)
 }
-func (m *ObjectIndex) XXX_Size() int {
+func (m *TreeNode) XXX_Size() int {
 	return m.Size()
 }
-func (m *ObjectIndex) XXX_DiscardUnknown() {
-	xxx_messageInfo_ObjectIndex.DiscardUnknown(m)
+func (m *TreeNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_TreeNode.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ObjectIndex proto.InternalMessageInfo
+var xxx_messageInfo_TreeNode proto.InternalMessageInfo
 
-func (m *ObjectIndex) GetObjects() map[string]*BlockRef {
+func (m *TreeNode) GetPath() string {
 	if m != nil {
-		return m.Objects
+		return m.Path
 	}
-	return nil
+	return ""
 }
 
-func (m *ObjectIndex) GetTags() map[string]*Object {
+func (m *TreeNode) GetFileType() FileType {
 	if m != nil {
-		return m.Tags
+		return m.FileType
+	}
+	return FileType_RESERVED
+}
+
+func (m *TreeNode) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Repo)(nil), "pfs.Repo")
-	proto.RegisterType((*Branch)(nil), "pfs.Branch")
-	proto.RegisterType((*BranchInfo)(nil), "pfs.BranchInfo")
-	proto.RegisterType((*BranchInfos)(nil), "pfs.BranchInfos")
-	proto.RegisterType((*File)(nil), "pfs.File")
-	proto.RegisterType((*Block)(nil), "pfs.Block")
-	proto.RegisterType((*Object)(nil), "pfs.Object")
-	proto.RegisterType((*Tag)(nil), "pfs.Tag")
-	proto.RegisterType((*RepoInfo)(nil), "pfs.RepoInfo")
-	proto.RegisterType((*RepoAuthInfo)(nil), "pfs.RepoAuthInfo")
-	proto.RegisterType((*Commit)(nil), "pfs.Commit")
-	proto.RegisterType((*CommitRange)(nil), "pfs.CommitRange")
-	proto.RegisterType((*CommitInfo)(nil), "pfs.CommitInfo")
-	proto.RegisterType((*FileInfo)(nil), "pfs.FileInfo")
-	proto.RegisterType((*ByteRange)(nil), "pfs.ByteRange")
-	proto.RegisterType((*BlockRef)(nil), "pfs.BlockRef")
-	proto.RegisterType((*ObjectInfo)(nil), "pfs.ObjectInfo")
-	proto.RegisterType((*CreateRepoRequest)(nil), "pfs.CreateRepoRequest")
-	proto.RegisterType((*InspectRepoRequest)(nil), "pfs.InspectRepoRequest")
-	proto.RegisterType((*ListRepoRequest)(nil), "pfs.ListRepoRequest")
-	proto.RegisterType((*ListRepoResponse)(nil), "pfs.ListRepoResponse")
-	proto.RegisterType((*DeleteRepoRequest)(nil), "pfs.DeleteRepoRequest")
-	proto.RegisterType((*StartCommitRequest)(nil), "pfs.StartCommitRequest")
-	proto.RegisterType((*BuildCommitRequest)(nil), "pfs.BuildCommitRequest")
-	proto.RegisterType((*FinishCommitRequest)(nil), "pfs.FinishCommitRequest")
-	proto.RegisterType((*InspectCommitRequest)(nil), "pfs.InspectCommitRequest")
-	proto.RegisterType((*ListCommitRequest)(nil), "pfs.ListCommitRequest")
-	proto.RegisterType((*CommitInfos)(nil), "pfs.CommitInfos")
-	proto.RegisterType((*CreateBranchRequest)(nil), "pfs.CreateBranchRequest")
-	proto.RegisterType((*InspectBranchRequest)(nil), "pfs.InspectBranchRequest")
-	proto.RegisterType((*ListBranchRequest)(nil), "pfs.ListBranchRequest")
-	proto.RegisterType((*DeleteBranchRequest)(nil), "pfs.DeleteBranchRequest")
-	proto.RegisterType((*DeleteCommitRequest)(nil), "pfs.DeleteCommitRequest")
-	proto.RegisterType((*FlushCommitRequest)(nil), "pfs.FlushCommitRequest")
-	proto.RegisterType((*SubscribeCommitRequest)(nil), "pfs.SubscribeCommitRequest")
-	proto.RegisterType((*GetFileRequest)(nil), "pfs.GetFileRequest")
-	proto.RegisterType((*OverwriteIndex)(nil), "pfs.OverwriteIndex")
-	proto.RegisterType((*PutFileRequest)(nil), "pfs.PutFileRequest")
-	proto.RegisterType((*PutFileRecord)(nil), "pfs.PutFileRecord")
-	proto.RegisterType((*PutFileRecords)(nil), "pfs.PutFileRecords")
-	proto.RegisterType((*CopyFileRequest)(nil), "pfs.CopyFileRequest")
-	proto.RegisterType((*InspectFileRequest)(nil), "pfs.InspectFileRequest")
-	proto.RegisterType((*ListFileRequest)(nil), "pfs.ListFileRequest")
-	proto.RegisterType((*WalkFileRequest)(nil), "pfs.WalkFileRequest")
-	proto.RegisterType((*GlobFileRequest)(nil), "pfs.GlobFileRequest")
-	proto.RegisterType((*FileInfos)(nil), "pfs.FileInfos")
-	proto.RegisterType((*DiffFileRequest)(nil), "pfs.DiffFileRequest")
-	proto.RegisterType((*DiffFileResponse)(nil), "pfs.DiffFileResponse")
-	proto.RegisterType((*DeleteFileRequest)(nil), "pfs.DeleteFileRequest")
-	proto.RegisterType((*PutObjectRequest)(nil), "pfs.PutObjectRequest")
-	proto.RegisterType((*GetObjectsRequest)(nil), "pfs.GetObjectsRequest")
-	proto.RegisterType((*GetBlocksRequest)(nil), "pfs.GetBlocksRequest")
-	proto.RegisterType((*TagObjectRequest)(nil), "pfs.TagObjectRequest")
-	proto.RegisterType((*ListObjectsRequest)(nil), "pfs.ListObjectsRequest")
-	proto.RegisterType((*ListTagsRequest)(nil), "pfs.ListTagsRequest")
-	proto.RegisterType((*ListTagsResponse)(nil), "pfs.ListTagsResponse")
-	proto.RegisterType((*DeleteObjectsRequest)(nil), "pfs.DeleteObjectsRequest")
-	proto.RegisterType((*DeleteObjectsResponse)(nil), "pfs.DeleteObjectsResponse")
-	proto.RegisterType((*DeleteTagsRequest)(nil), "pfs.DeleteTagsRequest")
-	proto.RegisterType((*DeleteTagsResponse)(nil), "pfs.DeleteTagsResponse")
-	proto.RegisterType((*CheckObjectRequest)(nil), "pfs.CheckObjectRequest")
-	proto.RegisterType((*CheckObjectResponse)(nil), "pfs.CheckObjectResponse")
-	proto.RegisterType((*Objects)(nil), "pfs.Objects")
-	proto.RegisterType((*ObjectIndex)(nil), "pfs.ObjectIndex")
-	proto.RegisterMapType((map[string]*BlockRef)(nil), "pfs.ObjectIndex.ObjectsEntry")
-	proto.RegisterMapType((map[string]*Object)(nil), "pfs.ObjectIndex.TagsEntry")
-	proto.RegisterEnum("pfs.FileType", FileType_name, FileType_value)
-	proto.RegisterEnum("pfs.CommitState", CommitState_name, CommitState_value)
-	proto.RegisterEnum("pfs.Delimiter", Delimiter_name, Delimiter_value)
+func (m *TreeNode) GetSizeBytes() uint64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type GetManifestRequest struct {
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// path, if set, limits the manifest to the files under it
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *GetManifestRequest) Reset()         { *m = GetManifestRequest{} }
+func (m *GetManifestRequest) String() string { return proto.CompactTextString(m) }
+func (*GetManifestRequest) ProtoMessage()    {}
+func (*GetManifestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{67}
+}
+func (m *GetManifestRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetManifestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetManifestRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetManifestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetManifestRequest.Merge(dst, src)
+}
+func (m *GetManifestRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetManifestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetManifestRequest.DiscardUnknown(m)
+}
 
-// APIClient is the client API for API service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type APIClient interface {
-	// Repo rpcs
-	// CreateRepo creates a new repo.
-	// An error is returned if the repo already exists.
-	CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// InspectRepo returns info about a repo.
-	InspectRepo(ctx context.Context, in *InspectRepoRequest, opts ...grpc.CallOption) (*RepoInfo, error)
-	// ListRepo returns info about all repos.
-	ListRepo(ctx context.Context, in *ListRepoRequest, opts ...grpc.CallOption) (*ListRepoResponse, error)
-	// DeleteRepo deletes a repo.
-	DeleteRepo(ctx context.Context, in *DeleteRepoRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// Commit rpcs
-	// StartCommit creates a new write commit from a parent commit.
-	StartCommit(ctx context.Context, in *StartCommitRequest, opts ...grpc.CallOption) (*Commit, error)
-	// FinishCommit turns a write commit into a read commit.
-	FinishCommit(ctx context.Context, in *FinishCommitRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// InspectCommit returns the info about a commit.
-	InspectCommit(ctx context.Context, in *InspectCommitRequest, opts ...grpc.CallOption) (*CommitInfo, error)
-	// ListCommit returns info about all commits. This is deprecated in favor of
-	// ListCommitStream.
-	ListCommit(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*CommitInfos, error)
-	// ListCommitStream is like ListCommit, but returns its results in a GRPC stream
-	ListCommitStream(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (API_ListCommitStreamClient, error)
-	// DeleteCommit deletes a commit.
-	DeleteCommit(ctx context.Context, in *DeleteCommitRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// FlushCommit waits for downstream commits to finish
-	FlushCommit(ctx context.Context, in *FlushCommitRequest, opts ...grpc.CallOption) (API_FlushCommitClient, error)
-	// SubscribeCommit subscribes for new commits on a given branch
-	SubscribeCommit(ctx context.Context, in *SubscribeCommitRequest, opts ...grpc.CallOption) (API_SubscribeCommitClient, error)
-	// BuildCommit builds a commit that's backed by the given tree
-	BuildCommit(ctx context.Context, in *BuildCommitRequest, opts ...grpc.CallOption) (*Commit, error)
-	// CreateBranch creates a new branch
-	CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// InspectBranch returns info about a branch.
-	InspectBranch(ctx context.Context, in *InspectBranchRequest, opts ...grpc.CallOption) (*BranchInfo, error)
-	// ListBranch returns info about the heads of branches.
-	ListBranch(ctx context.Context, in *ListBranchRequest, opts ...grpc.CallOption) (*BranchInfos, error)
-	// DeleteBranch deletes a branch; note that the commits still exist.
-	DeleteBranch(ctx context.Context, in *DeleteBranchRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// File rpcs
-	// PutFile writes the specified file to pfs.
-	PutFile(ctx context.Context, opts ...grpc.CallOption) (API_PutFileClient, error)
-	// CopyFile copies the contents of one file to another.
-	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// GetFile returns a byte stream of the contents of the file.
-	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (API_GetFileClient, error)
-	// InspectFile returns info about a file.
-	InspectFile(ctx context.Context, in *InspectFileRequest, opts ...grpc.CallOption) (*FileInfo, error)
-	// ListFile returns info about all files. This is deprecated in favor of
-	// ListFileStream
-	ListFile(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*FileInfos, error)
-	// ListFileStream is a streaming version of ListFile
-	// TODO(msteffen): When the dash has been updated to use ListFileStream,
-	// replace ListFile with this RPC (https://github.com/pachyderm/dash/issues/201)
-	ListFileStream(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (API_ListFileStreamClient, error)
-	// WalkFile walks over all the files under a directory, including children of children.
-	WalkFile(ctx context.Context, in *WalkFileRequest, opts ...grpc.CallOption) (API_WalkFileClient, error)
-	// GlobFile returns info about all files. This is deprecated in favor of
-	// GlobFileStream
-	GlobFile(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (*FileInfos, error)
-	// GlobFileStream is a streaming version of GlobFile
-	// TODO(msteffen): When the dash has been updated to use GlobFileStream,
-	// replace GlobFile with this RPC (https://github.com/pachyderm/dash/issues/201)
-	GlobFileStream(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (API_GlobFileStreamClient, error)
-	// DiffFile returns the differences between 2 paths at 2 commits.
-	DiffFile(ctx context.Context, in *DiffFileRequest, opts ...grpc.CallOption) (*DiffFileResponse, error)
-	// DeleteFile deletes a file.
-	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	// DeleteAll deletes everything
-	DeleteAll(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error)
+var xxx_messageInfo_GetManifestRequest proto.InternalMessageInfo
+
+func (m *GetManifestRequest) GetCommit() *Commit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
 }
 
-type aPIClient struct {
-	cc *grpc.ClientConn
+func (m *GetManifestRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
 }
 
-func NewAPIClient(cc *grpc.ClientConn) APIClient {
-	return &aPIClient{cc}
+type DeleteFileRequest struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *aPIClient) CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/CreateRepo", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteFileRequest) Reset()         { *m = DeleteFileRequest{} }
+func (m *DeleteFileRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFileRequest) ProtoMessage()    {}
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{68}
+}
+func (m *DeleteFileRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteFileRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (dst *DeleteFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFileRequest.Merge(dst, src)
+}
+func (m *DeleteFileRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFileRequest.DiscardUnknown(m)
 }
 
-func (c *aPIClient) InspectRepo(ctx context.Context, in *InspectRepoRequest, opts ...grpc.CallOption) (*RepoInfo, error) {
-	out := new(RepoInfo)
-	err := c.cc.Invoke(ctx, "/pfs.API/InspectRepo", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeleteFileRequest proto.InternalMessageInfo
+
+func (m *DeleteFileRequest) GetFile() *File {
+	if m != nil {
+		return m.File
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) ListRepo(ctx context.Context, in *ListRepoRequest, opts ...grpc.CallOption) (*ListRepoResponse, error) {
-	out := new(ListRepoResponse)
-	err := c.cc.Invoke(ctx, "/pfs.API/ListRepo", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PutObjectRequest struct {
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Tags  []*Tag `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Block *Block `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	// dedup_scope, if set, restricts deduplication of the object to objects put
+	// in the same scope: the object's hash is derived from the scope as well as
+	// its content (see pfs.ScopeHash), so identical content put in different
+	// scopes is stored separately. "" is the global scope.
+	DedupScope           string   `protobuf:"bytes,4,opt,name=dedup_scope,json=dedupScope,proto3" json:"dedup_scope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *aPIClient) DeleteRepo(ctx context.Context, in *DeleteRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/DeleteRepo", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PutObjectRequest) Reset()         { *m = PutObjectRequest{} }
+func (m *PutObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*PutObjectRequest) ProtoMessage()    {}
+func (*PutObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{69}
+}
+func (m *PutObjectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PutObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PutObjectRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (dst *PutObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutObjectRequest.Merge(dst, src)
+}
+func (m *PutObjectRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PutObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutObjectRequest.DiscardUnknown(m)
 }
 
-func (c *aPIClient) StartCommit(ctx context.Context, in *StartCommitRequest, opts ...grpc.CallOption) (*Commit, error) {
-	out := new(Commit)
-	err := c.cc.Invoke(ctx, "/pfs.API/StartCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PutObjectRequest proto.InternalMessageInfo
+
+func (m *PutObjectRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) FinishCommit(ctx context.Context, in *FinishCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/FinishCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PutObjectRequest) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) InspectCommit(ctx context.Context, in *InspectCommitRequest, opts ...grpc.CallOption) (*CommitInfo, error) {
-	out := new(CommitInfo)
-	err := c.cc.Invoke(ctx, "/pfs.API/InspectCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PutObjectRequest) GetBlock() *Block {
+	if m != nil {
+		return m.Block
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) ListCommit(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*CommitInfos, error) {
-	out := new(CommitInfos)
-	err := c.cc.Invoke(ctx, "/pfs.API/ListCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PutObjectRequest) GetDedupScope() string {
+	if m != nil {
+		return m.DedupScope
 	}
-	return out, nil
+	return ""
 }
 
-func (c *aPIClient) ListCommitStream(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (API_ListCommitStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[0], "/pfs.API/ListCommitStream", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPIListCommitStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type GetObjectsRequest struct {
+	Objects     []*Object `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	OffsetBytes uint64    `protobuf:"varint,2,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
+	// The number of bytes requested.
+	SizeBytes uint64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// The total amount of bytes in these objects.  It's OK if it's not
+	// entirely accurate or if it's unknown (in which case it'd be set to 0).
+	// It's used primarily as a hint for cache eviction.
+	TotalSize            uint64   `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type API_ListCommitStreamClient interface {
-	Recv() (*CommitInfo, error)
-	grpc.ClientStream
+func (m *GetObjectsRequest) Reset()         { *m = GetObjectsRequest{} }
+func (m *GetObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetObjectsRequest) ProtoMessage()    {}
+func (*GetObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{70}
+}
+func (m *GetObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetObjectsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetObjectsRequest.Merge(dst, src)
+}
+func (m *GetObjectsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetObjectsRequest.DiscardUnknown(m)
 }
 
-type aPIListCommitStreamClient struct {
-	grpc.ClientStream
+var xxx_messageInfo_GetObjectsRequest proto.InternalMessageInfo
+
+func (m *GetObjectsRequest) GetObjects() []*Object {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
 }
 
-func (x *aPIListCommitStreamClient) Recv() (*CommitInfo, error) {
-	m := new(CommitInfo)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *GetObjectsRequest) GetOffsetBytes() uint64 {
+	if m != nil {
+		return m.OffsetBytes
 	}
-	return m, nil
+	return 0
 }
 
-func (c *aPIClient) DeleteCommit(ctx context.Context, in *DeleteCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/DeleteCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetObjectsRequest) GetSizeBytes() uint64 {
+	if m != nil {
+		return m.SizeBytes
 	}
-	return out, nil
+	return 0
 }
 
-func (c *aPIClient) FlushCommit(ctx context.Context, in *FlushCommitRequest, opts ...grpc.CallOption) (API_FlushCommitClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[1], "/pfs.API/FlushCommit", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPIFlushCommitClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *GetObjectsRequest) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
 	}
-	return x, nil
+	return 0
 }
 
-type API_FlushCommitClient interface {
-	Recv() (*CommitInfo, error)
-	grpc.ClientStream
+type GetBlocksRequest struct {
+	BlockRefs   []*BlockRef `protobuf:"bytes,1,rep,name=blockRefs,proto3" json:"blockRefs,omitempty"`
+	OffsetBytes uint64      `protobuf:"varint,2,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
+	// The number of bytes requested.
+	SizeBytes uint64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// The total amount of bytes in these blocks.  It's OK if it's not
+	// entirely accurate or if it's unknown (in which case it'd be set to 0).
+	// It's used primarily as a hint for cache eviction.
+	TotalSize            uint64   `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type aPIFlushCommitClient struct {
-	grpc.ClientStream
+func (m *GetBlocksRequest) Reset()         { *m = GetBlocksRequest{} }
+func (m *GetBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksRequest) ProtoMessage()    {}
+func (*GetBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{71}
 }
-
-func (x *aPIFlushCommitClient) Recv() (*CommitInfo, error) {
-	m := new(CommitInfo)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *GetBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *aPIClient) SubscribeCommit(ctx context.Context, in *SubscribeCommitRequest, opts ...grpc.CallOption) (API_SubscribeCommitClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[2], "/pfs.API/SubscribeCommit", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPISubscribeCommitClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *GetBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetBlocksRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
-}
-
-type API_SubscribeCommitClient interface {
-	Recv() (*CommitInfo, error)
-	grpc.ClientStream
 }
-
-type aPISubscribeCommitClient struct {
-	grpc.ClientStream
+func (dst *GetBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksRequest.Merge(dst, src)
 }
-
-func (x *aPISubscribeCommitClient) Recv() (*CommitInfo, error) {
-	m := new(CommitInfo)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *GetBlocksRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (c *aPIClient) BuildCommit(ctx context.Context, in *BuildCommitRequest, opts ...grpc.CallOption) (*Commit, error) {
-	out := new(Commit)
-	err := c.cc.Invoke(ctx, "/pfs.API/BuildCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksRequest.DiscardUnknown(m)
 }
 
-func (c *aPIClient) CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/CreateBranch", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+var xxx_messageInfo_GetBlocksRequest proto.InternalMessageInfo
 
-func (c *aPIClient) InspectBranch(ctx context.Context, in *InspectBranchRequest, opts ...grpc.CallOption) (*BranchInfo, error) {
-	out := new(BranchInfo)
-	err := c.cc.Invoke(ctx, "/pfs.API/InspectBranch", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetBlocksRequest) GetBlockRefs() []*BlockRef {
+	if m != nil {
+		return m.BlockRefs
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) ListBranch(ctx context.Context, in *ListBranchRequest, opts ...grpc.CallOption) (*BranchInfos, error) {
-	out := new(BranchInfos)
-	err := c.cc.Invoke(ctx, "/pfs.API/ListBranch", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetBlocksRequest) GetOffsetBytes() uint64 {
+	if m != nil {
+		return m.OffsetBytes
 	}
-	return out, nil
+	return 0
 }
 
-func (c *aPIClient) DeleteBranch(ctx context.Context, in *DeleteBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/DeleteBranch", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetBlocksRequest) GetSizeBytes() uint64 {
+	if m != nil {
+		return m.SizeBytes
 	}
-	return out, nil
+	return 0
 }
 
-func (c *aPIClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (API_PutFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[3], "/pfs.API/PutFile", opts...)
-	if err != nil {
-		return nil, err
+func (m *GetBlocksRequest) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
 	}
-	x := &aPIPutFileClient{stream}
-	return x, nil
+	return 0
 }
 
-type API_PutFileClient interface {
-	Send(*PutFileRequest) error
-	CloseAndRecv() (*types.Empty, error)
-	grpc.ClientStream
+type TagObjectRequest struct {
+	Object               *Object  `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Tags                 []*Tag   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type aPIPutFileClient struct {
-	grpc.ClientStream
+func (m *TagObjectRequest) Reset()         { *m = TagObjectRequest{} }
+func (m *TagObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*TagObjectRequest) ProtoMessage()    {}
+func (*TagObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{72}
 }
-
-func (x *aPIPutFileClient) Send(m *PutFileRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *TagObjectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (x *aPIPutFileClient) CloseAndRecv() (*types.Empty, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(types.Empty)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *TagObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TagObjectRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return m, nil
 }
-
-func (c *aPIClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/CopyFile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (dst *TagObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagObjectRequest.Merge(dst, src)
 }
-
-func (c *aPIClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (API_GetFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[4], "/pfs.API/GetFile", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPIGetFileClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *TagObjectRequest) XXX_Size() int {
+	return m.Size()
 }
-
-type API_GetFileClient interface {
-	Recv() (*types.BytesValue, error)
-	grpc.ClientStream
+func (m *TagObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagObjectRequest.DiscardUnknown(m)
 }
 
-type aPIGetFileClient struct {
-	grpc.ClientStream
-}
+var xxx_messageInfo_TagObjectRequest proto.InternalMessageInfo
 
-func (x *aPIGetFileClient) Recv() (*types.BytesValue, error) {
-	m := new(types.BytesValue)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *TagObjectRequest) GetObject() *Object {
+	if m != nil {
+		return m.Object
 	}
-	return m, nil
+	return nil
 }
 
-func (c *aPIClient) InspectFile(ctx context.Context, in *InspectFileRequest, opts ...grpc.CallOption) (*FileInfo, error) {
-	out := new(FileInfo)
-	err := c.cc.Invoke(ctx, "/pfs.API/InspectFile", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TagObjectRequest) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) ListFile(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*FileInfos, error) {
-	out := new(FileInfos)
-	err := c.cc.Invoke(ctx, "/pfs.API/ListFile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ListObjectsRequest struct {
+	// If set, only objects whose hash starts with prefix are listed
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *aPIClient) ListFileStream(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (API_ListFileStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[5], "/pfs.API/ListFileStream", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPIListFileStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ListObjectsRequest) Reset()         { *m = ListObjectsRequest{} }
+func (m *ListObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListObjectsRequest) ProtoMessage()    {}
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{73}
+}
+func (m *ListObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListObjectsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
+}
+func (dst *ListObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListObjectsRequest.Merge(dst, src)
+}
+func (m *ListObjectsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListObjectsRequest.DiscardUnknown(m)
 }
 
-type API_ListFileStreamClient interface {
-	Recv() (*FileInfo, error)
-	grpc.ClientStream
+var xxx_messageInfo_ListObjectsRequest proto.InternalMessageInfo
+
+func (m *ListObjectsRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
 }
 
-type aPIListFileStreamClient struct {
-	grpc.ClientStream
+type ListTagsRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	IncludeObject        bool     `protobuf:"varint,2,opt,name=include_object,json=includeObject,proto3" json:"include_object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *aPIListFileStreamClient) Recv() (*FileInfo, error) {
-	m := new(FileInfo)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ListTagsRequest) Reset()         { *m = ListTagsRequest{} }
+func (m *ListTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTagsRequest) ProtoMessage()    {}
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{74}
+}
+func (m *ListTagsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListTagsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return m, nil
+}
+func (dst *ListTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsRequest.Merge(dst, src)
+}
+func (m *ListTagsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsRequest.DiscardUnknown(m)
 }
 
-func (c *aPIClient) WalkFile(ctx context.Context, in *WalkFileRequest, opts ...grpc.CallOption) (API_WalkFileClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[6], "/pfs.API/WalkFile", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPIWalkFileClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+var xxx_messageInfo_ListTagsRequest proto.InternalMessageInfo
+
+func (m *ListTagsRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
 	}
-	return x, nil
+	return ""
 }
 
-type API_WalkFileClient interface {
-	Recv() (*FileInfo, error)
-	grpc.ClientStream
+func (m *ListTagsRequest) GetIncludeObject() bool {
+	if m != nil {
+		return m.IncludeObject
+	}
+	return false
 }
 
-type aPIWalkFileClient struct {
-	grpc.ClientStream
+type ListTagsResponse struct {
+	Tag                  *Tag     `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Object               *Object  `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *aPIWalkFileClient) Recv() (*FileInfo, error) {
-	m := new(FileInfo)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ListTagsResponse) Reset()         { *m = ListTagsResponse{} }
+func (m *ListTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTagsResponse) ProtoMessage()    {}
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{75}
+}
+func (m *ListTagsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListTagsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return m, nil
+}
+func (dst *ListTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTagsResponse.Merge(dst, src)
+}
+func (m *ListTagsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTagsResponse.DiscardUnknown(m)
 }
 
-func (c *aPIClient) GlobFile(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (*FileInfos, error) {
-	out := new(FileInfos)
-	err := c.cc.Invoke(ctx, "/pfs.API/GlobFile", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ListTagsResponse proto.InternalMessageInfo
+
+func (m *ListTagsResponse) GetTag() *Tag {
+	if m != nil {
+		return m.Tag
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) GlobFileStream(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (API_GlobFileStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[7], "/pfs.API/GlobFileStream", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPIGlobFileStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ListTagsResponse) GetObject() *Object {
+	if m != nil {
+		return m.Object
 	}
-	return x, nil
+	return nil
 }
 
-type API_GlobFileStreamClient interface {
-	Recv() (*FileInfo, error)
-	grpc.ClientStream
+type DeleteObjectsRequest struct {
+	Objects              []*Object `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-type aPIGlobFileStreamClient struct {
-	grpc.ClientStream
+func (m *DeleteObjectsRequest) Reset()         { *m = DeleteObjectsRequest{} }
+func (m *DeleteObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteObjectsRequest) ProtoMessage()    {}
+func (*DeleteObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{76}
 }
-
-func (x *aPIGlobFileStreamClient) Recv() (*FileInfo, error) {
-	m := new(FileInfo)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *DeleteObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *aPIClient) DiffFile(ctx context.Context, in *DiffFileRequest, opts ...grpc.CallOption) (*DiffFileResponse, error) {
-	out := new(DiffFileResponse)
-	err := c.cc.Invoke(ctx, "/pfs.API/DiffFile", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeleteObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteObjectsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (dst *DeleteObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteObjectsRequest.Merge(dst, src)
+}
+func (m *DeleteObjectsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteObjectsRequest.DiscardUnknown(m)
 }
 
-func (c *aPIClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/DeleteFile", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeleteObjectsRequest proto.InternalMessageInfo
+
+func (m *DeleteObjectsRequest) GetObjects() []*Object {
+	if m != nil {
+		return m.Objects
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) DeleteAll(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.API/DeleteAll", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type DeleteObjectsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// APIServer is the server API for API service.
-type APIServer interface {
-	// Repo rpcs
-	// CreateRepo creates a new repo.
-	// An error is returned if the repo already exists.
-	CreateRepo(context.Context, *CreateRepoRequest) (*types.Empty, error)
-	// InspectRepo returns info about a repo.
-	InspectRepo(context.Context, *InspectRepoRequest) (*RepoInfo, error)
-	// ListRepo returns info about all repos.
-	ListRepo(context.Context, *ListRepoRequest) (*ListRepoResponse, error)
-	// DeleteRepo deletes a repo.
-	DeleteRepo(context.Context, *DeleteRepoRequest) (*types.Empty, error)
-	// Commit rpcs
-	// StartCommit creates a new write commit from a parent commit.
-	StartCommit(context.Context, *StartCommitRequest) (*Commit, error)
-	// FinishCommit turns a write commit into a read commit.
-	FinishCommit(context.Context, *FinishCommitRequest) (*types.Empty, error)
-	// InspectCommit returns the info about a commit.
-	InspectCommit(context.Context, *InspectCommitRequest) (*CommitInfo, error)
-	// ListCommit returns info about all commits. This is deprecated in favor of
-	// ListCommitStream.
-	ListCommit(context.Context, *ListCommitRequest) (*CommitInfos, error)
-	// ListCommitStream is like ListCommit, but returns its results in a GRPC stream
-	ListCommitStream(*ListCommitRequest, API_ListCommitStreamServer) error
-	// DeleteCommit deletes a commit.
-	DeleteCommit(context.Context, *DeleteCommitRequest) (*types.Empty, error)
-	// FlushCommit waits for downstream commits to finish
-	FlushCommit(*FlushCommitRequest, API_FlushCommitServer) error
-	// SubscribeCommit subscribes for new commits on a given branch
-	SubscribeCommit(*SubscribeCommitRequest, API_SubscribeCommitServer) error
-	// BuildCommit builds a commit that's backed by the given tree
-	BuildCommit(context.Context, *BuildCommitRequest) (*Commit, error)
-	// CreateBranch creates a new branch
-	CreateBranch(context.Context, *CreateBranchRequest) (*types.Empty, error)
-	// InspectBranch returns info about a branch.
-	InspectBranch(context.Context, *InspectBranchRequest) (*BranchInfo, error)
-	// ListBranch returns info about the heads of branches.
-	ListBranch(context.Context, *ListBranchRequest) (*BranchInfos, error)
-	// DeleteBranch deletes a branch; note that the commits still exist.
-	DeleteBranch(context.Context, *DeleteBranchRequest) (*types.Empty, error)
-	// File rpcs
-	// PutFile writes the specified file to pfs.
-	PutFile(API_PutFileServer) error
-	// CopyFile copies the contents of one file to another.
-	CopyFile(context.Context, *CopyFileRequest) (*types.Empty, error)
-	// GetFile returns a byte stream of the contents of the file.
-	GetFile(*GetFileRequest, API_GetFileServer) error
-	// InspectFile returns info about a file.
-	InspectFile(context.Context, *InspectFileRequest) (*FileInfo, error)
-	// ListFile returns info about all files. This is deprecated in favor of
-	// ListFileStream
-	ListFile(context.Context, *ListFileRequest) (*FileInfos, error)
-	// ListFileStream is a streaming version of ListFile
-	// TODO(msteffen): When the dash has been updated to use ListFileStream,
-	// replace ListFile with this RPC (https://github.com/pachyderm/dash/issues/201)
-	ListFileStream(*ListFileRequest, API_ListFileStreamServer) error
-	// WalkFile walks over all the files under a directory, including children of children.
-	WalkFile(*WalkFileRequest, API_WalkFileServer) error
-	// GlobFile returns info about all files. This is deprecated in favor of
-	// GlobFileStream
-	GlobFile(context.Context, *GlobFileRequest) (*FileInfos, error)
-	// GlobFileStream is a streaming version of GlobFile
-	// TODO(msteffen): When the dash has been updated to use GlobFileStream,
-	// replace GlobFile with this RPC (https://github.com/pachyderm/dash/issues/201)
-	GlobFileStream(*GlobFileRequest, API_GlobFileStreamServer) error
-	// DiffFile returns the differences between 2 paths at 2 commits.
-	DiffFile(context.Context, *DiffFileRequest) (*DiffFileResponse, error)
-	// DeleteFile deletes a file.
-	DeleteFile(context.Context, *DeleteFileRequest) (*types.Empty, error)
-	// DeleteAll deletes everything
-	DeleteAll(context.Context, *types.Empty) (*types.Empty, error)
+func (m *DeleteObjectsResponse) Reset()         { *m = DeleteObjectsResponse{} }
+func (m *DeleteObjectsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteObjectsResponse) ProtoMessage()    {}
+func (*DeleteObjectsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{77}
 }
-
-func RegisterAPIServer(s *grpc.Server, srv APIServer) {
-	s.RegisterService(&_API_serviceDesc, srv)
+func (m *DeleteObjectsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func _API_CreateRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRepoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).CreateRepo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/CreateRepo",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).CreateRepo(ctx, req.(*CreateRepoRequest))
+func (m *DeleteObjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteObjectsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *DeleteObjectsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteObjectsResponse.Merge(dst, src)
+}
+func (m *DeleteObjectsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteObjectsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteObjectsResponse.DiscardUnknown(m)
 }
 
-func _API_InspectRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectRepoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).InspectRepo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/InspectRepo",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).InspectRepo(ctx, req.(*InspectRepoRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+var xxx_messageInfo_DeleteObjectsResponse proto.InternalMessageInfo
+
+type DeleteTagsRequest struct {
+	Tags                 []*Tag   `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _API_ListRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRepoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).ListRepo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/ListRepo",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).ListRepo(ctx, req.(*ListRepoRequest))
+func (m *DeleteTagsRequest) Reset()         { *m = DeleteTagsRequest{} }
+func (m *DeleteTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTagsRequest) ProtoMessage()    {}
+func (*DeleteTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{78}
+}
+func (m *DeleteTagsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteTagsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *DeleteTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTagsRequest.Merge(dst, src)
+}
+func (m *DeleteTagsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTagsRequest.DiscardUnknown(m)
 }
 
-func _API_DeleteRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRepoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).DeleteRepo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/DeleteRepo",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteRepo(ctx, req.(*DeleteRepoRequest))
+var xxx_messageInfo_DeleteTagsRequest proto.InternalMessageInfo
+
+func (m *DeleteTagsRequest) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_StartCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).StartCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/StartCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).StartCommit(ctx, req.(*StartCommitRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type DeleteTagsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _API_FinishCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FinishCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).FinishCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/FinishCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).FinishCommit(ctx, req.(*FinishCommitRequest))
+func (m *DeleteTagsResponse) Reset()         { *m = DeleteTagsResponse{} }
+func (m *DeleteTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteTagsResponse) ProtoMessage()    {}
+func (*DeleteTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{79}
+}
+func (m *DeleteTagsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteTagsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *DeleteTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTagsResponse.Merge(dst, src)
+}
+func (m *DeleteTagsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTagsResponse.DiscardUnknown(m)
 }
 
-func _API_InspectCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).InspectCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/InspectCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).InspectCommit(ctx, req.(*InspectCommitRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+var xxx_messageInfo_DeleteTagsResponse proto.InternalMessageInfo
+
+type CheckObjectRequest struct {
+	Object               *Object  `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _API_ListCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).ListCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/ListCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).ListCommit(ctx, req.(*ListCommitRequest))
+func (m *CheckObjectRequest) Reset()         { *m = CheckObjectRequest{} }
+func (m *CheckObjectRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckObjectRequest) ProtoMessage()    {}
+func (*CheckObjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{80}
+}
+func (m *CheckObjectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckObjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckObjectRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *CheckObjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckObjectRequest.Merge(dst, src)
+}
+func (m *CheckObjectRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckObjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckObjectRequest.DiscardUnknown(m)
 }
 
-func _API_ListCommitStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListCommitRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_CheckObjectRequest proto.InternalMessageInfo
+
+func (m *CheckObjectRequest) GetObject() *Object {
+	if m != nil {
+		return m.Object
 	}
-	return srv.(APIServer).ListCommitStream(m, &aPIListCommitStreamServer{stream})
+	return nil
 }
 
-type API_ListCommitStreamServer interface {
-	Send(*CommitInfo) error
-	grpc.ServerStream
+type CheckObjectResponse struct {
+	Exists               bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type aPIListCommitStreamServer struct {
-	grpc.ServerStream
+func (m *CheckObjectResponse) Reset()         { *m = CheckObjectResponse{} }
+func (m *CheckObjectResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckObjectResponse) ProtoMessage()    {}
+func (*CheckObjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{81}
 }
-
-func (x *aPIListCommitStreamServer) Send(m *CommitInfo) error {
-	return x.ServerStream.SendMsg(m)
+func (m *CheckObjectResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func _API_DeleteCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).DeleteCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/DeleteCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteCommit(ctx, req.(*DeleteCommitRequest))
+func (m *CheckObjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckObjectResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *CheckObjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckObjectResponse.Merge(dst, src)
+}
+func (m *CheckObjectResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckObjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckObjectResponse.DiscardUnknown(m)
 }
 
-func _API_FlushCommit_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(FlushCommitRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_CheckObjectResponse proto.InternalMessageInfo
+
+func (m *CheckObjectResponse) GetExists() bool {
+	if m != nil {
+		return m.Exists
 	}
-	return srv.(APIServer).FlushCommit(m, &aPIFlushCommitServer{stream})
+	return false
 }
 
-type API_FlushCommitServer interface {
-	Send(*CommitInfo) error
-	grpc.ServerStream
+// CacheInfo describes one of pachd's in-memory caches of object storage.
+type CacheInfo struct {
+	// Name is the cache's name ("object", "tag", "object_info" or "block").
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// SizeBytes is the most memory that the cache may use. It's a share of
+	// pachd's block cache size.
+	SizeBytes int64 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Bytes is the memory that the cache uses now, for Items values.
+	Bytes int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Items int64 `protobuf:"varint,4,opt,name=items,proto3" json:"items,omitempty"`
+	// Gets is the number of lookups in the cache, and Hits is the number of
+	// them that were served from it, rather than from object storage.
+	Gets int64 `protobuf:"varint,5,opt,name=gets,proto3" json:"gets,omitempty"`
+	Hits int64 `protobuf:"varint,6,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Evictions is the number of values evicted to keep the cache within
+	// SizeBytes.
+	Evictions            int64    `protobuf:"varint,7,opt,name=evictions,proto3" json:"evictions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type aPIFlushCommitServer struct {
-	grpc.ServerStream
+func (m *CacheInfo) Reset()         { *m = CacheInfo{} }
+func (m *CacheInfo) String() string { return proto.CompactTextString(m) }
+func (*CacheInfo) ProtoMessage()    {}
+func (*CacheInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{82}
 }
-
-func (x *aPIFlushCommitServer) Send(m *CommitInfo) error {
-	return x.ServerStream.SendMsg(m)
+func (m *CacheInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func _API_SubscribeCommit_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribeCommitRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *CacheInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CacheInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return srv.(APIServer).SubscribeCommit(m, &aPISubscribeCommitServer{stream})
 }
-
-type API_SubscribeCommitServer interface {
-	Send(*CommitInfo) error
-	grpc.ServerStream
+func (dst *CacheInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheInfo.Merge(dst, src)
 }
-
-type aPISubscribeCommitServer struct {
-	grpc.ServerStream
+func (m *CacheInfo) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *aPISubscribeCommitServer) Send(m *CommitInfo) error {
-	return x.ServerStream.SendMsg(m)
+func (m *CacheInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheInfo.DiscardUnknown(m)
 }
 
-func _API_BuildCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BuildCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).BuildCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/BuildCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).BuildCommit(ctx, req.(*BuildCommitRequest))
+var xxx_messageInfo_CacheInfo proto.InternalMessageInfo
+
+func (m *CacheInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _API_CreateBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBranchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).CreateBranch(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/CreateBranch",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).CreateBranch(ctx, req.(*CreateBranchRequest))
+func (m *CacheInfo) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _API_InspectBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectBranchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).InspectBranch(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/InspectBranch",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).InspectBranch(ctx, req.(*InspectBranchRequest))
+func (m *CacheInfo) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _API_ListBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBranchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).ListBranch(ctx, in)
+func (m *CacheInfo) GetItems() int64 {
+	if m != nil {
+		return m.Items
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/ListBranch",
+	return 0
+}
+
+func (m *CacheInfo) GetGets() int64 {
+	if m != nil {
+		return m.Gets
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).ListBranch(ctx, req.(*ListBranchRequest))
+	return 0
+}
+
+func (m *CacheInfo) GetHits() int64 {
+	if m != nil {
+		return m.Hits
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _API_DeleteBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBranchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *CacheInfo) GetEvictions() int64 {
+	if m != nil {
+		return m.Evictions
 	}
-	if interceptor == nil {
-		return srv.(APIServer).DeleteBranch(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/DeleteBranch",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteBranch(ctx, req.(*DeleteBranchRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _API_PutFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(APIServer).PutFile(&aPIPutFileServer{stream})
-}
-
-type API_PutFileServer interface {
-	SendAndClose(*types.Empty) error
-	Recv() (*PutFileRequest, error)
-	grpc.ServerStream
+	return 0
 }
 
-type aPIPutFileServer struct {
-	grpc.ServerStream
+type InspectCacheResponse struct {
+	Caches               []*CacheInfo `protobuf:"bytes,1,rep,name=caches,proto3" json:"caches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (x *aPIPutFileServer) SendAndClose(m *types.Empty) error {
-	return x.ServerStream.SendMsg(m)
+func (m *InspectCacheResponse) Reset()         { *m = InspectCacheResponse{} }
+func (m *InspectCacheResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectCacheResponse) ProtoMessage()    {}
+func (*InspectCacheResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{83}
 }
-
-func (x *aPIPutFileServer) Recv() (*PutFileRequest, error) {
-	m := new(PutFileRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *InspectCacheResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func _API_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CopyFileRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).CopyFile(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/CopyFile",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).CopyFile(ctx, req.(*CopyFileRequest))
+func (m *InspectCacheResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InspectCacheResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-func _API_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetFileRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(APIServer).GetFile(m, &aPIGetFileServer{stream})
+func (dst *InspectCacheResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCacheResponse.Merge(dst, src)
 }
-
-type API_GetFileServer interface {
-	Send(*types.BytesValue) error
-	grpc.ServerStream
+func (m *InspectCacheResponse) XXX_Size() int {
+	return m.Size()
 }
-
-type aPIGetFileServer struct {
-	grpc.ServerStream
+func (m *InspectCacheResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCacheResponse.DiscardUnknown(m)
 }
 
-func (x *aPIGetFileServer) Send(m *types.BytesValue) error {
-	return x.ServerStream.SendMsg(m)
-}
+var xxx_messageInfo_InspectCacheResponse proto.InternalMessageInfo
 
-func _API_InspectFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectFileRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).InspectFile(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/InspectFile",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).InspectFile(ctx, req.(*InspectFileRequest))
+func (m *InspectCacheResponse) GetCaches() []*CacheInfo {
+	if m != nil {
+		return m.Caches
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_ListFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFileRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).ListFile(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/ListFile",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).ListFile(ctx, req.(*ListFileRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type Objects struct {
+	Objects              []*Object `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func _API_ListFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListFileRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *Objects) Reset()         { *m = Objects{} }
+func (m *Objects) String() string { return proto.CompactTextString(m) }
+func (*Objects) ProtoMessage()    {}
+func (*Objects) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{84}
+}
+func (m *Objects) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Objects) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Objects.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return srv.(APIServer).ListFileStream(m, &aPIListFileStreamServer{stream})
 }
-
-type API_ListFileStreamServer interface {
-	Send(*FileInfo) error
-	grpc.ServerStream
+func (dst *Objects) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Objects.Merge(dst, src)
 }
-
-type aPIListFileStreamServer struct {
-	grpc.ServerStream
+func (m *Objects) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *aPIListFileStreamServer) Send(m *FileInfo) error {
-	return x.ServerStream.SendMsg(m)
+func (m *Objects) XXX_DiscardUnknown() {
+	xxx_messageInfo_Objects.DiscardUnknown(m)
 }
 
-func _API_WalkFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(WalkFileRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_Objects proto.InternalMessageInfo
+
+func (m *Objects) GetObjects() []*Object {
+	if m != nil {
+		return m.Objects
 	}
-	return srv.(APIServer).WalkFile(m, &aPIWalkFileServer{stream})
+	return nil
 }
 
-type API_WalkFileServer interface {
-	Send(*FileInfo) error
-	grpc.ServerStream
+type ObjectIndex struct {
+	Objects              map[string]*BlockRef `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Tags                 map[string]*Object   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-type aPIWalkFileServer struct {
-	grpc.ServerStream
+func (m *ObjectIndex) Reset()         { *m = ObjectIndex{} }
+func (m *ObjectIndex) String() string { return proto.CompactTextString(m) }
+func (*ObjectIndex) ProtoMessage()    {}
+func (*ObjectIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pfs_daa13a627571e170, []int{85}
 }
-
-func (x *aPIWalkFileServer) Send(m *FileInfo) error {
-	return x.ServerStream.SendMsg(m)
+func (m *ObjectIndex) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func _API_GlobFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GlobFileRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).GlobFile(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/GlobFile",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GlobFile(ctx, req.(*GlobFileRequest))
+func (m *ObjectIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObjectIndex.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-func _API_GlobFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GlobFileRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(APIServer).GlobFileStream(m, &aPIGlobFileStreamServer{stream})
+func (dst *ObjectIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectIndex.Merge(dst, src)
 }
-
-type API_GlobFileStreamServer interface {
-	Send(*FileInfo) error
-	grpc.ServerStream
+func (m *ObjectIndex) XXX_Size() int {
+	return m.Size()
 }
-
-type aPIGlobFileStreamServer struct {
-	grpc.ServerStream
+func (m *ObjectIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectIndex.DiscardUnknown(m)
 }
 
-func (x *aPIGlobFileStreamServer) Send(m *FileInfo) error {
-	return x.ServerStream.SendMsg(m)
-}
+var xxx_messageInfo_ObjectIndex proto.InternalMessageInfo
 
-func _API_DiffFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DiffFileRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).DiffFile(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/DiffFile",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DiffFile(ctx, req.(*DiffFileRequest))
+func (m *ObjectIndex) GetObjects() map[string]*BlockRef {
+	if m != nil {
+		return m.Objects
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteFileRequest)
-	if err := dec(in); err != nil {
+func (m *ObjectIndex) GetTags() map[string]*Object {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Repo)(nil), "pfs.Repo")
+	proto.RegisterType((*Branch)(nil), "pfs.Branch")
+	proto.RegisterType((*BranchInfo)(nil), "pfs.BranchInfo")
+	proto.RegisterType((*BranchInfos)(nil), "pfs.BranchInfos")
+	proto.RegisterType((*File)(nil), "pfs.File")
+	proto.RegisterType((*Block)(nil), "pfs.Block")
+	proto.RegisterType((*Object)(nil), "pfs.Object")
+	proto.RegisterType((*Tag)(nil), "pfs.Tag")
+	proto.RegisterType((*RepoInfo)(nil), "pfs.RepoInfo")
+	proto.RegisterType((*RepoAuthInfo)(nil), "pfs.RepoAuthInfo")
+	proto.RegisterType((*Commit)(nil), "pfs.Commit")
+	proto.RegisterType((*CommitRange)(nil), "pfs.CommitRange")
+	proto.RegisterType((*CommitInfo)(nil), "pfs.CommitInfo")
+	proto.RegisterMapType((map[string]string)(nil), "pfs.CommitInfo.LabelsEntry")
+	proto.RegisterType((*FileInfo)(nil), "pfs.FileInfo")
+	proto.RegisterType((*FileOwner)(nil), "pfs.FileOwner")
+	proto.RegisterType((*ByteRange)(nil), "pfs.ByteRange")
+	proto.RegisterType((*BlockRef)(nil), "pfs.BlockRef")
+	proto.RegisterType((*ObjectInfo)(nil), "pfs.ObjectInfo")
+	proto.RegisterType((*CreateRepoRequest)(nil), "pfs.CreateRepoRequest")
+	proto.RegisterType((*InspectRepoRequest)(nil), "pfs.InspectRepoRequest")
+	proto.RegisterType((*InspectReposRequest)(nil), "pfs.InspectReposRequest")
+	proto.RegisterType((*InspectRepoResult)(nil), "pfs.InspectRepoResult")
+	proto.RegisterType((*ListRepoRequest)(nil), "pfs.ListRepoRequest")
+	proto.RegisterType((*ListRepoResponse)(nil), "pfs.ListRepoResponse")
+	proto.RegisterType((*DiskUsageRequest)(nil), "pfs.DiskUsageRequest")
+	proto.RegisterType((*RepoDiskUsage)(nil), "pfs.RepoDiskUsage")
+	proto.RegisterType((*DiskUsageResponse)(nil), "pfs.DiskUsageResponse")
+	proto.RegisterType((*DeleteRepoRequest)(nil), "pfs.DeleteRepoRequest")
+	proto.RegisterType((*StartCommitRequest)(nil), "pfs.StartCommitRequest")
+	proto.RegisterMapType((map[string]string)(nil), "pfs.StartCommitRequest.LabelsEntry")
+	proto.RegisterType((*ProvenanceGraphRequest)(nil), "pfs.ProvenanceGraphRequest")
+	proto.RegisterType((*ProvenanceGraphNode)(nil), "pfs.ProvenanceGraphNode")
+	proto.RegisterType((*BuildCommitRequest)(nil), "pfs.BuildCommitRequest")
+	proto.RegisterType((*FinishCommitRequest)(nil), "pfs.FinishCommitRequest")
+	proto.RegisterMapType((map[string]string)(nil), "pfs.FinishCommitRequest.LabelsEntry")
+	proto.RegisterType((*InspectCommitRequest)(nil), "pfs.InspectCommitRequest")
+	proto.RegisterType((*InspectCommitsRequest)(nil), "pfs.InspectCommitsRequest")
+	proto.RegisterType((*InspectCommitResult)(nil), "pfs.InspectCommitResult")
+	proto.RegisterType((*ListCommitRequest)(nil), "pfs.ListCommitRequest")
+	proto.RegisterType((*CommitInfos)(nil), "pfs.CommitInfos")
+	proto.RegisterType((*CreateBranchRequest)(nil), "pfs.CreateBranchRequest")
+	proto.RegisterType((*InspectBranchRequest)(nil), "pfs.InspectBranchRequest")
+	proto.RegisterType((*ListBranchRequest)(nil), "pfs.ListBranchRequest")
+	proto.RegisterType((*ListCommitBranchesRequest)(nil), "pfs.ListCommitBranchesRequest")
+	proto.RegisterType((*SetMaxHistoryDepthRequest)(nil), "pfs.SetMaxHistoryDepthRequest")
+	proto.RegisterType((*SwapBranchRequest)(nil), "pfs.SwapBranchRequest")
+	proto.RegisterType((*DeleteBranchRequest)(nil), "pfs.DeleteBranchRequest")
+	proto.RegisterType((*DeleteCommitRequest)(nil), "pfs.DeleteCommitRequest")
+	proto.RegisterType((*SquashCommitRequest)(nil), "pfs.SquashCommitRequest")
+	proto.RegisterType((*FlushCommitRequest)(nil), "pfs.FlushCommitRequest")
+	proto.RegisterType((*SubscribeCommitRequest)(nil), "pfs.SubscribeCommitRequest")
+	proto.RegisterType((*GetFileRequest)(nil), "pfs.GetFileRequest")
+	proto.RegisterType((*OverwriteIndex)(nil), "pfs.OverwriteIndex")
+	proto.RegisterType((*PutFileRequest)(nil), "pfs.PutFileRequest")
+	proto.RegisterType((*PutFileChunkRequest)(nil), "pfs.PutFileChunkRequest")
+	proto.RegisterType((*FileChunk)(nil), "pfs.FileChunk")
+	proto.RegisterType((*PutFileChunksRequest)(nil), "pfs.PutFileChunksRequest")
+	proto.RegisterType((*PutFileRecord)(nil), "pfs.PutFileRecord")
+	proto.RegisterType((*PutFileRecords)(nil), "pfs.PutFileRecords")
+	proto.RegisterType((*CopyFileRequest)(nil), "pfs.CopyFileRequest")
+	proto.RegisterType((*InspectFileRequest)(nil), "pfs.InspectFileRequest")
+	proto.RegisterType((*ListFileRequest)(nil), "pfs.ListFileRequest")
+	proto.RegisterType((*WalkFileRequest)(nil), "pfs.WalkFileRequest")
+	proto.RegisterType((*GlobFileRequest)(nil), "pfs.GlobFileRequest")
+	proto.RegisterType((*FileInfos)(nil), "pfs.FileInfos")
+	proto.RegisterType((*DiffFileRequest)(nil), "pfs.DiffFileRequest")
+	proto.RegisterType((*DiffFileResponse)(nil), "pfs.DiffFileResponse")
+	proto.RegisterType((*GetCommitTreeRequest)(nil), "pfs.GetCommitTreeRequest")
+	proto.RegisterType((*TreeNode)(nil), "pfs.TreeNode")
+	proto.RegisterType((*GetManifestRequest)(nil), "pfs.GetManifestRequest")
+	proto.RegisterType((*DeleteFileRequest)(nil), "pfs.DeleteFileRequest")
+	proto.RegisterType((*PutObjectRequest)(nil), "pfs.PutObjectRequest")
+	proto.RegisterType((*GetObjectsRequest)(nil), "pfs.GetObjectsRequest")
+	proto.RegisterType((*GetBlocksRequest)(nil), "pfs.GetBlocksRequest")
+	proto.RegisterType((*TagObjectRequest)(nil), "pfs.TagObjectRequest")
+	proto.RegisterType((*ListObjectsRequest)(nil), "pfs.ListObjectsRequest")
+	proto.RegisterType((*ListTagsRequest)(nil), "pfs.ListTagsRequest")
+	proto.RegisterType((*ListTagsResponse)(nil), "pfs.ListTagsResponse")
+	proto.RegisterType((*DeleteObjectsRequest)(nil), "pfs.DeleteObjectsRequest")
+	proto.RegisterType((*DeleteObjectsResponse)(nil), "pfs.DeleteObjectsResponse")
+	proto.RegisterType((*DeleteTagsRequest)(nil), "pfs.DeleteTagsRequest")
+	proto.RegisterType((*DeleteTagsResponse)(nil), "pfs.DeleteTagsResponse")
+	proto.RegisterType((*CheckObjectRequest)(nil), "pfs.CheckObjectRequest")
+	proto.RegisterType((*CheckObjectResponse)(nil), "pfs.CheckObjectResponse")
+	proto.RegisterType((*CacheInfo)(nil), "pfs.CacheInfo")
+	proto.RegisterType((*InspectCacheResponse)(nil), "pfs.InspectCacheResponse")
+	proto.RegisterType((*Objects)(nil), "pfs.Objects")
+	proto.RegisterType((*ObjectIndex)(nil), "pfs.ObjectIndex")
+	proto.RegisterMapType((map[string]*BlockRef)(nil), "pfs.ObjectIndex.ObjectsEntry")
+	proto.RegisterMapType((map[string]*Object)(nil), "pfs.ObjectIndex.TagsEntry")
+	proto.RegisterEnum("pfs.FileType", FileType_name, FileType_value)
+	proto.RegisterEnum("pfs.CommitState", CommitState_name, CommitState_value)
+	proto.RegisterEnum("pfs.Delimiter", Delimiter_name, Delimiter_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type APIClient interface {
+	// Repo rpcs
+	// CreateRepo creates a new repo.
+	// An error is returned if the repo already exists.
+	CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// InspectRepo returns info about a repo.
+	InspectRepo(ctx context.Context, in *InspectRepoRequest, opts ...grpc.CallOption) (*RepoInfo, error)
+	// InspectRepos returns info about several repos, in the order that they're
+	// requested. Repos that can't be inspected are flagged, rather than failing
+	// the call.
+	InspectRepos(ctx context.Context, in *InspectReposRequest, opts ...grpc.CallOption) (API_InspectReposClient, error)
+	// ListRepo returns info about all repos.
+	ListRepo(ctx context.Context, in *ListRepoRequest, opts ...grpc.CallOption) (*ListRepoResponse, error)
+	// DiskUsage returns the storage used by a repo, or by every repo. It reads
+	// every commit in every repo, so it may be slow.
+	DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error)
+	// DeleteRepo deletes a repo.
+	DeleteRepo(ctx context.Context, in *DeleteRepoRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// Commit rpcs
+	// StartCommit creates a new write commit from a parent commit.
+	StartCommit(ctx context.Context, in *StartCommitRequest, opts ...grpc.CallOption) (*Commit, error)
+	// FinishCommit turns a write commit into a read commit.
+	FinishCommit(ctx context.Context, in *FinishCommitRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// InspectCommit returns the info about a commit.
+	InspectCommit(ctx context.Context, in *InspectCommitRequest, opts ...grpc.CallOption) (*CommitInfo, error)
+	// InspectCommits returns info about several commits, in the order that
+	// they're requested. Commits that can't be inspected are flagged, rather
+	// than failing the call.
+	InspectCommits(ctx context.Context, in *InspectCommitsRequest, opts ...grpc.CallOption) (API_InspectCommitsClient, error)
+	// ListCommit returns info about all commits. This is deprecated in favor of
+	// ListCommitStream.
+	ListCommit(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*CommitInfos, error)
+	// ListCommitStream is like ListCommit, but returns its results in a GRPC stream
+	ListCommitStream(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (API_ListCommitStreamClient, error)
+	// DeleteCommit deletes a commit.
+	DeleteCommit(ctx context.Context, in *DeleteCommitRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// SquashCommit deletes the ancestors of a commit, and their downstream
+	// commits, except for those that are still needed.
+	SquashCommit(ctx context.Context, in *SquashCommitRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// FlushCommit waits for downstream commits to finish
+	FlushCommit(ctx context.Context, in *FlushCommitRequest, opts ...grpc.CallOption) (API_FlushCommitClient, error)
+	// SubscribeCommit subscribes for new commits on a given branch
+	SubscribeCommit(ctx context.Context, in *SubscribeCommitRequest, opts ...grpc.CallOption) (API_SubscribeCommitClient, error)
+	// BuildCommit builds a commit that's backed by the given tree
+	BuildCommit(ctx context.Context, in *BuildCommitRequest, opts ...grpc.CallOption) (*Commit, error)
+	// ProvenanceGraph streams the commits in a commit's provenance, one node
+	// (and its edges) at a time, starting with the commit itself, so that large
+	// lineage graphs can be rendered as they arrive.
+	ProvenanceGraph(ctx context.Context, in *ProvenanceGraphRequest, opts ...grpc.CallOption) (API_ProvenanceGraphClient, error)
+	// CreateBranch creates a new branch
+	CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// InspectBranch returns info about a branch.
+	InspectBranch(ctx context.Context, in *InspectBranchRequest, opts ...grpc.CallOption) (*BranchInfo, error)
+	// ListBranch returns info about the heads of branches.
+	ListBranch(ctx context.Context, in *ListBranchRequest, opts ...grpc.CallOption) (*BranchInfos, error)
+	// ListCommitBranches returns info about the branches whose head is a
+	// commit, or optionally that have the commit in their history.
+	ListCommitBranches(ctx context.Context, in *ListCommitBranchesRequest, opts ...grpc.CallOption) (*BranchInfos, error)
+	// SetMaxHistoryDepth limits the number of commits kept in a branch's
+	// history; older commits are deleted as new commits are finished.
+	SetMaxHistoryDepth(ctx context.Context, in *SetMaxHistoryDepthRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// SwapBranch atomically swaps the heads (and provenance) of two branches
+	// in the same repo, e.g. to promote a staging branch to production.
+	SwapBranch(ctx context.Context, in *SwapBranchRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// DeleteBranch deletes a branch; note that the commits still exist.
+	DeleteBranch(ctx context.Context, in *DeleteBranchRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// File rpcs
+	// PutFile writes the specified file to pfs.
+	PutFile(ctx context.Context, opts ...grpc.CallOption) (API_PutFileClient, error)
+	// PutFileChunk stores a chunk of a file's content, which is written to the
+	// file by PutFileChunks. Clients can upload the chunks of a large file
+	// concurrently, in separate streams.
+	PutFileChunk(ctx context.Context, opts ...grpc.CallOption) (API_PutFileChunkClient, error)
+	// PutFileChunks writes the chunks stored by PutFileChunk to a file, in
+	// the order of their offsets.
+	PutFileChunks(ctx context.Context, in *PutFileChunksRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// CopyFile copies the contents of one file to another.
+	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// GetFile returns a byte stream of the contents of the file.
+	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (API_GetFileClient, error)
+	// InspectFile returns info about a file.
+	InspectFile(ctx context.Context, in *InspectFileRequest, opts ...grpc.CallOption) (*FileInfo, error)
+	// ListFile returns info about all files. This is deprecated in favor of
+	// ListFileStream
+	ListFile(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*FileInfos, error)
+	// ListFileStream is a streaming version of ListFile
+	// TODO(msteffen): When the dash has been updated to use ListFileStream,
+	// replace ListFile with this RPC (https://github.com/pachyderm/dash/issues/201)
+	ListFileStream(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (API_ListFileStreamClient, error)
+	// WalkFile walks over all the files under a directory, including children of children.
+	WalkFile(ctx context.Context, in *WalkFileRequest, opts ...grpc.CallOption) (API_WalkFileClient, error)
+	// GlobFile returns info about all files. This is deprecated in favor of
+	// GlobFileStream
+	GlobFile(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (*FileInfos, error)
+	// GlobFileStream is a streaming version of GlobFile
+	// TODO(msteffen): When the dash has been updated to use GlobFileStream,
+	// replace GlobFile with this RPC (https://github.com/pachyderm/dash/issues/201)
+	GlobFileStream(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (API_GlobFileStreamClient, error)
+	// DiffFile returns the differences between 2 paths at 2 commits.
+	DiffFile(ctx context.Context, in *DiffFileRequest, opts ...grpc.CallOption) (*DiffFileResponse, error)
+	// GetCommitTree returns the path and hash of every file and directory in a
+	// commit, in order of path, so that commits can be diffed without reading
+	// their files' content.
+	GetCommitTree(ctx context.Context, in *GetCommitTreeRequest, opts ...grpc.CallOption) (API_GetCommitTreeClient, error)
+	// GetManifest returns a manifest of the files in a commit, as
+	// newline-delimited JSON: one ManifestEntry (path, size and hashes) per file,
+	// in order of path, so that copies of the files kept outside of pachyderm
+	// can be verified against it.
+	GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (API_GetManifestClient, error)
+	// DeleteFile deletes a file.
+	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	// DeleteAll deletes everything
+	DeleteAll(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error)
+}
+
+type aPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIClient(cc *grpc.ClientConn) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/CreateRepo", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(APIServer).DeleteFile(ctx, in)
+	return out, nil
+}
+
+func (c *aPIClient) InspectRepo(ctx context.Context, in *InspectRepoRequest, opts ...grpc.CallOption) (*RepoInfo, error) {
+	out := new(RepoInfo)
+	err := c.cc.Invoke(ctx, "/pfs.API/InspectRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/DeleteFile",
+	return out, nil
+}
+
+func (c *aPIClient) InspectRepos(ctx context.Context, in *InspectReposRequest, opts ...grpc.CallOption) (API_InspectReposClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[0], "/pfs.API/InspectRepos", opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+	x := &aPIInspectReposClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func _API_DeleteAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.Empty)
-	if err := dec(in); err != nil {
+type API_InspectReposClient interface {
+	Recv() (*InspectRepoResult, error)
+	grpc.ClientStream
+}
+
+type aPIInspectReposClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIInspectReposClient) Recv() (*InspectRepoResult, error) {
+	m := new(InspectRepoResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(APIServer).DeleteAll(ctx, in)
+	return m, nil
+}
+
+func (c *aPIClient) ListRepo(ctx context.Context, in *ListRepoRequest, opts ...grpc.CallOption) (*ListRepoResponse, error) {
+	out := new(ListRepoResponse)
+	err := c.cc.Invoke(ctx, "/pfs.API/ListRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pfs.API/DeleteAll",
+	return out, nil
+}
+
+func (c *aPIClient) DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error) {
+	out := new(DiskUsageResponse)
+	err := c.cc.Invoke(ctx, "/pfs.API/DiskUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteAll(ctx, req.(*types.Empty))
+	return out, nil
+}
+
+func (c *aPIClient) DeleteRepo(ctx context.Context, in *DeleteRepoRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/DeleteRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-var _API_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pfs.API",
-	HandlerType: (*APIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateRepo",
-			Handler:    _API_CreateRepo_Handler,
-		},
-		{
-			MethodName: "InspectRepo",
-			Handler:    _API_InspectRepo_Handler,
-		},
-		{
-			MethodName: "ListRepo",
-			Handler:    _API_ListRepo_Handler,
-		},
-		{
-			MethodName: "DeleteRepo",
-			Handler:    _API_DeleteRepo_Handler,
-		},
-		{
-			MethodName: "StartCommit",
-			Handler:    _API_StartCommit_Handler,
-		},
-		{
-			MethodName: "FinishCommit",
-			Handler:    _API_FinishCommit_Handler,
-		},
-		{
-			MethodName: "InspectCommit",
-			Handler:    _API_InspectCommit_Handler,
-		},
-		{
-			MethodName: "ListCommit",
-			Handler:    _API_ListCommit_Handler,
-		},
-		{
-			MethodName: "DeleteCommit",
-			Handler:    _API_DeleteCommit_Handler,
-		},
-		{
-			MethodName: "BuildCommit",
-			Handler:    _API_BuildCommit_Handler,
-		},
-		{
-			MethodName: "CreateBranch",
-			Handler:    _API_CreateBranch_Handler,
-		},
-		{
-			MethodName: "InspectBranch",
-			Handler:    _API_InspectBranch_Handler,
-		},
-		{
-			MethodName: "ListBranch",
-			Handler:    _API_ListBranch_Handler,
-		},
-		{
-			MethodName: "DeleteBranch",
-			Handler:    _API_DeleteBranch_Handler,
-		},
-		{
-			MethodName: "CopyFile",
-			Handler:    _API_CopyFile_Handler,
-		},
-		{
-			MethodName: "InspectFile",
-			Handler:    _API_InspectFile_Handler,
-		},
-		{
-			MethodName: "ListFile",
-			Handler:    _API_ListFile_Handler,
-		},
-		{
-			MethodName: "GlobFile",
-			Handler:    _API_GlobFile_Handler,
-		},
-		{
-			MethodName: "DiffFile",
-			Handler:    _API_DiffFile_Handler,
-		},
-		{
-			MethodName: "DeleteFile",
-			Handler:    _API_DeleteFile_Handler,
-		},
-		{
-			MethodName: "DeleteAll",
-			Handler:    _API_DeleteAll_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "ListCommitStream",
-			Handler:       _API_ListCommitStream_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "FlushCommit",
-			Handler:       _API_FlushCommit_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "SubscribeCommit",
-			Handler:       _API_SubscribeCommit_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "PutFile",
-			Handler:       _API_PutFile_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "GetFile",
-			Handler:       _API_GetFile_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ListFileStream",
-			Handler:       _API_ListFileStream_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "WalkFile",
-			Handler:       _API_WalkFile_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "GlobFileStream",
-			Handler:       _API_GlobFileStream_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "client/pfs/pfs.proto",
-}
-
-// ObjectAPIClient is the client API for ObjectAPI service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ObjectAPIClient interface {
-	PutObject(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectClient, error)
-	PutObjectSplit(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectSplitClient, error)
-	PutObjects(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectsClient, error)
-	GetObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (ObjectAPI_GetObjectClient, error)
-	GetObjects(ctx context.Context, in *GetObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_GetObjectsClient, error)
-	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (ObjectAPI_GetBlocksClient, error)
-	TagObject(ctx context.Context, in *TagObjectRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	InspectObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (*ObjectInfo, error)
-	// CheckObject checks if an object exists in the blob store without
-	// actually reading the object.
-	CheckObject(ctx context.Context, in *CheckObjectRequest, opts ...grpc.CallOption) (*CheckObjectResponse, error)
-	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_ListObjectsClient, error)
-	DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error)
-	GetTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (ObjectAPI_GetTagClient, error)
-	InspectTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (*ObjectInfo, error)
-	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (ObjectAPI_ListTagsClient, error)
-	DeleteTags(ctx context.Context, in *DeleteTagsRequest, opts ...grpc.CallOption) (*DeleteTagsResponse, error)
-	Compact(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error)
+func (c *aPIClient) StartCommit(ctx context.Context, in *StartCommitRequest, opts ...grpc.CallOption) (*Commit, error) {
+	out := new(Commit)
+	err := c.cc.Invoke(ctx, "/pfs.API/StartCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type objectAPIClient struct {
-	cc *grpc.ClientConn
+func (c *aPIClient) FinishCommit(ctx context.Context, in *FinishCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/FinishCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewObjectAPIClient(cc *grpc.ClientConn) ObjectAPIClient {
-	return &objectAPIClient{cc}
+func (c *aPIClient) InspectCommit(ctx context.Context, in *InspectCommitRequest, opts ...grpc.CallOption) (*CommitInfo, error) {
+	out := new(CommitInfo)
+	err := c.cc.Invoke(ctx, "/pfs.API/InspectCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *objectAPIClient) PutObject(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[0], "/pfs.ObjectAPI/PutObject", opts...)
+func (c *aPIClient) InspectCommits(ctx context.Context, in *InspectCommitsRequest, opts ...grpc.CallOption) (API_InspectCommitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[1], "/pfs.API/InspectCommits", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIPutObjectClient{stream}
+	x := &aPIInspectCommitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
 	return x, nil
 }
 
-type ObjectAPI_PutObjectClient interface {
-	Send(*PutObjectRequest) error
-	CloseAndRecv() (*Object, error)
+type API_InspectCommitsClient interface {
+	Recv() (*InspectCommitResult, error)
 	grpc.ClientStream
 }
 
-type objectAPIPutObjectClient struct {
+type aPIInspectCommitsClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectClient) Send(m *PutObjectRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *objectAPIPutObjectClient) CloseAndRecv() (*Object, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(Object)
+func (x *aPIInspectCommitsClient) Recv() (*InspectCommitResult, error) {
+	m := new(InspectCommitResult)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *objectAPIClient) PutObjectSplit(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectSplitClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[1], "/pfs.ObjectAPI/PutObjectSplit", opts...)
+func (c *aPIClient) ListCommit(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (*CommitInfos, error) {
+	out := new(CommitInfos)
+	err := c.cc.Invoke(ctx, "/pfs.API/ListCommit", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIPutObjectSplitClient{stream}
+	return out, nil
+}
+
+func (c *aPIClient) ListCommitStream(ctx context.Context, in *ListCommitRequest, opts ...grpc.CallOption) (API_ListCommitStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[2], "/pfs.API/ListCommitStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIListCommitStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
 	return x, nil
 }
 
-type ObjectAPI_PutObjectSplitClient interface {
-	Send(*PutObjectRequest) error
-	CloseAndRecv() (*Objects, error)
+type API_ListCommitStreamClient interface {
+	Recv() (*CommitInfo, error)
 	grpc.ClientStream
 }
 
-type objectAPIPutObjectSplitClient struct {
+type aPIListCommitStreamClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectSplitClient) Send(m *PutObjectRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (x *aPIListCommitStreamClient) Recv() (*CommitInfo, error) {
+	m := new(CommitInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (x *objectAPIPutObjectSplitClient) CloseAndRecv() (*Objects, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
+func (c *aPIClient) DeleteCommit(ctx context.Context, in *DeleteCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/DeleteCommit", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	m := new(Objects)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+	return out, nil
+}
+
+func (c *aPIClient) SquashCommit(ctx context.Context, in *SquashCommitRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/SquashCommit", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *objectAPIClient) PutObjects(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[2], "/pfs.ObjectAPI/PutObjects", opts...)
+func (c *aPIClient) FlushCommit(ctx context.Context, in *FlushCommitRequest, opts ...grpc.CallOption) (API_FlushCommitClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[3], "/pfs.API/FlushCommit", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIPutObjectsClient{stream}
+	x := &aPIFlushCommitClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
 	return x, nil
 }
 
-type ObjectAPI_PutObjectsClient interface {
-	Send(*PutObjectRequest) error
-	CloseAndRecv() (*types.Empty, error)
+type API_FlushCommitClient interface {
+	Recv() (*CommitInfo, error)
 	grpc.ClientStream
 }
 
-type objectAPIPutObjectsClient struct {
+type aPIFlushCommitClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectsClient) Send(m *PutObjectRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *objectAPIPutObjectsClient) CloseAndRecv() (*types.Empty, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(types.Empty)
+func (x *aPIFlushCommitClient) Recv() (*CommitInfo, error) {
+	m := new(CommitInfo)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *objectAPIClient) GetObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (ObjectAPI_GetObjectClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[3], "/pfs.ObjectAPI/GetObject", opts...)
+func (c *aPIClient) SubscribeCommit(ctx context.Context, in *SubscribeCommitRequest, opts ...grpc.CallOption) (API_SubscribeCommitClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[4], "/pfs.API/SubscribeCommit", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIGetObjectClient{stream}
+	x := &aPISubscribeCommitClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -5532,29 +6021,38 @@ func (c *objectAPIClient) GetObject(ctx context.Context, in *Object, opts ...grp
 	return x, nil
 }
 
-type ObjectAPI_GetObjectClient interface {
-	Recv() (*types.BytesValue, error)
+type API_SubscribeCommitClient interface {
+	Recv() (*CommitInfo, error)
 	grpc.ClientStream
 }
 
-type objectAPIGetObjectClient struct {
+type aPISubscribeCommitClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIGetObjectClient) Recv() (*types.BytesValue, error) {
-	m := new(types.BytesValue)
+func (x *aPISubscribeCommitClient) Recv() (*CommitInfo, error) {
+	m := new(CommitInfo)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *objectAPIClient) GetObjects(ctx context.Context, in *GetObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_GetObjectsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[4], "/pfs.ObjectAPI/GetObjects", opts...)
+func (c *aPIClient) BuildCommit(ctx context.Context, in *BuildCommitRequest, opts ...grpc.CallOption) (*Commit, error) {
+	out := new(Commit)
+	err := c.cc.Invoke(ctx, "/pfs.API/BuildCommit", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIGetObjectsClient{stream}
+	return out, nil
+}
+
+func (c *aPIClient) ProvenanceGraph(ctx context.Context, in *ProvenanceGraphRequest, opts ...grpc.CallOption) (API_ProvenanceGraphClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[5], "/pfs.API/ProvenanceGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIProvenanceGraphClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -5564,129 +6062,178 @@ func (c *objectAPIClient) GetObjects(ctx context.Context, in *GetObjectsRequest,
 	return x, nil
 }
 
-type ObjectAPI_GetObjectsClient interface {
-	Recv() (*types.BytesValue, error)
+type API_ProvenanceGraphClient interface {
+	Recv() (*ProvenanceGraphNode, error)
 	grpc.ClientStream
 }
 
-type objectAPIGetObjectsClient struct {
+type aPIProvenanceGraphClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIGetObjectsClient) Recv() (*types.BytesValue, error) {
-	m := new(types.BytesValue)
+func (x *aPIProvenanceGraphClient) Recv() (*ProvenanceGraphNode, error) {
+	m := new(ProvenanceGraphNode)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *objectAPIClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (ObjectAPI_GetBlocksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[5], "/pfs.ObjectAPI/GetBlocks", opts...)
+func (c *aPIClient) CreateBranch(ctx context.Context, in *CreateBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/CreateBranch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIGetBlocksClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+	return out, nil
 }
 
-type ObjectAPI_GetBlocksClient interface {
-	Recv() (*types.BytesValue, error)
-	grpc.ClientStream
+func (c *aPIClient) InspectBranch(ctx context.Context, in *InspectBranchRequest, opts ...grpc.CallOption) (*BranchInfo, error) {
+	out := new(BranchInfo)
+	err := c.cc.Invoke(ctx, "/pfs.API/InspectBranch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type objectAPIGetBlocksClient struct {
-	grpc.ClientStream
+func (c *aPIClient) ListBranch(ctx context.Context, in *ListBranchRequest, opts ...grpc.CallOption) (*BranchInfos, error) {
+	out := new(BranchInfos)
+	err := c.cc.Invoke(ctx, "/pfs.API/ListBranch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *objectAPIGetBlocksClient) Recv() (*types.BytesValue, error) {
-	m := new(types.BytesValue)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *aPIClient) ListCommitBranches(ctx context.Context, in *ListCommitBranchesRequest, opts ...grpc.CallOption) (*BranchInfos, error) {
+	out := new(BranchInfos)
+	err := c.cc.Invoke(ctx, "/pfs.API/ListCommitBranches", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *objectAPIClient) TagObject(ctx context.Context, in *TagObjectRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+func (c *aPIClient) SetMaxHistoryDepth(ctx context.Context, in *SetMaxHistoryDepthRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/TagObject", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/pfs.API/SetMaxHistoryDepth", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *objectAPIClient) InspectObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (*ObjectInfo, error) {
-	out := new(ObjectInfo)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/InspectObject", in, out, opts...)
+func (c *aPIClient) SwapBranch(ctx context.Context, in *SwapBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/SwapBranch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *objectAPIClient) CheckObject(ctx context.Context, in *CheckObjectRequest, opts ...grpc.CallOption) (*CheckObjectResponse, error) {
-	out := new(CheckObjectResponse)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/CheckObject", in, out, opts...)
+func (c *aPIClient) DeleteBranch(ctx context.Context, in *DeleteBranchRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/DeleteBranch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *objectAPIClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_ListObjectsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[6], "/pfs.ObjectAPI/ListObjects", opts...)
+func (c *aPIClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (API_PutFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[6], "/pfs.API/PutFile", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIListObjectsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	x := &aPIPutFileClient{stream}
+	return x, nil
+}
+
+type API_PutFileClient interface {
+	Send(*PutFileRequest) error
+	CloseAndRecv() (*types.Empty, error)
+	grpc.ClientStream
+}
+
+type aPIPutFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIPutFileClient) Send(m *PutFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIPutFileClient) CloseAndRecv() (*types.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	m := new(types.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) PutFileChunk(ctx context.Context, opts ...grpc.CallOption) (API_PutFileChunkClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[7], "/pfs.API/PutFileChunk", opts...)
+	if err != nil {
 		return nil, err
 	}
+	x := &aPIPutFileChunkClient{stream}
 	return x, nil
 }
 
-type ObjectAPI_ListObjectsClient interface {
-	Recv() (*Object, error)
+type API_PutFileChunkClient interface {
+	Send(*PutFileChunkRequest) error
+	CloseAndRecv() (*FileChunk, error)
 	grpc.ClientStream
 }
 
-type objectAPIListObjectsClient struct {
+type aPIPutFileChunkClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIListObjectsClient) Recv() (*Object, error) {
-	m := new(Object)
+func (x *aPIPutFileChunkClient) Send(m *PutFileChunkRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIPutFileChunkClient) CloseAndRecv() (*FileChunk, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(FileChunk)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *objectAPIClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
-	out := new(DeleteObjectsResponse)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/DeleteObjects", in, out, opts...)
+func (c *aPIClient) PutFileChunks(ctx context.Context, in *PutFileChunksRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/PutFileChunks", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *objectAPIClient) GetTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (ObjectAPI_GetTagClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[7], "/pfs.ObjectAPI/GetTag", opts...)
+func (c *aPIClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/CopyFile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIGetTagClient{stream}
+	return out, nil
+}
+
+func (c *aPIClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (API_GetFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[8], "/pfs.API/GetFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIGetFileClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -5696,16 +6243,16 @@ func (c *objectAPIClient) GetTag(ctx context.Context, in *Tag, opts ...grpc.Call
 	return x, nil
 }
 
-type ObjectAPI_GetTagClient interface {
+type API_GetFileClient interface {
 	Recv() (*types.BytesValue, error)
 	grpc.ClientStream
 }
 
-type objectAPIGetTagClient struct {
+type aPIGetFileClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIGetTagClient) Recv() (*types.BytesValue, error) {
+func (x *aPIGetFileClient) Recv() (*types.BytesValue, error) {
 	m := new(types.BytesValue)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -5713,21 +6260,30 @@ func (x *objectAPIGetTagClient) Recv() (*types.BytesValue, error) {
 	return m, nil
 }
 
-func (c *objectAPIClient) InspectTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (*ObjectInfo, error) {
-	out := new(ObjectInfo)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/InspectTag", in, out, opts...)
+func (c *aPIClient) InspectFile(ctx context.Context, in *InspectFileRequest, opts ...grpc.CallOption) (*FileInfo, error) {
+	out := new(FileInfo)
+	err := c.cc.Invoke(ctx, "/pfs.API/InspectFile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *objectAPIClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (ObjectAPI_ListTagsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[8], "/pfs.ObjectAPI/ListTags", opts...)
+func (c *aPIClient) ListFile(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*FileInfos, error) {
+	out := new(FileInfos)
+	err := c.cc.Invoke(ctx, "/pfs.API/ListFile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &objectAPIListTagsClient{stream}
+	return out, nil
+}
+
+func (c *aPIClient) ListFileStream(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (API_ListFileStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[9], "/pfs.API/ListFileStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIListFileStreamClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -5737,2390 +6293,2151 @@ func (c *objectAPIClient) ListTags(ctx context.Context, in *ListTagsRequest, opt
 	return x, nil
 }
 
-type ObjectAPI_ListTagsClient interface {
-	Recv() (*ListTagsResponse, error)
+type API_ListFileStreamClient interface {
+	Recv() (*FileInfo, error)
 	grpc.ClientStream
 }
 
-type objectAPIListTagsClient struct {
+type aPIListFileStreamClient struct {
 	grpc.ClientStream
 }
 
-func (x *objectAPIListTagsClient) Recv() (*ListTagsResponse, error) {
-	m := new(ListTagsResponse)
+func (x *aPIListFileStreamClient) Recv() (*FileInfo, error) {
+	m := new(FileInfo)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *objectAPIClient) DeleteTags(ctx context.Context, in *DeleteTagsRequest, opts ...grpc.CallOption) (*DeleteTagsResponse, error) {
-	out := new(DeleteTagsResponse)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/DeleteTags", in, out, opts...)
+func (c *aPIClient) WalkFile(ctx context.Context, in *WalkFileRequest, opts ...grpc.CallOption) (API_WalkFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[10], "/pfs.API/WalkFile", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *objectAPIClient) Compact(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/Compact", in, out, opts...)
-	if err != nil {
+	x := &aPIWalkFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ObjectAPIServer is the server API for ObjectAPI service.
-type ObjectAPIServer interface {
-	PutObject(ObjectAPI_PutObjectServer) error
-	PutObjectSplit(ObjectAPI_PutObjectSplitServer) error
-	PutObjects(ObjectAPI_PutObjectsServer) error
-	GetObject(*Object, ObjectAPI_GetObjectServer) error
-	GetObjects(*GetObjectsRequest, ObjectAPI_GetObjectsServer) error
-	GetBlocks(*GetBlocksRequest, ObjectAPI_GetBlocksServer) error
-	TagObject(context.Context, *TagObjectRequest) (*types.Empty, error)
-	InspectObject(context.Context, *Object) (*ObjectInfo, error)
-	// CheckObject checks if an object exists in the blob store without
-	// actually reading the object.
-	CheckObject(context.Context, *CheckObjectRequest) (*CheckObjectResponse, error)
-	ListObjects(*ListObjectsRequest, ObjectAPI_ListObjectsServer) error
-	DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error)
-	GetTag(*Tag, ObjectAPI_GetTagServer) error
-	InspectTag(context.Context, *Tag) (*ObjectInfo, error)
-	ListTags(*ListTagsRequest, ObjectAPI_ListTagsServer) error
-	DeleteTags(context.Context, *DeleteTagsRequest) (*DeleteTagsResponse, error)
-	Compact(context.Context, *types.Empty) (*types.Empty, error)
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func RegisterObjectAPIServer(s *grpc.Server, srv ObjectAPIServer) {
-	s.RegisterService(&_ObjectAPI_serviceDesc, srv)
+type API_WalkFileClient interface {
+	Recv() (*FileInfo, error)
+	grpc.ClientStream
 }
 
-func _ObjectAPI_PutObject_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ObjectAPIServer).PutObject(&objectAPIPutObjectServer{stream})
+type aPIWalkFileClient struct {
+	grpc.ClientStream
 }
 
-type ObjectAPI_PutObjectServer interface {
-	SendAndClose(*Object) error
-	Recv() (*PutObjectRequest, error)
-	grpc.ServerStream
+func (x *aPIWalkFileClient) Recv() (*FileInfo, error) {
+	m := new(FileInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-type objectAPIPutObjectServer struct {
-	grpc.ServerStream
-}
-
-func (x *objectAPIPutObjectServer) SendAndClose(m *Object) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *objectAPIPutObjectServer) Recv() (*PutObjectRequest, error) {
-	m := new(PutObjectRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (c *aPIClient) GlobFile(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (*FileInfos, error) {
+	out := new(FileInfos)
+	err := c.cc.Invoke(ctx, "/pfs.API/GlobFile", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-func _ObjectAPI_PutObjectSplit_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ObjectAPIServer).PutObjectSplit(&objectAPIPutObjectSplitServer{stream})
+	return out, nil
 }
 
-type ObjectAPI_PutObjectSplitServer interface {
-	SendAndClose(*Objects) error
-	Recv() (*PutObjectRequest, error)
-	grpc.ServerStream
+func (c *aPIClient) GlobFileStream(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (API_GlobFileStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[11], "/pfs.API/GlobFileStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIGlobFileStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type objectAPIPutObjectSplitServer struct {
-	grpc.ServerStream
+type API_GlobFileStreamClient interface {
+	Recv() (*FileInfo, error)
+	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectSplitServer) SendAndClose(m *Objects) error {
-	return x.ServerStream.SendMsg(m)
+type aPIGlobFileStreamClient struct {
+	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectSplitServer) Recv() (*PutObjectRequest, error) {
-	m := new(PutObjectRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (x *aPIGlobFileStreamClient) Recv() (*FileInfo, error) {
+	m := new(FileInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _ObjectAPI_PutObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ObjectAPIServer).PutObjects(&objectAPIPutObjectsServer{stream})
+func (c *aPIClient) DiffFile(ctx context.Context, in *DiffFileRequest, opts ...grpc.CallOption) (*DiffFileResponse, error) {
+	out := new(DiffFileResponse)
+	err := c.cc.Invoke(ctx, "/pfs.API/DiffFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type ObjectAPI_PutObjectsServer interface {
-	SendAndClose(*types.Empty) error
-	Recv() (*PutObjectRequest, error)
-	grpc.ServerStream
+func (c *aPIClient) GetCommitTree(ctx context.Context, in *GetCommitTreeRequest, opts ...grpc.CallOption) (API_GetCommitTreeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[12], "/pfs.API/GetCommitTree", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIGetCommitTreeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type objectAPIPutObjectsServer struct {
-	grpc.ServerStream
+type API_GetCommitTreeClient interface {
+	Recv() (*TreeNode, error)
+	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectsServer) SendAndClose(m *types.Empty) error {
-	return x.ServerStream.SendMsg(m)
+type aPIGetCommitTreeClient struct {
+	grpc.ClientStream
 }
 
-func (x *objectAPIPutObjectsServer) Recv() (*PutObjectRequest, error) {
-	m := new(PutObjectRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func (x *aPIGetCommitTreeClient) Recv() (*TreeNode, error) {
+	m := new(TreeNode)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _ObjectAPI_GetObject_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Object)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (c *aPIClient) GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (API_GetManifestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[13], "/pfs.API/GetManifest", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return srv.(ObjectAPIServer).GetObject(m, &objectAPIGetObjectServer{stream})
-}
-
-type ObjectAPI_GetObjectServer interface {
-	Send(*types.BytesValue) error
-	grpc.ServerStream
-}
-
-type objectAPIGetObjectServer struct {
-	grpc.ServerStream
-}
-
-func (x *objectAPIGetObjectServer) Send(m *types.BytesValue) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _ObjectAPI_GetObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetObjectsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	x := &aPIGetManifestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return srv.(ObjectAPIServer).GetObjects(m, &objectAPIGetObjectsServer{stream})
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type ObjectAPI_GetObjectsServer interface {
-	Send(*types.BytesValue) error
-	grpc.ServerStream
+type API_GetManifestClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
 }
 
-type objectAPIGetObjectsServer struct {
-	grpc.ServerStream
+type aPIGetManifestClient struct {
+	grpc.ClientStream
 }
 
-func (x *objectAPIGetObjectsServer) Send(m *types.BytesValue) error {
-	return x.ServerStream.SendMsg(m)
+func (x *aPIGetManifestClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _ObjectAPI_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetBlocksRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (c *aPIClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/DeleteFile", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return srv.(ObjectAPIServer).GetBlocks(m, &objectAPIGetBlocksServer{stream})
+	return out, nil
 }
 
-type ObjectAPI_GetBlocksServer interface {
-	Send(*types.BytesValue) error
-	grpc.ServerStream
+func (c *aPIClient) DeleteAll(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.API/DeleteAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type objectAPIGetBlocksServer struct {
-	grpc.ServerStream
+// APIServer is the server API for API service.
+type APIServer interface {
+	// Repo rpcs
+	// CreateRepo creates a new repo.
+	// An error is returned if the repo already exists.
+	CreateRepo(context.Context, *CreateRepoRequest) (*types.Empty, error)
+	// InspectRepo returns info about a repo.
+	InspectRepo(context.Context, *InspectRepoRequest) (*RepoInfo, error)
+	// InspectRepos returns info about several repos, in the order that they're
+	// requested. Repos that can't be inspected are flagged, rather than failing
+	// the call.
+	InspectRepos(*InspectReposRequest, API_InspectReposServer) error
+	// ListRepo returns info about all repos.
+	ListRepo(context.Context, *ListRepoRequest) (*ListRepoResponse, error)
+	// DiskUsage returns the storage used by a repo, or by every repo. It reads
+	// every commit in every repo, so it may be slow.
+	DiskUsage(context.Context, *DiskUsageRequest) (*DiskUsageResponse, error)
+	// DeleteRepo deletes a repo.
+	DeleteRepo(context.Context, *DeleteRepoRequest) (*types.Empty, error)
+	// Commit rpcs
+	// StartCommit creates a new write commit from a parent commit.
+	StartCommit(context.Context, *StartCommitRequest) (*Commit, error)
+	// FinishCommit turns a write commit into a read commit.
+	FinishCommit(context.Context, *FinishCommitRequest) (*types.Empty, error)
+	// InspectCommit returns the info about a commit.
+	InspectCommit(context.Context, *InspectCommitRequest) (*CommitInfo, error)
+	// InspectCommits returns info about several commits, in the order that
+	// they're requested. Commits that can't be inspected are flagged, rather
+	// than failing the call.
+	InspectCommits(*InspectCommitsRequest, API_InspectCommitsServer) error
+	// ListCommit returns info about all commits. This is deprecated in favor of
+	// ListCommitStream.
+	ListCommit(context.Context, *ListCommitRequest) (*CommitInfos, error)
+	// ListCommitStream is like ListCommit, but returns its results in a GRPC stream
+	ListCommitStream(*ListCommitRequest, API_ListCommitStreamServer) error
+	// DeleteCommit deletes a commit.
+	DeleteCommit(context.Context, *DeleteCommitRequest) (*types.Empty, error)
+	// SquashCommit deletes the ancestors of a commit, and their downstream
+	// commits, except for those that are still needed.
+	SquashCommit(context.Context, *SquashCommitRequest) (*types.Empty, error)
+	// FlushCommit waits for downstream commits to finish
+	FlushCommit(*FlushCommitRequest, API_FlushCommitServer) error
+	// SubscribeCommit subscribes for new commits on a given branch
+	SubscribeCommit(*SubscribeCommitRequest, API_SubscribeCommitServer) error
+	// BuildCommit builds a commit that's backed by the given tree
+	BuildCommit(context.Context, *BuildCommitRequest) (*Commit, error)
+	// ProvenanceGraph streams the commits in a commit's provenance, one node
+	// (and its edges) at a time, starting with the commit itself, so that large
+	// lineage graphs can be rendered as they arrive.
+	ProvenanceGraph(*ProvenanceGraphRequest, API_ProvenanceGraphServer) error
+	// CreateBranch creates a new branch
+	CreateBranch(context.Context, *CreateBranchRequest) (*types.Empty, error)
+	// InspectBranch returns info about a branch.
+	InspectBranch(context.Context, *InspectBranchRequest) (*BranchInfo, error)
+	// ListBranch returns info about the heads of branches.
+	ListBranch(context.Context, *ListBranchRequest) (*BranchInfos, error)
+	// ListCommitBranches returns info about the branches whose head is a
+	// commit, or optionally that have the commit in their history.
+	ListCommitBranches(context.Context, *ListCommitBranchesRequest) (*BranchInfos, error)
+	// SetMaxHistoryDepth limits the number of commits kept in a branch's
+	// history; older commits are deleted as new commits are finished.
+	SetMaxHistoryDepth(context.Context, *SetMaxHistoryDepthRequest) (*types.Empty, error)
+	// SwapBranch atomically swaps the heads (and provenance) of two branches
+	// in the same repo, e.g. to promote a staging branch to production.
+	SwapBranch(context.Context, *SwapBranchRequest) (*types.Empty, error)
+	// DeleteBranch deletes a branch; note that the commits still exist.
+	DeleteBranch(context.Context, *DeleteBranchRequest) (*types.Empty, error)
+	// File rpcs
+	// PutFile writes the specified file to pfs.
+	PutFile(API_PutFileServer) error
+	// PutFileChunk stores a chunk of a file's content, which is written to the
+	// file by PutFileChunks. Clients can upload the chunks of a large file
+	// concurrently, in separate streams.
+	PutFileChunk(API_PutFileChunkServer) error
+	// PutFileChunks writes the chunks stored by PutFileChunk to a file, in
+	// the order of their offsets.
+	PutFileChunks(context.Context, *PutFileChunksRequest) (*types.Empty, error)
+	// CopyFile copies the contents of one file to another.
+	CopyFile(context.Context, *CopyFileRequest) (*types.Empty, error)
+	// GetFile returns a byte stream of the contents of the file.
+	GetFile(*GetFileRequest, API_GetFileServer) error
+	// InspectFile returns info about a file.
+	InspectFile(context.Context, *InspectFileRequest) (*FileInfo, error)
+	// ListFile returns info about all files. This is deprecated in favor of
+	// ListFileStream
+	ListFile(context.Context, *ListFileRequest) (*FileInfos, error)
+	// ListFileStream is a streaming version of ListFile
+	// TODO(msteffen): When the dash has been updated to use ListFileStream,
+	// replace ListFile with this RPC (https://github.com/pachyderm/dash/issues/201)
+	ListFileStream(*ListFileRequest, API_ListFileStreamServer) error
+	// WalkFile walks over all the files under a directory, including children of children.
+	WalkFile(*WalkFileRequest, API_WalkFileServer) error
+	// GlobFile returns info about all files. This is deprecated in favor of
+	// GlobFileStream
+	GlobFile(context.Context, *GlobFileRequest) (*FileInfos, error)
+	// GlobFileStream is a streaming version of GlobFile
+	// TODO(msteffen): When the dash has been updated to use GlobFileStream,
+	// replace GlobFile with this RPC (https://github.com/pachyderm/dash/issues/201)
+	GlobFileStream(*GlobFileRequest, API_GlobFileStreamServer) error
+	// DiffFile returns the differences between 2 paths at 2 commits.
+	DiffFile(context.Context, *DiffFileRequest) (*DiffFileResponse, error)
+	// GetCommitTree returns the path and hash of every file and directory in a
+	// commit, in order of path, so that commits can be diffed without reading
+	// their files' content.
+	GetCommitTree(*GetCommitTreeRequest, API_GetCommitTreeServer) error
+	// GetManifest returns a manifest of the files in a commit, as
+	// newline-delimited JSON: one ManifestEntry (path, size and hashes) per file,
+	// in order of path, so that copies of the files kept outside of pachyderm
+	// can be verified against it.
+	GetManifest(*GetManifestRequest, API_GetManifestServer) error
+	// DeleteFile deletes a file.
+	DeleteFile(context.Context, *DeleteFileRequest) (*types.Empty, error)
+	// DeleteAll deletes everything
+	DeleteAll(context.Context, *types.Empty) (*types.Empty, error)
 }
 
-func (x *objectAPIGetBlocksServer) Send(m *types.BytesValue) error {
-	return x.ServerStream.SendMsg(m)
+func RegisterAPIServer(s *grpc.Server, srv APIServer) {
+	s.RegisterService(&_API_serviceDesc, srv)
 }
 
-func _ObjectAPI_TagObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TagObjectRequest)
+func _API_CreateRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRepoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ObjectAPIServer).TagObject(ctx, in)
+		return srv.(APIServer).CreateRepo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/TagObject",
+		FullMethod: "/pfs.API/CreateRepo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).TagObject(ctx, req.(*TagObjectRequest))
+		return srv.(APIServer).CreateRepo(ctx, req.(*CreateRepoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ObjectAPI_InspectObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Object)
+func _API_InspectRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectRepoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ObjectAPIServer).InspectObject(ctx, in)
+		return srv.(APIServer).InspectRepo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/InspectObject",
+		FullMethod: "/pfs.API/InspectRepo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).InspectObject(ctx, req.(*Object))
+		return srv.(APIServer).InspectRepo(ctx, req.(*InspectRepoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ObjectAPI_CheckObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckObjectRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func _API_InspectRepos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InspectReposRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	if interceptor == nil {
-		return srv.(ObjectAPIServer).CheckObject(ctx, in)
+	return srv.(APIServer).InspectRepos(m, &aPIInspectReposServer{stream})
+}
+
+type API_InspectReposServer interface {
+	Send(*InspectRepoResult) error
+	grpc.ServerStream
+}
+
+type aPIInspectReposServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIInspectReposServer) Send(m *InspectRepoResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_ListRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListRepo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/CheckObject",
+		FullMethod: "/pfs.API/ListRepo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).CheckObject(ctx, req.(*CheckObjectRequest))
+		return srv.(APIServer).ListRepo(ctx, req.(*ListRepoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ObjectAPI_ListObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListObjectsRequest)
+func _API_DiskUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DiskUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/DiskUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DiskUsage(ctx, req.(*DiskUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/DeleteRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteRepo(ctx, req.(*DeleteRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_StartCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).StartCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/StartCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).StartCommit(ctx, req.(*StartCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FinishCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FinishCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/FinishCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FinishCommit(ctx, req.(*FinishCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_InspectCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).InspectCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/InspectCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).InspectCommit(ctx, req.(*InspectCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_InspectCommits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InspectCommitsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ObjectAPIServer).ListObjects(m, &objectAPIListObjectsServer{stream})
+	return srv.(APIServer).InspectCommits(m, &aPIInspectCommitsServer{stream})
 }
 
-type ObjectAPI_ListObjectsServer interface {
-	Send(*Object) error
+type API_InspectCommitsServer interface {
+	Send(*InspectCommitResult) error
 	grpc.ServerStream
 }
 
-type objectAPIListObjectsServer struct {
+type aPIInspectCommitsServer struct {
 	grpc.ServerStream
 }
 
-func (x *objectAPIListObjectsServer) Send(m *Object) error {
+func (x *aPIInspectCommitsServer) Send(m *InspectCommitResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ObjectAPI_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteObjectsRequest)
+func _API_ListCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ObjectAPIServer).DeleteObjects(ctx, in)
+		return srv.(APIServer).ListCommit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/DeleteObjects",
+		FullMethod: "/pfs.API/ListCommit",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
+		return srv.(APIServer).ListCommit(ctx, req.(*ListCommitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ObjectAPI_GetTag_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Tag)
+func _API_ListCommitStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCommitRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ObjectAPIServer).GetTag(m, &objectAPIGetTagServer{stream})
+	return srv.(APIServer).ListCommitStream(m, &aPIListCommitStreamServer{stream})
 }
 
-type ObjectAPI_GetTagServer interface {
-	Send(*types.BytesValue) error
+type API_ListCommitStreamServer interface {
+	Send(*CommitInfo) error
 	grpc.ServerStream
 }
 
-type objectAPIGetTagServer struct {
+type aPIListCommitStreamServer struct {
 	grpc.ServerStream
 }
 
-func (x *objectAPIGetTagServer) Send(m *types.BytesValue) error {
+func (x *aPIListCommitStreamServer) Send(m *CommitInfo) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ObjectAPI_InspectTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Tag)
+func _API_DeleteCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ObjectAPIServer).InspectTag(ctx, in)
+		return srv.(APIServer).DeleteCommit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/InspectTag",
+		FullMethod: "/pfs.API/DeleteCommit",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).InspectTag(ctx, req.(*Tag))
+		return srv.(APIServer).DeleteCommit(ctx, req.(*DeleteCommitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ObjectAPI_ListTags_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ListTagsRequest)
+func _API_SquashCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SquashCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SquashCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/SquashCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SquashCommit(ctx, req.(*SquashCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FlushCommit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FlushCommitRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ObjectAPIServer).ListTags(m, &objectAPIListTagsServer{stream})
+	return srv.(APIServer).FlushCommit(m, &aPIFlushCommitServer{stream})
 }
 
-type ObjectAPI_ListTagsServer interface {
-	Send(*ListTagsResponse) error
+type API_FlushCommitServer interface {
+	Send(*CommitInfo) error
 	grpc.ServerStream
 }
 
-type objectAPIListTagsServer struct {
+type aPIFlushCommitServer struct {
 	grpc.ServerStream
 }
 
-func (x *objectAPIListTagsServer) Send(m *ListTagsResponse) error {
+func (x *aPIFlushCommitServer) Send(m *CommitInfo) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ObjectAPI_DeleteTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTagsRequest)
+func _API_SubscribeCommit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeCommitRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeCommit(m, &aPISubscribeCommitServer{stream})
+}
+
+type API_SubscribeCommitServer interface {
+	Send(*CommitInfo) error
+	grpc.ServerStream
+}
+
+type aPISubscribeCommitServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeCommitServer) Send(m *CommitInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_BuildCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildCommitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ObjectAPIServer).DeleteTags(ctx, in)
+		return srv.(APIServer).BuildCommit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/DeleteTags",
+		FullMethod: "/pfs.API/BuildCommit",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).DeleteTags(ctx, req.(*DeleteTagsRequest))
+		return srv.(APIServer).BuildCommit(ctx, req.(*BuildCommitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ObjectAPI_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.Empty)
+func _API_ProvenanceGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProvenanceGraphRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).ProvenanceGraph(m, &aPIProvenanceGraphServer{stream})
+}
+
+type API_ProvenanceGraphServer interface {
+	Send(*ProvenanceGraphNode) error
+	grpc.ServerStream
+}
+
+type aPIProvenanceGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIProvenanceGraphServer) Send(m *ProvenanceGraphNode) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_CreateBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBranchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ObjectAPIServer).Compact(ctx, in)
+		return srv.(APIServer).CreateBranch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pfs.ObjectAPI/Compact",
+		FullMethod: "/pfs.API/CreateBranch",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ObjectAPIServer).Compact(ctx, req.(*types.Empty))
+		return srv.(APIServer).CreateBranch(ctx, req.(*CreateBranchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ObjectAPI_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pfs.ObjectAPI",
-	HandlerType: (*ObjectAPIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "TagObject",
-			Handler:    _ObjectAPI_TagObject_Handler,
-		},
-		{
-			MethodName: "InspectObject",
-			Handler:    _ObjectAPI_InspectObject_Handler,
-		},
-		{
-			MethodName: "CheckObject",
-			Handler:    _ObjectAPI_CheckObject_Handler,
-		},
-		{
-			MethodName: "DeleteObjects",
-			Handler:    _ObjectAPI_DeleteObjects_Handler,
-		},
-		{
-			MethodName: "InspectTag",
-			Handler:    _ObjectAPI_InspectTag_Handler,
-		},
-		{
-			MethodName: "DeleteTags",
-			Handler:    _ObjectAPI_DeleteTags_Handler,
-		},
-		{
-			MethodName: "Compact",
-			Handler:    _ObjectAPI_Compact_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "PutObject",
-			Handler:       _ObjectAPI_PutObject_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "PutObjectSplit",
-			Handler:       _ObjectAPI_PutObjectSplit_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "PutObjects",
-			Handler:       _ObjectAPI_PutObjects_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "GetObject",
-			Handler:       _ObjectAPI_GetObject_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "GetObjects",
-			Handler:       _ObjectAPI_GetObjects_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "GetBlocks",
-			Handler:       _ObjectAPI_GetBlocks_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ListObjects",
-			Handler:       _ObjectAPI_ListObjects_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "GetTag",
-			Handler:       _ObjectAPI_GetTag_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ListTags",
-			Handler:       _ObjectAPI_ListTags_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "client/pfs/pfs.proto",
-}
-
-func (m *Repo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_InspectBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectBranchRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Repo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	if interceptor == nil {
+		return srv.(APIServer).InspectBranch(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/InspectBranch",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).InspectBranch(ctx, req.(*InspectBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Branch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_ListBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBranchRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Branch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n1, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n1
+	if interceptor == nil {
+		return srv.(APIServer).ListBranch(ctx, in)
 	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/ListBranch",
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListBranch(ctx, req.(*ListBranchRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *BranchInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_ListCommitBranches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommitBranchesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BranchInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	if interceptor == nil {
+		return srv.(APIServer).ListCommitBranches(ctx, in)
 	}
-	if m.Head != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Head.Size()))
-		n2, err := m.Head.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/ListCommitBranches",
 	}
-	if len(m.Provenance) > 0 {
-		for _, msg := range m.Provenance {
-			dAtA[i] = 0x1a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListCommitBranches(ctx, req.(*ListCommitBranchesRequest))
 	}
-	if m.Branch != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
-		n3, err := m.Branch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetMaxHistoryDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaxHistoryDepthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Subvenance) > 0 {
-		for _, msg := range m.Subvenance {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if interceptor == nil {
+		return srv.(APIServer).SetMaxHistoryDepth(ctx, in)
 	}
-	if len(m.DirectProvenance) > 0 {
-		for _, msg := range m.DirectProvenance {
-			dAtA[i] = 0x32
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/SetMaxHistoryDepth",
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetMaxHistoryDepth(ctx, req.(*SetMaxHistoryDepthRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *BranchInfos) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_SwapBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapBranchRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BranchInfos) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.BranchInfo) > 0 {
-		for _, msg := range m.BranchInfo {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if interceptor == nil {
+		return srv.(APIServer).SwapBranch(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/SwapBranch",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SwapBranch(ctx, req.(*SwapBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *File) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_DeleteBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBranchRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *File) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Commit != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
-		n4, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
+	if interceptor == nil {
+		return srv.(APIServer).DeleteBranch(ctx, in)
 	}
-	if len(m.Path) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/DeleteBranch",
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteBranch(ctx, req.(*DeleteBranchRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Block) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func _API_PutFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).PutFile(&aPIPutFileServer{stream})
 }
 
-func (m *Block) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type API_PutFileServer interface {
+	SendAndClose(*types.Empty) error
+	Recv() (*PutFileRequest, error)
+	grpc.ServerStream
 }
 
-func (m *Object) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type aPIPutFileServer struct {
+	grpc.ServerStream
 }
 
-func (m *Object) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func (x *aPIPutFileServer) SendAndClose(m *types.Empty) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *Tag) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func (x *aPIPutFileServer) Recv() (*PutFileRequest, error) {
+	m := new(PutFileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *Tag) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func _API_PutFileChunk_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).PutFileChunk(&aPIPutFileChunkServer{stream})
 }
 
-func (m *RepoInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+type API_PutFileChunkServer interface {
+	SendAndClose(*FileChunk) error
+	Recv() (*PutFileChunkRequest, error)
+	grpc.ServerStream
+}
+
+type aPIPutFileChunkServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIPutFileChunkServer) SendAndClose(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPIPutFileChunkServer) Recv() (*PutFileChunkRequest, error) {
+	m := new(PutFileChunkRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *RepoInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n5, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+func _API_PutFileChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutFileChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Created != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Created.Size()))
-		n6, err := m.Created.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+	if interceptor == nil {
+		return srv.(APIServer).PutFileChunks(ctx, in)
 	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/PutFileChunks",
 	}
-	if len(m.Description) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
-		i += copy(dAtA[i:], m.Description)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).PutFileChunks(ctx, req.(*PutFileChunksRequest))
 	}
-	if m.AuthInfo != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.AuthInfo.Size()))
-		n7, err := m.AuthInfo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Branches) > 0 {
-		for _, msg := range m.Branches {
-			dAtA[i] = 0x3a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if interceptor == nil {
+		return srv.(APIServer).CopyFile(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/CopyFile",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CopyFile(ctx, req.(*CopyFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RepoAuthInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func _API_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(APIServer).GetFile(m, &aPIGetFileServer{stream})
 }
 
-func (m *RepoAuthInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.AccessLevel != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.AccessLevel))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type API_GetFileServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
 }
 
-func (m *Commit) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type aPIGetFileServer struct {
+	grpc.ServerStream
 }
 
-func (m *Commit) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n8, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+func (x *aPIGetFileServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_InspectFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+	if interceptor == nil {
+		return srv.(APIServer).InspectFile(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/InspectFile",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).InspectFile(ctx, req.(*InspectFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *CommitRange) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_ListFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CommitRange) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Lower != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Lower.Size()))
-		n9, err := m.Lower.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+	if interceptor == nil {
+		return srv.(APIServer).ListFile(ctx, in)
 	}
-	if m.Upper != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Upper.Size()))
-		n10, err := m.Upper.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/ListFile",
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListFile(ctx, req.(*ListFileRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *CommitInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func _API_ListFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(APIServer).ListFileStream(m, &aPIListFileStreamServer{stream})
 }
 
-func (m *CommitInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Commit != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
-		n11, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
+type API_ListFileStreamServer interface {
+	Send(*FileInfo) error
+	grpc.ServerStream
+}
+
+type aPIListFileStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIListFileStreamServer) Send(m *FileInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_WalkFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WalkFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	if m.ParentCommit != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.ParentCommit.Size()))
-		n12, err := m.ParentCommit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
+	return srv.(APIServer).WalkFile(m, &aPIWalkFileServer{stream})
+}
+
+type API_WalkFileServer interface {
+	Send(*FileInfo) error
+	grpc.ServerStream
+}
+
+type aPIWalkFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIWalkFileServer) Send(m *FileInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_GlobFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GlobFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Started != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Started.Size()))
-		n13, err := m.Started.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n13
+	if interceptor == nil {
+		return srv.(APIServer).GlobFile(ctx, in)
 	}
-	if m.Finished != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Finished.Size()))
-		n14, err := m.Finished.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n14
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/GlobFile",
 	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GlobFile(ctx, req.(*GlobFileRequest))
 	}
-	if len(m.Provenance) > 0 {
-		for _, msg := range m.Provenance {
-			dAtA[i] = 0x32
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GlobFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GlobFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	if m.Tree != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Tree.Size()))
-		n15, err := m.Tree.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n15
+	return srv.(APIServer).GlobFileStream(m, &aPIGlobFileStreamServer{stream})
+}
+
+type API_GlobFileStreamServer interface {
+	Send(*FileInfo) error
+	grpc.ServerStream
+}
+
+type aPIGlobFileStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIGlobFileStreamServer) Send(m *FileInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_DiffFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Description) > 0 {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
-		i += copy(dAtA[i:], m.Description)
+	if interceptor == nil {
+		return srv.(APIServer).DiffFile(ctx, in)
 	}
-	if len(m.Subvenance) > 0 {
-		for _, msg := range m.Subvenance {
-			dAtA[i] = 0x4a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/DiffFile",
 	}
-	if len(m.BranchProvenance) > 0 {
-		for _, msg := range m.BranchProvenance {
-			dAtA[i] = 0x52
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.ChildCommits) > 0 {
-		for _, msg := range m.ChildCommits {
-			dAtA[i] = 0x5a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.ReadyProvenance != 0 {
-		dAtA[i] = 0x60
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.ReadyProvenance))
-	}
-	if len(m.Trees) > 0 {
-		for _, msg := range m.Trees {
-			dAtA[i] = 0x6a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.Datums != nil {
-		dAtA[i] = 0x72
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Datums.Size()))
-		n16, err := m.Datums.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n16
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DiffFile(ctx, req.(*DiffFileRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *FileInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func _API_GetCommitTree_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCommitTreeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(APIServer).GetCommitTree(m, &aPIGetCommitTreeServer{stream})
 }
 
-func (m *FileInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.File != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n17, err := m.File.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n17
-	}
-	if m.FileType != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.FileType))
-	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
-	}
-	if len(m.Children) > 0 {
-		for _, s := range m.Children {
-			dAtA[i] = 0x32
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
-	}
-	if len(m.Objects) > 0 {
-		for _, msg := range m.Objects {
-			dAtA[i] = 0x42
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.BlockRefs) > 0 {
-		for _, msg := range m.BlockRefs {
-			dAtA[i] = 0x4a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.Committed != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Committed.Size()))
-		n18, err := m.Committed.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n18
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type API_GetCommitTreeServer interface {
+	Send(*TreeNode) error
+	grpc.ServerStream
 }
 
-func (m *ByteRange) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type aPIGetCommitTreeServer struct {
+	grpc.ServerStream
 }
 
-func (m *ByteRange) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Lower != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Lower))
-	}
-	if m.Upper != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Upper))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func (x *aPIGetCommitTreeServer) Send(m *TreeNode) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *BlockRef) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func _API_GetManifest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetManifestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(APIServer).GetManifest(m, &aPIGetManifestServer{stream})
 }
 
-func (m *BlockRef) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Block != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Block.Size()))
-		n19, err := m.Block.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n19
+type API_GetManifestServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
+}
+
+type aPIGetManifestServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIGetManifestServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Range != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Range.Size()))
-		n20, err := m.Range.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n20
+	if interceptor == nil {
+		return srv.(APIServer).DeleteFile(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/DeleteFile",
 	}
-	return i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ObjectInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _API_DeleteAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(APIServer).DeleteAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.API/DeleteAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteAll(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ObjectInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Object != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
-		n21, err := m.Object.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n21
+var _API_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pfs.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRepo",
+			Handler:    _API_CreateRepo_Handler,
+		},
+		{
+			MethodName: "InspectRepo",
+			Handler:    _API_InspectRepo_Handler,
+		},
+		{
+			MethodName: "ListRepo",
+			Handler:    _API_ListRepo_Handler,
+		},
+		{
+			MethodName: "DiskUsage",
+			Handler:    _API_DiskUsage_Handler,
+		},
+		{
+			MethodName: "DeleteRepo",
+			Handler:    _API_DeleteRepo_Handler,
+		},
+		{
+			MethodName: "StartCommit",
+			Handler:    _API_StartCommit_Handler,
+		},
+		{
+			MethodName: "FinishCommit",
+			Handler:    _API_FinishCommit_Handler,
+		},
+		{
+			MethodName: "InspectCommit",
+			Handler:    _API_InspectCommit_Handler,
+		},
+		{
+			MethodName: "ListCommit",
+			Handler:    _API_ListCommit_Handler,
+		},
+		{
+			MethodName: "DeleteCommit",
+			Handler:    _API_DeleteCommit_Handler,
+		},
+		{
+			MethodName: "SquashCommit",
+			Handler:    _API_SquashCommit_Handler,
+		},
+		{
+			MethodName: "BuildCommit",
+			Handler:    _API_BuildCommit_Handler,
+		},
+		{
+			MethodName: "CreateBranch",
+			Handler:    _API_CreateBranch_Handler,
+		},
+		{
+			MethodName: "InspectBranch",
+			Handler:    _API_InspectBranch_Handler,
+		},
+		{
+			MethodName: "ListBranch",
+			Handler:    _API_ListBranch_Handler,
+		},
+		{
+			MethodName: "ListCommitBranches",
+			Handler:    _API_ListCommitBranches_Handler,
+		},
+		{
+			MethodName: "SetMaxHistoryDepth",
+			Handler:    _API_SetMaxHistoryDepth_Handler,
+		},
+		{
+			MethodName: "SwapBranch",
+			Handler:    _API_SwapBranch_Handler,
+		},
+		{
+			MethodName: "DeleteBranch",
+			Handler:    _API_DeleteBranch_Handler,
+		},
+		{
+			MethodName: "PutFileChunks",
+			Handler:    _API_PutFileChunks_Handler,
+		},
+		{
+			MethodName: "CopyFile",
+			Handler:    _API_CopyFile_Handler,
+		},
+		{
+			MethodName: "InspectFile",
+			Handler:    _API_InspectFile_Handler,
+		},
+		{
+			MethodName: "ListFile",
+			Handler:    _API_ListFile_Handler,
+		},
+		{
+			MethodName: "GlobFile",
+			Handler:    _API_GlobFile_Handler,
+		},
+		{
+			MethodName: "DiffFile",
+			Handler:    _API_DiffFile_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _API_DeleteFile_Handler,
+		},
+		{
+			MethodName: "DeleteAll",
+			Handler:    _API_DeleteAll_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "InspectRepos",
+			Handler:       _API_InspectRepos_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "InspectCommits",
+			Handler:       _API_InspectCommits_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListCommitStream",
+			Handler:       _API_ListCommitStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FlushCommit",
+			Handler:       _API_FlushCommit_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeCommit",
+			Handler:       _API_SubscribeCommit_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ProvenanceGraph",
+			Handler:       _API_ProvenanceGraph_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PutFile",
+			Handler:       _API_PutFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PutFileChunk",
+			Handler:       _API_PutFileChunk_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetFile",
+			Handler:       _API_GetFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListFileStream",
+			Handler:       _API_ListFileStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WalkFile",
+			Handler:       _API_WalkFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GlobFileStream",
+			Handler:       _API_GlobFileStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetCommitTree",
+			Handler:       _API_GetCommitTree_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetManifest",
+			Handler:       _API_GetManifest_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "client/pfs/pfs.proto",
+}
+
+// ObjectAPIClient is the client API for ObjectAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ObjectAPIClient interface {
+	PutObject(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectClient, error)
+	PutObjectSplit(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectSplitClient, error)
+	PutObjects(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectsClient, error)
+	GetObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (ObjectAPI_GetObjectClient, error)
+	GetObjects(ctx context.Context, in *GetObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_GetObjectsClient, error)
+	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (ObjectAPI_GetBlocksClient, error)
+	TagObject(ctx context.Context, in *TagObjectRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	InspectObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (*ObjectInfo, error)
+	// CheckObject checks if an object exists in the blob store without
+	// actually reading the object.
+	CheckObject(ctx context.Context, in *CheckObjectRequest, opts ...grpc.CallOption) (*CheckObjectResponse, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_ListObjectsClient, error)
+	DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error)
+	GetTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (ObjectAPI_GetTagClient, error)
+	InspectTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (*ObjectInfo, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (ObjectAPI_ListTagsClient, error)
+	DeleteTags(ctx context.Context, in *DeleteTagsRequest, opts ...grpc.CallOption) (*DeleteTagsResponse, error)
+	Compact(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error)
+	// InspectCache returns the stats of the object caches of the pachd that
+	// serves the request (see debug.InspectCache for those of every pod).
+	InspectCache(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*InspectCacheResponse, error)
+}
+
+type objectAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewObjectAPIClient(cc *grpc.ClientConn) ObjectAPIClient {
+	return &objectAPIClient{cc}
+}
+
+func (c *objectAPIClient) PutObject(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[0], "/pfs.ObjectAPI/PutObject", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.BlockRef != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.BlockRef.Size()))
-		n22, err := m.BlockRef.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n22
+	x := &objectAPIPutObjectClient{stream}
+	return x, nil
+}
+
+type ObjectAPI_PutObjectClient interface {
+	Send(*PutObjectRequest) error
+	CloseAndRecv() (*Object, error)
+	grpc.ClientStream
+}
+
+type objectAPIPutObjectClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIPutObjectClient) Send(m *PutObjectRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *objectAPIPutObjectClient) CloseAndRecv() (*Object, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	m := new(Object)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *CreateRepoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) PutObjectSplit(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectSplitClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[1], "/pfs.ObjectAPI/PutObjectSplit", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &objectAPIPutObjectSplitClient{stream}
+	return x, nil
 }
 
-func (m *CreateRepoRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n23, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n23
-	}
-	if len(m.Description) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
-		i += copy(dAtA[i:], m.Description)
-	}
-	if m.Update {
-		dAtA[i] = 0x20
-		i++
-		if m.Update {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+type ObjectAPI_PutObjectSplitClient interface {
+	Send(*PutObjectRequest) error
+	CloseAndRecv() (*Objects, error)
+	grpc.ClientStream
+}
+
+type objectAPIPutObjectSplitClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIPutObjectSplitClient) Send(m *PutObjectRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *objectAPIPutObjectSplitClient) CloseAndRecv() (*Objects, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	m := new(Objects)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *InspectRepoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) PutObjects(ctx context.Context, opts ...grpc.CallOption) (ObjectAPI_PutObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[2], "/pfs.ObjectAPI/PutObjects", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &objectAPIPutObjectsClient{stream}
+	return x, nil
 }
 
-func (m *InspectRepoRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n24, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n24
+type ObjectAPI_PutObjectsClient interface {
+	Send(*PutObjectRequest) error
+	CloseAndRecv() (*types.Empty, error)
+	grpc.ClientStream
+}
+
+type objectAPIPutObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIPutObjectsClient) Send(m *PutObjectRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *objectAPIPutObjectsClient) CloseAndRecv() (*types.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	m := new(types.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *ListRepoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) GetObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (ObjectAPI_GetObjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[3], "/pfs.ObjectAPI/GetObject", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &objectAPIGetObjectClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *ListRepoRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type ObjectAPI_GetObjectClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
 }
 
-func (m *ListRepoResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+type objectAPIGetObjectClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIGetObjectClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *ListRepoResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.RepoInfo) > 0 {
-		for _, msg := range m.RepoInfo {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (c *objectAPIClient) GetObjects(ctx context.Context, in *GetObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_GetObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[4], "/pfs.ObjectAPI/GetObjects", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	x := &objectAPIGetObjectsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return i, nil
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *DeleteRepoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+type ObjectAPI_GetObjectsClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
+}
+
+type objectAPIGetObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIGetObjectsClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *DeleteRepoRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n25, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n25
+func (c *objectAPIClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (ObjectAPI_GetBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[5], "/pfs.ObjectAPI/GetBlocks", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Force {
-		dAtA[i] = 0x10
-		i++
-		if m.Force {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	x := &objectAPIGetBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.All {
-		dAtA[i] = 0x18
-		i++
-		if m.All {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return x, nil
+}
+
+type ObjectAPI_GetBlocksClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
+}
+
+type objectAPIGetBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIGetBlocksClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *StartCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) TagObject(ctx context.Context, in *TagObjectRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/TagObject", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *StartCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Parent != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Parent.Size()))
-		n26, err := m.Parent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n26
-	}
-	if len(m.Provenance) > 0 {
-		for _, msg := range m.Provenance {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.Branch) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Branch)))
-		i += copy(dAtA[i:], m.Branch)
-	}
-	if len(m.Description) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
-		i += copy(dAtA[i:], m.Description)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (c *objectAPIClient) InspectObject(ctx context.Context, in *Object, opts ...grpc.CallOption) (*ObjectInfo, error) {
+	out := new(ObjectInfo)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/InspectObject", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *BuildCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) CheckObject(ctx context.Context, in *CheckObjectRequest, opts ...grpc.CallOption) (*CheckObjectResponse, error) {
+	out := new(CheckObjectResponse)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/CheckObject", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *BuildCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Parent != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Parent.Size()))
-		n27, err := m.Parent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n27
-	}
-	if len(m.Provenance) > 0 {
-		for _, msg := range m.Provenance {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.Tree != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Tree.Size()))
-		n28, err := m.Tree.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n28
+func (c *objectAPIClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (ObjectAPI_ListObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[6], "/pfs.ObjectAPI/ListObjects", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Branch) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Branch)))
-		i += copy(dAtA[i:], m.Branch)
+	x := &objectAPIListObjectsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if len(m.ID) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return x, nil
+}
+
+type ObjectAPI_ListObjectsClient interface {
+	Recv() (*Object, error)
+	grpc.ClientStream
+}
+
+type objectAPIListObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIListObjectsClient) Recv() (*Object, error) {
+	m := new(Object)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *FinishCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
+	out := new(DeleteObjectsResponse)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/DeleteObjects", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *FinishCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Commit != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
-		n29, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n29
-	}
-	if len(m.Description) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
-		i += copy(dAtA[i:], m.Description)
-	}
-	if m.Tree != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Tree.Size()))
-		n30, err := m.Tree.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n30
-	}
-	if m.Empty {
-		dAtA[i] = 0x20
-		i++
-		if m.Empty {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if len(m.Trees) > 0 {
-		for _, msg := range m.Trees {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+func (c *objectAPIClient) GetTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (ObjectAPI_GetTagClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[7], "/pfs.ObjectAPI/GetTag", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	x := &objectAPIGetTagClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.Datums != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Datums.Size()))
-		n31, err := m.Datums.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n31
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return x, nil
+}
+
+type ObjectAPI_GetTagClient interface {
+	Recv() (*types.BytesValue, error)
+	grpc.ClientStream
+}
+
+type objectAPIGetTagClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIGetTagClient) Recv() (*types.BytesValue, error) {
+	m := new(types.BytesValue)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *InspectCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) InspectTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (*ObjectInfo, error) {
+	out := new(ObjectInfo)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/InspectTag", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *InspectCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Commit != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
-		n32, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n32
+func (c *objectAPIClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (ObjectAPI_ListTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectAPI_serviceDesc.Streams[8], "/pfs.ObjectAPI/ListTags", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.BlockState != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.BlockState))
+	x := &objectAPIListTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return x, nil
 }
 
-func (m *ListCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ObjectAPI_ListTagsClient interface {
+	Recv() (*ListTagsResponse, error)
+	grpc.ClientStream
 }
 
-func (m *ListCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n33, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n33
-	}
-	if m.From != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.From.Size()))
-		n34, err := m.From.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n34
-	}
-	if m.To != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.To.Size()))
-		n35, err := m.To.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n35
-	}
-	if m.Number != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Number))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+type objectAPIListTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectAPIListTagsClient) Recv() (*ListTagsResponse, error) {
+	m := new(ListTagsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return i, nil
+	return m, nil
 }
 
-func (m *CommitInfos) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) DeleteTags(ctx context.Context, in *DeleteTagsRequest, opts ...grpc.CallOption) (*DeleteTagsResponse, error) {
+	out := new(DeleteTagsResponse)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/DeleteTags", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *CommitInfos) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.CommitInfo) > 0 {
-		for _, msg := range m.CommitInfo {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (c *objectAPIClient) Compact(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/Compact", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *CreateBranchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *objectAPIClient) InspectCache(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*InspectCacheResponse, error) {
+	out := new(InspectCacheResponse)
+	err := c.cc.Invoke(ctx, "/pfs.ObjectAPI/InspectCache", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *CreateBranchRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Head != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Head.Size()))
-		n36, err := m.Head.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n36
-	}
-	if len(m.SBranch) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.SBranch)))
-		i += copy(dAtA[i:], m.SBranch)
-	}
-	if m.Branch != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
-		n37, err := m.Branch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n37
-	}
-	if len(m.Provenance) > 0 {
-		for _, msg := range m.Provenance {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+// ObjectAPIServer is the server API for ObjectAPI service.
+type ObjectAPIServer interface {
+	PutObject(ObjectAPI_PutObjectServer) error
+	PutObjectSplit(ObjectAPI_PutObjectSplitServer) error
+	PutObjects(ObjectAPI_PutObjectsServer) error
+	GetObject(*Object, ObjectAPI_GetObjectServer) error
+	GetObjects(*GetObjectsRequest, ObjectAPI_GetObjectsServer) error
+	GetBlocks(*GetBlocksRequest, ObjectAPI_GetBlocksServer) error
+	TagObject(context.Context, *TagObjectRequest) (*types.Empty, error)
+	InspectObject(context.Context, *Object) (*ObjectInfo, error)
+	// CheckObject checks if an object exists in the blob store without
+	// actually reading the object.
+	CheckObject(context.Context, *CheckObjectRequest) (*CheckObjectResponse, error)
+	ListObjects(*ListObjectsRequest, ObjectAPI_ListObjectsServer) error
+	DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error)
+	GetTag(*Tag, ObjectAPI_GetTagServer) error
+	InspectTag(context.Context, *Tag) (*ObjectInfo, error)
+	ListTags(*ListTagsRequest, ObjectAPI_ListTagsServer) error
+	DeleteTags(context.Context, *DeleteTagsRequest) (*DeleteTagsResponse, error)
+	Compact(context.Context, *types.Empty) (*types.Empty, error)
+	// InspectCache returns the stats of the object caches of the pachd that
+	// serves the request (see debug.InspectCache for those of every pod).
+	InspectCache(context.Context, *types.Empty) (*InspectCacheResponse, error)
 }
 
-func (m *InspectBranchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func RegisterObjectAPIServer(s *grpc.Server, srv ObjectAPIServer) {
+	s.RegisterService(&_ObjectAPI_serviceDesc, srv)
 }
 
-func (m *InspectBranchRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Branch != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
-		n38, err := m.Branch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n38
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func _ObjectAPI_PutObject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ObjectAPIServer).PutObject(&objectAPIPutObjectServer{stream})
 }
 
-func (m *ListBranchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ObjectAPI_PutObjectServer interface {
+	SendAndClose(*Object) error
+	Recv() (*PutObjectRequest, error)
+	grpc.ServerStream
 }
 
-func (m *ListBranchRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n39, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n39
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type objectAPIPutObjectServer struct {
+	grpc.ServerStream
 }
 
-func (m *DeleteBranchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func (x *objectAPIPutObjectServer) SendAndClose(m *Object) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *objectAPIPutObjectServer) Recv() (*PutObjectRequest, error) {
+	m := new(PutObjectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *DeleteBranchRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Branch != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
-		n40, err := m.Branch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n40
-	}
-	if m.Force {
-		dAtA[i] = 0x10
-		i++
-		if m.Force {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func _ObjectAPI_PutObjectSplit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ObjectAPIServer).PutObjectSplit(&objectAPIPutObjectSplitServer{stream})
 }
 
-func (m *DeleteCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ObjectAPI_PutObjectSplitServer interface {
+	SendAndClose(*Objects) error
+	Recv() (*PutObjectRequest, error)
+	grpc.ServerStream
 }
 
-func (m *DeleteCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Commit != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
-		n41, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n41
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type objectAPIPutObjectSplitServer struct {
+	grpc.ServerStream
 }
 
-func (m *FlushCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func (x *objectAPIPutObjectSplitServer) SendAndClose(m *Objects) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *objectAPIPutObjectSplitServer) Recv() (*PutObjectRequest, error) {
+	m := new(PutObjectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *FlushCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Commits) > 0 {
-		for _, msg := range m.Commits {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.ToRepos) > 0 {
-		for _, msg := range m.ToRepos {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func _ObjectAPI_PutObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ObjectAPIServer).PutObjects(&objectAPIPutObjectsServer{stream})
 }
 
-func (m *SubscribeCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ObjectAPI_PutObjectsServer interface {
+	SendAndClose(*types.Empty) error
+	Recv() (*PutObjectRequest, error)
+	grpc.ServerStream
 }
 
-func (m *SubscribeCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Repo != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
-		n42, err := m.Repo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n42
-	}
-	if len(m.Branch) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Branch)))
-		i += copy(dAtA[i:], m.Branch)
-	}
-	if m.From != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.From.Size()))
-		n43, err := m.From.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n43
-	}
-	if m.State != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.State))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type objectAPIPutObjectsServer struct {
+	grpc.ServerStream
 }
 
-func (m *GetFileRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func (x *objectAPIPutObjectsServer) SendAndClose(m *types.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *objectAPIPutObjectsServer) Recv() (*PutObjectRequest, error) {
+	m := new(PutObjectRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *GetFileRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.File != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n44, err := m.File.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n44
-	}
-	if m.OffsetBytes != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
-	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func _ObjectAPI_GetObject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Object)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return i, nil
+	return srv.(ObjectAPIServer).GetObject(m, &objectAPIGetObjectServer{stream})
 }
 
-func (m *OverwriteIndex) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ObjectAPI_GetObjectServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
 }
 
-func (m *OverwriteIndex) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Index))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+type objectAPIGetObjectServer struct {
+	grpc.ServerStream
 }
 
-func (m *PutFileRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (x *objectAPIGetObjectServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectAPI_GetObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(ObjectAPIServer).GetObjects(m, &objectAPIGetObjectsServer{stream})
 }
 
-func (m *PutFileRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.File != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n45, err := m.File.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n45
-	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+type ObjectAPI_GetObjectsServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
+}
+
+type objectAPIGetObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *objectAPIGetObjectsServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectAPI_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	if len(m.Url) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Url)))
-		i += copy(dAtA[i:], m.Url)
+	return srv.(ObjectAPIServer).GetBlocks(m, &objectAPIGetBlocksServer{stream})
+}
+
+type ObjectAPI_GetBlocksServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
+}
+
+type objectAPIGetBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *objectAPIGetBlocksServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectAPI_TagObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Recursive {
-		dAtA[i] = 0x30
-		i++
-		if m.Recursive {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).TagObject(ctx, in)
 	}
-	if m.Delimiter != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Delimiter))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/TagObject",
 	}
-	if m.TargetFileDatums != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.TargetFileDatums))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).TagObject(ctx, req.(*TagObjectRequest))
 	}
-	if m.TargetFileBytes != 0 {
-		dAtA[i] = 0x48
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.TargetFileBytes))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObjectAPI_InspectObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Object)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.OverwriteIndex != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.OverwriteIndex.Size()))
-		n46, err := m.OverwriteIndex.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n46
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).InspectObject(ctx, in)
 	}
-	if m.HeaderRecords != 0 {
-		dAtA[i] = 0x58
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.HeaderRecords))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/InspectObject",
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).InspectObject(ctx, req.(*Object))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PutFileRecord) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+func _ObjectAPI_CheckObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckObjectRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PutFileRecord) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
-	}
-	if len(m.ObjectHash) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.ObjectHash)))
-		i += copy(dAtA[i:], m.ObjectHash)
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).CheckObject(ctx, in)
 	}
-	if m.OverwriteIndex != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.OverwriteIndex.Size()))
-		n47, err := m.OverwriteIndex.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n47
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/CheckObject",
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).CheckObject(ctx, req.(*CheckObjectRequest))
 	}
-	return i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PutFileRecords) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func _ObjectAPI_ListObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(ObjectAPIServer).ListObjects(m, &objectAPIListObjectsServer{stream})
 }
 
-func (m *PutFileRecords) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Split {
-		dAtA[i] = 0x8
-		i++
-		if m.Split {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if len(m.Records) > 0 {
-		for _, msg := range m.Records {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+type ObjectAPI_ListObjectsServer interface {
+	Send(*Object) error
+	grpc.ServerStream
+}
+
+type objectAPIListObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *objectAPIListObjectsServer) Send(m *Object) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectAPI_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Tombstone {
-		dAtA[i] = 0x18
-		i++
-		if m.Tombstone {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).DeleteObjects(ctx, in)
 	}
-	if m.Header != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Header.Size()))
-		n48, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n48
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/DeleteObjects",
 	}
-	if m.Footer != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Footer.Size()))
-		n49, err := m.Footer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n49
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObjectAPI_GetTag_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Tag)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return i, nil
+	return srv.(ObjectAPIServer).GetTag(m, &objectAPIGetTagServer{stream})
 }
 
-func (m *CopyFileRequest) Marshal() (dAtA []byte, err error) {
+type ObjectAPI_GetTagServer interface {
+	Send(*types.BytesValue) error
+	grpc.ServerStream
+}
+
+type objectAPIGetTagServer struct {
+	grpc.ServerStream
+}
+
+func (x *objectAPIGetTagServer) Send(m *types.BytesValue) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectAPI_InspectTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tag)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).InspectTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/InspectTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).InspectTag(ctx, req.(*Tag))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObjectAPI_ListTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTagsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ObjectAPIServer).ListTags(m, &objectAPIListTagsServer{stream})
+}
+
+type ObjectAPI_ListTagsServer interface {
+	Send(*ListTagsResponse) error
+	grpc.ServerStream
+}
+
+type objectAPIListTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *objectAPIListTagsServer) Send(m *ListTagsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectAPI_DeleteTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).DeleteTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/DeleteTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).DeleteTags(ctx, req.(*DeleteTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObjectAPI_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/Compact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).Compact(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ObjectAPI_InspectCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectAPIServer).InspectCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pfs.ObjectAPI/InspectCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectAPIServer).InspectCache(ctx, req.(*types.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ObjectAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pfs.ObjectAPI",
+	HandlerType: (*ObjectAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TagObject",
+			Handler:    _ObjectAPI_TagObject_Handler,
+		},
+		{
+			MethodName: "InspectObject",
+			Handler:    _ObjectAPI_InspectObject_Handler,
+		},
+		{
+			MethodName: "CheckObject",
+			Handler:    _ObjectAPI_CheckObject_Handler,
+		},
+		{
+			MethodName: "DeleteObjects",
+			Handler:    _ObjectAPI_DeleteObjects_Handler,
+		},
+		{
+			MethodName: "InspectTag",
+			Handler:    _ObjectAPI_InspectTag_Handler,
+		},
+		{
+			MethodName: "DeleteTags",
+			Handler:    _ObjectAPI_DeleteTags_Handler,
+		},
+		{
+			MethodName: "Compact",
+			Handler:    _ObjectAPI_Compact_Handler,
+		},
+		{
+			MethodName: "InspectCache",
+			Handler:    _ObjectAPI_InspectCache_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutObject",
+			Handler:       _ObjectAPI_PutObject_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PutObjectSplit",
+			Handler:       _ObjectAPI_PutObjectSplit_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PutObjects",
+			Handler:       _ObjectAPI_PutObjects_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetObject",
+			Handler:       _ObjectAPI_GetObject_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetObjects",
+			Handler:       _ObjectAPI_GetObjects_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetBlocks",
+			Handler:       _ObjectAPI_GetBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListObjects",
+			Handler:       _ObjectAPI_ListObjects_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetTag",
+			Handler:       _ObjectAPI_GetTag_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTags",
+			Handler:       _ObjectAPI_ListTags_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "client/pfs/pfs.proto",
+}
+
+func (m *Repo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8130,40 +8447,16 @@ func (m *CopyFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CopyFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Repo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Src != nil {
+	if len(m.Name) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Src.Size()))
-		n50, err := m.Src.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n50
-	}
-	if m.Dst != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Dst.Size()))
-		n51, err := m.Dst.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n51
-	}
-	if m.Overwrite {
-		dAtA[i] = 0x18
-		i++
-		if m.Overwrite {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8171,7 +8464,7 @@ func (m *CopyFileRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *InspectFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *Branch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8181,20 +8474,26 @@ func (m *InspectFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *InspectFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Branch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
+	if m.Repo != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n52, err := m.File.MarshalTo(dAtA[i:])
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n1, err := m.Repo.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n52
+		i += n1
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8202,7 +8501,7 @@ func (m *InspectFileRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ListFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *BranchInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8212,35 +8511,77 @@ func (m *ListFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BranchInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
+	if len(m.Name) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n53, err := m.File.MarshalTo(dAtA[i:])
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Head != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Head.Size()))
+		n2, err := m.Head.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += n2
 	}
-	if m.Full {
-		dAtA[i] = 0x10
-		i++
-		if m.Full {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if len(m.Provenance) > 0 {
+		for _, msg := range m.Provenance {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
+	}
+	if m.Branch != nil {
+		dAtA[i] = 0x22
 		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n3, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
 	}
-	if m.History != 0 {
-		dAtA[i] = 0x18
+	if len(m.Subvenance) > 0 {
+		for _, msg := range m.Subvenance {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.DirectProvenance) > 0 {
+		for _, msg := range m.DirectProvenance {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.MaxHistoryDepth != 0 {
+		dAtA[i] = 0x38
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.History))
+		i = encodeVarintPfs(dAtA, i, uint64(m.MaxHistoryDepth))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8248,7 +8589,7 @@ func (m *ListFileRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *WalkFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *BranchInfos) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8258,20 +8599,22 @@ func (m *WalkFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WalkFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BranchInfos) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n54, err := m.File.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.BranchInfo) > 0 {
+		for _, msg := range m.BranchInfo {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n54
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8279,7 +8622,7 @@ func (m *WalkFileRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GlobFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *File) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8289,7 +8632,7 @@ func (m *GlobFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GlobFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *File) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -8298,17 +8641,17 @@ func (m *GlobFileRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
-		n55, err := m.Commit.MarshalTo(dAtA[i:])
+		n4, err := m.Commit.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n55
+		i += n4
 	}
-	if len(m.Pattern) > 0 {
+	if len(m.Path) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Pattern)))
-		i += copy(dAtA[i:], m.Pattern)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8316,7 +8659,7 @@ func (m *GlobFileRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *FileInfos) Marshal() (dAtA []byte, err error) {
+func (m *Block) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8326,22 +8669,16 @@ func (m *FileInfos) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *FileInfos) MarshalTo(dAtA []byte) (int, error) {
+func (m *Block) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.FileInfo) > 0 {
-		for _, msg := range m.FileInfo {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8349,7 +8686,7 @@ func (m *FileInfos) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DiffFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *Object) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8359,40 +8696,16 @@ func (m *DiffFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DiffFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Object) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.NewFile != nil {
+	if len(m.Hash) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.NewFile.Size()))
-		n56, err := m.NewFile.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n56
-	}
-	if m.OldFile != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.OldFile.Size()))
-		n57, err := m.OldFile.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n57
-	}
-	if m.Shallow {
-		dAtA[i] = 0x18
-		i++
-		if m.Shallow {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8400,7 +8713,7 @@ func (m *DiffFileRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DiffFileResponse) Marshal() (dAtA []byte, err error) {
+func (m *Tag) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8410,34 +8723,16 @@ func (m *DiffFileResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DiffFileResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *Tag) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.NewFiles) > 0 {
-		for _, msg := range m.NewFiles {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.OldFiles) > 0 {
-		for _, msg := range m.OldFiles {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8445,7 +8740,7 @@ func (m *DiffFileResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DeleteFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *RepoInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8455,51 +8750,55 @@ func (m *DeleteFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RepoInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
+	if m.Repo != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
-		n58, err := m.File.MarshalTo(dAtA[i:])
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n5, err := m.Repo.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n58
+		i += n5
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.Created != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Created.Size()))
+		n6, err := m.Created.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
 	}
-	return i, nil
-}
-
-func (m *PutObjectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PutObjectRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		dAtA[i] = 0xa
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
 	}
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x12
+	if m.AuthInfo != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.AuthInfo.Size()))
+		n7, err := m.AuthInfo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if len(m.Branches) > 0 {
+		for _, msg := range m.Branches {
+			dAtA[i] = 0x3a
 			i++
 			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -8509,15 +8808,21 @@ func (m *PutObjectRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if m.Block != nil {
-		dAtA[i] = 0x1a
+	if len(m.RecommendedGlob) > 0 {
+		dAtA[i] = 0x42
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Block.Size()))
-		n59, err := m.Block.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n59
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.RecommendedGlob)))
+		i += copy(dAtA[i:], m.RecommendedGlob)
+	}
+	if m.MaxCommitFiles != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.MaxCommitFiles))
+	}
+	if m.MaxCommitBytes != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.MaxCommitBytes))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8525,7 +8830,7 @@ func (m *PutObjectRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetObjectsRequest) Marshal() (dAtA []byte, err error) {
+func (m *RepoAuthInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8535,37 +8840,15 @@ func (m *GetObjectsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RepoAuthInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for _, msg := range m.Objects {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.OffsetBytes != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
-	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
-	}
-	if m.TotalSize != 0 {
-		dAtA[i] = 0x20
+	if m.AccessLevel != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.TotalSize))
+		i = encodeVarintPfs(dAtA, i, uint64(m.AccessLevel))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8573,7 +8856,7 @@ func (m *GetObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetBlocksRequest) Marshal() (dAtA []byte, err error) {
+func (m *Commit) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8583,37 +8866,26 @@ func (m *GetBlocksRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Commit) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.BlockRefs) > 0 {
-		for _, msg := range m.BlockRefs {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.OffsetBytes != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
-	}
-	if m.SizeBytes != 0 {
-		dAtA[i] = 0x18
+	if m.Repo != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n8, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	if m.TotalSize != 0 {
-		dAtA[i] = 0x20
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.TotalSize))
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8621,7 +8893,7 @@ func (m *GetBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *TagObjectRequest) Marshal() (dAtA []byte, err error) {
+func (m *CommitRange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8631,32 +8903,30 @@ func (m *TagObjectRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TagObjectRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CommitRange) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Object != nil {
+	if m.Lower != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
-		n60, err := m.Object.MarshalTo(dAtA[i:])
+		i = encodeVarintPfs(dAtA, i, uint64(m.Lower.Size()))
+		n9, err := m.Lower.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n60
+		i += n9
 	}
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if m.Upper != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Upper.Size()))
+		n10, err := m.Upper.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n10
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8664,28 +8934,7 @@ func (m *TagObjectRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ListObjectsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ListObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
-}
-
-func (m *ListTagsRequest) Marshal() (dAtA []byte, err error) {
+func (m *CommitInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8695,92 +8944,59 @@ func (m *ListTagsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListTagsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CommitInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Prefix) > 0 {
+	if m.Commit != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(len(m.Prefix)))
-		i += copy(dAtA[i:], m.Prefix)
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n11, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
 	}
-	if m.IncludeObject {
-		dAtA[i] = 0x10
+	if m.ParentCommit != nil {
+		dAtA[i] = 0x12
 		i++
-		if m.IncludeObject {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintPfs(dAtA, i, uint64(m.ParentCommit.Size()))
+		n12, err := m.ParentCommit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i++
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
-}
-
-func (m *ListTagsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+		i += n12
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListTagsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Tag != nil {
-		dAtA[i] = 0xa
+	if m.Started != nil {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Tag.Size()))
-		n61, err := m.Tag.MarshalTo(dAtA[i:])
+		i = encodeVarintPfs(dAtA, i, uint64(m.Started.Size()))
+		n13, err := m.Started.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
+		i += n13
 	}
-	if m.Object != nil {
-		dAtA[i] = 0x12
+	if m.Finished != nil {
+		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
-		n62, err := m.Object.MarshalTo(dAtA[i:])
+		i = encodeVarintPfs(dAtA, i, uint64(m.Finished.Size()))
+		n14, err := m.Finished.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		i += n14
 	}
-	return i, nil
-}
-
-func (m *DeleteObjectsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
 	}
-	return dAtA[:n], nil
-}
-
-func (m *DeleteObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Objects) > 0 {
-		for _, msg := range m.Objects {
-			dAtA[i] = 0xa
+	if len(m.Provenance) > 0 {
+		for _, msg := range m.Provenance {
+			dAtA[i] = 0x32
 			i++
 			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -8790,51 +9006,179 @@ func (m *DeleteObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
-}
-
-func (m *DeleteObjectsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.Tree != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Tree.Size()))
+		n15, err := m.Tree.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
 	}
-	return dAtA[:n], nil
-}
-
-func (m *DeleteObjectsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
 	}
-	return i, nil
-}
-
-func (m *DeleteTagsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
+	if len(m.Subvenance) > 0 {
+		for _, msg := range m.Subvenance {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.BranchProvenance) > 0 {
+		for _, msg := range m.BranchProvenance {
+			dAtA[i] = 0x52
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.ChildCommits) > 0 {
+		for _, msg := range m.ChildCommits {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.ReadyProvenance != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.ReadyProvenance))
+	}
+	if len(m.Trees) > 0 {
+		for _, msg := range m.Trees {
+			dAtA[i] = 0x6a
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Datums != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Datums.Size()))
+		n16, err := m.Datums.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x7a
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + 1 + len(v) + sovPfs(uint64(len(v)))
+			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *FileInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *DeleteTagsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *FileInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0xa
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n17, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.FileType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.FileType))
+	}
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	}
+	if len(m.Children) > 0 {
+		for _, s := range m.Children {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if len(m.Objects) > 0 {
+		for _, msg := range m.Objects {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.BlockRefs) > 0 {
+		for _, msg := range m.BlockRefs {
+			dAtA[i] = 0x4a
 			i++
 			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -8844,13 +9188,38 @@ func (m *DeleteTagsRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Committed != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Committed.Size()))
+		n18, err := m.Committed.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.Mode != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Mode))
+	}
+	if m.Owner != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Owner.Size()))
+		n19, err := m.Owner.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DeleteTagsResponse) Marshal() (dAtA []byte, err error) {
+func (m *FileOwner) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8860,18 +9229,28 @@ func (m *DeleteTagsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteTagsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *FileOwner) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.Uid != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Uid))
+	}
+	if m.Gid != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Gid))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CheckObjectRequest) Marshal() (dAtA []byte, err error) {
+func (m *ByteRange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8881,20 +9260,20 @@ func (m *CheckObjectRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckObjectRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ByteRange) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Object != nil {
-		dAtA[i] = 0xa
+	if m.Lower != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
-		n63, err := m.Object.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n63
+		i = encodeVarintPfs(dAtA, i, uint64(m.Lower))
+	}
+	if m.Upper != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Upper))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8902,7 +9281,7 @@ func (m *CheckObjectRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *CheckObjectResponse) Marshal() (dAtA []byte, err error) {
+func (m *BlockRef) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8912,20 +9291,30 @@ func (m *CheckObjectResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckObjectResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlockRef) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Exists {
-		dAtA[i] = 0x8
+	if m.Block != nil {
+		dAtA[i] = 0xa
 		i++
-		if m.Exists {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintPfs(dAtA, i, uint64(m.Block.Size()))
+		n20, err := m.Block.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n20
+	}
+	if m.Range != nil {
+		dAtA[i] = 0x12
 		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Range.Size()))
+		n21, err := m.Range.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8933,7 +9322,7 @@ func (m *CheckObjectResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *Objects) Marshal() (dAtA []byte, err error) {
+func (m *ObjectInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8943,22 +9332,30 @@ func (m *Objects) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Objects) MarshalTo(dAtA []byte) (int, error) {
+func (m *ObjectInfo) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for _, msg := range m.Objects {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if m.Object != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
+		n22, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if m.BlockRef != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.BlockRef.Size()))
+		n23, err := m.BlockRef.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n23
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8966,7 +9363,7 @@ func (m *Objects) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ObjectIndex) Marshal() (dAtA []byte, err error) {
+func (m *CreateRepoRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8976,66 +9373,52 @@ func (m *ObjectIndex) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ObjectIndex) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateRepoRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for k, _ := range m.Objects {
-			dAtA[i] = 0xa
-			i++
-			v := m.Objects[k]
-			msgSize := 0
-			if v != nil {
-				msgSize = v.Size()
-				msgSize += 1 + sovPfs(uint64(msgSize))
-			}
-			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + msgSize
-			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if v != nil {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintPfs(dAtA, i, uint64(v.Size()))
-				n64, err := v.MarshalTo(dAtA[i:])
-				if err != nil {
-					return 0, err
-				}
-				i += n64
-			}
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n24, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n24
 	}
-	if len(m.Tags) > 0 {
-		for k, _ := range m.Tags {
-			dAtA[i] = 0x12
-			i++
-			v := m.Tags[k]
-			msgSize := 0
-			if v != nil {
-				msgSize = v.Size()
-				msgSize += 1 + sovPfs(uint64(msgSize))
-			}
-			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + msgSize
-			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if v != nil {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintPfs(dAtA, i, uint64(v.Size()))
-				n65, err := v.MarshalTo(dAtA[i:])
-				if err != nil {
-					return 0, err
-				}
-				i += n65
-			}
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
+	}
+	if m.Update {
+		dAtA[i] = 0x20
+		i++
+		if m.Update {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
+	}
+	if len(m.RecommendedGlob) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.RecommendedGlob)))
+		i += copy(dAtA[i:], m.RecommendedGlob)
+	}
+	if m.MaxCommitFiles != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.MaxCommitFiles))
+	}
+	if m.MaxCommitBytes != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.MaxCommitBytes))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9043,1517 +9426,5752 @@ func (m *ObjectIndex) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintPfs(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *InspectRepoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *Repo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *InspectRepoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n25, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Branch) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectReposRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectReposRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Repos) > 0 {
+		for _, msg := range m.Repos {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *BranchInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectRepoResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectRepoResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Head != nil {
-		l = m.Head.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if len(m.Provenance) > 0 {
-		for _, e := range m.Provenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n26, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n26
 	}
-	if m.Branch != nil {
-		l = m.Branch.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if len(m.Subvenance) > 0 {
-		for _, e := range m.Subvenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Info != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Info.Size()))
+		n27, err := m.Info.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n27
 	}
-	if len(m.DirectProvenance) > 0 {
-		for _, e := range m.DirectProvenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.NotFound {
+		dAtA[i] = 0x18
+		i++
+		if m.NotFound {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *BranchInfos) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.BranchInfo) > 0 {
-		for _, e := range m.BranchInfo {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ListRepoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *File) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ListRepoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Block) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListRepoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Object) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ListRepoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.RepoInfo) > 0 {
+		for _, msg := range m.RepoInfo {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Tag) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiskUsageRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DiskUsageRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n28, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RepoInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RepoDiskUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RepoDiskUsage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Created != nil {
-		l = m.Created.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n29, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n29
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.LogicalBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.LogicalBytes))
 	}
-	if m.AuthInfo != nil {
-		l = m.AuthInfo.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.PhysicalBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.PhysicalBytes))
 	}
-	if len(m.Branches) > 0 {
-		for _, e := range m.Branches {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
+	if m.ExclusiveBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.ExclusiveBytes))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RepoAuthInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiskUsageResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DiskUsageResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.AccessLevel != 0 {
-		n += 1 + sovPfs(uint64(m.AccessLevel))
+	if len(m.Repos) > 0 {
+		for _, msg := range m.Repos {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Commit) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteRepoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRepoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n30, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n30
 	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.All {
+		dAtA[i] = 0x18
+		i++
+		if m.All {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CommitRange) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Lower != nil {
-		l = m.Lower.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Upper != nil {
-		l = m.Upper.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *StartCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CommitInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *StartCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Parent != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Parent.Size()))
+		n31, err := m.Parent.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n31
 	}
-	if m.ParentCommit != nil {
-		l = m.ParentCommit.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Provenance) > 0 {
+		for _, msg := range m.Provenance {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.Started != nil {
-		l = m.Started.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Branch) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Branch)))
+		i += copy(dAtA[i:], m.Branch)
 	}
-	if m.Finished != nil {
-		l = m.Finished.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
-	}
-	if len(m.Provenance) > 0 {
-		for _, e := range m.Provenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
-	}
-	if m.Tree != nil {
-		l = m.Tree.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if len(m.Subvenance) > 0 {
-		for _, e := range m.Subvenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
 	}
-	if len(m.BranchProvenance) > 0 {
-		for _, e := range m.BranchProvenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x2a
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + 1 + len(v) + sovPfs(uint64(len(v)))
+			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
 		}
 	}
-	if len(m.ChildCommits) > 0 {
-		for _, e := range m.ChildCommits {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ReadyProvenance != 0 {
-		n += 1 + sovPfs(uint64(m.ReadyProvenance))
+	return i, nil
+}
+
+func (m *ProvenanceGraphRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Trees) > 0 {
-		for _, e := range m.Trees {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ProvenanceGraphRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n32, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n32
 	}
-	if m.Datums != nil {
-		l = m.Datums.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.MaxDepth != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.MaxDepth))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *FileInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ProvenanceGraphNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvenanceGraphNode) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.FileType != 0 {
-		n += 1 + sovPfs(uint64(m.FileType))
-	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n33, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n33
 	}
-	if len(m.Children) > 0 {
-		for _, s := range m.Children {
-			l = len(s)
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Branch != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n34, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n34
 	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Depth != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Depth))
 	}
-	if len(m.Objects) > 0 {
-		for _, e := range m.Objects {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if len(m.Provenance) > 0 {
+		for _, msg := range m.Provenance {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if len(m.BlockRefs) > 0 {
-		for _, e := range m.BlockRefs {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Truncated {
+		dAtA[i] = 0x28
+		i++
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-	}
-	if m.Committed != nil {
-		l = m.Committed.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ByteRange) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BuildCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BuildCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Lower != 0 {
-		n += 1 + sovPfs(uint64(m.Lower))
-	}
-	if m.Upper != 0 {
-		n += 1 + sovPfs(uint64(m.Upper))
+	if m.Parent != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Parent.Size()))
+		n35, err := m.Parent.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n35
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Provenance) > 0 {
+		for _, msg := range m.Provenance {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	return n
-}
-
-func (m *BlockRef) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Tree != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Tree.Size()))
+		n36, err := m.Tree.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n36
 	}
-	var l int
-	_ = l
-	if m.Block != nil {
-		l = m.Block.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Branch) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Branch)))
+		i += copy(dAtA[i:], m.Branch)
 	}
-	if m.Range != nil {
-		l = m.Range.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.ID) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ObjectInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FinishCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Object != nil {
-		l = m.Object.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.BlockRef != nil {
-		l = m.BlockRef.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CreateRepoRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *FinishCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n37, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n37
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
 	}
-	if m.Update {
-		n += 2
+	if m.Tree != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Tree.Size()))
+		n38, err := m.Tree.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n38
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Empty {
+		dAtA[i] = 0x20
+		i++
+		if m.Empty {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return n
-}
-
-func (m *InspectRepoRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Trees) > 0 {
+		for _, msg := range m.Trees {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	var l int
-	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Datums != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Datums.Size()))
+		n39, err := m.Datums.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
 	}
-	return n
-}
-
-func (m *ListRepoRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x42
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + 1 + len(v) + sovPfs(uint64(len(v)))
+			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
 	}
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ListRepoResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.RepoInfo) > 0 {
-		for _, e := range m.RepoInfo {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n40, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n40
+	}
+	if m.BlockState != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.BlockState))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteRepoRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectCommitsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectCommitsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Force {
-		n += 2
-	}
-	if m.All {
-		n += 2
+	if len(m.Commits) > 0 {
+		for _, msg := range m.Commits {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StartCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectCommitResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectCommitResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Parent != nil {
-		l = m.Parent.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n41, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n41
 	}
-	if len(m.Provenance) > 0 {
-		for _, e := range m.Provenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Info != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Info.Size()))
+		n42, err := m.Info.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n42
 	}
-	l = len(m.Branch)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.NotFound {
+		dAtA[i] = 0x18
+		i++
+		if m.NotFound {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *BuildCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Parent != nil {
-		l = m.Parent.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if len(m.Provenance) > 0 {
-		for _, e := range m.Provenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
-	}
-	if m.Tree != nil {
-		l = m.Tree.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Branch)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *FinishCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Tree != nil {
-		l = m.Tree.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Empty {
-		n += 2
-	}
-	if len(m.Trees) > 0 {
-		for _, e := range m.Trees {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
-	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
-	}
-	if m.Datums != nil {
-		l = m.Datums.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *InspectCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.BlockState != 0 {
-		n += 1 + sovPfs(uint64(m.BlockState))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ListCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ListCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n43, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
 	}
 	if m.From != nil {
-		l = m.From.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.From.Size()))
+		n44, err := m.From.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
 	}
 	if m.To != nil {
-		l = m.To.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.To.Size()))
+		n45, err := m.To.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
 	}
 	if m.Number != 0 {
-		n += 1 + sovPfs(uint64(m.Number))
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Number))
+	}
+	if len(m.LabelSelector) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.LabelSelector)))
+		i += copy(dAtA[i:], m.LabelSelector)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CommitInfos) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CommitInfos) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitInfos) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if len(m.CommitInfo) > 0 {
-		for _, e := range m.CommitInfo {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+		for _, msg := range m.CommitInfo {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateBranchRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CreateBranchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateBranchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Head != nil {
-		l = m.Head.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Head.Size()))
+		n46, err := m.Head.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
 	}
-	l = len(m.SBranch)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.SBranch) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.SBranch)))
+		i += copy(dAtA[i:], m.SBranch)
 	}
 	if m.Branch != nil {
-		l = m.Branch.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n47, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n47
 	}
 	if len(m.Provenance) > 0 {
-		for _, e := range m.Provenance {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+		for _, msg := range m.Provenance {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InspectBranchRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectBranchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectBranchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Branch != nil {
-		l = m.Branch.Size()
-		n += 1 + l + sovPfs(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n48, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n48
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ListBranchRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListBranchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ListBranchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n49, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteBranchRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ListCommitBranchesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ListCommitBranchesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Branch != nil {
-		l = m.Branch.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n50, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
 	}
-	if m.Force {
-		n += 2
+	if m.History {
+		dAtA[i] = 0x10
+		i++
+		if m.History {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetMaxHistoryDepthRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetMaxHistoryDepthRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Branch != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n51, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.Depth != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Depth))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *FlushCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SwapBranchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SwapBranchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Commits) > 0 {
-		for _, e := range m.Commits {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Branch != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n52, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n52
 	}
-	if len(m.ToRepos) > 0 {
-		for _, e := range m.ToRepos {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Other != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Other.Size()))
+		n53, err := m.Other.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n53
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *SubscribeCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteBranchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteBranchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Repo != nil {
-		l = m.Repo.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Branch)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.From != nil {
-		l = m.From.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Branch != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Branch.Size()))
+		n54, err := m.Branch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
 	}
-	if m.State != 0 {
-		n += 1 + sovPfs(uint64(m.State))
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.OffsetBytes != 0 {
-		n += 1 + sovPfs(uint64(m.OffsetBytes))
-	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n55, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *OverwriteIndex) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SquashCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SquashCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Index != 0 {
-		n += 1 + sovPfs(uint64(m.Index))
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n56, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FlushCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FlushCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	l = len(m.Url)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Recursive {
-		n += 2
-	}
-	if m.Delimiter != 0 {
-		n += 1 + sovPfs(uint64(m.Delimiter))
-	}
-	if m.TargetFileDatums != 0 {
-		n += 1 + sovPfs(uint64(m.TargetFileDatums))
-	}
-	if m.TargetFileBytes != 0 {
-		n += 1 + sovPfs(uint64(m.TargetFileBytes))
-	}
-	if m.OverwriteIndex != nil {
-		l = m.OverwriteIndex.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Commits) > 0 {
+		for _, msg := range m.Commits {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.HeaderRecords != 0 {
-		n += 1 + sovPfs(uint64(m.HeaderRecords))
+	if len(m.ToRepos) > 0 {
+		for _, msg := range m.ToRepos {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutFileRecord) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
-	}
-	l = len(m.ObjectHash)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.OverwriteIndex != nil {
-		l = m.OverwriteIndex.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *SubscribeCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PutFileRecords) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SubscribeCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Split {
-		n += 2
-	}
-	if len(m.Records) > 0 {
-		for _, e := range m.Records {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Repo.Size()))
+		n57, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n57
 	}
-	if m.Tombstone {
-		n += 2
+	if len(m.Branch) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Branch)))
+		i += copy(dAtA[i:], m.Branch)
 	}
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.From != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.From.Size()))
+		n58, err := m.From.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
 	}
-	if m.Footer != nil {
-		l = m.Footer.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.State != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.State))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CopyFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Src != nil {
-		l = m.Src.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n59, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n59
 	}
-	if m.Dst != nil {
-		l = m.Dst.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.OffsetBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
 	}
-	if m.Overwrite {
-		n += 2
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InspectFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *OverwriteIndex) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ListFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *OverwriteIndex) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Full {
-		n += 2
-	}
-	if m.History != 0 {
-		n += 1 + sovPfs(uint64(m.History))
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Index))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *WalkFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *PutFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GlobFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *PutFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n60, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n60
 	}
-	l = len(m.Pattern)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
 	}
-	return n
-}
-
-func (m *FileInfos) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Url) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Url)))
+		i += copy(dAtA[i:], m.Url)
 	}
-	var l int
-	_ = l
-	if len(m.FileInfo) > 0 {
-		for _, e := range m.FileInfo {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.Recursive {
+		dAtA[i] = 0x30
+		i++
+		if m.Recursive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *DiffFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.NewFile != nil {
-		l = m.NewFile.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.OldFile != nil {
-		l = m.OldFile.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.Shallow {
-		n += 2
+	if m.Delimiter != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Delimiter))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.TargetFileDatums != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.TargetFileDatums))
 	}
-	return n
-}
-
-func (m *DiffFileResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.TargetFileBytes != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.TargetFileBytes))
 	}
-	var l int
-	_ = l
-	if len(m.NewFiles) > 0 {
-		for _, e := range m.NewFiles {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.OverwriteIndex != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OverwriteIndex.Size()))
+		n61, err := m.OverwriteIndex.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n61
 	}
-	if len(m.OldFiles) > 0 {
-		for _, e := range m.OldFiles {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
+	if m.HeaderRecords != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.HeaderRecords))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteFileRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.File != nil {
-		l = m.File.Size()
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *PutFileChunkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PutObjectRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *PutFileChunkRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
-	}
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n62, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n62
 	}
-	if m.Block != nil {
-		l = m.Block.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if len(m.UploadId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.UploadId)))
+		i += copy(dAtA[i:], m.UploadId)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetObjectsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FileChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FileChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for _, e := range m.Objects {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
-		}
-	}
 	if m.OffsetBytes != 0 {
-		n += 1 + sovPfs(uint64(m.OffsetBytes))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
 	}
 	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
 	}
-	if m.TotalSize != 0 {
-		n += 1 + sovPfs(uint64(m.TotalSize))
+	if m.Object != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
+		n63, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n63
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetBlocksRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PutFileChunksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PutFileChunksRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.BlockRefs) > 0 {
-		for _, e := range m.BlockRefs {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n64, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n64
 	}
-	if m.OffsetBytes != 0 {
-		n += 1 + sovPfs(uint64(m.OffsetBytes))
+	if len(m.Chunks) > 0 {
+		for _, msg := range m.Chunks {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPfs(uint64(m.SizeBytes))
+	if m.Overwrite {
+		dAtA[i] = 0x18
+		i++
+		if m.Overwrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if m.TotalSize != 0 {
-		n += 1 + sovPfs(uint64(m.TotalSize))
+	if len(m.UploadId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.UploadId)))
+		i += copy(dAtA[i:], m.UploadId)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *TagObjectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PutFileRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PutFileRecord) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Object != nil {
-		l = m.Object.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
 	}
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if len(m.ObjectHash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.ObjectHash)))
+		i += copy(dAtA[i:], m.ObjectHash)
+	}
+	if m.OverwriteIndex != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OverwriteIndex.Size()))
+		n65, err := m.OverwriteIndex.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n65
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ListObjectsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *PutFileRecords) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ListTagsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *PutFileRecords) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Prefix)
-	if l > 0 {
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Split {
+		dAtA[i] = 0x8
+		i++
+		if m.Split {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if m.IncludeObject {
-		n += 2
+	if len(m.Records) > 0 {
+		for _, msg := range m.Records {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Tombstone {
+		dAtA[i] = 0x18
+		i++
+		if m.Tombstone {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Header != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Header.Size()))
+		n66, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n66
+	}
+	if m.Footer != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Footer.Size()))
+		n67, err := m.Footer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n67
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ListTagsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CopyFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CopyFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Tag != nil {
-		l = m.Tag.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Src != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Src.Size()))
+		n68, err := m.Src.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n68
 	}
-	if m.Object != nil {
-		l = m.Object.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.Dst != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Dst.Size()))
+		n69, err := m.Dst.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n69
+	}
+	if m.Overwrite {
+		dAtA[i] = 0x18
+		i++
+		if m.Overwrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteObjectsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *InspectFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for _, e := range m.Objects {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n70, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n70
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteObjectsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ListFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DeleteTagsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ListFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n71, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n71
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Full {
+		dAtA[i] = 0x10
+		i++
+		if m.Full {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return n
-}
-
-func (m *DeleteTagsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.History != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.History))
 	}
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CheckObjectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WalkFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *WalkFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Object != nil {
-		l = m.Object.Size()
-		n += 1 + l + sovPfs(uint64(l))
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n72, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n72
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CheckObjectResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GlobFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Exists {
-		n += 2
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n73, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n73
+	}
+	if len(m.Pattern) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Pattern)))
+		i += copy(dAtA[i:], m.Pattern)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Objects) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FileInfos) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FileInfos) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for _, e := range m.Objects {
-			l = e.Size()
-			n += 1 + l + sovPfs(uint64(l))
+	if len(m.FileInfo) > 0 {
+		for _, msg := range m.FileInfo {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ObjectIndex) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiffFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DiffFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Objects) > 0 {
-		for k, v := range m.Objects {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovPfs(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+	if m.NewFile != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.NewFile.Size()))
+		n74, err := m.NewFile.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n74
 	}
-	if len(m.Tags) > 0 {
-		for k, v := range m.Tags {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovPfs(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+	if m.OldFile != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OldFile.Size()))
+		n75, err := m.OldFile.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n75
+	}
+	if m.Shallow {
+		dAtA[i] = 0x18
+		i++
+		if m.Shallow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func sovPfs(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *DiffFileResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
-}
-func sozPfs(x uint64) (n int) {
-	return sovPfs(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return dAtA[:n], nil
 }
-func (m *Repo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+
+func (m *DiffFileResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.NewFiles) > 0 {
+		for _, msg := range m.NewFiles {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
+			i += n
 		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Repo: wiretype end group for non-group")
+	}
+	if len(m.OldFiles) > 0 {
+		for _, msg := range m.OldFiles {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Repo: illegal tag %d (wire type %d)", fieldNum, wire)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetCommitTreeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCommitTreeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n76, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
+		i += n76
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TreeNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TreeNode) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.FileType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.FileType))
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetManifestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetManifestRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Commit.Size()))
+		n77, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n77
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.File != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.File.Size()))
+		n78, err := m.File.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n78
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PutObjectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PutObjectRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Block != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Block.Size()))
+		n79, err := m.Block.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n79
+	}
+	if len(m.DedupScope) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.DedupScope)))
+		i += copy(dAtA[i:], m.DedupScope)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetObjectsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, msg := range m.Objects {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.OffsetBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	}
+	if m.TotalSize != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.TotalSize))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetBlocksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.BlockRefs) > 0 {
+		for _, msg := range m.BlockRefs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.OffsetBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	}
+	if m.TotalSize != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.TotalSize))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TagObjectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TagObjectRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Object != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
+		n80, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n80
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ListObjectsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ListTagsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTagsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if m.IncludeObject {
+		dAtA[i] = 0x10
+		i++
+		if m.IncludeObject {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ListTagsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListTagsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Tag != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Tag.Size()))
+		n81, err := m.Tag.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n81
+	}
+	if m.Object != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
+		n82, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n82
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteObjectsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, msg := range m.Objects {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteObjectsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteObjectsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteTagsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteTagsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteTagsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteTagsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CheckObjectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckObjectRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Object != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Object.Size()))
+		n83, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n83
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CheckObjectResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckObjectResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Exists {
+		dAtA[i] = 0x8
+		i++
+		if m.Exists {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CacheInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CacheInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.SizeBytes))
+	}
+	if m.Bytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Bytes))
+	}
+	if m.Items != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Items))
+	}
+	if m.Gets != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Gets))
+	}
+	if m.Hits != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Hits))
+	}
+	if m.Evictions != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPfs(dAtA, i, uint64(m.Evictions))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InspectCacheResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InspectCacheResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Caches) > 0 {
+		for _, msg := range m.Caches {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Objects) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Objects) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, msg := range m.Objects {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ObjectIndex) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectIndex) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for k, _ := range m.Objects {
+			dAtA[i] = 0xa
+			i++
+			v := m.Objects[k]
+			msgSize := 0
+			if v != nil {
+				msgSize = v.Size()
+				msgSize += 1 + sovPfs(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + msgSize
+			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if v != nil {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintPfs(dAtA, i, uint64(v.Size()))
+				n84, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
+				}
+				i += n84
+			}
+		}
+	}
+	if len(m.Tags) > 0 {
+		for k, _ := range m.Tags {
+			dAtA[i] = 0x12
+			i++
+			v := m.Tags[k]
+			msgSize := 0
+			if v != nil {
+				msgSize = v.Size()
+				msgSize += 1 + sovPfs(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovPfs(uint64(len(k))) + msgSize
+			i = encodeVarintPfs(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPfs(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if v != nil {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintPfs(dAtA, i, uint64(v.Size()))
+				n85, err := v.MarshalTo(dAtA[i:])
+				if err != nil {
+					return 0, err
+				}
+				i += n85
+			}
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintPfs(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Repo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Branch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BranchInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Subvenance) > 0 {
+		for _, e := range m.Subvenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if len(m.DirectProvenance) > 0 {
+		for _, e := range m.DirectProvenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.MaxHistoryDepth != 0 {
+		n += 1 + sovPfs(uint64(m.MaxHistoryDepth))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BranchInfos) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.BranchInfo) > 0 {
+		for _, e := range m.BranchInfo {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *File) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Block) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Object) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tag) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RepoInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.AuthInfo != nil {
+		l = m.AuthInfo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Branches) > 0 {
+		for _, e := range m.Branches {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	l = len(m.RecommendedGlob)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.MaxCommitFiles != 0 {
+		n += 1 + sovPfs(uint64(m.MaxCommitFiles))
+	}
+	if m.MaxCommitBytes != 0 {
+		n += 1 + sovPfs(uint64(m.MaxCommitBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RepoAuthInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccessLevel != 0 {
+		n += 1 + sovPfs(uint64(m.AccessLevel))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Lower != nil {
+		l = m.Lower.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Upper != nil {
+		l = m.Upper.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.ParentCommit != nil {
+		l = m.ParentCommit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Started != nil {
+		l = m.Started.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Finished != nil {
+		l = m.Finished.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Tree != nil {
+		l = m.Tree.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Subvenance) > 0 {
+		for _, e := range m.Subvenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if len(m.BranchProvenance) > 0 {
+		for _, e := range m.BranchProvenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if len(m.ChildCommits) > 0 {
+		for _, e := range m.ChildCommits {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.ReadyProvenance != 0 {
+		n += 1 + sovPfs(uint64(m.ReadyProvenance))
+	}
+	if len(m.Trees) > 0 {
+		for _, e := range m.Trees {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Datums != nil {
+		l = m.Datums.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + 1 + len(v) + sovPfs(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FileInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.FileType != 0 {
+		n += 1 + sovPfs(uint64(m.FileType))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if len(m.Children) > 0 {
+		for _, s := range m.Children {
+			l = len(s)
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Objects) > 0 {
+		for _, e := range m.Objects {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if len(m.BlockRefs) > 0 {
+		for _, e := range m.BlockRefs {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Committed != nil {
+		l = m.Committed.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovPfs(uint64(m.Mode))
+	}
+	if m.Owner != nil {
+		l = m.Owner.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FileOwner) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Uid != 0 {
+		n += 1 + sovPfs(uint64(m.Uid))
+	}
+	if m.Gid != 0 {
+		n += 1 + sovPfs(uint64(m.Gid))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ByteRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Lower != 0 {
+		n += 1 + sovPfs(uint64(m.Lower))
+	}
+	if m.Upper != 0 {
+		n += 1 + sovPfs(uint64(m.Upper))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BlockRef) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Range != nil {
+		l = m.Range.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.BlockRef != nil {
+		l = m.BlockRef.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Update {
+		n += 2
+	}
+	l = len(m.RecommendedGlob)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.MaxCommitFiles != 0 {
+		n += 1 + sovPfs(uint64(m.MaxCommitFiles))
+	}
+	if m.MaxCommitBytes != 0 {
+		n += 1 + sovPfs(uint64(m.MaxCommitBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectReposRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Repos) > 0 {
+		for _, e := range m.Repos {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectRepoResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.NotFound {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListRepoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.RepoInfo) > 0 {
+		for _, e := range m.RepoInfo {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiskUsageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RepoDiskUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.LogicalBytes != 0 {
+		n += 1 + sovPfs(uint64(m.LogicalBytes))
+	}
+	if m.PhysicalBytes != 0 {
+		n += 1 + sovPfs(uint64(m.PhysicalBytes))
+	}
+	if m.ExclusiveBytes != 0 {
+		n += 1 + sovPfs(uint64(m.ExclusiveBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiskUsageResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Repos) > 0 {
+		for _, e := range m.Repos {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.All {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StartCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Parent != nil {
+		l = m.Parent.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	l = len(m.Branch)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + 1 + len(v) + sovPfs(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProvenanceGraphRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.MaxDepth != 0 {
+		n += 1 + sovPfs(uint64(m.MaxDepth))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProvenanceGraphNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Depth != 0 {
+		n += 1 + sovPfs(uint64(m.Depth))
+	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Truncated {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BuildCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Parent != nil {
+		l = m.Parent.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Tree != nil {
+		l = m.Tree.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Branch)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FinishCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Tree != nil {
+		l = m.Tree.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Empty {
+		n += 2
+	}
+	if len(m.Trees) > 0 {
+		for _, e := range m.Trees {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.Datums != nil {
+		l = m.Datums.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + 1 + len(v) + sovPfs(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.BlockState != 0 {
+		n += 1 + sovPfs(uint64(m.BlockState))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectCommitsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Commits) > 0 {
+		for _, e := range m.Commits {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectCommitResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.NotFound {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.From != nil {
+		l = m.From.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.To != nil {
+		l = m.To.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Number != 0 {
+		n += 1 + sovPfs(uint64(m.Number))
+	}
+	l = len(m.LabelSelector)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitInfos) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.CommitInfo) > 0 {
+		for _, e := range m.CommitInfo {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateBranchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.SBranch)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectBranchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListBranchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListCommitBranchesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.History {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetMaxHistoryDepthRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Depth != 0 {
+		n += 1 + sovPfs(uint64(m.Depth))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SwapBranchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Other != nil {
+		l = m.Other.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteBranchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Branch != nil {
+		l = m.Branch.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SquashCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FlushCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Commits) > 0 {
+		for _, e := range m.Commits {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if len(m.ToRepos) > 0 {
+		for _, e := range m.ToRepos {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SubscribeCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Branch)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.From != nil {
+		l = m.From.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.State != 0 {
+		n += 1 + sovPfs(uint64(m.State))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.OffsetBytes != 0 {
+		n += 1 + sovPfs(uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OverwriteIndex) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovPfs(uint64(m.Index))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Url)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Recursive {
+		n += 2
+	}
+	if m.Delimiter != 0 {
+		n += 1 + sovPfs(uint64(m.Delimiter))
+	}
+	if m.TargetFileDatums != 0 {
+		n += 1 + sovPfs(uint64(m.TargetFileDatums))
+	}
+	if m.TargetFileBytes != 0 {
+		n += 1 + sovPfs(uint64(m.TargetFileBytes))
+	}
+	if m.OverwriteIndex != nil {
+		l = m.OverwriteIndex.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.HeaderRecords != 0 {
+		n += 1 + sovPfs(uint64(m.HeaderRecords))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutFileChunkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.UploadId)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FileChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OffsetBytes != 0 {
+		n += 1 + sovPfs(uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutFileChunksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Chunks) > 0 {
+		for _, e := range m.Chunks {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Overwrite {
+		n += 2
+	}
+	l = len(m.UploadId)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutFileRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	l = len(m.ObjectHash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.OverwriteIndex != nil {
+		l = m.OverwriteIndex.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutFileRecords) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Split {
+		n += 2
+	}
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Tombstone {
+		n += 2
+	}
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Footer != nil {
+		l = m.Footer.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CopyFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Src != nil {
+		l = m.Src.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Dst != nil {
+		l = m.Dst.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Overwrite {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Full {
+		n += 2
+	}
+	if m.History != 0 {
+		n += 1 + sovPfs(uint64(m.History))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WalkFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GlobFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FileInfos) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.FileInfo) > 0 {
+		for _, e := range m.FileInfo {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiffFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NewFile != nil {
+		l = m.NewFile.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.OldFile != nil {
+		l = m.OldFile.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Shallow {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiffFileResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.NewFiles) > 0 {
+		for _, e := range m.NewFiles {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if len(m.OldFiles) > 0 {
+		for _, e := range m.OldFiles {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetCommitTreeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TreeNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.FileType != 0 {
+		n += 1 + sovPfs(uint64(m.FileType))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetManifestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.File != nil {
+		l = m.File.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PutObjectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	l = len(m.DedupScope)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetObjectsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, e := range m.Objects {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.OffsetBytes != 0 {
+		n += 1 + sovPfs(uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.TotalSize != 0 {
+		n += 1 + sovPfs(uint64(m.TotalSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetBlocksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.BlockRefs) > 0 {
+		for _, e := range m.BlockRefs {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.OffsetBytes != 0 {
+		n += 1 + sovPfs(uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.TotalSize != 0 {
+		n += 1 + sovPfs(uint64(m.TotalSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TagObjectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListObjectsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListTagsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.IncludeObject {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListTagsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Tag != nil {
+		l = m.Tag.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteObjectsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, e := range m.Objects {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteObjectsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteTagsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteTagsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckObjectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckObjectResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Exists {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CacheInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPfs(uint64(m.SizeBytes))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovPfs(uint64(m.Bytes))
+	}
+	if m.Items != 0 {
+		n += 1 + sovPfs(uint64(m.Items))
+	}
+	if m.Gets != 0 {
+		n += 1 + sovPfs(uint64(m.Gets))
+	}
+	if m.Hits != 0 {
+		n += 1 + sovPfs(uint64(m.Hits))
+	}
+	if m.Evictions != 0 {
+		n += 1 + sovPfs(uint64(m.Evictions))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InspectCacheResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Caches) > 0 {
+		for _, e := range m.Caches {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Objects) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, e := range m.Objects {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectIndex) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for k, v := range m.Objects {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovPfs(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovPfs(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovPfs(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovPfs(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovPfs(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPfs(x uint64) (n int) {
+	return sovPfs(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Repo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Repo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Repo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Branch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Branch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Branch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Head == nil {
+				m.Head = &Commit{}
+			}
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provenance = append(m.Provenance, &Branch{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
+			}
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subvenance = append(m.Subvenance, &Branch{})
+			if err := m.Subvenance[len(m.Subvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DirectProvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DirectProvenance = append(m.DirectProvenance, &Branch{})
+			if err := m.DirectProvenance[len(m.DirectProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxHistoryDepth", wireType)
+			}
+			m.MaxHistoryDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxHistoryDepth |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchInfos) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchInfos: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchInfos: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BranchInfo = append(m.BranchInfo, &BranchInfo{})
+			if err := m.BranchInfo[len(m.BranchInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *File) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: File: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: File: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Block) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Block: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Block: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Object) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Object: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Object: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Tag) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Tag: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Tag: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
@@ -10566,75 +15184,1058 @@ func (m *Repo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RepoInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RepoInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RepoInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &types.Timestamp{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			}
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthInfo == nil {
+				m.AuthInfo = &RepoAuthInfo{}
+			}
+			if err := m.AuthInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Branches = append(m.Branches, &Branch{})
+			if err := m.Branches[len(m.Branches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecommendedGlob", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecommendedGlob = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommitFiles", wireType)
+			}
+			m.MaxCommitFiles = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCommitFiles |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommitBytes", wireType)
+			}
+			m.MaxCommitBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCommitBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RepoAuthInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RepoAuthInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RepoAuthInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessLevel", wireType)
+			}
+			m.AccessLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccessLevel |= (auth.Scope(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Commit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Lower == nil {
+				m.Lower = &Commit{}
+			}
+			if err := m.Lower.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Upper == nil {
+				m.Upper = &Commit{}
+			}
+			if err := m.Upper.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParentCommit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ParentCommit == nil {
+				m.ParentCommit = &Commit{}
+			}
+			if err := m.ParentCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Started", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Started == nil {
+				m.Started = &types.Timestamp{}
+			}
+			if err := m.Started.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Finished == nil {
+				m.Finished = &types.Timestamp{}
+			}
+			if err := m.Finished.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			}
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provenance = append(m.Provenance, &Commit{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tree == nil {
+				m.Tree = &Object{}
+			}
+			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subvenance = append(m.Subvenance, &CommitRange{})
+			if err := m.Subvenance[len(m.Subvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchProvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BranchProvenance = append(m.BranchProvenance, &Branch{})
+			if err := m.BranchProvenance[len(m.BranchProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChildCommits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPfs(dAtA[iNdEx:])
-			if err != nil {
+			m.ChildCommits = append(m.ChildCommits, &Commit{})
+			if err := m.ChildCommits[len(m.ChildCommits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPfs
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadyProvenance", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			m.ReadyProvenance = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadyProvenance |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Branch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trees", wireType)
 			}
-			if iNdEx >= l {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Trees = append(m.Trees, &Object{})
+			if err := m.Trees[len(m.Trees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Branch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Branch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Datums", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10658,18 +16259,18 @@ func (m *Branch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Repo == nil {
-				m.Repo = &Repo{}
+			if m.Datums == nil {
+				m.Datums = &Object{}
 			}
-			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Datums.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -10679,20 +16280,109 @@ func (m *Branch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPfs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPfs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPfs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPfs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPfs
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPfs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPfs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10716,7 +16406,7 @@ func (m *Branch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchInfo) Unmarshal(dAtA []byte) error {
+func (m *FileInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10726,28 +16416,99 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowPfs
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FileInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FileInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.File == nil {
+				m.File = &File{}
+			}
+			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileType", wireType)
+			}
+			m.FileType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FileType |= (FileType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BranchInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10772,13 +16533,13 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Children = append(m.Children, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -10788,28 +16549,26 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Head == nil {
-				m.Head = &Commit{}
-			}
-			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10833,14 +16592,14 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Provenance = append(m.Provenance, &Branch{})
-			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Objects = append(m.Objects, &Object{})
+			if err := m.Objects[len(m.Objects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockRefs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10864,16 +16623,14 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Branch == nil {
-				m.Branch = &Branch{}
-			}
-			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.BlockRefs = append(m.BlockRefs, &BlockRef{})
+			if err := m.BlockRefs[len(m.BlockRefs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subvenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Committed", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10897,16 +16654,18 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Subvenance = append(m.Subvenance, &Branch{})
-			if err := m.Subvenance[len(m.Subvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Committed == nil {
+				m.Committed = &types.Timestamp{}
+			}
+			if err := m.Committed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DirectProvenance", wireType)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
 			}
-			var msglen int
+			m.Mode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -10916,77 +16675,14 @@ func (m *BranchInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Mode |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DirectProvenance = append(m.DirectProvenance, &Branch{})
-			if err := m.DirectProvenance[len(m.DirectProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPfs(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPfs
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *BranchInfos) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BranchInfos: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchInfos: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11010,8 +16706,10 @@ func (m *BranchInfos) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BranchInfo = append(m.BranchInfo, &BranchInfo{})
-			if err := m.BranchInfo[len(m.BranchInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Owner == nil {
+				m.Owner = &FileOwner{}
+			}
+			if err := m.Owner.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11037,7 +16735,7 @@ func (m *BranchInfos) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *File) Unmarshal(dAtA []byte) error {
+func (m *FileOwner) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11060,17 +16758,17 @@ func (m *File) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: File: wiretype end group for non-group")
+			return fmt.Errorf("proto: FileOwner: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: File: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FileOwner: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
 			}
-			var msglen int
+			m.Uid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11080,30 +16778,16 @@ func (m *File) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Uid |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
-			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
 			}
-			var stringLen uint64
+			m.Gid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11113,21 +16797,11 @@ func (m *File) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Gid |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Path = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -11150,7 +16824,7 @@ func (m *File) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Block) Unmarshal(dAtA []byte) error {
+func (m *ByteRange) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11173,17 +16847,17 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Block: wiretype end group for non-group")
+			return fmt.Errorf("proto: ByteRange: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Block: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ByteRange: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
 			}
-			var stringLen uint64
+			m.Lower = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11193,21 +16867,30 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Lower |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPfs
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Upper = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Upper |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -11230,7 +16913,7 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Object) Unmarshal(dAtA []byte) error {
+func (m *BlockRef) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11253,17 +16936,17 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Object: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockRef: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Object: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockRef: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11273,20 +16956,57 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
+			if m.Block == nil {
+				m.Block = &Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Range", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Range == nil {
+				m.Range = &ByteRange{}
+			}
+			if err := m.Range.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11310,7 +17030,7 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Tag) Unmarshal(dAtA []byte) error {
+func (m *ObjectInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11333,17 +17053,17 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Tag: wiretype end group for non-group")
+			return fmt.Errorf("proto: ObjectInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Tag: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ObjectInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11353,20 +17073,57 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Object == nil {
+				m.Object = &Object{}
+			}
+			if err := m.Object.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockRef", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockRef == nil {
+				m.BlockRef = &BlockRef{}
+			}
+			if err := m.BlockRef.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11390,7 +17147,7 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RepoInfo) Unmarshal(dAtA []byte) error {
+func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11413,10 +17170,10 @@ func (m *RepoInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RepoInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateRepoRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RepoInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11452,59 +17209,7 @@ func (m *RepoInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Created == nil {
-				m.Created = &types.Timestamp{}
-			}
-			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
-			}
-			m.SizeBytes = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SizeBytes |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
 			}
@@ -11533,11 +17238,11 @@ func (m *RepoInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.Description = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthInfo", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11547,30 +17252,17 @@ func (m *RepoInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.AuthInfo == nil {
-				m.AuthInfo = &RepoAuthInfo{}
-			}
-			if err := m.AuthInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
+			m.Update = bool(v != 0)
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branches", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecommendedGlob", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11580,79 +17272,45 @@ func (m *RepoInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Branches = append(m.Branches, &Branch{})
-			if err := m.Branches[len(m.Branches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.RecommendedGlob = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPfs(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPfs
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RepoAuthInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommitFiles", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RepoAuthInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RepoAuthInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.MaxCommitFiles = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCommitFiles |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccessLevel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommitBytes", wireType)
 			}
-			m.AccessLevel = 0
+			m.MaxCommitBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -11662,7 +17320,7 @@ func (m *RepoAuthInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AccessLevel |= (auth.Scope(b) & 0x7F) << shift
+				m.MaxCommitBytes |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -11689,7 +17347,7 @@ func (m *RepoAuthInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Commit) Unmarshal(dAtA []byte) error {
+func (m *InspectRepoRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11712,10 +17370,10 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+			return fmt.Errorf("proto: InspectRepoRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InspectRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11751,35 +17409,6 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -11802,7 +17431,7 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CommitRange) Unmarshal(dAtA []byte) error {
+func (m *InspectReposRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11825,48 +17454,15 @@ func (m *CommitRange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CommitRange: wiretype end group for non-group")
+			return fmt.Errorf("proto: InspectReposRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CommitRange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InspectReposRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Lower == nil {
-				m.Lower = &Commit{}
-			}
-			if err := m.Lower.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repos", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11890,10 +17486,8 @@ func (m *CommitRange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Upper == nil {
-				m.Upper = &Commit{}
-			}
-			if err := m.Upper.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Repos = append(m.Repos, &Repo{})
+			if err := m.Repos[len(m.Repos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11919,7 +17513,7 @@ func (m *CommitRange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CommitInfo) Unmarshal(dAtA []byte) error {
+func (m *InspectRepoResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11942,15 +17536,15 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CommitInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: InspectRepoResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InspectRepoResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11974,16 +17568,16 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
 			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ParentCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12007,18 +17601,18 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ParentCommit == nil {
-				m.ParentCommit = &Commit{}
+			if m.Info == nil {
+				m.Info = &RepoInfo{}
 			}
-			if err := m.ParentCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Started", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotFound", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12028,30 +17622,17 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Started == nil {
-				m.Started = &types.Timestamp{}
-			}
-			if err := m.Started.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.NotFound = bool(v != 0)
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12061,47 +17642,126 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Finished == nil {
-				m.Finished = &types.Timestamp{}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if err := m.Finished.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRepoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRepoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
 			}
-			m.SizeBytes = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SizeBytes |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRepoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRepoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRepoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RepoInfo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12125,14 +17785,65 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Provenance = append(m.Provenance, &Commit{})
-			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.RepoInfo = append(m.RepoInfo, &RepoInfo{})
+			if err := m.RepoInfo[len(m.RepoInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiskUsageRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiskUsageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiskUsageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12156,76 +17867,67 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Tree == nil {
-				m.Tree = &Object{}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
 			}
-			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subvenance", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RepoDiskUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Subvenance = append(m.Subvenance, &CommitRange{})
-			if err := m.Subvenance[len(m.Subvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 10:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RepoDiskUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RepoDiskUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchProvenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12249,16 +17951,18 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BranchProvenance = append(m.BranchProvenance, &Branch{})
-			if err := m.BranchProvenance[len(m.BranchProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChildCommits", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogicalBytes", wireType)
 			}
-			var msglen int
+			m.LogicalBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12268,28 +17972,16 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.LogicalBytes |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ChildCommits = append(m.ChildCommits, &Commit{})
-			if err := m.ChildCommits[len(m.ChildCommits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 12:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadyProvenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PhysicalBytes", wireType)
 			}
-			m.ReadyProvenance = 0
+			m.PhysicalBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12299,16 +17991,16 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReadyProvenance |= (int64(b) & 0x7F) << shift
+				m.PhysicalBytes |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 13:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trees", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExclusiveBytes", wireType)
 			}
-			var msglen int
+			m.ExclusiveBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12318,26 +18010,65 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.ExclusiveBytes |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Trees = append(m.Trees, &Object{})
-			if err := m.Trees[len(m.Trees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiskUsageResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
 			}
-			iNdEx = postIndex
-		case 14:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiskUsageResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiskUsageResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Datums", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repos", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12361,10 +18092,8 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Datums == nil {
-				m.Datums = &Object{}
-			}
-			if err := m.Datums.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Repos = append(m.Repos, &RepoDiskUsage{})
+			if err := m.Repos[len(m.Repos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -12390,7 +18119,7 @@ func (m *CommitInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FileInfo) Unmarshal(dAtA []byte) error {
+func (m *DeleteRepoRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12413,15 +18142,15 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FileInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteRepoRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FileInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12445,18 +18174,18 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.File == nil {
-				m.File = &File{}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
 			}
-			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
 			}
-			m.FileType = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12466,16 +18195,17 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FileType |= (FileType(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Force = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field All", wireType)
 			}
-			m.SizeBytes = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12485,16 +18215,68 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SizeBytes |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			m.All = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartCommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12504,26 +18286,30 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Children = append(m.Children, string(dAtA[iNdEx:postIndex]))
+			if m.Parent == nil {
+				m.Parent = &Commit{}
+			}
+			if err := m.Parent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12533,28 +18319,28 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
+			m.Provenance = append(m.Provenance, &Commit{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12564,28 +18350,26 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Objects = append(m.Objects, &Object{})
-			if err := m.Objects[len(m.Objects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Branch = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockRefs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12595,26 +18379,24 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BlockRefs = append(m.BlockRefs, &BlockRef{})
-			if err := m.BlockRefs[len(m.BlockRefs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Description = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Committed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12638,12 +18420,97 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Committed == nil {
-				m.Committed = &types.Timestamp{}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
 			}
-			if err := m.Committed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPfs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPfs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPfs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPfs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPfs
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPfs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPfs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
 			}
+			m.Labels[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12667,7 +18534,7 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ByteRange) Unmarshal(dAtA []byte) error {
+func (m *ProvenanceGraphRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12690,17 +18557,17 @@ func (m *ByteRange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ByteRange: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProvenanceGraphRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ByteRange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProvenanceGraphRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
-			m.Lower = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12710,16 +18577,30 @@ func (m *ByteRange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Lower |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDepth", wireType)
 			}
-			m.Upper = 0
+			m.MaxDepth = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -12729,7 +18610,7 @@ func (m *ByteRange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Upper |= (uint64(b) & 0x7F) << shift
+				m.MaxDepth |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -12756,7 +18637,7 @@ func (m *ByteRange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockRef) Unmarshal(dAtA []byte) error {
+func (m *ProvenanceGraphNode) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12779,15 +18660,15 @@ func (m *BlockRef) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockRef: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProvenanceGraphNode: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockRef: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProvenanceGraphNode: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12811,16 +18692,16 @@ func (m *BlockRef) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Block == nil {
-				m.Block = &Block{}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
 			}
-			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Range", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12844,13 +18725,83 @@ func (m *BlockRef) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Range == nil {
-				m.Range = &ByteRange{}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
 			}
-			if err := m.Range.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depth", wireType)
+			}
+			m.Depth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Depth |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provenance = append(m.Provenance, &Commit{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -12873,7 +18824,7 @@ func (m *BlockRef) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ObjectInfo) Unmarshal(dAtA []byte) error {
+func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12896,15 +18847,15 @@ func (m *ObjectInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ObjectInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: BuildCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ObjectInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BuildCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12928,16 +18879,16 @@ func (m *ObjectInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Object == nil {
-				m.Object = &Object{}
+			if m.Parent == nil {
+				m.Parent = &Commit{}
 			}
-			if err := m.Object.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Parent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockRef", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12961,13 +18912,102 @@ func (m *ObjectInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.BlockRef == nil {
-				m.BlockRef = &BlockRef{}
+			m.Provenance = append(m.Provenance, &Commit{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if err := m.BlockRef.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tree == nil {
+				m.Tree = &Object{}
+			}
+			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Branch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -12990,7 +19030,7 @@ func (m *ObjectInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
+func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13013,17 +19053,50 @@ func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateRepoRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: FinishCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FinishCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -13033,30 +19106,26 @@ func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Repo == nil {
-				m.Repo = &Repo{}
-			}
-			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Description = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -13066,24 +19135,28 @@ func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
+			if m.Tree == nil {
+				m.Tree = &Object{}
+			}
+			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Empty", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -13100,61 +19173,60 @@ func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Update = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPfs(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			m.Empty = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trees", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *InspectRepoRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
+			m.Trees = append(m.Trees, &Object{})
+			if err := m.Trees[len(m.Trees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: InspectRepoRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InspectRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Datums", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13178,64 +19250,131 @@ func (m *InspectRepoRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Repo == nil {
-				m.Repo = &Repo{}
+			if m.Datums == nil {
+				m.Datums = &Object{}
 			}
-			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Datums.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPfs(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ListRepoRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPfs
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPfs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPfs
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPfs
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPfs
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPfs(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPfs
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ListRepoRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -13258,7 +19397,7 @@ func (m *ListRepoRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListRepoResponse) Unmarshal(dAtA []byte) error {
+func (m *InspectCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13281,15 +19420,15 @@ func (m *ListRepoResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListRepoResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: InspectCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRepoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InspectCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RepoInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13313,11 +19452,32 @@ func (m *ListRepoResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RepoInfo = append(m.RepoInfo, &RepoInfo{})
-			if err := m.RepoInfo[len(m.RepoInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+			}
+			m.BlockState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockState |= (CommitState(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -13340,7 +19500,7 @@ func (m *ListRepoResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteRepoRequest) Unmarshal(dAtA []byte) error {
+func (m *InspectCommitsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13363,15 +19523,15 @@ func (m *DeleteRepoRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteRepoRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: InspectCommitsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InspectCommitsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13395,53 +19555,11 @@ func (m *DeleteRepoRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Repo == nil {
-				m.Repo = &Repo{}
-			}
-			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Commits = append(m.Commits, &Commit{})
+			if err := m.Commits[len(m.Commits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Force = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field All", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.All = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -13464,7 +19582,7 @@ func (m *DeleteRepoRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *InspectCommitResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13487,15 +19605,15 @@ func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StartCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: InspectCommitResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StartCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InspectCommitResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13519,16 +19637,16 @@ func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Parent == nil {
-				m.Parent = &Commit{}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
 			}
-			if err := m.Parent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13552,16 +19670,18 @@ func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Provenance = append(m.Provenance, &Commit{})
-			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Info == nil {
+				m.Info = &CommitInfo{}
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotFound", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -13571,24 +19691,15 @@ func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Branch = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.NotFound = bool(v != 0)
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13613,7 +19724,7 @@ func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13637,7 +19748,7 @@ func (m *StartCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13660,15 +19771,15 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BuildCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BuildCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13692,16 +19803,16 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Parent == nil {
-				m.Parent = &Commit{}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
 			}
-			if err := m.Parent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13725,14 +19836,16 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Provenance = append(m.Provenance, &Commit{})
-			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.From == nil {
+				m.From = &Commit{}
+			}
+			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13756,16 +19869,35 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Tree == nil {
-				m.Tree = &Object{}
+			if m.To == nil {
+				m.To = &Commit{}
 			}
-			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.To.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
+			}
+			m.Number = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Number |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelSelector", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13790,13 +19922,64 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Branch = string(dAtA[iNdEx:postIndex])
+			m.LabelSelector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitInfos) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitInfos: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitInfos: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitInfo", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -13806,20 +19989,22 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.CommitInfo = append(m.CommitInfo, &CommitInfo{})
+			if err := m.CommitInfo[len(m.CommitInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13843,7 +20028,7 @@ func (m *BuildCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *CreateBranchRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13866,15 +20051,15 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FinishCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateBranchRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FinishCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13898,16 +20083,16 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
+			if m.Head == nil {
+				m.Head = &Commit{}
 			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SBranch", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13932,11 +20117,11 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
+			m.SBranch = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13960,18 +20145,18 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Tree == nil {
-				m.Tree = &Object{}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
 			}
-			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Empty", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -13981,15 +20166,77 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Empty = bool(v != 0)
-		case 5:
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provenance = append(m.Provenance, &Branch{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectBranchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectBranchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trees", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14013,16 +20260,69 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Trees = append(m.Trees, &Object{})
-			if err := m.Trees[len(m.Trees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Branch == nil {
+				m.Branch = &Branch{}
+			}
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.SizeBytes = 0
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListBranchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListBranchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -14032,14 +20332,79 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SizeBytes |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListCommitBranchesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListCommitBranchesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListCommitBranchesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Datums", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14063,13 +20428,33 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Datums == nil {
-				m.Datums = &Object{}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
 			}
-			if err := m.Datums.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.History = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -14092,7 +20477,7 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InspectCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *SetMaxHistoryDepthRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14115,15 +20500,15 @@ func (m *InspectCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InspectCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetMaxHistoryDepthRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InspectCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetMaxHistoryDepthRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14147,18 +20532,18 @@ func (m *InspectCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
 			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Depth", wireType)
 			}
-			m.BlockState = 0
+			m.Depth = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -14168,7 +20553,7 @@ func (m *InspectCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockState |= (CommitState(b) & 0x7F) << shift
+				m.Depth |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -14195,7 +20580,7 @@ func (m *InspectCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *SwapBranchRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14218,15 +20603,15 @@ func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SwapBranchRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SwapBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14250,16 +20635,16 @@ func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Repo == nil {
-				m.Repo = &Repo{}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
 			}
-			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Other", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14283,16 +20668,67 @@ func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.From == nil {
-				m.From = &Commit{}
+			if m.Other == nil {
+				m.Other = &Branch{}
 			}
-			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Other.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteBranchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteBranchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14316,18 +20752,18 @@ func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.To == nil {
-				m.To = &Commit{}
+			if m.Branch == nil {
+				m.Branch = &Branch{}
 			}
-			if err := m.To.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
 			}
-			m.Number = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -14337,11 +20773,12 @@ func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Number |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Force = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -14364,7 +20801,7 @@ func (m *ListCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CommitInfos) Unmarshal(dAtA []byte) error {
+func (m *DeleteCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14387,15 +20824,15 @@ func (m *CommitInfos) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CommitInfos: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CommitInfos: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14419,8 +20856,10 @@ func (m *CommitInfos) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CommitInfo = append(m.CommitInfo, &CommitInfo{})
-			if err := m.CommitInfo[len(m.CommitInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14446,7 +20885,7 @@ func (m *CommitInfos) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateBranchRequest) Unmarshal(dAtA []byte) error {
+func (m *SquashCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14469,77 +20908,15 @@ func (m *CreateBranchRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateBranchRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SquashCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SquashCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Head == nil {
-				m.Head = &Commit{}
-			}
-			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SBranch", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SBranch = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14563,41 +20940,10 @@ func (m *CreateBranchRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Branch == nil {
-				m.Branch = &Branch{}
-			}
-			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if m.Commit == nil {
+				m.Commit = &Commit{}
 			}
-			m.Provenance = append(m.Provenance, &Branch{})
-			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14623,7 +20969,7 @@ func (m *CreateBranchRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InspectBranchRequest) Unmarshal(dAtA []byte) error {
+func (m *FlushCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14646,15 +20992,46 @@ func (m *InspectBranchRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InspectBranchRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: FlushCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InspectBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FlushCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commits = append(m.Commits, &Commit{})
+			if err := m.Commits[len(m.Commits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToRepos", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14678,10 +21055,8 @@ func (m *InspectBranchRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Branch == nil {
-				m.Branch = &Branch{}
-			}
-			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ToRepos = append(m.ToRepos, &Repo{})
+			if err := m.ToRepos[len(m.ToRepos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14707,7 +21082,7 @@ func (m *InspectBranchRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListBranchRequest) Unmarshal(dAtA []byte) error {
+func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14730,10 +21105,10 @@ func (m *ListBranchRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListBranchRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubscribeCommitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubscribeCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -14769,60 +21144,38 @@ func (m *ListBranchRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPfs(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPfs
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DeleteBranchRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPfs
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteBranchRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteBranchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Branch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14846,18 +21199,18 @@ func (m *DeleteBranchRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Branch == nil {
-				m.Branch = &Branch{}
+			if m.From == nil {
+				m.From = &Commit{}
 			}
-			if err := m.Branch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var v int
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -14867,12 +21220,11 @@ func (m *DeleteBranchRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.State |= (CommitState(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Force = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -14895,7 +21247,7 @@ func (m *DeleteBranchRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14918,15 +21270,15 @@ func (m *DeleteCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14950,13 +21302,51 @@ func (m *DeleteCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
+			if m.File == nil {
+				m.File = &File{}
 			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OffsetBytes", wireType)
+			}
+			m.OffsetBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OffsetBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			}
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -14979,7 +21369,7 @@ func (m *DeleteCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FlushCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *OverwriteIndex) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15002,48 +21392,17 @@ func (m *FlushCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FlushCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: OverwriteIndex: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FlushCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OverwriteIndex: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commits", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Commits = append(m.Commits, &Commit{})
-			if err := m.Commits[len(m.Commits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToRepos", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15053,23 +21412,11 @@ func (m *FlushCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Index |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ToRepos = append(m.ToRepos, &Repo{})
-			if err := m.ToRepos[len(m.ToRepos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -15092,7 +21439,7 @@ func (m *FlushCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15115,17 +21462,50 @@ func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubscribeCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubscribeCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.File == nil {
+				m.File = &File{}
+			}
+			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15135,28 +21515,26 @@ func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Repo == nil {
-				m.Repo = &Repo{}
-			}
-			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Branch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15181,11 +21559,88 @@ func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Branch = string(dAtA[iNdEx:postIndex])
+			m.Url = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recursive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Recursive = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delimiter", wireType)
+			}
+			m.Delimiter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Delimiter |= (Delimiter(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetFileDatums", wireType)
+			}
+			m.TargetFileDatums = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetFileDatums |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetFileBytes", wireType)
+			}
+			m.TargetFileBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetFileBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OverwriteIndex", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15209,18 +21664,18 @@ func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.From == nil {
-				m.From = &Commit{}
+			if m.OverwriteIndex == nil {
+				m.OverwriteIndex = &OverwriteIndex{}
 			}
-			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.OverwriteIndex.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 11:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderRecords", wireType)
 			}
-			m.State = 0
+			m.HeaderRecords = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15230,7 +21685,7 @@ func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= (CommitState(b) & 0x7F) << shift
+				m.HeaderRecords |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -15257,7 +21712,7 @@ func (m *SubscribeCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
+func (m *PutFileChunkRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15280,10 +21735,10 @@ func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutFileChunkRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutFileChunkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -15320,10 +21775,10 @@ func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OffsetBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			m.OffsetBytes = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15333,16 +21788,28 @@ func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.OffsetBytes |= (int64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadId", wireType)
 			}
-			m.SizeBytes = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15352,11 +21819,21 @@ func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SizeBytes |= (int64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UploadId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -15379,7 +21856,7 @@ func (m *GetFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OverwriteIndex) Unmarshal(dAtA []byte) error {
+func (m *FileChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15402,17 +21879,17 @@ func (m *OverwriteIndex) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OverwriteIndex: wiretype end group for non-group")
+			return fmt.Errorf("proto: FileChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OverwriteIndex: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FileChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OffsetBytes", wireType)
 			}
-			m.Index = 0
+			m.OffsetBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15422,11 +21899,63 @@ func (m *OverwriteIndex) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= (int64(b) & 0x7F) << shift
+				m.OffsetBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			}
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Object == nil {
+				m.Object = &Object{}
+			}
+			if err := m.Object.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -15449,7 +21978,7 @@ func (m *OverwriteIndex) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
+func (m *PutFileChunksRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15472,10 +22001,10 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutFileChunksRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutFileChunksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -15504,49 +22033,18 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.File == nil {
-				m.File = &File{}
-			}
-			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			if m.File == nil {
+				m.File = &File{}
+			}
+			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15556,24 +22054,26 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Url = string(dAtA[iNdEx:postIndex])
+			m.Chunks = append(m.Chunks, &FileChunk{})
+			if err := m.Chunks[len(m.Chunks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Recursive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Overwrite", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -15590,12 +22090,12 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Recursive = bool(v != 0)
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delimiter", wireType)
+			m.Overwrite = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadId", wireType)
 			}
-			m.Delimiter = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15605,16 +22105,77 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Delimiter |= (Delimiter(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UploadId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutFileRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutFileRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetFileDatums", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
 			}
-			m.TargetFileDatums = 0
+			m.SizeBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15624,16 +22185,16 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TargetFileDatums |= (int64(b) & 0x7F) << shift
+				m.SizeBytes |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetFileBytes", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectHash", wireType)
 			}
-			m.TargetFileBytes = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15643,12 +22204,22 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TargetFileBytes |= (int64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObjectHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field OverwriteIndex", wireType)
 			}
@@ -15681,25 +22252,6 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeaderRecords", wireType)
-			}
-			m.HeaderRecords = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.HeaderRecords |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -15722,7 +22274,7 @@ func (m *PutFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
+func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15745,17 +22297,17 @@ func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutFileRecord: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutFileRecords: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutFileRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutFileRecords: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Split", wireType)
 			}
-			m.SizeBytes = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15765,16 +22317,17 @@ func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SizeBytes |= (int64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Split = bool(v != 0)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15784,24 +22337,46 @@ func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ObjectHash = string(dAtA[iNdEx:postIndex])
+			m.Records = append(m.Records, &PutFileRecord{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tombstone", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Tombstone = bool(v != 0)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OverwriteIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15825,10 +22400,43 @@ func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OverwriteIndex == nil {
-				m.OverwriteIndex = &OverwriteIndex{}
+			if m.Header == nil {
+				m.Header = &PutFileRecord{}
 			}
-			if err := m.OverwriteIndex.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Footer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Footer == nil {
+				m.Footer = &PutFileRecord{}
+			}
+			if err := m.Footer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -15854,7 +22462,7 @@ func (m *PutFileRecord) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
+func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15877,17 +22485,17 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutFileRecords: wiretype end group for non-group")
+			return fmt.Errorf("proto: CopyFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutFileRecords: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CopyFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Split", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Src", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -15897,15 +22505,28 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Split = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Src == nil {
+				m.Src = &File{}
+			}
+			if err := m.Src.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Dst", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15929,14 +22550,16 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Records = append(m.Records, &PutFileRecord{})
-			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Dst == nil {
+				m.Dst = &File{}
+			}
+			if err := m.Dst.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tombstone", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Overwrite", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -15953,43 +22576,61 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Tombstone = bool(v != 0)
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPfs
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Overwrite = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &PutFileRecord{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectFileRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectFileRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Footer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16013,10 +22654,10 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Footer == nil {
-				m.Footer = &PutFileRecord{}
+			if m.File == nil {
+				m.File = &File{}
 			}
-			if err := m.Footer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -16042,7 +22683,7 @@ func (m *PutFileRecords) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
+func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16065,15 +22706,15 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CopyFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CopyFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Src", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16097,18 +22738,18 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Src == nil {
-				m.Src = &File{}
+			if m.File == nil {
+				m.File = &File{}
 			}
-			if err := m.Src.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dst", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Full", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16118,30 +22759,17 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPfs
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Dst == nil {
-				m.Dst = &File{}
-			}
-			if err := m.Dst.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Full = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Overwrite", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
 			}
-			var v int
+			m.History = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16151,12 +22779,11 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.History |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Overwrite = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -16179,7 +22806,7 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InspectFileRequest) Unmarshal(dAtA []byte) error {
+func (m *WalkFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16202,10 +22829,10 @@ func (m *InspectFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InspectFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: WalkFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InspectFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WalkFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -16263,7 +22890,7 @@ func (m *InspectFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
+func (m *GlobFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16286,15 +22913,15 @@ func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GlobFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GlobFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16318,18 +22945,18 @@ func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.File == nil {
-				m.File = &File{}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
 			}
-			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Full", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16339,17 +22966,77 @@ func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Full = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
 			}
-			m.History = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FileInfos) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FileInfos: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FileInfos: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileInfo", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16359,11 +23046,23 @@ func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.History |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileInfo = append(m.FileInfo, &FileInfo{})
+			if err := m.FileInfo[len(m.FileInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -16386,7 +23085,7 @@ func (m *ListFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WalkFileRequest) Unmarshal(dAtA []byte) error {
+func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16409,15 +23108,15 @@ func (m *WalkFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WalkFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiffFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WalkFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiffFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field File", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewFile", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16441,13 +23140,66 @@ func (m *WalkFileRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.File == nil {
-				m.File = &File{}
+			if m.NewFile == nil {
+				m.NewFile = &File{}
 			}
-			if err := m.File.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.NewFile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldFile", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OldFile == nil {
+				m.OldFile = &File{}
+			}
+			if err := m.OldFile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shallow", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Shallow = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -16470,7 +23222,7 @@ func (m *WalkFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobFileRequest) Unmarshal(dAtA []byte) error {
+func (m *DiffFileResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16493,15 +23245,15 @@ func (m *GlobFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiffFileResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiffFileResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewFiles", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16525,18 +23277,16 @@ func (m *GlobFileRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
-			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NewFiles = append(m.NewFiles, &FileInfo{})
+			if err := m.NewFiles[len(m.NewFiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OldFiles", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16546,20 +23296,22 @@ func (m *GlobFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pattern = string(dAtA[iNdEx:postIndex])
+			m.OldFiles = append(m.OldFiles, &FileInfo{})
+			if err := m.OldFiles[len(m.OldFiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -16583,7 +23335,7 @@ func (m *GlobFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FileInfos) Unmarshal(dAtA []byte) error {
+func (m *GetCommitTreeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16606,15 +23358,15 @@ func (m *FileInfos) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FileInfos: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetCommitTreeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FileInfos: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetCommitTreeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16638,8 +23390,10 @@ func (m *FileInfos) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FileInfo = append(m.FileInfo, &FileInfo{})
-			if err := m.FileInfo[len(m.FileInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -16665,7 +23419,7 @@ func (m *FileInfos) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
+func (m *TreeNode) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16688,17 +23442,17 @@ func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DiffFileRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TreeNode: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DiffFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TreeNode: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewFile", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16708,30 +23462,45 @@ func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NewFile == nil {
-				m.NewFile = &File{}
-			}
-			if err := m.NewFile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileType", wireType)
+			}
+			m.FileType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FileType |= (FileType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldFile", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16741,30 +23510,28 @@ func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OldFile == nil {
-				m.OldFile = &File{}
-			}
-			if err := m.OldFile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shallow", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
 			}
-			var v int
+			m.SizeBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16774,12 +23541,11 @@ func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.SizeBytes |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Shallow = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -16802,7 +23568,7 @@ func (m *DiffFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DiffFileResponse) Unmarshal(dAtA []byte) error {
+func (m *GetManifestRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16825,15 +23591,15 @@ func (m *DiffFileResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DiffFileResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetManifestRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DiffFileResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetManifestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewFiles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16857,16 +23623,18 @@ func (m *DiffFileResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NewFiles = append(m.NewFiles, &FileInfo{})
-			if err := m.NewFiles[len(m.NewFiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldFiles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPfs
@@ -16876,22 +23644,20 @@ func (m *DiffFileResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPfs
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OldFiles = append(m.OldFiles, &FileInfo{})
-			if err := m.OldFiles[len(m.OldFiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -17123,6 +23889,35 @@ func (m *PutObjectRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DedupScope", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DedupScope = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -17567,6 +24362,35 @@ func (m *ListObjectsRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: ListObjectsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -18227,6 +25051,282 @@ func (m *CheckObjectResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CacheInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CacheInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CacheInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
+			}
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			m.Items = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Items |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gets", wireType)
+			}
+			m.Gets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gets |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hits", wireType)
+			}
+			m.Hits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hits |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evictions", wireType)
+			}
+			m.Evictions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Evictions |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectCacheResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPfs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectCacheResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectCacheResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Caches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Caches = append(m.Caches, &CacheInfo{})
+			if err := m.Caches[len(m.Caches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPfs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Objects) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -18711,200 +25811,263 @@ var (
 	ErrIntOverflowPfs   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("client/pfs/pfs.proto", fileDescriptor_pfs_01fe324dfe67e43e) }
-
-var fileDescriptor_pfs_01fe324dfe67e43e = []byte{
-	// 3067 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x4b, 0x8f, 0x1b, 0xc7,
-	0xb5, 0x9e, 0x26, 0x9b, 0x64, 0xf3, 0x70, 0x86, 0xc3, 0x29, 0x8d, 0x47, 0x34, 0x65, 0xbd, 0x5a,
-	0xb2, 0xaf, 0x2c, 0xdb, 0x33, 0xe3, 0x91, 0x7d, 0xf5, 0xb2, 0x3d, 0xd0, 0x3c, 0x24, 0x53, 0x10,
-	0x24, 0xdd, 0xe6, 0x5c, 0x5f, 0x5c, 0x03, 0x09, 0xd1, 0x24, 0x8b, 0x64, 0x5b, 0x4d, 0x76, 0xbb,
-	0xab, 0xa9, 0xf1, 0xf8, 0x0f, 0x24, 0x9b, 0xec, 0x0d, 0x64, 0x13, 0x20, 0x3f, 0x20, 0x40, 0x7e,
-	0x45, 0x90, 0x55, 0x16, 0x59, 0x07, 0x81, 0xb2, 0x0c, 0x10, 0x20, 0xdb, 0x6c, 0x12, 0xd4, 0xab,
-	0xbb, 0xfa, 0xc1, 0xe1, 0x8c, 0x01, 0x2f, 0x24, 0x56, 0x57, 0x9d, 0x73, 0xea, 0xd4, 0x79, 0x7c,
-	0x75, 0x4e, 0x49, 0xb0, 0xde, 0x77, 0x1d, 0x3c, 0x0d, 0xb7, 0xfc, 0x21, 0xa1, 0x7f, 0x36, 0xfd,
-	0xc0, 0x0b, 0x3d, 0x54, 0xf4, 0x87, 0xa4, 0x75, 0x69, 0xe4, 0x79, 0x23, 0x17, 0x6f, 0xb1, 0xa9,
-	0xde, 0x6c, 0xb8, 0x85, 0x27, 0x7e, 0x78, 0xc2, 0x29, 0x5a, 0x57, 0xd3, 0x8b, 0xa1, 0x33, 0xc1,
-	0x24, 0xb4, 0x27, 0xbe, 0x20, 0xb8, 0x92, 0x26, 0x38, 0x0e, 0x6c, 0xdf, 0xc7, 0x81, 0xd8, 0xa2,
-	0xb5, 0x3e, 0xf2, 0x46, 0x1e, 0x1b, 0x6e, 0xd1, 0x91, 0x98, 0xdd, 0x10, 0xea, 0xd8, 0xb3, 0x70,
-	0xcc, 0xfe, 0xe2, 0xf3, 0x66, 0x0b, 0x74, 0x0b, 0xfb, 0x1e, 0x42, 0xa0, 0x4f, 0xed, 0x09, 0x6e,
-	0x6a, 0xd7, 0xb4, 0x5b, 0x55, 0x8b, 0x8d, 0xcd, 0x87, 0x50, 0xde, 0x0b, 0xec, 0x69, 0x7f, 0x8c,
-	0x2e, 0x83, 0x1e, 0x60, 0xdf, 0x63, 0xab, 0xb5, 0x9d, 0xea, 0x26, 0x3d, 0x10, 0x65, 0xb3, 0xd8,
-	0x74, 0xc4, 0x5c, 0x50, 0x98, 0xff, 0xa5, 0x01, 0x70, 0xee, 0xf6, 0x74, 0x98, 0x2b, 0x1f, 0x5d,
-	0x05, 0x7d, 0x8c, 0xed, 0x01, 0x63, 0xab, 0xed, 0xd4, 0x98, 0xd4, 0x7d, 0x6f, 0x32, 0x71, 0x42,
-	0x8b, 0x2d, 0xa0, 0x0f, 0x00, 0xfc, 0xc0, 0x7b, 0x8d, 0xa7, 0xf6, 0xb4, 0x8f, 0x9b, 0xc5, 0x6b,
-	0xc5, 0x88, 0x8c, 0x4b, 0xb6, 0x94, 0x65, 0x74, 0x03, 0xca, 0x3d, 0x36, 0xdb, 0xd4, 0x15, 0x79,
-	0x82, 0x50, 0x2c, 0x51, 0x89, 0x64, 0xd6, 0x93, 0x12, 0x4b, 0x39, 0x12, 0xe3, 0x65, 0x74, 0x0f,
-	0xd6, 0x06, 0x4e, 0x80, 0xfb, 0x61, 0x57, 0xd1, 0xa2, 0x9c, 0xe5, 0x69, 0x70, 0xaa, 0x97, 0x11,
-	0x91, 0xb9, 0x0b, 0xb5, 0xf8, 0xec, 0x04, 0x6d, 0x43, 0x8d, 0xef, 0xdf, 0x75, 0xa6, 0x43, 0x6a,
-	0x45, 0x2a, 0x62, 0x55, 0x11, 0x41, 0xc9, 0x2c, 0xe8, 0x45, 0x63, 0x73, 0x17, 0xf4, 0xc7, 0x8e,
-	0xcb, 0x0e, 0xd5, 0x67, 0x16, 0x11, 0xa6, 0x4f, 0x18, 0x49, 0x2c, 0x51, 0xdb, 0xfa, 0x76, 0x38,
-	0x96, 0xe6, 0xa7, 0x63, 0xf3, 0x12, 0x94, 0xf6, 0x5c, 0xaf, 0xff, 0x8a, 0x2e, 0x8e, 0x6d, 0x32,
-	0x96, 0x86, 0xa7, 0x63, 0xf3, 0x1d, 0x28, 0xbf, 0xe8, 0x7d, 0x83, 0xfb, 0x61, 0xee, 0xea, 0xdb,
-	0x50, 0x3c, 0xb2, 0x47, 0xb9, 0x11, 0xf1, 0x6f, 0x0d, 0x0c, 0xea, 0x77, 0xe6, 0xd2, 0x05, 0x41,
-	0xf1, 0x09, 0x54, 0xfa, 0x01, 0xb6, 0x43, 0x2c, 0x1d, 0xdc, 0xda, 0xe4, 0x91, 0xbb, 0x29, 0x23,
-	0x77, 0xf3, 0x48, 0x86, 0xb6, 0x25, 0x49, 0xd1, 0x65, 0x00, 0xe2, 0x7c, 0x8f, 0xbb, 0xbd, 0x93,
-	0x10, 0x93, 0x66, 0xf1, 0x9a, 0x76, 0x4b, 0xb7, 0xaa, 0x74, 0x66, 0x8f, 0x4e, 0xa0, 0x6b, 0x50,
-	0x1b, 0x60, 0xd2, 0x0f, 0x1c, 0x3f, 0x74, 0xbc, 0x69, 0xb3, 0xc4, 0x74, 0x53, 0xa7, 0xd0, 0x26,
-	0x54, 0x69, 0x78, 0x73, 0x4b, 0x97, 0xd9, 0xc6, 0x6b, 0x91, 0x6a, 0x8f, 0x66, 0x21, 0xb7, 0xb5,
-	0x61, 0x8b, 0x11, 0xfa, 0x2f, 0x30, 0xb8, 0xdd, 0x31, 0x69, 0x56, 0xb2, 0xbe, 0x8d, 0x16, 0x9f,
-	0xea, 0x86, 0xde, 0x28, 0x99, 0x5f, 0xc0, 0xb2, 0x2a, 0x08, 0x6d, 0xc2, 0xb2, 0xdd, 0xef, 0x63,
-	0x42, 0xba, 0x2e, 0x7e, 0x8d, 0x5d, 0x66, 0x8c, 0xfa, 0x4e, 0x6d, 0x93, 0xa5, 0x58, 0xa7, 0xef,
-	0xf9, 0xd8, 0xaa, 0x71, 0x82, 0x67, 0x74, 0xdd, 0xdc, 0x85, 0x32, 0xf7, 0xde, 0x22, 0xf3, 0x6d,
-	0x40, 0xc1, 0xe1, 0x96, 0xab, 0xee, 0x95, 0xdf, 0xfc, 0xe5, 0x6a, 0xa1, 0x7d, 0x60, 0x15, 0x9c,
-	0x81, 0xd9, 0x81, 0x9a, 0x70, 0xbf, 0x3d, 0x1d, 0x61, 0x74, 0x1d, 0x4a, 0xae, 0x77, 0x8c, 0x83,
-	0xbc, 0xf8, 0xe0, 0x2b, 0x94, 0x64, 0x46, 0x01, 0x22, 0x2f, 0xcf, 0xf8, 0x8a, 0xf9, 0x4f, 0x1d,
-	0x80, 0xcf, 0xb0, 0x43, 0x9d, 0x29, 0xea, 0xb6, 0x61, 0xc5, 0xb7, 0x03, 0x3c, 0x0d, 0xbb, 0x82,
-	0x36, 0x47, 0xfc, 0x32, 0xa7, 0x10, 0x27, 0xfe, 0x04, 0x2a, 0x24, 0xb4, 0x03, 0x1a, 0x11, 0xc5,
-	0xc5, 0x11, 0x21, 0x48, 0xd1, 0x7f, 0x83, 0x31, 0x74, 0xa6, 0x0e, 0x19, 0xe3, 0x81, 0xc8, 0xec,
-	0xd3, 0xd8, 0x22, 0xda, 0x54, 0x24, 0x95, 0xd2, 0x91, 0x94, 0xc4, 0x16, 0x35, 0xab, 0x85, 0xee,
-	0x2a, 0xb6, 0x5c, 0x05, 0x3d, 0x0c, 0x30, 0x6e, 0x56, 0x94, 0x23, 0xf2, 0x0c, 0xb2, 0xd8, 0x42,
-	0x3a, 0x2e, 0x8d, 0x6c, 0x5c, 0x6e, 0x27, 0x90, 0xa7, 0xca, 0xf6, 0x6b, 0xa8, 0xfb, 0x51, 0x77,
-	0xa6, 0xe1, 0x47, 0xa0, 0x86, 0xa2, 0x28, 0xe4, 0xc0, 0x0f, 0xa7, 0x8a, 0xe1, 0x87, 0xba, 0xa6,
-	0x3f, 0x76, 0xdc, 0x81, 0xf0, 0x0c, 0x69, 0xd6, 0xb2, 0xc7, 0x5b, 0x66, 0x14, 0xfc, 0x83, 0xa0,
-	0xf7, 0xa1, 0x11, 0x60, 0x7b, 0x70, 0xa2, 0x6e, 0xb5, 0x7c, 0x4d, 0xbb, 0x55, 0xb4, 0x56, 0xd9,
-	0xbc, 0x22, 0xfc, 0x3a, 0x94, 0xe8, 0x91, 0x49, 0x73, 0x45, 0x11, 0x2a, 0x8c, 0xc1, 0x57, 0x68,
-	0xfc, 0x0c, 0xec, 0x70, 0x36, 0x21, 0xcd, 0x7a, 0xd6, 0x60, 0x62, 0xc9, 0xfc, 0x7d, 0x01, 0x0c,
-	0x8a, 0x71, 0x12, 0x4b, 0x86, 0x8e, 0x8b, 0x13, 0xc9, 0x40, 0x17, 0x2d, 0x36, 0x8d, 0x6e, 0x43,
-	0x95, 0xfe, 0x76, 0xc3, 0x13, 0x9f, 0xdf, 0x32, 0xf5, 0x9d, 0x95, 0x88, 0xe6, 0xe8, 0xc4, 0xc7,
-	0xd4, 0xef, 0x7c, 0xb4, 0x08, 0x41, 0x5a, 0x60, 0xb0, 0x93, 0x07, 0x78, 0xca, 0xbc, 0x5e, 0xb5,
-	0xa2, 0xef, 0x08, 0x0d, 0xa9, 0x9b, 0x97, 0x39, 0x1a, 0xa2, 0x77, 0xa1, 0xe2, 0x31, 0xc5, 0x49,
-	0xd3, 0xc8, 0x1e, 0x58, 0xae, 0xa1, 0x0f, 0xa0, 0xda, 0xa3, 0x78, 0x6b, 0xe1, 0x21, 0x11, 0xde,
-	0xe5, 0x1a, 0xee, 0x89, 0x59, 0x2b, 0x5e, 0x47, 0xf7, 0xa0, 0xca, 0x3d, 0x43, 0x53, 0x01, 0x16,
-	0xc6, 0x74, 0x4c, 0x6c, 0xde, 0x85, 0x2a, 0x3d, 0x06, 0xcf, 0xfd, 0x75, 0x35, 0xf7, 0x75, 0x99,
-	0xee, 0xeb, 0x6a, 0xba, 0xeb, 0x32, 0xc3, 0x2d, 0x30, 0xa4, 0x26, 0xe8, 0x1a, 0x94, 0x98, 0x2e,
-	0xc2, 0xda, 0xa0, 0xe8, 0xc9, 0x17, 0xd0, 0x4d, 0x28, 0x05, 0x74, 0x0b, 0x91, 0xd3, 0x75, 0x4e,
-	0x21, 0x37, 0xb6, 0xf8, 0xa2, 0xf9, 0x33, 0x00, 0x6e, 0x06, 0x09, 0x1a, 0xdc, 0x18, 0x09, 0xd0,
-	0x90, 0x4e, 0xe7, 0x4b, 0xd4, 0x91, 0x6c, 0x87, 0x6e, 0x80, 0x87, 0x42, 0x78, 0xca, 0x4c, 0x86,
-	0x34, 0x93, 0x19, 0xc0, 0xda, 0x3e, 0xbb, 0x15, 0x18, 0x2a, 0xe2, 0x6f, 0x67, 0x98, 0x2c, 0x44,
-	0xcd, 0x54, 0x1e, 0x16, 0xb3, 0x79, 0xb8, 0x01, 0xe5, 0x99, 0x3f, 0xb0, 0x43, 0xcc, 0xc0, 0xc4,
-	0xb0, 0xc4, 0xd7, 0x53, 0xdd, 0x28, 0x34, 0x8a, 0xe6, 0x1d, 0x40, 0xed, 0x29, 0xf1, 0xa9, 0xca,
-	0x67, 0xde, 0xd4, 0xbc, 0x08, 0xab, 0xcf, 0x1c, 0xa2, 0x72, 0x3c, 0xd5, 0x0d, 0xad, 0x51, 0x30,
-	0xbf, 0x80, 0x46, 0xbc, 0x40, 0x7c, 0x6f, 0x4a, 0x58, 0x28, 0x53, 0x26, 0xb5, 0x12, 0x58, 0x89,
-	0x04, 0xf2, 0xbb, 0x29, 0x10, 0x23, 0xf3, 0x6b, 0x58, 0x3b, 0xc0, 0x2e, 0x3e, 0x97, 0x05, 0xd6,
-	0xa1, 0x34, 0xf4, 0x82, 0x3e, 0x77, 0x9d, 0x61, 0xf1, 0x0f, 0xd4, 0x80, 0xa2, 0xed, 0xba, 0xcc,
-	0x1e, 0x86, 0x45, 0x87, 0xe6, 0x6f, 0x34, 0x40, 0x1d, 0x0a, 0xb1, 0x02, 0x0f, 0x84, 0xf4, 0x1b,
-	0x50, 0xe6, 0x98, 0x9d, 0x0b, 0xfd, 0x7c, 0x29, 0x85, 0x9d, 0x85, 0xd3, 0xb1, 0x73, 0x23, 0xaa,
-	0xcb, 0xb8, 0x37, 0x64, 0x29, 0x96, 0x72, 0x95, 0x9e, 0x71, 0x95, 0xf9, 0x3b, 0x0d, 0xd0, 0xde,
-	0x2c, 0x42, 0xa9, 0x9f, 0x4e, 0x45, 0x09, 0xef, 0xc5, 0x79, 0xf0, 0xbe, 0x91, 0xa8, 0x2d, 0xe3,
-	0x33, 0xd4, 0xa1, 0xd0, 0x3e, 0x10, 0x55, 0x48, 0xa1, 0x7d, 0x40, 0x8b, 0xde, 0x0b, 0x8f, 0xd9,
-	0x05, 0x94, 0x51, 0x79, 0xf1, 0x85, 0x9a, 0x32, 0x48, 0x21, 0x1b, 0xbb, 0x0b, 0xf5, 0x5c, 0x87,
-	0x12, 0xeb, 0x25, 0x44, 0x6c, 0xf3, 0x8f, 0x18, 0xb1, 0x4b, 0x73, 0x11, 0x3b, 0x09, 0x9a, 0xe5,
-	0x34, 0x68, 0xc6, 0x80, 0x5e, 0x99, 0x0f, 0xe8, 0x53, 0x58, 0x17, 0xb9, 0xf3, 0x23, 0x0e, 0xff,
-	0x31, 0xd4, 0x38, 0x30, 0x90, 0x90, 0xe6, 0x26, 0xc7, 0x78, 0xf5, 0x7e, 0xec, 0xd0, 0x79, 0x0b,
-	0x18, 0x11, 0x1b, 0x9b, 0xbf, 0xd4, 0x60, 0x8d, 0xa6, 0x57, 0x72, 0xb7, 0x05, 0xe9, 0x71, 0x15,
-	0xf4, 0x61, 0xe0, 0x4d, 0x72, 0x7b, 0x0e, 0xba, 0x80, 0x2e, 0x41, 0x21, 0xf4, 0x12, 0x16, 0x16,
-	0xcb, 0x85, 0x90, 0x16, 0x65, 0xe5, 0xe9, 0x6c, 0xd2, 0xc3, 0x01, 0x33, 0xb0, 0x6e, 0x89, 0x2f,
-	0x5a, 0xef, 0xc7, 0xe5, 0x13, 0xab, 0xf7, 0xf9, 0xb1, 0xb2, 0xf5, 0x7e, 0x4c, 0x66, 0x41, 0x3f,
-	0x1a, 0x9b, 0xbf, 0xd5, 0xe0, 0x02, 0x07, 0x3b, 0x71, 0xa9, 0x8b, 0xd3, 0xc8, 0x16, 0x49, 0x9b,
-	0xd7, 0x22, 0xbd, 0x0d, 0x06, 0xe9, 0x8a, 0xd8, 0xe4, 0x11, 0x53, 0x21, 0xa2, 0x69, 0xbb, 0x91,
-	0x48, 0xbc, 0xf9, 0x0d, 0x91, 0x92, 0x27, 0xfa, 0xa9, 0x2d, 0x96, 0xf9, 0x30, 0xf2, 0x70, 0x52,
-	0xcb, 0x78, 0x27, 0x6d, 0xee, 0x4e, 0xe6, 0x0e, 0xf7, 0x56, 0x92, 0x73, 0x01, 0xb2, 0xbe, 0x84,
-	0x0b, 0x1c, 0x00, 0xcf, 0xbf, 0x5f, 0x3e, 0x10, 0x9a, 0x0f, 0xa4, 0xc4, 0xf3, 0xc7, 0xa8, 0x69,
-	0x03, 0x7a, 0xec, 0xce, 0xd2, 0xb9, 0xfd, 0x2e, 0x54, 0x64, 0x99, 0xa5, 0x65, 0x61, 0x46, 0xae,
-	0xa1, 0x9b, 0x60, 0x84, 0x5e, 0x97, 0x9e, 0x8a, 0x08, 0x38, 0x52, 0x4e, 0x5b, 0x09, 0x3d, 0xfa,
-	0x4b, 0xcc, 0x1f, 0x34, 0xd8, 0xe8, 0xcc, 0x7a, 0x34, 0xe5, 0x7b, 0xf8, 0x5c, 0x81, 0x1d, 0x43,
-	0x54, 0x21, 0x01, 0x51, 0x32, 0xe0, 0x8b, 0xf3, 0x02, 0xfe, 0x3d, 0x28, 0xf1, 0x9c, 0xd3, 0xe7,
-	0xe4, 0x1c, 0x5f, 0x36, 0xbf, 0x85, 0xfa, 0x13, 0x1c, 0xb2, 0xa2, 0x2c, 0xd6, 0xe8, 0xb4, 0xa2,
-	0xed, 0x3a, 0x2c, 0x7b, 0xc3, 0x21, 0xc1, 0xa1, 0x40, 0x95, 0x02, 0xab, 0x27, 0x6b, 0x7c, 0x8e,
-	0xe3, 0x4a, 0xb6, 0x56, 0x2b, 0x2a, 0xb0, 0x63, 0xbe, 0x07, 0xf5, 0x17, 0xaf, 0x71, 0x70, 0x1c,
-	0x38, 0x21, 0x6e, 0x4f, 0x07, 0xf8, 0x3b, 0xea, 0x54, 0x87, 0x0e, 0xd8, 0x9e, 0x45, 0x8b, 0x7f,
-	0x98, 0xff, 0x28, 0x40, 0xfd, 0xe5, 0xec, 0x3c, 0xba, 0xad, 0x43, 0xe9, 0xb5, 0xed, 0xce, 0x38,
-	0x94, 0x2e, 0x5b, 0xfc, 0x83, 0xde, 0x92, 0xb3, 0xc0, 0x15, 0x78, 0x4e, 0x87, 0xe8, 0x1d, 0x7a,
-	0x5b, 0xf7, 0x67, 0x01, 0x71, 0x5e, 0x63, 0x06, 0x8b, 0x86, 0x15, 0x4f, 0xa0, 0x0f, 0xa1, 0x3a,
-	0xc0, 0xae, 0x33, 0x71, 0x42, 0x1c, 0x30, 0x64, 0xac, 0x8b, 0x52, 0xe9, 0x40, 0xce, 0x5a, 0x31,
-	0x01, 0xfa, 0x10, 0x50, 0x68, 0x07, 0x23, 0x1c, 0x76, 0x59, 0x2d, 0x2b, 0x00, 0xd5, 0x60, 0x07,
-	0x69, 0xf0, 0x15, 0xaa, 0xe1, 0x01, 0x9b, 0x47, 0xb7, 0x61, 0x4d, 0xa5, 0xe6, 0x16, 0xaa, 0xf2,
-	0x92, 0x3c, 0x26, 0xe6, 0x66, 0xfc, 0x0c, 0x56, 0x3d, 0x69, 0xa7, 0x2e, 0xb7, 0x0f, 0xaf, 0x2a,
-	0x2f, 0x70, 0x9c, 0x4e, 0xd8, 0xd0, 0xaa, 0x7b, 0x49, 0x9b, 0xbe, 0x0b, 0x75, 0x0a, 0x25, 0x38,
-	0xe8, 0x06, 0xb8, 0xef, 0x05, 0x03, 0xda, 0x2e, 0xd0, 0x6d, 0x56, 0xf8, 0xac, 0xc5, 0x27, 0x79,
-	0x81, 0x24, 0xba, 0xe0, 0x5f, 0x69, 0xb0, 0x12, 0x19, 0x9c, 0x2e, 0xa7, 0x3c, 0xa9, 0xa5, 0x3c,
-	0x89, 0xae, 0x42, 0x8d, 0x57, 0x80, 0x5d, 0x56, 0x60, 0xf3, 0x10, 0x05, 0x3e, 0xf5, 0x25, 0x2d,
-	0xb3, 0x73, 0x8e, 0x50, 0x3c, 0xf3, 0x11, 0xcc, 0x3f, 0x6a, 0x4a, 0x00, 0x30, 0x75, 0xa9, 0x87,
-	0x89, 0xef, 0x8a, 0x84, 0x36, 0x2c, 0xfe, 0x81, 0x3e, 0x84, 0x8a, 0x3c, 0x24, 0x4f, 0x42, 0xc4,
-	0xc4, 0x27, 0x78, 0x2d, 0x49, 0x42, 0xbd, 0x1f, 0x7a, 0x93, 0x1e, 0x09, 0xbd, 0x29, 0x16, 0xb5,
-	0x53, 0x3c, 0x81, 0x6e, 0x43, 0x99, 0x5b, 0x48, 0xb4, 0xa5, 0x79, 0xa2, 0x04, 0x05, 0xa5, 0x1d,
-	0x7a, 0x1e, 0x0d, 0x93, 0xd2, 0x7c, 0x5a, 0x4e, 0x61, 0x3a, 0xb0, 0xba, 0xef, 0xf9, 0x27, 0x6a,
-	0x34, 0x5f, 0x82, 0x22, 0x09, 0xfa, 0xd9, 0x60, 0xa6, 0xb3, 0x74, 0x71, 0x40, 0x64, 0xfb, 0xad,
-	0x2e, 0x0e, 0x48, 0x48, 0x8f, 0x10, 0xd9, 0x4a, 0x1e, 0x21, 0x9a, 0x50, 0xca, 0xdd, 0xb3, 0xe7,
-	0x8e, 0xf9, 0x73, 0x5e, 0xee, 0x9e, 0x23, 0xdb, 0x10, 0xe8, 0xc3, 0x99, 0xeb, 0x0a, 0x24, 0x66,
-	0x63, 0xd4, 0x84, 0xca, 0xd8, 0x21, 0xa1, 0x17, 0x9c, 0x88, 0xbc, 0x97, 0x9f, 0xe6, 0x36, 0xac,
-	0xfe, 0x9f, 0xed, 0xbe, 0x3a, 0x87, 0x46, 0x2f, 0x61, 0xf5, 0x89, 0xeb, 0xf5, 0x54, 0x8e, 0x33,
-	0x15, 0x1d, 0x4d, 0xa8, 0xf8, 0x76, 0x18, 0xe2, 0x40, 0x56, 0x5b, 0xf2, 0x93, 0xf6, 0x59, 0xb2,
-	0x37, 0x25, 0x51, 0xf7, 0x99, 0x29, 0xd9, 0x25, 0x09, 0xef, 0x3e, 0xd9, 0x45, 0x7e, 0x0c, 0xab,
-	0x07, 0xce, 0x70, 0xa8, 0xaa, 0x72, 0x13, 0x8c, 0x29, 0x3e, 0xee, 0xe6, 0x1f, 0xa0, 0x32, 0xc5,
-	0xc7, 0xec, 0xa5, 0xef, 0x26, 0x18, 0x9e, 0x3b, 0xe0, 0x54, 0x19, 0x57, 0x56, 0x3c, 0x77, 0xc0,
-	0xa8, 0x9a, 0x50, 0x21, 0x63, 0xdb, 0x75, 0xbd, 0x63, 0xe1, 0x4c, 0xf9, 0x69, 0x7e, 0x03, 0x8d,
-	0x78, 0xe3, 0xb8, 0xd7, 0x90, 0x3b, 0x93, 0x39, 0x8a, 0x8b, 0xed, 0xd9, 0x21, 0xe5, 0xfe, 0x32,
-	0x37, 0xd2, 0xb4, 0x42, 0x09, 0x42, 0xaf, 0x72, 0x7e, 0x89, 0x9e, 0xc3, 0x47, 0x63, 0x68, 0xbc,
-	0x9c, 0x85, 0xa2, 0x64, 0x14, 0x2c, 0x11, 0x0a, 0x6b, 0x2a, 0x0a, 0xbf, 0x03, 0x7a, 0x68, 0x8f,
-	0xa4, 0x12, 0x06, 0x13, 0x74, 0x64, 0x8f, 0x2c, 0x36, 0x1b, 0x37, 0xaf, 0xc5, 0x39, 0xcd, 0xab,
-	0xf9, 0x6b, 0x0d, 0xd6, 0x9e, 0x60, 0xb1, 0x15, 0x51, 0xae, 0x69, 0xd9, 0xc7, 0x6b, 0xa7, 0xf4,
-	0xf1, 0x79, 0x97, 0x96, 0xbe, 0xe8, 0xd2, 0x4a, 0xd4, 0xca, 0x97, 0x01, 0x42, 0x2f, 0xb4, 0xdd,
-	0x2e, 0x9d, 0x12, 0x75, 0x62, 0x95, 0xcd, 0x74, 0x9c, 0xef, 0x31, 0xed, 0xbb, 0x1a, 0x4f, 0x70,
-	0xc8, 0x34, 0x8e, 0x94, 0x4b, 0xbc, 0x1e, 0x68, 0x0b, 0x5e, 0x0f, 0x7e, 0x72, 0x15, 0xff, 0x17,
-	0x1a, 0x47, 0xf6, 0x28, 0xe9, 0xaa, 0x33, 0x75, 0xf7, 0xa7, 0x7a, 0xce, 0x5c, 0x07, 0x44, 0x71,
-	0x23, 0xe9, 0x17, 0x9a, 0xbb, 0x74, 0xf6, 0xc8, 0x1e, 0x45, 0xd6, 0xd8, 0x80, 0xb2, 0x1f, 0xe0,
-	0xa1, 0xf3, 0x9d, 0x78, 0x7b, 0x16, 0x5f, 0xf4, 0xa2, 0x72, 0xa6, 0x7d, 0x77, 0x36, 0xc0, 0x5d,
-	0xa1, 0x0b, 0x07, 0x94, 0x15, 0x31, 0xcb, 0x25, 0x9b, 0x1d, 0xde, 0x75, 0x73, 0x89, 0x22, 0x13,
-	0x5a, 0x50, 0x0c, 0xed, 0x91, 0xd0, 0x3d, 0x56, 0x8c, 0x4e, 0x2a, 0x47, 0x2b, 0xcc, 0x3d, 0x9a,
-	0xf9, 0x39, 0xac, 0xf3, 0x90, 0xff, 0x51, 0x61, 0x65, 0x5e, 0x84, 0xb7, 0x52, 0xec, 0x5c, 0x31,
-	0xf3, 0x63, 0x99, 0x4a, 0xaa, 0x01, 0xa4, 0x1d, 0xb5, 0x79, 0x76, 0x54, 0x59, 0x84, 0xa0, 0xfb,
-	0x80, 0xf6, 0xc7, 0xb8, 0xff, 0xea, 0xfc, 0x6e, 0x33, 0x3f, 0x82, 0x0b, 0x09, 0x56, 0x61, 0xb3,
-	0x0d, 0x28, 0xe3, 0xef, 0x1c, 0x12, 0x12, 0x71, 0x85, 0x8a, 0x2f, 0x73, 0x1b, 0x2a, 0xe2, 0x14,
-	0x67, 0x3d, 0xfd, 0x2f, 0x0a, 0x50, 0x93, 0x2f, 0x45, 0xb4, 0xe2, 0xb8, 0x9b, 0x66, 0xbb, 0xac,
-	0xb0, 0x31, 0x12, 0x31, 0x26, 0x87, 0xd3, 0x30, 0x38, 0x89, 0xb3, 0x73, 0x33, 0x11, 0x60, 0xad,
-	0x0c, 0x17, 0xb5, 0x08, 0x67, 0x61, 0x74, 0xad, 0x36, 0x2c, 0xab, 0x82, 0x68, 0x81, 0xf7, 0x0a,
-	0x9f, 0x88, 0xb0, 0xa2, 0x43, 0x74, 0x43, 0x42, 0x50, 0xee, 0x63, 0x14, 0x5f, 0x7b, 0x50, 0xb8,
-	0xa7, 0xb5, 0x0e, 0xa0, 0x1a, 0x49, 0xcf, 0x91, 0x73, 0x3d, 0x29, 0x27, 0xd9, 0x63, 0x47, 0x52,
-	0x6e, 0x7f, 0xc0, 0xdf, 0x3c, 0xd9, 0x43, 0xe5, 0x32, 0x18, 0xd6, 0x61, 0xe7, 0xd0, 0xfa, 0xea,
-	0xf0, 0xa0, 0xb1, 0x84, 0x0c, 0xd0, 0x1f, 0xb7, 0x9f, 0x1d, 0x36, 0x34, 0x54, 0x81, 0xe2, 0x41,
-	0xdb, 0x6a, 0x14, 0x6e, 0xdf, 0x91, 0x5d, 0x25, 0xab, 0xc3, 0x51, 0x0d, 0x2a, 0x9d, 0xa3, 0x47,
-	0xd6, 0x11, 0x23, 0xaf, 0x42, 0xc9, 0x3a, 0x7c, 0x74, 0xf0, 0xff, 0x0d, 0x8d, 0xca, 0x79, 0xdc,
-	0x7e, 0xde, 0xee, 0x7c, 0x79, 0x78, 0xd0, 0x28, 0xdc, 0x7e, 0x08, 0xd5, 0xa8, 0xfa, 0xa4, 0x42,
-	0x9f, 0xbf, 0x78, 0x7e, 0xc8, 0xc5, 0x3f, 0xed, 0xbc, 0x78, 0xde, 0xd0, 0xe8, 0xe8, 0x59, 0xfb,
-	0xf9, 0x61, 0xa3, 0x40, 0x37, 0xea, 0xfc, 0xcf, 0xb3, 0x46, 0x91, 0x0e, 0xf6, 0x3b, 0x5f, 0x35,
-	0xf4, 0x9d, 0xbf, 0xaf, 0x40, 0xf1, 0xd1, 0xcb, 0x36, 0xfa, 0x02, 0x20, 0x7e, 0x7a, 0x43, 0x1b,
-	0xfc, 0xee, 0x4c, 0xbf, 0xc5, 0xb5, 0x36, 0x32, 0x6f, 0x96, 0x87, 0x13, 0x3f, 0x3c, 0x31, 0x97,
-	0xd0, 0x5d, 0xa8, 0x29, 0xcf, 0x68, 0xe8, 0x22, 0x13, 0x90, 0x7d, 0x58, 0x6b, 0x25, 0x5f, 0xbe,
-	0xcc, 0x25, 0x74, 0x1f, 0x0c, 0xf9, 0x62, 0x86, 0xd6, 0xd9, 0x62, 0xea, 0x65, 0xad, 0xf5, 0x56,
-	0x6a, 0x56, 0x84, 0xff, 0x12, 0xd5, 0x39, 0x7e, 0x2c, 0x13, 0x3a, 0x67, 0x5e, 0xcf, 0x4e, 0xd1,
-	0xf9, 0x53, 0xa8, 0x29, 0xef, 0x61, 0x42, 0xe7, 0xec, 0x0b, 0x59, 0x4b, 0xad, 0x24, 0xcc, 0x25,
-	0xb4, 0x07, 0xcb, 0xea, 0x8b, 0x0f, 0x6a, 0x8a, 0x8b, 0x2f, 0xf3, 0x08, 0x74, 0xca, 0xd6, 0x9f,
-	0xc3, 0x4a, 0xe2, 0xe5, 0x04, 0xbd, 0xad, 0x1a, 0x2c, 0x29, 0x25, 0xfd, 0x8c, 0x60, 0x2e, 0xa1,
-	0x7b, 0x00, 0xf1, 0x3b, 0x88, 0x38, 0x79, 0xe6, 0x61, 0xa4, 0xd5, 0x48, 0x31, 0x12, 0x73, 0x09,
-	0xed, 0x72, 0xa8, 0x94, 0x51, 0x16, 0x60, 0x7b, 0x32, 0x97, 0x3f, 0xbb, 0xf1, 0xb6, 0x46, 0x4f,
-	0xaf, 0xb6, 0xd3, 0xe2, 0xf4, 0x39, 0x1d, 0xf6, 0x29, 0xa7, 0x7f, 0x08, 0x35, 0xa5, 0xad, 0x16,
-	0x86, 0xcf, 0x36, 0xda, 0xf9, 0x0a, 0xec, 0xc3, 0x6a, 0xaa, 0x5f, 0x46, 0x97, 0xb8, 0xe7, 0x72,
-	0xbb, 0xe8, 0x7c, 0x21, 0x9f, 0x42, 0x4d, 0x79, 0x67, 0x14, 0x1a, 0x64, 0x5f, 0x1e, 0x73, 0x5c,
-	0xaf, 0xbe, 0xd9, 0x88, 0xc3, 0xe7, 0x3c, 0xe3, 0x9c, 0xc9, 0xf5, 0x42, 0x48, 0xc2, 0xf5, 0x49,
-	0x29, 0xe9, 0x7f, 0x31, 0x8e, 0x5d, 0x2f, 0x78, 0x63, 0xd7, 0x25, 0x19, 0x1b, 0x29, 0x46, 0xc2,
-	0x95, 0x57, 0x9f, 0x56, 0x12, 0x9e, 0x3b, 0xab, 0xf2, 0x0f, 0xa0, 0x22, 0x5a, 0x18, 0x74, 0x21,
-	0xd9, 0xd0, 0x2c, 0xe0, 0xbc, 0xa5, 0xa1, 0x07, 0x60, 0xc8, 0x2e, 0x47, 0x64, 0x7a, 0xaa, 0xe9,
-	0x39, 0x65, 0xdf, 0x5d, 0xa8, 0x88, 0xa7, 0x08, 0xb1, 0x6f, 0xf2, 0x61, 0xa2, 0x75, 0x29, 0xc3,
-	0xc9, 0xea, 0x9e, 0xaf, 0x28, 0x0c, 0x33, 0x87, 0xc7, 0xf8, 0xc4, 0x84, 0x24, 0xf0, 0x49, 0x15,
-	0x94, 0xac, 0x80, 0xcd, 0x25, 0xb4, 0xc3, 0xf1, 0x49, 0xd1, 0x3a, 0xd5, 0x0a, 0xb5, 0xea, 0x09,
-	0x16, 0xc2, 0x30, 0xad, 0x2e, 0x89, 0x44, 0x8a, 0xe5, 0x73, 0xa6, 0x37, 0xdb, 0xd6, 0xd0, 0x1d,
-	0x30, 0x64, 0x2b, 0x24, 0x98, 0x52, 0x9d, 0x51, 0x1e, 0xd3, 0x0e, 0x18, 0xb2, 0x1b, 0x12, 0x4c,
-	0xa9, 0xe6, 0x28, 0x5f, 0x47, 0x49, 0x94, 0xd0, 0x31, 0xcd, 0x99, 0xb3, 0xdd, 0x7d, 0x30, 0x64,
-	0xe3, 0x21, 0x98, 0x52, 0x0d, 0x90, 0x80, 0xec, 0x74, 0x77, 0xa2, 0x42, 0x36, 0x63, 0x56, 0x21,
-	0xfb, 0x6c, 0x71, 0xf0, 0x39, 0xbb, 0xeb, 0x70, 0x88, 0x1f, 0xb9, 0x2e, 0x9a, 0x43, 0x36, 0x9f,
-	0x7d, 0xe7, 0xcf, 0x15, 0xa8, 0xf2, 0x2b, 0x9a, 0xde, 0x79, 0x77, 0xa0, 0x1a, 0x35, 0x28, 0xe8,
-	0x2d, 0x19, 0xce, 0x89, 0x72, 0xaa, 0xa5, 0x5e, 0xeb, 0x2c, 0x8a, 0xef, 0xb3, 0x77, 0x07, 0x3e,
-	0xd1, 0x61, 0x2f, 0x0c, 0x73, 0x38, 0x97, 0x15, 0x4e, 0xc2, 0x58, 0x77, 0x01, 0x22, 0x2a, 0x32,
-	0x8f, 0xed, 0xb4, 0x0c, 0xba, 0x0f, 0xd5, 0xa8, 0xcd, 0x41, 0xaa, 0x66, 0x8b, 0xe3, 0xff, 0x10,
-	0x20, 0xee, 0x90, 0x84, 0xe1, 0x33, 0x2d, 0xd3, 0x62, 0x31, 0xfb, 0x4c, 0x03, 0xde, 0xca, 0x88,
-	0x13, 0xa4, 0x5b, 0x9b, 0xc5, 0x42, 0x3e, 0x63, 0x85, 0x55, 0xc2, 0xee, 0xe9, 0xee, 0xe3, 0x94,
-	0x10, 0xd8, 0x8a, 0xf0, 0x33, 0xcf, 0x10, 0xab, 0x89, 0x0a, 0x91, 0x65, 0xf0, 0x1e, 0xd4, 0x94,
-	0x62, 0x57, 0xa4, 0x7e, 0xb6, 0x72, 0x6e, 0x35, 0xb3, 0x0b, 0x51, 0xdc, 0xde, 0x85, 0x9a, 0xd2,
-	0xc9, 0x08, 0x19, 0xd9, 0xde, 0x26, 0x15, 0x2e, 0xdb, 0x1a, 0xfa, 0x12, 0x56, 0x12, 0x6d, 0x80,
-	0x40, 0xfb, 0xbc, 0xce, 0xa2, 0xd5, 0xca, 0x5b, 0x8a, 0x54, 0xb8, 0x03, 0xe5, 0x27, 0x98, 0xf6,
-	0x38, 0x28, 0x6a, 0x0f, 0x16, 0x9b, 0xfa, 0x7d, 0x00, 0x61, 0xac, 0x24, 0x63, 0x8e, 0x99, 0x1e,
-	0x72, 0xa0, 0xa3, 0x25, 0xaf, 0x02, 0x57, 0x4a, 0x93, 0xa2, 0x14, 0x62, 0x89, 0x3e, 0x84, 0xee,
-	0xb3, 0x2b, 0xf3, 0x9a, 0xb1, 0xab, 0x79, 0xad, 0x0a, 0xb8, 0x98, 0x99, 0x8f, 0x4e, 0xf7, 0x10,
-	0x2a, 0xfb, 0xde, 0xc4, 0xb7, 0xfb, 0xe1, 0xf9, 0xd3, 0x7a, 0x6f, 0xf7, 0x0f, 0x6f, 0xae, 0x68,
-	0x7f, 0x7a, 0x73, 0x45, 0xfb, 0xeb, 0x9b, 0x2b, 0xda, 0x0f, 0x7f, 0xbb, 0xb2, 0xf4, 0xf5, 0x47,
-	0x23, 0x27, 0x1c, 0xcf, 0x7a, 0x9b, 0x7d, 0x6f, 0xb2, 0xe5, 0xdb, 0xfd, 0xf1, 0xc9, 0x00, 0x07,
-	0xea, 0x88, 0x04, 0xfd, 0xad, 0xf8, 0xbf, 0xeb, 0xf5, 0xca, 0x4c, 0xe4, 0x9d, 0xff, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x85, 0x32, 0x8a, 0x32, 0xc3, 0x27, 0x00, 0x00,
+func init() { proto.RegisterFile("client/pfs/pfs.proto", fileDescriptor_pfs_daa13a627571e170) }
+
+var fileDescriptor_pfs_daa13a627571e170 = []byte{
+	// 4074 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5b, 0xcd, 0x6f, 0x1b, 0x49,
+	0x76, 0x57, 0xf3, 0xb3, 0xf9, 0x28, 0x51, 0x54, 0x49, 0xd6, 0xd0, 0xd4, 0x8c, 0x2d, 0x97, 0x67,
+	0x66, 0xbd, 0x9e, 0x59, 0x59, 0x23, 0xef, 0xee, 0x8c, 0xc7, 0x5f, 0xb0, 0x3e, 0x2c, 0x6b, 0xe0,
+	0xb5, 0x9d, 0xa6, 0x76, 0x82, 0x4c, 0x90, 0x10, 0x4d, 0xb2, 0x48, 0xf6, 0xba, 0xc9, 0xe6, 0x74,
+	0x35, 0x2d, 0x6b, 0x81, 0x9c, 0x93, 0x1c, 0x72, 0xc9, 0x69, 0x82, 0x5c, 0x02, 0x04, 0xc8, 0x75,
+	0x03, 0xe4, 0x1f, 0xc8, 0x31, 0xc8, 0x29, 0x7f, 0x41, 0x90, 0x38, 0xd7, 0x20, 0x40, 0x90, 0x7b,
+	0xb0, 0xa8, 0xaf, 0xee, 0xea, 0x0f, 0x8a, 0x94, 0xb1, 0x7b, 0x98, 0x51, 0xf5, 0xab, 0xf7, 0x5e,
+	0x55, 0xbd, 0x7a, 0xf5, 0xde, 0xab, 0x5f, 0xd1, 0xb0, 0xd1, 0x75, 0x1d, 0x32, 0x0e, 0xee, 0x4c,
+	0xfa, 0x94, 0xfd, 0xb7, 0x33, 0xf1, 0xbd, 0xc0, 0x43, 0xf9, 0x49, 0x9f, 0x36, 0xb7, 0x06, 0x9e,
+	0x37, 0x70, 0xc9, 0x1d, 0x4e, 0xea, 0x4c, 0xfb, 0x77, 0xc8, 0x68, 0x12, 0x9c, 0x0b, 0x8e, 0xe6,
+	0xf5, 0x64, 0x67, 0xe0, 0x8c, 0x08, 0x0d, 0xec, 0xd1, 0x44, 0x32, 0x5c, 0x4b, 0x32, 0x9c, 0xf9,
+	0xf6, 0x64, 0x42, 0x7c, 0x39, 0x44, 0x73, 0x63, 0xe0, 0x0d, 0x3c, 0xde, 0xbc, 0xc3, 0x5a, 0x92,
+	0xba, 0x29, 0xa7, 0x63, 0x4f, 0x83, 0x21, 0xff, 0x9f, 0xa0, 0xe3, 0x26, 0x14, 0x2c, 0x32, 0xf1,
+	0x10, 0x82, 0xc2, 0xd8, 0x1e, 0x91, 0x86, 0xb1, 0x6d, 0xdc, 0xaa, 0x58, 0xbc, 0x8d, 0xef, 0x43,
+	0x69, 0xdf, 0xb7, 0xc7, 0xdd, 0x21, 0xfa, 0x08, 0x0a, 0x3e, 0x99, 0x78, 0xbc, 0xb7, 0xba, 0x57,
+	0xd9, 0x61, 0x0b, 0x62, 0x62, 0x56, 0xc1, 0xd7, 0x85, 0x73, 0x9a, 0xf0, 0x3f, 0xe4, 0x00, 0x84,
+	0xf4, 0xc9, 0xb8, 0x9f, 0xa9, 0x1f, 0x5d, 0x87, 0xc2, 0x90, 0xd8, 0x3d, 0x2e, 0x56, 0xdd, 0xab,
+	0x72, 0xad, 0x07, 0xde, 0x68, 0xe4, 0x04, 0x16, 0xef, 0x40, 0x9f, 0x01, 0x4c, 0x7c, 0xef, 0x0d,
+	0x19, 0xdb, 0xe3, 0x2e, 0x69, 0xe4, 0xb7, 0xf3, 0x21, 0x9b, 0xd0, 0x6c, 0x69, 0xdd, 0xe8, 0x26,
+	0x94, 0x3a, 0x9c, 0xda, 0x28, 0x6c, 0x1b, 0x49, 0x46, 0xd9, 0xc5, 0x34, 0xd2, 0x69, 0x47, 0x69,
+	0x2c, 0x66, 0x68, 0x8c, 0xba, 0xd1, 0x57, 0xb0, 0xd6, 0x73, 0x7c, 0xd2, 0x0d, 0xda, 0xda, 0x2c,
+	0x4a, 0x69, 0x99, 0xba, 0xe0, 0x7a, 0x15, 0xcd, 0xe5, 0x36, 0xac, 0x8d, 0xec, 0xb7, 0xed, 0xa1,
+	0x43, 0x03, 0xcf, 0x3f, 0x6f, 0xf7, 0xc8, 0x24, 0x18, 0x36, 0xca, 0xdb, 0xc6, 0xad, 0xbc, 0xb5,
+	0x3a, 0xb2, 0xdf, 0x3e, 0x13, 0xf4, 0x43, 0x46, 0xc6, 0x8f, 0xa1, 0x1a, 0xd9, 0x89, 0xa2, 0x5d,
+	0xa8, 0x8a, 0xb9, 0xb6, 0x9d, 0x71, 0x9f, 0x59, 0x9c, 0x0d, 0xb7, 0xaa, 0x0d, 0xc7, 0xd8, 0x2c,
+	0xe8, 0x84, 0x6d, 0xfc, 0x18, 0x0a, 0x4f, 0x1d, 0x97, 0x1b, 0xa0, 0xcb, 0xad, 0x27, 0xb7, 0x29,
+	0x66, 0x50, 0xd9, 0xc5, 0xf6, 0x61, 0x62, 0x07, 0x43, 0xb5, 0x55, 0xac, 0x8d, 0xb7, 0xa0, 0xb8,
+	0xef, 0x7a, 0xdd, 0xd7, 0xac, 0x73, 0x68, 0xd3, 0xa1, 0xda, 0x24, 0xd6, 0xc6, 0x1f, 0x42, 0xe9,
+	0x65, 0xe7, 0x57, 0xa4, 0x1b, 0x64, 0xf6, 0x5e, 0x85, 0xfc, 0xa9, 0x3d, 0xc8, 0xf4, 0x9e, 0xff,
+	0xcf, 0x81, 0xc9, 0x7c, 0x84, 0x6f, 0xff, 0x1c, 0x07, 0xfa, 0x29, 0x94, 0xbb, 0x3e, 0xb1, 0x03,
+	0xa2, 0x9c, 0xa1, 0xb9, 0x23, 0xbc, 0x7c, 0x47, 0x79, 0xf9, 0xce, 0xa9, 0x3a, 0x06, 0x96, 0x62,
+	0x45, 0x1f, 0x01, 0x50, 0xe7, 0xd7, 0xa4, 0xdd, 0x39, 0x0f, 0x08, 0x6d, 0xe4, 0xb7, 0x8d, 0x5b,
+	0x05, 0xab, 0xc2, 0x28, 0xfb, 0x8c, 0x80, 0xb6, 0xa1, 0xda, 0x23, 0xb4, 0xeb, 0x3b, 0x93, 0xc0,
+	0xf1, 0xc6, 0x8d, 0x22, 0x9f, 0x9b, 0x4e, 0x42, 0x3b, 0x50, 0x61, 0x47, 0x41, 0x58, 0xba, 0xc4,
+	0x07, 0x5e, 0x0b, 0xa7, 0xf6, 0x64, 0x1a, 0x08, 0x5b, 0x9b, 0xb6, 0x6c, 0xa1, 0x1f, 0x81, 0x29,
+	0xec, 0x4e, 0x68, 0xa3, 0x9c, 0xf6, 0x83, 0xb0, 0x13, 0xfd, 0x18, 0xea, 0x3e, 0x61, 0x16, 0x27,
+	0xe3, 0x1e, 0xe9, 0xb5, 0x07, 0xae, 0xd7, 0x69, 0x98, 0x7c, 0xfc, 0x55, 0x8d, 0x7e, 0xec, 0x7a,
+	0x1d, 0x74, 0x0b, 0xea, 0xcc, 0x55, 0xc4, 0xf6, 0xb4, 0xfb, 0x8e, 0x4b, 0x68, 0xa3, 0xc2, 0x3d,
+	0xa5, 0x36, 0xb2, 0xdf, 0x8a, 0xdd, 0x63, 0xdb, 0x4b, 0x13, 0x9c, 0x62, 0xd1, 0x90, 0xe0, 0xe4,
+	0x2b, 0xff, 0xa6, 0x60, 0x16, 0xea, 0x45, 0xfc, 0x08, 0x96, 0xf5, 0x75, 0xa0, 0x1d, 0x58, 0xb6,
+	0xbb, 0x5d, 0x42, 0x69, 0xdb, 0x25, 0x6f, 0x88, 0xcb, 0xf7, 0xa2, 0xb6, 0x57, 0xdd, 0xe1, 0xd1,
+	0xa0, 0xd5, 0xf5, 0x26, 0xc4, 0xaa, 0x0a, 0x86, 0xe7, 0xac, 0x1f, 0x3f, 0x86, 0x92, 0x50, 0x3a,
+	0x6f, 0xf7, 0x36, 0x21, 0xe7, 0x88, 0x8d, 0xab, 0xec, 0x97, 0xde, 0xfd, 0xfb, 0xf5, 0xdc, 0xc9,
+	0xa1, 0x95, 0x73, 0x7a, 0xb8, 0x05, 0x55, 0xe9, 0x7d, 0xf6, 0x78, 0x40, 0xd0, 0x0d, 0x28, 0xba,
+	0xde, 0x19, 0xf1, 0xb3, 0xdc, 0x53, 0xf4, 0x30, 0x96, 0x29, 0x8b, 0x65, 0x59, 0x21, 0x41, 0xf4,
+	0xe0, 0xff, 0x2c, 0x02, 0x08, 0x0a, 0x5f, 0xd4, 0x42, 0x4e, 0xbf, 0x0b, 0x2b, 0x13, 0xdb, 0x27,
+	0xe3, 0x40, 0x1a, 0x2f, 0x4b, 0xfd, 0xb2, 0xe0, 0x90, 0x2b, 0xfe, 0x29, 0x94, 0x69, 0x60, 0xfb,
+	0xcc, 0x21, 0xf3, 0xf3, 0x1d, 0x52, 0xb2, 0xa2, 0x9f, 0x83, 0xd9, 0x77, 0xc6, 0x0e, 0x1d, 0x92,
+	0x5e, 0xa3, 0x30, 0x57, 0x2c, 0xe4, 0x4d, 0x38, 0x72, 0x31, 0xe9, 0xc8, 0xf1, 0x30, 0xa8, 0x07,
+	0x20, 0x39, 0x77, 0xad, 0x9b, 0x05, 0xd5, 0xc0, 0x27, 0x84, 0x47, 0x1b, 0xc5, 0x26, 0x0e, 0xb0,
+	0xc5, 0x3b, 0x92, 0xc7, 0xc2, 0x4c, 0x1f, 0x8b, 0xdd, 0x58, 0x90, 0xac, 0xf0, 0xf1, 0xea, 0xfa,
+	0x78, 0x6c, 0x3b, 0x93, 0x91, 0x52, 0x06, 0x2d, 0x6d, 0xa2, 0x90, 0x11, 0x29, 0x05, 0x97, 0x16,
+	0x29, 0x77, 0x61, 0xa5, 0x3b, 0x74, 0xdc, 0x9e, 0xdc, 0x19, 0xda, 0xa8, 0xa6, 0x97, 0xb7, 0xcc,
+	0x39, 0xc4, 0x87, 0x3c, 0x5b, 0x76, 0xef, 0x5c, 0x1f, 0x6a, 0x59, 0x84, 0x56, 0x4e, 0xd7, 0x94,
+	0xdf, 0x80, 0x22, 0x5b, 0x32, 0x6d, 0xac, 0x6c, 0xe7, 0x93, 0xc6, 0x10, 0x3d, 0xcc, 0x7f, 0x7a,
+	0x76, 0x30, 0x1d, 0xd1, 0x46, 0x2d, 0x6d, 0x30, 0xd9, 0x85, 0xee, 0x42, 0xc9, 0xb5, 0x3b, 0xc4,
+	0xa5, 0x8d, 0x55, 0xae, 0x68, 0x4b, 0x9b, 0x1d, 0xf3, 0xc2, 0x9d, 0xe7, 0xbc, 0xf7, 0x68, 0x1c,
+	0xf8, 0xe7, 0x96, 0x64, 0x6d, 0xde, 0x83, 0xaa, 0x46, 0x46, 0x75, 0xc8, 0xbf, 0x26, 0xe7, 0x32,
+	0x42, 0xb2, 0x26, 0xda, 0x80, 0xe2, 0x1b, 0xdb, 0x9d, 0xaa, 0xb4, 0x29, 0x3e, 0xbe, 0xce, 0x7d,
+	0x65, 0xe0, 0xff, 0xce, 0x81, 0xc9, 0xce, 0xbc, 0x0a, 0x9d, 0x2c, 0x2a, 0xc4, 0x0e, 0x1f, 0xeb,
+	0xb4, 0x38, 0x19, 0xdd, 0x86, 0x0a, 0xfb, 0xdb, 0x0e, 0xce, 0x27, 0x42, 0x53, 0x6d, 0x6f, 0x25,
+	0xe4, 0x39, 0x3d, 0x9f, 0x10, 0xe6, 0x67, 0xa2, 0x35, 0x2f, 0x60, 0x36, 0xc1, 0xe4, 0x96, 0xf6,
+	0xc9, 0x98, 0x7b, 0x59, 0xc5, 0x0a, 0xbf, 0xc3, 0xe0, 0xcf, 0xdc, 0x6a, 0x59, 0x04, 0x7f, 0xf4,
+	0x09, 0x94, 0x3d, 0x6e, 0x28, 0xda, 0x30, 0xd3, 0x06, 0x56, 0x7d, 0xe8, 0x33, 0xa8, 0x74, 0x58,
+	0x7a, 0xb1, 0x48, 0x9f, 0x4a, 0x6f, 0x12, 0x33, 0xdc, 0x97, 0x54, 0x2b, 0xea, 0x47, 0x5f, 0x41,
+	0x45, 0x78, 0x02, 0x3b, 0x7a, 0x30, 0xf7, 0x0c, 0x45, 0xcc, 0x6c, 0x86, 0x23, 0xaf, 0x47, 0x1a,
+	0xd5, 0x6d, 0xe3, 0xd6, 0x8a, 0xc5, 0xdb, 0xe8, 0x63, 0x28, 0x7a, 0x67, 0x63, 0xe2, 0x73, 0x07,
+	0xa9, 0xee, 0xd5, 0x42, 0xc3, 0xbc, 0x64, 0x54, 0x4b, 0x74, 0xe2, 0x3b, 0x50, 0x09, 0x69, 0x6c,
+	0x9f, 0xa6, 0x4e, 0x8f, 0x5b, 0x7b, 0xc5, 0x62, 0x4d, 0x46, 0x19, 0xc8, 0xf8, 0xb6, 0x62, 0xb1,
+	0x26, 0xfe, 0x12, 0x2a, 0xcc, 0x62, 0x22, 0xac, 0x6d, 0xe8, 0x61, 0xad, 0xa0, 0x22, 0xd9, 0x86,
+	0x1e, 0xc9, 0x0a, 0x2a, 0x78, 0x59, 0x60, 0xaa, 0x45, 0xa3, 0x6d, 0x28, 0xf2, 0x65, 0xcb, 0x8d,
+	0x05, 0xcd, 0x24, 0xa2, 0x83, 0xcd, 0xde, 0x67, 0x43, 0x34, 0x72, 0xda, 0xec, 0xc3, 0x81, 0x2d,
+	0xd1, 0x89, 0xff, 0x04, 0x40, 0x58, 0x5c, 0xc5, 0x43, 0x61, 0xf7, 0x58, 0x3c, 0x54, 0xfe, 0x2c,
+	0xba, 0x98, 0xcf, 0xf0, 0x11, 0xda, 0x3e, 0xe9, 0x4b, 0xe5, 0x89, 0x1d, 0x31, 0xd5, 0x8e, 0xe0,
+	0xff, 0x35, 0x60, 0xed, 0x80, 0x27, 0x5c, 0x1e, 0xf1, 0xc9, 0xf7, 0x53, 0x42, 0xe7, 0x66, 0x84,
+	0x44, 0x8c, 0xc9, 0xa7, 0x63, 0xcc, 0x26, 0x94, 0xa6, 0x93, 0x9e, 0x1d, 0x10, 0x1e, 0x28, 0x4d,
+	0x4b, 0x7e, 0x65, 0x66, 0xce, 0xe2, 0xe2, 0x99, 0xb3, 0xb4, 0x70, 0xe6, 0x2c, 0xcf, 0xc8, 0x9c,
+	0xb9, 0x7a, 0x1e, 0xdf, 0x05, 0x74, 0x32, 0xa6, 0x13, 0x66, 0xb2, 0x85, 0xd7, 0x8c, 0x7f, 0x0e,
+	0xeb, 0x9a, 0x10, 0x55, 0x52, 0xd7, 0xa1, 0xc8, 0xba, 0xa9, 0xac, 0xe4, 0x34, 0x31, 0x41, 0xc7,
+	0x7f, 0x61, 0xc0, 0x5a, 0x6c, 0x34, 0x3a, 0x75, 0xe7, 0x1a, 0xf8, 0x06, 0x14, 0x78, 0xd1, 0xa2,
+	0x6f, 0x9e, 0x2a, 0xb6, 0x2c, 0xde, 0x85, 0xb6, 0xa0, 0x32, 0xf6, 0x82, 0x76, 0xdf, 0x9b, 0x8e,
+	0x45, 0x12, 0x33, 0x2d, 0x73, 0xec, 0x05, 0x4f, 0xd9, 0x37, 0x73, 0x4f, 0xe2, 0xfb, 0x9e, 0xcf,
+	0xad, 0x5f, 0xb1, 0xc4, 0x07, 0xfe, 0x00, 0x56, 0x9f, 0x3b, 0x54, 0x5f, 0xf4, 0x37, 0x05, 0xd3,
+	0xa8, 0xe7, 0xf0, 0x23, 0xa8, 0x47, 0x1d, 0x74, 0xe2, 0x8d, 0x29, 0x0f, 0x3c, 0x6c, 0x2a, 0x7a,
+	0x99, 0x9a, 0x98, 0x87, 0xe9, 0xcb, 0x16, 0xfe, 0x02, 0xea, 0x87, 0x0e, 0x7d, 0xfd, 0x4b, 0x6a,
+	0x0f, 0xc8, 0x82, 0xe6, 0xfc, 0x7b, 0x03, 0x56, 0xd8, 0x67, 0x28, 0x37, 0xcf, 0x24, 0x37, 0x61,
+	0xc5, 0xf5, 0x06, 0x4e, 0xd7, 0x76, 0xe5, 0x0e, 0x8b, 0x93, 0xb7, 0x2c, 0x89, 0x22, 0xc4, 0x7d,
+	0x02, 0xb5, 0xc9, 0xf0, 0x9c, 0x6a, 0x5c, 0x22, 0x0a, 0xae, 0x28, 0xaa, 0x60, 0xfb, 0x11, 0xac,
+	0x92, 0xb7, 0x5d, 0x77, 0x4a, 0x9d, 0x37, 0x2a, 0x5a, 0x16, 0x38, 0x5f, 0x2d, 0x24, 0x73, 0x46,
+	0xfc, 0x10, 0xd6, 0xb4, 0x85, 0x49, 0xcb, 0xdc, 0x8a, 0x6f, 0x39, 0x0a, 0x67, 0x1a, 0xb1, 0xca,
+	0xbd, 0xff, 0x0e, 0xd6, 0x0e, 0x89, 0x4b, 0x2e, 0x75, 0xb6, 0x36, 0xa0, 0xd8, 0xf7, 0xfc, 0xae,
+	0x88, 0x0a, 0xa6, 0x25, 0x3e, 0x58, 0x90, 0xb2, 0x5d, 0x57, 0xee, 0x33, 0x6b, 0xe2, 0x1f, 0x72,
+	0x80, 0x5a, 0xac, 0x30, 0x91, 0x59, 0x54, 0x6a, 0xbf, 0x09, 0x25, 0x51, 0xe9, 0x64, 0x16, 0x4c,
+	0xa2, 0x2b, 0x51, 0x71, 0xe4, 0x2e, 0xae, 0x38, 0x36, 0xc3, 0x8b, 0x97, 0x38, 0xe7, 0xf2, 0x2b,
+	0x19, 0x04, 0x0a, 0xe9, 0x20, 0x70, 0x3f, 0xcc, 0xab, 0xe2, 0x26, 0x76, 0x93, 0x0f, 0x91, 0x9e,
+	0xf4, 0xef, 0x3a, 0xbf, 0x7e, 0x07, 0x9b, 0x51, 0x95, 0x70, 0xec, 0xdb, 0x93, 0xa1, 0x66, 0x9d,
+	0xf9, 0xe5, 0xe4, 0x16, 0x54, 0x58, 0x38, 0x11, 0xb7, 0xba, 0x1c, 0x8f, 0x23, 0xe6, 0xc8, 0x7e,
+	0x2b, 0xae, 0x73, 0xff, 0x6c, 0xc0, 0x7a, 0x42, 0xf9, 0x0b, 0x96, 0x8a, 0x16, 0xd2, 0x1c, 0xdd,
+	0x61, 0x73, 0xb3, 0xef, 0xb0, 0x1b, 0x50, 0x14, 0x43, 0xe7, 0xf9, 0xd0, 0xe2, 0x23, 0xb1, 0x65,
+	0x85, 0x8b, 0xb7, 0xec, 0x43, 0xa8, 0x04, 0xfe, 0x74, 0xdc, 0xe5, 0x37, 0xae, 0x22, 0xf7, 0x99,
+	0x88, 0x80, 0x7f, 0x63, 0x00, 0xda, 0x9f, 0x86, 0x25, 0xd7, 0xef, 0xcf, 0x73, 0x54, 0xad, 0x9a,
+	0x9f, 0x55, 0xab, 0x6e, 0xc6, 0xee, 0xf4, 0x91, 0x6b, 0xd5, 0x20, 0x77, 0x72, 0x28, 0xf3, 0x42,
+	0xee, 0xe4, 0x10, 0xff, 0x5f, 0x0e, 0xd6, 0x9f, 0xf2, 0x6a, 0x3a, 0x35, 0xe5, 0xf9, 0x46, 0x4f,
+	0xf8, 0x69, 0x2e, 0xed, 0xa7, 0x73, 0xe7, 0xc9, 0xc2, 0x29, 0xc3, 0x70, 0x64, 0x32, 0x13, 0x1f,
+	0x51, 0xf9, 0x59, 0x9c, 0x59, 0x7e, 0xc6, 0x2b, 0xb2, 0x52, 0xb2, 0x22, 0x8b, 0xaa, 0xd3, 0xf2,
+	0xec, 0xea, 0xf4, 0x41, 0x78, 0x8a, 0x44, 0x15, 0xf6, 0xb1, 0xac, 0x72, 0x52, 0xe6, 0xf8, 0x5d,
+	0x1f, 0xa3, 0x31, 0x6c, 0xc8, 0xc4, 0xf5, 0x1e, 0x56, 0xff, 0x02, 0xaa, 0xa2, 0x06, 0xa1, 0x81,
+	0x1d, 0x08, 0xe5, 0xb5, 0xd8, 0x2d, 0xa3, 0xc5, 0xe8, 0x16, 0x70, 0x26, 0xde, 0xc6, 0x8f, 0xe0,
+	0x4a, 0x6c, 0xbc, 0x30, 0xc7, 0x7e, 0x02, 0x65, 0x75, 0x7d, 0x30, 0xd2, 0x1e, 0xa7, 0xfa, 0xf0,
+	0x5f, 0x1b, 0x61, 0x8a, 0x56, 0x13, 0xe6, 0xb9, 0x76, 0xc1, 0xa3, 0xa9, 0x67, 0xdc, 0xd5, 0xc4,
+	0x0d, 0xe0, 0xfd, 0x73, 0xee, 0x6f, 0x0c, 0x58, 0x63, 0xb9, 0x35, 0x6e, 0xc2, 0x39, 0x39, 0xe0,
+	0x3a, 0x14, 0xfa, 0xbe, 0x37, 0xca, 0x44, 0xce, 0x58, 0x07, 0xda, 0x82, 0x5c, 0xe0, 0x35, 0xf2,
+	0xe9, 0xee, 0x5c, 0xc0, 0xee, 0xeb, 0xa5, 0xf1, 0x74, 0xd4, 0x21, 0xbe, 0x4c, 0x6a, 0xf2, 0x8b,
+	0x25, 0x47, 0xee, 0x14, 0x6d, 0x4a, 0x5c, 0xd2, 0x0d, 0x3c, 0x5f, 0x9e, 0xb0, 0x15, 0x4e, 0x6d,
+	0x49, 0x22, 0x03, 0xac, 0xa2, 0x85, 0x73, 0xc0, 0x4a, 0x16, 0x56, 0x29, 0xc0, 0x4a, 0xb3, 0x0f,
+	0x74, 0xc3, 0x36, 0x4b, 0xed, 0xeb, 0xa2, 0xa4, 0x94, 0x91, 0x2d, 0x2c, 0x95, 0x04, 0x1e, 0x68,
+	0xcc, 0xc2, 0x03, 0xaf, 0x82, 0x49, 0xdb, 0x5a, 0x80, 0xac, 0x58, 0x65, 0x2a, 0x54, 0x68, 0x91,
+	0x33, 0x7f, 0x21, 0xfa, 0x37, 0x23, 0x46, 0xa6, 0xf1, 0x44, 0x7c, 0x3f, 0xf4, 0xee, 0xf8, 0x2c,
+	0xa3, 0x91, 0x8c, 0x99, 0x23, 0xe1, 0x3d, 0xb1, 0xa9, 0x71, 0xc9, 0x39, 0x15, 0xcf, 0x77, 0x70,
+	0x35, 0x72, 0x84, 0x7d, 0x09, 0x25, 0x5d, 0xea, 0x4c, 0x35, 0xa0, 0x2c, 0x21, 0x47, 0x59, 0x1c,
+	0xa8, 0x4f, 0xfc, 0x2d, 0x5c, 0x6d, 0x91, 0xe0, 0x17, 0x71, 0xe8, 0xf1, 0x32, 0x2b, 0x8a, 0xb2,
+	0x4e, 0x4e, 0xcb, 0x3a, 0xf8, 0x8f, 0x61, 0xad, 0x75, 0x66, 0x4f, 0x2e, 0x6f, 0x21, 0x16, 0x1c,
+	0xbd, 0x60, 0x98, 0x80, 0x7a, 0x24, 0x8f, 0xe8, 0xc1, 0xaf, 0x60, 0x5d, 0x54, 0x47, 0xef, 0xa1,
+	0x3e, 0xb3, 0x4a, 0xc2, 0x5f, 0x2b, 0x8d, 0x97, 0x0f, 0x58, 0x4c, 0xb6, 0xf5, 0xfd, 0xd4, 0x7e,
+	0x9f, 0x14, 0x83, 0x6d, 0x40, 0x4f, 0xdd, 0x69, 0x52, 0x74, 0xb1, 0xb0, 0x85, 0x3e, 0x06, 0x33,
+	0xf0, 0xda, 0xa2, 0xa2, 0xcc, 0x25, 0x2f, 0x11, 0xe5, 0xc0, 0x63, 0x7f, 0x29, 0xfe, 0xc1, 0x80,
+	0xcd, 0xd6, 0xb4, 0xc3, 0x92, 0x56, 0x87, 0x5c, 0x2a, 0x98, 0x6c, 0xc6, 0x8a, 0x8e, 0x28, 0xc9,
+	0xaa, 0x20, 0x93, 0x9f, 0x15, 0x64, 0x3e, 0x85, 0xa2, 0x08, 0xde, 0x85, 0x19, 0xc1, 0x5b, 0x74,
+	0xe3, 0xef, 0xa1, 0x76, 0x4c, 0xf8, 0x55, 0x4c, 0x9b, 0xd1, 0x45, 0x98, 0xc6, 0x0d, 0x58, 0xf6,
+	0xfa, 0x7d, 0x4a, 0x02, 0xad, 0x92, 0xcf, 0x5b, 0x55, 0x41, 0x13, 0x99, 0x31, 0x0d, 0x65, 0xe4,
+	0xb5, 0xc4, 0x89, 0x3f, 0x85, 0xda, 0xcb, 0x37, 0xc4, 0x3f, 0xf3, 0x9d, 0x80, 0x9c, 0x8c, 0x7b,
+	0xe4, 0x2d, 0x73, 0x08, 0x87, 0x35, 0xf8, 0x98, 0x79, 0x4b, 0x7c, 0xe0, 0xff, 0xc9, 0x41, 0xed,
+	0xd5, 0xf4, 0x32, 0x73, 0x0b, 0xd3, 0x61, 0x9e, 0x23, 0x21, 0xe2, 0x83, 0xa3, 0x06, 0xbe, 0x2b,
+	0xe3, 0x25, 0x6b, 0xb2, 0x12, 0xcb, 0x27, 0xdd, 0xa9, 0xcf, 0xee, 0x0a, 0x3c, 0xb1, 0x9b, 0x56,
+	0x44, 0x40, 0x9f, 0x43, 0xa5, 0x47, 0x5c, 0x67, 0xe4, 0x04, 0xc4, 0xe7, 0xb9, 0xbd, 0x26, 0xaf,
+	0xf7, 0x87, 0x8a, 0x6a, 0x45, 0x0c, 0xe8, 0x73, 0x40, 0x81, 0xed, 0x0f, 0x88, 0xb8, 0xe5, 0xb6,
+	0x65, 0x49, 0x60, 0xf2, 0x85, 0xd4, 0x45, 0x0f, 0x9b, 0xe1, 0x21, 0xa7, 0xb3, 0xc7, 0x07, 0x9d,
+	0x5b, 0x58, 0x48, 0x40, 0xca, 0xab, 0x11, 0xb3, 0x30, 0xe3, 0x03, 0x58, 0xf5, 0x94, 0x9d, 0xda,
+	0xc2, 0x3e, 0x02, 0x74, 0x59, 0x17, 0x95, 0x46, 0xcc, 0x86, 0x56, 0xcd, 0x8b, 0xdb, 0xf4, 0x13,
+	0xa8, 0xb1, 0xb8, 0x4c, 0xfc, 0x36, 0xbb, 0x9b, 0xfb, 0x3d, 0xca, 0xc1, 0x97, 0xbc, 0xb5, 0x22,
+	0xa8, 0x96, 0x20, 0x8a, 0x4b, 0xb5, 0x04, 0xa5, 0x07, 0xb0, 0x2e, 0xed, 0x7d, 0x30, 0x9c, 0x8e,
+	0x5f, 0x5f, 0xd6, 0xe8, 0x39, 0xdd, 0xe8, 0x5b, 0x50, 0x99, 0x4e, 0x5c, 0xcf, 0xee, 0xb5, 0x9d,
+	0x9e, 0xbc, 0x7b, 0x98, 0x82, 0x70, 0xd2, 0xc3, 0x3e, 0x54, 0xc2, 0x51, 0x52, 0x0e, 0x65, 0xcc,
+	0x73, 0xa8, 0x5c, 0xc2, 0xa1, 0x34, 0x5c, 0x25, 0x3f, 0x13, 0x57, 0x61, 0x67, 0x70, 0x43, 0x5f,
+	0x1d, 0x5d, 0x70, 0x79, 0x9f, 0x42, 0xa9, 0xcb, 0xf9, 0xe5, 0xf9, 0x8e, 0x70, 0x2a, 0x61, 0x24,
+	0xd9, 0xcb, 0x7c, 0x2a, 0xdc, 0x01, 0x59, 0x5e, 0x44, 0x84, 0xb8, 0x39, 0x0a, 0x09, 0x73, 0xfc,
+	0x95, 0x01, 0x2b, 0xa1, 0xa3, 0xb3, 0x6d, 0x49, 0x2c, 0xd8, 0x48, 0x2e, 0xf8, 0x3a, 0x54, 0xc5,
+	0xaa, 0xda, 0x1c, 0xf7, 0x13, 0xa1, 0x01, 0x04, 0xe9, 0x19, 0x43, 0xff, 0x32, 0x5c, 0x27, 0xbf,
+	0xb0, 0xeb, 0xe0, 0x7f, 0x35, 0xa0, 0x16, 0x9b, 0x0f, 0x65, 0x9b, 0x4c, 0x27, 0xae, 0x0c, 0xa4,
+	0xa6, 0x25, 0x3e, 0xd0, 0xe7, 0x50, 0x56, 0xce, 0x95, 0xd3, 0xae, 0xd3, 0x31, 0x59, 0x4b, 0xb1,
+	0xf0, 0x8b, 0x8d, 0x37, 0xea, 0xd0, 0xc0, 0x1b, 0x87, 0x16, 0x0a, 0x09, 0xe8, 0x36, 0x94, 0x84,
+	0x67, 0x4a, 0x74, 0x3e, 0x4b, 0x95, 0xe4, 0x60, 0xbc, 0x7d, 0xcf, 0x63, 0xc7, 0xb3, 0x38, 0x9b,
+	0x57, 0x70, 0x60, 0x07, 0x56, 0x0f, 0xbc, 0xc9, 0xb9, 0x1e, 0x45, 0xb6, 0x20, 0x4f, 0xfd, 0x6e,
+	0x7a, 0xc3, 0x19, 0x95, 0x75, 0xf6, 0xa8, 0x7a, 0x85, 0xd0, 0x3b, 0x7b, 0x34, 0xb8, 0x78, 0x93,
+	0x35, 0x68, 0x6a, 0xf1, 0x98, 0x85, 0xff, 0x54, 0xe0, 0x3a, 0x8b, 0x4b, 0x30, 0x30, 0xb5, 0x3f,
+	0x75, 0x5d, 0x99, 0x3d, 0x79, 0x5b, 0xaf, 0x2e, 0x44, 0xbc, 0x55, 0x9f, 0x78, 0x17, 0x56, 0xff,
+	0xd0, 0x76, 0x5f, 0x5f, 0x62, 0x46, 0xaf, 0x60, 0x95, 0x61, 0x78, 0xba, 0xc4, 0xa2, 0x15, 0xce,
+	0xc4, 0x0e, 0x02, 0xe2, 0xab, 0x7b, 0x9a, 0xfa, 0x64, 0x98, 0xac, 0x82, 0xcc, 0x69, 0x08, 0x8a,
+	0xa7, 0xb0, 0x29, 0xc5, 0x22, 0x40, 0x71, 0xd6, 0xc2, 0x67, 0xb0, 0x7a, 0xe8, 0xf4, 0xfb, 0xfa,
+	0x54, 0x3e, 0x06, 0x73, 0x4c, 0xce, 0xda, 0xd9, 0x0b, 0x28, 0x8f, 0xc9, 0x19, 0x6b, 0x30, 0x2e,
+	0xcf, 0xed, 0x09, 0xae, 0xd4, 0x56, 0x96, 0x3d, 0xb7, 0xc7, 0xb9, 0x1a, 0x50, 0xa6, 0x43, 0xdb,
+	0x75, 0xbd, 0x33, 0xb9, 0x99, 0xea, 0x13, 0xff, 0x0a, 0xea, 0xd1, 0xc0, 0x11, 0xa8, 0xa6, 0x46,
+	0xa6, 0x33, 0x26, 0x2e, 0x87, 0xe7, 0x8b, 0x54, 0xe3, 0xab, 0xb3, 0x91, 0xe4, 0x95, 0x93, 0xa0,
+	0xac, 0x98, 0x3d, 0x26, 0xb2, 0xb4, 0x3c, 0xf5, 0xc9, 0xa5, 0x8c, 0x8e, 0xff, 0x0c, 0x4c, 0x26,
+	0xc3, 0x61, 0x0c, 0xf5, 0x7e, 0x6c, 0x44, 0xef, 0xc7, 0x97, 0x7a, 0x82, 0x50, 0xef, 0x08, 0x79,
+	0xed, 0x1d, 0x21, 0x1e, 0x89, 0x0a, 0x89, 0x4b, 0x30, 0xfe, 0x05, 0xa0, 0x63, 0x56, 0xbb, 0x8e,
+	0x9d, 0x3e, 0xa1, 0x97, 0xbb, 0x64, 0x66, 0xbd, 0x76, 0xef, 0x29, 0xcc, 0xed, 0x12, 0xee, 0xfa,
+	0x97, 0x06, 0xd4, 0x5f, 0x4d, 0x03, 0x19, 0xee, 0xa5, 0x4c, 0x98, 0x94, 0x0c, 0x3d, 0x29, 0x7d,
+	0x08, 0x85, 0xc0, 0x1e, 0xa8, 0x0d, 0x31, 0xb9, 0xa6, 0x53, 0x7b, 0x60, 0x71, 0x6a, 0x04, 0xfa,
+	0xe7, 0x67, 0x81, 0xfe, 0xd7, 0x19, 0x1a, 0xd1, 0x9b, 0x4e, 0xda, 0x94, 0xbd, 0xc8, 0xca, 0x38,
+	0x0e, 0x9c, 0xc4, 0xdf, 0x68, 0xf1, 0xdf, 0x1a, 0xb0, 0x76, 0x4c, 0xe4, 0x5c, 0xf4, 0x2b, 0xb0,
+	0x7a, 0x8b, 0x31, 0x2e, 0x78, 0x8b, 0xc9, 0xaa, 0xac, 0x0a, 0xf3, 0x2a, 0xab, 0x18, 0x24, 0xf1,
+	0x11, 0x40, 0xe0, 0x05, 0xb6, 0xdb, 0x66, 0x24, 0xb5, 0x59, 0x9c, 0xd2, 0x72, 0x7e, 0x4d, 0xf0,
+	0xdf, 0x19, 0x50, 0x3f, 0x26, 0x01, 0x5f, 0x52, 0x38, 0xb9, 0xd8, 0x0b, 0x90, 0x31, 0xe7, 0x05,
+	0xe8, 0xf7, 0x3e, 0xc5, 0x5f, 0x42, 0xfd, 0xd4, 0x1e, 0xc4, 0xf7, 0x72, 0xa1, 0x67, 0x93, 0x0b,
+	0xb7, 0x16, 0x7f, 0x0e, 0x88, 0x05, 0xd9, 0xc4, 0xbe, 0x6c, 0x42, 0x69, 0xe2, 0x93, 0xbe, 0xf3,
+	0x56, 0x9e, 0x18, 0xf9, 0xc5, 0x02, 0x20, 0xe3, 0x3e, 0xb5, 0x07, 0xf3, 0x58, 0x59, 0x95, 0xe5,
+	0x8c, 0xbb, 0xee, 0xb4, 0x47, 0xda, 0x72, 0x8e, 0x22, 0x2a, 0xaf, 0x48, 0xaa, 0x18, 0x11, 0xb7,
+	0xa0, 0x1e, 0x69, 0x94, 0xe1, 0xa4, 0x09, 0xf9, 0xc0, 0x1e, 0xc8, 0x35, 0x45, 0x13, 0x66, 0x44,
+	0x6d, 0xc9, 0xb9, 0xd9, 0x15, 0xcd, 0x43, 0xd8, 0x10, 0x87, 0xe5, 0xbd, 0xdc, 0x0d, 0x7f, 0x00,
+	0x57, 0x12, 0xe2, 0x62, 0x62, 0xf8, 0x0b, 0x75, 0x08, 0x75, 0x03, 0x28, 0xfb, 0x1a, 0x99, 0xf6,
+	0xdd, 0x00, 0xa4, 0x8b, 0x48, 0x45, 0xf7, 0x00, 0x1d, 0x0c, 0x49, 0xf7, 0xf5, 0xe5, 0xb7, 0x13,
+	0xff, 0x04, 0xd6, 0x63, 0xa2, 0xd2, 0x66, 0x9b, 0x50, 0x22, 0x6f, 0x1d, 0x1a, 0x50, 0x59, 0x87,
+	0xc8, 0x2f, 0xfc, 0x8f, 0x06, 0x54, 0x0e, 0xec, 0xee, 0x90, 0xcc, 0xfc, 0x3d, 0xd3, 0x9c, 0x12,
+	0x72, 0x03, 0x8a, 0xfa, 0x6d, 0xa5, 0xd8, 0x51, 0x54, 0x27, 0x20, 0x23, 0x11, 0xf7, 0xf2, 0x96,
+	0xf8, 0x60, 0xea, 0x07, 0x24, 0x10, 0xbf, 0x05, 0xc8, 0x5b, 0xbc, 0xcd, 0x68, 0x43, 0x76, 0x57,
+	0x14, 0x6f, 0x5c, 0xbc, 0xcd, 0x8a, 0x05, 0xf2, 0xc6, 0xe9, 0x32, 0x94, 0x52, 0x3d, 0x69, 0x45,
+	0x04, 0xfc, 0x28, 0x02, 0xe8, 0xd8, 0xc4, 0xc3, 0x25, 0xb2, 0x7a, 0xd3, 0xe6, 0xbf, 0x62, 0x31,
+	0xb4, 0x7a, 0x33, 0x5c, 0x9c, 0x25, 0x7b, 0xf1, 0x2e, 0x94, 0xe5, 0xc6, 0x2d, 0xba, 0xe1, 0x7f,
+	0x9e, 0x83, 0xaa, 0x7a, 0x8d, 0x64, 0x37, 0x84, 0x2f, 0x93, 0x62, 0x1f, 0x69, 0x62, 0x9c, 0x45,
+	0xb6, 0x25, 0x2a, 0xa9, 0xb8, 0xd1, 0x4e, 0xec, 0xac, 0x35, 0x53, 0x52, 0xcc, 0x09, 0x84, 0x08,
+	0xe7, 0x6b, 0x9e, 0xc0, 0xb2, 0xae, 0x28, 0x03, 0xc7, 0xbc, 0xa9, 0xdf, 0x21, 0x52, 0x01, 0x28,
+	0x82, 0x35, 0x9b, 0x87, 0x50, 0x09, 0xb5, 0x67, 0xe8, 0xb9, 0x11, 0xd7, 0x13, 0x47, 0x75, 0x43,
+	0x2d, 0xb7, 0x3f, 0x13, 0x4f, 0xf8, 0x3c, 0xe9, 0x2d, 0x83, 0x69, 0x1d, 0xb5, 0x8e, 0xac, 0x6f,
+	0x8f, 0x0e, 0xeb, 0x4b, 0xc8, 0x84, 0xc2, 0xd3, 0x93, 0xe7, 0x47, 0x75, 0x03, 0x95, 0x21, 0x7f,
+	0x78, 0x62, 0xd5, 0x73, 0xb7, 0xef, 0x42, 0x55, 0xbb, 0x37, 0xa3, 0x2a, 0x94, 0x5b, 0xa7, 0x4f,
+	0xac, 0x53, 0xce, 0x5e, 0x81, 0xa2, 0x75, 0xf4, 0xe4, 0xf0, 0x8f, 0xea, 0x06, 0xd3, 0xf3, 0xf4,
+	0xe4, 0xc5, 0x49, 0xeb, 0xd9, 0xd1, 0x61, 0x3d, 0x77, 0xfb, 0x3e, 0x54, 0xc2, 0xdb, 0x22, 0x53,
+	0xfa, 0xe2, 0xe5, 0x8b, 0x23, 0xa1, 0xfe, 0x9b, 0xd6, 0xcb, 0x17, 0x75, 0x83, 0xb5, 0x9e, 0x9f,
+	0xbc, 0x38, 0xaa, 0xe7, 0xd8, 0x40, 0xad, 0x3f, 0x78, 0x5e, 0xcf, 0xb3, 0xc6, 0x41, 0xeb, 0xdb,
+	0x7a, 0x61, 0xef, 0x9f, 0x36, 0x20, 0xff, 0xe4, 0xd5, 0x09, 0x7a, 0x04, 0x10, 0xbd, 0xee, 0xa2,
+	0x4d, 0xe1, 0x08, 0xc9, 0xe7, 0xde, 0xe6, 0x66, 0xea, 0x09, 0xfe, 0x88, 0x21, 0xdc, 0x78, 0x09,
+	0x7d, 0x09, 0x55, 0xed, 0xf1, 0x12, 0x7d, 0xc0, 0x15, 0xa4, 0x1f, 0x4f, 0x9b, 0xf1, 0xa7, 0x41,
+	0xbc, 0x84, 0x0e, 0x61, 0x59, 0x63, 0xa3, 0xa8, 0x91, 0x94, 0xa4, 0xd1, 0xe0, 0x29, 0x9d, 0x0c,
+	0xb6, 0xc5, 0x4b, 0xbb, 0x06, 0xba, 0x07, 0xa6, 0x7a, 0x98, 0x44, 0x1b, 0x9c, 0x2f, 0xf1, 0x80,
+	0xd9, 0xbc, 0x92, 0xa0, 0xca, 0xb8, 0xb1, 0x84, 0x1e, 0x40, 0x25, 0x7a, 0x5b, 0x14, 0x5c, 0xc9,
+	0x37, 0xca, 0xe6, 0x66, 0x92, 0x1c, 0x4a, 0x3f, 0x02, 0x88, 0x5e, 0xee, 0xa4, 0xdd, 0x52, 0x4f,
+	0x79, 0x17, 0xd8, 0xed, 0x67, 0x50, 0xd5, 0xde, 0xb9, 0xa4, 0xdd, 0xd2, 0x2f, 0x5f, 0x4d, 0xbd,
+	0xac, 0xc1, 0x4b, 0x68, 0x1f, 0x96, 0x75, 0x60, 0x5f, 0x5a, 0x2d, 0x03, 0xeb, 0xbf, 0x60, 0xe8,
+	0x87, 0xb0, 0x12, 0x43, 0xc1, 0xd1, 0x55, 0xdd, 0xc0, 0x71, 0x2d, 0x49, 0x1c, 0x17, 0x2f, 0xa1,
+	0x6f, 0xa0, 0x16, 0x63, 0xa5, 0xa8, 0x99, 0x96, 0x0f, 0x37, 0xaf, 0x91, 0xa5, 0x3b, 0xdc, 0xbe,
+	0xaf, 0x00, 0x22, 0xc8, 0x53, 0x5a, 0x31, 0x05, 0x86, 0x37, 0xeb, 0x89, 0x49, 0x50, 0xbc, 0x84,
+	0x1e, 0x8b, 0x6c, 0xa7, 0x4e, 0x8d, 0x4f, 0xec, 0xd1, 0x4c, 0xf9, 0xf4, 0x22, 0x76, 0x0d, 0x66,
+	0x49, 0x1d, 0x0a, 0x94, 0x96, 0xcc, 0x40, 0x07, 0x2f, 0xb0, 0xe4, 0x3e, 0x2c, 0xeb, 0x90, 0xa0,
+	0xd4, 0x91, 0x81, 0x12, 0x5e, 0xa0, 0xe3, 0x3e, 0x54, 0x35, 0x68, 0x50, 0x3a, 0x42, 0x1a, 0x2c,
+	0xcc, 0x5e, 0xc4, 0x01, 0xac, 0x26, 0x30, 0x3f, 0x24, 0x7e, 0x9b, 0x94, 0x8d, 0x04, 0x66, 0x2b,
+	0xf9, 0x19, 0x54, 0xb5, 0xd7, 0x3e, 0x39, 0x83, 0xf4, 0xfb, 0x5f, 0xd2, 0x15, 0x9f, 0xc3, 0x6a,
+	0xe2, 0x9d, 0x53, 0x8e, 0x9d, 0xfd, 0xb4, 0xda, 0x6c, 0x64, 0x75, 0xb2, 0x3b, 0x85, 0xda, 0x0e,
+	0xfd, 0x49, 0x40, 0x9a, 0x32, 0xe3, 0x95, 0x60, 0x21, 0xc7, 0x96, 0x4a, 0x62, 0x8e, 0x1d, 0xd7,
+	0x92, 0xfc, 0x45, 0x2d, 0x5e, 0x52, 0xce, 0x28, 0x65, 0x23, 0x67, 0x8a, 0x0b, 0xd6, 0x13, 0x82,
+	0xcc, 0x19, 0x9f, 0x89, 0xd2, 0x2f, 0x8e, 0xdc, 0xa3, 0x6b, 0x09, 0x77, 0x4c, 0x40, 0xfa, 0x99,
+	0x9a, 0x5e, 0x00, 0x4a, 0xe3, 0xf4, 0x52, 0xd3, 0x4c, 0x00, 0xff, 0x02, 0x93, 0x3c, 0x02, 0x88,
+	0xf0, 0x79, 0xb9, 0xa6, 0x14, 0x60, 0x7f, 0xb1, 0x87, 0xeb, 0x10, 0x7c, 0xec, 0x94, 0x2c, 0xaa,
+	0xe3, 0x6b, 0x28, 0x4b, 0xd8, 0x04, 0xad, 0xc7, 0x41, 0x94, 0x39, 0x92, 0xb7, 0x0c, 0xf4, 0x00,
+	0x96, 0x75, 0x40, 0x4d, 0x8e, 0x9f, 0x81, 0x20, 0x36, 0x13, 0x98, 0x19, 0x97, 0x3e, 0x0c, 0x31,
+	0xaf, 0x03, 0x01, 0xa0, 0x5d, 0x4d, 0x89, 0xd3, 0x45, 0xe6, 0x6f, 0x2a, 0x74, 0x47, 0xe6, 0x98,
+	0x04, 0xd8, 0x73, 0x81, 0xec, 0x63, 0x28, 0x1f, 0x13, 0x7d, 0xed, 0x71, 0x20, 0xbc, 0xb9, 0x95,
+	0x92, 0xe4, 0xb5, 0xe2, 0xb7, 0xac, 0x8c, 0xe0, 0xe7, 0x22, 0xca, 0xaf, 0x5c, 0x49, 0x2c, 0xbf,
+	0xea, 0x8a, 0xe2, 0x37, 0x7f, 0xbc, 0x84, 0xf6, 0x44, 0x66, 0xd4, 0x66, 0x9d, 0x80, 0x80, 0x9a,
+	0xb5, 0x98, 0x08, 0xf3, 0xbe, 0x7b, 0x50, 0x53, 0x4c, 0x32, 0xa4, 0x66, 0x4b, 0x26, 0x07, 0xdb,
+	0x35, 0xd0, 0x5d, 0x30, 0x15, 0x04, 0x24, 0x85, 0x12, 0x88, 0x50, 0x96, 0xd0, 0x1e, 0x98, 0x0a,
+	0x05, 0x92, 0x42, 0x09, 0x50, 0x28, 0x7b, 0x8e, 0x8a, 0x29, 0x36, 0xc7, 0xa4, 0x64, 0xc6, 0x70,
+	0xf7, 0xc0, 0x54, 0x80, 0x8b, 0x14, 0x4a, 0x00, 0x3f, 0xcd, 0x2b, 0x09, 0x6a, 0x98, 0xee, 0x1f,
+	0xc2, 0x4a, 0x0c, 0x3f, 0x91, 0x9e, 0x94, 0x85, 0xa9, 0xc8, 0x91, 0x15, 0x62, 0xc2, 0x47, 0x3e,
+	0x86, 0xaa, 0x06, 0x61, 0xc8, 0x5d, 0x4c, 0x83, 0x1a, 0xf3, 0xdd, 0x21, 0x2c, 0x3b, 0xf8, 0x22,
+	0xf4, 0xb2, 0x63, 0x31, 0x7f, 0x7c, 0xc8, 0x6b, 0x46, 0x12, 0x90, 0x27, 0xae, 0x8b, 0x66, 0xb0,
+	0xcd, 0x16, 0xdf, 0xfb, 0x1b, 0x13, 0x2a, 0xa2, 0xd4, 0x65, 0xb5, 0xe3, 0x5d, 0xa8, 0x84, 0xa0,
+	0x88, 0xac, 0xa0, 0x92, 0x20, 0x49, 0x53, 0x2f, 0x8f, 0xf9, 0x99, 0xbc, 0xc7, 0x71, 0x5f, 0x41,
+	0x68, 0x71, 0x84, 0x77, 0x86, 0xe4, 0xb2, 0x26, 0x49, 0xb9, 0xe8, 0x63, 0x80, 0x90, 0x8b, 0xce,
+	0x12, 0xbb, 0x28, 0x9a, 0xdc, 0x83, 0x4a, 0x88, 0x9c, 0x20, 0x7d, 0x66, 0xf3, 0x0d, 0x7f, 0x04,
+	0x10, 0x8a, 0x52, 0x69, 0xf8, 0x14, 0x0a, 0x33, 0x5f, 0xcd, 0x01, 0x9f, 0x81, 0x40, 0x47, 0xe4,
+	0x0a, 0x92, 0x68, 0xc9, 0x7c, 0x25, 0x0f, 0xf8, 0x05, 0x25, 0x66, 0xf7, 0x24, 0xa0, 0x71, 0x81,
+	0x0b, 0xdc, 0x09, 0xb3, 0x64, 0x96, 0x21, 0x56, 0x63, 0x37, 0x2d, 0x1e, 0x49, 0xf6, 0xa1, 0xaa,
+	0xdd, 0x93, 0xa5, 0xf3, 0xa6, 0x2f, 0xdd, 0xcd, 0x46, 0xba, 0x23, 0x3c, 0x3f, 0x5f, 0x42, 0x55,
+	0x03, 0x47, 0xa4, 0x8e, 0x34, 0x5c, 0x92, 0x70, 0x97, 0x5d, 0x03, 0x3d, 0x83, 0x95, 0x18, 0x82,
+	0x20, 0x0f, 0x5e, 0x16, 0x28, 0xd1, 0x6c, 0x66, 0x75, 0x85, 0x53, 0xb8, 0x0b, 0xa5, 0x63, 0xc2,
+	0xe0, 0x11, 0x14, 0x22, 0x0b, 0xf3, 0x4d, 0xfd, 0x63, 0x00, 0x69, 0xac, 0xb8, 0x60, 0x86, 0x99,
+	0xee, 0x8b, 0x80, 0xcb, 0xae, 0x8e, 0x5a, 0xd8, 0xd4, 0xf0, 0x8d, 0xe6, 0x95, 0x04, 0x55, 0x4d,
+	0x6d, 0x97, 0xbb, 0x76, 0x04, 0x6e, 0xc4, 0xce, 0xb5, 0xae, 0xe0, 0x83, 0x14, 0x3d, 0x5c, 0xdd,
+	0x7d, 0x28, 0x1f, 0x78, 0xa3, 0x89, 0xdd, 0x0d, 0x2e, 0x7f, 0xac, 0xd1, 0x41, 0x78, 0x17, 0xe3,
+	0x18, 0xc0, 0x4c, 0x0d, 0xf1, 0x8b, 0x82, 0x0e, 0x29, 0xe0, 0xa5, 0xfd, 0xc7, 0xff, 0xf2, 0xee,
+	0x9a, 0xf1, 0x6f, 0xef, 0xae, 0x19, 0xff, 0xf1, 0xee, 0x9a, 0xf1, 0xc3, 0x7f, 0x5d, 0x5b, 0xfa,
+	0xee, 0x27, 0x03, 0x27, 0x18, 0x4e, 0x3b, 0x3b, 0x5d, 0x6f, 0x74, 0x67, 0x62, 0x77, 0x87, 0xe7,
+	0x3d, 0xe2, 0xeb, 0x2d, 0xea, 0x77, 0xef, 0x44, 0xff, 0x4a, 0xae, 0x53, 0xe2, 0xa3, 0xdd, 0xfd,
+	0xed, 0x00, 0x9e, 0xb6, 0xbb, 0x02, 0x3a, 0x37, 0x00, 0x00,
 }
@@ -0,0 +1,117 @@
+package grpcutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// circuitOpenMsg prefixes the errors of calls that a CircuitBreaker fails fast
+const circuitOpenMsg = "circuit breaker open"
+
+// CircuitBreaker stops a client from calling a server that's down. After
+// 'threshold' consecutive calls fail because the server is unavailable, the
+// breaker opens, and calls fail fast (without reaching the server) for
+// 'cooldown'. After that, the breaker is half-open: a single call is let
+// through to probe the server, and if it succeeds the breaker closes, while if
+// it fails the breaker opens for another 'cooldown'.
+//
+// Only failures that mean the server couldn't be reached (codes.Unavailable)
+// count; any other response, including an error, means the server is up.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu sync.Mutex
+	// failures is the number of consecutive calls that failed
+	failures int
+	// openUntil is when the breaker becomes half-open
+	openUntil time.Time
+	// probing is true while a half-open breaker's probe call is in flight
+	probing bool
+}
+
+// NewCircuitBreaker returns a CircuitBreaker that opens after 'threshold'
+// consecutive failures, for 'cooldown'
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow returns an error if a call must fail fast. Otherwise it returns
+// whether the call is the probe of a half-open breaker.
+func (b *CircuitBreaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return false, nil
+	}
+	if wait := time.Until(b.openUntil); wait > 0 || b.probing {
+		if wait < 0 {
+			wait = 0
+		}
+		return false, status.Errorf(codes.Unavailable, "%s after %d consecutive failures (retrying in %v)",
+			circuitOpenMsg, b.failures, wait.Round(time.Millisecond))
+	}
+	b.probing = true
+	return true, nil
+}
+
+// record records the result 'err' of a call
+func (b *CircuitBreaker) record(probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+	}
+	if status.Code(err) != codes.Unavailable {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor that passes unary calls
+// through the breaker
+func (b *CircuitBreaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		probe, err := b.allow()
+		if err != nil {
+			return err
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		b.record(probe, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns an interceptor that passes the creation of
+// streams through the breaker (errors on an established stream don't count)
+func (b *CircuitBreaker) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		probe, err := b.allow()
+		if err != nil {
+			return nil, err
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		b.record(probe, err)
+		return stream, err
+	}
+}
+
+// IsCircuitOpen returns true if 'err' is the error of a call that a
+// CircuitBreaker failed fast
+func IsCircuitOpen(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unavailable && strings.HasPrefix(s.Message(), circuitOpenMsg)
+}
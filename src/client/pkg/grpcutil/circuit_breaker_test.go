@@ -0,0 +1,79 @@
+package grpcutil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(3, 200*time.Millisecond)
+	intercept := b.UnaryClientInterceptor()
+	var calls int
+	var result error
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return result
+	}
+	call := func() error {
+		return intercept(context.Background(), "/test", nil, nil, nil, invoker)
+	}
+
+	// Errors from a server that's up don't open the breaker
+	result = fmt.Errorf("not found")
+	for i := 0; i < 5; i++ {
+		require.YesError(t, call())
+	}
+	require.Equal(t, 5, calls)
+
+	// After 3 consecutive failures, the breaker opens and calls fail fast
+	calls = 0
+	result = status.Error(codes.Unavailable, "connection refused")
+	for i := 0; i < 3; i++ {
+		err := call()
+		require.YesError(t, err)
+		require.False(t, IsCircuitOpen(err))
+	}
+	require.Equal(t, 3, calls)
+	err := call()
+	require.True(t, IsCircuitOpen(err))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 3, calls)
+
+	// After the cooldown, a single probe is let through, and if it fails the
+	// breaker opens again
+	time.Sleep(250 * time.Millisecond)
+	require.False(t, IsCircuitOpen(call()))
+	require.Equal(t, 4, calls)
+	require.True(t, IsCircuitOpen(call()))
+	require.Equal(t, 4, calls)
+
+	// If the probe succeeds, the breaker closes
+	time.Sleep(250 * time.Millisecond)
+	result = nil
+	require.NoError(t, call())
+	require.NoError(t, call())
+	require.Equal(t, 6, calls)
+
+	// Streams are created through the breaker too
+	streamCalls := 0
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamCalls++
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	interceptStream := b.StreamClientInterceptor()
+	for i := 0; i < 3; i++ {
+		_, err := interceptStream(context.Background(), &grpc.StreamDesc{}, nil, "/test", streamer)
+		require.False(t, IsCircuitOpen(err))
+	}
+	_, err = interceptStream(context.Background(), &grpc.StreamDesc{}, nil, "/test", streamer)
+	require.True(t, IsCircuitOpen(err))
+	require.Equal(t, 3, streamCalls)
+}
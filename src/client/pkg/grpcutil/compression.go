@@ -0,0 +1,70 @@
+package grpcutil
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// CompressionNone disables the compression of gRPC messages
+	CompressionNone = "none"
+	// CompressionGzip compresses gRPC messages with gzip
+	CompressionGzip = "gzip"
+)
+
+func init() {
+	// Servers decompress the requests that clients compress (and compress
+	// their responses the same way) with the compressors registered here, so
+	// this is registered in every process that serves or calls pachd
+	encoding.RegisterCompressor(&gzipCompressor{})
+}
+
+// ValidateCompression returns an error if 'compression' isn't a gRPC
+// compression setting that clients of pachd accept. An empty setting is the
+// same as CompressionNone.
+func ValidateCompression(compression string) error {
+	switch compression {
+	case "", CompressionNone, CompressionGzip:
+		return nil
+	default:
+		return fmt.Errorf("invalid gRPC compression %q (must be %q or %q)", compression, CompressionNone, CompressionGzip)
+	}
+}
+
+// gzipCompressor is an encoding.Compressor that compresses gRPC messages
+// with gzip, reusing writers between messages
+type gzipCompressor struct {
+	writers sync.Pool
+}
+
+func (c *gzipCompressor) Name() string {
+	return CompressionGzip
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if gw, ok := c.writers.Get().(*gzip.Writer); ok {
+		gw.Reset(w)
+		return &gzipWriter{Writer: gw, pool: &c.writers}, nil
+	}
+	return &gzipWriter{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+// gzipWriter returns its gzip.Writer to the pool that it came from when it's
+// closed
+type gzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *gzipWriter) Close() error {
+	defer w.pool.Put(w.Writer)
+	return w.Writer.Close()
+}
@@ -0,0 +1,56 @@
+package grpcutil
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultHandlerTimeout is the default amount of time that a worker's unary
+// RPC handlers may run before they're cancelled. Worker RPCs (e.g. Status and
+// Cancel) normally return immediately, so this only catches stuck handlers.
+// Streaming RPCs aren't limited, as their streams may legitimately stay open
+// for as long as their callers read from them.
+const DefaultHandlerTimeout = 10 * time.Minute
+
+// handlerTimeoutError returns the error that an RPC to 'method' fails with
+// if its handler doesn't finish within 'timeout'
+func handlerTimeoutError(method string, timeout time.Duration) error {
+	return status.Errorf(codes.DeadlineExceeded, "handler for %s didn't finish within %v and was cancelled", method, timeout)
+}
+
+// UnaryHandlerTimeoutInterceptor returns an interceptor that cancels the
+// context of unary handlers that run for longer than 'timeout', and fails
+// their RPCs with a DeadlineExceeded status. The RPC fails once the timeout
+// passes even if the handler ignores its context, so a stuck handler doesn't
+// hold up its caller.
+func UnaryHandlerTimeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		type result struct {
+			resp interface{}
+			err  error
+		}
+		done := make(chan result, 1)
+		go func() {
+			resp, err := handler(ctx, req)
+			done <- result{resp, err}
+		}()
+		select {
+		case r := <-done:
+			if r.err != nil && ctx.Err() == context.DeadlineExceeded {
+				return nil, handlerTimeoutError(info.FullMethod, timeout)
+			}
+			return r.resp, r.err
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, handlerTimeoutError(info.FullMethod, timeout)
+			}
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
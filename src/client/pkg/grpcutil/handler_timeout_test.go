@@ -0,0 +1,46 @@
+package grpcutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestUnaryHandlerTimeout(t *testing.T) {
+	intercept := UnaryHandlerTimeoutInterceptor(100 * time.Millisecond)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test"}
+
+	// Handlers that finish in time aren't affected
+	resp, err := intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "done", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "done", resp)
+
+	// A handler that runs past the timeout is cancelled
+	cancelled := make(chan struct{})
+	_, err = intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	<-cancelled
+
+	// The RPC fails even if the handler ignores its context
+	stuck := make(chan struct{})
+	defer close(stuck)
+	start := time.Now()
+	_, err = intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-stuck
+		return nil, nil
+	})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.True(t, time.Since(start) < 10*time.Second)
+}
@@ -28,6 +28,15 @@ const (
 	// TLSKeyFile is the name of the mounted file containing a private key
 	// corresponding to the public certificate in TLSCertFile
 	TLSKeyFile = "tls.key"
+
+	// DefaultMaxConnectionIdle is the default amount of time that a connection
+	// to a worker may be idle before the worker closes it
+	DefaultMaxConnectionIdle = 5 * time.Minute
+
+	// DefaultMaxConnectionAge is the default amount of time that a connection
+	// to a worker may be open before the worker closes it. RPCs that are in
+	// progress when a connection reaches its max age are allowed to finish.
+	DefaultMaxConnectionAge = time.Hour
 )
 
 var (
@@ -45,6 +54,19 @@ type ServerOptions struct {
 	Cancel       chan struct{}
 	RegisterFunc func(*grpc.Server) error
 
+	// If set, connections that have had no RPCs in progress for
+	// MaxConnectionIdle are closed. Clients reconnect when they make their next
+	// RPC, so this keeps stale (e.g. half-open) connections from accumulating
+	// and using up file descriptors.
+	MaxConnectionIdle time.Duration
+	// If set, connections are closed once they've been open for
+	// MaxConnectionAge (plus or minus 10%), after their RPCs finish.
+	MaxConnectionAge time.Duration
+	// If set, unary RPC handlers' contexts are cancelled once they've run for
+	// HandlerTimeout, and their RPCs fail with a DeadlineExceeded status (see
+	// UnaryHandlerTimeoutInterceptor). Streaming RPCs aren't limited.
+	HandlerTimeout time.Duration
+
 	// If set, grpcutil may enable TLS.  This should be set for public ports that
 	// serve GRPC services to 3rd party clients.
 	//
@@ -78,6 +100,16 @@ func Serve(
 				PermitWithoutStream: true,
 			}),
 		}
+		if server.MaxConnectionIdle != 0 || server.MaxConnectionAge != 0 {
+			// A zero duration is infinite, in keepalive.ServerParameters
+			opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+				MaxConnectionIdle: server.MaxConnectionIdle,
+				MaxConnectionAge:  server.MaxConnectionAge,
+			}))
+		}
+		if server.HandlerTimeout != 0 {
+			opts = append(opts, grpc.UnaryInterceptor(UnaryHandlerTimeoutInterceptor(server.HandlerTimeout)))
+		}
 		if server.PublicPortTLSAllowed {
 			// Validate environment
 			certPath := path.Join(TLSVolumePath, TLSCertFile)
@@ -0,0 +1,79 @@
+package grpcutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/version"
+	"github.com/pachyderm/pachyderm/src/client/version/versionpb"
+)
+
+// serveVersion serves the version API with 'options' on a free port, and
+// returns a connection to it and a function that closes the connection and
+// stops serving
+func serveVersion(t *testing.T, options ServerOptions) (*grpc.ClientConn, func()) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	options.Port = uint16(listener.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, listener.Close())
+	options.MaxMsgSize = MaxMsgSize
+	options.RegisterFunc = func(s *grpc.Server) error {
+		versionpb.RegisterAPIServer(s, version.NewAPIServer(version.Version, version.APIServerOptions{}))
+		return nil
+	}
+	cancel := make(chan struct{})
+	options.Cancel = cancel
+	go Serve(options)
+
+	ctx, cancelDial := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelDial()
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("localhost:%d", options.Port), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	return conn, func() {
+		conn.Close()
+		close(cancel)
+	}
+}
+
+// waitForStateChange returns the state of 'conn' after it leaves 'state', or
+// 'state' if it doesn't leave it within 'timeout'
+func waitForStateChange(conn *grpc.ClientConn, state connectivity.State, timeout time.Duration) connectivity.State {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if !conn.WaitForStateChange(ctx, state) {
+		return state
+	}
+	return conn.GetState()
+}
+
+func TestMaxConnectionIdle(t *testing.T) {
+	conn, stop := serveVersion(t, ServerOptions{MaxConnectionIdle: 500 * time.Millisecond})
+	defer stop()
+	c := versionpb.NewAPIClient(conn)
+	_, err := c.GetVersion(context.Background(), &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, connectivity.Ready, conn.GetState())
+
+	// The server closes the connection once it's idle, and the client
+	// reconnects for its next RPC
+	require.NotEqual(t, connectivity.Ready, waitForStateChange(conn, connectivity.Ready, 10*time.Second))
+	_, err = c.GetVersion(context.Background(), &types.Empty{})
+	require.NoError(t, err)
+}
+
+func TestNoMaxConnectionIdle(t *testing.T) {
+	conn, stop := serveVersion(t, ServerOptions{})
+	defer stop()
+	c := versionpb.NewAPIClient(conn)
+	_, err := c.GetVersion(context.Background(), &types.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, connectivity.Ready, waitForStateChange(conn, connectivity.Ready, 2*time.Second))
+}
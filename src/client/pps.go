@@ -1,9 +1,12 @@
 package client
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"io"
+	"sort"
 	"time"
 
 	"github.com/pachyderm/pachyderm/src/client/pfs"
@@ -37,12 +40,75 @@ const (
 	PPSJobIDEnv = "PPS_JOB_ID"
 	// PPSSpecCommitEnv is the namespace in which pachyderm is deployed
 	PPSSpecCommitEnv = "PPS_SPEC_COMMIT"
+	// PPSVerifyInputsEnv is the env var that, when set to "true" in a
+	// pipeline's transform, makes workers check each staged input file against
+	// the hash recorded in PFS before running user code.
+	PPSVerifyInputsEnv = "PPS_VERIFY_INPUTS"
+	// PPSMaxJobDatumsEnv is the env var that sets the cluster's default for
+	// the largest number of datums that a job may have (see
+	// pps.CreatePipelineRequest.MaxDatums).
+	PPSMaxJobDatumsEnv = "PPS_MAX_JOB_DATUMS"
+	// PPSMaxJobOutputBytesEnv is the env var that sets the cluster's default
+	// for the largest number of bytes of output that a job may write (see
+	// pps.CreatePipelineRequest.MaxOutputBytes).
+	PPSMaxJobOutputBytesEnv = "PPS_MAX_JOB_OUTPUT_BYTES"
+	// PPSMaxConcurrentJobsEnv is the env var that sets the most jobs that may
+	// run at once across all of the cluster's pipelines. Jobs beyond it wait
+	// for a job slot before they start.
+	PPSMaxConcurrentJobsEnv = "PPS_MAX_CONCURRENT_JOBS"
+	// PPSWorkerGracePeriodEnv is the env var that sets how many seconds a
+	// worker's locks on its chunks and merges outlive a missed renewal, before
+	// they're reassigned to other workers.
+	PPSWorkerGracePeriodEnv = "PPS_WORKER_GRACE_PERIOD"
+	// PPSPreemptionEnv is the env var that, when set to "true" in a worker's
+	// sidecar, makes the sidecar ignore SIGTERM, so that it keeps serving the
+	// worker while the worker drains after a preemption notice (see
+	// pps.Preemption).
+	PPSPreemptionEnv = "PPS_PREEMPTION"
+	// PPSLogRetentionMaxAgeEnv is the env var that sets the cluster's default
+	// for how long datums' logs are kept in stats branches (see
+	// pps.LogRetention.MaxAge).
+	PPSLogRetentionMaxAgeEnv = "PPS_LOG_RETENTION_MAX_AGE"
+	// PPSLogRetentionMaxBytesEnv is the env var that sets the cluster's
+	// default for the most bytes of logs kept in each stats branch (see
+	// pps.LogRetention.MaxBytes).
+	PPSLogRetentionMaxBytesEnv = "PPS_LOG_RETENTION_MAX_BYTES"
+	// PPSPachdVersionEnv is the env var that tells workers the version of the
+	// pachd that created them, which may differ from their own (see
+	// pps.CreatePipelineRequest.WorkerVersion).
+	PPSPachdVersionEnv = "PPS_PACHD_VERSION"
+	// PPSWorkerHandlerTimeoutEnv is the env var that sets how long the unary
+	// handlers of a worker's gRPC API may run before they're cancelled (see
+	// pps.CreatePipelineRequest.WorkerHandlerTimeout).
+	PPSWorkerHandlerTimeoutEnv = "PPS_WORKER_HANDLER_TIMEOUT"
+	// PPSUnchangedOutputLabel is the label (set to "true") of output commits
+	// whose content is identical to their parent's. Pipelines don't run jobs
+	// for output commits whose only changed inputs have it (see
+	// pps.CreatePipelineRequest.SkipUnchangedOutput).
+	PPSUnchangedOutputLabel = "pachyderm.io/unchanged-output"
+	// PPSQuarantinedOutputLabel is the label of output commits whose job's
+	// output failed validation (see pps.OutputValidation). It's set to the ID
+	// of the staging commit, on the output repo's quarantine branch, that holds
+	// the rejected output.
+	PPSQuarantinedOutputLabel = "pachyderm.io/quarantined-output"
+	// PPSDatumsSucceededLabel, PPSDatumsSkippedLabel and PPSDatumsFailedLabel
+	// are the labels of the output commits of jobs with failed datums, which
+	// are set to the number of datums that the job processed successfully,
+	// skipped (as they were processed by an earlier job) and failed. The
+	// failed datums' IDs are listed in the commit's description.
+	PPSDatumsSucceededLabel = "pachyderm.io/datums-succeeded"
+	PPSDatumsSkippedLabel   = "pachyderm.io/datums-skipped"
+	PPSDatumsFailedLabel    = "pachyderm.io/datums-failed"
 	// PPSInputPrefix is the prefix of the path where datums are downloaded
 	// to.  A datum of an input named `XXX` is downloaded to `/pfs/XXX/`.
 	PPSInputPrefix = "/pfs"
 	// PPSScratchSpace is where pps workers store data while it's waiting to be
 	// processed.
 	PPSScratchSpace = "/pfs/.scratch"
+	// PPSObjectSecretsPath is where the secrets of a pipeline's object inputs
+	// are mounted in its workers. The secret of an object input is mounted at
+	// `/pach-object-secrets/<secret name>/`.
+	PPSObjectSecretsPath = "/pach-object-secrets"
 	// PPSWorkerPort is the port that workers use for their gRPC server
 	PPSWorkerPort = 80
 	// PPSWorkerVolume is the name of the volume in which workers store
@@ -63,6 +129,14 @@ const (
 	// OutputCommitIDEnv is an env var that is added to the environment of user
 	// pipelined code and indicates the id of the output commit.
 	OutputCommitIDEnv = "PACH_OUTPUT_COMMIT_ID"
+	// TraceIDEnv is an env var that is added to the environment of user
+	// pipeline code and indicates the trace ID of the job currently being run.
+	TraceIDEnv = "PACH_TRACE_ID"
+	// PFSDirEnv is an env var that is added to the environment of user
+	// pipeline code and indicates the directory containing the datum's inputs
+	// and its output directory. It's /pfs, unless the pipeline runs several
+	// datums per worker at once, in which case each datum has its own.
+	PFSDirEnv = "PACH_PFS_DIR"
 )
 
 // NewJob creates a pps.Job.
@@ -210,6 +284,39 @@ func (c APIClient) InspectJob(jobID string, blockState bool) (*pps.JobInfo, erro
 	return jobInfo, grpcutil.ScrubGRPC(err)
 }
 
+// WatchJob calls f with a JobEvent with the job's progress, and then with an
+// event each time its state or progress changes. It returns once f has been
+// called with an event for the job's terminal state, or once f returns an
+// error; if f returns errutil.ErrBreak, WatchJob returns nil.
+func (c APIClient) WatchJob(jobID string, f func(*pps.JobEvent) error) error {
+	ctx, cancel := context.WithCancel(c.Ctx())
+	defer cancel()
+	client, err := c.PpsAPIClient.WatchJob(
+		ctx,
+		&pps.WatchJobRequest{
+			Job: NewJob(jobID),
+		},
+	)
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	for {
+		event, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return grpcutil.ScrubGRPC(err)
+		}
+		if err := f(event); err != nil {
+			if err == errutil.ErrBreak {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 // InspectJobOutputCommit returns info about a job that created a commit.
 // blockState will cause the call to block until the job reaches a terminal state (failure or success).
 func (c APIClient) InspectJobOutputCommit(repoName, commitID string, blockState bool) (*pps.JobInfo, error) {
@@ -341,6 +448,39 @@ func (c APIClient) StopJob(jobID string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// StopPipelineJobs stops all of the pipeline 'pipelineName's jobs that
+// haven't finished, and returns the jobs that it stopped.
+func (c APIClient) StopPipelineJobs(pipelineName string) ([]*pps.Job, error) {
+	response, err := c.PpsAPIClient.StopPipelineJobs(
+		c.Ctx(),
+		&pps.StopPipelineJobsRequest{
+			Pipeline: NewPipeline(pipelineName),
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response.Jobs, nil
+}
+
+// RerunJob starts a new job that processes the same input commits as the job
+// 'jobID', with the same version of its pipeline. The new job's output is
+// written to a new branch of the pipeline's output repo, named after the new
+// job, rather than to the pipeline's output branch. The branch is deleted once
+// the new job finishes, but its output commit remains in JobInfo.OutputCommit.
+func (c APIClient) RerunJob(jobID string) (*pps.Job, error) {
+	job, err := c.PpsAPIClient.RerunJob(
+		c.Ctx(),
+		&pps.RerunJobRequest{
+			Job: NewJob(jobID),
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return job, nil
+}
+
 // RestartDatum restarts a datum that's being processed as part of a job.
 // datumFilter is a slice of strings which are matched against either the Path
 // or Hash of the datum, the order of the strings in datumFilter is irrelevant.
@@ -433,6 +573,41 @@ func (c APIClient) InspectDatum(jobID string, datumID string) (*pps.DatumInfo, e
 	return datumInfo, nil
 }
 
+// InspectDatums returns the number of datums that a pipeline with the input
+// 'input' would process for the input commits 'commits', without creating the
+// pipeline. Inputs that have no commit in 'commits' use the head of their
+// branch.
+func (c APIClient) InspectDatums(input *pps.Input, commits ...*pfs.Commit) (int64, error) {
+	resp, err := c.PpsAPIClient.InspectDatums(
+		c.Ctx(),
+		&pps.InspectDatumsRequest{
+			Input:   input,
+			Commits: commits,
+		},
+	)
+	if err != nil {
+		return 0, grpcutil.ScrubGRPC(err)
+	}
+	return resp.Count, nil
+}
+
+// PreviewTrigger returns the pipelines that finishing the commit 'commitID'
+// (which may be a branch name) in 'repoName' would trigger, with each
+// pipeline after the pipelines that trigger it, and an estimate of the number
+// of datums that each would process.
+func (c APIClient) PreviewTrigger(repoName string, commitID string) ([]*pps.TriggeredPipeline, error) {
+	resp, err := c.PpsAPIClient.PreviewTrigger(
+		c.Ctx(),
+		&pps.PreviewTriggerRequest{
+			Commit: NewCommit(repoName, commitID),
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return resp.Pipelines, nil
+}
+
 // LogsIter iterates through log messages returned from pps.GetLogs. Logs can
 // be fetched with 'Next()'. The log message received can be examined with
 // 'Message()', and any errors can be examined with 'Err()'.
@@ -481,11 +656,28 @@ func (c APIClient) GetLogs(
 	master bool,
 	follow bool,
 	tail int64,
+) *LogsIter {
+	return c.GetWorkerLogs(pipelineName, jobID, "", data, datumID, master, follow, tail)
+}
+
+// GetWorkerLogs is like GetLogs, but only returns logs from the worker
+// identified by 'worker', which may be either the worker's pod name or its IP
+// address. Passing "" for 'worker' returns logs from all workers.
+func (c APIClient) GetWorkerLogs(
+	pipelineName string,
+	jobID string,
+	worker string,
+	data []string,
+	datumID string,
+	master bool,
+	follow bool,
+	tail int64,
 ) *LogsIter {
 	request := pps.GetLogsRequest{
 		Master: master,
 		Follow: follow,
 		Tail:   tail,
+		Worker: worker,
 	}
 	resp := &LogsIter{}
 	if pipelineName != "" {
@@ -564,11 +756,80 @@ func (c APIClient) InspectPipeline(pipelineName string) (*pps.PipelineInfo, erro
 	return pipelineInfo, grpcutil.ScrubGRPC(err)
 }
 
+// InspectPipelineVersion returns the version of a pipeline that each of its
+// workers is running, along with the pipeline's current version. Skew is set
+// in the result if any worker isn't running the current version.
+func (c APIClient) InspectPipelineVersion(pipelineName string) (*pps.PipelineVersionInfo, error) {
+	versionInfo, err := c.PpsAPIClient.InspectPipelineVersion(
+		c.Ctx(),
+		&pps.InspectPipelineVersionRequest{
+			Pipeline: NewPipeline(pipelineName),
+		},
+	)
+	return versionInfo, grpcutil.ScrubGRPC(err)
+}
+
+// InspectJobHistory returns the number of the pipeline's most recent jobs that
+// succeeded and failed, along with aggregates of their duration and
+// throughput. If limit is 0, all of the pipeline's jobs are aggregated.
+func (c APIClient) InspectJobHistory(pipelineName string, limit int64) (*pps.JobHistory, error) {
+	history, err := c.PpsAPIClient.InspectJobHistory(
+		c.Ctx(),
+		&pps.InspectJobHistoryRequest{
+			Pipeline: NewPipeline(pipelineName),
+			Limit:    limit,
+		},
+	)
+	return history, grpcutil.ScrubGRPC(err)
+}
+
+// WatchWorkers calls f with a WorkerEvent for each of the pipeline's workers
+// that's currently registered, and then with an event each time one of its
+// workers joins or leaves. It only returns once f returns an error (or the
+// client's context is cancelled); if f returns errutil.ErrBreak, WatchWorkers
+// returns nil.
+func (c APIClient) WatchWorkers(pipelineName string, f func(*pps.WorkerEvent) error) error {
+	ctx, cancel := context.WithCancel(c.Ctx())
+	defer cancel()
+	client, err := c.PpsAPIClient.WatchWorkers(
+		ctx,
+		&pps.WatchWorkersRequest{
+			Pipeline: NewPipeline(pipelineName),
+		},
+	)
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	for {
+		event, err := client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return grpcutil.ScrubGRPC(err)
+		}
+		if err := f(event); err != nil {
+			if err == errutil.ErrBreak {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 // ListPipeline returns info about all pipelines.
 func (c APIClient) ListPipeline() ([]*pps.PipelineInfo, error) {
+	return c.ListPipelineByAnnotation("")
+}
+
+// ListPipelineByAnnotation returns info about the pipelines whose annotations
+// match 'annotationSelector' (all pipelines, if it's empty). The selector
+// uses the syntax of Kubernetes label selectors, e.g. "team=vision" or
+// "team in (vision,nlp),stage!=dev".
+func (c APIClient) ListPipelineByAnnotation(annotationSelector string) ([]*pps.PipelineInfo, error) {
 	pipelineInfos, err := c.PpsAPIClient.ListPipeline(
 		c.Ctx(),
-		&pps.ListPipelineRequest{},
+		&pps.ListPipelineRequest{AnnotationSelector: annotationSelector},
 	)
 	if err != nil {
 		return nil, grpcutil.ScrubGRPC(err)
@@ -611,6 +872,64 @@ func (c APIClient) StopPipeline(name string) error {
 	return grpcutil.ScrubGRPC(err)
 }
 
+// RunPipeline runs the next job of the pipeline 'name' with 'parallelism'
+// workers, instead of the number set by its parallelism_spec, without
+// changing its spec. Jobs never run with fewer workers than the pipeline has,
+// so 'parallelism' must be more than that. The pipeline's workers are scaled
+// up for the job, and back down once it's finished. If the pipeline has a job
+// that's waiting to start, that job is returned, and it's the job that runs
+// with 'parallelism' workers. Otherwise, nil is returned, and the pipeline's
+// next job does.
+func (c APIClient) RunPipeline(name string, parallelism int64) (*pps.Job, error) {
+	response, err := c.PpsAPIClient.RunPipeline(
+		c.Ctx(),
+		&pps.RunPipelineRequest{
+			Pipeline:    NewPipeline(name),
+			Parallelism: parallelism,
+		},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return response.Job, nil
+}
+
+// PromoteInputs moves the trigger branch of each of a pipeline's deferred
+// inputs to the head of the input's branch, so that the pipeline processes
+// the commits made to the input since it was last promoted. If inputNames
+// is non-empty, only the deferred inputs with those names are promoted.
+func (c APIClient) PromoteInputs(pipelineName string, inputNames ...string) error {
+	pipelineInfo, err := c.InspectPipeline(pipelineName)
+	if err != nil {
+		return err
+	}
+	promote := make(map[string]bool)
+	for _, name := range inputNames {
+		promote[name] = true
+	}
+	var inputs []*pps.PFSInput
+	pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
+		if input.Pfs != nil && input.Pfs.Deferred != nil && (len(inputNames) == 0 || promote[input.Pfs.Name]) {
+			inputs = append(inputs, input.Pfs)
+			delete(promote, input.Pfs.Name)
+		}
+	})
+	for _, name := range inputNames {
+		if promote[name] {
+			return fmt.Errorf("pipeline %s has no deferred input named %s", pipelineName, name)
+		}
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("pipeline %s has no deferred inputs", pipelineName)
+	}
+	for _, input := range inputs {
+		if err := c.SetBranch(input.Repo, input.Branch, input.Deferred.Branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RerunPipeline reruns a pipeline over a given set of commits. Exclude and
 // include are filters that either include or exclude the ancestors of the
 // given commits.  A commit is considered the ancestor of itself. The behavior
@@ -627,6 +946,104 @@ func (c APIClient) RerunPipeline(name string, include []*pfs.Commit, exclude []*
 	return grpcutil.ScrubGRPC(err)
 }
 
+// DownstreamInfo is a pipeline that's derived from a repo or commit, as
+// returned by DownstreamProvenance.
+type DownstreamInfo struct {
+	Pipeline *pps.Pipeline
+	// Commits are the pipeline's output commits that are derived from the
+	// commit, oldest first. It's nil if DownstreamProvenance was given a repo.
+	Commits []*pfs.Commit
+}
+
+// DownstreamProvenance returns the pipelines that are derived, directly or
+// transitively, from the repo 'repoName' or, if 'commitID' is set, from that
+// commit (along with their output commits that are derived from it). It's
+// the set of pipelines that run again when the repo or commit changes. The
+// result is in topological order: each pipeline comes after the pipelines
+// that it's derived from.
+func (c APIClient) DownstreamProvenance(repoName string, commitID string) ([]*DownstreamInfo, error) {
+	downstream := make(map[string]*DownstreamInfo)
+	if commitID == "" {
+		branchInfos, err := c.ListBranch(repoName)
+		if err != nil {
+			return nil, err
+		}
+		for _, branchInfo := range branchInfos {
+			for _, subvBranch := range branchInfo.Subvenance {
+				downstream[subvBranch.Repo.Name] = &DownstreamInfo{Pipeline: NewPipeline(subvBranch.Repo.Name)}
+			}
+		}
+	} else {
+		commitInfo, err := c.InspectCommit(repoName, commitID)
+		if err != nil {
+			return nil, err
+		}
+		// A commit can reach a pipeline by several paths (e.g. both sides of
+		// a cross), which give overlapping ranges
+		seen := make(map[string]bool)
+		addCommit := func(info *DownstreamInfo, commit *pfs.Commit) {
+			if !seen[commit.Repo.Name+"@"+commit.ID] {
+				seen[commit.Repo.Name+"@"+commit.ID] = true
+				info.Commits = append(info.Commits, commit)
+			}
+		}
+		for _, subvRange := range commitInfo.Subvenance {
+			subvRepo := subvRange.Upper.Repo.Name
+			info, ok := downstream[subvRepo]
+			if !ok {
+				info = &DownstreamInfo{Pipeline: NewPipeline(subvRepo)}
+				downstream[subvRepo] = info
+			}
+			// A range's commits are its lower commit and the ancestors of its
+			// upper commit that come after it (ListCommit excludes 'from', so
+			// the lower commit is added explicitly)
+			subvCommitInfos, err := c.ListCommit(subvRepo, subvRange.Upper.ID, subvRange.Lower.ID, 0)
+			if err != nil {
+				return nil, err
+			}
+			addCommit(info, subvRange.Lower)
+			for i := len(subvCommitInfos) - 1; i >= 0; i-- {
+				addCommit(info, subvCommitInfos[i].Commit)
+			}
+		}
+	}
+	// Repos can be downstream of others without being pipelines (their
+	// branches can be given provenance by CreateBranch), so only the repos of
+	// pipelines are kept. Each pipeline's output branch is provenant on every
+	// branch upstream of it, so ordering them by how many of the other
+	// pipelines they're provenant on sorts them topologically.
+	pipelineInfos, err := c.ListPipeline()
+	if err != nil {
+		return nil, err
+	}
+	var result []*DownstreamInfo
+	upstreamCount := make(map[string]int)
+	for _, pipelineInfo := range pipelineInfos {
+		info, ok := downstream[pipelineInfo.Pipeline.Name]
+		if !ok {
+			continue
+		}
+		branchInfo, err := c.InspectBranch(pipelineInfo.Pipeline.Name, pipelineInfo.OutputBranch)
+		if err != nil {
+			return nil, err
+		}
+		for _, provBranch := range branchInfo.Provenance {
+			if _, ok := downstream[provBranch.Repo.Name]; ok && provBranch.Repo.Name != pipelineInfo.Pipeline.Name {
+				upstreamCount[pipelineInfo.Pipeline.Name]++
+			}
+		}
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		iName, jName := result[i].Pipeline.Name, result[j].Pipeline.Name
+		if upstreamCount[iName] != upstreamCount[jName] {
+			return upstreamCount[iName] < upstreamCount[jName]
+		}
+		return iName < jName
+	})
+	return result, nil
+}
+
 // CreatePipelineService creates a new pipeline service.
 func (c APIClient) CreatePipelineService(
 	name string,
@@ -670,9 +1087,19 @@ func (c APIClient) CreatePipelineService(
 // lower chance of collisions, the default value is 10 MB and collisions should
 // be unlikely until you have 10 million objects.
 func (c APIClient) GarbageCollect(memoryBytes int64) error {
+	return c.GarbageCollectParallel(memoryBytes, 0)
+}
+
+// GarbageCollectParallel is like GarbageCollect, but uses 'concurrency'
+// goroutines to find and delete unreferenced objects, which speeds up garbage
+// collection of large object stores. 0 means the server's default.
+func (c APIClient) GarbageCollectParallel(memoryBytes int64, concurrency int64) error {
 	_, err := c.PpsAPIClient.GarbageCollect(
 		c.Ctx(),
-		&pps.GarbageCollectRequest{MemoryBytes: memoryBytes},
+		&pps.GarbageCollectRequest{
+			MemoryBytes: memoryBytes,
+			Concurrency: concurrency,
+		},
 	)
 	return grpcutil.ScrubGRPC(err)
 }
@@ -0,0 +1,158 @@
+package local
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/datum"
+)
+
+// Input is a single input to a datum: a file or directory, read from the
+// local filesystem, that's made available to the transform at /pfs/<Name>.
+type Input struct {
+	// Name is the name of the input, and of its directory under /pfs
+	Name string
+	// Repo is the repo that the input reads from. Its data is read from
+	// <root>/<Repo>
+	Repo string
+	// Path is the path of the file or directory, relative to <root>/<Repo>
+	Path string
+	// SizeBytes is the size of the file, or of all files under the directory
+	SizeBytes uint64
+	// EmptyFiles indicates that the input's files are created, but their
+	// content isn't copied
+	EmptyFiles bool
+}
+
+// Datums returns the datums of 'input', whose data is read from the local
+// filesystem rather than from PFS. The data for an input from repo 'repo' is
+// read from root/repo, and datums are constructed from it using the same glob
+// semantics and in the same order as in PFS. Only pfs, atom, union and cross
+// inputs are supported, and pfs inputs can't be split.
+func Datums(root string, input *pps.Input) ([][]*Input, error) {
+	switch {
+	case input.Atom != nil:
+		return pfsDatums(root, &pps.PFSInput{
+			Name:       input.Atom.Name,
+			Repo:       input.Atom.Repo,
+			Branch:     input.Atom.Branch,
+			Glob:       input.Atom.Glob,
+			Lazy:       input.Atom.Lazy,
+			EmptyFiles: input.Atom.EmptyFiles,
+		})
+	case input.Pfs != nil:
+		if input.Pfs.Split != nil {
+			return nil, fmt.Errorf("split inputs can't be read from the local filesystem")
+		}
+		return pfsDatums(root, input.Pfs)
+	case input.Union != nil:
+		var result [][]*Input
+		for _, input := range input.Union {
+			datums, err := Datums(root, input)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, datums...)
+		}
+		return result, nil
+	case input.Cross != nil:
+		return crossDatums(root, input.Cross)
+	case input.Cron != nil:
+		return nil, fmt.Errorf("cron inputs can't be read from the local filesystem")
+	case input.Git != nil:
+		return nil, fmt.Errorf("git inputs can't be read from the local filesystem")
+	case input.Object != nil:
+		return nil, fmt.Errorf("object inputs can't be read from the local filesystem")
+	}
+	return nil, fmt.Errorf("unrecognized input type")
+}
+
+func pfsDatums(root string, input *pps.PFSInput) ([][]*Input, error) {
+	name := input.Name
+	if name == "" {
+		name = input.Repo
+	}
+	repoRoot := filepath.Join(root, input.Repo)
+	g, err := datum.NewGlob(input.Glob)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]uint64)
+	var inputs []*Input
+	if err := filepath.Walk(repoRoot, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(repoRoot, p)
+		if err != nil {
+			return err
+		}
+		pfsPath := path.Clean("/" + filepath.ToSlash(rel))
+		if !info.IsDir() {
+			// Add this file's size to all of its parents, so that directories
+			// are sized (and sorted) like they are in PFS
+			for dir := pfsPath; dir != "/"; {
+				dir = path.Dir(dir)
+				sizes[dir] += uint64(info.Size())
+			}
+			sizes[pfsPath] = uint64(info.Size())
+		}
+		if g.Match(pfsPath) {
+			inputs = append(inputs, &Input{
+				Name:       name,
+				Repo:       input.Repo,
+				Path:       pfsPath,
+				EmptyFiles: input.EmptyFiles,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for _, input := range inputs {
+		input.SizeBytes = sizes[input.Path]
+	}
+	// Sort the inputs the same way that PFS inputs are sorted
+	sort.Slice(inputs, func(i, j int) bool {
+		return datum.Less(
+			datum.File{Path: inputs[i].Path, SizeBytes: int64(inputs[i].SizeBytes)},
+			datum.File{Path: inputs[j].Path, SizeBytes: int64(inputs[j].SizeBytes)},
+		)
+	})
+	var result [][]*Input
+	for _, input := range inputs {
+		result = append(result, []*Input{input})
+	}
+	return result, nil
+}
+
+// crossDatums returns the cross product of the datums of 'cross', ordered
+// the same way as the worker's cross datums.
+func crossDatums(root string, cross []*pps.Input) ([][]*Input, error) {
+	var datumSets [][][]*Input
+	var lens []int
+	for _, input := range cross {
+		datums, err := Datums(root, input)
+		if err != nil {
+			return nil, err
+		}
+		datumSets = append(datumSets, datums)
+		lens = append(lens, len(datums))
+	}
+	var result [][]*Input
+	for i := 0; i < datum.CrossLen(lens); i++ {
+		var inputs []*Input
+		for j, index := range datum.CrossIndexes(i, lens) {
+			inputs = append(inputs, datumSets[j][index]...)
+		}
+		sort.Slice(inputs, func(i, j int) bool {
+			return inputs[i].Name < inputs[j].Name
+		})
+		result = append(result, inputs)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func writeLocalFile(t *testing.T, root string, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0777))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, path), []byte(content), 0644))
+}
+
+func datumPaths(datums [][]*Input) [][]string {
+	var result [][]string
+	for _, datum := range datums {
+		var paths []string
+		for _, input := range datum {
+			paths = append(paths, input.Name+":"+input.Path)
+		}
+		result = append(result, paths)
+	}
+	return result
+}
+
+func TestDatums(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-datums")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	writeLocalFile(t, root, "images/a/1", "1")
+	writeLocalFile(t, root, "images/a/2", "22")
+	writeLocalFile(t, root, "images/b/1", "333")
+	writeLocalFile(t, root, "labels/x", "x")
+
+	datums, err := Datums(root, client.NewPFSInput("images", "/"))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"images:/"}}, datumPaths(datums))
+
+	// Directories are sorted by descending size, like PFS
+	datums, err = Datums(root, client.NewPFSInput("images", "/*"))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"images:/a"}, {"images:/b"}}, datumPaths(datums))
+
+	datums, err = Datums(root, client.NewPFSInput("images", "/*/*"))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"images:/b/1"}, {"images:/a/2"}, {"images:/a/1"}}, datumPaths(datums))
+
+	datums, err = Datums(root, client.NewCrossInput(
+		client.NewPFSInput("images", "/*"),
+		client.NewPFSInput("labels", "/*"),
+	))
+	require.NoError(t, err)
+	paths := datumPaths(datums)
+	sort.Slice(paths, func(i, j int) bool { return paths[i][0] < paths[j][0] })
+	require.Equal(t, [][]string{{"images:/a", "labels:/x"}, {"images:/b", "labels:/x"}}, paths)
+
+	_, err = Datums(root, &pps.Input{Cron: &pps.CronInput{Name: "tick"}})
+	require.YesError(t, err)
+}
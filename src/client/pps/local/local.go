@@ -0,0 +1,192 @@
+// Package local runs pipelines against data on the local machine, using
+// docker rather than a Pachyderm cluster. It's meant for developing and
+// debugging pipelines: nothing that it does touches PFS or PPS.
+package local
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// Run runs the pipeline in 'request' over the data in inputDir. Datums are
+// constructed from inputDir the same way that PPS constructs them from PFS,
+// with the data for an input from repo "foo" read from inputDir/foo. The
+// transform is then run once per datum, in the pipeline's image, via the
+// docker CLI. Every datum's output is written to outputDir, which is mounted
+// at /pfs/out. Output from the transform and from Run itself is written to
+// 'stdout' and 'stderr'.
+func Run(request *pps.CreatePipelineRequest, inputDir string, outputDir string, stdout io.Writer, stderr io.Writer) error {
+	if request.Transform == nil {
+		return fmt.Errorf("pipeline must specify a transform")
+	}
+	if request.Transform.Image == "" {
+		return fmt.Errorf("pipeline must specify an image to run locally")
+	}
+	if request.Input == nil {
+		return fmt.Errorf("pipeline must specify an input")
+	}
+	inputDir, err := filepath.Abs(inputDir)
+	if err != nil {
+		return err
+	}
+	outputDir, err = filepath.Abs(outputDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0777); err != nil {
+		return err
+	}
+	datums, err := Datums(inputDir, request.Input)
+	if err != nil {
+		return err
+	}
+	for i, datum := range datums {
+		fmt.Fprintf(stderr, "processing datum %d/%d\n", i+1, len(datums))
+		if err := runDatum(request, inputDir, outputDir, datum, stdout, stderr); err != nil {
+			return fmt.Errorf("error processing datum %d (%s): %v", i+1, datumString(datum), err)
+		}
+	}
+	return nil
+}
+
+func runDatum(request *pps.CreatePipelineRequest, inputDir string, outputDir string, data []*Input, stdout io.Writer, stderr io.Writer) (retErr error) {
+	scratch, err := ioutil.TempDir("", "pachctl-run-local")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(scratch); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	if err := os.MkdirAll(filepath.Join(scratch, "out"), 0777); err != nil {
+		return err
+	}
+	// Stage the datum's inputs in scratch, which is mounted at /pfs
+	for _, input := range data {
+		src := filepath.Join(inputDir, input.Repo, input.Path)
+		dst := filepath.Join(scratch, input.Name, input.Path)
+		if err := copyTree(src, dst, input.EmptyFiles); err != nil {
+			return err
+		}
+	}
+	transform := request.Transform
+	args := []string{
+		"run", "--rm", "-i",
+		"-v", fmt.Sprintf("%s:%s", scratch, client.PPSInputPrefix),
+		"-v", fmt.Sprintf("%s:%s", outputDir, filepath.Join(client.PPSInputPrefix, "out")),
+	}
+	for _, env := range datumEnv(request, data) {
+		args = append(args, "-e", env)
+	}
+	if transform.User != "" {
+		args = append(args, "-u", transform.User)
+	}
+	if transform.WorkingDir != "" {
+		args = append(args, "-w", transform.WorkingDir)
+	}
+	if len(transform.Cmd) > 0 {
+		args = append(args, "--entrypoint", transform.Cmd[0], transform.Image)
+		args = append(args, transform.Cmd[1:]...)
+	} else {
+		args = append(args, transform.Image)
+	}
+	cmd := exec.Command("docker", args...)
+	cmd.Stdin = strings.NewReader(strings.Join(transform.Stdin, "\n") + "\n")
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+				for _, code := range transform.AcceptReturnCode {
+					if int(code) == status.ExitStatus() {
+						return nil
+					}
+				}
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// datumEnv returns the environment variables that the transform sees while
+// processing 'data', mirroring the variables set by workers.
+func datumEnv(request *pps.CreatePipelineRequest, data []*Input) []string {
+	var result []string
+	for name, value := range request.Transform.Env {
+		result = append(result, fmt.Sprintf("%s=%s", name, value))
+	}
+	if request.Pipeline != nil {
+		result = append(result, fmt.Sprintf("%s=%s", client.PPSPipelineNameEnv, request.Pipeline.Name))
+	}
+	for _, input := range data {
+		result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(client.PPSInputPrefix, input.Name, input.Path)))
+	}
+	return result
+}
+
+// copyTree copies the file or directory at src to dst. If emptyFiles is set,
+// files are created at dst but their content isn't copied.
+func copyTree(src string, dst string, emptyFiles bool) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0777)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0777); err != nil {
+			return err
+		}
+		return copyFile(path, target, emptyFiles)
+	})
+}
+
+func copyFile(src string, dst string, empty bool) (retErr error) {
+	w, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := w.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	if empty {
+		return nil
+	}
+	r, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func datumString(data []*Input) string {
+	var files []string
+	for _, input := range data {
+		files = append(files, input.Path)
+	}
+	return strings.Join(files, ", ")
+}
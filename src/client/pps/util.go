@@ -32,6 +32,8 @@ func InputName(input *Input) string {
 		return input.Atom.Name
 	case input.Pfs != nil:
 		return input.Pfs.Name
+	case input.Object != nil:
+		return input.Object.Name
 	case input.Cross != nil:
 		if len(input.Cross) > 0 {
 			return InputName(input.Cross[0])
@@ -72,7 +74,7 @@ func InputBranches(input *Input) []*pfs.Branch {
 		if input.Pfs != nil {
 			result = append(result, &pfs.Branch{
 				Repo: &pfs.Repo{Name: input.Pfs.Repo},
-				Name: input.Pfs.Branch,
+				Name: PFSInputBranch(input.Pfs),
 			})
 		}
 		if input.Cron != nil {
@@ -84,13 +86,22 @@ func InputBranches(input *Input) []*pfs.Branch {
 		if input.Git != nil {
 			result = append(result, &pfs.Branch{
 				Repo: &pfs.Repo{Name: input.Git.Name},
-				Name: input.Atom.Branch,
+				Name: input.Git.Branch,
 			})
 		}
 	})
 	return result
 }
 
+// PFSInputBranch returns the branch of the PFS input 'input' that its
+// pipeline processes, which is its trigger branch if it's deferred.
+func PFSInputBranch(input *PFSInput) string {
+	if input.Deferred != nil {
+		return input.Deferred.Branch
+	}
+	return input.Branch
+}
+
 // ValidateGitCloneURL returns an error if the provided URL is invalid
 func ValidateGitCloneURL(url string) error {
 	exampleURL := "https://github.com/org/foo.git"
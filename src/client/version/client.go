@@ -2,6 +2,8 @@ package version
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 
 	pb "github.com/pachyderm/pachyderm/src/client/version/versionpb"
 )
@@ -32,6 +34,70 @@ var (
 	}
 )
 
+// ErrIncompatible is returned by CheckCompatible if a client and server's
+// versions are incompatible
+type ErrIncompatible struct {
+	Client *pb.Version
+	Server *pb.Version
+}
+
+func (e ErrIncompatible) Error() string {
+	return fmt.Sprintf("client version %s is incompatible with pachd version %s", PrettyPrintVersion(e.Client), PrettyPrintVersion(e.Server))
+}
+
+// CheckCompatible returns an ErrIncompatible if a client with version
+// 'clientVersion' shouldn't be used with a server with version
+// 'serverVersion'. Versions with different major versions are never
+// compatible, and versions with different minor versions are only compatible
+// if allowMinorMismatch is set.
+func CheckCompatible(clientVersion *pb.Version, serverVersion *pb.Version, allowMinorMismatch bool) error {
+	if clientVersion.Major != serverVersion.Major ||
+		(clientVersion.Minor != serverVersion.Minor && !allowMinorMismatch) {
+		return ErrIncompatible{Client: clientVersion, Server: serverVersion}
+	}
+	return nil
+}
+
+// CheckWorkerCompatible returns an error if workers with version
+// 'workerVersion' can't run under pachd with version 'pachdVersion'. Workers
+// must have pachd's major version, and a minor version at most one away from
+// pachd's, so that a pipeline can be tested on the next release's workers
+// before the rest of the cluster is upgraded (or kept on the previous
+// release's workers while it is).
+func CheckWorkerCompatible(pachdVersion *pb.Version, workerVersion *pb.Version) error {
+	minorDiff := int64(workerVersion.Minor) - int64(pachdVersion.Minor)
+	if workerVersion.Major != pachdVersion.Major || minorDiff > 1 || minorDiff < -1 {
+		return fmt.Errorf("worker version %s is incompatible with pachd version %s (workers must have the same major version as pachd, and a minor version at most one away)",
+			PrettyPrintVersion(workerVersion), PrettyPrintVersion(pachdVersion))
+	}
+	return nil
+}
+
+var versionRE = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(.*)$`)
+
+// ParseVersion parses a version string, such as "1.2.3" or "1.2.3rc1", as
+// returned by PrettyPrintVersion.
+func ParseVersion(s string) (*pb.Version, error) {
+	match := versionRE.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("invalid version \"%s\", must be of the form \"1.2.3\"", s)
+	}
+	var parts [3]uint32
+	for i := range parts {
+		n, err := strconv.ParseUint(match[i+1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version \"%s\": %v", s, err)
+		}
+		parts[i] = uint32(n)
+	}
+	return &pb.Version{
+		Major:      parts[0],
+		Minor:      parts[1],
+		Micro:      parts[2],
+		Additional: match[4],
+	}, nil
+}
+
 // PrettyPrintVersion returns a version string optionally tagged with metadata.
 // For example: "1.2.3", or "1.2.3rc1" if version.Additional is "rc1".
 func PrettyPrintVersion(version *pb.Version) string {
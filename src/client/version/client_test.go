@@ -0,0 +1,50 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	pb "github.com/pachyderm/pachyderm/src/client/version/versionpb"
+)
+
+func TestCheckCompatible(t *testing.T) {
+	client := &pb.Version{Major: 1, Minor: 8, Micro: 1}
+	require.NoError(t, CheckCompatible(client, &pb.Version{Major: 1, Minor: 8, Micro: 3, Additional: "rc1"}, false))
+
+	// A minor mismatch is only allowed if allowMinorMismatch is set
+	minorMismatch := &pb.Version{Major: 1, Minor: 9, Micro: 0}
+	err := CheckCompatible(client, minorMismatch, false)
+	require.YesError(t, err)
+	require.Equal(t, "client version 1.8.1 is incompatible with pachd version 1.9.0", err.Error())
+	require.NoError(t, CheckCompatible(client, minorMismatch, true))
+
+	// A major mismatch is never allowed
+	majorMismatch := &pb.Version{Major: 2, Minor: 8, Micro: 1}
+	require.YesError(t, CheckCompatible(client, majorMismatch, false))
+	require.YesError(t, CheckCompatible(client, majorMismatch, true))
+}
+
+func TestCheckWorkerCompatible(t *testing.T) {
+	pachd := &pb.Version{Major: 1, Minor: 8, Micro: 1}
+	require.NoError(t, CheckWorkerCompatible(pachd, &pb.Version{Major: 1, Minor: 8, Micro: 4}))
+	require.NoError(t, CheckWorkerCompatible(pachd, &pb.Version{Major: 1, Minor: 9, Micro: 0, Additional: "rc1"}))
+	require.NoError(t, CheckWorkerCompatible(pachd, &pb.Version{Major: 1, Minor: 7, Micro: 5}))
+	require.YesError(t, CheckWorkerCompatible(pachd, &pb.Version{Major: 1, Minor: 10, Micro: 0}))
+	require.YesError(t, CheckWorkerCompatible(pachd, &pb.Version{Major: 1, Minor: 6, Micro: 0}))
+	require.YesError(t, CheckWorkerCompatible(pachd, &pb.Version{Major: 2, Minor: 8, Micro: 1}))
+}
+
+func TestParseVersion(t *testing.T) {
+	for _, v := range []*pb.Version{
+		{Major: 1, Minor: 8, Micro: 1},
+		{Major: 1, Minor: 10, Micro: 12, Additional: "rc1"},
+	} {
+		parsed, err := ParseVersion(PrettyPrintVersion(v))
+		require.NoError(t, err)
+		require.Equal(t, v, parsed)
+	}
+	for _, s := range []string{"", "1.8", "v1.8.1", "1.8.x", "latest"} {
+		_, err := ParseVersion(s)
+		require.YesError(t, err)
+	}
+}
@@ -2,11 +2,16 @@ package cmds
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/admin"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
 
+	units "github.com/docker/go-units"
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/golang/snappy"
 	"github.com/spf13/cobra"
 )
@@ -88,5 +93,112 @@ pachctl restore -u s3://bucket/backup` + codeend,
 			return nil
 		}),
 	}
-	return []*cobra.Command{extract, restore, inspectCluster}
+	migrateStorage := &cobra.Command{
+		Use:   "migrate-storage url",
+		Short: "Move the cluster's data to another object store.",
+		Long: `Move the cluster's data to another object store.
+
+Every object is copied to the object store at url and verified, after which
+the cluster is switched to use it. Pipelines must be stopped first, and pachd
+and the pipelines' workers must be restarted afterwards to start using the
+new object store. The original object store isn't modified. If the migration
+is interrupted, running it again resumes it without copying the objects that
+were already copied.
+` + codestart + `# Move the cluster's data to s3:
+pachctl migrate-storage s3://bucket` + codeend,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return c.MigrateStorage(args[0], func(progress *admin.MigrateStorageProgress) error {
+				if progress.Switched {
+					fmt.Printf("Migrated %d objects (copied %d, %s) and switched the cluster to %s.\n",
+						progress.Objects, progress.Copied, units.BytesSize(float64(progress.Bytes)), args[0])
+					fmt.Println("Restart pachd and the pipelines' workers to start using it.")
+					return nil
+				}
+				fmt.Printf("Migrated %d objects (copied %d, %s)\n",
+					progress.Objects, progress.Copied, units.BytesSize(float64(progress.Bytes)))
+				return nil
+			})
+		}),
+	}
+	exportPipeline := &cobra.Command{
+		Use:   "export-pipeline pipeline-name",
+		Short: "Export a single pipeline to stdout.",
+		Long: `Export a single pipeline to stdout, so that it can be recreated on another cluster with import-pipeline.
+
+The export contains the pipeline's spec, the ACL of its output repo and whether
+it's stopped, but not the pipeline's data.
+` + codestart + `# Promote a pipeline from a dev cluster to a prod cluster:
+pachctl export-pipeline foo >foo.json
+# (after switching to the prod cluster)
+pachctl import-pipeline -f foo.json` + codeend,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			c, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			export, err := c.ExportPipeline(args[0])
+			if err != nil {
+				return err
+			}
+			marshaller := &jsonpb.Marshaler{Indent: "  "}
+			if err := marshaller.Marshal(os.Stdout, export); err != nil {
+				return err
+			}
+			fmt.Println()
+			return nil
+		}),
+	}
+	var exportPath string
+	var repoMappings []string
+	importPipeline := &cobra.Command{
+		Use:   "import-pipeline",
+		Short: "Recreate a pipeline from the output of export-pipeline.",
+		Long: `Recreate a pipeline from the output of export-pipeline.
+
+The pipeline's input repos must exist on this cluster. If they're named
+differently than on the cluster that the pipeline was exported from, they can
+be replaced with --repo. The pipeline is created stopped, and started once its
+ACL is restored, unless it was stopped when it was exported.
+` + codestart + `# Import a pipeline, reading from the repo "prod-images" instead of "images":
+pachctl import-pipeline -f foo.json --repo images=prod-images` + codeend,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
+			repos := make(map[string]string)
+			for _, mapping := range repoMappings {
+				parts := strings.SplitN(mapping, "=", 2)
+				if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return fmt.Errorf("invalid --repo %q, expected old-repo=new-repo", mapping)
+				}
+				repos[parts[0]] = parts[1]
+			}
+			var r io.Reader = os.Stdin
+			if exportPath != "-" {
+				f, err := os.Open(exportPath)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := f.Close(); err != nil && retErr == nil {
+						retErr = err
+					}
+				}()
+				r = f
+			}
+			export := &admin.PipelineExport{}
+			if err := jsonpb.Unmarshal(r, export); err != nil {
+				return fmt.Errorf("malformed pipeline export: %v", err)
+			}
+			c, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return c.ImportPipeline(export, repos)
+		}),
+	}
+	importPipeline.Flags().StringVarP(&exportPath, "file", "f", "-", "The file containing the exported pipeline. - reads from stdin.")
+	importPipeline.Flags().StringSliceVar(&repoMappings, "repo", nil, "Replace an input repo of the pipeline with another repo, as old-repo=new-repo; may be repeated.")
+	return []*cobra.Command{extract, restore, inspectCluster, migrateStorage, exportPipeline, importPipeline}
 }
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"math/rand"
@@ -115,6 +116,79 @@ func TestExtractRestore(t *testing.T) {
 	require.Equal(t, 1, len(bis))
 }
 
+func TestExportImportPipeline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestExportImportPipeline_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestExportImportPipeline")
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{
+			fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+		},
+		nil,
+		client.NewPFSInput(dataRepo, "/*"),
+		"",
+		false,
+	))
+	require.NoError(t, c.StopPipeline(pipeline))
+	export, err := c.ExportPipeline(pipeline)
+	require.NoError(t, err)
+	require.True(t, export.Stopped)
+
+	// Importing the pipeline into an empty cluster fails, as its input repo
+	// doesn't exist
+	require.NoError(t, c.DeleteAll())
+	err = c.ImportPipeline(export, nil)
+	require.YesError(t, err)
+	require.Matches(t, dataRepo, err.Error())
+
+	// Import the pipeline with its input repo renamed
+	otherRepo := tu.UniqueString("TestExportImportPipeline_other")
+	require.NoError(t, c.CreateRepo(otherRepo))
+	_, err = c.PutFile(otherRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.ImportPipeline(export, map[string]string{dataRepo: otherRepo}))
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.True(t, pipelineInfo.Stopped)
+	require.Equal(t, otherRepo, pipelineInfo.Input.Pfs.Repo)
+	require.Equal(t, dataRepo, pipelineInfo.Input.Pfs.Name)
+	// It was created stopped, so it hasn't processed the input repo's commit
+	commitInfos, err := c.ListCommit(pipeline, "", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(commitInfos))
+
+	require.NoError(t, c.StartPipeline(pipeline))
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(otherRepo, "master")}, nil)
+	require.NoError(t, err)
+	commitInfos = collectCommitInfos(t, commitIter)
+	require.Equal(t, 1, len(commitInfos))
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(pipeline, "master", "file", 0, 0, &buf))
+	require.Equal(t, "foo", buf.String())
+
+	// A pipeline that wasn't stopped is started once it's imported, and its
+	// Atom inputs are renamed like PFS inputs
+	export.Stopped = false
+	export.Pipeline.Input = &pps.Input{Atom: &pps.AtomInput{Repo: dataRepo, Glob: "/*"}}
+	require.NoError(t, c.DeletePipeline(pipeline, false))
+	require.NoError(t, c.ImportPipeline(export, map[string]string{dataRepo: otherRepo}))
+	pipelineInfo, err = c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.False(t, pipelineInfo.Stopped)
+	require.Equal(t, otherRepo, pipelineInfo.Input.Atom.Repo)
+	require.Equal(t, dataRepo, pipelineInfo.Input.Atom.Name)
+}
+
 func TestExtractRestoreHeadlessBranches(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
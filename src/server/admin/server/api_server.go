@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"path"
 	"sync"
 	"time"
 
+	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/golang/snappy"
 	"golang.org/x/net/context"
 
@@ -28,6 +31,9 @@ type apiServer struct {
 	address        string
 	pachClient     *client.APIClient
 	pachClientOnce sync.Once
+	storageRoot    string
+	etcdClient     *etcd.Client
+	etcdPrefix     string
 	clusterInfo    *admin.ClusterInfo
 }
 
@@ -107,8 +113,11 @@ func (a *apiServer) Extract(request *admin.ExtractRequest, extractServer admin.A
 			}
 			if err := handleOp(&admin.Op{Op1_8: &admin.Op1_8{
 				Repo: &pfs.CreateRepoRequest{
-					Repo:        ri.Repo,
-					Description: ri.Description,
+					Repo:            ri.Repo,
+					Description:     ri.Description,
+					RecommendedGlob: ri.RecommendedGlob,
+					MaxCommitFiles:  ri.MaxCommitFiles,
+					MaxCommitBytes:  ri.MaxCommitBytes,
 				}},
 			}); err != nil {
 				return err
@@ -258,25 +267,53 @@ func sortPipelineInfos(pis []*pps.PipelineInfo) []*pps.PipelineInfo {
 
 func pipelineInfoToRequest(pi *pps.PipelineInfo) *pps.CreatePipelineRequest {
 	return &pps.CreatePipelineRequest{
-		Pipeline:           pi.Pipeline,
-		Transform:          pi.Transform,
-		ParallelismSpec:    pi.ParallelismSpec,
-		Egress:             pi.Egress,
-		OutputBranch:       pi.OutputBranch,
-		ScaleDownThreshold: pi.ScaleDownThreshold,
-		ResourceRequests:   pi.ResourceRequests,
-		ResourceLimits:     pi.ResourceLimits,
-		Input:              pi.Input,
-		Description:        pi.Description,
-		CacheSize:          pi.CacheSize,
-		EnableStats:        pi.EnableStats,
-		Batch:              pi.Batch,
-		MaxQueueSize:       pi.MaxQueueSize,
-		Service:            pi.Service,
-		ChunkSpec:          pi.ChunkSpec,
-		DatumTimeout:       pi.DatumTimeout,
-		JobTimeout:         pi.JobTimeout,
-		Salt:               pi.Salt,
+		Pipeline:            pi.Pipeline,
+		Transform:           pi.Transform,
+		ParallelismSpec:     pi.ParallelismSpec,
+		Egress:              pi.Egress,
+		OutputBranch:        pi.OutputBranch,
+		ScaleDownThreshold:  pi.ScaleDownThreshold,
+		ResourceRequests:    pi.ResourceRequests,
+		ResourceLimits:      pi.ResourceLimits,
+		Input:               pi.Input,
+		Description:         pi.Description,
+		CacheSize:           pi.CacheSize,
+		EnableStats:         pi.EnableStats,
+		Batch:               pi.Batch,
+		MaxQueueSize:        pi.MaxQueueSize,
+		Service:             pi.Service,
+		ChunkSpec:           pi.ChunkSpec,
+		DatumTimeout:        pi.DatumTimeout,
+		JobTimeout:          pi.JobTimeout,
+		Salt:                pi.Salt,
+		StatsRetention:      pi.StatsRetention,
+		DisruptionBudget:    pi.DisruptionBudget,
+		DatumPriority:       pi.DatumPriority,
+		MaxDatumCrashes:     pi.MaxDatumCrashes,
+		InfraFailureRetries: pi.InfraFailureRetries,
+		Finalizer:           pi.Finalizer,
+		OutputSchema:        pi.OutputSchema,
+		UploadTries:         pi.UploadTries,
+		StageConcurrency:    pi.StageConcurrency,
+		DeadLetterBranch:    pi.DeadLetterBranch,
+		MaxDatums:           pi.MaxDatums,
+		Debounce:            pi.Debounce,
+		WorkloadIdentity:    pi.WorkloadIdentity,
+		MaxOutputBytes:      pi.MaxOutputBytes,
+		HealthCheck:         pi.HealthCheck,
+		DatumsPerWorker:     pi.DatumsPerWorker,
+		Annotations:         pi.Annotations,
+		SkipUnchangedOutput: pi.SkipUnchangedOutput,
+		LogRetention:        pi.LogRetention,
+		OutputPermissions:   pi.OutputPermissions,
+		OutputValidation:    pi.OutputValidation,
+		WorkerVersion:       pi.WorkerVersion,
+		OutputPartition:     pi.OutputPartition,
+		IdleScaleDown:       pi.IdleScaleDown,
+		OrderedMerge:        pi.OrderedMerge,
+		OOMRetry:            pi.OOMRetry,
+		Preemption:          pi.Preemption,
+		EmptyInput:          pi.EmptyInput,
 	}
 }
 
@@ -372,6 +409,122 @@ func (a *apiServer) Restore(restoreServer admin.API_RestoreServer) (retErr error
 	}
 }
 
+const (
+	// migrateProgressInterval is the number of objects migrated between the
+	// progress messages that MigrateStorage sends
+	migrateProgressInterval = 1000
+	// migrationLeaseTTL is the TTL, in seconds, of the lease on the fence that
+	// stops objects from being written during a migration
+	migrationLeaseTTL = 30
+)
+
+func (a *apiServer) MigrateStorage(request *admin.MigrateStorageRequest, migrateServer admin.API_MigrateStorageServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	ctx := migrateServer.Context()
+	url, err := obj.ParseURL(request.URL)
+	if err != nil {
+		return fmt.Errorf("error parsing url %v: %v", request.URL, err)
+	}
+	if url.Object != "" {
+		return fmt.Errorf("url %v must be an object store, without a path", request.URL)
+	}
+	if request.URL == os.Getenv(obj.StorageURLEnvVar) {
+		return fmt.Errorf("the cluster's data is already stored in %v", request.URL)
+	}
+	src, err := obj.NewClientFromEnv(ctx, a.storageRoot)
+	if err != nil {
+		return err
+	}
+	srcRoot, err := obj.StorageRootFromEnv()
+	if err != nil {
+		return err
+	}
+	dst, err := obj.NewClientFromURLAndSecret(ctx, url)
+	if err != nil {
+		return err
+	}
+	dstRoot := obj.StorageRootForURL(a.storageRoot, url)
+
+	// Stop objects from being written while the migration runs, so that
+	// every object is copied. The fence is leased, so that writes resume if
+	// this pachd dies before the migration finishes.
+	migrationKey := path.Join(a.etcdPrefix, obj.StorageMigrationKey)
+	lease, err := a.etcdClient.Grant(ctx, migrationLeaseTTL)
+	if err != nil {
+		return fmt.Errorf("error granting lease: %v", err)
+	}
+	defer func() {
+		// Revoking the lease deletes the fence, unless the migration finished
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := a.etcdClient.Revoke(ctx, lease.ID); err != nil && retErr == nil {
+			retErr = fmt.Errorf("error revoking lease: %v", err)
+		}
+	}()
+	if _, err := a.etcdClient.KeepAlive(ctx, lease.ID); err != nil {
+		return fmt.Errorf("error with KeepAlive: %v", err)
+	}
+	resp, err := a.etcdClient.Txn(ctx).If(
+		etcd.Compare(etcd.CreateRevision(migrationKey), "=", 0),
+	).Then(
+		etcd.OpPut(migrationKey, request.URL, etcd.WithLease(lease.ID)),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("the cluster's data is already being migrated")
+	}
+
+	// Workers write to object storage directly, and won't switch to the new
+	// object store until they're restarted, so they must be stopped (checked
+	// once the fence is up, so that jobs that start later can't write objects)
+	pachClient := a.getPachClient().WithCtx(ctx)
+	pipelineInfos, err := pachClient.ListPipeline()
+	if err != nil {
+		return err
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		if !pipelineInfo.Stopped {
+			return fmt.Errorf("pipeline %s must be stopped before migrating the cluster's data", pipelineInfo.Pipeline.Name)
+		}
+	}
+
+	// Writes are only acknowledged if they finished before the fence was
+	// put, so one pass copies every object that the cluster refers to
+	stats, err := obj.Migrate(src, srcRoot, dst, dstRoot, func(stats *obj.MigrateStats) error {
+		if stats.Objects%migrateProgressInterval != 0 {
+			return nil
+		}
+		return migrateServer.Send(&admin.MigrateStorageProgress{
+			Objects: stats.Objects,
+			Copied:  stats.Copied,
+			Bytes:   stats.Bytes,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	// Switch the cluster to the new object store. This is a single etcd
+	// transaction, so the cluster either uses the old object store or (once
+	// pachd and the workers are restarted) the new one, which has every
+	// object. pachds that haven't been restarted can't write objects, as
+	// they'd write them to the old object store.
+	if _, err := a.etcdClient.Txn(ctx).Then(
+		etcd.OpPut(path.Join(a.etcdPrefix, obj.StorageURLKey), request.URL),
+		etcd.OpDelete(migrationKey),
+	).Commit(); err != nil {
+		return fmt.Errorf("could not switch the cluster to %v: %v", request.URL, err)
+	}
+	return migrateServer.Send(&admin.MigrateStorageProgress{
+		Objects:  stats.Objects,
+		Copied:   stats.Copied,
+		Bytes:    stats.Bytes,
+		Switched: true,
+	})
+}
+
 func (a *apiServer) getPachClient() *client.APIClient {
 	a.pachClientOnce.Do(func() {
 		var err error
@@ -1,6 +1,7 @@
 package server
 
 import (
+	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/pachyderm/pachyderm/src/client/admin"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 )
@@ -11,10 +12,13 @@ type APIServer interface {
 }
 
 // NewAPIServer returns a new admin.APIServer
-func NewAPIServer(address string, clusterInfo *admin.ClusterInfo) APIServer {
+func NewAPIServer(address string, storageRoot string, etcdClient *etcd.Client, etcdPrefix string, clusterInfo *admin.ClusterInfo) APIServer {
 	return &apiServer{
 		Logger:      log.NewLogger("admin.API"),
 		address:     address,
+		storageRoot: storageRoot,
+		etcdClient:  etcdClient,
+		etcdPrefix:  etcdPrefix,
 		clusterInfo: clusterInfo,
 	}
 }
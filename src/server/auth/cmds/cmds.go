@@ -256,6 +256,7 @@ func WhoamiCmd() *cobra.Command {
 // CheckCmd returns a cobra command that sends an "Authorize" RPC to Pachd, to
 // determine whether the specified user has access to the specified repo.
 func CheckCmd() *cobra.Command {
+	var branch string
 	check := &cobra.Command{
 		Use:   "check (none|reader|writer|owner) repo",
 		Short: "Check whether you have reader/writer/etc-level access to 'repo'",
@@ -276,8 +277,9 @@ func CheckCmd() *cobra.Command {
 				return fmt.Errorf("could not connect: %v", err)
 			}
 			resp, err := c.Authorize(c.Ctx(), &auth.AuthorizeRequest{
-				Repo:  repo,
-				Scope: scope,
+				Repo:   repo,
+				Scope:  scope,
+				Branch: branch,
 			})
 			if err != nil {
 				return grpcutil.ScrubGRPC(err)
@@ -286,6 +288,9 @@ func CheckCmd() *cobra.Command {
 			return nil
 		}),
 	}
+	check.Flags().StringVarP(&branch, "branch", "b", "", "check your access "+
+		"to this branch of 'repo', which may be granted by branch-scoped ACL "+
+		"entries as well as by the repo's ACL")
 	return check
 }
 
@@ -316,7 +321,7 @@ func GetCmd() *cobra.Command {
 					return grpcutil.ScrubGRPC(err)
 				}
 				t := template.Must(template.New("ACLEntries").Parse(
-					"{{range .}}{{.Username }}: {{.Scope}}\n{{end}}"))
+					"{{range .}}{{.Username }}: {{.Scope}}{{if .Branch}} (branch {{.Branch}}){{end}}\n{{end}}"))
 				return t.Execute(os.Stdout, resp.Entries)
 			}
 			// Get User's scope on an acl
@@ -338,6 +343,7 @@ func GetCmd() *cobra.Command {
 // SetScopeCmd returns a cobra command that lets a user set the level of access
 // that another user has to a repo
 func SetScopeCmd() *cobra.Command {
+	var branch string
 	setScope := &cobra.Command{
 		Use:   "set username (none|reader|writer|owner) repo",
 		Short: "Set the scope of access that 'username' has to 'repo'",
@@ -347,8 +353,12 @@ func SetScopeCmd() *cobra.Command {
 			"way (the default). Similarly, 'pachctl auth set github-alice reader " +
 			"private-data' would let \"github-alice\" read from \"private-data\" but " +
 			"not create commits (writer) or modify the repo's access permissions " +
-			"(owner). Currently all Pachyderm authentication uses GitHub OAuth, so " +
-			"'username' must be a GitHub username",
+			"(owner). With --branch, the scope applies only to that branch of " +
+			"'repo', e.g. 'pachctl auth set github-alice writer data --branch dev' " +
+			"lets \"github-alice\" write to the \"dev\" branch of \"data\" " +
+			"without letting them write to its other branches. Currently all " +
+			"Pachyderm authentication uses GitHub OAuth, so 'username' must be a " +
+			"GitHub username",
 		Run: cmdutil.RunFixedArgs(3, func(args []string) error {
 			scope, err := auth.ParseScope(args[1])
 			if err != nil {
@@ -363,10 +373,14 @@ func SetScopeCmd() *cobra.Command {
 				Repo:     repo,
 				Scope:    scope,
 				Username: username,
+				Branch:   branch,
 			})
 			return grpcutil.ScrubGRPC(err)
 		}),
 	}
+	setScope.Flags().StringVarP(&branch, "branch", "b", "", "set the scope "+
+		"of access that 'username' has to this branch of 'repo' only (only "+
+		"reader and writer access can be granted to a branch)")
 	return setScope
 }
 
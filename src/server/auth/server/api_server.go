@@ -1115,7 +1115,12 @@ func (a *apiServer) Authorize(ctx context.Context, req *authclient.AuthorizeRequ
 		return nil, fmt.Errorf("error getting ACL for repo \"%s\": %v", req.Repo, err)
 	}
 
-	scope, err := a.getScope(ctx, callerInfo.Subject, &acl)
+	var scope authclient.Scope
+	if req.Branch != "" {
+		scope, err = a.getBranchScope(ctx, callerInfo.Subject, &acl, req.Branch)
+	} else {
+		scope, err = a.getScope(ctx, callerInfo.Subject, &acl)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1168,6 +1173,9 @@ func validateSetScopeRequest(ctx context.Context, req *authclient.SetScopeReques
 	if req.Repo == "" {
 		return fmt.Errorf("invalid request: must set repo")
 	}
+	if req.Branch != "" && req.Scope == authclient.Scope_OWNER {
+		return fmt.Errorf("invalid request: branch-scoped ACL entries can't grant OWNER access")
+	}
 	return nil
 }
 
@@ -1278,12 +1286,27 @@ func (a *apiServer) SetScope(ctx context.Context, req *authclient.SetScopeReques
 		if err != nil {
 			return err
 		}
+		entries := acl.Entries
+		if req.Branch != "" {
+			if acl.Branches == nil {
+				acl.Branches = make(map[string]*authclient.BranchACL)
+			}
+			if acl.Branches[req.Branch] == nil {
+				acl.Branches[req.Branch] = &authclient.BranchACL{
+					Entries: make(map[string]authclient.Scope),
+				}
+			}
+			entries = acl.Branches[req.Branch].Entries
+		}
 		if req.Scope != authclient.Scope_NONE {
-			acl.Entries[principal] = req.Scope
+			entries[principal] = req.Scope
 		} else {
-			delete(acl.Entries, principal)
+			delete(entries, principal)
 		}
-		if len(acl.Entries) == 0 {
+		if req.Branch != "" && len(entries) == 0 {
+			delete(acl.Branches, req.Branch)
+		}
+		if len(acl.Entries) == 0 && len(acl.Branches) == 0 {
 			return acls.Delete(req.Repo)
 		}
 		return acls.Put(req.Repo, &acl)
@@ -1317,6 +1340,28 @@ func (a *apiServer) getScope(ctx context.Context, subject string, acl *authclien
 	return scope, nil
 }
 
+// getBranchScope is like getScope, but returns the access that 'subject' has
+// to the branch 'branch' of the ACL's repo, which is the greater of its access
+// to the repo and any access granted by the ACL's entries for 'branch'
+func (a *apiServer) getBranchScope(ctx context.Context, subject string, acl *authclient.ACL, branch string) (authclient.Scope, error) {
+	scope, err := a.getScope(ctx, subject, acl)
+	if err != nil {
+		return authclient.Scope_NONE, err
+	}
+	branchACL, ok := acl.Branches[branch]
+	if !ok {
+		return scope, nil
+	}
+	branchScope, err := a.getScope(ctx, subject, &authclient.ACL{Entries: branchACL.Entries})
+	if err != nil {
+		return authclient.Scope_NONE, err
+	}
+	if scope < branchScope {
+		scope = branchScope
+	}
+	return scope, nil
+}
+
 func (a *apiServer) GetScope(ctx context.Context, req *authclient.GetScopeRequest) (resp *authclient.GetScopeResponse, retErr error) {
 	a.LogReq(req)
 	defer func(start time.Time) { a.LogResp(req, resp, retErr, time.Since(start)) }(time.Now())
@@ -1436,6 +1481,15 @@ func (a *apiServer) GetACL(ctx context.Context, req *authclient.GetACLRequest) (
 			Scope:    scope,
 		})
 	}
+	for branch, branchACL := range acl.Branches {
+		for user, scope := range branchACL.Entries {
+			resp.Entries = append(resp.Entries, &authclient.ACLEntry{
+				Username: user,
+				Scope:    scope,
+				Branch:   branch,
+			})
+		}
+	}
 	// For now, no access is require to read a repo's ACL
 	// https://github.com/pachyderm/pachyderm/issues/2353
 	return resp, nil
@@ -1473,7 +1527,10 @@ func (a *apiServer) SetACL(ctx context.Context, req *authclient.SetACLRequest) (
 		newACL.Entries = make(map[string]authclient.Scope)
 	}
 	for _, entry := range req.Entries {
-		user, scope := entry.Username, entry.Scope
+		user, scope, branch := entry.Username, entry.Scope, entry.Branch
+		if branch != "" && scope == authclient.Scope_OWNER {
+			return nil, fmt.Errorf("invalid request: branch-scoped ACL entries can't grant OWNER access")
+		}
 		eg.Go(func() error {
 			principal, err := a.canonicalizeSubject(ctx, user)
 			if err != nil {
@@ -1481,7 +1538,19 @@ func (a *apiServer) SetACL(ctx context.Context, req *authclient.SetACLRequest) (
 			}
 			aclMu.Lock()
 			defer aclMu.Unlock()
-			newACL.Entries[principal] = scope
+			if branch == "" {
+				newACL.Entries[principal] = scope
+				return nil
+			}
+			if newACL.Branches == nil {
+				newACL.Branches = make(map[string]*authclient.BranchACL)
+			}
+			if newACL.Branches[branch] == nil {
+				newACL.Branches[branch] = &authclient.BranchACL{
+					Entries: make(map[string]authclient.Scope),
+				}
+			}
+			newACL.Branches[branch].Entries[principal] = scope
 			return nil
 		})
 	}
@@ -1558,7 +1627,7 @@ func (a *apiServer) SetACL(ctx context.Context, req *authclient.SetACLRequest) (
 		}
 
 		// Set new ACL
-		if len(newACL.Entries) == 0 {
+		if len(newACL.Entries) == 0 && len(newACL.Branches) == 0 {
 			return acls.Delete(req.Repo)
 		}
 		return acls.Put(req.Repo, newACL)
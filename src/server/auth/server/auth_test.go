@@ -1447,6 +1447,121 @@ func TestUnprivilegedUserCannotMakeSelfOwner(t *testing.T) {
 	require.ElementsEqual(t, entries(alice, "owner"), getACL(t, aliceClient, repo))
 }
 
+// TestBranchScopedACL tests that an ACL entry scoped to one branch of a repo
+// grants access to that branch only
+func TestBranchScopedACL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	alice, bob := tu.UniqueString("alice"), tu.UniqueString("bob")
+	aliceClient, bobClient := getPachClient(t, alice), getPachClient(t, bob)
+
+	// alice creates a repo with a master and dev branch
+	repo := tu.UniqueString("TestBranchScopedACL")
+	require.NoError(t, aliceClient.CreateRepo(repo))
+	_, err := aliceClient.PutFile(repo, "master", "/file", strings.NewReader("1"))
+	require.NoError(t, err)
+	require.NoError(t, aliceClient.CreateBranch(repo, "dev", "master", nil))
+
+	// alice makes bob a reader of the repo, and a writer of 'dev'
+	_, err = aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Repo:     repo,
+		Username: bob,
+		Scope:    auth.Scope_READER,
+	})
+	require.NoError(t, err)
+	_, err = aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Repo:     repo,
+		Username: bob,
+		Scope:    auth.Scope_WRITER,
+		Branch:   "dev",
+	})
+	require.NoError(t, err)
+	resp, err := aliceClient.GetACL(aliceClient.Ctx(), &auth.GetACLRequest{Repo: repo})
+	require.NoError(t, err)
+	var branchEntries []*auth.ACLEntry
+	for _, e := range resp.Entries {
+		if e.Branch != "" {
+			branchEntries = append(branchEntries, e)
+		}
+	}
+	require.Equal(t, 1, len(branchEntries))
+	require.Equal(t, "dev", branchEntries[0].Branch)
+	require.Equal(t, auth.Scope_WRITER, branchEntries[0].Scope)
+
+	// bob can write to 'dev', both by branch name and by commit ID
+	_, err = bobClient.PutFile(repo, "dev", "/file", strings.NewReader("2"))
+	require.NoError(t, err)
+	commit, err := bobClient.StartCommit(repo, "dev")
+	require.NoError(t, err)
+	_, err = bobClient.PutFile(repo, commit.ID, "/file", strings.NewReader("3"))
+	require.NoError(t, err)
+	require.NoError(t, bobClient.FinishCommit(repo, commit.ID))
+	authorizeResp, err := bobClient.Authorize(bobClient.Ctx(), &auth.AuthorizeRequest{
+		Repo:   repo,
+		Scope:  auth.Scope_WRITER,
+		Branch: "dev",
+	})
+	require.NoError(t, err)
+	require.True(t, authorizeResp.Authorized)
+
+	// bob can't write to 'master', or to the repo as a whole
+	masterCommits := CommitCnt(t, aliceClient, repo)
+	_, err = bobClient.PutFile(repo, "master", "/file", strings.NewReader("4"))
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	_, err = bobClient.StartCommit(repo, "master")
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	require.YesError(t, bobClient.CreateBranch(repo, "master", "dev", nil))
+	require.Equal(t, masterCommits, CommitCnt(t, aliceClient, repo))
+
+	// bob can't delete a commit that's in both 'dev' and 'master', even
+	// though he can write to 'dev'
+	masterInfo, err := aliceClient.InspectCommit(repo, "master")
+	require.NoError(t, err)
+	require.NoError(t, aliceClient.CreateBranch(repo, "dev", masterInfo.Commit.ID, nil))
+	err = bobClient.DeleteCommit(repo, masterInfo.Commit.ID)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	_, err = aliceClient.InspectCommit(repo, masterInfo.Commit.ID)
+	require.NoError(t, err)
+	require.Equal(t, masterCommits, CommitCnt(t, aliceClient, repo))
+	for _, branch := range []string{"master", ""} {
+		authorizeResp, err = bobClient.Authorize(bobClient.Ctx(), &auth.AuthorizeRequest{
+			Repo:   repo,
+			Scope:  auth.Scope_WRITER,
+			Branch: branch,
+		})
+		require.NoError(t, err)
+		require.False(t, authorizeResp.Authorized)
+	}
+
+	// branch-scoped entries can't grant OWNER
+	_, err = aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Repo:     repo,
+		Username: bob,
+		Scope:    auth.Scope_OWNER,
+		Branch:   "dev",
+	})
+	require.YesError(t, err)
+
+	// removing bob's branch entry revokes bob's access to 'dev'
+	_, err = aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Repo:     repo,
+		Username: bob,
+		Scope:    auth.Scope_NONE,
+		Branch:   "dev",
+	})
+	require.NoError(t, err)
+	_, err = bobClient.PutFile(repo, "dev", "/file", strings.NewReader("5"))
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	require.ElementsEqual(t,
+		entries(alice, "owner", bob, "reader"), getACL(t, aliceClient, repo))
+}
+
 func TestGetScopeRequiresReader(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -1501,6 +1616,47 @@ func TestListRepoNotLoggedInError(t *testing.T) {
 	require.Matches(t, "no authentication token", err.Error())
 }
 
+// TestDiskUsage tests that users can only see the disk usage of repos that
+// they can read
+func TestDiskUsage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	alice, bob := tu.UniqueString("alice"), tu.UniqueString("bob")
+	aliceClient, bobClient := getPachClient(t, alice), getPachClient(t, bob)
+
+	// alice creates two repos, and bob can read one of them
+	readable, private := tu.UniqueString("TestDiskUsage"), tu.UniqueString("TestDiskUsage")
+	for _, repo := range []string{readable, private} {
+		require.NoError(t, aliceClient.CreateRepo(repo))
+		_, err := aliceClient.PutFile(repo, "master", "/file", strings.NewReader("data"))
+		require.NoError(t, err)
+	}
+	_, err := aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Username: bob,
+		Scope:    auth.Scope_READER,
+		Repo:     readable,
+	})
+	require.NoError(t, err)
+
+	// bob only sees the usage of the repo that bob can read
+	repoUsages, err := bobClient.DiskUsage("")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(repoUsages))
+	require.Equal(t, readable, repoUsages[0].Repo.Name)
+	_, err = bobClient.DiskUsage(readable)
+	require.NoError(t, err)
+	_, err = bobClient.DiskUsage(private)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+
+	// alice sees both
+	repoUsages, err = aliceClient.DiskUsage("")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(repoUsages))
+}
+
 // TestListRepoNoAuthInfoIfDeactivated tests that if auth isn't activated, then
 // ListRepo returns RepoInfos where AuthInfo isn't set (i.e. is nil)
 func TestListRepoNoAuthInfoIfDeactivated(t *testing.T) {
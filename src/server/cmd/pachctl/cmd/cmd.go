@@ -163,6 +163,15 @@ func PachctlCmd() (*cobra.Command, error) {
 
 Environment variables:
   ADDRESS=<host>:<port>, the pachd server to connect to (e.g. 127.0.0.1:30650).
+  PACH_VERSION_CHECK=strict|relaxed|none, how pachctl checks that pachd's version
+    is compatible with its own. "strict" (the default) requires the same major
+    and minor version, "relaxed" only warns if the minor versions differ, and
+    "none" disables the check.
+  PACH_CIRCUIT_BREAKER_THRESHOLD=<n>, the number of consecutive calls that must
+    fail because pachd is unavailable before pachctl stops calling pachd for a
+    cooldown, failing calls fast instead (default 5, 0 disables this).
+  PACH_CIRCUIT_BREAKER_COOLDOWN=<duration>, how long pachctl fails calls fast
+    before probing pachd again (default 10s).
 `,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			if !verbose {
@@ -268,9 +277,9 @@ Environment variables:
 				if err != nil {
 					return fmt.Errorf("could not parse timeout duration %q: %v", timeout, err)
 				}
-				pachClient, err = client.NewOnUserMachine(false, "user", client.WithDialTimeout(timeout))
+				pachClient, err = client.NewOnUserMachine(false, "user", client.WithDialTimeout(timeout), client.WithVersionCheck(client.VersionCheckNone))
 			} else {
-				pachClient, err = client.NewOnUserMachine(false, "user")
+				pachClient, err = client.NewOnUserMachine(false, "user", client.WithVersionCheck(client.VersionCheckNone))
 			}
 			if err != nil {
 				return err
@@ -2,17 +2,21 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path"
 	"runtime/debug"
 	"runtime/pprof"
 	"strconv"
+	"syscall"
 	"time"
 
 	etcd "github.com/coreos/etcd/clientv3"
 	units "github.com/docker/go-units"
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client"
 	adminclient "github.com/pachyderm/pachyderm/src/client/admin"
 	authclient "github.com/pachyderm/pachyderm/src/client/auth"
@@ -40,9 +44,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/deploy/assets"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/metrics"
 	"github.com/pachyderm/pachyderm/src/server/pkg/netutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/statsd"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	pps_server "github.com/pachyderm/pachyderm/src/server/pps/server"
 	"github.com/pachyderm/pachyderm/src/server/pps/server/githook"
@@ -102,8 +109,64 @@ type appEnv struct {
 	IAMRole               string `env:"IAM_ROLE,default="`
 	ImagePullSecret       string `env:"IMAGE_PULL_SECRET,default="`
 	NoExposeDockerSocket  bool   `env:"NO_EXPOSE_DOCKER_SOCKET,default=false"`
+	WorkerBudget          int64  `env:"WORKER_BUDGET,default=0"`
+	RequireImageDigests   bool   `env:"REQUIRE_IMAGE_DIGESTS,default=false"`
+	MaxJobDatums          int64  `env:"MAX_JOB_DATUMS,default=0"`
+	MaxJobOutputBytes     int64  `env:"MAX_JOB_OUTPUT_BYTES,default=0"`
+	WorkerGracePeriod     int64  `env:"WORKER_GRACE_PERIOD,default=0"`
+	LogRetentionMaxAge    string `env:"LOG_RETENTION_MAX_AGE,default="`
+	LogRetentionMaxBytes  int64  `env:"LOG_RETENTION_MAX_BYTES,default=0"`
 	ExposeObjectAPI       bool   `env:"EXPOSE_OBJECT_API,default=false"`
 	MemoryRequest         string `env:"PACHD_MEMORY_REQUEST,default=1T"`
+	// PutFileConcurrencyLimit limits the number of concurrent etcd
+	// transactions that write put-file records into open commits (0 means no
+	// limit)
+	PutFileConcurrencyLimit int `env:"PUT_FILE_CONCURRENCY_LIMIT,default=100"`
+	// DedupScope is the scope within which identical file content is stored
+	// once: "global" or "repo" (see pfs_server.DedupScopeRepo)
+	DedupScope string `env:"DEDUP_SCOPE,default=global"`
+	// MaxPutFileStreamsPerClient is the most put-file streams that each client
+	// (user, or host if auth isn't active) may have open at once (0 means no
+	// limit). The default is far more than any well-behaved client needs (e.g.
+	// 'pachctl put-file -p' opens a handful), and only stops runaway clients
+	// from exhausting pachd's resources.
+	MaxPutFileStreamsPerClient int `env:"MAX_PUT_FILE_STREAMS_PER_CLIENT,default=1000"`
+	// MaxCommitFiles and MaxCommitBytes are the most files and bytes that a
+	// commit may add or change, relative to its parent, unless its repo sets
+	// its own limits (0 means no limit)
+	MaxCommitFiles int64 `env:"MAX_COMMIT_FILES,default=0"`
+	MaxCommitBytes int64 `env:"MAX_COMMIT_BYTES,default=0"`
+	// PipelineSpecConfigMapSelector, if set, is a label selector for
+	// ConfigMaps in pachd's namespace that hold pipeline specs, which pachd
+	// applies (and re-applies as pipeline updates when they change)
+	PipelineSpecConfigMapSelector string `env:"PIPELINE_SPEC_CONFIGMAP_SELECTOR,default="`
+	// PipelineSpecConfigMapNames, if set, is a comma-separated list of the
+	// only selected ConfigMaps that pachd reads pipeline specs from (and may
+	// update, see assets.AssetOpts.PipelineSpecConfigMapNames)
+	PipelineSpecConfigMapNames string `env:"PIPELINE_SPEC_CONFIGMAP_NAMES,default="`
+	// PipelineSpecConfigMapToken is the auth token of the user that pachd
+	// creates and updates the pipelines in ConfigMaps as, if auth is active
+	PipelineSpecConfigMapToken string `env:"PIPELINE_SPEC_CONFIGMAP_TOKEN,default="`
+	// WorkerRcGCGracePeriod, if set, is how long the worker RCs of deleted
+	// pipelines and of old pipeline versions without unfinished jobs are kept
+	// before they're deleted (e.g. "1h"). If unset, they're kept.
+	WorkerRcGCGracePeriod string `env:"WORKER_RC_GC_GRACE_PERIOD,default="`
+	// PipelineInputCheckInterval is how often pipelines' input repos are
+	// checked, so that pipelines that can no longer read one of them are
+	// failed (e.g. "1m"). If it's empty or 0, they aren't checked.
+	PipelineInputCheckInterval string `env:"PIPELINE_INPUT_CHECK_INTERVAL,default=1m"`
+	// WorkerCPUPerWeight, if set, is the CPU that workers request for each
+	// unit of their pipeline's scheduling weight, if their pipeline doesn't
+	// request CPU itself (e.g. "100m"), so that workers sharing a node get CPU
+	// in proportion to their pipelines' weights
+	WorkerCPUPerWeight string `env:"WORKER_CPU_PER_WEIGHT,default="`
+	// WorkerMaxWeightedCPU, if set, is the most CPU that workers request for
+	// their pipeline's scheduling weight (e.g. "4"). Pipelines whose weight
+	// would have their workers request more are rejected.
+	WorkerMaxWeightedCPU string `env:"WORKER_MAX_WEIGHTED_CPU,default="`
+	// MaxConcurrentJobs is the most jobs that may run at once across all
+	// pipelines. Jobs beyond it wait for a job slot. 0 means no limit.
+	MaxConcurrentJobs int64 `env:"MAX_CONCURRENT_JOBS,default=0"`
 }
 
 func main() {
@@ -132,6 +195,19 @@ func doReadinessCheck(appEnvObj interface{}) error {
 	return pachClient.Health()
 }
 
+// waitForWorkerExit returns once the worker in this sidecar's pod has exited,
+// i.e. once nothing is listening on the worker's port
+func waitForWorkerExit() {
+	for {
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", client.PPSWorkerPort), time.Second)
+		if err != nil {
+			return
+		}
+		conn.Close()
+		time.Sleep(time.Second)
+	}
+}
+
 func doSidecarMode(appEnvObj interface{}) (retErr error) {
 	defer func() {
 		if retErr != nil {
@@ -140,6 +216,20 @@ func doSidecarMode(appEnvObj interface{}) (retErr error) {
 	}()
 	appEnv := appEnvObj.(*appEnv)
 	debug.SetGCPercent(50)
+	if os.Getenv(client.PPSPreemptionEnv) == "true" {
+		// The worker drains when its node is preempted, and needs its sidecar
+		// until it's done, so on SIGTERM the sidecar keeps serving until the
+		// worker has exited (or it's killed at the end of the pod's grace
+		// period)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM)
+		go func() {
+			<-signals
+			waitForWorkerExit()
+			log.Infof("worker has exited after being preempted; exiting")
+			os.Exit(0)
+		}()
+	}
 	go func() {
 		log.Println(http.ListenAndServe(fmt.Sprintf(":%d", appEnv.PProfPort), nil))
 	}()
@@ -171,6 +261,9 @@ func doSidecarMode(appEnvObj interface{}) (retErr error) {
 	if err != nil {
 		return fmt.Errorf("getClusterID: %v", err)
 	}
+	if err := obj.LoadStorageURL(etcdClientV3, appEnv.EtcdPrefix); err != nil {
+		return err
+	}
 	kubeClient, err := getKubeClient(appEnv)
 	if err != nil {
 		return fmt.Errorf("getKubeClient: %v", err)
@@ -179,6 +272,10 @@ func doSidecarMode(appEnvObj interface{}) (retErr error) {
 	if appEnv.Metrics {
 		reporter = metrics.NewReporter(clusterID, kubeClient)
 	}
+	// Sidecars publish the events of the output commits that they finish
+	if err := events.StartFromEnv(); err != nil {
+		log.Printf("error publishing events: %v\n", err)
+	}
 	address, err := netutil.ExternalIP()
 	if err != nil {
 		return fmt.Errorf("ExternalIP: %v", err)
@@ -208,7 +305,7 @@ func doSidecarMode(appEnvObj interface{}) (retErr error) {
 				if err != nil {
 					return fmt.Errorf("units.RAMInBytes: %v", err)
 				}
-				blockAPIServer, err := pfs_server.NewBlockAPIServer(appEnv.StorageRoot, blockCacheBytes, appEnv.StorageBackend, etcdAddress)
+				blockAPIServer, err := pfs_server.NewBlockAPIServer(appEnv.StorageRoot, blockCacheBytes, appEnv.StorageBackend, etcdAddress, appEnv.EtcdPrefix)
 				if err != nil {
 					return fmt.Errorf("pfs.NewBlockAPIServer: %v", err)
 				}
@@ -218,7 +315,7 @@ func doSidecarMode(appEnvObj interface{}) (retErr error) {
 				if err != nil {
 					return err
 				}
-				pfsAPIServer, err := pfs_server.NewAPIServer(address, []string{etcdAddress}, path.Join(appEnv.EtcdPrefix, appEnv.PFSEtcdPrefix), treeCache, appEnv.StorageRoot, memoryRequestBytes)
+				pfsAPIServer, err := pfs_server.NewAPIServer(address, []string{etcdAddress}, path.Join(appEnv.EtcdPrefix, appEnv.PFSEtcdPrefix), treeCache, appEnv.StorageRoot, memoryRequestBytes, pfsAPIServerOptions(appEnv))
 				if err != nil {
 					return fmt.Errorf("pfs.NewAPIServer: %v", err)
 				}
@@ -251,11 +348,23 @@ func doSidecarMode(appEnvObj interface{}) (retErr error) {
 				}
 				eprsclient.RegisterAPIServer(s, enterpriseAPIServer)
 
-				healthclient.RegisterHealthServer(s, health.NewHealthServer())
+				// Every API is registered by the time the health server is, and
+				// they're served as soon as this returns, so the sidecar is ready
+				// (workers wait for this before they start)
+				healthServer := health.NewHealthServer()
+				healthServer.Ready()
+				healthclient.RegisterHealthServer(s, healthServer)
 				debugclient.RegisterDebugServer(s, debugserver.NewDebugServer(
 					"", // no name for pachd servers
 					etcdClientV3,
 					path.Join(appEnv.EtcdPrefix, appEnv.PPSEtcdPrefix),
+					func(ctx context.Context) ([]*pfsclient.CacheInfo, error) {
+						response, err := blockAPIServer.InspectCache(ctx, &types.Empty{})
+						if err != nil {
+							return nil, err
+						}
+						return response.Caches, nil
+					},
 				))
 				return nil
 			},
@@ -301,6 +410,9 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 	if err != nil {
 		return fmt.Errorf("getClusterID: %v", err)
 	}
+	if err := obj.LoadStorageURL(etcdClientV3, appEnv.EtcdPrefix); err != nil {
+		return err
+	}
 	kubeClient, err := getKubeClient(appEnv)
 	if err != nil {
 		return fmt.Errorf("getKubeClient: %v", err)
@@ -368,6 +480,12 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 		}
 		return fmt.Errorf("RunGitHookServer: %v", err)
 	})
+	if err := statsd.StartFromEnv(); err != nil {
+		log.Printf("error pushing metrics to statsd: %v\n", err)
+	}
+	if err := events.StartFromEnv(); err != nil {
+		log.Printf("error publishing events: %v\n", err)
+	}
 	eg.Go(func() error {
 		http.Handle("/metrics", promhttp.Handler())
 		err := http.ListenAndServe(fmt.Sprintf(":%v", assets.PrometheusPort), nil)
@@ -387,7 +505,7 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 					if err != nil {
 						return err
 					}
-					pfsAPIServer, err := pfs_server.NewAPIServer(address, []string{etcdAddress}, path.Join(appEnv.EtcdPrefix, appEnv.PFSEtcdPrefix), treeCache, appEnv.StorageRoot, memoryRequestBytes)
+					pfsAPIServer, err := pfs_server.NewAPIServer(address, []string{etcdAddress}, path.Join(appEnv.EtcdPrefix, appEnv.PFSEtcdPrefix), treeCache, appEnv.StorageRoot, memoryRequestBytes, pfsAPIServerOptions(appEnv))
 					if err != nil {
 						return fmt.Errorf("pfs.NewAPIServer: %v", err)
 					}
@@ -408,6 +526,21 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 						appEnv.IAMRole,
 						appEnv.ImagePullSecret,
 						appEnv.NoExposeDockerSocket,
+						appEnv.WorkerBudget,
+						appEnv.RequireImageDigests,
+						appEnv.MaxJobDatums,
+						appEnv.MaxJobOutputBytes,
+						appEnv.WorkerGracePeriod,
+						appEnv.LogRetentionMaxAge,
+						appEnv.LogRetentionMaxBytes,
+						appEnv.PipelineSpecConfigMapSelector,
+						appEnv.PipelineSpecConfigMapNames,
+						appEnv.PipelineSpecConfigMapToken,
+						appEnv.WorkerRcGCGracePeriod,
+						appEnv.PipelineInputCheckInterval,
+						appEnv.WorkerCPUPerWeight,
+						appEnv.WorkerMaxWeightedCPU,
+						appEnv.MaxConcurrentJobs,
 						reporter,
 					)
 					if err != nil {
@@ -421,7 +554,7 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 						blockAPIServer, err := pfs_server.NewBlockAPIServer(
 							appEnv.StorageRoot,
 							0 /* = blockCacheBytes (disable cache) */, appEnv.StorageBackend,
-							etcdAddress)
+							etcdAddress, appEnv.EtcdPrefix)
 						if err != nil {
 							return fmt.Errorf("pfs.NewBlockAPIServer: %v", err)
 						}
@@ -444,13 +577,22 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 					eprsclient.RegisterAPIServer(s, enterpriseAPIServer)
 
 					deployclient.RegisterAPIServer(s, deployserver.NewDeployServer(kubeClient, kubeNamespace))
-					adminclient.RegisterAPIServer(s, adminserver.NewAPIServer(address, &adminclient.ClusterInfo{ID: clusterID}))
+					adminclient.RegisterAPIServer(s, adminserver.NewAPIServer(address, appEnv.StorageRoot, etcdClientV3, appEnv.EtcdPrefix, &adminclient.ClusterInfo{ID: clusterID}))
 					healthclient.RegisterHealthServer(s, publicHealthServer)
 					versionpb.RegisterAPIServer(s, version.NewAPIServer(version.Version, version.APIServerOptions{}))
 					debugclient.RegisterDebugServer(s, debugserver.NewDebugServer(
 						"", // no name for pachd servers
 						etcdClientV3,
 						path.Join(appEnv.EtcdPrefix, appEnv.PPSEtcdPrefix),
+						// The object caches are served on the peer port
+						func(ctx context.Context) ([]*pfsclient.CacheInfo, error) {
+							pachClient, err := client.NewFromAddress(address)
+							if err != nil {
+								return nil, err
+							}
+							defer pachClient.Close()
+							return debugserver.ObjectAPICacheInfos(pachClient.ObjectAPIClient)(ctx)
+						},
 					))
 					return nil
 				},
@@ -490,7 +632,7 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 						return fmt.Errorf("units.RAMInBytes: %v", err)
 					}
 					blockAPIServer, err := pfs_server.NewBlockAPIServer(
-						appEnv.StorageRoot, blockCacheBytes, appEnv.StorageBackend, etcdAddress)
+						appEnv.StorageRoot, blockCacheBytes, appEnv.StorageBackend, etcdAddress, appEnv.EtcdPrefix)
 					if err != nil {
 						return fmt.Errorf("pfs.NewBlockAPIServer: %v", err)
 					}
@@ -501,7 +643,7 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 						return err
 					}
 					pfsAPIServer, err := pfs_server.NewAPIServer(
-						address, []string{etcdAddress}, path.Join(appEnv.EtcdPrefix, appEnv.PFSEtcdPrefix), treeCache, appEnv.StorageRoot, memoryRequestBytes)
+						address, []string{etcdAddress}, path.Join(appEnv.EtcdPrefix, appEnv.PFSEtcdPrefix), treeCache, appEnv.StorageRoot, memoryRequestBytes, pfsAPIServerOptions(appEnv))
 					if err != nil {
 						return fmt.Errorf("pfs.NewAPIServer: %v", err)
 					}
@@ -522,6 +664,21 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 						appEnv.IAMRole,
 						appEnv.ImagePullSecret,
 						appEnv.NoExposeDockerSocket,
+						appEnv.WorkerBudget,
+						appEnv.RequireImageDigests,
+						appEnv.MaxJobDatums,
+						appEnv.MaxJobOutputBytes,
+						appEnv.WorkerGracePeriod,
+						appEnv.LogRetentionMaxAge,
+						appEnv.LogRetentionMaxBytes,
+						appEnv.PipelineSpecConfigMapSelector,
+						appEnv.PipelineSpecConfigMapNames,
+						appEnv.PipelineSpecConfigMapToken,
+						appEnv.WorkerRcGCGracePeriod,
+						appEnv.PipelineInputCheckInterval,
+						appEnv.WorkerCPUPerWeight,
+						appEnv.WorkerMaxWeightedCPU,
+						appEnv.MaxConcurrentJobs,
 						reporter,
 					)
 					if err != nil {
@@ -547,7 +704,7 @@ func doFullMode(appEnvObj interface{}) (retErr error) {
 					deployclient.RegisterAPIServer(s, deployserver.NewDeployServer(kubeClient, kubeNamespace))
 					healthclient.RegisterHealthServer(s, peerHealthServer)
 					versionpb.RegisterAPIServer(s, version.NewAPIServer(version.Version, version.APIServerOptions{}))
-					adminclient.RegisterAPIServer(s, adminserver.NewAPIServer(address, &adminclient.ClusterInfo{ID: clusterID}))
+					adminclient.RegisterAPIServer(s, adminserver.NewAPIServer(address, appEnv.StorageRoot, etcdClientV3, appEnv.EtcdPrefix, &adminclient.ClusterInfo{ID: clusterID}))
 					return nil
 				},
 			},
@@ -633,6 +790,18 @@ func getKubeClient(env *appEnv) (*kube.Clientset, error) {
 	return kube.NewForConfig(config)
 }
 
+// pfsAPIServerOptions returns the options of the PFS API server that are set
+// in 'env'
+func pfsAPIServerOptions(env *appEnv) pfs_server.APIServerOptions {
+	return pfs_server.APIServerOptions{
+		PutFileConcurrencyLimit:    env.PutFileConcurrencyLimit,
+		DedupScope:                 env.DedupScope,
+		MaxPutFileStreamsPerClient: env.MaxPutFileStreamsPerClient,
+		MaxCommitFiles:             env.MaxCommitFiles,
+		MaxCommitBytes:             env.MaxCommitBytes,
+	}
+}
+
 // getNamespace returns the kubernetes namespace that this pachd pod runs in
 func getNamespace() string {
 	namespace := os.Getenv("PACHD_POD_NAMESPACE")
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/server/pfs/csi"
+	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// appEnv stores the environment variables that the PFS CSI node plugin needs
+type appEnv struct {
+	// Endpoint is the unix socket that the plugin serves CSI on, which the
+	// kubelet finds through the node driver registrar
+	Endpoint string `env:"CSI_ENDPOINT,default=/csi/csi.sock"`
+
+	// NodeID is the name of the node that the plugin runs on, via the k8s
+	// downward API
+	NodeID string `env:"NODE_ID,required"`
+
+	// PachdAddress is the address of pachd, which volumes' data is read from
+	PachdAddress string `env:"PACHD_ADDRESS,default=pachd:650"`
+
+	// StateDir is where the plugin records published volumes and caches file
+	// content. It should be on the host, so that it outlives the plugin.
+	StateDir string `env:"STATE_DIR,default=/csi/state"`
+
+	// CacheBytes is the amount of file content that the plugin caches
+	CacheBytes int64 `env:"CACHE_BYTES,default=0"`
+}
+
+func main() {
+	cmdutil.Main(do, &appEnv{})
+}
+
+func do(appEnvObj interface{}) error {
+	appEnv := appEnvObj.(*appEnv)
+	c, err := client.NewFromAddress(appEnv.PachdAddress)
+	if err != nil {
+		return fmt.Errorf("error connecting to pachd: %v", err)
+	}
+	cacheBytes := appEnv.CacheBytes
+	if cacheBytes == 0 {
+		cacheBytes = csi.DefaultCacheBytes
+	}
+	driver, err := csi.NewDriver(c, appEnv.StateDir, cacheBytes)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(appEnv.Endpoint), 0755); err != nil {
+		return err
+	}
+	log.Infof("serving %s on %s", csi.DriverName, appEnv.Endpoint)
+	return csi.NewServer(driver, appEnv.NodeID).Serve(appEnv.Endpoint)
+}
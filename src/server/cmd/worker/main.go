@@ -7,8 +7,10 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -22,6 +24,8 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/version/versionpb"
 	debugserver "github.com/pachyderm/pachyderm/src/server/debug/server"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/worker"
 	"google.golang.org/grpc"
@@ -37,6 +41,10 @@ type appEnv struct {
 	// Prefix in etcd for all pachd-related records
 	PPSPrefix string `env:"PPS_ETCD_PREFIX,required"`
 
+	// The cluster's etcd prefix, under which the storage URL is stored (the
+	// default if unset, as in the sidecar)
+	EtcdPrefix string `env:"ETCD_PREFIX,default="`
+
 	// worker gets its own IP here, via the k8s downward API. It then writes that
 	// IP back to etcd so that pachd can discover it
 	PPSWorkerIP string `env:"PPS_WORKER_IP,required"`
@@ -55,6 +63,27 @@ type appEnv struct {
 
 	// StorageRoot is where we store hashtrees
 	StorageRoot string `env:"PACH_ROOT,default=/pach"`
+
+	// SidecarWaitTimeout is how long the worker waits for its sidecar to be
+	// ready when it starts, before giving up
+	SidecarWaitTimeout string `env:"PPS_SIDECAR_WAIT_TIMEOUT,default=5m"`
+
+	// HandlerTimeout is how long the worker's unary gRPC handlers may run
+	// before they're cancelled (grpcutil.DefaultHandlerTimeout if unset, and
+	// no limit if "0"). pachd sets it from the pipeline's
+	// worker_handler_timeout.
+	HandlerTimeout string `env:"PPS_WORKER_HANDLER_TIMEOUT,default="`
+
+	// MaxConnectionIdle and MaxConnectionAge are how long a connection to the
+	// worker's gRPC server may be idle, or open, before the worker closes it
+	// (grpcutil.DefaultMaxConnectionIdle and grpcutil.DefaultMaxConnectionAge
+	// if unset, and no limit if "0")
+	MaxConnectionIdle string `env:"PPS_WORKER_MAX_CONNECTION_IDLE,default="`
+	MaxConnectionAge  string `env:"PPS_WORKER_MAX_CONNECTION_AGE,default="`
+
+	// PachdVersion is the version of the pachd that created this worker, which
+	// may differ from the worker's own if the pipeline pins a worker version
+	PachdVersion string `env:"PPS_PACHD_VERSION,default="`
 }
 
 func main() {
@@ -147,15 +176,53 @@ func getPipelineInfo(etcdClient *etcd.Client, pachClient *client.APIClient, appE
 	return ppsutil.GetPipelineInfo(pachClient, &pipelinePtr)
 }
 
+// parseDuration parses the duration 'value' of one of the worker's settings,
+// returning 'defaultValue' if it's unset
+func parseDuration(value string, defaultValue time.Duration, setting string) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", setting, value, err)
+	}
+	return d, nil
+}
+
 func do(appEnvObj interface{}) error {
 	go func() {
 		log.Println(http.ListenAndServe(":651", nil))
 	}()
 
 	appEnv := appEnvObj.(*appEnv)
+	if appEnv.PachdVersion != "" {
+		pachdVersion, err := version.ParseVersion(appEnv.PachdVersion)
+		if err != nil {
+			return err
+		}
+		if err := version.CheckWorkerCompatible(pachdVersion, version.Version); err != nil {
+			return err
+		}
+	}
 
-	// Construct a client that connects to the sidecar.
-	pachClient, err := client.NewFromAddress("localhost:653")
+	// Construct a client that connects to the sidecar, once it's ready.
+	sidecarWaitTimeout, err := time.ParseDuration(appEnv.SidecarWaitTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid sidecar wait timeout %q: %v", appEnv.SidecarWaitTimeout, err)
+	}
+	handlerTimeout, err := parseDuration(appEnv.HandlerTimeout, grpcutil.DefaultHandlerTimeout, "handler timeout")
+	if err != nil {
+		return err
+	}
+	maxConnectionIdle, err := parseDuration(appEnv.MaxConnectionIdle, grpcutil.DefaultMaxConnectionIdle, "max connection idle")
+	if err != nil {
+		return err
+	}
+	maxConnectionAge, err := parseDuration(appEnv.MaxConnectionAge, grpcutil.DefaultMaxConnectionAge, "max connection age")
+	if err != nil {
+		return err
+	}
+	pachClient, err := worker.ConnectToSidecar("localhost:653", sidecarWaitTimeout, log.Infof)
 	if err != nil {
 		return fmt.Errorf("error constructing pachClient: %v", err)
 	}
@@ -168,6 +235,12 @@ func do(appEnvObj interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error constructing etcdClient: %v", err)
 	}
+	if appEnv.EtcdPrefix == "" {
+		appEnv.EtcdPrefix = col.DefaultPrefix
+	}
+	if err := obj.LoadStorageURL(etcdClient, appEnv.EtcdPrefix); err != nil {
+		return err
+	}
 
 	pipelineInfo, err := getPipelineInfo(etcdClient, pachClient, appEnv)
 	if err != nil {
@@ -187,13 +260,16 @@ func do(appEnvObj interface{}) error {
 	eg.Go(func() error {
 		return grpcutil.Serve(
 			grpcutil.ServerOptions{
-				MaxMsgSize: grpcutil.MaxMsgSize,
-				Port:       client.PPSWorkerPort,
+				MaxMsgSize:        grpcutil.MaxMsgSize,
+				Port:              client.PPSWorkerPort,
+				MaxConnectionIdle: maxConnectionIdle,
+				MaxConnectionAge:  maxConnectionAge,
+				HandlerTimeout:    handlerTimeout,
 				RegisterFunc: func(s *grpc.Server) error {
 					defer close(ready)
 					worker.RegisterWorkerServer(s, apiServer)
 					versionpb.RegisterAPIServer(s, version.NewAPIServer(version.Version, version.APIServerOptions{}))
-					debugclient.RegisterDebugServer(s, debugserver.NewDebugServer(appEnv.PodName, etcdClient, appEnv.PPSPrefix))
+					debugclient.RegisterDebugServer(s, debugserver.NewDebugServer(appEnv.PodName, etcdClient, appEnv.PPSPrefix, debugserver.ObjectAPICacheInfos(pachClient.ObjectAPIClient)))
 					return nil
 				},
 			},
@@ -219,13 +295,40 @@ func do(appEnvObj interface{}) error {
 		return fmt.Errorf("error with KeepAlive: %v", err)
 	}
 
-	// Actually write "key" into etcd
+	// Actually write "key" into etcd. The value is our pod name, so that pachd
+	// can map a worker's IP to its pod (e.g. to get logs from one worker)
 	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second) // new ctx
 	defer cancel()
-	if _, err := etcdClient.Put(ctx, key, "", etcd.WithLease(resp.ID)); err != nil {
+	if _, err := etcdClient.Put(ctx, key, appEnv.PodName, etcd.WithLease(resp.ID)); err != nil {
 		return fmt.Errorf("error putting IP address: %v", err)
 	}
 
+	// If the pipeline handles preemption, drain when the node is about to be
+	// preempted, which kubernetes announces with SIGTERM (the cloud's notice
+	// may also be polled), and deregister, so that pachd stops using this
+	// worker. Once the worker has drained it exits, which also lets its
+	// sidecar exit (see doSidecarMode in pachd).
+	if pipelineInfo.Preemption != nil {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM)
+		go func() {
+			<-signals
+			apiServer.Preempt("received SIGTERM")
+		}()
+		go apiServer.WatchPreemptionNotice(pachClient.Ctx())
+		go func() {
+			<-apiServer.Preempted()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if _, err := etcdClient.Revoke(ctx, resp.ID); err != nil {
+				log.Errorf("error deregistering preempted worker: %v", err)
+			}
+			<-apiServer.Drained()
+			log.Infof("preempted worker has drained; exiting")
+			os.Exit(0)
+		}()
+	}
+
 	// If server ever exits, return error
 	return eg.Wait()
 }
@@ -2,20 +2,43 @@ package server
 
 import (
 	"fmt"
+	"os"
 	"runtime/pprof"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+
 	"github.com/pachyderm/pachyderm/src/client/debug"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/server/worker"
 )
 
-// NewDebugServer creates a new server that serves the debug api over GRPC
-func NewDebugServer(name string, etcdClient *etcd.Client, etcdPrefix string) debug.DebugServer {
+// CacheInfosFunc returns the stats of the object caches of the pod that a
+// debug server runs in
+type CacheInfosFunc func(ctx context.Context) ([]*pfs.CacheInfo, error)
+
+// ObjectAPICacheInfos returns a CacheInfosFunc that returns the caches of the
+// pachd that 'objectAPI' talks to (e.g. a worker's sidecar)
+func ObjectAPICacheInfos(objectAPI pfs.ObjectAPIClient) CacheInfosFunc {
+	return func(ctx context.Context) ([]*pfs.CacheInfo, error) {
+		response, err := objectAPI.InspectCache(ctx, &types.Empty{})
+		if err != nil {
+			return nil, err
+		}
+		return response.Caches, nil
+	}
+}
+
+// NewDebugServer creates a new server that serves the debug api over GRPC.
+// 'cacheInfos' returns the stats of the pod's object caches for InspectCache.
+func NewDebugServer(name string, etcdClient *etcd.Client, etcdPrefix string, cacheInfos CacheInfosFunc) debug.DebugServer {
 	return &debugServer{
 		name:       name,
 		etcdClient: etcdClient,
 		etcdPrefix: etcdPrefix,
+		cacheInfos: cacheInfos,
 	}
 }
 
@@ -23,6 +46,7 @@ type debugServer struct {
 	name       string
 	etcdClient *etcd.Client
 	etcdPrefix string
+	cacheInfos CacheInfosFunc
 }
 
 func (s *debugServer) Dump(request *debug.DumpRequest, server debug.Debug_DumpServer) error {
@@ -63,3 +87,36 @@ func (s *debugServer) Dump(request *debug.DumpRequest, server debug.Debug_DumpSe
 	}
 	return nil
 }
+
+func (s *debugServer) InspectCache(ctx context.Context, request *debug.InspectCacheRequest) (*debug.InspectCacheResponse, error) {
+	caches, err := s.cacheInfos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// pachd servers have no name, so they're named after their pod's
+	// hostname, which is the pod's name
+	pod := s.name
+	if pod == "" {
+		if pod, err = os.Hostname(); err != nil {
+			return nil, err
+		}
+	}
+	response := &debug.InspectCacheResponse{
+		Pods: []*debug.PodCacheInfo{{Pod: pod, Caches: caches}},
+	}
+	if request.Recursed {
+		return response, nil
+	}
+	cs, err := worker.Clients(ctx, "", s.etcdClient, s.etcdPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		workerResponse, err := c.InspectCache(ctx, &debug.InspectCacheRequest{Recursed: true})
+		if err != nil {
+			return nil, err
+		}
+		response.Pods = append(response.Pods, workerResponse.Pods...)
+	}
+	return response, nil
+}
@@ -166,6 +166,67 @@ func benchmarkFiles(b *testing.B, fileNum int, minSize uint64, maxSize uint64, l
 // 	}
 // }
 
+func BenchmarkLocalSparseRead(b *testing.B) {
+	benchmarkSparseRead(b, 100, 10*MB)
+}
+
+// benchmarkSparseRead compares eager and lazy inputs for a pipeline whose
+// transform reads only 1% of the 'fileNum' files (each of size 'fileSize') in
+// its single datum.
+func benchmarkSparseRead(b *testing.B, fileNum int, fileSize int64) {
+	scalePachd(b)
+	r := getRand()
+
+	repo := tu.UniqueString("BenchmarkSparseRead")
+	c := getPachClient(b)
+	require.NoError(b, c.CreateRepo(repo))
+	commit, err := c.StartCommit(repo, "master")
+	require.NoError(b, err)
+	for k := 0; k < fileNum; k++ {
+		_, err := c.PutFile(repo, commit.ID, fmt.Sprintf("file%d", k), workload.NewReader(r, fileSize))
+		require.NoError(b, err)
+	}
+	require.NoError(b, c.FinishCommit(repo, commit.ID))
+
+	var cmd []string
+	for k := 0; k < fileNum/100; k++ {
+		cmd = append(cmd, fmt.Sprintf("cat %s > /dev/null", path.Join("/pfs", repo, fmt.Sprintf("file%d", k))))
+	}
+	for _, lazy := range []bool{false, true} {
+		if !b.Run(fmt.Sprintf("Read1PercentLazy=%v", lazy), func(b *testing.B) {
+			b.N = 1
+			pipeline := tu.UniqueString("BenchmarkSparseReadPipeline")
+			_, err := c.PpsAPIClient.CreatePipeline(
+				c.Ctx(),
+				&pps.CreatePipelineRequest{
+					Pipeline: client.NewPipeline(pipeline),
+					Transform: &pps.Transform{
+						Cmd:   []string{"bash"},
+						Stdin: cmd,
+					},
+					ParallelismSpec: &pps.ParallelismSpec{
+						Constant: 1,
+					},
+					Input: &pps.Input{
+						Pfs: &pps.PFSInput{
+							Repo: repo,
+							Glob: "/",
+							Lazy: lazy,
+						},
+					},
+				})
+			require.NoError(b, err)
+			commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(repo, commit.ID)}, nil)
+			require.NoError(b, err)
+			_, err = commitIter.Next()
+			require.NoError(b, err)
+			b.SetBytes(int64(fileNum/100) * fileSize)
+		}) {
+			return
+		}
+	}
+}
+
 func BenchmarkDailyDataShuffle(b *testing.B) {
 	// The following workload consists of roughly 500GB of data
 	//benchmarkDataShuffle(b, 20, 1000, 1*KB, 100*MB, 10)
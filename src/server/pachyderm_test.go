@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,6 +31,8 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/client/version"
+	"github.com/pachyderm/pachyderm/src/server/pfs/csi"
 	pfspretty "github.com/pachyderm/pachyderm/src/server/pfs/pretty"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
@@ -37,20 +40,24 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/pretty"
+	pfssync "github.com/pachyderm/pachyderm/src/server/pkg/sync"
 	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/server/pkg/workload"
 	ppspretty "github.com/pachyderm/pachyderm/src/server/pps/pretty"
 	pps_server "github.com/pachyderm/pachyderm/src/server/pps/server"
 	"github.com/pachyderm/pachyderm/src/server/pps/server/githook"
+	workerpkg "github.com/pachyderm/pachyderm/src/server/worker"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/types"
 	prom_api "github.com/prometheus/client_golang/api"
 	prom_api_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	prom_model "github.com/prometheus/common/model"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 )
@@ -1523,6 +1530,107 @@ func TestPachdRestartResumesRunningJobs(t *testing.T) {
 	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
 }
 
+// TestPachdRestartReattachesToStandbyJob checks that when pachd restarts while
+// a standby pipeline is running a job, the new pachd re-attaches to the job
+// rather than scaling down its workers, so the job's completed datums aren't
+// processed again
+func TestPachdRestartReattachesToStandbyJob(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	// this test cannot be run in parallel because it restarts pachd, which
+	// breaks other tests.
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPachdRestartReattachesToStandbyJob_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestPachdRestartReattachesToStandbyJob")
+	_, err := c.PpsAPIClient.CreatePipeline(context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("for f in /pfs/%s/*; do echo processing $(basename $f); done", dataRepo),
+					"sleep 5",
+				},
+			},
+			ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+			Input:           client.NewPFSInput(dataRepo, "/*"),
+			Standby:         true,
+		})
+	require.NoError(t, err)
+
+	numFiles := 10
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for i := 0; i < numFiles; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// Wait until the job is part of the way through its datums
+	var jobInfo *pps.JobInfo
+	require.NoErrorWithinTRetry(t, 2*time.Minute, func() error {
+		jobInfos, err := c.ListJob(pipeline, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jobInfos) != 1 {
+			return fmt.Errorf("expected 1 job, got %d", len(jobInfos))
+		}
+		jobInfo = jobInfos[0]
+		if jobInfo.DataProcessed < 2 {
+			return fmt.Errorf("job has only processed %d datums", jobInfo.DataProcessed)
+		}
+		return nil
+	})
+	require.Equal(t, pps.JobState_JOB_RUNNING, jobInfo.State)
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	workerPods := func() []string {
+		podList, err := tu.GetKubeClient(t).CoreV1().Pods(v1.NamespaceDefault).List(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(
+				map[string]string{"app": rcName, "suite": "pachyderm"},
+			)),
+		})
+		require.NoError(t, err)
+		var names []string
+		for _, pod := range podList.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+	podsBefore := workerPods()
+
+	restartOne(t)
+	// need a new client because the old one will have a defunct connection
+	c = getUsablePachClient(t)
+
+	jobInfo, err = c.InspectJob(jobInfo.Job.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
+	require.Equal(t, int64(numFiles), jobInfo.DataProcessed)
+
+	// The job's workers weren't replaced, and each datum was processed once
+	require.ElementsEqual(t, podsBefore, workerPods())
+	processed := make(map[string]int)
+	iter := c.GetLogs(pipeline, jobInfo.Job.ID, nil, "", false, false, 0)
+	for iter.Next() {
+		if msg := strings.TrimSpace(iter.Message().Message); strings.HasPrefix(msg, "processing ") {
+			processed[strings.TrimPrefix(msg, "processing ")]++
+		}
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, numFiles, len(processed))
+	for file, n := range processed {
+		require.Equal(t, 1, n, "%s was processed %d times", file, n)
+	}
+}
+
 // TestUpdatePipelineThatHasNoOutput tracks #1637
 func TestUpdatePipelineThatHasNoOutput(t *testing.T) {
 	if testing.Short() {
@@ -2013,6 +2121,123 @@ func TestUpdatePipeline(t *testing.T) {
 	require.Equal(t, "buzz\n", buffer.String())
 }
 
+func TestInspectPipelineVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestInspectPipelineVersion_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipelineName := tu.UniqueString("pipeline")
+	createPipeline := func(update bool) {
+		require.NoError(t, c.CreatePipeline(
+			pipelineName,
+			"",
+			[]string{"bash"},
+			[]string{"echo foo >/pfs/out/file"},
+			&pps.ParallelismSpec{
+				Constant: 2,
+			},
+			client.NewPFSInput(dataRepo, "/*"),
+			"",
+			update,
+		))
+	}
+	// Once every worker is running the pipeline's current version, there
+	// should be no skew
+	checkVersion := func(version uint64) {
+		require.NoError(t, backoff.Retry(func() error {
+			pipelineInfo, err := c.InspectPipeline(pipelineName)
+			if err != nil {
+				return err
+			}
+			versionInfo, err := c.InspectPipelineVersion(pipelineName)
+			if err != nil {
+				return err
+			}
+			if versionInfo.Version != version {
+				return fmt.Errorf("expected version %d, got %d", version, versionInfo.Version)
+			}
+			if versionInfo.SpecCommit.ID != pipelineInfo.SpecCommit.ID {
+				return fmt.Errorf("expected spec commit %s, got %s", pipelineInfo.SpecCommit.ID, versionInfo.SpecCommit.ID)
+			}
+			if len(versionInfo.Workers) != 2 || versionInfo.Skew {
+				return fmt.Errorf("update hasn't rolled out yet: %v", versionInfo)
+			}
+			for _, worker := range versionInfo.Workers {
+				if worker.Error != "" {
+					return fmt.Errorf("couldn't get the status of worker %s: %s", worker.WorkerID, worker.Error)
+				}
+				if worker.Version != version || worker.SpecCommit.ID != pipelineInfo.SpecCommit.ID {
+					return fmt.Errorf("worker %v isn't running version %d (spec commit %s)", worker, version, pipelineInfo.SpecCommit.ID)
+				}
+			}
+			return nil
+		}, backoff.NewTestingBackOff()))
+	}
+	createPipeline(false)
+	checkVersion(1)
+	createPipeline(true)
+	checkVersion(2)
+}
+
+func TestInspectJobHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestInspectJobHistory_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	// The pipeline fails for any commit that adds a file named "fail"
+	pipelineName := tu.UniqueString("pipeline")
+	require.NoError(t, c.CreatePipeline(
+		pipelineName,
+		"",
+		[]string{"bash"},
+		[]string{
+			fmt.Sprintf("if [ -e /pfs/%s/fail ]; then exit 1; fi", dataRepo),
+			fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+		},
+		nil,
+		client.NewPFSInput(dataRepo, "/"),
+		"",
+		false,
+	))
+	for i, file := range []string{"a", "b", "c", "fail"} {
+		_, err := c.PutFile(dataRepo, "master", file, strings.NewReader(fmt.Sprintf("%d", i)))
+		require.NoError(t, err)
+		commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+		require.NoError(t, err)
+		collectCommitInfos(t, commitIter)
+	}
+
+	history, err := c.InspectJobHistory(pipelineName, 0)
+	require.NoError(t, err)
+	require.Equal(t, pipelineName, history.Pipeline.Name)
+	require.Equal(t, int64(4), history.Jobs)
+	require.Equal(t, int64(3), history.Succeeded)
+	require.Equal(t, int64(1), history.Failed)
+	require.Equal(t, 0.25, history.FailureRate)
+	require.Equal(t, int64(4), history.Duration.Count)
+	require.True(t, history.Duration.Mean > 0)
+	require.Equal(t, int64(4), history.DataProcessed.Count)
+
+	// Only the most recent (failed) job is aggregated
+	history, err = c.InspectJobHistory(pipelineName, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), history.Jobs)
+	require.Equal(t, int64(0), history.Succeeded)
+	require.Equal(t, int64(1), history.Failed)
+	require.Equal(t, 1.0, history.FailureRate)
+
+	_, err = c.InspectJobHistory(pipelineName, -1)
+	require.YesError(t, err)
+}
+
 func TestUpdateFailedPipeline(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -2132,6 +2357,90 @@ func TestUpdateStoppedPipeline(t *testing.T) {
 	require.Equal(t, true, pipelineInfo.Stopped)
 }
 
+func TestUpdatePipelineSquashOutput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestUpdatePipelineSquashOutput_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestUpdatePipelineSquashOutput")
+	downstream := tu.UniqueString("TestUpdatePipelineSquashOutput_downstream")
+	createPipeline := func(stdin string, update bool) error {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd:   []string{"bash"},
+					Stdin: []string{stdin},
+				},
+				Input:        client.NewPFSInput(dataRepo, "/*"),
+				Update:       update,
+				Reprocess:    update,
+				SquashOutput: update,
+			})
+		return err
+	}
+	require.NoError(t, createPipeline("echo foo >/pfs/out/file", false))
+	require.NoError(t, c.CreatePipeline(
+		downstream,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/file /pfs/out/file", pipeline)},
+		&pps.ParallelismSpec{
+			Constant: 1,
+		},
+		client.NewPFSInput(pipeline, "/"),
+		"",
+		false,
+	))
+	for i := 0; i < 3; i++ {
+		_, err := c.PutFile(dataRepo, "master", fmt.Sprintf("file%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+		iter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+		require.NoError(t, err)
+		collectCommitInfos(t, iter)
+	}
+	commitInfos, err := c.ListCommit(pipeline, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(commitInfos))
+
+	// squash_output is only meaningful for updates
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline:     client.NewPipeline(tu.UniqueString("TestUpdatePipelineSquashOutput_create")),
+			Transform:    &pps.Transform{Cmd: []string{"true"}},
+			Input:        client.NewPFSInput(dataRepo, "/*"),
+			SquashOutput: true,
+		})
+	require.YesError(t, err)
+
+	// Once the updated pipeline's output has been processed downstream, the
+	// old output commits of both pipelines are deleted
+	require.NoError(t, createPipeline("echo bar >/pfs/out/file", true))
+	require.NoError(t, backoff.Retry(func() error {
+		for _, repo := range []string{pipeline, downstream} {
+			commitInfos, err := c.ListCommit(repo, "master", "", 0)
+			if err != nil {
+				return err
+			}
+			if len(commitInfos) != 1 {
+				return fmt.Errorf("expected 1 commit in %s, but got %d", repo, len(commitInfos))
+			}
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+	for _, repo := range []string{pipeline, downstream} {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(repo, "master", "file", 0, 0, &buf))
+		require.Equal(t, "bar\n", buf.String())
+	}
+}
+
 func TestUpdatePipelineRunningJob(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -2420,77 +2729,240 @@ func TestStandby(t *testing.T) {
 	})
 }
 
-func TestPipelineEnv(t *testing.T) {
+func TestIdleScaleDown(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
 
-	// make a secret to reference
-	k := tu.GetKubeClient(t)
-	secretName := tu.UniqueString("test-secret")
-	_, err := k.CoreV1().Secrets(v1.NamespaceDefault).Create(
-		&v1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: secretName,
-			},
-			Data: map[string][]byte{
-				"foo": []byte("foo\n"),
-			},
-		},
-	)
-	require.NoError(t, err)
 	c := getPachClient(t)
 	require.NoError(t, c.DeleteAll())
-	// create repos
-	dataRepo := tu.UniqueString("TestPipelineEnv_data")
+
+	dataRepo := tu.UniqueString("TestIdleScaleDown_data")
+	pipeline := tu.UniqueString("TestIdleScaleDown")
 	require.NoError(t, c.CreateRepo(dataRepo))
-	// create pipeline
-	pipelineName := tu.UniqueString("pipeline")
-	_, err = c.PpsAPIClient.CreatePipeline(
-		context.Background(),
+	_, err := c.PpsAPIClient.CreatePipeline(context.Background(),
 		&pps.CreatePipelineRequest{
-			Pipeline: client.NewPipeline(pipelineName),
+			Pipeline: client.NewPipeline(pipeline),
 			Transform: &pps.Transform{
-				Cmd: []string{"sh"},
+				Cmd: []string{"bash"},
 				Stdin: []string{
-					"ls /var/secret",
-					"cat /var/secret/foo > /pfs/out/foo",
-					"echo $bar> /pfs/out/bar",
-					"echo $foo> /pfs/out/foo_env",
-					fmt.Sprintf("echo $%s >/pfs/out/job_id", client.JobIDEnv),
-					fmt.Sprintf("echo $%s >/pfs/out/output_commit_id", client.OutputCommitIDEnv),
-					fmt.Sprintf("echo $%s >/pfs/out/input", dataRepo),
-					fmt.Sprintf("echo $%s_COMMIT >/pfs/out/input_commit", dataRepo),
-				},
-				Env: map[string]string{"bar": "bar"},
-				Secrets: []*pps.Secret{
-					{
-						Name:      secretName,
-						Key:       "foo",
-						MountPath: "/var/secret",
-						EnvVar:    "foo",
-					},
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
 				},
 			},
-			ParallelismSpec: &pps.ParallelismSpec{
-				Constant: 1,
+			ParallelismSpec: &pps.ParallelismSpec{Constant: 3},
+			Input:           client.NewPFSInput(dataRepo, "/*"),
+			IdleScaleDown: &pps.IdleScaleDown{
+				Timeout:    types.DurationProto(10 * time.Second),
+				MinWorkers: 1,
 			},
-			Input: client.NewPFSInput(dataRepo, "/*"),
+		},
+	)
+	require.NoError(t, err)
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	waitForReplicas := func(replicas int32) {
+		require.NoErrorWithinTRetry(t, 2*time.Minute, func() error {
+			rc, err := pipelineRc(t, pipelineInfo)
+			if err != nil {
+				return err
+			}
+			if *rc.Spec.Replicas != replicas {
+				return fmt.Errorf("expected %d replicas, but the RC has %d", replicas, *rc.Spec.Replicas)
+			}
+			return nil
 		})
+	}
+
+	// The pipeline has no commits to process, so it's scaled down to its floor
+	waitForReplicas(1)
+	pipelineInfo, err = c.InspectPipeline(pipeline)
 	require.NoError(t, err)
-	// Do first commit to repo
-	_, err = c.PutFile(dataRepo, "master", "file", strings.NewReader("foo\n"))
+	require.Equal(t, pps.PipelineState_PIPELINE_RUNNING, pipelineInfo.State)
+
+	// A new commit scales it back up, and it's processed as usual
+	commit, err := c.StartCommit(dataRepo, "master")
 	require.NoError(t, err)
-	jis, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
-	require.Equal(t, 1, len(jis))
-	var buffer bytes.Buffer
-	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "foo", 0, 0, &buffer))
-	require.Equal(t, "foo\n", buffer.String())
-	buffer.Reset()
-	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "foo_env", 0, 0, &buffer))
-	require.Equal(t, "foo\n", buffer.String())
-	buffer.Reset()
-	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "bar", 0, 0, &buffer))
+	_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+	waitForReplicas(3)
+	commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(pipeline, "master", "file", 0, 0, &buf))
+	require.Equal(t, "foo", buf.String())
+
+	// Once it's idle again, it's scaled back down
+	waitForReplicas(1)
+}
+
+func TestRunPipelineParallelism(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestRunPipelineParallelism_data")
+	pipeline := tu.UniqueString("TestRunPipelineParallelism")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{
+			"sleep 10",
+			fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+		},
+		&pps.ParallelismSpec{Constant: 1},
+		client.NewPFSInput(dataRepo, "/*"),
+		"",
+		false,
+	))
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	waitForReplicas := func(replicas int32) {
+		require.NoErrorWithinTRetry(t, 2*time.Minute, func() error {
+			rc, err := pipelineRc(t, pipelineInfo)
+			if err != nil {
+				return err
+			}
+			if *rc.Spec.Replicas != replicas {
+				return fmt.Errorf("expected %d replicas, but the RC has %d", replicas, *rc.Spec.Replicas)
+			}
+			return nil
+		})
+	}
+	waitForReplicas(1)
+	putFiles := func(n int) *pfs.Commit {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		for i := 0; i < n; i++ {
+			_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file-%d", i), strings.NewReader("foo"))
+			require.NoError(t, err)
+		}
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+		return commit
+	}
+
+	// The pipeline already has 1 worker, so its jobs can't run with fewer
+	_, err = c.RunPipeline(pipeline, 1)
+	require.YesError(t, err)
+	require.Matches(t, "must be more than", err.Error())
+
+	// The next job runs with 3 workers
+	job, err := c.RunPipeline(pipeline, 3)
+	require.NoError(t, err)
+	require.Nil(t, job)
+	commit := putFiles(6)
+	var jobInfo *pps.JobInfo
+	require.NoErrorWithinTRetry(t, 30*time.Second, func() error {
+		jobInfos, err := c.ListJob(pipeline, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jobInfos) != 1 {
+			return fmt.Errorf("expected 1 job, got %d", len(jobInfos))
+		}
+		jobInfo = jobInfos[0]
+		return nil
+	})
+	require.Equal(t, uint64(3), jobInfo.ParallelismSpec.Constant)
+	waitForReplicas(3)
+	commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, []*pfs.Repo{client.NewRepo(pipeline)})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+	jobInfo, err = c.InspectJob(jobInfo.Job.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
+
+	// Once it's finished, the pipeline returns to its parallelism_spec, and
+	// later jobs run with it
+	waitForReplicas(1)
+	commit = putFiles(1)
+	commitIter, err = c.FlushCommit([]*pfs.Commit{commit}, []*pfs.Repo{client.NewRepo(pipeline)})
+	require.NoError(t, err)
+	commitInfos := collectCommitInfos(t, commitIter)
+	require.Equal(t, 1, len(commitInfos))
+	jobInfo, err = c.InspectJobOutputCommit(pipeline, commitInfos[0].Commit.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), jobInfo.ParallelismSpec.Constant)
+	waitForReplicas(1)
+}
+
+func TestPipelineEnv(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	// make a secret to reference
+	k := tu.GetKubeClient(t)
+	secretName := tu.UniqueString("test-secret")
+	_, err := k.CoreV1().Secrets(v1.NamespaceDefault).Create(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: secretName,
+			},
+			Data: map[string][]byte{
+				"foo": []byte("foo\n"),
+			},
+		},
+	)
+	require.NoError(t, err)
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	// create repos
+	dataRepo := tu.UniqueString("TestPipelineEnv_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	// create pipeline
+	pipelineName := tu.UniqueString("pipeline")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipelineName),
+			Transform: &pps.Transform{
+				Cmd: []string{"sh"},
+				Stdin: []string{
+					"ls /var/secret",
+					"cat /var/secret/foo > /pfs/out/foo",
+					"echo $bar> /pfs/out/bar",
+					"echo $foo> /pfs/out/foo_env",
+					fmt.Sprintf("echo $%s >/pfs/out/job_id", client.JobIDEnv),
+					fmt.Sprintf("echo $%s >/pfs/out/output_commit_id", client.OutputCommitIDEnv),
+					fmt.Sprintf("echo $%s >/pfs/out/input", dataRepo),
+					fmt.Sprintf("echo $%s_COMMIT >/pfs/out/input_commit", dataRepo),
+				},
+				Env: map[string]string{"bar": "bar"},
+				Secrets: []*pps.Secret{
+					{
+						Name:      secretName,
+						Key:       "foo",
+						MountPath: "/var/secret",
+						EnvVar:    "foo",
+					},
+				},
+			},
+			ParallelismSpec: &pps.ParallelismSpec{
+				Constant: 1,
+			},
+			Input: client.NewPFSInput(dataRepo, "/*"),
+		})
+	require.NoError(t, err)
+	// Do first commit to repo
+	_, err = c.PutFile(dataRepo, "master", "file", strings.NewReader("foo\n"))
+	require.NoError(t, err)
+	jis, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.Equal(t, 1, len(jis))
+	var buffer bytes.Buffer
+	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "foo", 0, 0, &buffer))
+	require.Equal(t, "foo\n", buffer.String())
+	buffer.Reset()
+	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "foo_env", 0, 0, &buffer))
+	require.Equal(t, "foo\n", buffer.String())
+	buffer.Reset()
+	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "bar", 0, 0, &buffer))
 	require.Equal(t, "bar\n", buffer.String())
 	buffer.Reset()
 	require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "job_id", 0, 0, &buffer))
@@ -2506,6 +2978,79 @@ func TestPipelineEnv(t *testing.T) {
 	require.Equal(t, fmt.Sprintf("%s\n", jis[0].Input.Pfs.Commit), buffer.String())
 }
 
+func TestJobTraceID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestJobTraceID_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipelineName := tu.UniqueString("TestJobTraceID")
+	require.NoError(t, c.CreatePipeline(
+		pipelineName,
+		"",
+		[]string{"sh"},
+		[]string{
+			fmt.Sprintf("echo $%s >/pfs/out/trace_id", client.TraceIDEnv),
+			fmt.Sprintf("echo trace id is $%s", client.TraceIDEnv),
+		},
+		&pps.ParallelismSpec{
+			Constant: 1,
+		},
+		client.NewPFSInput(dataRepo, "/*"),
+		"",
+		false,
+	))
+	var traceIDs []string
+	for i := 0; i < 2; i++ {
+		_, err := c.PutFile(dataRepo, "master", fmt.Sprintf("file%d", i), strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		jis, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(jis))
+		require.NotEqual(t, "", jis[0].TraceID)
+		traceIDs = append(traceIDs, jis[0].TraceID)
+
+		// The user code sees the job's trace ID
+		var buffer bytes.Buffer
+		require.NoError(t, c.GetFile(pipelineName, jis[0].OutputCommit.ID, "trace_id", 0, 0, &buffer))
+		require.Equal(t, fmt.Sprintf("%s\n", jis[0].TraceID), buffer.String())
+
+		// Every log message for the job, from the user code, the workers and
+		// the master, is tagged with the job's trace ID
+		for _, master := range []bool{false, true} {
+			require.NoErrorWithinTRetry(t, time.Minute, func() error {
+				iter := c.GetLogs("", jis[0].Job.ID, nil, "", master, false, 0)
+				var numLogs int
+				var userLog bool
+				for iter.Next() {
+					numLogs++
+					if iter.Message().TraceID != jis[0].TraceID {
+						return fmt.Errorf("log message %q has trace ID %q, expected %q", iter.Message().Message, iter.Message().TraceID, jis[0].TraceID)
+					}
+					if iter.Message().Message == fmt.Sprintf("trace id is %s", jis[0].TraceID) {
+						userLog = true
+					}
+				}
+				if err := iter.Err(); err != nil {
+					return err
+				}
+				if numLogs == 0 {
+					return fmt.Errorf("no logs found")
+				}
+				if !master && !userLog {
+					return fmt.Errorf("user code's log message not found")
+				}
+				return nil
+			})
+		}
+	}
+	// Each job has its own trace ID
+	require.NotEqual(t, traceIDs[0], traceIDs[1])
+}
+
 func TestPipelineWithFullObjects(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -3066,6 +3611,94 @@ func TestStopJob(t *testing.T) {
 	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
 }
 
+func TestStopPipelineJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestStopPipelineJobs_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipelineName := tu.UniqueString("TestStopPipelineJobs")
+	require.NoError(t, c.CreatePipeline(
+		pipelineName,
+		"",
+		[]string{"sleep", "600"},
+		nil,
+		&pps.ParallelismSpec{
+			Constant: 1,
+		},
+		client.NewPFSInput(dataRepo, "/"),
+		"",
+		false,
+	))
+
+	// Create two input commits to trigger two jobs
+	for i := 0; i < 2; i++ {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+	}
+	var jobID string
+	require.NoError(t, backoff.Retry(func() error {
+		jobInfos, err := c.ListJob(pipelineName, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jobInfos) == 0 || jobInfos[len(jobInfos)-1].State != pps.JobState_JOB_RUNNING {
+			return fmt.Errorf("waiting for the first job to start running")
+		}
+		jobID = jobInfos[len(jobInfos)-1].Job.ID
+		return nil
+	}, backoff.NewTestingBackOff()))
+
+	jobs, err := c.StopPipelineJobs(pipelineName)
+	require.NoError(t, err)
+	stopped := make(map[string]bool)
+	for _, job := range jobs {
+		stopped[job.ID] = true
+	}
+	require.True(t, stopped[jobID])
+
+	// The second job may only be created once the first is stopped, so stop
+	// the pipeline's jobs until none are running
+	require.NoError(t, backoff.Retry(func() error {
+		jobs, err := c.StopPipelineJobs(pipelineName)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			stopped[job.ID] = true
+		}
+		jobInfos, err := c.ListJob(pipelineName, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jobInfos) != 2 {
+			return fmt.Errorf("expected 2 jobs, got %d", len(jobInfos))
+		}
+		for _, jobInfo := range jobInfos {
+			if jobInfo.State != pps.JobState_JOB_KILLED {
+				return fmt.Errorf("job %s is %v", jobInfo.Job.ID, jobInfo.State)
+			}
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+
+	// Every job was stopped, and its output commit closed
+	jobInfos, err := c.ListJob(pipelineName, nil, nil)
+	require.NoError(t, err)
+	for _, jobInfo := range jobInfos {
+		require.True(t, stopped[jobInfo.Job.ID])
+		commitInfo, err := c.InspectCommit(pipelineName, jobInfo.OutputCommit.ID)
+		require.NoError(t, err)
+		require.NotNil(t, commitInfo.Finished)
+	}
+}
+
 func TestGetLogs(t *testing.T) {
 	testGetLogs(t, false)
 }
@@ -3720,31 +4353,488 @@ func TestPipelineResourceLimitDefaults(t *testing.T) {
 	require.False(t, ok)
 }
 
-func TestPipelinePartialResourceRequest(t *testing.T) {
+func TestPipelineDisruptionBudget(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
 
 	c := getPachClient(t)
 	require.NoError(t, c.DeleteAll())
-	// create repos
-	dataRepo := tu.UniqueString("TestPipelinePartialResourceRequest")
-	pipelineName := tu.UniqueString("pipeline")
+	dataRepo := tu.UniqueString("TestPipelineDisruptionBudget_data")
+	pipelineName := tu.UniqueString("TestPipelineDisruptionBudget")
 	require.NoError(t, c.CreateRepo(dataRepo))
-	// Resources are not yet in client.CreatePipeline() (we may add them later)
 	_, err := c.PpsAPIClient.CreatePipeline(
 		context.Background(),
 		&pps.CreatePipelineRequest{
-			Pipeline: client.NewPipeline(fmt.Sprintf("%s-%d", pipelineName, 0)),
+			Pipeline: client.NewPipeline(pipelineName),
 			Transform: &pps.Transform{
-				Cmd: []string{"true"},
+				Cmd: []string{"cp", path.Join("/pfs", dataRepo, "file"), "/pfs/out/file"},
 			},
-			ResourceRequests: &pps.ResourceSpec{
-				Cpu:    0.5,
-				Memory: "100M",
+			ParallelismSpec: &pps.ParallelismSpec{
+				Constant: 4,
 			},
-			Input: &pps.Input{
-				Pfs: &pps.PFSInput{
+			Input:            client.NewAtomInput(dataRepo, "/*"),
+			DisruptionBudget: &pps.DisruptionBudget{MinAvailable: "75%"},
+		})
+	require.NoError(t, err)
+
+	// A PodDisruptionBudget covering the pipeline's workers should be created
+	pipelineInfo, err := c.InspectPipeline(pipelineName)
+	require.NoError(t, err)
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	pdbs := tu.GetKubeClient(t).PolicyV1beta1().PodDisruptionBudgets(v1.NamespaceDefault)
+	require.NoError(t, backoff.Retry(func() error {
+		pdb, err := pdbs.Get(rcName, metav1.GetOptions{})
+		if err != nil {
+			return err // retry
+		}
+		if pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.String() != "75%" {
+			return fmt.Errorf("expected minAvailable 75%%, but got %v", pdb.Spec.MinAvailable)
+		}
+		if pdb.Spec.MaxUnavailable != nil {
+			return fmt.Errorf("expected no maxUnavailable, but got %v", pdb.Spec.MaxUnavailable)
+		}
+		if pdb.Spec.Selector.MatchLabels["app"] != rcName {
+			return fmt.Errorf("expected PDB to select the pipeline's workers, but its selector is %v", pdb.Spec.Selector)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+
+	// Deleting the pipeline should delete the PodDisruptionBudget
+	require.NoError(t, c.DeletePipeline(pipelineName, false))
+	require.NoError(t, backoff.Retry(func() error {
+		_, err := pdbs.Get(rcName, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("PodDisruptionBudget %s still exists", rcName)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+
+	// Pipelines must set exactly one of min_available and max_unavailable
+	for _, budget := range []*pps.DisruptionBudget{
+		{},
+		{MinAvailable: "1", MaxUnavailable: "1"},
+		{MaxUnavailable: "150%"},
+		{MinAvailable: "-1"},
+	} {
+		_, err = c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline:         client.NewPipeline(pipelineName),
+				Transform:        &pps.Transform{Cmd: []string{"true"}},
+				Input:            client.NewAtomInput(dataRepo, "/*"),
+				DisruptionBudget: budget,
+			})
+		require.YesError(t, err)
+	}
+}
+
+func TestInspectDatums(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo1 := tu.UniqueString("TestInspectDatums_data1")
+	dataRepo2 := tu.UniqueString("TestInspectDatums_data2")
+	require.NoError(t, c.CreateRepo(dataRepo1))
+	require.NoError(t, c.CreateRepo(dataRepo2))
+
+	// dataRepo1 has two directories of three files each
+	commit1, err := c.StartCommit(dataRepo1, "master")
+	require.NoError(t, err)
+	for _, dir := range []string{"a", "b"} {
+		for i := 0; i < 3; i++ {
+			_, err = c.PutFile(dataRepo1, commit1.ID, fmt.Sprintf("/%s/%d", dir, i), strings.NewReader("foo"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, c.FinishCommit(dataRepo1, commit1.ID))
+	// dataRepo2 has four files in its first commit, and one more in its second
+	commit2, err := c.StartCommit(dataRepo2, "master")
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		_, err = c.PutFile(dataRepo2, commit2.ID, fmt.Sprintf("/%d", i), strings.NewReader("bar"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo2, commit2.ID))
+	_, err = c.PutFile(dataRepo2, "master", "/4", strings.NewReader("bar"))
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		input    *pps.Input
+		commits  []*pfs.Commit
+		expected int64
+	}{
+		{client.NewPFSInput(dataRepo1, "/"), nil, 1},
+		{client.NewPFSInput(dataRepo1, "/*"), nil, 2},
+		{client.NewPFSInput(dataRepo1, "/*/*"), nil, 6},
+		{client.NewPFSInput(dataRepo1, "/c/*"), nil, 0},
+		{client.NewPFSInput(dataRepo2, "/*"), nil, 5},
+		{client.NewPFSInput(dataRepo2, "/*"), []*pfs.Commit{commit2}, 4},
+		{client.NewUnionInput(
+			client.NewPFSInput(dataRepo1, "/*/*"),
+			client.NewPFSInput(dataRepo2, "/*"),
+		), nil, 11},
+		{client.NewCrossInput(
+			client.NewPFSInput(dataRepo1, "/*"),
+			client.NewPFSInput(dataRepo2, "/*"),
+		), []*pfs.Commit{commit2}, 8},
+	} {
+		count, err := c.InspectDatums(test.input, test.commits...)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, count)
+	}
+
+	// The count matches the datums of a real job
+	pipeline := tu.UniqueString("TestInspectDatums")
+	input := client.NewCrossInput(
+		client.NewPFSInput(dataRepo1, "/*/*"),
+		client.NewPFSInput(dataRepo2, "/*"),
+	)
+	expected, err := c.InspectDatums(input)
+	require.NoError(t, err)
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"true"},
+		nil,
+		&pps.ParallelismSpec{
+			Constant: 1,
+		},
+		input,
+		"",
+		false,
+	))
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo2, "master")}, []*pfs.Repo{client.NewRepo(pipeline)})
+	require.NoError(t, err)
+	commitInfos := collectCommitInfos(t, commitIter)
+	require.Equal(t, 1, len(commitInfos))
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, expected, jobInfos[0].DataTotal)
+
+	// Inputs must be valid
+	_, err = c.InspectDatums(client.NewPFSInput(tu.UniqueString("nonexistent"), "/*"))
+	require.YesError(t, err)
+}
+
+func TestPreviewTrigger(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestPreviewTrigger_data")
+	otherRepo := tu.UniqueString("TestPreviewTrigger_other")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	require.NoError(t, c.CreateRepo(otherRepo))
+	_, err := c.PutFile(otherRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	// dataRepo -> first -> second -> crossed <- otherRepo -> unrelated
+	first := tu.UniqueString("first")
+	second := tu.UniqueString("second")
+	crossed := tu.UniqueString("crossed")
+	unrelated := tu.UniqueString("unrelated")
+	for _, p := range []struct {
+		name  string
+		input *pps.Input
+	}{
+		{first, client.NewPFSInput(dataRepo, "/*")},
+		{second, client.NewPFSInput(first, "/*")},
+		{crossed, client.NewCrossInput(
+			client.NewPFSInput(second, "/"),
+			client.NewPFSInput(otherRepo, "/*"),
+		)},
+		{unrelated, client.NewPFSInput(otherRepo, "/*")},
+	} {
+		require.NoError(t, c.CreatePipeline(
+			p.name,
+			"",
+			[]string{"bash"},
+			[]string{"cp -r /pfs/*/* /pfs/out/ || true"},
+			nil,
+			p.input,
+			"",
+			false,
+		))
+	}
+	_, err = c.FlushJobAll([]*pfs.Commit{client.NewCommit(otherRepo, "master")}, nil)
+	require.NoError(t, err)
+
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	triggered, err := c.PreviewTrigger(dataRepo, commit.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(triggered))
+	require.Equal(t, first, triggered[0].Pipeline.Name)
+	require.True(t, triggered[0].Direct)
+	require.Equal(t, int64(3), triggered[0].EstimatedDatums)
+	require.Equal(t, second, triggered[1].Pipeline.Name)
+	require.False(t, triggered[1].Direct)
+	require.Equal(t, crossed, triggered[2].Pipeline.Name)
+	require.False(t, triggered[2].Direct)
+
+	// The pipelines that run once the commit is finished are the ones that
+	// were predicted
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	var predicted, actual []string
+	for _, p := range triggered {
+		predicted = append(predicted, p.Pipeline.Name)
+	}
+	for _, jobInfo := range jobInfos {
+		actual = append(actual, jobInfo.Pipeline.Name)
+	}
+	sort.Strings(predicted)
+	sort.Strings(actual)
+	require.Equal(t, predicted, actual)
+}
+
+func TestSplitInput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestSplitInput_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("line%d\n", i))
+	}
+	_, err := c.PutFile(dataRepo, "master", "/file", strings.NewReader(strings.Join(lines, "")))
+	require.NoError(t, err)
+
+	// Each datum writes the number of lines in its part of the file to a
+	// file named after its first line
+	pipeline := tu.UniqueString("TestSplitInput")
+	input := client.NewPFSInput(dataRepo, "/file")
+	input.Pfs.Split = &pps.Split{Records: 3}
+	count, err := c.InspectDatums(input)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), count)
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("wc -l < /pfs/%s/file > /pfs/out/$(head -n 1 /pfs/%s/file)", dataRepo, dataRepo)},
+		&pps.ParallelismSpec{
+			Constant: 2,
+		},
+		input,
+		"",
+		false,
+	))
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, []*pfs.Repo{client.NewRepo(pipeline)})
+	require.NoError(t, err)
+	commitInfos := collectCommitInfos(t, commitIter)
+	require.Equal(t, 1, len(commitInfos))
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, int64(4), jobInfos[0].DataTotal)
+	for name, expected := range map[string]string{"line0": "3", "line3": "3", "line6": "3", "line9": "1"} {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, commitInfos[0].Commit.ID, name, 0, 0, &buf))
+		require.Equal(t, expected, strings.TrimSpace(buf.String()))
+	}
+
+	// Split may set records or bytes, but not both
+	input.Pfs.Split = &pps.Split{Records: 3, Bytes: 10}
+	_, err = c.InspectDatums(input)
+	require.YesError(t, err)
+}
+
+func TestWatchWorkers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestWatchWorkers_data")
+	pipelineName := tu.UniqueString("TestWatchWorkers")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	require.NoError(t, c.CreatePipeline(
+		pipelineName,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+		&pps.ParallelismSpec{
+			Constant: 2,
+		},
+		client.NewAtomInput(dataRepo, "/*"),
+		"",
+		false,
+	))
+
+	events := make(chan *pps.WorkerEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		c.WithCtx(ctx).WatchWorkers(pipelineName, func(event *pps.WorkerEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	next := func() *pps.WorkerEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * time.Minute):
+			t.Fatal("timed out waiting for a worker event")
+		}
+		return nil
+	}
+
+	// Both of the pipeline's workers should be reported as joining
+	pipelineInfo, err := c.InspectPipeline(pipelineName)
+	require.NoError(t, err)
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	joined := make(map[string]bool)
+	for len(joined) < 2 {
+		event := next()
+		require.Equal(t, pps.WorkerEventType_WORKER_JOINED, event.Type)
+		require.Equal(t, rcName, event.RcName)
+		joined[event.Address] = true
+	}
+
+	// Deleting the pipeline should report both workers as leaving
+	require.NoError(t, c.DeletePipeline(pipelineName, false))
+	for len(joined) > 0 {
+		event := next()
+		require.Equal(t, pps.WorkerEventType_WORKER_LEFT, event.Type)
+		require.True(t, joined[event.Address])
+		delete(joined, event.Address)
+	}
+
+	// Watching a pipeline that doesn't exist is an error
+	require.YesError(t, c.WatchWorkers(tu.UniqueString("TestWatchWorkers_none"), func(*pps.WorkerEvent) error {
+		return nil
+	}))
+}
+
+func TestWatchJob(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestWatchJob_data")
+	pipelineName := tu.UniqueString("TestWatchJob")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	numFiles := 10
+	for i := 0; i < numFiles; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// Each datum is its own chunk, so that the job's progress is reported as
+	// each datum finishes
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipelineName),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{"sleep 1", fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+			},
+			Input:     client.NewPFSInput(dataRepo, "/*"),
+			ChunkSpec: &pps.ChunkSpec{Number: 1},
+		})
+	require.NoError(t, err)
+
+	var jobInfos []*pps.JobInfo
+	require.NoError(t, backoff.Retry(func() error {
+		jobInfos, err = c.ListJob(pipelineName, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jobInfos) != 1 {
+			return fmt.Errorf("expected 1 job, got %d", len(jobInfos))
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+
+	// Progress only increases, and the stream ends with the job's terminal
+	// state
+	var events []*pps.JobEvent
+	require.NoError(t, c.WatchJob(jobInfos[0].Job.ID, func(event *pps.JobEvent) error {
+		if len(events) > 0 {
+			prev := events[len(events)-1]
+			require.False(t, ppsutil.IsTerminal(prev.State))
+			require.True(t, event.DataProcessed >= prev.DataProcessed)
+			require.True(t, event.DataProcessed+event.DataSkipped+event.DataFailed >= prev.DataProcessed+prev.DataSkipped+prev.DataFailed)
+		}
+		events = append(events, event)
+		return nil
+	}))
+	require.True(t, len(events) > 2)
+	last := events[len(events)-1]
+	require.Equal(t, pps.JobState_JOB_SUCCESS, last.State)
+	require.Equal(t, int64(numFiles), last.DataTotal)
+	require.Equal(t, int64(numFiles), last.DataProcessed)
+	require.Equal(t, int64(0), last.ActiveWorkers)
+
+	// Watching a finished job sends just its terminal event
+	events = nil
+	require.NoError(t, c.WatchJob(jobInfos[0].Job.ID, func(event *pps.JobEvent) error {
+		events = append(events, event)
+		return nil
+	}))
+	require.Equal(t, 1, len(events))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, events[0].State)
+
+	// Watching a job that doesn't exist is an error
+	require.YesError(t, c.WatchJob(uuid.NewWithoutDashes(), func(*pps.JobEvent) error {
+		return nil
+	}))
+}
+
+func TestPipelinePartialResourceRequest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	// create repos
+	dataRepo := tu.UniqueString("TestPipelinePartialResourceRequest")
+	pipelineName := tu.UniqueString("pipeline")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	// Resources are not yet in client.CreatePipeline() (we may add them later)
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(fmt.Sprintf("%s-%d", pipelineName, 0)),
+			Transform: &pps.Transform{
+				Cmd: []string{"true"},
+			},
+			ResourceRequests: &pps.ResourceSpec{
+				Cpu:    0.5,
+				Memory: "100M",
+			},
+			Input: &pps.Input{
+				Pfs: &pps.PFSInput{
 					Repo:   dataRepo,
 					Branch: "master",
 					Glob:   "/*",
@@ -3875,21 +4965,103 @@ func TestPodSpecOpts(t *testing.T) {
 	require.Equal(t, "hostname", pod.Spec.Hostname)
 }
 
-func TestPipelineLargeOutput(t *testing.T) {
+func TestImagePullConfig(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
 
 	c := getPachClient(t)
 	require.NoError(t, c.DeleteAll())
-
-	dataRepo := tu.UniqueString("TestPipelineInputDataModification_data")
+	dataRepo := tu.UniqueString("TestImagePullConfig_data")
 	require.NoError(t, c.CreateRepo(dataRepo))
-
-	numFiles := 100
-	commit1, err := c.StartCommit(dataRepo, "master")
-	require.NoError(t, err)
-	for i := 0; i < numFiles; i++ {
+	pipelineName := tu.UniqueString("TestImagePullConfig")
+	createPipeline := func(secret string, policy string) error {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipelineName),
+				Transform: &pps.Transform{
+					Cmd:              []string{"cp", path.Join("/pfs", dataRepo, "file"), "/pfs/out/file"},
+					ImagePullSecrets: []string{secret},
+					ImagePullPolicy:  policy,
+				},
+				Input: client.NewPFSInput(dataRepo, "/*"),
+			})
+		return err
+	}
+
+	// Pull secrets must exist, and pull policies must be valid
+	secretName := tu.UniqueString("test-pull-secret")
+	err := createPipeline(secretName, "Always")
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "not found"))
+	kubeClient := tu.GetKubeClient(t)
+	_, err = kubeClient.CoreV1().Secrets(v1.NamespaceDefault).Create(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: secretName,
+			},
+			Type: v1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				v1.DockerConfigJsonKey: []byte(`{"auths":{}}`),
+			},
+		},
+	)
+	require.NoError(t, err)
+	err = createPipeline(secretName, "Sometimes")
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "image_pull_policy"))
+	require.NoError(t, createPipeline(secretName, "Always"))
+
+	// The pull secret and policy are set on the worker pods
+	pipelineInfo, err := c.InspectPipeline(pipelineName)
+	require.NoError(t, err)
+	require.Equal(t, "Always", pipelineInfo.Transform.ImagePullPolicy)
+	var pod v1.Pod
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	require.NoError(t, backoff.Retry(func() error {
+		podList, err := kubeClient.CoreV1().Pods(v1.NamespaceDefault).List(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(
+				map[string]string{"app": rcName, "suite": "pachyderm"},
+			)),
+		})
+		if err != nil {
+			return err // retry
+		}
+		if len(podList.Items) != 1 || len(podList.Items[0].Spec.Containers) == 0 {
+			return fmt.Errorf("could not find single container for pipeline %s", pipelineInfo.Pipeline.Name)
+		}
+		pod = podList.Items[0]
+		return nil // no more retries
+	}, backoff.NewTestingBackOff()))
+	var secrets []string
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		secrets = append(secrets, secret.Name)
+	}
+	require.OneOfEquals(t, secretName, secrets)
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if container.Name == client.PPSWorkerSidecarContainerName {
+			continue
+		}
+		require.Equal(t, v1.PullAlways, container.ImagePullPolicy)
+	}
+}
+
+func TestPipelineLargeOutput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineInputDataModification_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+
+	numFiles := 100
+	commit1, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for i := 0; i < numFiles; i++ {
 		_, err = c.PutFile(dataRepo, commit1.ID, fmt.Sprintf("file-%d", i), strings.NewReader(""))
 	}
 	require.NoError(t, c.FinishCommit(dataRepo, commit1.ID))
@@ -5045,6 +6217,302 @@ func TestSelfReferentialPipeline(t *testing.T) {
 	))
 }
 
+func TestPipelineMissingGlob(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestPipelineMissingGlob_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestPipelineMissingGlob")
+	createPipeline := func() error {
+		return c.CreatePipeline(
+			pipeline,
+			"",
+			[]string{"true"},
+			nil,
+			nil,
+			client.NewPFSInput(dataRepo, ""),
+			"",
+			false,
+		)
+	}
+
+	// An empty glob is rejected with an explanation of what it's for
+	err := createPipeline()
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "must specify a glob"))
+	require.True(t, strings.Contains(err.Error(), `"/*"`))
+	require.False(t, strings.Contains(err.Error(), "recommended glob"))
+
+	// If the repo has a recommended glob, it's suggested
+	_, err = c.PfsAPIClient.CreateRepo(context.Background(), &pfs.CreateRepoRequest{
+		Repo:            client.NewRepo(dataRepo),
+		RecommendedGlob: "/*/*",
+		Update:          true,
+	})
+	require.NoError(t, err)
+	repoInfo, err := c.InspectRepo(dataRepo)
+	require.NoError(t, err)
+	require.Equal(t, "/*/*", repoInfo.RecommendedGlob)
+	err = createPipeline()
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), `the recommended glob for `+dataRepo+` is "/*/*"`))
+}
+
+func TestDeferredInput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestDeferredInput_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestDeferredInput")
+	input := client.NewPFSInput(dataRepo, "/")
+	input.Pfs.Deferred = &pps.Deferred{}
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("ls /pfs/%s | wc -l > /pfs/out/count", dataRepo)},
+			},
+			Input: input,
+		})
+	require.NoError(t, err)
+	// The trigger branch is created with the pipeline
+	triggerBranch := pipeline + "-trigger"
+	_, err = c.InspectBranch(dataRepo, triggerBranch)
+	require.NoError(t, err)
+
+	// Ordinary commits don't trigger jobs
+	for i := 0; i < 2; i++ {
+		_, err := c.PutFile(dataRepo, "master", fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	time.Sleep(10 * time.Second)
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(jobInfos))
+
+	// Promoting the input runs one job against the latest commit
+	checkCount := func(expected string) {
+		require.NoError(t, c.PromoteInputs(pipeline))
+		commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, triggerBranch)}, []*pfs.Repo{client.NewRepo(pipeline)})
+		require.NoError(t, err)
+		commitInfos := collectCommitInfos(t, commitIter)
+		require.Equal(t, 1, len(commitInfos))
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, commitInfos[0].Commit.ID, "count", 0, 0, &buf))
+		require.Equal(t, expected, strings.TrimSpace(buf.String()))
+	}
+	checkCount("2")
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+
+	_, err = c.PutFile(dataRepo, "master", "file2", strings.NewReader("foo"))
+	require.NoError(t, err)
+	time.Sleep(10 * time.Second)
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	checkCount("3")
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(jobInfos))
+
+	// Only deferred inputs can be promoted
+	require.YesError(t, c.PromoteInputs(pipeline, "nonexistent"))
+}
+
+func TestRerunJob(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestRerunJob_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestRerunJob")
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+		nil,
+		client.NewPFSInput(dataRepo, "/*"),
+		"",
+		false,
+	))
+	var commits []*pfs.Commit
+	for i := 0; i < 2; i++ {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		for j := 0; j < 2; j++ {
+			_, err := c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d-%d", i, j), strings.NewReader("foo"))
+			require.NoError(t, err)
+		}
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+		commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, []*pfs.Repo{client.NewRepo(pipeline)})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+		commits = append(commits, commit)
+	}
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(jobInfos))
+	// ListJob returns the newest job first; the second job skipped the datums
+	// processed by the first
+	original := jobInfos[0]
+	require.Equal(t, int64(2), original.DataProcessed)
+	require.Equal(t, int64(2), original.DataSkipped)
+	outputHead, err := c.InspectCommit(pipeline, "master")
+	require.NoError(t, err)
+
+	// The rerun processes all of the second job's datums again
+	job, err := c.RerunJob(original.Job.ID)
+	require.NoError(t, err)
+	rerun, err := c.InspectJob(job.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, pps.JobState_JOB_SUCCESS.String(), rerun.State.String())
+	require.Equal(t, original.Job.ID, rerun.RerunOf.ID)
+	require.Equal(t, original.DataTotal, rerun.DataTotal)
+	require.Equal(t, int64(4), rerun.DataProcessed)
+	require.Equal(t, int64(0), rerun.DataSkipped)
+	require.Equal(t, commits[1].ID, rerun.Input.Pfs.Commit)
+
+	// Its output is in its own commit, and matches the original's
+	var originalFiles, rerunFiles []string
+	fileInfos, err := c.ListFile(pipeline, original.OutputCommit.ID, "/")
+	require.NoError(t, err)
+	for _, fi := range fileInfos {
+		originalFiles = append(originalFiles, fi.File.Path)
+	}
+	fileInfos, err = c.ListFile(pipeline, rerun.OutputCommit.ID, "/")
+	require.NoError(t, err)
+	for _, fi := range fileInfos {
+		rerunFiles = append(rerunFiles, fi.File.Path)
+	}
+	require.Equal(t, 4, len(rerunFiles))
+	require.Equal(t, originalFiles, rerunFiles)
+	// The rerun's branch is deleted once it's finished
+	require.NoErrorWithinTRetry(t, 30*time.Second, func() error {
+		branches, err := c.ListBranch(pipeline)
+		if err != nil {
+			return err
+		}
+		for _, branch := range branches {
+			if branch.Name == ppsconsts.RerunBranchPrefix+job.ID {
+				return fmt.Errorf("branch %s still exists", branch.Name)
+			}
+		}
+		return nil
+	})
+	commitInfo, err := c.InspectCommit(pipeline, "master")
+	require.NoError(t, err)
+	require.Equal(t, outputHead.Commit.ID, commitInfo.Commit.ID)
+
+	// The first job's inputs are unaffected by the later commit
+	job, err = c.RerunJob(jobInfos[1].Job.ID)
+	require.NoError(t, err)
+	rerun, err = c.InspectJob(job.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, pps.JobState_JOB_SUCCESS.String(), rerun.State.String())
+	require.Equal(t, int64(2), rerun.DataProcessed)
+	require.Equal(t, commits[0].ID, rerun.Input.Pfs.Commit)
+
+	// Jobs of previous versions of the pipeline can't be rerun
+	_, err = c.PpsAPIClient.CreatePipeline(context.Background(), &pps.CreatePipelineRequest{
+		Pipeline: client.NewPipeline(pipeline),
+		Transform: &pps.Transform{
+			Cmd:   []string{"bash"},
+			Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+		},
+		Input:     client.NewPFSInput(dataRepo, "/*"),
+		Update:    true,
+		Reprocess: true,
+	})
+	require.NoError(t, err)
+	require.NoErrorWithinTRetry(t, time.Minute, func() error {
+		pipelineInfo, err := c.InspectPipeline(pipeline)
+		if err != nil {
+			return err
+		}
+		if pipelineInfo.Version != 2 {
+			return fmt.Errorf("expected version 2, got %d", pipelineInfo.Version)
+		}
+		return nil
+	})
+	_, err = c.RerunJob(original.Job.ID)
+	require.YesError(t, err)
+}
+
+func TestDeferredInputReleaseSchedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestDeferredInputReleaseSchedule_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestDeferredInputReleaseSchedule")
+	input := client.NewPFSInput(dataRepo, "/")
+	input.Pfs.Deferred = &pps.Deferred{ReleaseSchedule: "@every 30s"}
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("ls /pfs/%s | wc -l > /pfs/out/count", dataRepo)},
+			},
+			Input: input,
+		})
+	require.NoError(t, err)
+
+	// Commits made between releases are buffered, and processed together in
+	// one job
+	for i := 0; i < 3; i++ {
+		_, err := c.PutFile(dataRepo, "master", fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(jobInfos))
+	require.NoErrorWithinTRetry(t, 90*time.Second, func() error {
+		jobInfos, err := c.ListJob(pipeline, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jobInfos) != 1 {
+			return fmt.Errorf("expected 1 job, got %d", len(jobInfos))
+		}
+		return nil
+	})
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	jobInfo, err := c.InspectJob(jobInfos[0].Job.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(pipeline, jobInfo.OutputCommit.ID, "count", 0, 0, &buf))
+	require.Equal(t, "3", strings.TrimSpace(buf.String()))
+
+	// No job is run if no commits are made before the next release
+	time.Sleep(60 * time.Second)
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+}
+
 func TestPipelineBadImage(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -7202,7 +8670,7 @@ func TestDeleteCommitRunsJob(t *testing.T) {
 	require.Equal(t, "commit 3 data", buf.String())
 }
 
-func TestEntryPoint(t *testing.T) {
+func TestMaxHistoryDepthKeepsPipelineOutput(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
@@ -7210,27 +8678,117 @@ func TestEntryPoint(t *testing.T) {
 	c := getPachClient(t)
 	require.NoError(t, c.DeleteAll())
 
-	dataRepo := tu.UniqueString("TestEntryPoint_data")
-	require.NoError(t, c.CreateRepo(dataRepo))
-
-	commit1, err := c.StartCommit(dataRepo, "master")
-	require.NoError(t, err)
-	_, err = c.PutFile(dataRepo, commit1.ID, "file", strings.NewReader("foo"))
-	require.NoError(t, err)
-	require.NoError(t, c.FinishCommit(dataRepo, commit1.ID))
-
-	pipeline := tu.UniqueString("TestSimplePipeline")
+	repo := tu.UniqueString("TestMaxHistoryDepthKeepsPipelineOutput")
+	require.NoError(t, c.CreateRepo(repo))
+	pipeline := tu.UniqueString("pipeline")
 	require.NoError(t, c.CreatePipeline(
 		pipeline,
-		"pachyderm_entrypoint",
-		nil,
-		nil,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", repo)},
 		&pps.ParallelismSpec{
 			Constant: 1,
 		},
-		&pps.Input{
-			Pfs: &pps.PFSInput{
-				Name: "in",
+		client.NewPFSInput(repo, "/"),
+		"",
+		false,
+	))
+	require.NoError(t, c.SetMaxHistoryDepth(repo, "master", 2))
+
+	// Each input commit is processed before the next one is made, so every
+	// input commit beyond the depth has a finished output commit and job
+	numCommits := 5
+	for i := 0; i < numCommits; i++ {
+		commit, err := c.StartCommit(repo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(repo, commit.ID, fmt.Sprintf("/%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(repo, commit.ID))
+		commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+	}
+
+	// Pruning the input repo's history deletes none of the pipeline's output
+	// commits or jobs, so it keeps the input commits they came from
+	outputCommits, err := c.ListCommit(pipeline, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, numCommits, len(outputCommits))
+	inputCommits, err := c.ListCommit(repo, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, numCommits, len(inputCommits))
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, numCommits, len(jobInfos))
+	for _, jobInfo := range jobInfos {
+		require.Equal(t, pps.JobState_JOB_SUCCESS.String(), jobInfo.State.String())
+		_, err := c.InspectJob(jobInfo.Job.ID, false)
+		require.NoError(t, err)
+		_, err = c.InspectCommit(pipeline, jobInfo.OutputCommit.ID)
+		require.NoError(t, err)
+	}
+
+	// Once the output branch's history is limited too, the input commits
+	// whose output commits were deleted are deleted as the next input commit
+	// is finished. The output branch is pruned after its head is finished,
+	// which may be after FlushCommit returns, so wait for that first.
+	require.NoError(t, c.SetMaxHistoryDepth(pipeline, "master", 1))
+	for _, file := range []string{"/next", "/last"} {
+		commit, err := c.StartCommit(repo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(repo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(repo, commit.ID))
+		commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+		require.NoErrorWithinT(t, 30*time.Second, func() error {
+			return backoff.Retry(func() error {
+				outputCommits, err := c.ListCommit(pipeline, "master", "", 0)
+				if err != nil {
+					return err
+				}
+				if len(outputCommits) != 1 {
+					return fmt.Errorf("expected 1 output commit, but got %d", len(outputCommits))
+				}
+				return nil
+			}, backoff.NewTestingBackOff())
+		})
+	}
+	inputCommits, err = c.ListCommit(repo, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(inputCommits))
+}
+
+func TestEntryPoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestEntryPoint_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+
+	commit1, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit1.ID, "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit1.ID))
+
+	pipeline := tu.UniqueString("TestSimplePipeline")
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"pachyderm_entrypoint",
+		nil,
+		nil,
+		&pps.ParallelismSpec{
+			Constant: 1,
+		},
+		&pps.Input{
+			Pfs: &pps.PFSInput{
+				Name: "in",
 				Repo: dataRepo,
 				Glob: "/*",
 			},
@@ -7523,113 +9081,1707 @@ func TestPachdPrometheusStats(t *testing.T) {
 		countQuery(t, query) // Just check query has a result
 	})
 
-	// Check PPS API is reported
-	t.Run("ListJobSeconds", func(t *testing.T) {
-		query := fmt.Sprintf("sum(pachyderm_pachd_list_job_seconds_count{%v}) without %v", pod, without)
-		countQuery(t, query)
-	})
-	t.Run("ListJobAvgRuntime", func(t *testing.T) {
-		sum := fmt.Sprintf("sum(pachyderm_pachd_list_job_time_sum{%v}) without %v", pod, without)
-		count := fmt.Sprintf("sum(pachyderm_pachd_list_job_time_count{%v}) without %v", pod, without)
-		avgQuery(t, sum, count, 1)
-	})
+	// Check PPS API is reported
+	t.Run("ListJobSeconds", func(t *testing.T) {
+		query := fmt.Sprintf("sum(pachyderm_pachd_list_job_seconds_count{%v}) without %v", pod, without)
+		countQuery(t, query)
+	})
+	t.Run("ListJobAvgRuntime", func(t *testing.T) {
+		sum := fmt.Sprintf("sum(pachyderm_pachd_list_job_time_sum{%v}) without %v", pod, without)
+		count := fmt.Sprintf("sum(pachyderm_pachd_list_job_time_count{%v}) without %v", pod, without)
+		avgQuery(t, sum, count, 1)
+	})
+
+}
+
+func TestRapidUpdatePipelines(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	pipeline := tu.UniqueString("TestRapidUpdatePipelines")
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"cp", "/pfs/time/time", "/pfs/out/time"},
+		nil,
+		nil,
+		client.NewCronInput("time", "@every 5s"),
+		"",
+		false,
+	))
+
+	time.Sleep(10 * time.Second)
+
+	for i := 0; i < 20; i++ {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd: []string{"cp", "/pfs/time/time", "/pfs/out/time"},
+				},
+				Input:     client.NewCronInput("time", "@every 5s"),
+				Update:    true,
+				Reprocess: true,
+			})
+		require.NoError(t, err)
+	}
+	require.NoErrorWithinTRetry(t, 2*time.Minute, func() error {
+		jis, err := c.ListJob(pipeline, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(jis) < 10 {
+			return fmt.Errorf("should have more than 10 jobs in 2 minutes")
+		}
+		for i := 0; i < 5; i++ {
+			difference := jis[i].Started.Seconds - jis[i+1].Started.Seconds
+			if difference < 4 {
+				return fmt.Errorf("jobs too close together")
+			} else if difference > 6 {
+				return fmt.Errorf("jobs too far apart")
+			}
+		}
+		return nil
+	})
+}
+
+func TestDatumTries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestDatumTries_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	tries := int64(5)
+	pipeline := tu.UniqueString("TestSimplePipeline")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"unknown"}, // Cmd fails because "unknown" isn't a known command.
+			},
+			Input:      client.NewPFSInput(dataRepo, "/"),
+			DatumTries: tries,
+		})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+
+	iter := c.GetLogs("", jobInfos[0].Job.ID, nil, "", false, false, 0)
+	var observedTries int64
+	for iter.Next() {
+		if strings.Contains(iter.Message().Message, "errored running user code after") {
+			observedTries++
+		}
+	}
+	require.Equal(t, tries, observedTries)
+}
+
+func TestMaxDatumCrashes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestMaxDatumCrashes_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"good1", "good2", "poison"} {
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// The "poison" datum crashes the worker (pid 1) every time it's processed
+	pipeline := tu.UniqueString("TestMaxDatumCrashes")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("if [ -e /pfs/%s/poison ]; then kill -ABRT 1; sleep 60; fi", dataRepo),
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+				},
+			},
+			Input:           client.NewPFSInput(dataRepo, "/*"),
+			MaxDatumCrashes: 2,
+		})
+	require.NoError(t, err)
+
+	// The job succeeds without the poison datum
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	jobInfo := jobInfos[0]
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
+	require.Equal(t, 1, len(jobInfo.QuarantinedDatums))
+	require.Equal(t, int64(2), jobInfo.DataProcessed)
+	require.Equal(t, int64(0), jobInfo.DataFailed)
+	require.Equal(t, int64(3), jobInfo.DataTotal)
+	fileInfos, err := c.ListFile(pipeline, jobInfo.OutputCommit.ID, "/")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(fileInfos))
+	for _, fileInfo := range fileInfos {
+		require.NotEqual(t, "/poison", fileInfo.File.Path)
+	}
+
+	// The job's crash counts are deleted once it finishes
+	resp, err := getEtcdClient(t).Get(context.Background(), col.DefaultPrefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	require.NoError(t, err)
+	for _, kv := range resp.Kvs {
+		require.False(t, strings.Contains(string(kv.Key), "/crash/"+jobInfo.Job.ID+"/"), "crash count %s wasn't deleted", kv.Key)
+	}
+}
+
+func TestInfraFailureRetries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestInfraFailureRetries_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// A worker whose image can't be pulled is restarted until the pipeline
+	// runs out of retries
+	badImage := tu.UniqueString("TestInfraFailureRetries_bad_image")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(badImage),
+			Transform: &pps.Transform{
+				Image: "bs/badimage:vcrap",
+				Cmd:   []string{"true"},
+			},
+			Input:               client.NewPFSInput(dataRepo, "/*"),
+			InfraFailureRetries: 2,
+		})
+	require.NoError(t, err)
+	require.NoError(t, backoff.Retry(func() error {
+		pipelineInfo, err := c.InspectPipeline(badImage)
+		if err != nil {
+			return err
+		}
+		if pipelineInfo.State != pps.PipelineState_PIPELINE_FAILURE {
+			return fmt.Errorf("pipeline %s should have failed", badImage)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+	pipelineInfo, err := c.InspectPipeline(badImage)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), pipelineInfo.InfraFailures)
+	require.True(t, strings.Contains(pipelineInfo.Reason, "after 2 retries"))
+
+	// Failures in user code are never retried this way
+	userFailure := tu.UniqueString("TestInfraFailureRetries_user_failure")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(userFailure),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{"exit 1"},
+			},
+			Input:               client.NewPFSInput(dataRepo, "/*"),
+			InfraFailureRetries: 2,
+		})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, []string{userFailure})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_FAILURE, jobInfos[0].State)
+	require.Equal(t, uint64(0), jobInfos[0].Restart)
+	pipelineInfo, err = c.InspectPipeline(userFailure)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), pipelineInfo.InfraFailures)
+}
+
+func TestOutputSchema(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestOutputSchema_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"good", "empty"} {
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// The "empty" datum exits successfully, but produces no output
+	pipeline := tu.UniqueString("TestOutputSchema")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("if [ -e /pfs/%s/empty ]; then exit 0; fi", dataRepo),
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+				},
+			},
+			Input:      client.NewPFSInput(dataRepo, "/*"),
+			DatumTries: 1,
+			OutputSchema: &pps.OutputSchema{
+				Required: []*pps.OutputRequirement{{Pattern: "/*", MinBytes: 1}},
+			},
+		})
+	require.NoError(t, err)
+
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	jobInfo := jobInfos[0]
+	require.Equal(t, pps.JobState_JOB_FAILURE, jobInfo.State)
+	require.Equal(t, int64(1), jobInfo.DataFailed)
+
+	// The datum's logs explain why it failed
+	iter := c.GetLogs("", jobInfo.Job.ID, nil, "", false, false, 0)
+	var found bool
+	for iter.Next() {
+		if strings.Contains(iter.Message().Message, "no output file matches /*") {
+			found = true
+		}
+	}
+	require.NoError(t, iter.Err())
+	require.True(t, found)
+}
+
+func TestOutputPartition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestOutputPartition_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"2019-01-01.csv", "2019-02-01.csv", "summary.csv"} {
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	pipeline := tu.UniqueString("TestOutputPartition")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+			},
+			Input: client.NewPFSInput(dataRepo, "/*"),
+			OutputPartition: &pps.OutputPartition{
+				Pattern:   "^/(?P<year>[0-9]{4})-(?P<month>[0-9]{2})-",
+				Unmatched: "__unpartitioned__",
+			},
+		})
+	require.NoError(t, err)
+
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, []string{pipeline})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	outputCommit := jobInfos[0].OutputCommit
+	for file, content := range map[string]string{
+		"/year=2019/month=01/2019-01-01.csv": "2019-01-01.csv",
+		"/year=2019/month=02/2019-02-01.csv": "2019-02-01.csv",
+		"/__unpartitioned__/summary.csv":     "summary.csv",
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, outputCommit.ID, file, 0, 0, &buf))
+		require.Equal(t, content, buf.String())
+	}
+
+	// Without 'unmatched', datums that don't match fail
+	failingPipeline := tu.UniqueString("TestOutputPartition_failing")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(failingPipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+			},
+			Input:      client.NewPFSInput(dataRepo, "/*"),
+			DatumTries: 1,
+			OutputPartition: &pps.OutputPartition{
+				Pattern: "^/(?P<year>[0-9]{4})-",
+			},
+		})
+	require.NoError(t, err)
+	jobInfos, err = c.FlushJobAll([]*pfs.Commit{commit}, []string{failingPipeline})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_FAILURE, jobInfos[0].State)
+	require.Equal(t, int64(1), jobInfos[0].DataFailed)
+
+	// Patterns without named groups are rejected
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(tu.UniqueString("TestOutputPartition_invalid")),
+			Transform: &pps.Transform{
+				Cmd: []string{"true"},
+			},
+			Input: client.NewPFSInput(dataRepo, "/*"),
+			OutputPartition: &pps.OutputPartition{
+				Pattern: "^/([0-9]{4})-",
+			},
+		})
+	require.YesError(t, err)
+}
+
+func TestDatumArgs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestDatumArgs_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"acme-2019.csv", "summary.csv"} {
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	pipeline := tu.UniqueString("TestDatumArgs")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				// The datum's args follow the script's name ("sh")
+				Cmd:        []string{"sh", "-c", fmt.Sprintf("for f in /pfs/%s/*; do echo \"$(pwd) $*\" > /pfs/out/$(basename $f); done", dataRepo), "sh"},
+				WorkingDir: "/",
+				DatumArgs: &pps.DatumArgs{
+					Pattern:        "^/(?P<customer>[a-z]+)-(?P<year>[0-9]+)",
+					WorkingDir:     "tmp",
+					Args:           []string{"${customer}", "${year}"},
+					AllowUnmatched: true,
+				},
+			},
+			Input: client.NewPFSInput(dataRepo, "/*"),
+		})
+	require.NoError(t, err)
+
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, []string{pipeline})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	outputCommit := jobInfos[0].OutputCommit
+	for file, content := range map[string]string{
+		"/acme-2019.csv": "/tmp acme 2019\n",
+		"/summary.csv":   "/ \n",
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, outputCommit.ID, file, 0, 0, &buf))
+		require.Equal(t, content, buf.String())
+	}
+}
+
+func TestMaxDatums(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestMaxDatums_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// A job with more datums than max_datums fails without being started, and
+	// says which glob to check
+	pipeline := tu.UniqueString("TestMaxDatums")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+			},
+			Input:     client.NewPFSInput(dataRepo, "/*"),
+			MaxDatums: 3,
+		})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_FAILURE, jobInfos[0].State)
+	require.True(t, strings.Contains(jobInfos[0].Reason, "5 datums"), jobInfos[0].Reason)
+	require.True(t, strings.Contains(jobInfos[0].Reason, fmt.Sprintf("%s: \"/*\"", dataRepo)), jobInfos[0].Reason)
+	require.Equal(t, int64(0), jobInfos[0].DataProcessed)
+
+	// Removing the limit lets the next job run
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+			},
+			Input:     client.NewPFSInput(dataRepo, "/*"),
+			MaxDatums: -1,
+			Update:    true,
+		})
+	require.NoError(t, err)
+	commit, err = c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit.ID, "file5", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+	jobInfos, err = c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	require.Equal(t, int64(6), jobInfos[0].DataProcessed+jobInfos[0].DataSkipped)
+}
+
+func TestDebounce(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestDebounce_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestDebounce")
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+			},
+			Input: client.NewPFSInput(dataRepo, "/"),
+			Debounce: &pps.Debounce{
+				QuietPeriod: types.DurationProto(10 * time.Second),
+			},
+		})
+	require.NoError(t, err)
+
+	// A burst of commits yields a single job, of the last commit
+	var commits []*pfs.Commit
+	for i := 0; i < 5; i++ {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+		commits = append(commits, commit)
+	}
+	jobInfos, err := c.FlushJobAll(commits[len(commits)-1:], nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	for i := 0; i < 5; i++ {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, "master", fmt.Sprintf("file%d", i), 0, 0, &buf))
+		require.Equal(t, "foo", buf.String())
+	}
+
+	// The superseded commits' output commits are finished without jobs
+	commitInfos, err := c.ListCommit(pipeline, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(commitInfos))
+	for _, commitInfo := range commitInfos {
+		require.NotNil(t, commitInfo.Finished)
+	}
+
+	// In the next burst, the superseded output commit keeps its parent's
+	// output rather than being empty
+	commits = nil
+	for i := 5; i < 7; i++ {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+		commits = append(commits, commit)
+	}
+	_, err = c.FlushJobAll(commits[len(commits)-1:], nil)
+	require.NoError(t, err)
+	commitInfos, err = c.ListCommit(pipeline, "master", "", 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(commitInfos))
+	superseded := commitInfos[1]
+	require.Equal(t, "true", superseded.Labels[client.PPSUnchangedOutputLabel])
+	fileInfos, err := c.ListFile(pipeline, superseded.Commit.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, 5, len(fileInfos))
+
+	// Validation rejects a max wait shorter than the quiet period
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(tu.UniqueString("TestDebounce_invalid")),
+			Transform: &pps.Transform{
+				Cmd: []string{"true"},
+			},
+			Input: client.NewPFSInput(dataRepo, "/"),
+			Debounce: &pps.Debounce{
+				QuietPeriod: types.DurationProto(10 * time.Second),
+				MaxWait:     types.DurationProto(time.Second),
+			},
+		})
+	require.YesError(t, err)
+}
+
+func TestJobsRunInAncestryOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataA := tu.UniqueString("TestJobsRunInAncestryOrder_A")
+	require.NoError(t, c.CreateRepo(dataA))
+	dataB := tu.UniqueString("TestJobsRunInAncestryOrder_B")
+	require.NoError(t, c.CreateRepo(dataB))
+	for _, repo := range []string{dataA, dataB} {
+		_, err := c.PutFile(repo, "master", "file", strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	pipeline := tu.UniqueString("TestJobsRunInAncestryOrder")
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					"sleep 5",
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataA),
+				},
+			},
+			Input: client.NewCrossInput(
+				client.NewPFSInput(dataA, "/"),
+				client.NewPFSInput(dataB, "/"),
+			),
+		})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataB, "master")}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+
+	// Start a commit in each input, and finish them in the opposite order, so
+	// that the inputs of the second output commit are finished first
+	commitA, err := c.StartCommit(dataA, "master")
+	require.NoError(t, err)
+	commitB, err := c.StartCommit(dataB, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataB, commitB.ID, "file", strings.NewReader("bar"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataB, commitB.ID))
+	_, err = c.PutFile(dataA, commitA.ID, "file", strings.NewReader("bar"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataA, commitA.ID))
+	_, err = c.FlushJobAll([]*pfs.Commit{commitB}, nil)
+	require.NoError(t, err)
+
+	// Each job starts after the job of its parent output commit finishes
+	jobInfos, err = c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(jobInfos))
+	for i := len(jobInfos) - 1; i > 0; i-- {
+		parent, child := jobInfos[i], jobInfos[i-1]
+		require.Equal(t, pps.JobState_JOB_SUCCESS, parent.State)
+		require.Equal(t, pps.JobState_JOB_SUCCESS, child.State)
+		childInfo, err := c.InspectCommit(pipeline, child.OutputCommit.ID)
+		require.NoError(t, err)
+		require.Equal(t, parent.OutputCommit.ID, childInfo.ParentCommit.ID)
+		finished, err := types.TimestampFromProto(parent.Finished)
+		require.NoError(t, err)
+		started, err := types.TimestampFromProto(child.Started)
+		require.NoError(t, err)
+		require.False(t, started.Before(finished))
+	}
+}
+
+func TestDatumsPerWorker(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestDatumsPerWorker_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	numFiles := 20
+	for i := 0; i < numFiles; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader(fmt.Sprintf("%d", i)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// Each datum finds its input and output directory through the env
+	pipeline := tu.UniqueString("TestDatumsPerWorker")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					"sleep 1",
+					fmt.Sprintf("cp $PACH_PFS_DIR/%s/* $PACH_PFS_DIR/out/", dataRepo),
+				},
+			},
+			ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+			Input:           client.NewPFSInput(dataRepo, "/*"),
+			DatumsPerWorker: 4,
+		})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	require.Equal(t, int64(numFiles), jobInfos[0].DataProcessed)
+	for i := 0; i < numFiles; i++ {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, "master", fmt.Sprintf("file%d", i), 0, 0, &buf))
+		require.Equal(t, fmt.Sprintf("%d", i), buf.String())
+	}
+}
+
+func TestPipelineAnnotations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineAnnotations_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	createPipeline := func(name string, annotations map[string]string) {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(name),
+				Transform: &pps.Transform{
+					Cmd: []string{"bash"},
+					Stdin: []string{
+						fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+					},
+				},
+				ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+				Input:           client.NewPFSInput(dataRepo, "/*"),
+				Annotations:     annotations,
+			})
+		require.NoError(t, err)
+	}
+	pipelineA := tu.UniqueString("TestPipelineAnnotations_a")
+	createPipeline(pipelineA, map[string]string{
+		"owner":       "data-team",
+		"environment": "production",
+		"description": "copies the data, for auditing",
+	})
+	pipelineB := tu.UniqueString("TestPipelineAnnotations_b")
+	createPipeline(pipelineB, map[string]string{
+		"owner":       "data-team",
+		"environment": "staging",
+	})
+
+	// Annotations round-trip through the pipeline's info and spec
+	pipelineInfo, err := c.InspectPipeline(pipelineA)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"owner":       "data-team",
+		"environment": "production",
+		"description": "copies the data, for auditing",
+	}, pipelineInfo.Annotations)
+	require.Equal(t, pipelineInfo.Annotations, ppsutil.PipelineReqFromInfo(pipelineInfo).Annotations)
+
+	// ListPipeline returns the pipelines whose annotations match the selector
+	pipelineNames := func(selector string) []string {
+		pipelineInfos, err := c.ListPipelineByAnnotation(selector)
+		require.NoError(t, err)
+		var names []string
+		for _, pipelineInfo := range pipelineInfos {
+			names = append(names, pipelineInfo.Pipeline.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	both := []string{pipelineA, pipelineB}
+	sort.Strings(both)
+	require.Equal(t, both, pipelineNames(""))
+	require.Equal(t, both, pipelineNames("owner=data-team"))
+	require.Equal(t, []string{pipelineA}, pipelineNames("owner=data-team,environment=production"))
+	require.Equal(t, []string{pipelineB}, pipelineNames("environment notin (production)"))
+	require.Equal(t, []string{pipelineA}, pipelineNames("description"))
+	require.Equal(t, 0, len(pipelineNames("owner=other-team")))
+	_, err = c.ListPipelineByAnnotation("owner==")
+	require.YesError(t, err)
+
+	// Annotations that are valid label values are attached to the workers
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	kubeClient := tu.GetKubeClient(t)
+	require.NoError(t, backoff.Retry(func() error {
+		podList, err := kubeClient.CoreV1().Pods(v1.NamespaceDefault).List(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(
+				map[string]string{"app": rcName, "suite": "pachyderm"},
+			)),
+		})
+		if err != nil {
+			return err // retry
+		}
+		if len(podList.Items) != 1 {
+			return fmt.Errorf("could not find single worker for pipeline %s", pipelineA)
+		}
+		labels := podList.Items[0].Labels
+		if labels["annotations.pachyderm.io/owner"] != "data-team" ||
+			labels["annotations.pachyderm.io/environment"] != "production" {
+			return fmt.Errorf("worker is missing annotation labels: %v", labels)
+		}
+		if _, ok := labels["annotations.pachyderm.io/description"]; ok {
+			return fmt.Errorf("worker has an invalid annotation label: %v", labels)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+}
+
+// TestPipelineWorkerVersion runs a pipeline whose workers are pinned to the
+// version in $TEST_WORKER_VERSION (e.g. the next release, whose images have
+// been pushed to the cluster's registry), or to pachd's own version if it's
+// unset.
+func TestPipelineWorkerVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineWorkerVersion_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipeline := tu.UniqueString("TestPipelineWorkerVersion")
+	createPipeline := func(workerVersion string) error {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd:   []string{"bash"},
+					Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+				},
+				ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+				Input:           client.NewPFSInput(dataRepo, "/*"),
+				WorkerVersion:   workerVersion,
+			})
+		return err
+	}
+
+	// Workers must be a compatible version
+	pachdVersion := version.Version
+	require.YesError(t, createPipeline("latest"))
+	require.YesError(t, createPipeline(fmt.Sprintf("%d.0.0", pachdVersion.Major+1)))
+	require.YesError(t, createPipeline(fmt.Sprintf("%d.%d.0", pachdVersion.Major, pachdVersion.Minor+2)))
+
+	workerVersion := os.Getenv("TEST_WORKER_VERSION")
+	if workerVersion == "" {
+		workerVersion = version.PrettyVersion()
+	}
+	require.NoError(t, createPipeline(workerVersion))
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, workerVersion, pipelineInfo.WorkerVersion)
+	require.Equal(t, workerVersion, ppsutil.PipelineReqFromInfo(pipelineInfo).WorkerVersion)
+
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(pipeline, jobInfos[0].OutputCommit.ID, "file", 0, 0, &buf))
+	require.Equal(t, "foo", buf.String())
+
+	// The workers' init container (which installs the worker binary) and
+	// sidecar run the pinned version's images
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	kubeClient := tu.GetKubeClient(t)
+	podList, err := kubeClient.CoreV1().Pods(v1.NamespaceDefault).List(metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(
+			map[string]string{"app": rcName, "suite": "pachyderm"},
+		)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(podList.Items))
+	pod := podList.Items[0]
+	require.True(t, strings.HasSuffix(pod.Spec.InitContainers[0].Image, ":"+workerVersion))
+	for _, container := range pod.Spec.Containers {
+		if container.Name == client.PPSWorkerSidecarContainerName {
+			require.True(t, strings.HasSuffix(container.Image, ":"+workerVersion))
+		}
+	}
+}
+
+func TestSkipUnchangedOutput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestSkipUnchangedOutput_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	// 'upstream' only reads /file, and 'downstream' copies its output
+	upstream := tu.UniqueString("TestSkipUnchangedOutput_upstream")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(upstream),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/file /pfs/out/file", dataRepo)},
+			},
+			Input:               client.NewPFSInput(dataRepo, "/file"),
+			SkipUnchangedOutput: true,
+		})
+	require.NoError(t, err)
+	downstream := tu.UniqueString("TestSkipUnchangedOutput_downstream")
+	require.NoError(t, c.CreatePipeline(
+		downstream,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", upstream)},
+		nil,
+		client.NewPFSInput(upstream, "/"),
+		"",
+		false,
+	))
+	flush := func() []*pfs.CommitInfo {
+		commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+		require.NoError(t, err)
+		commitInfos := collectCommitInfos(t, commitIter)
+		require.Equal(t, 2, len(commitInfos))
+		return commitInfos
+	}
+	numJobs := func(pipeline string) int {
+		jobInfos, err := c.ListJob(pipeline, nil, nil)
+		require.NoError(t, err)
+		return len(jobInfos)
+	}
+	flush()
+
+	// Adding a file that the upstream pipeline doesn't read runs an upstream
+	// job, but its output is unchanged, so the downstream commit is finished
+	// without a job
+	_, err = c.PutFile(dataRepo, "master", "other", strings.NewReader("bar"))
+	require.NoError(t, err)
+	for _, commitInfo := range flush() {
+		require.Equal(t, "true", commitInfo.Labels[client.PPSUnchangedOutputLabel])
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID, "file", 0, 0, &buf))
+		require.Equal(t, "foo", buf.String())
+	}
+	require.Equal(t, 2, numJobs(upstream))
+	require.Equal(t, 1, numJobs(downstream))
+
+	// Once the upstream output changes, the downstream pipeline runs again
+	_, err = c.PutFile(dataRepo, "master", "file", strings.NewReader("bar"))
+	require.NoError(t, err)
+	for _, commitInfo := range flush() {
+		require.Equal(t, "", commitInfo.Labels[client.PPSUnchangedOutputLabel])
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID, "file", 0, 0, &buf))
+		require.Equal(t, "foobar", buf.String())
+	}
+	require.Equal(t, 3, numJobs(upstream))
+	require.Equal(t, 2, numJobs(downstream))
+}
+
+func TestEmptyInput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	// An invalid empty_input is rejected
+	dataRepo := tu.UniqueString("TestEmptyInput_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline:   client.NewPipeline(tu.UniqueString("TestEmptyInput_invalid")),
+			Transform:  &pps.Transform{Cmd: []string{"true"}},
+			Input:      client.NewPFSInput(dataRepo, "/*"),
+			EmptyInput: "ignore",
+		})
+	require.YesError(t, err)
+
+	for _, emptyInput := range []string{"empty", "skip", "error"} {
+		t.Run(emptyInput, func(t *testing.T) {
+			dataRepo := tu.UniqueString("TestEmptyInput_data")
+			require.NoError(t, c.CreateRepo(dataRepo))
+			_, err := c.PutFile(dataRepo, "master", "a.csv", strings.NewReader("foo"))
+			require.NoError(t, err)
+
+			// 'upstream' only reads .csv files, and 'downstream' copies its
+			// output
+			upstream := tu.UniqueString("TestEmptyInput_upstream")
+			_, err = c.PpsAPIClient.CreatePipeline(
+				context.Background(),
+				&pps.CreatePipelineRequest{
+					Pipeline: client.NewPipeline(upstream),
+					Transform: &pps.Transform{
+						Cmd:   []string{"bash"},
+						Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+					},
+					Input:      client.NewPFSInput(dataRepo, "/*.csv"),
+					EmptyInput: emptyInput,
+				})
+			require.NoError(t, err)
+			downstream := tu.UniqueString("TestEmptyInput_downstream")
+			require.NoError(t, c.CreatePipeline(
+				downstream,
+				"",
+				[]string{"bash"},
+				[]string{
+					fmt.Sprintf("cp -r /pfs/%s/. /pfs/out/", upstream),
+					"touch /pfs/out/ran",
+				},
+				nil,
+				client.NewPFSInput(upstream, "/"),
+				"",
+				false,
+			))
+			flush := func() (*pfs.CommitInfo, *pfs.CommitInfo) {
+				commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+				require.NoError(t, err)
+				commitInfos := collectCommitInfos(t, commitIter)
+				require.Equal(t, 2, len(commitInfos))
+				if commitInfos[0].Commit.Repo.Name != upstream {
+					commitInfos[0], commitInfos[1] = commitInfos[1], commitInfos[0]
+				}
+				return commitInfos[0], commitInfos[1]
+			}
+			listJob := func(pipeline string) []*pps.JobInfo {
+				jobInfos, err := c.ListJob(pipeline, nil, nil)
+				require.NoError(t, err)
+				return jobInfos
+			}
+			flush()
+
+			// Replace the .csv file with one that 'upstream' doesn't read, so
+			// that its next job has no datums
+			commit, err := c.StartCommit(dataRepo, "master")
+			require.NoError(t, err)
+			require.NoError(t, c.DeleteFile(dataRepo, commit.ID, "a.csv"))
+			_, err = c.PutFile(dataRepo, commit.ID, "b.txt", strings.NewReader("bar"))
+			require.NoError(t, err)
+			require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+			upstreamInfo, downstreamInfo := flush()
+			upstreamJobs, downstreamJobs := listJob(upstream), listJob(downstream)
+			require.Equal(t, 2, len(upstreamJobs))
+			require.Equal(t, int64(0), upstreamJobs[0].DataTotal)
+			switch emptyInput {
+			case "empty":
+				// The output commit has no files, and 'downstream' runs
+				require.Equal(t, pps.JobState_JOB_SUCCESS, upstreamJobs[0].State)
+				require.NotNil(t, upstreamInfo.Trees)
+				fileInfos, err := c.ListFile(upstream, upstreamInfo.Commit.ID, "/")
+				require.NoError(t, err)
+				require.Equal(t, 0, len(fileInfos))
+				require.Equal(t, 2, len(downstreamJobs))
+				require.Equal(t, pps.JobState_JOB_SUCCESS, downstreamJobs[0].State)
+				fileInfos, err = c.ListFile(downstream, downstreamInfo.Commit.ID, "/")
+				require.NoError(t, err)
+				require.Equal(t, 1, len(fileInfos))
+				require.Equal(t, "/ran", fileInfos[0].File.Path)
+			case "skip":
+				// The output commit is a copy of the previous one, and
+				// 'downstream' doesn't run
+				require.Equal(t, pps.JobState_JOB_SUCCESS, upstreamJobs[0].State)
+				for _, commitInfo := range []*pfs.CommitInfo{upstreamInfo, downstreamInfo} {
+					require.Equal(t, "true", commitInfo.Labels[client.PPSUnchangedOutputLabel])
+					var buf bytes.Buffer
+					require.NoError(t, c.GetFile(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID, "a.csv", 0, 0, &buf))
+					require.Equal(t, "foo", buf.String())
+				}
+				require.Equal(t, 1, len(downstreamJobs))
+			case "error":
+				// The job fails, and so does the downstream job
+				require.Equal(t, pps.JobState_JOB_FAILURE, upstreamJobs[0].State)
+				require.True(t, strings.Contains(upstreamJobs[0].Reason, "no datums"))
+				require.Nil(t, upstreamInfo.Trees)
+				require.Equal(t, 2, len(downstreamJobs))
+				require.Equal(t, pps.JobState_JOB_FAILURE, downstreamJobs[0].State)
+			}
+		})
+	}
+}
+
+func TestOrderedMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestOrderedMerge_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"a", "b", "c"} {
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file+"\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// Each pipeline processes its datums in a different order, and every
+	// datum writes to the same output file
+	var pipelines []string
+	for _, first := range []string{"/a", "/c"} {
+		pipeline := tu.UniqueString("TestOrderedMerge")
+		_, err = c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd:   []string{"bash"},
+					Stdin: []string{fmt.Sprintf("cat /pfs/%s/* > /pfs/out/all", dataRepo)},
+				},
+				ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+				Input:           client.NewPFSInput(dataRepo, "/*"),
+				DatumPriority:   []*pps.DatumPriority{{Pattern: first, Priority: 10}},
+				OrderedMerge:    true,
+			})
+		require.NoError(t, err)
+		pipelines = append(pipelines, pipeline)
+	}
+
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, pipelines)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(jobInfos))
+	for _, jobInfo := range jobInfos {
+		require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(jobInfo.Pipeline.Name, jobInfo.OutputCommit.ID, "/all", 0, 0, &buf))
+		require.Equal(t, "a\nb\nc\n", buf.String())
+	}
+}
+
+func TestOutputValidation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestOutputValidation_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("good"))
+	require.NoError(t, err)
+
+	// 'pipeline' copies its input, and its output is invalid if it contains
+	// "bad". 'downstream' copies its output.
+	pipeline := tu.UniqueString("TestOutputValidation")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{fmt.Sprintf("cp /pfs/%s/file /pfs/out/file", dataRepo)},
+			},
+			Input: client.NewPFSInput(dataRepo, "/"),
+			OutputValidation: &pps.OutputValidation{
+				Cmd: []string{"bash", "-c", "echo checking $PACH_OUTPUT_COMMIT_ID; ! grep -q bad file"},
+			},
+		})
+	require.NoError(t, err)
+	downstream := tu.UniqueString("TestOutputValidation_downstream")
+	require.NoError(t, c.CreatePipeline(
+		downstream,
+		"",
+		[]string{"bash"},
+		[]string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", pipeline)},
+		nil,
+		client.NewPFSInput(pipeline, "/"),
+		"",
+		false,
+	))
+	getFile := func(repo, commit string) string {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(repo, commit, "file", 0, 0, &buf))
+		return buf.String()
+	}
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(collectCommitInfos(t, commitIter)))
+	require.Equal(t, "good", getFile(pipeline, "master"))
+	goodCommit, err := c.InspectCommit(pipeline, "master")
+	require.NoError(t, err)
+
+	// Invalid output fails the job, and the output branch keeps the previous
+	// good content, while the bad output is kept on the quarantine branch. The
+	// downstream pipeline doesn't run a job for it.
+	_, err = c.PutFile(dataRepo, "master", "file", strings.NewReader("bad"))
+	require.NoError(t, err)
+	commitIter, err = c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	collectCommitInfos(t, commitIter)
+	jobInfos, err := c.ListJob(pipeline, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_FAILURE, jobInfos[0].State)
+	require.True(t, strings.Contains(jobInfos[0].Reason, "output failed validation"))
+	commitInfo, err := c.InspectCommit(pipeline, "master")
+	require.NoError(t, err)
+	require.Equal(t, goodCommit.Commit.ID, commitInfo.ParentCommit.ID)
+	require.Equal(t, "good", getFile(pipeline, "master"))
+	require.Equal(t, "true", commitInfo.Labels[client.PPSUnchangedOutputLabel])
+	quarantineBranch := workerpkg.QuarantineBranch("master")
+	stagingInfo, err := c.InspectCommit(pipeline, quarantineBranch)
+	require.NoError(t, err)
+	require.Equal(t, stagingInfo.Commit.ID, commitInfo.Labels[client.PPSQuarantinedOutputLabel])
+	require.Equal(t, "goodbad", getFile(pipeline, quarantineBranch))
+	require.Equal(t, "good", getFile(downstream, "master"))
+	jobInfos, err = c.ListJob(downstream, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+
+	// Once the output is valid again, it reaches the output branch
+	err = c.DeleteFile(dataRepo, "master", "file")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, "master", "file", strings.NewReader("better"))
+	require.NoError(t, err)
+	commitIter, err = c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	collectCommitInfos(t, commitIter)
+	require.Equal(t, "better", getFile(pipeline, "master"))
+	require.Equal(t, "better", getFile(downstream, "master"))
+	// Valid output's staging commit isn't kept on the quarantine branch
+	quarantineInfo, err := c.InspectCommit(pipeline, quarantineBranch)
+	require.NoError(t, err)
+	require.Equal(t, stagingInfo.Commit.ID, quarantineInfo.Commit.ID)
+}
+
+func TestDownstreamProvenance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	// data -> a -> b -> d
+	//      -> c ----/
+	// other -> e
+	dataRepo := tu.UniqueString("TestDownstreamProvenance_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	otherRepo := tu.UniqueString("TestDownstreamProvenance_other")
+	require.NoError(t, c.CreateRepo(otherRepo))
+	createPipeline := func(name string, input *pps.Input) {
+		require.NoError(t, c.CreatePipeline(
+			name,
+			"",
+			[]string{"bash"},
+			[]string{"cp -r /pfs/*/* /pfs/out/ || true"},
+			&pps.ParallelismSpec{
+				Constant: 1,
+			},
+			input,
+			"",
+			false,
+		))
+	}
+	// The names of the pipelines aren't in topological order, so that the
+	// result's order doesn't come from sorting them by name
+	a := tu.UniqueString("TestDownstreamProvenance_z")
+	b := tu.UniqueString("TestDownstreamProvenance_y")
+	c2 := tu.UniqueString("TestDownstreamProvenance_x")
+	d := tu.UniqueString("TestDownstreamProvenance_w")
+	e := tu.UniqueString("TestDownstreamProvenance_v")
+	createPipeline(a, client.NewPFSInput(dataRepo, "/"))
+	createPipeline(b, client.NewPFSInput(a, "/"))
+	createPipeline(c2, client.NewPFSInput(dataRepo, "/"))
+	createPipeline(d, client.NewCrossInput(
+		client.NewPFSInput(b, "/"),
+		client.NewPFSInput(c2, "/"),
+	))
+	createPipeline(e, client.NewPFSInput(otherRepo, "/"))
+
+	pipelineNames := func(infos []*client.DownstreamInfo) []string {
+		var names []string
+		for _, info := range infos {
+			names = append(names, info.Pipeline.Name)
+		}
+		return names
+	}
+	position := func(names []string, name string) int {
+		for i, n := range names {
+			if n == name {
+				return i
+			}
+		}
+		return -1
+	}
+	checkOrder := func(names []string) {
+		require.True(t, position(names, a) < position(names, b), names)
+		require.True(t, position(names, b) < position(names, d), names)
+		require.True(t, position(names, c2) < position(names, d), names)
+	}
+
+	// The downstream pipelines of a repo are all of the pipelines derived
+	// from it, in dependency order
+	infos, err := c.DownstreamProvenance(dataRepo, "")
+	require.NoError(t, err)
+	names := pipelineNames(infos)
+	require.Equal(t, 4, len(names))
+	require.Equal(t, -1, position(names, e))
+	checkOrder(names)
+	for _, info := range infos {
+		require.Equal(t, 0, len(info.Commits))
+	}
+	infos, err = c.DownstreamProvenance(a, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{b, d}, pipelineNames(infos))
+
+	// The downstream of a commit includes each pipeline's output commits
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+	commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	commitInfos := collectCommitInfos(t, commitIter)
+	infos, err = c.DownstreamProvenance(dataRepo, commit.ID)
+	require.NoError(t, err)
+	names = pipelineNames(infos)
+	require.Equal(t, 4, len(names))
+	checkOrder(names)
+	outputCommits := make(map[string]bool)
+	for _, commitInfo := range commitInfos {
+		outputCommits[commitInfo.Commit.Repo.Name+"@"+commitInfo.Commit.ID] = true
+	}
+	for _, info := range infos {
+		// Each pipeline's range starts and ends at its one output commit,
+		// which must still be included
+		require.Equal(t, 1, len(info.Commits))
+		for _, outputCommit := range info.Commits {
+			require.Equal(t, info.Pipeline.Name, outputCommit.Repo.Name)
+			require.True(t, outputCommits[outputCommit.Repo.Name+"@"+outputCommit.ID], outputCommit)
+		}
+	}
+}
+
+func TestMaxOutputBytes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestMaxOutputBytes_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	numFiles := 20
+	for i := 0; i < numFiles; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// Each datum writes 1MB, so the job exceeds its cap after a few datums
+	// and is stopped
+	pipeline := tu.UniqueString("TestMaxOutputBytes")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("for f in /pfs/%s/*; do head -c 1048576 /dev/urandom > /pfs/out/$(basename $f); done", dataRepo),
+				},
+			},
+			ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+			Input:           client.NewPFSInput(dataRepo, "/*"),
+			MaxOutputBytes:  3 * 1024 * 1024,
+		})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_FAILURE, jobInfos[0].State)
+	require.True(t, jobInfos[0].OverQuota)
+	require.True(t, strings.HasPrefix(jobInfos[0].Reason, "over quota"), jobInfos[0].Reason)
+	require.True(t, jobInfos[0].DataProcessed < int64(numFiles))
+}
+
+func TestDeadLetterBranch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestDeadLetterBranch_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"good", "bad", "bad-too"} {
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	pipeline := tu.UniqueString("TestDeadLetterBranch")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("if [ -e /pfs/%s/bad ] || [ -e /pfs/%s/bad-too ]; then exit 1; fi", dataRepo, dataRepo),
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+				},
+			},
+			Input:            client.NewPFSInput(dataRepo, "/*"),
+			DatumTries:       1,
+			DeadLetterBranch: "dead-letter",
+		})
+	require.NoError(t, err)
+
+	// The job succeeds, with the output of the datum that didn't fail
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	jobInfo := jobInfos[0]
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfo.State)
+	require.Equal(t, int64(1), jobInfo.DataProcessed)
+	require.Equal(t, int64(0), jobInfo.DataFailed)
+	require.Equal(t, 2, len(jobInfo.DeadLetteredDatums))
+	fileInfos, err := c.ListFile(pipeline, jobInfo.OutputCommit.ID, "/")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(fileInfos))
+	require.Equal(t, "/good", fileInfos[0].File.Path)
+
+	// The failed datums are recorded in the dead letter branch, with their
+	// inputs, in a single commit
+	var failedPaths []string
+	for _, datumID := range jobInfo.DeadLetteredDatums {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline, "dead-letter", workerpkg.DeadLetterPath(jobInfo.Job.ID, datumID), 0, 0, &buf))
+		record := &pps.DeadLetterRecord{}
+		require.NoError(t, jsonpb.Unmarshal(&buf, record))
+		require.Equal(t, jobInfo.Job.ID, record.Job.ID)
+		require.Equal(t, datumID, record.DatumID)
+		require.True(t, record.Error != "")
+		require.Equal(t, 1, len(record.Inputs))
+		require.Equal(t, dataRepo, record.Inputs[0].Name)
+		require.Equal(t, dataRepo, record.Inputs[0].File.Commit.Repo.Name)
+		require.Equal(t, commit.ID, record.Inputs[0].File.Commit.ID)
+		failedPaths = append(failedPaths, record.Inputs[0].File.Path)
+	}
+	require.ElementsEqual(t, []string{"/bad", "/bad-too"}, failedPaths)
+	commitInfos, err := c.ListCommit(pipeline, "dead-letter", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(commitInfos))
+}
+
+func TestOutputCommitFailureSummary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestOutputCommitFailureSummary_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit1, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"good1", "good2", "good3", "bad1", "bad2"} {
+		_, err = c.PutFile(dataRepo, commit1.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(dataRepo, commit1.ID))
+
+	pipeline := tu.UniqueString("TestOutputCommitFailureSummary")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("if ls /pfs/%s/bad* >/dev/null 2>&1; then exit 1; fi", dataRepo),
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+				},
+			},
+			Input:            client.NewPFSInput(dataRepo, "/*"),
+			DatumTries:       1,
+			DeadLetterBranch: "dead-letter",
+		})
+	require.NoError(t, err)
+
+	// The job succeeds, and its output commit carries the summary of its
+	// failed datums
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit1}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+	commitInfo, err := c.InspectCommit(pipeline, jobInfos[0].OutputCommit.ID)
+	require.NoError(t, err)
+	require.Equal(t, "3", commitInfo.Labels[client.PPSDatumsSucceededLabel])
+	require.Equal(t, "0", commitInfo.Labels[client.PPSDatumsSkippedLabel])
+	require.Equal(t, "2", commitInfo.Labels[client.PPSDatumsFailedLabel])
+	require.Equal(t, 2, len(jobInfos[0].DeadLetteredDatums))
+	for _, datumID := range jobInfos[0].DeadLetteredDatums {
+		require.True(t, strings.Contains(commitInfo.Description, datumID), commitInfo.Description)
+	}
+
+	// The next job skips the datums that succeeded, and fails the same datums
+	commit2, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit2.ID, "good4", strings.NewReader("good4"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit2.ID))
+	jobInfos, err = c.FlushJobAll([]*pfs.Commit{commit2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	commitInfo, err = c.InspectCommit(pipeline, jobInfos[0].OutputCommit.ID)
+	require.NoError(t, err)
+	require.Equal(t, "1", commitInfo.Labels[client.PPSDatumsSucceededLabel])
+	require.Equal(t, "3", commitInfo.Labels[client.PPSDatumsSkippedLabel])
+	require.Equal(t, "2", commitInfo.Labels[client.PPSDatumsFailedLabel])
 
+	// Output commits with failed datums can be found by their labels
+	commitInfos, err := c.ListCommitByLabel(pipeline, client.PPSDatumsFailedLabel)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(commitInfos))
 }
 
-func TestRapidUpdatePipelines(t *testing.T) {
+func TestStageConcurrency(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
 
 	c := getPachClient(t)
 	require.NoError(t, c.DeleteAll())
-	pipeline := tu.UniqueString("TestRapidUpdatePipelines")
-	require.NoError(t, c.CreatePipeline(
-		pipeline,
-		"",
-		[]string{"cp", "/pfs/time/time", "/pfs/out/time"},
-		nil,
-		nil,
-		client.NewCronInput("time", "@every 5s"),
-		"",
-		false,
-	))
-
-	time.Sleep(10 * time.Second)
 
-	for i := 0; i < 20; i++ {
-		_, err := c.PpsAPIClient.CreatePipeline(
-			context.Background(),
-			&pps.CreatePipelineRequest{
-				Pipeline: client.NewPipeline(pipeline),
-				Transform: &pps.Transform{
-					Cmd: []string{"cp", "/pfs/time/time", "/pfs/out/time"},
-				},
-				Input:     client.NewCronInput("time", "@every 5s"),
-				Update:    true,
-				Reprocess: true,
-			})
+	dataRepo := tu.UniqueString("TestStageConcurrency_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	numFiles := 20
+	for i := 0; i < numFiles; i++ {
+		_, err = c.PutFile(dataRepo, commit.ID, fmt.Sprintf("file%d", i), strings.NewReader(fmt.Sprintf("%d", i)))
 		require.NoError(t, err)
 	}
-	require.NoErrorWithinTRetry(t, 2*time.Minute, func() error {
-		jis, err := c.ListJob(pipeline, nil, nil)
-		if err != nil {
-			return err
-		}
-		if len(jis) < 10 {
-			return fmt.Errorf("should have more than 10 jobs in 2 minutes")
-		}
-		for i := 0; i < 5; i++ {
-			difference := jis[i].Started.Seconds - jis[i+1].Started.Seconds
-			if difference < 4 {
-				return fmt.Errorf("jobs too close together")
-			} else if difference > 6 {
-				return fmt.Errorf("jobs too far apart")
-			}
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	// Each datum's output includes a symlink through /pfs/out, which must be
+	// read from that datum's output even if the next datum's code is running
+	// by the time it's uploaded
+	pipeline := tu.UniqueString("TestStageConcurrency")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"bash"},
+				Stdin: []string{
+					fmt.Sprintf("for f in /pfs/%s/*; do", dataRepo),
+					"  name=$(basename $f)",
+					"  cp $f /pfs/out/$name",
+					"  ln -s /pfs/out/$name /pfs/out/$name.link",
+					"done",
+				},
+			},
+			Input:            client.NewPFSInput(dataRepo, "/*"),
+			StageConcurrency: &pps.StageConcurrency{Download: 2, Upload: 2},
+		})
+	require.NoError(t, err)
+
+	commitIter, err := c.FlushCommit([]*pfs.Commit{commit}, nil)
+	require.NoError(t, err)
+	commitInfos := collectCommitInfos(t, commitIter)
+	require.Equal(t, 1, len(commitInfos))
+	outputCommit := commitInfos[0].Commit
+	for i := 0; i < numFiles; i++ {
+		for _, file := range []string{fmt.Sprintf("file%d", i), fmt.Sprintf("file%d.link", i)} {
+			var buf bytes.Buffer
+			require.NoError(t, c.GetFile(pipeline, outputCommit.ID, file, 0, 0, &buf))
+			require.Equal(t, fmt.Sprintf("%d", i), buf.String())
 		}
-		return nil
-	})
+	}
 }
 
-func TestDatumTries(t *testing.T) {
+func TestPipelineFinalizer(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
 
 	c := getPachClient(t)
 	require.NoError(t, c.DeleteAll())
+	kubeClient := tu.GetKubeClient(t)
+	finalizerPods := func(pipeline string) ([]v1.Pod, error) {
+		podList, err := kubeClient.CoreV1().Pods(v1.NamespaceDefault).List(
+			metav1.ListOptions{
+				LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(
+					map[string]string{"component": "finalizer", "suite": "pachyderm"},
+				)),
+			})
+		if err != nil {
+			return nil, err
+		}
+		var result []v1.Pod
+		for _, pod := range podList.Items {
+			if pod.ObjectMeta.Annotations["pipelineName"] == pipeline {
+				result = append(result, pod)
+			}
+		}
+		return result, nil
+	}
 
-	dataRepo := tu.UniqueString("TestDatumTries_data")
+	dataRepo := tu.UniqueString("TestPipelineFinalizer_data")
 	require.NoError(t, c.CreateRepo(dataRepo))
 
-	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	// The pipeline isn't removed until its finalizer has run
+	pipeline := tu.UniqueString("TestPipelineFinalizer")
+	_, err := c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipeline),
+			Transform: &pps.Transform{
+				Cmd: []string{"true"},
+			},
+			Input: client.NewPFSInput(dataRepo, "/*"),
+			Finalizer: &pps.Finalizer{
+				Cmd: []string{"sh", "-c", "sleep 15"},
+			},
+		})
+	require.NoError(t, err)
+	deleteErr := make(chan error, 1)
+	go func() {
+		deleteErr <- c.DeletePipeline(pipeline, false)
+	}()
+	require.NoError(t, backoff.Retry(func() error {
+		pods, err := finalizerPods(pipeline)
+		if err != nil {
+			return err
+		}
+		if len(pods) == 0 {
+			return fmt.Errorf("finalizer of %s hasn't started", pipeline)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+	_, err = c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.NoError(t, <-deleteErr)
+	_, err = c.InspectPipeline(pipeline)
+	require.YesError(t, err)
+	pods, err := finalizerPods(pipeline)
 	require.NoError(t, err)
+	require.Equal(t, 0, len(pods))
 
-	tries := int64(5)
-	pipeline := tu.UniqueString("TestSimplePipeline")
+	// A pipeline whose finalizer fails is still deleted
+	failing := tu.UniqueString("TestPipelineFinalizer_failing")
 	_, err = c.PpsAPIClient.CreatePipeline(
 		context.Background(),
 		&pps.CreatePipelineRequest{
-			Pipeline: client.NewPipeline(pipeline),
+			Pipeline: client.NewPipeline(failing),
 			Transform: &pps.Transform{
-				Cmd: []string{"unknown"}, // Cmd fails because "unknown" isn't a known command.
+				Cmd: []string{"true"},
+			},
+			Input: client.NewPFSInput(dataRepo, "/*"),
+			Finalizer: &pps.Finalizer{
+				Cmd:     []string{"sh", "-c", "exit 1"},
+				Timeout: types.DurationProto(30 * time.Second),
+				Tries:   2,
 			},
-			Input:      client.NewPFSInput(dataRepo, "/"),
-			DatumTries: tries,
 		})
 	require.NoError(t, err)
-	jobInfos, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, c.DeletePipeline(failing, false))
+	_, err = c.InspectPipeline(failing)
+	require.YesError(t, err)
+	pods, err = finalizerPods(failing)
 	require.NoError(t, err)
-	require.Equal(t, 1, len(jobInfos))
-
-	iter := c.GetLogs("", jobInfos[0].Job.ID, nil, "", false, false, 0)
-	var observedTries int64
-	for iter.Next() {
-		if strings.Contains(iter.Message().Message, "errored running user code after") {
-			observedTries++
-		}
-	}
-	require.Equal(t, tries, observedTries)
+	require.Equal(t, 0, len(pods))
 }
 
 func TestInspectJob(t *testing.T) {
@@ -7828,7 +10980,7 @@ func getObjectCountForRepo(t testing.TB, c *client.APIClient, repo string) int {
 	require.NoError(t, err)
 	repoInfo, err := pachClient.InspectRepo(repo)
 	require.NoError(t, err)
-	activeStat, err := pps_server.CollectActiveObjectsAndTags(context.Background(), c, []*pfs.RepoInfo{repoInfo}, pipelineInfos, 0, "")
+	activeStat, err := pps_server.CollectActiveObjectsAndTags(context.Background(), c, []*pfs.RepoInfo{repoInfo}, pipelineInfos, 0, 0, "")
 	require.NoError(t, err)
 	return activeStat.NObjects
 }
@@ -8067,3 +11219,229 @@ func getEtcdClient(t testing.TB) *etcd.Client {
 	})
 	return etcdClient
 }
+
+func TestOutputPermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestOutputPermissions_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	// 'upstream' writes a script without any permissions set, and 'downstream'
+	// checks the mode it's downloaded with
+	upstream := tu.UniqueString("TestOutputPermissions_upstream")
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(upstream),
+			Transform: &pps.Transform{
+				Cmd:   []string{"bash"},
+				Stdin: []string{"echo 'echo hello' > /pfs/out/script", "chmod 0600 /pfs/out/script"},
+			},
+			Input: client.NewPFSInput(dataRepo, "/"),
+			OutputPermissions: &pps.OutputPermissions{
+				Mode:  "0755",
+				Owner: &pfs.FileOwner{Uid: 1000, Gid: 1000},
+			},
+		})
+	require.NoError(t, err)
+	downstream := tu.UniqueString("TestOutputPermissions_downstream")
+	require.NoError(t, c.CreatePipeline(
+		downstream,
+		"",
+		[]string{"bash"},
+		[]string{
+			fmt.Sprintf("stat -c %%a /pfs/%s/script > /pfs/out/mode", upstream),
+			fmt.Sprintf("/pfs/%s/script > /pfs/out/output", upstream),
+		},
+		nil,
+		client.NewPFSInput(upstream, "/"),
+		"",
+		false,
+	))
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(collectCommitInfos(t, commitIter)))
+
+	// The output file has the pipeline's permissions after it's committed...
+	fileInfo, err := c.InspectFile(upstream, "master", "script")
+	require.NoError(t, err)
+	require.Equal(t, uint32(0755), fileInfo.Mode)
+	require.Equal(t, &pfs.FileOwner{Uid: 1000, Gid: 1000}, fileInfo.Owner)
+
+	// ...and when it's downloaded, both by a downstream pipeline...
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(downstream, "master", "mode", 0, 0, &buf))
+	require.Equal(t, "755\n", buf.String())
+	buf.Reset()
+	require.NoError(t, c.GetFile(downstream, "master", "output", 0, 0, &buf))
+	require.Equal(t, "hello\n", buf.String())
+
+	// ...and locally
+	dir, err := ioutil.TempDir("", "TestOutputPermissions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	puller := pfssync.NewPuller()
+	require.NoError(t, puller.Pull(c, dir, upstream, "master", "/", false, false, 1, nil, ""))
+	info, err := os.Stat(path.Join(dir, "script"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
+
+func TestPipelineInputRepoDeleted(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineInputRepoDeleted_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	pipeline := tu.UniqueString("TestPipelineInputRepoDeleted")
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{"cp /pfs/*/* /pfs/out/"},
+		nil,
+		client.NewPFSInput(dataRepo, "/*"),
+		"",
+		false,
+	))
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+
+	// Once the pipeline's input repo is deleted out from under it, the
+	// pipeline is failed with a reason that says so (pachd checks pipelines'
+	// inputs every minute by default)
+	require.NoError(t, c.DeleteRepo(dataRepo, true))
+	require.NoErrorWithinTRetry(t, 3*time.Minute, func() error {
+		pipelineInfo, err := c.InspectPipeline(pipeline)
+		if err != nil {
+			return err
+		}
+		if pipelineInfo.State != pps.PipelineState_PIPELINE_FAILURE {
+			return fmt.Errorf("pipeline %s should have failed, but is %v", pipeline, pipelineInfo.State)
+		}
+		return nil
+	})
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("input repo %q no longer exists", dataRepo), pipelineInfo.Reason)
+}
+
+// TestCSIVolume mounts a commit into a pod with the PFS CSI driver, if it's
+// deployed (see "pachctl deploy --csi-driver")
+func TestCSIVolume(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	kubeClient := tu.GetKubeClient(t)
+	if _, err := kubeClient.AppsV1().DaemonSets(v1.NamespaceDefault).Get("pfs-csi", metav1.GetOptions{}); err != nil {
+		t.Skip("Skipping test that requires the PFS CSI driver, which isn't deployed")
+	}
+
+	c := getPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestCSIVolume_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "dir/file", strings.NewReader("foo\n"))
+	require.NoError(t, err)
+
+	// The volume is a PersistentVolume with the driver's attributes, bound
+	// to a claim that the pod mounts
+	name := tu.UniqueString("pfs-csi-test-")
+	_, err = kubeClient.CoreV1().PersistentVolumes().Create(&v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity:                      v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+			AccessModes:                   []v1.PersistentVolumeAccessMode{v1.ReadOnlyMany},
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain,
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       csi.DriverName,
+					VolumeHandle: name,
+					ReadOnly:     true,
+					VolumeAttributes: map[string]string{
+						csi.RepoAttribute: dataRepo,
+						csi.PathAttribute: "dir",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer kubeClient.CoreV1().PersistentVolumes().Delete(name, &metav1.DeleteOptions{})
+	noStorageClass := ""
+	_, err = kubeClient.CoreV1().PersistentVolumeClaims(v1.NamespaceDefault).Create(&v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadOnlyMany},
+			StorageClassName: &noStorageClass,
+			VolumeName:       name,
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer kubeClient.CoreV1().PersistentVolumeClaims(v1.NamespaceDefault).Delete(name, &metav1.DeleteOptions{})
+
+	// The pod reads the file, and checks that the volume is read-only
+	pods := kubeClient.CoreV1().Pods(v1.NamespaceDefault)
+	_, err = pods.Create(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:    "reader",
+				Image:   "ubuntu:16.04",
+				Command: []string{"sh", "-c", "cat /pfs/dir/file && ! touch /pfs/dir/new"},
+				VolumeMounts: []v1.VolumeMount{{
+					Name:      "pfs",
+					MountPath: "/pfs",
+					ReadOnly:  true,
+				}},
+			}},
+			RestartPolicy: v1.RestartPolicyNever,
+			Volumes: []v1.Volume{{
+				Name: "pfs",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: name,
+						ReadOnly:  true,
+					},
+				},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	defer pods.Delete(name, &metav1.DeleteOptions{})
+	var phase v1.PodPhase
+	b := backoff.NewTestingBackOff()
+	b.MaxElapsedTime = 5 * time.Minute
+	require.NoError(t, backoff.Retry(func() error {
+		pod, err := pods.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			return fmt.Errorf("pod %s is %s", name, pod.Status.Phase) // retry
+		}
+		phase = pod.Status.Phase
+		return nil
+	}, b))
+	logs, err := pods.GetLogs(name, &v1.PodLogOptions{}).Do().Raw()
+	require.NoError(t, err)
+	require.Equal(t, v1.PodSucceeded, phase, string(logs))
+	require.Equal(t, "foo\n", string(logs))
+}
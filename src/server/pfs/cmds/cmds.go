@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	gosync "sync"
 	"syscall"
@@ -61,6 +62,9 @@ func Cmds(noMetrics *bool) []*cobra.Command {
 	}
 
 	var description string
+	var recommendedGlob string
+	var maxCommitFiles int64
+	var maxCommitBytes int64
 	createRepo := &cobra.Command{
 		Use:   "create-repo repo-name",
 		Short: "Create a new repo.",
@@ -73,14 +77,20 @@ func Cmds(noMetrics *bool) []*cobra.Command {
 			_, err = c.PfsAPIClient.CreateRepo(
 				c.Ctx(),
 				&pfsclient.CreateRepoRequest{
-					Repo:        client.NewRepo(args[0]),
-					Description: description,
+					Repo:            client.NewRepo(args[0]),
+					Description:     description,
+					RecommendedGlob: recommendedGlob,
+					MaxCommitFiles:  maxCommitFiles,
+					MaxCommitBytes:  maxCommitBytes,
 				},
 			)
 			return grpcutil.ScrubGRPC(err)
 		}),
 	}
 	createRepo.Flags().StringVarP(&description, "description", "d", "", "A description of the repo.")
+	createRepo.Flags().StringVar(&recommendedGlob, "recommended-glob", "", "The glob that pipelines reading from the repo should usually use (e.g. \"/*\"), which is suggested to users who create a pipeline without one.")
+	createRepo.Flags().Int64Var(&maxCommitFiles, "max-commit-files", 0, "The most files that a commit in the repo may add or change, which overrides the cluster's limit. 0 means the cluster's limit applies, and a negative number means no limit.")
+	createRepo.Flags().Int64Var(&maxCommitBytes, "max-commit-bytes", 0, "The most bytes that a commit in the repo may add or change, which overrides the cluster's limit. 0 means the cluster's limit applies, and a negative number means no limit.")
 
 	updateRepo := &cobra.Command{
 		Use:   "update-repo repo-name",
@@ -94,15 +104,21 @@ func Cmds(noMetrics *bool) []*cobra.Command {
 			_, err = c.PfsAPIClient.CreateRepo(
 				c.Ctx(),
 				&pfsclient.CreateRepoRequest{
-					Repo:        client.NewRepo(args[0]),
-					Description: description,
-					Update:      true,
+					Repo:            client.NewRepo(args[0]),
+					Description:     description,
+					RecommendedGlob: recommendedGlob,
+					MaxCommitFiles:  maxCommitFiles,
+					MaxCommitBytes:  maxCommitBytes,
+					Update:          true,
 				},
 			)
 			return grpcutil.ScrubGRPC(err)
 		}),
 	}
 	updateRepo.Flags().StringVarP(&description, "description", "d", "", "A description of the repo.")
+	updateRepo.Flags().StringVar(&recommendedGlob, "recommended-glob", "", "The glob that pipelines reading from the repo should usually use (e.g. \"/*\"), which is suggested to users who create a pipeline without one.")
+	updateRepo.Flags().Int64Var(&maxCommitFiles, "max-commit-files", 0, "The most files that a commit in the repo may add or change, which overrides the cluster's limit. 0 keeps the repo's current limit, and a negative number means no limit.")
+	updateRepo.Flags().Int64Var(&maxCommitBytes, "max-commit-bytes", 0, "The most bytes that a commit in the repo may add or change, which overrides the cluster's limit. 0 keeps the repo's current limit, and a negative number means no limit.")
 
 	inspectRepo := &cobra.Command{
 		Use:   "inspect-repo repo-name",
@@ -128,15 +144,42 @@ func Cmds(noMetrics *bool) []*cobra.Command {
 	}
 	rawFlag(inspectRepo)
 
+	var diskUsage bool
 	listRepo := &cobra.Command{
 		Use:   "list-repo",
 		Short: "Return all repos.",
-		Long:  "Return all repos.",
+		Long: `Return all repos.
+
+With --size, the storage used by each repo is returned instead: its logical
+size (the total size of its commits), its physical size (the size of the
+distinct data its commits reference, after deduplication), and its exclusive
+size (the part of its physical size that no other repo references, i.e.
+roughly the storage that deleting the repo would free). Computing these reads
+every commit in every repo, so it may be slow.`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			c, err := client.NewOnUserMachine(metrics, "user")
 			if err != nil {
 				return err
 			}
+			if diskUsage {
+				repoUsages, err := c.DiskUsage("")
+				if err != nil {
+					return err
+				}
+				if raw {
+					for _, repoUsage := range repoUsages {
+						if err := marshaller.Marshal(os.Stdout, repoUsage); err != nil {
+							return err
+						}
+					}
+					return nil
+				}
+				writer := tabwriter.NewWriter(os.Stdout, pretty.RepoDiskUsageHeader)
+				for _, repoUsage := range repoUsages {
+					pretty.PrintRepoDiskUsage(writer, repoUsage)
+				}
+				return writer.Flush()
+			}
 			repoInfos, err := c.ListRepo()
 			if err != nil {
 				return err
@@ -161,6 +204,7 @@ func Cmds(noMetrics *bool) []*cobra.Command {
 			return writer.Flush()
 		}),
 	}
+	listRepo.Flags().BoolVar(&diskUsage, "size", false, "Return the storage used by each repo.")
 	rawFlag(listRepo)
 
 	var force bool
@@ -220,6 +264,7 @@ This layers the data in the commit over the data in the parent.
 	}
 
 	var parent string
+	var labels map[string]string
 	startCommit := &cobra.Command{
 		Use:   "start-commit repo-name [branch]",
 		Short: "Start a new commit.",
@@ -238,6 +283,9 @@ $ pachctl start-commit test patch -p master
 
 # Start a commit with XXX as the parent in repo "test", not on any branch
 $ pachctl start-commit test -p XXX
+
+# Start a commit in repo "test" on branch "master", labeled "release=v2"
+$ pachctl start-commit test master --label release=v2
 ` + codeend,
 		Run: cmdutil.RunBoundedArgs(1, 2, func(args []string) error {
 			cli, err := client.NewOnUserMachine(metrics, "user")
@@ -253,6 +301,7 @@ $ pachctl start-commit test -p XXX
 					Branch:      branch,
 					Parent:      client.NewCommit(args[0], parent),
 					Description: description,
+					Labels:      labels,
 				})
 			if err != nil {
 				return grpcutil.ScrubGRPC(err)
@@ -264,6 +313,7 @@ $ pachctl start-commit test -p XXX
 	startCommit.Flags().StringVarP(&parent, "parent", "p", "", "The parent of the new commit, unneeded if branch is specified and you want to use the previous head of the branch as the parent.")
 	startCommit.Flags().StringVarP(&description, "message", "m", "", "A description of this commit's contents")
 	startCommit.Flags().StringVar(&description, "description", "", "A description of this commit's contents (synonym for --message)")
+	startCommit.Flags().StringToStringVarP(&labels, "label", "l", nil, "A label to attach to this commit, as key=value (may be repeated)")
 
 	finishCommit := &cobra.Command{
 		Use:   "finish-commit repo-name commit-id",
@@ -274,11 +324,12 @@ $ pachctl start-commit test -p XXX
 			if err != nil {
 				return err
 			}
-			if description != "" {
+			if description != "" || len(labels) > 0 {
 				_, err := cli.PfsAPIClient.FinishCommit(cli.Ctx(),
 					&pfsclient.FinishCommitRequest{
 						Commit:      client.NewCommit(args[0], args[1]),
 						Description: description,
+						Labels:      labels,
 					})
 				return grpcutil.ScrubGRPC(err)
 			}
@@ -287,6 +338,7 @@ $ pachctl start-commit test -p XXX
 	}
 	finishCommit.Flags().StringVarP(&description, "message", "m", "", "A description of this commit's contents (overwrites any existing commit description)")
 	finishCommit.Flags().StringVar(&description, "description", "", "A description of this commit's contents (synonym for --message)")
+	finishCommit.Flags().StringToStringVarP(&labels, "label", "l", nil, "A label to add to this commit, as key=value (may be repeated; overwrites any existing label with the same key)")
 
 	inspectCommit := &cobra.Command{
 		Use:   "inspect-commit repo-name commit-id",
@@ -314,6 +366,7 @@ $ pachctl start-commit test -p XXX
 
 	var from string
 	var number int
+	var selector string
 	listCommit := &cobra.Command{
 		Use:   "list-commit repo-name",
 		Short: "Return all commits on a set of repos.",
@@ -335,6 +388,12 @@ $ pachctl list-commit foo XXX
 
 # return commits in repo "foo" since commit XXX
 $ pachctl list-commit foo master --from XXX
+
+# return commits in repo "foo" labeled "release=v2"
+$ pachctl list-commit foo -l release=v2
+
+# return commits in repo "foo" whose "env" label is "prod" or "staging"
+$ pachctl list-commit foo -l 'env in (prod,staging)'
 ` + codeend,
 		Run: cmdutil.RunBoundedArgs(1, 2, func(args []string) (retErr error) {
 			c, err := client.NewOnUserMachine(metrics, "user")
@@ -347,12 +406,12 @@ $ pachctl list-commit foo master --from XXX
 				to = args[1]
 			}
 			if raw {
-				return c.ListCommitF(args[0], to, from, uint64(number), func(ci *pfsclient.CommitInfo) error {
+				return c.ListCommitLabelF(args[0], to, from, uint64(number), selector, func(ci *pfsclient.CommitInfo) error {
 					return marshaller.Marshal(os.Stdout, ci)
 				})
 			}
 			writer := tabwriter.NewWriter(os.Stdout, pretty.CommitHeader)
-			if err := c.ListCommitF(args[0], to, from, uint64(number), func(ci *pfsclient.CommitInfo) error {
+			if err := c.ListCommitLabelF(args[0], to, from, uint64(number), selector, func(ci *pfsclient.CommitInfo) error {
 				pretty.PrintCommitInfo(writer, ci)
 				return nil
 			}); err != nil {
@@ -363,6 +422,7 @@ $ pachctl list-commit foo master --from XXX
 	}
 	listCommit.Flags().StringVarP(&from, "from", "f", "", "list all commits since this commit")
 	listCommit.Flags().IntVarP(&number, "number", "n", 0, "list only this many commits; if set to zero, list all commits")
+	listCommit.Flags().StringVarP(&selector, "selector", "l", "", "list only commits whose labels match this selector, e.g. 'release=v2' or 'env in (prod,staging)'")
 	rawFlag(listCommit)
 
 	printCommitIter := func(commitIter client.CommitInfoIterator) error {
@@ -547,6 +607,52 @@ $ pachctl subscribe-commit test master --new
 	}
 	rawFlag(listBranch)
 
+	var branchHistory bool
+	listCommitBranches := &cobra.Command{
+		Use:   "list-commit-branches repo-name commit-id",
+		Short: "Return the branches that reference a commit.",
+		Long: `Return the branches that reference a commit.
+
+By default, only the branches whose head is the commit are returned. With
+--history, branches that have the commit in their history are returned too:
+branches in the commit's repo whose head descends from it, and branches in
+downstream repos whose history includes a commit computed from it. A commit
+that no branch references, even with --history, isn't needed by any branch.
+
+Examples:
+
+` + codestart + `# return the branches of repo foo whose head is commit XXX
+$ pachctl list-commit-branches foo XXX
+
+# return all branches in any repo that have commit XXX in their history
+$ pachctl list-commit-branches foo XXX --history` + codeend,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			client, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			branches, err := client.ListCommitBranches(args[0], args[1], branchHistory)
+			if err != nil {
+				return err
+			}
+			if raw {
+				for _, branch := range branches {
+					if err := marshaller.Marshal(os.Stdout, branch); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.CommitBranchHeader)
+			for _, branch := range branches {
+				pretty.PrintCommitBranch(writer, branch)
+			}
+			return writer.Flush()
+		}),
+	}
+	listCommitBranches.Flags().BoolVar(&branchHistory, "history", false, "Also return branches that have the commit in their history.")
+	rawFlag(listCommitBranches)
+
 	setBranch := &cobra.Command{
 		Use:   "set-branch repo-name commit-id/branch-name new-branch-name",
 		Short: "DEPRECATED Set a commit and its ancestors to a branch",
@@ -571,6 +677,62 @@ $ pachctl set-branch foo test master` + codeend,
 		}),
 	}
 
+	setMaxHistoryDepth := &cobra.Command{
+		Use:   "set-max-history-depth repo-name branch-name depth",
+		Short: "Limit the number of commits kept in a branch's history.",
+		Long: `Limit the number of commits kept in a branch's history.
+
+Once a branch has more than 'depth' commits, its oldest commits are deleted as
+new commits are finished on it. Commits that are still needed, because they're
+the head of another branch, are still open, or have downstream commits (e.g. a
+pipeline's output commits), are kept, and commits that downstream pipelines
+haven't finished processing don't count towards the depth. To delete the
+commits that a pipeline's output commits came from, limit the history of the
+pipeline's output branch too. Deleting old commits doesn't change the content
+of the branch's remaining commits. A depth of 0 removes the limit.
+
+Examples:
+
+` + codestart + `# keep only the 100 most recent commits on the master branch of repo foo
+$ pachctl set-max-history-depth foo master 100` + codeend,
+		Run: cmdutil.RunFixedArgs(3, func(args []string) error {
+			depth, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("could not parse depth %q: %v", args[2], err)
+			}
+			client, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return client.SetMaxHistoryDepth(args[0], args[1], depth)
+		}),
+	}
+
+	swapBranch := &cobra.Command{
+		Use:   "swap-branch repo-name branch-name other-branch-name",
+		Short: "Atomically swap the heads of two branches.",
+		Long: `Atomically swap the heads of two branches.
+
+Each branch takes the other's head commit (and provenance), in a single
+transaction, so downstream pipelines see both branches change at once and never
+one without the other. This is useful for blue/green promotion: swapping a
+staging branch with a production branch makes staging's head production's
+head, while keeping production's previous head in staging so it can be swapped
+back. A swap that would make a branch part of its own provenance is refused.
+
+Examples:
+
+` + codestart + `# promote the staging branch of repo foo to production
+$ pachctl swap-branch foo staging production` + codeend,
+		Run: cmdutil.RunFixedArgs(3, func(args []string) error {
+			client, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return client.SwapBranch(args[0], args[1], args[2])
+		}),
+	}
+
 	deleteBranch := &cobra.Command{
 		Use:   "delete-branch repo-name branch-name",
 		Short: "Delete a branch",
@@ -607,6 +769,7 @@ $ pachctl set-branch foo test master` + codeend,
 	var headerRecords uint
 	var putFileCommit bool
 	var overwrite bool
+	var chunkConcurrency int
 	putFile := &cobra.Command{
 		Use:   "put-file repo-name branch [path/to/file/in/pfs]",
 		Short: "Put a file into the filesystem.",
@@ -623,6 +786,9 @@ $ pachctl put-file repo branch path -f file
 # Put a file from the local filesystem as repo/branch/file:
 $ pachctl put-file repo branch -f file
 
+# Put a large file from the local filesystem, uploading 8 chunks of it at once:
+$ pachctl put-file repo branch path -f file --chunk-concurrency 8
+
 # Put the contents of a directory as repo/branch/path/dir/file:
 $ pachctl put-file -r repo branch path -f dir
 
@@ -681,6 +847,9 @@ want to consider using commit IDs directly.
 			if putFileCommit {
 				fmt.Fprintf(os.Stderr, "flag --commit / -c is deprecated; as of 1.7.2, you will get the same behavior without it\n")
 			}
+			if chunkConcurrency > 1 && split != "" {
+				return fmt.Errorf("--chunk-concurrency can't be used with --split")
+			}
 
 			limiter := limit.New(int(parallelism))
 			var sources []string
@@ -735,19 +904,19 @@ want to consider using commit IDs directly.
 						return fmt.Errorf("must specify filename when reading data from stdin")
 					}
 					eg.Go(func() error {
-						return putFileHelper(c, pfc, repoName, branch, joinPaths("", source), source, recursive, overwrite, limiter, split, targetFileDatums, targetFileBytes, headerRecords, filesPut)
+						return putFileHelper(c, pfc, repoName, branch, joinPaths("", source), source, recursive, overwrite, chunkConcurrency, limiter, split, targetFileDatums, targetFileBytes, headerRecords, filesPut)
 					})
 				} else if len(sources) == 1 && len(args) == 3 {
 					// We have a single source and the user has specified a path,
 					// we use the path and ignore source (in terms of naming the file).
 					eg.Go(func() error {
-						return putFileHelper(c, pfc, repoName, branch, path, source, recursive, overwrite, limiter, split, targetFileDatums, targetFileBytes, headerRecords, filesPut)
+						return putFileHelper(c, pfc, repoName, branch, path, source, recursive, overwrite, chunkConcurrency, limiter, split, targetFileDatums, targetFileBytes, headerRecords, filesPut)
 					})
 				} else if len(sources) > 1 && len(args) == 3 {
 					// We have multiple sources and the user has specified a path,
 					// we use that path as a prefix for the filepaths.
 					eg.Go(func() error {
-						return putFileHelper(c, pfc, repoName, branch, joinPaths(path, source), source, recursive, overwrite, limiter, split, targetFileDatums, targetFileBytes, headerRecords, filesPut)
+						return putFileHelper(c, pfc, repoName, branch, joinPaths(path, source), source, recursive, overwrite, chunkConcurrency, limiter, split, targetFileDatums, targetFileBytes, headerRecords, filesPut)
 					})
 				}
 			}
@@ -764,6 +933,7 @@ want to consider using commit IDs directly.
 	putFile.Flags().UintVar(&headerRecords, "header-records", 0, "the number of records that will be converted to a PFS 'header', and prepended to future retrievals of any subset of data from PFS; needs to be used with --split=(json|line|csv)")
 	putFile.Flags().BoolVarP(&putFileCommit, "commit", "c", false, "DEPRECATED: Put file(s) in a new commit.")
 	putFile.Flags().BoolVarP(&overwrite, "overwrite", "o", false, "Overwrite the existing content of the file, either from previous commits or previous calls to put-file within this commit.")
+	putFile.Flags().IntVar(&chunkConcurrency, "chunk-concurrency", 1, "The number of chunks of each local file that are uploaded in parallel; raising it speeds up putting large files. Can't be used with --split.")
 
 	copyFile := &cobra.Command{
 		Use:   "copy-file src-repo src-commit src-path dst-repo dst-commit dst-path",
@@ -784,6 +954,10 @@ want to consider using commit IDs directly.
 		Use:   "get-file repo-name commit-id path/to/file",
 		Short: "Return the contents of a file.",
 		Long: `Return the contents of a file.
+
+Files in open commits of input repos can be read before the commits are
+finished. They reflect the put-file calls to the commit that have completed so
+far, and may still change.
 ` + codestart + `# get file "XXX" on branch "master" in repo "foo"
 $ pachctl get-file foo master XXX
 
@@ -807,25 +981,58 @@ $ pachctl get-file foo master^2 XXX
 				puller := sync.NewPuller()
 				return puller.Pull(client, outputPath, args[0], args[1], args[2], false, false, parallelism, nil, "")
 			}
-			var w io.Writer
-			// If an output path is given, print the output to stdout
+			// If no output path is given, print the output to stdout
 			if outputPath == "" {
-				w = os.Stdout
-			} else {
-				f, err := os.Create(outputPath)
-				if err != nil {
-					return err
-				}
-				defer f.Close()
-				w = f
+				return client.GetFile(args[0], args[1], args[2], 0, 0, os.Stdout)
 			}
-			return client.GetFile(args[0], args[1], args[2], 0, 0, w)
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := client.GetFile(args[0], args[1], args[2], 0, 0, f); err != nil {
+				return err
+			}
+			// Give the downloaded file the permissions it has in PFS
+			fileInfo, err := client.InspectFile(args[0], args[1], args[2])
+			if err != nil {
+				return err
+			}
+			return sync.SetPermissions(outputPath, fileInfo.Mode, fileInfo.Owner)
 		}),
 	}
 	getFile.Flags().BoolVarP(&recursive, "recursive", "r", false, "Recursively download a directory.")
 	getFile.Flags().StringVarP(&outputPath, "output", "o", "", "The path where data will be downloaded.")
 	getFile.Flags().IntVarP(&parallelism, "parallelism", "p", DefaultParallelism, "The maximum number of files that can be downloaded in parallel")
 
+	getManifest := &cobra.Command{
+		Use:   "get-manifest repo-name commit-id [path/to/dir]",
+		Short: "Return a manifest of the files in a commit.",
+		Long: `Return a manifest of the files in a commit (or of those under a path), as
+newline-delimited JSON: the path, size and hashes of each file, in order of
+path. "sha256" is the sha256 digest of the file's content, so the manifest can
+be used to verify copies of the files kept outside of pachyderm (e.g. with
+sha256sum). "node_hash" is the hash returned by inspect-file, which is derived
+from how the file's content is stored, not from the content alone.
+` + codestart + `# get the manifest of the head of branch "master" in repo "foo"
+$ pachctl get-manifest foo master > manifest.json
+
+# get the manifest of the files under "dir"
+$ pachctl get-manifest foo master dir
+` + codeend,
+		Run: cmdutil.RunBoundedArgs(2, 3, func(args []string) error {
+			client, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			var path string
+			if len(args) == 3 {
+				path = args[2]
+			}
+			return client.GetManifest(args[0], args[1], path, os.Stdout)
+		}),
+	}
+
 	inspectFile := &cobra.Command{
 		Use:   "inspect-file repo-name commit-id path/to/file",
 		Short: "Return info about a file.",
@@ -1043,6 +1250,42 @@ $ pachctl diff-file foo master path1 bar master path2
 		}),
 	}
 
+	inspectCache := &cobra.Command{
+		Use:   "inspect-cache",
+		Short: "Return the stats of pachd's object caches.",
+		Long: `Return the stats of pachd's in-memory caches of object storage: the memory
+each uses (out of its share of the block cache size that pachd was deployed
+with), and how often lookups are served from it. Each pod has its own caches,
+so the stats are listed for the pachd that serves the request and for every
+worker's pachd sidecar. With several pachd replicas, only one of them is listed.`,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := client.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			podCacheInfos, err := client.InspectCache()
+			if err != nil {
+				return err
+			}
+			if raw {
+				for _, podCacheInfo := range podCacheInfos {
+					if err := marshaller.Marshal(os.Stdout, podCacheInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.CacheHeader)
+			for _, podCacheInfo := range podCacheInfos {
+				for _, cacheInfo := range podCacheInfo.Caches {
+					pretty.PrintCacheInfo(writer, podCacheInfo.Pod, cacheInfo)
+				}
+			}
+			return writer.Flush()
+		}),
+	}
+	rawFlag(inspectCache)
+
 	var debug bool
 	var commits cmdutil.RepeatedStringArg
 	mount := &cobra.Command{
@@ -1140,12 +1383,16 @@ $ pachctl diff-file foo master path1 bar master path2
 	result = append(result, deleteCommit)
 	result = append(result, createBranch)
 	result = append(result, listBranch)
+	result = append(result, listCommitBranches)
 	result = append(result, setBranch)
+	result = append(result, setMaxHistoryDepth)
+	result = append(result, swapBranch)
 	result = append(result, deleteBranch)
 	result = append(result, file)
 	result = append(result, putFile)
 	result = append(result, copyFile)
 	result = append(result, getFile)
+	result = append(result, getManifest)
 	result = append(result, inspectFile)
 	result = append(result, listFile)
 	result = append(result, globFile)
@@ -1153,6 +1400,7 @@ $ pachctl diff-file foo master path1 bar master path2
 	result = append(result, deleteFile)
 	result = append(result, getObject)
 	result = append(result, getTag)
+	result = append(result, inspectCache)
 	result = append(result, mount)
 	result = append(result, unmount)
 	return result
@@ -1172,7 +1420,7 @@ func parseCommits(args []string) (map[string]string, error) {
 
 func putFileHelper(c *client.APIClient, pfc client.PutFileClient,
 	repo, commit, path, source string, recursive, overwrite bool, // destination
-	limiter limit.ConcurrencyLimiter,
+	chunkConcurrency int, limiter limit.ConcurrencyLimiter,
 	split string, targetFileDatums, targetFileBytes, headerRecords uint, // split
 	filesPut *gosync.Map) (retErr error) {
 	if _, ok := filesPut.LoadOrStore(path, nil); ok {
@@ -1238,7 +1486,7 @@ func putFileHelper(c *client.APIClient, pfc client.PutFileClient,
 				// filePath into childDest, and then this walk loop will go on to the
 				// next one
 				return putFileHelper(c, pfc, repo, commit, childDest, filePath, false,
-					overwrite, limiter, split, targetFileDatums, targetFileBytes,
+					overwrite, chunkConcurrency, limiter, split, targetFileDatums, targetFileBytes,
 					headerRecords, filesPut)
 			})
 			return nil
@@ -1258,6 +1506,13 @@ func putFileHelper(c *client.APIClient, pfc client.PutFileClient,
 			retErr = err
 		}
 	}()
+	if chunkConcurrency > 1 && split == "" {
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		return c.PutFileParallel(repo, commit, path, f, info.Size(), 0, chunkConcurrency, overwrite)
+	}
 	return putFile(f)
 }
 
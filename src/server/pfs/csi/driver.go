@@ -0,0 +1,412 @@
+// Package csi implements the node plugin of a Kubernetes CSI driver that
+// mounts a PFS commit read-only into any pod. A volume is requested with the
+// attributes:
+//
+//	repo:   the repo to mount (required)
+//	commit: the commit or branch to mount, "master" by default. Branches are
+//	        resolved to their head commit when the volume is published, so a
+//	        pod's view of the data doesn't change while it's running.
+//	path:   the file or directory in the commit to mount, "/" by default
+//
+// If auth is active, the volume's node publish secret must contain the key
+// AuthTokenSecret, whose value is the Pachyderm auth token that the volume's
+// data is read with.
+//
+// The kubelet's NodePublishVolume and NodeUnpublishVolume calls, served by
+// Server, map directly onto Driver.Publish and Driver.Unpublish. The
+// volume is a FUSE mount that fetches files from PFS when they're first read,
+// and files are cached on the node (see fuse.Cache), so pods that mount the
+// same data only download it once. The plugin (src/server/cmd/pfs-csi) is
+// deployed on every node by "pachctl deploy --csi-driver".
+//
+// The FUSE servers run in the plugin, so if the plugin restarts, the volumes
+// it published are re-mounted at their target paths (see NewDriver).
+// Containers that were already running only see the new mounts if their
+// volume mounts have "mountPropagation: HostToContainer"; other containers
+// see the new mounts once they restart.
+package csi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pfs/fuse"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+const (
+	// DriverName is the name that the driver registers with Kubernetes under,
+	// and that pods' volumes refer to
+	DriverName = "pfs.csi.pachyderm.io"
+
+	// RepoAttribute, CommitAttribute and PathAttribute are the volume
+	// attributes that select the data that a volume contains
+	RepoAttribute   = "repo"
+	CommitAttribute = "commit"
+	PathAttribute   = "path"
+
+	// AuthTokenSecret is the key, in a volume's node publish secret, of the
+	// auth token that the volume's data is read with
+	AuthTokenSecret = "auth_token"
+
+	// DefaultCacheBytes is the default amount of file content that the driver
+	// caches on each node
+	DefaultCacheBytes = 10 * 1024 * 1024 * 1024
+)
+
+// Volume is a published volume, which is recorded in the driver's state
+// directory so that it can be re-mounted if the driver restarts
+type Volume struct {
+	ID         string    `json:"id"`
+	TargetPath string    `json:"target_path"`
+	File       *pfs.File `json:"file"`
+	// AuthToken is the token that the volume's data is read with. It's
+	// recorded so that the volume can be re-mounted without the kubelet
+	// (which only sends it in NodePublishVolume), which is why the state
+	// directory is only readable by the driver.
+	AuthToken string `json:"auth_token,omitempty"`
+	// CreatedTarget is true if the driver created TargetPath, in which case
+	// it's removed when the volume is unpublished
+	CreatedTarget bool `json:"created_target"`
+}
+
+// mount is a volume's FUSE server. Volumes are mounted and unmounted without
+// holding the driver's lock, so that a slow mount doesn't block the other
+// volumes on the node; 'publishing' and 'unmounting' record a volume's
+// progress instead.
+type mount struct {
+	volume     *Volume
+	publishing bool
+	unmounting bool
+	unmount    chan struct{} // closed to unmount the volume
+	exited     chan struct{} // closed once the FUSE server has exited
+	err        error         // the FUSE server's error, set before exited is closed
+}
+
+// Driver publishes and unpublishes PFS volumes on a node.
+type Driver struct {
+	// c is connected to pachd, and has no auth token. Each volume reads PFS
+	// with a copy of c that has the volume's own token.
+	c        *client.APIClient
+	stateDir string
+	cache    *fuse.Cache
+
+	mu     sync.Mutex
+	mounts map[string]*mount // volume ID -> mount
+}
+
+// NewDriver returns a Driver that reads from PFS with 'c' (with each volume's
+// auth token), records published volumes in 'stateDir' and caches up to
+// 'cacheBytes' of file content there. Volumes that a previous driver
+// published are re-mounted, as their FUSE servers are gone, unless the
+// kubelet has already removed their pods.
+func NewDriver(c *client.APIClient, stateDir string, cacheBytes int64) (*Driver, error) {
+	if err := os.MkdirAll(filepath.Join(stateDir, "volumes"), 0700); err != nil {
+		return nil, err
+	}
+	cache, err := fuse.NewCache(filepath.Join(stateDir, "cache"), cacheBytes)
+	if err != nil {
+		return nil, err
+	}
+	d := &Driver{
+		c:        c,
+		stateDir: stateDir,
+		cache:    cache,
+		mounts:   make(map[string]*mount),
+	}
+	if err := d.adopt(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ParseVolumeAttributes returns the file in PFS that a volume with the
+// attributes 'attributes' contains, with the commit (which may be a branch)
+// unresolved.
+func ParseVolumeAttributes(attributes map[string]string) (*pfs.File, error) {
+	for key := range attributes {
+		switch key {
+		case RepoAttribute, CommitAttribute, PathAttribute:
+		default:
+			if !strings.Contains(key, "/") { // e.g. csi.storage.k8s.io/pod.name
+				return nil, fmt.Errorf("unrecognized volume attribute \"%s\"", key)
+			}
+		}
+	}
+	repo := attributes[RepoAttribute]
+	if repo == "" {
+		return nil, fmt.Errorf("volume attribute \"%s\" must be set", RepoAttribute)
+	}
+	commit := attributes[CommitAttribute]
+	if commit == "" {
+		commit = "master"
+	}
+	path := attributes[PathAttribute]
+	if path == "" {
+		path = "/"
+	}
+	return client.NewFile(repo, commit, path), nil
+}
+
+// Publish mounts the data selected by 'attributes' at 'targetPath' as the
+// volume 'volumeID', reading it with the auth token in 'secrets' (if any).
+// Volumes can only be mounted read-only. Publishing a volume that's already
+// published at 'targetPath' does nothing, as the kubelet may retry publish
+// calls.
+func (d *Driver) Publish(ctx context.Context, volumeID string, targetPath string, attributes map[string]string, secrets map[string]string, readOnly bool) (retErr error) {
+	if volumeID == "" || targetPath == "" {
+		return fmt.Errorf("volume ID and target path must be set")
+	}
+	if !readOnly {
+		return fmt.Errorf("pfs volumes can only be mounted read-only")
+	}
+	file, err := ParseVolumeAttributes(attributes)
+	if err != nil {
+		return err
+	}
+	m, err := d.reserve(volumeID, targetPath)
+	if err != nil || m == nil {
+		return err
+	}
+	defer func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if retErr != nil {
+			delete(d.mounts, volumeID)
+			return
+		}
+		m.publishing = false
+	}()
+	c := d.c.WithCtx(ctx)
+	c.SetAuthToken(secrets[AuthTokenSecret])
+	commitInfo, err := c.InspectCommit(file.Commit.Repo.Name, file.Commit.ID)
+	if err != nil {
+		return err
+	}
+	file.Commit = commitInfo.Commit
+	m.volume.File = file
+	m.volume.AuthToken = secrets[AuthTokenSecret]
+	if _, err := os.Stat(targetPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(targetPath, 0755); err != nil {
+			return err
+		}
+		m.volume.CreatedTarget = true
+	} else if err != nil {
+		return err
+	}
+	// The volume is recorded before it's mounted, so that it's cleaned up if
+	// the driver crashes in between
+	if err := d.writeVolume(m.volume); err != nil {
+		return err
+	}
+	if err := d.serve(m); err != nil {
+		d.removeVolume(m.volume)
+		return err
+	}
+	return nil
+}
+
+// reserve adds a mount for the volume 'volumeID' at 'targetPath', which is
+// being published, so that concurrent calls for the same volume fail rather
+// than mounting it twice. It returns nil if the volume is already published
+// at 'targetPath'.
+func (d *Driver) reserve(volumeID string, targetPath string) (*mount, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if m, ok := d.mounts[volumeID]; ok {
+		if m.volume.TargetPath != targetPath {
+			return nil, fmt.Errorf("volume %s is already published at %s", volumeID, m.volume.TargetPath)
+		}
+		if m.publishing || m.unmounting {
+			return nil, fmt.Errorf("volume %s is being published or unpublished", volumeID)
+		}
+		return nil, nil
+	}
+	m := &mount{
+		volume: &Volume{
+			ID:         volumeID,
+			TargetPath: targetPath,
+		},
+		publishing: true,
+	}
+	d.mounts[volumeID] = m
+	return m, nil
+}
+
+// serve starts the FUSE server of 'm', and returns once its volume is
+// mounted
+func (d *Driver) serve(m *mount) error {
+	m.unmount = make(chan struct{})
+	m.exited = make(chan struct{})
+	c := d.c.WithCtx(context.Background())
+	c.SetAuthToken(m.volume.AuthToken)
+	opts := &fuse.Options{
+		File:    m.volume.File,
+		Unmount: m.unmount,
+		Mounted: make(chan struct{}),
+		Cache:   d.cache,
+	}
+	go func() {
+		m.err = fuse.Mount(c, m.volume.TargetPath, opts)
+		close(m.exited)
+	}()
+	select {
+	case <-opts.Mounted:
+		return nil
+	case <-m.exited:
+		if m.err != nil {
+			return m.err
+		}
+		return fmt.Errorf("fuse mount at %s exited before it was ready", m.volume.TargetPath)
+	}
+}
+
+// Unpublish unmounts the volume 'volumeID' from 'targetPath'. Unpublishing a
+// volume that isn't published does nothing. If the unmount doesn't finish
+// before 'ctx' is done, an error is returned, and retrying waits for the
+// same unmount.
+func (d *Driver) Unpublish(ctx context.Context, volumeID string, targetPath string) error {
+	d.mu.Lock()
+	m, ok := d.mounts[volumeID]
+	if !ok {
+		d.mu.Unlock()
+		return nil
+	}
+	if m.volume.TargetPath != targetPath {
+		d.mu.Unlock()
+		return fmt.Errorf("volume %s is published at %s, not %s", volumeID, m.volume.TargetPath, targetPath)
+	}
+	if m.publishing {
+		d.mu.Unlock()
+		return fmt.Errorf("volume %s is being published", volumeID)
+	}
+	if !m.unmounting {
+		m.unmounting = true
+		close(m.unmount)
+	}
+	d.mu.Unlock()
+	select {
+	case <-m.exited:
+	case <-ctx.Done():
+		return fmt.Errorf("volume %s is still being unmounted: %v", volumeID, ctx.Err())
+	}
+	if m.err != nil {
+		// The FUSE server failed, so its mount may not have been removed
+		if err := detach(targetPath); err != nil {
+			return err
+		}
+	}
+	if err := d.removeVolume(m.volume); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.mounts[volumeID] == m {
+		delete(d.mounts, volumeID)
+	}
+	return nil
+}
+
+// Volumes returns the volumes that are currently published
+func (d *Driver) Volumes() []*Volume {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var result []*Volume
+	for _, m := range d.mounts {
+		if !m.publishing && !m.unmounting {
+			result = append(result, m.volume)
+		}
+	}
+	return result
+}
+
+// adopt re-mounts the volumes recorded in the state directory, which were
+// published by a previous driver. Volumes whose pods the kubelet has removed
+// (along with the directory that contains their target path) are removed
+// instead.
+func (d *Driver) adopt() error {
+	files, err := ioutil.ReadDir(filepath.Join(d.stateDir, "volumes"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(filepath.Join(d.stateDir, "volumes", f.Name()))
+		if err != nil {
+			return err
+		}
+		volume := &Volume{}
+		if err := json.Unmarshal(data, volume); err != nil {
+			return fmt.Errorf("error reading volume record %s: %v", f.Name(), err)
+		}
+		// The mount's FUSE server is gone, so the mount is detached rather
+		// than unmounted cleanly
+		if err := detach(volume.TargetPath); err != nil {
+			return err
+		}
+		if _, err := os.Stat(filepath.Dir(volume.TargetPath)); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			if err := d.removeVolume(volume); err != nil {
+				return err
+			}
+			continue
+		}
+		m := &mount{volume: volume}
+		if err := d.serve(m); err != nil {
+			// The kubelet still unpublishes the volume, which does nothing
+			log.Errorf("error re-mounting volume %s at %s: %v", volume.ID, volume.TargetPath, err)
+			if err := d.removeVolume(volume); err != nil {
+				return err
+			}
+			continue
+		}
+		d.mounts[volume.ID] = m
+	}
+	return nil
+}
+
+// detach lazily unmounts whatever is mounted at 'targetPath', if anything
+func detach(targetPath string) error {
+	if err := syscall.Unmount(targetPath, syscall.MNT_DETACH); err != nil &&
+		err != syscall.EINVAL && err != syscall.ENOENT {
+		return fmt.Errorf("error unmounting %s: %v", targetPath, err)
+	}
+	return nil
+}
+
+func (d *Driver) volumePath(volumeID string) string {
+	// Volume IDs are chosen by the container orchestrator, so they're escaped
+	return filepath.Join(d.stateDir, "volumes", strings.Replace(volumeID, "/", "_", -1)+".json")
+}
+
+func (d *Driver) writeVolume(volume *Volume) error {
+	data, err := json.Marshal(volume)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(d.volumePath(volume.ID), data, 0600)
+}
+
+// removeVolume removes the record of 'volume', and its target path if the
+// driver created it
+func (d *Driver) removeVolume(volume *Volume) error {
+	if volume.CreatedTarget {
+		if err := os.Remove(volume.TargetPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Remove(d.volumePath(volume.ID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
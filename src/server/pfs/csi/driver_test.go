@@ -0,0 +1,187 @@
+package csi
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pfs/fuse"
+	"github.com/pachyderm/pachyderm/src/server/pfs/server"
+)
+
+func TestParseVolumeAttributes(t *testing.T) {
+	file, err := ParseVolumeAttributes(map[string]string{
+		RepoAttribute:                            "repo",
+		"csi.storage.k8s.io/pod.name":            "pod",
+		"csi.storage.k8s.io/ephemeral":           "true",
+		"csi.storage.k8s.io/pod.uid":             "uid",
+		"csi.storage.k8s.io/serviceAccount.name": "default",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "repo", file.Commit.Repo.Name)
+	require.Equal(t, "master", file.Commit.ID)
+	require.Equal(t, "/", file.Path)
+
+	file, err = ParseVolumeAttributes(map[string]string{
+		RepoAttribute:   "repo",
+		CommitAttribute: "staging",
+		PathAttribute:   "/dir",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "staging", file.Commit.ID)
+	require.Equal(t, "/dir", file.Path)
+
+	_, err = ParseVolumeAttributes(map[string]string{CommitAttribute: "master"})
+	require.YesError(t, err)
+	_, err = ParseVolumeAttributes(map[string]string{RepoAttribute: "repo", "branch": "master"})
+	require.YesError(t, err)
+}
+
+func TestPublish(t *testing.T) {
+	if !fuse.Available() {
+		t.Skip("Skipping test that requires FUSE")
+	}
+	c := server.GetPachClient(t)
+	require.NoError(t, c.CreateRepo("repo"))
+	_, err := c.PutFile("repo", "master", "dir/file1", strings.NewReader("foo"))
+	require.NoError(t, err)
+	_, err = c.PutFile("repo", "master", "dir/file2", strings.NewReader("bar"))
+	require.NoError(t, err)
+	stateDir, err := ioutil.TempDir("", "pfs-csi")
+	require.NoError(t, err)
+	defer os.RemoveAll(stateDir)
+	driver, err := NewDriver(c, stateDir, DefaultCacheBytes)
+	require.NoError(t, err)
+
+	targetPath := filepath.Join(stateDir, "target")
+	attributes := map[string]string{
+		RepoAttribute:   "repo",
+		CommitAttribute: "master",
+		PathAttribute:   "dir",
+	}
+	require.YesError(t, driver.Publish(context.Background(), "volume", targetPath, attributes, nil, false))
+	require.NoError(t, driver.Publish(context.Background(), "volume", targetPath, attributes, nil, true))
+	// Publishing is idempotent
+	require.NoError(t, driver.Publish(context.Background(), "volume", targetPath, attributes, nil, true))
+	require.Equal(t, 1, len(driver.Volumes()))
+
+	// Later commits aren't visible in the volume, which is pinned to the
+	// commit that was the head of master when it was published
+	_, err = c.PutFile("repo", "master", "dir/file3", strings.NewReader("baz"))
+	require.NoError(t, err)
+	files, err := ioutil.ReadDir(filepath.Join(targetPath, "dir"))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	// Read each file twice, the second time from the cache
+	for i := 0; i < 2; i++ {
+		data, err := ioutil.ReadFile(filepath.Join(targetPath, "dir", "file1"))
+		require.NoError(t, err)
+		require.Equal(t, "foo", string(data))
+		data, err = ioutil.ReadFile(filepath.Join(targetPath, "dir", "file2"))
+		require.NoError(t, err)
+		require.Equal(t, "bar", string(data))
+	}
+	require.YesError(t, ioutil.WriteFile(filepath.Join(targetPath, "dir", "file4"), []byte("qux"), 0644))
+
+	// Unpublishing removes the mount, along with the target path that the
+	// driver created, and is idempotent
+	require.NoError(t, driver.Unpublish(context.Background(), "volume", targetPath))
+	require.NoError(t, driver.Unpublish(context.Background(), "volume", targetPath))
+	require.Equal(t, 0, len(driver.Volumes()))
+	_, err = os.Stat(targetPath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestAdopt(t *testing.T) {
+	if !fuse.Available() {
+		t.Skip("Skipping test that requires FUSE")
+	}
+	c := server.GetPachClient(t)
+	require.NoError(t, c.CreateRepo("repo"))
+	_, err := c.PutFile("repo", "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	stateDir, err := ioutil.TempDir("", "pfs-csi")
+	require.NoError(t, err)
+	defer os.RemoveAll(stateDir)
+	driver, err := NewDriver(c, stateDir, DefaultCacheBytes)
+	require.NoError(t, err)
+
+	// Target paths are in per-pod directories, like the kubelet's
+	podDir := filepath.Join(stateDir, "pod")
+	goneDir := filepath.Join(stateDir, "gone")
+	require.NoError(t, os.MkdirAll(podDir, 0755))
+	require.NoError(t, os.MkdirAll(goneDir, 0755))
+	attributes := map[string]string{RepoAttribute: "repo"}
+	require.NoError(t, driver.Publish(context.Background(), "volume", filepath.Join(podDir, "mount"), attributes, nil, true))
+	require.NoError(t, driver.Publish(context.Background(), "gone", filepath.Join(goneDir, "mount"), attributes, nil, true))
+	// The kubelet removes the pod of "gone" while the driver is down
+	require.NoError(t, syscall.Unmount(filepath.Join(goneDir, "mount"), syscall.MNT_DETACH))
+	require.NoError(t, os.RemoveAll(goneDir))
+
+	// A new driver (as if the plugin restarted) re-mounts "volume", whose pod
+	// still exists, and forgets "gone"
+	driver, err = NewDriver(c, stateDir, DefaultCacheBytes)
+	require.NoError(t, err)
+	volumes := driver.Volumes()
+	require.Equal(t, 1, len(volumes))
+	require.Equal(t, "volume", volumes[0].ID)
+	data, err := ioutil.ReadFile(filepath.Join(podDir, "mount", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(data))
+	require.NoError(t, driver.Unpublish(context.Background(), "volume", filepath.Join(podDir, "mount")))
+	require.NoError(t, driver.Unpublish(context.Background(), "gone", filepath.Join(goneDir, "mount")))
+	records, err := ioutil.ReadDir(filepath.Join(stateDir, "volumes"))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+}
+
+func TestServer(t *testing.T) {
+	stateDir, err := ioutil.TempDir("", "pfs-csi")
+	require.NoError(t, err)
+	defer os.RemoveAll(stateDir)
+	// The driver isn't connected to pachd, so only calls that fail before
+	// reading PFS can be made
+	driver, err := NewDriver(nil, stateDir, DefaultCacheBytes)
+	require.NoError(t, err)
+	socketPath := filepath.Join(stateDir, "csi.sock")
+	go NewServer(driver, "node").Serve(socketPath)
+
+	conn, err := grpc.Dial(socketPath, grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	info, err := NewIdentityClient(conn).GetPluginInfo(ctx, &GetPluginInfoRequest{}, grpc.FailFast(false))
+	require.NoError(t, err)
+	require.Equal(t, DriverName, info.Name)
+	node := NewNodeClient(conn)
+	nodeInfo, err := node.NodeGetInfo(ctx, &NodeGetInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "node", nodeInfo.NodeId)
+	_, err = node.NodePublishVolume(ctx, &NodePublishVolumeRequest{
+		VolumeId:      "volume",
+		TargetPath:    filepath.Join(stateDir, "target"),
+		Readonly:      true,
+		VolumeContext: map[string]string{CommitAttribute: "master"},
+	})
+	require.YesError(t, err)
+	// Unpublishing a volume that was never published succeeds
+	_, err = node.NodeUnpublishVolume(ctx, &NodeUnpublishVolumeRequest{
+		VolumeId:   "volume",
+		TargetPath: filepath.Join(stateDir, "target"),
+	})
+	require.NoError(t, err)
+}
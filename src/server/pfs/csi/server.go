@@ -0,0 +1,88 @@
+package csi
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/pachyderm/pachyderm/src/client/version"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// Server serves the CSI Identity and Node services over a Driver, which is
+// how the kubelet (and the node driver registrar) talk to the plugin
+type Server struct {
+	driver *Driver
+	nodeID string
+}
+
+// NewServer returns a Server that publishes volumes with 'driver' on
+// the node 'nodeID' (the Kubernetes node's name)
+func NewServer(driver *Driver, nodeID string) *Server {
+	return &Server{
+		driver: driver,
+		nodeID: nodeID,
+	}
+}
+
+// Serve serves the CSI services on the unix socket at 'socketPath', which is
+// replaced if a previous plugin left it behind. It returns when the listener
+// fails.
+func (s *Server) Serve(socketPath string) error {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer()
+	RegisterIdentityServer(server, s)
+	RegisterNodeServer(server, s)
+	return server.Serve(listener)
+}
+
+// GetPluginInfo implements the CSI Identity service
+func (s *Server) GetPluginInfo(ctx context.Context, request *GetPluginInfoRequest) (*GetPluginInfoResponse, error) {
+	return &GetPluginInfoResponse{
+		Name:          DriverName,
+		VendorVersion: version.PrettyPrintVersion(version.Version),
+	}, nil
+}
+
+// GetPluginCapabilities implements the CSI Identity service
+func (s *Server) GetPluginCapabilities(ctx context.Context, request *GetPluginCapabilitiesRequest) (*GetPluginCapabilitiesResponse, error) {
+	return &GetPluginCapabilitiesResponse{}, nil
+}
+
+// Probe implements the CSI Identity service
+func (s *Server) Probe(ctx context.Context, request *ProbeRequest) (*ProbeResponse, error) {
+	return &ProbeResponse{}, nil
+}
+
+// NodePublishVolume implements the CSI Node service
+func (s *Server) NodePublishVolume(ctx context.Context, request *NodePublishVolumeRequest) (*NodePublishVolumeResponse, error) {
+	if err := s.driver.Publish(ctx, request.VolumeId, request.TargetPath, request.VolumeContext, request.Secrets, request.Readonly); err != nil {
+		return nil, fmt.Errorf("error publishing volume %s: %v", request.VolumeId, err)
+	}
+	return &NodePublishVolumeResponse{}, nil
+}
+
+// NodeUnpublishVolume implements the CSI Node service
+func (s *Server) NodeUnpublishVolume(ctx context.Context, request *NodeUnpublishVolumeRequest) (*NodeUnpublishVolumeResponse, error) {
+	if err := s.driver.Unpublish(ctx, request.VolumeId, request.TargetPath); err != nil {
+		return nil, fmt.Errorf("error unpublishing volume %s: %v", request.VolumeId, err)
+	}
+	return &NodeUnpublishVolumeResponse{}, nil
+}
+
+// NodeGetCapabilities implements the CSI Node service
+func (s *Server) NodeGetCapabilities(ctx context.Context, request *NodeGetCapabilitiesRequest) (*NodeGetCapabilitiesResponse, error) {
+	return &NodeGetCapabilitiesResponse{}, nil
+}
+
+// NodeGetInfo implements the CSI Node service
+func (s *Server) NodeGetInfo(ctx context.Context, request *NodeGetInfoRequest) (*NodeGetInfoResponse, error) {
+	return &NodeGetInfoResponse{NodeId: s.nodeID}, nil
+}
@@ -0,0 +1,113 @@
+package fuse
+
+import (
+	"container/list"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache is a cache of the content of files read through FUSE mounts, which
+// can be shared between mounts. Files are keyed by their hash, so a file is
+// only downloaded once, however many mounts or commits it's in. Once the
+// cache holds more than its size limit, the least recently read files are
+// evicted.
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	mu   sync.Mutex
+	size int64
+	// lru contains a *cacheEntry for each cached file, most recently read
+	// first, and entries indexes it by key
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+// NewCache returns a Cache that stores up to 'maxBytes' of files in 'dir'.
+// Anything already in 'dir' (e.g. files cached by a previous process) is
+// discarded.
+func NewCache(dir string, maxBytes int64) (*Cache, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+// open returns the cached file with 'key', or false if it isn't cached
+func (c *Cache) open(key string) (*os.File, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		c.remove(e)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return f, true
+}
+
+// tempFile returns a new file in the cache's directory for a file to be
+// downloaded to. Once the download is done, the file is either added to the
+// cache with put or removed with discard.
+func (c *Cache) tempFile() (*os.File, error) {
+	return ioutil.TempFile(c.dir, "tmp-")
+}
+
+// put adds the downloaded file 'f' (from tempFile) to the cache under 'key',
+// and evicts the least recently read files if the cache is over its limit.
+// Files that are evicted while they're open can still be read through their
+// open handles.
+func (c *Cache) put(f *os.File, key string, size int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		// Another reader downloaded the same file first
+		return os.Remove(f.Name())
+	}
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		return err
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, size: size})
+	c.size += size
+	for c.size > c.maxBytes && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+	return nil
+}
+
+// discard removes the temporary file 'f' (from tempFile), whose download
+// failed
+func (c *Cache) discard(f *os.File) {
+	os.Remove(f.Name())
+}
+
+// remove evicts the file in 'e' from the cache. c.mu must be held.
+func (c *Cache) remove(e *list.Element) {
+	entry := c.lru.Remove(e).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+	os.Remove(c.path(entry.key))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
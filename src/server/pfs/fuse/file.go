@@ -2,6 +2,7 @@ package fuse
 
 import (
 	"context"
+	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"math"
@@ -30,15 +31,6 @@ func newFile(fs *filesystem, name string) (*file, fuse.Status) {
 	if status != fuse.OK {
 		return nil, status
 	}
-	ctx, cancel := context.WithCancel(fs.c.Ctx())
-	c := fs.c.WithCtx(ctx)
-	f, err := ioutil.TempFile("", "pfs-fuse")
-	if err != nil {
-		return nil, fuse.ToStatus(err)
-	}
-	if err := os.Remove(f.Name()); err != nil {
-		return nil, fuse.ToStatus(err)
-	}
 	_, pfsFile, err := fs.parsePath(name)
 	if err != nil {
 		return nil, toStatus(err)
@@ -46,6 +38,42 @@ func newFile(fs *filesystem, name string) (*file, fuse.Status) {
 	if pfsFile == nil {
 		return nil, fuse.Status(syscall.EISDIR)
 	}
+	ctx, cancel := context.WithCancel(fs.c.Ctx())
+	c := fs.c.WithCtx(ctx)
+	var key string
+	var f *os.File
+	if fs.cache != nil {
+		fi, err := fs.c.InspectFile(pfsFile.Commit.Repo.Name, pfsFile.Commit.ID, pfsFile.Path)
+		if err != nil {
+			cancel()
+			return nil, toStatus(err)
+		}
+		key = hex.EncodeToString(fi.Hash)
+		if cached, ok := fs.cache.open(key); ok {
+			counter := newCounter()
+			counter.finish()
+			return &file{
+				attr:    attr,
+				cancel:  cancel,
+				pfsFile: pfsFile,
+				file:    cached,
+				counter: counter,
+			}, fuse.OK
+		}
+		if f, err = fs.cache.tempFile(); err != nil {
+			cancel()
+			return nil, fuse.ToStatus(err)
+		}
+	} else {
+		if f, err = ioutil.TempFile("", "pfs-fuse"); err != nil {
+			cancel()
+			return nil, fuse.ToStatus(err)
+		}
+		if err := os.Remove(f.Name()); err != nil {
+			cancel()
+			return nil, fuse.ToStatus(err)
+		}
+	}
 	counter := newCounter()
 	// Argument order is important here because it means that writes to w must
 	// complete writing to f before being written to counter. Thus counter can
@@ -63,6 +91,15 @@ func newFile(fs *filesystem, name string) (*file, fuse.Status) {
 		if err := c.GetFile(pfsFile.Commit.Repo.Name, pfsFile.Commit.ID, pfsFile.Path, 0, 0, w); err != nil {
 			result.err = err
 			counter.cancel()
+			if fs.cache != nil {
+				fs.cache.discard(f)
+			}
+			return
+		}
+		if fs.cache != nil {
+			// The cache is best-effort, so a file that can't be cached is
+			// still readable through f
+			fs.cache.put(f, key, int64(attr.Size))
 		}
 	}()
 	return result, fuse.OK
@@ -109,6 +146,7 @@ func (f *file) Flush() fuse.Status {
 
 func (f *file) Release() {
 	f.cancel()
+	f.file.Close()
 }
 
 func (f *file) Fsync(flags int) (code fuse.Status) {
@@ -175,4 +213,11 @@ func (c *counter) cancel() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.n = math.MaxInt64
+	c.cond.Broadcast()
+}
+
+// finish indicates that the whole file has already been written (e.g. it was
+// cached), so calls to wait() return immediately
+func (c *counter) finish() {
+	c.cancel()
 }
@@ -3,52 +3,118 @@ package fuse
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hanwen/go-fuse/fuse"
 	"github.com/hanwen/go-fuse/fuse/nodefs"
 	"github.com/hanwen/go-fuse/fuse/pathfs"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 )
 
 const (
 	modeFile = fuse.S_IFREG | 0444 // everyone can read, no one can do anything else
 	modeDir  = fuse.S_IFDIR | 0555 // everyone can read and execute, no one can do anything else (execute permission is required to list a dir)
+
+	// unmountTimeout is how long unmounting a busy mount is retried before
+	// it's lazily unmounted instead (see unmount)
+	unmountTimeout = 5 * time.Second
 )
 
 // Mount pfs to mountPoint, opts may be left nil.
 func Mount(c *client.APIClient, mountPoint string, opts *Options) error {
-	nfs := pathfs.NewPathNodeFs(newFileSystem(c, opts.getCommits()), nil)
+	nfs := pathfs.NewPathNodeFs(newFileSystem(c, opts.getCommits(), opts.getFile(), opts.getCache()), nil)
 	server, _, err := nodefs.MountRoot(mountPoint, nfs.Root(), opts.getFuse())
 	if err != nil {
 		return fmt.Errorf("nodefs.MountRoot: %v", err)
 	}
+	if mounted := opts.getMounted(); mounted != nil {
+		go func() {
+			if err := server.WaitMount(); err == nil {
+				close(mounted)
+			}
+		}()
+	}
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
+	served := make(chan struct{})
+	unmountErr := make(chan error, 1)
 	go func() {
+		defer signal.Stop(sigChan)
 		select {
 		case <-sigChan:
 		case <-opts.getUnmount():
+		case <-served:
+			// The mount was removed by someone else
+			unmountErr <- nil
+			return
 		}
-		server.Unmount()
+		unmountErr <- unmount(server, mountPoint)
 	}()
 	server.Serve()
+	close(served)
+	return <-unmountErr
+}
+
+// unmount removes the mount of 'server' at 'mountPoint'. A mount can't be
+// removed while it's busy (e.g. while a process that was reading from it
+// exits), so this is retried for unmountTimeout, after which the mount is
+// lazily unmounted with "fusermount -uz", which detaches it from the file
+// system right away and removes it once it's no longer busy.
+func unmount(server *fuse.Server, mountPoint string) error {
+	err := backoff.Retry(server.Unmount, backoff.RetryEvery(100*time.Millisecond).For(unmountTimeout))
+	if err == nil {
+		return nil
+	}
+	if out, lazyErr := exec.Command(fusermount(), "-uz", mountPoint).CombinedOutput(); lazyErr != nil {
+		return fmt.Errorf("could not unmount %s (%v), nor lazily unmount it: %v: %s", mountPoint, err, lazyErr, out)
+	}
 	return nil
 }
 
+// fusermount returns the path of the fusermount binary (see Available)
+func fusermount() string {
+	if path, err := exec.LookPath("fusermount"); err == nil {
+		return path
+	}
+	return "/bin/fusermount"
+}
+
+// Available returns true if FUSE mounts can be created on this machine, i.e.
+// /dev/fuse exists and the fusermount binary can be found.
+func Available() bool {
+	if _, err := os.Stat("/dev/fuse"); err != nil {
+		return false
+	}
+	if _, err := exec.LookPath("fusermount"); err != nil {
+		if _, err := os.Stat("/bin/fusermount"); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 type filesystem struct {
 	pathfs.FileSystem
 	c         *client.APIClient
 	commits   map[string]string
 	commitsMu sync.RWMutex
+	// view, if set, is the only file (along with its parents and children)
+	// that this filesystem exposes, see Options.File
+	view *pfs.File
+	// cache, if set, caches the content of files that are read, see
+	// Options.Cache
+	cache *Cache
 }
 
-func newFileSystem(c *client.APIClient, commits map[string]string) pathfs.FileSystem {
+func newFileSystem(c *client.APIClient, commits map[string]string, view *pfs.File, cache *Cache) pathfs.FileSystem {
 	if commits == nil {
 		commits = make(map[string]string)
 	}
@@ -56,6 +122,8 @@ func newFileSystem(c *client.APIClient, commits map[string]string) pathfs.FileSy
 		FileSystem: pathfs.NewDefaultFileSystem(),
 		c:          c,
 		commits:    commits,
+		view:       view,
+		cache:      cache,
 	}
 }
 
@@ -91,7 +159,9 @@ func (fs *filesystem) OpenDir(name string, context *fuse.Context) ([]fuse.DirEnt
 			return result, fuse.OK
 		}
 		if err := fs.c.ListFileF(f.Commit.Repo.Name, f.Commit.ID, f.Path, 0, func(fi *pfs.FileInfo) error {
-			result = append(result, fileDirEntry(fi))
+			if fs.visible(fi.File.Path) {
+				result = append(result, fileDirEntry(fi))
+			}
 			return nil
 		}); err != nil {
 			return nil, toStatus(err)
@@ -147,6 +217,12 @@ func (fs *filesystem) commit(repo string) (string, error) {
 }
 
 func (fs *filesystem) parsePath(name string) (*pfs.Repo, *pfs.File, error) {
+	if fs.view != nil {
+		if !fs.visible(name) {
+			return nil, nil, fmt.Errorf("file %s not found", name)
+		}
+		return nil, client.NewFile(fs.view.Commit.Repo.Name, fs.view.Commit.ID, name), nil
+	}
 	components := strings.Split(name, "/")
 	switch {
 	case name == "":
@@ -162,7 +238,25 @@ func (fs *filesystem) parsePath(name string) (*pfs.Repo, *pfs.File, error) {
 	}
 }
 
+// visible returns true if the file at 'name' should be exposed by fs, i.e. fs
+// isn't restricted to a view, or 'name' is the view, one of its parents or one
+// of its children.
+func (fs *filesystem) visible(name string) bool {
+	if fs.view == nil {
+		return true
+	}
+	name = strings.Trim(path.Clean("/"+name), "/")
+	view := strings.Trim(path.Clean("/"+fs.view.Path), "/")
+	return name == "" || view == "" || name == view ||
+		strings.HasPrefix(view, name+"/") || strings.HasPrefix(name, view+"/")
+}
+
 func (fs *filesystem) getAttr(name string) (*fuse.Attr, fuse.Status) {
+	if fs.view != nil && name == "" {
+		return &fuse.Attr{
+			Mode: modeDir,
+		}, fuse.OK
+	}
 	r, f, err := fs.parsePath(name)
 	if err != nil {
 		return nil, toStatus(err)
@@ -203,6 +297,10 @@ func repoDirEntry(ri *pfs.RepoInfo) fuse.DirEntry {
 func fileMode(fi *pfs.FileInfo) uint32 {
 	switch fi.FileType {
 	case pfs.FileType_FILE:
+		if fi.Mode != 0 {
+			// Keep the file's read and execute bits, but the mount is read-only
+			return fuse.S_IFREG | fi.Mode&0555
+		}
 		return modeFile
 	case pfs.FileType_DIR:
 		return modeDir
@@ -133,6 +133,71 @@ func TestHeadlessBranch(t *testing.T) {
 	})
 }
 
+func TestVisible(t *testing.T) {
+	fs := newFileSystem(nil, nil, client.NewFile("repo", "commit", "/dir/file"), nil).(*filesystem)
+	require.True(t, fs.visible(""))
+	require.True(t, fs.visible("dir"))
+	require.True(t, fs.visible("/dir/file"))
+	require.True(t, fs.visible("dir/file/child"))
+	require.False(t, fs.visible("dir/file2"))
+	require.False(t, fs.visible("dir2"))
+	require.False(t, fs.visible("di"))
+
+	fs = newFileSystem(nil, nil, nil, nil).(*filesystem)
+	require.True(t, fs.visible("anything"))
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pfs-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cache, err := NewCache(dir, 6)
+	require.NoError(t, err)
+	put := func(key, content string) {
+		f, err := cache.tempFile()
+		require.NoError(t, err)
+		_, err = f.WriteString(content)
+		require.NoError(t, err)
+		require.NoError(t, cache.put(f, key, int64(len(content))))
+		require.NoError(t, f.Close())
+	}
+	read := func(key string) (string, bool) {
+		f, ok := cache.open(key)
+		if !ok {
+			return "", false
+		}
+		defer f.Close()
+		data, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		return string(data), true
+	}
+	put("a", "foo")
+	put("b", "bar")
+	data, ok := read("a")
+	require.True(t, ok)
+	require.Equal(t, "foo", data)
+
+	// "b" is the least recently read file, so it's evicted to make room
+	put("c", "baz")
+	_, ok = read("b")
+	require.False(t, ok)
+	data, ok = read("a")
+	require.True(t, ok)
+	require.Equal(t, "foo", data)
+	data, ok = read("c")
+	require.True(t, ok)
+	require.Equal(t, "baz", data)
+
+	// Temporary files that are discarded aren't cached
+	f, err := cache.tempFile()
+	require.NoError(t, err)
+	cache.discard(f)
+	require.NoError(t, f.Close())
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+}
+
 func mount(tb testing.TB, c *client.APIClient, commits map[string]string, f func(mountPoint string)) {
 	dir, err := ioutil.TempDir("", "pfs")
 	require.NoError(tb, err)
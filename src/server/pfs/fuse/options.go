@@ -1,6 +1,9 @@
 package fuse
 
-import "github.com/hanwen/go-fuse/fuse/nodefs"
+import (
+	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+)
 
 // Options is for configuring fuse mounts. Any of the fields may be left nil
 // and `nil` itself is a valid set of Options which uses the default for
@@ -12,7 +15,20 @@ type Options struct {
 	// will be used.
 	Commits map[string]string
 
+	// File, if set, restricts the mount to a single file or directory in PFS.
+	// The root of the mount is then the root of File's repo at File's commit,
+	// rather than a directory of repos, and only File, its parent directories
+	// and its children are visible.
+	File *pfs.File
+
 	Unmount chan struct{}
+
+	// Mounted, if set, is closed once the mount is ready to serve requests.
+	Mounted chan struct{}
+
+	// Cache, if set, caches the content of files that are read through the
+	// mount, so that they're only downloaded from PFS once.
+	Cache *Cache
 }
 
 func (o *Options) getFuse() *nodefs.Options {
@@ -35,3 +51,24 @@ func (o *Options) getUnmount() chan struct{} {
 	}
 	return o.Unmount
 }
+
+func (o *Options) getFile() *pfs.File {
+	if o == nil {
+		return nil
+	}
+	return o.File
+}
+
+func (o *Options) getMounted() chan struct{} {
+	if o == nil {
+		return nil
+	}
+	return o.Mounted
+}
+
+func (o *Options) getCache() *Cache {
+	if o == nil {
+		return nil
+	}
+	return o.Cache
+}
@@ -16,12 +16,19 @@ const (
 	RepoHeader = "NAME\tCREATED\tSIZE\t\n"
 	// RepoAuthHeader is the header for repos with auth information attached.
 	RepoAuthHeader = "NAME\tCREATED\tSIZE\tACCESS LEVEL\t\n"
+	// RepoDiskUsageHeader is the header for the storage used by repos.
+	RepoDiskUsageHeader = "NAME\tLOGICAL SIZE\tPHYSICAL SIZE\tEXCLUSIVE SIZE\t\n"
 	// CommitHeader is the header for commits.
 	CommitHeader = "REPO\tCOMMIT\tPARENT\tSTARTED\tDURATION\tSIZE\t\n"
 	// BranchHeader is the header for branches.
 	BranchHeader = "BRANCH\tHEAD\t\n"
+	// CommitBranchHeader is the header for branches that may be in different
+	// repos.
+	CommitBranchHeader = "REPO\tBRANCH\tHEAD\t\n"
 	// FileHeader is the header for files.
 	FileHeader = "COMMIT\tNAME\tTYPE\tCOMMITTED\tSIZE\t\n"
+	// CacheHeader is the header for object caches.
+	CacheHeader = "POD\tCACHE\tUSED\tSIZE\tITEMS\tGETS\tHIT RATE\tEVICTIONS\t\n"
 )
 
 // PrintRepoHeader prints a repo header.
@@ -48,11 +55,22 @@ func PrintRepoInfo(w io.Writer, repoInfo *pfs.RepoInfo) {
 	fmt.Fprintln(w)
 }
 
+// PrintRepoDiskUsage pretty-prints the storage used by a repo.
+func PrintRepoDiskUsage(w io.Writer, repoUsage *pfs.RepoDiskUsage) {
+	fmt.Fprintf(w, "%s\t", repoUsage.Repo.Name)
+	fmt.Fprintf(w, "%s\t", units.BytesSize(float64(repoUsage.LogicalBytes)))
+	fmt.Fprintf(w, "%s\t", units.BytesSize(float64(repoUsage.PhysicalBytes)))
+	fmt.Fprintf(w, "%s\t\n", units.BytesSize(float64(repoUsage.ExclusiveBytes)))
+}
+
 // PrintDetailedRepoInfo pretty-prints detailed repo info.
 func PrintDetailedRepoInfo(repoInfo *pfs.RepoInfo) error {
 	template, err := template.New("RepoInfo").Funcs(funcMap).Parse(
 		`Name: {{.Repo.Name}}{{if .Description}}
-Description: {{.Description}}{{end}}
+Description: {{.Description}}{{end}}{{if .RecommendedGlob}}
+Recommended glob: {{.RecommendedGlob}}{{end}}{{if .MaxCommitFiles}}
+Max commit files: {{.MaxCommitFiles}}{{end}}{{if .MaxCommitBytes}}
+Max commit bytes: {{.MaxCommitBytes}}{{end}}
 Created: {{prettyAgo .Created}}
 Size: {{prettySize .SizeBytes}}{{if .AuthInfo}}
 Access level: {{ .AuthInfo.AccessLevel.String }}{{end}}
@@ -72,6 +90,12 @@ func PrintBranchHeader(w io.Writer) {
 	fmt.Fprint(w, BranchHeader)
 }
 
+// PrintCommitBranch pretty-prints a Branch, including its repo.
+func PrintCommitBranch(w io.Writer, branchInfo *pfs.BranchInfo) {
+	fmt.Fprintf(w, "%s\t", branchInfo.Branch.Repo.Name)
+	PrintBranch(w, branchInfo)
+}
+
 // PrintBranch pretty-prints a Branch.
 func PrintBranch(w io.Writer, branchInfo *pfs.BranchInfo) {
 	fmt.Fprintf(w, "%s\t", branchInfo.Branch.Name)
@@ -116,7 +140,8 @@ Parent: {{.ParentCommit.ID}}{{end}}
 Started: {{prettyAgo .Started}}{{if .Finished}}
 Finished: {{prettyAgo .Finished}} {{end}}
 Size: {{prettySize .SizeBytes}}{{if .Provenance}}
-Provenance: {{range .Provenance}} {{.Repo.Name}}/{{.ID}} {{end}} {{end}}
+Provenance: {{range .Provenance}} {{.Repo.Name}}/{{.ID}} {{end}} {{end}}{{if .Labels}}
+Labels: {{range $key, $value := .Labels}} {{$key}}={{$value}} {{end}}{{end}}
 `)
 	if err != nil {
 		return err
@@ -128,6 +153,22 @@ Provenance: {{range .Provenance}} {{.Repo.Name}}/{{.ID}} {{end}} {{end}}
 	return nil
 }
 
+// PrintCacheInfo pretty-prints the stats of an object cache of the pod 'pod'.
+func PrintCacheInfo(w io.Writer, pod string, cacheInfo *pfs.CacheInfo) {
+	fmt.Fprintf(w, "%s\t", pod)
+	fmt.Fprintf(w, "%s\t", cacheInfo.Name)
+	fmt.Fprintf(w, "%s\t", units.BytesSize(float64(cacheInfo.Bytes)))
+	fmt.Fprintf(w, "%s\t", units.BytesSize(float64(cacheInfo.SizeBytes)))
+	fmt.Fprintf(w, "%d\t", cacheInfo.Items)
+	fmt.Fprintf(w, "%d\t", cacheInfo.Gets)
+	if cacheInfo.Gets > 0 {
+		fmt.Fprintf(w, "%.1f%%\t", 100*float64(cacheInfo.Hits)/float64(cacheInfo.Gets))
+	} else {
+		fmt.Fprintf(w, "-\t")
+	}
+	fmt.Fprintf(w, "%d\t\n", cacheInfo.Evictions)
+}
+
 // PrintFileInfoHeader prints a file info header.
 func PrintFileInfoHeader(w io.Writer) {
 	fmt.Fprint(w, FileHeader)
@@ -144,7 +185,11 @@ func PrintFileInfo(w io.Writer, fileInfo *pfs.FileInfo) {
 	} else {
 		fmt.Fprint(w, "dir\t")
 	}
-	fmt.Fprintf(w, "%s\t", pretty.Ago(fileInfo.Committed))
+	if fileInfo.Committed != nil {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(fileInfo.Committed))
+	} else {
+		fmt.Fprint(w, "open\t")
+	}
 	fmt.Fprintf(w, "%s\t\n", units.BytesSize(float64(fileInfo.SizeBytes)))
 }
 
@@ -154,6 +199,9 @@ func PrintDetailedFileInfo(fileInfo *pfs.FileInfo) error {
 		`Path: {{.File.Path}}
 Type: {{fileType .FileType}}
 Size: {{prettySize .SizeBytes}}
+Committed: {{if .Committed}}{{prettyAgo .Committed}}{{else}}no, the commit is still open{{end}}{{if .Mode}}
+Mode: {{fileMode .Mode}}{{end}}{{with .Owner}}
+Owner: {{.Uid}}:{{.Gid}}{{end}}
 Children: {{range .Children}} {{.}} {{end}}
 `)
 	if err != nil {
@@ -175,8 +223,13 @@ func fileType(fileType pfs.FileType) string {
 	return "dir"
 }
 
+func fileMode(mode uint32) string {
+	return fmt.Sprintf("%04o (%s)", mode, os.FileMode(mode))
+}
+
 var funcMap = template.FuncMap{
 	"prettyAgo":  pretty.Ago,
 	"prettySize": pretty.Size,
 	"fileType":   fileType,
+	"fileMode":   fileMode,
 }
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bufio"
 	"fmt"
 	"sync"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 
@@ -51,19 +53,30 @@ type apiServer struct {
 	// store API and auth API. Instead of accessing it directly, functions should
 	// call getPachClient()
 	_pachClient *client.APIClient
+
+	// putFileStreams limits the put-file streams that each client has open
+	putFileStreams *putFileStreamLimiter
 }
 
-func newAPIServer(address string, etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Cache, storageRoot string, memoryRequest int64) (*apiServer, error) {
-	d, err := newDriver(etcdAddresses, etcdPrefix, treeCache, storageRoot, memoryRequest)
+func newAPIServer(address string, etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Cache, storageRoot string, memoryRequest int64, options APIServerOptions) (*apiServer, error) {
+	d, err := newDriver(etcdAddresses, etcdPrefix, treeCache, storageRoot, memoryRequest, options)
+	if err != nil {
+		return nil, err
+	}
+	putFileStreams, err := newPutFileStreamLimiter(options.MaxPutFileStreamsPerClient)
 	if err != nil {
 		return nil, err
 	}
 	s := &apiServer{
-		Logger:  log.NewLogger("pfs.API"),
-		driver:  d,
-		address: address,
+		Logger:         log.NewLogger("pfs.API"),
+		driver:         d,
+		address:        address,
+		putFileStreams: putFileStreams,
 	}
 	go func() { s.getPachClient(context.Background()) }() // Begin dialing connection on startup
+	if events.Enabled() {
+		go d.publishCommitEvents()
+	}
 	return s, nil
 }
 
@@ -71,7 +84,7 @@ func (a *apiServer) CreateRepo(ctx context.Context, request *pfs.CreateRepoReque
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	if err := a.driver.createRepo(a.getPachClient(ctx), request.Repo, request.Description, request.Update); err != nil {
+	if err := a.driver.createRepo(a.getPachClient(ctx), request.Repo, request.Description, request.RecommendedGlob, request.MaxCommitFiles, request.MaxCommitBytes, request.Update); err != nil {
 		return nil, err
 	}
 	return &types.Empty{}, nil
@@ -92,6 +105,17 @@ func (a *apiServer) ListRepo(ctx context.Context, request *pfs.ListRepoRequest)
 	return repoInfos, err
 }
 
+func (a *apiServer) DiskUsage(ctx context.Context, request *pfs.DiskUsageRequest) (response *pfs.DiskUsageResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	repos, err := a.driver.diskUsage(a.getPachClient(ctx), request.Repo)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.DiskUsageResponse{Repos: repos}, nil
+}
+
 func (a *apiServer) DeleteRepo(ctx context.Context, request *pfs.DeleteRepoRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
@@ -113,7 +137,7 @@ func (a *apiServer) StartCommit(ctx context.Context, request *pfs.StartCommitReq
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	commit, err := a.driver.startCommit(a.getPachClient(ctx), request.Parent, request.Branch, request.Provenance, request.Description)
+	commit, err := a.driver.startCommit(a.getPachClient(ctx), request.Parent, request.Branch, request.Provenance, request.Description, request.Labels)
 	if err != nil {
 		return nil, err
 	}
@@ -135,10 +159,10 @@ func (a *apiServer) FinishCommit(ctx context.Context, request *pfs.FinishCommitR
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 	if request.Trees != nil {
-		if err := a.driver.finishOutputCommit(a.getPachClient(ctx), request.Commit, request.Trees, request.Datums, request.SizeBytes); err != nil {
+		if err := a.driver.finishOutputCommit(a.getPachClient(ctx), request.Commit, request.Trees, request.Datums, request.SizeBytes, request.Description, request.Labels); err != nil {
 			return nil, err
 		}
-	} else if err := a.driver.finishCommit(a.getPachClient(ctx), request.Commit, request.Tree, request.Empty, request.Description); err != nil {
+	} else if err := a.driver.finishCommit(a.getPachClient(ctx), request.Commit, request.Tree, request.Empty, request.Description, request.Labels); err != nil {
 		return nil, err
 	}
 	return &types.Empty{}, nil
@@ -155,7 +179,7 @@ func (a *apiServer) ListCommit(ctx context.Context, request *pfs.ListCommitReque
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	commitInfos, err := a.driver.listCommit(a.getPachClient(ctx), request.Repo, request.To, request.From, request.Number)
+	commitInfos, err := a.driver.listCommit(a.getPachClient(ctx), request.Repo, request.To, request.From, request.Number, request.LabelSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +194,7 @@ func (a *apiServer) ListCommitStream(req *pfs.ListCommitRequest, respServer pfs.
 	defer func(start time.Time) {
 		a.Log(req, fmt.Sprintf("stream containing %d commits", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.listCommitF(a.getPachClient(respServer.Context()), req.Repo, req.To, req.From, req.Number, func(ci *pfs.CommitInfo) error {
+	return a.driver.listCommitF(a.getPachClient(respServer.Context()), req.Repo, req.To, req.From, req.Number, req.LabelSelector, func(ci *pfs.CommitInfo) error {
 		sent++
 		return respServer.Send(ci)
 	})
@@ -186,6 +210,26 @@ func (a *apiServer) CreateBranch(ctx context.Context, request *pfs.CreateBranchR
 	return &types.Empty{}, nil
 }
 
+func (a *apiServer) SetMaxHistoryDepth(ctx context.Context, request *pfs.SetMaxHistoryDepthRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	if err := a.driver.setMaxHistoryDepth(a.getPachClient(ctx), request.Branch, request.Depth); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+func (a *apiServer) SwapBranch(ctx context.Context, request *pfs.SwapBranchRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	if err := a.driver.swapBranch(a.getPachClient(ctx), request.Branch, request.Other); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 func (a *apiServer) InspectBranch(ctx context.Context, request *pfs.InspectBranchRequest) (response *pfs.BranchInfo, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
@@ -203,6 +247,17 @@ func (a *apiServer) ListBranch(ctx context.Context, request *pfs.ListBranchReque
 	return &pfs.BranchInfos{BranchInfo: branches}, nil
 }
 
+func (a *apiServer) ListCommitBranches(ctx context.Context, request *pfs.ListCommitBranchesRequest) (response *pfs.BranchInfos, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	branches, err := a.driver.listCommitBranches(a.getPachClient(ctx), request.Commit, request.History)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.BranchInfos{BranchInfo: branches}, nil
+}
+
 func (a *apiServer) DeleteBranch(ctx context.Context, request *pfs.DeleteBranchRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
@@ -223,6 +278,16 @@ func (a *apiServer) DeleteCommit(ctx context.Context, request *pfs.DeleteCommitR
 	return &types.Empty{}, nil
 }
 
+func (a *apiServer) SquashCommit(ctx context.Context, request *pfs.SquashCommitRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	if err := a.driver.squashCommit(a.getPachClient(ctx), request.Commit); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 func (a *apiServer) FlushCommit(request *pfs.FlushCommitRequest, stream pfs.API_FlushCommitServer) (retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
@@ -238,6 +303,11 @@ func (a *apiServer) SubscribeCommit(request *pfs.SubscribeCommitRequest, stream
 }
 
 func (a *apiServer) PutFile(putFileServer pfs.API_PutFileServer) (retErr error) {
+	release, err := a.acquirePutFileStream(putFileServer.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
 	s := newPutFileServer(putFileServer)
 	r, err := s.Peek()
 	if err != nil {
@@ -257,6 +327,39 @@ func (a *apiServer) PutFile(putFileServer pfs.API_PutFileServer) (retErr error)
 	return a.driver.putFiles(pachClient, s)
 }
 
+func (a *apiServer) PutFileChunk(server pfs.API_PutFileChunkServer) (retErr error) {
+	release, err := a.acquirePutFileStream(server.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
+	request, err := server.Recv()
+	if err != nil {
+		return err
+	}
+	logRequest := *request
+	logRequest.Value = nil
+	func() { a.Log(&logRequest, nil, nil, 0) }()
+	var chunk *pfs.FileChunk
+	defer func(start time.Time) { a.Log(&logRequest, chunk, retErr, time.Since(start)) }(time.Now())
+	r := &putFileChunkReader{server: server}
+	r.buffer.Write(request.Value)
+	chunk, err = a.driver.putFileChunk(a.getPachClient(server.Context()), request.File, request.UploadId, r)
+	if err != nil {
+		return err
+	}
+	return server.SendAndClose(chunk)
+}
+
+func (a *apiServer) PutFileChunks(ctx context.Context, request *pfs.PutFileChunksRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if err := a.driver.putFileChunks(a.getPachClient(ctx), request.File, request.UploadId, request.Chunks, request.Overwrite); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 func (a *apiServer) CopyFile(ctx context.Context, request *pfs.CopyFileRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
@@ -331,6 +434,28 @@ func (a *apiServer) WalkFile(request *pfs.WalkFileRequest, server pfs.API_WalkFi
 	})
 }
 
+func (a *apiServer) GetCommitTree(request *pfs.GetCommitTreeRequest, server pfs.API_GetCommitTreeServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	var sent int
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
+	}(time.Now())
+	return a.driver.getCommitTree(a.getPachClient(server.Context()), request.Commit, func(node *pfs.TreeNode) error {
+		sent++
+		return server.Send(node)
+	})
+}
+
+func (a *apiServer) GetManifest(request *pfs.GetManifestRequest, server pfs.API_GetManifestServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	w := bufio.NewWriterSize(grpcutil.NewStreamingBytesWriter(server), grpcutil.MaxMsgSize/10)
+	if err := a.driver.getManifest(a.getPachClient(server.Context()), request.Commit, request.Path, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
 func (a *apiServer) GlobFile(ctx context.Context, request *pfs.GlobFileRequest) (response *pfs.FileInfos, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) {
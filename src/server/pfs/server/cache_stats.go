@@ -10,22 +10,29 @@ import (
 	"github.com/golang/groupcache"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+
+	pfsclient "github.com/pachyderm/pachyderm/src/client/pfs"
 )
 
+// cacheStatFields are the fields of groupcache.CacheStats that are reported,
+// in addition to the group's groupcache.Stats
+var cacheStatFields = []string{"Bytes", "Items", "Evictions"}
+
 type cacheStats struct {
 	cacheName      string
 	descriptions   map[string]*prometheus.Desc
-	stats          *groupcache.Stats
+	group          *groupcache.Group
 	descriptionsMu sync.RWMutex
 }
 
-// RegisterCacheStats creates a new wrapper for groupcache stats that implements
-// the prometheus.Collector interface, and registers it
-func RegisterCacheStats(cacheName string, groupCacheStats *groupcache.Stats) {
+// RegisterCacheStats creates a new wrapper for the stats of a groupcache group
+// (and of its caches) that implements the prometheus.Collector interface, and
+// registers it
+func RegisterCacheStats(cacheName string, group *groupcache.Group) {
 	c := &cacheStats{
 		cacheName:    cacheName,
 		descriptions: make(map[string]*prometheus.Desc),
-		stats:        groupCacheStats,
+		group:        group,
 	}
 	if err := prometheus.Register(c); err != nil {
 		logrus.Infof("error registering prometheus metric: %v", err)
@@ -33,7 +40,7 @@ func RegisterCacheStats(cacheName string, groupCacheStats *groupcache.Stats) {
 }
 
 func (c *cacheStats) Describe(ch chan<- *prometheus.Desc) {
-	for _, statFieldName := range groupCacheStatFields() {
+	for _, statFieldName := range append(groupCacheStatFields(), cacheStatFields...) {
 		statName := c.statName(statFieldName)
 		desc := prometheus.NewDesc(
 			statName,
@@ -51,9 +58,13 @@ func (c *cacheStats) Describe(ch chan<- *prometheus.Desc) {
 }
 
 func (c *cacheStats) Collect(ch chan<- prometheus.Metric) {
-	r := reflect.ValueOf(c.stats)
-	for _, statFieldName := range groupCacheStatFields() {
-		value := reflect.Indirect(r).FieldByName(statFieldName)
+	stats := reflect.ValueOf(&c.group.Stats).Elem()
+	caches := reflect.ValueOf(totalCacheStats(c.group))
+	for _, statFieldName := range append(groupCacheStatFields(), cacheStatFields...) {
+		value := stats.FieldByName(statFieldName)
+		if !value.IsValid() {
+			value = caches.FieldByName(statFieldName)
+		}
 		func() {
 			c.descriptionsMu.RLock()
 			defer c.descriptionsMu.RUnlock()
@@ -89,3 +100,32 @@ func groupCacheStatFields() (fields []string) {
 	}
 	return fields
 }
+
+// totalCacheStats returns the stats of the main and hot caches of 'group'
+// combined
+func totalCacheStats(group *groupcache.Group) groupcache.CacheStats {
+	main := group.CacheStats(groupcache.MainCache)
+	hot := group.CacheStats(groupcache.HotCache)
+	return groupcache.CacheStats{
+		Bytes:     main.Bytes + hot.Bytes,
+		Items:     main.Items + hot.Items,
+		Gets:      main.Gets + hot.Gets,
+		Hits:      main.Hits + hot.Hits,
+		Evictions: main.Evictions + hot.Evictions,
+	}
+}
+
+// cacheInfo returns the stats of the groupcache group 'group', named 'name',
+// whose caches may use up to 'sizeBytes'
+func cacheInfo(name string, group *groupcache.Group, sizeBytes int64) *pfsclient.CacheInfo {
+	stats := totalCacheStats(group)
+	return &pfsclient.CacheInfo{
+		Name:      name,
+		SizeBytes: sizeBytes,
+		Bytes:     stats.Bytes,
+		Items:     stats.Items,
+		Gets:      group.Stats.Gets.Get(),
+		Hits:      group.Stats.CacheHits.Get(),
+		Evictions: stats.Evictions,
+	}
+}
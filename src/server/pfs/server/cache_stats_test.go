@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/groupcache"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+func TestCacheInfo(t *testing.T) {
+	sizeBytes := int64(10 * 1024)
+	value := strings.Repeat("x", 1024)
+	group := groupcache.NewGroup("test"+uuid.New(), sizeBytes, groupcache.GetterFunc(
+		func(ctx groupcache.Context, key string, dest groupcache.Sink) error {
+			return dest.SetString(value)
+		}))
+	get := func(key string) {
+		var s string
+		require.NoError(t, group.Get(context.Background(), key, groupcache.StringSink(&s)))
+		require.Equal(t, value, s)
+	}
+
+	// Repeated lookups of a key are hits
+	for _, key := range []string{"a", "a", "b", "a", "b", "c"} {
+		get(key)
+	}
+	info := cacheInfo("test", group, sizeBytes)
+	require.Equal(t, "test", info.Name)
+	require.Equal(t, sizeBytes, info.SizeBytes)
+	require.Equal(t, int64(6), info.Gets)
+	require.Equal(t, int64(3), info.Hits)
+	require.Equal(t, int64(3), info.Items)
+	require.Equal(t, int64(0), info.Evictions)
+
+	// Values are evicted to keep the cache within its size
+	for i := 0; i < 100; i++ {
+		get(uuid.New())
+	}
+	info = cacheInfo("test", group, sizeBytes)
+	require.Equal(t, int64(106), info.Gets)
+	require.Equal(t, int64(3), info.Hits)
+	require.True(t, info.Evictions > 0)
+	require.True(t, info.Bytes <= sizeBytes)
+	require.True(t, info.Items < 100)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/dlock"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
+	"github.com/pachyderm/pachyderm/src/server/pkg/pfsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+const (
+	// commitEventsLockPath is the etcd key (under the PFS etcd prefix) of the
+	// lock held by the pachd that publishes commit events
+	commitEventsLockPath = "commit_events_lock"
+	// commitEventsRevisionKey is the etcd key (under the PFS etcd prefix)
+	// that holds the revision of the last commit change whose event was
+	// published, so that the pachd that takes over publishing commit events
+	// resumes where the last one stopped
+	commitEventsRevisionKey = "commit_events_revision"
+)
+
+// commitEvent returns the event for a commit changing from 'prev' to
+// 'commitInfo', or nil if the change didn't finish it. 'prev' is nil if the
+// commit was just created (in which case an event is published if it was
+// created finished).
+func commitEvent(prev *pfs.CommitInfo, commitInfo *pfs.CommitInfo) *events.Event {
+	if commitInfo.Finished == nil || (prev != nil && prev.Finished != nil) {
+		return nil
+	}
+	return events.NewCommitFinishedEvent(commitInfo.Commit)
+}
+
+// isCommitKey returns true if 'key', relative to the root of the commit
+// collections, is a commit's key ("<repo>/<commit>") rather than the key of
+// an entry in one of their indexes
+func isCommitKey(key string) bool {
+	return !strings.Contains(strings.SplitN(key, "/", 2)[0], "__index_")
+}
+
+// publishCommitEvents publishes an event each time a commit is finished, for
+// as long as this process runs. Only the pachd that holds the lock at
+// commitEventsLockPath publishes them, so that each event is published once
+// (barring failures), and it resumes from the revision stored at
+// commitEventsRevisionKey, so that commits finished while another pachd took
+// over aren't missed.
+func (d *driver) publishCommitEvents() {
+	lock := dlock.NewDLock(d.etcdClient, path.Join(d.prefix, commitEventsLockPath))
+	backoff.RetryNotify(func() error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ctx, err := lock.Lock(ctx)
+		if err != nil {
+			return err
+		}
+		defer lock.Unlock(ctx)
+		return events.PublishWatched(ctx, d.etcdClient, path.Join(d.prefix, commitEventsRevisionKey),
+			pfsdb.Commits(d.etcdClient, d.prefix, "").ReadOnly(ctx).WatchWithPrevFrom,
+			func(event *watch.Event) (*events.Event, error) {
+				if event.Type != watch.EventPut || !isCommitKey(string(event.Key)) {
+					return nil, nil
+				}
+				var key string
+				commitInfo := &pfs.CommitInfo{}
+				if err := event.Unmarshal(&key, commitInfo); err != nil {
+					return nil, err
+				}
+				var prev *pfs.CommitInfo
+				if event.PrevKey != nil {
+					prev = &pfs.CommitInfo{}
+					if err := event.UnmarshalPrev(&key, prev); err != nil {
+						return nil, err
+					}
+				}
+				return commitEvent(prev, commitInfo), nil
+			})
+	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+		logrus.Errorf("error publishing commit events: %v; retrying in %v", err, d)
+		return nil
+	})
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
+)
+
+func TestCommitEvent(t *testing.T) {
+	started := &pfs.CommitInfo{Commit: client.NewCommit("images", "1"), Started: now()}
+	require.True(t, commitEvent(nil, started) == nil)
+
+	finished := &pfs.CommitInfo{Commit: client.NewCommit("images", "1"), Started: started.Started, Finished: now()}
+	event := commitEvent(started, finished)
+	require.Equal(t, events.CommitFinished, event.Type)
+	require.Equal(t, "images", event.Repo)
+	require.Equal(t, "1", event.Commit)
+
+	// Later changes to a finished commit (e.g. to its subvenance) don't
+	// publish another event, but a commit that's created finished does
+	require.True(t, commitEvent(finished, finished) == nil)
+	require.Equal(t, events.CommitFinished, commitEvent(nil, finished).Type)
+}
+
+func TestIsCommitKey(t *testing.T) {
+	require.True(t, isCommitKey("images/1"))
+	require.False(t, isCommitKey("images__index_Provenance/abc/1"))
+}
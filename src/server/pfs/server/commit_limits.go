@@ -0,0 +1,77 @@
+package server
+
+import (
+	"fmt"
+
+	units "github.com/docker/go-units"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+// commitLimits are the most files and bytes that a commit may add or change,
+// relative to its parent (0 means no limit)
+type commitLimits struct {
+	files int64
+	bytes int64
+}
+
+// repoCommitLimit returns the limit that applies to a repo's commits, given
+// the repo's limit 'repoLimit' (see pfs.RepoInfo.MaxCommitFiles) and the
+// cluster's limit 'clusterLimit'
+func repoCommitLimit(repoLimit int64, clusterLimit int64) int64 {
+	switch {
+	case repoLimit > 0:
+		return repoLimit
+	case repoLimit < 0:
+		return 0
+	}
+	return clusterLimit
+}
+
+// repoCommitLimits returns the limits on the commits in the repo 'repoInfo'
+func (d *driver) repoCommitLimits(repoInfo *pfs.RepoInfo) commitLimits {
+	return commitLimits{
+		files: repoCommitLimit(repoInfo.MaxCommitFiles, d.maxCommitFiles),
+		bytes: repoCommitLimit(repoInfo.MaxCommitBytes, d.maxCommitBytes),
+	}
+}
+
+// checkCommitLimits returns an error if 'tree', the tree of 'commit', adds or
+// changes more files or bytes than 'limits' allow, relative to 'parentTree',
+// the tree of its parent (files that 'commit' inherits unchanged from its
+// parent don't count towards the limits)
+func checkCommitLimits(commit *pfs.Commit, tree hashtree.HashTree, parentTree hashtree.HashTree, limits commitLimits) error {
+	if limits.files <= 0 && limits.bytes <= 0 {
+		return nil
+	}
+	// Stop counting once a limit is exceeded, as the diff may be huge
+	var files, bytes int64
+	if err := tree.Diff(parentTree, "", "", -1, func(path string, node *hashtree.NodeProto, new bool) error {
+		if node.FileNode == nil || !new {
+			return nil
+		}
+		files++
+		bytes += node.SubtreeSize
+		if (limits.files > 0 && files > limits.files) || (limits.bytes > 0 && bytes > limits.bytes) {
+			return errutil.ErrBreak
+		}
+		return nil
+	}); err != nil && err != errutil.ErrBreak {
+		return err
+	}
+	if limits.files > 0 && files > limits.files {
+		return fmt.Errorf("commit %s adds or changes more than the limit of %d files per commit in repo %q; "+
+			"split its files across several commits, or raise the repo's limit with "+
+			"\"pachctl update-repo %s --max-commit-files\"", commit.ID, limits.files,
+			commit.Repo.Name, commit.Repo.Name)
+	}
+	if limits.bytes > 0 && bytes > limits.bytes {
+		return fmt.Errorf("commit %s adds or changes more than the limit of %s per commit in repo %q; "+
+			"split its files across several commits, or raise the repo's limit with "+
+			"\"pachctl update-repo %s --max-commit-bytes\"", commit.ID,
+			units.BytesSize(float64(limits.bytes)), commit.Repo.Name, commit.Repo.Name)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+const (
+	// DedupScopeGlobal deduplicates identical content across the whole
+	// cluster. It's the default, and stores the least data.
+	DedupScopeGlobal = "global"
+	// DedupScopeRepo only deduplicates identical content within each repo, so
+	// that whether a repo holds some content can't be learned by putting the
+	// same content in another repo. Access to repos is controlled per repo (see
+	// auth.ACL), so this is also the scope that isolates ACL domains.
+	DedupScopeRepo = client.PFSDedupScopeRepo
+)
+
+// validateDedupScope returns an error if 'scope' isn't a dedup scope that PFS
+// supports ("" means DedupScopeGlobal)
+func validateDedupScope(scope string) error {
+	switch scope {
+	case "", DedupScopeGlobal, DedupScopeRepo:
+		return nil
+	}
+	return fmt.Errorf("invalid dedup scope %q, must be %q or %q", scope, DedupScopeGlobal, DedupScopeRepo)
+}
+
+// objectScope returns the dedup scope (see pfs.PutObjectRequest.DedupScope)
+// of the objects that hold the content of files in 'repo'
+func (d *driver) objectScope(repo string) string {
+	if d.dedupScope == DedupScopeRepo {
+		return pfs.RepoScope(repo)
+	}
+	return ""
+}
+
+// rescopeObject returns an object holding the content of 'object' in the
+// dedup scope of 'repo', when PFS deduplicates content within each repo.
+// Content that's already in the scope is stored once, as usual, so this only
+// stores a copy of content that came from outside of the repo.
+func (d *driver) rescopeObject(pachClient *client.APIClient, repo string, object *pfs.Object) (_ *pfs.Object, retErr error) {
+	if d.dedupScope != DedupScopeRepo || object == nil {
+		return object, nil
+	}
+	r, err := pachClient.GetObjectReader(object.Hash)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	object, _, err = pachClient.PutObjectInScope(pfs.RepoScope(repo), r)
+	return object, err
+}
+
+// rescopeRecords moves the objects referenced by 'records', which are to be
+// written to 'repo', into the repo's dedup scope (see rescopeObject)
+func (d *driver) rescopeRecords(pachClient *client.APIClient, repo string, records *pfs.PutFileRecords) error {
+	if d.dedupScope != DedupScopeRepo {
+		return nil
+	}
+	all := append([]*pfs.PutFileRecord{records.Header, records.Footer}, records.Records...)
+	for _, record := range all {
+		if record == nil || record.ObjectHash == "" {
+			continue
+		}
+		object, err := d.rescopeObject(pachClient, repo, &pfs.Object{Hash: record.ObjectHash})
+		if err != nil {
+			return err
+		}
+		record.ObjectHash = object.Hash
+	}
+	return nil
+}
+
+// rescopeTree moves the objects referenced by 'tree', which is to be
+// committed to 'repo', into the repo's dedup scope (see rescopeObject). It
+// returns true if 'tree' was changed, in which case it must be rehashed.
+func (d *driver) rescopeTree(pachClient *client.APIClient, repo string, tree hashtree.HashTree) (bool, error) {
+	if d.dedupScope != DedupScopeRepo {
+		return false, nil
+	}
+	// 'tree' can't be written while it's being walked, so the changes are
+	// applied afterwards
+	var updates []func() error
+	if err := tree.Walk("/", func(path string, node *hashtree.NodeProto) error {
+		switch {
+		case node.FileNode != nil:
+			var objects []*pfs.Object
+			changed := false
+			for _, object := range node.FileNode.Objects {
+				newObject, err := d.rescopeObject(pachClient, repo, object)
+				if err != nil {
+					return err
+				}
+				objects = append(objects, newObject)
+				changed = changed || newObject.Hash != object.Hash
+			}
+			if changed {
+				updates = append(updates, func() error {
+					return tree.PutFileOverwrite(path, objects, &pfs.OverwriteIndex{}, 0)
+				})
+			}
+		case node.DirNode != nil && node.DirNode.Shared != nil:
+			shared := node.DirNode.Shared
+			header, err := d.rescopeObject(pachClient, repo, shared.Header)
+			if err != nil {
+				return err
+			}
+			footer, err := d.rescopeObject(pachClient, repo, shared.Footer)
+			if err != nil {
+				return err
+			}
+			if header.GetHash() != shared.Header.GetHash() || footer.GetHash() != shared.Footer.GetHash() {
+				updates = append(updates, func() error {
+					return tree.PutDirHeaderFooter(path, header, footer, shared.HeaderSize, shared.FooterSize)
+				})
+			}
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	for _, update := range updates {
+		if err := update(); err != nil {
+			return false, err
+		}
+	}
+	return len(updates) > 0, nil
+}
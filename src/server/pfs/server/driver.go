@@ -3,7 +3,9 @@ package server
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -46,6 +48,10 @@ import (
 	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
+	lru "github.com/hashicorp/golang-lru"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -58,6 +64,20 @@ const (
 
 	// maxInt is the maximum value for 'int' (system-dependent). Not in 'math'!
 	maxInt = int(^uint(0) >> 1)
+
+	// putFileRecordsBatchSize is the maximum number of files whose put-file
+	// records are written to an open commit in one etcd transaction
+	putFileRecordsBatchSize = 100
+
+	// pruneBatchSize is the maximum number of commits (not counting their
+	// downstream commits) that pruneBranch deletes in one etcd transaction
+	pruneBatchSize = 100
+
+	// commitDataCacheSize is the number of commits whose data diskUsage
+	// caches, and objectSizeCacheSize is the number of objects whose sizes it
+	// caches
+	commitDataCacheSize = 10000
+	objectSizeCacheSize = 1000000
 )
 
 var (
@@ -74,6 +94,21 @@ func validateRepoName(name string) error {
 	return nil
 }
 
+// validateLabels determines if commit labels are valid. Labels have the same
+// syntax as Kubernetes labels, so that they can be queried with Kubernetes
+// label selectors
+func validateLabels(labels map[string]string) error {
+	for key, val := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("label key (%v) invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(val); len(errs) > 0 {
+			return fmt.Errorf("label value (%v) for key %v invalid: %s", val, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
 // IsPermissionError returns true if a given error is a permission error.
 func IsPermissionError(err error) bool {
 	return strings.Contains(err.Error(), "has already finished")
@@ -104,6 +139,9 @@ type driver struct {
 	commits        collectionFactory
 	branches       collectionFactory
 	openCommits    col.Collection
+	// uploadChunks returns the collection of the chunks stored by
+	// putFileChunk in a repo as part of an upload
+	uploadChunks func(repo string, uploadID string) col.Collection
 
 	// a cache for hashtrees
 	treeCache *hashtree.Cache
@@ -113,14 +151,37 @@ type driver struct {
 
 	// memory limiter (useful for limiting operations that could use a lot of memory)
 	memoryLimiter *semaphore.Weighted
+
+	// putFileLimiter limits the number of concurrent etcd transactions that
+	// merge put-file records into open commits
+	putFileLimiter limit.ConcurrencyLimiter
+
+	// dedupScope is the scope within which identical file content is stored
+	// once (see objectScope)
+	dedupScope string
+
+	// maxCommitFiles and maxCommitBytes are the cluster's limits on the files
+	// and bytes that a commit adds or changes, relative to its parent, unless
+	// its repo overrides them (0 means no limit)
+	maxCommitFiles int64
+	maxCommitBytes int64
+
+	// commitDataCache and objectSizeCache hold the data that finished commits
+	// refer to, and the sizes of objects, so that diskUsage doesn't read the
+	// same commits' files and objects on every call (neither ever changes)
+	commitDataCache *lru.Cache
+	objectSizeCache *lru.Cache
 }
 
 // newDriver is used to create a new Driver instance
-func newDriver(etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Cache, storageRoot string, memoryRequest int64) (*driver, error) {
+func newDriver(etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Cache, storageRoot string, memoryRequest int64, options APIServerOptions) (*driver, error) {
 	// Validate arguments
 	if treeCache == nil {
 		return nil, fmt.Errorf("cannot initialize driver with nil treeCache")
 	}
+	if err := validateDedupScope(options.DedupScope); err != nil {
+		return nil, err
+	}
 
 	// Initialize etcd client
 	etcdClient, err := etcd.New(etcd.Config{
@@ -130,6 +191,14 @@ func newDriver(etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Ca
 	if err != nil {
 		return nil, fmt.Errorf("could not connect to etcd: %v", err)
 	}
+	commitDataCache, err := lru.New(commitDataCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	objectSizeCache, err := lru.New(objectSizeCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	// Initialize driver
 	d := &driver{
 		etcdClient:     etcdClient,
@@ -143,10 +212,19 @@ func newDriver(etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Ca
 			return pfsdb.Branches(etcdClient, etcdPrefix, repo)
 		},
 		openCommits: pfsdb.OpenCommits(etcdClient, etcdPrefix),
+		uploadChunks: func(repo string, uploadID string) col.Collection {
+			return pfsdb.UploadChunks(etcdClient, etcdPrefix, repo, uploadID)
+		},
 		treeCache:   treeCache,
 		storageRoot: storageRoot,
 		// Allow up to a third of the requested memory to be used for memory intensive operations
-		memoryLimiter: semaphore.NewWeighted(memoryRequest / 3),
+		memoryLimiter:   semaphore.NewWeighted(memoryRequest / 3),
+		putFileLimiter:  limit.New(options.PutFileConcurrencyLimit),
+		dedupScope:      options.DedupScope,
+		maxCommitFiles:  options.MaxCommitFiles,
+		maxCommitBytes:  options.MaxCommitBytes,
+		commitDataCache: commitDataCache,
+		objectSizeCache: objectSizeCache,
 	}
 	return d, nil
 }
@@ -173,6 +251,100 @@ func (d *driver) checkIsAuthorized(pachClient *client.APIClient, r *pfs.Repo, s
 	return nil
 }
 
+// checkIsAuthorizedInCommit is like checkIsAuthorized, but for operations on
+// a commit, which may also be authorized by branch-scoped ACL entries. The
+// user is authorized if the repo's ACL grants them scope 's' or, if
+// 'commit.ID' is a branch, the branch's ACL entries do. If 'commit.ID' is a
+// commit ID, the entries of every branch that contains the commit must grant
+// 's' (so that a user can write to a commit that they started on a branch
+// they can write to, but not to one that's also in a branch they can't).
+func (d *driver) checkIsAuthorizedInCommit(pachClient *client.APIClient, commit *pfs.Commit, s auth.Scope) error {
+	err := d.checkIsAuthorized(pachClient, commit.Repo, s)
+	if !auth.IsErrNotAuthorized(err) {
+		return err
+	}
+	branches, branchErr := d.commitBranches(pachClient, commit)
+	if branchErr != nil {
+		return branchErr
+	}
+	if len(branches) == 0 {
+		return err
+	}
+	for _, branch := range branches {
+		resp, authErr := pachClient.AuthAPIClient.Authorize(pachClient.Ctx(), &auth.AuthorizeRequest{
+			Repo:   commit.Repo.Name,
+			Scope:  s,
+			Branch: branch,
+		})
+		if authErr != nil {
+			return fmt.Errorf("error during authorization check for operation on \"%s@%s\": %v",
+				commit.Repo.Name, branch, grpcutil.ScrubGRPC(authErr))
+		}
+		if !resp.Authorized {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitBranches returns the branch 'commit.ID' if it's a branch, or else the
+// branches that contain the commit 'commit.ID' (i.e. whose head is the commit
+// or one of its descendants)
+func (d *driver) commitBranches(pachClient *client.APIClient, commit *pfs.Commit) ([]string, error) {
+	ctx := pachClient.Ctx()
+	branches := d.branches(commit.Repo.Name).ReadOnly(ctx)
+	branchInfo := &pfs.BranchInfo{}
+	if err := branches.Get(commit.ID, branchInfo); err == nil {
+		return []string{commit.ID}, nil
+	} else if !col.IsErrNotFound(err) {
+		return nil, err
+	}
+	// contains memoizes whether each commit visited so far is the commit or
+	// one of its descendants, as branches often share most of their history
+	commits := d.commits(commit.Repo.Name).ReadOnly(ctx)
+	contains := map[string]bool{commit.ID: true}
+	containsCommit := func(id string) (bool, error) {
+		var path []string
+		result := false
+		for id != "" {
+			if c, ok := contains[id]; ok {
+				result = c
+				break
+			}
+			path = append(path, id)
+			commitInfo := &pfs.CommitInfo{}
+			if err := commits.Get(id, commitInfo); err != nil {
+				return false, err
+			}
+			id = ""
+			if commitInfo.ParentCommit != nil {
+				id = commitInfo.ParentCommit.ID
+			}
+		}
+		for _, visited := range path {
+			contains[visited] = result
+		}
+		return result, nil
+	}
+	var result []string
+	if err := branches.List(branchInfo, col.DefaultOptions, func(string) error {
+		if branchInfo.Head == nil {
+			return nil
+		}
+		ok, err := containsCommit(branchInfo.Head.ID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			result = append(result, branchInfo.Branch.Name)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func now() *types.Timestamp {
 	t, err := types.TimestampProto(time.Now())
 	if err != nil {
@@ -189,7 +361,7 @@ func absent(key string) etcd.Cmp {
 	return etcd.Compare(etcd.CreateRevision(key), "=", 0)
 }
 
-func (d *driver) createRepo(pachClient *client.APIClient, repo *pfs.Repo, description string, update bool) error {
+func (d *driver) createRepo(pachClient *client.APIClient, repo *pfs.Repo, description string, recommendedGlob string, maxCommitFiles int64, maxCommitBytes int64, update bool) error {
 	ctx := pachClient.Ctx()
 	// Check that the user is logged in (user doesn't need any access level to
 	// create a repo, but they must be authenticated if auth is active)
@@ -203,7 +375,7 @@ func (d *driver) createRepo(pachClient *client.APIClient, repo *pfs.Repo, descri
 		return err
 	}
 	if update {
-		return d.updateRepo(pachClient, repo, description)
+		return d.updateRepo(pachClient, repo, description, recommendedGlob, maxCommitFiles, maxCommitBytes)
 	}
 
 	_, err = col.NewSTM(ctx, d.etcdClient, func(stm col.STM) error {
@@ -240,16 +412,19 @@ func (d *driver) createRepo(pachClient *client.APIClient, repo *pfs.Repo, descri
 		}
 
 		repoInfo := &pfs.RepoInfo{
-			Repo:        repo,
-			Created:     now(),
-			Description: description,
+			Repo:            repo,
+			Created:         now(),
+			Description:     description,
+			RecommendedGlob: recommendedGlob,
+			MaxCommitFiles:  maxCommitFiles,
+			MaxCommitBytes:  maxCommitBytes,
 		}
 		return repos.Create(repo.Name, repoInfo)
 	})
 	return err
 }
 
-func (d *driver) updateRepo(pachClient *client.APIClient, repo *pfs.Repo, description string) error {
+func (d *driver) updateRepo(pachClient *client.APIClient, repo *pfs.Repo, description string, recommendedGlob string, maxCommitFiles int64, maxCommitBytes int64) error {
 	ctx := pachClient.Ctx()
 	_, err := col.NewSTM(ctx, d.etcdClient, func(stm col.STM) error {
 		repos := d.repos.ReadWrite(stm)
@@ -263,6 +438,14 @@ func (d *driver) updateRepo(pachClient *client.APIClient, repo *pfs.Repo, descri
 			return err
 		}
 		repoInfo.Description = description
+		repoInfo.RecommendedGlob = recommendedGlob
+		// Keep the repo's limits unless new ones are set
+		if maxCommitFiles != 0 {
+			repoInfo.MaxCommitFiles = maxCommitFiles
+		}
+		if maxCommitBytes != 0 {
+			repoInfo.MaxCommitBytes = maxCommitBytes
+		}
 		return repos.Put(repo.Name, repoInfo)
 	})
 	return err
@@ -336,6 +519,187 @@ func (d *driver) listRepo(pachClient *client.APIClient, includeAuth bool) (*pfs.
 	return result, nil
 }
 
+// diskUsage returns the storage used by 'repo', or by every repo if 'repo' is
+// nil. Every finished commit in every repo is read, as 'repo' may share data
+// with any of them (see pfs.RepoDiskUsage), but the data that each commit
+// refers to is only read from its files once (see commitData).
+func (d *driver) diskUsage(pachClient *client.APIClient, repo *pfs.Repo) ([]*pfs.RepoDiskUsage, error) {
+	ctx := pachClient.Ctx()
+	if repo != nil {
+		if _, err := d.inspectRepo(pachClient, repo, false); err != nil {
+			return nil, err
+		}
+		if err := d.checkIsAuthorized(pachClient, repo, auth.Scope_READER); err != nil {
+			return nil, err
+		}
+	}
+	var repoNames []string
+	repoInfo := &pfs.RepoInfo{}
+	if err := d.repos.ReadOnly(ctx).List(repoInfo, col.DefaultOptions, func(repoName string) error {
+		repoNames = append(repoNames, repoName)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(repoNames)
+	// dataRef is a chunk of data that files refer to (an object, or a range of
+	// a block), and the repos whose files refer to it
+	type dataRef struct {
+		size  uint64
+		repos map[string]bool
+	}
+	refs := make(map[string]*dataRef)
+	usage := make(map[string]*pfs.RepoDiskUsage)
+	for _, repoName := range repoNames {
+		repoUsage := &pfs.RepoDiskUsage{Repo: client.NewRepo(repoName)}
+		usage[repoName] = repoUsage
+		var commitInfos []*pfs.CommitInfo
+		commitInfo := &pfs.CommitInfo{}
+		if err := d.commits(repoName).ReadOnly(ctx).List(commitInfo, col.DefaultOptions, func(string) error {
+			if commitInfo.Finished != nil {
+				commitInfos = append(commitInfos, proto.Clone(commitInfo).(*pfs.CommitInfo))
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		for _, commitInfo := range commitInfos {
+			repoUsage.LogicalBytes += commitInfo.SizeBytes
+			commitData, err := d.commitData(pachClient, commitInfo)
+			if err != nil {
+				return nil, err
+			}
+			for key, size := range commitData {
+				ref, ok := refs[key]
+				if !ok {
+					ref = &dataRef{size: size, repos: make(map[string]bool)}
+					refs[key] = ref
+				}
+				ref.repos[repoName] = true
+			}
+		}
+	}
+	for _, ref := range refs {
+		for repoName := range ref.repos {
+			usage[repoName].PhysicalBytes += ref.size
+			if len(ref.repos) == 1 {
+				usage[repoName].ExclusiveBytes += ref.size
+			}
+		}
+	}
+	if repo != nil {
+		return []*pfs.RepoDiskUsage{usage[repo.Name]}, nil
+	}
+	// Every repo's data is read above, as data is only exclusive to a repo if
+	// no other repo refers to it, but only the usage of the repos that the
+	// caller can read is returned
+	var result []*pfs.RepoDiskUsage
+	for _, repoName := range repoNames {
+		if repoName == ppsconsts.SpecRepo {
+			continue
+		}
+		if err := d.checkIsAuthorized(pachClient, client.NewRepo(repoName), auth.Scope_READER); err != nil {
+			if auth.IsErrNotAuthorized(err) {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, usage[repoName])
+	}
+	return result, nil
+}
+
+// commitData returns the chunks of data (objects, or ranges of blocks) that
+// the files in the finished commit 'commitInfo' refer to, keyed by a string
+// that identifies each chunk, with their sizes. Finished commits don't
+// change, so the result is cached.
+func (d *driver) commitData(pachClient *client.APIClient, commitInfo *pfs.CommitInfo) (map[string]uint64, error) {
+	cacheKey := path.Join(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID)
+	if cached, ok := d.commitDataCache.Get(cacheKey); ok {
+		return cached.(map[string]uint64), nil
+	}
+	result := make(map[string]uint64)
+	if err := d.walkCommitFiles(pachClient, commitInfo, func(fileNode *hashtree.FileNodeProto) error {
+		for _, object := range fileNode.Objects {
+			key := "object/" + object.Hash
+			if _, ok := result[key]; ok {
+				continue
+			}
+			size, err := d.objectSize(pachClient, object.Hash)
+			if err != nil {
+				return err
+			}
+			result[key] = size
+		}
+		for _, blockRef := range fileNode.BlockRefs {
+			key := fmt.Sprintf("block/%s/%d-%d", blockRef.Block.Hash, blockRef.Range.Lower, blockRef.Range.Upper)
+			result[key] = blockRef.Range.Upper - blockRef.Range.Lower
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	d.commitDataCache.Add(cacheKey, result)
+	return result, nil
+}
+
+// objectSize returns the size of the object with hash 'hash'. Objects don't
+// change, so their sizes are cached.
+func (d *driver) objectSize(pachClient *client.APIClient, hash string) (uint64, error) {
+	if cached, ok := d.objectSizeCache.Get(hash); ok {
+		return cached.(uint64), nil
+	}
+	objectInfo, err := pachClient.InspectObject(hash)
+	if err != nil {
+		return 0, err
+	}
+	size := objectInfo.BlockRef.Range.Upper - objectInfo.BlockRef.Range.Lower
+	d.objectSizeCache.Add(hash, size)
+	return size, nil
+}
+
+// walkCommitFiles calls 'f' with each file in the finished commit
+// 'commitInfo'. Unlike walkFile, it doesn't check that the caller is
+// authorized to read the commit.
+func (d *driver) walkCommitFiles(pachClient *client.APIClient, commitInfo *pfs.CommitInfo, f func(*hashtree.FileNodeProto) error) (retErr error) {
+	walk := func(path string, node *hashtree.NodeProto) error {
+		if node.FileNode == nil {
+			return nil
+		}
+		return f(node.FileNode)
+	}
+	if commitInfo.Trees != nil {
+		rs, err := d.getTrees(pachClient, commitInfo, "/")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			for _, r := range rs {
+				if err := r.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}
+		}()
+		return hashtree.Walk(rs, "/", walk)
+	}
+	if commitInfo.Tree == nil {
+		return nil
+	}
+	var tree hashtree.HashTree
+	if cached, ok := d.treeCache.Get(commitInfo.Commit.ID); ok {
+		if tree, ok = cached.(hashtree.HashTree); !ok {
+			return fmt.Errorf("corrupted cache: expected hashtree.Hashtree, found %v", cached)
+		}
+	} else {
+		var err error
+		if tree, err = hashtree.GetHashTreeObject(pachClient, d.storageRoot, commitInfo.Tree); err != nil {
+			return err
+		}
+		d.treeCache.Add(commitInfo.Commit.ID, tree)
+	}
+	return tree.Walk("/", walk)
+}
+
 func (d *driver) deleteRepo(pachClient *client.APIClient, repo *pfs.Repo, force bool) error {
 	ctx := pachClient.Ctx()
 	// TODO(msteffen): Fix d.deleteAll() so that it doesn't need to delete and
@@ -414,12 +778,12 @@ func (d *driver) deleteRepo(pachClient *client.APIClient, repo *pfs.Repo, force
 	return nil
 }
 
-func (d *driver) startCommit(pachClient *client.APIClient, parent *pfs.Commit, branch string, provenance []*pfs.Commit, description string) (*pfs.Commit, error) {
-	return d.makeCommit(pachClient, "", parent, branch, provenance, nil, nil, nil, description)
+func (d *driver) startCommit(pachClient *client.APIClient, parent *pfs.Commit, branch string, provenance []*pfs.Commit, description string, labels map[string]string) (*pfs.Commit, error) {
+	return d.makeCommit(pachClient, "", parent, branch, provenance, nil, nil, nil, description, labels)
 }
 
 func (d *driver) buildCommit(pachClient *client.APIClient, ID string, parent *pfs.Commit, branch string, provenance []*pfs.Commit, tree *pfs.Object) (*pfs.Commit, error) {
-	return d.makeCommit(pachClient, ID, parent, branch, provenance, tree, nil, nil, "")
+	return d.makeCommit(pachClient, ID, parent, branch, provenance, tree, nil, nil, "", nil)
 }
 
 // make commit makes a new commit in 'branch', with the parent 'parent' and the
@@ -432,14 +796,21 @@ func (d *driver) buildCommit(pachClient *client.APIClient, ID string, parent *pf
 //   to the new commit
 // - If neither 'parent.ID' nor 'branch' are set, the new commit will have no
 //   parent
-func (d *driver) makeCommit(pachClient *client.APIClient, ID string, parent *pfs.Commit, branch string, provenance []*pfs.Commit, treeRef *pfs.Object, recordFiles []string, records []*pfs.PutFileRecords, description string) (*pfs.Commit, error) {
+func (d *driver) makeCommit(pachClient *client.APIClient, ID string, parent *pfs.Commit, branch string, provenance []*pfs.Commit, treeRef *pfs.Object, recordFiles []string, records []*pfs.PutFileRecords, description string, labels map[string]string) (*pfs.Commit, error) {
 	// Validate arguments:
 	if parent == nil {
 		return nil, fmt.Errorf("parent cannot be nil")
 	}
+	if err := validateLabels(labels); err != nil {
+		return nil, err
+	}
 
 	// Check that caller is authorized
-	if err := d.checkIsAuthorized(pachClient, parent.Repo, auth.Scope_WRITER); err != nil {
+	if branch != "" {
+		if err := d.checkIsAuthorizedInCommit(pachClient, client.NewCommit(parent.Repo.Name, branch), auth.Scope_WRITER); err != nil {
+			return nil, err
+		}
+	} else if err := d.checkIsAuthorized(pachClient, parent.Repo, auth.Scope_WRITER); err != nil {
 		return nil, err
 	}
 
@@ -455,6 +826,7 @@ func (d *driver) makeCommit(pachClient *client.APIClient, ID string, parent *pfs
 		Commit:      newCommit,
 		Started:     now(),
 		Description: description,
+		Labels:      labels,
 	}
 
 	//  BuildCommit case: if the caller passed a tree reference with the commit
@@ -466,6 +838,20 @@ func (d *driver) makeCommit(pachClient *client.APIClient, ID string, parent *pfs
 		if err != nil {
 			return nil, err
 		}
+		// The tree comes from the client, so its content may not be in the
+		// repo's dedup scope yet
+		rescoped, err := d.rescopeTree(pachClient, parent.Repo.Name, tree)
+		if err != nil {
+			return nil, err
+		}
+		if rescoped {
+			if err := tree.Hash(); err != nil {
+				return nil, err
+			}
+			if treeRef, err = hashtree.PutHashTree(pachClient, tree); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Txn: create the actual commit in etcd and update the branch + parent/child
@@ -551,6 +937,11 @@ func (d *driver) makeCommit(pachClient *client.APIClient, ID string, parent *pfs
 				if err := tree.Hash(); err != nil {
 					return err
 				}
+			}
+			if err := checkCommitLimits(newCommit, tree, parentTree, d.repoCommitLimits(repoInfo)); err != nil {
+				return err
+			}
+			if records != nil {
 				treeRef, err = hashtree.PutHashTree(pachClient, tree)
 				if err != nil {
 					return err
@@ -614,12 +1005,19 @@ func (d *driver) makeCommit(pachClient *client.APIClient, ID string, parent *pfs
 	}); err != nil {
 		return nil, err
 	}
+	if treeRef != nil || records != nil {
+		// The commit was created finished
+		d.pruneHeadBranches(pachClient, newCommit)
+	}
 	return newCommit, nil
 }
 
-func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit, tree *pfs.Object, empty bool, description string) (retErr error) {
+func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit, tree *pfs.Object, empty bool, description string, labels map[string]string) (retErr error) {
 	ctx := pachClient.Ctx()
-	if err := d.checkIsAuthorized(pachClient, commit.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, commit, auth.Scope_WRITER); err != nil {
+		return err
+	}
+	if err := validateLabels(labels); err != nil {
 		return err
 	}
 	commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
@@ -632,6 +1030,12 @@ func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit,
 	if description != "" {
 		commitInfo.Description = description
 	}
+	for key, val := range labels {
+		if commitInfo.Labels == nil {
+			commitInfo.Labels = make(map[string]string)
+		}
+		commitInfo.Labels[key] = val
+	}
 
 	scratchPrefix := d.scratchCommitPrefix(commit)
 	defer func() {
@@ -664,6 +1068,11 @@ func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit,
 		if err != nil {
 			return err
 		}
+		repoInfo, err := d.inspectRepo(pachClient, commit.Repo, false)
+		if err != nil {
+			return err
+		}
+		limits := d.repoCommitLimits(repoInfo)
 
 		if tree == nil {
 			var err error
@@ -671,6 +1080,9 @@ func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit,
 			if err != nil {
 				return err
 			}
+			if err := checkCommitLimits(commit, finishedTree, parentTree, limits); err != nil {
+				return err
+			}
 			// Put the tree to object storage.
 			treeRef, err := hashtree.PutHashTree(pachClient, finishedTree)
 			if err != nil {
@@ -683,6 +1095,9 @@ func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit,
 			if err != nil {
 				return err
 			}
+			if err := checkCommitLimits(commit, finishedTree, parentTree, limits); err != nil {
+				return err
+			}
 			commitInfo.Tree = tree
 		}
 
@@ -716,14 +1131,21 @@ func (d *driver) finishCommit(pachClient *client.APIClient, commit *pfs.Commit,
 		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	d.pruneHeadBranches(pachClient, commitInfo.Commit)
+	return nil
 }
 
-func (d *driver) finishOutputCommit(pachClient *client.APIClient, commit *pfs.Commit, trees []*pfs.Object, datums *pfs.Object, size uint64) (retErr error) {
+func (d *driver) finishOutputCommit(pachClient *client.APIClient, commit *pfs.Commit, trees []*pfs.Object, datums *pfs.Object, size uint64, description string, labels map[string]string) (retErr error) {
 	ctx := pachClient.Ctx()
 	if err := d.checkIsAuthorized(pachClient, commit.Repo, auth.Scope_WRITER); err != nil {
 		return err
 	}
+	if err := validateLabels(labels); err != nil {
+		return err
+	}
 	commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
 	if err != nil {
 		return err
@@ -731,6 +1153,15 @@ func (d *driver) finishOutputCommit(pachClient *client.APIClient, commit *pfs.Co
 	if commitInfo.Finished != nil {
 		return fmt.Errorf("commit %s has already been finished", commit.FullID())
 	}
+	if description != "" {
+		commitInfo.Description = description
+	}
+	for key, val := range labels {
+		if commitInfo.Labels == nil {
+			commitInfo.Labels = make(map[string]string)
+		}
+		commitInfo.Labels[key] = val
+	}
 	commitInfo.Trees = trees
 	commitInfo.Datums = datums
 	commitInfo.SizeBytes = size
@@ -745,7 +1176,11 @@ func (d *driver) finishOutputCommit(pachClient *client.APIClient, commit *pfs.Co
 		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	d.pruneHeadBranches(pachClient, commitInfo.Commit)
+	return nil
 }
 
 // propagateCommit selectively starts commits in or downstream of 'branch' in
@@ -892,7 +1327,11 @@ nextSubvBranch:
 		}
 
 		// Set provenance and upstream subvenance (appendSubvenance needs
-		// newCommitInfo.ParentCommit to extend the correct subvenance range)
+		// newCommitInfo.ParentCommit to extend the correct subvenance range).
+		// Also propagate the labels of the provenant commits, except for any
+		// that they disagree on
+		labels := make(map[string]string)
+		conflicts := make(map[string]bool)
 		for _, prov := range commitProvMap {
 			// set provenance of 'newCommit'
 			newCommitInfo.Provenance = append(newCommitInfo.Provenance, prov.commit)
@@ -906,6 +1345,18 @@ nextSubvBranch:
 			}); err != nil {
 				return err
 			}
+			for key, val := range provCommitInfo.Labels {
+				if prev, ok := labels[key]; ok && prev != val {
+					conflicts[key] = true
+				}
+				labels[key] = val
+			}
+		}
+		for key := range conflicts {
+			delete(labels, key)
+		}
+		if len(labels) > 0 {
+			newCommitInfo.Labels = labels
 		}
 
 		// finally create open 'commit'
@@ -1054,9 +1505,9 @@ func (d *driver) resolveCommit(stm col.STM, userCommit *pfs.Commit) (*pfs.Commit
 	return commitInfo, nil
 }
 
-func (d *driver) listCommit(pachClient *client.APIClient, repo *pfs.Repo, to *pfs.Commit, from *pfs.Commit, number uint64) ([]*pfs.CommitInfo, error) {
+func (d *driver) listCommit(pachClient *client.APIClient, repo *pfs.Repo, to *pfs.Commit, from *pfs.Commit, number uint64, labelSelector string) ([]*pfs.CommitInfo, error) {
 	var result []*pfs.CommitInfo
-	if err := d.listCommitF(pachClient, repo, to, from, number, func(ci *pfs.CommitInfo) error {
+	if err := d.listCommitF(pachClient, repo, to, from, number, labelSelector, func(ci *pfs.CommitInfo) error {
 		result = append(result, ci)
 		return nil
 	}); err != nil {
@@ -1065,7 +1516,7 @@ func (d *driver) listCommit(pachClient *client.APIClient, repo *pfs.Repo, to *pf
 	return result, nil
 }
 
-func (d *driver) listCommitF(pachClient *client.APIClient, repo *pfs.Repo, to *pfs.Commit, from *pfs.Commit, number uint64, f func(*pfs.CommitInfo) error) error {
+func (d *driver) listCommitF(pachClient *client.APIClient, repo *pfs.Repo, to *pfs.Commit, from *pfs.Commit, number uint64, labelSelector string, f func(*pfs.CommitInfo) error) error {
 	ctx := pachClient.Ctx()
 	if err := d.checkIsAuthorized(pachClient, repo, auth.Scope_READER); err != nil {
 		return err
@@ -1073,9 +1524,13 @@ func (d *driver) listCommitF(pachClient *client.APIClient, repo *pfs.Repo, to *p
 	if from != nil && from.Repo.Name != repo.Name || to != nil && to.Repo.Name != repo.Name {
 		return fmt.Errorf("`from` and `to` commits need to be from repo %s", repo.Name)
 	}
+	selector, err := k8slabels.Parse(labelSelector)
+	if err != nil {
+		return fmt.Errorf("invalid label selector %q: %v", labelSelector, err)
+	}
 
 	// Make sure that the repo exists
-	_, err := d.inspectRepo(pachClient, repo, !includeAuth)
+	_, err = d.inspectRepo(pachClient, repo, !includeAuth)
 	if err != nil {
 		return err
 	}
@@ -1109,13 +1564,23 @@ func (d *driver) listCommitF(pachClient *client.APIClient, repo *pfs.Repo, to *p
 	} else if from == nil && to == nil {
 		// if neither from and to is given, we list all commits in
 		// the repo, sorted by revision timestamp
-		if err := commits.List(ci, col.DefaultOptions, func(commitID string) error {
+		listF := func(commitID string) error {
+			if !selector.Matches(k8slabels.Set(ci.Labels)) {
+				return nil
+			}
 			if number <= 0 {
 				return errutil.ErrBreak
 			}
 			number--
 			return f(proto.Clone(ci).(*pfs.CommitInfo))
-		}); err != nil {
+		}
+		// if the selector requires a particular label, only the commits with
+		// that label need to be read
+		if indexVal, ok := labelIndexVal(selector); ok {
+			if err := commits.GetByIndex(pfsdb.LabelIndex, indexVal, ci, col.DefaultOptions, listF); err != nil {
+				return err
+			}
+		} else if err := commits.List(ci, col.DefaultOptions, listF); err != nil {
 			return err
 		}
 	} else {
@@ -1125,19 +1590,38 @@ func (d *driver) listCommitF(pachClient *client.APIClient, repo *pfs.Repo, to *p
 			if err := commits.Get(cursor.ID, &commitInfo); err != nil {
 				return err
 			}
+			cursor = commitInfo.ParentCommit
+			if !selector.Matches(k8slabels.Set(commitInfo.Labels)) {
+				continue
+			}
 			if err := f(&commitInfo); err != nil {
 				if err == errutil.ErrBreak {
 					return nil
 				}
 				return err
 			}
-			cursor = commitInfo.ParentCommit
 			number--
 		}
 	}
 	return nil
 }
 
+// labelIndexVal returns the value of pfsdb.LabelIndex under which all of the
+// commits matching 'selector' are indexed, if 'selector' requires some label
+// to have a single specific value
+func labelIndexVal(selector k8slabels.Selector) (string, bool) {
+	requirements, _ := selector.Requirements()
+	for _, r := range requirements {
+		switch r.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			if values := r.Values(); values.Len() == 1 {
+				return col.MapIndexVal(r.Key(), values.List()[0]), true
+			}
+		}
+	}
+	return "", false
+}
+
 func (d *driver) subscribeCommit(pachClient *client.APIClient, repo *pfs.Repo, branch string, from *pfs.Commit, state pfs.CommitState, f func(*pfs.CommitInfo) error) error {
 	if from != nil && from.Repo.Name != repo.Name {
 		return fmt.Errorf("the `from` commit needs to be from repo %s", repo.Name)
@@ -1152,7 +1636,7 @@ func (d *driver) subscribeCommit(pachClient *client.APIClient, repo *pfs.Repo, b
 	// keep track of the commits that have been sent
 	seen := make(map[string]bool)
 	// include all commits that are currently on the given branch,
-	commitInfos, err := d.listCommit(pachClient, repo, client.NewCommit(repo.Name, branch), from, 0)
+	commitInfos, err := d.listCommit(pachClient, repo, client.NewCommit(repo.Name, branch), from, 0, "")
 	if err != nil {
 		// We skip NotFound error because it's ok if the branch
 		// doesn't exist yet, in which case ListCommit returns
@@ -1276,7 +1760,7 @@ func (d *driver) flushCommit(pachClient *client.APIClient, fromCommits []*pfs.Co
 
 func (d *driver) deleteCommit(pachClient *client.APIClient, userCommit *pfs.Commit) error {
 	ctx := pachClient.Ctx()
-	if err := d.checkIsAuthorized(pachClient, userCommit.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, userCommit, auth.Scope_WRITER); err != nil {
 		return err
 	}
 	// Main txn: Delete all downstream commits, and update subvenance of upstream commits
@@ -1351,157 +1835,12 @@ func (d *driver) deleteCommit(pachClient *client.APIClient, userCommit *pfs.Comm
 			deleteCommit(subv.Lower, subv.Upper)
 		}
 
-		// 5) Remove the commits in 'deleted' from all remaining upstream commits'
-		// subvenance.
-		// While 'commit' is required to be an input commit (no provenance),
-		// downstream commits from 'commit' may have multiple inputs, and those
-		// other inputs must have their subvenance updated
-		visited := make(map[string]bool) // visitied upstream (provenant) commits
-		for _, deletedInfo := range deleted {
-			for _, provCommit := range deletedInfo.Provenance {
-				// Check if we've fixed provCommit already (or if it's deleted and
-				// doesn't need to be fixed
-				if _, isDeleted := deleted[provCommit.ID]; isDeleted || visited[provCommit.ID] {
-					continue
-				}
-				visited[provCommit.ID] = true
-
-				// fix provCommit's subvenance
-				provCI := &pfs.CommitInfo{}
-				if err := d.commits(provCommit.Repo.Name).ReadWrite(stm).Update(provCommit.ID, provCI, func() error {
-					subvTo := 0 // copy subvFrom to subvTo, excepting subv ranges to delete (so that they're overwritten)
-				nextSubvRange:
-					for subvFrom, subv := range provCI.Subvenance {
-						// Compute path (of commit IDs) connecting subv.Upper to subv.Lower
-						cur := subv.Upper.ID
-						path := []string{cur}
-						for cur != subv.Lower.ID {
-							// Get CommitInfo for 'cur' (either in 'deleted' or from etcd)
-							// and traverse parent
-							curInfo, ok := deleted[cur]
-							if !ok {
-								curInfo = &pfs.CommitInfo{}
-								if err := d.commits(subv.Lower.Repo.Name).ReadWrite(stm).Get(cur, curInfo); err != nil {
-									return fmt.Errorf("error reading commitInfo for subvenant \"%s/%s\": %v", subv.Lower.Repo.Name, cur, err)
-								}
-							}
-							if curInfo.ParentCommit == nil {
-								break
-							}
-							cur = curInfo.ParentCommit.ID
-							path = append(path, cur)
-						}
-
-						// move 'subv.Upper' through parents until it points to a non-deleted commit
-						for j := range path {
-							if _, ok := deleted[subv.Upper.ID]; !ok {
-								break
-							}
-							if j+1 >= len(path) {
-								// All commits in subvRange are deleted. Remove entire Range
-								// from provCI.Subvenance
-								continue nextSubvRange
-							}
-							subv.Upper.ID = path[j+1]
-						}
-
-						// move 'subv.Lower' through children until it points to a non-deleted commit
-						for j := len(path) - 1; j >= 0; j-- {
-							if _, ok := deleted[subv.Lower.ID]; !ok {
-								break
-							}
-							// We'll eventually get to a non-deleted commit because the
-							// 'upper' block didn't exit
-							subv.Lower.ID = path[j-1]
-						}
-						provCI.Subvenance[subvTo] = provCI.Subvenance[subvFrom]
-						subvTo++
-					}
-					provCI.Subvenance = provCI.Subvenance[:subvTo]
-					return nil
-				}); err != nil {
-					return fmt.Errorf("err fixing subvenance of upstream commit %s/%s: %v", provCommit.Repo.Name, provCommit.ID, err)
-				}
-			}
-		}
-
-		// 6) Rewrite ParentCommit of deleted commits' children, and
-		// ChildCommits of deleted commits' parents
-		visited = make(map[string]bool) // visited child/parent commits
-		for deletedID, deletedInfo := range deleted {
-			if visited[deletedID] {
-				continue
-			}
-
-			// Traverse downwards until we find the lowest (most ancestral)
-			// non-nil, deleted commit
-			lowestCommitInfo := deletedInfo
-			for {
-				if lowestCommitInfo.ParentCommit == nil {
-					break // parent is nil
-				}
-				parentInfo, ok := deleted[lowestCommitInfo.ParentCommit.ID]
-				if !ok {
-					break // parent is not deleted
-				}
-				lowestCommitInfo = parentInfo // parent exists and is deleted--go down
-			}
-
-			// BFS upwards through graph for all non-deleted children
-			var next *pfs.Commit                            // next vertex to search
-			queue := []*pfs.Commit{lowestCommitInfo.Commit} // queue of vertices to explore
-			liveChildren := make(map[string]struct{})       // live children discovered so far
-			for len(queue) > 0 {
-				next, queue = queue[0], queue[1:]
-				if visited[next.ID] {
-					continue
-				}
-				visited[next.ID] = true
-				nextInfo, ok := deleted[next.ID]
-				if !ok {
-					liveChildren[next.ID] = struct{}{}
-					continue
-				}
-				queue = append(queue, nextInfo.ChildCommits...)
-			}
-
-			// Point all non-deleted children at the first valid parent (or nil),
-			// and point first non-deleted parent at all non-deleted children
-			commits := d.commits(deletedInfo.Commit.Repo.Name).ReadWrite(stm)
-			parent := lowestCommitInfo.ParentCommit
-			for child := range liveChildren {
-				commitInfo := &pfs.CommitInfo{}
-				if err := commits.Update(child, commitInfo, func() error {
-					commitInfo.ParentCommit = parent
-					return nil
-				}); err != nil {
-					return fmt.Errorf("err updating child commit %v: %v", lowestCommitInfo.Commit, err)
-				}
-			}
-			if parent != nil {
-				commitInfo := &pfs.CommitInfo{}
-				if err := commits.Update(parent.ID, commitInfo, func() error {
-					// Add existing live commits in commitInfo.ChildCommits to the
-					// live children above lowestCommitInfo, then put them all in
-					// 'parent'
-					for _, child := range commitInfo.ChildCommits {
-						if _, ok := deleted[child.ID]; ok {
-							continue
-						}
-						liveChildren[child.ID] = struct{}{}
-					}
-					commitInfo.ChildCommits = make([]*pfs.Commit, 0, len(liveChildren))
-					for child := range liveChildren {
-						commitInfo.ChildCommits = append(commitInfo.ChildCommits, client.NewCommit(parent.Repo.Name, child))
-					}
-					return nil
-				}); err != nil {
-					return fmt.Errorf("err rewriting children of ancestor commit %v: %v", lowestCommitInfo.Commit, err)
-				}
-			}
+		// 5) Remove the commits in 'deleted' from the rest of the commit graph
+		if err := d.unlinkDeletedCommits(stm, deleted); err != nil {
+			return err
 		}
 
-		// 7) Traverse affected repos and rewrite all branches so that no branch
+		// 6) Traverse affected repos and rewrite all branches so that no branch
 		// points to a deleted commit
 		var shortestBranch *pfs.Branch
 		var shortestBranchLen = maxInt
@@ -1537,23 +1876,486 @@ func (d *driver) deleteCommit(pachClient *client.APIClient, userCommit *pfs.Comm
 				}
 			}
 		}
-
-		// 8) propagate the changes to 'branch' and its subvenance. This may start
-		// new HEAD commits downstream, if the new branch heads haven't been
-		// processed yet
-		return d.propagateCommit(stm, shortestBranch)
-	}); err != nil {
-		return fmt.Errorf("error rewriting commit graph: %v", err)
-	}
-
-	// Delete the scratch space for this commit
-	// TODO put scratch spaces in a collection and do this in the txn above
-	if deleteScratch {
-		if _, err := d.etcdClient.Delete(ctx, d.scratchCommitPrefix(userCommit), etcd.WithPrefix()); err != nil {
-			return err
+
+		// 7) propagate the changes to 'branch' and its subvenance. This may start
+		// new HEAD commits downstream, if the new branch heads haven't been
+		// processed yet
+		return d.propagateCommit(stm, shortestBranch)
+	}); err != nil {
+		return fmt.Errorf("error rewriting commit graph: %v", err)
+	}
+
+	// Delete the scratch space for this commit
+	// TODO put scratch spaces in a collection and do this in the txn above
+	if deleteScratch {
+		if _, err := d.etcdClient.Delete(ctx, d.scratchCommitPrefix(userCommit), etcd.WithPrefix()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unlinkDeletedCommits removes the commits in 'deleted', which have already
+// been deleted from etcd, from the rest of the commit graph, by removing them
+// from the subvenance of their provenance and by pointing their children at
+// their closest remaining ancestors
+func (d *driver) unlinkDeletedCommits(stm col.STM, deleted map[string]*pfs.CommitInfo) error {
+	// 1) Remove the commits in 'deleted' from all remaining upstream commits'
+	// subvenance.
+	// Deleted commits may have multiple inputs, and the inputs that weren't
+	// deleted must have their subvenance updated
+	visited := make(map[string]bool) // visitied upstream (provenant) commits
+	for _, deletedInfo := range deleted {
+		for _, provCommit := range deletedInfo.Provenance {
+			// Check if we've fixed provCommit already (or if it's deleted and
+			// doesn't need to be fixed
+			if _, isDeleted := deleted[provCommit.ID]; isDeleted || visited[provCommit.ID] {
+				continue
+			}
+			visited[provCommit.ID] = true
+
+			// fix provCommit's subvenance
+			provCI := &pfs.CommitInfo{}
+			if err := d.commits(provCommit.Repo.Name).ReadWrite(stm).Update(provCommit.ID, provCI, func() error {
+				subvTo := 0 // copy subvFrom to subvTo, excepting subv ranges to delete (so that they're overwritten)
+			nextSubvRange:
+				for subvFrom, subv := range provCI.Subvenance {
+					// Compute path (of commit IDs) connecting subv.Upper to subv.Lower
+					cur := subv.Upper.ID
+					path := []string{cur}
+					for cur != subv.Lower.ID {
+						// Get CommitInfo for 'cur' (either in 'deleted' or from etcd)
+						// and traverse parent
+						curInfo, ok := deleted[cur]
+						if !ok {
+							curInfo = &pfs.CommitInfo{}
+							if err := d.commits(subv.Lower.Repo.Name).ReadWrite(stm).Get(cur, curInfo); err != nil {
+								return fmt.Errorf("error reading commitInfo for subvenant \"%s/%s\": %v", subv.Lower.Repo.Name, cur, err)
+							}
+						}
+						if curInfo.ParentCommit == nil {
+							break
+						}
+						cur = curInfo.ParentCommit.ID
+						path = append(path, cur)
+					}
+
+					// move 'subv.Upper' through parents until it points to a non-deleted commit
+					for j := range path {
+						if _, ok := deleted[subv.Upper.ID]; !ok {
+							break
+						}
+						if j+1 >= len(path) {
+							// All commits in subvRange are deleted. Remove entire Range
+							// from provCI.Subvenance
+							continue nextSubvRange
+						}
+						subv.Upper.ID = path[j+1]
+					}
+
+					// move 'subv.Lower' through children until it points to a non-deleted commit
+					for j := len(path) - 1; j >= 0; j-- {
+						if _, ok := deleted[subv.Lower.ID]; !ok {
+							break
+						}
+						// We'll eventually get to a non-deleted commit because the
+						// 'upper' block didn't exit
+						subv.Lower.ID = path[j-1]
+					}
+					provCI.Subvenance[subvTo] = provCI.Subvenance[subvFrom]
+					subvTo++
+				}
+				provCI.Subvenance = provCI.Subvenance[:subvTo]
+				return nil
+			}); err != nil {
+				return fmt.Errorf("err fixing subvenance of upstream commit %s/%s: %v", provCommit.Repo.Name, provCommit.ID, err)
+			}
+		}
+	}
+
+	// 2) Rewrite ParentCommit of deleted commits' children, and
+	// ChildCommits of deleted commits' parents
+	visited = make(map[string]bool) // visited child/parent commits
+	for deletedID, deletedInfo := range deleted {
+		if visited[deletedID] {
+			continue
+		}
+
+		// Traverse downwards until we find the lowest (most ancestral)
+		// non-nil, deleted commit
+		lowestCommitInfo := deletedInfo
+		for {
+			if lowestCommitInfo.ParentCommit == nil {
+				break // parent is nil
+			}
+			parentInfo, ok := deleted[lowestCommitInfo.ParentCommit.ID]
+			if !ok {
+				break // parent is not deleted
+			}
+			lowestCommitInfo = parentInfo // parent exists and is deleted--go down
+		}
+
+		// BFS upwards through graph for all non-deleted children
+		var next *pfs.Commit                            // next vertex to search
+		queue := []*pfs.Commit{lowestCommitInfo.Commit} // queue of vertices to explore
+		liveChildren := make(map[string]struct{})       // live children discovered so far
+		for len(queue) > 0 {
+			next, queue = queue[0], queue[1:]
+			if visited[next.ID] {
+				continue
+			}
+			visited[next.ID] = true
+			nextInfo, ok := deleted[next.ID]
+			if !ok {
+				liveChildren[next.ID] = struct{}{}
+				continue
+			}
+			queue = append(queue, nextInfo.ChildCommits...)
+		}
+
+		// Point all non-deleted children at the first valid parent (or nil),
+		// and point first non-deleted parent at all non-deleted children
+		commits := d.commits(deletedInfo.Commit.Repo.Name).ReadWrite(stm)
+		parent := lowestCommitInfo.ParentCommit
+		for child := range liveChildren {
+			commitInfo := &pfs.CommitInfo{}
+			if err := commits.Update(child, commitInfo, func() error {
+				commitInfo.ParentCommit = parent
+				return nil
+			}); err != nil {
+				return fmt.Errorf("err updating child commit %v: %v", lowestCommitInfo.Commit, err)
+			}
+		}
+		if parent != nil {
+			commitInfo := &pfs.CommitInfo{}
+			if err := commits.Update(parent.ID, commitInfo, func() error {
+				// Add existing live commits in commitInfo.ChildCommits to the
+				// live children above lowestCommitInfo, then put them all in
+				// 'parent'
+				for _, child := range commitInfo.ChildCommits {
+					if _, ok := deleted[child.ID]; ok {
+						continue
+					}
+					liveChildren[child.ID] = struct{}{}
+				}
+				commitInfo.ChildCommits = make([]*pfs.Commit, 0, len(liveChildren))
+				for child := range liveChildren {
+					commitInfo.ChildCommits = append(commitInfo.ChildCommits, client.NewCommit(parent.Repo.Name, child))
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("err rewriting children of ancestor commit %v: %v", lowestCommitInfo.Commit, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setMaxHistoryDepth sets the number of commits kept in 'branch's history (see
+// pfs.BranchInfo.MaxHistoryDepth), and deletes the commits that are now beyond
+// it.
+func (d *driver) setMaxHistoryDepth(pachClient *client.APIClient, branch *pfs.Branch, depth int64) error {
+	if err := d.checkIsAuthorizedInCommit(pachClient, client.NewCommit(branch.Repo.Name, branch.Name), auth.Scope_WRITER); err != nil {
+		return err
+	}
+	if depth < 0 {
+		return fmt.Errorf("max history depth must be non-negative")
+	}
+	if _, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
+		branchInfo := &pfs.BranchInfo{}
+		return d.branches(branch.Repo.Name).ReadWrite(stm).Update(branch.Name, branchInfo, func() error {
+			branchInfo.MaxHistoryDepth = depth
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	return d.pruneBranch(pachClient, branch)
+}
+
+// pruneHeadBranches prunes the history of the branches whose head is 'commit'
+// and that have a MaxHistoryDepth, after 'commit' is finished. Errors are
+// logged rather than returned, as 'commit' itself has been finished already.
+func (d *driver) pruneHeadBranches(pachClient *client.APIClient, commit *pfs.Commit) {
+	var toPrune []*pfs.Branch
+	branchInfo := &pfs.BranchInfo{}
+	if err := d.branches(commit.Repo.Name).ReadOnly(pachClient.Ctx()).List(branchInfo, col.DefaultOptions, func(string) error {
+		if branchInfo.MaxHistoryDepth > 0 && branchInfo.Head != nil && branchInfo.Head.ID == commit.ID {
+			toPrune = append(toPrune, branchInfo.Branch)
+		}
+		return nil
+	}); err != nil {
+		logrus.Errorf("could not list the branches of %s to prune: %v", commit.Repo.Name, err)
+		return
+	}
+	for _, branch := range toPrune {
+		if err := d.pruneBranch(pachClient, branch); err != nil {
+			logrus.Errorf("could not prune the history of %s@%s: %v", branch.Repo.Name, branch.Name, err)
+		}
+	}
+}
+
+// pruneBranch deletes the commits in 'branch's history beyond its
+// MaxHistoryDepth. Commits that are still needed (because they're open, are
+// the head of a branch, or have downstream commits) are kept. A commit with
+// downstream commits is kept until those are deleted (e.g. by pruning the
+// downstream branches' own histories), so that pruning an input repo never
+// deletes a pipeline's output commits. Each job's output commit is downstream
+// of the job's input commits, so this also keeps the commits that jobs
+// (finished or not) refer to. Apart from the branch's head, commits whose
+// downstream commits are still open (i.e. that downstream pipelines haven't
+// finished processing) don't count towards the depth, so that a slow pipeline
+// doesn't push the commits it has processed out of the history. Each commit's
+// tree contains all of its files, so deleting a commit's ancestors doesn't
+// change its content.
+//
+// The history is read outside of a transaction, and the commits to prune are
+// then deleted in batches of pruneBatchSize, each in its own transaction (in
+// which they're checked again), so that pruning a long history doesn't exceed
+// etcd's limit on the number of operations in a transaction.
+func (d *driver) pruneBranch(pachClient *client.APIClient, branch *pfs.Branch) error {
+	toPrune, err := d.commitsToPrune(pachClient, branch)
+	if err != nil {
+		return err
+	}
+	for len(toPrune) > 0 {
+		batch := toPrune
+		if len(batch) > pruneBatchSize {
+			batch = batch[:pruneBatchSize]
+		}
+		toPrune = toPrune[len(batch):]
+		if _, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
+			return d.deletePrunedCommits(stm, batch)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitsToPrune returns the commits in 'branch's history beyond its
+// MaxHistoryDepth that may be deleted (see pruneBranch)
+func (d *driver) commitsToPrune(pachClient *client.APIClient, branch *pfs.Branch) ([]*pfs.Commit, error) {
+	branchInfo := &pfs.BranchInfo{}
+	if err := d.branches(branch.Repo.Name).ReadOnly(pachClient.Ctx()).Get(branch.Name, branchInfo); err != nil {
+		return nil, err
+	}
+	if branchInfo.MaxHistoryDepth == 0 || branchInfo.Head == nil {
+		return nil, nil
+	}
+	readOnlyCommits := func(repo string) commitGetter {
+		return d.commits(repo).ReadOnly(pachClient.Ctx())
+	}
+	var result []*pfs.Commit
+	depth := int64(0)
+	for commit := branchInfo.Head; commit != nil; {
+		commitInfo := &pfs.CommitInfo{}
+		if err := readOnlyCommits(branch.Repo.Name).Get(commit.ID, commitInfo); err != nil {
+			return nil, err
+		}
+		commit = commitInfo.ParentCommit
+		withDownstream, err := d.withDownstream(readOnlyCommits, commitInfo)
+		if err != nil {
+			return nil, err
+		}
+		if commitInfo.Commit.ID != branchInfo.Head.ID && hasOpenCommit(withDownstream[1:]) {
+			continue
+		}
+		depth++
+		if depth <= branchInfo.MaxHistoryDepth || commitInfo.Finished == nil || len(commitInfo.Subvenance) > 0 {
+			continue
+		}
+		result = append(result, commitInfo.Commit)
+	}
+	return result, nil
+}
+
+// deletePrunedCommits deletes the commits in 'toPrune', except for those
+// that are still needed (because they're open, are the head of a branch, or
+// have downstream commits)
+func (d *driver) deletePrunedCommits(stm col.STM, toPrune []*pfs.Commit) error {
+	isHead := d.headChecker(stm)
+	deleted := make(map[string]*pfs.CommitInfo)
+	for _, commit := range toPrune {
+		commitInfo := &pfs.CommitInfo{}
+		if err := d.commits(commit.Repo.Name).ReadWrite(stm).Get(commit.ID, commitInfo); err != nil {
+			if col.IsErrNotFound(err) {
+				// The commit was deleted since the history was read
+				continue
+			}
+			return err
+		}
+		if commitInfo.Finished == nil || len(commitInfo.Subvenance) > 0 {
+			continue
+		}
+		head, err := isHead(commitInfo.Commit)
+		if err != nil {
+			return err
+		}
+		if head {
+			continue
+		}
+		deleted[commitInfo.Commit.ID] = commitInfo
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+	// Unlike deleteCommit, this doesn't reduce the repos' sizes, as the
+	// remaining commits still contain the deleted commits' files
+	for _, ci := range deleted {
+		if err := d.commits(ci.Commit.Repo.Name).ReadWrite(stm).Delete(ci.Commit.ID); err != nil {
+			return err
+		}
+	}
+	return d.unlinkDeletedCommits(stm, deleted)
+}
+
+// branchHeads returns the IDs of the commits in 'repo' that are the head of
+// one of its branches.
+func (d *driver) branchHeads(stm col.STM, repo string) (map[string]bool, error) {
+	repoInfo := &pfs.RepoInfo{}
+	if err := d.repos.ReadWrite(stm).Get(repo, repoInfo); err != nil {
+		return nil, err
+	}
+	heads := make(map[string]bool)
+	for _, branch := range repoInfo.Branches {
+		branchInfo := &pfs.BranchInfo{}
+		if err := d.branches(branch.Repo.Name).ReadWrite(stm).Get(branch.Name, branchInfo); err != nil {
+			if col.IsErrNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if branchInfo.Head != nil {
+			heads[branchInfo.Head.ID] = true
+		}
+	}
+	return heads, nil
+}
+
+// headChecker returns a function that reports whether a commit is the head of
+// a branch, reading each repo's branch heads from 'stm' when first needed
+func (d *driver) headChecker(stm col.STM) func(commit *pfs.Commit) (bool, error) {
+	heads := make(map[string]map[string]bool)
+	return func(commit *pfs.Commit) (bool, error) {
+		if _, ok := heads[commit.Repo.Name]; !ok {
+			repoHeads, err := d.branchHeads(stm, commit.Repo.Name)
+			if err != nil {
+				return false, err
+			}
+			heads[commit.Repo.Name] = repoHeads
+		}
+		return heads[commit.Repo.Name][commit.ID], nil
+	}
+}
+
+// commitGetter reads commit infos, either in an STM (a ReadWriteCollection)
+// or outside of one (a ReadonlyCollection)
+type commitGetter interface {
+	Get(key string, val proto.Message) error
+}
+
+// withDownstream returns 'commitInfo' and the infos of its downstream
+// commits, which are read from the collections returned by 'commits'
+func (d *driver) withDownstream(commits func(repo string) commitGetter, commitInfo *pfs.CommitInfo) ([]*pfs.CommitInfo, error) {
+	result := []*pfs.CommitInfo{commitInfo}
+	for _, subv := range commitInfo.Subvenance {
+		subvCommits := commits(subv.Lower.Repo.Name)
+		for commit := subv.Upper; ; {
+			subvInfo := &pfs.CommitInfo{}
+			if err := subvCommits.Get(commit.ID, subvInfo); err != nil {
+				return nil, err
+			}
+			result = append(result, subvInfo)
+			if commit.ID == subv.Lower.ID || subvInfo.ParentCommit == nil {
+				break
+			}
+			commit = subvInfo.ParentCommit
+		}
+	}
+	return result, nil
+}
+
+// hasOpenCommit returns true if any of 'commitInfos' is open
+func hasOpenCommit(commitInfos []*pfs.CommitInfo) bool {
+	for _, ci := range commitInfos {
+		if ci.Finished == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// squashCommit deletes the ancestors of 'userCommit', along with their
+// downstream commits, so that the history of 'userCommit' starts with it. An
+// ancestor is kept if it, or any of its downstream commits, is open (e.g.
+// because a downstream job is still processing it) or is the head of a
+// branch, but its own ancestors may still be deleted. Unlike pruneBranch,
+// which keeps commits that have downstream commits, squashing is requested
+// explicitly, so it deletes them. Each commit's tree contains all of its files, so this
+// doesn't change the content of 'userCommit'.
+func (d *driver) squashCommit(pachClient *client.APIClient, userCommit *pfs.Commit) error {
+	if err := d.checkIsAuthorizedInCommit(pachClient, userCommit, auth.Scope_WRITER); err != nil {
+		return err
+	}
+	_, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
+		userCommitInfo, err := d.resolveCommit(stm, userCommit)
+		if err != nil {
+			return err
+		}
+		isHead := d.headChecker(stm)
+		// squashable returns 'commitInfo' and its downstream commits, or nil if
+		// any of them must be kept
+		squashable := func(commitInfo *pfs.CommitInfo) ([]*pfs.CommitInfo, error) {
+			result, err := d.withDownstream(func(repo string) commitGetter {
+				return d.commits(repo).ReadWrite(stm)
+			}, commitInfo)
+			if err != nil {
+				return nil, err
+			}
+			for _, ci := range result {
+				if ci.Finished == nil {
+					return nil, nil
+				}
+				head, err := isHead(ci.Commit)
+				if err != nil {
+					return nil, err
+				}
+				if head {
+					return nil, nil
+				}
+			}
+			return result, nil
+		}
+
+		deleted := make(map[string]*pfs.CommitInfo)
+		commits := d.commits(userCommitInfo.Commit.Repo.Name).ReadWrite(stm)
+		for commit := userCommitInfo.ParentCommit; commit != nil; {
+			commitInfo := &pfs.CommitInfo{}
+			if err := commits.Get(commit.ID, commitInfo); err != nil {
+				return err
+			}
+			commit = commitInfo.ParentCommit
+			toDelete, err := squashable(commitInfo)
+			if err != nil {
+				return err
+			}
+			for _, ci := range toDelete {
+				deleted[ci.Commit.ID] = ci
+			}
+		}
+		if len(deleted) == 0 {
+			return nil
 		}
-	}
-	return nil
+		// As in pruneBranch, this doesn't reduce the repos' sizes
+		for _, ci := range deleted {
+			if err := d.commits(ci.Commit.Repo.Name).ReadWrite(stm).Delete(ci.Commit.ID); err != nil {
+				return err
+			}
+		}
+		return d.unlinkDeletedCommits(stm, deleted)
+	})
+	return err
 }
 
 // createBranch creates a new branch or updates an existing branch (must be one
@@ -1565,7 +2367,7 @@ func (d *driver) deleteCommit(pachClient *client.APIClient, userCommit *pfs.Comm
 // for 'branch' itself once 'b.Provenance' has been set.
 func (d *driver) createBranch(pachClient *client.APIClient, branch *pfs.Branch, commit *pfs.Commit, provenance []*pfs.Branch) error {
 	ctx := pachClient.Ctx()
-	if err := d.checkIsAuthorized(pachClient, branch.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, client.NewCommit(branch.Repo.Name, branch.Name), auth.Scope_WRITER); err != nil {
 		return err
 	}
 	// Validate request. The request must do exactly one of:
@@ -1680,6 +2482,117 @@ func (d *driver) createBranch(pachClient *client.APIClient, branch *pfs.Branch,
 	return err
 }
 
+// swapBranch atomically swaps the heads and direct provenance of 'branch' and
+// 'other', so that each takes the other's place (e.g. to promote a staging
+// branch to production). The full provenance of both branches, and of the
+// branches downstream of them, is recomputed in the same transaction, and
+// output commits are propagated once for the swap as a whole, so downstream
+// branches never see one of the branches swapped without the other.
+func (d *driver) swapBranch(pachClient *client.APIClient, branch *pfs.Branch, other *pfs.Branch) error {
+	// A branch's head must be a commit in the branch's repo
+	if branch.Repo.Name != other.Repo.Name {
+		return fmt.Errorf("cannot swap branches in different repos (%s and %s)", branchKey(branch), branchKey(other))
+	}
+	for _, b := range []*pfs.Branch{branch, other} {
+		if err := d.checkIsAuthorizedInCommit(pachClient, client.NewCommit(b.Repo.Name, b.Name), auth.Scope_WRITER); err != nil {
+			return err
+		}
+	}
+	if branchKey(branch) == branchKey(other) {
+		return fmt.Errorf("cannot swap branch %s with itself", branchKey(branch))
+	}
+	_, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
+		branchInfo := &pfs.BranchInfo{}
+		if err := d.branches(branch.Repo.Name).ReadWrite(stm).Get(branch.Name, branchInfo); err != nil {
+			return err
+		}
+		otherInfo := &pfs.BranchInfo{}
+		if err := d.branches(other.Repo.Name).ReadWrite(stm).Get(other.Name, otherInfo); err != nil {
+			return err
+		}
+		// The provenance of both branches, and of every branch downstream of
+		// either, must be recomputed
+		var toUpdate []*pfs.Branch
+		for _, branches := range [][]*pfs.Branch{{branch, other}, branchInfo.Subvenance, otherInfo.Subvenance} {
+			for _, b := range branches {
+				add(&toUpdate, b)
+			}
+		}
+		branchInfo.Head, otherInfo.Head = otherInfo.Head, branchInfo.Head
+		branchInfo.DirectProvenance, otherInfo.DirectProvenance = otherInfo.DirectProvenance, branchInfo.DirectProvenance
+		for _, bi := range []*pfs.BranchInfo{branchInfo, otherInfo} {
+			if err := d.branches(bi.Branch.Repo.Name).ReadWrite(stm).Put(bi.Branch.Name, bi); err != nil {
+				return err
+			}
+		}
+		for _, b := range toUpdate {
+			var provenance []*pfs.Branch
+			if err := d.branchProvenance(stm, b, map[string]bool{}, &provenance); err != nil {
+				return fmt.Errorf("cannot swap %s and %s: %v", branchKey(branch), branchKey(other), err)
+			}
+			// Re-read 'b', as updating other branches' subvenance may have
+			// modified it
+			bi := &pfs.BranchInfo{}
+			if err := d.branches(b.Repo.Name).ReadWrite(stm).Get(b.Name, bi); err != nil {
+				return err
+			}
+			for _, oldProvBranch := range bi.Provenance {
+				if !has(&provenance, oldProvBranch) {
+					oldProvBranchInfo := &pfs.BranchInfo{}
+					if err := d.branches(oldProvBranch.Repo.Name).ReadWrite(stm).Update(oldProvBranch.Name, oldProvBranchInfo, func() error {
+						del(&oldProvBranchInfo.Subvenance, bi.Branch)
+						return nil
+					}); err != nil {
+						return err
+					}
+				}
+			}
+			bi.Provenance = nil
+			for _, provBranch := range provenance {
+				if err := d.addBranchProvenance(bi, provBranch, stm); err != nil {
+					return err
+				}
+			}
+			if err := d.branches(b.Repo.Name).ReadWrite(stm).Put(b.Name, bi); err != nil {
+				return err
+			}
+		}
+		// Propagating the swapped heads creates new output commits in the
+		// downstream branches, which are provenant on both new heads
+		if err := d.propagateCommit(stm, branch); err != nil {
+			return err
+		}
+		return d.propagateCommit(stm, other)
+	})
+	return err
+}
+
+// branchProvenance adds the full provenance of 'branch', computed from the
+// direct provenance of it and the branches upstream of it, to 'provenance'.
+// 'visiting' holds the branches whose provenance is being computed, which
+// would be part of a provenance cycle if they're reached again.
+func (d *driver) branchProvenance(stm col.STM, branch *pfs.Branch, visiting map[string]bool, provenance *[]*pfs.Branch) error {
+	branchInfo := &pfs.BranchInfo{}
+	if err := d.branches(branch.Repo.Name).ReadWrite(stm).Get(branch.Name, branchInfo); err != nil {
+		return err
+	}
+	visiting[branchKey(branch)] = true
+	defer delete(visiting, branchKey(branch))
+	for _, provBranch := range branchInfo.DirectProvenance {
+		if visiting[branchKey(provBranch)] {
+			return fmt.Errorf("branch %s would be in its own provenance", branchKey(provBranch))
+		}
+		if has(provenance, provBranch) {
+			continue // already computed
+		}
+		add(provenance, provBranch)
+		if err := d.branchProvenance(stm, provBranch, visiting, provenance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *driver) inspectBranch(pachClient *client.APIClient, branch *pfs.Branch) (*pfs.BranchInfo, error) {
 	result := &pfs.BranchInfo{}
 	if err := d.branches(branch.Repo.Name).ReadOnly(pachClient.Ctx()).Get(branch.Name, result); err != nil {
@@ -1704,8 +2617,80 @@ func (d *driver) listBranch(pachClient *client.APIClient, repo *pfs.Repo) ([]*pf
 	return result, nil
 }
 
+// listCommitBranches returns the branches whose head is 'commit'. If
+// 'history' is set, it also returns the branches whose head descends from
+// 'commit' or from a commit that's provenant on it (which may be in a
+// downstream repo).
+func (d *driver) listCommitBranches(pachClient *client.APIClient, commit *pfs.Commit, history bool) ([]*pfs.BranchInfo, error) {
+	ctx := pachClient.Ctx()
+	commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return nil, err
+	}
+	// referenced maps the ID of each commit that a returned branch may have as
+	// its head to the commit's repo
+	referenced := map[string]string{commitInfo.Commit.ID: commitInfo.Commit.Repo.Name}
+	if history {
+		// Add the commits that are provenant on 'commit'
+		for _, subvRange := range commitInfo.Subvenance {
+			commits := d.commits(subvRange.Upper.Repo.Name).ReadOnly(ctx)
+			for subvCommit := subvRange.Upper; subvCommit != nil; {
+				referenced[subvCommit.ID] = subvCommit.Repo.Name
+				if subvCommit.ID == subvRange.Lower.ID {
+					break
+				}
+				subvCommitInfo := &pfs.CommitInfo{}
+				if err := commits.Get(subvCommit.ID, subvCommitInfo); err != nil {
+					return nil, err
+				}
+				subvCommit = subvCommitInfo.ParentCommit
+			}
+		}
+		// Add the descendants of all of those commits
+		var queue []*pfs.Commit
+		for id, repo := range referenced {
+			queue = append(queue, client.NewCommit(repo, id))
+		}
+		for len(queue) > 0 {
+			ci := &pfs.CommitInfo{}
+			if err := d.commits(queue[0].Repo.Name).ReadOnly(ctx).Get(queue[0].ID, ci); err != nil {
+				return nil, err
+			}
+			queue = queue[1:]
+			for _, child := range ci.ChildCommits {
+				if _, ok := referenced[child.ID]; !ok {
+					referenced[child.ID] = child.Repo.Name
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+	repos := make(map[string]bool)
+	for _, repo := range referenced {
+		repos[repo] = true
+	}
+	var sortedRepos []string
+	for repo := range repos {
+		sortedRepos = append(sortedRepos, repo)
+	}
+	sort.Strings(sortedRepos)
+	var result []*pfs.BranchInfo
+	for _, repo := range sortedRepos {
+		branchInfos, err := d.listBranch(pachClient, client.NewRepo(repo))
+		if err != nil {
+			return nil, err
+		}
+		for _, branchInfo := range branchInfos {
+			if branchInfo.Head != nil && referenced[branchInfo.Head.ID] == repo {
+				result = append(result, branchInfo)
+			}
+		}
+	}
+	return result, nil
+}
+
 func (d *driver) deleteBranch(pachClient *client.APIClient, branch *pfs.Branch, force bool) error {
-	if err := d.checkIsAuthorized(pachClient, branch.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, client.NewCommit(branch.Repo.Name, branch.Name), auth.Scope_WRITER); err != nil {
 		return err
 	}
 	_, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
@@ -1789,30 +2774,12 @@ func (d *driver) putFiles(pachClient *client.APIClient, s *putFileServer) error
 		return err
 	}
 	commit := req.File.Commit
-	// oneOff is true if we're creating the commit as part of this put-file
-	oneOff := false
-	// inspectCommit will replace file.Commit.ID with an actual commit ID if
-	// it's a branch. So we want to save it first.
-	branch := ""
-	if !uuid.IsUUIDWithoutDashes(commit.ID) {
-		branch = commit.ID
-	}
-	commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
+	branch, oneOff, err := d.putFileCommit(pachClient, commit)
 	if err != nil {
-		if (!isNotFoundErr(err) && !isNoHeadErr(err)) || branch == "" {
-			return err
-		}
-		oneOff = true
-	}
-	if commitInfo != nil && commitInfo.Finished != nil {
-		if branch == "" {
-			return pfsserver.ErrCommitFinished{commit}
-		}
-		oneOff = true
+		return err
 	}
 
 	var files []*pfs.File
-	var putFilePaths []string
 	var putFileRecords []*pfs.PutFileRecords
 	var mu sync.Mutex
 	if err := forEachPutFile(s, func(req *pfs.PutFileRequest, r io.Reader) error {
@@ -1824,23 +2791,194 @@ func (d *driver) putFiles(pachClient *client.APIClient, s *putFileServer) error
 		mu.Lock()
 		defer mu.Unlock()
 		files = append(files, req.File)
-		putFilePaths = append(putFilePaths, req.File.Path)
 		putFileRecords = append(putFileRecords, records)
 		return nil
 	}); err != nil {
 		return err
 	}
+	return d.writePutFileRecords(pachClient, commit, branch, oneOff, files, putFileRecords)
+}
+
+// putFileCommit resolves 'commit', which files are being put in. If it's a
+// branch whose head is finished (or that has no head), oneOff is true, and
+// the files are put in a new commit on the branch, which is returned.
+func (d *driver) putFileCommit(pachClient *client.APIClient, commit *pfs.Commit) (branch string, oneOff bool, _ error) {
+	// inspectCommit will replace file.Commit.ID with an actual commit ID if
+	// it's a branch. So we want to save it first.
+	if !uuid.IsUUIDWithoutDashes(commit.ID) {
+		branch = commit.ID
+	}
+	commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		if (!isNotFoundErr(err) && !isNoHeadErr(err)) || branch == "" {
+			return "", false, err
+		}
+		oneOff = true
+	}
+	if commitInfo != nil && commitInfo.Finished != nil {
+		if branch == "" {
+			return "", false, pfsserver.ErrCommitFinished{commit}
+		}
+		oneOff = true
+	}
+	return branch, oneOff, nil
+}
+
+// writePutFileRecords writes the records of the files put in 'commit' (see
+// putFileCommit), where putFileRecords[i] are the records of files[i]
+func (d *driver) writePutFileRecords(pachClient *client.APIClient, commit *pfs.Commit, branch string, oneOff bool, files []*pfs.File, putFileRecords []*pfs.PutFileRecords) error {
 	if oneOff {
+		var putFilePaths []string
+		for _, file := range files {
+			putFilePaths = append(putFilePaths, file.Path)
+		}
 		// oneOff puts only work on branches, so we know branch != "". We pass
 		// a commit with no ID, that ID will be filled in with the head of
 		// branch (if it exists).
-		_, err := d.makeCommit(pachClient, "", client.NewCommit(commit.Repo.Name, ""), branch, nil, nil, putFilePaths, putFileRecords, "")
+		_, err := d.makeCommit(pachClient, "", client.NewCommit(commit.Repo.Name, ""), branch, nil, nil, putFilePaths, putFileRecords, "", nil)
 		return err
 	}
+	// Merge the records of each file before writing them, so that each file's
+	// records are written once, and write them in batches, concurrently.
+	var commits []*pfs.Commit
+	paths := make(map[string][]string) // commit ID -> paths, in order
+	merged := make(map[string]map[string]*pfs.PutFileRecords)
 	for i, file := range files {
-		if err := d.upsertPutFileRecords(pachClient, file, putFileRecords[i]); err != nil {
+		if merged[file.Commit.ID] == nil {
+			commits = append(commits, file.Commit)
+			merged[file.Commit.ID] = make(map[string]*pfs.PutFileRecords)
+		}
+		p := path.Join("/", file.Path)
+		records := merged[file.Commit.ID][p]
+		if records == nil {
+			records = &pfs.PutFileRecords{}
+			merged[file.Commit.ID][p] = records
+			paths[file.Commit.ID] = append(paths[file.Commit.ID], p)
+		}
+		mergePutFileRecords(records, putFileRecords[i])
+	}
+	var eg errgroup.Group
+	for _, commit := range commits {
+		commit, commitPaths := commit, paths[commit.ID]
+		for len(commitPaths) > 0 {
+			batchSize := putFileRecordsBatchSize
+			if len(commitPaths) < batchSize {
+				batchSize = len(commitPaths)
+			}
+			batchPaths := commitPaths[:batchSize]
+			batch := make([]*pfs.PutFileRecords, batchSize)
+			for i, p := range batchPaths {
+				batch[i] = merged[commit.ID][p]
+			}
+			commitPaths = commitPaths[batchSize:]
+			eg.Go(func() error {
+				return d.upsertPutFileRecordsBatch(pachClient, commit, batchPaths, batch)
+			})
+		}
+	}
+	return eg.Wait()
+}
+
+// uploadChunksTTL is how long (in seconds) the record of a chunk stored by
+// putFileChunk is kept, so that the records of uploads that are never
+// finished by putFileChunks are eventually deleted
+const uploadChunksTTL = 24 * 60 * 60
+
+// validateUploadID returns an error if 'uploadID' can't identify an upload
+func validateUploadID(uploadID string) error {
+	if uploadID == "" {
+		return fmt.Errorf("upload ID must be set")
+	}
+	if strings.Contains(uploadID, "/") {
+		return fmt.Errorf("upload ID %q must not contain \"/\"", uploadID)
+	}
+	return nil
+}
+
+// putFileChunk stores the content of 'r' as a chunk of 'file', to be written
+// to it by putFileChunks, and records the chunk in etcd as part of the upload
+// 'uploadID', so that putFileChunks can check that it was put in the file's
+// repo.
+func (d *driver) putFileChunk(pachClient *client.APIClient, file *pfs.File, uploadID string, r io.Reader) (*pfs.FileChunk, error) {
+	if err := d.checkIsAuthorizedInCommit(pachClient, file.Commit, auth.Scope_WRITER); err != nil {
+		return nil, err
+	}
+	if err := validateUploadID(uploadID); err != nil {
+		return nil, err
+	}
+	object, size, err := pachClient.PutObjectInScope(d.objectScope(file.Commit.Repo.Name), r)
+	if err != nil {
+		return nil, err
+	}
+	chunk := &pfs.FileChunk{SizeBytes: size, Object: object}
+	if _, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
+		return d.uploadChunks(file.Commit.Repo.Name, uploadID).ReadWrite(stm).PutTTL(object.Hash, chunk, uploadChunksTTL)
+	}); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+// putFileChunks writes 'chunks' (stored by putFileChunk, possibly
+// concurrently and in any order) to 'file', in the order of their offsets.
+// The chunks must cover the file's content exactly. The chunks' objects come
+// from the client, so each one is checked against the chunks that
+// putFileChunk recorded for the upload 'uploadID' in the file's repo,
+// otherwise a client that knew the hash of content in another repo could
+// copy it into this one. The upload's records are deleted once its chunks
+// have been written.
+func (d *driver) putFileChunks(pachClient *client.APIClient, file *pfs.File, uploadID string, chunks []*pfs.FileChunk, overwrite bool) error {
+	if err := d.checkIsAuthorizedInCommit(pachClient, file.Commit, auth.Scope_WRITER); err != nil {
+		return err
+	}
+	if err := hashtree.ValidatePath(file.Path); err != nil {
+		return err
+	}
+	if err := validateUploadID(uploadID); err != nil {
+		return err
+	}
+	uploadChunks := d.uploadChunks(file.Commit.Repo.Name, uploadID)
+	chunks = append([]*pfs.FileChunk(nil), chunks...)
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].OffsetBytes < chunks[j].OffsetBytes })
+	records := &pfs.PutFileRecords{Tombstone: overwrite}
+	var offset int64
+	for _, chunk := range chunks {
+		if chunk.Object == nil {
+			return fmt.Errorf("chunk at offset %d has no object", chunk.OffsetBytes)
+		}
+		if chunk.OffsetBytes != offset {
+			return fmt.Errorf("chunk at offset %d doesn't follow the previous chunk, which ends at offset %d", chunk.OffsetBytes, offset)
+		}
+		// Use the size of the stored chunk, rather than trusting the request
+		stored := &pfs.FileChunk{}
+		if err := uploadChunks.ReadOnly(pachClient.Ctx()).Get(chunk.Object.Hash, stored); err != nil {
+			if col.IsErrNotFound(err) {
+				return fmt.Errorf("object %s wasn't stored by PutFileChunk in upload %s of repo %s", chunk.Object.Hash, uploadID, file.Commit.Repo.Name)
+			}
 			return err
 		}
+		if stored.SizeBytes != chunk.SizeBytes {
+			return fmt.Errorf("chunk at offset %d has %d bytes, not %d", chunk.OffsetBytes, stored.SizeBytes, chunk.SizeBytes)
+		}
+		records.Records = append(records.Records, &pfs.PutFileRecord{
+			SizeBytes:  stored.SizeBytes,
+			ObjectHash: chunk.Object.Hash,
+		})
+		offset += stored.SizeBytes
+	}
+	branch, oneOff, err := d.putFileCommit(pachClient, file.Commit)
+	if err != nil {
+		return err
+	}
+	if err := d.writePutFileRecords(pachClient, file.Commit, branch, oneOff, []*pfs.File{file}, []*pfs.PutFileRecords{records}); err != nil {
+		return err
+	}
+	if _, err := col.NewSTM(pachClient.Ctx(), d.etcdClient, func(stm col.STM) error {
+		uploadChunks.ReadWrite(stm).DeleteAll()
+		return nil
+	}); err != nil {
+		// The records expire anyway, so the chunks' content is written
+		logrus.Errorf("could not delete the records of upload %s in repo %s: %v", uploadID, file.Commit.Repo.Name, err)
 	}
 	return nil
 }
@@ -1848,7 +2986,7 @@ func (d *driver) putFiles(pachClient *client.APIClient, s *putFileServer) error
 func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter pfs.Delimiter,
 	targetFileDatums, targetFileBytes, headerRecords int64, overwriteIndex *pfs.OverwriteIndex,
 	reader io.Reader) (*pfs.PutFileRecords, error) {
-	if err := d.checkIsAuthorized(pachClient, file.Commit.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, file.Commit, auth.Scope_WRITER); err != nil {
 		return nil, err
 	}
 	//  validation -- make sure the various putFileSplit options are coherent
@@ -1865,7 +3003,7 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 	}
 
 	if delimiter == pfs.Delimiter_NONE {
-		objects, size, err := pachClient.PutObjectSplit(reader)
+		objects, size, err := pachClient.PutObjectSplitInScope(d.objectScope(file.Commit.Repo.Name), reader)
 		if err != nil {
 			return nil, err
 		}
@@ -1989,7 +3127,7 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 					eg.Go(func() error {
 						defer putObjectLimiter.Release()
 						defer d.memoryLimiter.Release(_bufferLen)
-						object, size, err := pachClient.PutObject(_buffer)
+						object, size, err := pachClient.PutObjectInScope(d.objectScope(file.Commit.Repo.Name), _buffer)
 						if err != nil {
 							return err
 						}
@@ -2025,7 +3163,7 @@ func (d *driver) putFile(pachClient *client.APIClient, file *pfs.File, delimiter
 				putObjectLimiter.Acquire()
 				eg.Go(func() error {
 					defer putObjectLimiter.Release()
-					object, size, err := pachClient.PutObject(bytes.NewReader(value))
+					object, size, err := pachClient.PutObjectInScope(d.objectScope(file.Commit.Repo.Name), bytes.NewReader(value))
 					if err != nil {
 						return err
 					}
@@ -2110,7 +3248,7 @@ func (d *driver) copyFile(pachClient *client.APIClient, src *pfs.File, dst *pfs.
 	if err := d.checkIsAuthorized(pachClient, src.Commit.Repo, auth.Scope_READER); err != nil {
 		return err
 	}
-	if err := d.checkIsAuthorized(pachClient, dst.Commit.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, dst.Commit, auth.Scope_WRITER); err != nil {
 		return err
 	}
 	if err := hashtree.ValidatePath(dst.Path); err != nil {
@@ -2189,6 +3327,14 @@ func (d *driver) copyFile(pachClient *client.APIClient, src *pfs.File, dst *pfs.
 			}
 		}
 
+		// Content copied from another repo is stored again in the dedup scope
+		// of 'dst's repo
+		if src.Commit.Repo.Name != dst.Commit.Repo.Name {
+			if err := d.rescopeRecords(pachClient, dst.Commit.Repo.Name, record); err != nil {
+				return err
+			}
+		}
+
 		// Either upsert 'record' to etcd (if 'dst' is in an open commit) or add it
 		// to 'records' to be put at the end
 		if dstIsOpenCommit {
@@ -2208,7 +3354,7 @@ func (d *driver) copyFile(pachClient *client.APIClient, src *pfs.File, dst *pfs.
 	}
 	// dst is finished => all PutFileRecords are in 'records'--put in a new commit
 	if !dstIsOpenCommit {
-		_, err = d.makeCommit(pachClient, "", client.NewCommit(dst.Commit.Repo.Name, ""), branch, nil, nil, paths, records, "")
+		_, err = d.makeCommit(pachClient, "", client.NewCommit(dst.Commit.Repo.Name, ""), branch, nil, nil, paths, records, "", nil)
 		return err
 	}
 	return nil
@@ -2307,6 +3453,12 @@ func (d *driver) downloadTree(pachClient *client.APIClient, object *pfs.Object,
 	if err != nil {
 		return nil, err
 	}
+	if objClient, err = obj.NewLayoutClientFromEnv(objClient); err != nil {
+		return nil, err
+	}
+	if objClient, err = obj.NewCompressedClientFromEnv(objClient); err != nil {
+		return nil, err
+	}
 	info, err := pachClient.InspectObject(object.Hash)
 	if err != nil {
 		return nil, err
@@ -2577,6 +3729,8 @@ func nodeToFileInfo(ci *pfs.CommitInfo, path string, node *hashtree.NodeProto, f
 	}
 	if node.FileNode != nil {
 		fileInfo.FileType = pfs.FileType_FILE
+		fileInfo.Mode = node.FileNode.Mode
+		fileInfo.Owner = node.FileNode.Owner
 		if full {
 			fileInfo.Objects = node.FileNode.Objects
 			fileInfo.BlockRefs = node.FileNode.BlockRefs
@@ -2842,6 +3996,51 @@ func (d *driver) walkFile(pachClient *client.APIClient, file *pfs.File, f func(*
 	})
 }
 
+// getCommitTree calls 'f' with every file and directory in 'commit', in
+// order of path. Nodes' hashes are the same as their FileInfos'.
+func (d *driver) getCommitTree(pachClient *client.APIClient, commit *pfs.Commit, f func(*pfs.TreeNode) error) error {
+	if commit == nil || commit.Repo == nil {
+		return fmt.Errorf("must specify a commit")
+	}
+	return d.walkFile(pachClient, client.NewFile(commit.Repo.Name, commit.ID, "/"), func(fi *pfs.FileInfo) error {
+		return f(&pfs.TreeNode{
+			Path:      fi.File.Path,
+			FileType:  fi.FileType,
+			Hash:      fi.Hash,
+			SizeBytes: fi.SizeBytes,
+		})
+	})
+}
+
+// getManifest writes the manifest of the files under 'path' in 'commit' to
+// 'w': a pfs.ManifestEntry per file, as a line of JSON, in order of path.
+// Each file's content is read to compute its sha256 digest.
+func (d *driver) getManifest(pachClient *client.APIClient, commit *pfs.Commit, path string, w io.Writer) error {
+	if commit == nil || commit.Repo == nil {
+		return fmt.Errorf("must specify a commit")
+	}
+	encoder := json.NewEncoder(w)
+	return d.walkFile(pachClient, client.NewFile(commit.Repo.Name, commit.ID, path), func(fi *pfs.FileInfo) error {
+		if fi.FileType != pfs.FileType_FILE {
+			return nil
+		}
+		r, err := d.getFile(pachClient, fi.File, 0, 0)
+		if err != nil {
+			return err
+		}
+		hash := sha256.New()
+		if _, err := io.Copy(hash, r); err != nil {
+			return fmt.Errorf("error reading %s: %v", fi.File.Path, err)
+		}
+		return encoder.Encode(&pfs.ManifestEntry{
+			Path:      fi.File.Path,
+			SizeBytes: fi.SizeBytes,
+			SHA256:    hex.EncodeToString(hash.Sum(nil)),
+			NodeHash:  pfs.EncodeHash(fi.Hash),
+		})
+	})
+}
+
 func (d *driver) globFile(pachClient *client.APIClient, commit *pfs.Commit, pattern string, f func(*pfs.FileInfo) error) (retErr error) {
 	if err := d.checkIsAuthorized(pachClient, commit.Repo, auth.Scope_READER); err != nil {
 		return err
@@ -2957,7 +4156,7 @@ func (d *driver) diffFile(pachClient *client.APIClient, newFile *pfs.File, oldFi
 }
 
 func (d *driver) deleteFile(pachClient *client.APIClient, file *pfs.File) error {
-	if err := d.checkIsAuthorized(pachClient, file.Commit.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedInCommit(pachClient, file.Commit, auth.Scope_WRITER); err != nil {
 		return err
 	}
 	branch := ""
@@ -2972,7 +4171,7 @@ func (d *driver) deleteFile(pachClient *client.APIClient, file *pfs.File) error
 		if branch == "" {
 			return pfsserver.ErrCommitFinished{file.Commit}
 		}
-		_, err := d.makeCommit(pachClient, "", client.NewCommit(file.Commit.Repo.Name, ""), branch, nil, nil, []string{file.Path}, []*pfs.PutFileRecords{&pfs.PutFileRecords{Tombstone: true}}, "")
+		_, err := d.makeCommit(pachClient, "", client.NewCommit(file.Commit.Repo.Name, ""), branch, nil, nil, []string{file.Path}, []*pfs.PutFileRecords{&pfs.PutFileRecords{Tombstone: true}}, "", nil)
 		return err
 	}
 	return d.upsertPutFileRecords(pachClient, file, &pfs.PutFileRecords{Tombstone: true})
@@ -2998,45 +4197,65 @@ func (d *driver) deleteAll(pachClient *client.APIClient) error {
 // To check that a key exists in etcd, we assert that its CreateRevision
 // is greater than zero.
 func (d *driver) upsertPutFileRecords(pachClient *client.APIClient, file *pfs.File, newRecords *pfs.PutFileRecords) error {
-	prefix, err := d.scratchFilePrefix(file)
-	if err != nil {
-		return err
+	return d.upsertPutFileRecordsBatch(pachClient, file.Commit, []string{file.Path}, []*pfs.PutFileRecords{newRecords})
+}
+
+// upsertPutFileRecordsBatch merges newRecords[i] into the records of the file
+// at paths[i] in the open commit 'commit', in a single etcd transaction. The
+// paths must be distinct. Concurrent calls are limited by d.putFileLimiter.
+func (d *driver) upsertPutFileRecordsBatch(pachClient *client.APIClient, commit *pfs.Commit, paths []string, newRecords []*pfs.PutFileRecords) error {
+	prefixes := make([]string, len(paths))
+	for i, p := range paths {
+		prefix, err := d.scratchFilePrefix(client.NewFile(commit.Repo.Name, commit.ID, p))
+		if err != nil {
+			return err
+		}
+		prefixes[i] = prefix
 	}
 
+	d.putFileLimiter.Acquire()
+	defer d.putFileLimiter.Release()
 	ctx := pachClient.Ctx()
-	_, err = col.NewSTM(ctx, d.etcdClient, func(stm col.STM) error {
+	_, err := col.NewSTM(ctx, d.etcdClient, func(stm col.STM) error {
 		commitsCol := d.openCommits.ReadOnly(ctx)
-		var commit pfs.Commit
-		err := commitsCol.Get(file.Commit.ID, &commit)
+		var openCommit pfs.Commit
+		err := commitsCol.Get(commit.ID, &openCommit)
 		if err != nil {
 			return err
 		}
 		// Dumb check to make sure the unmarshalled value exists (and matches the current ID)
 		// to denote that the current commit is indeed open
-		if commit.ID != file.Commit.ID {
-			return fmt.Errorf("commit %v is not open", file.Commit.ID)
+		if openCommit.ID != commit.ID {
+			return fmt.Errorf("commit %v is not open", commit.ID)
 		}
 		recordsCol := d.putFileRecords.ReadWrite(stm)
-		var existingRecords pfs.PutFileRecords
-		return recordsCol.Upsert(prefix, &existingRecords, func() error {
-			if newRecords.Tombstone {
-				existingRecords.Tombstone = true
-				existingRecords.Records = nil
-			}
-			existingRecords.Split = newRecords.Split
-			existingRecords.Records = append(existingRecords.Records, newRecords.Records...)
-			existingRecords.Header = newRecords.Header
-			existingRecords.Footer = newRecords.Footer
-			return nil
-		})
+		for i, prefix := range prefixes {
+			var existingRecords pfs.PutFileRecords
+			if err := recordsCol.Upsert(prefix, &existingRecords, func() error {
+				mergePutFileRecords(&existingRecords, newRecords[i])
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
-	if err != nil {
-		return err
-	}
-
 	return err
 }
 
+// mergePutFileRecords appends the records of a put-file, 'newRecords', to
+// 'records', the records of earlier put-files to the same file
+func mergePutFileRecords(records *pfs.PutFileRecords, newRecords *pfs.PutFileRecords) {
+	if newRecords.Tombstone {
+		records.Tombstone = true
+		records.Records = nil
+	}
+	records.Split = newRecords.Split
+	records.Records = append(records.Records, newRecords.Records...)
+	records.Header = newRecords.Header
+	records.Footer = newRecords.Footer
+}
+
 func (d *driver) applyWrite(key string, records *pfs.PutFileRecords, tree hashtree.HashTree) error {
 	// a map that keeps track of the sizes of objects
 	sizeMap := make(map[string]int64)
@@ -3280,6 +4499,25 @@ func (r *putFileReader) Read(p []byte) (int, error) {
 	return r.buffer.Read(p)
 }
 
+// putFileChunkReader reads the content of a chunk from a PutFileChunk stream
+type putFileChunkReader struct {
+	server pfs.API_PutFileChunkServer
+	buffer bytes.Buffer
+}
+
+func (r *putFileChunkReader) Read(p []byte) (int, error) {
+	if r.buffer.Len() == 0 {
+		request, err := r.server.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buffer.Reset()
+		// buffer.Write cannot error
+		r.buffer.Write(request.Value)
+	}
+	return r.buffer.Read(p)
+}
+
 type putFileServer struct {
 	pfs.API_PutFileServer
 	req *pfs.PutFileRequest
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxInspectBatchSize is the most repos or commits that may be inspected by
+// one InspectRepos or InspectCommits call
+const MaxInspectBatchSize = 1000
+
+// checkInspectBatchSize returns an InvalidArgument error if a batch inspect
+// request for 'n' items is too large
+func checkInspectBatchSize(n int) error {
+	if n > MaxInspectBatchSize {
+		return status.Errorf(codes.InvalidArgument, "can't inspect %d items in one call (the limit is %d); split them into smaller batches", n, MaxInspectBatchSize)
+	}
+	return nil
+}
+
+func (a *apiServer) InspectRepos(request *pfs.InspectReposRequest, server pfs.API_InspectReposServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	sent := 0
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("stream containing %d repos", sent), retErr, time.Since(start))
+	}(time.Now())
+	if err := checkInspectBatchSize(len(request.Repos)); err != nil {
+		return err
+	}
+	pachClient := a.getPachClient(server.Context())
+	for _, repo := range request.Repos {
+		result := &pfs.InspectRepoResult{Repo: repo}
+		if repo == nil {
+			result.Error = "repo must be set"
+		} else if repoInfo, err := a.driver.inspectRepo(pachClient, repo, true); err != nil {
+			result.NotFound = isNotFoundErr(err)
+			result.Error = err.Error()
+		} else {
+			result.Info = repoInfo
+		}
+		if err := server.Send(result); err != nil {
+			return err
+		}
+		sent++
+	}
+	return nil
+}
+
+func (a *apiServer) InspectCommits(request *pfs.InspectCommitsRequest, server pfs.API_InspectCommitsServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	sent := 0
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("stream containing %d commits", sent), retErr, time.Since(start))
+	}(time.Now())
+	if err := checkInspectBatchSize(len(request.Commits)); err != nil {
+		return err
+	}
+	pachClient := a.getPachClient(server.Context())
+	for _, commit := range request.Commits {
+		result := &pfs.InspectCommitResult{Commit: commit}
+		// inspectCommit resolves branch names in place, so it's given a copy
+		// of the requested commit
+		if commit.GetRepo() == nil {
+			result.Error = "commit's repo must be set"
+		} else if commitInfo, err := a.driver.inspectCommit(pachClient, &pfs.Commit{Repo: commit.Repo, ID: commit.ID}, pfs.CommitState_STARTED); err != nil {
+			result.NotFound = isNotFoundErr(err) || isNoHeadErr(err)
+			result.Error = err.Error()
+		} else {
+			result.Info = commitInfo
+		}
+		if err := server.Send(result); err != nil {
+			return err
+		}
+		sent++
+	}
+	return nil
+}
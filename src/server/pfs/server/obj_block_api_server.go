@@ -55,6 +55,8 @@ type objBlockAPIServer struct {
 	blockCache      *groupcache.Group
 	// The total number of bytes cached for objects
 	objectCacheBytes int64
+	// The most bytes that each of the caches may use (see InspectCache)
+	cacheShareBytes int64
 	// The GC generation number.  Incrementing this number effectively
 	// invalidates all current cache.
 	generation int
@@ -62,16 +64,40 @@ type objBlockAPIServer struct {
 
 	objectIndexes     map[string]*pfsclient.ObjectIndex
 	objectIndexesLock sync.RWMutex
+
+	// etcdClient and etcdPrefix are used to check that objects may be
+	// written (see obj.CheckStorageWritable)
+	etcdClient *etcd.Client
+	etcdPrefix string
 }
 
 // In test mode, we use unique names for cache groups, since we might want
 // to run multiple block servers locally, which would conflict if groups
 // had the same name. We also do not report stats to prometheus
-func newObjBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, objClient obj.Client, test bool) (*objBlockAPIServer, error) {
+func newObjBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string, objClient obj.Client, test bool) (*objBlockAPIServer, error) {
 	// defensive mesaure incase IsNotExist checking breaks due to underlying changes
 	if err := obj.TestIsNotExist(objClient); err != nil {
 		return nil, err
 	}
+	objClient, err := obj.NewLayoutClientFromEnv(objClient)
+	if err != nil {
+		return nil, err
+	}
+	objClient, err = obj.NewCompressedClientFromEnv(objClient)
+	if err != nil {
+		return nil, err
+	}
+	objClient, err = obj.NewThrottledClientFromEnv(objClient)
+	if err != nil {
+		return nil, err
+	}
+	etcdClient, err := etcd.New(etcd.Config{
+		Endpoints:   []string{etcdAddress},
+		DialOptions: client.DefaultDialOptions(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error instantiating etcd client: %v", err)
+	}
 	oneCacheShare := cacheBytes / (objectCacheShares + tagCacheShares + objectInfoCacheShares)
 	s := &objBlockAPIServer{
 		Logger:           log.NewLogger("pfs.BlockAPI.Obj"),
@@ -79,6 +105,9 @@ func newObjBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, objC
 		objClient:        objClient,
 		objectIndexes:    make(map[string]*pfsclient.ObjectIndex),
 		objectCacheBytes: oneCacheShare * objectCacheShares,
+		cacheShareBytes:  oneCacheShare,
+		etcdClient:       etcdClient,
+		etcdPrefix:       etcdPrefix,
 	}
 
 	objectGroupName := "object"
@@ -100,9 +129,10 @@ func newObjBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, objC
 	s.blockCache = groupcache.NewGroup(blockGroupName, oneCacheShare*blockCacheShares, groupcache.GetterFunc(s.blockGetter))
 
 	if !test {
-		RegisterCacheStats("tag", &s.tagCache.Stats)
-		RegisterCacheStats("object", &s.objectCache.Stats)
-		RegisterCacheStats("object_info", &s.objectInfoCache.Stats)
+		RegisterCacheStats("tag", s.tagCache)
+		RegisterCacheStats("object", s.objectCache)
+		RegisterCacheStats("object_info", s.objectInfoCache)
+		RegisterCacheStats("block", s.blockCache)
 	}
 
 	go s.watchGC(etcdAddress)
@@ -147,6 +177,14 @@ func (s *objBlockAPIServer) watchGC(etcdAddress string) {
 	})
 }
 
+// checkWritable returns an error if objects can't be written, because the
+// cluster's data is being (or has been) migrated to another object store.
+// Writes call it once they've finished, so that a write is only acknowledged
+// if any migration will copy its objects.
+func (s *objBlockAPIServer) checkWritable(ctx context.Context) error {
+	return obj.CheckStorageWritable(ctx, s.etcdClient, s.etcdPrefix)
+}
+
 func (s *objBlockAPIServer) setGeneration(newGen int) {
 	s.genLock.Lock()
 	defer s.genLock.Unlock()
@@ -161,44 +199,56 @@ func (s *objBlockAPIServer) getGeneration() int {
 	return s.generation
 }
 
-func newMinioBlockAPIServer(dir string, cacheBytes int64, etcdAddress string) (*objBlockAPIServer, error) {
+func newMinioBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string) (*objBlockAPIServer, error) {
 	objClient, err := obj.NewMinioClientFromSecret("")
 	if err != nil {
 		return nil, err
 	}
-	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, objClient, false)
+	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix, objClient, false)
 }
 
-func newAmazonBlockAPIServer(dir string, cacheBytes int64, etcdAddress string) (*objBlockAPIServer, error) {
+func newAmazonBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string) (*objBlockAPIServer, error) {
 	objClient, err := obj.NewAmazonClientFromSecret("")
 	if err != nil {
 		return nil, err
 	}
-	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, objClient, false)
+	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix, objClient, false)
 }
 
-func newGoogleBlockAPIServer(dir string, cacheBytes int64, etcdAddress string) (*objBlockAPIServer, error) {
+func newGoogleBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string) (*objBlockAPIServer, error) {
 	objClient, err := obj.NewGoogleClientFromSecret(context.Background(), "")
 	if err != nil {
 		return nil, err
 	}
-	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, objClient, false)
+	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix, objClient, false)
 }
 
-func newMicrosoftBlockAPIServer(dir string, cacheBytes int64, etcdAddress string) (*objBlockAPIServer, error) {
+func newMicrosoftBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string) (*objBlockAPIServer, error) {
 	objClient, err := obj.NewMicrosoftClientFromSecret("")
 	if err != nil {
 		return nil, err
 	}
-	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, objClient, false)
+	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix, objClient, false)
+}
+
+func newURLBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string, storageURL string) (*objBlockAPIServer, error) {
+	url, err := obj.ParseURL(storageURL)
+	if err != nil {
+		return nil, err
+	}
+	objClient, err := obj.NewClientFromURLAndSecret(context.Background(), url)
+	if err != nil {
+		return nil, err
+	}
+	return newObjBlockAPIServer(obj.StorageRootForURL(dir, url), cacheBytes, etcdAddress, etcdPrefix, objClient, false)
 }
 
-func newLocalBlockAPIServer(dir string, cacheBytes int64, etcdAddress string) (*objBlockAPIServer, error) {
+func newLocalBlockAPIServer(dir string, cacheBytes int64, etcdAddress string, etcdPrefix string) (*objBlockAPIServer, error) {
 	objClient, err := obj.NewLocalClient(dir)
 	if err != nil {
 		return nil, err
 	}
-	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, objClient, true)
+	return newObjBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix, objClient, true)
 }
 
 func (s *objBlockAPIServer) PutObject(server pfsclient.ObjectAPI_PutObjectServer) (retErr error) {
@@ -223,6 +273,9 @@ func (s *objBlockAPIServer) PutObject(server pfsclient.ObjectAPI_PutObjectServer
 	if err := eg.Wait(); err != nil {
 		return err
 	}
+	if err := s.checkWritable(server.Context()); err != nil {
+		return err
+	}
 	return server.SendAndClose(object)
 }
 
@@ -246,17 +299,20 @@ func (s *objBlockAPIServer) PutObjectSplit(server pfsclient.ObjectAPI_PutObjectS
 			return err
 		}
 	}
+	if err := s.checkWritable(server.Context()); err != nil {
+		return err
+	}
 	return server.SendAndClose(&pfsclient.Objects{Objects: objects})
 }
 
-func (s *objBlockAPIServer) putObject(ctx context.Context, dataReader io.Reader, split bool) (_ *pfsclient.Object, retErr error) {
+func (s *objBlockAPIServer) putObject(ctx context.Context, dataReader *putObjectReader, split bool) (_ *pfsclient.Object, retErr error) {
 	hash := pfsclient.NewHash()
 	r := io.TeeReader(dataReader, hash)
 	block := &pfsclient.Block{Hash: uuid.NewWithoutDashes()}
 	var size int64
 	if err := func() (retErr error) {
 		blockPath := s.blockPath(block)
-		w, err := s.objClient.Writer(blockPath)
+		w, err := obj.NewWriterContext(ctx, s.objClient, blockPath)
 		if err != nil {
 			return err
 		}
@@ -290,7 +346,7 @@ func (s *objBlockAPIServer) putObject(ctx context.Context, dataReader io.Reader,
 			return nil, err
 		}
 	}
-	object := &pfsclient.Object{Hash: pfsclient.EncodeHash(hash.Sum(nil))}
+	object := &pfsclient.Object{Hash: pfsclient.EncodeHash(pfsclient.ScopeHash(dataReader.scope, hash.Sum(nil)))}
 	// Now that we have a hash of the object we can check if it already exists.
 	resp, err := s.CheckObject(ctx, &pfsclient.CheckObjectRequest{Object: object})
 	if err != nil {
@@ -332,7 +388,13 @@ func (s *objBlockAPIServer) PutObjects(server pfsclient.ObjectAPI_PutObjectsServ
 	putObjectReader := &putObjectReader{
 		server: server,
 	}
-	w, err := s.objClient.Writer(blockPath)
+	// Check once the block has been written (below)
+	defer func() {
+		if retErr == nil {
+			retErr = s.checkWritable(server.Context())
+		}
+	}()
+	w, err := obj.NewWriterContext(server.Context(), s.objClient, blockPath)
 	if err != nil {
 		return err
 	}
@@ -536,6 +598,9 @@ func (s *objBlockAPIServer) TagObject(ctx context.Context, request *pfsclient.Ta
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	if err := s.checkWritable(ctx); err != nil {
+		return nil, err
+	}
 	return &types.Empty{}, nil
 }
 
@@ -563,7 +628,7 @@ func (s *objBlockAPIServer) ListObjects(request *pfsclient.ListObjectsRequest, l
 	func() { s.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { s.Log(request, nil, retErr, time.Since(start)) }(time.Now())
 
-	return s.objClient.Walk(s.objectDir(), func(key string) error {
+	return s.objClient.Walk(filepath.Join(s.objectDir(), request.Prefix), func(key string) error {
 		return listObjectsServer.Send(client.NewObject(filepath.Base(key)))
 	})
 }
@@ -609,6 +674,9 @@ func (s *objBlockAPIServer) ListTags(request *pfsclient.ListTagsRequest, server
 func (s *objBlockAPIServer) DeleteTags(ctx context.Context, request *pfsclient.DeleteTagsRequest) (response *pfsclient.DeleteTagsResponse, retErr error) {
 	func() { s.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { s.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if err := s.checkWritable(ctx); err != nil {
+		return nil, err
+	}
 
 	limiter := limit.New(100)
 	var eg errgroup.Group
@@ -646,6 +714,9 @@ func (s *objBlockAPIServer) isNotFoundErr(err error) bool {
 func (s *objBlockAPIServer) DeleteObjects(ctx context.Context, request *pfsclient.DeleteObjectsRequest) (response *pfsclient.DeleteObjectsResponse, retErr error) {
 	func() { s.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { s.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if err := s.checkWritable(ctx); err != nil {
+		return nil, err
+	}
 
 	limiter := limit.New(100)
 	var eg errgroup.Group
@@ -706,12 +777,28 @@ func (s *objBlockAPIServer) InspectTag(ctx context.Context, request *pfsclient.T
 func (s *objBlockAPIServer) Compact(ctx context.Context, request *types.Empty) (response *types.Empty, retErr error) {
 	func() { s.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { s.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if err := s.checkWritable(ctx); err != nil {
+		return nil, err
+	}
 	if err := s.compact(); err != nil {
 		return nil, err
 	}
 	return &types.Empty{}, nil
 }
 
+func (s *objBlockAPIServer) InspectCache(ctx context.Context, request *types.Empty) (response *pfsclient.InspectCacheResponse, retErr error) {
+	func() { s.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { s.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	return &pfsclient.InspectCacheResponse{
+		Caches: []*pfsclient.CacheInfo{
+			cacheInfo("object", s.objectCache, s.cacheShareBytes*objectCacheShares),
+			cacheInfo("tag", s.tagCache, s.cacheShareBytes*tagCacheShares),
+			cacheInfo("object_info", s.objectInfoCache, s.cacheShareBytes*objectInfoCacheShares),
+			cacheInfo("block", s.blockCache, s.cacheShareBytes*blockCacheShares),
+		},
+	}, nil
+}
+
 func (s *objBlockAPIServer) objectPrefix(prefix string) string {
 	return s.objectPath(&pfsclient.Object{Hash: prefix})
 }
@@ -1180,6 +1267,9 @@ type putObjectReader struct {
 	server putObjectServer
 	buffer bytes.Buffer
 	tags   []*pfsclient.Tag
+	// scope is the dedup scope of the object (see
+	// pfsclient.PutObjectRequest.DedupScope)
+	scope string
 }
 
 func (r *putObjectReader) Read(p []byte) (int, error) {
@@ -1192,6 +1282,9 @@ func (r *putObjectReader) Read(p []byte) (int, error) {
 		// buffer.Write cannot error
 		r.buffer.Write(request.Value)
 		r.tags = append(r.tags, request.Tags...)
+		if request.DedupScope != "" {
+			r.scope = request.DedupScope
+		}
 	}
 	return r.buffer.Read(p)
 }
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+)
+
+func (a *apiServer) ProvenanceGraph(request *pfs.ProvenanceGraphRequest, server pfs.API_ProvenanceGraphServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	sent := 0
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("stream containing %d commits", sent), retErr, time.Since(start))
+	}(time.Now())
+	return a.driver.provenanceGraph(a.getPachClient(server.Context()), request.Commit, request.MaxDepth, func(node *pfs.ProvenanceGraphNode) error {
+		sent++
+		return server.Send(node)
+	})
+}
+
+// provenanceGraph calls 'f' with each commit in the provenance graph of
+// 'commit', breadth first, starting with 'commit' itself. If 'maxDepth' is
+// positive, commits that are more than 'maxDepth' edges from 'commit' are
+// left out.
+func (d *driver) provenanceGraph(pachClient *client.APIClient, commit *pfs.Commit, maxDepth int64, f func(*pfs.ProvenanceGraphNode) error) error {
+	if commit == nil || commit.Repo == nil {
+		return fmt.Errorf("must specify a commit")
+	}
+	commitInfo, err := d.inspectCommit(pachClient, commit, pfs.CommitState_STARTED)
+	if err != nil {
+		return err
+	}
+	branch, err := d.commitBranch(pachClient, commit, commitInfo)
+	if err != nil {
+		return err
+	}
+	type graphNode struct {
+		commitInfo *pfs.CommitInfo
+		branch     *pfs.Branch
+		depth      int64
+	}
+	queue := []graphNode{{commitInfo, branch, 0}}
+	seen := map[string]bool{commitKey(commitInfo.Commit): true}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		node := &pfs.ProvenanceGraphNode{
+			Commit: n.commitInfo.Commit,
+			Branch: n.branch,
+			Depth:  n.depth,
+		}
+		direct, err := d.directProvenance(pachClient, n.commitInfo, n.branch)
+		if err != nil {
+			return err
+		}
+		if maxDepth > 0 && n.depth >= maxDepth {
+			node.Truncated = len(direct) > 0
+			direct = nil
+		}
+		for _, i := range direct {
+			provCommit := n.commitInfo.Provenance[i]
+			node.Provenance = append(node.Provenance, provCommit)
+			if seen[commitKey(provCommit)] {
+				continue
+			}
+			seen[commitKey(provCommit)] = true
+			provCommitInfo, err := d.inspectCommit(pachClient, provCommit, pfs.CommitState_STARTED)
+			if err != nil {
+				return err
+			}
+			queue = append(queue, graphNode{provCommitInfo, provenanceBranch(n.commitInfo, i), n.depth + 1})
+		}
+		if err := f(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provenanceBranch returns the branch of commitInfo.Provenance[i], or nil if
+// it's unknown
+func provenanceBranch(commitInfo *pfs.CommitInfo, i int) *pfs.Branch {
+	if len(commitInfo.BranchProvenance) != len(commitInfo.Provenance) {
+		return nil
+	}
+	return commitInfo.BranchProvenance[i]
+}
+
+// commitBranch returns the branch that 'commit' is in, as far as can be told:
+// the branch that 'commit' names, if it names one, or otherwise a branch
+// whose head it is, or a branch with the same provenance as it. It returns nil
+// if there's none.
+func (d *driver) commitBranch(pachClient *client.APIClient, commit *pfs.Commit, commitInfo *pfs.CommitInfo) (*pfs.Branch, error) {
+	branchInfos, err := d.listBranch(pachClient, commit.Repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, branchInfo := range branchInfos {
+		if branchInfo.Branch.Name == commit.ID {
+			return branchInfo.Branch, nil
+		}
+	}
+	for _, branchInfo := range branchInfos {
+		if branchInfo.Head != nil && branchInfo.Head.ID == commitInfo.Commit.ID {
+			return branchInfo.Branch, nil
+		}
+	}
+	provenance := make(map[string]bool)
+	for _, branch := range commitInfo.BranchProvenance {
+		provenance[branchKey(branch)] = true
+	}
+	for _, branchInfo := range branchInfos {
+		if len(branchInfo.Provenance) == 0 || len(branchInfo.Provenance) != len(provenance) {
+			continue
+		}
+		match := true
+		for _, branch := range branchInfo.Provenance {
+			match = match && provenance[branchKey(branch)]
+		}
+		if match {
+			return branchInfo.Branch, nil
+		}
+	}
+	return nil, nil
+}
+
+// directProvenance returns the indices of the commits in
+// commitInfo.Provenance (which holds all of the commit's provenance) that the
+// commit is directly provenant on: those in the branches that its branch,
+// 'branch', is directly provenant on. If 'branch' is unknown, or its direct
+// provenance has since changed so that none of the commits are in it, they're
+// the commits whose branches aren't in the provenance of any other branch in
+// the commit's provenance.
+func (d *driver) directProvenance(pachClient *client.APIClient, commitInfo *pfs.CommitInfo, branch *pfs.Branch) ([]int, error) {
+	if len(commitInfo.Provenance) == 0 {
+		return nil, nil
+	}
+	if len(commitInfo.BranchProvenance) != len(commitInfo.Provenance) {
+		// The commits' branches are unknown, so none of them can be ruled out
+		var result []int
+		for i := range commitInfo.Provenance {
+			result = append(result, i)
+		}
+		return result, nil
+	}
+	if branch != nil {
+		branchInfo, err := d.inspectBranch(pachClient, branch)
+		if err != nil && !isNotFoundErr(err) {
+			return nil, err
+		}
+		if err == nil {
+			directBranches := make(map[string]bool)
+			for _, b := range branchInfo.DirectProvenance {
+				directBranches[branchKey(b)] = true
+			}
+			var result []int
+			for i, b := range commitInfo.BranchProvenance {
+				if directBranches[branchKey(b)] {
+					result = append(result, i)
+				}
+			}
+			if len(result) > 0 {
+				return result, nil
+			}
+		}
+	}
+	// indirect holds the branches that are in the provenance of another
+	// branch in the commit's provenance
+	indirect := make(map[string]bool)
+	for _, b := range commitInfo.BranchProvenance {
+		branchInfo, err := d.inspectBranch(pachClient, b)
+		if err != nil {
+			if isNotFoundErr(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, provBranch := range branchInfo.Provenance {
+			indirect[branchKey(provBranch)] = true
+		}
+	}
+	var result []int
+	for i, b := range commitInfo.BranchProvenance {
+		if !indirect[branchKey(b)] {
+			result = append(result, i)
+		}
+	}
+	return result, nil
+}
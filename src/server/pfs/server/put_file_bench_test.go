@@ -0,0 +1,85 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+)
+
+const (
+	putFileStreams        = 16
+	filesPerPutFileStream = 500
+
+	parallelPutFileBytes     = 512 * 1024 * 1024
+	parallelPutFileChunkSize = 16 * 1024 * 1024
+)
+
+// BenchmarkConcurrentPutFile measures the throughput of many concurrent
+// put-file streams of small files into a single open commit, which is limited
+// by writing the files' records to etcd rather than by uploading their
+// contents. Each op is one commit of putFileStreams*filesPerPutFileStream
+// files.
+func BenchmarkConcurrentPutFile(b *testing.B) {
+	c := GetPachClient(b)
+	repo := tu.UniqueString("BenchmarkConcurrentPutFile")
+	require.NoError(b, c.CreateRepo(repo))
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		commit, err := c.StartCommit(repo, "master")
+		require.NoError(b, err)
+		var eg errgroup.Group
+		for i := 0; i < putFileStreams; i++ {
+			i := i
+			eg.Go(func() (retErr error) {
+				pfc, err := c.NewPutFileClient()
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := pfc.Close(); err != nil && retErr == nil {
+						retErr = err
+					}
+				}()
+				for j := 0; j < filesPerPutFileStream; j++ {
+					if _, err := pfc.PutFile(repo, commit.ID, fmt.Sprintf("%d/%d", i, j), strings.NewReader("foo\n")); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		require.NoError(b, eg.Wait())
+		require.NoError(b, c.FinishCommit(repo, commit.ID))
+	}
+}
+
+// BenchmarkPutFileParallel measures the throughput of putting a single large
+// file with PutFileParallel, uploading one chunk at a time vs. several chunks
+// at once. Each op is one put of parallelPutFileBytes of random data.
+func BenchmarkPutFileParallel(b *testing.B) {
+	c := GetPachClient(b)
+	repo := tu.UniqueString("BenchmarkPutFileParallel")
+	require.NoError(b, c.CreateRepo(repo))
+	data := make([]byte, parallelPutFileBytes)
+	rand.Read(data)
+	for _, concurrency := range []int{1, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			b.SetBytes(parallelPutFileBytes)
+			for n := 0; n < b.N; n++ {
+				// Each op puts new chunks, so that they aren't deduplicated
+				for i := 0; i < len(data); i += parallelPutFileChunkSize {
+					data[i]++
+				}
+				require.NoError(b, c.PutFileParallel(repo, "master", "file", bytes.NewReader(data),
+					parallelPutFileBytes, parallelPutFileChunkSize, concurrency, true))
+			}
+		})
+	}
+}
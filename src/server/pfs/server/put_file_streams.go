@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// putFileStreamUsersCacheSize is the number of auth tokens whose users
+	// are cached by putFileStreamLimiter
+	putFileStreamUsersCacheSize = 10000
+	// putFileStreamUserTTL is how long the user of an auth token is cached
+	// for, after which it's looked up again (e.g. in case auth was activated)
+	putFileStreamUserTTL = time.Minute
+)
+
+// putFileStreamLimiter bounds the number of put-file streams (PutFile and
+// PutFileChunk) that each client has open at once
+type putFileStreamLimiter struct {
+	max int
+
+	// users caches the user of each auth token (see client), so that a WhoAmI
+	// call isn't made for every stream
+	users *lru.Cache // token -> *putFileStreamUser
+
+	mu   sync.Mutex
+	open map[string]int // client -> open streams
+}
+
+// putFileStreamUser is an entry in putFileStreamLimiter.users
+type putFileStreamUser struct {
+	// username is the token's user, or "" if auth isn't active
+	username string
+	expires  time.Time
+}
+
+// newPutFileStreamLimiter returns a putFileStreamLimiter that lets each
+// client open up to 'max' streams. If 'max' is 0, streams aren't limited.
+func newPutFileStreamLimiter(max int) (*putFileStreamLimiter, error) {
+	users, err := lru.New(putFileStreamUsersCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &putFileStreamLimiter{
+		max:   max,
+		users: users,
+		open:  make(map[string]int),
+	}, nil
+}
+
+// acquire records that the client 'c' opened a stream, and returns a
+// function that records that it closed the stream. If 'c' already has the
+// most streams open that it's allowed, it returns a ResourceExhausted error.
+func (l *putFileStreamLimiter) acquire(c string) (func(), error) {
+	if l.max <= 0 {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.open[c] >= l.max {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"%s has too many put-file streams open (the limit is %d); wait for some to finish before opening more", c, l.max)
+	}
+	l.open[c]++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.open[c]--
+		if l.open[c] == 0 {
+			delete(l.open, c)
+		}
+	}, nil
+}
+
+// client returns the client that a put-file stream with the context 'ctx' is
+// counted against: its user if auth is active, and otherwise the host that
+// it's from. The user of each auth token is cached, so that each stream
+// doesn't cost a WhoAmI call.
+func (l *putFileStreamLimiter) client(ctx context.Context, pachClient *client.APIClient) (string, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[auth.ContextTokenKey]) == 1 {
+		token = md[auth.ContextTokenKey][0]
+	}
+	var user *putFileStreamUser
+	if v, ok := l.users.Get(token); ok && time.Now().Before(v.(*putFileStreamUser).expires) {
+		user = v.(*putFileStreamUser)
+	} else {
+		user = &putFileStreamUser{expires: time.Now().Add(putFileStreamUserTTL)}
+		resp, err := pachClient.WhoAmI(pachClient.Ctx(), &auth.WhoAmIRequest{})
+		if err == nil {
+			user.username = resp.Username
+		} else if !auth.IsErrNotActivated(err) {
+			return "", err
+		}
+		l.users.Add(token, user)
+	}
+	if user.username != "" {
+		return user.username, nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown client", nil
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String(), nil
+	}
+	return host, nil
+}
+
+// acquirePutFileStream counts a put-file stream with the context 'ctx'
+// against its client's limit (see putFileStreamLimiter.acquire)
+func (a *apiServer) acquirePutFileStream(ctx context.Context) (func(), error) {
+	if a.putFileStreams.max <= 0 {
+		return func() {}, nil
+	}
+	c, err := a.putFileStreams.client(ctx, a.getPachClient(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return a.putFileStreams.acquire(c)
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPutFileStreamLimiter(t *testing.T) {
+	l, err := newPutFileStreamLimiter(2)
+	require.NoError(t, err)
+	release1, err := l.acquire("alice")
+	require.NoError(t, err)
+	release2, err := l.acquire("alice")
+	require.NoError(t, err)
+	_, err = l.acquire("alice")
+	require.YesError(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Each client has its own limit
+	release3, err := l.acquire("bob")
+	require.NoError(t, err)
+
+	// Closing a stream lets the client open another
+	release1()
+	release1, err = l.acquire("alice")
+	require.NoError(t, err)
+	release1()
+	release2()
+	release3()
+	require.Equal(t, 0, len(l.open))
+
+	// A limit of 0 means no limit
+	l, err = newPutFileStreamLimiter(0)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, err := l.acquire("alice")
+		require.NoError(t, err)
+	}
+}
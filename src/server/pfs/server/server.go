@@ -1,8 +1,11 @@
 package server
 
 import (
+	"os"
+
 	pfsclient "github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 )
 
 // Valid object storage backends
@@ -24,21 +27,51 @@ type BlockAPIServer interface {
 	pfsclient.ObjectAPIServer
 }
 
+// APIServerOptions are the optional settings of an APIServer. Their zero
+// values are the defaults.
+type APIServerOptions struct {
+	// PutFileConcurrencyLimit limits the number of concurrent etcd
+	// transactions that write put-file records into open commits (0 means no
+	// limit)
+	PutFileConcurrencyLimit int
+	// DedupScope is the scope within which identical file content is stored
+	// once (DedupScopeGlobal or DedupScopeRepo)
+	DedupScope string
+	// MaxPutFileStreamsPerClient limits the number of put-file streams that
+	// each client may have open at once (0 means no limit)
+	MaxPutFileStreamsPerClient int
+	// MaxCommitFiles and MaxCommitBytes are the most files and bytes that a
+	// commit may add or change, relative to its parent, unless its repo sets
+	// its own limits (0 means no limit)
+	MaxCommitFiles int64
+	MaxCommitBytes int64
+}
+
 // NewAPIServer creates an APIServer.
-func NewAPIServer(address string, etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Cache, storageRoot string, memoryRequest int64) (APIServer, error) {
-	return newAPIServer(address, etcdAddresses, etcdPrefix, treeCache, storageRoot, memoryRequest)
+func NewAPIServer(address string, etcdAddresses []string, etcdPrefix string, treeCache *hashtree.Cache, storageRoot string, memoryRequest int64, options APIServerOptions) (APIServer, error) {
+	return newAPIServer(address, etcdAddresses, etcdPrefix, treeCache, storageRoot, memoryRequest, options)
 }
 
 // NewBlockAPIServer creates a BlockAPIServer using the credentials it finds in
-// the environment
-func NewBlockAPIServer(dir string, cacheBytes int64, backend string, etcdAddress string) (BlockAPIServer, error) {
+// the environment. etcdPrefix is the cluster's etcd prefix (under which
+// obj.StorageURLKey is stored).
+func NewBlockAPIServer(dir string, cacheBytes int64, backend string, etcdAddress string, etcdPrefix string) (BlockAPIServer, error) {
+	// If the cluster's data has been migrated to another object store, use
+	// that rather than the configured backend
+	if storageURL, ok := os.LookupEnv(obj.StorageURLEnvVar); ok {
+		blockAPIServer, err := newURLBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix, storageURL)
+		if err != nil {
+			return nil, err
+		}
+		return blockAPIServer, nil
+	}
 	switch backend {
 	case MinioBackendEnvVar:
 		// S3 compatible doesn't like leading slashes
 		if len(dir) > 0 && dir[0] == '/' {
 			dir = dir[1:]
 		}
-		blockAPIServer, err := newMinioBlockAPIServer(dir, cacheBytes, etcdAddress)
+		blockAPIServer, err := newMinioBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix)
 		if err != nil {
 			return nil, err
 		}
@@ -48,20 +81,20 @@ func NewBlockAPIServer(dir string, cacheBytes int64, backend string, etcdAddress
 		if len(dir) > 0 && dir[0] == '/' {
 			dir = dir[1:]
 		}
-		blockAPIServer, err := newAmazonBlockAPIServer(dir, cacheBytes, etcdAddress)
+		blockAPIServer, err := newAmazonBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix)
 		if err != nil {
 			return nil, err
 		}
 		return blockAPIServer, nil
 	case GoogleBackendEnvVar:
 		// TODO figure out if google likes leading slashses
-		blockAPIServer, err := newGoogleBlockAPIServer(dir, cacheBytes, etcdAddress)
+		blockAPIServer, err := newGoogleBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix)
 		if err != nil {
 			return nil, err
 		}
 		return blockAPIServer, nil
 	case MicrosoftBackendEnvVar:
-		blockAPIServer, err := newMicrosoftBlockAPIServer(dir, cacheBytes, etcdAddress)
+		blockAPIServer, err := newMicrosoftBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix)
 		if err != nil {
 			return nil, err
 		}
@@ -69,7 +102,7 @@ func NewBlockAPIServer(dir string, cacheBytes int64, backend string, etcdAddress
 	case LocalBackendEnvVar:
 		fallthrough
 	default:
-		blockAPIServer, err := newLocalBlockAPIServer(dir, cacheBytes, etcdAddress)
+		blockAPIServer, err := newLocalBlockAPIServer(dir, cacheBytes, etcdAddress, etcdPrefix)
 		if err != nil {
 			return nil, err
 		}
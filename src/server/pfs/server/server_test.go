@@ -3,6 +3,9 @@ package server
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -23,6 +26,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/sql"
@@ -364,6 +368,263 @@ func TestPutFileIntoOpenCommit(t *testing.T) {
 	require.YesError(t, err)
 }
 
+func TestDedupScope(t *testing.T) {
+	// fileObjects puts the same content in "foo" and "bar" in each of the
+	// repos 'a' and 'b', copies a/foo to c/foo and builds a commit of it in
+	// d/foo (from an object put outside of PFS), and returns the objects that
+	// hold each file
+	fileObjects := func(dedupScope string) map[string][]*pfs.Object {
+		client := getPachClientWithDedupScope(t, dedupScope)
+		content := strings.Repeat("same content\n", 1000)
+		objects := make(map[string][]*pfs.Object)
+		for _, repo := range []string{"a", "b"} {
+			require.NoError(t, client.CreateRepo(repo))
+			for _, file := range []string{"foo", "bar"} {
+				_, err := client.PutFile(repo, "master", file, strings.NewReader(content))
+				require.NoError(t, err)
+				fileInfo, err := client.InspectFile(repo, "master", file)
+				require.NoError(t, err)
+				objects[path.Join(repo, file)] = fileInfo.Objects
+			}
+			var buf bytes.Buffer
+			require.NoError(t, client.GetFile(repo, "master", "foo", 0, 0, &buf))
+			require.Equal(t, content, buf.String())
+		}
+		require.NoError(t, client.CreateRepo("c"))
+		_, err := client.PutFile("c", "master", "init", strings.NewReader(""))
+		require.NoError(t, err)
+		require.NoError(t, client.CopyFile("a", "master", "foo", "c", "master", "foo", false))
+		require.NoError(t, client.CreateRepo("d"))
+		object, size, err := client.PutObject(strings.NewReader(content))
+		require.NoError(t, err)
+		tree, err := hashtree.NewDBHashTree("")
+		require.NoError(t, err)
+		require.NoError(t, tree.PutFile("foo", []*pfs.Object{object}, size))
+		require.NoError(t, tree.Hash())
+		treeObject, err := hashtree.PutHashTree(client, tree)
+		require.NoError(t, err)
+		_, err = client.BuildCommit("d", "master", "", treeObject.Hash)
+		require.NoError(t, err)
+		for _, repo := range []string{"c", "d"} {
+			fileInfo, err := client.InspectFile(repo, "master", "foo")
+			require.NoError(t, err)
+			objects[path.Join(repo, "foo")] = fileInfo.Objects
+			var buf bytes.Buffer
+			require.NoError(t, client.GetFile(repo, "master", "foo", 0, 0, &buf))
+			require.Equal(t, content, buf.String())
+		}
+		return objects
+	}
+
+	// By default, identical content is stored once across the cluster
+	objects := fileObjects(DedupScopeGlobal)
+	require.Equal(t, objects["a/foo"], objects["a/bar"])
+	require.Equal(t, objects["a/foo"], objects["b/foo"])
+	require.Equal(t, objects["a/foo"], objects["c/foo"])
+
+	// With the repo scope, it's stored once per repo
+	objects = fileObjects(DedupScopeRepo)
+	require.Equal(t, objects["a/foo"], objects["a/bar"])
+	require.Equal(t, objects["b/foo"], objects["b/bar"])
+	require.NotEqual(t, objects["a/foo"], objects["b/foo"])
+	// including content copied or built into a repo from elsewhere
+	require.NotEqual(t, objects["a/foo"], objects["c/foo"])
+	hash := pfs.NewHash()
+	hash.Write([]byte(strings.Repeat("same content\n", 1000)))
+	require.Equal(t, []*pfs.Object{{Hash: pfs.EncodeHash(pfs.ScopeHash(pfs.RepoScope("d"), hash.Sum(nil)))}}, objects["d/foo"])
+
+	// Invalid scopes are rejected
+	require.YesError(t, validateDedupScope("acl"))
+}
+
+func TestPutFileParallel(t *testing.T) {
+	client := GetPachClient(t)
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	content := strings.Repeat("0123456789abcdef\n", 10000)
+	getFile := func(path string) string {
+		var buf bytes.Buffer
+		require.NoError(t, client.GetFile(repo, "master", path, 0, 0, &buf))
+		return buf.String()
+	}
+
+	// Small chunks uploaded concurrently finish out of order, but the file's
+	// content is in order
+	for _, concurrency := range []int{1, 8} {
+		path := fmt.Sprintf("file%d", concurrency)
+		require.NoError(t, client.PutFileParallel(repo, "master", path, strings.NewReader(content), int64(len(content)), 1000, concurrency, false))
+		require.Equal(t, content, getFile(path))
+	}
+	require.NoError(t, client.PutFileParallel(repo, "master", "empty", strings.NewReader(""), 0, 0, 8, false))
+	require.Equal(t, "", getFile("empty"))
+
+	// Without overwrite the chunks are appended to the file, otherwise they
+	// replace it
+	require.NoError(t, client.PutFileParallel(repo, "master", "file1", strings.NewReader("foo\n"), 4, 0, 8, false))
+	require.Equal(t, content+"foo\n", getFile("file1"))
+	require.NoError(t, client.PutFileParallel(repo, "master", "file1", strings.NewReader("bar\n"), 4, 0, 8, true))
+	require.Equal(t, "bar\n", getFile("file1"))
+
+	// Chunks are put in the order of their offsets, not the order they're listed
+	file := pclient.NewFile(repo, "master", "reversed")
+	putChunk := func(file *pfs.File, uploadID string, value string) *pfs.FileChunk {
+		putFileChunkClient, err := client.PfsAPIClient.PutFileChunk(client.Ctx())
+		require.NoError(t, err)
+		require.NoError(t, putFileChunkClient.Send(&pfs.PutFileChunkRequest{File: file, UploadId: uploadID, Value: []byte(value)}))
+		chunk, err := putFileChunkClient.CloseAndRecv()
+		require.NoError(t, err)
+		return chunk
+	}
+	var chunks []*pfs.FileChunk
+	var offset int64
+	for _, value := range []string{"foo\n", "bar\n", "buzz\n"} {
+		chunk := putChunk(file, "upload1", value)
+		require.Equal(t, int64(len(value)), chunk.SizeBytes)
+		chunk.OffsetBytes = offset
+		offset += chunk.SizeBytes
+		chunks = append([]*pfs.FileChunk{chunk}, chunks...)
+	}
+
+	// Chunks with gaps, overlaps or the wrong size are rejected, as are
+	// chunks from another upload, or without an upload ID
+	for _, request := range []*pfs.PutFileChunksRequest{
+		{UploadId: "upload1", Chunks: []*pfs.FileChunk{chunks[2], chunks[0]}},
+		{UploadId: "upload1", Chunks: []*pfs.FileChunk{chunks[2], chunks[1], {OffsetBytes: 4, SizeBytes: 4, Object: chunks[1].Object}}},
+		{UploadId: "upload1", Chunks: []*pfs.FileChunk{chunks[2], {OffsetBytes: 4, SizeBytes: 5, Object: chunks[1].Object}}},
+		{UploadId: "upload2", Chunks: chunks},
+		{Chunks: chunks},
+	} {
+		request.File = file
+		_, err := client.PfsAPIClient.PutFileChunks(client.Ctx(), request)
+		require.YesError(t, err)
+	}
+	_, err := client.PfsAPIClient.PutFileChunks(client.Ctx(), &pfs.PutFileChunksRequest{File: file, UploadId: "upload1", Chunks: chunks})
+	require.NoError(t, err)
+	require.Equal(t, "foo\nbar\nbuzz\n", getFile("reversed"))
+
+	// An upload's chunks are only written once
+	_, err = client.PfsAPIClient.PutFileChunks(client.Ctx(), &pfs.PutFileChunksRequest{File: file, UploadId: "upload1", Chunks: chunks})
+	require.YesError(t, err)
+	require.Equal(t, "foo\nbar\nbuzz\n", getFile("reversed"))
+
+	// Chunks must have been stored in the file's repo, so content can't be
+	// copied from other repos, or from objects put outside of PFS
+	otherRepo := "other"
+	require.NoError(t, client.CreateRepo(otherRepo))
+	otherChunk := putChunk(pclient.NewFile(otherRepo, "master", "file"), "upload3", "foo\n")
+	globalObject, _, err := client.PutObject(strings.NewReader("foo\n"))
+	require.NoError(t, err)
+	for _, object := range []*pfs.Object{otherChunk.Object, globalObject} {
+		_, err = client.PfsAPIClient.PutFileChunks(client.Ctx(), &pfs.PutFileChunksRequest{
+			File:     pclient.NewFile(repo, "master", "copied"),
+			UploadId: "upload3",
+			Chunks:   []*pfs.FileChunk{{SizeBytes: 4, Object: object}},
+		})
+		require.YesError(t, err)
+	}
+}
+
+func TestPutFileStreamLimit(t *testing.T) {
+	client, apiServer := newTestServer(t, "", 3)
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	commit, err := client.StartCommit(repo, "master")
+	require.NoError(t, err)
+
+	// A client can have up to the limit of put-file streams open at once
+	var streams []pfs.API_PutFileClient
+	for i := 0; i < 3; i++ {
+		stream, err := client.PfsAPIClient.PutFile(client.Ctx())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pfs.PutFileRequest{
+			File:  pclient.NewFile(repo, commit.ID, fmt.Sprintf("file%d", i)),
+			Value: []byte("foo"),
+		}))
+		streams = append(streams, stream)
+	}
+
+	// Once the server has seen the open streams, streams beyond the limit are
+	// rejected
+	require.NoError(t, backoff.Retry(func() error {
+		l := apiServer.putFileStreams
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.open["127.0.0.1"] != 3 {
+			return fmt.Errorf("expected 3 open put-file streams, but there are %d", l.open["127.0.0.1"])
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+	_, err = client.PutFile(repo, commit.ID, "extra", strings.NewReader("bar"))
+	require.YesError(t, err)
+	require.Matches(t, "too many put-file streams open", err.Error())
+
+	// Once the open streams finish, more can be opened
+	for _, stream := range streams {
+		_, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+	}
+	_, err = client.PutFile(repo, commit.ID, "extra", strings.NewReader("bar"))
+	require.NoError(t, err)
+	require.NoError(t, client.FinishCommit(repo, commit.ID))
+	fileInfos, err := client.ListFile(repo, commit.ID, "/")
+	require.NoError(t, err)
+	require.Equal(t, 4, len(fileInfos))
+}
+
+func TestReadOpenCommit(t *testing.T) {
+	client := GetPachClient(t)
+
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	commit, err := client.StartCommit(repo, "master")
+	require.NoError(t, err)
+
+	// Files in an open commit reflect the writes made so far
+	_, err = client.PutFile(repo, commit.ID, "foo", strings.NewReader("foo\n"))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, client.GetFile(repo, commit.ID, "foo", 0, 0, &buf))
+	require.Equal(t, "foo\n", buf.String())
+	_, err = client.PutFile(repo, commit.ID, "foo", strings.NewReader("bar\n"))
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, client.GetFile(repo, "master", "foo", 0, 0, &buf))
+	require.Equal(t, "foo\nbar\n", buf.String())
+	fileInfos, err := client.ListFile(repo, commit.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(fileInfos))
+	require.Nil(t, fileInfos[0].Committed)
+
+	// Writes that haven't been flushed aren't visible
+	pfc, err := client.NewPutFileClient()
+	require.NoError(t, err)
+	_, err = pfc.PutFile(repo, commit.ID, "buzz", strings.NewReader("buzz\n"))
+	require.NoError(t, err)
+	_, err = pfc.PutFile(repo, commit.ID, "foo", strings.NewReader("buzz\n"))
+	require.NoError(t, err)
+	_, err = client.InspectFile(repo, commit.ID, "buzz")
+	require.YesError(t, err)
+	buf.Reset()
+	require.NoError(t, client.GetFile(repo, commit.ID, "foo", 0, 0, &buf))
+	require.Equal(t, "foo\nbar\n", buf.String())
+	require.NoError(t, pfc.Close())
+	buf.Reset()
+	require.NoError(t, client.GetFile(repo, commit.ID, "buzz", 0, 0, &buf))
+	require.Equal(t, "buzz\n", buf.String())
+
+	// Once the commit is finished, its files are committed
+	require.NoError(t, client.FinishCommit(repo, commit.ID))
+	fileInfos, err = client.ListFile(repo, commit.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(fileInfos))
+	for _, fileInfo := range fileInfos {
+		require.NotNil(t, fileInfo.Committed)
+	}
+	buf.Reset()
+	require.NoError(t, client.GetFile(repo, commit.ID, "foo", 0, 0, &buf))
+	require.Equal(t, "foo\nbar\nbuzz\n", buf.String())
+}
+
 func TestCreateInvalidBranchName(t *testing.T) {
 
 	client := GetPachClient(t)
@@ -485,6 +746,55 @@ func TestInspectCommit(t *testing.T) {
 	require.True(t, finished.After(tFinished))
 }
 
+func TestInspectCommits(t *testing.T) {
+	c := GetPachClient(t)
+
+	repo := "TestInspectCommits"
+	require.NoError(t, c.CreateRepo(repo))
+	commit, err := c.StartCommit(repo, "master")
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(repo, commit.ID))
+	require.NoError(t, c.CreateRepo("TestInspectCommits_empty"))
+
+	// Present commits are returned in the order they're requested, and
+	// missing ones are flagged
+	results, err := c.InspectCommits([]*pfs.Commit{
+		pclient.NewCommit(repo, "master"),
+		pclient.NewCommit(repo, uuid.NewWithoutDashes()),
+		pclient.NewCommit("TestInspectCommits_empty", "master"),
+		pclient.NewCommit("TestInspectCommits_missing", "master"),
+		pclient.NewCommit(repo, commit.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, len(results))
+	require.Equal(t, "master", results[0].Commit.ID)
+	require.Equal(t, commit.ID, results[0].Info.Commit.ID)
+	require.Equal(t, "", results[0].Error)
+	for _, result := range results[1:4] {
+		require.Nil(t, result.Info)
+		require.True(t, result.NotFound)
+		require.NotEqual(t, "", result.Error)
+	}
+	require.Equal(t, commit.ID, results[4].Info.Commit.ID)
+
+	// Repos can be inspected in batches too
+	repoResults, err := c.InspectRepos([]string{repo, "TestInspectCommits_missing"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(repoResults))
+	require.Equal(t, repo, repoResults[0].Info.Repo.Name)
+	require.False(t, repoResults[0].NotFound)
+	require.Nil(t, repoResults[1].Info)
+	require.True(t, repoResults[1].NotFound)
+
+	// Batches are capped
+	repos := make([]string, MaxInspectBatchSize+1)
+	for i := range repos {
+		repos[i] = repo
+	}
+	_, err = c.InspectRepos(repos)
+	require.YesError(t, err)
+}
+
 func TestInspectCommitBlock(t *testing.T) {
 	client := GetPachClient(t)
 
@@ -503,6 +813,332 @@ func TestInspectCommitBlock(t *testing.T) {
 	require.NotNil(t, commitInfo.Finished)
 }
 
+func TestMaxHistoryDepth(t *testing.T) {
+	client := GetPachClient(t)
+
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	_, err := client.PutFile(repo, "master", "/0", strings.NewReader("0"))
+	require.NoError(t, err)
+	require.NoError(t, client.SetMaxHistoryDepth(repo, "master", 3))
+	for i := 1; i < 10; i++ {
+		_, err := client.PutFile(repo, "master", fmt.Sprintf("/%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+		if i == 4 {
+			// The head of another branch is never deleted
+			require.NoError(t, client.CreateBranch(repo, "keep", "master", nil))
+		}
+		commitInfos, err := client.ListCommit(repo, "master", "", 0)
+		require.NoError(t, err)
+		if i < 7 {
+			require.True(t, len(commitInfos) <= 3)
+		} else {
+			require.Equal(t, 4, len(commitInfos))
+		}
+		// The content of the branch is unchanged
+		fileInfos, err := client.ListFile(repo, "master", "/")
+		require.NoError(t, err)
+		require.Equal(t, i+1, len(fileInfos))
+	}
+	fileInfos, err := client.ListFile(repo, "keep", "/")
+	require.NoError(t, err)
+	require.Equal(t, 5, len(fileInfos))
+
+	// Commits with downstream commits aren't deleted until their downstream
+	// commits are
+	require.NoError(t, client.CreateRepo("out"))
+	require.NoError(t, client.CreateBranch("out", "master", "", []*pfs.Branch{pclient.NewBranch(repo, "master")}))
+	for i := 10; i < 13; i++ {
+		_, err := client.PutFile(repo, "master", fmt.Sprintf("/%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+	}
+	commitInfos, err := client.ListCommit(repo, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 7, len(commitInfos))
+	require.NoError(t, client.SetMaxHistoryDepth("out", "master", 1))
+	outCommitInfos, err := client.ListCommit("out", "master", "", 0)
+	require.NoError(t, err)
+	for i := len(outCommitInfos) - 1; i >= 0; i-- {
+		require.NoError(t, client.FinishCommit("out", outCommitInfos[i].Commit.ID))
+	}
+	outCommitInfos, err = client.ListCommit("out", "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(outCommitInfos))
+	_, err = client.PutFile(repo, "master", "/13", strings.NewReader("13"))
+	require.NoError(t, err)
+	commitInfos, err = client.ListCommit(repo, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(commitInfos))
+	fileInfos, err = client.ListFile(repo, "master", "/")
+	require.NoError(t, err)
+	require.Equal(t, 14, len(fileInfos))
+
+	// Pruning never deletes downstream commits, so commits whose downstream
+	// commits are kept are kept too, even once they're all finished
+	require.NoError(t, client.SetMaxHistoryDepth("out", "master", 0))
+	for i := 14; i < 16; i++ {
+		require.NoError(t, client.FinishCommit("out", "master"))
+		_, err = client.PutFile(repo, "master", fmt.Sprintf("/%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+	}
+	commitInfos, err = client.ListCommit(repo, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(commitInfos))
+	outCommitInfos, err = client.ListCommit("out", "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(outCommitInfos))
+	for _, outCommitInfo := range outCommitInfos {
+		for _, prov := range outCommitInfo.Provenance {
+			_, err := client.InspectCommit(prov.Repo.Name, prov.ID)
+			require.NoError(t, err)
+		}
+	}
+	fileInfos, err = client.ListFile(repo, "master", "/")
+	require.NoError(t, err)
+	require.Equal(t, 16, len(fileInfos))
+
+	require.YesError(t, client.SetMaxHistoryDepth(repo, "master", -1))
+}
+
+func TestMaxHistoryDepthBatches(t *testing.T) {
+	client := GetPachClient(t)
+
+	// Setting the depth of a history that's longer than one batch prunes it
+	// over several transactions
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	numCommits := 2*pruneBatchSize + 10
+	for i := 0; i < numCommits; i++ {
+		_, err := client.PutFile(repo, "master", fmt.Sprintf("/%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, client.SetMaxHistoryDepth(repo, "master", 2))
+	commitInfos, err := client.ListCommit(repo, "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(commitInfos))
+	require.Nil(t, commitInfos[1].ParentCommit)
+	fileInfos, err := client.ListFile(repo, "master", "/")
+	require.NoError(t, err)
+	require.Equal(t, numCommits, len(fileInfos))
+}
+
+func TestSwapBranch(t *testing.T) {
+	client := GetPachClient(t)
+
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	_, err := client.PutFile(repo, "production", "/file", strings.NewReader("blue"))
+	require.NoError(t, err)
+	_, err = client.PutFile(repo, "staging", "/file", strings.NewReader("green"))
+	require.NoError(t, err)
+	production, err := client.InspectCommit(repo, "production")
+	require.NoError(t, err)
+	staging, err := client.InspectCommit(repo, "staging")
+	require.NoError(t, err)
+
+	// 'both' is downstream of both branches, and 'out' of production only
+	require.NoError(t, client.CreateRepo("both"))
+	require.NoError(t, client.CreateBranch("both", "master", "", []*pfs.Branch{
+		pclient.NewBranch(repo, "production"),
+		pclient.NewBranch(repo, "staging"),
+	}))
+	require.NoError(t, client.CreateRepo("out"))
+	require.NoError(t, client.CreateBranch("out", "master", "", []*pfs.Branch{pclient.NewBranch(repo, "production")}))
+	numCommits := func(repo string) int {
+		commitInfos, err := client.ListCommit(repo, "master", "", 0)
+		require.NoError(t, err)
+		return len(commitInfos)
+	}
+	bothCommits, outCommits := numCommits("both"), numCommits("out")
+
+	require.NoError(t, client.SwapBranch(repo, "production", "staging"))
+	var buf bytes.Buffer
+	require.NoError(t, client.GetFile(repo, "production", "/file", 0, 0, &buf))
+	require.Equal(t, "green", buf.String())
+	buf.Reset()
+	require.NoError(t, client.GetFile(repo, "staging", "/file", 0, 0, &buf))
+	require.Equal(t, "blue", buf.String())
+
+	// 'both' never sees an intermediate state: swapping one branch at a time
+	// would have given it a commit provenant on only one of the two heads,
+	// while swapping them atomically leaves its inputs unchanged
+	require.Equal(t, bothCommits, numCommits("both"))
+	bothInfo, err := client.InspectCommit("both", "master")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(bothInfo.Provenance))
+	for _, c := range bothInfo.Provenance {
+		require.EqualOneOf(t, []string{production.Commit.ID, staging.Commit.ID}, c.ID)
+	}
+	// 'out' gets a single new commit, of production's new head
+	require.Equal(t, outCommits+1, numCommits("out"))
+	outInfo, err := client.InspectCommit("out", "master")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(outInfo.Provenance))
+	require.Equal(t, staging.Commit.ID, outInfo.Provenance[0].ID)
+	outBranch, err := client.InspectBranch("out", "master")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(outBranch.Provenance))
+	require.Equal(t, "production", outBranch.Provenance[0].Name)
+
+	// Branches can be swapped back
+	require.NoError(t, client.SwapBranch(repo, "staging", "production"))
+	outInfo, err = client.InspectCommit("out", "master")
+	require.NoError(t, err)
+	require.Equal(t, production.Commit.ID, outInfo.Provenance[0].ID)
+
+	// Branches that don't exist can't be swapped, and neither can branches in
+	// different repos (such as a branch and a branch downstream of it)
+	require.YesError(t, client.SwapBranch(repo, "production", "missing"))
+	_, err = client.PfsAPIClient.SwapBranch(client.Ctx(), &pfs.SwapBranchRequest{
+		Branch: pclient.NewBranch(repo, "production"),
+		Other:  pclient.NewBranch("out", "master"),
+	})
+	require.YesError(t, err)
+	require.Matches(t, "different repos", err.Error())
+	require.YesError(t, client.SwapBranch(repo, "production", "production"))
+	outBranch, err = client.InspectBranch("out", "master")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(outBranch.Provenance))
+}
+
+func TestSquashCommit(t *testing.T) {
+	client := GetPachClient(t)
+
+	require.NoError(t, client.CreateRepo("in"))
+	require.NoError(t, client.CreateRepo("out"))
+	require.NoError(t, client.CreateBranch("out", "master", "", []*pfs.Branch{pclient.NewBranch("in", "master")}))
+	var inCommits []*pfs.Commit
+	for i := 0; i < 4; i++ {
+		_, err := client.PutFile("in", "master", fmt.Sprintf("/%d", i), strings.NewReader(fmt.Sprint(i)))
+		require.NoError(t, err)
+		commitInfo, err := client.InspectCommit("in", "master")
+		require.NoError(t, err)
+		inCommits = append(inCommits, commitInfo.Commit)
+	}
+	// The head of another branch is never deleted
+	require.NoError(t, client.CreateBranch("in", "keep", inCommits[0].ID, nil))
+	// Leave the downstream commit of the second input commit open, as if a
+	// downstream job were still processing it
+	outCommitInfos, err := client.ListCommit("out", "master", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(outCommitInfos))
+	for i, outCommitInfo := range outCommitInfos {
+		if i != 2 {
+			require.NoError(t, client.FinishCommit("out", outCommitInfo.Commit.ID))
+		}
+	}
+
+	require.NoError(t, client.SquashCommit("in", inCommits[3].ID))
+	commitIDs := func(repo string) []string {
+		commitInfos, err := client.ListCommit(repo, "master", "", 0)
+		require.NoError(t, err)
+		var result []string
+		for _, commitInfo := range commitInfos {
+			result = append(result, commitInfo.Commit.ID)
+		}
+		return result
+	}
+	require.Equal(t, []string{inCommits[3].ID, inCommits[1].ID, inCommits[0].ID}, commitIDs("in"))
+	require.Equal(t, []string{outCommitInfos[0].Commit.ID, outCommitInfos[2].Commit.ID, outCommitInfos[3].Commit.ID}, commitIDs("out"))
+	// The content of the commit is unchanged
+	fileInfos, err := client.ListFile("in", "master", "/")
+	require.NoError(t, err)
+	require.Equal(t, 4, len(fileInfos))
+
+	// Once the open downstream commit is finished and the other branch is
+	// deleted, the rest of the history can be squashed
+	require.NoError(t, client.FinishCommit("out", outCommitInfos[2].Commit.ID))
+	require.NoError(t, client.DeleteBranch("in", "keep", false))
+	require.NoError(t, client.SquashCommit("in", inCommits[3].ID))
+	require.Equal(t, []string{inCommits[3].ID}, commitIDs("in"))
+	require.Equal(t, []string{outCommitInfos[0].Commit.ID}, commitIDs("out"))
+	_, err = client.InspectCommit("in", inCommits[0].ID)
+	require.YesError(t, err)
+}
+
+func TestDiskUsage(t *testing.T) {
+	client := GetPachClient(t)
+
+	shared := strings.Repeat("shared\n", 100) // 700 bytes
+	other := strings.Repeat("other\n", 100)   // 600 bytes
+	require.NoError(t, client.CreateRepo("repo1"))
+	require.NoError(t, client.CreateRepo("repo2"))
+	// repo1 stores the shared data twice, in two commits
+	_, err := client.PutFile("repo1", "master", "/a", strings.NewReader(shared))
+	require.NoError(t, err)
+	_, err = client.PutFile("repo1", "master", "/b", strings.NewReader(shared))
+	require.NoError(t, err)
+	// repo2 stores the shared data once, and data of its own
+	_, err = client.PutFile("repo2", "master", "/c", strings.NewReader(shared))
+	require.NoError(t, err)
+	_, err = client.PutFile("repo2", "master", "/d", strings.NewReader(other))
+	require.NoError(t, err)
+
+	repoUsages, err := client.DiskUsage("")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(repoUsages))
+	require.Equal(t, "repo1", repoUsages[0].Repo.Name)
+	require.Equal(t, uint64(700+1400), repoUsages[0].LogicalBytes)
+	require.Equal(t, uint64(700), repoUsages[0].PhysicalBytes)
+	require.Equal(t, uint64(0), repoUsages[0].ExclusiveBytes)
+	require.Equal(t, "repo2", repoUsages[1].Repo.Name)
+	require.Equal(t, uint64(700+1300), repoUsages[1].LogicalBytes)
+	require.Equal(t, uint64(1300), repoUsages[1].PhysicalBytes)
+	require.Equal(t, uint64(600), repoUsages[1].ExclusiveBytes)
+
+	// The commits' data is cached by the first call, and reused
+	repoUsages, err = client.DiskUsage("repo2")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(repoUsages))
+	require.Equal(t, uint64(1300), repoUsages[0].PhysicalBytes)
+	require.Equal(t, uint64(600), repoUsages[0].ExclusiveBytes)
+
+	// Once repo2 is deleted, all of repo1's data is exclusive to it
+	require.NoError(t, client.DeleteRepo("repo2", false))
+	repoUsages, err = client.DiskUsage("repo1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(700), repoUsages[0].ExclusiveBytes)
+	_, err = client.DiskUsage("repo2")
+	require.YesError(t, err)
+}
+
+func TestListCommitBranches(t *testing.T) {
+	client := GetPachClient(t)
+
+	require.NoError(t, client.CreateRepo("in"))
+	require.NoError(t, client.CreateRepo("out"))
+	require.NoError(t, client.CreateBranch("out", "master", "", []*pfs.Branch{pclient.NewBranch("in", "master")}))
+	_, err := client.PutFile("in", "master", "/1", strings.NewReader("1"))
+	require.NoError(t, err)
+	commit1, err := client.InspectCommit("in", "master")
+	require.NoError(t, err)
+	require.NoError(t, client.CreateBranch("in", "pinned", commit1.Commit.ID, nil))
+	_, err = client.PutFile("in", "master", "/2", strings.NewReader("2"))
+	require.NoError(t, err)
+	commit2, err := client.InspectCommit("in", "master")
+	require.NoError(t, err)
+	outHead, err := client.InspectCommit("out", "master")
+	require.NoError(t, err)
+
+	branchNames := func(repo string, commitID string, history bool) []string {
+		branchInfos, err := client.ListCommitBranches(repo, commitID, history)
+		require.NoError(t, err)
+		var result []string
+		for _, branchInfo := range branchInfos {
+			result = append(result, branchInfo.Branch.Repo.Name+"@"+branchInfo.Branch.Name)
+		}
+		sort.Strings(result)
+		return result
+	}
+	require.Equal(t, []string{"in@pinned"}, branchNames("in", commit1.Commit.ID, false))
+	require.Equal(t, []string{"in@master", "in@pinned", "out@master"}, branchNames("in", commit1.Commit.ID, true))
+	require.Equal(t, []string{"in@master"}, branchNames("in", commit2.Commit.ID, false))
+	require.Equal(t, []string{"in@master", "out@master"}, branchNames("in", commit2.Commit.ID, true))
+	require.Equal(t, []string{"out@master"}, branchNames("out", outHead.Commit.ID, true))
+	// Branch names are resolved to their head
+	require.Equal(t, []string{"in@master"}, branchNames("in", "master", false))
+}
+
 func TestDeleteCommit(t *testing.T) {
 	client := GetPachClient(t)
 
@@ -972,6 +1608,69 @@ func TestPutFile(t *testing.T) {
 	require.NoError(t, client.GetFile(repo, commit4.ID, "dir2", 0, 0, &buffer))
 }
 
+func TestConcurrentPutFile(t *testing.T) {
+	client := GetPachClient(t)
+
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+	commit, err := client.StartCommit(repo, "master")
+	require.NoError(t, err)
+
+	// Each stream puts its own files, appends to one of its files twice, and
+	// appends to a file shared by all of the streams
+	streams, filesPerStream := 8, 250
+	var eg errgroup.Group
+	for i := 0; i < streams; i++ {
+		i := i
+		eg.Go(func() (retErr error) {
+			pfc, err := client.NewPutFileClient()
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := pfc.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+			for j := 0; j < filesPerStream; j++ {
+				if _, err := pfc.PutFile(repo, commit.ID, fmt.Sprintf("dir%d/file%d", i, j), strings.NewReader(fmt.Sprintf("%d-%d\n", i, j))); err != nil {
+					return err
+				}
+			}
+			for _, content := range []string{"a\n", "b\n"} {
+				if _, err := pfc.PutFile(repo, commit.ID, fmt.Sprintf("dir%d/file0", i), strings.NewReader(content)); err != nil {
+					return err
+				}
+			}
+			_, err = pfc.PutFile(repo, commit.ID, "shared", strings.NewReader(fmt.Sprintf("%d\n", i)))
+			return err
+		})
+	}
+	require.NoError(t, eg.Wait())
+	require.NoError(t, client.FinishCommit(repo, commit.ID))
+
+	for i := 0; i < streams; i++ {
+		fileInfos, err := client.ListFile(repo, commit.ID, fmt.Sprintf("dir%d", i))
+		require.NoError(t, err)
+		require.Equal(t, filesPerStream, len(fileInfos))
+		var buf bytes.Buffer
+		require.NoError(t, client.GetFile(repo, commit.ID, fmt.Sprintf("dir%d/file0", i), 0, 0, &buf))
+		require.Equal(t, fmt.Sprintf("%d-0\na\nb\n", i), buf.String())
+		buf.Reset()
+		require.NoError(t, client.GetFile(repo, commit.ID, fmt.Sprintf("dir%d/file%d", i, filesPerStream-1), 0, 0, &buf))
+		require.Equal(t, fmt.Sprintf("%d-%d\n", i, filesPerStream-1), buf.String())
+	}
+	var buf bytes.Buffer
+	require.NoError(t, client.GetFile(repo, commit.ID, "shared", 0, 0, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	sort.Strings(lines)
+	var expected []string
+	for i := 0; i < streams; i++ {
+		expected = append(expected, fmt.Sprintf("%d", i))
+	}
+	require.Equal(t, expected, lines)
+}
+
 func TestPutFile2(t *testing.T) {
 	client := GetPachClient(t)
 
@@ -1743,6 +2442,97 @@ func TestListCommit(t *testing.T) {
 	}
 }
 
+func TestListCommitByLabel(t *testing.T) {
+	client := GetPachClient(t)
+
+	repo := "test"
+	require.NoError(t, client.CreateRepo(repo))
+
+	commit1, err := client.StartCommitLabels(repo, "master", map[string]string{"release": "v1", "env": "prod"})
+	require.NoError(t, err)
+	require.NoError(t, client.FinishCommit(repo, commit1.ID))
+	commit2, err := client.StartCommitLabels(repo, "master", map[string]string{"release": "v2"})
+	require.NoError(t, err)
+	// Labels set in FinishCommit are added to those set in StartCommit
+	require.NoError(t, client.FinishCommitLabels(repo, commit2.ID, map[string]string{"env": "staging"}))
+	commit3, err := client.StartCommit(repo, "master")
+	require.NoError(t, err)
+	require.NoError(t, client.FinishCommitLabels(repo, commit3.ID, map[string]string{"release": "v2", "env": "dev"}))
+
+	commitInfo, err := client.InspectCommit(repo, commit2.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"release": "v2", "env": "staging"}, commitInfo.Labels)
+
+	commitIDs := func(selector string) []string {
+		commitInfos, err := client.ListCommitByLabel(repo, selector)
+		require.NoError(t, err)
+		var result []string
+		for _, commitInfo := range commitInfos {
+			result = append(result, commitInfo.Commit.ID)
+		}
+		sort.Strings(result)
+		return result
+	}
+	sorted := func(IDs ...string) []string {
+		sort.Strings(IDs)
+		return IDs
+	}
+	// equality
+	require.Equal(t, sorted(commit1.ID), commitIDs("release=v1"))
+	require.Equal(t, sorted(commit2.ID, commit3.ID), commitIDs("release=v2"))
+	require.Equal(t, sorted(commit2.ID), commitIDs("release=v2,env=staging"))
+	require.Equal(t, sorted(commit1.ID), commitIDs("release!=v2,env!=staging"))
+	// set membership
+	require.Equal(t, sorted(commit1.ID, commit2.ID), commitIDs("env in (prod,staging)"))
+	require.Equal(t, sorted(commit3.ID), commitIDs("env notin (prod,staging)"))
+	require.Equal(t, sorted(commit1.ID, commit2.ID, commit3.ID), commitIDs("release"))
+	require.Equal(t, 0, len(commitIDs("release=v3")))
+	// an empty selector matches every commit
+	require.Equal(t, sorted(commit1.ID, commit2.ID, commit3.ID), commitIDs(""))
+
+	// The selector also applies when listing a branch, and 'number' limits the
+	// number of matching commits
+	var branchIDs []string
+	require.NoError(t, client.ListCommitLabelF(repo, "master", "", 1, "release=v2", func(ci *pfs.CommitInfo) error {
+		branchIDs = append(branchIDs, ci.Commit.ID)
+		return nil
+	}))
+	require.Equal(t, []string{commit3.ID}, branchIDs)
+
+	// Invalid selectors and labels are rejected
+	_, err = client.ListCommitByLabel(repo, "release in v2")
+	require.YesError(t, err)
+	_, err = client.StartCommitLabels(repo, "master", map[string]string{"release": "not a valid value"})
+	require.YesError(t, err)
+}
+
+func TestPropagateCommitLabels(t *testing.T) {
+	client := GetPachClient(t)
+
+	require.NoError(t, client.CreateRepo("in1"))
+	require.NoError(t, client.CreateRepo("in2"))
+	require.NoError(t, client.CreateRepo("out"))
+	require.NoError(t, client.CreateBranch("out", "master", "", []*pfs.Branch{
+		pclient.NewBranch("in1", "master"),
+		pclient.NewBranch("in2", "master"),
+	}))
+
+	commit1, err := client.StartCommitLabels("in1", "master", map[string]string{"release": "v1", "source": "in1"})
+	require.NoError(t, err)
+	require.NoError(t, client.FinishCommit("in1", commit1.ID))
+	outCommitInfo, err := client.InspectCommit("out", "master")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"release": "v1", "source": "in1"}, outCommitInfo.Labels)
+
+	// Labels that the input commits disagree on aren't propagated
+	commit2, err := client.StartCommitLabels("in2", "master", map[string]string{"release": "v1", "source": "in2"})
+	require.NoError(t, err)
+	require.NoError(t, client.FinishCommit("in2", commit2.ID))
+	outCommitInfo, err = client.InspectCommit("out", "master")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"release": "v1"}, outCommitInfo.Labels)
+}
+
 func TestOffsetRead(t *testing.T) {
 	client := GetPachClient(t)
 
@@ -3293,6 +4083,247 @@ func TestBuildCommit(t *testing.T) {
 	require.Equal(t, uint64(fooSize+barSize), commitInfo.SizeBytes)
 }
 
+func TestGetCommitTree(t *testing.T) {
+	c := GetPachClient(t)
+	repo := tu.UniqueString("TestGetCommitTree")
+	require.NoError(t, c.CreateRepo(repo))
+
+	// Build a commit from a known tree
+	tree, err := hashtree.NewDBHashTree("")
+	require.NoError(t, err)
+	for _, file := range []string{"foo", "dir/bar", "dir/sub/baz", "dir/sub/qux"} {
+		object, size, err := c.PutObject(strings.NewReader(file + "\n"))
+		require.NoError(t, err)
+		require.NoError(t, tree.PutFile(file, []*pfs.Object{object}, size))
+	}
+	require.NoError(t, tree.Hash())
+	treeObj, err := hashtree.PutHashTree(c, tree)
+	require.NoError(t, err)
+	commit, err := c.BuildCommit(repo, "master", "", treeObj.Hash)
+	require.NoError(t, err)
+
+	var expected []*pfs.TreeNode
+	require.NoError(t, tree.Walk("/", func(path string, node *hashtree.NodeProto) error {
+		fileType := pfs.FileType_FILE
+		if node.DirNode != nil {
+			fileType = pfs.FileType_DIR
+		}
+		expected = append(expected, &pfs.TreeNode{
+			Path:      path,
+			FileType:  fileType,
+			Hash:      node.Hash,
+			SizeBytes: uint64(node.SubtreeSize),
+		})
+		return nil
+	}))
+	var nodes []*pfs.TreeNode
+	require.NoError(t, c.GetCommitTree(repo, commit.ID, func(node *pfs.TreeNode) error {
+		nodes = append(nodes, node)
+		return nil
+	}))
+	require.Equal(t, 7, len(nodes))
+	require.Equal(t, expected, nodes)
+
+	// Directories' hashes change with their descendants
+	commit2, err := c.StartCommit(repo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(repo, commit2.ID, "dir/sub/new", strings.NewReader("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(repo, commit2.ID))
+	hashes := make(map[string][]byte)
+	require.NoError(t, c.GetCommitTree(repo, commit2.ID, func(node *pfs.TreeNode) error {
+		hashes[node.Path] = node.Hash
+		return nil
+	}))
+	for _, node := range nodes {
+		switch node.Path {
+		case "/", "/dir", "/dir/sub":
+			require.NotEqual(t, node.Hash, hashes[node.Path])
+		default:
+			require.Equal(t, node.Hash, hashes[node.Path])
+		}
+	}
+}
+
+func TestGetManifest(t *testing.T) {
+	c := GetPachClient(t)
+	repo := tu.UniqueString("TestGetManifest")
+	require.NoError(t, c.CreateRepo(repo))
+	commit, err := c.StartCommit(repo, "master")
+	require.NoError(t, err)
+	files := []string{"foo", "dir/bar", "dir/sub/baz", "empty"}
+	for _, file := range files {
+		content := file + "\n"
+		if file == "empty" {
+			content = ""
+		}
+		_, err = c.PutFile(repo, commit.ID, file, strings.NewReader(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(repo, commit.ID))
+
+	readManifest := func(path string) []*pfs.ManifestEntry {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetManifest(repo, commit.ID, path, &buf))
+		var entries []*pfs.ManifestEntry
+		decoder := json.NewDecoder(&buf)
+		for decoder.More() {
+			entry := &pfs.ManifestEntry{}
+			require.NoError(t, decoder.Decode(entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	// The manifest lists every file, in order of path, with the sha256 digest
+	// of its content, and the same size and node hash that InspectFile returns
+	entries := readManifest("")
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+		fileInfo, err := c.InspectFile(repo, commit.ID, entry.Path)
+		require.NoError(t, err)
+		require.Equal(t, pfs.EncodeHash(fileInfo.Hash), entry.NodeHash)
+		require.Equal(t, fileInfo.SizeBytes, entry.SizeBytes)
+		var content bytes.Buffer
+		require.NoError(t, c.GetFile(repo, commit.ID, entry.Path, 0, 0, &content))
+		digest := sha256.Sum256(content.Bytes())
+		require.Equal(t, hex.EncodeToString(digest[:]), entry.SHA256)
+	}
+	require.Equal(t, "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c", entries[3].SHA256)
+	require.Equal(t, []string{"/dir/bar", "/dir/sub/baz", "/empty", "/foo"}, paths)
+	require.Equal(t, uint64(len("foo\n")), entries[3].SizeBytes)
+
+	// Manifests can be limited to a directory
+	entries = readManifest("dir")
+	require.Equal(t, 2, len(entries))
+	require.Equal(t, "/dir/bar", entries[0].Path)
+	require.Equal(t, "/dir/sub/baz", entries[1].Path)
+}
+
+func TestSyncDir(t *testing.T) {
+	c := GetPachClient(t)
+	repo := tu.UniqueString("TestSyncDir")
+	require.NoError(t, c.CreateRepo(repo))
+	dir, err := ioutil.TempDir("", "TestSyncDir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeFile := func(name string, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	checkFiles := func(commit *pfs.Commit, expected map[string]string) {
+		files := make(map[string]string)
+		require.NoError(t, c.Walk(repo, commit.ID, "/", func(fileInfo *pfs.FileInfo) error {
+			if fileInfo.FileType == pfs.FileType_FILE {
+				var buf bytes.Buffer
+				require.NoError(t, c.GetFile(repo, commit.ID, fileInfo.File.Path, 0, 0, &buf))
+				files[fileInfo.File.Path] = buf.String()
+			}
+			return nil
+		}))
+		require.Equal(t, expected, files)
+	}
+	writeFile("foo", "foo\n")
+	writeFile("empty", "")
+	writeFile("dir/bar", "bar\n")
+	writeFile("dir/sub/baz", "baz\n")
+	writeFile("old/qux", "qux\n")
+
+	commit1, err := c.SyncDir(repo, "master", dir)
+	require.NoError(t, err)
+	checkFiles(commit1, map[string]string{
+		"/foo":         "foo\n",
+		"/empty":       "",
+		"/dir/bar":     "bar\n",
+		"/dir/sub/baz": "baz\n",
+		"/old/qux":     "qux\n",
+	})
+
+	// If nothing changed, nothing is uploaded, and no commit is made
+	commit, err := c.SyncDir(repo, "master", dir)
+	require.NoError(t, err)
+	require.Equal(t, commit1.ID, commit.ID)
+
+	// Changes, additions and deletions are applied in one commit
+	writeFile("dir/bar", "bar2\n")
+	writeFile("new", "new\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "dir/sub/baz")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "old")))
+	commit2, err := c.SyncDir(repo, "master", dir)
+	require.NoError(t, err)
+	commitInfo, err := c.InspectCommit(repo, commit2.ID)
+	require.NoError(t, err)
+	require.Equal(t, commit1.ID, commitInfo.ParentCommit.ID)
+	checkFiles(commit2, map[string]string{
+		"/foo":     "foo\n",
+		"/empty":   "",
+		"/dir/bar": "bar2\n",
+		"/new":     "new\n",
+	})
+	// Files and directories that are no longer in the local directory are deleted
+	_, err = c.InspectFile(repo, commit2.ID, "old")
+	require.YesError(t, err)
+	_, err = c.InspectFile(repo, commit2.ID, "dir/sub/baz")
+	require.YesError(t, err)
+
+	// Files that were written in several pieces don't hash the same as their
+	// local copies, so they're uploaded again, in one piece
+	commit3, err := c.StartCommit(repo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(repo, commit3.ID, "foo", strings.NewReader("more\n"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(repo, commit3.ID))
+	writeFile("foo", "foo\nmore\n")
+	commit4, err := c.SyncDir(repo, "master", dir)
+	require.NoError(t, err)
+	require.NotEqual(t, commit3.ID, commit4.ID)
+	checkFiles(commit4, map[string]string{
+		"/foo":     "foo\nmore\n",
+		"/empty":   "",
+		"/dir/bar": "bar2\n",
+		"/new":     "new\n",
+	})
+	commit, err = c.SyncDir(repo, "master", dir)
+	require.NoError(t, err)
+	require.Equal(t, commit4.ID, commit.ID)
+}
+
+func TestListObjectsPrefix(t *testing.T) {
+	c := GetPachClient(t)
+
+	var objects []*pfs.Object
+	for i := 0; i < 20; i++ {
+		object, _, err := c.PutObject(strings.NewReader(fmt.Sprintf("object %d\n", i)))
+		require.NoError(t, err)
+		objects = append(objects, object)
+	}
+	listObjects := func(prefix string) map[string]bool {
+		listObjectsClient, err := c.ObjectAPIClient.ListObjects(c.Ctx(), &pfs.ListObjectsRequest{Prefix: prefix})
+		require.NoError(t, err)
+		result := make(map[string]bool)
+		for object, err := listObjectsClient.Recv(); err != io.EOF; object, err = listObjectsClient.Recv() {
+			require.NoError(t, err)
+			result[object.Hash] = true
+		}
+		return result
+	}
+	all := listObjects("")
+	for _, object := range objects {
+		require.True(t, all[object.Hash])
+	}
+	// Listing each shard of the keyspace lists every object exactly once
+	total := 0
+	for _, prefix := range "0123456789abcdef" {
+		shard := listObjects(string(prefix))
+		for hash := range shard {
+			require.True(t, strings.HasPrefix(hash, string(prefix)))
+		}
+		total += len(shard)
+	}
+	require.Equal(t, len(all), total)
+}
+
 func TestPropagateCommit(t *testing.T) {
 	c := GetPachClient(t)
 	repo1 := tu.UniqueString("TestPropagateCommit1")
@@ -4712,3 +5743,144 @@ func TestFileHistory(t *testing.T) {
 		require.Equal(t, i, len(fileInfos))
 	}
 }
+
+func TestMaxCommitFiles(t *testing.T) {
+	c := GetPachClient(t)
+	repo := tu.UniqueString("TestMaxCommitFiles")
+	_, err := c.PfsAPIClient.CreateRepo(c.Ctx(), &pfs.CreateRepoRequest{
+		Repo:           &pfs.Repo{Name: repo},
+		MaxCommitFiles: 2,
+	})
+	require.NoError(t, err)
+
+	commit, err := c.StartCommit(repo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"a", "b", "dir/c"} {
+		_, err = c.PutFile(repo, commit.ID, file, strings.NewReader("foo\n"))
+		require.NoError(t, err)
+	}
+	// The commit is rejected with an explanation, and is left open
+	err = c.FinishCommit(repo, commit.ID)
+	require.YesError(t, err)
+	require.Matches(t, "more than the limit of 2 files", err.Error())
+	require.Matches(t, "--max-commit-files", err.Error())
+	commitInfo, err := c.InspectCommit(repo, commit.ID)
+	require.NoError(t, err)
+	require.Nil(t, commitInfo.Finished)
+
+	// Once it's within the limit, it's finished
+	require.NoError(t, c.DeleteFile(repo, commit.ID, "dir"))
+	require.NoError(t, c.FinishCommit(repo, commit.ID))
+
+	// Only the files that a commit adds or changes count, not those it
+	// inherits from its parent
+	commit, err = c.StartCommit(repo, "master")
+	require.NoError(t, err)
+	for _, file := range []string{"dir/c", "dir/d"} {
+		_, err = c.PutFile(repo, commit.ID, file, strings.NewReader("foo\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FinishCommit(repo, commit.ID))
+
+	// Commits that copy-file makes itself are limited too
+	err = c.CopyFile(repo, commit.ID, "/", repo, "master", "copy", false)
+	require.YesError(t, err)
+	require.Matches(t, "more than the limit of 2 files", err.Error())
+
+	// Updating the repo without limits keeps its limits
+	_, err = c.PfsAPIClient.CreateRepo(c.Ctx(), &pfs.CreateRepoRequest{
+		Repo:        &pfs.Repo{Name: repo},
+		Description: "limited",
+		Update:      true,
+	})
+	require.NoError(t, err)
+	repoInfo, err := c.InspectRepo(repo)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), repoInfo.MaxCommitFiles)
+
+	// A negative limit lifts the limit for the repo
+	_, err = c.PfsAPIClient.CreateRepo(c.Ctx(), &pfs.CreateRepoRequest{
+		Repo:           &pfs.Repo{Name: repo},
+		MaxCommitFiles: -1,
+		Update:         true,
+	})
+	require.NoError(t, err)
+	repoInfo, err = c.InspectRepo(repo)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), repoInfo.MaxCommitFiles)
+	require.NoError(t, c.CopyFile(repo, commit.ID, "/", repo, "master", "copy", false))
+}
+
+func TestRepoCommitLimit(t *testing.T) {
+	// A repo's own limit overrides the cluster's, and a negative limit means
+	// no limit
+	require.Equal(t, int64(10), repoCommitLimit(0, 10))
+	require.Equal(t, int64(5), repoCommitLimit(5, 10))
+	require.Equal(t, int64(20), repoCommitLimit(20, 10))
+	require.Equal(t, int64(0), repoCommitLimit(-1, 10))
+	require.Equal(t, int64(0), repoCommitLimit(0, 0))
+}
+
+func TestProvenanceGraph(t *testing.T) {
+	c := GetPachClient(t)
+	in := tu.UniqueString("TestProvenanceGraph_in")
+	mid := tu.UniqueString("TestProvenanceGraph_mid")
+	out := tu.UniqueString("TestProvenanceGraph_out")
+	require.NoError(t, c.CreateRepo(in))
+	require.NoError(t, c.CreateRepo(mid))
+	require.NoError(t, c.CreateRepo(out))
+	// 'out' reads both 'mid' and 'in', which 'mid' reads too
+	require.NoError(t, c.CreateBranch(mid, "master", "", []*pfs.Branch{pclient.NewBranch(in, "master")}))
+	require.NoError(t, c.CreateBranch(out, "master", "", []*pfs.Branch{pclient.NewBranch(mid, "master"), pclient.NewBranch(in, "master")}))
+	inCommit, err := c.StartCommit(in, "master")
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(in, inCommit.ID))
+	midInfo, err := c.InspectCommit(mid, "master")
+	require.NoError(t, err)
+	outInfo, err := c.InspectCommit(out, "master")
+	require.NoError(t, err)
+
+	type node struct {
+		branch     string
+		depth      int64
+		provenance []string
+		truncated  bool
+	}
+	// graph returns the nodes that are streamed, by commit ID, and checks
+	// that each commit is streamed once, starting with 'out'
+	graph := func(maxDepth int64) map[string]node {
+		result := make(map[string]node)
+		require.NoError(t, c.ProvenanceGraph(out, "master", maxDepth, func(n *pfs.ProvenanceGraphNode) error {
+			if len(result) == 0 {
+				require.Equal(t, outInfo.Commit.ID, n.Commit.ID)
+			}
+			_, ok := result[n.Commit.ID]
+			require.False(t, ok)
+			var provenance []string
+			for _, commit := range n.Provenance {
+				provenance = append(provenance, commit.ID)
+			}
+			sort.Strings(provenance)
+			result[n.Commit.ID] = node{n.Branch.Repo.Name + "@" + n.Branch.Name, n.Depth, provenance, n.Truncated}
+			return nil
+		}))
+		return result
+	}
+	sorted := func(ids ...string) []string {
+		sort.Strings(ids)
+		return ids
+	}
+	// Each commit has edges to only the commits it's directly provenant on
+	require.Equal(t, map[string]node{
+		outInfo.Commit.ID: {out + "@master", 0, sorted(midInfo.Commit.ID, inCommit.ID), false},
+		midInfo.Commit.ID: {mid + "@master", 1, []string{inCommit.ID}, false},
+		inCommit.ID:       {in + "@master", 1, nil, false},
+	}, graph(0))
+
+	// With a max depth, the graph is cut off
+	require.Equal(t, map[string]node{
+		outInfo.Commit.ID: {out + "@master", 0, sorted(midInfo.Commit.ID, inCommit.ID), false},
+		midInfo.Commit.ID: {mid + "@master", 1, nil, true},
+		inCommit.ID:       {in + "@master", 1, nil, false},
+	}, graph(1))
+}
@@ -73,6 +73,21 @@ func runServers(t testing.TB, port int32, apiServer pfs.APIServer,
 // serving requests for them on a new port, and then returns a client connected
 // to the new servers (allows PFS tests to run in parallel without conflict)
 func GetPachClient(t testing.TB) *client.APIClient {
+	c, _ := newTestServer(t, "", 0)
+	return c
+}
+
+// getPachClientWithDedupScope is like GetPachClient, except that the new
+// PFSAPIServer deduplicates file content within 'dedupScope'
+func getPachClientWithDedupScope(t testing.TB, dedupScope string) *client.APIClient {
+	c, _ := newTestServer(t, dedupScope, 0)
+	return c
+}
+
+// newTestServer is like GetPachClient, but also returns the new
+// PFSAPIServer, which deduplicates file content within 'dedupScope' and lets
+// each client open 'maxPutFileStreams' put-file streams at once
+func newTestServer(t testing.TB, dedupScope string, maxPutFileStreams int) (*client.APIClient, *apiServer) {
 	// src/server/pfs/server/driver.go expects an etcd server at "localhost:32379"
 	// Try to establish a connection before proceeding with the test (which will
 	// fail if the connection can't be established)
@@ -95,17 +110,20 @@ func GetPachClient(t testing.TB) *client.APIClient {
 	serveAddress := fmt.Sprintf("localhost:%d", port)
 
 	// initialize new BlockAPIServier
-	blockAPIServer, err := newLocalBlockAPIServer(root, localBlockServerCacheBytes, etcdAddress)
-	require.NoError(t, err)
 	etcdPrefix := generateRandomString(32)
+	blockAPIServer, err := newLocalBlockAPIServer(root, localBlockServerCacheBytes, etcdAddress, etcdPrefix)
+	require.NoError(t, err)
 	treeCache, err := hashtree.NewCache(testingTreeCacheSize)
 	if err != nil {
 		panic(fmt.Sprintf("could not initialize treeCache: %v", err))
 	}
-	apiServer, err := newAPIServer(serveAddress, []string{"localhost:32379"}, etcdPrefix, treeCache, "/tmp", 64*1024*1024)
+	apiServer, err := newAPIServer(serveAddress, []string{"localhost:32379"}, etcdPrefix, treeCache, "/tmp", 64*1024*1024, APIServerOptions{
+		DedupScope:                 dedupScope,
+		MaxPutFileStreamsPerClient: maxPutFileStreams,
+	})
 	require.NoError(t, err)
 	runServers(t, servePort, apiServer, blockAPIServer)
 	c, err := client.NewFromAddress(serveAddress)
 	require.NoError(t, err)
-	return c
+	return c, apiServer
 }
@@ -172,6 +172,13 @@ func (c *readWriteCollection) getIndexPath(val interface{}, index *Index, key st
 func (c *readWriteCollection) getMultiIndexPaths(val interface{}, index *Index, key string) []string {
 	var indexPaths []string
 	field := reflect.Indirect(reflect.ValueOf(val)).FieldByName(index.Field)
+	if field.Kind() == reflect.Map {
+		for _, k := range field.MapKeys() {
+			indexVal := MapIndexVal(k.Interface(), field.MapIndex(k).Interface())
+			indexPaths = append(indexPaths, c.indexPath(index, indexVal, key))
+		}
+		return indexPaths
+	}
 	for i := 0; i < field.Len(); i++ {
 		indexPaths = append(indexPaths, c.indexPath(index, field.Index(i).Interface(), key))
 	}
@@ -551,6 +558,10 @@ func (c *readonlyCollection) WatchWithPrev() (watch.Watcher, error) {
 	return watch.NewWatcherWithPrev(c.ctx, c.etcdClient, c.prefix, c.prefix, c.template)
 }
 
+func (c *readonlyCollection) WatchWithPrevFrom(rev int64) (watch.Watcher, error) {
+	return watch.NewWatcherWithPrevFrom(c.ctx, c.etcdClient, c.prefix, c.prefix, rev, c.template)
+}
+
 // WatchByIndex watches items in a collection that match a particular index
 func (c *readonlyCollection) WatchByIndex(index *Index, val interface{}) (watch.Watcher, error) {
 	eventCh := make(chan *watch.Event)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -286,6 +287,57 @@ func TestMultiIndex(t *testing.T) {
 	}))
 }
 
+func TestMapIndex(t *testing.T) {
+	etcdClient := getEtcdClient()
+	uuidPrefix := uuid.NewWithoutDashes()
+
+	labelIndex := &Index{
+		Field: "Labels",
+		Multi: true,
+	}
+	cis := NewCollection(etcdClient, uuidPrefix, []*Index{labelIndex}, &pfs.CommitInfo{}, nil, nil)
+
+	c1 := &pfs.CommitInfo{
+		Commit: client.NewCommit("repo", "c1"),
+		Labels: map[string]string{"release": "v1", "env": "prod"},
+	}
+	c2 := &pfs.CommitInfo{
+		Commit: client.NewCommit("repo", "c2"),
+		Labels: map[string]string{"release": "v2", "env": "prod"},
+	}
+	_, err := NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		cis := cis.ReadWrite(stm)
+		cis.Put(c1.Commit.ID, c1)
+		cis.Put(c2.Commit.ID, c2)
+		return nil
+	})
+	require.NoError(t, err)
+
+	cisReadonly := cis.ReadOnly(context.Background())
+	getByLabel := func(key, val string) []string {
+		var IDs []string
+		ci := &pfs.CommitInfo{}
+		require.NoError(t, cisReadonly.GetByIndex(labelIndex, MapIndexVal(key, val), ci, DefaultOptions, func(ID string) error {
+			IDs = append(IDs, ID)
+			return nil
+		}))
+		sort.Strings(IDs)
+		return IDs
+	}
+	require.Equal(t, []string{"c1"}, getByLabel("release", "v1"))
+	require.Equal(t, []string{"c2"}, getByLabel("release", "v2"))
+	require.Equal(t, []string{"c1", "c2"}, getByLabel("env", "prod"))
+
+	// Changing a label moves the commit to the new entry
+	c1.Labels["env"] = "dev"
+	_, err = NewSTM(context.Background(), etcdClient, func(stm STM) error {
+		return cis.ReadWrite(stm).Put(c1.Commit.ID, c1)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c2"}, getByLabel("env", "prod"))
+	require.Equal(t, []string{"c1"}, getByLabel("env", "dev"))
+}
+
 func TestBoolIndex(t *testing.T) {
 	etcdClient := getEtcdClient()
 	uuidPrefix := uuid.NewWithoutDashes()
@@ -489,6 +541,46 @@ func TestIteration(t *testing.T) {
 	})
 }
 
+func TestWatchWithPrevFrom(t *testing.T) {
+	etcdClient := getEtcdClient()
+	uuidPrefix := uuid.NewWithoutDashes()
+
+	jobInfos := NewCollection(etcdClient, uuidPrefix, nil, &pps.JobInfo{}, nil, nil)
+	putJob := func(state pps.JobState) int64 {
+		resp, err := NewSTM(context.Background(), etcdClient, func(stm STM) error {
+			return jobInfos.ReadWrite(stm).Put("j1", &pps.JobInfo{Job: client.NewJob("j1"), State: state})
+		})
+		require.NoError(t, err)
+		return resp.Header.Revision
+	}
+	putJob(pps.JobState_JOB_STARTING)
+	rev := putJob(pps.JobState_JOB_RUNNING)
+	putJob(pps.JobState_JOB_SUCCESS)
+
+	// Watching from 'rev' replays the changes made since, rather than listing
+	// the current items
+	watcher, err := jobInfos.ReadOnly(context.Background()).WatchWithPrevFrom(rev)
+	require.NoError(t, err)
+	defer watcher.Close()
+	prevState := pps.JobState_JOB_STARTING
+	for _, expected := range []pps.JobState{pps.JobState_JOB_RUNNING, pps.JobState_JOB_SUCCESS} {
+		event := <-watcher.Watch()
+		require.NoError(t, event.Err)
+		var ID string
+		job, prev := &pps.JobInfo{}, &pps.JobInfo{}
+		require.NoError(t, event.Unmarshal(&ID, job))
+		require.NoError(t, event.UnmarshalPrev(&ID, prev))
+		require.Equal(t, expected, job.State)
+		require.Equal(t, prevState, prev.State)
+		prevState = expected
+	}
+	select {
+	case event := <-watcher.Watch():
+		t.Fatalf("should not have received an event %v", event)
+	case <-time.After(time.Second):
+	}
+}
+
 var etcdClient *etcd.Client
 var etcdClientOnce sync.Once
 
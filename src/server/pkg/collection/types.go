@@ -2,6 +2,7 @@ package collection
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
 
@@ -49,13 +50,20 @@ type Collection interface {
 //
 // Multi specifies whether this is a multi-index.  A multi-index is an index
 // on a field that's a slice.  The item is then indexed on each element of
-// the slice.
+// the slice.  A multi-index may also be on a field that's a map, in which
+// case the item is indexed on each entry of the map (see MapIndexVal).
 type Index struct {
 	Field string
 	Multi bool
 	limit int64
 }
 
+// MapIndexVal returns the index value of the map entry 'key: val', for
+// querying a multi-index on a map field.
+func MapIndexVal(key, val interface{}) string {
+	return fmt.Sprintf("%v=%v", key, val)
+}
+
 // ReadWriteCollection is a collection interface that supports read,write and delete
 // operations.
 type ReadWriteCollection interface {
@@ -109,6 +117,9 @@ type ReadonlyCollection interface {
 	// WatchWithPrev is like Watch, but the events will include the previous
 	// versions of the key/value.
 	WatchWithPrev() (watch.Watcher, error)
+	// WatchWithPrevFrom is like WatchWithPrev, but rather than listing the
+	// current items, it replays the changes made at or after revision 'rev'.
+	WatchWithPrevFrom(rev int64) (watch.Watcher, error)
 	WatchOne(key string) (watch.Watcher, error)
 	WatchByIndex(index *Index, val interface{}) (watch.Watcher, error)
 }
@@ -0,0 +1,100 @@
+// Package datum implements the parts of constructing a pipeline's datums
+// that don't depend on where the inputs' files are read from. It's shared by
+// the worker, which reads files from PFS, and the local pipeline runner (see
+// src/client/pps/local), which reads them from the local filesystem, so that
+// both construct the same datums in the same order.
+package datum
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	globlib "github.com/gobwas/glob"
+)
+
+var globRegex = regexp.MustCompile(`[*?\[\]\{\}!]`)
+
+// Glob matches paths against an input's glob pattern the way that PFS's
+// GlobFile does
+type Glob struct {
+	pattern string
+	g       globlib.Glob
+}
+
+// NewGlob returns the Glob for 'pattern'
+func NewGlob(pattern string) (*Glob, error) {
+	result := &Glob{pattern: path.Clean("/" + pattern)}
+	if globRegex.MatchString(result.pattern) {
+		g, err := globlib.Compile(result.pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("malformed glob %s: %v", pattern, err)
+		}
+		result.g = g
+	}
+	return result, nil
+}
+
+// Match returns true if 'p' (an absolute, clean path) is matched by the glob.
+// A pattern without glob characters only matches itself, and the root is only
+// matched by a literal "/".
+func (g *Glob) Match(p string) bool {
+	if g.g == nil {
+		return p == g.pattern
+	}
+	return p != "/" && g.g.Match(p)
+}
+
+// File is the part of a datum's input that determines the order in which
+// datums are processed
+type File struct {
+	Path string
+	// SizeBytes is the size of the file (or of all files under the
+	// directory), or of the part of it that's in the datum if it was split
+	SizeBytes int64
+	// OffsetBytes is the offset of the part of the file that's in the datum,
+	// if it was split
+	OffsetBytes int64
+}
+
+// Less returns true if the datum for 'a' is processed before the datum for
+// 'b'. Datums are sorted by descending size, because it can boost performance
+// to process the biggest datums first, and then by path and offset, so that
+// the order is deterministic (two files can't have the same path and offset).
+func Less(a, b File) bool {
+	if a.SizeBytes != b.SizeBytes {
+		return a.SizeBytes > b.SizeBytes
+	}
+	if a.Path != b.Path {
+		return a.Path < b.Path
+	}
+	return a.OffsetBytes < b.OffsetBytes
+}
+
+// CrossLen returns the number of datums in the cross product of inputs with
+// 'lens' datums each
+func CrossLen(lens []int) int {
+	if len(lens) == 0 {
+		return 0
+	}
+	result := 1
+	for _, n := range lens {
+		result *= n
+	}
+	return result
+}
+
+// CrossIndexes returns the index of the datum from each input (of inputs
+// with 'lens' datums each) that make up the i'th datum of their cross
+// product. The first input's index varies fastest.
+func CrossIndexes(i int, lens []int) []int {
+	if i >= CrossLen(lens) {
+		panic("index out of bounds")
+	}
+	result := make([]int, len(lens))
+	for j, n := range lens {
+		result[j] = i % n
+		i /= n
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package datum
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestGlob(t *testing.T) {
+	g, err := NewGlob("/*")
+	require.NoError(t, err)
+	require.True(t, g.Match("/a"))
+	require.False(t, g.Match("/a/b"))
+	require.False(t, g.Match("/"))
+
+	g, err = NewGlob("/")
+	require.NoError(t, err)
+	require.True(t, g.Match("/"))
+	require.False(t, g.Match("/a"))
+
+	_, err = NewGlob("/[")
+	require.YesError(t, err)
+}
+
+func TestLess(t *testing.T) {
+	files := []File{
+		{Path: "/b", SizeBytes: 1},
+		{Path: "/a", SizeBytes: 1},
+		{Path: "/c", SizeBytes: 2, OffsetBytes: 10},
+		{Path: "/c", SizeBytes: 2},
+	}
+	sort.Slice(files, func(i, j int) bool { return Less(files[i], files[j]) })
+	require.Equal(t, []File{
+		{Path: "/c", SizeBytes: 2},
+		{Path: "/c", SizeBytes: 2, OffsetBytes: 10},
+		{Path: "/a", SizeBytes: 1},
+		{Path: "/b", SizeBytes: 1},
+	}, files)
+}
+
+func TestCross(t *testing.T) {
+	lens := []int{2, 3}
+	require.Equal(t, 6, CrossLen(lens))
+	require.Equal(t, 0, CrossLen(nil))
+	seen := make(map[[2]int]bool)
+	for i := 0; i < CrossLen(lens); i++ {
+		indexes := CrossIndexes(i, lens)
+		seen[[2]int{indexes[0], indexes[1]}] = true
+	}
+	require.Equal(t, 6, len(seen))
+	require.Equal(t, []int{1, 0}, CrossIndexes(1, lens))
+	require.Equal(t, []int{0, 1}, CrossIndexes(2, lens))
+}
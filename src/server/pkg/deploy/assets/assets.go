@@ -11,9 +11,13 @@ import (
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	auth "github.com/pachyderm/pachyderm/src/server/auth/server"
+	"github.com/pachyderm/pachyderm/src/server/pfs/csi"
 	pfs "github.com/pachyderm/pachyderm/src/server/pfs/server"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/statsd"
 	"github.com/pachyderm/pachyderm/src/server/pps/server/githook"
+	appsv1 "k8s.io/api/apps/v1"
 	apps "k8s.io/api/apps/v1beta1"
 	"k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -35,6 +39,9 @@ var (
 	workerImage    = "pachyderm/worker"
 	pauseImage     = "gcr.io/google_containers/pause-amd64:3.0"
 	dashImage      = "pachyderm/dash"
+	pfsCSIImage    = "pachyderm/pfs-csi"
+	// The sidecar that registers the PFS CSI node plugin with the kubelet
+	csiRegistrarImage = "quay.io/k8scsi/csi-node-driver-registrar:v1.0.2"
 
 	// ServiceAccountName is the name of Pachyderm's service account.
 	// It's public because it's needed by pps.APIServer to create the RCs for
@@ -48,6 +55,10 @@ var (
 	defaultEtcdStorageClassName = "etcd-storage-class"
 	grpcProxyName               = "grpc-proxy"
 	pachdName                   = "pachd"
+	pfsCSIName                  = "pfs-csi"
+	// The directory on each node where the kubelet finds the PFS CSI node
+	// plugin's socket, and where the plugin keeps its state
+	pfsCSIPluginDir = "/var/lib/kubelet/plugins/" + csi.DriverName
 	// PrometheusPort hosts the prometheus stats for scraping
 	PrometheusPort = 9091
 
@@ -60,16 +71,43 @@ var (
 		APIGroups: []string{""},
 		Verbs:     []string{"get", "list", "watch"},
 		Resources: []string{"nodes", "pods", "pods/log", "endpoints"},
+	}, {
+		// Worker pods that fail for infrastructure reasons are deleted, so
+		// that they're restarted, and pipelines' finalizers run in their own
+		// pods
+		APIGroups: []string{""},
+		Verbs:     []string{"create", "delete"},
+		Resources: []string{"pods"},
 	}, {
 		APIGroups: []string{""},
 		Verbs:     []string{"get", "list", "watch", "create", "update", "delete"},
-		Resources: []string{"replicationcontrollers", "services"},
+		Resources: []string{"replicationcontrollers", "services", "serviceaccounts"},
+	}, {
+		APIGroups: []string{"policy"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "delete"},
+		Resources: []string{"poddisruptionbudgets"},
 	}, {
 		APIGroups:     []string{""},
 		Verbs:         []string{"get", "list", "watch", "create", "update", "delete"},
 		Resources:     []string{"secrets"},
 		ResourceNames: []string{client.StorageSecretName},
+	}, {
+		// pachd checks that the image pull secrets that pipelines name exist
+		APIGroups: []string{""},
+		Verbs:     []string{"get"},
+		Resources: []string{"secrets"},
 	}}
+	// Policy rule that lets pachd read pipeline specs from ConfigMaps, if
+	// PipelineSpecConfigMapSelector is set (see configMapPolicyRules)
+	configMapPolicyRule = rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Verbs:     []string{"get", "list", "watch"},
+		Resources: []string{"configmaps"},
+	}
+	// The name of the secret, and the key in it, that holds the auth token
+	// that pachd creates the pipelines in ConfigMaps with, if auth is active
+	pipelineSpecTokenSecretName = "pachyderm-pipeline-spec-token"
+	pipelineSpecTokenSecretKey  = "auth-token"
 
 	// The name of the local volume (mounted kubernetes secret) where pachd
 	// should read a TLS cert and private key for authenticating with clients
@@ -193,11 +231,125 @@ type AssetOpts struct {
 	// NoExposeDockerSocket if true prevents pipelines from accessing the docker socket.
 	NoExposeDockerSocket bool
 
+	// WorkerBudget, if nonzero, is the maximum number of workers that may run
+	// across all pipelines. It's divided between running pipelines according
+	// to their scheduling weights.
+	WorkerBudget int64
+
+	// RequireImageDigests, if set, causes pachd to reject pipelines whose
+	// images aren't pinned by digest (e.g. "image@sha256:<digest>").
+	RequireImageDigests bool
+
+	// MaxJobDatums, if nonzero, is the largest number of datums that a job
+	// may have, unless its pipeline sets max_datums.
+	MaxJobDatums int64
+
+	// MaxJobOutputBytes, if nonzero, is the largest number of bytes of output
+	// that a job may write, unless its pipeline sets max_output_bytes.
+	MaxJobOutputBytes int64
+
+	// WorkerGracePeriod, if nonzero, is how many seconds a worker's locks on
+	// its chunks and merges outlive a missed renewal, before they're
+	// reassigned to other workers.
+	WorkerGracePeriod int64
+
+	// LogRetentionMaxAge and LogRetentionMaxBytes, if set, are the defaults
+	// for how long datums' logs are kept in stats branches, and for how many
+	// bytes of them are kept, unless a pipeline sets its log_retention.
+	LogRetentionMaxAge   string
+	LogRetentionMaxBytes int64
+
+	// PipelineSpecConfigMapSelector, if set, is a label selector for
+	// ConfigMaps that hold pipeline specs. pachd creates their pipelines, and
+	// updates them whenever the ConfigMaps change.
+	PipelineSpecConfigMapSelector string
+
+	// PipelineSpecConfigMapNames are the names of the selected ConfigMaps
+	// that pachd reads pipeline specs from. pachd annotates them with the
+	// outcome of applying their specs, so it may update them, but no other
+	// ConfigMaps.
+	PipelineSpecConfigMapNames []string
+
+	// WorkerRcGCGracePeriod, if set, is how long pachd keeps the worker RCs
+	// of deleted pipelines, and of old pipeline versions without unfinished
+	// jobs, before deleting them (e.g. "1h")
+	WorkerRcGCGracePeriod string
+
+	// PipelineInputCheckInterval, if set, is how often pachd checks that
+	// pipelines can still read their input repos, failing those that can't
+	// (e.g. "1m").
+	PipelineInputCheckInterval string
+
+	// WorkerCPUPerWeight, if set, is the CPU that workers request per unit of
+	// their pipeline's scheduling weight, if their pipeline doesn't request
+	// CPU itself (e.g. "100m").
+	WorkerCPUPerWeight string
+
+	// WorkerMaxWeightedCPU, if set, is the most CPU that workers request for
+	// their pipeline's scheduling weight (e.g. "4").
+	WorkerMaxWeightedCPU string
+
+	// MaxConcurrentJobs, if nonzero, is the most jobs that may run at once
+	// across all pipelines. Jobs beyond it wait for a job slot.
+	MaxConcurrentJobs int64
+
+	// DedupScope is the scope within which pachd stores identical file content
+	// once: "global" (the default) or "repo".
+	DedupScope string
+
+	// MaxCommitFiles and MaxCommitBytes, if nonzero, are the most files and
+	// bytes that a commit may add or change, relative to its parent, unless
+	// its repo sets its own limits.
+	MaxCommitFiles int64
+	MaxCommitBytes int64
+
+	// StatsDAddress, if set, is the address (host:port) of a StatsD server
+	// that pachd and workers push their metrics to, and StatsDTagFormat
+	// determines how the metrics' labels are sent (see statsd.TagFormatEnv).
+	StatsDAddress   string
+	StatsDTagFormat string
+
+	// EventSink, if set, is the URL of a message queue (e.g.
+	// "nats://nats:4222") that pachd publishes job, commit and pipeline
+	// events to, on subjects prefixed with EventSubject (see
+	// events.SubjectEnv).
+	EventSink    string
+	EventSubject string
+
+	// WorkerGRPCCompression, if set, is how workers compress their requests
+	// to pachd (see client.WorkerCompressionEnv), independently of external
+	// clients
+	WorkerGRPCCompression string
+
 	// ExposeObjectAPI, if set, causes pachd to serve Object/Block API requests on
 	// its public port. This should generally be false in production (it breaks
 	// auth) but is needed by tests
 	ExposeObjectAPI bool
 
+	// StorageCompression is the codec ("gzip" or "snappy") that objects are
+	// compressed with in object storage. If it's empty, objects are stored
+	// uncompressed.
+	StorageCompression string
+
+	// StorageCompressionLevel is the level of the gzip codec (1-9). 0 means the
+	// codec's default.
+	StorageCompressionLevel int
+
+	// StorageKeyLayout, if set, is the layout of the keys that objects'
+	// content is stored under (see obj.NewLayoutClient), e.g.
+	// "layout/{kind}/{year}/{month}/{name}".
+	StorageKeyLayout string
+
+	// StorageMaxOutstandingWriteBytes is the most bytes (e.g. "1G") that pachd
+	// buffers in writes to object storage before put-file requests are slowed
+	// down. If it's empty, writes aren't throttled.
+	StorageMaxOutstandingWriteBytes string
+
+	// CSIDriver, if set, deploys the PFS CSI node plugin on every node, so
+	// that any pod can mount a PFS commit read-only as a volume (see
+	// src/server/pfs/csi)
+	CSIDriver bool
+
 	// If set, the files indictated by 'TLS.ServerCert' and 'TLS.ServerKey' are
 	// placed into a Kubernetes secret and used by pachd nodes to authenticate
 	// during TLS
@@ -279,6 +431,26 @@ func ServiceAccount(opts *AssetOpts) *v1.ServiceAccount {
 	}
 }
 
+// policyRules returns the policy rules for Pachyderm's Role or ClusterRole
+func policyRules(opts *AssetOpts) []rbacv1.PolicyRule {
+	if opts.PipelineSpecConfigMapSelector == "" {
+		return rolePolicyRules
+	}
+	return append(append([]rbacv1.PolicyRule{}, rolePolicyRules...), configMapPolicyRules(opts.PipelineSpecConfigMapNames)...)
+}
+
+// configMapPolicyRules returns the policy rules that let pachd read pipeline
+// specs from ConfigMaps, and annotate the ConfigMaps named in 'names' (and
+// only those) with the outcome
+func configMapPolicyRules(names []string) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{configMapPolicyRule, {
+		APIGroups:     []string{""},
+		Verbs:         []string{"update"},
+		Resources:     []string{"configmaps"},
+		ResourceNames: names,
+	}}
+}
+
 // ClusterRole returns a ClusterRole that should be bound to the Pachyderm service account.
 func ClusterRole(opts *AssetOpts) *rbacv1.ClusterRole {
 	return &rbacv1.ClusterRole{
@@ -287,7 +459,7 @@ func ClusterRole(opts *AssetOpts) *rbacv1.ClusterRole {
 			APIVersion: "rbac.authorization.k8s.io/v1",
 		},
 		ObjectMeta: objectMeta(roleName, labels(""), nil, opts.Namespace),
-		Rules:      rolePolicyRules,
+		Rules:      policyRules(opts),
 	}
 }
 
@@ -320,7 +492,7 @@ func Role(opts *AssetOpts) *rbacv1.Role {
 			APIVersion: "rbac.authorization.k8s.io/v1",
 		},
 		ObjectMeta: objectMeta(roleName, labels(""), nil, opts.Namespace),
-		Rules:      rolePolicyRules,
+		Rules:      policyRules(opts),
 	}
 }
 
@@ -403,6 +575,13 @@ func versionedWorkerImage(opts *AssetOpts) string {
 	return workerImage
 }
 
+func versionedPFSCSIImage(opts *AssetOpts) string {
+	if opts.Version != "" {
+		return fmt.Sprintf("%s:%s", pfsCSIImage, opts.Version)
+	}
+	return pfsCSIImage
+}
+
 func imagePullSecrets(opts *AssetOpts) []v1.LocalObjectReference {
 	var result []v1.LocalObjectReference
 	if opts.ImagePullSecret != "" {
@@ -417,6 +596,7 @@ func PachdDeployment(opts *AssetOpts, objectStoreBackend backend, hostPath strin
 	mem.Add(resource.MustParse(opts.PachdNonCacheMemRequest))
 	cpu := resource.MustParse(opts.PachdCPURequest)
 	image := AddRegistry(opts.Registry, versionedPachdImage(opts))
+	trueVal := true
 	volumes := []v1.Volume{
 		{
 			Name: "pach-disk",
@@ -512,6 +692,40 @@ func PachdDeployment(opts *AssetOpts, objectStoreBackend backend, hostPath strin
 								{Name: "BLOCK_CACHE_BYTES", Value: opts.BlockCacheSize},
 								{Name: "IAM_ROLE", Value: opts.IAMRole},
 								{Name: "NO_EXPOSE_DOCKER_SOCKET", Value: strconv.FormatBool(opts.NoExposeDockerSocket)},
+								{Name: "WORKER_BUDGET", Value: strconv.FormatInt(opts.WorkerBudget, 10)},
+								{Name: "REQUIRE_IMAGE_DIGESTS", Value: strconv.FormatBool(opts.RequireImageDigests)},
+								{Name: "MAX_JOB_DATUMS", Value: strconv.FormatInt(opts.MaxJobDatums, 10)},
+								{Name: "MAX_JOB_OUTPUT_BYTES", Value: strconv.FormatInt(opts.MaxJobOutputBytes, 10)},
+								{Name: "WORKER_GRACE_PERIOD", Value: strconv.FormatInt(opts.WorkerGracePeriod, 10)},
+								{Name: "LOG_RETENTION_MAX_AGE", Value: opts.LogRetentionMaxAge},
+								{Name: "LOG_RETENTION_MAX_BYTES", Value: strconv.FormatInt(opts.LogRetentionMaxBytes, 10)},
+								{Name: "PIPELINE_SPEC_CONFIGMAP_SELECTOR", Value: opts.PipelineSpecConfigMapSelector},
+								{Name: "PIPELINE_SPEC_CONFIGMAP_NAMES", Value: strings.Join(opts.PipelineSpecConfigMapNames, ",")},
+								{
+									Name: "PIPELINE_SPEC_CONFIGMAP_TOKEN",
+									ValueFrom: &v1.EnvVarSource{
+										SecretKeyRef: &v1.SecretKeySelector{
+											LocalObjectReference: v1.LocalObjectReference{
+												Name: pipelineSpecTokenSecretName,
+											},
+											Key:      pipelineSpecTokenSecretKey,
+											Optional: &trueVal,
+										},
+									},
+								},
+								{Name: "WORKER_RC_GC_GRACE_PERIOD", Value: opts.WorkerRcGCGracePeriod},
+								{Name: "PIPELINE_INPUT_CHECK_INTERVAL", Value: opts.PipelineInputCheckInterval},
+								{Name: "WORKER_CPU_PER_WEIGHT", Value: opts.WorkerCPUPerWeight},
+								{Name: "WORKER_MAX_WEIGHTED_CPU", Value: opts.WorkerMaxWeightedCPU},
+								{Name: "MAX_CONCURRENT_JOBS", Value: strconv.FormatInt(opts.MaxConcurrentJobs, 10)},
+								{Name: client.PFSDedupScopeEnv, Value: opts.DedupScope},
+								{Name: "MAX_COMMIT_FILES", Value: strconv.FormatInt(opts.MaxCommitFiles, 10)},
+								{Name: "MAX_COMMIT_BYTES", Value: strconv.FormatInt(opts.MaxCommitBytes, 10)},
+								{Name: statsd.AddressEnv, Value: opts.StatsDAddress},
+								{Name: statsd.TagFormatEnv, Value: opts.StatsDTagFormat},
+								{Name: events.SinkEnv, Value: opts.EventSink},
+								{Name: events.SubjectEnv, Value: opts.EventSubject},
+								{Name: client.WorkerCompressionEnv, Value: opts.WorkerGRPCCompression},
 								{Name: auth.DisableAuthenticationEnvVar, Value: strconv.FormatBool(opts.DisableAuthentication)},
 								{
 									Name: "PACHD_POD_NAMESPACE",
@@ -532,6 +746,10 @@ func PachdDeployment(opts *AssetOpts, objectStoreBackend backend, hostPath strin
 									},
 								},
 								{Name: "EXPOSE_OBJECT_API", Value: strconv.FormatBool(opts.ExposeObjectAPI)},
+								{Name: obj.StorageCompressionEnvVar, Value: opts.StorageCompression},
+								{Name: obj.StorageCompressionLevelEnvVar, Value: strconv.Itoa(opts.StorageCompressionLevel)},
+								{Name: obj.StorageKeyLayoutEnvVar, Value: opts.StorageKeyLayout},
+								{Name: obj.StorageMaxOutstandingWriteBytesEnvVar, Value: opts.StorageMaxOutstandingWriteBytes},
 							}, GetSecretEnvVars("")...),
 							Ports: []v1.ContainerPort{
 								{
@@ -1276,6 +1494,192 @@ func WriteDashboardAssets(encoder Encoder, opts *AssetOpts) error {
 	return encoder.Encode(DashDeployment(opts))
 }
 
+// CSIDriverObject returns the CSIDriver object that registers the PFS CSI
+// driver with Kubernetes. Its volumes don't need to be attached to nodes
+// before they're mounted, as the node plugin fetches their data from pachd.
+func CSIDriverObject(opts *AssetOpts) interface{} {
+	return map[string]interface{}{
+		"apiVersion": "storage.k8s.io/v1beta1",
+		"kind":       "CSIDriver",
+		"metadata": map[string]interface{}{
+			"name":   csi.DriverName,
+			"labels": labels(pfsCSIName),
+		},
+		"spec": map[string]interface{}{
+			"attachRequired": false,
+			"podInfoOnMount": false,
+		},
+	}
+}
+
+// CSIServiceAccount returns the service account of the PFS CSI node plugin
+func CSIServiceAccount(opts *AssetOpts) *v1.ServiceAccount {
+	return &v1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: objectMeta(pfsCSIName, labels(pfsCSIName), nil, opts.Namespace),
+	}
+}
+
+// CSIClusterRole returns the ClusterRole of the PFS CSI node plugin, which
+// lets its node driver registrar record events
+func CSIClusterRole(opts *AssetOpts) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRole",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: objectMeta(pfsCSIName, labels(pfsCSIName), nil, opts.Namespace),
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+			Resources: []string{"events"},
+		}},
+	}
+}
+
+// CSIClusterRoleBinding returns a ClusterRoleBinding that binds the PFS CSI
+// node plugin's ClusterRole to its ServiceAccount
+func CSIClusterRoleBinding(opts *AssetOpts) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: objectMeta(pfsCSIName, labels(pfsCSIName), nil, opts.Namespace),
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      pfsCSIName,
+			Namespace: opts.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			Kind: "ClusterRole",
+			Name: pfsCSIName,
+		},
+	}
+}
+
+// CSIDaemonSet returns a DaemonSet that runs the PFS CSI node plugin, and the
+// sidecar that registers it with the kubelet, on every node. The plugin
+// mounts volumes with FUSE, so it's privileged, and it mounts them in the
+// kubelet's pods directory, which is shared with the host.
+func CSIDaemonSet(opts *AssetOpts) *appsv1.DaemonSet {
+	trueVal := true
+	bidirectional := v1.MountPropagationBidirectional
+	directoryOrCreate := v1.HostPathDirectoryOrCreate
+	directory := v1.HostPathDirectory
+	charDevice := v1.HostPathCharDev
+	// The registrar's image isn't on Docker Hub, so its registry is only
+	// replaced if one is set
+	registrarImage := csiRegistrarImage
+	if opts.Registry != "" {
+		registrarImage = AddRegistry(opts.Registry, csiRegistrarImage)
+	}
+	hostPathVolume := func(name, path string, pathType *v1.HostPathType) v1.Volume {
+		return v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{
+					Path: path,
+					Type: pathType,
+				},
+			},
+		}
+	}
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: objectMeta(pfsCSIName, labels(pfsCSIName), nil, opts.Namespace),
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels(pfsCSIName),
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: objectMeta(pfsCSIName, labels(pfsCSIName), nil, opts.Namespace),
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "node-driver-registrar",
+							Image: registrarImage,
+							Args: []string{
+								"--csi-address=/csi/csi.sock",
+								"--kubelet-registration-path=" + pfsCSIPluginDir + "/csi.sock",
+							},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "plugin-dir", MountPath: "/csi"},
+								{Name: "registration-dir", MountPath: "/registration"},
+							},
+							ImagePullPolicy: "IfNotPresent",
+						},
+						{
+							Name:  pfsCSIName,
+							Image: AddRegistry(opts.Registry, versionedPFSCSIImage(opts)),
+							Env: []v1.EnvVar{
+								{Name: "CSI_ENDPOINT", Value: "/csi/csi.sock"},
+								{Name: "STATE_DIR", Value: "/csi/state"},
+								{Name: "PACHD_ADDRESS", Value: fmt.Sprintf("%s:650", pachdName)},
+								{
+									Name: "NODE_ID",
+									ValueFrom: &v1.EnvVarSource{
+										FieldRef: &v1.ObjectFieldSelector{
+											FieldPath: "spec.nodeName",
+										},
+									},
+								},
+							},
+							SecurityContext: &v1.SecurityContext{
+								Privileged: &trueVal,
+							},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "plugin-dir", MountPath: "/csi"},
+								{
+									Name:             "pods-dir",
+									MountPath:        "/var/lib/kubelet/pods",
+									MountPropagation: &bidirectional,
+								},
+								{Name: "fuse-device", MountPath: "/dev/fuse"},
+							},
+							ImagePullPolicy: "IfNotPresent",
+						},
+					},
+					ServiceAccountName: pfsCSIName,
+					Volumes: []v1.Volume{
+						hostPathVolume("plugin-dir", pfsCSIPluginDir, &directoryOrCreate),
+						hostPathVolume("registration-dir", "/var/lib/kubelet/plugins_registry", &directory),
+						hostPathVolume("pods-dir", "/var/lib/kubelet/pods", &directory),
+						hostPathVolume("fuse-device", "/dev/fuse", &charDevice),
+					},
+					ImagePullSecrets: imagePullSecrets(opts),
+				},
+			},
+		},
+	}
+}
+
+// WriteCSIDriverAssets writes the k8s config for deploying the PFS CSI node
+// plugin to 'encoder'
+func WriteCSIDriverAssets(encoder Encoder, opts *AssetOpts) error {
+	if err := encoder.Encode(CSIDriverObject(opts)); err != nil {
+		return err
+	}
+	if err := encoder.Encode(CSIServiceAccount(opts)); err != nil {
+		return err
+	}
+	if !opts.NoRBAC {
+		if err := encoder.Encode(CSIClusterRole(opts)); err != nil {
+			return err
+		}
+		if err := encoder.Encode(CSIClusterRoleBinding(opts)); err != nil {
+			return err
+		}
+	}
+	return encoder.Encode(CSIDaemonSet(opts))
+}
+
 // WriteAssets writes the assets to encoder.
 func WriteAssets(encoder Encoder, opts *AssetOpts, objectStoreBackend backend,
 	persistentDiskBackend backend, volumeSize int,
@@ -1382,6 +1786,11 @@ func WriteAssets(encoder Encoder, opts *AssetOpts, objectStoreBackend backend,
 			return err
 		}
 	}
+	if opts.CSIDriver {
+		if err := WriteCSIDriverAssets(encoder, opts); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1527,6 +1936,8 @@ func Images(opts *AssetOpts) []string {
 		pauseImage,
 		versionedPachdImage(opts),
 		opts.DashImage,
+		versionedPFSCSIImage(opts),
+		csiRegistrarImage,
 	}
 }
 
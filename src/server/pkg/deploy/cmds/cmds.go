@@ -19,6 +19,7 @@ import (
 
 	"github.com/pachyderm/pachyderm/src/client"
 	deployclient "github.com/pachyderm/pachyderm/src/client/deploy"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 
 	"github.com/pachyderm/pachyderm/src/client/version"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
@@ -26,9 +27,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/deploy/assets"
 	"github.com/pachyderm/pachyderm/src/server/pkg/deploy/images"
 	_metrics "github.com/pachyderm/pachyderm/src/server/pkg/metrics"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 
+	units "github.com/docker/go-units"
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var defaultDashImage = "pachyderm/dash:1.7-preview-11"
@@ -169,6 +173,7 @@ func DeployCmd(noMetrics *bool) *cobra.Command {
 	var opts *assets.AssetOpts
 	var dashOnly bool
 	var noDash bool
+	var csiDriver bool
 	var dashImage string
 	var registry string
 	var imagePullSecret string
@@ -177,7 +182,33 @@ func DeployCmd(noMetrics *bool) *cobra.Command {
 	var localRoles bool
 	var namespace string
 	var noExposeDockerSocket bool
+	var workerBudget int64
+	var requireImageDigests bool
+	var maxJobDatums int64
+	var maxJobOutputBytes int64
+	var workerGracePeriod int64
+	var logRetentionMaxAge string
+	var logRetentionMaxBytes int64
+	var pipelineSpecConfigMapSelector string
+	var pipelineSpecConfigMaps []string
+	var workerRcGCGracePeriod string
+	var pipelineInputCheckInterval string
+	var workerCPUPerWeight string
+	var workerMaxWeightedCPU string
+	var maxConcurrentJobs int64
+	var dedupScope string
+	var maxCommitFiles int64
+	var maxCommitBytes int64
+	var statsdAddress string
+	var statsdTagFormat string
+	var eventSink string
+	var eventSubject string
+	var workerGRPCCompression string
 	var exposeObjectAPI bool
+	var storageCompression string
+	var storageCompressionLevel int
+	var storageKeyLayout string
+	var storageMaxOutstandingWriteBytes string
 	var tlsCertKey string
 
 	deployLocal := &cobra.Command{
@@ -541,30 +572,102 @@ particular backend, run "pachctl deploy storage <backend>"`,
 		Long:  "Deploy a Pachyderm cluster.",
 		PersistentPreRun: cmdutil.Run(func([]string) error {
 			dashImage = getDefaultOrLatestDashImage(dashImage, dryRun)
+			if err := obj.ValidateCompression(storageCompression, storageCompressionLevel); err != nil {
+				return err
+			}
+			if err := obj.ValidateKeyLayout(storageKeyLayout); err != nil {
+				return err
+			}
+			if workerRcGCGracePeriod != "" {
+				if _, err := time.ParseDuration(workerRcGCGracePeriod); err != nil {
+					return fmt.Errorf("--worker-rc-gc-grace-period must be a duration, e.g. \"1h\"")
+				}
+			}
+			if pipelineInputCheckInterval != "" {
+				if _, err := time.ParseDuration(pipelineInputCheckInterval); err != nil {
+					return fmt.Errorf("--pipeline-input-check-interval must be a duration, e.g. \"1m\"")
+				}
+			}
+			if workerCPUPerWeight != "" {
+				if _, err := resource.ParseQuantity(workerCPUPerWeight); err != nil {
+					return fmt.Errorf("--worker-cpu-per-weight must be a CPU quantity, e.g. \"100m\"")
+				}
+			}
+			if pipelineSpecConfigMapSelector != "" && len(pipelineSpecConfigMaps) == 0 {
+				return fmt.Errorf("--pipeline-spec-configmaps must name the ConfigMaps that --pipeline-spec-configmap-selector selects, as pachd may only update those")
+			}
+			if workerMaxWeightedCPU != "" {
+				if _, err := resource.ParseQuantity(workerMaxWeightedCPU); err != nil {
+					return fmt.Errorf("--worker-max-weighted-cpu must be a CPU quantity, e.g. \"4\"")
+				}
+			}
+			if maxConcurrentJobs < 0 {
+				return fmt.Errorf("--max-concurrent-jobs must not be negative")
+			}
+			if err := grpcutil.ValidateCompression(workerGRPCCompression); err != nil {
+				return fmt.Errorf("--worker-grpc-compression: %v", err)
+			}
+			if storageMaxOutstandingWriteBytes != "" {
+				if _, err := units.RAMInBytes(storageMaxOutstandingWriteBytes); err != nil {
+					return fmt.Errorf("--storage-max-outstanding-write-bytes must be a size, e.g. \"1G\"")
+				}
+			}
+			if logRetentionMaxAge != "" {
+				if maxAge, err := time.ParseDuration(logRetentionMaxAge); err != nil || maxAge <= 0 {
+					return fmt.Errorf("--log-retention-max-age must be a positive duration, e.g. \"720h\"")
+				}
+			}
 			opts = &assets.AssetOpts{
-				PachdShards:             uint64(pachdShards),
-				Version:                 version.PrettyPrintVersion(version.Version),
-				LogLevel:                logLevel,
-				Metrics:                 metrics,
-				PachdCPURequest:         pachdCPURequest,
-				PachdNonCacheMemRequest: pachdNonCacheMemRequest,
-				BlockCacheSize:          blockCacheSize,
-				EtcdCPURequest:          etcdCPURequest,
-				EtcdMemRequest:          etcdMemRequest,
-				EtcdNodes:               etcdNodes,
-				EtcdVolume:              etcdVolume,
-				EtcdStorageClassName:    etcdStorageClassName,
-				DashOnly:                dashOnly,
-				NoDash:                  noDash,
-				DashImage:               dashImage,
-				Registry:                registry,
-				ImagePullSecret:         imagePullSecret,
-				NoGuaranteed:            noGuaranteed,
-				NoRBAC:                  noRBAC,
-				LocalRoles:              localRoles,
-				Namespace:               namespace,
-				NoExposeDockerSocket:    noExposeDockerSocket,
-				ExposeObjectAPI:         exposeObjectAPI,
+				PachdShards:                     uint64(pachdShards),
+				Version:                         version.PrettyPrintVersion(version.Version),
+				LogLevel:                        logLevel,
+				Metrics:                         metrics,
+				PachdCPURequest:                 pachdCPURequest,
+				PachdNonCacheMemRequest:         pachdNonCacheMemRequest,
+				BlockCacheSize:                  blockCacheSize,
+				EtcdCPURequest:                  etcdCPURequest,
+				EtcdMemRequest:                  etcdMemRequest,
+				EtcdNodes:                       etcdNodes,
+				EtcdVolume:                      etcdVolume,
+				EtcdStorageClassName:            etcdStorageClassName,
+				DashOnly:                        dashOnly,
+				NoDash:                          noDash,
+				DashImage:                       dashImage,
+				Registry:                        registry,
+				ImagePullSecret:                 imagePullSecret,
+				NoGuaranteed:                    noGuaranteed,
+				NoRBAC:                          noRBAC,
+				LocalRoles:                      localRoles,
+				Namespace:                       namespace,
+				NoExposeDockerSocket:            noExposeDockerSocket,
+				WorkerBudget:                    workerBudget,
+				RequireImageDigests:             requireImageDigests,
+				MaxJobDatums:                    maxJobDatums,
+				MaxJobOutputBytes:               maxJobOutputBytes,
+				WorkerGracePeriod:               workerGracePeriod,
+				LogRetentionMaxAge:              logRetentionMaxAge,
+				LogRetentionMaxBytes:            logRetentionMaxBytes,
+				PipelineSpecConfigMapSelector:   pipelineSpecConfigMapSelector,
+				PipelineSpecConfigMapNames:      pipelineSpecConfigMaps,
+				WorkerRcGCGracePeriod:           workerRcGCGracePeriod,
+				PipelineInputCheckInterval:      pipelineInputCheckInterval,
+				WorkerCPUPerWeight:              workerCPUPerWeight,
+				WorkerMaxWeightedCPU:            workerMaxWeightedCPU,
+				MaxConcurrentJobs:               maxConcurrentJobs,
+				DedupScope:                      dedupScope,
+				MaxCommitFiles:                  maxCommitFiles,
+				MaxCommitBytes:                  maxCommitBytes,
+				StatsDAddress:                   statsdAddress,
+				StatsDTagFormat:                 statsdTagFormat,
+				EventSink:                       eventSink,
+				EventSubject:                    eventSubject,
+				WorkerGRPCCompression:           workerGRPCCompression,
+				ExposeObjectAPI:                 exposeObjectAPI,
+				StorageCompression:              storageCompression,
+				StorageCompressionLevel:         storageCompressionLevel,
+				StorageKeyLayout:                storageKeyLayout,
+				StorageMaxOutstandingWriteBytes: storageMaxOutstandingWriteBytes,
+				CSIDriver:                       csiDriver,
 			}
 			if tlsCertKey != "" {
 				// TODO(msteffen): If either the cert path or the key path contains a
@@ -590,6 +693,7 @@ particular backend, run "pachctl deploy storage <backend>"`,
 	deploy.PersistentFlags().StringVar(&logLevel, "log-level", "info", "The level of log messages to print options are, from least to most verbose: \"error\", \"info\", \"debug\".")
 	deploy.PersistentFlags().BoolVar(&dashOnly, "dashboard-only", false, "Only deploy the Pachyderm UI (experimental), without the rest of pachyderm. This is for launching the UI adjacent to an existing Pachyderm cluster. After deployment, run \"pachctl port-forward\" to connect")
 	deploy.PersistentFlags().BoolVar(&noDash, "no-dashboard", false, "Don't deploy the Pachyderm UI alongside Pachyderm (experimental).")
+	deploy.PersistentFlags().BoolVar(&csiDriver, "csi-driver", false, "Deploy the PFS CSI node plugin on every node, so that any pod can mount a PFS commit read-only as a volume with the CSI driver \"pfs.csi.pachyderm.io\". Requires Kubernetes 1.14 or later.")
 	deploy.PersistentFlags().StringVar(&registry, "registry", "", "The registry to pull images from.")
 	deploy.PersistentFlags().StringVar(&imagePullSecret, "image-pull-secret", "", "A secret in Kubernetes that's needed to pull from your private registry.")
 	deploy.PersistentFlags().StringVar(&dashImage, "dash-image", "", "Image URL for pachyderm dashboard")
@@ -597,8 +701,34 @@ particular backend, run "pachctl deploy storage <backend>"`,
 	deploy.PersistentFlags().BoolVar(&noRBAC, "no-rbac", false, "Don't deploy RBAC roles for Pachyderm. (for k8s versions prior to 1.8)")
 	deploy.PersistentFlags().BoolVar(&localRoles, "local-roles", false, "Use namespace-local roles instead of cluster roles. Ignored if --no-rbac is set.")
 	deploy.PersistentFlags().StringVar(&namespace, "namespace", "default", "Kubernetes namespace to deploy Pachyderm to.")
+	deploy.PersistentFlags().Int64Var(&workerBudget, "worker-budget", 0, "The maximum number of workers that may run across all pipelines. When running pipelines need more workers than this, the budget is divided between them according to their scheduling weights. 0 means no limit.")
+	deploy.PersistentFlags().BoolVar(&requireImageDigests, "require-image-digests", false, "Reject pipelines whose images aren't pinned by digest (e.g. \"image@sha256:<digest>\"), so that every pipeline runs a known image.")
+	deploy.PersistentFlags().Int64Var(&maxJobDatums, "max-job-datums", 0, "The largest number of datums that a job may have, unless its pipeline sets max_datums. Jobs with more datums fail without being started, as their inputs' globs are likely to be wrong. 0 means no limit.")
+	deploy.PersistentFlags().Int64Var(&maxJobOutputBytes, "max-job-output-bytes", 0, "The largest number of bytes of output that a job may write, unless its pipeline sets max_output_bytes. Jobs that write more are stopped and fail as over quota, so that no job can fill the object store. 0 means no limit.")
+	deploy.PersistentFlags().Int64Var(&workerGracePeriod, "worker-grace-period", 0, "How many seconds a worker's locks on the datums it's processing outlive a missed renewal before the datums are reassigned to other workers, so that a worker that's briefly paused (e.g. by a long GC or a network blip) keeps its work. 0 means no grace period.")
+	deploy.PersistentFlags().StringVar(&logRetentionMaxAge, "log-retention-max-age", "", "How long datums' logs are kept in pipelines' stats branches (e.g. \"720h\"), unless a pipeline sets its log_retention. If unset, logs are kept forever.")
+	deploy.PersistentFlags().Int64Var(&logRetentionMaxBytes, "log-retention-max-bytes", 0, "The most bytes of logs kept in each pipeline's stats branch, unless the pipeline sets its log_retention. The logs of the datums processed longest ago are pruned first. 0 means no limit.")
+	deploy.PersistentFlags().StringVar(&pipelineSpecConfigMapSelector, "pipeline-spec-configmap-selector", "", "A label selector (e.g. \"pachyderm.io/pipeline-spec=true\") for ConfigMaps in pachd's namespace that hold pipeline specs under the key \"pipeline.json\". pachd creates their pipelines, and updates them whenever the ConfigMaps change, so that pipelines can be managed declaratively by GitOps tooling. If unset, ConfigMaps are ignored.")
+	deploy.PersistentFlags().StringSliceVar(&pipelineSpecConfigMaps, "pipeline-spec-configmaps", nil, "The names of the ConfigMaps selected by --pipeline-spec-configmap-selector that pachd reads pipeline specs from (required with it). pachd annotates them with the outcome of applying their specs, so it's given permission to update them, but no other ConfigMaps. Selected ConfigMaps that aren't named are ignored.")
+	deploy.PersistentFlags().StringVar(&workerRcGCGracePeriod, "worker-rc-gc-grace-period", "", "If set, pachd deletes the worker replication controllers of deleted pipelines, and of old pipeline versions that have no unfinished jobs, once they've been stale for this long (e.g. \"1h\"), e.g. if they were left behind by failed pipeline updates. If unset, they're kept.")
+	deploy.PersistentFlags().StringVar(&pipelineInputCheckInterval, "pipeline-input-check-interval", "1m", "How often pachd checks that each pipeline can still read its input repos with its own credentials. Pipelines whose input repo has been deleted, or that have lost access to it, are failed with a reason that says so. 0 disables the check.")
+	deploy.PersistentFlags().Int64Var(&maxConcurrentJobs, "max-concurrent-jobs", 0, "The most jobs that may run at once across all pipelines, which keeps a burst of jobs from overwhelming Kubernetes and pachd on a large cluster. Jobs beyond it wait, with the reason \"waiting for job slot\", and start in the order that they were queued as running jobs finish. 0 means no limit.")
+	deploy.PersistentFlags().StringVar(&workerCPUPerWeight, "worker-cpu-per-weight", "", "If set, workers whose pipeline doesn't request CPU request this much CPU (e.g. \"100m\") for each unit of their pipeline's scheduling weight. Kubernetes gives containers cgroup CPU shares in proportion to their CPU requests, so workers that share a node and contend for CPU get it in proportion to their pipelines' weights, and a busy pipeline can't starve the others. Note that requests also reserve node capacity. If unset, such workers request no CPU.")
+	deploy.PersistentFlags().StringVar(&workerMaxWeightedCPU, "worker-max-weighted-cpu", "", "If set, the most CPU (e.g. \"4\") that workers request for their pipeline's scheduling weight (see --worker-cpu-per-weight). Creating or updating a pipeline whose weight would have its workers request more fails. If unset, the request is only capped at the pipeline's CPU limit.")
+	deploy.PersistentFlags().StringVar(&dedupScope, "dedup-scope", "global", "The scope within which identical file content is stored once: \"global\" deduplicates content across all repos, while \"repo\" only deduplicates it within each repo, so that repos' contents can't be compared by deduplication (at the cost of storing content that's in several repos more than once).")
+	deploy.PersistentFlags().Int64Var(&maxCommitFiles, "max-commit-files", 0, "The most files that a commit may add or change, relative to its parent, unless its repo sets its own limit (see \"pachctl update-repo --max-commit-files\"). Finishing a commit that adds or changes more files fails, so that no single commit can overwhelm the cluster. 0 means no limit.")
+	deploy.PersistentFlags().Int64Var(&maxCommitBytes, "max-commit-bytes", 0, "The most bytes that a commit may add or change, relative to its parent, unless its repo sets its own limit (see \"pachctl update-repo --max-commit-bytes\"). Finishing a commit that adds or changes more bytes fails. 0 means no limit.")
+	deploy.PersistentFlags().StringVar(&statsdAddress, "statsd-address", "", "The address (host:port) of a StatsD server (e.g. a Datadog agent) that pachd and workers push their metrics to, in addition to serving them to Prometheus.")
+	deploy.PersistentFlags().StringVar(&statsdTagFormat, "statsd-tag-format", "", "How metrics' labels are sent to StatsD: \"datadog\" sends them as DogStatsD tags, otherwise they're appended to the metrics' names.")
+	deploy.PersistentFlags().StringVar(&eventSink, "event-sink", "", "The URL of a message queue (e.g. \"nats://nats:4222\") that pachd publishes job, commit and pipeline lifecycle events to, as JSON.")
+	deploy.PersistentFlags().StringVar(&eventSubject, "event-subject", "", "The prefix of the subjects that events are published on (default \"pachyderm\"); each event is published on \"<prefix>.<type>\", e.g. \"pachyderm.job.failed\".")
 	deploy.PersistentFlags().BoolVar(&noExposeDockerSocket, "no-expose-docker-socket", false, "Don't expose the Docker socket to worker containers. This limits the privileges of workers which prevents them from automatically setting the container's working dir and user.")
 	deploy.PersistentFlags().BoolVar(&exposeObjectAPI, "expose-object-api", false, "If set, instruct pachd to serve its object/block API on its public port (not safe with auth enabled, do not set in production).")
+	deploy.PersistentFlags().StringVar(&storageCompression, "storage-compression", "", "Compress objects in object storage with this codec (\"gzip\" or \"snappy\"). Objects whose content doesn't compress are stored uncompressed. \"none\" stores new objects uncompressed, but still reads objects that were compressed, so it must be used instead of unsetting the codec on a cluster that has compressed objects. If unset, objects are stored uncompressed.")
+	deploy.PersistentFlags().IntVar(&storageCompressionLevel, "storage-compression-level", 0, "The level of the gzip codec, from 1 (fastest) to 9 (smallest). 0 means gzip's default.")
+	deploy.PersistentFlags().StringVar(&workerGRPCCompression, "worker-grpc-compression", "", "How workers compress their transfers with pachd's PFS API (\"gzip\" or \"none\"), independently of how external clients such as pachctl compress theirs (which is set on the client by $PACH_GRPC_COMPRESSION). If unset, workers' transfers aren't compressed.")
+	deploy.PersistentFlags().StringVar(&storageKeyLayout, "storage-key-layout", "", "The layout of the keys that objects' content is stored under in object storage, so that bucket lifecycle rules can target them by prefix, e.g. \"layout/{kind}/{year}/{month}/{name}\". It must begin with a fixed directory, and contain {kind} (\"block\", \"object\", \"tag\" or \"index\") and {name}; {year}, {month} and {day} are the date that the object was written. Objects are still found (and deduplicated) by name, through small pointer objects. If unset, objects' content is stored under their names.")
+	deploy.PersistentFlags().StringVar(&storageMaxOutstandingWriteBytes, "storage-max-outstanding-write-bytes", "", "The most bytes (e.g. \"1G\") that pachd buffers in writes to object storage. Once it's reached, put-file requests are slowed down until the object store catches up. If unset, writes aren't throttled.")
 	deploy.PersistentFlags().StringVar(&tlsCertKey, "tls", "", "string of the form \"<cert path>,<key path>\" of the signed TLS certificate and private key that Pachd should use for TLS authentication (enables TLS-encrypted communication with Pachd)")
 
 	deploy.AddCommand(
@@ -683,6 +813,7 @@ Are you sure you want to proceed? yN
 				"serviceaccount",
 				"secret",
 				"statefulset",
+				"daemonset",
 				"clusterrole",
 				"clusterrolebinding",
 			}
@@ -0,0 +1,379 @@
+// Package events publishes the lifecycle events of pachyderm's jobs, commits
+// and pipelines (e.g. a job failing) to a message queue as JSON, so that
+// systems outside of pachyderm can react to them. Events are delivered at
+// least once: each is retried until the queue accepts it, so consumers may
+// see an event more than once, and should deduplicate events by their IDs.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+const (
+	// SinkEnv is the env var that holds the URL of the message queue that
+	// events are published to (e.g. "nats://nats:4222"). Its scheme selects
+	// the kind of queue. If it's unset, events aren't published.
+	SinkEnv = "EVENT_SINK"
+	// SubjectEnv is the env var that holds the prefix of the subjects that
+	// events are published on. Each event is published on the subject
+	// "<prefix>.<type>" (e.g. "pachyderm.job.failed"). It defaults to
+	// DefaultSubject.
+	SubjectEnv = "EVENT_SUBJECT"
+	// BufferSizeEnv is the env var that holds the number of events that are
+	// held while the queue is unreachable, after which emitted events are
+	// dropped. It defaults to DefaultBufferSize.
+	BufferSizeEnv = "EVENT_BUFFER_SIZE"
+
+	// DefaultSubject is the default prefix of the subjects that events are
+	// published on
+	DefaultSubject = "pachyderm"
+	// DefaultBufferSize is the default number of events that are held while
+	// the queue is unreachable
+	DefaultBufferSize = 1000
+)
+
+// EnvVars are the env vars that configure the event sink
+var EnvVars = []string{SinkEnv, SubjectEnv, BufferSizeEnv}
+
+// droppedEvents counts the events that Emit dropped because the buffer was
+// full, by type
+var droppedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Subsystem: "events",
+		Name:      "dropped_total",
+		Help:      "Number of events that were dropped because the event buffer was full, by type",
+	},
+	[]string{
+		"type",
+	},
+)
+
+func init() {
+	if err := prometheus.Register(droppedEvents); err != nil {
+		log.Infof("error registering prometheus metric: %v", err)
+	}
+}
+
+// Type is the type of an event
+type Type string
+
+const (
+	// JobStarted is published when a job starts processing datums
+	JobStarted Type = "job.started"
+	// JobFinished is published when a job succeeds
+	JobFinished Type = "job.finished"
+	// JobFailed is published when a job fails
+	JobFailed Type = "job.failed"
+	// JobKilled is published when a job is killed (e.g. by StopJob)
+	JobKilled Type = "job.killed"
+	// CommitFinished is published when a commit is finished
+	CommitFinished Type = "commit.finished"
+	// PipelineCreated is published when a pipeline is created
+	PipelineCreated Type = "pipeline.created"
+	// PipelineUpdated is published when a pipeline is updated
+	PipelineUpdated Type = "pipeline.updated"
+	// PipelineDeleted is published when a pipeline is deleted
+	PipelineDeleted Type = "pipeline.deleted"
+)
+
+// Event is the JSON payload of a published event. Only the fields that are
+// relevant to its type are set.
+type Event struct {
+	// ID is unique to each event, so that consumers can deduplicate events
+	// that are delivered more than once
+	ID       string    `json:"id"`
+	Type     Type      `json:"type"`
+	Time     time.Time `json:"time"`
+	Pipeline string    `json:"pipeline,omitempty"`
+	Job      string    `json:"job,omitempty"`
+	Repo     string    `json:"repo,omitempty"`
+	Commit   string    `json:"commit,omitempty"`
+	State    string    `json:"state,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+func newEvent(eventType Type) *Event {
+	return &Event{
+		ID:   uuid.NewWithoutDashes(),
+		Type: eventType,
+		Time: time.Now().UTC(),
+	}
+}
+
+// NewJobEvent returns the event for the job in 'jobPtr' entering its current
+// state, or nil if no event is published for that state
+func NewJobEvent(jobPtr *pps.EtcdJobInfo) *Event {
+	var eventType Type
+	switch jobPtr.State {
+	case pps.JobState_JOB_RUNNING:
+		eventType = JobStarted
+	case pps.JobState_JOB_SUCCESS:
+		eventType = JobFinished
+	case pps.JobState_JOB_FAILURE:
+		eventType = JobFailed
+	case pps.JobState_JOB_KILLED:
+		eventType = JobKilled
+	default:
+		return nil
+	}
+	event := newEvent(eventType)
+	event.Pipeline = jobPtr.Pipeline.Name
+	event.Job = jobPtr.Job.ID
+	if jobPtr.OutputCommit != nil {
+		event.Repo = jobPtr.OutputCommit.Repo.Name
+		event.Commit = jobPtr.OutputCommit.ID
+	}
+	event.State = jobPtr.State.String()
+	event.Reason = jobPtr.Reason
+	return event
+}
+
+// NewCommitFinishedEvent returns the event for 'commit' being finished
+func NewCommitFinishedEvent(commit *pfs.Commit) *Event {
+	event := newEvent(CommitFinished)
+	event.Repo = commit.Repo.Name
+	event.Commit = commit.ID
+	return event
+}
+
+// NewPipelineEvent returns the event of type 'eventType' for 'pipeline'
+func NewPipelineEvent(eventType Type, pipeline string) *Event {
+	event := newEvent(eventType)
+	event.Pipeline = pipeline
+	return event
+}
+
+// Sink is a message queue that events are published to
+type Sink interface {
+	// Publish publishes 'payload' on 'subject', and returns once the queue
+	// has accepted it
+	Publish(subject string, payload []byte) error
+	// Close closes the sink's connection to the queue
+	Close() error
+}
+
+// NewSink returns the Sink for the queue at 'sinkURL', whose scheme selects
+// the kind of queue
+func NewSink(sinkURL string) (Sink, error) {
+	u, err := url.Parse(sinkURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event sink %q: %v", sinkURL, err)
+	}
+	switch u.Scheme {
+	case "nats":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid event sink %q: the NATS server's address must be set", sinkURL)
+		}
+		return NewNATSSink(u.Host), nil
+	default:
+		return nil, fmt.Errorf("invalid event sink %q: unsupported scheme %q (the supported schemes are: nats)", sinkURL, u.Scheme)
+	}
+}
+
+// Publisher publishes events to a Sink in the background, so that emitting an
+// event doesn't wait for the queue. Events are published in the order that
+// they're emitted, and each is retried until the sink accepts it. While the
+// sink is unreachable, events are held in a bounded buffer; once it's full,
+// Emit drops events (counting them in droppedEvents) rather than blocking its
+// caller, while Publish waits for room, so callers that must not lose events
+// should use Publish and record what was delivered.
+type Publisher struct {
+	sink    Sink
+	subject string
+	buffer  chan *pendingEvent
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// pendingEvent is an event that's waiting to be published. 'published', if
+// set, is closed once the sink has accepted it.
+type pendingEvent struct {
+	event     *Event
+	published chan struct{}
+}
+
+// NewPublisher returns a Publisher that publishes events to 'sink' on
+// subjects prefixed with 'subject', and holds up to 'bufferSize' events that
+// haven't been published yet
+func NewPublisher(sink Sink, subject string, bufferSize int) *Publisher {
+	p := &Publisher{
+		sink:    sink,
+		subject: subject,
+		buffer:  make(chan *pendingEvent, bufferSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Emit queues 'event' to be published without blocking. If the buffer is
+// full (or the Publisher is closed), 'event' is dropped and counted in the
+// pachyderm_events_dropped_total metric. It's a no-op if 'event' is nil.
+func (p *Publisher) Emit(event *Event) {
+	if event == nil {
+		return
+	}
+	select {
+	case <-p.stop:
+		return
+	default:
+	}
+	select {
+	case p.buffer <- &pendingEvent{event: event}:
+	default:
+		droppedEvents.WithLabelValues(string(event.Type)).Inc()
+		log.Errorf("event buffer is full, dropping %s event %s", event.Type, event.ID)
+	}
+}
+
+// Publish is like Emit, but it returns once the sink has accepted 'event',
+// so that callers can record that it was delivered. It returns an error if
+// 'ctx' is cancelled or the Publisher is closed first.
+func (p *Publisher) Publish(ctx context.Context, event *Event) error {
+	if event == nil {
+		return nil
+	}
+	pending := &pendingEvent{event: event, published: make(chan struct{})}
+	select {
+	case p.buffer <- pending:
+	case <-p.stop:
+		return fmt.Errorf("publisher is closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-pending.published:
+		return nil
+	case <-p.stop:
+		return fmt.Errorf("publisher is closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+	for {
+		select {
+		case pending := <-p.buffer:
+			if p.publish(pending.event) && pending.published != nil {
+				close(pending.published)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// publish publishes 'event', retrying until the sink accepts it or the
+// Publisher is closed. It returns true if the sink accepted 'event'.
+func (p *Publisher) publish(event *Event) bool {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("could not marshal %s event %s: %v", event.Type, event.ID, err)
+		return false
+	}
+	subject := p.subject + "." + string(event.Type)
+	return backoff.RetryNotify(func() error {
+		return p.sink.Publish(subject, payload)
+	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+		log.Errorf("error publishing %s event %s, retrying in %v: %v", event.Type, event.ID, d, err)
+		select {
+		case <-p.stop:
+			return err
+		default:
+			return nil
+		}
+	}) == nil
+}
+
+// Close stops publishing events, dropping those that haven't been published
+// yet, and closes the sink
+func (p *Publisher) Close() error {
+	close(p.stop)
+	<-p.done
+	return p.sink.Close()
+}
+
+var (
+	defaultPublisherMu sync.Mutex
+	defaultPublisher   *Publisher
+)
+
+// Emit queues 'event' to be published by the Publisher started by
+// StartFromEnv. It's a no-op if no Publisher was started (because no sink is
+// configured), or if 'event' is nil.
+func Emit(event *Event) {
+	defaultPublisherMu.Lock()
+	p := defaultPublisher
+	defaultPublisherMu.Unlock()
+	if p != nil {
+		p.Emit(event)
+	}
+}
+
+// Publish is like Emit, but it returns once 'event' has been published (see
+// Publisher.Publish). It returns immediately if no Publisher was started.
+func Publish(ctx context.Context, event *Event) error {
+	defaultPublisherMu.Lock()
+	p := defaultPublisher
+	defaultPublisherMu.Unlock()
+	if p == nil {
+		return nil
+	}
+	return p.Publish(ctx, event)
+}
+
+// Enabled returns true if StartFromEnv started a Publisher, so that events
+// passed to Emit are published
+func Enabled() bool {
+	defaultPublisherMu.Lock()
+	defer defaultPublisherMu.Unlock()
+	return defaultPublisher != nil
+}
+
+// StartFromEnv starts publishing the events passed to Emit to the sink
+// configured by the env vars in EnvVars, if there is one, for as long as the
+// process runs
+func StartFromEnv() error {
+	sinkURL := os.Getenv(SinkEnv)
+	if sinkURL == "" {
+		return nil
+	}
+	subject := DefaultSubject
+	if value := os.Getenv(SubjectEnv); value != "" {
+		subject = value
+	}
+	bufferSize := DefaultBufferSize
+	if value := os.Getenv(BufferSizeEnv); value != "" {
+		var err error
+		if bufferSize, err = strconv.Atoi(value); err != nil || bufferSize <= 0 {
+			return fmt.Errorf("invalid %s %q", BufferSizeEnv, value)
+		}
+	}
+	sink, err := NewSink(sinkURL)
+	if err != nil {
+		return err
+	}
+	defaultPublisherMu.Lock()
+	defer defaultPublisherMu.Unlock()
+	defaultPublisher = NewPublisher(sink, subject, bufferSize)
+	return nil
+}
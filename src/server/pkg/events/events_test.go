@@ -0,0 +1,233 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	dto "github.com/prometheus/client_model/go"
+)
+
+type message struct {
+	subject string
+	payload []byte
+}
+
+// mockSink is a queue that fails the first 'failures' events published to
+// it, and sends the rest to 'published'
+type mockSink struct {
+	failures  int
+	published chan message
+}
+
+func (s *mockSink) Publish(subject string, payload []byte) error {
+	if s.failures > 0 {
+		s.failures--
+		return fmt.Errorf("queue unavailable")
+	}
+	s.published <- message{subject, payload}
+	return nil
+}
+
+func (s *mockSink) Close() error {
+	return nil
+}
+
+func receiveEvent(t *testing.T, published chan message) (string, *Event) {
+	select {
+	case m := <-published:
+		event := &Event{}
+		require.NoError(t, json.Unmarshal(m.payload, event))
+		return m.subject, event
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return "", nil
+}
+
+func TestPublisher(t *testing.T) {
+	sink := &mockSink{failures: 2, published: make(chan message, 10)}
+	p := NewPublisher(sink, "test", 10)
+	defer p.Close()
+
+	jobPtr := &pps.EtcdJobInfo{
+		Job:          client.NewJob("job1"),
+		Pipeline:     client.NewPipeline("edges"),
+		OutputCommit: client.NewCommit("edges", "commit1"),
+		State:        pps.JobState_JOB_FAILURE,
+		Reason:       "datum failed",
+	}
+	p.Emit(NewJobEvent(jobPtr))
+	p.Emit(NewCommitFinishedEvent(client.NewCommit("images", "commit2")))
+	p.Emit(NewPipelineEvent(PipelineCreated, "edges"))
+	// Events that aren't published (e.g. for starting jobs) are ignored
+	jobPtr.State = pps.JobState_JOB_STARTING
+	p.Emit(NewJobEvent(jobPtr))
+
+	// The first event is retried until the sink accepts it, and the events
+	// are published in order
+	subject, event := receiveEvent(t, sink.published)
+	require.Equal(t, "test.job.failed", subject)
+	require.Equal(t, JobFailed, event.Type)
+	require.Equal(t, "edges", event.Pipeline)
+	require.Equal(t, "job1", event.Job)
+	require.Equal(t, "edges", event.Repo)
+	require.Equal(t, "commit1", event.Commit)
+	require.Equal(t, "JOB_FAILURE", event.State)
+	require.Equal(t, "datum failed", event.Reason)
+	require.NotEqual(t, "", event.ID)
+
+	subject, event = receiveEvent(t, sink.published)
+	require.Equal(t, "test.commit.finished", subject)
+	require.Equal(t, "images", event.Repo)
+	require.Equal(t, "commit2", event.Commit)
+
+	subject, event = receiveEvent(t, sink.published)
+	require.Equal(t, "test.pipeline.created", subject)
+	require.Equal(t, "edges", event.Pipeline)
+
+	select {
+	case m := <-sink.published:
+		t.Fatalf("unexpected event published on %s", m.subject)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func droppedCount(t *testing.T, eventType Type) float64 {
+	var metric dto.Metric
+	require.NoError(t, droppedEvents.WithLabelValues(string(eventType)).Write(&metric))
+	return metric.GetCounter().GetValue()
+}
+
+func TestPublisherBufferFull(t *testing.T) {
+	// The sink blocks until events are received, so events back up
+	sink := &mockSink{published: make(chan message)}
+	p := NewPublisher(sink, "test", 2)
+	defer p.Close()
+	dropped := droppedCount(t, PipelineUpdated)
+	emitted := make(chan struct{})
+	go func() {
+		defer close(emitted)
+		for i := 0; i < 10; i++ {
+			p.Emit(NewPipelineEvent(PipelineUpdated, strconv.Itoa(i)))
+		}
+	}()
+	// Once the buffer is full, Emit drops events rather than blocking
+	select {
+	case <-emitted:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Emit blocked while the buffer was full")
+	}
+	// The events that fit (the buffered ones, and possibly one that the
+	// Publisher was already publishing) are published in order, and the rest
+	// are counted as dropped
+	var published int
+	for {
+		select {
+		case m := <-sink.published:
+			event := &Event{}
+			require.NoError(t, json.Unmarshal(m.payload, event))
+			require.Equal(t, strconv.Itoa(published), event.Pipeline)
+			published++
+			continue
+		case <-time.After(200 * time.Millisecond):
+		}
+		break
+	}
+	require.True(t, published == 2 || published == 3, "published %d events", published)
+	require.Equal(t, float64(10-published), droppedCount(t, PipelineUpdated)-dropped)
+}
+
+func TestPublisherPublish(t *testing.T) {
+	sink := &mockSink{failures: 2, published: make(chan message, 10)}
+	p := NewPublisher(sink, "test", 10)
+	// Publish returns once the sink has accepted the event
+	require.NoError(t, p.Publish(context.Background(), NewPipelineEvent(PipelineCreated, "edges")))
+	require.Equal(t, 1, len(sink.published))
+	_, event := receiveEvent(t, sink.published)
+	require.Equal(t, "edges", event.Pipeline)
+
+	// It returns an error if the event can't be published first
+	sink.failures = 1000
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.YesError(t, p.Publish(ctx, NewPipelineEvent(PipelineDeleted, "edges")))
+	require.NoError(t, p.Close())
+}
+
+// serveNATS accepts connections on 'l' as a minimal NATS server, and sends
+// the messages that are published to it to 'published'. The first
+// connection is closed after its first message, before it's acknowledged.
+func serveNATS(t *testing.T, l net.Listener, published chan message) {
+	for first := true; ; first = false {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func(conn net.Conn, first bool) {
+			defer conn.Close()
+			fmt.Fprintf(conn, "INFO {\"server_id\":\"test\"}\r\n")
+			r := bufio.NewReader(conn)
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				fields := strings.Fields(line)
+				switch {
+				case len(fields) == 3 && fields[0] == "PUB":
+					size, err := strconv.Atoi(fields[2])
+					require.NoError(t, err)
+					payload := make([]byte, size+2) // the payload is followed by \r\n
+					_, err = io.ReadFull(r, payload)
+					require.NoError(t, err)
+					published <- message{fields[1], payload[:size]}
+					if first {
+						return
+					}
+				case len(fields) == 1 && fields[0] == "PING":
+					fmt.Fprintf(conn, "PONG\r\n")
+				}
+			}
+		}(conn, first)
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	published := make(chan message, 10)
+	go serveNATS(t, l, published)
+
+	sink, err := NewSink("nats://" + l.Addr().String())
+	require.NoError(t, err)
+	p := NewPublisher(sink, DefaultSubject, 10)
+	defer p.Close()
+	p.Emit(NewPipelineEvent(PipelineDeleted, "edges"))
+
+	// The first connection closes before the event is acknowledged, so it's
+	// published again over a new connection
+	for i := 0; i < 2; i++ {
+		subject, event := receiveEvent(t, published)
+		require.Equal(t, "pachyderm.pipeline.deleted", subject)
+		require.Equal(t, "edges", event.Pipeline)
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink("kafka://kafka:9092")
+	require.YesError(t, err)
+	_, err = NewSink("nats://")
+	require.YesError(t, err)
+}
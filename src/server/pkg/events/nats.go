@@ -0,0 +1,107 @@
+package events
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsTimeout bounds how long the NATS sink waits to connect to the server,
+// and for the server to acknowledge each event
+const natsTimeout = 10 * time.Second
+
+// natsSink publishes events to a NATS server, using NATS's text protocol.
+// Each event is followed by a PING, and is only considered published once
+// the server answers it with a PONG, which the server sends after it has
+// processed the event. Events published on subjects that a JetStream stream
+// captures are persisted by the server.
+type natsSink struct {
+	address string
+	mu      sync.Mutex
+	// conn and r are nil until the sink connects, and after a connection
+	// error, so that the next event reconnects
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewNATSSink returns a Sink that publishes events to the NATS server at
+// 'address' (host:port). It connects when the first event is published.
+func NewNATSSink(address string) Sink {
+	return &natsSink{address: address}
+}
+
+func (s *natsSink) connect() error {
+	conn, err := net.DialTimeout("tcp", s.address, natsTimeout)
+	if err != nil {
+		return fmt.Errorf("error connecting to NATS at %s: %v", s.address, err)
+	}
+	s.conn, s.r = conn, bufio.NewReader(conn)
+	// The server introduces itself with an INFO message
+	conn.SetDeadline(time.Now().Add(natsTimeout))
+	line, err := s.r.ReadString('\n')
+	if err == nil && !strings.HasPrefix(line, "INFO") {
+		err = fmt.Errorf("expected INFO from NATS server, got %q", strings.TrimSpace(line))
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"pachyderm\"}\r\n")
+	}
+	if err != nil {
+		s.disconnect()
+		return err
+	}
+	return nil
+}
+
+func (s *natsSink) disconnect() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.conn, s.r = nil, nil
+}
+
+func (s *natsSink) Publish(subject string, payload []byte) (retErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if retErr != nil {
+			s.disconnect()
+		}
+	}()
+	s.conn.SetDeadline(time.Now().Add(natsTimeout))
+	if _, err := fmt.Fprintf(s.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload); err != nil {
+		return err
+	}
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := fmt.Fprintf(s.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server rejected event: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// Other messages (e.g. +OK and INFO) are ignored
+	}
+}
+
+func (s *natsSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disconnect()
+	return nil
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+// PublishWatched publishes the events for the etcd changes delivered by the
+// watch that 'watchFrom' starts at a given revision, where 'eventFor' returns
+// the event for a change, or nil if none is published for it. After each
+// event is published, the revision of its change is recorded at
+// 'revisionKey', and the watch resumes from the revision after it, so that
+// changes made while the watch was restarting (e.g. while the process
+// calling PublishWatched failed over to another pachd) are still published.
+// If no revision has been recorded yet, the watch starts from the current
+// revision.
+//
+// PublishWatched returns when 'ctx' is cancelled or the watch fails, and
+// should be retried by its caller, which should hold a lock so that each
+// change's event is only published by one pachd.
+func PublishWatched(ctx context.Context, etcdClient *etcd.Client, revisionKey string, watchFrom func(rev int64) (watch.Watcher, error), eventFor func(*watch.Event) (*Event, error)) error {
+	resp, err := etcdClient.Get(ctx, revisionKey)
+	if err != nil {
+		return fmt.Errorf("error reading the last published revision at %s: %v", revisionKey, err)
+	}
+	fromRev := resp.Header.Revision + 1
+	if len(resp.Kvs) > 0 {
+		rev, err := strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid revision %q at %s: %v", resp.Kvs[0].Value, revisionKey, err)
+		}
+		fromRev = rev + 1
+	}
+	watcher, err := watchFrom(fromRev)
+	if err != nil {
+		return fmt.Errorf("error creating watch: %v", err)
+	}
+	defer watcher.Close()
+	for {
+		select {
+		case event, ok := <-watcher.Watch():
+			if !ok {
+				return fmt.Errorf("watch closed unexpectedly")
+			}
+			if event.Err == rpctypes.ErrCompacted {
+				// The changes since the last published event are gone, so
+				// start again from the current revision
+				log.Errorf("changes since revision %d were compacted before their events were published (see %s); some events may be missing", fromRev, revisionKey)
+				if _, err := etcdClient.Delete(ctx, revisionKey); err != nil {
+					return err
+				}
+				return event.Err
+			}
+			if event.Err != nil {
+				return fmt.Errorf("event err: %+v", event.Err)
+			}
+			e, err := eventFor(event)
+			if err != nil {
+				return err
+			}
+			if e == nil {
+				continue
+			}
+			// Wait for the event to be published before recording it, so
+			// that it's published again if this process fails first
+			if err := Publish(ctx, e); err != nil {
+				return err
+			}
+			if _, err := etcdClient.Put(ctx, revisionKey, strconv.FormatInt(event.Rev, 10)); err != nil {
+				return fmt.Errorf("error recording the last published revision at %s: %v", revisionKey, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
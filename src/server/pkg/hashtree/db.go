@@ -932,6 +932,11 @@ type MergeNode struct {
 type Reader struct {
 	pbr    pbutil.Reader
 	filter func(k []byte) (bool, error)
+	// pruned contains the roots of the subtrees that the reader omits, and
+	// deltas maps each of their ancestors to the total size of its pruned
+	// descendants
+	pruned map[string]bool
+	deltas map[string]int64
 }
 
 // NewReader creates a new hashtree reader.
@@ -942,8 +947,67 @@ func NewReader(r io.Reader, filter func(k []byte) (bool, error)) *Reader {
 	}
 }
 
+// NewPruningReader creates a new hashtree reader that omits the subtree
+// rooted at each path in 'prune', which maps each of those paths to the size
+// of its subtree. The sizes of the pruned subtrees are subtracted from the
+// sizes of their ancestors. Note that, as with merged directories, the hashes
+// of the ancestors aren't recomputed.
+func NewPruningReader(r io.Reader, filter func(k []byte) (bool, error), prune map[string]int64) *Reader {
+	result := NewReader(r, filter)
+	result.pruned = make(map[string]bool)
+	result.deltas = make(map[string]int64)
+	for p, size := range prune {
+		p = clean(p)
+		result.pruned[p] = true
+		for p != "" {
+			p, _ = split(p)
+			result.deltas[p] += size
+		}
+	}
+	return result
+}
+
 // Read reads the next merge node.
 func (r *Reader) Read() (*MergeNode, error) {
+	for {
+		n, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		if len(r.pruned) == 0 {
+			return n, nil
+		}
+		if r.isPruned(s(n.k)) {
+			continue
+		}
+		if delta, ok := r.deltas[s(n.k)]; ok {
+			nodeProto := &NodeProto{}
+			if err := nodeProto.Unmarshal(n.v); err != nil {
+				return nil, err
+			}
+			nodeProto.SubtreeSize -= delta
+			if n.v, err = nodeProto.Marshal(); err != nil {
+				return nil, err
+			}
+		}
+		return n, nil
+	}
+}
+
+// isPruned returns true if 'path' is in a subtree that the reader omits.
+func (r *Reader) isPruned(path string) bool {
+	for {
+		if r.pruned[path] {
+			return true
+		}
+		if path == "" {
+			return false
+		}
+		path, _ = split(path)
+	}
+}
+
+func (r *Reader) read() (*MergeNode, error) {
 	_k, err := r.pbr.ReadBytes()
 	if err != nil {
 		return nil, err
@@ -1146,6 +1210,9 @@ func pathToTree(k []byte, numTrees int64) uint64 {
 type nodeStream struct {
 	node *MergeNode
 	r    *Reader
+	// index is the position of r in the readers being merged, which orders
+	// the nodes that share a path
+	index int
 }
 
 type mergePQ struct {
@@ -1157,6 +1224,16 @@ func (mq *mergePQ) k(i int) []byte {
 	return mq.q[i].node.k
 }
 
+// less returns true if the node of stream i comes before that of stream j.
+// Nodes that share a path are ordered by their streams' indexes, so that
+// they're merged in the order of the readers they came from.
+func (mq *mergePQ) less(i, j int) bool {
+	if c := bytes.Compare(mq.k(i), mq.k(j)); c != 0 {
+		return c < 0
+	}
+	return mq.q[i].index < mq.q[j].index
+}
+
 func (mq *mergePQ) insert(s *nodeStream) error {
 	// Get next node in stream
 	var err error
@@ -1172,7 +1249,7 @@ func (mq *mergePQ) insert(s *nodeStream) error {
 	// Propagate insert up the queue
 	i := mq.size
 	for i > 1 {
-		if bytes.Compare(mq.k(i/2), mq.k(i)) <= 0 {
+		if mq.less(i/2, i) {
 			break
 		}
 		mq.swap(i/2, i)
@@ -1220,7 +1297,14 @@ func merge(ns []*MergeNode) (*MergeNode, error) {
 		// Merge file content
 		if base.nodeProto.nodetype() == file {
 			base.nodeProto.FileNode.BlockRefs = append(base.nodeProto.FileNode.BlockRefs, n.nodeProto.FileNode.BlockRefs...)
-
+			// Keep the permissions of whichever file has them (a job's
+			// output files all have its pipeline's permissions)
+			if base.nodeProto.FileNode.Mode == 0 {
+				base.nodeProto.FileNode.Mode = n.nodeProto.FileNode.Mode
+			}
+			if base.nodeProto.FileNode.Owner == nil {
+				base.nodeProto.FileNode.Owner = n.nodeProto.FileNode.Owner
+			}
 		}
 		hasher := pfs.NewHash()
 		hasher.Write(append(base.nodeProto.Hash, n.nodeProto.Hash...))
@@ -1244,12 +1328,12 @@ func (mq *mergePQ) fill() error {
 		l, r := i*2, i*2+1
 		if l > mq.size {
 			break
-		} else if r > mq.size || bytes.Compare(mq.k(l), mq.k(r)) <= 0 {
+		} else if r > mq.size || mq.less(l, r) {
 			next = l
 		} else {
 			next = r
 		}
-		if bytes.Compare(mq.k(i), mq.k(next)) <= 0 {
+		if mq.less(i, next) {
 			break
 		}
 		mq.swap(i, next)
@@ -1263,12 +1347,14 @@ func (mq *mergePQ) swap(i, j int) {
 	mq.q[i], mq.q[j] = mq.q[j], mq.q[i]
 }
 
-// Merge merges a collection of hashtree readers into a hashtree writer.
+// Merge merges a collection of hashtree readers into a hashtree writer. The
+// content of files that appear in more than one reader is concatenated in
+// the order of 'rs'.
 func Merge(w *Writer, rs []*Reader) (uint64, error) {
 	mq := &mergePQ{q: make([]*nodeStream, len(rs)+1)}
 	// Setup first set of nodes
-	for _, r := range rs {
-		if err := mq.insert(&nodeStream{r: r}); err != nil {
+	for i, r := range rs {
+		if err := mq.insert(&nodeStream{r: r, index: i}); err != nil {
 			return 0, err
 		}
 	}
@@ -38,17 +38,21 @@ type FileNodeProto struct {
 	// block_refs/objects. Without this signal, all calls to pfs.GetFile() would
 	// need to check the parent directory's metadata before beginning to return
 	// the file's contents, which would be slow.)
-	HasHeaderFooter      bool     `protobuf:"varint,6,opt,name=has_header_footer,json=hasHeaderFooter,proto3" json:"has_header_footer,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	HasHeaderFooter bool `protobuf:"varint,6,opt,name=has_header_footer,json=hasHeaderFooter,proto3" json:"has_header_footer,omitempty"`
+	// mode and owner are the file's permissions (see pfs.FileInfo). They
+	// aren't part of the node's hash, which only reflects its content.
+	Mode                 uint32         `protobuf:"varint,7,opt,name=mode,proto3" json:"mode,omitempty"`
+	Owner                *pfs.FileOwner `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *FileNodeProto) Reset()         { *m = FileNodeProto{} }
 func (m *FileNodeProto) String() string { return proto.CompactTextString(m) }
 func (*FileNodeProto) ProtoMessage()    {}
 func (*FileNodeProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{0}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{0}
 }
 func (m *FileNodeProto) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -98,6 +102,20 @@ func (m *FileNodeProto) GetHasHeaderFooter() bool {
 	return false
 }
 
+func (m *FileNodeProto) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileNodeProto) GetOwner() *pfs.FileOwner {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
 // Shared refers to data common to all direct children of a directory (i.e.
 // headers and footers)
 type Shared struct {
@@ -116,7 +134,7 @@ func (m *Shared) Reset()         { *m = Shared{} }
 func (m *Shared) String() string { return proto.CompactTextString(m) }
 func (*Shared) ProtoMessage()    {}
 func (*Shared) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{1}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{1}
 }
 func (m *Shared) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -194,7 +212,7 @@ func (m *DirectoryNodeProto) Reset()         { *m = DirectoryNodeProto{} }
 func (m *DirectoryNodeProto) String() string { return proto.CompactTextString(m) }
 func (*DirectoryNodeProto) ProtoMessage()    {}
 func (*DirectoryNodeProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{2}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{2}
 }
 func (m *DirectoryNodeProto) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -261,7 +279,7 @@ func (m *NodeProto) Reset()         { *m = NodeProto{} }
 func (m *NodeProto) String() string { return proto.CompactTextString(m) }
 func (*NodeProto) ProtoMessage()    {}
 func (*NodeProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{3}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{3}
 }
 func (m *NodeProto) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -350,7 +368,7 @@ func (m *HashTreeProto) Reset()         { *m = HashTreeProto{} }
 func (m *HashTreeProto) String() string { return proto.CompactTextString(m) }
 func (*HashTreeProto) ProtoMessage()    {}
 func (*HashTreeProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{4}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{4}
 }
 func (m *HashTreeProto) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -404,7 +422,7 @@ func (m *BucketHeader) Reset()         { *m = BucketHeader{} }
 func (m *BucketHeader) String() string { return proto.CompactTextString(m) }
 func (*BucketHeader) ProtoMessage()    {}
 func (*BucketHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{5}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{5}
 }
 func (m *BucketHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -452,7 +470,7 @@ func (m *Index) Reset()         { *m = Index{} }
 func (m *Index) String() string { return proto.CompactTextString(m) }
 func (*Index) ProtoMessage()    {}
 func (*Index) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hashtree_7c4d1e2389fbd556, []int{6}
+	return fileDescriptor_hashtree_46cee560c139cbd1, []int{6}
 }
 func (m *Index) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -554,6 +572,21 @@ func (m *FileNodeProto) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Mode != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintHashtree(dAtA, i, uint64(m.Mode))
+	}
+	if m.Owner != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintHashtree(dAtA, i, uint64(m.Owner.Size()))
+		n1, err := m.Owner.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -579,21 +612,21 @@ func (m *Shared) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintHashtree(dAtA, i, uint64(m.Header.Size()))
-		n1, err := m.Header.MarshalTo(dAtA[i:])
+		n2, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n1
+		i += n2
 	}
 	if m.Footer != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintHashtree(dAtA, i, uint64(m.Footer.Size()))
-		n2, err := m.Footer.MarshalTo(dAtA[i:])
+		n3, err := m.Footer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n2
+		i += n3
 	}
 	if m.HeaderSize != 0 {
 		dAtA[i] = 0x18
@@ -645,11 +678,11 @@ func (m *DirectoryNodeProto) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintHashtree(dAtA, i, uint64(m.Shared.Size()))
-		n3, err := m.Shared.MarshalTo(dAtA[i:])
+		n4, err := m.Shared.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -693,21 +726,21 @@ func (m *NodeProto) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintHashtree(dAtA, i, uint64(m.FileNode.Size()))
-		n4, err := m.FileNode.MarshalTo(dAtA[i:])
+		n5, err := m.FileNode.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
 	}
 	if m.DirNode != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintHashtree(dAtA, i, uint64(m.DirNode.Size()))
-		n5, err := m.DirNode.MarshalTo(dAtA[i:])
+		n6, err := m.DirNode.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n6
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -755,11 +788,11 @@ func (m *HashTreeProto) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintHashtree(dAtA, i, uint64(v.Size()))
-				n6, err := v.MarshalTo(dAtA[i:])
+				n7, err := v.MarshalTo(dAtA[i:])
 				if err != nil {
 					return 0, err
 				}
-				i += n6
+				i += n7
 			}
 		}
 	}
@@ -858,6 +891,13 @@ func (m *FileNodeProto) Size() (n int) {
 	if m.HasHeaderFooter {
 		n += 2
 	}
+	if m.Mode != 0 {
+		n += 1 + sovHashtree(uint64(m.Mode))
+	}
+	if m.Owner != nil {
+		l = m.Owner.Size()
+		n += 1 + l + sovHashtree(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1130,6 +1170,58 @@ func (m *FileNodeProto) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.HasHeaderFooter = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHashtree
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHashtree
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHashtree
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Owner == nil {
+				m.Owner = &pfs.FileOwner{}
+			}
+			if err := m.Owner.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHashtree(dAtA[iNdEx:])
@@ -2096,46 +2188,48 @@ var (
 )
 
 func init() {
-	proto.RegisterFile("server/pkg/hashtree/hashtree.proto", fileDescriptor_hashtree_7c4d1e2389fbd556)
-}
-
-var fileDescriptor_hashtree_7c4d1e2389fbd556 = []byte{
-	// 582 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x65, 0x6d, 0x27, 0x71, 0x26, 0xa9, 0x08, 0x0b, 0x02, 0xab, 0x42, 0x69, 0x30, 0x02, 0x05,
-	0x04, 0x89, 0x54, 0x10, 0x20, 0x8e, 0x15, 0x54, 0x25, 0x07, 0x40, 0x5b, 0x4e, 0x5c, 0x22, 0x7f,
-	0x8c, 0x6b, 0x13, 0xd7, 0x1b, 0xed, 0x3a, 0x15, 0xe9, 0xef, 0xe0, 0xc0, 0x99, 0x03, 0xff, 0x04,
-	0x89, 0x23, 0x3f, 0x01, 0x95, 0x3f, 0x82, 0xf6, 0xa3, 0x75, 0x0b, 0x3d, 0x44, 0x9a, 0xf7, 0xe6,
-	0xcd, 0xf3, 0xbc, 0xcd, 0x2e, 0x84, 0x12, 0xc5, 0x11, 0x8a, 0xe9, 0x72, 0x71, 0x30, 0xcd, 0x23,
-	0x99, 0xd7, 0x02, 0xf1, 0xac, 0x98, 0x2c, 0x05, 0xaf, 0x39, 0xf5, 0x4f, 0xf1, 0xe6, 0x8d, 0xa4,
-	0x2c, 0xb0, 0xaa, 0xa7, 0xcb, 0x4c, 0xaa, 0x9f, 0xe9, 0x87, 0xdf, 0x08, 0x6c, 0xec, 0x16, 0x25,
-	0xbe, 0xe5, 0x29, 0xbe, 0xd7, 0x13, 0xf7, 0xa0, 0xc3, 0xe3, 0x4f, 0x98, 0xd4, 0x32, 0xf0, 0x46,
-	0xee, 0xb8, 0xb7, 0xdd, 0x9b, 0x28, 0xf9, 0x3b, 0xcd, 0xb1, 0xd3, 0x1e, 0x7d, 0x04, 0x10, 0x97,
-	0x3c, 0x59, 0xcc, 0x05, 0x66, 0x32, 0x68, 0x69, 0xe5, 0x86, 0x56, 0xee, 0x28, 0x9a, 0x61, 0xc6,
-	0xba, 0xb1, 0xad, 0x24, 0x7d, 0x08, 0xd7, 0xf2, 0x48, 0xce, 0x73, 0x8c, 0x52, 0x14, 0xf3, 0x8c,
-	0xf3, 0x1a, 0x45, 0xd0, 0x1e, 0x91, 0xb1, 0xcf, 0xae, 0xe6, 0x91, 0xdc, 0xd3, 0xfc, 0xae, 0xa6,
-	0x67, 0x9e, 0x4f, 0x06, 0xce, 0xcc, 0xf3, 0x9d, 0x81, 0x3b, 0xf3, 0x7c, 0x77, 0xe0, 0x85, 0x5f,
-	0x08, 0xb4, 0xf7, 0xf3, 0x48, 0x60, 0x4a, 0xef, 0x42, 0xdb, 0x98, 0x04, 0x64, 0x44, 0xfe, 0x5d,
-	0xce, 0xb6, 0x94, 0xc8, 0x7e, 0xc2, 0xb9, 0x44, 0x64, 0x5a, 0x74, 0x0b, 0x7a, 0x76, 0x1d, 0x59,
-	0x1c, 0x63, 0xe0, 0x8e, 0xc8, 0xd8, 0x65, 0x60, 0xa8, 0xfd, 0xe2, 0x18, 0x95, 0xc0, 0x48, 0x8d,
-	0xc0, 0x33, 0x02, 0x43, 0x29, 0x41, 0x98, 0x01, 0x7d, 0x55, 0x08, 0x4c, 0x6a, 0x2e, 0xd6, 0xcd,
-	0xf9, 0x6d, 0x82, 0x9f, 0xe4, 0x45, 0x99, 0x0a, 0xac, 0x02, 0x77, 0xe4, 0x8e, 0xbb, 0xec, 0x0c,
-	0xd3, 0x31, 0xb4, 0xa5, 0xce, 0xa1, 0xdd, 0x7a, 0xdb, 0x83, 0xc9, 0xd9, 0xdf, 0x65, 0xf2, 0x31,
-	0xdb, 0x3f, 0x7f, 0x08, 0xe1, 0x0f, 0x02, 0xdd, 0xc6, 0x9f, 0x82, 0x57, 0x45, 0x87, 0xa8, 0xf3,
-	0x77, 0x99, 0xae, 0x15, 0xa7, 0x8c, 0x74, 0xdc, 0x3e, 0xd3, 0x35, 0xbd, 0x03, 0x7d, 0xb9, 0x8a,
-	0x95, 0xf7, 0xf9, 0x80, 0x3d, 0xcb, 0xe9, 0x84, 0x4f, 0xa1, 0x9b, 0x15, 0x25, 0xce, 0x2b, 0x9e,
-	0xa2, 0xdd, 0xe8, 0x56, 0xb3, 0xd1, 0x85, 0x6b, 0xc1, 0xfc, 0xcc, 0x42, 0xfa, 0x1c, 0xfc, 0xb4,
-	0x10, 0x66, 0xa8, 0xa5, 0x87, 0x6e, 0x37, 0x43, 0xff, 0x1f, 0x08, 0xeb, 0xa4, 0x85, 0x50, 0x28,
-	0xfc, 0x4e, 0x60, 0x63, 0x2f, 0x92, 0xf9, 0x07, 0x81, 0x36, 0x4b, 0x00, 0x9d, 0x23, 0x14, 0xb2,
-	0xe0, 0x95, 0x8e, 0xd3, 0x62, 0xa7, 0x90, 0x4e, 0xc1, 0xc9, 0x64, 0xe0, 0xe8, 0x6b, 0xb5, 0xd5,
-	0xd8, 0x5f, 0x18, 0x9f, 0xec, 0xca, 0xd7, 0x55, 0x2d, 0xd6, 0xcc, 0xc9, 0xe4, 0xe6, 0x0c, 0x3a,
-	0x16, 0xd2, 0x01, 0xb8, 0x0b, 0x5c, 0xdb, 0x03, 0x52, 0x25, 0x7d, 0x00, 0xad, 0xa3, 0xa8, 0x5c,
-	0xa1, 0xbd, 0x0f, 0xd7, 0x1b, 0xc3, 0x66, 0x4d, 0xa3, 0x78, 0xe9, 0xbc, 0x20, 0xe1, 0x7d, 0xe8,
-	0xef, 0xac, 0x92, 0x05, 0xd6, 0xe6, 0x5e, 0xd2, 0x9b, 0xd0, 0x8e, 0x35, 0xb6, 0x9e, 0x16, 0x85,
-	0x8f, 0xa1, 0xf5, 0xa6, 0x4a, 0xf1, 0x33, 0xed, 0x03, 0x59, 0xe8, 0x5e, 0x9f, 0x91, 0x85, 0x92,
-	0xf3, 0x2c, 0x93, 0x58, 0xeb, 0xcf, 0x79, 0xcc, 0xa2, 0x9d, 0xbd, 0x9f, 0x27, 0x43, 0xf2, 0xeb,
-	0x64, 0x48, 0x7e, 0x9f, 0x0c, 0xc9, 0xd7, 0x3f, 0xc3, 0x2b, 0x1f, 0x9f, 0x1d, 0x14, 0x75, 0xbe,
-	0x8a, 0x27, 0x09, 0x3f, 0x9c, 0x2e, 0xa3, 0x24, 0x5f, 0xa7, 0x28, 0xce, 0x57, 0x52, 0x24, 0xd3,
-	0x4b, 0x1e, 0x79, 0xdc, 0xd6, 0x8f, 0xf7, 0xc9, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5c, 0x3f,
-	0xaa, 0x91, 0x02, 0x04, 0x00, 0x00,
+	proto.RegisterFile("server/pkg/hashtree/hashtree.proto", fileDescriptor_hashtree_46cee560c139cbd1)
+}
+
+var fileDescriptor_hashtree_46cee560c139cbd1 = []byte{
+	// 617 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xbe, 0x63, 0x3b, 0x89, 0x73, 0x92, 0xdc, 0x9b, 0x3b, 0x20, 0xb0, 0x2a, 0x94, 0x1a, 0xf3,
+	0x23, 0x83, 0x20, 0x91, 0x0a, 0x02, 0xc4, 0xb2, 0x82, 0xaa, 0x64, 0x41, 0xd1, 0x94, 0x15, 0x9b,
+	0xc8, 0x3f, 0xc7, 0xb5, 0x49, 0x6a, 0x47, 0x33, 0x4e, 0x21, 0x7d, 0x0e, 0x16, 0x3c, 0x01, 0x6f,
+	0x82, 0xc4, 0x92, 0x07, 0x60, 0x81, 0xca, 0x8b, 0xa0, 0xf9, 0x69, 0xdd, 0x42, 0x17, 0x91, 0xce,
+	0xf7, 0x9d, 0xef, 0x9c, 0x39, 0xdf, 0xcc, 0x89, 0x21, 0x10, 0xc8, 0x8f, 0x90, 0x4f, 0x96, 0xf3,
+	0x83, 0x49, 0x1e, 0x89, 0xbc, 0xe6, 0x88, 0x67, 0xc1, 0x78, 0xc9, 0xab, 0xba, 0xa2, 0xee, 0x29,
+	0xde, 0xb8, 0x9a, 0x2c, 0x0a, 0x2c, 0xeb, 0xc9, 0x32, 0x13, 0xf2, 0xa7, 0xf3, 0xc1, 0x0f, 0x02,
+	0x83, 0x9d, 0x62, 0x81, 0xaf, 0xab, 0x14, 0xdf, 0xa8, 0x8a, 0x3b, 0xd0, 0xa9, 0xe2, 0xf7, 0x98,
+	0xd4, 0xc2, 0x73, 0x7c, 0x3b, 0xec, 0x6d, 0xf5, 0xc6, 0x52, 0xbe, 0xa7, 0x38, 0x76, 0x9a, 0xa3,
+	0x0f, 0x00, 0xe2, 0x45, 0x95, 0xcc, 0x67, 0x1c, 0x33, 0xe1, 0xb5, 0x94, 0x72, 0xa0, 0x94, 0xdb,
+	0x92, 0x66, 0x98, 0xb1, 0x6e, 0x6c, 0x22, 0x41, 0xef, 0xc3, 0xff, 0x79, 0x24, 0x66, 0x39, 0x46,
+	0x29, 0xf2, 0x59, 0x56, 0x55, 0x35, 0x72, 0xaf, 0xed, 0x93, 0xd0, 0x65, 0xff, 0xe5, 0x91, 0xd8,
+	0x55, 0xfc, 0x8e, 0xa2, 0x29, 0x05, 0xe7, 0xb0, 0x4a, 0xd1, 0xeb, 0xf8, 0x24, 0x1c, 0x30, 0x15,
+	0xd3, 0xdb, 0xd0, 0xaa, 0x3e, 0x94, 0xc8, 0x3d, 0xd7, 0x27, 0x61, 0x6f, 0xeb, 0x5f, 0x75, 0x90,
+	0x9c, 0x7b, 0x4f, 0xb2, 0x4c, 0x27, 0xa7, 0x8e, 0x4b, 0x86, 0xd6, 0xd4, 0x71, 0xad, 0xa1, 0x3d,
+	0x75, 0x5c, 0x7b, 0xe8, 0x04, 0x9f, 0x08, 0xb4, 0xf7, 0xf3, 0x88, 0x63, 0x4a, 0x6f, 0x41, 0x5b,
+	0x1f, 0xef, 0x11, 0x9f, 0xfc, 0x69, 0xcb, 0xa4, 0xa4, 0xc8, 0x0c, 0x67, 0x5d, 0x22, 0xd2, 0x29,
+	0xba, 0x09, 0x3d, 0x63, 0x44, 0x14, 0xc7, 0xe8, 0xd9, 0x3e, 0x09, 0x6d, 0x06, 0x9a, 0xda, 0x2f,
+	0x8e, 0x51, 0x0a, 0xb4, 0x54, 0x0b, 0x1c, 0x2d, 0xd0, 0x94, 0x14, 0x04, 0x19, 0xd0, 0x17, 0x05,
+	0xc7, 0xa4, 0xae, 0xf8, 0xba, 0xb9, 0xf9, 0x0d, 0x70, 0x93, 0xbc, 0x58, 0xa4, 0x1c, 0x4b, 0xcf,
+	0xf6, 0xed, 0xb0, 0xcb, 0xce, 0x30, 0x0d, 0xa1, 0x2d, 0x94, 0x0f, 0xd5, 0xad, 0xb7, 0x35, 0x1c,
+	0x9f, 0x3d, 0xb4, 0xf6, 0xc7, 0x4c, 0xfe, 0xfc, 0x25, 0x04, 0x5f, 0x09, 0x74, 0x9b, 0xfe, 0x14,
+	0x9c, 0x32, 0x3a, 0x44, 0xe5, 0xbf, 0xcb, 0x54, 0x2c, 0x39, 0xd9, 0x48, 0xd9, 0xed, 0x33, 0x15,
+	0xd3, 0x9b, 0xd0, 0x17, 0xab, 0x58, 0xf6, 0x3e, 0x6f, 0xb0, 0x67, 0x38, 0xe5, 0xf0, 0x31, 0x74,
+	0xb3, 0x62, 0x81, 0xb3, 0x52, 0x3e, 0x94, 0x9e, 0xe8, 0x7a, 0x33, 0xd1, 0x85, 0x85, 0x62, 0x6e,
+	0x66, 0x20, 0x7d, 0x0a, 0x6e, 0x5a, 0x70, 0x5d, 0xd4, 0x52, 0x45, 0x37, 0x9a, 0xa2, 0xbf, 0x2f,
+	0x84, 0x75, 0xd2, 0x82, 0x4b, 0x14, 0x7c, 0x21, 0x30, 0xd8, 0x8d, 0x44, 0xfe, 0x96, 0xa3, 0xf1,
+	0xe2, 0x41, 0xe7, 0x08, 0xb9, 0x28, 0xaa, 0x52, 0xd9, 0x69, 0xb1, 0x53, 0x48, 0x27, 0x60, 0x65,
+	0xc2, 0xb3, 0xd4, 0x42, 0x6e, 0x36, 0xed, 0x2f, 0x94, 0x8f, 0x77, 0xc4, 0xcb, 0xb2, 0xe6, 0x6b,
+	0x66, 0x65, 0x62, 0x63, 0x0a, 0x1d, 0x03, 0xe9, 0x10, 0xec, 0x39, 0xae, 0xcd, 0x05, 0xc9, 0x90,
+	0xde, 0x83, 0xd6, 0x51, 0xb4, 0x58, 0xa1, 0xd9, 0x87, 0x2b, 0x4d, 0xc3, 0x66, 0x4c, 0xad, 0x78,
+	0x6e, 0x3d, 0x23, 0xc1, 0x5d, 0xe8, 0x6f, 0xaf, 0x92, 0x39, 0xd6, 0x7a, 0xa3, 0xe9, 0x35, 0x68,
+	0xc7, 0x0a, 0x9b, 0x9e, 0x06, 0x05, 0x0f, 0xa1, 0xf5, 0xaa, 0x4c, 0xf1, 0x23, 0xed, 0x03, 0x99,
+	0xab, 0x5c, 0x9f, 0x91, 0xb9, 0x94, 0x57, 0x59, 0x26, 0xb0, 0x56, 0xc7, 0x39, 0xcc, 0xa0, 0xed,
+	0xdd, 0x6f, 0x27, 0x23, 0xf2, 0xfd, 0x64, 0x44, 0x7e, 0x9e, 0x8c, 0xc8, 0xe7, 0x5f, 0xa3, 0x7f,
+	0xde, 0x3d, 0x39, 0x28, 0xea, 0x7c, 0x15, 0x8f, 0x93, 0xea, 0x70, 0xb2, 0x8c, 0x92, 0x7c, 0x9d,
+	0x22, 0x3f, 0x1f, 0x09, 0x9e, 0x4c, 0x2e, 0xf9, 0x3c, 0xc4, 0x6d, 0xf5, 0xb7, 0x7f, 0xf4, 0x7b,
+	0x00, 0xfb, 0x81, 0x8f, 0x0d, 0x3c, 0x04, 0x00, 0x00,
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"testing"
 
 	bolt "github.com/coreos/bbolt"
@@ -634,3 +636,91 @@ func TestCache(t *testing.T) {
 	_, err = h.Get("foo")
 	require.YesError(t, err)
 }
+
+func TestPruningReader(t *testing.T) {
+	o := NewOrdered("")
+	o.PutDir("/a")
+	o.PutFile("/a/x", []byte("x"), 1, &FileNodeProto{})
+	o.PutFile("/a/y", []byte("y"), 2, &FileNodeProto{})
+	o.PutDir("/b")
+	o.PutFile("/b/z", []byte("z"), 4, &FileNodeProto{})
+	o.PutFile("/b/zz", []byte("zz"), 8, &FileNodeProto{})
+	in := &bytes.Buffer{}
+	require.NoError(t, o.Serialize(in))
+
+	out := &bytes.Buffer{}
+	size, err := Merge(NewWriter(out), []*Reader{
+		NewPruningReader(in, nil, map[string]int64{"/a": 3, "/b/z": 4}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), size)
+	sizes := make(map[string]int64)
+	require.NoError(t, nodes([]io.ReadCloser{ioutil.NopCloser(out)}, func(path string, node *NodeProto) error {
+		sizes[path] = node.SubtreeSize
+		return nil
+	}))
+	require.Equal(t, map[string]int64{"": 8, "/b": 8, "/b/zz": 8}, sizes)
+}
+
+func TestMergePermissions(t *testing.T) {
+	serialize := func(mode uint32, owner *pfs.FileOwner) *bytes.Buffer {
+		o := NewOrdered("")
+		o.PutFile("/f", []byte("f"), 1, &FileNodeProto{Mode: mode, Owner: owner})
+		buf := &bytes.Buffer{}
+		require.NoError(t, o.Serialize(buf))
+		return buf
+	}
+	owner := &pfs.FileOwner{Uid: 1000, Gid: 1000}
+	out := &bytes.Buffer{}
+	_, err := Merge(NewWriter(out), []*Reader{
+		NewReader(serialize(0, nil), nil),
+		NewReader(serialize(0755, owner), nil),
+		NewReader(serialize(0, nil), nil),
+	})
+	require.NoError(t, err)
+	var file *FileNodeProto
+	require.NoError(t, nodes([]io.ReadCloser{ioutil.NopCloser(out)}, func(path string, node *NodeProto) error {
+		if path == "/f" {
+			file = node.FileNode
+		}
+		return nil
+	}))
+	// The merged file keeps the permissions of the file that has them
+	require.Equal(t, uint32(0755), file.Mode)
+	require.Equal(t, owner, file.Owner)
+}
+
+func TestMergeOrder(t *testing.T) {
+	serialize := func(block string) *bytes.Buffer {
+		o := NewOrdered("")
+		o.PutFile("/f", []byte(block), 1, &FileNodeProto{
+			BlockRefs: []*pfs.BlockRef{{Block: &pfs.Block{Hash: block}}},
+		})
+		buf := &bytes.Buffer{}
+		require.NoError(t, o.Serialize(buf))
+		return buf
+	}
+	blocks := func(blockNames ...string) []string {
+		var rs []*Reader
+		for _, block := range blockNames {
+			rs = append(rs, NewReader(serialize(block), nil))
+		}
+		out := &bytes.Buffer{}
+		_, err := Merge(NewWriter(out), rs)
+		require.NoError(t, err)
+		var result []string
+		require.NoError(t, nodes([]io.ReadCloser{ioutil.NopCloser(out)}, func(path string, node *NodeProto) error {
+			if path == "/f" {
+				for _, blockRef := range node.FileNode.BlockRefs {
+					result = append(result, blockRef.Block.Hash)
+				}
+			}
+			return nil
+		}))
+		return result
+	}
+	// A file's content is merged in the order of the readers it came from
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	require.Equal(t, names, blocks(names...))
+	require.Equal(t, []string{"g", "f", "e", "d", "c", "b", "a"}, blocks("g", "f", "e", "d", "c", "b", "a"))
+}
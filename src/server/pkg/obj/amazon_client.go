@@ -234,6 +234,12 @@ func (c *amazonClient) Writer(name string) (io.WriteCloser, error) {
 }
 
 func (c *amazonClient) Walk(name string, fn func(name string) error) error {
+	return c.WalkInfo(name, func(info *ObjectInfo) error {
+		return fn(info.Name)
+	})
+}
+
+func (c *amazonClient) WalkInfo(name string, fn func(info *ObjectInfo) error) error {
 	var fnErr error
 	var prefix *string
 
@@ -255,7 +261,11 @@ func (c *amazonClient) Walk(name string, fn func(name string) error) error {
 					key = reverse(key)
 				}
 				if strings.HasPrefix(key, name) {
-					if err := fn(key); err != nil {
+					if err := fn(&ObjectInfo{
+						Name: key,
+						ETag: aws.StringValue(object.ETag),
+						Size: aws.Int64Value(object.Size),
+					}); err != nil {
 						fnErr = err
 						return false
 					}
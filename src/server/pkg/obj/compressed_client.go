@@ -0,0 +1,634 @@
+package obj
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+)
+
+// Storage compression environment variables
+const (
+	// StorageCompressionEnvVar is the codec that objects are compressed with
+	// when they're written ("gzip" or "snappy", or "none" to write them
+	// uncompressed while still reading objects that were compressed). If it's
+	// unset, objects are read and written as they're stored.
+	StorageCompressionEnvVar = "STORAGE_COMPRESSION"
+	// StorageCompressionLevelEnvVar is the level of the gzip codec, from 1
+	// (fastest) to 9 (smallest). If it's unset, gzip's default is used.
+	StorageCompressionLevelEnvVar = "STORAGE_COMPRESSION_LEVEL"
+)
+
+// Valid storage compression codecs. zstd, which would compress about as well
+// as gzip at close to snappy's speed, isn't offered, as there's no Go zstd
+// implementation among pachyderm's vendored dependencies.
+const (
+	GzipCompression   = "gzip"
+	SnappyCompression = "snappy"
+	NoCompression     = "none"
+)
+
+const (
+	// compressionMagic begins every object that a compressedClient writes in
+	// frames. It's followed by compressionFormat and the object's ID (which
+	// identifies its index, see compressionIndexSuffix). Objects that don't
+	// begin with it are stored as they were written, and are read as they are.
+	// Objects are only written in frames if they're compressed, or if they'd
+	// otherwise be mistaken for being in frames because they begin with
+	// compressionMagic themselves.
+	compressionMagic  = "\x89PACHZ\r\n"
+	compressionFormat = 1
+	compressionIDSize = 8
+	// compressionHeaderSize is the size of the header of an object that's
+	// written in frames
+	compressionHeaderSize = len(compressionMagic) + 1 + compressionIDSize
+	// compressionMinFrameSize and compressionMaxFrameSize bound the amount of
+	// an object's content that's compressed together, in one frame. A frame
+	// ends with the first write that makes it at least
+	// compressionMinFrameSize, so each object that pfs writes to a block is
+	// compressed on its own, unless it's small enough to be compressed along
+	// with the objects written after it. Larger writes are split into
+	// compressionMaxFrameSize frames.
+	compressionMinFrameSize = 64 * 1024
+	compressionMaxFrameSize = 1024 * 1024
+	// compressionMinRatio is the largest size, relative to its content, that
+	// a compressed frame can have for the frame to be stored compressed.
+	// Content that's already compressed (e.g. images or gzipped files) doesn't
+	// get any smaller, so it's stored as it is. An object whose first frame
+	// doesn't compress is stored as it is, without frames, so that reads of
+	// part of it go straight to the store.
+	compressionMinRatio = 0.9
+	// compressionIndexSuffix is appended to the name of an object that's
+	// written in more than one frame to get the name of its index, which lists
+	// where each of its frames begins. Indexes aren't listed by Walk, and are
+	// deleted along with their objects.
+	compressionIndexSuffix = ".pachz-index"
+)
+
+type codecID byte
+
+const (
+	codecNone codecID = iota
+	codecGzip
+	codecSnappy
+)
+
+var codecIDs = map[string]codecID{
+	GzipCompression:   codecGzip,
+	SnappyCompression: codecSnappy,
+	NoCompression:     codecNone,
+}
+
+// compressedClient is a Client that compresses the objects it writes, and
+// decompresses the objects it reads. Compression is transparent to readers:
+// offsets and sizes passed to Reader are in the uncompressed object.
+//
+// Objects are compressed in frames (see compressionMinFrameSize), each of
+// which is decompressed on its own, so reading part of an object only
+// decompresses the frames that hold that part.
+type compressedClient struct {
+	Client
+	codec codecID
+	level int
+}
+
+// ValidateCompression returns an error if 'codec' and 'level' aren't a valid
+// storage compression setting. An empty codec disables compression, and a
+// level of 0 is the codec's default.
+func ValidateCompression(codec string, level int) error {
+	if codec == "" {
+		if level != 0 {
+			return fmt.Errorf("a compression level can't be set without a codec")
+		}
+		return nil
+	}
+	id, ok := codecIDs[codec]
+	if !ok {
+		return fmt.Errorf("unsupported storage compression codec %q (must be %q, %q or %q)", codec, GzipCompression, SnappyCompression, NoCompression)
+	}
+	if level == 0 {
+		return nil
+	}
+	if id != codecGzip {
+		return fmt.Errorf("the %s codec doesn't have compression levels", codec)
+	}
+	if level < gzip.BestSpeed || level > gzip.BestCompression {
+		return fmt.Errorf("%s compression level must be between %d and %d", codec, gzip.BestSpeed, gzip.BestCompression)
+	}
+	return nil
+}
+
+// NewCompressedClient returns a Client that compresses the objects that it
+// writes to 'c' with 'codec', and decompresses them when they're read.
+// Objects that aren't compressed, because they were written before
+// compression was enabled or because their content doesn't compress, are read
+// as they are, and reads of part of them go straight to 'c'.
+//
+// If 'codec' is empty, 'c' is returned as it is, so objects are read and
+// written exactly as they're stored. A cluster that has compressed objects
+// must use NoCompression instead to stop compressing, which writes objects
+// uncompressed but still decompresses the objects that were compressed.
+func NewCompressedClient(c Client, codec string, level int) (Client, error) {
+	if err := ValidateCompression(codec, level); err != nil {
+		return nil, err
+	}
+	if codec == "" {
+		return c, nil
+	}
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return &compressedClient{
+		Client: c,
+		codec:  codecIDs[codec],
+		level:  level,
+	}, nil
+}
+
+// NewCompressedClientFromEnv returns a compressed client that writes to 'c'
+// with the codec and level in the storage compression environment variables,
+// or 'c' itself if no codec is set.
+func NewCompressedClientFromEnv(c Client) (Client, error) {
+	var level int
+	if levelStr, ok := os.LookupEnv(StorageCompressionLevelEnvVar); ok && levelStr != "" {
+		var err error
+		if level, err = strconv.Atoi(levelStr); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", StorageCompressionLevelEnvVar, err)
+		}
+	}
+	return NewCompressedClient(c, os.Getenv(StorageCompressionEnvVar), level)
+}
+
+func (c *compressedClient) Writer(name string) (io.WriteCloser, error) {
+	w, err := c.Client.Writer(name)
+	if err != nil {
+		return nil, err
+	}
+	return &compressedWriter{c: c, name: name, w: w}, nil
+}
+
+// Reader reads an object from its beginning in one request. Reads of part of
+// an object first read its header, and then the part itself if the object
+// isn't in frames, or the frames that hold the part if it is.
+func (c *compressedClient) Reader(name string, offset uint64, size uint64) (io.ReadCloser, error) {
+	if offset == 0 {
+		r, err := c.Client.Reader(name, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		header, content, err := readHeader(r)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		if header == nil {
+			return limitReader(content, size, r), nil
+		}
+		return limitReader(newFrameReader(content), size, r), nil
+	}
+	header, err := c.readHeader(name)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return c.Client.Reader(name, offset, size)
+	}
+	// Start at the last frame that begins at or before 'offset'
+	start := frameIndexEntry{storedOffset: uint64(compressionHeaderSize)}
+	index, err := c.readIndex(name, header)
+	if err != nil {
+		return nil, err
+	}
+	if i := sort.Search(len(index), func(i int) bool { return index[i].offset > offset }); i > 0 {
+		start = index[i-1]
+	}
+	// and end at the first frame that begins after the part, if there is one
+	var storedSize uint64
+	if size != 0 {
+		if i := sort.Search(len(index), func(i int) bool { return index[i].offset >= offset+size }); i < len(index) {
+			storedSize = index[i].storedOffset - start.storedOffset
+		}
+	}
+	r, err := c.Client.Reader(name, start.storedOffset, storedSize)
+	if err != nil {
+		return nil, err
+	}
+	fr := newFrameReader(r)
+	if err := fr.skip(offset - start.offset); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return limitReader(fr, size, r), nil
+}
+
+func (c *compressedClient) Delete(name string) error {
+	if err := c.Client.Delete(name); err != nil {
+		return err
+	}
+	if err := c.Client.Delete(name + compressionIndexSuffix); err != nil && !c.Client.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Walk doesn't list the indexes of objects
+func (c *compressedClient) Walk(prefix string, fn func(name string) error) error {
+	return c.Client.Walk(prefix, func(name string) error {
+		if strings.HasSuffix(name, compressionIndexSuffix) {
+			return nil
+		}
+		return fn(name)
+	})
+}
+
+// readHeader reads the header of the object 'name', and returns nil if the
+// object isn't in frames
+func (c *compressedClient) readHeader(name string) ([]byte, error) {
+	r, err := c.Client.Reader(name, 0, uint64(compressionHeaderSize))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	header, _, err := readHeader(r)
+	return header, err
+}
+
+// readHeader reads the header of an object from 'r', and returns the header
+// (or nil, if the object isn't in frames) and the object's content after it
+func readHeader(r io.Reader) ([]byte, io.Reader, error) {
+	header := make([]byte, compressionHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, nil, err
+	}
+	if n < len(header) || string(header[:len(compressionMagic)]) != compressionMagic {
+		// The object was written as it is
+		return nil, io.MultiReader(bytes.NewReader(header[:n]), r), nil
+	}
+	if format := header[len(compressionMagic)]; format != compressionFormat {
+		return nil, nil, fmt.Errorf("object has an unknown compression format (%d)", format)
+	}
+	return header, r, nil
+}
+
+type frameIndexEntry struct {
+	// offset is where a frame begins in the object's content, and
+	// storedOffset is where it begins in the stored object
+	offset       uint64
+	storedOffset uint64
+}
+
+// readIndex returns the index of the object 'name', whose header is
+// 'header'. It returns nil (so the object is read from its first frame) if
+// the object doesn't have an index, because it has only one frame or because
+// its writer failed before writing its index, or if the index belongs to an
+// earlier object of the same name.
+func (c *compressedClient) readIndex(name string, header []byte) ([]frameIndexEntry, error) {
+	r, err := c.Client.Reader(name+compressionIndexSuffix, 0, 0)
+	if err != nil {
+		if c.Client.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	id := header[len(header)-compressionIDSize:]
+	if len(data) < compressionIDSize || !bytes.Equal(data[:compressionIDSize], id) {
+		return nil, nil
+	}
+	data = data[compressionIDSize:]
+	var index []frameIndexEntry
+	for len(data) > 0 {
+		offset, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("index of object %s is corrupt", name)
+		}
+		data = data[n:]
+		storedOffset, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("index of object %s is corrupt", name)
+		}
+		data = data[n:]
+		index = append(index, frameIndexEntry{offset: offset, storedOffset: storedOffset})
+	}
+	return index, nil
+}
+
+// compressedWriter buffers each frame of an object, and writes it to 'w',
+// compressed if it gets enough smaller. The object's first frame decides
+// whether the object is written in frames or as it is.
+type compressedWriter struct {
+	c    *compressedClient
+	name string
+	w    io.WriteCloser
+	// frame is the content of the current frame
+	frame bytes.Buffer
+	// started is set once the first frame has been written, and framed is
+	// set if the object is written in frames
+	started bool
+	framed  bool
+	id      []byte
+	// offset and storedOffset are where the next frame begins in the
+	// object's content and in the stored object
+	offset       uint64
+	storedOffset uint64
+	index        []frameIndexEntry
+	compressed   bytes.Buffer
+	gzipWriter   *gzip.Writer
+}
+
+func (w *compressedWriter) Write(p []byte) (int, error) {
+	if w.started && !w.framed {
+		return w.w.Write(p)
+	}
+	n := len(p)
+	for len(p) > 0 {
+		chunk := p
+		if room := compressionMaxFrameSize - w.frame.Len(); len(chunk) > room {
+			chunk = chunk[:room]
+		}
+		w.frame.Write(chunk)
+		p = p[len(chunk):]
+		if w.frame.Len() >= compressionMaxFrameSize {
+			if err := w.writeFrame(); err != nil {
+				return 0, err
+			}
+			if !w.framed {
+				// The rest of the object is written as it is
+				if _, err := w.w.Write(p); err != nil {
+					return 0, err
+				}
+				return n, nil
+			}
+		}
+	}
+	if w.frame.Len() >= compressionMinFrameSize {
+		if err := w.writeFrame(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// writeFrame writes the current frame
+func (w *compressedWriter) writeFrame() error {
+	content := w.frame.Bytes()
+	defer w.frame.Reset()
+	id := codecNone
+	if w.c.codec != codecNone {
+		if err := w.compress(content); err != nil {
+			return err
+		}
+		if float64(w.compressed.Len()) < compressionMinRatio*float64(len(content)) {
+			id = w.c.codec
+		}
+	}
+	if !w.started {
+		w.started = true
+		// Objects are only written in frames if they're compressed, or if
+		// they'd otherwise be mistaken for being in frames
+		if id == codecNone && !bytes.HasPrefix(content, []byte(compressionMagic)) {
+			_, err := w.w.Write(content)
+			return err
+		}
+		w.framed = true
+		w.id = make([]byte, compressionIDSize)
+		if _, err := rand.Read(w.id); err != nil {
+			return err
+		}
+		header := append([]byte(compressionMagic), compressionFormat)
+		header = append(header, w.id...)
+		if _, err := w.w.Write(header); err != nil {
+			return err
+		}
+		w.storedOffset = uint64(len(header))
+	}
+	stored := content
+	if id != codecNone {
+		stored = w.compressed.Bytes()
+	}
+	frameHeader := make([]byte, 1+2*binary.MaxVarintLen64)
+	frameHeader[0] = byte(id)
+	n := 1 + binary.PutUvarint(frameHeader[1:], uint64(len(content)))
+	n += binary.PutUvarint(frameHeader[n:], uint64(len(stored)))
+	if _, err := w.w.Write(frameHeader[:n]); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(stored); err != nil {
+		return err
+	}
+	w.index = append(w.index, frameIndexEntry{offset: w.offset, storedOffset: w.storedOffset})
+	w.offset += uint64(len(content))
+	w.storedOffset += uint64(n + len(stored))
+	return nil
+}
+
+// compress compresses 'content' into w.compressed
+func (w *compressedWriter) compress(content []byte) error {
+	w.compressed.Reset()
+	var cw io.WriteCloser
+	if w.c.codec == codecSnappy {
+		cw = snappy.NewBufferedWriter(&w.compressed)
+	} else {
+		if w.gzipWriter == nil {
+			var err error
+			if w.gzipWriter, err = gzip.NewWriterLevel(&w.compressed, w.c.level); err != nil {
+				return err
+			}
+		} else {
+			w.gzipWriter.Reset(&w.compressed)
+		}
+		cw = w.gzipWriter
+	}
+	if _, err := cw.Write(content); err != nil {
+		return err
+	}
+	return cw.Close()
+}
+
+func (w *compressedWriter) Close() error {
+	if w.frame.Len() > 0 || !w.started {
+		if err := w.writeFrame(); err != nil {
+			w.w.Close()
+			return err
+		}
+	}
+	if err := w.w.Close(); err != nil {
+		return err
+	}
+	if len(w.index) < 2 {
+		return nil
+	}
+	// Write the index, so that reads of part of the object can start at the
+	// frame that holds it
+	index := append([]byte{}, w.id...)
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, entry := range w.index {
+		index = append(index, buf[:binary.PutUvarint(buf, entry.offset)]...)
+		index = append(index, buf[:binary.PutUvarint(buf, entry.storedOffset)]...)
+	}
+	iw, err := w.c.Client.Writer(w.name + compressionIndexSuffix)
+	if err != nil {
+		return err
+	}
+	if _, err := iw.Write(index); err != nil {
+		iw.Close()
+		return err
+	}
+	return iw.Close()
+}
+
+// frameReader reads the content of the frames of an object
+type frameReader struct {
+	r *bufio.Reader
+	// stored is the stored content of the current frame, frame is its
+	// content, and remaining is the amount of its content that hasn't been
+	// read
+	stored    *io.LimitedReader
+	frame     io.Reader
+	remaining uint64
+}
+
+func newFrameReader(r io.Reader) *frameReader {
+	return &frameReader{r: bufio.NewReader(r)}
+}
+
+func (r *frameReader) Read(p []byte) (int, error) {
+	for r.remaining == 0 {
+		codec, size, storedSize, err := r.nextFrame()
+		if err != nil {
+			return 0, err
+		}
+		if err := r.open(codec, size, storedSize); err != nil {
+			return 0, err
+		}
+	}
+	if uint64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.frame.Read(p)
+	r.remaining -= uint64(n)
+	if err == io.EOF {
+		if r.remaining != 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+		err = nil
+	}
+	return n, err
+}
+
+// skip discards the next 'n' bytes of content, without decompressing the
+// frames that it skips entirely
+func (r *frameReader) skip(n uint64) error {
+	for n > 0 {
+		if r.remaining == 0 {
+			codec, size, storedSize, err := r.nextFrame()
+			if err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			if n >= size {
+				if _, err := io.CopyN(ioutil.Discard, r.r, int64(storedSize)); err != nil {
+					return noEOF(err)
+				}
+				n -= size
+				continue
+			}
+			if err := r.open(codec, size, storedSize); err != nil {
+				return err
+			}
+		}
+		m := n
+		if m > r.remaining {
+			m = r.remaining
+		}
+		if _, err := io.CopyN(ioutil.Discard, r, int64(m)); err != nil {
+			return err
+		}
+		n -= m
+	}
+	return nil
+}
+
+// nextFrame reads the header of the next frame, after the rest of the
+// current one. It returns io.EOF if there isn't one.
+func (r *frameReader) nextFrame() (codec codecID, size uint64, storedSize uint64, retErr error) {
+	if r.stored != nil {
+		// Compressed frames may end with data that their decompressor
+		// doesn't read
+		if _, err := io.Copy(ioutil.Discard, r.stored); err != nil {
+			return 0, 0, 0, err
+		}
+		r.stored = nil
+	}
+	c, err := r.r.ReadByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if size, err = binary.ReadUvarint(r.r); err != nil {
+		return 0, 0, 0, noEOF(err)
+	}
+	if storedSize, err = binary.ReadUvarint(r.r); err != nil {
+		return 0, 0, 0, noEOF(err)
+	}
+	return codecID(c), size, storedSize, nil
+}
+
+// open starts reading the content of a frame, whose header has been read
+func (r *frameReader) open(codec codecID, size uint64, storedSize uint64) error {
+	r.stored = &io.LimitedReader{R: r.r, N: int64(storedSize)}
+	r.remaining = size
+	switch codec {
+	case codecNone:
+		r.frame = r.stored
+	case codecGzip:
+		gr, err := gzip.NewReader(r.stored)
+		if err != nil {
+			return err
+		}
+		r.frame = gr
+	case codecSnappy:
+		r.frame = snappy.NewReader(r.stored)
+	default:
+		return fmt.Errorf("object was compressed with an unknown codec (%d)", codec)
+	}
+	return nil
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// readCloser reads from a Reader and closes a different Closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitReader returns a ReadCloser that reads up to 'size' bytes from 'r'
+// (or all of it, if 'size' is 0) and closes 'closer'.
+func limitReader(r io.Reader, size uint64, closer io.Closer) io.ReadCloser {
+	if size != 0 {
+		r = io.LimitReader(r, int64(size))
+	}
+	return &readCloser{Reader: r, Closer: closer}
+}
@@ -0,0 +1,213 @@
+package obj
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// textCorpus returns about 'size' bytes of English-like text
+func textCorpus(size int) []byte {
+	words := strings.Fields(`the of and to in is was that for it as with be on
+		by at this from or an which are have had not but were they their been
+		has more one all would there when will so other into some what only
+		commit repo pipeline datum branch file job worker input output data`)
+	r := rand.New(rand.NewSource(0))
+	var buf bytes.Buffer
+	for buf.Len() < size {
+		buf.WriteString(words[r.Intn(len(words))])
+		if r.Intn(12) == 0 {
+			buf.WriteString(".\n")
+		} else {
+			buf.WriteString(" ")
+		}
+	}
+	return buf.Bytes()
+}
+
+func writeObject(t *testing.T, c Client, name string, data []byte) {
+	w, err := c.Writer(name)
+	require.NoError(t, err)
+	// Write in pieces, so that the probe is split across writes
+	for len(data) > 0 {
+		n := 1000
+		if n > len(data) {
+			n = len(data)
+		}
+		_, err := w.Write(data[:n])
+		require.NoError(t, err)
+		data = data[n:]
+	}
+	require.NoError(t, w.Close())
+}
+
+func readObject(t *testing.T, c Client, name string, offset, size uint64) []byte {
+	r, err := c.Reader(name, offset, size)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	return data
+}
+
+// checkReads checks that whole and partial reads of the object 'name' return
+// the same bytes as 'data'
+func checkReads(t *testing.T, c Client, name string, data []byte) {
+	require.True(t, bytes.Equal(data, readObject(t, c, name, 0, 0)))
+	if len(data) < 10 {
+		return
+	}
+	for _, r := range [][2]uint64{
+		{0, 10},
+		{1, uint64(len(data)) - 1},
+		{uint64(len(data)) / 2, 0},
+		{uint64(len(data)) / 3, uint64(len(data)) / 3},
+	} {
+		expected := data[r[0]:]
+		if r[1] != 0 {
+			expected = expected[:r[1]]
+		}
+		require.True(t, bytes.Equal(expected, readObject(t, c, name, r[0], r[1])))
+	}
+}
+
+// checkReadFetches checks that a read of 'size' bytes at 'offset' of the
+// object 'name' through 'c' fetches at most 'max' bytes from 'mem'
+func checkReadFetches(t *testing.T, c Client, mem *memClient, name string, offset, size uint64, max int) {
+	readBytes := mem.readBytes
+	readObject(t, c, name, offset, size)
+	require.True(t, mem.readBytes-readBytes <= max, "read of %d bytes at %d of %s fetched %d bytes", size, offset, name, mem.readBytes-readBytes)
+}
+
+func TestCompressedClient(t *testing.T) {
+	text := textCorpus(4 * 1024 * 1024)
+	random := make([]byte, 256*1024)
+	rand.New(rand.NewSource(0)).Read(random)
+	for _, codec := range []string{GzipCompression, SnappyCompression} {
+		mem := newMemClient()
+		c, err := NewCompressedClient(mem, codec, 0)
+		require.NoError(t, err)
+
+		// Compressible objects are stored compressed, in frames, and read back
+		// unchanged
+		writeObject(t, c, "text", text)
+		require.True(t, len(mem.objects["text"]) < len(text)/2)
+		require.True(t, mem.Exists("text"+compressionIndexSuffix))
+		checkReads(t, c, "text", text)
+
+		// Reads of part of a compressed object only fetch the frames that
+		// hold it (plus the object's header and index)
+		checkReadFetches(t, c, mem, "text", uint64(len(text))-100, 10, compressionMaxFrameSize)
+		checkReadFetches(t, c, mem, "text", uint64(len(text))/2, 10, compressionMaxFrameSize)
+		r := rand.New(rand.NewSource(0))
+		for i := 0; i < 20; i++ {
+			offset := r.Intn(len(text))
+			size := r.Intn(len(text) - offset)
+			require.True(t, bytes.Equal(text[offset:offset+size], readObject(t, c, "text", uint64(offset), uint64(size))))
+		}
+
+		// Incompressible objects are stored as they are, and reads of part of
+		// them go straight to the store
+		writeObject(t, c, "random", random)
+		require.True(t, bytes.Equal(random, mem.objects["random"]))
+		require.False(t, mem.Exists("random"+compressionIndexSuffix))
+		checkReads(t, c, "random", random)
+		checkReadFetches(t, c, mem, "random", 1000, 10, compressionHeaderSize+10)
+
+		// Small and empty objects
+		writeObject(t, c, "small", []byte("foo"))
+		checkReads(t, c, "small", []byte("foo"))
+		writeObject(t, c, "empty", nil)
+		checkReads(t, c, "empty", nil)
+
+		// Objects written before compression was enabled are read as they are
+		writeObject(t, mem, "legacy", text)
+		checkReads(t, c, "legacy", text)
+		checkReadFetches(t, c, mem, "legacy", 1000, 10, compressionHeaderSize+10)
+		writeObject(t, mem, "legacy-small", []byte("foo"))
+		checkReads(t, c, "legacy-small", []byte("foo"))
+
+		// Indexes aren't listed, and are deleted with their objects
+		require.NoError(t, c.Walk("text", func(name string) error {
+			require.Equal(t, "text", name)
+			return nil
+		}))
+		writeObject(t, c, "deleted", text)
+		require.NoError(t, c.Delete("deleted"))
+		require.False(t, mem.Exists("deleted"+compressionIndexSuffix))
+
+		// An index left by an earlier object of the same name isn't used
+		writeObject(t, c, "overwritten", text)
+		writeObject(t, mem, "overwritten", nil)
+		small := textCorpus(compressionMinFrameSize / 2)
+		writeObject(t, c, "overwritten", small)
+		checkReads(t, c, "overwritten", small)
+
+		// Compressed objects can still be read once compression is turned
+		// off, and new objects are written as they are
+		uncompressed, err := NewCompressedClient(mem, NoCompression, 0)
+		require.NoError(t, err)
+		checkReads(t, uncompressed, "text", text)
+		writeObject(t, uncompressed, "after", text)
+		require.True(t, bytes.Equal(text, mem.objects["after"]))
+		checkReads(t, uncompressed, "after", text)
+
+		// Objects that begin like a header are read back unchanged, whether
+		// or not compression is enabled
+		lookalike := append([]byte(compressionMagic), compressionFormat)
+		lookalike = append(lookalike, text[:compressionMaxFrameSize+1000]...)
+		writeObject(t, uncompressed, "lookalike", lookalike)
+		checkReads(t, uncompressed, "lookalike", lookalike)
+		checkReads(t, c, "lookalike", lookalike)
+		writeObject(t, c, "lookalike-compressed", lookalike)
+		checkReads(t, uncompressed, "lookalike-compressed", lookalike)
+		writeObject(t, uncompressed, "magic", []byte(compressionMagic))
+		checkReads(t, uncompressed, "magic", []byte(compressionMagic))
+	}
+
+	// Without a codec, objects are read and written through the store itself
+	mem := newMemClient()
+	c, err := NewCompressedClient(mem, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, Client(mem), c)
+}
+
+func TestValidateCompression(t *testing.T) {
+	require.NoError(t, ValidateCompression("", 0))
+	require.NoError(t, ValidateCompression(GzipCompression, 0))
+	require.NoError(t, ValidateCompression(GzipCompression, 9))
+	require.NoError(t, ValidateCompression(SnappyCompression, 0))
+	require.NoError(t, ValidateCompression(NoCompression, 0))
+	require.YesError(t, ValidateCompression("", 1))
+	require.YesError(t, ValidateCompression("zstd", 0))
+	require.YesError(t, ValidateCompression(GzipCompression, 10))
+	require.YesError(t, ValidateCompression(SnappyCompression, 1))
+}
+
+// TestCompressionSavings reports the storage saved by each codec on a text
+// corpus (run with -v to see it)
+func TestCompressionSavings(t *testing.T) {
+	text := textCorpus(4 * 1024 * 1024)
+	for _, setting := range []struct {
+		codec string
+		level int
+	}{
+		{SnappyCompression, 0},
+		{GzipCompression, 1},
+		{GzipCompression, 0},
+		{GzipCompression, 9},
+	} {
+		mem := newMemClient()
+		c, err := NewCompressedClient(mem, setting.codec, setting.level)
+		require.NoError(t, err)
+		writeObject(t, c, "text", text)
+		stored := len(mem.objects["text"])
+		t.Logf("%s (level %d): %d bytes stored for %d bytes of text (%.1f%% saved)",
+			setting.codec, setting.level, stored, len(text), 100*(1-float64(stored)/float64(len(text))))
+		require.True(t, stored < len(text)/2)
+	}
+}
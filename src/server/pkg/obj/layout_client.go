@@ -0,0 +1,224 @@
+package obj
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// StorageKeyLayoutEnvVar is the layout of the keys that objects are stored
+// under (see NewLayoutClient). If it's unset, objects are stored under their
+// names.
+const StorageKeyLayoutEnvVar = "STORAGE_KEY_LAYOUT"
+
+const (
+	// layoutPointerMagic begins every pointer object written by a
+	// layoutClient, and is followed by the key that the object's content is
+	// stored under. Objects that don't begin with it were written before the
+	// layout was configured.
+	layoutPointerMagic = "\x89PACHL\r\n"
+	// maxLayoutPointerSize is the largest that a pointer object can be
+	maxLayoutPointerSize = 2048
+)
+
+// layoutPlaceholders are the placeholders that may appear in a storage key
+// layout, and how each is filled in for the object 'name' written at 'now'
+var layoutPlaceholders = map[string]func(name string, now time.Time) string{
+	"{kind}":  func(name string, now time.Time) string { return path.Base(path.Dir(name)) },
+	"{name}":  func(name string, now time.Time) string { return path.Base(name) },
+	"{year}":  func(name string, now time.Time) string { return now.Format("2006") },
+	"{month}": func(name string, now time.Time) string { return now.Format("01") },
+	"{day}":   func(name string, now time.Time) string { return now.Format("02") },
+}
+
+// ValidateKeyLayout returns an error if 'layout' isn't a valid storage key
+// layout. An empty layout stores objects under their names.
+func ValidateKeyLayout(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	if !strings.Contains(layout, "{kind}") || !strings.Contains(layout, "{name}") {
+		return fmt.Errorf("storage key layout %q must contain {kind} and {name}, so that objects' keys are unique", layout)
+	}
+	root := strings.SplitN(layout, "/", 2)[0]
+	if root == "" || strings.Contains(root, "{") || !strings.Contains(layout, "/") {
+		return fmt.Errorf("storage key layout %q must begin with a fixed directory (e.g. \"layout/\") that objects' keys are kept under", layout)
+	}
+	rest := layout
+	for placeholder := range layoutPlaceholders {
+		rest = strings.Replace(rest, placeholder, "", -1)
+	}
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("storage key layout %q has an unknown placeholder (the placeholders are {kind}, {name}, {year}, {month} and {day})", layout)
+	}
+	return nil
+}
+
+// layoutClient is a Client that stores objects' content under keys in a
+// configured layout, rather than under their names, so that bucket lifecycle
+// rules can target them by prefix. Objects are still addressed by name
+// (which, for blocks and objects, is the hash of their content, so content is
+// still deduplicated): each object's name holds a small pointer object that
+// indexes the key that its content is stored under.
+type layoutClient struct {
+	Client
+	layout string
+	// root is the fixed directory that layout's keys are kept under
+	root string
+	now  func() time.Time
+}
+
+// NewLayoutClient returns a Client that stores the content of the objects
+// that it writes to 'c' under keys in 'layout' (e.g.
+// "layout/{kind}/{year}/{month}/{name}"), and reads them back by name.
+// Objects that were written before the layout was configured are read as
+// they are, so a layout can be configured on an existing cluster. If 'layout'
+// is empty, 'c' is returned.
+//
+// Reading an object reads its pointer first, so it takes an extra round trip
+// to the object store, and every object is stored as two objects.
+func NewLayoutClient(c Client, layout string) (Client, error) {
+	if err := ValidateKeyLayout(layout); err != nil {
+		return nil, err
+	}
+	if layout == "" {
+		return c, nil
+	}
+	return &layoutClient{
+		Client: c,
+		layout: layout,
+		root:   strings.SplitN(layout, "/", 2)[0] + "/",
+		now:    time.Now,
+	}, nil
+}
+
+// NewLayoutClientFromEnv returns a layout client that writes to 'c' with the
+// layout in StorageKeyLayoutEnvVar.
+func NewLayoutClientFromEnv(c Client) (Client, error) {
+	return NewLayoutClient(c, os.Getenv(StorageKeyLayoutEnvVar))
+}
+
+// key returns the key that the content of the object 'name' is stored under,
+// if it's written now
+func (c *layoutClient) key(name string) string {
+	key := c.layout
+	now := c.now().UTC()
+	for placeholder, fill := range layoutPlaceholders {
+		key = strings.Replace(key, placeholder, fill(name, now), -1)
+	}
+	return key
+}
+
+// readPointer returns the key that the content of the object 'name' is
+// stored under, or "" if 'name' isn't a pointer (because it was written
+// before the layout was configured)
+func (c *layoutClient) readPointer(name string) (string, error) {
+	r, err := c.Client.Reader(name, 0, maxLayoutPointerSize)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.HasPrefix(data, []byte(layoutPointerMagic)) {
+		return "", nil
+	}
+	return string(data[len(layoutPointerMagic):]), nil
+}
+
+func (c *layoutClient) Writer(name string) (io.WriteCloser, error) {
+	key := c.key(name)
+	w, err := c.Client.Writer(key)
+	if err != nil {
+		return nil, err
+	}
+	return &layoutWriter{c: c, w: w, name: name, key: key}, nil
+}
+
+func (c *layoutClient) Reader(name string, offset uint64, size uint64) (io.ReadCloser, error) {
+	key, err := c.readPointer(name)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		key = name
+	}
+	return c.Client.Reader(key, offset, size)
+}
+
+func (c *layoutClient) Delete(name string) error {
+	key, err := c.readPointer(name)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		if err := c.Client.Delete(key); err != nil && !c.IsNotExist(err) {
+			return err
+		}
+	}
+	return c.Client.Delete(name)
+}
+
+func (c *layoutClient) Walk(prefix string, fn func(name string) error) error {
+	return c.Client.Walk(prefix, func(name string) error {
+		// Objects' content is only addressed through their pointers
+		if strings.HasPrefix(name, c.root) {
+			return nil
+		}
+		return fn(name)
+	})
+}
+
+// layoutWriter writes an object's content under its key, and then its
+// pointer under its name
+type layoutWriter struct {
+	c    *layoutClient
+	w    io.WriteCloser
+	name string
+	key  string
+}
+
+func (w *layoutWriter) Write(p []byte) (int, error) {
+	return w.w.Write(p)
+}
+
+func (w *layoutWriter) Close() error {
+	if err := w.w.Close(); err != nil {
+		return err
+	}
+	// If the object is being overwritten (e.g. a tag), its old content may
+	// be under a different key, which is deleted once the pointer is updated
+	var oldKey string
+	if w.c.Client.Exists(w.name) {
+		var err error
+		if oldKey, err = w.c.readPointer(w.name); err != nil {
+			return err
+		}
+	}
+	// The pointer is written last, so that an object exists (as far as its
+	// readers can tell) only once its content is stored
+	pw, err := w.c.Client.Writer(w.name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(pw, layoutPointerMagic+w.key); err != nil {
+		pw.Close()
+		return err
+	}
+	if err := pw.Close(); err != nil {
+		return err
+	}
+	if oldKey != "" && oldKey != w.key {
+		if err := w.c.Client.Delete(oldKey); err != nil && !w.c.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
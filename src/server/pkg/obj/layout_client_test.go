@@ -0,0 +1,73 @@
+package obj
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestLayoutClient(t *testing.T) {
+	mem := newMemClient()
+	c, err := NewLayoutClient(mem, "layout/{kind}/{year}/{month}/{name}")
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	c.(*layoutClient).now = func() time.Time { return now }
+	text := textCorpus(64 * 1024)
+
+	// Objects' content is stored under the layout, and read back by name
+	writeObject(t, c, "pach/block/abc", text)
+	require.True(t, bytes.Equal(text, mem.objects["layout/block/2026/10/abc"]))
+	require.True(t, c.Exists("pach/block/abc"))
+	checkReads(t, c, "pach/block/abc", text)
+
+	// Objects written before the layout was configured are read as they are
+	writeObject(t, mem, "pach/object/legacy", text)
+	checkReads(t, c, "pach/object/legacy", text)
+	writeObject(t, mem, "pach/object/small", []byte("foo"))
+	checkReads(t, c, "pach/object/small", []byte("foo"))
+
+	// Only objects' names are walked, not the keys their content is under
+	var names []string
+	require.NoError(t, c.Walk("", func(name string) error {
+		names = append(names, name)
+		return nil
+	}))
+	sort.Strings(names)
+	require.Equal(t, []string{"pach/block/abc", "pach/object/legacy", "pach/object/small"}, names)
+
+	// Overwriting an object a month later moves its content
+	now = now.AddDate(0, 1, 0)
+	writeObject(t, c, "pach/tag/t", []byte("v1"))
+	now = now.AddDate(0, 1, 0)
+	writeObject(t, c, "pach/tag/t", []byte("v2"))
+	checkReads(t, c, "pach/tag/t", []byte("v2"))
+	require.False(t, mem.Exists("layout/tag/2026/11/t"))
+	require.True(t, mem.Exists("layout/tag/2026/12/t"))
+
+	// Deleting an object deletes its content too
+	require.NoError(t, c.Delete("pach/block/abc"))
+	require.False(t, mem.Exists("pach/block/abc"))
+	require.False(t, mem.Exists("layout/block/2026/10/abc"))
+	require.NoError(t, c.Delete("pach/object/legacy"))
+	require.False(t, mem.Exists("pach/object/legacy"))
+
+	// Compression is applied to the content, under the layout
+	compressed, err := NewCompressedClient(c, GzipCompression, 0)
+	require.NoError(t, err)
+	writeObject(t, compressed, "pach/block/def", text)
+	require.True(t, len(mem.objects["layout/block/2026/12/def"]) < len(text)/2)
+	checkReads(t, compressed, "pach/block/def", text)
+}
+
+func TestValidateKeyLayout(t *testing.T) {
+	require.NoError(t, ValidateKeyLayout(""))
+	require.NoError(t, ValidateKeyLayout("layout/{kind}/{name}"))
+	require.NoError(t, ValidateKeyLayout("archive/{kind}/{year}-{month}-{day}/{name}"))
+	require.YesError(t, ValidateKeyLayout("layout/{kind}"))
+	require.YesError(t, ValidateKeyLayout("layout/{name}"))
+	require.YesError(t, ValidateKeyLayout("{kind}/{name}"))
+	require.YesError(t, ValidateKeyLayout("layout/{kind}/{hour}/{name}"))
+}
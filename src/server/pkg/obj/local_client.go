@@ -1,6 +1,7 @@
 package obj
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -58,6 +59,12 @@ func (c *localClient) Delete(path string) error {
 }
 
 func (c *localClient) Walk(dir string, walkFn func(name string) error) error {
+	return c.WalkInfo(dir, func(info *ObjectInfo) error {
+		return walkFn(info.Name)
+	})
+}
+
+func (c *localClient) WalkInfo(dir string, walkFn func(info *ObjectInfo) error) error {
 	dir = filepath.Join(c.root, dir)
 	fi, _ := os.Stat(dir)
 	prefix := ""
@@ -78,7 +85,11 @@ func (c *localClient) Walk(dir string, walkFn func(name string) error) error {
 		if !strings.HasPrefix(filepath.Base(relPath), prefix) {
 			return nil
 		}
-		return walkFn(relPath)
+		return walkFn(&ObjectInfo{
+			Name: relPath,
+			ETag: fmt.Sprint(fileInfo.ModTime().UnixNano()),
+			Size: fileInfo.Size(),
+		})
 	})
 }
 
@@ -0,0 +1,232 @@
+package obj
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	"golang.org/x/net/context"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/src/client/limit"
+)
+
+const (
+	// StorageURLKey is the etcd key (under the cluster's etcd prefix) that
+	// holds the URL of the object store that the cluster's data was migrated
+	// to, if it has been migrated.
+	StorageURLKey = "storage-url"
+	// StorageMigrationKey is the etcd key (under the cluster's etcd prefix)
+	// that holds the URL of the object store that the cluster's data is being
+	// migrated to, while it's being migrated. Objects can't be written while
+	// it's set (see CheckStorageWritable).
+	StorageMigrationKey = "storage-migration"
+	// migrateConcurrency is the number of objects that Migrate copies at once
+	migrateConcurrency = 50
+)
+
+// LoadStorageURL reads the URL of the object store that the cluster's data
+// was migrated to (if any) from etcd, and sets StorageURLEnvVar to it so that
+// the object clients created by this process use that object store rather
+// than the configured storage backend. It must be called before any object
+// clients are created.
+func LoadStorageURL(etcdClient *etcd.Client, etcdPrefix string) error {
+	resp, err := etcdClient.Get(context.Background(), path.Join(etcdPrefix, StorageURLKey))
+	if err != nil {
+		return fmt.Errorf("could not read the storage URL: %v", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil
+	}
+	return os.Setenv(StorageURLEnvVar, string(resp.Kvs[0].Value))
+}
+
+// CheckStorageWritable returns an error if objects that this process writes
+// might not end up in the cluster's object store, because the cluster's data
+// is being migrated to another object store (and objects written now might
+// not be copied), or because it has been migrated to an object store other
+// than the one this process uses (which it will only use once it's
+// restarted). It should be called once a write has finished, and before it's
+// acknowledged: a write that it accepts finished before any migration that
+// starts afterwards, which therefore copies the write's objects.
+func CheckStorageWritable(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string) error {
+	resp, err := etcdClient.Txn(ctx).Then(
+		etcd.OpGet(path.Join(etcdPrefix, StorageMigrationKey)),
+		etcd.OpGet(path.Join(etcdPrefix, StorageURLKey)),
+	).Commit()
+	if err != nil {
+		return fmt.Errorf("could not read the storage URL: %v", err)
+	}
+	if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) > 0 {
+		return fmt.Errorf("objects can't be written while the cluster's data is being migrated to %s", kvs[0].Value)
+	}
+	if kvs := resp.Responses[1].GetResponseRange().Kvs; len(kvs) > 0 && string(kvs[0].Value) != os.Getenv(StorageURLEnvVar) {
+		return fmt.Errorf("the cluster's data has been migrated to %s, so pachd must be restarted before objects can be written", kvs[0].Value)
+	}
+	return nil
+}
+
+// StorageRootForURL returns the storage root that the cluster's objects are
+// stored under in the object store at 'url'.
+func StorageRootForURL(storageRoot string, url *ObjectStoreURL) string {
+	// S3 doesn't like leading slashes
+	if url.Store == "s3" {
+		return strings.TrimPrefix(storageRoot, "/")
+	}
+	return storageRoot
+}
+
+// MigrateStats describes the progress of a migration.
+type MigrateStats struct {
+	// Objects is the number of objects that have been migrated so far
+	Objects int64
+	// Copied is the number of those objects that had to be copied, as
+	// opposed to already being in the destination (e.g. because they were
+	// copied by an earlier, interrupted migration)
+	Copied int64
+	// Bytes is the total size of the objects that were copied
+	Bytes int64
+}
+
+// Migrate copies every object under srcRoot in 'src' to the same path under
+// dstRoot in 'dst', and verifies that the content of each object in 'dst'
+// matches the original. It never modifies 'src', and objects that are already
+// in 'dst' with the right content aren't copied again, so an interrupted
+// migration can be resumed by calling Migrate again. Objects that are in
+// 'dst' with the wrong content (e.g. partially written ones) are replaced.
+// 'progress', if non-nil, is called after each object is migrated, and the
+// migration is aborted if it returns an error.
+func Migrate(src Client, srcRoot string, dst Client, dstRoot string, progress func(*MigrateStats) error) (*MigrateStats, error) {
+	stats := &MigrateStats{}
+	var statsMu sync.Mutex
+	eg, ctx := errgroup.WithContext(context.Background())
+	limiter := limit.New(migrateConcurrency)
+	srcPrefix := strings.TrimPrefix(srcRoot, "/")
+	if err := src.Walk(srcRoot, func(name string) error {
+		limiter.Acquire()
+		if ctx.Err() != nil {
+			// Copying an earlier object failed, so stop walking
+			limiter.Release()
+			return ctx.Err()
+		}
+		eg.Go(func() error {
+			defer limiter.Release()
+			dstName := path.Join(dstRoot, strings.TrimPrefix(strings.TrimPrefix(name, "/"), srcPrefix))
+			copied, size, err := migrateObject(src, name, dst, dstName)
+			if err != nil {
+				return err
+			}
+			statsMu.Lock()
+			defer statsMu.Unlock()
+			stats.Objects++
+			if copied {
+				stats.Copied++
+				stats.Bytes += size
+			}
+			if progress != nil {
+				return progress(stats)
+			}
+			return nil
+		})
+		return nil
+	}); err != nil {
+		if egErr := eg.Wait(); egErr != nil {
+			return nil, egErr
+		}
+		return nil, err
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	// Check that the destination has as many objects as the source
+	var dstObjects int64
+	if err := dst.Walk(dstRoot, func(name string) error {
+		dstObjects++
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if dstObjects < stats.Objects {
+		return nil, fmt.Errorf("migrated %d objects, but the destination only has %d", stats.Objects, dstObjects)
+	}
+	return stats, nil
+}
+
+// migrateObject copies the object 'srcName' in 'src' to 'dstName' in 'dst',
+// unless it's already there, and verifies the copy. It returns whether the
+// object was copied and its size.
+func migrateObject(src Client, srcName string, dst Client, dstName string) (bool, int64, error) {
+	srcHash, size, err := hashObject(src, srcName)
+	if err != nil {
+		return false, 0, fmt.Errorf("could not read %s: %v", srcName, err)
+	}
+	if dst.Exists(dstName) {
+		dstHash, dstSize, err := hashObject(dst, dstName)
+		if err == nil && dstSize == size && bytes.Equal(dstHash, srcHash) {
+			return false, size, nil
+		}
+		// The object is incomplete or corrupt, so replace it
+		if err := dst.Delete(dstName); err != nil && !dst.IsNotExist(err) {
+			return false, 0, fmt.Errorf("could not delete %s: %v", dstName, err)
+		}
+	}
+	if err := copyObject(src, srcName, dst, dstName); err != nil {
+		return false, 0, fmt.Errorf("could not copy %s: %v", srcName, err)
+	}
+	dstHash, dstSize, err := hashObject(dst, dstName)
+	if err != nil {
+		return false, 0, fmt.Errorf("could not verify %s: %v", dstName, err)
+	}
+	if dstSize != size || !bytes.Equal(dstHash, srcHash) {
+		return false, 0, fmt.Errorf("copy of %s doesn't match the original", srcName)
+	}
+	return true, size, nil
+}
+
+func copyObject(src Client, srcName string, dst Client, dstName string) (retErr error) {
+	r, err := src.Reader(srcName, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	w, err := dst.Writer(dstName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := w.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// hashObject returns the SHA-256 hash and the size of the object 'name'.
+func hashObject(c Client, name string) (_ []byte, _ int64, retErr error) {
+	r, err := c.Reader(name, 0, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	h := sha256.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.Sum(nil), size, nil
+}
@@ -0,0 +1,207 @@
+package obj
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	"golang.org/x/net/context"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+// memClient is an in-memory object store. Writes fail once failWrites
+// objects have been written, if failWrites is positive.
+type memClient struct {
+	mu         sync.Mutex
+	objects    map[string][]byte
+	writes     int
+	failWrites int
+	// reads counts the calls to Reader, and readBytes the bytes they return
+	reads     int
+	readBytes int
+}
+
+func newMemClient() *memClient {
+	return &memClient{objects: make(map[string][]byte)}
+}
+
+type memWriter struct {
+	bytes.Buffer
+	c    *memClient
+	name string
+}
+
+func (w *memWriter) Close() error {
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	w.c.objects[w.name] = append([]byte{}, w.Bytes()...)
+	return nil
+}
+
+func (c *memClient) Writer(name string) (io.WriteCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failWrites > 0 && c.writes >= c.failWrites {
+		return nil, fmt.Errorf("injected write failure")
+	}
+	c.writes++
+	return &memWriter{c: c, name: name}, nil
+}
+
+func (c *memClient) Reader(name string, offset uint64, size uint64) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reads++
+	data, ok := c.objects[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", name)
+	}
+	data = data[offset:]
+	// Like object stores' range reads, reads past the end of the object are
+	// truncated
+	if size != 0 && size < uint64(len(data)) {
+		data = data[:size]
+	}
+	c.readBytes += len(data)
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (c *memClient) Delete(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[name]; !ok {
+		return fmt.Errorf("%s not found", name)
+	}
+	delete(c.objects, name)
+	return nil
+}
+
+func (c *memClient) Walk(prefix string, fn func(name string) error) error {
+	c.mu.Lock()
+	var names []string
+	for name := range c.objects {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	c.mu.Unlock()
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *memClient) Exists(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.objects[name]
+	return ok
+}
+
+func (c *memClient) IsRetryable(err error) bool {
+	return false
+}
+
+func (c *memClient) IsNotExist(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
+
+func (c *memClient) IsIgnorable(err error) bool {
+	return false
+}
+
+func TestMigrate(t *testing.T) {
+	src := newMemClient()
+	for i := 0; i < 100; i++ {
+		src.objects[path.Join("/pach/block", fmt.Sprint(i))] = []byte(strings.Repeat(fmt.Sprint(i), i))
+	}
+	src.objects["/other/object"] = []byte("not part of the cluster's data")
+	dst := newMemClient()
+	dst.failWrites = 50
+
+	// The first migration is interrupted part way through
+	_, err := Migrate(src, "/pach", dst, "pach", nil)
+	require.YesError(t, err)
+	require.True(t, len(dst.objects) > 0)
+	// Corrupt one of the objects that was copied, as if it was only
+	// partially written
+	dst.objects["pach/block/0"] = []byte("garbage")
+
+	// Resuming it copies only the objects that are missing or corrupt
+	dst.failWrites = 0
+	copiedBefore := len(dst.objects) - 1
+	var progressCalls int
+	stats, err := Migrate(src, "/pach", dst, "pach", func(stats *MigrateStats) error {
+		progressCalls++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), stats.Objects)
+	require.Equal(t, int64(100-copiedBefore), stats.Copied)
+	require.Equal(t, 100, progressCalls)
+	require.Equal(t, 100, len(dst.objects))
+	for i := 0; i < 100; i++ {
+		require.Equal(t, src.objects[path.Join("/pach/block", fmt.Sprint(i))], dst.objects[path.Join("pach/block", fmt.Sprint(i))])
+	}
+
+	// Once everything has been migrated, migrating again copies nothing, and
+	// the source is left untouched
+	stats, err = Migrate(src, "/pach", dst, "pach", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), stats.Objects)
+	require.Equal(t, int64(0), stats.Copied)
+	require.Equal(t, 101, len(src.objects))
+
+	// An error from progress aborts the migration
+	_, err = Migrate(src, "/pach", newMemClient(), "pach", func(*MigrateStats) error {
+		return fmt.Errorf("client went away")
+	})
+	require.YesError(t, err)
+}
+
+func TestCheckStorageWritable(t *testing.T) {
+	etcdClient, err := etcd.New(etcd.Config{
+		Endpoints:   []string{"localhost:32379"},
+		DialOptions: client.DefaultDialOptions(),
+	})
+	require.NoError(t, err)
+	defer etcdClient.Close()
+	ctx := context.Background()
+	prefix := uuid.NewWithoutDashes()
+	defer etcdClient.Delete(ctx, prefix, etcd.WithPrefix())
+
+	// Objects can be written until a migration starts
+	require.NoError(t, CheckStorageWritable(ctx, etcdClient, prefix))
+	_, err = etcdClient.Put(ctx, path.Join(prefix, StorageMigrationKey), "s3://bucket")
+	require.NoError(t, err)
+	err = CheckStorageWritable(ctx, etcdClient, prefix)
+	require.YesError(t, err)
+	require.Matches(t, "being migrated", err.Error())
+
+	// Once the cluster has been switched to the new object store, only the
+	// processes that use it can write objects
+	_, err = etcdClient.Delete(ctx, path.Join(prefix, StorageMigrationKey))
+	require.NoError(t, err)
+	_, err = etcdClient.Put(ctx, path.Join(prefix, StorageURLKey), "s3://bucket")
+	require.NoError(t, err)
+	err = CheckStorageWritable(ctx, etcdClient, prefix)
+	require.YesError(t, err)
+	require.Matches(t, "must be restarted", err.Error())
+	require.NoError(t, LoadStorageURL(etcdClient, prefix))
+	defer os.Unsetenv(StorageURLEnvVar)
+	require.NoError(t, CheckStorageWritable(ctx, etcdClient, prefix))
+}
@@ -76,6 +76,12 @@ func (c *minioClient) Writer(name string) (io.WriteCloser, error) {
 }
 
 func (c *minioClient) Walk(name string, fn func(name string) error) error {
+	return c.WalkInfo(name, func(info *ObjectInfo) error {
+		return fn(info.Name)
+	})
+}
+
+func (c *minioClient) WalkInfo(name string, fn func(info *ObjectInfo) error) error {
 	recursive := true // Recursively walk by default.
 
 	doneCh := make(chan struct{})
@@ -84,7 +90,11 @@ func (c *minioClient) Walk(name string, fn func(name string) error) error {
 		if objInfo.Err != nil {
 			return objInfo.Err
 		}
-		if err := fn(objInfo.Key); err != nil {
+		if err := fn(&ObjectInfo{
+			Name: objInfo.Key,
+			ETag: objInfo.ETag,
+			Size: objInfo.Size,
+		}); err != nil {
 			return err
 		}
 	}
@@ -24,6 +24,10 @@ import (
 const (
 	StorageBackendEnvVar = "STORAGE_BACKEND"
 	PachRootEnvVar       = "PACH_ROOT"
+	// StorageURLEnvVar, if set, is the URL of an object store (e.g.
+	// "s3://bucket") that overrides the storage backend. It's set by
+	// LoadStorageURL once the cluster's data has been migrated.
+	StorageURLEnvVar = "STORAGE_URL"
 )
 
 // Valid object storage backends
@@ -105,6 +109,13 @@ func StorageRootFromEnv() (string, error) {
 	if !ok {
 		return "", fmt.Errorf("%s not found", PachRootEnvVar)
 	}
+	if storageURL, ok := os.LookupEnv(StorageURLEnvVar); ok {
+		url, err := ParseURL(storageURL)
+		if err != nil {
+			return "", err
+		}
+		return StorageRootForURL(storageRoot, url), nil
+	}
 	storageBackend, ok := os.LookupEnv(StorageBackendEnvVar)
 	if !ok {
 		return "", fmt.Errorf("%s not found", StorageBackendEnvVar)
@@ -157,6 +168,22 @@ type Client interface {
 	IsIgnorable(err error) bool
 }
 
+// ObjectInfo is the metadata of an object in object storage.
+type ObjectInfo struct {
+	Name string
+	// ETag identifies the object's content (as far as the store can tell, e.g.
+	// it's a modification time for local objects).
+	ETag string
+	Size int64
+}
+
+// InfoWalker is implemented by Clients that can list objects along with
+// their metadata.
+type InfoWalker interface {
+	// WalkInfo is like Walk, but calls `fn` with the objects' metadata.
+	WalkInfo(prefix string, fn func(info *ObjectInfo) error) error
+}
+
 // NewGoogleClient creates a google client with the given bucket name.
 func NewGoogleClient(ctx context.Context, bucket string, credFile string) (Client, error) {
 	return newGoogleClient(ctx, bucket, credFile)
@@ -489,6 +516,13 @@ func ParseURL(urlStr string) (*ObjectStoreURL, error) {
 
 // NewClientFromEnv creates a client based on environment variables.
 func NewClientFromEnv(ctx context.Context, storageRoot string) (Client, error) {
+	if storageURL, ok := os.LookupEnv(StorageURLEnvVar); ok {
+		url, err := ParseURL(storageURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewClientFromURLAndSecret(ctx, url)
+	}
 	storageBackend, ok := os.LookupEnv(StorageBackendEnvVar)
 	if !ok {
 		return nil, fmt.Errorf("storage backend environment variable not found")
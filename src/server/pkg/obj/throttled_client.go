@@ -0,0 +1,191 @@
+package obj
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	units "github.com/docker/go-units"
+)
+
+// StorageMaxOutstandingWriteBytesEnvVar is the most bytes that pachd may have
+// written to object storage writers that haven't been closed yet (e.g. "1G").
+// If it's unset or 0, writes aren't throttled.
+const StorageMaxOutstandingWriteBytesEnvVar = "STORAGE_MAX_OUTSTANDING_WRITE_BYTES"
+
+// throttledWriteChunkSize is the most bytes that a throttled writer acquires
+// at once, so that a large write waits for room chunk by chunk rather than
+// taking up the whole limit as soon as it starts
+const throttledWriteChunkSize = 64 * 1024
+
+// throttledClient is a Client that bounds the number of bytes in writes that
+// haven't finished yet. Object store writers (e.g. S3's multipart uploader)
+// buffer the bytes written to them until they're uploaded, so if bytes are
+// written faster than the object store can absorb them (e.g. during a burst
+// of put-file requests), pachd's memory would grow without bound. Instead,
+// writes block once too many bytes are outstanding, which pushes back on the
+// gRPC streams that the bytes are read from, so that clients slow down.
+type throttledClient struct {
+	Client
+	maxBytes int64
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// outstanding is the number of bytes written to writers that haven't been
+	// closed yet
+	outstanding int64
+	// writers contains the writers that have written bytes and haven't been
+	// closed yet, oldest first
+	writers *list.List
+}
+
+// NewThrottledClient returns a Client that writes to 'c', and blocks writes
+// while more than 'maxBytes' have been written to writers that haven't been
+// closed. Bytes written to a writer are only released once it's closed, so
+// the oldest open writer is never blocked (otherwise, writers that are all
+// waiting for each other to close would deadlock), and the outstanding bytes
+// may exceed 'maxBytes' by up to the size of one object. If 'maxBytes' is 0,
+// 'c' is returned.
+func NewThrottledClient(c Client, maxBytes int64) Client {
+	if maxBytes <= 0 {
+		return c
+	}
+	result := &throttledClient{
+		Client:   c,
+		maxBytes: maxBytes,
+		writers:  list.New(),
+	}
+	result.cond = sync.NewCond(&result.mu)
+	return result
+}
+
+// NewThrottledClientFromEnv returns a throttled client that writes to 'c',
+// with the limit in StorageMaxOutstandingWriteBytesEnvVar.
+func NewThrottledClientFromEnv(c Client) (Client, error) {
+	maxBytesStr := os.Getenv(StorageMaxOutstandingWriteBytesEnvVar)
+	if maxBytesStr == "" {
+		return c, nil
+	}
+	maxBytes, err := units.RAMInBytes(maxBytesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", StorageMaxOutstandingWriteBytesEnvVar, err)
+	}
+	return NewThrottledClient(c, maxBytes), nil
+}
+
+func (c *throttledClient) Writer(name string) (io.WriteCloser, error) {
+	return c.WriterContext(context.Background(), name)
+}
+
+// WriterContext is like Writer, but once 'ctx' is done, the returned writer
+// releases the bytes written to it (even if it's never closed, e.g. because
+// the put-file stream writing to it was cancelled) and its writes fail.
+func (c *throttledClient) WriterContext(ctx context.Context, name string) (io.WriteCloser, error) {
+	w, err := c.Client.Writer(name)
+	if err != nil {
+		return nil, err
+	}
+	result := &throttledWriter{c: c, w: w, ctx: ctx, closed: make(chan struct{})}
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				c.release(result)
+			case <-result.closed:
+			}
+		}()
+	}
+	return result, nil
+}
+
+// NewWriterContext returns a writer for the object 'name' in 'c'. If 'c'
+// throttles writes (see NewThrottledClient), the writer stops waiting and
+// releases its bytes once 'ctx' is done.
+func NewWriterContext(ctx context.Context, c Client, name string) (io.WriteCloser, error) {
+	if c, ok := c.(*throttledClient); ok {
+		return c.WriterContext(ctx, name)
+	}
+	return c.Writer(name)
+}
+
+// acquire blocks until 'w' may write 'n' more bytes, or until w.ctx is done
+func (c *throttledClient) acquire(w *throttledWriter, n int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	if w.elem == nil {
+		w.elem = c.writers.PushBack(w)
+	}
+	for c.outstanding >= c.maxBytes && c.writers.Front() != w.elem {
+		c.cond.Wait()
+		if err := w.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	c.outstanding += n
+	w.held += n
+	return nil
+}
+
+// release releases the bytes held by 'w', which has been closed (or whose
+// context is done)
+func (c *throttledClient) release(w *throttledWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if w.elem != nil {
+		c.writers.Remove(w.elem)
+		w.elem = nil
+	}
+	c.outstanding -= w.held
+	w.held = 0
+	c.cond.Broadcast()
+}
+
+type throttledWriter struct {
+	c   *throttledClient
+	w   io.WriteCloser
+	ctx context.Context
+	// closed is closed when this writer is closed
+	closed    chan struct{}
+	closeOnce sync.Once
+	// held is the number of bytes written to this writer, which count towards
+	// c.outstanding, and elem is its element in c.writers. Both are protected
+	// by c.mu.
+	held int64
+	elem *list.Element
+}
+
+func (w *throttledWriter) Write(p []byte) (int, error) {
+	chunkSize := int64(throttledWriteChunkSize)
+	if w.c.maxBytes < chunkSize {
+		chunkSize = w.c.maxBytes
+	}
+	var written int
+	for len(p) > 0 {
+		chunk := p
+		if int64(len(chunk)) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		if err := w.c.acquire(w, int64(len(chunk))); err != nil {
+			return written, err
+		}
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+func (w *throttledWriter) Close() error {
+	w.closeOnce.Do(func() { close(w.closed) })
+	defer w.c.release(w)
+	return w.w.Close()
+}
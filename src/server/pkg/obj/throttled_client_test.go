@@ -0,0 +1,192 @@
+package obj
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// slowClient is a Client whose writers buffer everything written to them in
+// memory, and take 'latency' to upload it when they're closed, like a slow
+// object store. It records the most bytes that were buffered at once.
+type slowClient struct {
+	Client
+	latency time.Duration
+
+	mu          sync.Mutex
+	buffered    int64
+	maxBuffered int64
+}
+
+func (c *slowClient) Writer(name string) (io.WriteCloser, error) {
+	return &slowWriter{c: c}, nil
+}
+
+type slowWriter struct {
+	c *slowClient
+	n int64
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	w.n += int64(len(p))
+	w.c.buffered += int64(len(p))
+	if w.c.buffered > w.c.maxBuffered {
+		w.c.maxBuffered = w.c.buffered
+	}
+	return len(p), nil
+}
+
+func (w *slowWriter) Close() error {
+	time.Sleep(w.c.latency)
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	w.c.buffered -= w.n
+	return nil
+}
+
+// writeObjects writes 'objects' 1MB objects to 'c' from each of 'writers'
+// goroutines, and returns how long it took
+func writeObjects(t *testing.T, c Client, writers int, objects int) time.Duration {
+	start := time.Now()
+	data := make([]byte, 64*1024)
+	var eg errgroup.Group
+	for i := 0; i < writers; i++ {
+		eg.Go(func() error {
+			for j := 0; j < objects; j++ {
+				w, err := c.Writer("object")
+				if err != nil {
+					return err
+				}
+				for k := 0; k < 16; k++ {
+					if _, err := w.Write(data); err != nil {
+						return err
+					}
+				}
+				if err := w.Close(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+	return time.Since(start)
+}
+
+func TestThrottledClient(t *testing.T) {
+	const latency = 10 * time.Millisecond
+	// Without throttling, every writer's bytes are buffered at once
+	slow := &slowClient{latency: latency}
+	writeObjects(t, slow, 16, 8)
+	require.Equal(t, int64(16*1024*1024), slow.maxBuffered)
+
+	// With throttling, about 4MB are buffered (plus up to one object and one
+	// write), so only a few objects are uploaded at once and writers are
+	// slowed down to the rate that the object store absorbs them
+	slow = &slowClient{latency: latency}
+	elapsed := writeObjects(t, NewThrottledClient(slow, 4*1024*1024), 16, 8)
+	require.True(t, slow.maxBuffered <= (5*1024+64)*1024, "buffered %d bytes", slow.maxBuffered)
+	require.True(t, elapsed >= (16*8/6)*latency, "writes took %v", elapsed)
+	require.Equal(t, int64(0), slow.buffered)
+
+	// An object larger than the limit can still be written, and blocks newer
+	// writers until it's closed
+	slow = &slowClient{latency: latency}
+	c := NewThrottledClient(slow, 1000)
+	w, err := c.Writer("object")
+	require.NoError(t, err)
+	_, err = w.Write(make([]byte, 5000))
+	require.NoError(t, err)
+	_, err = w.Write(make([]byte, 5000))
+	require.NoError(t, err)
+	w2, err := c.Writer("object2")
+	require.NoError(t, err)
+	written := make(chan struct{})
+	go func() {
+		w2.Write(make([]byte, 10))
+		close(written)
+	}()
+	select {
+	case <-written:
+		t.Fatal("write wasn't blocked")
+	case <-time.After(5 * latency):
+	}
+	require.NoError(t, w.Close())
+	<-written
+	require.NoError(t, w2.Close())
+}
+
+func TestThrottledClientChunksAndContext(t *testing.T) {
+	slow := &slowClient{}
+	c := NewThrottledClient(slow, 1000)
+	w, err := c.Writer("object")
+	require.NoError(t, err)
+	_, err = w.Write(make([]byte, 500))
+	require.NoError(t, err)
+
+	// A large write by a newer writer waits for room chunk by chunk, rather
+	// than acquiring all of its bytes at once (or none of them)
+	ctx, cancel := context.WithCancel(context.Background())
+	w2, err := NewWriterContext(ctx, c, "object2")
+	require.NoError(t, err)
+	type result struct {
+		n   int
+		err error
+	}
+	written := make(chan result)
+	go func() {
+		n, err := w2.Write(make([]byte, 5000))
+		written <- result{n, err}
+	}()
+	select {
+	case r := <-written:
+		t.Fatalf("write wasn't blocked after its first chunk (wrote %d bytes, err %v)", r.n, r.err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	slow.mu.Lock()
+	require.Equal(t, int64(1500), slow.buffered)
+	slow.mu.Unlock()
+
+	// Once its context is done, the blocked write fails and the writer's
+	// bytes are released, even though it's never closed, so newer writers
+	// aren't blocked by them
+	cancel()
+	r := <-written
+	require.Equal(t, 1000, r.n)
+	require.YesError(t, r.err)
+	w3, err := c.Writer("object3")
+	require.NoError(t, err)
+	_, err = w3.Write(make([]byte, 400))
+	require.NoError(t, err)
+	_, err = w2.Write(make([]byte, 10))
+	require.YesError(t, err)
+	require.NoError(t, w2.Close())
+	require.NoError(t, w3.Close())
+	require.NoError(t, w.Close())
+}
+
+func TestThrottledClientFromEnv(t *testing.T) {
+	defer os.Unsetenv(StorageMaxOutstandingWriteBytesEnvVar)
+	slow := &slowClient{}
+	c, err := NewThrottledClientFromEnv(slow)
+	require.NoError(t, err)
+	require.Equal(t, slow, c)
+
+	require.NoError(t, os.Setenv(StorageMaxOutstandingWriteBytesEnvVar, "512M"))
+	c, err = NewThrottledClientFromEnv(slow)
+	require.NoError(t, err)
+	require.Equal(t, int64(512*1024*1024), c.(*throttledClient).maxBytes)
+
+	require.NoError(t, os.Setenv(StorageMaxOutstandingWriteBytesEnvVar, "lots"))
+	_, err = NewThrottledClientFromEnv(slow)
+	require.YesError(t, err)
+}
@@ -17,11 +17,16 @@ const (
 	commitsPrefix        = "/commits"
 	branchesPrefix       = "/branches"
 	openCommitsPrefix    = "/openCommits"
+	uploadChunksPrefix   = "/uploadChunks"
 )
 
 var (
 	// ProvenanceIndex is a secondary index on provenance
 	ProvenanceIndex = &col.Index{Field: "Provenance", Multi: true}
+
+	// LabelIndex is a secondary index on labels. Its values are given by
+	// col.MapIndexVal
+	LabelIndex = &col.Index{Field: "Labels", Multi: true}
 )
 
 // Repos returns a collection of repos
@@ -53,7 +58,7 @@ func Commits(etcdClient *etcd.Client, etcdPrefix string, repo string) col.Collec
 	return col.NewCollection(
 		etcdClient,
 		path.Join(etcdPrefix, commitsPrefix, repo),
-		[]*col.Index{ProvenanceIndex},
+		[]*col.Index{ProvenanceIndex, LabelIndex},
 		&pfs.CommitInfo{},
 		nil,
 		nil,
@@ -88,3 +93,16 @@ func OpenCommits(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
 		nil,
 	)
 }
+
+// UploadChunks returns a collection of the chunks stored by PutFileChunk in
+// 'repo' as part of the upload 'uploadID', keyed by their objects' hashes
+func UploadChunks(etcdClient *etcd.Client, etcdPrefix string, repo string, uploadID string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, uploadChunksPrefix, repo, uploadID),
+		nil,
+		&pfs.FileChunk{},
+		nil,
+		nil,
+	)
+}
@@ -15,4 +15,9 @@ const (
 	// PPSTokenKey is a key (in etcd) that maps to PPS's auth token.
 	// This is the token that PPS uses to authorize spec writes.
 	PPSTokenKey = "master_token"
+
+	// RerunBranchPrefix is the prefix of the output branches that RerunJob
+	// creates, which is followed by the ID of the new job. The branch is
+	// deleted once the job finishes.
+	RerunBranchPrefix = "rerun-"
 )
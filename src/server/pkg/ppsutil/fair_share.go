@@ -0,0 +1,89 @@
+package ppsutil
+
+import (
+	"sort"
+)
+
+// WorkerDemand is the number of workers that a pipeline would like to run
+// (based on its parallelism spec), along with its scheduling weight.
+type WorkerDemand struct {
+	Pipeline string
+	Workers  int64
+	Weight   int64
+}
+
+// FairShare divides 'budget' workers between the pipelines in 'demands'.
+// Every pipeline gets at least one worker (so that none of them stall) and no
+// pipeline gets more workers than it wants. The rest of the budget is divided
+// in proportion to the pipelines' weights (a weight <= 0 is treated as 1),
+// with any share that a pipeline doesn't need redistributed among the others
+// ("water-filling"). If the budget covers every pipeline's demand, each
+// pipeline simply gets what it asked for.
+func FairShare(budget int64, demands []WorkerDemand) map[string]int64 {
+	result := make(map[string]int64)
+	// Give every pipeline its first worker up front
+	var unsatisfied []WorkerDemand
+	for _, d := range demands {
+		if d.Workers <= 0 {
+			continue
+		}
+		if d.Weight <= 0 {
+			d.Weight = 1
+		}
+		result[d.Pipeline] = 1
+		budget--
+		if d.Workers > 1 {
+			unsatisfied = append(unsatisfied, d)
+		}
+	}
+	for len(unsatisfied) > 0 && budget > 0 {
+		var totalWeight int64
+		for _, d := range unsatisfied {
+			totalWeight += d.Weight
+		}
+		// Fully satisfy every pipeline whose remaining demand fits within its
+		// share of the budget, and then go around again with what's left
+		var next []WorkerDemand
+		var used int64
+		for _, d := range unsatisfied {
+			need := d.Workers - result[d.Pipeline]
+			if need*totalWeight <= budget*d.Weight {
+				result[d.Pipeline] += need
+				used += need
+			} else {
+				next = append(next, d)
+			}
+		}
+		if len(next) < len(unsatisfied) {
+			budget -= used
+			unsatisfied = next
+			continue
+		}
+		// Every remaining pipeline wants more than its share, so give each its
+		// share, rounded down, and hand out what rounding left over by largest
+		// remainder (ties broken by weight, then name, so that the result is
+		// deterministic)
+		remainders := make(map[string]int64)
+		for _, d := range unsatisfied {
+			share := budget * d.Weight / totalWeight
+			result[d.Pipeline] += share
+			used += share
+			remainders[d.Pipeline] = budget * d.Weight % totalWeight
+		}
+		sort.Slice(unsatisfied, func(i, j int) bool {
+			ri, rj := remainders[unsatisfied[i].Pipeline], remainders[unsatisfied[j].Pipeline]
+			if ri != rj {
+				return ri > rj
+			}
+			if unsatisfied[i].Weight != unsatisfied[j].Weight {
+				return unsatisfied[i].Weight > unsatisfied[j].Weight
+			}
+			return unsatisfied[i].Pipeline < unsatisfied[j].Pipeline
+		})
+		for i := int64(0); i < budget-used; i++ {
+			result[unsatisfied[i].Pipeline]++
+		}
+		break
+	}
+	return result
+}
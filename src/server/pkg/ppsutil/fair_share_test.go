@@ -0,0 +1,57 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestFairShareNoContention(t *testing.T) {
+	result := FairShare(100, []WorkerDemand{
+		{Pipeline: "a", Workers: 10, Weight: 1},
+		{Pipeline: "b", Workers: 20, Weight: 5},
+	})
+	require.Equal(t, map[string]int64{"a": 10, "b": 20}, result)
+}
+
+func TestFairShareWeights(t *testing.T) {
+	// Under contention, the budget is divided in proportion to the weights
+	result := FairShare(40, []WorkerDemand{
+		{Pipeline: "a", Workers: 100, Weight: 1},
+		{Pipeline: "b", Workers: 100, Weight: 3},
+	})
+	require.Equal(t, map[string]int64{"a": 10, "b": 30}, result)
+
+	// Weights default to 1
+	result = FairShare(40, []WorkerDemand{
+		{Pipeline: "a", Workers: 100},
+		{Pipeline: "b", Workers: 100, Weight: 1},
+	})
+	require.Equal(t, map[string]int64{"a": 20, "b": 20}, result)
+}
+
+func TestFairShareRedistribution(t *testing.T) {
+	// 'a' only needs 5 of its 20 workers, so the rest are split between 'b' and
+	// 'c' by weight
+	result := FairShare(60, []WorkerDemand{
+		{Pipeline: "a", Workers: 5, Weight: 2},
+		{Pipeline: "b", Workers: 100, Weight: 1},
+		{Pipeline: "c", Workers: 100, Weight: 2},
+	})
+	require.Equal(t, map[string]int64{"a": 5, "b": 19, "c": 36}, result)
+	var total int64
+	for _, workers := range result {
+		total += workers
+	}
+	require.Equal(t, int64(60), total)
+}
+
+func TestFairShareMinimumOneWorker(t *testing.T) {
+	// Every pipeline gets a worker, even if that exceeds the budget
+	result := FairShare(2, []WorkerDemand{
+		{Pipeline: "a", Workers: 10, Weight: 100},
+		{Pipeline: "b", Workers: 10, Weight: 1},
+		{Pipeline: "c", Workers: 10, Weight: 1},
+	})
+	require.Equal(t, map[string]int64{"a": 1, "b": 1, "c": 1}, result)
+}
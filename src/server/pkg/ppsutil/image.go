@@ -0,0 +1,29 @@
+package ppsutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// imageDigest matches the digest at the end of an image reference that's
+// pinned by digest, e.g. "ubuntu@sha256:<hex>" or "ubuntu:16.04@sha256:<hex>"
+var imageDigest = regexp.MustCompile(`@(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+
+// ImageHasDigest returns true if 'image' is pinned by digest, so it always
+// refers to the same image, unlike an image referenced only by a tag.
+func ImageHasDigest(image string) bool {
+	return imageDigest.MatchString(image)
+}
+
+// ValidateImage returns an error if 'image' has a malformed digest or, if
+// 'requireDigest' is set, if it isn't pinned by digest.
+func ValidateImage(image string, requireDigest bool) error {
+	if strings.Contains(image, "@") && !ImageHasDigest(image) {
+		return fmt.Errorf("image %q has an invalid digest (must be sha256:<64 hex digits> or sha512:<128 hex digits>)", image)
+	}
+	if requireDigest && !ImageHasDigest(image) {
+		return fmt.Errorf("image %q must be pinned by digest (e.g. %s@sha256:<digest>), as this cluster requires image digests", image, strings.SplitN(image, "@", 2)[0])
+	}
+	return nil
+}
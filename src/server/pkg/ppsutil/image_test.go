@@ -0,0 +1,40 @@
+package ppsutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestValidateImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	for _, image := range []string{
+		"ubuntu@" + digest,
+		"ubuntu:16.04@" + digest,
+		"registry.example.com:5000/team/image@" + digest,
+		"ubuntu@sha512:" + strings.Repeat("0f", 64),
+	} {
+		require.True(t, ImageHasDigest(image))
+		require.NoError(t, ValidateImage(image, false))
+		require.NoError(t, ValidateImage(image, true))
+	}
+
+	// Images referenced by tag are only rejected if digests are required
+	for _, image := range []string{"ubuntu", "ubuntu:16.04", "registry.example.com:5000/team/image:v1"} {
+		require.False(t, ImageHasDigest(image))
+		require.NoError(t, ValidateImage(image, false))
+		require.YesError(t, ValidateImage(image, true))
+	}
+
+	// Malformed digests are always rejected
+	for _, image := range []string{
+		"ubuntu@sha256:abc",
+		"ubuntu@md5:" + strings.Repeat("ab", 16),
+		"ubuntu@" + strings.ToUpper(digest),
+		"ubuntu@",
+	} {
+		require.False(t, ImageHasDigest(image))
+		require.YesError(t, ValidateImage(image, false))
+	}
+}
@@ -0,0 +1,25 @@
+package ppsutil
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// OutputFileMode returns the permission bits set by the mode of 'perms', or 0
+// if it has none. It returns an error if the mode isn't an octal number of at
+// most 0777.
+func OutputFileMode(perms *pps.OutputPermissions) (uint32, error) {
+	if perms.GetMode() == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(perms.Mode, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("mode %q isn't an octal number (e.g. \"0644\")", perms.Mode)
+	}
+	if mode > 0777 {
+		return 0, fmt.Errorf("mode %q has bits other than permission bits (it must be at most 0777)", perms.Mode)
+	}
+	return uint32(mode), nil
+}
@@ -0,0 +1,28 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestOutputFileMode(t *testing.T) {
+	for mode, expected := range map[string]uint32{
+		"":     0,
+		"0644": 0644,
+		"755":  0755,
+		"0":    0,
+	} {
+		actual, err := OutputFileMode(&pps.OutputPermissions{Mode: mode})
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+	for _, mode := range []string{"rwxr-xr-x", "0x1ff", "0789", "1777", "-1"} {
+		_, err := OutputFileMode(&pps.OutputPermissions{Mode: mode})
+		require.YesError(t, err)
+	}
+	mode, err := OutputFileMode(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), mode)
+}
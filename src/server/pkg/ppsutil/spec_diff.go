@@ -0,0 +1,153 @@
+package ppsutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// defaultedFields are the fields of a pipeline spec (other than its inputs'
+// fields) that pachd fills in with a default value if they're unset
+var defaultedFields = map[string]bool{
+	"transform.image":   true,
+	"output_branch":     true,
+	"cache_size":        true,
+	"resource_requests": true,
+	"max_queue_size":    true,
+	"salt":              true,
+}
+
+// defaultedInputFields are the fields of each input in a pipeline spec that
+// pachd fills in with a default value if they're unset
+var defaultedInputFields = []string{
+	".atom.name", ".atom.branch",
+	".pfs.name", ".pfs.branch",
+	".cron.repo", ".cron.start",
+	".git.name", ".git.branch",
+}
+
+// DiffPipelineSpecs compares the pipeline specs 'oldSpec' and 'newSpec', and
+// returns one line for each field that differs between them, of the form
+// "path.to.field: <old value> -> <new value>" (where a value is "(unset)" if
+// the field isn't set in that spec). The lines are sorted by field. If
+// 'ignoreDefaults' is set, fields that are unset in 'newSpec' but that pachd
+// would fill in with a default value (e.g. an input's branch) aren't
+// reported, which is useful when 'newSpec' is a manifest that hasn't been
+// created yet.
+func DiffPipelineSpecs(oldSpec *pps.CreatePipelineRequest, newSpec *pps.CreatePipelineRequest, ignoreDefaults bool) ([]string, error) {
+	oldValue, err := specValue(oldSpec)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := specValue(newSpec)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	diffValues("", oldValue, newValue, func(path string, oldField interface{}, newField interface{}) {
+		if ignoreDefaults && newField == nil && isDefaulted(path) {
+			return
+		}
+		result = append(result, fmt.Sprintf("%s: %s -> %s", path, formatValue(oldField), formatValue(newField)))
+	})
+	return result, nil
+}
+
+// specValue converts 'request' to the generic JSON representation of its
+// manifest, leaving out the fields that only affect how the request is
+// applied.
+func specValue(request *pps.CreatePipelineRequest) (interface{}, error) {
+	request = proto.Clone(request).(*pps.CreatePipelineRequest)
+	request.Update = false
+	request.Reprocess = false
+	request.SquashOutput = false
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	spec, err := marshaler.MarshalToString(request)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err := json.Unmarshal([]byte(spec), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// diffValues calls 'report' for each of the fields at or below 'path' that
+// differ between 'oldValue' and 'newValue'.
+func diffValues(path string, oldValue interface{}, newValue interface{}, report func(string, interface{}, interface{})) {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := make(map[string]bool)
+		for key := range oldMap {
+			keys[key] = true
+		}
+		for key := range newMap {
+			keys[key] = true
+		}
+		var sortedKeys []string
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+		for _, key := range sortedKeys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			diffValues(keyPath, oldMap[key], newMap[key], report)
+		}
+		return
+	}
+	oldList, oldIsList := oldValue.([]interface{})
+	newList, newIsList := newValue.([]interface{})
+	if oldIsList && newIsList {
+		for i := 0; i < len(oldList) || i < len(newList); i++ {
+			var oldElem, newElem interface{}
+			if i < len(oldList) {
+				oldElem = oldList[i]
+			}
+			if i < len(newList) {
+				newElem = newList[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), oldElem, newElem, report)
+		}
+		return
+	}
+	if !reflect.DeepEqual(oldValue, newValue) {
+		report(path, oldValue, newValue)
+	}
+}
+
+func isDefaulted(path string) bool {
+	if defaultedFields[path] {
+		return true
+	}
+	if strings.HasPrefix(path, "input.") {
+		for _, suffix := range defaultedInputFields {
+			if strings.HasSuffix(path, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "(unset)"
+	}
+	result, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(result)
+}
@@ -0,0 +1,67 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestDiffPipelineSpecs(t *testing.T) {
+	old := &pps.CreatePipelineRequest{
+		Pipeline: client.NewPipeline("pipeline"),
+		Transform: &pps.Transform{
+			Image: "busybox",
+			Cmd:   []string{"sh", "-c", "cp /pfs/in/* /pfs/out"},
+		},
+		ParallelismSpec: &pps.ParallelismSpec{Constant: 1},
+		Input:           client.NewPFSInput("in", "/*"),
+		EnableStats:     true,
+	}
+	diff, err := DiffPipelineSpecs(old, old, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(diff))
+
+	updated := proto.Clone(old).(*pps.CreatePipelineRequest)
+	updated.Transform.Image = "ubuntu"
+	updated.Transform.Cmd = updated.Transform.Cmd[:2]
+	updated.Input.Pfs.Glob = "/"
+	updated.EnableStats = false
+	updated.DatumTries = 3
+	updated.Update = true
+	diff, err = DiffPipelineSpecs(old, updated, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`datum_tries: (unset) -> "3"`,
+		`enable_stats: true -> (unset)`,
+		`input.pfs.glob: "/*" -> "/"`,
+		`transform.cmd[2]: "cp /pfs/in/* /pfs/out" -> (unset)`,
+		`transform.image: "busybox" -> "ubuntu"`,
+	}, diff)
+
+	// Fields that pachd fills in with defaults are only compared if they're
+	// set in the updated spec
+	old.Input.Pfs.Name = "in"
+	old.Input.Pfs.Branch = "master"
+	old.Salt = "abc"
+	updated = proto.Clone(old).(*pps.CreatePipelineRequest)
+	updated.Input.Pfs.Name = ""
+	updated.Salt = ""
+	diff, err = DiffPipelineSpecs(old, updated, true)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(diff))
+	updated.Input.Pfs.Branch = "dev"
+	diff, err = DiffPipelineSpecs(old, updated, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{`input.pfs.branch: "master" -> "dev"`}, diff)
+	diff, err = DiffPipelineSpecs(old, updated, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`input.pfs.branch: "master" -> "dev"`,
+		`input.pfs.name: "in" -> (unset)`,
+		`salt: "abc" -> (unset)`,
+	}, diff)
+}
@@ -55,11 +55,18 @@ func PipelineRepo(pipeline *ppsclient.Pipeline) *pfs.Repo {
 // PipelineRcName generates the name of the k8s replication controller that
 // manages a pipeline's workers
 func PipelineRcName(name string, version uint64) string {
+	return fmt.Sprintf("%s%d", PipelineRcNamePrefix(name), version)
+}
+
+// PipelineRcNamePrefix returns the prefix of the names of the RCs of every
+// version of a pipeline (each version's RC name is the prefix followed by the
+// version number)
+func PipelineRcNamePrefix(name string) string {
 	// k8s won't allow RC names that contain upper-case letters
 	// or underscores
 	// TODO: deal with name collision
 	name = strings.Replace(name, "_", "-", -1)
-	return fmt.Sprintf("pipeline-%s-v%d", strings.ToLower(name), version)
+	return fmt.Sprintf("pipeline-%s-v", strings.ToLower(name))
 }
 
 // GetRequestsResourceListFromPipeline returns a list of resources that the pipeline,
@@ -180,6 +187,7 @@ func GetPipelineInfo(pachClient *client.APIClient, ptr *pps.EtcdPipelineInfo) (*
 	result.State = ptr.State
 	result.Reason = ptr.Reason
 	result.JobCounts = ptr.JobCounts
+	result.InfraFailures = ptr.InfraFailures
 	result.SpecCommit = ptr.SpecCommit
 	return result, nil
 }
@@ -216,7 +224,7 @@ func JobInput(pipelineInfo *pps.PipelineInfo, outputCommitInfo *pfs.CommitInfo)
 			}
 		}
 		if input.Pfs != nil {
-			if commit, ok := branchToCommit[key(input.Pfs.Repo, input.Pfs.Branch)]; ok {
+			if commit, ok := branchToCommit[key(input.Pfs.Repo, pps.PFSInputBranch(input.Pfs))]; ok {
 				input.Pfs.Commit = commit.ID
 			}
 		}
@@ -230,6 +238,11 @@ func JobInput(pipelineInfo *pps.PipelineInfo, outputCommitInfo *pfs.CommitInfo)
 				input.Git.Commit = commit.ID
 			}
 		}
+		if input.Object != nil {
+			// Object inputs have no commits, so the job's datums are made from
+			// a listing of the bucket identified by its output commit
+			input.Object.Snapshot = outputCommitInfo.Commit.ID
+		}
 	})
 	return jobInput
 }
@@ -237,26 +250,54 @@ func JobInput(pipelineInfo *pps.PipelineInfo, outputCommitInfo *pfs.CommitInfo)
 // PipelineReqFromInfo converts a PipelineInfo into a CreatePipelineRequest.
 func PipelineReqFromInfo(pipelineInfo *ppsclient.PipelineInfo) *ppsclient.CreatePipelineRequest {
 	return &ppsclient.CreatePipelineRequest{
-		Pipeline:           pipelineInfo.Pipeline,
-		Transform:          pipelineInfo.Transform,
-		ParallelismSpec:    pipelineInfo.ParallelismSpec,
-		HashtreeSpec:       pipelineInfo.HashtreeSpec,
-		Egress:             pipelineInfo.Egress,
-		OutputBranch:       pipelineInfo.OutputBranch,
-		ScaleDownThreshold: pipelineInfo.ScaleDownThreshold,
-		ResourceRequests:   pipelineInfo.ResourceRequests,
-		ResourceLimits:     pipelineInfo.ResourceLimits,
-		Input:              pipelineInfo.Input,
-		Description:        pipelineInfo.Description,
-		CacheSize:          pipelineInfo.CacheSize,
-		EnableStats:        pipelineInfo.EnableStats,
-		Batch:              pipelineInfo.Batch,
-		MaxQueueSize:       pipelineInfo.MaxQueueSize,
-		Service:            pipelineInfo.Service,
-		ChunkSpec:          pipelineInfo.ChunkSpec,
-		DatumTimeout:       pipelineInfo.DatumTimeout,
-		JobTimeout:         pipelineInfo.JobTimeout,
-		Salt:               pipelineInfo.Salt,
+		Pipeline:            pipelineInfo.Pipeline,
+		Transform:           pipelineInfo.Transform,
+		ParallelismSpec:     pipelineInfo.ParallelismSpec,
+		HashtreeSpec:        pipelineInfo.HashtreeSpec,
+		Egress:              pipelineInfo.Egress,
+		OutputBranch:        pipelineInfo.OutputBranch,
+		ScaleDownThreshold:  pipelineInfo.ScaleDownThreshold,
+		ResourceRequests:    pipelineInfo.ResourceRequests,
+		ResourceLimits:      pipelineInfo.ResourceLimits,
+		Input:               pipelineInfo.Input,
+		Description:         pipelineInfo.Description,
+		CacheSize:           pipelineInfo.CacheSize,
+		EnableStats:         pipelineInfo.EnableStats,
+		Batch:               pipelineInfo.Batch,
+		MaxQueueSize:        pipelineInfo.MaxQueueSize,
+		Service:             pipelineInfo.Service,
+		ChunkSpec:           pipelineInfo.ChunkSpec,
+		DatumTimeout:        pipelineInfo.DatumTimeout,
+		JobTimeout:          pipelineInfo.JobTimeout,
+		Salt:                pipelineInfo.Salt,
+		StatsRetention:      pipelineInfo.StatsRetention,
+		DisruptionBudget:    pipelineInfo.DisruptionBudget,
+		DatumPriority:       pipelineInfo.DatumPriority,
+		MaxDatumCrashes:     pipelineInfo.MaxDatumCrashes,
+		InfraFailureRetries: pipelineInfo.InfraFailureRetries,
+		Finalizer:           pipelineInfo.Finalizer,
+		OutputSchema:        pipelineInfo.OutputSchema,
+		UploadTries:         pipelineInfo.UploadTries,
+		StageConcurrency:    pipelineInfo.StageConcurrency,
+		DeadLetterBranch:    pipelineInfo.DeadLetterBranch,
+		MaxDatums:           pipelineInfo.MaxDatums,
+		Debounce:            pipelineInfo.Debounce,
+		WorkloadIdentity:    pipelineInfo.WorkloadIdentity,
+		MaxOutputBytes:      pipelineInfo.MaxOutputBytes,
+		HealthCheck:         pipelineInfo.HealthCheck,
+		DatumsPerWorker:     pipelineInfo.DatumsPerWorker,
+		Annotations:         pipelineInfo.Annotations,
+		SkipUnchangedOutput: pipelineInfo.SkipUnchangedOutput,
+		LogRetention:        pipelineInfo.LogRetention,
+		OutputPermissions:   pipelineInfo.OutputPermissions,
+		OutputValidation:    pipelineInfo.OutputValidation,
+		WorkerVersion:       pipelineInfo.WorkerVersion,
+		OutputPartition:     pipelineInfo.OutputPartition,
+		IdleScaleDown:       pipelineInfo.IdleScaleDown,
+		OrderedMerge:        pipelineInfo.OrderedMerge,
+		OOMRetry:            pipelineInfo.OOMRetry,
+		Preemption:          pipelineInfo.Preemption,
+		EmptyInput:          pipelineInfo.EmptyInput,
 	}
 }
 
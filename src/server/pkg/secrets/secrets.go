@@ -0,0 +1,50 @@
+// Package secrets implements reading pipeline secrets from external secrets
+// managers (rather than from kubernetes).
+package secrets
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Provider fetches secrets from an external secrets manager.
+type Provider interface {
+	// GetSecret returns the key/value data of the secret at 'path', along
+	// with how long the data may be used for before it should be fetched
+	// again (0 if the secrets manager doesn't say).
+	GetSecret(path string) (map[string]string, time.Duration, error)
+}
+
+var (
+	providersMu sync.Mutex
+	providers   = make(map[string]func() (Provider, error))
+)
+
+// Register makes a provider available under 'name', which pipelines can then
+// set as the provider of their secrets. 'newProvider' is called by each
+// worker that needs the provider.
+func Register(name string, newProvider func() (Provider, error)) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = newProvider
+}
+
+// IsRegistered returns true if a provider has been registered under 'name'.
+func IsRegistered(name string) bool {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	_, ok := providers[name]
+	return ok
+}
+
+// NewProvider creates the provider registered under 'name'.
+func NewProvider(name string) (Provider, error) {
+	providersMu.Lock()
+	newProvider, ok := providers[name]
+	providersMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown secret provider %q", name)
+	}
+	return newProvider()
+}
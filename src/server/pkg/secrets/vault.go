@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"fmt"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// VaultProvider is the name of the provider that reads secrets from Vault
+const VaultProvider = "vault"
+
+func init() {
+	Register(VaultProvider, newVaultProvider)
+}
+
+type vaultProvider struct {
+	client *vault.Client
+}
+
+// newVaultProvider creates a provider that reads secrets from Vault. The
+// Vault address and token are read from the standard VAULT_ADDR and
+// VAULT_TOKEN environment variables, which pipelines can set in their
+// transform (e.g. with VAULT_TOKEN coming from a kubernetes secret).
+func newVaultProvider() (Provider, error) {
+	client, err := vault.NewClient(vault.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("error creating vault client: %v", err)
+	}
+	return &vaultProvider{client: client}, nil
+}
+
+func (v *vaultProvider) GetSecret(path string) (map[string]string, time.Duration, error) {
+	secret, err := v.client.Logical().Read(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not read %s from vault: %v", path, err)
+	}
+	if secret == nil {
+		return nil, 0, fmt.Errorf("no secret at %s in vault", path)
+	}
+	data := secret.Data
+	// Version 2 of Vault's KV secrets engine nests the secret's data under
+	// "data", alongside its "metadata"
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	result := make(map[string]string)
+	for key, value := range data {
+		s, ok := value.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("value of %s in vault secret %s is a %T, not a string", key, path, value)
+		}
+		result[key] = s
+	}
+	return result, time.Duration(secret.LeaseDuration) * time.Second, nil
+}
@@ -0,0 +1,234 @@
+// Package statsd pushes the metrics that pachd and workers register with
+// Prometheus to a StatsD server (e.g. a Datadog agent), for clusters whose
+// monitoring can't scrape pods' /metrics endpoints. The metrics are read from
+// a Prometheus registry, so both sinks report the same metrics, defined once.
+package statsd
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// AddressEnv is the env var that holds the address (host:port) of the
+	// StatsD server that metrics are pushed to. If it's unset, they aren't.
+	AddressEnv = "STATSD_ADDRESS"
+	// PrefixEnv is the env var that holds a prefix that's added to the names
+	// of the metrics (e.g. "myteam.").
+	PrefixEnv = "STATSD_PREFIX"
+	// TagFormatEnv is the env var that determines how metrics' labels are
+	// sent. If it's "datadog", they're sent as DogStatsD tags, otherwise
+	// they're appended to the metrics' names.
+	TagFormatEnv = "STATSD_TAG_FORMAT"
+	// IntervalEnv is the env var that holds how often metrics are pushed
+	// (e.g. "30s"). It defaults to DefaultInterval.
+	IntervalEnv = "STATSD_INTERVAL"
+
+	// DefaultInterval is how often metrics are pushed by default
+	DefaultInterval = 10 * time.Second
+
+	// maxPacketSize is the largest UDP packet that's sent, which fits in the
+	// MTU of most networks
+	maxPacketSize = 1432
+)
+
+// EnvVars are the env vars that configure the StatsD sink
+var EnvVars = []string{AddressEnv, PrefixEnv, TagFormatEnv, IntervalEnv}
+
+var invalidChars = regexp.MustCompile(`[^a-zA-Z0-9_\-]`)
+
+// Options configures a Sink
+type Options struct {
+	// Address is the address (host:port) of the StatsD server
+	Address string
+	// Prefix is added to the names of the metrics
+	Prefix string
+	// DatadogTags causes labels to be sent as DogStatsD tags, rather than
+	// being appended to the metrics' names
+	DatadogTags bool
+}
+
+// Sink pushes the metrics in a Prometheus registry to a StatsD server.
+// Prometheus counters (and the counts and sums of histograms and summaries)
+// are sent as StatsD counters, of their increase since the last push, and
+// gauges as gauges.
+type Sink struct {
+	options  Options
+	gatherer prometheus.Gatherer
+	conn     net.Conn
+	// counters holds the value of each counter when it was last pushed
+	counters map[string]float64
+}
+
+// NewSink returns a Sink that pushes the metrics in 'gatherer'
+func NewSink(options Options, gatherer prometheus.Gatherer) (*Sink, error) {
+	conn, err := net.Dial("udp", options.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to statsd at %s: %v", options.Address, err)
+	}
+	return &Sink{
+		options:  options,
+		gatherer: gatherer,
+		conn:     conn,
+		counters: make(map[string]float64),
+	}, nil
+}
+
+// Push sends the current value of each metric to the StatsD server
+func (s *Sink) Push() error {
+	// Gather returns as many metrics as it can, even if it errors
+	families, gatherErr := s.gatherer.Gather()
+	var lines []string
+	for _, family := range families {
+		name := s.options.Prefix + family.GetName()
+		for _, metric := range family.Metric {
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				lines = s.appendCount(lines, name, metric.Label, metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				lines = s.appendLine(lines, name, metric.Label, metric.GetGauge().GetValue(), "g")
+			case dto.MetricType_UNTYPED:
+				lines = s.appendLine(lines, name, metric.Label, metric.GetUntyped().GetValue(), "g")
+			case dto.MetricType_HISTOGRAM:
+				lines = s.appendCount(lines, name+"_count", metric.Label, float64(metric.GetHistogram().GetSampleCount()))
+				lines = s.appendCount(lines, name+"_sum", metric.Label, metric.GetHistogram().GetSampleSum())
+			case dto.MetricType_SUMMARY:
+				lines = s.appendCount(lines, name+"_count", metric.Label, float64(metric.GetSummary().GetSampleCount()))
+				lines = s.appendCount(lines, name+"_sum", metric.Label, metric.GetSummary().GetSampleSum())
+			}
+		}
+	}
+	if err := s.send(lines); err != nil {
+		return err
+	}
+	return gatherErr
+}
+
+// appendCount appends a line with the increase in the counter 'name' since
+// it was last pushed, if it has increased
+func (s *Sink) appendCount(lines []string, name string, labels []*dto.LabelPair, value float64) []string {
+	key := s.format(name, labels, 0, "c")
+	delta := value - s.counters[key]
+	if delta < 0 {
+		delta = value // the counter was reset
+	}
+	s.counters[key] = value
+	if delta == 0 {
+		return lines
+	}
+	return s.appendLine(lines, name, labels, delta, "c")
+}
+
+func (s *Sink) appendLine(lines []string, name string, labels []*dto.LabelPair, value float64, metricType string) []string {
+	return append(lines, s.format(name, labels, value, metricType))
+}
+
+// format returns the StatsD line that sets the metric 'name' with 'labels'
+// to 'value'
+func (s *Sink) format(name string, labels []*dto.LabelPair, value float64, metricType string) string {
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	if !s.options.DatadogTags {
+		for _, label := range labels {
+			fmt.Fprintf(&buf, ".%s.%s", sanitize(label.GetName()), sanitize(label.GetValue()))
+		}
+	}
+	fmt.Fprintf(&buf, ":%s|%s", strconv.FormatFloat(value, 'f', -1, 64), metricType)
+	if s.options.DatadogTags && len(labels) > 0 {
+		var tags []string
+		for _, label := range labels {
+			tags = append(tags, sanitize(label.GetName())+":"+sanitize(label.GetValue()))
+		}
+		fmt.Fprintf(&buf, "|#%s", strings.Join(tags, ","))
+	}
+	return buf.String()
+}
+
+// send sends 'lines' in as few packets as fit them
+func (s *Sink) send(lines []string) error {
+	var packet bytes.Buffer
+	flush := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+		_, err := s.conn.Write(packet.Bytes())
+		packet.Reset()
+		return err
+	}
+	for _, line := range lines {
+		if packet.Len() > 0 && packet.Len()+1+len(line) > maxPacketSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+		packet.WriteString(line)
+	}
+	return flush()
+}
+
+// Run pushes metrics every 'interval' until 'stop' is closed
+func (s *Sink) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Push(); err != nil {
+				log.Errorf("error pushing metrics to statsd: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Close closes the connection to the StatsD server
+func (s *Sink) Close() error {
+	return s.conn.Close()
+}
+
+// sanitize replaces the characters in 's' that have a meaning in StatsD
+// lines (or names) with underscores
+func sanitize(s string) string {
+	return invalidChars.ReplaceAllString(s, "_")
+}
+
+// StartFromEnv starts pushing the metrics registered with Prometheus's
+// default registry to the StatsD server configured by the env vars in
+// EnvVars, if there is one, for as long as the process runs
+func StartFromEnv() error {
+	address := os.Getenv(AddressEnv)
+	if address == "" {
+		return nil
+	}
+	interval := DefaultInterval
+	if value := os.Getenv(IntervalEnv); value != "" {
+		var err error
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			return fmt.Errorf("invalid %s %q", IntervalEnv, value)
+		}
+	}
+	sink, err := NewSink(Options{
+		Address:     address,
+		Prefix:      os.Getenv(PrefixEnv),
+		DatadogTags: os.Getenv(TagFormatEnv) == "datadog",
+	}, prometheus.DefaultGatherer)
+	if err != nil {
+		return err
+	}
+	go sink.Run(interval, nil)
+	return nil
+}
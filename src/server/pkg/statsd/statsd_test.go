@@ -0,0 +1,98 @@
+package statsd
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// receive returns the lines of the packets that 'conn' receives until none
+// arrive for a while, sorted
+func receive(t *testing.T, conn net.PacketConn) []string {
+	var lines []string
+	buf := make([]byte, 65536)
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+		lines = append(lines, strings.Split(string(buf[:n]), "\n")...)
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// newTestSink returns a Sink that pushes the metrics of a new registry to a
+// mock StatsD server, the registry's metrics, and the server's connection
+func newTestSink(t *testing.T, options Options) (*Sink, *prometheus.CounterVec, prometheus.Gauge, prometheus.Histogram, net.PacketConn) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "pachyderm",
+		Name:      "datum_count",
+		Help:      "datums",
+	}, []string{"pipeline", "state"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "pachyderm",
+		Name:      "queue_size",
+		Help:      "queue size",
+	})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "pachyderm",
+		Name:      "proc_time",
+		Help:      "processing time",
+	})
+	registry.MustRegister(counter, gauge, histogram)
+	options.Address = conn.LocalAddr().String()
+	sink, err := NewSink(options, registry)
+	require.NoError(t, err)
+	return sink, counter, gauge, histogram, conn
+}
+
+func TestPush(t *testing.T) {
+	sink, counter, gauge, histogram, conn := newTestSink(t, Options{Prefix: "test."})
+	defer conn.Close()
+	defer sink.Close()
+
+	counter.WithLabelValues("edges", "finished").Add(3)
+	gauge.Set(7)
+	histogram.Observe(1.5)
+	histogram.Observe(2.5)
+	require.NoError(t, sink.Push())
+	require.Equal(t, []string{
+		"test.pachyderm_datum_count.pipeline.edges.state.finished:3|c",
+		"test.pachyderm_proc_time_count:2|c",
+		"test.pachyderm_proc_time_sum:4|c",
+		"test.pachyderm_queue_size:7|g",
+	}, receive(t, conn))
+
+	// Counters are sent as their increase since the last push, and unchanged
+	// counters aren't sent
+	counter.WithLabelValues("edges", "finished").Add(2)
+	gauge.Set(1)
+	require.NoError(t, sink.Push())
+	require.Equal(t, []string{
+		"test.pachyderm_datum_count.pipeline.edges.state.finished:2|c",
+		"test.pachyderm_queue_size:1|g",
+	}, receive(t, conn))
+}
+
+func TestPushDatadogTags(t *testing.T) {
+	sink, counter, _, _, conn := newTestSink(t, Options{DatadogTags: true})
+	defer conn.Close()
+	defer sink.Close()
+
+	counter.WithLabelValues("my.pipeline", "finished").Inc()
+	require.NoError(t, sink.Push())
+	require.Equal(t, []string{
+		"pachyderm_datum_count:1|c|#pipeline:my_pipeline,state:finished",
+		"pachyderm_queue_size:0|g",
+	}, receive(t, conn))
+}
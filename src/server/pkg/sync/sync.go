@@ -2,6 +2,7 @@
 package sync
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path"
@@ -10,16 +11,23 @@ import (
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	pachclient "github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/limit"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pfs/fuse"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 
 	"golang.org/x/sync/errgroup"
 )
 
+// cleanUpTimeout is how long CleanUp waits for a Puller's goroutines to exit
+// (e.g. for its FUSE mounts to be removed, see fuse.Mount) before it gives up
+// on them
+const cleanUpTimeout = 30 * time.Second
+
 // Puller as a struct for managing a Pull operation.
 type Puller struct {
 	sync.Mutex
@@ -34,6 +42,9 @@ type Puller struct {
 	wg sync.WaitGroup
 	// size is the total amount this puller has pulled
 	size int64
+	// unmounts contains a channel for each FUSE mount created by Mount, which
+	// is closed by CleanUp to remove the mount
+	unmounts []chan struct{}
 }
 
 // NewPuller creates a new Puller struct.
@@ -134,6 +145,23 @@ func (p *Puller) makeFile(path string, f func(io.Writer) error) (retErr error) {
 	return nil
 }
 
+// SetPermissions gives the local file at 'path' the permissions of a file in
+// PFS: its mode, if 'mode' is set, and its owner, if 'owner' is set. The owner
+// is only a hint, and is skipped if this process isn't allowed to set it.
+func SetPermissions(path string, mode uint32, owner *pfs.FileOwner) error {
+	if mode != 0 {
+		if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+			return err
+		}
+	}
+	if owner != nil {
+		if err := os.Lchown(path, int(owner.Uid), int(owner.Gid)); err != nil && !os.IsPermission(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 // Pull clones an entire repo at a certain commit.
 // root is the local path you want to clone to.
 // fileInfo is the file/dir we are puuling.
@@ -153,20 +181,8 @@ func (p *Puller) Pull(client *pachclient.APIClient, root string, repo, commit, f
 			return err
 		}
 		if statsTree != nil {
-			statsPath := filepath.Join(statsRoot, basepath)
-			if fileInfo.FileType == pfs.FileType_DIR {
-				statsTree.PutDir(statsPath)
-			} else {
-				var blockRefs []*pfs.BlockRef
-				for _, object := range fileInfo.Objects {
-					objectInfo, err := client.InspectObject(object.Hash)
-					if err != nil {
-						return err
-					}
-					blockRefs = append(blockRefs, objectInfo.BlockRef)
-				}
-				blockRefs = append(blockRefs, fileInfo.BlockRefs...)
-				statsTree.PutFile(statsPath, fileInfo.Hash, int64(fileInfo.SizeBytes), &hashtree.FileNodeProto{BlockRefs: blockRefs})
+			if err := putStats(client, statsTree, filepath.Join(statsRoot, basepath), fileInfo); err != nil {
+				return err
 			}
 		}
 		path := filepath.Join(root, basepath)
@@ -174,19 +190,28 @@ func (p *Puller) Pull(client *pachclient.APIClient, root string, repo, commit, f
 			return os.MkdirAll(path, 0700)
 		}
 		if pipes {
-			return p.makePipe(path, func(w io.Writer) error {
+			if err := p.makePipe(path, func(w io.Writer) error {
 				return client.GetFile(repo, commit, fileInfo.File.Path, 0, 0, w)
-			})
+			}); err != nil {
+				return err
+			}
+			return SetPermissions(path, fileInfo.Mode, fileInfo.Owner)
 		}
 		if emptyFiles {
-			return p.makeFile(path, func(w io.Writer) error { return nil })
+			if err := p.makeFile(path, func(w io.Writer) error { return nil }); err != nil {
+				return err
+			}
+			return SetPermissions(path, fileInfo.Mode, fileInfo.Owner)
 		}
 		eg.Go(func() (retErr error) {
 			limiter.Acquire()
 			defer limiter.Release()
-			return p.makeFile(path, func(w io.Writer) error {
+			if err := p.makeFile(path, func(w io.Writer) error {
 				return client.GetFile(repo, commit, fileInfo.File.Path, 0, 0, w)
-			})
+			}); err != nil {
+				return err
+			}
+			return SetPermissions(path, fileInfo.Mode, fileInfo.Owner)
 		})
 		return nil
 	}); err != nil {
@@ -195,6 +220,82 @@ func (p *Puller) Pull(client *pachclient.APIClient, root string, repo, commit, f
 	return eg.Wait()
 }
 
+// Mount is like Pull with pipes set, except that instead of creating a pipe
+// for each file, it exposes 'file' through a FUSE mount at mountPoint (under
+// mountPoint/file). Files are fetched from PFS the first time they're read,
+// and unlike pipes they support seeking. The mount is removed by CleanUp.
+func (p *Puller) Mount(client *pachclient.APIClient, mountPoint string, repo, commit, file string,
+	statsTree *hashtree.Ordered, statsRoot string) error {
+	if statsTree != nil {
+		if err := client.Walk(repo, commit, file, func(fileInfo *pfs.FileInfo) error {
+			basepath, err := filepath.Rel(file, fileInfo.File.Path)
+			if err != nil {
+				return err
+			}
+			return putStats(client, statsTree, filepath.Join(statsRoot, basepath), fileInfo)
+		}); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(mountPoint, 0700); err != nil {
+		return err
+	}
+	opts := &fuse.Options{
+		File:    pachclient.NewFile(repo, commit, file),
+		Unmount: make(chan struct{}),
+		Mounted: make(chan struct{}),
+	}
+	errCh := make(chan error, 1)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		err := fuse.Mount(client, mountPoint, opts)
+		select {
+		case <-opts.Mounted:
+			// The mount was ready, so it's being removed by CleanUp, which
+			// returns the error (e.g. if it couldn't be removed)
+			if err != nil {
+				select {
+				case p.errCh <- err:
+				default:
+				}
+			}
+		default:
+			errCh <- err
+		}
+	}()
+	select {
+	case <-opts.Mounted:
+	case err := <-errCh:
+		if err == nil {
+			err = fmt.Errorf("fuse mount at %s exited before it was ready", mountPoint)
+		}
+		return err
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.unmounts = append(p.unmounts, opts.Unmount)
+	return nil
+}
+
+func putStats(client *pachclient.APIClient, statsTree *hashtree.Ordered, statsPath string, fileInfo *pfs.FileInfo) error {
+	if fileInfo.FileType == pfs.FileType_DIR {
+		statsTree.PutDir(statsPath)
+		return nil
+	}
+	var blockRefs []*pfs.BlockRef
+	for _, object := range fileInfo.Objects {
+		objectInfo, err := client.InspectObject(object.Hash)
+		if err != nil {
+			return err
+		}
+		blockRefs = append(blockRefs, objectInfo.BlockRef)
+	}
+	blockRefs = append(blockRefs, fileInfo.BlockRefs...)
+	statsTree.PutFile(statsPath, fileInfo.Hash, int64(fileInfo.SizeBytes), &hashtree.FileNodeProto{BlockRefs: blockRefs})
+	return nil
+}
+
 // PullDiff is like Pull except that it materializes a Diff of the content
 // rather than a the actual content. If newOnly is true then only new files
 // will be downloaded and they will be downloaded under root. Otherwise new and
@@ -292,16 +393,22 @@ func (p *Puller) PullTree(client *pachclient.APIClient, root string, tree hashtr
 				hashes = append(hashes, object.Hash)
 			}
 			if pipes {
-				return p.makePipe(path, func(w io.Writer) error {
+				if err := p.makePipe(path, func(w io.Writer) error {
 					return client.GetObjects(hashes, 0, 0, uint64(node.SubtreeSize), w)
-				})
+				}); err != nil {
+					return err
+				}
+				return SetPermissions(path, node.FileNode.Mode, node.FileNode.Owner)
 			}
 			limiter.Acquire()
 			eg.Go(func() (retErr error) {
 				defer limiter.Release()
-				return p.makeFile(path, func(w io.Writer) error {
+				if err := p.makeFile(path, func(w io.Writer) error {
 					return client.GetObjects(hashes, 0, 0, uint64(node.SubtreeSize), w)
-				})
+				}); err != nil {
+					return err
+				}
+				return SetPermissions(path, node.FileNode.Mode, node.FileNode.Owner)
 			})
 		}
 		return nil
@@ -311,11 +418,13 @@ func (p *Puller) PullTree(client *pachclient.APIClient, root string, tree hashtr
 	return eg.Wait()
 }
 
-// CleanUp cleans up blocked syscalls for pipes that were never opened. And
-// returns the total number of bytes that have been pulled/pushed. It also
-// returns any errors that might have been encountered while trying to read
-// data for the pipes. CleanUp should be called after all code that might
-// access pipes has completed running, it should not be called concurrently.
+// CleanUp cleans up blocked syscalls for pipes that were never opened, and
+// removes FUSE mounts. And returns the total number of bytes that have been
+// pulled/pushed. It also returns any errors that might have been encountered
+// while trying to read data for the pipes or removing the mounts, and an
+// error if they aren't cleaned up within cleanUpTimeout. CleanUp should be
+// called after all code that might access pipes has completed running, it
+// should not be called concurrently.
 func (p *Puller) CleanUp() (int64, error) {
 	var result error
 	select {
@@ -337,10 +446,34 @@ func (p *Puller) CleanUp() (int64, error) {
 			pipes = append(pipes, f)
 		}
 		p.pipes = make(map[string]bool)
+		// Remove FUSE mounts, which causes their goros to exit
+		for _, unmount := range p.unmounts {
+			close(unmount)
+		}
+		p.unmounts = nil
 	}()
 
-	// Wait for all goros to exit
-	p.wg.Wait()
+	// Wait for all goros to exit. A goro that doesn't (e.g. a FUSE mount that
+	// couldn't be removed, or that's still busy after being lazily unmounted)
+	// is abandoned after cleanUpTimeout.
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		if result == nil {
+			select {
+			case result = <-p.errCh:
+			default:
+			}
+		}
+	case <-time.After(cleanUpTimeout):
+		if result == nil {
+			result = fmt.Errorf("timed out after %v waiting for the puller's pipes and mounts to be cleaned up", cleanUpTimeout)
+		}
+	}
 
 	// Close the pipes
 	for _, pipe := range pipes {
@@ -348,8 +481,7 @@ func (p *Puller) CleanUp() (int64, error) {
 			result = err
 		}
 	}
-	size := p.size
-	p.size = 0
+	size := atomic.SwapInt64(&p.size, 0)
 	return size, result
 }
 
@@ -446,7 +578,7 @@ func PushFile(c *pachclient.APIClient, pfc pachclient.PutFileClient, pfsFile *pf
 				return err
 			}
 
-			if object.Hash != pfs.EncodeHash(hash.Sum(nil)) {
+			if !pfs.HasContent(object, pfsFile.Commit.Repo.Name, hash.Sum(nil)) {
 				break
 			}
 		}
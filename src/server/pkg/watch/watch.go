@@ -10,6 +10,7 @@ import (
 	"reflect"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/gogo/protobuf/proto"
 )
 
@@ -94,37 +95,55 @@ func (s byModRev) Less(i, j int) bool {
 
 // NewWatcher watches a given etcd prefix for events.
 func NewWatcher(ctx context.Context, client *etcd.Client, trimPrefix, prefix string, template proto.Message) (Watcher, error) {
-	return newWatcher(ctx, client, []byte(trimPrefix), prefix, false, template)
+	return newWatcher(ctx, client, []byte(trimPrefix), prefix, false, 0, template)
 }
 
 // NewWatcherWithPrev is like NewWatcher, except that the returned events
 // include the previous version of the values.
 func NewWatcherWithPrev(ctx context.Context, client *etcd.Client, trimPrefix, prefix string, template proto.Message) (Watcher, error) {
-	return newWatcher(ctx, client, []byte(trimPrefix), prefix, true, template)
+	return newWatcher(ctx, client, []byte(trimPrefix), prefix, true, 0, template)
 }
 
-func newWatcher(ctx context.Context, client *etcd.Client, trimPrefix []byte, prefix string, withPrev bool, template proto.Message) (Watcher, error) {
+// NewWatcherWithPrevFrom is like NewWatcherWithPrev, except that instead of
+// listing the current items, it returns the events that happened at or after
+// revision 'rev'. If 'rev' has been compacted, the watcher returns an
+// EventError whose Err is rpctypes.ErrCompacted.
+func NewWatcherWithPrevFrom(ctx context.Context, client *etcd.Client, trimPrefix, prefix string, rev int64, template proto.Message) (Watcher, error) {
+	return newWatcher(ctx, client, []byte(trimPrefix), prefix, true, rev, template)
+}
+
+// newWatcher watches 'prefix' starting at revision 'fromRev' or, if 'fromRev'
+// is 0, lists the current items and then watches for changes to them
+func newWatcher(ctx context.Context, client *etcd.Client, trimPrefix []byte, prefix string, withPrev bool, fromRev int64, template proto.Message) (Watcher, error) {
 	eventCh := make(chan *Event)
 	done := make(chan struct{})
-	// First list the collection to get the current items
-	// Sort by mod revision--how the items would have been returned if we watched
-	// them from the beginning.
-	resp, err := client.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithSort(etcd.SortByModRevision, etcd.SortAscend))
-	if err != nil {
-		return nil, err
+	nextRevision := fromRev
+	var kvs []*mvccpb.KeyValue
+	if fromRev == 0 {
+		// First list the collection to get the current items
+		// Sort by mod revision--how the items would have been returned if we watched
+		// them from the beginning.
+		resp, err := client.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithSort(etcd.SortByModRevision, etcd.SortAscend))
+		if err != nil {
+			return nil, err
+		}
+		kvs = resp.Kvs
+		nextRevision = resp.Header.Revision + 1
 	}
 
-	nextRevision := resp.Header.Revision + 1
 	etcdWatcher := etcd.NewWatcher(client)
 	// Issue a watch that uses the revision timestamp returned by the
 	// Get request earlier.  That way even if some items are added between
 	// when we list the collection and when we start watching the collection,
 	// we won't miss any items.
-	options := []etcd.OpOption{etcd.WithPrefix(), etcd.WithRev(nextRevision)}
-	if withPrev {
-		options = append(options, etcd.WithPrevKV())
+	watchOptions := func() []etcd.OpOption {
+		options := []etcd.OpOption{etcd.WithPrefix(), etcd.WithRev(nextRevision)}
+		if withPrev {
+			options = append(options, etcd.WithPrevKV())
+		}
+		return options
 	}
-	rch := etcdWatcher.Watch(ctx, prefix, options...)
+	rch := etcdWatcher.Watch(ctx, prefix, watchOptions()...)
 
 	go func() (retErr error) {
 		defer func() {
@@ -140,7 +159,7 @@ func newWatcher(ctx context.Context, client *etcd.Client, trimPrefix []byte, pre
 			close(eventCh)
 			etcdWatcher.Close()
 		}()
-		for _, etcdKv := range resp.Kvs {
+		for _, etcdKv := range kvs {
 			eventCh <- &Event{
 				Key:      bytes.TrimPrefix(etcdKv.Key, trimPrefix),
 				Value:    etcdKv.Value,
@@ -162,7 +181,7 @@ func newWatcher(ctx context.Context, client *etcd.Client, trimPrefix []byte, pre
 					return err
 				}
 				etcdWatcher = etcd.NewWatcher(client)
-				rch = etcdWatcher.Watch(ctx, prefix, etcd.WithPrefix(), etcd.WithRev(nextRevision))
+				rch = etcdWatcher.Watch(ctx, prefix, watchOptions()...)
 				continue
 			}
 			if err := resp.Err(); err != nil {
@@ -16,6 +16,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/client/pps/local"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/tabwriter"
@@ -90,6 +91,29 @@ The increase the throughput of a job increase the Shard paremeter.
 	inspectJob.Flags().BoolVarP(&block, "block", "b", false, "block until the job has either succeeded or failed")
 	rawFlag(inspectJob)
 
+	watchJob := &cobra.Command{
+		Use:   "watch-job job-id",
+		Short: "Watch a job's progress until it finishes.",
+		Long: `Watch a job's progress until it finishes.
+
+The job's state and datum counts are printed, followed by a line each time they
+change, until the job succeeds, fails, or is killed.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return client.WatchJob(args[0], func(event *ppsclient.JobEvent) error {
+				if raw {
+					return marshaller.Marshal(os.Stdout, event)
+				}
+				pretty.PrintJobEvent(os.Stdout, event)
+				return nil
+			})
+		}),
+	}
+	rawFlag(watchJob)
+
 	var pipelineName string
 	var outputCommitStr string
 	var inputCommitStrs []string
@@ -242,6 +266,54 @@ $ pachctl flush-job foo/XXX -p bar -p baz
 		}),
 	}
 
+	stopPipelineJobs := &cobra.Command{
+		Use:   "stop-pipeline-jobs pipeline-name",
+		Short: "Stop all of a pipeline's running jobs.",
+		Long: `Stop all of a pipeline's running jobs at once, and print the IDs of the jobs
+that were stopped. Each job is stopped as it is by stop-job. The pipeline keeps
+running, and starts jobs for new input commits; use stop-pipeline to prevent
+that.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			jobs, err := client.StopPipelineJobs(args[0])
+			if err != nil {
+				cmdutil.ErrorAndExit("error from StopPipelineJobs: %s", err.Error())
+			}
+			for _, job := range jobs {
+				fmt.Println(job.ID)
+			}
+			return nil
+		}),
+	}
+
+	rerunJob := &cobra.Command{
+		Use:   "rerun-job job-id",
+		Short: "Rerun a job with its original inputs.",
+		Long: `Rerun a job with its original inputs.
+
+The new job processes the same input commits as the original job, with the
+same version of its pipeline, which must be the pipeline's current version.
+Its output is written to the branch "rerun-<new-job-id>" of the pipeline's
+output repo, so the pipeline's output branch isn't affected, and it doesn't
+egress its output. The branch is deleted once the job finishes; its output
+commit can still be found with inspect-job. The new job's ID is printed.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			job, err := client.RerunJob(args[0])
+			if err != nil {
+				cmdutil.ErrorAndExit("error from RerunJob: %s", err.Error())
+			}
+			fmt.Println(job.ID)
+			return nil
+		}),
+	}
+
 	restartDatum := &cobra.Command{
 		Use:   "restart-datum job-id datum-path1,datum-path2",
 		Short: "Restart a datum.",
@@ -303,15 +375,58 @@ $ pachctl flush-job foo/XXX -p bar -p baz
 	listDatum.Flags().Int64Var(&pageSize, "pageSize", 0, "Specify the number of results sent back in a single page")
 	listDatum.Flags().Int64Var(&page, "page", 0, "Specify the page of results to send")
 
+	var preview bool
+	var previewPipelinePath string
+	var previewCommitStrs []string
 	inspectDatum := &cobra.Command{
 		Use:   "inspect-datum job-id datum-id",
 		Short: "Display detailed info about a single datum.",
-		Long:  "Display detailed info about a single datum.",
-		Run: cmdutil.RunBoundedArgs(2, 2, func(args []string) error {
+		Long: `Display detailed info about a single datum.
+
+With --preview, print the number of datums that a pipeline would process,
+without creating the pipeline or running any jobs.
+
+Examples:
+
+` + codestart + `# print the number of datums in the pipeline in pipeline.json, using the
+# heads of its input branches
+$ pachctl inspect-datum --preview -f pipeline.json
+
+# print the number of datums in the pipeline in pipeline.json, using the
+# commit XXX of its input repo "images"
+$ pachctl inspect-datum --preview -f pipeline.json -c images/XXX
+` + codeend,
+		Run: cmdutil.RunBoundedArgs(0, 2, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine(metrics, "user")
 			if err != nil {
 				return err
 			}
+			if preview {
+				if len(args) != 0 {
+					return fmt.Errorf("--preview doesn't take a job or datum ID")
+				}
+				cfgReader, err := ppsutil.NewPipelineManifestReader(previewPipelinePath)
+				if err != nil {
+					return err
+				}
+				request, err := cfgReader.NextCreatePipelineRequest()
+				if err != nil {
+					return err
+				}
+				commits, err := cmdutil.ParseCommits(previewCommitStrs)
+				if err != nil {
+					return err
+				}
+				count, err := client.InspectDatums(request.Input, commits...)
+				if err != nil {
+					return err
+				}
+				fmt.Println(count)
+				return nil
+			}
+			if len(args) != 2 {
+				return fmt.Errorf("expected a job ID and a datum ID")
+			}
 			datumInfo, err := client.InspectDatum(args[0], args[1])
 			if err != nil {
 				return err
@@ -324,6 +439,49 @@ $ pachctl flush-job foo/XXX -p bar -p baz
 		}),
 	}
 	rawFlag(inspectDatum)
+	inspectDatum.Flags().BoolVar(&preview, "preview", false, "Print the number of datums that the pipeline in --file would process, without running it.")
+	inspectDatum.Flags().StringVarP(&previewPipelinePath, "file", "f", "-", "With --preview, the file containing the pipeline, it can be a url or local file. - reads from stdin.")
+	inspectDatum.Flags().StringSliceVarP(&previewCommitStrs, "commit", "c", []string{}, "With --preview, the input commits (of the form repo/commit) to count the datums of, instead of the heads of the input branches.")
+
+	previewTrigger := &cobra.Command{
+		Use:   "preview-trigger repo-name commit-id",
+		Short: "Preview the pipelines that finishing a commit would trigger.",
+		Long: `Preview the pipelines that finishing a commit would trigger, directly or
+through the output of other pipelines, and the number of datums that each would
+process. Use this before finishing a commit to a widely-consumed repo to see how
+much work it will start.
+
+Examples:
+
+` + codestart + `# preview the pipelines that finishing the open commit on "master" of the
+# repo "images" would trigger
+$ pachctl preview-trigger images master
+` + codeend,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			triggered, err := client.PreviewTrigger(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if raw {
+				for _, t := range triggered {
+					if err := marshaller.Marshal(os.Stdout, t); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.TriggeredPipelineHeader)
+			for _, t := range triggered {
+				pretty.PrintTriggeredPipeline(writer, t)
+			}
+			return writer.Flush()
+		}),
+	}
+	rawFlag(previewTrigger)
 
 	var (
 		jobID       string
@@ -332,6 +490,7 @@ $ pachctl flush-job foo/XXX -p bar -p baz
 		master      bool
 		follow      bool
 		tail        int64
+		worker      string
 	)
 	getLogs := &cobra.Command{
 		Use:   "get-logs [--pipeline=<pipeline>|--job=<job id>] [--datum=<datum id>]",
@@ -348,6 +507,9 @@ $ pachctl get-logs --job=aedfa12aedf
 
 # return logs emitted by the pipeline \"filter\" while processing /apple.txt and a file with the hash 123aef
 $ pachctl get-logs --pipeline=filter --inputs=/apple.txt,123aef
+
+# return logs emitted by the worker pod "pipeline-filter-v1-abcde" of the "filter" pipeline
+$ pachctl get-logs --pipeline=filter --worker=pipeline-filter-v1-abcde
 ` + codeend,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine(metrics, "user")
@@ -370,7 +532,7 @@ $ pachctl get-logs --pipeline=filter --inputs=/apple.txt,123aef
 
 			// Issue RPC
 			marshaler := &jsonpb.Marshaler{}
-			iter := client.GetLogs(pipelineName, jobID, data, datumID, master, follow, tail)
+			iter := client.GetWorkerLogs(pipelineName, jobID, worker, data, datumID, master, follow, tail)
 			for iter.Next() {
 				var messageStr string
 				if raw {
@@ -402,6 +564,8 @@ $ pachctl get-logs --pipeline=filter --inputs=/apple.txt,123aef
 	getLogs.Flags().BoolVar(&raw, "raw", false, "Return log messages verbatim from server.")
 	getLogs.Flags().BoolVarP(&follow, "follow", "f", false, "Follow logs as more are created.")
 	getLogs.Flags().Int64VarP(&tail, "tail", "t", 0, "Lines of recent logs to display.")
+	getLogs.Flags().StringVar(&worker, "worker", "", "Return log messages only "+
+		"from this worker (accepts pod name or IP address, pipeline or job must be set).")
 
 	pipeline := &cobra.Command{
 		Use:   "pipeline",
@@ -471,6 +635,7 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 	createPipeline.Flags().StringVarP(&password, "password", "", "", "Your password for the registry being pushed to.")
 
 	var reprocess bool
+	var squashOutput bool
 	updatePipeline := &cobra.Command{
 		Use:   "update-pipeline -f pipeline.json",
 		Short: "Update an existing Pachyderm pipeline.",
@@ -493,6 +658,7 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 				}
 				request.Update = true
 				request.Reprocess = reprocess
+				request.SquashOutput = squashOutput
 				if request.Input.Atom != nil {
 					fmt.Println("WARNING: The `atom` input type has been deprecated and will be removed in a future version. Please replace `atom` with `pfs`.")
 				}
@@ -519,16 +685,95 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 	updatePipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as, defaults to your OS username.")
 	updatePipeline.Flags().StringVarP(&password, "password", "", "", "Your password for the registry being pushed to.")
 	updatePipeline.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	updatePipeline.Flags().BoolVar(&squashOutput, "squash-output", false, "If true, delete the pipeline's old output commits once the updated pipeline's first output commit is finished.")
+
+	run := &cobra.Command{
+		Use:   "run",
+		Short: "Run pipelines outside of a Pachyderm cluster.",
+		Long:  "Run pipelines outside of a Pachyderm cluster.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			return nil
+		}),
+	}
+
+	var localInputDir string
+	var localOutputDir string
+	runLocal := &cobra.Command{
+		Use:   "local -f pipeline.json",
+		Short: "Run a pipeline over local data, without a cluster.",
+		Long: `Run a pipeline over local data, without a cluster.
+
+The pipeline's transform is run in its image using the local docker daemon,
+once for each datum. Datums are constructed the same way that Pachyderm
+constructs them, except that the data for an input from the repo "foo" is
+read from the directory <input>/foo rather than from PFS. The output of every
+datum is written to the output directory. Nothing is read from or written to
+a Pachyderm cluster, and cron and git inputs are not supported.
+
+Examples:
+
+` + codestart + `# run the pipeline in edges.json over the files in ./data/images
+$ pachctl run local -f edges.json --input ./data --output ./edges
+` + codeend,
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			cfgReader, err := ppsutil.NewPipelineManifestReader(pipelinePath)
+			if err != nil {
+				return err
+			}
+			for {
+				request, err := cfgReader.NextCreatePipelineRequest()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					return err
+				}
+				if err := local.Run(request, localInputDir, localOutputDir, os.Stdout, os.Stderr); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+	runLocal.Flags().StringVarP(&pipelinePath, "file", "f", "-", "The file containing the pipeline, it can be a url or local file. - reads from stdin.")
+	runLocal.Flags().StringVarP(&localInputDir, "input", "i", ".", "The directory containing the pipeline's input data, with one subdirectory per input repo.")
+	runLocal.Flags().StringVarP(&localOutputDir, "output", "o", "out", "The directory that the pipeline's output is written to.")
+	run.AddCommand(runLocal)
 
+	var history bool
+	var historyLimit int64
 	inspectPipeline := &cobra.Command{
 		Use:   "inspect-pipeline pipeline-name",
 		Short: "Return info about a pipeline.",
-		Long:  "Return info about a pipeline.",
+		Long: `Return info about a pipeline.
+
+With --history, print the number of the pipeline's recent jobs that succeeded
+and failed, and the mean, standard deviation and 5th and 95th percentiles of
+their duration, throughput (datums processed or skipped per second) and
+datums processed. Killed jobs don't count towards the failure rate.
+
+Examples:
+
+` + codestart + `# aggregate all of the jobs of pipeline foo
+$ pachctl inspect-pipeline foo --history
+
+# aggregate the last 20 jobs of pipeline foo
+$ pachctl inspect-pipeline foo --history --last 20
+` + codeend,
 		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine(metrics, "user")
 			if err != nil {
 				return err
 			}
+			if history {
+				jobHistory, err := client.InspectJobHistory(args[0], historyLimit)
+				if err != nil {
+					return err
+				}
+				if raw {
+					return marshaller.Marshal(os.Stdout, jobHistory)
+				}
+				return pretty.PrintJobHistory(jobHistory)
+			}
 			pipelineInfo, err := client.InspectPipeline(args[0])
 			if err != nil {
 				return err
@@ -543,6 +788,59 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 		}),
 	}
 	rawFlag(inspectPipeline)
+	inspectPipeline.Flags().BoolVar(&history, "history", false, "Print aggregate statistics of the pipeline's jobs, instead of its info.")
+	inspectPipeline.Flags().Int64Var(&historyLimit, "last", 0, "With --history, only aggregate the pipeline's last N jobs (0 aggregates all of its jobs).")
+
+	inspectPipelineVersion := &cobra.Command{
+		Use:   "inspect-pipeline-version pipeline-name",
+		Short: "Return the versions of a pipeline that its workers are running.",
+		Long: `Return the versions of a pipeline that its workers are running.
+
+Workers run the version of the pipeline that was current when they started, so
+while a pipeline is being updated, some of its workers may still run the old
+version. This command compares the version that each worker is running with
+the pipeline's current version, and reports skew if they differ. Once the
+update has rolled out, every worker runs the current version.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			versionInfo, err := client.InspectPipelineVersion(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return marshaller.Marshal(os.Stdout, versionInfo)
+			}
+			return pretty.PrintDetailedPipelineVersionInfo(versionInfo)
+		}),
+	}
+	rawFlag(inspectPipelineVersion)
+
+	watchWorkers := &cobra.Command{
+		Use:   "watch-workers pipeline-name",
+		Short: "Watch workers join and leave a pipeline.",
+		Long: `Watch workers join and leave a pipeline.
+
+The workers that are already running are reported as having joined, followed
+by each worker that starts or stops (e.g. because the pipeline was scaled or
+updated) until the command is interrupted.`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return client.WatchWorkers(args[0], func(event *ppsclient.WorkerEvent) error {
+				if raw {
+					return marshaller.Marshal(os.Stdout, event)
+				}
+				pretty.PrintWorkerEvent(os.Stdout, event)
+				return nil
+			})
+		}),
+	}
+	rawFlag(watchWorkers)
 
 	extractPipeline := &cobra.Command{
 		Use:   "extract-pipeline pipeline-name",
@@ -561,6 +859,71 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 		}),
 	}
 
+	var diffPipelinePath string
+	diffPipeline := &cobra.Command{
+		Use:   "diff-pipeline pipeline-name [new-pipeline-name]",
+		Short: "Compare the spec of a pipeline with a manifest or another version.",
+		Long: `Compare the spec of a pipeline with a manifest or another version.
+
+Each field that differs between the specs is printed along with its value in
+each of them. Previous versions of a pipeline's spec are referenced with the
+same ancestry syntax as commits, so "foo~1" is the version of pipeline foo
+before its last update. When comparing with a manifest, fields that the
+manifest doesn't set but that Pachyderm fills in with a default value (e.g. the
+branch of an input) are ignored.
+
+Examples:
+
+` + codestart + `# compare pipeline foo with the manifest in pipeline.json
+$ pachctl diff-pipeline foo -f pipeline.json
+
+# show what changed in pipeline foo's last update
+$ pachctl diff-pipeline foo~1 foo
+` + codeend,
+		Run: cmdutil.RunBoundedArgs(1, 2, func(args []string) error {
+			if (len(args) == 2) == (diffPipelinePath != "") {
+				return fmt.Errorf("either a second pipeline or --file must be given")
+			}
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			oldSpec, err := client.ExtractPipeline(args[0])
+			if err != nil {
+				return err
+			}
+			var newSpec *ppsclient.CreatePipelineRequest
+			if len(args) == 2 {
+				newSpec, err = client.ExtractPipeline(args[1])
+				if err != nil {
+					return err
+				}
+			} else {
+				cfgReader, err := ppsutil.NewPipelineManifestReader(diffPipelinePath)
+				if err != nil {
+					return err
+				}
+				newSpec, err = cfgReader.NextCreatePipelineRequest()
+				if err != nil {
+					return err
+				}
+			}
+			diff, err := ppsutil.DiffPipelineSpecs(oldSpec, newSpec, len(args) == 1)
+			if err != nil {
+				return err
+			}
+			if len(diff) == 0 {
+				fmt.Println("The pipeline specs are identical.")
+				return nil
+			}
+			for _, line := range diff {
+				fmt.Println(line)
+			}
+			return nil
+		}),
+	}
+	diffPipeline.Flags().StringVarP(&diffPipelinePath, "file", "f", "", "The file containing the manifest to compare the pipeline with, it can be a url or local file. - reads from stdin.")
+
 	var editor string
 	editPipeline := &cobra.Command{
 		Use:   "edit-pipeline pipeline-name",
@@ -614,6 +977,7 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 			}
 			request.Update = true
 			request.Reprocess = reprocess
+			request.SquashOutput = squashOutput
 			if request.Input.Atom != nil {
 				fmt.Println("WARNING: The `atom` input type has been deprecated and will be removed in a future version. Please replace `atom` with `pfs`.")
 			}
@@ -627,19 +991,33 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 		}),
 	}
 	editPipeline.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	editPipeline.Flags().BoolVar(&squashOutput, "squash-output", false, "If true, delete the pipeline's old output commits once the updated pipeline's first output commit is finished.")
 	editPipeline.Flags().StringVar(&editor, "editor", "", "Editor to use for modifying the manifest.")
 
 	var spec bool
+	var selector string
 	listPipeline := &cobra.Command{
 		Use:   "list-pipeline",
 		Short: "Return info about all pipelines.",
-		Long:  "Return info about all pipelines.",
+		Long: `Return info about all pipelines.
+
+Examples:
+
+` + codestart + `# return all pipelines
+$ pachctl list-pipeline
+
+# return the pipelines annotated as owned by the data team, in production
+$ pachctl list-pipeline -l owner=data-team,environment=production
+
+# return the pipelines that aren't annotated as being in development
+$ pachctl list-pipeline -l 'environment!=dev'
+` + codeend,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine(metrics, "user")
 			if err != nil {
 				return fmt.Errorf("error connecting to pachd: %v", err)
 			}
-			pipelineInfos, err := client.ListPipeline()
+			pipelineInfos, err := client.ListPipelineByAnnotation(selector)
 			if err != nil {
 				return err
 			}
@@ -668,6 +1046,7 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 	}
 	rawFlag(listPipeline)
 	listPipeline.Flags().BoolVarP(&spec, "spec", "s", false, "Output create-pipeline compatibility specs.")
+	listPipeline.Flags().StringVarP(&selector, "selector", "l", "", "list only pipelines whose annotations match this selector, e.g. 'owner=data-team' or 'environment in (prod,staging)'")
 
 	var all bool
 	var force bool
@@ -737,7 +1116,72 @@ All jobs created by a pipeline will create commits in the pipeline's repo.
 		}),
 	}
 
+	var runParallelism int64
+	runPipeline := &cobra.Command{
+		Use:   "run-pipeline pipeline-name",
+		Short: "Run a pipeline's next job with more workers.",
+		Long: `Run a pipeline's next job with more workers.
+
+The pipeline's next job runs with the number of workers set by --parallelism,
+instead of the number set by its parallelism_spec, which helps it get through a
+one-off backlog without changing the pipeline's spec. The pipeline's workers
+are scaled up for the job, and back down once it's finished. If the pipeline
+has a job that's waiting to start (e.g. for its inputs), that job is the one
+that runs with more workers. Jobs never run with fewer workers than the
+pipeline has, so --parallelism must be more than that.
+
+Examples:
+
+` + codestart + `# run the next job of pipeline foo with 20 workers
+$ pachctl run-pipeline foo --parallelism 20` + codeend,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			job, err := client.RunPipeline(args[0], runParallelism)
+			if err != nil {
+				cmdutil.ErrorAndExit("error from RunPipeline: %s", err.Error())
+			}
+			if job != nil {
+				fmt.Printf("job %s will run with %d workers\n", job.ID, runParallelism)
+			} else {
+				fmt.Printf("the next job of pipeline %s will run with %d workers\n", args[0], runParallelism)
+			}
+			return nil
+		}),
+	}
+	runPipeline.Flags().Int64Var(&runParallelism, "parallelism", 0, "The number of workers that the job runs with.")
+
+	var promoteInputNames []string
+	promoteInputs := &cobra.Command{
+		Use:   "promote-inputs pipeline-name",
+		Short: "Process the commits made to a pipeline's deferred inputs.",
+		Long: `Process the commits made to a pipeline's deferred inputs.
+
+The trigger branch of each of the pipeline's deferred inputs is moved to the
+head of the input's branch, which starts a job that processes the inputs as of
+those commits.
+
+Examples:
+
+` + codestart + `# process the commits made to the deferred inputs of pipeline foo
+$ pachctl promote-inputs foo
+
+# only process the commits made to foo's deferred input named bar
+$ pachctl promote-inputs foo --input bar` + codeend,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine(metrics, "user")
+			if err != nil {
+				return err
+			}
+			return client.PromoteInputs(args[0], promoteInputNames...)
+		}),
+	}
+	promoteInputs.Flags().StringSliceVar(&promoteInputNames, "input", nil, "The name of a deferred input to promote; may be repeated. By default, all of the pipeline's deferred inputs are promoted.")
+
 	var memory string
+	var gcConcurrency int64
 	garbageCollect := &cobra.Command{
 		Use:   "garbage-collect",
 		Short: "Garbage collect unused data.",
@@ -763,6 +1207,12 @@ have; at around 10M objects it starts to become likely with the default values.
 To lower Pachyderm's error rate and make garbage-collection more comprehensive,
 you can increase the amount of memory used for the bloom filters with the
 --memory flag. The default value is 10MB.
+
+Garbage collection lists and deletes objects with several goroutines at once,
+each sweeping a different range of the object store. On large clusters, you
+can speed up garbage collection by raising --concurrency, at the cost of more
+load on the object store (and more memory, as up to --concurrency hash trees
+are read at once; 100 if --concurrency isn't set).
 `,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
 			client, err := pachdclient.NewOnUserMachine(metrics, "user")
@@ -773,32 +1223,43 @@ you can increase the amount of memory used for the bloom filters with the
 			if err != nil {
 				return err
 			}
-			return client.GarbageCollect(memoryBytes)
+			return client.GarbageCollectParallel(memoryBytes, gcConcurrency)
 		}),
 	}
 	garbageCollect.Flags().StringVarP(&memory, "memory", "m", "0", "The amount of memory to use during garbage collection. Default is 10MB.")
+	garbageCollect.Flags().Int64Var(&gcConcurrency, "concurrency", 0, "The number of goroutines to use during garbage collection. Default is 8 for deleting objects and 100 for reading hash trees.")
 
 	var result []*cobra.Command
 	result = append(result, job)
 	result = append(result, inspectJob)
+	result = append(result, watchJob)
 	result = append(result, listJob)
 	result = append(result, flushJob)
 	result = append(result, deleteJob)
 	result = append(result, stopJob)
+	result = append(result, stopPipelineJobs)
+	result = append(result, rerunJob)
 	result = append(result, restartDatum)
 	result = append(result, listDatum)
 	result = append(result, inspectDatum)
+	result = append(result, previewTrigger)
 	result = append(result, getLogs)
 	result = append(result, pipeline)
 	result = append(result, createPipeline)
 	result = append(result, updatePipeline)
+	result = append(result, run)
 	result = append(result, inspectPipeline)
+	result = append(result, inspectPipelineVersion)
+	result = append(result, watchWorkers)
 	result = append(result, extractPipeline)
+	result = append(result, diffPipeline)
 	result = append(result, editPipeline)
 	result = append(result, listPipeline)
 	result = append(result, deletePipeline)
 	result = append(result, startPipeline)
 	result = append(result, stopPipeline)
+	result = append(result, runPipeline)
+	result = append(result, promoteInputs)
 	result = append(result, garbageCollect)
 	return result, nil
 }
@@ -828,6 +1289,12 @@ func (arr ByCreationTime) Less(i, j int) bool {
 // pushImage pushes an image as registry/user/image. Registry and user can be
 // left empty.
 func pushImage(registry string, username string, password string, image string) (string, error) {
+	if ppsutil.ImageHasDigest(image) {
+		// Pushing the image under a new tag would lose its digest, and an image
+		// pinned by digest must already be in a registry
+		fmt.Printf("Not pushing %s, as it's pinned by digest.\n", image)
+		return image, nil
+	}
 	client, err := docker.NewClientFromEnv()
 	if err != nil {
 		return "", err
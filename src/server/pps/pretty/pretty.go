@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"text/template"
+	"time"
 
 	"github.com/docker/go-units"
 	"github.com/fatih/color"
@@ -26,6 +28,8 @@ const (
 	JobHeader = "ID\tOUTPUT COMMIT\tSTARTED\tDURATION\tRESTART\tPROGRESS\tDL\tUL\tSTATE\t\n"
 	// DatumHeader is the header for datums
 	DatumHeader = "ID\tSTATUS\tTIME\t\n"
+	// TriggeredPipelineHeader is the header for triggered pipelines
+	TriggeredPipelineHeader = "PIPELINE\tTRIGGERED BY\tESTIMATED DATUMS\tSTOPPED\t\n"
 )
 
 // PrintJobHeader prints a job header.
@@ -91,20 +95,113 @@ func PrintWorkerStatus(w io.Writer, workerStatus *ppsclient.WorkerStatus) {
 	fmt.Fprintf(w, "%d\t\n", workerStatus.QueueSize)
 }
 
+// PrintWorkerEvent pretty prints a worker joining or leaving a pipeline.
+func PrintWorkerEvent(w io.Writer, event *ppsclient.WorkerEvent) {
+	switch event.Type {
+	case ppsclient.WorkerEventType_WORKER_JOINED:
+		fmt.Fprintf(w, "joined: %s (%s)\n", event.Address, event.RcName)
+	case ppsclient.WorkerEventType_WORKER_LEFT:
+		fmt.Fprintf(w, "left:   %s (%s)\n", event.Address, event.RcName)
+	}
+}
+
+// PrintJobEvent pretty prints the progress of a job.
+func PrintJobEvent(w io.Writer, event *ppsclient.JobEvent) {
+	fmt.Fprintf(w, "%s: %d + %d / %d (%d failed, %d active workers)", jobState(event.State),
+		event.DataProcessed, event.DataSkipped, event.DataTotal, event.DataFailed, event.ActiveWorkers)
+	if event.Reason != "" {
+		fmt.Fprintf(w, ": %s", event.Reason)
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintDetailedPipelineVersionInfo pretty-prints the versions of a pipeline
+// that its workers are running.
+func PrintDetailedPipelineVersionInfo(versionInfo *ppsclient.PipelineVersionInfo) error {
+	fmt.Printf("Pipeline: %s\n", versionInfo.Pipeline.Name)
+	fmt.Printf("Version: %d\n", versionInfo.Version)
+	fmt.Printf("Spec Commit: %s\n", specCommitID(versionInfo.SpecCommit))
+	if versionInfo.Skew {
+		fmt.Printf("Skew: %s\n", color.New(color.FgYellow).SprintFunc()("workers are running an old version"))
+	} else {
+		fmt.Printf("Skew: none\n")
+	}
+	fmt.Printf("Workers:\n")
+	writer := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprint(writer, "WORKER\tVERSION\tSPEC COMMIT\tERROR\t\n")
+	for _, workerStatus := range versionInfo.Workers {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\t\n", workerStatus.WorkerID, workerStatus.Version, specCommitID(workerStatus.SpecCommit), workerStatus.Error)
+	}
+	return writer.Flush()
+}
+
+// PrintJobHistory pretty-prints the aggregated recent jobs of a pipeline.
+func PrintJobHistory(history *ppsclient.JobHistory) error {
+	fmt.Printf("Pipeline: %s\n", history.Pipeline.Name)
+	fmt.Printf("Jobs: %d", history.Jobs)
+	if history.Since != nil {
+		fmt.Printf(" (started between %s and %s)", pretty.Ago(history.Since), pretty.Ago(history.Until))
+	}
+	fmt.Println()
+	fmt.Printf("Succeeded: %d\n", history.Succeeded)
+	fmt.Printf("Failed: %d\n", history.Failed)
+	fmt.Printf("Killed: %d\n", history.Killed)
+	fmt.Printf("Running: %d\n", history.Running)
+	fmt.Printf("Failure Rate: %.1f%%\n", history.FailureRate*100)
+	writer := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprint(writer, "\tMEAN\tSTDDEV\t5TH PERCENTILE\t95TH PERCENTILE\t\n")
+	seconds := func(s float64) string {
+		return (time.Duration(s * float64(time.Second))).Round(time.Millisecond).String()
+	}
+	number := func(n float64) string {
+		return fmt.Sprintf("%.2f", n)
+	}
+	for _, row := range []struct {
+		name      string
+		aggregate *ppsclient.Aggregate
+		format    func(float64) string
+	}{
+		{"Duration", history.Duration, seconds},
+		{"Datums/Second", history.DatumsPerSecond, number},
+		{"Datums Processed", history.DataProcessed, number},
+	} {
+		if row.aggregate == nil || row.aggregate.Count == 0 {
+			fmt.Fprintf(writer, "%s\t-\t-\t-\t-\t\n", row.name)
+			continue
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\n", row.name, row.format(row.aggregate.Mean),
+			row.format(row.aggregate.Stddev), row.format(row.aggregate.FifthPercentile),
+			row.format(row.aggregate.NinetyFifthPercentile))
+	}
+	return writer.Flush()
+}
+
+func specCommitID(commit *pfsclient.Commit) string {
+	if commit == nil {
+		return "-"
+	}
+	return commit.ID
+}
+
 // PrintDetailedJobInfo pretty-prints detailed job info.
 func PrintDetailedJobInfo(jobInfo *ppsclient.JobInfo) error {
 	template, err := template.New("JobInfo").Funcs(funcMap).Parse(
 		`ID: {{.Job.ID}} {{if .Pipeline}}
 Pipeline: {{.Pipeline.Name}} {{end}} {{if .ParentJob}}
-Parent: {{.ParentJob.ID}} {{end}}
+Parent: {{.ParentJob.ID}} {{end}} {{if .RerunOf}}
+Rerun Of: {{.RerunOf.ID}} {{end}}
 Started: {{prettyAgo .Started}} {{if .Finished}}
 Duration: {{prettyTimeDifference .Started .Finished}} {{end}}
 State: {{jobState .State}}
 Reason: {{.Reason}}
-Processed: {{.DataProcessed}}
+{{if .OverQuota}}Over Quota: true
+{{end}}{{if .TraceID}}Trace ID: {{.TraceID}}
+{{end}}Processed: {{.DataProcessed}}
 Failed: {{.DataFailed}}
 Skipped: {{.DataSkipped}}
-Total: {{.DataTotal}}
+{{if .QuarantinedDatums}}Quarantined:{{range .QuarantinedDatums}} {{.}}{{end}}
+{{end}}{{if .DeadLetteredDatums}}Dead Lettered:{{range .DeadLetteredDatums}} {{.}}{{end}}
+{{end}}Total: {{.DataTotal}}
 Data Downloaded: {{prettySize .Stats.DownloadBytes}}
 Data Uploaded: {{prettySize .Stats.UploadBytes}}
 Download Time: {{prettyDuration .Stats.DownloadTime}}
@@ -146,7 +243,8 @@ Egress: {{.Egress.URL}} {{end}}
 func PrintDetailedPipelineInfo(pipelineInfo *ppsclient.PipelineInfo) error {
 	template, err := template.New("PipelineInfo").Funcs(funcMap).Parse(
 		`Name: {{.Pipeline.Name}}{{if .Description}}
-Description: {{.Description}}{{end}}
+Description: {{.Description}}{{end}}{{ if .Annotations }}
+Annotations: {{annotations .Annotations}}{{end}}
 Created: {{prettyAgo .CreatedAt}}
 State: {{pipelineState .State}}
 Stopped: {{ .Stopped }}
@@ -161,11 +259,36 @@ Parallelism Spec: {{.ParallelismSpec}}
 	GPU: {{ .ResourceLimits.Gpu }} {{end}}
 Datum Timeout: {{.DatumTimeout}}
 Job Timeout: {{.JobTimeout}}
-Input:
+{{ if .StatsRetention }}Stats Retention: {{.StatsRetention}}
+{{end}}{{ with .LogRetention }}Log Retention:{{ if .MaxAge }} max age {{prettyDuration .MaxAge}}{{end}}{{ if .MaxBytes }} max bytes {{.MaxBytes}}{{end}}
+{{end}}{{ if .WorkerVersion }}Worker Version: {{.WorkerVersion}}
+{{end}}{{ with .OutputPartition }}Output Partition: {{.Pattern}}{{ if .Input }} on input {{.Input}}{{end}}{{ if .Unmatched }} (unmatched: {{.Unmatched}}){{end}}
+{{end}}{{ with .IdleScaleDown }}Idle Scale Down: to {{.MinWorkers}} workers after {{prettyDuration .Timeout}}
+{{end}}{{ with .OutputValidation }}Output Validation: {{.Cmd}}{{ if .Timeout }} with timeout {{prettyDuration .Timeout}}{{end}}
+{{end}}{{ with .OutputPermissions }}Output Permissions:{{ if .Mode }} mode {{.Mode}}{{end}}{{ with .Owner }} owner {{.Uid}}:{{.Gid}}{{end}}
+{{end}}{{ with .DisruptionBudget }}Disruption Budget: {{ if .MinAvailable }}min available {{.MinAvailable}}{{else}}max unavailable {{.MaxUnavailable}}{{end}}
+{{end}}{{ if .DatumPriority }}Datum Priority:{{range .DatumPriority}} {{.Pattern}}={{.Priority}}{{end}}
+{{end}}{{ if .MaxDatumCrashes }}Max Datum Crashes: {{.MaxDatumCrashes}}
+{{end}}{{ if gt .DatumsPerWorker 1 }}Datums Per Worker: {{.DatumsPerWorker}}
+{{end}}{{ if gt .MaxDatums 0 }}Max Datums: {{.MaxDatums}}
+{{end}}{{ if gt .MaxOutputBytes 0 }}Max Output Bytes: {{.MaxOutputBytes}}
+{{end}}{{ if .SkipUnchangedOutput }}Skip Unchanged Output: true
+{{end}}{{ if .OrderedMerge }}Ordered Merge: true
+{{end}}{{ with .OOMRetry }}OOM Retry: {{.Memory}}{{ if .MaxMemory }}, up to {{.MaxMemory}}{{end}}
+{{end}}{{ if .EmptyInput }}Empty Input: {{.EmptyInput}}
+{{end}}{{ with .Preemption }}Preemption: drain on {{ if .Notice }}{{.Notice}}{{else}}signal{{end}} notice{{ if .GracePeriod }}, grace period {{prettyDuration .GracePeriod}}{{end}}
+{{end}}{{ with .HealthCheck }}Health Check: {{.Cmd}}{{ if .Interval }} every {{prettyDuration .Interval}}{{end}}
+{{end}}{{ with .Debounce }}Debounce: quiet period {{prettyDuration .QuietPeriod}}{{ if .MaxWait }}, max wait {{prettyDuration .MaxWait}}{{end}}
+{{end}}{{ with .WorkloadIdentity }}Workload Identity:{{ if .ServiceAccount }} service account {{.ServiceAccount}}{{end}}{{ if .GCPServiceAccount }} GCP {{.GCPServiceAccount}}{{end}}{{ if .AWSRoleARN }} AWS {{.AWSRoleARN}}{{end}}
+{{end}}{{ if .InfraFailureRetries }}Infra Failure Retries: {{.InfraFailures}}/{{.InfraFailureRetries}}
+{{end}}{{ with .Finalizer }}Finalizer: {{.Cmd}}
+{{end}}{{ with .OutputSchema }}Required Output:{{range .Required}} {{.Pattern}}{{end}}
+{{end}}Input:
 {{pipelineInput .}}
 {{ if .GithookURL }}Githook URL: {{.GithookURL}} {{end}}
 Output Branch: {{.OutputBranch}}
-Transform:
+{{ if .DeadLetterBranch }}Dead Letter Branch: {{.DeadLetterBranch}}
+{{end}}Transform:
 {{prettyTransform .Transform}}
 {{ if .Egress }}Egress: {{.Egress.URL}} {{end}}
 {{if .RecentError}} Recent Error: {{.RecentError}} {{end}}
@@ -198,6 +321,15 @@ func PrintDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	fmt.Fprintf(w, "%s\t%s\t%s\n", datumInfo.Datum.ID, datumState(datumInfo.State), totalTime)
 }
 
+// PrintTriggeredPipeline pretty-prints a pipeline that a commit would trigger
+func PrintTriggeredPipeline(w io.Writer, triggered *ppsclient.TriggeredPipeline) {
+	triggeredBy := "commit"
+	if !triggered.Direct {
+		triggeredBy = "pipeline"
+	}
+	fmt.Fprintf(w, "%s\t%s\t%d\t%t\t\n", triggered.Pipeline.Name, triggeredBy, triggered.EstimatedDatums, triggered.Stopped)
+}
+
 // PrintDetailedDatumInfo pretty-prints detailed info about a datum
 func PrintDetailedDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	fmt.Fprintf(w, "ID\t%s\n", datumInfo.Datum.ID)
@@ -355,6 +487,15 @@ func prettyTransform(transform *ppsclient.Transform) (string, error) {
 	return pretty.UnescapeHTML(string(result)), nil
 }
 
+func annotations(annotations map[string]string) string {
+	var pairs []string
+	for key, value := range annotations {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
 // ShorthandInput renders a pps.Input as a short, readable string
 func ShorthandInput(input *ppsclient.Input) string {
 	switch {
@@ -376,6 +517,8 @@ func ShorthandInput(input *ppsclient.Input) string {
 		return "(" + strings.Join(subInput, " ∪ ") + ")"
 	case input.Cron != nil:
 		return fmt.Sprintf("%s:%s", input.Cron.Name, input.Cron.Spec)
+	case input.Object != nil:
+		return fmt.Sprintf("%s:%s", input.Object.URL, input.Object.Glob)
 	}
 	return ""
 }
@@ -393,4 +536,5 @@ var funcMap = template.FuncMap{
 	"prettySize":           pretty.Size,
 	"jobCounts":            jobCounts,
 	"prettyTransform":      prettyTransform,
+	"annotations":          annotations,
 }
@@ -20,15 +20,21 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/client/version"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/deploy/assets"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 	"github.com/pachyderm/pachyderm/src/server/pkg/metrics"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/secrets"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
 	ppsserver "github.com/pachyderm/pachyderm/src/server/pps"
@@ -39,6 +45,7 @@ import (
 
 	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	logrus "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -49,6 +56,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	kube "k8s.io/client-go/kubernetes"
 )
 
@@ -65,6 +74,13 @@ var (
 	zeroVal         = int64(0)
 	suite           = "pachyderm"
 	defaultGCMemory = 20 * 1024 * 1024 // 20 MB
+	// defaultGCConcurrency is the number of goroutines that garbage
+	// collection uses to sweep the object store if the request doesn't set it
+	defaultGCConcurrency = 8
+	// defaultGCMarkConcurrency is the number of hash trees that garbage
+	// collection reads at once, while finding live objects, if the request
+	// doesn't set a concurrency
+	defaultGCMarkConcurrency = 100
 )
 
 func newErrJobNotFound(job string) error {
@@ -121,8 +137,52 @@ type apiServer struct {
 	iamRole               string
 	imagePullSecret       string
 	noExposeDockerSocket  bool
-	reporter              *metrics.Reporter
-	monitorCancels        map[string]func()
+	workerBudget          int64
+	requireImageDigests   bool
+	maxJobDatums          int64
+	maxJobOutputBytes     int64
+	workerGracePeriod     int64
+	logRetentionMaxAge    string
+	logRetentionMaxBytes  int64
+	// pipelineSpecConfigMapSelector, if set, selects the ConfigMaps that
+	// pipeline specs are read from (see watchPipelineSpecConfigMaps)
+	pipelineSpecConfigMapSelector string
+	// pipelineSpecConfigMapNames, if non-empty, holds the names of the only
+	// selected ConfigMaps that pipeline specs are read from
+	pipelineSpecConfigMapNames map[string]bool
+	// pipelineSpecConfigMapToken is the auth token that the pipelines in
+	// ConfigMaps are created with (see applyPipelineSpec)
+	pipelineSpecConfigMapToken string
+	// workerRcGCGracePeriod, if positive, is how long worker RCs must be
+	// stale before they're deleted (see collectWorkerRcs)
+	workerRcGCGracePeriod time.Duration
+	// pipelineInputCheckInterval, if positive, is how often pipelines' input
+	// repos are checked (see checkPipelineInputs)
+	pipelineInputCheckInterval time.Duration
+	// workerCPUPerWeight, if nonzero, is the CPU that workers request per
+	// unit of their pipeline's scheduling weight (see weightedCPURequest)
+	workerCPUPerWeight resource.Quantity
+	// workerMaxWeightedCPU, if nonzero, is the most CPU that workers request
+	// for their pipeline's scheduling weight (see weightedCPURequest)
+	workerMaxWeightedCPU resource.Quantity
+	// maxConcurrentJobs, if positive, is the most jobs that may run at once
+	// across all pipelines (see monitorJobSlots)
+	maxConcurrentJobs int64
+	reporter          *metrics.Reporter
+	monitorCancels    map[string]func()
+	// idlePipelines holds the pipelines whose workers have been scaled down
+	// because they're idle (see monitorIdleWorkers)
+	idleMu        sync.Mutex
+	idlePipelines map[string]bool
+	// parallelismBoosts holds the number of workers of pipelines that have an
+	// unfinished job that runs with more workers than their parallelism_spec
+	// sets (see monitorParallelismBoosts)
+	boostMu           sync.Mutex
+	parallelismBoosts map[string]int64
+	// infraRestarts holds the worker pods that are waiting to be restarted
+	// after an infrastructure failure (see retryInfraFailure)
+	infraRestartMu sync.Mutex
+	infraRestarts  map[string]bool
 	// collections
 	pipelines col.Collection
 	jobs      col.Collection
@@ -175,10 +235,28 @@ func validateNames(names map[string]bool, input *pps.Input) error {
 			return fmt.Errorf(`name "%s" was used more than once`, input.Git.Name)
 		}
 		names[input.Git.Name] = true
+	case input.Object != nil:
+		if names[input.Object.Name] {
+			return fmt.Errorf(`name "%s" was used more than once`, input.Object.Name)
+		}
+		names[input.Object.Name] = true
 	}
 	return nil
 }
 
+// missingGlobErr returns the error for the input 'name', from the repo 'repo',
+// not specifying a glob. The error explains what the glob is for, and
+// suggests the repo's recommended glob if it has one.
+func missingGlobErr(pachClient *client.APIClient, name string, repo string) error {
+	msg := fmt.Sprintf("input %q must specify a glob, which determines how the "+
+		"files in %s are divided into datums (e.g. \"/*\" processes each top-level "+
+		"file or directory separately, and \"/\" processes all of them together)", name, repo)
+	if repoInfo, err := pachClient.InspectRepo(repo); err == nil && repoInfo.RecommendedGlob != "" {
+		msg += fmt.Sprintf("; the recommended glob for %s is %q", repo, repoInfo.RecommendedGlob)
+	}
+	return goerr.New(msg)
+}
+
 func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName string, input *pps.Input, job bool) error {
 	if err := validateNames(make(map[string]bool), input); err != nil {
 		return err
@@ -200,7 +278,7 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 				case input.Atom.Branch == "" && !job:
 					return fmt.Errorf("input must specify a branch")
 				case len(input.Atom.Glob) == 0:
-					return fmt.Errorf("input must specify a glob")
+					return missingGlobErr(pachClient, input.Atom.Name, input.Atom.Repo)
 				}
 				// Note that input.Atom.Commit is empty if a) this is a job b) one of
 				// the job pipeline's input branches has no commits yet
@@ -229,7 +307,22 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 				case input.Pfs.Branch == "" && !job:
 					return fmt.Errorf("input must specify a branch")
 				case len(input.Pfs.Glob) == 0:
-					return fmt.Errorf("input must specify a glob")
+					return missingGlobErr(pachClient, input.Pfs.Name, input.Pfs.Repo)
+				}
+				if input.Pfs.Split != nil {
+					if err := validateSplit(input.Pfs); err != nil {
+						return err
+					}
+				}
+				if input.Pfs.Deferred != nil {
+					if input.Pfs.Deferred.Branch == input.Pfs.Branch {
+						return fmt.Errorf("the trigger branch of a deferred input must differ from its branch")
+					}
+					if input.Pfs.Deferred.ReleaseSchedule != "" {
+						if _, err := cron.ParseStandard(input.Pfs.Deferred.ReleaseSchedule); err != nil {
+							return fmt.Errorf("error parsing release_schedule: %v", err)
+						}
+					}
 				}
 				// Note that input.Pfs.Commit is empty if a) this is a job b) one of
 				// the job pipeline's input branches has no commits yet
@@ -275,6 +368,15 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 					return err
 				}
 			}
+			if input.Object != nil {
+				if set {
+					return fmt.Errorf("multiple input types set")
+				}
+				set = true
+				if err := validateObjectInput(input.Object); err != nil {
+					return err
+				}
+			}
 			if !set {
 				return fmt.Errorf("no input set")
 			}
@@ -286,7 +388,87 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 	return result
 }
 
+func validateObjectInput(input *pps.ObjectInput) error {
+	switch {
+	case input.Name == "":
+		return fmt.Errorf("input must specify a name")
+	case input.Name == "out":
+		return fmt.Errorf("input cannot be named \"out\", as pachyderm " +
+			"already creates /pfs/out to collect job output")
+	case input.Glob == "":
+		return fmt.Errorf("input %q must specify a glob, which determines how "+
+			"the objects in %s are divided into datums", input.Name, input.URL)
+	}
+	url, err := obj.ParseURL(input.URL)
+	if err != nil {
+		return err
+	}
+	if url.Store != "s3" {
+		return fmt.Errorf("object input %q must read from an s3 bucket (e.g. \"s3://bucket/path\")", input.Name)
+	}
+	return nil
+}
+
+func validateSplit(input *pps.PFSInput) error {
+	switch {
+	case input.Split.Records < 0:
+		return fmt.Errorf("split records must be non-negative")
+	case input.Split.Bytes < 0:
+		return fmt.Errorf("split bytes must be non-negative")
+	case input.Split.Records > 0 && input.Split.Bytes > 0:
+		return fmt.Errorf("split may not set both records and bytes")
+	case input.Lazy:
+		return fmt.Errorf("a split input can't be lazy")
+	case input.EmptyFiles:
+		return fmt.Errorf("a split input can't set empty_files")
+	}
+	return nil
+}
+
 func validateTransform(transform *pps.Transform) error {
+	for _, secret := range transform.GetSecrets() {
+		if secret.Provider == "" {
+			continue
+		}
+		if !secrets.IsRegistered(secret.Provider) {
+			return fmt.Errorf("unknown secret provider %q", secret.Provider)
+		}
+		if secret.Path == "" {
+			return fmt.Errorf("secrets from provider %q must set path", secret.Provider)
+		}
+		if secret.EnvVar == "" && secret.MountPath == "" {
+			return fmt.Errorf("secret %s must set env_var or mount_path, as otherwise it's never used", secret.Path)
+		}
+		if secret.EnvVar != "" && secret.Key == "" {
+			return fmt.Errorf("secret %s must set key in order to set env_var", secret.Path)
+		}
+	}
+	switch v1.PullPolicy(transform.GetImagePullPolicy()) {
+	case "", v1.PullAlways, v1.PullIfNotPresent, v1.PullNever:
+	default:
+		return fmt.Errorf("image_pull_policy must be one of %q, %q or %q", v1.PullAlways, v1.PullIfNotPresent, v1.PullNever)
+	}
+	return nil
+}
+
+// validateImagePullSecrets checks that each of the image pull secrets that
+// 'transform' references exists in k8s, since otherwise the pipeline's
+// workers would fail to start. pachd's RBAC role only lets it read its own
+// storage secret, so if k8s forbids the lookup the secret is assumed to exist.
+func (a *apiServer) validateImagePullSecrets(transform *pps.Transform) error {
+	for _, secret := range transform.GetImagePullSecrets() {
+		if _, err := a.kubeClient.CoreV1().Secrets(a.namespace).Get(secret, metav1.GetOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("image pull secret %q not found", secret)
+			}
+			if errors.IsForbidden(err) {
+				return fmt.Errorf("could not verify that image pull secret %q exists, "+
+					"as pachd's role lacks the RBAC rule that allows \"get\" on "+
+					"\"secrets\" in namespace %q: %v", secret, a.namespace, err)
+			}
+			return fmt.Errorf("could not read image pull secret %q: %v", secret, err)
+		}
+	}
 	return nil
 }
 
@@ -393,6 +575,8 @@ const (
 	pipelineOpListDatum
 	// pipelineOpGetLogs is required for GetLogs
 	pipelineOpGetLogs
+	// pipelineOpInspectJobHistory is required for InspectJobHistory
+	pipelineOpInspectJobHistory
 	// pipelineOpUpdate is required for UpdatePipeline
 	pipelineOpUpdate
 	// pipelineOpUpdate is required for DeletePipeline
@@ -468,7 +652,7 @@ func (a *apiServer) authorizePipelineOp(pachClient *client.APIClient, operation
 		} else if !isNotFoundErr(err) {
 			return err
 		}
-	case pipelineOpListDatum, pipelineOpGetLogs:
+	case pipelineOpListDatum, pipelineOpGetLogs, pipelineOpInspectJobHistory:
 		required = auth.Scope_READER
 	case pipelineOpUpdate:
 		required = auth.Scope_WRITER
@@ -512,6 +696,16 @@ func (a *apiServer) CreateJob(ctx context.Context, request *pps.CreateJobRequest
 			OutputCommit: request.OutputCommit,
 			Pipeline:     request.Pipeline,
 			Stats:        &pps.ProcessStats{},
+			TraceID:      uuid.NewWithoutDashes(),
+		}
+		// The job runs with the parallelism set by RunPipeline, if any
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		if err := a.pipelines.ReadWrite(stm).Update(request.Pipeline.Name, pipelinePtr, func() error {
+			jobPtr.Parallelism = pipelinePtr.NextJobParallelism
+			pipelinePtr.NextJobParallelism = 0
+			return nil
+		}); err != nil {
+			return err
 		}
 		return ppsutil.UpdateJobState(a.pipelines.ReadWrite(stm), a.jobs.ReadWrite(stm), jobPtr, pps.JobState_JOB_STARTING, "")
 	})
@@ -615,6 +809,119 @@ func (a *apiServer) InspectJob(ctx context.Context, request *pps.InspectJobReque
 	return jobInfo, nil
 }
 
+// watchJobStatusInterval is how often WatchJob checks the statuses of a
+// running job's workers
+const watchJobStatusInterval = 2 * time.Second
+
+// newJobEvent returns a JobEvent with the progress of the job 'jobPtr', whose
+// pipeline's workers have the statuses 'statuses'
+func newJobEvent(jobPtr *pps.EtcdJobInfo, statuses []*pps.WorkerStatus) *pps.JobEvent {
+	event := &pps.JobEvent{
+		Job:           jobPtr.Job,
+		State:         jobPtr.State,
+		Reason:        jobPtr.Reason,
+		DataTotal:     jobPtr.DataTotal,
+		DataProcessed: jobPtr.DataProcessed,
+		DataSkipped:   jobPtr.DataSkipped,
+		DataFailed:    jobPtr.DataFailed,
+	}
+	for _, status := range statuses {
+		if status.JobID == jobPtr.Job.ID {
+			event.ActiveWorkers++
+		}
+	}
+	return event
+}
+
+func (a *apiServer) WatchJob(request *pps.WatchJobRequest, resp pps.API_WatchJobServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	sent := 0
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("stream containing %d JobEvents", sent), retErr, time.Since(start))
+	}(time.Now())
+	if request.Job == nil {
+		return fmt.Errorf("must specify a job")
+	}
+	ctx := resp.Context()
+	pachClient := a.getPachClient().WithCtx(ctx)
+	if err := checkLoggedIn(pachClient); err != nil {
+		return err
+	}
+	// Make sure the job exists, and find the RC of the workers processing it
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(ctx).Get(request.Job.ID, jobPtr); err != nil {
+		return err
+	}
+	jobInfo, err := a.jobInfoFromPtr(pachClient, jobPtr)
+	if err != nil {
+		return err
+	}
+	rcName := ppsutil.PipelineRcName(jobInfo.Pipeline.Name, jobInfo.PipelineVersion)
+	watcher, err := a.jobs.ReadOnly(ctx).WatchOne(request.Job.ID)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	ticker := time.NewTicker(watchJobStatusInterval)
+	defer ticker.Stop()
+
+	var last *pps.JobEvent
+	var statuses []*pps.WorkerStatus
+	// update sends an event if the job's progress has changed, and returns
+	// true once the job has finished
+	update := func(refreshStatuses bool) (bool, error) {
+		if jobPtr.State != pps.JobState_JOB_RUNNING {
+			statuses = nil
+		} else if refreshStatuses {
+			if s, err := workerpkg.Status(ctx, rcName, a.etcdClient, a.etcdPrefix); err != nil {
+				logrus.Errorf("could not get the statuses of the workers of job %s: %v", request.Job.ID, err)
+			} else {
+				statuses = s
+			}
+		}
+		event := newJobEvent(jobPtr, statuses)
+		if last == nil || !proto.Equal(event, last) {
+			if err := resp.Send(event); err != nil {
+				return false, err
+			}
+			sent++
+			last = event
+		}
+		return ppsutil.IsTerminal(jobPtr.State), nil
+	}
+	if done, err := update(true); err != nil || done {
+		return err
+	}
+	for {
+		select {
+		case ev, ok := <-watcher.Watch():
+			if !ok {
+				return fmt.Errorf("the stream for job updates closed unexpectedly")
+			}
+			switch ev.Type {
+			case watch.EventError:
+				return ev.Err
+			case watch.EventDelete:
+				return fmt.Errorf("job %s was deleted", request.Job.ID)
+			case watch.EventPut:
+				var jobID string
+				if err := ev.Unmarshal(&jobID, jobPtr); err != nil {
+					return err
+				}
+				if done, err := update(false); err != nil || done {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if done, err := update(true); err != nil || done {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 // listJob is the internal implementation of ListJob shared between ListJob and
 // ListJobStream. When ListJob is removed, this should be inlined into
 // ListJobStream.
@@ -728,6 +1035,12 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 		Reason:        jobPtr.Reason,
 		Started:       jobPtr.Started,
 		Finished:      jobPtr.Finished,
+		TraceID:       jobPtr.TraceID,
+		RerunOf:       jobPtr.RerunOf,
+
+		QuarantinedDatums:  jobPtr.QuarantinedDatums,
+		DeadLetteredDatums: jobPtr.DeadLetteredDatums,
+		OverQuota:          jobPtr.OverQuota,
 	}
 	commitInfo, err := pachClient.InspectCommit(jobPtr.OutputCommit.Repo.Name, jobPtr.OutputCommit.ID)
 	if err != nil {
@@ -739,16 +1052,9 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 		}
 		return nil, err
 	}
-	var specCommit *pfs.Commit
-	for i, provCommit := range commitInfo.Provenance {
-		provBranch := commitInfo.BranchProvenance[i]
-		if provBranch.Repo.Name == ppsconsts.SpecRepo && provBranch.Name == jobPtr.Pipeline.Name {
-			specCommit = provCommit
-			break
-		}
-	}
-	if specCommit == nil {
-		return nil, fmt.Errorf("couldn't find spec commit for job %s, (this is likely a bug)", jobPtr.Job.ID)
+	specCommit, err := jobSpecCommit(jobPtr, commitInfo)
+	if err != nil {
+		return nil, err
 	}
 	pipelinePtr := &pps.EtcdPipelineInfo{}
 	if err := a.pipelines.ReadOnly(pachClient.Ctx()).Get(jobPtr.Pipeline.Name, pipelinePtr); err != nil {
@@ -765,6 +1071,18 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 	result.Transform = pipelineInfo.Transform
 	result.PipelineVersion = pipelineInfo.Version
 	result.ParallelismSpec = pipelineInfo.ParallelismSpec
+	if jobPtr.Parallelism > 0 {
+		// The job runs with more workers than its pipeline has, unless the
+		// pipeline has since been updated to have at least as many (see
+		// RunPipeline)
+		workers, err := ppsutil.GetExpectedNumWorkers(a.kubeClient, pipelineInfo.ParallelismSpec)
+		if err != nil {
+			return nil, err
+		}
+		if jobPtr.Parallelism > int64(workers) {
+			result.ParallelismSpec = &pps.ParallelismSpec{Constant: uint64(jobPtr.Parallelism)}
+		}
+	}
 	result.Egress = pipelineInfo.Egress
 	result.Service = pipelineInfo.Service
 	result.OutputRepo = &pfs.Repo{Name: jobPtr.Pipeline.Name}
@@ -772,6 +1090,9 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 	result.ResourceRequests = pipelineInfo.ResourceRequests
 	result.ResourceLimits = pipelineInfo.ResourceLimits
 	result.Input = ppsutil.JobInput(pipelineInfo, commitInfo)
+	if jobPtr.Input != nil {
+		result.Input = jobPtr.Input
+	}
 	result.EnableStats = pipelineInfo.EnableStats
 	result.Salt = pipelineInfo.Salt
 	result.Batch = pipelineInfo.Batch
@@ -784,6 +1105,22 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 	return result, nil
 }
 
+// jobSpecCommit returns the commit in the spec repo that holds the version of
+// the pipeline that the job 'jobPtr' runs. It's the provenance of the job's
+// output commit, 'commitInfo', except for reruns, which record it themselves.
+func jobSpecCommit(jobPtr *pps.EtcdJobInfo, commitInfo *pfs.CommitInfo) (*pfs.Commit, error) {
+	if jobPtr.SpecCommit != nil {
+		return jobPtr.SpecCommit, nil
+	}
+	for i, provCommit := range commitInfo.Provenance {
+		provBranch := commitInfo.BranchProvenance[i]
+		if provBranch.Repo.Name == ppsconsts.SpecRepo && provBranch.Name == jobPtr.Pipeline.Name {
+			return provCommit, nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find spec commit for job %s, (this is likely a bug)", jobPtr.Job.ID)
+}
+
 func (a *apiServer) ListJob(ctx context.Context, request *pps.ListJobRequest) (response *pps.JobInfos, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) {
@@ -894,16 +1231,172 @@ func (a *apiServer) StopJob(ctx context.Context, request *pps.StopJobRequest) (r
 	if err := a.jobs.ReadOnly(ctx).Get(request.Job.ID, jobPtr); err != nil {
 		return nil, err
 	}
-	// Finish the job's output commit without a tree -- worker/master will mark
-	// the job 'killed'
-	if _, err := pachClient.PfsAPIClient.FinishCommit(ctx,
+	if err := stopJob(pachClient, jobPtr); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
+// stopJob stops the job in 'jobPtr' by finishing its output commit without a
+// tree -- worker/master will mark the job 'killed', and its workers cancel
+// the datums they're processing when they see that it was killed
+func stopJob(pachClient *client.APIClient, jobPtr *pps.EtcdJobInfo) error {
+	_, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(),
 		&pfs.FinishCommitRequest{
 			Commit: jobPtr.OutputCommit,
 			Empty:  true,
-		}); err != nil {
+		})
+	return err
+}
+
+func (a *apiServer) StopPipelineJobs(ctx context.Context, request *pps.StopPipelineJobsRequest) (response *pps.StopPipelineJobsResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.getPachClient().WithCtx(ctx)
+	if err := checkLoggedIn(pachClient); err != nil {
 		return nil, err
 	}
-	return &types.Empty{}, nil
+	if request.Pipeline == nil {
+		return nil, fmt.Errorf("must specify a pipeline")
+	}
+	if _, err := a.inspectPipeline(pachClient, request.Pipeline.Name); err != nil {
+		return nil, err
+	}
+	jobPtrs, err := a.inFlightJobs(ctx, request.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+	// Stop the jobs concurrently, as each waits for its output commit to be
+	// finished
+	var eg errgroup.Group
+	stopped := make([]bool, len(jobPtrs))
+	for i, jobPtr := range jobPtrs {
+		i, jobPtr := i, jobPtr
+		eg.Go(func() error {
+			if err := stopJob(pachClient, jobPtr); err != nil {
+				// The job finished on its own since it was listed
+				if pfsserver.IsCommitFinishedErr(err) {
+					return nil
+				}
+				return fmt.Errorf("error stopping job %s: %v", jobPtr.Job.ID, grpcutil.ScrubGRPC(err))
+			}
+			stopped[i] = true
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	response = &pps.StopPipelineJobsResponse{}
+	for i, jobPtr := range jobPtrs {
+		if stopped[i] {
+			response.Jobs = append(response.Jobs, jobPtr.Job)
+		}
+	}
+	return response, nil
+}
+
+func (a *apiServer) RerunJob(ctx context.Context, request *pps.RerunJobRequest) (response *pps.Job, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.getPachClient().WithCtx(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	if request.Job == nil {
+		return nil, fmt.Errorf("must specify a job")
+	}
+
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(ctx).Get(request.Job.ID, jobPtr); err != nil {
+		return nil, err
+	}
+	jobInfo, err := a.jobInfoFromPtr(pachClient, jobPtr)
+	if err != nil {
+		return nil, err
+	}
+	commitInfo, err := pachClient.InspectCommit(jobPtr.OutputCommit.Repo.Name, jobPtr.OutputCommit.ID)
+	if err != nil {
+		return nil, err
+	}
+	specCommit, err := jobSpecCommit(jobPtr, commitInfo)
+	if err != nil {
+		return nil, err
+	}
+	// The rerun is processed by the pipeline's current workers, which only run
+	// jobs of the pipeline's current version
+	pipelineInfo, err := a.inspectPipeline(pachClient, jobInfo.Pipeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case pipelineInfo.Service != nil:
+		return nil, fmt.Errorf("can't rerun jobs of services")
+	case pipelineInfo.Stopped:
+		return nil, fmt.Errorf("pipeline %s is stopped; start it to rerun its jobs", pipelineInfo.Pipeline.Name)
+	case pipelineInfo.State == pps.PipelineState_PIPELINE_STANDBY:
+		return nil, fmt.Errorf("pipeline %s is in standby, so it has no workers to rerun its jobs", pipelineInfo.Pipeline.Name)
+	case jobInfo.PipelineVersion != pipelineInfo.Version:
+		return nil, fmt.Errorf("job %s ran version %d of pipeline %s, which is now at version %d; only jobs of a pipeline's current version can be rerun",
+			jobInfo.Job.ID, jobInfo.PipelineVersion, pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	}
+	// Make sure that the job's input commits haven't been deleted
+	var visitErr error
+	checkCommit := func(repo, commit string) {
+		if visitErr != nil || commit == "" {
+			return
+		}
+		if _, err := pachClient.InspectCommit(repo, commit); err != nil {
+			visitErr = fmt.Errorf("can't rerun job %s, as its input commit %s/%s can't be read: %v", jobInfo.Job.ID, repo, commit, err)
+		}
+	}
+	pps.VisitInput(jobInfo.Input, func(input *pps.Input) {
+		switch {
+		case input.Atom != nil:
+			checkCommit(input.Atom.Repo, input.Atom.Commit)
+		case input.Pfs != nil:
+			checkCommit(input.Pfs.Repo, input.Pfs.Commit)
+		case input.Cron != nil:
+			checkCommit(input.Cron.Repo, input.Cron.Commit)
+		case input.Git != nil:
+			checkCommit(input.Git.Name, input.Git.Commit)
+		}
+	})
+	if visitErr != nil {
+		return nil, visitErr
+	}
+
+	// Start the rerun's output commit in a new branch, so that it doesn't
+	// affect the pipeline's output branch or skip any of the job's datums
+	job := client.NewJob(uuid.NewWithoutDashes())
+	outputCommit, err := pachClient.PfsAPIClient.StartCommit(ctx, &pfs.StartCommitRequest{
+		Parent:      client.NewCommit(jobInfo.OutputRepo.Name, ""),
+		Branch:      ppsconsts.RerunBranchPrefix + job.ID,
+		Description: fmt.Sprintf("rerun of job %s", jobInfo.Job.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+		rerunPtr := &pps.EtcdJobInfo{
+			Job:          job,
+			OutputCommit: outputCommit,
+			Pipeline:     jobInfo.Pipeline,
+			Stats:        &pps.ProcessStats{},
+			TraceID:      uuid.NewWithoutDashes(),
+			RerunOf:      jobInfo.Job,
+			Input:        jobInfo.Input,
+			SpecCommit:   specCommit,
+		}
+		return ppsutil.UpdateJobState(a.pipelines.ReadWrite(stm), a.jobs.ReadWrite(stm), rerunPtr, pps.JobState_JOB_STARTING, "")
+	}); err != nil {
+		if _, deleteErr := pachClient.PfsAPIClient.DeleteCommit(ctx, &pfs.DeleteCommitRequest{Commit: outputCommit}); deleteErr != nil {
+			logrus.Errorf("could not delete output commit of failed rerun: %v", deleteErr)
+		}
+		return nil, err
+	}
+	return job, nil
 }
 
 func (a *apiServer) RestartDatum(ctx context.Context, request *pps.RestartDatumRequest) (response *types.Empty, retErr error) {
@@ -994,7 +1487,10 @@ func (a *apiServer) listDatum(pachClient *client.APIClient, job *pps.Job, page,
 		}
 		var datumInfos []*pps.DatumInfo
 		for i := start; i < end; i++ {
-			datum := df.Datum(i) // flattened slice of *worker.Input to job
+			datum, err := df.Datum(i) // flattened slice of *worker.Input to job
+			if err != nil {
+				return nil, err
+			}
 			id := workerpkg.HashDatum(jobInfo.Pipeline.Name, jobInfo.Salt, datum)
 			datumInfo := &pps.DatumInfo{
 				Datum: &pps.Datum{
@@ -1030,6 +1526,7 @@ func (a *apiServer) listDatum(pachClient *client.APIClient, job *pps.Job, page,
 		"stats": true,
 		"logs":  true,
 		"pfs":   true,
+		"jobs":  true, // job summaries written by stats compaction
 	}
 	pathToDatumHash := func(path string) (string, error) {
 		_, datumHash := filepath.Split(path)
@@ -1197,7 +1694,10 @@ func (a *apiServer) getDatum(pachClient *client.APIClient, repo string, commit *
 	if i >= df.Len() {
 		return nil, fmt.Errorf("index %d out of range", i)
 	}
-	inputs := df.Datum(i)
+	inputs, err := df.Datum(i)
+	if err != nil {
+		return nil, err
+	}
 	for _, input := range inputs {
 		datumInfo.Data = append(datumInfo.Data, input.FileInfo)
 	}
@@ -1246,6 +1746,87 @@ func (a *apiServer) InspectDatum(ctx context.Context, request *pps.InspectDatumR
 	return datumInfo, nil
 }
 
+func (a *apiServer) InspectDatums(ctx context.Context, request *pps.InspectDatumsRequest) (response *pps.InspectDatumsResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.getPachClient().WithCtx(ctx)
+	if err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	if request.Input == nil {
+		return nil, fmt.Errorf("must specify an input")
+	}
+	if err := a.validateInput(pachClient, "", request.Input, false); err != nil {
+		return nil, err
+	}
+	input, err := previewInput(pachClient, request.Input, request.Commits)
+	if err != nil {
+		return nil, err
+	}
+	count, err := workerpkg.CountDatums(pachClient, input)
+	if err != nil {
+		return nil, err
+	}
+	return &pps.InspectDatumsResponse{Count: count}, nil
+}
+
+// previewInput returns a copy of 'input' with the commit of each input set to
+// the commit in 'commits' from the same repo, or else to the head of the
+// input's branch, so that it can be passed to CountDatums
+func previewInput(pachClient *client.APIClient, input *pps.Input, commits []*pfs.Commit) (*pps.Input, error) {
+	repoToCommit := make(map[string]string)
+	for _, commit := range commits {
+		if commit.Repo == nil {
+			return nil, fmt.Errorf("commits must specify a repo")
+		}
+		repoToCommit[commit.Repo.Name] = commit.ID
+	}
+	commitFor := func(repo string, branch string) (string, error) {
+		if commit, ok := repoToCommit[repo]; ok {
+			return commit, nil
+		}
+		branchInfo, err := pachClient.InspectBranch(repo, branch)
+		if err != nil {
+			return "", err
+		}
+		if branchInfo.Head == nil {
+			return "", nil
+		}
+		return branchInfo.Head.ID, nil
+	}
+	// The repos of cron and git inputs are created along with their pipeline,
+	// so they may not exist yet, in which case the input has no datums
+	pipelineRepoCommitFor := func(repo string, branch string) (string, error) {
+		if _, ok := repoToCommit[repo]; !ok {
+			if _, err := pachClient.InspectRepo(repo); err != nil {
+				return "", nil
+			}
+		}
+		return commitFor(repo, branch)
+	}
+	result := proto.Clone(input).(*pps.Input)
+	var visitErr error
+	pps.VisitInput(result, func(input *pps.Input) {
+		if visitErr != nil {
+			return
+		}
+		switch {
+		case input.Atom != nil:
+			input.Atom.Commit, visitErr = commitFor(input.Atom.Repo, input.Atom.Branch)
+		case input.Pfs != nil:
+			input.Pfs.Commit, visitErr = commitFor(input.Pfs.Repo, input.Pfs.Branch)
+		case input.Cron != nil:
+			input.Cron.Commit, visitErr = pipelineRepoCommitFor(input.Cron.Repo, "master")
+		case input.Git != nil:
+			input.Git.Commit, visitErr = pipelineRepoCommitFor(input.Git.Name, input.Git.Branch)
+		}
+	})
+	if visitErr != nil {
+		return nil, visitErr
+	}
+	return result, nil
+}
+
 func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer) (retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
@@ -1259,6 +1840,9 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 		if len(request.DataFilters) > 0 || request.Datum != nil {
 			return fmt.Errorf("must specify the Job or Pipeline that the datum is from to get logs for it")
 		}
+		if request.Worker != "" {
+			return fmt.Errorf("must specify the Job or Pipeline that the worker belongs to to get logs from it")
+		}
 		// no authorization is done to get logs from master
 		containerName, rcName = "pachd", "pachd"
 	} else {
@@ -1312,6 +1896,23 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	if len(pods) == 0 {
 		return fmt.Errorf("no pods belonging to the rc \"%s\" were found", rcName)
 	}
+	// If the caller asked for a specific worker, only scrape that worker's pod
+	if request.Worker != "" {
+		podName, err := a.workerPodName(ctx, rcName, request.Worker)
+		if err != nil {
+			return err
+		}
+		var workerPods []v1.Pod
+		for _, pod := range pods {
+			if pod.ObjectMeta.Name == podName {
+				workerPods = append(workerPods, pod)
+			}
+		}
+		if len(workerPods) == 0 {
+			return fmt.Errorf("worker pod \"%s\" does not belong to the rc \"%s\"", podName, rcName)
+		}
+		pods = workerPods
+	}
 
 	// Spawn one goroutine per pod. Each goro writes its pod's logs to a channel
 	// and channels are read into the output server in a stable order.
@@ -1404,121 +2005,495 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 			return err
 		}
 	}
-	return egErr
+	return egErr
+}
+
+func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, statsCommit *pfs.Commit) error {
+	pfsClient := pachClient.PfsAPIClient
+	fs, err := pfsClient.GlobFileStream(pachClient.Ctx(), &pfs.GlobFileRequest{
+		Commit:  statsCommit,
+		Pattern: "*/logs*", // this is the path where logs reside (rotated logs are in logs.1, logs.2, ...)
+	})
+	if err != nil {
+		return grpcutil.ScrubGRPC(err)
+	}
+	// Each datum's logs files are read in order, so that its rotated logs are
+	// sent in the order that they were written
+	var fileInfos []*pfs.FileInfo
+	for {
+		fileInfo, err := fs.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		fileInfos = append(fileInfos, fileInfo)
+	}
+	workerpkg.SortLogsFiles(fileInfos)
+	var datums [][]*pfs.FileInfo
+	for i, fileInfo := range fileInfos {
+		if i == 0 || path.Dir(fileInfo.File.Path) != path.Dir(fileInfos[i-1].File.Path) {
+			datums = append(datums, nil)
+		}
+		datums[len(datums)-1] = append(datums[len(datums)-1], fileInfo)
+	}
+
+	limiter := limit.New(20)
+	var eg errgroup.Group
+	var mu sync.Mutex
+	for _, datumFiles := range datums {
+		datumFiles := datumFiles
+		eg.Go(func() error {
+			limiter.Acquire()
+			defer limiter.Release()
+			for _, fileInfo := range datumFiles {
+				if err := sendStatsLogs(pachClient, request, apiGetLogsServer, &mu, fileInfo); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// sendStatsLogs sends the log messages in the stats logs file 'fileInfo' that
+// match 'request' to 'apiGetLogsServer' (holding 'mu' for each message)
+func sendStatsLogs(pachClient *client.APIClient, request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, mu *sync.Mutex, fileInfo *pfs.FileInfo) error {
+	var buf bytes.Buffer
+	if err := pachClient.GetFile(fileInfo.File.Commit.Repo.Name, fileInfo.File.Commit.ID, fileInfo.File.Path, 0, 0, &buf); err != nil {
+		return err
+	}
+	// Parse pods' log lines, and filter out irrelevant ones
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		logBytes := scanner.Bytes()
+		msg := new(pps.LogMessage)
+		if err := jsonpb.Unmarshal(bytes.NewReader(logBytes), msg); err != nil {
+			continue
+		}
+		if request.Pipeline != nil && request.Pipeline.Name != msg.PipelineName {
+			continue
+		}
+		if request.Job != nil && request.Job.ID != msg.JobID {
+			continue
+		}
+		if request.Datum != nil && request.Datum.ID != msg.DatumID {
+			continue
+		}
+		if request.Master != msg.Master {
+			continue
+		}
+		if !workerpkg.MatchDatum(request.DataFilters, msg.Data) {
+			continue
+		}
+
+		mu.Lock()
+		if err := apiGetLogsServer.Send(msg); err != nil {
+			mu.Unlock()
+			return err
+		}
+		mu.Unlock()
+	}
+	return nil
+}
+
+func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Pipeline == nil {
+		return fmt.Errorf("pipeline has no name")
+	}
+	if err := a.validateInput(pachClient, pipelineInfo.Pipeline.Name, pipelineInfo.Input, false); err != nil {
+		return err
+	}
+	if len(branchProvenance(pipelineInfo.Input)) == 0 {
+		// Jobs are started by commits to a pipeline's input branches, which
+		// object inputs don't have
+		return fmt.Errorf("a pipeline must have at least one input that isn't " +
+			"an object input (e.g. a cron input), so that its jobs are triggered")
+	}
+	if err := validateTransform(pipelineInfo.Transform); err != nil {
+		return fmt.Errorf("invalid transform: %v", err)
+	}
+	if err := a.validateImagePullSecrets(pipelineInfo.Transform); err != nil {
+		return fmt.Errorf("invalid transform: %v", err)
+	}
+	if err := a.validateWeightedCPURequest(pipelineInfo); err != nil {
+		return err
+	}
+	if err := ppsutil.ValidateImage(pipelineInfo.Transform.Image, a.requireImageDigests); err != nil {
+		return fmt.Errorf("invalid transform: %v", err)
+	}
+	if pipelineInfo.ParallelismSpec != nil {
+		if pipelineInfo.ParallelismSpec.Constant < 0 {
+			return fmt.Errorf("ParallelismSpec.Constant must be > 0")
+		}
+		if pipelineInfo.ParallelismSpec.Coefficient < 0 {
+			return fmt.Errorf("ParallelismSpec.Coefficient must be > 0")
+		}
+		if pipelineInfo.ParallelismSpec.Constant != 0 &&
+			pipelineInfo.ParallelismSpec.Coefficient != 0 {
+			return fmt.Errorf("contradictory parallelism strategies: must set at " +
+				"most one of ParallelismSpec.Constant and ParallelismSpec.Coefficient")
+		}
+		if pipelineInfo.Service != nil && pipelineInfo.ParallelismSpec.Constant != 1 {
+			return fmt.Errorf("services can only be run with a constant parallelism of 1")
+		}
+	}
+	if pipelineInfo.HashtreeSpec != nil {
+		if pipelineInfo.HashtreeSpec.Constant <= 0 {
+			return fmt.Errorf("HashtreeSpec.Constant must be > 0")
+		}
+	}
+	if pipelineInfo.OutputBranch == "" {
+		return fmt.Errorf("pipeline needs to specify an output branch")
+	}
+	if _, err := resource.ParseQuantity(pipelineInfo.CacheSize); err != nil {
+		return fmt.Errorf("could not parse cacheSize '%s': %v", pipelineInfo.CacheSize, err)
+	}
+	if pipelineInfo.JobTimeout != nil {
+		_, err := types.DurationFromProto(pipelineInfo.JobTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.DatumTimeout != nil {
+		_, err := types.DurationFromProto(pipelineInfo.DatumTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.StatsRetention != nil {
+		if !pipelineInfo.EnableStats {
+			return fmt.Errorf("stats_retention requires enable_stats")
+		}
+		statsRetention, err := types.DurationFromProto(pipelineInfo.StatsRetention)
+		if err != nil {
+			return err
+		}
+		if statsRetention <= 0 {
+			return fmt.Errorf("stats_retention must be positive")
+		}
+	}
+	if pipelineInfo.LogRetention != nil {
+		if err := validateLogRetention(pipelineInfo); err != nil {
+			return fmt.Errorf("invalid log_retention: %v", err)
+		}
+	}
+	if pipelineInfo.OutputPermissions != nil {
+		if err := validateOutputPermissions(pipelineInfo); err != nil {
+			return fmt.Errorf("invalid output_permissions: %v", err)
+		}
+	}
+	if pipelineInfo.OutputValidation != nil {
+		if err := validateOutputValidation(pipelineInfo); err != nil {
+			return fmt.Errorf("invalid output_validation: %v", err)
+		}
+	}
+	if pipelineInfo.DisruptionBudget != nil {
+		if err := validateDisruptionBudget(pipelineInfo.DisruptionBudget); err != nil {
+			return fmt.Errorf("invalid disruption_budget: %v", err)
+		}
+	}
+	if err := workerpkg.ValidateDatumPriority(pipelineInfo.DatumPriority); err != nil {
+		return fmt.Errorf("invalid datum_priority: %v", err)
+	}
+	if err := workerpkg.ValidateOutputSchema(pipelineInfo.OutputSchema); err != nil {
+		return fmt.Errorf("invalid output_schema: %v", err)
+	}
+	if pipelineInfo.OutputPartition != nil && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't have an output partition, as they don't process datums")
+	}
+	if err := workerpkg.ValidateOutputPartition(pipelineInfo.OutputPartition, pipelineInfo.Input); err != nil {
+		return fmt.Errorf("invalid output_partition: %v", err)
+	}
+	if pipelineInfo.Transform.DatumArgs != nil && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't have datum args, as they don't process datums")
+	}
+	if err := workerpkg.ValidateDatumArgs(pipelineInfo.Transform.DatumArgs, pipelineInfo.Input); err != nil {
+		return fmt.Errorf("invalid datum_args: %v", err)
+	}
+	if err := workerpkg.ValidateShell(pipelineInfo.Transform); err != nil {
+		return fmt.Errorf("invalid shell: %v", err)
+	}
+	if pipelineInfo.OrderedMerge && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't set ordered_merge, as they don't process datums")
+	}
+	if pipelineInfo.OOMRetry != nil && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't set oom_retry, as they don't process datums")
+	}
+	if err := workerpkg.ValidateOOMRetry(pipelineInfo.OOMRetry, pipelineInfo.ResourceLimits); err != nil {
+		return fmt.Errorf("invalid oom_retry: %v", err)
+	}
+	if pipelineInfo.Preemption != nil && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't set preemption, as they don't process datums")
+	}
+	if err := workerpkg.ValidatePreemption(pipelineInfo.Preemption); err != nil {
+		return fmt.Errorf("invalid preemption: %v", err)
+	}
+	if pipelineInfo.EmptyInput != "" && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't set empty_input, as they don't run jobs")
+	}
+	if err := workerpkg.ValidateEmptyInput(pipelineInfo.EmptyInput); err != nil {
+		return fmt.Errorf("invalid empty_input: %v", err)
+	}
+	if err := validateIdleScaleDown(pipelineInfo); err != nil {
+		return fmt.Errorf("invalid idle_scale_down: %v", err)
+	}
+	if pipelineInfo.UploadTries < 0 {
+		return fmt.Errorf("upload_tries must be non-negative")
+	}
+	if pipelineInfo.DeadLetterBranch != "" {
+		if pipelineInfo.DeadLetterBranch == pipelineInfo.OutputBranch {
+			return fmt.Errorf("dead_letter_branch must be different from output_branch")
+		}
+		if pipelineInfo.DeadLetterBranch == "stats" {
+			return fmt.Errorf("dead_letter_branch can't be \"stats\", which is reserved for the pipeline's stats")
+		}
+	}
+	if pipelineInfo.MaxDatums < -1 {
+		return fmt.Errorf("max_datums must be positive, 0 (the cluster's default) or -1 (no limit)")
+	}
+	if pipelineInfo.MaxOutputBytes < -1 {
+		return fmt.Errorf("max_output_bytes must be positive, 0 (the cluster's default) or -1 (no limit)")
+	}
+	if pipelineInfo.Debounce != nil {
+		if err := validateDebounce(pipelineInfo); err != nil {
+			return fmt.Errorf("invalid debounce: %v", err)
+		}
+	}
+	if pipelineInfo.DatumsPerWorker < 0 {
+		return fmt.Errorf("datums_per_worker must be non-negative")
+	}
+	if pipelineInfo.DatumsPerWorker > 1 && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't have datums_per_worker, as they don't process datums")
+	}
+	for key := range pipelineInfo.Annotations {
+		if key == "" {
+			return fmt.Errorf("annotations can't have an empty key")
+		}
+	}
+	if pipelineInfo.SkipUnchangedOutput && pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't have skip_unchanged_output, as they don't run jobs")
+	}
+	if pipelineInfo.HealthCheck != nil {
+		if err := validateHealthCheck(pipelineInfo); err != nil {
+			return fmt.Errorf("invalid health_check: %v", err)
+		}
+	}
+	if pipelineInfo.WorkloadIdentity != nil {
+		if err := validateWorkloadIdentity(pipelineInfo.WorkloadIdentity); err != nil {
+			return fmt.Errorf("invalid workload_identity: %v", err)
+		}
+		name := workerServiceAccountName(pipelineInfo.Pipeline.Name, pipelineInfo.WorkloadIdentity)
+		existing, err := a.kubeClient.CoreV1().ServiceAccounts(a.namespace).Get(name, metav1.GetOptions{})
+		if err != nil && !isNotFoundErr(err) {
+			return err
+		}
+		if err == nil {
+			if err := checkWorkerServiceAccount(existing, pipelineInfo.Pipeline.Name); err != nil {
+				return fmt.Errorf("invalid workload_identity: %v", err)
+			}
+		}
+	}
+	if sc := pipelineInfo.StageConcurrency; sc != nil && (sc.Download < 0 || sc.Upload < 0) {
+		return fmt.Errorf("stage_concurrency must be non-negative")
+	}
+	if pipelineInfo.MaxDatumCrashes < 0 {
+		return fmt.Errorf("max_datum_crashes must be non-negative")
+	}
+	if pipelineInfo.InfraFailureRetries < 0 {
+		return fmt.Errorf("infra_failure_retries must be non-negative")
+	}
+	if pipelineInfo.Finalizer != nil {
+		if err := validateFinalizer(pipelineInfo.Finalizer); err != nil {
+			return fmt.Errorf("invalid finalizer: %v", err)
+		}
+	}
+	if pipelineInfo.WorkerHandlerTimeout != nil {
+		timeout, err := types.DurationFromProto(pipelineInfo.WorkerHandlerTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid worker_handler_timeout: %v", err)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("worker_handler_timeout must be non-negative")
+		}
+	}
+	if pipelineInfo.WorkerVersion != "" {
+		if err := a.validateWorkerVersion(pipelineInfo.WorkerVersion); err != nil {
+			return fmt.Errorf("invalid worker_version: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateWorkerVersion checks that workers of the version 'v' can run with
+// this pachd, and that the cluster's worker images can be switched to that
+// version, which they can't if they're pinned by digest
+func (a *apiServer) validateWorkerVersion(v string) error {
+	workerVersion, err := version.ParseVersion(v)
+	if err != nil {
+		return err
+	}
+	if err := version.CheckWorkerCompatible(version.Version, workerVersion); err != nil {
+		return err
+	}
+	for _, image := range []string{a.workerImage, a.workerSidecarImage} {
+		if strings.Contains(image, "@") {
+			return fmt.Errorf("the cluster's worker image %q is pinned by digest, "+
+				"which identifies a single version's image", image)
+		}
+	}
+	return nil
 }
 
-func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, statsCommit *pfs.Commit) error {
-	pfsClient := pachClient.PfsAPIClient
-	fs, err := pfsClient.GlobFileStream(pachClient.Ctx(), &pfs.GlobFileRequest{
-		Commit:  statsCommit,
-		Pattern: "*/logs", // this is the path where logs reside
-	})
-	if err != nil {
-		return grpcutil.ScrubGRPC(err)
+// validateDisruptionBudget checks that exactly one of budget's fields is set,
+// and that it's set to a number of workers or a percentage of them
+func validateDisruptionBudget(budget *pps.DisruptionBudget) error {
+	if (budget.MinAvailable == "") == (budget.MaxUnavailable == "") {
+		return fmt.Errorf("exactly one of min_available and max_unavailable must be set")
 	}
-
-	limiter := limit.New(20)
-	var eg errgroup.Group
-	var mu sync.Mutex
-	for {
-		fileInfo, err := fs.Recv()
-		if err == io.EOF {
-			break
+	value := budget.MinAvailable + budget.MaxUnavailable
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || percent < 0 || percent > 100 {
+			return fmt.Errorf("%q is not a percentage between 0%% and 100%%", value)
 		}
-		eg.Go(func() error {
-			if err != nil {
-				return err
-			}
-			limiter.Acquire()
-			defer limiter.Release()
-			var buf bytes.Buffer
-			if err := pachClient.GetFile(fileInfo.File.Commit.Repo.Name, fileInfo.File.Commit.ID, fileInfo.File.Path, 0, 0, &buf); err != nil {
-				return err
-			}
-			// Parse pods' log lines, and filter out irrelevant ones
-			scanner := bufio.NewScanner(&buf)
-			for scanner.Scan() {
-				logBytes := scanner.Bytes()
-				msg := new(pps.LogMessage)
-				if err := jsonpb.Unmarshal(bytes.NewReader(logBytes), msg); err != nil {
-					continue
-				}
-				if request.Pipeline != nil && request.Pipeline.Name != msg.PipelineName {
-					continue
-				}
-				if request.Job != nil && request.Job.ID != msg.JobID {
-					continue
-				}
-				if request.Datum != nil && request.Datum.ID != msg.DatumID {
-					continue
-				}
-				if request.Master != msg.Master {
-					continue
-				}
-				if !workerpkg.MatchDatum(request.DataFilters, msg.Data) {
-					continue
-				}
-
-				mu.Lock()
-				if err := apiGetLogsServer.Send(msg); err != nil {
-					mu.Unlock()
-					return err
-				}
-				mu.Unlock()
-			}
-			return nil
-		})
+		return nil
 	}
-	return eg.Wait()
+	if workers, err := strconv.Atoi(value); err != nil || workers < 0 {
+		return fmt.Errorf("%q is neither a number of workers nor a percentage", value)
+	}
+	return nil
 }
 
-func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
-	if pipelineInfo.Pipeline == nil {
-		return fmt.Errorf("pipeline has no name")
-	}
-	if err := a.validateInput(pachClient, pipelineInfo.Pipeline.Name, pipelineInfo.Input, false); err != nil {
-		return err
+// validateHealthCheck checks that the pipeline's health check has a command
+// and a non-negative interval
+func validateHealthCheck(pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't have health checks, as they don't process datums")
 	}
-	if err := validateTransform(pipelineInfo.Transform); err != nil {
-		return fmt.Errorf("invalid transform: %v", err)
+	if len(pipelineInfo.HealthCheck.Cmd) == 0 {
+		return fmt.Errorf("cmd must be set")
 	}
-	if pipelineInfo.ParallelismSpec != nil {
-		if pipelineInfo.ParallelismSpec.Constant < 0 {
-			return fmt.Errorf("ParallelismSpec.Constant must be > 0")
+	if pipelineInfo.HealthCheck.Interval != nil {
+		interval, err := types.DurationFromProto(pipelineInfo.HealthCheck.Interval)
+		if err != nil {
+			return err
 		}
-		if pipelineInfo.ParallelismSpec.Coefficient < 0 {
-			return fmt.Errorf("ParallelismSpec.Coefficient must be > 0")
+		if interval < 0 {
+			return fmt.Errorf("interval must be non-negative")
 		}
-		if pipelineInfo.ParallelismSpec.Constant != 0 &&
-			pipelineInfo.ParallelismSpec.Coefficient != 0 {
-			return fmt.Errorf("contradictory parallelism strategies: must set at " +
-				"most one of ParallelismSpec.Constant and ParallelismSpec.Coefficient")
+	}
+	return nil
+}
+
+// validateLogRetention checks that the pipeline's log_retention has a
+// positive max age (if it's set) and a valid max bytes
+func validateLogRetention(pipelineInfo *pps.PipelineInfo) error {
+	if !pipelineInfo.EnableStats {
+		return fmt.Errorf("log_retention requires enable_stats, as logs are only kept in the stats branch")
+	}
+	if pipelineInfo.LogRetention.MaxAge != nil {
+		maxAge, err := types.DurationFromProto(pipelineInfo.LogRetention.MaxAge)
+		if err != nil {
+			return err
 		}
-		if pipelineInfo.Service != nil && pipelineInfo.ParallelismSpec.Constant != 1 {
-			return fmt.Errorf("services can only be run with a constant parallelism of 1")
+		if maxAge <= 0 {
+			return fmt.Errorf("max_age must be positive")
 		}
 	}
-	if pipelineInfo.HashtreeSpec != nil {
-		if pipelineInfo.HashtreeSpec.Constant <= 0 {
-			return fmt.Errorf("HashtreeSpec.Constant must be > 0")
-		}
+	if pipelineInfo.LogRetention.MaxBytes < -1 {
+		return fmt.Errorf("max_bytes must be positive, 0 (the cluster's default) or -1 (no limit)")
 	}
-	if pipelineInfo.OutputBranch == "" {
-		return fmt.Errorf("pipeline needs to specify an output branch")
+	return nil
+}
+
+// validateOutputPermissions checks that the pipeline's output_permissions
+// set a valid mode or an owner, and that the pipeline has output files
+func validateOutputPermissions(pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Service != nil {
+		return fmt.Errorf("services don't have output files")
 	}
-	if _, err := resource.ParseQuantity(pipelineInfo.CacheSize); err != nil {
-		return fmt.Errorf("could not parse cacheSize '%s': %v", pipelineInfo.CacheSize, err)
+	mode, err := ppsutil.OutputFileMode(pipelineInfo.OutputPermissions)
+	if err != nil {
+		return err
 	}
-	if pipelineInfo.JobTimeout != nil {
-		_, err := types.DurationFromProto(pipelineInfo.JobTimeout)
+	if mode == 0 && pipelineInfo.OutputPermissions.Owner == nil {
+		return fmt.Errorf("at least one of mode and owner must be set")
+	}
+	return nil
+}
+
+// validateOutputValidation checks that the pipeline's output_validation has a
+// command and a positive timeout (if it's set), and that the pipeline has
+// jobs whose output it can validate
+func validateOutputValidation(pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't have output validation, as they don't run jobs")
+	}
+	if len(pipelineInfo.OutputValidation.Cmd) == 0 {
+		return fmt.Errorf("cmd must be set")
+	}
+	if pipelineInfo.OutputValidation.Timeout != nil {
+		timeout, err := types.DurationFromProto(pipelineInfo.OutputValidation.Timeout)
 		if err != nil {
 			return err
 		}
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be positive")
+		}
 	}
-	if pipelineInfo.DatumTimeout != nil {
-		_, err := types.DurationFromProto(pipelineInfo.DatumTimeout)
-		if err != nil {
-			return err
+	return nil
+}
+
+// validateDebounce checks that the pipeline's debounce has a positive quiet
+// period, and a max wait (if any) that's at least as long
+func validateDebounce(pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't be debounced, as they don't run jobs")
+	}
+	if pipelineInfo.Debounce.QuietPeriod == nil {
+		return fmt.Errorf("quiet_period must be set")
+	}
+	quietPeriod, err := types.DurationFromProto(pipelineInfo.Debounce.QuietPeriod)
+	if err != nil {
+		return err
+	}
+	if quietPeriod <= 0 {
+		return fmt.Errorf("quiet_period must be positive")
+	}
+	if pipelineInfo.Debounce.MaxWait == nil {
+		return nil
+	}
+	maxWait, err := types.DurationFromProto(pipelineInfo.Debounce.MaxWait)
+	if err != nil {
+		return err
+	}
+	if maxWait != 0 && maxWait < quietPeriod {
+		return fmt.Errorf("max_wait (%v) must be unset or at least quiet_period (%v)", maxWait, quietPeriod)
+	}
+	return nil
+}
+
+// validateWorkloadIdentity checks that 'wi' names a cloud identity, and a
+// valid k8s service account name (if any) other than pachd's. Service
+// accounts that exist already are checked when the workers are created (see
+// applyWorkloadIdentity).
+func validateWorkloadIdentity(wi *pps.WorkloadIdentity) error {
+	if wi.GCPServiceAccount == "" && wi.AWSRoleARN == "" {
+		return fmt.Errorf("one of gcp_service_account and aws_role_arn must be set")
+	}
+	if wi.AWSRoleARN != "" && !strings.HasPrefix(wi.AWSRoleARN, "arn:") {
+		return fmt.Errorf("aws_role_arn %q is not an ARN", wi.AWSRoleARN)
+	}
+	if wi.ServiceAccount != "" {
+		if errs := validation.IsDNS1123Subdomain(wi.ServiceAccount); len(errs) > 0 {
+			return fmt.Errorf("%q is not a valid service account name: %s", wi.ServiceAccount, strings.Join(errs, "; "))
+		}
+		// Workers mustn't get pachd's permissions
+		if wi.ServiceAccount == assets.ServiceAccountName {
+			return fmt.Errorf("service account %q is pachd's, which workers can't run as", wi.ServiceAccount)
 		}
 	}
 	return nil
@@ -1531,7 +2506,7 @@ func branchProvenance(input *pps.Input) []*pfs.Branch {
 			result = append(result, client.NewBranch(input.Atom.Repo, input.Atom.Branch))
 		}
 		if input.Pfs != nil {
-			result = append(result, client.NewBranch(input.Pfs.Repo, input.Pfs.Branch))
+			result = append(result, client.NewBranch(input.Pfs.Repo, pps.PFSInputBranch(input.Pfs)))
 		}
 		if input.Cron != nil {
 			result = append(result, client.NewBranch(input.Cron.Repo, "master"))
@@ -1784,31 +2759,61 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 		request.Salt = uuid.NewWithoutDashes()
 	}
 	pipelineInfo := &pps.PipelineInfo{
-		Pipeline:         request.Pipeline,
-		Version:          1,
-		Transform:        request.Transform,
-		ParallelismSpec:  request.ParallelismSpec,
-		HashtreeSpec:     request.HashtreeSpec,
-		Input:            request.Input,
-		OutputBranch:     request.OutputBranch,
-		Egress:           request.Egress,
-		CreatedAt:        now(),
-		ResourceRequests: request.ResourceRequests,
-		ResourceLimits:   request.ResourceLimits,
-		Description:      request.Description,
-		CacheSize:        request.CacheSize,
-		EnableStats:      request.EnableStats,
-		Salt:             request.Salt,
-		Batch:            request.Batch,
-		MaxQueueSize:     request.MaxQueueSize,
-		Service:          request.Service,
-		ChunkSpec:        request.ChunkSpec,
-		DatumTimeout:     request.DatumTimeout,
-		JobTimeout:       request.JobTimeout,
-		Standby:          request.Standby,
-		DatumTries:       request.DatumTries,
-		SchedulingSpec:   request.SchedulingSpec,
-		PodSpec:          request.PodSpec,
+		Pipeline:             request.Pipeline,
+		Version:              1,
+		Stopped:              request.Stopped,
+		Transform:            request.Transform,
+		ParallelismSpec:      request.ParallelismSpec,
+		HashtreeSpec:         request.HashtreeSpec,
+		Input:                request.Input,
+		OutputBranch:         request.OutputBranch,
+		Egress:               request.Egress,
+		CreatedAt:            now(),
+		ResourceRequests:     request.ResourceRequests,
+		ResourceLimits:       request.ResourceLimits,
+		Description:          request.Description,
+		CacheSize:            request.CacheSize,
+		EnableStats:          request.EnableStats,
+		Salt:                 request.Salt,
+		Batch:                request.Batch,
+		MaxQueueSize:         request.MaxQueueSize,
+		Service:              request.Service,
+		ChunkSpec:            request.ChunkSpec,
+		DatumTimeout:         request.DatumTimeout,
+		JobTimeout:           request.JobTimeout,
+		Standby:              request.Standby,
+		DatumTries:           request.DatumTries,
+		SchedulingSpec:       request.SchedulingSpec,
+		PodSpec:              request.PodSpec,
+		StatsRetention:       request.StatsRetention,
+		DisruptionBudget:     request.DisruptionBudget,
+		DatumPriority:        request.DatumPriority,
+		MaxDatumCrashes:      request.MaxDatumCrashes,
+		InfraFailureRetries:  request.InfraFailureRetries,
+		Finalizer:            request.Finalizer,
+		OutputSchema:         request.OutputSchema,
+		UploadTries:          request.UploadTries,
+		StageConcurrency:     request.StageConcurrency,
+		DeadLetterBranch:     request.DeadLetterBranch,
+		MaxDatums:            request.MaxDatums,
+		Debounce:             request.Debounce,
+		WorkloadIdentity:     request.WorkloadIdentity,
+		MaxOutputBytes:       request.MaxOutputBytes,
+		HealthCheck:          request.HealthCheck,
+		DatumsPerWorker:      request.DatumsPerWorker,
+		Annotations:          request.Annotations,
+		SkipUnchangedOutput:  request.SkipUnchangedOutput,
+		LogRetention:         request.LogRetention,
+		OutputPermissions:    request.OutputPermissions,
+		OutputValidation:     request.OutputValidation,
+		WorkerVersion:        request.WorkerVersion,
+		OutputPartition:      request.OutputPartition,
+		IdleScaleDown:        request.IdleScaleDown,
+		OrderedMerge:         request.OrderedMerge,
+		OOMRetry:             request.OOMRetry,
+		Preemption:           request.Preemption,
+		EmptyInput:           request.EmptyInput,
+		WorkerHandlerTimeout: request.WorkerHandlerTimeout,
 	}
 	setPipelineDefaults(pipelineInfo)
 
@@ -1837,12 +2842,15 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	operation := pipelineOpCreate
 	if request.Update {
 		operation = pipelineOpUpdate
+	} else if request.SquashOutput {
+		return nil, fmt.Errorf("squash_output can only be set when updating a pipeline")
 	}
 	if err := a.authorizePipelineOp(pachClient, operation, pipelineInfo.Input, pipelineInfo.Pipeline.Name); err != nil {
 		return nil, err
 	}
 	pipelineName := pipelineInfo.Pipeline.Name
 	pps.SortInput(pipelineInfo.Input) // Makes datum hashes comparable
+	var specCommit *pfs.Commit        // the commit containing 'pipelineInfo'
 	if request.Update {
 		// inspect the pipeline here so that if it doesn't exist users get a
 		// sensible error message
@@ -1897,11 +2905,13 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 				pipelinePtr.State = pps.PipelineState_PIPELINE_STARTING
 				// Clear any failure reasons
 				pipelinePtr.Reason = ""
+				pipelinePtr.InfraFailures = 0
 				return nil
 			})
 		}); err != nil {
 			return nil, err
 		}
+		specCommit = pipelinePtr.SpecCommit
 		if pipelinePtr.AuthToken != "" {
 			if err := a.fixPipelineInputRepoACLs(pachClient, pipelineInfo, oldPipelineInfo); err != nil {
 				return nil, err
@@ -1966,9 +2976,18 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 		}
 	}
 
-	// Create a branch for the pipeline's output data (provenant on the spec branch)
-	provenance := append(branchProvenance(pipelineInfo.Input),
-		client.NewBranch(ppsconsts.SpecRepo, pipelineName))
+	if err := createTriggerBranches(pachClient, pipelineInfo.Input); err != nil {
+		return nil, err
+	}
+
+	// Create a branch for the pipeline's output data (provenant on the spec
+	// branch). A stopped pipeline's output branch has no provenance, so that
+	// it isn't triggered until it's started (see StopPipeline).
+	var provenance []*pfs.Branch
+	if !pipelineInfo.Stopped {
+		provenance = append(branchProvenance(pipelineInfo.Input),
+			client.NewBranch(ppsconsts.SpecRepo, pipelineName))
+	}
 	outputBranch := client.NewBranch(pipelineName, pipelineInfo.OutputBranch)
 	if _, err := pfsClient.CreateBranch(ctx, &pfs.CreateBranchRequest{
 		Branch:     outputBranch,
@@ -1984,10 +3003,72 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 			return nil, fmt.Errorf("could not create/update stats branch: %v", err)
 		}
 	}
-
+	if request.SquashOutput {
+		go a.squashOutput(pipelineName, specCommit)
+	}
 	return &types.Empty{}, nil
 }
 
+// squashOutput waits for the output commit of the pipeline 'pipelineName'
+// that's provenant on 'specCommit' (i.e. its first output commit since it was
+// updated), and that commit's downstream commits, to finish, and then deletes
+// the output commit's ancestors. It runs after an UpdatePipeline call with
+// SquashOutput set has returned, so errors are logged.
+func (a *apiServer) squashOutput(pipelineName string, specCommit *pfs.Commit) {
+	if err := a.sudo(a.getPachClient(), func(superUserClient *client.APIClient) error {
+		// Stats commits are provenant on the spec commit too, but unlike the
+		// output commit, they're also provenant on a commit in the output repo
+		var outputCommit *pfs.Commit
+		if err := superUserClient.FlushCommitF([]*pfs.Commit{specCommit}, []*pfs.Repo{client.NewRepo(pipelineName)}, func(commitInfo *pfs.CommitInfo) error {
+			for _, provCommit := range commitInfo.Provenance {
+				if provCommit.Repo.Name == pipelineName {
+					return nil
+				}
+			}
+			outputCommit = commitInfo.Commit
+			return nil
+		}); err != nil {
+			return err
+		}
+		if outputCommit == nil {
+			return nil // the pipeline has no output since it was updated
+		}
+		// Wait for downstream jobs to process the new output, so that their
+		// previous outputs can be deleted too
+		if err := superUserClient.FlushCommitF([]*pfs.Commit{outputCommit}, nil, func(*pfs.CommitInfo) error {
+			return nil
+		}); err != nil {
+			return err
+		}
+		return superUserClient.SquashCommit(pipelineName, outputCommit.ID)
+	}); err != nil {
+		logrus.Errorf("could not squash the output of pipeline %s: %v", pipelineName, err)
+	}
+}
+
+// createTriggerBranches creates the trigger branches of the deferred inputs in
+// 'input' that don't exist yet. They start out with no commits, so none of
+// their inputs' commits are processed until they're promoted.
+func createTriggerBranches(pachClient *client.APIClient, input *pps.Input) error {
+	var visitErr error
+	pps.VisitInput(input, func(input *pps.Input) {
+		if visitErr != nil || input.Pfs == nil || input.Pfs.Deferred == nil {
+			return
+		}
+		_, err := pachClient.InspectBranch(input.Pfs.Repo, input.Pfs.Deferred.Branch)
+		if err == nil {
+			return
+		} else if !isNotFoundErr(err) {
+			visitErr = err
+			return
+		}
+		if err := pachClient.CreateBranch(input.Pfs.Repo, input.Pfs.Deferred.Branch, "", nil); err != nil {
+			visitErr = fmt.Errorf("could not create trigger branch %s@%s: %v", input.Pfs.Repo, input.Pfs.Deferred.Branch, err)
+		}
+	})
+	return visitErr
+}
+
 // setPipelineDefaults sets the default values for a pipeline info
 func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) {
 	now := time.Now()
@@ -2007,6 +3088,9 @@ func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) {
 			if input.Pfs.Branch == "" {
 				input.Pfs.Branch = "master"
 			}
+			if input.Pfs.Deferred != nil && input.Pfs.Deferred.Branch == "" {
+				input.Pfs.Deferred.Branch = fmt.Sprintf("%s-trigger", pipelineInfo.Pipeline.Name)
+			}
 			if input.Pfs.Name == "" {
 				input.Pfs.Name = input.Pfs.Repo
 			}
@@ -2050,6 +3134,9 @@ func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) {
 	if pipelineInfo.DatumTries == 0 {
 		pipelineInfo.DatumTries = DefaultDatumTries
 	}
+	if pipelineInfo.UploadTries == 0 {
+		pipelineInfo.UploadTries = workerpkg.DefaultUploadTries
+	}
 }
 
 func (a *apiServer) InspectPipeline(ctx context.Context, request *pps.InspectPipelineRequest) (response *pps.PipelineInfo, retErr error) {
@@ -2125,6 +3212,105 @@ func (a *apiServer) inspectPipeline(pachClient *client.APIClient, name string) (
 	return pipelineInfo, nil
 }
 
+func (a *apiServer) InspectPipelineVersion(ctx context.Context, request *pps.InspectPipelineVersionRequest) (response *pps.PipelineVersionInfo, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if request.Pipeline == nil {
+		return nil, fmt.Errorf("must specify a pipeline")
+	}
+	pachClient := a.getPachClient().WithCtx(ctx)
+	pipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := workerpkg.PipelineStatus(ctx, pipelineInfo.Pipeline.Name, a.etcdClient, a.etcdPrefix)
+	if err != nil {
+		return nil, err
+	}
+	response = &pps.PipelineVersionInfo{
+		Pipeline:   pipelineInfo.Pipeline,
+		SpecCommit: pipelineInfo.SpecCommit,
+		Version:    pipelineInfo.Version,
+		Workers:    workers,
+	}
+	for _, worker := range workers {
+		if worker.Error != "" {
+			// The worker's spec commit is unknown, but the version of its
+			// RC (which PipelineStatus reports) is what it runs
+			if worker.Version != pipelineInfo.Version {
+				response.Skew = true
+			}
+			continue
+		}
+		// Workers that don't report a spec commit predate this API, so they
+		// can't be running the current version
+		if worker.SpecCommit == nil || worker.SpecCommit.ID != pipelineInfo.SpecCommit.ID {
+			response.Skew = true
+		}
+	}
+	return response, nil
+}
+
+func (a *apiServer) InspectJobHistory(ctx context.Context, request *pps.InspectJobHistoryRequest) (response *pps.JobHistory, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	if request.Pipeline == nil {
+		return nil, fmt.Errorf("must specify a pipeline")
+	}
+	if request.Limit < 0 {
+		return nil, fmt.Errorf("limit must be non-negative, but was %d", request.Limit)
+	}
+	pachClient := a.getPachClient().WithCtx(ctx)
+	pipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorizePipelineOp(pachClient, pipelineOpInspectJobHistory, nil, pipelineInfo.Pipeline.Name); err != nil {
+		return nil, err
+	}
+	// The aggregates are computed from the jobs' etcd records, which contain
+	// their states, times and datum counts, so that no job's output commit has
+	// to be read. Jobs are listed newest first.
+	var jobPtrs []*pps.EtcdJobInfo
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipelineInfo.Pipeline, jobPtr, col.DefaultOptions, func(key string) error {
+		jobPtrs = append(jobPtrs, proto.Clone(jobPtr).(*pps.EtcdJobInfo))
+		if request.Limit > 0 && int64(len(jobPtrs)) >= request.Limit {
+			return errutil.ErrBreak
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	response = aggregateJobHistory(jobPtrs)
+	response.Pipeline = pipelineInfo.Pipeline
+	return response, nil
+}
+
+func (a *apiServer) WatchWorkers(request *pps.WatchWorkersRequest, resp pps.API_WatchWorkersServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	sent := 0
+	defer func(start time.Time) {
+		a.Log(request, fmt.Sprintf("stream containing %d WorkerEvents", sent), retErr, time.Since(start))
+	}(time.Now())
+	if request.Pipeline == nil {
+		return fmt.Errorf("must specify a pipeline")
+	}
+	pachClient := a.getPachClient().WithCtx(resp.Context())
+	// Make sure the pipeline exists (and that the caller can see it)
+	pipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name)
+	if err != nil {
+		return err
+	}
+	if err := workerpkg.WatchPipelineWorkers(resp.Context(), pipelineInfo.Pipeline.Name, a.etcdClient, a.etcdPrefix, func(event *pps.WorkerEvent) error {
+		sent++
+		return resp.Send(event)
+	}); err != nil && resp.Context().Err() == nil {
+		return err
+	}
+	return nil
+}
+
 func (a *apiServer) ListPipeline(ctx context.Context, request *pps.ListPipelineRequest) (response *pps.PipelineInfos, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) {
@@ -2139,6 +3325,10 @@ func (a *apiServer) ListPipeline(ctx context.Context, request *pps.ListPipelineR
 	if err := checkLoggedIn(pachClient); err != nil {
 		return nil, err
 	}
+	selector, err := k8slabels.Parse(request.AnnotationSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation selector %q: %v", request.AnnotationSelector, err)
+	}
 	pipelineInfos := &pps.PipelineInfos{}
 	pipelinePtr := &pps.EtcdPipelineInfo{}
 	if err := a.pipelines.ReadOnly(pachClient.Ctx()).List(pipelinePtr, col.DefaultOptions, func(string) error {
@@ -2146,6 +3336,9 @@ func (a *apiServer) ListPipeline(ctx context.Context, request *pps.ListPipelineR
 		if err != nil {
 			return err
 		}
+		if !selector.Matches(k8slabels.Set(pipelineInfo.Annotations)) {
+			return nil
+		}
 		pipelineInfos.PipelineInfo = append(pipelineInfos.PipelineInfo, pipelineInfo)
 		return nil
 	}); err != nil {
@@ -2231,6 +3424,9 @@ func (a *apiServer) deletePipeline(pachClient *client.APIClient, request *pps.De
 		return nil, fmt.Errorf("error deleting workers: %v", err)
 	}
 
+	// Run the pipeline's finalizer, if any, now that its workers have stopped
+	a.runFinalizer(pachClient.Ctx(), pipelineInfo)
+
 	// If necessary, revoke the pipeline's auth token and remove it from its inputs' ACLs
 	if pipelinePtr.AuthToken != "" {
 		// If auth was deactivated after the pipeline was created, don't try to revoke
@@ -2430,11 +3626,16 @@ type ActiveStat struct {
 }
 
 // CollectActiveObjectsAndTags collects all objects/tags that are not deleted
-// or eligible for garbage collection
-func CollectActiveObjectsAndTags(ctx context.Context, pachClient *client.APIClient, repoInfos []*pfs.RepoInfo, pipelineInfos []*pps.PipelineInfo, memoryAllowance int, storageRoot string) (*ActiveStat, error) {
+// or eligible for garbage collection. At most 'concurrency' hash trees
+// (defaultGCMarkConcurrency if unset) are read at once, which bounds the
+// memory used beyond 'memoryAllowance'.
+func CollectActiveObjectsAndTags(ctx context.Context, pachClient *client.APIClient, repoInfos []*pfs.RepoInfo, pipelineInfos []*pps.PipelineInfo, memoryAllowance int, concurrency int, storageRoot string) (*ActiveStat, error) {
 	if memoryAllowance == 0 {
 		memoryAllowance = defaultGCMemory
 	}
+	if concurrency <= 0 {
+		concurrency = defaultGCMarkConcurrency
+	}
 	result := &ActiveStat{
 		// Each bloom filter gets half the memory allowance, times 8 to convert
 		// from bytes to bits.
@@ -2473,7 +3674,7 @@ func CollectActiveObjectsAndTags(ctx context.Context, pachClient *client.APIClie
 	}
 
 	// Get all commit trees
-	limiter := limit.New(100)
+	limiter := limit.New(concurrency)
 	var eg errgroup.Group
 	for _, repo := range repoInfos {
 		repo := repo
@@ -2575,74 +3776,22 @@ func (a *apiServer) GarbageCollect(ctx context.Context, request *pps.GarbageColl
 	if err != nil {
 		return nil, err
 	}
-	activeStat, err := CollectActiveObjectsAndTags(ctx, pachClient, append(repoInfos.RepoInfo, specRepoInfo), pipelineInfos.PipelineInfo, int(request.MemoryBytes), a.storageRoot)
-	if err != nil {
-		return nil, err
-	}
-
-	// Iterate through all objects.  If they are not active, delete them.
-	objects, err := objClient.ListObjects(ctx, &pfs.ListObjectsRequest{})
+	activeStat, err := CollectActiveObjectsAndTags(ctx, pachClient, append(repoInfos.RepoInfo, specRepoInfo), pipelineInfos.PipelineInfo, int(request.MemoryBytes), int(request.Concurrency), a.storageRoot)
 	if err != nil {
 		return nil, err
 	}
-
-	var objectsToDelete []*pfs.Object
-	deleteObjectsIfMoreThan := func(n int) error {
-		if len(objectsToDelete) > n {
-			if _, err := objClient.DeleteObjects(ctx, &pfs.DeleteObjectsRequest{
-				Objects: objectsToDelete,
-			}); err != nil {
-				return fmt.Errorf("error deleting objects: %v", err)
-			}
-			objectsToDelete = []*pfs.Object{}
-		}
-		return nil
-	}
-	for object, err := objects.Recv(); err != io.EOF; object, err = objects.Recv() {
-		if err != nil {
-			return nil, fmt.Errorf("error receiving objects from ListObjects: %v", err)
-		}
-		if !activeStat.Objects.TestString(object.Hash) {
-			objectsToDelete = append(objectsToDelete, object)
-		}
-		// Delete objects in batches
-		if err := deleteObjectsIfMoreThan(100); err != nil {
-			return nil, err
-		}
-	}
-	if err := deleteObjectsIfMoreThan(0); err != nil {
-		return nil, err
+	concurrency := int(request.Concurrency)
+	if concurrency <= 0 {
+		concurrency = defaultGCConcurrency
 	}
 
-	// Iterate through all tags.  If they are not active, delete them
-	tags, err := objClient.ListTags(ctx, &pfs.ListTagsRequest{})
-	if err != nil {
+	// Every live object and tag has been marked before anything is deleted,
+	// and the bloom filters only have false positives, so the sweep never
+	// deletes a live object or tag, however many goroutines it uses
+	if err := sweepObjects(ctx, objClient, activeStat.Objects, concurrency); err != nil {
 		return nil, err
 	}
-	var tagsToDelete []*pfs.Tag
-	deleteTagsIfMoreThan := func(n int) error {
-		if len(tagsToDelete) > n {
-			if _, err := objClient.DeleteTags(ctx, &pfs.DeleteTagsRequest{
-				Tags: tagsToDelete,
-			}); err != nil {
-				return fmt.Errorf("error deleting tags: %v", err)
-			}
-			tagsToDelete = []*pfs.Tag{}
-		}
-		return nil
-	}
-	for resp, err := tags.Recv(); err != io.EOF; resp, err = tags.Recv() {
-		if err != nil {
-			return nil, fmt.Errorf("error receiving tags from ListTags: %v", err)
-		}
-		if !activeStat.Tags.TestString(resp.Tag.Name) {
-			tagsToDelete = append(tagsToDelete, resp.Tag)
-		}
-		if err := deleteTagsIfMoreThan(100); err != nil {
-			return nil, err
-		}
-	}
-	if err := deleteTagsIfMoreThan(0); err != nil {
+	if err := sweepTags(ctx, objClient, activeStat.Tags, concurrency); err != nil {
 		return nil, err
 	}
 
@@ -2824,6 +3973,26 @@ func (a *apiServer) rcPods(rcName string) ([]v1.Pod, error) {
 	return podList.Items, nil
 }
 
+// workerPodName resolves 'worker', which may be either the IP address or the
+// pod name of one of rcName's workers, to a pod name, using the keys that
+// workers register in etcd when they start.
+func (a *apiServer) workerPodName(ctx context.Context, rcName string, worker string) (string, error) {
+	resp, err := a.etcdClient.Get(ctx, path.Join(a.etcdPrefix, workerpkg.WorkerEtcdPrefix, rcName)+"/", etcd.WithPrefix())
+	if err != nil {
+		return "", fmt.Errorf("could not read worker registrations for rc \"%s\": %v", rcName, err)
+	}
+	for _, kv := range resp.Kvs {
+		ip, podName := path.Base(string(kv.Key)), string(kv.Value)
+		if worker == ip || (podName != "" && worker == podName) {
+			if podName == "" {
+				return "", fmt.Errorf("worker \"%s\" did not register its pod name; it may be running an older version of pachyderm", worker)
+			}
+			return podName, nil
+		}
+	}
+	return "", fmt.Errorf("no worker matching \"%s\" is registered for the rc \"%s\"", worker, rcName)
+}
+
 func (a *apiServer) resolveCommit(pachClient *client.APIClient, commit *pfs.Commit) (*pfs.Commit, error) {
 	ci, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
 	if err != nil {
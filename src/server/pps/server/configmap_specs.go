@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_watch "k8s.io/apimachinery/pkg/watch"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+const (
+	// pipelineSpecConfigMapKey is the key under which a ConfigMap that holds
+	// a pipeline spec stores it
+	pipelineSpecConfigMapKey = "pipeline.json"
+	// pipelineSpecHashAnnotation is set on ConfigMaps that hold pipeline specs
+	// to the hash of the last spec that pachd processed, so that each version
+	// of a spec is only applied once
+	pipelineSpecHashAnnotation = "pachyderm.io/pipeline-spec-hash"
+	// pipelineSpecErrorAnnotation is set on ConfigMaps that hold pipeline
+	// specs to the reason that their current spec couldn't be applied, if it
+	// couldn't
+	pipelineSpecErrorAnnotation = "pachyderm.io/pipeline-spec-error"
+	// pipelineSpecConfigMapAnnotation is set on pipelines created from
+	// ConfigMaps (see PipelineInfo.Annotations) to the name of the ConfigMap
+	// that created them, which is the only one that may update them
+	pipelineSpecConfigMapAnnotation = "pachyderm.io/pipeline-spec-configmap"
+	// pipelineSpecResyncInterval is how often the specs in ConfigMaps that
+	// couldn't be applied are retried
+	pipelineSpecResyncInterval = time.Minute
+)
+
+// pipelineSpecFromConfigMap parses the pipeline spec stored in 'cm'
+func pipelineSpecFromConfigMap(cm *v1.ConfigMap) (*pps.CreatePipelineRequest, error) {
+	spec, ok := cm.Data[pipelineSpecConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap has no %q key", pipelineSpecConfigMapKey)
+	}
+	var request pps.CreatePipelineRequest
+	if err := jsonpb.Unmarshal(strings.NewReader(spec), &request); err != nil {
+		return nil, fmt.Errorf("malformed pipeline spec: %v", err)
+	}
+	if request.Pipeline == nil || request.Pipeline.Name == "" {
+		return nil, fmt.Errorf("pipeline spec must set pipeline.name")
+	}
+	return &request, nil
+}
+
+// isTransientErr returns true if 'err' is an error that applying a pipeline
+// spec may succeed after if it's retried, rather than a problem with the spec
+func isTransientErr(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return true
+	}
+	return false
+}
+
+// syncPipelineSpecConfigMap applies the pipeline spec stored in 'cm' with
+// 'apply', unless it has been applied already, and records the outcome in
+// cm's annotations. It returns true if cm's annotations were changed (and
+// so cm needs to be written back). If the spec is malformed or rejected, the
+// pipeline keeps its last good spec and the reason is recorded in the
+// pipelineSpecErrorAnnotation annotation. The spec's hash is only recorded
+// once it's applied, so a rejected spec is retried (see
+// pipelineSpecResyncInterval), in case it was rejected because of the state
+// of the cluster (e.g. a missing input repo) rather than the spec itself.
+// The error is cleared whenever cm holds the applied spec, including when a
+// rejected spec is reverted to it. Transient errors are returned.
+func syncPipelineSpecConfigMap(cm *v1.ConfigMap, apply func(*pps.CreatePipelineRequest) error) (bool, error) {
+	sum := sha256.Sum256([]byte(cm.Data[pipelineSpecConfigMapKey]))
+	hash := hex.EncodeToString(sum[:])
+	if cm.Annotations[pipelineSpecHashAnnotation] == hash {
+		// The spec's pipeline already runs it, so any error is stale
+		if _, ok := cm.Annotations[pipelineSpecErrorAnnotation]; ok {
+			delete(cm.Annotations, pipelineSpecErrorAnnotation)
+			return true, nil
+		}
+		return false, nil
+	}
+	request, err := pipelineSpecFromConfigMap(cm)
+	if err == nil {
+		err = apply(request)
+		if isTransientErr(err) {
+			return false, err
+		}
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string)
+	}
+	if err != nil {
+		if cm.Annotations[pipelineSpecErrorAnnotation] == err.Error() {
+			return false, nil // already recorded
+		}
+		log.Errorf("PPS master: couldn't apply the pipeline spec in ConfigMap %s, so its pipeline keeps its last good spec: %v", cm.Name, err)
+		cm.Annotations[pipelineSpecErrorAnnotation] = err.Error()
+		return true, nil
+	}
+	log.Infof("PPS master: applied the pipeline spec in ConfigMap %s to pipeline %s", cm.Name, request.Pipeline.Name)
+	cm.Annotations[pipelineSpecHashAnnotation] = hash
+	delete(cm.Annotations, pipelineSpecErrorAnnotation)
+	return true, nil
+}
+
+// checkPipelineSpecOwner returns an error unless the pipeline in
+// 'pipelineInfo' was created from the ConfigMap 'configMap', which is the
+// only ConfigMap that may update it
+func checkPipelineSpecOwner(pipelineInfo *pps.PipelineInfo, configMap string) error {
+	switch owner := pipelineInfo.Annotations[pipelineSpecConfigMapAnnotation]; owner {
+	case configMap:
+		return nil
+	case "":
+		return fmt.Errorf("pipeline %s wasn't created from a ConfigMap, so it can't be updated by ConfigMap %s", pipelineInfo.Pipeline.Name, configMap)
+	default:
+		return fmt.Errorf("pipeline %s was created from ConfigMap %s, so it can't be updated by ConfigMap %s", pipelineInfo.Pipeline.Name, owner, configMap)
+	}
+}
+
+// applyPipelineSpec creates the pipeline in 'request', which is stored in
+// the ConfigMap 'configMap', or updates it if it exists already and was
+// created from the same ConfigMap. The pipeline is created as the user whose
+// token is in pipelineSpecConfigMapToken (if auth is active), so the user's
+// ACLs decide which pipelines ConfigMaps may create.
+func (a *apiServer) applyPipelineSpec(pachClient *client.APIClient, configMap string, request *pps.CreatePipelineRequest) error {
+	pachClient = pachClient.WithCtx(pachClient.Ctx())
+	pachClient.SetAuthToken(a.pipelineSpecConfigMapToken)
+	pipelineInfo, err := pachClient.InspectPipeline(request.Pipeline.Name)
+	if err == nil {
+		if err := checkPipelineSpecOwner(pipelineInfo, configMap); err != nil {
+			return err
+		}
+		request.Update = true
+	} else if !isNotFoundErr(err) {
+		return err
+	}
+	if request.Annotations == nil {
+		request.Annotations = make(map[string]string)
+	}
+	request.Annotations[pipelineSpecConfigMapAnnotation] = configMap
+	_, err = pachClient.PpsAPIClient.CreatePipeline(pachClient.Ctx(), request)
+	return err
+}
+
+// configMapClient is the part of the kubernetes client for ConfigMaps that
+// syncPipelineSpecConfigMaps uses
+type configMapClient interface {
+	List(opts metav1.ListOptions) (*v1.ConfigMapList, error)
+	Watch(opts metav1.ListOptions) (kube_watch.Interface, error)
+	Update(*v1.ConfigMap) (*v1.ConfigMap, error)
+}
+
+// watchPipelineSpecConfigMaps applies the pipeline specs stored in the
+// ConfigMaps selected by pipelineSpecConfigMapSelector (and named in
+// pipelineSpecConfigMapNames, if it's set), and applies them again as
+// pipeline updates whenever they change. It's a helper function called by
+// master.
+func (a *apiServer) watchPipelineSpecConfigMaps(pachClient *client.APIClient) error {
+	return syncPipelineSpecConfigMaps(pachClient.Ctx(), a.kubeClient.CoreV1().ConfigMaps(a.namespace),
+		a.pipelineSpecConfigMapSelector, a.pipelineSpecConfigMapNames, pipelineSpecResyncInterval,
+		func(configMap string, request *pps.CreatePipelineRequest) error {
+			return a.applyPipelineSpec(pachClient, configMap, request)
+		})
+}
+
+// syncPipelineSpecConfigMaps applies the pipeline specs stored in the
+// ConfigMaps selected by 'selector' with 'apply' (see
+// syncPipelineSpecConfigMap) whenever they change, and retries the specs that
+// couldn't be applied every 'resyncInterval', until 'ctx' is done. If 'names'
+// is non-empty, selected ConfigMaps that it doesn't hold are ignored, as pachd
+// may only update the ConfigMaps that it names (see
+// assets.AssetOpts.PipelineSpecConfigMapNames).
+func syncPipelineSpecConfigMaps(ctx context.Context, configMaps configMapClient, selector string, names map[string]bool, resyncInterval time.Duration, apply func(string, *pps.CreatePipelineRequest) error) error {
+	sync := func(cm *v1.ConfigMap) error {
+		if len(names) > 0 && !names[cm.Name] {
+			return nil
+		}
+		changed, err := syncPipelineSpecConfigMap(cm, func(request *pps.CreatePipelineRequest) error {
+			return apply(cm.Name, request)
+		})
+		if err != nil {
+			return err
+		}
+		if changed {
+			if _, err := configMaps.Update(cm); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	watcher, err := configMaps.Watch(metav1.ListOptions{
+		LabelSelector: selector,
+		Watch:         true,
+	})
+	if err != nil {
+		return fmt.Errorf("error watching pipeline spec ConfigMaps: %v", err)
+	}
+	defer watcher.Stop()
+	resync := time.NewTicker(resyncInterval)
+	defer resync.Stop()
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("the stream of pipeline spec ConfigMaps closed unexpectedly")
+			}
+			if event.Type == kube_watch.Error {
+				return fmt.Errorf("error watching pipeline spec ConfigMaps: %v", event.Object)
+			}
+			if event.Type != kube_watch.Added && event.Type != kube_watch.Modified {
+				// Deleting a ConfigMap leaves its pipeline in place
+				continue
+			}
+			cm, ok := event.Object.(*v1.ConfigMap)
+			if !ok {
+				continue
+			}
+			if err := sync(cm); err != nil {
+				return err
+			}
+		case <-resync.C:
+			cms, err := configMaps.List(metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return fmt.Errorf("error listing pipeline spec ConfigMaps: %v", err)
+			}
+			for i := range cms.Items {
+				if err := sync(&cms.Items[i]); err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return context.DeadlineExceeded
+		}
+	}
+}
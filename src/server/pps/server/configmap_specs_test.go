@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube_watch "k8s.io/apimachinery/pkg/watch"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestSyncPipelineSpecConfigMap(t *testing.T) {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "edges"},
+		Data: map[string]string{
+			pipelineSpecConfigMapKey: `{"pipeline": {"name": "edges"}, "transform": {"cmd": ["v1"]}}`,
+		},
+	}
+	var applied []*pps.CreatePipelineRequest
+	apply := func(request *pps.CreatePipelineRequest) error {
+		applied = append(applied, request)
+		return nil
+	}
+
+	// A new spec is applied
+	changed, err := syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, 1, len(applied))
+	require.Equal(t, "edges", applied[0].Pipeline.Name)
+
+	// An unchanged spec (e.g. after pachd writes its annotations back) isn't
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, 1, len(applied))
+
+	// A change to the spec is applied as an update
+	cm.Data[pipelineSpecConfigMapKey] = `{"pipeline": {"name": "edges"}, "transform": {"cmd": ["v2"]}}`
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, 2, len(applied))
+	require.Equal(t, "v2", applied[1].Transform.Cmd[0])
+
+	// A malformed spec isn't applied, and the error is surfaced on the
+	// ConfigMap
+	cm.Data[pipelineSpecConfigMapKey] = `{"pipeline": {"name": "edges"`
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, 2, len(applied))
+	require.NotEqual(t, "", cm.Annotations[pipelineSpecErrorAnnotation])
+
+	// Retrying it doesn't change the ConfigMap again
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// As is a spec that pachd rejects
+	cm.Data[pipelineSpecConfigMapKey] = `{"pipeline": {"name": "edges"}, "transform": {"cmd": ["v3"]}}`
+	changed, err = syncPipelineSpecConfigMap(cm, func(*pps.CreatePipelineRequest) error {
+		return fmt.Errorf("invalid pipeline")
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, "invalid pipeline", cm.Annotations[pipelineSpecErrorAnnotation])
+
+	// A rejected spec is retried, and applied once it's accepted (e.g. once
+	// its input repo exists)
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, 3, len(applied))
+	require.Equal(t, "v3", applied[2].Transform.Cmd[0])
+	_, ok := cm.Annotations[pipelineSpecErrorAnnotation]
+	require.False(t, ok)
+
+	// Fixing a malformed spec clears the error
+	cm.Data[pipelineSpecConfigMapKey] = `{"pipeline": {"name": "edges"`
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	cm.Data[pipelineSpecConfigMapKey] = `{"pipeline": {"name": "edges"}, "transform": {"cmd": ["v4"]}}`
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, 4, len(applied))
+	_, ok = cm.Annotations[pipelineSpecErrorAnnotation]
+	require.False(t, ok)
+
+	// Reverting a rejected spec to the applied one clears the error, without
+	// applying the spec again
+	applied4 := cm.Data[pipelineSpecConfigMapKey]
+	cm.Data[pipelineSpecConfigMapKey] = `{"pipeline": {"name": "edges"}, "transform": {"cmd": ["v5"]}}`
+	changed, err = syncPipelineSpecConfigMap(cm, func(*pps.CreatePipelineRequest) error {
+		return fmt.Errorf("invalid pipeline")
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	cm.Data[pipelineSpecConfigMapKey] = applied4
+	changed, err = syncPipelineSpecConfigMap(cm, apply)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, 4, len(applied))
+	_, ok = cm.Annotations[pipelineSpecErrorAnnotation]
+	require.False(t, ok)
+}
+
+func TestCheckPipelineSpecOwner(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{Pipeline: client.NewPipeline("edges")}
+	// Pipelines that weren't created from a ConfigMap can't be updated by one
+	require.YesError(t, checkPipelineSpecOwner(pipelineInfo, "edges"))
+	// Only the ConfigMap that created a pipeline can update it
+	pipelineInfo.Annotations = map[string]string{pipelineSpecConfigMapAnnotation: "edges"}
+	require.NoError(t, checkPipelineSpecOwner(pipelineInfo, "edges"))
+	require.YesError(t, checkPipelineSpecOwner(pipelineInfo, "other"))
+}
+
+// fakeConfigMaps is a configMapClient that serves the ConfigMaps in 'items',
+// and sends the events written to 'watcher'
+type fakeConfigMaps struct {
+	mu      sync.Mutex
+	items   map[string]*v1.ConfigMap
+	watcher *kube_watch.FakeWatcher
+}
+
+func (f *fakeConfigMaps) List(metav1.ListOptions) (*v1.ConfigMapList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	list := &v1.ConfigMapList{}
+	for _, cm := range f.items {
+		list.Items = append(list.Items, *cm.DeepCopy())
+	}
+	return list, nil
+}
+
+func (f *fakeConfigMaps) Watch(metav1.ListOptions) (kube_watch.Interface, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeConfigMaps) Update(cm *v1.ConfigMap) (*v1.ConfigMap, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.items[cm.Name] = cm.DeepCopy()
+	return cm, nil
+}
+
+func (f *fakeConfigMaps) get(name string) *v1.ConfigMap {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.items[name].DeepCopy()
+}
+
+func TestSyncPipelineSpecConfigMaps(t *testing.T) {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "edges"},
+		Data: map[string]string{
+			pipelineSpecConfigMapKey: `{"pipeline": {"name": "edges"}, "input": {"pfs": {"repo": "images", "glob": "/*"}}}`,
+		},
+	}
+	// pachd may only update the ConfigMaps that it's given the names of, so
+	// others are ignored
+	unnamed := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "unnamed"},
+		Data: map[string]string{
+			pipelineSpecConfigMapKey: `{"pipeline": {"name": "unnamed"}}`,
+		},
+	}
+	configMaps := &fakeConfigMaps{
+		items:   map[string]*v1.ConfigMap{"edges": cm.DeepCopy(), "unnamed": unnamed.DeepCopy()},
+		watcher: kube_watch.NewFake(),
+	}
+	// The spec's input repo doesn't exist at first
+	var mu sync.Mutex
+	repoExists := false
+	var applied []string
+	apply := func(configMap string, request *pps.CreatePipelineRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !repoExists {
+			return fmt.Errorf("input repo images not found")
+		}
+		applied = append(applied, configMap)
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- syncPipelineSpecConfigMaps(ctx, configMaps, "", map[string]bool{"edges": true}, 100*time.Millisecond, apply)
+	}()
+
+	// The ConfigMap's spec is rejected, and the error is recorded without
+	// marking the spec as applied
+	configMaps.watcher.Add(unnamed.DeepCopy())
+	configMaps.watcher.Add(cm.DeepCopy())
+	require.NoErrorWithinT(t, 10*time.Second, func() error {
+		for {
+			cm := configMaps.get("edges")
+			if cm.Annotations[pipelineSpecErrorAnnotation] == "input repo images not found" {
+				if _, ok := cm.Annotations[pipelineSpecHashAnnotation]; ok {
+					return fmt.Errorf("rejected spec was marked as applied")
+				}
+				return nil
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	// Once the repo exists, the spec is applied by a resync, without any
+	// change to the ConfigMap
+	mu.Lock()
+	repoExists = true
+	mu.Unlock()
+	require.NoErrorWithinT(t, 10*time.Second, func() error {
+		for {
+			cm := configMaps.get("edges")
+			if cm.Annotations[pipelineSpecHashAnnotation] != "" {
+				if _, ok := cm.Annotations[pipelineSpecErrorAnnotation]; ok {
+					return fmt.Errorf("applied spec still has an error")
+				}
+				return nil
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	mu.Lock()
+	require.Equal(t, []string{"edges"}, applied)
+	mu.Unlock()
+
+	// Writing the annotations back doesn't apply the spec again
+	configMaps.watcher.Modify(configMaps.get("edges"))
+	time.Sleep(300 * time.Millisecond)
+	mu.Lock()
+	require.Equal(t, 1, len(applied))
+	mu.Unlock()
+	require.Equal(t, 0, len(configMaps.get("unnamed").Annotations))
+
+	cancel()
+	require.YesError(t, <-errCh)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// schedulingWeight returns the weight that 'schedulingSpec' gives its
+// pipeline (which defaults to 1)
+func schedulingWeight(schedulingSpec *pps.SchedulingSpec) int64 {
+	if schedulingSpec != nil && schedulingSpec.Weight > 0 {
+		return schedulingSpec.Weight
+	}
+	return 1
+}
+
+// weightedCPURequest returns the CPU that the workers of a pipeline with the
+// scheduling spec 'schedulingSpec' request when they don't request CPU
+// themselves: 'cpuPerWeight' for each unit of the pipeline's weight, capped
+// at 'maxCPU' (if it's nonzero). Kubernetes sets each container's cgroup
+// cpu.shares in proportion to its CPU request, so when workers on the same
+// node contend for CPU, each pipeline's workers get a share of it that's
+// proportional to the pipeline's weight, rather than one busy pipeline
+// starving the others.
+func weightedCPURequest(cpuPerWeight resource.Quantity, maxCPU resource.Quantity, schedulingSpec *pps.SchedulingSpec) resource.Quantity {
+	weight := schedulingWeight(schedulingSpec)
+	milliCPU := int64(math.MaxInt64)
+	if cpuPerWeight.MilliValue() <= math.MaxInt64/weight {
+		milliCPU = cpuPerWeight.MilliValue() * weight
+	}
+	if !maxCPU.IsZero() && milliCPU > maxCPU.MilliValue() {
+		milliCPU = maxCPU.MilliValue()
+	}
+	return *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)
+}
+
+// validateWeightedCPURequest returns an error if the workers of
+// 'pipelineInfo' would request more CPU for its scheduling weight than
+// a.workerMaxWeightedCPU allows (see weightedCPURequest)
+func (a *apiServer) validateWeightedCPURequest(pipelineInfo *pps.PipelineInfo) error {
+	if a.workerCPUPerWeight.IsZero() || a.workerMaxWeightedCPU.IsZero() {
+		return nil
+	}
+	if pipelineInfo.ResourceRequests != nil && pipelineInfo.ResourceRequests.Cpu != 0 {
+		return nil // the pipeline's workers request their own CPU
+	}
+	weight := schedulingWeight(pipelineInfo.SchedulingSpec)
+	if maxWeight := a.workerMaxWeightedCPU.MilliValue() / a.workerCPUPerWeight.MilliValue(); weight > maxWeight {
+		return fmt.Errorf("scheduling_spec.weight must be at most %d, as workers "+
+			"request %s of CPU per unit of weight (unless resource_requests.cpu is "+
+			"set), and may request at most %s", maxWeight,
+			a.workerCPUPerWeight.String(), a.workerMaxWeightedCPU.String())
+	}
+	return nil
+}
+
+// weightedResourceRequests returns the resources that a pipeline's workers
+// request, given the pipeline's own requests 'requests', limits 'limits' and
+// scheduling spec 'schedulingSpec': if a.workerCPUPerWeight is set and the
+// pipeline doesn't request CPU, its workers request CPU in proportion to its
+// weight (see weightedCPURequest), capped at a.workerMaxWeightedCPU and at the
+// pipeline's CPU limit (as Kubernetes rejects pods whose requests exceed their
+// limits)
+func (a *apiServer) weightedResourceRequests(requests v1.ResourceList, limits v1.ResourceList, schedulingSpec *pps.SchedulingSpec) v1.ResourceList {
+	if a.workerCPUPerWeight.IsZero() {
+		return requests
+	}
+	if cpu, ok := requests[v1.ResourceCPU]; ok && !cpu.IsZero() {
+		return requests
+	}
+	// Copy 'requests', which may be shared with the pipeline's worker options
+	result := make(v1.ResourceList)
+	for name, quantity := range requests {
+		result[name] = quantity
+	}
+	cpu := weightedCPURequest(a.workerCPUPerWeight, a.workerMaxWeightedCPU, schedulingSpec)
+	if limit, ok := limits[v1.ResourceCPU]; ok && !limit.IsZero() && cpu.Cmp(limit) > 0 {
+		cpu = limit
+	}
+	result[v1.ResourceCPU] = cpu
+	return result
+}
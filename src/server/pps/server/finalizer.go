@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+const (
+	defaultFinalizerTimeout = 5 * time.Minute
+	defaultFinalizerTries   = 3
+	// finalizerPollInterval is how often the status of a finalizer's pod is
+	// checked
+	finalizerPollInterval = time.Second
+	// maxFinalizerLogBytes is the amount of a failed finalizer's output
+	// that's included in its error
+	maxFinalizerLogBytes = 1024
+)
+
+func validateFinalizer(finalizer *pps.Finalizer) error {
+	if len(finalizer.Cmd) == 0 {
+		return fmt.Errorf("cmd must be set")
+	}
+	if finalizer.Tries < 0 {
+		return fmt.Errorf("tries must be non-negative")
+	}
+	if finalizer.Timeout != nil {
+		timeout, err := types.DurationFromProto(finalizer.Timeout)
+		if err != nil {
+			return err
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be positive")
+		}
+	}
+	return nil
+}
+
+// runFinalizer runs the finalizer of the pipeline 'pipelineInfo', if it has
+// one, until it succeeds, it has been tried Finalizer.Tries times, or 'ctx'
+// is done. The pipeline is deleted whether or not its finalizer succeeds, so
+// failures are logged rather than returned.
+func (a *apiServer) runFinalizer(ctx context.Context, pipelineInfo *pps.PipelineInfo) {
+	finalizer := pipelineInfo.Finalizer
+	if finalizer == nil {
+		return
+	}
+	tries := finalizer.Tries
+	if tries == 0 {
+		tries = defaultFinalizerTries
+	}
+	timeout := defaultFinalizerTimeout
+	if finalizer.Timeout != nil {
+		var err error
+		if timeout, err = types.DurationFromProto(finalizer.Timeout); err != nil {
+			logrus.Errorf("invalid finalizer timeout for pipeline %s: %v", pipelineInfo.Pipeline.Name, err)
+			timeout = defaultFinalizerTimeout
+		}
+	}
+	var err error
+	for i := int64(1); i <= tries; i++ {
+		if err = a.runFinalizerPod(ctx, pipelineInfo, timeout); err == nil {
+			return
+		}
+		logrus.Errorf("finalizer of pipeline %s failed (attempt %d of %d): %v", pipelineInfo.Pipeline.Name, i, tries, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	logrus.Warnf("deleting pipeline %s even though its finalizer failed %d times; the resources it cleans up may need to be deleted by hand (last error: %v)", pipelineInfo.Pipeline.Name, tries, err)
+}
+
+// runFinalizerPod runs the finalizer of the pipeline 'pipelineInfo' once, in
+// a new pod, and waits up to 'timeout' (or until 'ctx' is done) for it to
+// finish. The pod is deleted afterwards.
+func (a *apiServer) runFinalizerPod(ctx context.Context, pipelineInfo *pps.PipelineInfo, timeout time.Duration) (retErr error) {
+	pods := a.kubeClient.CoreV1().Pods(a.namespace)
+	pod, err := pods.Create(a.finalizerPod(pipelineInfo))
+	if err != nil {
+		return fmt.Errorf("could not create finalizer pod: %v", err)
+	}
+	defer func() {
+		if err := pods.Delete(pod.ObjectMeta.Name, &metav1.DeleteOptions{}); err != nil && !isNotFoundErr(err) && retErr == nil {
+			retErr = fmt.Errorf("could not delete finalizer pod: %v", err)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		pod, err = pods.Get(pod.ObjectMeta.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("could not get status of finalizer pod: %v", err)
+		}
+		switch pod.Status.Phase {
+		case v1.PodSucceeded:
+			return nil
+		case v1.PodFailed:
+			return fmt.Errorf("finalizer failed: %s", a.finalizerLogs(pod))
+		}
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("finalizer timed out after %v", timeout)
+			}
+			return ctx.Err()
+		case <-time.After(finalizerPollInterval):
+		}
+	}
+}
+
+// finalizerLogs returns the end of the output of the finalizer pod 'pod', for
+// error messages.
+func (a *apiServer) finalizerLogs(pod *v1.Pod) string {
+	limitBytes := int64(maxFinalizerLogBytes)
+	logs, err := a.kubeClient.CoreV1().Pods(a.namespace).GetLogs(pod.ObjectMeta.Name, &v1.PodLogOptions{
+		LimitBytes: &limitBytes,
+	}).DoRaw()
+	if err != nil {
+		return fmt.Sprintf("(could not read output: %v)", err)
+	}
+	return strings.TrimSpace(string(logs))
+}
+
+// finalizerPod returns the spec of a pod that runs the finalizer of the
+// pipeline 'pipelineInfo'. The pod has the pipeline's image, environment
+// variables and k8s secrets, but unlike its workers, it can't read from PFS.
+func (a *apiServer) finalizerPod(pipelineInfo *pps.PipelineInfo) *v1.Pod {
+	transform := pipelineInfo.Transform
+	image := transform.Image
+	if image == "" {
+		image = DefaultUserImage
+	}
+	env := []v1.EnvVar{{
+		Name:  client.PPSPipelineNameEnv,
+		Value: pipelineInfo.Pipeline.Name,
+	}}
+	for name, value := range transform.Env {
+		env = append(env, v1.EnvVar{Name: name, Value: value})
+	}
+	var volumes []v1.Volume
+	var volumeMounts []v1.VolumeMount
+	for _, secret := range transform.Secrets {
+		if secret.Provider != "" {
+			continue // only read by workers
+		}
+		if secret.MountPath != "" {
+			volumes = append(volumes, v1.Volume{
+				Name: secret.Name,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{
+						SecretName: secret.Name,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, v1.VolumeMount{
+				Name:      secret.Name,
+				MountPath: secret.MountPath,
+			})
+		}
+		if secret.EnvVar != "" {
+			env = append(env, v1.EnvVar{
+				Name: secret.EnvVar,
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: secret.Key,
+					},
+				},
+			})
+		}
+	}
+	var imagePullSecrets []v1.LocalObjectReference
+	for _, secret := range transform.ImagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, v1.LocalObjectReference{Name: secret})
+	}
+	if a.imagePullSecret != "" {
+		imagePullSecrets = append(imagePullSecrets, v1.LocalObjectReference{Name: a.imagePullSecret})
+	}
+	pullPolicy := transform.ImagePullPolicy
+	if pullPolicy == "" {
+		pullPolicy = a.workerImagePullPolicy
+	}
+	if pullPolicy == "" {
+		pullPolicy = "IfNotPresent"
+	}
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-finalizer-%s", rcName, uuid.NewWithoutDashes()[:8]),
+			Labels: map[string]string{
+				"suite":     suite,
+				"component": "finalizer",
+			},
+			Annotations: map[string]string{"pipelineName": pipelineInfo.Pipeline.Name},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:            "finalizer",
+				Image:           image,
+				Command:         pipelineInfo.Finalizer.Cmd,
+				ImagePullPolicy: v1.PullPolicy(pullPolicy),
+				Env:             env,
+				VolumeMounts:    volumeMounts,
+				WorkingDir:      transform.WorkingDir,
+			}},
+			RestartPolicy:    v1.RestartPolicyNever,
+			Volumes:          volumes,
+			ImagePullSecrets: imagePullSecrets,
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/willf/bloom"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/src/client/limit"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+)
+
+// gcDeleteBatchSize is the number of objects or tags deleted per request
+const gcDeleteBatchSize = 100
+
+// gcShards returns the prefixes that the object keyspace is split into, so
+// that 'concurrency' goroutines can list and delete objects independently.
+// Object hashes are hex, so every object is in exactly one shard. There are
+// more shards than goroutines, so that goroutines that finish a small shard
+// early move on to another one.
+func gcShards(concurrency int) []string {
+	if concurrency <= 1 {
+		return []string{""}
+	}
+	var shards []string
+	for i := 0; i < 16; i++ {
+		prefix := strconv.FormatInt(int64(i), 16)
+		if concurrency <= 4 {
+			shards = append(shards, prefix)
+			continue
+		}
+		for j := 0; j < 16; j++ {
+			shards = append(shards, prefix+strconv.FormatInt(int64(j), 16))
+		}
+	}
+	return shards
+}
+
+// sweepObjects deletes the objects that aren't in 'active', using
+// 'concurrency' goroutines that each sweep one shard (see gcShards) at a time
+func sweepObjects(ctx context.Context, objClient pfs.ObjectAPIClient, active *bloom.BloomFilter, concurrency int) error {
+	var eg errgroup.Group
+	limiter := limit.New(concurrency)
+	for _, shard := range gcShards(concurrency) {
+		shard := shard
+		limiter.Acquire()
+		eg.Go(func() error {
+			defer limiter.Release()
+			return sweepObjectShard(ctx, objClient, active, shard)
+		})
+	}
+	return eg.Wait()
+}
+
+// sweepObjectShard deletes the objects whose hash starts with 'shard' and that
+// aren't in 'active'
+func sweepObjectShard(ctx context.Context, objClient pfs.ObjectAPIClient, active *bloom.BloomFilter, shard string) error {
+	objects, err := objClient.ListObjects(ctx, &pfs.ListObjectsRequest{Prefix: shard})
+	if err != nil {
+		return err
+	}
+	var objectsToDelete []*pfs.Object
+	deleteObjectsIfMoreThan := func(n int) error {
+		if len(objectsToDelete) > n {
+			if _, err := objClient.DeleteObjects(ctx, &pfs.DeleteObjectsRequest{
+				Objects: objectsToDelete,
+			}); err != nil {
+				return fmt.Errorf("error deleting objects: %v", err)
+			}
+			objectsToDelete = []*pfs.Object{}
+		}
+		return nil
+	}
+	for object, err := objects.Recv(); err != io.EOF; object, err = objects.Recv() {
+		if err != nil {
+			return fmt.Errorf("error receiving objects from ListObjects: %v", err)
+		}
+		if !active.TestString(object.Hash) {
+			objectsToDelete = append(objectsToDelete, object)
+		}
+		// Delete objects in batches
+		if err := deleteObjectsIfMoreThan(gcDeleteBatchSize); err != nil {
+			return err
+		}
+	}
+	return deleteObjectsIfMoreThan(0)
+}
+
+// sweepTags deletes the tags that aren't in 'active'. Unlike object hashes,
+// tag names can be anything, so tags are listed in one stream (rather than by
+// shard), and up to 'concurrency' batches of them are deleted at once.
+func sweepTags(ctx context.Context, objClient pfs.ObjectAPIClient, active *bloom.BloomFilter, concurrency int) error {
+	tags, err := objClient.ListTags(ctx, &pfs.ListTagsRequest{})
+	if err != nil {
+		return err
+	}
+	var eg errgroup.Group
+	limiter := limit.New(concurrency)
+	var tagsToDelete []*pfs.Tag
+	deleteTagsIfMoreThan := func(n int) {
+		if len(tagsToDelete) > n {
+			batch := tagsToDelete
+			limiter.Acquire()
+			eg.Go(func() error {
+				defer limiter.Release()
+				if _, err := objClient.DeleteTags(ctx, &pfs.DeleteTagsRequest{
+					Tags: batch,
+				}); err != nil {
+					return fmt.Errorf("error deleting tags: %v", err)
+				}
+				return nil
+			})
+			tagsToDelete = []*pfs.Tag{}
+		}
+	}
+	for resp, err := tags.Recv(); err != io.EOF; resp, err = tags.Recv() {
+		if err != nil {
+			eg.Wait() // the listing error is more useful than a deletion error
+			return fmt.Errorf("error receiving tags from ListTags: %v", err)
+		}
+		if !active.TestString(resp.Tag.Name) {
+			tagsToDelete = append(tagsToDelete, resp.Tag)
+		}
+		deleteTagsIfMoreThan(gcDeleteBatchSize)
+	}
+	deleteTagsIfMoreThan(0)
+	return eg.Wait()
+}
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/willf/bloom"
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+// fakeObjectStore is an in-memory pfs.ObjectAPIClient that implements the
+// calls that garbage collection makes. Each page of listed objects, and each
+// delete request, takes 'latency', like a request to an object store.
+type fakeObjectStore struct {
+	pfs.ObjectAPIClient
+	latency time.Duration
+
+	mu      sync.Mutex
+	objects map[string]bool
+	// hashes are the hashes of 'objects' (including deleted ones), sorted,
+	// so that objects can be listed by prefix without a full scan
+	hashes []string
+	tags   map[string]bool
+	// listed is the number of times each object was listed
+	listed map[string]int
+}
+
+const fakeObjectStorePageSize = 1000
+
+func newFakeObjectStore(latency time.Duration) *fakeObjectStore {
+	return &fakeObjectStore{
+		latency: latency,
+		objects: make(map[string]bool),
+		tags:    make(map[string]bool),
+		listed:  make(map[string]int),
+	}
+}
+
+func (s *fakeObjectStore) ListObjects(ctx context.Context, request *pfs.ListObjectsRequest, opts ...grpc.CallOption) (pfs.ObjectAPI_ListObjectsClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream := &fakeListObjectsClient{latency: s.latency}
+	for i := sort.SearchStrings(s.hashes, request.Prefix); i < len(s.hashes) && strings.HasPrefix(s.hashes[i], request.Prefix); i++ {
+		if s.objects[s.hashes[i]] {
+			stream.objects = append(stream.objects, &pfs.Object{Hash: s.hashes[i]})
+			s.listed[s.hashes[i]]++
+		}
+	}
+	return stream, nil
+}
+
+func (s *fakeObjectStore) DeleteObjects(ctx context.Context, request *pfs.DeleteObjectsRequest, opts ...grpc.CallOption) (*pfs.DeleteObjectsResponse, error) {
+	time.Sleep(s.latency)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, object := range request.Objects {
+		delete(s.objects, object.Hash)
+	}
+	return &pfs.DeleteObjectsResponse{}, nil
+}
+
+func (s *fakeObjectStore) ListTags(ctx context.Context, request *pfs.ListTagsRequest, opts ...grpc.CallOption) (pfs.ObjectAPI_ListTagsClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream := &fakeListTagsClient{latency: s.latency}
+	for name := range s.tags {
+		stream.tags = append(stream.tags, &pfs.Tag{Name: name})
+	}
+	return stream, nil
+}
+
+func (s *fakeObjectStore) DeleteTags(ctx context.Context, request *pfs.DeleteTagsRequest, opts ...grpc.CallOption) (*pfs.DeleteTagsResponse, error) {
+	time.Sleep(s.latency)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tag := range request.Tags {
+		delete(s.tags, tag.Name)
+	}
+	return &pfs.DeleteTagsResponse{}, nil
+}
+
+type fakeListObjectsClient struct {
+	grpc.ClientStream
+	latency time.Duration
+	objects []*pfs.Object
+	sent    int
+}
+
+func (c *fakeListObjectsClient) Recv() (*pfs.Object, error) {
+	if c.sent == len(c.objects) {
+		return nil, io.EOF
+	}
+	if c.sent%fakeObjectStorePageSize == 0 {
+		time.Sleep(c.latency)
+	}
+	c.sent++
+	return c.objects[c.sent-1], nil
+}
+
+type fakeListTagsClient struct {
+	grpc.ClientStream
+	latency time.Duration
+	tags    []*pfs.Tag
+	sent    int
+}
+
+func (c *fakeListTagsClient) Recv() (*pfs.ListTagsResponse, error) {
+	if c.sent == len(c.tags) {
+		return nil, io.EOF
+	}
+	if c.sent%fakeObjectStorePageSize == 0 {
+		time.Sleep(c.latency)
+	}
+	c.sent++
+	return &pfs.ListTagsResponse{Tag: c.tags[c.sent-1]}, nil
+}
+
+// fillFakeObjectStore puts 'n' objects and tags in 's', and returns bloom
+// filters of the half of them that are live
+func fillFakeObjectStore(s *fakeObjectStore, n int) (*bloom.BloomFilter, *bloom.BloomFilter) {
+	rand := rand.New(rand.NewSource(0))
+	activeObjects := bloom.New(uint(n*64), 10)
+	activeTags := bloom.New(uint(n*64), 10)
+	for i := 0; i < n; i++ {
+		hash := fmt.Sprintf("%016x%016x", rand.Uint64(), rand.Uint64())
+		tag := fmt.Sprintf("tag-%d", i)
+		s.objects[hash] = true
+		s.hashes = append(s.hashes, hash)
+		s.tags[tag] = true
+		if i%2 == 0 {
+			activeObjects.AddString(hash)
+			activeTags.AddString(tag)
+		}
+	}
+	sort.Strings(s.hashes)
+	return activeObjects, activeTags
+}
+
+func TestGCShards(t *testing.T) {
+	require.Equal(t, []string{""}, gcShards(1))
+	require.Equal(t, 16, len(gcShards(4)))
+	require.Equal(t, 256, len(gcShards(8)))
+	// Every hex hash is in exactly one shard
+	for _, concurrency := range []int{2, 8} {
+		for _, hash := range []string{"0123", "a0", "ff", "7e57"} {
+			n := 0
+			for _, shard := range gcShards(concurrency) {
+				if strings.HasPrefix(hash, shard) {
+					n++
+				}
+			}
+			require.Equal(t, 1, n)
+		}
+	}
+}
+
+func TestSweep(t *testing.T) {
+	for _, concurrency := range []int{1, 3, 8} {
+		s := newFakeObjectStore(0)
+		activeObjects, activeTags := fillFakeObjectStore(s, 5000)
+		var live, liveTags []string
+		for hash := range s.objects {
+			if activeObjects.TestString(hash) {
+				live = append(live, hash)
+			}
+		}
+		for tag := range s.tags {
+			if activeTags.TestString(tag) {
+				liveTags = append(liveTags, tag)
+			}
+		}
+		require.NoError(t, sweepObjects(context.Background(), s, activeObjects, concurrency))
+		require.NoError(t, sweepTags(context.Background(), s, activeTags, concurrency))
+
+		// Exactly the live objects and tags are kept, and each object was only
+		// listed once
+		require.Equal(t, len(live), len(s.objects))
+		for _, hash := range live {
+			require.True(t, s.objects[hash])
+		}
+		require.Equal(t, len(liveTags), len(s.tags))
+		for _, tag := range liveTags {
+			require.True(t, s.tags[tag])
+		}
+		require.Equal(t, 5000, len(s.listed))
+		for _, n := range s.listed {
+			require.Equal(t, 1, n)
+		}
+	}
+}
+
+// BenchmarkSweep measures the time to sweep a large object store, half of
+// which is garbage, at concurrency 1 vs N. Each page of a listing, and each
+// delete request, takes 5ms, as a request to an object store would.
+func BenchmarkSweep(b *testing.B) {
+	for _, concurrency := range []int{1, 8, 32} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				s := newFakeObjectStore(5 * time.Millisecond)
+				activeObjects, activeTags := fillFakeObjectStore(s, 200000)
+				b.StartTimer()
+				require.NoError(b, sweepObjects(context.Background(), s, activeObjects, concurrency))
+				require.NoError(b, sweepTags(context.Background(), s, activeTags, concurrency))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+// validateIdleScaleDown checks that 'pipelineInfo's idle_scale_down is
+// well-formed, if it's set
+func validateIdleScaleDown(pipelineInfo *pps.PipelineInfo) error {
+	idle := pipelineInfo.IdleScaleDown
+	if idle == nil {
+		return nil
+	}
+	if pipelineInfo.Standby {
+		return fmt.Errorf("standby pipelines can't also scale down when idle, as standby already scales them down to 0 workers")
+	}
+	if pipelineInfo.Service != nil {
+		return fmt.Errorf("services can't scale down when idle, as they don't process commits")
+	}
+	if idle.Timeout == nil {
+		return fmt.Errorf("timeout must be set")
+	}
+	timeout, err := types.DurationFromProto(idle.Timeout)
+	if err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if idle.MinWorkers < 0 {
+		return fmt.Errorf("min_workers must be non-negative")
+	}
+	return nil
+}
+
+// setPipelineIdle records whether 'pipelineName' has been scaled down because
+// it's idle
+func (a *apiServer) setPipelineIdle(pipelineName string, idle bool) {
+	a.idleMu.Lock()
+	defer a.idleMu.Unlock()
+	if idle {
+		a.idlePipelines[pipelineName] = true
+	} else {
+		delete(a.idlePipelines, pipelineName)
+	}
+}
+
+// isPipelineIdle returns true if 'pipelineName' has been scaled down because
+// it's idle
+func (a *apiServer) isPipelineIdle(pipelineName string) bool {
+	a.idleMu.Lock()
+	defer a.idleMu.Unlock()
+	return a.idlePipelines[pipelineName]
+}
+
+// idleWorkers returns the number of workers that 'pipelineInfo' should have,
+// given that it would have 'parallelism' workers if it weren't idle
+func (a *apiServer) idleWorkers(pipelineInfo *pps.PipelineInfo, parallelism int64) int64 {
+	if pipelineInfo.IdleScaleDown == nil || !a.isPipelineIdle(pipelineInfo.Pipeline.Name) {
+		return parallelism
+	}
+	if pipelineInfo.IdleScaleDown.MinWorkers < parallelism {
+		return pipelineInfo.IdleScaleDown.MinWorkers
+	}
+	return parallelism
+}
+
+// monitorIdleWorkers scales 'pipelineInfo's workers down to its idle floor
+// once it has gone IdleScaleDown.Timeout without an input commit to process,
+// and back up when the next one arrives. It's a helper function called by
+// monitorPipeline.
+func (a *apiServer) monitorIdleWorkers(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	timeout, err := types.DurationFromProto(pipelineInfo.IdleScaleDown.Timeout)
+	if err != nil {
+		return err
+	}
+	pipelineName := pipelineInfo.Pipeline.Name
+
+	ciChan := make(chan *pfs.CommitInfo, 1)
+	ctx, cancel := context.WithCancel(pachClient.Ctx())
+	defer cancel()
+	go backoff.RetryNotify(func() error {
+		return pachClient.WithCtx(ctx).SubscribeCommitF(pipelineName, pipelineInfo.OutputBranch, "", pfs.CommitState_READY, func(ci *pfs.CommitInfo) error {
+			select {
+			case ciChan <- ci:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "SubscribeCommit (idle scale down)"))
+
+	idleTimer := time.NewTimer(timeout)
+	defer idleTimer.Stop()
+	// If this is a retry, the pipeline may have been scaled down already
+	idle := a.isPipelineIdle(pipelineName)
+	for {
+		select {
+		case ci := <-ciChan:
+			if ci.Finished != nil {
+				continue
+			}
+			if idle {
+				log.Infof("PPS master: scaling pipeline %s back up for commit %s", pipelineName, ci.Commit.ID)
+				a.setPipelineIdle(pipelineName, false)
+				idle = false
+				if err := a.scaleUpWorkersForPipeline(pipelineInfo); err != nil {
+					return err
+				}
+			}
+			// Wait for the commit to be finished before blocking on the job
+			// because the job may not exist yet.
+			if _, err := pachClient.BlockCommit(ci.Commit.Repo.Name, ci.Commit.ID); err != nil {
+				return err
+			}
+			if _, err := pachClient.InspectJobOutputCommit(ci.Commit.Repo.Name, ci.Commit.ID, true); err != nil {
+				return err
+			}
+			if !idleTimer.Stop() {
+				select {
+				case <-idleTimer.C:
+				default:
+				}
+			}
+			idleTimer.Reset(timeout)
+		case <-idleTimer.C:
+			log.Infof("PPS master: pipeline %s has been idle for %v, scaling it down to %d workers", pipelineName, timeout, pipelineInfo.IdleScaleDown.MinWorkers)
+			a.setPipelineIdle(pipelineName, true)
+			idle = true
+			if err := a.scaleUpWorkersForPipeline(pipelineInfo); err != nil {
+				return err
+			}
+		case <-pachClient.Ctx().Done():
+			return context.DeadlineExceeded
+		}
+	}
+}
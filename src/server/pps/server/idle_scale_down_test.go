@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestValidateIdleScaleDown(t *testing.T) {
+	pipelineInfo := func(idle *pps.IdleScaleDown) *pps.PipelineInfo {
+		return &pps.PipelineInfo{
+			Pipeline:      client.NewPipeline("pipeline"),
+			IdleScaleDown: idle,
+		}
+	}
+	require.NoError(t, validateIdleScaleDown(pipelineInfo(nil)))
+	require.NoError(t, validateIdleScaleDown(pipelineInfo(&pps.IdleScaleDown{
+		Timeout: types.DurationProto(time.Minute),
+	})))
+	require.YesError(t, validateIdleScaleDown(pipelineInfo(&pps.IdleScaleDown{})))
+	require.YesError(t, validateIdleScaleDown(pipelineInfo(&pps.IdleScaleDown{
+		Timeout: types.DurationProto(0),
+	})))
+	require.YesError(t, validateIdleScaleDown(pipelineInfo(&pps.IdleScaleDown{
+		Timeout:    types.DurationProto(time.Minute),
+		MinWorkers: -1,
+	})))
+	standby := pipelineInfo(&pps.IdleScaleDown{Timeout: types.DurationProto(time.Minute)})
+	standby.Standby = true
+	require.YesError(t, validateIdleScaleDown(standby))
+}
+
+func TestIdleWorkers(t *testing.T) {
+	a := &apiServer{idlePipelines: make(map[string]bool)}
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline: client.NewPipeline("pipeline"),
+		IdleScaleDown: &pps.IdleScaleDown{
+			Timeout:    types.DurationProto(time.Minute),
+			MinWorkers: 2,
+		},
+	}
+	require.Equal(t, int64(5), a.idleWorkers(pipelineInfo, 5))
+	a.setPipelineIdle("pipeline", true)
+	require.True(t, a.isPipelineIdle("pipeline"))
+	require.Equal(t, int64(2), a.idleWorkers(pipelineInfo, 5))
+	// The floor never scales a pipeline up
+	require.Equal(t, int64(1), a.idleWorkers(pipelineInfo, 1))
+	a.setPipelineIdle("pipeline", false)
+	require.Equal(t, int64(5), a.idleWorkers(pipelineInfo, 5))
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+// inFlightJobs returns the jobs of 'pipeline' that haven't finished, as
+// recorded in etcd
+func (a *apiServer) inFlightJobs(ctx context.Context, pipeline *pps.Pipeline) ([]*pps.EtcdJobInfo, error) {
+	var jobPtrs []*pps.EtcdJobInfo
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipeline, jobPtr, col.DefaultOptions, func(string) error {
+		if !ppsutil.IsTerminal(jobPtr.State) {
+			jobPtrs = append(jobPtrs, proto.Clone(jobPtr).(*pps.EtcdJobInfo))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return jobPtrs, nil
+}
+
+// reattachTimeout is the longest that a pipeline is kept running for the
+// jobs that were in flight when this pachd became the PPS master (see
+// reattachInFlightJobs)
+const reattachTimeout = 10 * time.Minute
+
+// reattachInFlightJobs keeps 'pipelineInfo' running while the jobs that were
+// in flight when this pachd became the PPS master (e.g. after the previous
+// master failed over) finish. Jobs' progress is recorded in etcd and their
+// datums are processed by the pipeline's workers, which keep running while
+// pachd restarts, so pachd re-attaches to them rather than restarting the
+// jobs. Without this, a standby pipeline would be put back in standby when
+// its monitor starts, which would scale down the workers that are processing
+// its jobs.
+//
+// The jobs are waited for in the background, so that the pipeline's monitor
+// can handle new commits meanwhile. The returned channel is closed once they
+// have finished, or after reattachTimeout, whichever is sooner; jobs that run
+// for longer are still waited for by the monitor, as their output commits
+// are unfinished. It's nil if the pipeline has no jobs in flight.
+func (a *apiServer) reattachInFlightJobs(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) (<-chan struct{}, error) {
+	jobPtrs, err := a.inFlightJobs(pachClient.Ctx(), pipelineInfo.Pipeline)
+	if err != nil || len(jobPtrs) == 0 {
+		return nil, err
+	}
+	if err := a.setPipelineState(pachClient, pipelineInfo, pps.PipelineState_PIPELINE_RUNNING, ""); err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctx, cancel := context.WithTimeout(pachClient.Ctx(), reattachTimeout)
+		defer cancel()
+		for _, jobPtr := range jobPtrs {
+			log.Infof("PPS master: re-attaching to job %s of pipeline %s (%s, %d of %d datums done)",
+				jobPtr.Job.ID, jobPtr.Pipeline.Name, jobPtr.State, jobPtr.DataProcessed+jobPtr.DataSkipped, jobPtr.DataTotal)
+			if err := a.waitJobFinished(ctx, jobPtr.Job.ID); err != nil {
+				log.Errorf("PPS master: stopped waiting for job %s of pipeline %s: %v", jobPtr.Job.ID, jobPtr.Pipeline.Name, err)
+				return
+			}
+		}
+	}()
+	return done, nil
+}
+
+// waitJobFinished blocks until the job 'jobID' has finished or been deleted
+func (a *apiServer) waitJobFinished(ctx context.Context, jobID string) error {
+	// The watch only sends events for jobs that exist, so jobs that were
+	// deleted already are checked for first
+	if err := a.jobs.ReadOnly(ctx).Get(jobID, &pps.EtcdJobInfo{}); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	watcher, err := a.jobs.ReadOnly(ctx).WatchOne(jobID)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	for {
+		select {
+		case ev, ok := <-watcher.Watch():
+			if !ok {
+				return fmt.Errorf("the stream for job updates closed unexpectedly")
+			}
+			switch ev.Type {
+			case watch.EventError:
+				return ev.Err
+			case watch.EventDelete:
+				return nil
+			case watch.EventPut:
+				var key string
+				jobPtr := &pps.EtcdJobInfo{}
+				if err := ev.Unmarshal(&key, jobPtr); err != nil {
+					return err
+				}
+				if ppsutil.IsTerminal(jobPtr.State) {
+					return nil
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
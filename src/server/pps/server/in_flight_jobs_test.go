@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	etcd "github.com/coreos/etcd/clientv3"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+func testInFlightServer(t *testing.T) *apiServer {
+	// Like the other packages' tests, this expects an etcd server at
+	// "localhost:32379"
+	etcdClient, err := etcd.New(etcd.Config{
+		Endpoints:   []string{"localhost:32379"},
+		DialOptions: client.DefaultDialOptions(),
+	})
+	require.NoError(t, err)
+	etcdPrefix := uuid.NewWithoutDashes()
+	return &apiServer{
+		etcdClient: etcdClient,
+		etcdPrefix: etcdPrefix,
+		pipelines:  ppsdb.Pipelines(etcdClient, etcdPrefix),
+		jobs:       ppsdb.Jobs(etcdClient, etcdPrefix),
+	}
+}
+
+func putJob(t *testing.T, a *apiServer, id string, pipeline string, state pps.JobState) {
+	_, err := col.NewSTM(context.Background(), a.etcdClient, func(stm col.STM) error {
+		return a.jobs.ReadWrite(stm).Put(id, &pps.EtcdJobInfo{
+			Job:          client.NewJob(id),
+			Pipeline:     client.NewPipeline(pipeline),
+			OutputCommit: client.NewCommit(pipeline, id),
+			State:        state,
+		})
+	})
+	require.NoError(t, err)
+}
+
+func TestInFlightJobs(t *testing.T) {
+	a := testInFlightServer(t)
+	defer a.etcdClient.Close()
+	ctx := context.Background()
+	putJob(t, a, "running", "pipeline", pps.JobState_JOB_RUNNING)
+	putJob(t, a, "finished", "pipeline", pps.JobState_JOB_SUCCESS)
+	putJob(t, a, "other", "other", pps.JobState_JOB_RUNNING)
+
+	jobPtrs, err := a.inFlightJobs(ctx, client.NewPipeline("pipeline"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobPtrs))
+	require.Equal(t, "running", jobPtrs[0].Job.ID)
+}
+
+func TestWaitJobFinished(t *testing.T) {
+	a := testInFlightServer(t)
+	defer a.etcdClient.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Jobs that are already finished, or don't exist, don't block
+	putJob(t, a, "finished", "pipeline", pps.JobState_JOB_FAILURE)
+	require.NoError(t, a.waitJobFinished(ctx, "finished"))
+	require.NoError(t, a.waitJobFinished(ctx, "missing"))
+
+	putJob(t, a, "running", "pipeline", pps.JobState_JOB_RUNNING)
+	done := make(chan error)
+	go func() {
+		done <- a.waitJobFinished(ctx, "running")
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("waitJobFinished returned before the job finished: %v", err)
+	case <-time.After(time.Second):
+	}
+	putJob(t, a, "running", "pipeline", pps.JobState_JOB_SUCCESS)
+	require.NoError(t, <-done)
+}
+
+func TestReattachInFlightJobs(t *testing.T) {
+	a := testInFlightServer(t)
+	defer a.etcdClient.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	pachClient := (&client.APIClient{}).WithCtx(ctx)
+	pipelineInfo := &pps.PipelineInfo{Pipeline: client.NewPipeline("pipeline")}
+	_, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+		return a.pipelines.ReadWrite(stm).Put("pipeline", &pps.EtcdPipelineInfo{
+			State: pps.PipelineState_PIPELINE_STANDBY,
+		})
+	})
+	require.NoError(t, err)
+
+	// Pipelines without jobs in flight aren't kept running
+	inFlight, err := a.reattachInFlightJobs(pachClient, pipelineInfo)
+	require.NoError(t, err)
+	require.True(t, inFlight == nil)
+
+	// Pipelines with jobs in flight are kept running without blocking the
+	// caller, until the jobs finish
+	putJob(t, a, "running", "pipeline", pps.JobState_JOB_RUNNING)
+	inFlight, err = a.reattachInFlightJobs(pachClient, pipelineInfo)
+	require.NoError(t, err)
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	require.NoError(t, a.pipelines.ReadOnly(ctx).Get("pipeline", pipelinePtr))
+	require.Equal(t, pps.PipelineState_PIPELINE_RUNNING, pipelinePtr.State)
+	select {
+	case <-inFlight:
+		t.Fatalf("in-flight jobs were reported finished before they finished")
+	case <-time.After(time.Second):
+	}
+	putJob(t, a, "running", "pipeline", pps.JobState_JOB_SUCCESS)
+	select {
+	case <-inFlight:
+	case <-ctx.Done():
+		t.Fatalf("in-flight jobs weren't reported finished after they finished")
+	}
+}
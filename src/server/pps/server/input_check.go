@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+// inputRepos returns the names of the repos that 'input' reads from, each
+// once, in the order they appear in it
+func inputRepos(input *pps.Input) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, branch := range pps.InputBranches(input) {
+		if !seen[branch.Repo.Name] {
+			seen[branch.Repo.Name] = true
+			result = append(result, branch.Repo.Name)
+		}
+	}
+	return result
+}
+
+// inputRepoFailure returns why a pipeline can't read its input repo 'repo',
+// given the result of inspecting the repo with the pipeline's auth token
+// ('repoInfo' or 'err'), or "" if it can (or if 'err' may be transient)
+func inputRepoFailure(repo string, repoInfo *pfs.RepoInfo, err error) string {
+	switch {
+	case auth.IsErrNotAuthorized(err):
+		return fmt.Sprintf("pipeline lost access to its input repo %q: %v", repo, grpcutil.ScrubGRPC(err))
+	case isNotFoundErr(err):
+		return fmt.Sprintf("input repo %q no longer exists", repo)
+	case err != nil:
+		return ""
+	case repoInfo.AuthInfo != nil && repoInfo.AuthInfo.AccessLevel < auth.Scope_READER:
+		// AuthInfo is only set if auth is active
+		return fmt.Sprintf("pipeline lost access to its input repo %q: it needs %v access, but has %v", repo, auth.Scope_READER, repoInfo.AuthInfo.AccessLevel)
+	}
+	return ""
+}
+
+// pipelineInputFailure returns why the pipeline 'pipelineInfo' can't read
+// one of its input repos, with its auth token 'authToken', or "" if it can
+// read them all
+func (a *apiServer) pipelineInputFailure(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, authToken string) string {
+	pipelineClient := pachClient.WithCtx(pachClient.Ctx())
+	pipelineClient.SetAuthToken(authToken)
+	for _, repo := range inputRepos(pipelineInfo.Input) {
+		repoInfo, err := pipelineClient.InspectRepo(repo)
+		if reason := inputRepoFailure(repo, repoInfo, err); reason != "" {
+			return reason
+		}
+		if err != nil {
+			log.Errorf("PPS master: could not check input repo %s of pipeline %s: %v", repo, pipelineInfo.Pipeline.Name, err)
+		}
+	}
+	return ""
+}
+
+// checkPipelineInputs fails the pipelines that can no longer read one of
+// their input repos, every a.pipelineInputCheckInterval, so that a pipeline
+// whose input is deleted (or whose access to it is revoked) says why it
+// stopped getting jobs. It's a helper function called by master.
+func (a *apiServer) checkPipelineInputs(pachClient *client.APIClient) error {
+	ticker := time.NewTicker(a.pipelineInputCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-pachClient.Ctx().Done():
+			return context.DeadlineExceeded
+		}
+		// The pipelines are failed after they're listed, rather than while
+		// they're being listed, which would modify the collection being read
+		failures := make(map[string]string)
+		if err := a.sudo(pachClient, func(superUserClient *client.APIClient) error {
+			pipelinePtr := &pps.EtcdPipelineInfo{}
+			return a.pipelines.ReadOnly(pachClient.Ctx()).List(pipelinePtr, col.DefaultOptions, func(pipelineName string) error {
+				if pipelinePtr.State == pps.PipelineState_PIPELINE_FAILURE || pipelinePtr.State == pps.PipelineState_PIPELINE_PAUSED {
+					return nil
+				}
+				pipelineInfo, err := ppsutil.GetPipelineInfo(superUserClient, pipelinePtr)
+				if err != nil {
+					log.Errorf("PPS master: could not read pipeline %s to check its inputs: %v", pipelineName, err)
+					return nil
+				}
+				if reason := a.pipelineInputFailure(pachClient, pipelineInfo, pipelinePtr.AuthToken); reason != "" {
+					failures[pipelineName] = reason
+				}
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+		for pipelineName, reason := range failures {
+			log.Infof("PPS master: failing pipeline %s: %s", pipelineName, reason)
+			if err := a.setPipelineFailure(pachClient.Ctx(), pipelineName, reason); err != nil {
+				return err
+			}
+		}
+	}
+}
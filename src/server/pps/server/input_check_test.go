@@ -0,0 +1,40 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestInputRepos(t *testing.T) {
+	input := client.NewCrossInput(
+		client.NewPFSInput("images", "/*"),
+		client.NewUnionInput(
+			client.NewPFSInput("labels", "/*"),
+			client.NewPFSInputOpts("images_b", "images", "b", "/*", false),
+		),
+	)
+	require.Equal(t, []string{"images", "labels"}, inputRepos(input))
+	require.Equal(t, 0, len(inputRepos(&pps.Input{})))
+}
+
+func TestInputRepoFailure(t *testing.T) {
+	require.Equal(t, "", inputRepoFailure("foo", &pfs.RepoInfo{}, nil))
+	require.Equal(t, "", inputRepoFailure("foo", &pfs.RepoInfo{
+		AuthInfo: &pfs.RepoAuthInfo{AccessLevel: auth.Scope_READER},
+	}, nil))
+	// Errors that may be transient don't fail the pipeline
+	require.Equal(t, "", inputRepoFailure("foo", nil, fmt.Errorf("context deadline exceeded")))
+
+	require.Equal(t, `input repo "foo" no longer exists`,
+		inputRepoFailure("foo", nil, fmt.Errorf("repos foo not found")))
+	require.Matches(t, `lost access to its input repo "foo"`,
+		inputRepoFailure("foo", nil, &auth.ErrNotAuthorized{Subject: "pipeline:bar", Repo: "foo", Required: auth.Scope_READER}))
+	require.Matches(t, `lost access to its input repo "foo": it needs READER access, but has NONE`,
+		inputRepoFailure("foo", &pfs.RepoInfo{AuthInfo: &pfs.RepoAuthInfo{AccessLevel: auth.Scope_NONE}}, nil))
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"path"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+// jobEvent returns the event for a job changing from the state in 'prev' to
+// the state in 'jobPtr', or nil if its state didn't change or no event is
+// published for its new state. 'prev' is nil if the job's previous state is
+// unknown (e.g. when the job was just created), in which case no event is
+// published.
+func jobEvent(prev *pps.EtcdJobInfo, jobPtr *pps.EtcdJobInfo) *events.Event {
+	if prev == nil || prev.State == jobPtr.State {
+		return nil
+	}
+	return events.NewJobEvent(jobPtr)
+}
+
+// jobEventsRevisionKey is the etcd key (under the PPS etcd prefix) that holds
+// the revision of the last job change whose event was published, so that a
+// new PPS master resumes publishing job events where the last one stopped.
+const jobEventsRevisionKey = "job_events_revision"
+
+// publishJobEvents publishes an event each time a job changes state (see
+// events.NewJobEvent). It's a helper function called by master, so that each
+// job's events are only published by one pachd. It resumes from the revision
+// stored at jobEventsRevisionKey, so that job changes made while the watch
+// was restarting (e.g. while the PPS master failed over) aren't missed.
+func (a *apiServer) publishJobEvents(ctx context.Context) error {
+	return events.PublishWatched(ctx, a.etcdClient, path.Join(a.etcdPrefix, jobEventsRevisionKey),
+		a.jobs.ReadOnly(ctx).WatchWithPrevFrom,
+		func(event *watch.Event) (*events.Event, error) {
+			if event.Type != watch.EventPut {
+				return nil, nil
+			}
+			var jobID string
+			jobPtr := &pps.EtcdJobInfo{}
+			if err := event.Unmarshal(&jobID, jobPtr); err != nil {
+				return nil, err
+			}
+			var prev *pps.EtcdJobInfo
+			if event.PrevKey != nil {
+				prev = &pps.EtcdJobInfo{}
+				if err := event.UnmarshalPrev(&jobID, prev); err != nil {
+					return nil, err
+				}
+			}
+			return jobEvent(prev, jobPtr), nil
+		})
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/montanaflynn/stats"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// aggregateJobHistory computes the JobHistory of the jobs in 'jobPtrs'
+// (without its pipeline, which the caller sets)
+func aggregateJobHistory(jobPtrs []*pps.EtcdJobInfo) *pps.JobHistory {
+	history := &pps.JobHistory{Jobs: int64(len(jobPtrs))}
+	var since, until time.Time
+	var durations, throughputs, dataProcessed []float64
+	for _, jobPtr := range jobPtrs {
+		if started, err := types.TimestampFromProto(jobPtr.Started); err == nil {
+			if since.IsZero() || started.Before(since) {
+				since = started
+				history.Since = jobPtr.Started
+			}
+			if until.IsZero() || started.After(until) {
+				until = started
+				history.Until = jobPtr.Started
+			}
+		}
+		switch jobPtr.State {
+		case pps.JobState_JOB_SUCCESS:
+			history.Succeeded++
+		case pps.JobState_JOB_FAILURE:
+			history.Failed++
+		case pps.JobState_JOB_KILLED:
+			history.Killed++
+		default:
+			history.Running++
+			continue
+		}
+		dataProcessed = append(dataProcessed, float64(jobPtr.DataProcessed))
+		duration, ok := jobDuration(jobPtr)
+		if !ok {
+			continue
+		}
+		durations = append(durations, duration.Seconds())
+		if duration > 0 {
+			throughputs = append(throughputs, float64(jobPtr.DataProcessed+jobPtr.DataSkipped)/duration.Seconds())
+		}
+	}
+	if history.Succeeded+history.Failed > 0 {
+		history.FailureRate = float64(history.Failed) / float64(history.Succeeded+history.Failed)
+	}
+	history.Duration = aggregateValues(durations)
+	history.DatumsPerSecond = aggregateValues(throughputs)
+	history.DataProcessed = aggregateValues(dataProcessed)
+	return history
+}
+
+// jobDuration returns how long the finished job in 'jobPtr' took, or false if
+// its start or finish time is missing
+func jobDuration(jobPtr *pps.EtcdJobInfo) (time.Duration, bool) {
+	if jobPtr.Started == nil || jobPtr.Finished == nil {
+		return 0, false
+	}
+	started, err := types.TimestampFromProto(jobPtr.Started)
+	if err != nil {
+		return 0, false
+	}
+	finished, err := types.TimestampFromProto(jobPtr.Finished)
+	if err != nil || finished.Before(started) {
+		return 0, false
+	}
+	return finished.Sub(started), true
+}
+
+// aggregateValues returns the Aggregate of 'values', which is empty if there
+// are no values
+func aggregateValues(values []float64) *pps.Aggregate {
+	if len(values) == 0 {
+		return &pps.Aggregate{}
+	}
+	// These only fail on empty input. Percentiles use the nearest rank, as a
+	// pipeline's history may only have a few jobs, which stats.Percentile
+	// can't compute the 5th percentile of.
+	mean, _ := stats.Mean(values)
+	stddev, _ := stats.StandardDeviation(values)
+	fifth, _ := stats.PercentileNearestRank(values, 5)
+	ninetyFifth, _ := stats.PercentileNearestRank(values, 95)
+	return &pps.Aggregate{
+		Count:                 int64(len(values)),
+		Mean:                  mean,
+		Stddev:                stddev,
+		FifthPercentile:       fifth,
+		NinetyFifthPercentile: ninetyFifth,
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestAggregateJobHistory(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(state pps.JobState, startMinutes, seconds int, processed, skipped int64) *pps.EtcdJobInfo {
+		jobPtr := &pps.EtcdJobInfo{
+			State:         state,
+			DataProcessed: processed,
+			DataSkipped:   skipped,
+		}
+		started := start.Add(time.Duration(startMinutes) * time.Minute)
+		jobPtr.Started, _ = types.TimestampProto(started)
+		if seconds >= 0 {
+			jobPtr.Finished, _ = types.TimestampProto(started.Add(time.Duration(seconds) * time.Second))
+		}
+		return jobPtr
+	}
+	// Jobs are listed newest first
+	history := aggregateJobHistory([]*pps.EtcdJobInfo{
+		job(pps.JobState_JOB_RUNNING, 50, -1, 3, 0),
+		job(pps.JobState_JOB_SUCCESS, 40, 10, 100, 0),
+		job(pps.JobState_JOB_KILLED, 30, 5, 0, 0),
+		job(pps.JobState_JOB_FAILURE, 20, 20, 10, 10),
+		job(pps.JobState_JOB_SUCCESS, 10, 30, 50, 100),
+		job(pps.JobState_JOB_SUCCESS, 0, 40, 80, 0),
+	})
+	require.Equal(t, int64(6), history.Jobs)
+	require.Equal(t, start.Unix(), history.Since.Seconds)
+	require.Equal(t, start.Add(50*time.Minute).Unix(), history.Until.Seconds)
+	require.Equal(t, int64(3), history.Succeeded)
+	require.Equal(t, int64(1), history.Failed)
+	require.Equal(t, int64(1), history.Killed)
+	require.Equal(t, int64(1), history.Running)
+	// Killed jobs don't count as failures
+	require.Equal(t, 0.25, history.FailureRate)
+
+	// Durations of the finished jobs: 10s, 5s, 20s, 30s and 40s
+	require.Equal(t, int64(5), history.Duration.Count)
+	require.Equal(t, 21.0, history.Duration.Mean)
+	require.Equal(t, 5.0, history.Duration.FifthPercentile)
+	require.Equal(t, 40.0, history.Duration.NinetyFifthPercentile)
+	// Throughputs: 100/10, 0/5, 20/20, 150/30 and 80/40 datums per second
+	require.Equal(t, int64(5), history.DatumsPerSecond.Count)
+	require.Equal(t, 3.6, history.DatumsPerSecond.Mean)
+	require.Equal(t, int64(5), history.DataProcessed.Count)
+	require.Equal(t, 48.0, history.DataProcessed.Mean)
+}
+
+func TestAggregateJobHistoryEmpty(t *testing.T) {
+	history := aggregateJobHistory(nil)
+	require.Equal(t, int64(0), history.Jobs)
+	require.Nil(t, history.Since)
+	require.Equal(t, 0.0, history.FailureRate)
+	require.Equal(t, int64(0), history.Duration.Count)
+	require.Equal(t, 0.0, history.DatumsPerSecond.Mean)
+}
@@ -27,6 +27,7 @@ import (
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/deploy/assets"
 	"github.com/pachyderm/pachyderm/src/server/pkg/dlock"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
 )
@@ -36,12 +37,94 @@ const (
 )
 
 var (
+	// failures are the reasons for which a worker's user container may be
+	// waiting that indicate that its pipeline is misconfigured
 	failures = map[string]bool{
 		"InvalidImageName": true,
+	}
+	// infraFailures are the reasons for which a worker's user container may be
+	// waiting that indicate a (possibly transient) problem with the cluster,
+	// rather than with its pipeline
+	infraFailures = map[string]bool{
 		"ErrImagePull":     true,
+		"ImagePullBackOff": true,
+	}
+)
+
+// podFailureCause classifies the reason that a worker pod failed
+type podFailureCause int
+
+const (
+	// noPodFailure means that the pod hasn't failed
+	noPodFailure podFailureCause = iota
+	// pipelineFailure means that the pod failed because its pipeline is
+	// misconfigured, so it won't succeed if it's restarted
+	pipelineFailure
+	// infraFailure means that the pod failed to start because of a problem
+	// with the cluster (e.g. its image couldn't be pulled), so it may succeed
+	// if it's restarted
+	infraFailure
+	// evictionFailure means that the pod was evicted from its node. Evicted
+	// pods are replaced by their replication controller.
+	evictionFailure
+)
+
+// classifyPodFailure returns the reason that the worker pod 'pod' failed, if
+// it did, along with a description of the failure. Failures in user code are
+// never reported here: the worker handles them by failing the datum, and
+// they're retried according to the pipeline's datum_tries.
+func classifyPodFailure(pod *v1.Pod) (podFailureCause, string) {
+	if pod.Status.Phase == v1.PodFailed && pod.Status.Reason == "Evicted" {
+		return evictionFailure, pod.Status.Message
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != "user" || status.State.Waiting == nil {
+			continue
+		}
+		if failures[status.State.Waiting.Reason] {
+			return pipelineFailure, status.State.Waiting.Message
+		}
+		if infraFailures[status.State.Waiting.Reason] {
+			return infraFailure, status.State.Waiting.Message
+		}
+	}
+	return noPodFailure, ""
+}
+
+// userContainerRunning returns true if the user container of the worker pod
+// 'pod' is running, i.e. the pod has started despite any earlier failures
+func userContainerRunning(pod *v1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == "user" {
+			return status.State.Running != nil
+		}
 	}
+	return false
+}
+
+const (
+	// infraFailureMinBackoff is how long a worker pod that failed for an
+	// infrastructure reason waits before it's restarted for the first time.
+	// Each further restart waits twice as long as the previous one, up to
+	// infraFailureMaxBackoff.
+	infraFailureMinBackoff = 10 * time.Second
+	infraFailureMaxBackoff = 5 * time.Minute
 )
 
+// infraFailureBackoff returns how long a worker pod waits before it's
+// restarted after its pipeline's workers have failed for infrastructure
+// reasons 'failures' times in a row (counting the pod's own failure)
+func infraFailureBackoff(failures int64) time.Duration {
+	backoff := infraFailureMinBackoff
+	for i := int64(1); i < failures && backoff < infraFailureMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > infraFailureMaxBackoff {
+		return infraFailureMaxBackoff
+	}
+	return backoff
+}
+
 // The master process is responsible for creating/deleting workers as
 // pipelines are created/removed.
 func (a *apiServer) master() {
@@ -61,6 +144,43 @@ func (a *apiServer) master() {
 
 		log.Infof("Launching PPS master process")
 
+		if a.pipelineSpecConfigMapSelector != "" {
+			go backoff.RetryNotify(func() error {
+				return a.watchPipelineSpecConfigMaps(pachClient.WithCtx(ctx))
+			}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "watch pipeline spec ConfigMaps"))
+		}
+
+		if a.workerRcGCGracePeriod > 0 {
+			go backoff.RetryNotify(func() error {
+				return a.collectWorkerRcs(pachClient.WithCtx(ctx))
+			}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "collect stale worker RCs"))
+		}
+
+		if a.pipelineInputCheckInterval > 0 {
+			go backoff.RetryNotify(func() error {
+				return a.checkPipelineInputs(pachClient.WithCtx(ctx))
+			}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "check pipeline inputs"))
+		}
+
+		if a.maxConcurrentJobs > 0 {
+			go backoff.RetryNotify(func() error {
+				return a.monitorJobSlots(ctx)
+			}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "monitor job slots"))
+		}
+
+		go backoff.RetryNotify(func() error {
+			return a.monitorParallelismBoosts(pachClient.WithCtx(ctx))
+		}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "monitor parallelism boosts"))
+
+		if events.Enabled() {
+			go backoff.RetryNotify(func() error {
+				return a.publishJobEvents(ctx)
+			}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "publish job events"))
+			go backoff.RetryNotify(func() error {
+				return a.publishPipelineEvents(ctx)
+			}, backoff.NewInfiniteBackOff(), notifyCtx(ctx, "publish pipeline events"))
+		}
+
 		pipelineWatcher, err := a.pipelines.ReadOnly(ctx).WatchWithPrev()
 		if err != nil {
 			return fmt.Errorf("error creating watch: %+v", err)
@@ -195,6 +315,17 @@ func (a *apiServer) master() {
 							return err
 						}
 					}
+					// Workers freed by a stopped or idle pipeline can go to
+					// other pipelines
+					if pipelineInfo.Stopped || pipelineInfo.State != pps.PipelineState_PIPELINE_RUNNING {
+						if err := a.rebalanceWorkers(pachClient); err != nil {
+							return err
+						}
+					}
+				case watch.EventDelete:
+					if err := a.rebalanceWorkers(pachClient); err != nil {
+						return err
+					}
 				}
 			case event := <-watchChan:
 				// if we get an error we restart the watch, k8s watches seem to
@@ -228,12 +359,28 @@ func (a *apiServer) master() {
 				if pod.Status.Phase == v1.PodFailed {
 					log.Errorf("pod failed because: %s", pod.Status.Message)
 				}
-				for _, status := range pod.Status.ContainerStatuses {
-					if status.Name == "user" && status.State.Waiting != nil && failures[status.State.Waiting.Reason] {
-						if err := a.setPipelineFailure(ctx, pod.ObjectMeta.Annotations["pipelineName"], status.State.Waiting.Message); err != nil {
-							return err
-						}
+				if pod.ObjectMeta.DeletionTimestamp != nil {
+					// The pod is being deleted (e.g. by retryInfraFailure), so
+					// its failure has already been handled
+					continue
+				}
+				if userContainerRunning(pod) {
+					if err := a.resetInfraFailures(pachClient, pod.ObjectMeta.Annotations["pipelineName"]); err != nil {
+						return err
 					}
+					continue
+				}
+				switch cause, reason := classifyPodFailure(pod); cause {
+				case pipelineFailure:
+					if err := a.setPipelineFailure(ctx, pod.ObjectMeta.Annotations["pipelineName"], reason); err != nil {
+						return err
+					}
+				case infraFailure:
+					if err := a.retryInfraFailure(pachClient, pod, reason); err != nil {
+						return err
+					}
+				case evictionFailure:
+					log.Infof("worker pod %s was evicted (%s), it will be replaced", pod.ObjectMeta.Name, reason)
 				}
 			}
 		}
@@ -251,6 +398,100 @@ func (a *apiServer) setPipelineFailure(ctx context.Context, pipelineName string,
 	return ppsutil.FailPipeline(ctx, a.etcdClient, a.pipelines, pipelineName, reason)
 }
 
+// retryInfraFailure handles the worker pod 'pod' failing to start for an
+// infrastructure reason. If its pipeline has infra_failure_retries left, the
+// pod is deleted so that its replication controller recreates it, after a
+// backoff that grows with each consecutive failure (see infraFailureBackoff),
+// otherwise the pipeline is failed. Retries are only used up by consecutive
+// failures: the count is reset once a worker starts (see resetInfraFailures).
+func (a *apiServer) retryInfraFailure(pachClient *client.APIClient, pod *v1.Pod, reason string) error {
+	podName := pod.ObjectMeta.Name
+	a.infraRestartMu.Lock()
+	pending := a.infraRestarts[podName]
+	a.infraRestartMu.Unlock()
+	if pending {
+		return nil // the pod's failure is already being handled
+	}
+	pipelineName := pod.ObjectMeta.Annotations["pipelineName"]
+	pipelineInfo, err := a.inspectPipeline(pachClient, pipelineName)
+	if err != nil {
+		return err
+	}
+	var retry bool
+	var failures int64
+	if _, err := col.NewSTM(pachClient.Ctx(), a.etcdClient, func(stm col.STM) error {
+		pipelines := a.pipelines.ReadWrite(stm)
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		if err := pipelines.Get(pipelineName, pipelinePtr); err != nil {
+			return err
+		}
+		retry = pipelinePtr.State != pps.PipelineState_PIPELINE_FAILURE &&
+			pipelinePtr.InfraFailures < pipelineInfo.InfraFailureRetries
+		if !retry {
+			return nil
+		}
+		pipelinePtr.InfraFailures++
+		failures = pipelinePtr.InfraFailures
+		return pipelines.Put(pipelineName, pipelinePtr)
+	}); err != nil {
+		return err
+	}
+	if !retry {
+		if pipelineInfo.InfraFailureRetries > 0 {
+			reason = fmt.Sprintf("%s (after %d retries)", reason, pipelineInfo.InfraFailureRetries)
+		}
+		return a.setPipelineFailure(pachClient.Ctx(), pipelineName, reason)
+	}
+	backoff := infraFailureBackoff(failures)
+	log.Infof("restarting worker pod %s of pipeline %s in %v after infrastructure failure: %s", podName, pipelineName, backoff, reason)
+	a.infraRestartMu.Lock()
+	a.infraRestarts[podName] = true
+	a.infraRestartMu.Unlock()
+	go func() {
+		defer func() {
+			a.infraRestartMu.Lock()
+			defer a.infraRestartMu.Unlock()
+			delete(a.infraRestarts, podName)
+		}()
+		select {
+		case <-time.After(backoff):
+		case <-pachClient.Ctx().Done():
+			return // the new master handles the pod's failure again
+		}
+		if err := a.kubeClient.CoreV1().Pods(a.namespace).Delete(podName, &metav1.DeleteOptions{}); err != nil && !isNotFoundErr(err) {
+			log.Errorf("could not restart worker pod %s of pipeline %s: %v", podName, pipelineName, err)
+		}
+	}()
+	return nil
+}
+
+// resetInfraFailures resets the number of times that the workers of the
+// pipeline 'pipelineName' have failed for an infrastructure reason, once one
+// of them has started, so that infra_failure_retries bounds consecutive
+// failures rather than the pipeline's failures over its lifetime
+func (a *apiServer) resetInfraFailures(pachClient *client.APIClient, pipelineName string) error {
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	if err := a.pipelines.ReadOnly(pachClient.Ctx()).Get(pipelineName, pipelinePtr); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil // the pipeline was deleted
+		}
+		return err
+	}
+	if pipelinePtr.InfraFailures == 0 {
+		return nil
+	}
+	_, err := col.NewSTM(pachClient.Ctx(), a.etcdClient, func(stm col.STM) error {
+		return a.pipelines.ReadWrite(stm).Update(pipelineName, pipelinePtr, func() error {
+			pipelinePtr.InfraFailures = 0
+			return nil
+		})
+	})
+	if col.IsErrNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (a *apiServer) checkOrDeployGithookService() error {
 	_, err := getGithookService(a.kubeClient, a.namespace)
 	if err != nil {
@@ -336,7 +577,20 @@ func (a *apiServer) upsertWorkersForPipeline(pipelineInfo *pps.PipelineInfo) err
 			pipelineInfo.Service,
 			pipelineInfo.SpecCommit.ID,
 			pipelineInfo.SchedulingSpec,
-			pipelineInfo.PodSpec)
+			pipelineInfo.PodSpec,
+			pipelineInfo.DisruptionBudget)
+		volumes, volumeMounts := objectInputSecretVolumes(pipelineInfo.Input)
+		options.volumes = append(options.volumes, volumes...)
+		options.volumeMounts = append(options.volumeMounts, volumeMounts...)
+		options.podLabels = annotationLabels(pipelineInfo.Annotations)
+		options.workerVersion = pipelineInfo.WorkerVersion
+		options.preemption = pipelineInfo.Preemption
+		options.handlerTimeout = pipelineInfo.WorkerHandlerTimeout
+		if pipelineInfo.WorkloadIdentity != nil {
+			if err := a.applyWorkloadIdentity(pipelineInfo, options); err != nil {
+				return err
+			}
+		}
 		// Set the pipeline name env
 		options.workerEnv = append(options.workerEnv, v1.EnvVar{
 			Name:  client.PPSPipelineNameEnv,
@@ -372,6 +626,7 @@ func (a *apiServer) deleteWorkersForPipeline(pipelineName string) error {
 		cancel()
 		delete(a.monitorCancels, pipelineName)
 	}
+	a.setPipelineIdle(pipelineName, false)
 	selector := fmt.Sprintf("pipelineName=%s", pipelineName)
 	falseVal := false
 	opts := &metav1.DeleteOptions{
@@ -399,6 +654,30 @@ func (a *apiServer) deleteWorkersForPipeline(pipelineName string) error {
 			}
 		}
 	}
+	pdbs, err := a.kubeClient.PolicyV1beta1().PodDisruptionBudgets(a.namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, pdb := range pdbs.Items {
+		if err := a.kubeClient.PolicyV1beta1().PodDisruptionBudgets(a.namespace).Delete(pdb.Name, opts); err != nil {
+			if !isNotFoundErr(err) {
+				return err
+			}
+		}
+	}
+	// Only the service accounts that were created for the pipeline's workload
+	// identity have its label
+	serviceAccounts, err := a.kubeClient.CoreV1().ServiceAccounts(a.namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, serviceAccount := range serviceAccounts.Items {
+		if err := a.kubeClient.CoreV1().ServiceAccounts(a.namespace).Delete(serviceAccount.Name, opts); err != nil {
+			if !isNotFoundErr(err) {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -423,16 +702,86 @@ func (a *apiServer) scaleUpWorkersForPipeline(pipelineInfo *pps.PipelineInfo) er
 	if err != nil {
 		return err
 	}
+	if a.workerBudget > 0 {
+		// The number of workers depends on what other pipelines are running
+		return a.rebalanceWorkers(a.getPachClient())
+	}
 	parallelism, err := ppsutil.GetExpectedNumWorkers(a.kubeClient, pipelineInfo.ParallelismSpec)
 	if err != nil {
 		log.Errorf("error getting number of workers, default to 1 worker: %v", err)
 		parallelism = 1
 	}
-	*workerRc.Spec.Replicas = int32(parallelism)
+	*workerRc.Spec.Replicas = int32(a.idleWorkers(pipelineInfo, a.boostedWorkers(pipelineInfo, int64(parallelism))))
 	_, err = rc.Update(workerRc)
 	return err
 }
 
+// rebalanceWorkers divides the worker budget between all running pipelines
+// according to their scheduling weights (see ppsutil.FairShare), and scales
+// each pipeline's RC accordingly. It's a no-op if pachd has no worker budget.
+func (a *apiServer) rebalanceWorkers(pachClient *client.APIClient) error {
+	if a.workerBudget <= 0 {
+		return nil
+	}
+	var demands []ppsutil.WorkerDemand
+	rcNames := make(map[string]string)
+	if err := a.sudo(pachClient, func(superUserClient *client.APIClient) error {
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		return a.pipelines.ReadOnly(pachClient.Ctx()).List(pipelinePtr, col.DefaultOptions, func(pipelineName string) error {
+			if pipelinePtr.State != pps.PipelineState_PIPELINE_RUNNING {
+				return nil
+			}
+			pipelineInfo, err := ppsutil.GetPipelineInfo(superUserClient, pipelinePtr)
+			if err != nil {
+				return err
+			}
+			if pipelineInfo.Stopped {
+				return nil
+			}
+			parallelism, err := ppsutil.GetExpectedNumWorkers(a.kubeClient, pipelineInfo.ParallelismSpec)
+			if err != nil {
+				log.Errorf("error getting number of workers, default to 1 worker: %v", err)
+				parallelism = 1
+			}
+			demand := ppsutil.WorkerDemand{
+				Pipeline: pipelineName,
+				Workers:  a.idleWorkers(pipelineInfo, a.boostedWorkers(pipelineInfo, int64(parallelism))),
+			}
+			if pipelineInfo.SchedulingSpec != nil {
+				demand.Weight = pipelineInfo.SchedulingSpec.Weight
+			}
+			demands = append(demands, demand)
+			rcNames[pipelineName] = ppsutil.PipelineRcName(pipelineName, pipelineInfo.Version)
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	rc := a.kubeClient.CoreV1().ReplicationControllers(a.namespace)
+	shares := ppsutil.FairShare(a.workerBudget, demands)
+	// Pipelines that demand no workers (i.e. idle pipelines scaled down to 0)
+	// have no share, and are scaled down to 0
+	for pipelineName, rcName := range rcNames {
+		workers := shares[pipelineName]
+		workerRc, err := rc.Get(rcName, metav1.GetOptions{})
+		if err != nil {
+			if isNotFoundErr(err) {
+				continue // workers haven't been created yet
+			}
+			return err
+		}
+		if *workerRc.Spec.Replicas == int32(workers) {
+			continue
+		}
+		log.Infof("PPS master: scaling pipeline %s to %d workers (worker budget: %d)", pipelineName, workers, a.workerBudget)
+		*workerRc.Spec.Replicas = int32(workers)
+		if _, err := rc.Update(workerRc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func notifyCtx(ctx context.Context, name string) func(error, time.Duration) error {
 	return func(err error, d time.Duration) error {
 		select {
@@ -464,6 +813,11 @@ func (a *apiServer) setPipelineState(pachClient *client.APIClient, pipelineInfo
 
 func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) {
 	var eg errgroup.Group
+	eg.Go(func() error {
+		return backoff.RetryNotify(func() error {
+			return a.monitorSchedulingMetrics(pachClient, pipelineInfo)
+		}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "scheduling metrics"))
+	})
 	pps.VisitInput(pipelineInfo.Input, func(in *pps.Input) {
 		if in.Cron != nil {
 			eg.Go(func() error {
@@ -472,6 +826,13 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 				}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "cron for "+in.Cron.Name))
 			})
 		}
+		if in.Pfs != nil && in.Pfs.Deferred != nil && in.Pfs.Deferred.ReleaseSchedule != "" {
+			eg.Go(func() error {
+				return backoff.RetryNotify(func() error {
+					return a.releaseDeferredInput(pachClient, in)
+				}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "release schedule for "+in.Pfs.Name))
+			})
+		}
 	})
 	if !pipelineInfo.Standby {
 		// Standby is false so simply put it in RUNNING and leave it there.  This is
@@ -483,6 +844,13 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 				return a.setPipelineState(pachClient, pipelineInfo, pps.PipelineState_PIPELINE_RUNNING, "")
 			}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "set running (Standby = false)"))
 		})
+		if pipelineInfo.IdleScaleDown != nil {
+			eg.Go(func() error {
+				return backoff.RetryNotify(func() error {
+					return a.monitorIdleWorkers(pachClient, pipelineInfo)
+				}, backoff.NewInfiniteBackOff(), notifyCtx(pachClient.Ctx(), "idle scale down"))
+			})
+		}
 	} else {
 		// Capacity 1 gives us a bit of buffer so we don't needlessly go into
 		// standby when SubscribeCommit takes too long to return.
@@ -497,12 +865,26 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 		})
 		eg.Go(func() error {
 			return backoff.RetryNotify(func() error {
-				if err := a.setPipelineState(pachClient, pipelineInfo, pps.PipelineState_PIPELINE_STANDBY, ""); err != nil {
+				// The pipeline stays running while jobs that were in flight
+				// before this pachd became the master finish, and goes into
+				// standby once they have (unless it's processing a commit)
+				inFlight, err := a.reattachInFlightJobs(pachClient, pipelineInfo)
+				if err != nil {
 					return err
 				}
+				if inFlight == nil {
+					if err := a.setPipelineState(pachClient, pipelineInfo, pps.PipelineState_PIPELINE_STANDBY, ""); err != nil {
+						return err
+					}
+				}
 				for {
 					var ci *pfs.CommitInfo
 					select {
+					case <-inFlight:
+						inFlight = nil
+						if err := a.setPipelineState(pachClient, pipelineInfo, pps.PipelineState_PIPELINE_STANDBY, ""); err != nil {
+							return err
+						}
 					case ci = <-ciChan:
 						if ci.Finished != nil {
 							continue
@@ -531,6 +913,9 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 							}
 						}
 
+						if inFlight != nil {
+							continue // the in-flight jobs keep it running
+						}
 						if err := a.setPipelineState(pachClient, pipelineInfo, pps.PipelineState_PIPELINE_STANDBY, ""); err != nil {
 							return err
 						}
@@ -595,6 +980,40 @@ func (a *apiServer) makeCronCommits(pachClient *client.APIClient, in *pps.Input)
 	}
 }
 
+// releaseDeferredInput moves the trigger branch of a single deferred input to
+// the head of the input's branch at each time in the input's release
+// schedule. It's a helper function called by monitorPipeline.
+func (a *apiServer) releaseDeferredInput(pachClient *client.APIClient, in *pps.Input) error {
+	schedule, err := cron.ParseStandard(in.Pfs.Deferred.ReleaseSchedule)
+	if err != nil {
+		return err // Shouldn't happen, as the input is validated in CreatePipeline
+	}
+	for {
+		select {
+		case <-time.After(time.Until(schedule.Next(time.Now()))):
+		case <-pachClient.Ctx().Done():
+			return pachClient.Ctx().Err()
+		}
+		branchInfo, err := pachClient.InspectBranch(in.Pfs.Repo, in.Pfs.Branch)
+		if err != nil {
+			return err
+		}
+		triggerInfo, err := pachClient.InspectBranch(in.Pfs.Repo, in.Pfs.Deferred.Branch)
+		if err != nil {
+			return err
+		}
+		if branchInfo.Head == nil || (triggerInfo.Head != nil && triggerInfo.Head.ID == branchInfo.Head.ID) {
+			// No commits were made since the last release, so there's
+			// nothing to process
+			continue
+		}
+		log.Infof("releasing %s@%s (commit %s) to pipeline input %s", in.Pfs.Repo, in.Pfs.Branch, branchInfo.Head.ID, in.Pfs.Name)
+		if err := pachClient.SetBranch(in.Pfs.Repo, branchInfo.Head.ID, in.Pfs.Deferred.Branch); err != nil {
+			return err
+		}
+	}
+}
+
 func isNilBranchErr(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "has no head")
 }
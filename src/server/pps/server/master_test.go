@@ -0,0 +1,74 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func waitingPod(reason string) *v1.Pod {
+	return &v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name: "user",
+				State: v1.ContainerState{
+					Waiting: &v1.ContainerStateWaiting{Reason: reason, Message: reason + " message"},
+				},
+			}},
+		},
+	}
+}
+
+func TestClassifyPodFailure(t *testing.T) {
+	cause, reason := classifyPodFailure(waitingPod("ErrImagePull"))
+	require.Equal(t, infraFailure, cause)
+	require.Equal(t, "ErrImagePull message", reason)
+	cause, _ = classifyPodFailure(waitingPod("ImagePullBackOff"))
+	require.Equal(t, infraFailure, cause)
+	cause, _ = classifyPodFailure(waitingPod("InvalidImageName"))
+	require.Equal(t, pipelineFailure, cause)
+	cause, _ = classifyPodFailure(waitingPod("ContainerCreating"))
+	require.Equal(t, noPodFailure, cause)
+
+	// Only the user container is considered
+	pod := waitingPod("ErrImagePull")
+	pod.Status.ContainerStatuses[0].Name = "storage"
+	cause, _ = classifyPodFailure(pod)
+	require.Equal(t, noPodFailure, cause)
+
+	// A user container that exited with an error was restarted by k8s and its
+	// failure is handled by the worker, not the master
+	pod = waitingPod("")
+	pod.Status.ContainerStatuses[0].State = v1.ContainerState{
+		Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+	}
+	cause, _ = classifyPodFailure(pod)
+	require.Equal(t, noPodFailure, cause)
+
+	pod = &v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted", Message: "low on memory"}}
+	cause, reason = classifyPodFailure(pod)
+	require.Equal(t, evictionFailure, cause)
+	require.Equal(t, "low on memory", reason)
+}
+
+func TestUserContainerRunning(t *testing.T) {
+	pod := waitingPod("ErrImagePull")
+	require.False(t, userContainerRunning(pod))
+	pod.Status.ContainerStatuses[0].State = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	require.True(t, userContainerRunning(pod))
+	// Only the user container is considered
+	pod.Status.ContainerStatuses[0].Name = "storage"
+	require.False(t, userContainerRunning(pod))
+}
+
+func TestInfraFailureBackoff(t *testing.T) {
+	require.Equal(t, 10*time.Second, infraFailureBackoff(1))
+	require.Equal(t, 20*time.Second, infraFailureBackoff(2))
+	require.Equal(t, 80*time.Second, infraFailureBackoff(4))
+	require.Equal(t, 5*time.Minute, infraFailureBackoff(6))
+	require.Equal(t, 5*time.Minute, infraFailureBackoff(1000))
+}
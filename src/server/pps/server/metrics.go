@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	workerpkg "github.com/pachyderm/pachyderm/src/server/worker"
+)
+
+// schedulingMetricsInterval is how often the PPS master updates a pipeline's
+// scheduling metrics
+const schedulingMetricsInterval = 10 * time.Second
+
+var (
+	queuedDatums = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "queued_datums",
+			Help:      "Number of datums in a pipeline's running jobs that haven't been processed yet",
+		},
+		[]string{
+			"pipeline",
+		},
+	)
+	jobQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "job_queue_depth",
+			Help:      "Number of a pipeline's jobs that haven't finished, by state (starting|running|merging)",
+		},
+		[]string{
+			"pipeline",
+			"state",
+		},
+	)
+	jobSlotQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "pachyderm",
+			Subsystem: "pps",
+			Name:      "job_slot_queue_depth",
+			Help:      "Number of jobs waiting for a job slot, across all pipelines, when the number of jobs that may run at once is limited",
+		},
+	)
+	datumSchedulingLatency = newLatencyCollector(prometheus.NewDesc(
+		"pachyderm_pps_datum_scheduling_latency_seconds",
+		"Time from a chunk of datums being dispatched (when its job is planned) to a worker picking it up",
+		[]string{"pipeline"},
+		nil,
+	))
+
+	// unfinishedJobStates are the states reported by jobQueueDepth
+	unfinishedJobStates = []pps.JobState{
+		pps.JobState_JOB_STARTING,
+		pps.JobState_JOB_RUNNING,
+		pps.JobState_JOB_MERGING,
+	}
+)
+
+// registerSchedulingMetrics registers the metrics updated by
+// monitorSchedulingMetrics and monitorJobSlots, which are served on pachd's metrics endpoint
+func registerSchedulingMetrics() {
+	for _, metric := range []prometheus.Collector{
+		queuedDatums,
+		jobQueueDepth,
+		jobSlotQueueDepth,
+		datumSchedulingLatency,
+	} {
+		if err := prometheus.Register(metric); err != nil {
+			log.Infof("error registering prometheus metric: %v", err)
+		}
+	}
+}
+
+// jobStateLabel returns the value of jobQueueDepth's "state" label for 'state'
+func jobStateLabel(state pps.JobState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "JOB_"))
+}
+
+// latencyCollector is a Prometheus collector for a histogram whose
+// observations are made by workers. Each worker reports a histogram of all of
+// its observations in its status, and latencyCollector adds up the
+// observations that each of a pipeline's workers made since they were last
+// reported, so that every observation is counted exactly once.
+type latencyCollector struct {
+	desc *prometheus.Desc
+
+	mu sync.Mutex
+	// totals holds each pipeline's histogram
+	totals map[string]*latencyTotals
+	// reported holds the histogram that each of a pipeline's workers last
+	// reported, by pipeline and then by worker
+	reported map[string]map[string]*pps.Histogram
+}
+
+// latencyTotals is a cumulative histogram, with counts by bucket upper bound
+type latencyTotals struct {
+	buckets map[float64]uint64
+	count   uint64
+	sum     float64
+}
+
+func newLatencyCollector(desc *prometheus.Desc) *latencyCollector {
+	return &latencyCollector{
+		desc:     desc,
+		totals:   make(map[string]*latencyTotals),
+		reported: make(map[string]map[string]*pps.Histogram),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *latencyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *latencyCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for pipeline, totals := range c.totals {
+		metric, err := prometheus.NewConstHistogram(c.desc, totals.count, totals.sum, totals.buckets, pipeline)
+		if err != nil {
+			log.Errorf("error collecting %s: %v", c.desc, err)
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// update adds the observations that the workers with 'statuses', which are
+// all of the workers of 'pipeline', have made since they were last reported.
+func (c *latencyCollector) update(pipeline string, statuses []*pps.WorkerStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	totals, ok := c.totals[pipeline]
+	if !ok {
+		totals = &latencyTotals{buckets: make(map[float64]uint64)}
+		c.totals[pipeline] = totals
+	}
+	reported := make(map[string]*pps.Histogram)
+	for _, status := range statuses {
+		h := status.SchedulingLatency
+		if h == nil || len(h.BucketCounts) != len(h.UpperBounds) {
+			continue // the worker hasn't picked up a chunk yet
+		}
+		reported[status.WorkerID] = h
+		prev := c.reported[pipeline][status.WorkerID]
+		if prev != nil && (prev.Count > h.Count || len(prev.BucketCounts) != len(h.BucketCounts)) {
+			prev = nil // the worker restarted, so its histogram was reset
+		}
+		for i, upperBound := range h.UpperBounds {
+			totals.buckets[upperBound] += h.BucketCounts[i]
+			if prev != nil {
+				totals.buckets[upperBound] -= prev.BucketCounts[i]
+			}
+		}
+		totals.count += h.Count
+		totals.sum += h.Sum
+		if prev != nil {
+			totals.count -= prev.Count
+			totals.sum -= prev.Sum
+		}
+	}
+	// Forget workers that have gone away
+	c.reported[pipeline] = reported
+}
+
+// schedulingMetrics updates the scheduling metrics of a pipeline
+type schedulingMetrics struct {
+	pipeline string
+}
+
+func newSchedulingMetrics(pipeline string) *schedulingMetrics {
+	return &schedulingMetrics{
+		pipeline: pipeline,
+	}
+}
+
+// update updates the pipeline's metrics from 'jobPtrs', its unfinished jobs,
+// and 'statuses', the statuses of its workers.
+func (m *schedulingMetrics) update(jobPtrs []*pps.EtcdJobInfo, statuses []*pps.WorkerStatus) {
+	depth := make(map[pps.JobState]int)
+	var queued int64
+	for _, jobPtr := range jobPtrs {
+		depth[jobPtr.State]++
+		if jobPtr.State == pps.JobState_JOB_RUNNING {
+			if remaining := jobPtr.DataTotal - jobPtr.DataProcessed - jobPtr.DataSkipped - jobPtr.DataFailed; remaining > 0 {
+				queued += remaining
+			}
+		}
+	}
+	for _, state := range unfinishedJobStates {
+		jobQueueDepth.WithLabelValues(m.pipeline, jobStateLabel(state)).Set(float64(depth[state]))
+	}
+	queuedDatums.WithLabelValues(m.pipeline).Set(float64(queued))
+	datumSchedulingLatency.update(m.pipeline, statuses)
+}
+
+// clear removes the pipeline's gauges, once it's no longer monitored. Its
+// scheduling latencies are kept, as they're cumulative.
+func (m *schedulingMetrics) clear() {
+	queuedDatums.DeleteLabelValues(m.pipeline)
+	for _, state := range unfinishedJobStates {
+		jobQueueDepth.DeleteLabelValues(m.pipeline, jobStateLabel(state))
+	}
+}
+
+// monitorSchedulingMetrics updates the scheduling metrics of the pipeline
+// 'pipelineInfo' every schedulingMetricsInterval, until pachClient's context
+// is cancelled. It's run by monitorPipeline.
+func (a *apiServer) monitorSchedulingMetrics(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	ctx := pachClient.Ctx()
+	m := newSchedulingMetrics(pipelineInfo.Pipeline.Name)
+	defer m.clear()
+	for {
+		var jobPtrs []*pps.EtcdJobInfo
+		jobPtr := &pps.EtcdJobInfo{}
+		if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipelineInfo.Pipeline, jobPtr, col.DefaultOptions, func(string) error {
+			if !ppsutil.IsTerminal(jobPtr.State) {
+				jobPtrs = append(jobPtrs, proto.Clone(jobPtr).(*pps.EtcdJobInfo))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		statuses, err := workerpkg.PipelineStatus(ctx, pipelineInfo.Pipeline.Name, a.etcdClient, a.etcdPrefix)
+		if err != nil {
+			return err
+		}
+		m.update(jobPtrs, statuses)
+		select {
+		case <-time.After(schedulingMetricsInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// monitorJobSlots updates jobSlotQueueDepth every schedulingMetricsInterval,
+// until 'ctx' is cancelled. It's run by master if the number of jobs that may
+// run at once is limited.
+func (a *apiServer) monitorJobSlots(ctx context.Context) error {
+	defer jobSlotQueueDepth.Set(0)
+	for {
+		depth, err := workerpkg.JobSlotQueueDepth(ctx, a.etcdClient, a.etcdPrefix, a.maxConcurrentJobs)
+		if err != nil {
+			return err
+		}
+		jobSlotQueueDepth.Set(float64(depth))
+		select {
+		case <-time.After(schedulingMetricsInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	var metric dto.Metric
+	require.NoError(t, gauge.Write(&metric))
+	return metric.GetGauge().GetValue()
+}
+
+func latencies(t *testing.T, pipeline string) (uint64, float64) {
+	ch := make(chan prometheus.Metric, 100)
+	datumSchedulingLatency.Collect(ch)
+	close(ch)
+	for m := range ch {
+		var metric dto.Metric
+		require.NoError(t, m.Write(&metric))
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "pipeline" && label.GetValue() == pipeline {
+				return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+	return 0, 0
+}
+
+// latencyStatus returns the status of a worker that has picked up chunks with
+// the scheduling latencies 'latencies'
+func latencyStatus(worker string, latencies ...float64) *pps.WorkerStatus {
+	h := &pps.Histogram{
+		UpperBounds:  []float64{1, 10},
+		BucketCounts: make([]uint64, 2),
+	}
+	for _, latency := range latencies {
+		for i, upperBound := range h.UpperBounds {
+			if latency <= upperBound {
+				h.BucketCounts[i]++
+			}
+		}
+		h.Count++
+		h.Sum += latency
+	}
+	return &pps.WorkerStatus{
+		WorkerID:          worker,
+		SchedulingLatency: h,
+	}
+}
+
+func TestSchedulingMetrics(t *testing.T) {
+	pipeline := "TestSchedulingMetrics"
+	m := newSchedulingMetrics(pipeline)
+	depth := func(state pps.JobState) float64 {
+		return gaugeValue(t, jobQueueDepth.WithLabelValues(pipeline, jobStateLabel(state)))
+	}
+
+	// Two jobs are queued, and no worker has picked up a chunk
+	m.update([]*pps.EtcdJobInfo{
+		{State: pps.JobState_JOB_STARTING},
+		{State: pps.JobState_JOB_STARTING},
+	}, []*pps.WorkerStatus{{WorkerID: "worker-1"}})
+	require.Equal(t, float64(2), depth(pps.JobState_JOB_STARTING))
+	require.Equal(t, float64(0), depth(pps.JobState_JOB_RUNNING))
+	require.Equal(t, float64(0), gaugeValue(t, queuedDatums.WithLabelValues(pipeline)))
+	count, _ := latencies(t, pipeline)
+	require.Equal(t, uint64(0), count)
+
+	// One job is running, with some of its datums done, and a worker has
+	// picked up one of its chunks
+	status := latencyStatus("worker-1", 2)
+	jobPtrs := []*pps.EtcdJobInfo{
+		{State: pps.JobState_JOB_RUNNING, DataTotal: 10, DataProcessed: 3, DataSkipped: 1, DataFailed: 1},
+		{State: pps.JobState_JOB_STARTING},
+	}
+	m.update(jobPtrs, []*pps.WorkerStatus{status})
+	require.Equal(t, float64(1), depth(pps.JobState_JOB_STARTING))
+	require.Equal(t, float64(1), depth(pps.JobState_JOB_RUNNING))
+	require.Equal(t, float64(5), gaugeValue(t, queuedDatums.WithLabelValues(pipeline)))
+	count, sum := latencies(t, pipeline)
+	require.Equal(t, uint64(1), count)
+	require.Equal(t, float64(2), sum)
+
+	// The same chunk is only observed once, even if the pipeline's monitor
+	// restarts
+	m.update(jobPtrs, []*pps.WorkerStatus{status})
+	newSchedulingMetrics(pipeline).update(jobPtrs, []*pps.WorkerStatus{status})
+	count, _ = latencies(t, pipeline)
+	require.Equal(t, uint64(1), count)
+
+	// Every chunk picked up between updates is observed
+	m.update(jobPtrs, []*pps.WorkerStatus{
+		latencyStatus("worker-1", 2, 5, 20),
+		latencyStatus("worker-2", 3),
+	})
+	count, sum = latencies(t, pipeline)
+	require.Equal(t, uint64(4), count)
+	require.Equal(t, float64(30), sum)
+
+	// A worker that restarts starts counting from zero
+	m.update(jobPtrs, []*pps.WorkerStatus{
+		latencyStatus("worker-1", 1),
+		latencyStatus("worker-2", 3),
+	})
+	count, sum = latencies(t, pipeline)
+	require.Equal(t, uint64(5), count)
+	require.Equal(t, float64(31), sum)
+
+	// The gauges are removed once the pipeline isn't monitored
+	m.clear()
+	require.Equal(t, float64(0), gaugeValue(t, queuedDatums.WithLabelValues(pipeline)))
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+// RunPipeline implements the protobuf pps.RunPipeline RPC
+func (a *apiServer) RunPipeline(ctx context.Context, request *pps.RunPipelineRequest) (response *pps.RunPipelineResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.getPachClient().WithCtx(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+
+	pipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	// check if the caller is authorized to update this pipeline
+	if err := a.authorizePipelineOp(pachClient, pipelineOpUpdate, pipelineInfo.Input, pipelineInfo.Pipeline.Name); err != nil {
+		return nil, err
+	}
+	if pipelineInfo.Service != nil {
+		return nil, fmt.Errorf("services don't run jobs, so their parallelism can't be raised for a job")
+	}
+	if request.Parallelism <= 0 {
+		return nil, fmt.Errorf("parallelism must be positive")
+	}
+	// Jobs never run with fewer workers than the pipeline has, so a lower
+	// parallelism would have no effect
+	workers, err := ppsutil.GetExpectedNumWorkers(a.kubeClient, pipelineInfo.ParallelismSpec)
+	if err != nil {
+		return nil, err
+	}
+	if request.Parallelism <= int64(workers) {
+		return nil, fmt.Errorf("parallelism must be more than the %d workers that pipeline %s already has", workers, request.Pipeline.Name)
+	}
+
+	// The parallelism applies to the pipeline's oldest job that's waiting to
+	// start (e.g. for its inputs, or for a job slot), if it has one. Jobs are
+	// listed newest first.
+	var waitingJob *pps.Job
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipelineInfo.Pipeline, jobPtr, col.DefaultOptions, func(string) error {
+		if jobPtr.State == pps.JobState_JOB_STARTING {
+			waitingJob = jobPtr.Job
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	response = &pps.RunPipelineResponse{}
+	if _, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+		response.Job = nil
+		if waitingJob != nil {
+			jobs := a.jobs.ReadWrite(stm)
+			jobPtr := &pps.EtcdJobInfo{}
+			if err := jobs.Get(waitingJob.ID, jobPtr); err != nil && !col.IsErrNotFound(err) {
+				return err
+			}
+			// The job may have started since it was listed, in which case the
+			// parallelism applies to the next job instead
+			if jobPtr.State == pps.JobState_JOB_STARTING && jobPtr.Job != nil {
+				jobPtr.Parallelism = request.Parallelism
+				response.Job = waitingJob
+				return jobs.Put(waitingJob.ID, jobPtr)
+			}
+		}
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		return a.pipelines.ReadWrite(stm).Update(request.Pipeline.Name, pipelinePtr, func() error {
+			pipelinePtr.NextJobParallelism = request.Parallelism
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// setParallelismBoost records that 'pipelineName' has an unfinished job that
+// runs with 'workers' workers (or none, if 'workers' is 0)
+func (a *apiServer) setParallelismBoost(pipelineName string, workers int64) {
+	a.boostMu.Lock()
+	defer a.boostMu.Unlock()
+	if workers > 0 {
+		a.parallelismBoosts[pipelineName] = workers
+	} else {
+		delete(a.parallelismBoosts, pipelineName)
+	}
+}
+
+// parallelismBoost returns the most workers that an unfinished job of
+// 'pipelineName' runs with, or 0 if none of its jobs set their parallelism
+func (a *apiServer) parallelismBoost(pipelineName string) int64 {
+	a.boostMu.Lock()
+	defer a.boostMu.Unlock()
+	return a.parallelismBoosts[pipelineName]
+}
+
+// boostedWorkers returns the number of workers that 'pipelineInfo' should
+// have, given that its parallelism_spec gives it 'parallelism' workers. While
+// one of its jobs runs with more workers (see RunPipeline), it has that many.
+func (a *apiServer) boostedWorkers(pipelineInfo *pps.PipelineInfo, parallelism int64) int64 {
+	if boost := a.parallelismBoost(pipelineInfo.Pipeline.Name); boost > parallelism {
+		return boost
+	}
+	return parallelism
+}
+
+// parallelismBoosts tracks the unfinished jobs that run with more workers
+// than their pipeline's parallelism_spec sets (see RunPipeline), so that the
+// most workers that each pipeline's jobs run with can be found without
+// listing its jobs
+type parallelismBoosts struct {
+	jobs map[string]*pps.EtcdJobInfo // job ID -> job
+}
+
+// update records the new state of the job 'jobID' ('jobPtr' is nil if it was
+// deleted), and returns the pipeline whose jobs it belongs to, if it may have
+// changed how many workers the pipeline's jobs run with
+func (b *parallelismBoosts) update(jobID string, jobPtr *pps.EtcdJobInfo) string {
+	prev := b.jobs[jobID]
+	if jobPtr == nil || ppsutil.IsTerminal(jobPtr.State) || jobPtr.Parallelism <= 0 {
+		delete(b.jobs, jobID)
+	} else {
+		b.jobs[jobID] = jobPtr
+	}
+	switch {
+	case prev != nil:
+		return prev.Pipeline.Name
+	case b.jobs[jobID] != nil:
+		return jobPtr.Pipeline.Name
+	}
+	return ""
+}
+
+// max returns the most workers that an unfinished job of 'pipelineName' runs
+// with, or 0 if none of them set their parallelism
+func (b *parallelismBoosts) max(pipelineName string) int64 {
+	var max int64
+	for _, jobPtr := range b.jobs {
+		if jobPtr.Pipeline.Name == pipelineName && jobPtr.Parallelism > max {
+			max = jobPtr.Parallelism
+		}
+	}
+	return max
+}
+
+// monitorParallelismBoosts scales each pipeline's workers up while one of its
+// jobs runs with more workers than its parallelism_spec sets (see
+// RunPipeline), and back down once the job is finished. Pipelines are only
+// rescaled when one of their jobs is updated in a way that changes this. It's
+// a helper function called by master.
+func (a *apiServer) monitorParallelismBoosts(pachClient *client.APIClient) error {
+	ctx := pachClient.Ctx()
+	// Boosts are found again from the pipelines' jobs if the watch is restarted
+	defer func() {
+		a.boostMu.Lock()
+		defer a.boostMu.Unlock()
+		a.parallelismBoosts = make(map[string]int64)
+	}()
+	jobWatcher, err := a.jobs.ReadOnly(ctx).Watch()
+	if err != nil {
+		return fmt.Errorf("error creating job watch: %v", err)
+	}
+	defer jobWatcher.Close()
+	boosts := &parallelismBoosts{jobs: make(map[string]*pps.EtcdJobInfo)}
+	for {
+		select {
+		case event, ok := <-jobWatcher.Watch():
+			if !ok {
+				return fmt.Errorf("job watch closed unexpectedly")
+			}
+			var jobID string
+			var jobPtr *pps.EtcdJobInfo
+			switch event.Type {
+			case watch.EventError:
+				return event.Err
+			case watch.EventDelete:
+				jobID = string(event.Key)
+			case watch.EventPut:
+				jobPtr = &pps.EtcdJobInfo{}
+				if err := event.Unmarshal(&jobID, jobPtr); err != nil {
+					return err
+				}
+			}
+			pipelineName := boosts.update(jobID, jobPtr)
+			if pipelineName == "" {
+				continue
+			}
+			if boost, prev := boosts.max(pipelineName), a.parallelismBoost(pipelineName); boost != prev {
+				log.Infof("PPS master: pipeline %s has a job that runs with %d workers (previously %d)", pipelineName, boost, prev)
+				a.setParallelismBoost(pipelineName, boost)
+				pipelineInfo, err := a.inspectPipeline(pachClient, pipelineName)
+				if err != nil {
+					if col.IsErrNotFound(err) {
+						continue // the pipeline was deleted
+					}
+					return err
+				}
+				if err := a.rescaleRunningPipeline(ctx, pipelineInfo); err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// rescaleRunningPipeline scales 'pipelineInfo's workers to the number that
+// it should have, if it's running. Pipelines in other states (e.g. standby)
+// are scaled when they start running.
+func (a *apiServer) rescaleRunningPipeline(ctx context.Context, pipelineInfo *pps.PipelineInfo) error {
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	if err := a.pipelines.ReadOnly(ctx).Get(pipelineInfo.Pipeline.Name, pipelinePtr); err != nil {
+		return err
+	}
+	if pipelinePtr.State != pps.PipelineState_PIPELINE_RUNNING {
+		return nil
+	}
+	return a.scaleUpWorkersForPipeline(pipelineInfo)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestParallelismBoost(t *testing.T) {
+	job := func(pipelineName string, state pps.JobState, parallelism int64) *pps.EtcdJobInfo {
+		return &pps.EtcdJobInfo{
+			Pipeline:    client.NewPipeline(pipelineName),
+			State:       state,
+			Parallelism: parallelism,
+		}
+	}
+	boosts := &parallelismBoosts{jobs: make(map[string]*pps.EtcdJobInfo)}
+	require.Equal(t, int64(0), boosts.max("pipeline"))
+	// Only unfinished jobs boost their pipeline's parallelism
+	require.Equal(t, "", boosts.update("1", job("pipeline", pps.JobState_JOB_SUCCESS, 10)))
+	require.Equal(t, "", boosts.update("2", job("pipeline", pps.JobState_JOB_STARTING, 0)))
+	require.Equal(t, "pipeline", boosts.update("3", job("pipeline", pps.JobState_JOB_RUNNING, 5)))
+	require.Equal(t, "pipeline", boosts.update("4", job("pipeline", pps.JobState_JOB_STARTING, 2)))
+	require.Equal(t, "other", boosts.update("5", job("other", pps.JobState_JOB_STARTING, 20)))
+	require.Equal(t, int64(5), boosts.max("pipeline"))
+	// Boosts end when their jobs finish or are deleted
+	require.Equal(t, "pipeline", boosts.update("3", job("pipeline", pps.JobState_JOB_SUCCESS, 5)))
+	require.Equal(t, int64(2), boosts.max("pipeline"))
+	require.Equal(t, "pipeline", boosts.update("4", nil))
+	require.Equal(t, int64(0), boosts.max("pipeline"))
+	require.Equal(t, "", boosts.update("4", nil))
+	require.Equal(t, int64(20), boosts.max("other"))
+
+	a := &apiServer{
+		idlePipelines:     make(map[string]bool),
+		parallelismBoosts: make(map[string]int64),
+	}
+	pipelineInfo := &pps.PipelineInfo{Pipeline: client.NewPipeline("pipeline")}
+	require.Equal(t, int64(2), a.boostedWorkers(pipelineInfo, 2))
+	a.setParallelismBoost("pipeline", 10)
+	require.Equal(t, int64(10), a.boostedWorkers(pipelineInfo, 2))
+	require.Equal(t, int64(10), a.idleWorkers(pipelineInfo, a.boostedWorkers(pipelineInfo, 2)))
+	// A boost never scales a pipeline down
+	require.Equal(t, int64(20), a.boostedWorkers(pipelineInfo, 20))
+	a.setParallelismBoost("pipeline", 0)
+	require.Equal(t, int64(2), a.boostedWorkers(pipelineInfo, 2))
+}
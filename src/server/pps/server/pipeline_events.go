@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"path"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+// pipelineEvent returns the event for a pipeline changing from 'prev' to
+// 'pipelinePtr', or nil if the change isn't one that events are published
+// for. 'prev' is nil if the pipeline was just created, and 'pipelinePtr' is
+// nil if it was deleted. A pipeline is updated when its spec commit changes;
+// other changes (e.g. to its state) don't publish an event.
+func pipelineEvent(pipelineName string, prev *pps.EtcdPipelineInfo, pipelinePtr *pps.EtcdPipelineInfo) *events.Event {
+	switch {
+	case pipelinePtr == nil:
+		return events.NewPipelineEvent(events.PipelineDeleted, pipelineName)
+	case prev == nil:
+		return events.NewPipelineEvent(events.PipelineCreated, pipelineName)
+	case prev.SpecCommit.GetID() != pipelinePtr.SpecCommit.GetID():
+		return events.NewPipelineEvent(events.PipelineUpdated, pipelineName)
+	}
+	return nil
+}
+
+// pipelineEventsRevisionKey is the etcd key (under the PPS etcd prefix) that
+// holds the revision of the last pipeline change whose event was published
+// (see jobEventsRevisionKey)
+const pipelineEventsRevisionKey = "pipeline_events_revision"
+
+// publishPipelineEvents publishes an event each time a pipeline is created,
+// updated or deleted. Like publishJobEvents, it's called by master, and
+// resumes from the revision stored at pipelineEventsRevisionKey.
+func (a *apiServer) publishPipelineEvents(ctx context.Context) error {
+	return events.PublishWatched(ctx, a.etcdClient, path.Join(a.etcdPrefix, pipelineEventsRevisionKey),
+		a.pipelines.ReadOnly(ctx).WatchWithPrevFrom,
+		func(event *watch.Event) (*events.Event, error) {
+			if event.Type == watch.EventDelete {
+				return pipelineEvent(string(event.Key), nil, nil), nil
+			}
+			var pipelineName string
+			pipelinePtr := &pps.EtcdPipelineInfo{}
+			if err := event.Unmarshal(&pipelineName, pipelinePtr); err != nil {
+				return nil, err
+			}
+			var prev *pps.EtcdPipelineInfo
+			if event.PrevKey != nil {
+				prev = &pps.EtcdPipelineInfo{}
+				if err := event.UnmarshalPrev(&pipelineName, prev); err != nil {
+					return nil, err
+				}
+			}
+			return pipelineEvent(pipelineName, prev, pipelinePtr), nil
+		})
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
+)
+
+func TestPipelineEvent(t *testing.T) {
+	v1 := &pps.EtcdPipelineInfo{SpecCommit: client.NewCommit("spec", "1"), State: pps.PipelineState_PIPELINE_STARTING}
+	event := pipelineEvent("edges", nil, v1)
+	require.Equal(t, events.PipelineCreated, event.Type)
+	require.Equal(t, "edges", event.Pipeline)
+
+	// A change that doesn't update the pipeline's spec doesn't publish an
+	// event
+	running := &pps.EtcdPipelineInfo{SpecCommit: client.NewCommit("spec", "1"), State: pps.PipelineState_PIPELINE_RUNNING}
+	require.True(t, pipelineEvent("edges", v1, running) == nil)
+
+	v2 := &pps.EtcdPipelineInfo{SpecCommit: client.NewCommit("spec", "2"), State: pps.PipelineState_PIPELINE_RUNNING}
+	require.Equal(t, events.PipelineUpdated, pipelineEvent("edges", running, v2).Type)
+	require.Equal(t, events.PipelineDeleted, pipelineEvent("edges", nil, nil).Type)
+}
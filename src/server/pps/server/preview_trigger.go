@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	workerpkg "github.com/pachyderm/pachyderm/src/server/worker"
+)
+
+// inputBranches returns the branches (as "repo@branch") that 'input' reads
+// from, which trigger its pipeline when new commits are finished on them
+func inputBranches(input *pps.Input) []string {
+	var result []string
+	pps.VisitInput(input, func(input *pps.Input) {
+		switch {
+		case input.Atom != nil:
+			result = append(result, input.Atom.Repo+"@"+input.Atom.Branch)
+		case input.Pfs != nil:
+			result = append(result, input.Pfs.Repo+"@"+pps.PFSInputBranch(input.Pfs))
+		}
+	})
+	return result
+}
+
+// triggeredPipelines returns the pipelines in 'pipelineInfos' that a new
+// commit on 'branches' of 'repo' would trigger, either directly or through
+// the output of other triggered pipelines, with each pipeline after the
+// pipelines that trigger it. Datums aren't estimated.
+func triggeredPipelines(pipelineInfos []*pps.PipelineInfo, repo string, branches []string) []*pps.TriggeredPipeline {
+	consumers := make(map[string][]*pps.PipelineInfo)
+	for _, pipelineInfo := range pipelineInfos {
+		for _, branch := range inputBranches(pipelineInfo.Input) {
+			consumers[branch] = append(consumers[branch], pipelineInfo)
+		}
+	}
+	var queue []string
+	for _, branch := range branches {
+		queue = append(queue, repo+"@"+branch)
+	}
+	var result []*pps.TriggeredPipeline
+	triggered := make(map[string]bool)
+	for direct := true; len(queue) > 0; direct = false {
+		var next []string
+		for _, branch := range queue {
+			for _, pipelineInfo := range consumers[branch] {
+				if triggered[pipelineInfo.Pipeline.Name] {
+					continue
+				}
+				triggered[pipelineInfo.Pipeline.Name] = true
+				result = append(result, &pps.TriggeredPipeline{
+					Pipeline: pipelineInfo.Pipeline,
+					Direct:   direct,
+					Stopped:  pipelineInfo.Stopped,
+				})
+				next = append(next, pipelineInfo.Pipeline.Name+"@"+pipelineInfo.OutputBranch)
+			}
+		}
+		queue = next
+	}
+	return result
+}
+
+// commitBranches returns the branches of the commit 'commit' is at the head of
+func commitBranches(pachClient *client.APIClient, commit *pfs.Commit) ([]string, error) {
+	commitInfo, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+	branchInfos, err := pachClient.ListBranch(commit.Repo.Name)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, branchInfo := range branchInfos {
+		if branchInfo.Head != nil && branchInfo.Head.ID == commitInfo.Commit.ID {
+			result = append(result, branchInfo.Branch.Name)
+		}
+	}
+	return result, nil
+}
+
+func (a *apiServer) PreviewTrigger(ctx context.Context, request *pps.PreviewTriggerRequest) (response *pps.PreviewTriggerResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.getPachClient().WithCtx(ctx)
+	if err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	if request.Commit == nil || request.Commit.Repo == nil {
+		return nil, fmt.Errorf("must specify a commit")
+	}
+	// Only commits at the head of a branch trigger pipelines when they're
+	// finished
+	branches, err := commitBranches(pachClient, request.Commit)
+	if err != nil {
+		return nil, err
+	}
+	pipelineInfos, err := pachClient.ListPipeline()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*pps.PipelineInfo)
+	for _, pipelineInfo := range pipelineInfos {
+		byName[pipelineInfo.Pipeline.Name] = pipelineInfo
+	}
+	response = &pps.PreviewTriggerResponse{
+		Pipelines: triggeredPipelines(pipelineInfos, request.Commit.Repo.Name, branches),
+	}
+	for _, triggered := range response.Pipelines {
+		// The previewed commit is counted even though it isn't finished yet,
+		// while other inputs are counted at the heads of their branches
+		input, err := previewInput(pachClient, byName[triggered.Pipeline.Name].Input, []*pfs.Commit{request.Commit})
+		if err != nil {
+			return nil, err
+		}
+		if triggered.EstimatedDatums, err = workerpkg.CountDatums(pachClient, input); err != nil {
+			return nil, fmt.Errorf("error estimating the datums of pipeline %s: %v", triggered.Pipeline.Name, err)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestTriggeredPipelines(t *testing.T) {
+	pipeline := func(name string, input *pps.Input) *pps.PipelineInfo {
+		return &pps.PipelineInfo{
+			Pipeline:     client.NewPipeline(name),
+			Input:        input,
+			OutputBranch: "master",
+		}
+	}
+	// data -> edges -> montage <- images
+	//      \-> (dev branch) other
+	// images -> thumbs
+	pipelineInfos := []*pps.PipelineInfo{
+		pipeline("montage", client.NewCrossInput(
+			client.NewPFSInputOpts("", "edges", "master", "/", false),
+			client.NewPFSInputOpts("", "images", "master", "/", false),
+		)),
+		pipeline("edges", client.NewPFSInputOpts("", "data", "master", "/*", false)),
+		pipeline("other", client.NewPFSInputOpts("", "data", "dev", "/*", false)),
+		pipeline("thumbs", client.NewPFSInputOpts("", "images", "master", "/*", false)),
+	}
+	pipelineInfos[0].Stopped = true
+
+	triggered := triggeredPipelines(pipelineInfos, "data", []string{"master"})
+	require.Equal(t, 2, len(triggered))
+	require.Equal(t, "edges", triggered[0].Pipeline.Name)
+	require.True(t, triggered[0].Direct)
+	require.Equal(t, "montage", triggered[1].Pipeline.Name)
+	require.False(t, triggered[1].Direct)
+	require.True(t, triggered[1].Stopped)
+
+	// A commit that's on several branches triggers the pipelines of each
+	triggered = triggeredPipelines(pipelineInfos, "data", []string{"master", "dev"})
+	require.Equal(t, 3, len(triggered))
+
+	// Pipelines that are triggered by several inputs are only listed once
+	triggered = triggeredPipelines(pipelineInfos, "data", []string{"master", "master"})
+	require.Equal(t, 2, len(triggered))
+	triggered = triggeredPipelines(pipelineInfos, "images", []string{"master"})
+	require.Equal(t, 2, len(triggered))
+	require.True(t, triggered[0].Direct)
+	require.True(t, triggered[1].Direct)
+
+	require.Equal(t, 0, len(triggeredPipelines(pipelineInfos, "data", nil)))
+
+	// Deferred inputs are triggered by their trigger branch
+	deferred := client.NewPFSInputOpts("", "data", "master", "/*", false)
+	deferred.Pfs.Deferred = &pps.Deferred{Branch: "staging"}
+	pipelineInfos = append(pipelineInfos, pipeline("deferred", deferred))
+	triggered = triggeredPipelines(pipelineInfos, "data", []string{"staging"})
+	require.Equal(t, 1, len(triggered))
+	require.Equal(t, "deferred", triggered[0].Pipeline.Name)
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
@@ -10,6 +12,8 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kube_labels "k8s.io/apimachinery/pkg/labels"
 	kube "k8s.io/client-go/kubernetes"
 )
 
@@ -29,8 +33,60 @@ func NewAPIServer(
 	iamRole string,
 	imagePullSecret string,
 	noExposeDockerSocket bool,
+	workerBudget int64,
+	requireImageDigests bool,
+	maxJobDatums int64,
+	maxJobOutputBytes int64,
+	workerGracePeriod int64,
+	logRetentionMaxAge string,
+	logRetentionMaxBytes int64,
+	pipelineSpecConfigMapSelector string,
+	pipelineSpecConfigMapNames string,
+	pipelineSpecConfigMapToken string,
+	workerRcGCGracePeriod string,
+	pipelineInputCheckInterval string,
+	workerCPUPerWeight string,
+	workerMaxWeightedCPU string,
+	maxConcurrentJobs int64,
 	reporter *metrics.Reporter,
 ) (ppsclient.APIServer, error) {
+	if _, err := kube_labels.Parse(pipelineSpecConfigMapSelector); err != nil {
+		return nil, fmt.Errorf("invalid pipeline spec ConfigMap selector: %v", err)
+	}
+	configMapNames := make(map[string]bool)
+	for _, name := range strings.Split(pipelineSpecConfigMapNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			configMapNames[name] = true
+		}
+	}
+	var rcGCGracePeriod time.Duration
+	if workerRcGCGracePeriod != "" {
+		var err error
+		if rcGCGracePeriod, err = time.ParseDuration(workerRcGCGracePeriod); err != nil {
+			return nil, fmt.Errorf("invalid worker RC GC grace period: %v", err)
+		}
+	}
+	var inputCheckInterval time.Duration
+	if pipelineInputCheckInterval != "" {
+		var err error
+		if inputCheckInterval, err = time.ParseDuration(pipelineInputCheckInterval); err != nil {
+			return nil, fmt.Errorf("invalid pipeline input check interval: %v", err)
+		}
+	}
+	var cpuPerWeight resource.Quantity
+	if workerCPUPerWeight != "" {
+		var err error
+		if cpuPerWeight, err = resource.ParseQuantity(workerCPUPerWeight); err != nil {
+			return nil, fmt.Errorf("invalid worker CPU per weight: %v", err)
+		}
+	}
+	var maxWeightedCPU resource.Quantity
+	if workerMaxWeightedCPU != "" {
+		var err error
+		if maxWeightedCPU, err = resource.ParseQuantity(workerMaxWeightedCPU); err != nil {
+			return nil, fmt.Errorf("invalid worker max weighted CPU: %v", err)
+		}
+	}
 	etcdClient, err := etcd.New(etcd.Config{
 		Endpoints:   []string{etcdAddress},
 		DialOptions: client.DefaultDialOptions(),
@@ -40,27 +96,46 @@ func NewAPIServer(
 	}
 
 	apiServer := &apiServer{
-		Logger:                log.NewLogger("pps.API"),
-		etcdPrefix:            etcdPrefix,
-		address:               address,
-		etcdClient:            etcdClient,
-		kubeClient:            kubeClient,
-		namespace:             namespace,
-		workerImage:           workerImage,
-		workerSidecarImage:    workerSidecarImage,
-		workerImagePullPolicy: workerImagePullPolicy,
-		storageRoot:           storageRoot,
-		storageBackend:        storageBackend,
-		storageHostPath:       storageHostPath,
-		iamRole:               iamRole,
-		imagePullSecret:       imagePullSecret,
-		noExposeDockerSocket:  noExposeDockerSocket,
-		reporter:              reporter,
-		pipelines:             ppsdb.Pipelines(etcdClient, etcdPrefix),
-		jobs:                  ppsdb.Jobs(etcdClient, etcdPrefix),
-		monitorCancels:        make(map[string]func()),
+		Logger:                        log.NewLogger("pps.API"),
+		etcdPrefix:                    etcdPrefix,
+		address:                       address,
+		etcdClient:                    etcdClient,
+		kubeClient:                    kubeClient,
+		namespace:                     namespace,
+		workerImage:                   workerImage,
+		workerSidecarImage:            workerSidecarImage,
+		workerImagePullPolicy:         workerImagePullPolicy,
+		storageRoot:                   storageRoot,
+		storageBackend:                storageBackend,
+		storageHostPath:               storageHostPath,
+		iamRole:                       iamRole,
+		imagePullSecret:               imagePullSecret,
+		noExposeDockerSocket:          noExposeDockerSocket,
+		workerBudget:                  workerBudget,
+		requireImageDigests:           requireImageDigests,
+		maxJobDatums:                  maxJobDatums,
+		maxJobOutputBytes:             maxJobOutputBytes,
+		workerGracePeriod:             workerGracePeriod,
+		logRetentionMaxAge:            logRetentionMaxAge,
+		logRetentionMaxBytes:          logRetentionMaxBytes,
+		pipelineSpecConfigMapSelector: pipelineSpecConfigMapSelector,
+		pipelineSpecConfigMapNames:    configMapNames,
+		pipelineSpecConfigMapToken:    pipelineSpecConfigMapToken,
+		workerRcGCGracePeriod:         rcGCGracePeriod,
+		pipelineInputCheckInterval:    inputCheckInterval,
+		workerCPUPerWeight:            cpuPerWeight,
+		workerMaxWeightedCPU:          maxWeightedCPU,
+		maxConcurrentJobs:             maxConcurrentJobs,
+		reporter:                      reporter,
+		pipelines:                     ppsdb.Pipelines(etcdClient, etcdPrefix),
+		jobs:                          ppsdb.Jobs(etcdClient, etcdPrefix),
+		monitorCancels:                make(map[string]func()),
+		idlePipelines:                 make(map[string]bool),
+		parallelismBoosts:             make(map[string]int64),
+		infraRestarts:                 make(map[string]bool),
 	}
 	apiServer.validateKube()
+	registerSchedulingMetrics()
 	go apiServer.master() // calls a.getPachClient(), which initializes spec repo
 	return apiServer, nil
 }
@@ -3,22 +3,70 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	client "github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/enterprise"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/client/version"
 	"github.com/pachyderm/pachyderm/src/server/pkg/deploy/assets"
+	"github.com/pachyderm/pachyderm/src/server/pkg/events"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/statsd"
 	"github.com/pachyderm/pachyderm/src/server/worker"
 
 	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// storageCompressionEnvVars returns pachd's storage compression and key
+// layout settings, so that workers (and their sidecars) store the objects they
+// write the same way that pachd does
+func storageCompressionEnvVars() []v1.EnvVar {
+	var env []v1.EnvVar
+	for _, name := range []string{obj.StorageCompressionEnvVar, obj.StorageCompressionLevelEnvVar, obj.StorageKeyLayoutEnvVar} {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, v1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env
+}
+
+// statsdEnvVars returns pachd's StatsD configuration, so that workers push
+// their metrics to the same StatsD server as pachd
+func statsdEnvVars() []v1.EnvVar {
+	var env []v1.EnvVar
+	for _, name := range statsd.EnvVars {
+		if value := os.Getenv(name); value != "" {
+			env = append(env, v1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env
+}
+
+// eventEnvVars returns pachd's event sink configuration, so that sidecars
+// publish the events of the commits that they finish to the same queue as pachd
+func eventEnvVars() []v1.EnvVar {
+	var env []v1.EnvVar
+	for _, name := range events.EnvVars {
+		if value := os.Getenv(name); value != "" {
+			env = append(env, v1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env
+}
+
 // Parameters used when creating the kubernetes replication controller in charge
 // of a job or pipeline's workers
 type workerOptions struct {
@@ -27,6 +75,7 @@ type workerOptions struct {
 	userImage        string              // The user's pipeline/job image
 	labels           map[string]string   // k8s labels attached to the RC and workers
 	annotations      map[string]string   // k8s annotations attached to the RC and workers
+	podLabels        map[string]string   // extra k8s labels attached to the workers only
 	parallelism      int32               // Number of replicas the RC maintains
 	cacheSize        string              // Size of cache that sidecar uses
 	resourceRequests *v1.ResourceList    // Resources requested by pipeline/job pods
@@ -37,11 +86,25 @@ type workerOptions struct {
 	etcdPrefix       string              // the prefix in etcd to use
 	schedulingSpec   *pps.SchedulingSpec // the SchedulingSpec for the pipeline
 	podSpec          string
+	disruptionBudget *pps.DisruptionBudget // PodDisruptionBudget for the workers
 
 	// Secrets that we mount in the worker container (e.g. for reading/writing to
 	// s3)
 	imagePullSecrets []v1.LocalObjectReference
-	service          *pps.Service
+	// Pull policy for the user's image and the worker binary's image, if
+	// different from the cluster's default
+	imagePullPolicy string
+	service         *pps.Service
+	// The k8s service account that the workers run as, if not the default
+	serviceAccount string
+	// The version of the worker and sidecar images, if not pachd's own
+	// version (see pps.CreatePipelineRequest.WorkerVersion)
+	workerVersion string
+	// How the workers handle the preemption of their node, if they do
+	preemption *pps.Preemption
+	// How long the unary handlers of the workers' gRPC API may run, if not
+	// the workers' default (see pps.CreatePipelineRequest.WorkerHandlerTimeout)
+	handlerTimeout *types.Duration
 }
 
 func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
@@ -49,6 +112,10 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 	if pullPolicy == "" {
 		pullPolicy = "IfNotPresent"
 	}
+	userPullPolicy := pullPolicy
+	if options.imagePullPolicy != "" {
+		userPullPolicy = options.imagePullPolicy
+	}
 	sidecarEnv := []v1.EnvVar{{
 		Name:  "BLOCK_CACHE_BYTES",
 		Value: options.cacheSize,
@@ -63,9 +130,49 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 		Value: a.storageBackend,
 	}}
 	sidecarEnv = append(sidecarEnv, assets.GetSecretEnvVars(a.storageBackend)...)
+	sidecarEnv = append(sidecarEnv, storageCompressionEnvVars()...)
+	sidecarEnv = append(sidecarEnv, eventEnvVars()...)
+	if options.preemption != nil {
+		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: client.PPSPreemptionEnv, Value: "true"})
+	}
 	workerEnv := options.workerEnv
 	workerEnv = append(options.workerEnv, v1.EnvVar{Name: "PACH_ROOT", Value: a.storageRoot})
 	workerEnv = append(workerEnv, assets.GetSecretEnvVars(a.storageBackend)...)
+	workerEnv = append(workerEnv, storageCompressionEnvVars()...)
+	workerEnv = append(workerEnv, statsdEnvVars()...)
+	workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSPachdVersionEnv, Value: version.PrettyVersion()})
+	if a.maxJobDatums > 0 {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSMaxJobDatumsEnv, Value: strconv.FormatInt(a.maxJobDatums, 10)})
+	}
+	if a.maxConcurrentJobs > 0 {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSMaxConcurrentJobsEnv, Value: strconv.FormatInt(a.maxConcurrentJobs, 10)})
+	}
+	if a.maxJobOutputBytes > 0 {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSMaxJobOutputBytesEnv, Value: strconv.FormatInt(a.maxJobOutputBytes, 10)})
+	}
+	if compression, ok := os.LookupEnv(client.WorkerCompressionEnv); ok {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.WorkerCompressionEnv, Value: compression})
+	}
+	if dedupScope, ok := os.LookupEnv(client.PFSDedupScopeEnv); ok {
+		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: client.PFSDedupScopeEnv, Value: dedupScope})
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PFSDedupScopeEnv, Value: dedupScope})
+	}
+	if a.workerGracePeriod > 0 {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSWorkerGracePeriodEnv, Value: strconv.FormatInt(a.workerGracePeriod, 10)})
+	}
+	if a.logRetentionMaxAge != "" {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSLogRetentionMaxAgeEnv, Value: a.logRetentionMaxAge})
+	}
+	if a.logRetentionMaxBytes > 0 {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: client.PPSLogRetentionMaxBytesEnv, Value: strconv.FormatInt(a.logRetentionMaxBytes, 10)})
+	}
+	if options.handlerTimeout != nil {
+		envVar, err := handlerTimeoutEnvVar(options.handlerTimeout)
+		if err != nil {
+			return v1.PodSpec{}, err
+		}
+		workerEnv = append(workerEnv, envVar)
+	}
 	// This only happens in local deployment.  We want the workers to be
 	// able to read from/write to the hostpath volume as well.
 	storageVolumeName := "pach-disk"
@@ -115,6 +222,11 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 	}
 	zeroVal := int64(0)
 	workerImage := a.workerImage
+	sidecarImage := a.workerSidecarImage
+	if options.workerVersion != "" {
+		workerImage = imageWithVersion(workerImage, options.workerVersion)
+		sidecarImage = imageWithVersion(sidecarImage, options.workerVersion)
+	}
 	resp, err := a.getPachClient().Enterprise.GetState(context.Background(), &enterprise.GetStateRequest{})
 	if err != nil {
 		return v1.PodSpec{}, err
@@ -128,7 +240,7 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 				Name:            "init",
 				Image:           workerImage,
 				Command:         []string{"/pach/worker.sh"},
-				ImagePullPolicy: v1.PullPolicy(pullPolicy),
+				ImagePullPolicy: v1.PullPolicy(userPullPolicy),
 				VolumeMounts:    options.volumeMounts,
 			},
 		},
@@ -137,7 +249,7 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 				Name:            client.PPSWorkerUserContainerName,
 				Image:           options.userImage,
 				Command:         []string{"/pach-bin/worker"},
-				ImagePullPolicy: v1.PullPolicy(pullPolicy),
+				ImagePullPolicy: v1.PullPolicy(userPullPolicy),
 				Env:             workerEnv,
 				Resources: v1.ResourceRequirements{
 					Requests: map[v1.ResourceName]resource.Quantity{
@@ -149,7 +261,7 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 			},
 			{
 				Name:            client.PPSWorkerSidecarContainerName,
-				Image:           a.workerSidecarImage,
+				Image:           sidecarImage,
 				Command:         []string{"/pachd", "--mode", "sidecar"},
 				ImagePullPolicy: v1.PullPolicy(pullPolicy),
 				Env:             sidecarEnv,
@@ -168,10 +280,26 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 		TerminationGracePeriodSeconds: &zeroVal,
 		SecurityContext:               &v1.PodSecurityContext{RunAsUser: &zeroVal},
 	}
+	if options.serviceAccount != "" {
+		// Workload identity integrations give pods credentials through their
+		// service account's token, so it must be mounted
+		automountToken := true
+		podSpec.ServiceAccountName = options.serviceAccount
+		podSpec.AutomountServiceAccountToken = &automountToken
+	}
 	if options.schedulingSpec != nil {
 		podSpec.NodeSelector = options.schedulingSpec.NodeSelector
 		podSpec.PriorityClassName = options.schedulingSpec.PriorityClassName
 	}
+	if options.preemption != nil {
+		// Give workers time to drain when their node is preempted
+		gracePeriod, err := worker.PreemptionGracePeriod(options.preemption)
+		if err != nil {
+			return v1.PodSpec{}, err
+		}
+		gracePeriodSeconds := int64(gracePeriod / time.Second)
+		podSpec.TerminationGracePeriodSeconds = &gracePeriodSeconds
+	}
 	resourceRequirements := v1.ResourceRequirements{
 		Requests: map[v1.ResourceName]resource.Quantity{
 			v1.ResourceCPU:    cpuZeroQuantity,
@@ -184,6 +312,7 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 	if options.resourceLimits != nil {
 		resourceRequirements.Limits = *options.resourceLimits
 	}
+	resourceRequirements.Requests = a.weightedResourceRequests(resourceRequirements.Requests, resourceRequirements.Limits, options.schedulingSpec)
 	podSpec.Containers[0].Resources = resourceRequirements
 	if options.podSpec != "" {
 		if err := json.Unmarshal([]byte(options.podSpec), &podSpec); err != nil {
@@ -193,10 +322,38 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 	return podSpec, nil
 }
 
+// handlerTimeoutEnvVar returns the env var that sets the workers' handler
+// timeout to 'timeout', in the format that the worker parses (a timeout of 0
+// becomes "0s", which disables it)
+func handlerTimeoutEnvVar(timeout *types.Duration) (v1.EnvVar, error) {
+	d, err := types.DurationFromProto(timeout)
+	if err != nil {
+		return v1.EnvVar{}, fmt.Errorf("invalid worker_handler_timeout: %v", err)
+	}
+	return v1.EnvVar{Name: client.PPSWorkerHandlerTimeoutEnv, Value: d.String()}, nil
+}
+
+// imageWithVersion returns 'image' with its tag replaced by the pachyderm
+// version 'v', e.g. "pachyderm/worker:1.8.1" becomes "pachyderm/worker:1.9.0".
+// A digest, if 'image' has one, is dropped, as it identifies the image of a
+// single version, which would take precedence over the tag (pipelines can't
+// set a worker_version if the cluster's images have digests, see
+// validateWorkerVersion, but pipelines created before they did may).
+func imageWithVersion(image string, v string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + v
+}
+
 func (a *apiServer) getWorkerOptions(pipelineName string, pipelineVersion uint64,
 	parallelism int32, resourceRequests *v1.ResourceList, resourceLimits *v1.ResourceList,
 	transform *pps.Transform, cacheSize string, service *pps.Service,
-	specCommitID string, schedulingSpec *pps.SchedulingSpec, podSpec string) *workerOptions {
+	specCommitID string, schedulingSpec *pps.SchedulingSpec, podSpec string,
+	disruptionBudget *pps.DisruptionBudget) *workerOptions {
 	rcName := ppsutil.PipelineRcName(pipelineName, pipelineVersion)
 	labels := labels(rcName)
 	labels["version"] = version.PrettyVersion()
@@ -255,6 +412,9 @@ func (a *apiServer) getWorkerOptions(pipelineName string, pipelineVersion uint64
 	var volumes []v1.Volume
 	var volumeMounts []v1.VolumeMount
 	for _, secret := range transform.Secrets {
+		if secret.Provider != "" {
+			continue // read from the external secrets manager by the worker
+		}
 		if secret.MountPath != "" {
 			volumes = append(volumes, v1.Volume{
 				Name: secret.Name,
@@ -344,11 +504,189 @@ func (a *apiServer) getWorkerOptions(pipelineName string, pipelineVersion uint64
 		volumes:          volumes,
 		volumeMounts:     volumeMounts,
 		imagePullSecrets: imagePullSecrets,
+		imagePullPolicy:  transform.ImagePullPolicy,
 		cacheSize:        cacheSize,
 		service:          service,
 		schedulingSpec:   schedulingSpec,
 		podSpec:          podSpec,
+		disruptionBudget: disruptionBudget,
+	}
+}
+
+// annotationLabelPrefix prefixes the keys of the k8s labels that a pipeline's
+// annotations are attached to its workers as, so that they can't clash with
+// pachyderm's own labels
+const annotationLabelPrefix = "annotations.pachyderm.io/"
+
+// annotationLabels returns the k8s labels of the pipeline annotations in
+// 'annotations' that can be labels (i.e. whose keys and values are valid label
+// keys and values). Other annotations are only stored in the pipeline's info.
+func annotationLabels(annotations map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range annotations {
+		key = annotationLabelPrefix + key
+		if len(validation.IsQualifiedName(key)) > 0 || len(validation.IsValidLabelValue(value)) > 0 {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// podLabels returns the labels of the workers in 'options'. These are the
+// RC's labels plus the workers' own labels, which aren't in the RC's selector
+// (so that they can't change which pods belong to it).
+func podLabels(options *workerOptions) map[string]string {
+	if len(options.podLabels) == 0 {
+		return options.labels
+	}
+	result := make(map[string]string)
+	for key, value := range options.podLabels {
+		result[key] = value
+	}
+	for key, value := range options.labels {
+		result[key] = value
+	}
+	return result
+}
+
+// objectInputSecretVolumes returns the volumes of the secrets of the object
+// inputs in 'input', and their mounts in the worker container, where the
+// worker reads the credentials of the inputs' buckets
+func objectInputSecretVolumes(input *pps.Input) ([]v1.Volume, []v1.VolumeMount) {
+	var volumes []v1.Volume
+	var volumeMounts []v1.VolumeMount
+	mounted := make(map[string]bool)
+	pps.VisitInput(input, func(input *pps.Input) {
+		if input.Object == nil || input.Object.Secret == "" || mounted[input.Object.Secret] {
+			return
+		}
+		mounted[input.Object.Secret] = true
+		name := fmt.Sprintf("object-input-secret-%d", len(volumes))
+		volumes = append(volumes, v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: input.Object.Secret,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(client.PPSObjectSecretsPath, input.Object.Secret),
+		})
+	})
+	return volumes, volumeMounts
+}
+
+const (
+	// gcpServiceAccountAnnotation binds a k8s service account to a GCP service
+	// account, with GCP Workload Identity
+	gcpServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+	// awsRoleARNAnnotation binds a k8s service account to an AWS IAM role,
+	// with IAM roles for service accounts
+	awsRoleARNAnnotation = "eks.amazonaws.com/role-arn"
+)
+
+// workerServiceAccountName returns the name of the k8s service account that
+// the workers of 'pipelineName' run as, given its workload identity 'wi'
+func workerServiceAccountName(pipelineName string, wi *pps.WorkloadIdentity) string {
+	if wi.ServiceAccount != "" {
+		return wi.ServiceAccount
 	}
+	// k8s won't allow names that contain upper-case letters or underscores
+	return fmt.Sprintf("pipeline-%s", strings.ToLower(strings.Replace(pipelineName, "_", "-", -1)))
+}
+
+// workloadIdentityAnnotations returns the annotations that bind a k8s
+// service account to the cloud identity in 'wi'
+func workloadIdentityAnnotations(wi *pps.WorkloadIdentity) map[string]string {
+	annotations := make(map[string]string)
+	if wi.GCPServiceAccount != "" {
+		annotations[gcpServiceAccountAnnotation] = wi.GCPServiceAccount
+	}
+	if wi.AWSRoleARN != "" {
+		annotations[awsRoleARNAnnotation] = wi.AWSRoleARN
+	}
+	return annotations
+}
+
+// workerServiceAccount returns the k8s service account that the workers of
+// the pipeline in 'pipelineInfo' run as, which is labelled with the pipeline
+// (so that it's deleted with the pipeline's workers) and annotated with its
+// workload identity
+func workerServiceAccount(pipelineInfo *pps.PipelineInfo) *v1.ServiceAccount {
+	return &v1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        workerServiceAccountName(pipelineInfo.Pipeline.Name, pipelineInfo.WorkloadIdentity),
+			Labels:      map[string]string{"pipelineName": pipelineInfo.Pipeline.Name},
+			Annotations: workloadIdentityAnnotations(pipelineInfo.WorkloadIdentity),
+		},
+	}
+}
+
+// checkWorkerServiceAccount returns an error unless the existing service
+// account 'serviceAccount' was created by pachyderm for the workers of
+// 'pipelineName'. Other service accounts (e.g. pachd's own) may have
+// permissions that the pipeline's code mustn't get, and mustn't be changed.
+func checkWorkerServiceAccount(serviceAccount *v1.ServiceAccount, pipelineName string) error {
+	if serviceAccount.Labels["pipelineName"] != pipelineName {
+		return fmt.Errorf("service account %s already exists, and wasn't created for pipeline %s", serviceAccount.Name, pipelineName)
+	}
+	return nil
+}
+
+// applyWorkloadIdentity makes the workers in 'options' run as the service
+// account of the workload identity of the pipeline in 'pipelineInfo', which
+// it creates or, if pachyderm created it for the pipeline already, annotates
+func (a *apiServer) applyWorkloadIdentity(pipelineInfo *pps.PipelineInfo, options *workerOptions) error {
+	serviceAccounts := a.kubeClient.CoreV1().ServiceAccounts(a.namespace)
+	serviceAccount := workerServiceAccount(pipelineInfo)
+	existing, err := serviceAccounts.Get(serviceAccount.Name, metav1.GetOptions{})
+	switch {
+	case isNotFoundErr(err):
+		if _, err := serviceAccounts.Create(serviceAccount); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err := checkWorkerServiceAccount(existing, pipelineInfo.Pipeline.Name); err != nil {
+			return err
+		}
+		if existing.Annotations == nil {
+			existing.Annotations = make(map[string]string)
+		}
+		delete(existing.Annotations, gcpServiceAccountAnnotation)
+		delete(existing.Annotations, awsRoleARNAnnotation)
+		for key, value := range serviceAccount.Annotations {
+			existing.Annotations[key] = value
+		}
+		if _, err := serviceAccounts.Update(existing); err != nil {
+			return err
+		}
+	}
+	options.serviceAccount = serviceAccount.Name
+	// The pods are annotated too, so that the identity that they act as is
+	// visible from them
+	for key, value := range serviceAccount.Annotations {
+		options.annotations[key] = value
+	}
+	return nil
+}
+
+// disruptionBudgetValue converts a field of a pps.DisruptionBudget, which is
+// either a number of workers or a percentage, to the form k8s expects
+func disruptionBudgetValue(value string) *intstr.IntOrString {
+	if value == "" {
+		return nil
+	}
+	result := intstr.Parse(value)
+	return &result
 }
 
 func (a *apiServer) createWorkerRc(options *workerOptions) error {
@@ -372,7 +710,7 @@ func (a *apiServer) createWorkerRc(options *workerOptions) error {
 			Template: &v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        options.rcName,
-					Labels:      options.labels,
+					Labels:      podLabels(options),
 					Annotations: options.annotations,
 				},
 				Spec: podSpec,
@@ -384,6 +722,28 @@ func (a *apiServer) createWorkerRc(options *workerOptions) error {
 			return err
 		}
 	}
+	if options.disruptionBudget != nil {
+		pdb := &policy.PodDisruptionBudget{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "PodDisruptionBudget",
+				APIVersion: "policy/v1beta1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   options.rcName,
+				Labels: options.labels,
+			},
+			Spec: policy.PodDisruptionBudgetSpec{
+				Selector:       metav1.SetAsLabelSelector(options.labels),
+				MinAvailable:   disruptionBudgetValue(options.disruptionBudget.MinAvailable),
+				MaxUnavailable: disruptionBudgetValue(options.disruptionBudget.MaxUnavailable),
+			},
+		}
+		if _, err := a.kubeClient.PolicyV1beta1().PodDisruptionBudgets(a.namespace).Create(pdb); err != nil {
+			if !isAlreadyExistsErr(err) {
+				return err
+			}
+		}
+	}
 	serviceAnnotations := map[string]string{
 		"prometheus.io/scrape": "true",
 		"prometheus.io/port":   strconv.Itoa(worker.PrometheusPort),
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+// workerRcGCInterval is how often the PPS master looks for stale worker RCs
+const workerRcGCInterval = time.Minute
+
+// rcPipelineVersion returns the version of the pipeline 'pipelineName' that
+// the worker RC 'rcName' runs, if 'rcName' is one of its RCs
+func rcPipelineVersion(pipelineName string, rcName string) (uint64, bool) {
+	prefix := ppsutil.PipelineRcNamePrefix(pipelineName)
+	if !strings.HasPrefix(rcName, prefix) {
+		return 0, false
+	}
+	version, err := strconv.ParseUint(strings.TrimPrefix(rcName, prefix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// pipelineRcState is what the PPS master knows about a pipeline when it looks
+// for stale worker RCs
+type pipelineRcState struct {
+	// version is the pipeline's current version
+	version uint64
+	// active is true if the pipeline has jobs that haven't finished, or if
+	// its state couldn't be read, in which case its RCs are all kept
+	active bool
+}
+
+// staleWorkerRcs returns the names of the worker RCs in 'rcs' that are stale,
+// given the state of the pipelines that exist, in 'pipelines': RCs of
+// pipelines that no longer exist, and RCs of old versions of pipelines that
+// have no unfinished jobs. The current version's RC is never stale.
+func staleWorkerRcs(rcs []v1.ReplicationController, pipelines map[string]pipelineRcState) []string {
+	var result []string
+	for _, rc := range rcs {
+		pipelineName := rc.Labels["pipelineName"]
+		if pipelineName == "" {
+			continue
+		}
+		version, ok := rcPipelineVersion(pipelineName, rc.Name)
+		if !ok {
+			continue
+		}
+		state, ok := pipelines[pipelineName]
+		if ok && (state.active || version >= state.version) {
+			continue
+		}
+		result = append(result, rc.Name)
+	}
+	return result
+}
+
+// expiredWorkerRcs returns the RCs in 'stale' that have been stale for at
+// least 'gracePeriod' as of 'now'. 'staleSince' records when each stale RC
+// was first seen to be stale, and is updated: RCs that are no longer stale
+// are forgotten.
+func expiredWorkerRcs(stale []string, staleSince map[string]time.Time, now time.Time, gracePeriod time.Duration) []string {
+	isStale := make(map[string]bool)
+	var result []string
+	for _, rcName := range stale {
+		isStale[rcName] = true
+		since, ok := staleSince[rcName]
+		if !ok {
+			staleSince[rcName] = now
+			since = now
+		}
+		if now.Sub(since) >= gracePeriod {
+			result = append(result, rcName)
+		}
+	}
+	for rcName := range staleSince {
+		if !isStale[rcName] {
+			delete(staleSince, rcName)
+		}
+	}
+	return result
+}
+
+// pipelineRcStates returns the state of every pipeline, for staleWorkerRcs
+func (a *apiServer) pipelineRcStates(pachClient *client.APIClient) (map[string]pipelineRcState, error) {
+	result := make(map[string]pipelineRcState)
+	if err := a.sudo(pachClient, func(superUserClient *client.APIClient) error {
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		return a.pipelines.ReadOnly(pachClient.Ctx()).List(pipelinePtr, col.DefaultOptions, func(pipelineName string) error {
+			pipelineInfo, err := ppsutil.GetPipelineInfo(superUserClient, pipelinePtr)
+			if err != nil {
+				log.Errorf("PPS master: could not read pipeline %s, so its worker RCs are kept: %v", pipelineName, err)
+				result[pipelineName] = pipelineRcState{active: true}
+				return nil
+			}
+			jobPtrs, err := a.inFlightJobs(pachClient.Ctx(), pipelineInfo.Pipeline)
+			if err != nil {
+				return err
+			}
+			result[pipelineName] = pipelineRcState{
+				version: pipelineInfo.Version,
+				active:  len(jobPtrs) > 0,
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// collectWorkerRcs deletes the worker RCs that have been stale (see
+// staleWorkerRcs) for a.workerRcGCGracePeriod, e.g. because their pipelines
+// were updated or deleted while pachd couldn't clean them up. It's a helper
+// function called by master.
+func (a *apiServer) collectWorkerRcs(pachClient *client.APIClient) error {
+	rcClient := a.kubeClient.CoreV1().ReplicationControllers(a.namespace)
+	staleSince := make(map[string]time.Time)
+	ticker := time.NewTicker(workerRcGCInterval)
+	defer ticker.Stop()
+	for {
+		// RCs are listed before pipelines, so that the RCs of pipelines that
+		// are created meanwhile aren't mistaken for orphans
+		rcs, err := rcClient.List(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(metav1.SetAsLabelSelector(map[string]string{
+				"suite":     suite,
+				"component": "worker",
+			})),
+		})
+		if err != nil {
+			return fmt.Errorf("error listing worker RCs: %v", err)
+		}
+		pipelines, err := a.pipelineRcStates(pachClient)
+		if err != nil {
+			return err
+		}
+		rcPipelines := make(map[string]string)
+		for _, rc := range rcs.Items {
+			rcPipelines[rc.Name] = rc.Labels["pipelineName"]
+		}
+		for _, rcName := range expiredWorkerRcs(staleWorkerRcs(rcs.Items, pipelines), staleSince, time.Now(), a.workerRcGCGracePeriod) {
+			log.Infof("PPS master: deleting worker RC %s, which has been stale for %v", rcName, a.workerRcGCGracePeriod)
+			_, pipelineExists := pipelines[rcPipelines[rcName]]
+			if err := a.deleteStaleWorkerRc(rcName, rcPipelines[rcName], pipelineExists); err != nil {
+				return err
+			}
+			delete(staleSince, rcName)
+		}
+		select {
+		case <-ticker.C:
+		case <-pachClient.Ctx().Done():
+			return context.DeadlineExceeded
+		}
+	}
+}
+
+// deleteStaleWorkerRc deletes the worker RC 'rcName' of the pipeline
+// 'pipelineName', along with the services and PodDisruptionBudget that were
+// created with it, selecting them by label, like deleteWorkersForPipeline. If
+// the pipeline no longer exists, the service accounts that were created for
+// its workload identity are deleted too.
+func (a *apiServer) deleteStaleWorkerRc(rcName string, pipelineName string, pipelineExists bool) error {
+	selector := metav1.FormatLabelSelector(metav1.SetAsLabelSelector(labels(rcName)))
+	falseVal := false
+	opts := &metav1.DeleteOptions{
+		OrphanDependents: &falseVal,
+	}
+	services, err := a.kubeClient.CoreV1().Services(a.namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, service := range services.Items {
+		if err := a.kubeClient.CoreV1().Services(a.namespace).Delete(service.Name, opts); err != nil && !isNotFoundErr(err) {
+			return err
+		}
+	}
+	rcs, err := a.kubeClient.CoreV1().ReplicationControllers(a.namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, rc := range rcs.Items {
+		if err := a.kubeClient.CoreV1().ReplicationControllers(a.namespace).Delete(rc.Name, opts); err != nil && !isNotFoundErr(err) {
+			return err
+		}
+	}
+	pdbs, err := a.kubeClient.PolicyV1beta1().PodDisruptionBudgets(a.namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for _, pdb := range pdbs.Items {
+		if err := a.kubeClient.PolicyV1beta1().PodDisruptionBudgets(a.namespace).Delete(pdb.Name, opts); err != nil && !isNotFoundErr(err) {
+			return err
+		}
+	}
+	if pipelineExists {
+		return nil
+	}
+	serviceAccounts, err := a.kubeClient.CoreV1().ServiceAccounts(a.namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("pipelineName=%s", pipelineName),
+	})
+	if err != nil {
+		return err
+	}
+	for _, serviceAccount := range serviceAccounts.Items {
+		if err := a.kubeClient.CoreV1().ServiceAccounts(a.namespace).Delete(serviceAccount.Name, opts); err != nil && !isNotFoundErr(err) {
+			return err
+		}
+	}
+	return nil
+}
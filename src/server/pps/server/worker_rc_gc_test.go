@@ -0,0 +1,81 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+func workerRc(pipelineName string, version uint64) v1.ReplicationController {
+	return v1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ppsutil.PipelineRcName(pipelineName, version),
+			Labels: map[string]string{"pipelineName": pipelineName},
+		},
+	}
+}
+
+func TestRcPipelineVersion(t *testing.T) {
+	version, ok := rcPipelineVersion("my_pipeline", "pipeline-my-pipeline-v12")
+	require.True(t, ok)
+	require.Equal(t, uint64(12), version)
+	// RCs of pipelines whose names start with the same prefix don't match
+	_, ok = rcPipelineVersion("my_pipeline", "pipeline-my-pipeline-v1-v2")
+	require.False(t, ok)
+	_, ok = rcPipelineVersion("foo", "pipeline-foobar-v1")
+	require.False(t, ok)
+}
+
+func TestStaleWorkerRcs(t *testing.T) {
+	rcs := []v1.ReplicationController{
+		workerRc("foo", 1),
+		workerRc("foo", 2),
+		workerRc("foo", 3),
+		workerRc("bar", 1),
+		workerRc("bar", 2),
+		workerRc("deleted", 4),
+	}
+	pipelines := map[string]pipelineRcState{
+		"foo": {version: 3},
+		"bar": {version: 2, active: true},
+	}
+	// Old versions of pipelines without unfinished jobs, and the RCs of
+	// deleted pipelines, are stale, while current versions are kept
+	require.ElementsEqual(t, []string{
+		"pipeline-foo-v1",
+		"pipeline-foo-v2",
+		"pipeline-deleted-v4",
+	}, staleWorkerRcs(rcs, pipelines))
+
+	// Once bar's jobs finish, its old version is stale too
+	pipelines["bar"] = pipelineRcState{version: 2}
+	require.ElementsEqual(t, []string{
+		"pipeline-foo-v1",
+		"pipeline-foo-v2",
+		"pipeline-bar-v1",
+		"pipeline-deleted-v4",
+	}, staleWorkerRcs(rcs, pipelines))
+}
+
+func TestExpiredWorkerRcs(t *testing.T) {
+	staleSince := make(map[string]time.Time)
+	start := time.Now()
+	grace := 10 * time.Minute
+
+	// RCs aren't deleted until they've been stale for the grace period
+	require.Equal(t, 0, len(expiredWorkerRcs([]string{"a", "b"}, staleSince, start, grace)))
+	require.Equal(t, 0, len(expiredWorkerRcs([]string{"a", "b", "c"}, staleSince, start.Add(5*time.Minute), grace)))
+	require.Equal(t, []string{"a", "b"}, expiredWorkerRcs([]string{"a", "b", "c"}, staleSince, start.Add(grace), grace))
+
+	// An RC that stops being stale (e.g. because a job started) starts its
+	// grace period over if it becomes stale again
+	require.Equal(t, 0, len(expiredWorkerRcs([]string{"c"}, staleSince, start.Add(11*time.Minute), grace)))
+	require.Equal(t, 0, len(expiredWorkerRcs([]string{"a", "c"}, staleSince, start.Add(12*time.Minute), grace)))
+	require.Equal(t, []string{"c"}, expiredWorkerRcs([]string{"a", "c"}, staleSince, start.Add(15*time.Minute), grace))
+	require.Equal(t, []string{"a", "c"}, expiredWorkerRcs([]string{"a", "c"}, staleSince, start.Add(22*time.Minute), grace))
+}
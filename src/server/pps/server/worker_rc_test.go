@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/client/version"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkerImageDigest(t *testing.T) {
+	// Images pinned by digest are used verbatim
+	a := &apiServer{}
+	image := "registry.example.com:5000/team/image:v1@sha256:" + strings.Repeat("ab", 32)
+	transform := &pps.Transform{Image: image, Cmd: []string{"true"}}
+	options := a.getWorkerOptions("pipeline", 1, 1, nil, nil, transform, "", nil, "", nil, "", nil)
+	require.Equal(t, image, options.userImage)
+	pod := a.finalizerPod(&pps.PipelineInfo{
+		Pipeline:  &pps.Pipeline{Name: "pipeline"},
+		Transform: transform,
+		Finalizer: &pps.Finalizer{Cmd: []string{"true"}},
+	})
+	require.Equal(t, image, pod.Spec.Containers[0].Image)
+}
+
+func TestImageWithVersion(t *testing.T) {
+	require.Equal(t, "pachyderm/worker:1.9.0", imageWithVersion("pachyderm/worker:1.8.1", "1.9.0"))
+	require.Equal(t, "pachyderm/pachd:1.9.0", imageWithVersion("pachyderm/pachd", "1.9.0"))
+	require.Equal(t, "registry.example.com:5000/pachyderm/worker:1.9.0rc1",
+		imageWithVersion("registry.example.com:5000/pachyderm/worker:1.8.1", "1.9.0rc1"))
+	// Digests identify a single version's image, so they're dropped
+	digest := "@sha256:" + strings.Repeat("ab", 32)
+	require.Equal(t, "pachyderm/pachd:1.9.0", imageWithVersion("pachyderm/pachd:1.8.1"+digest, "1.9.0"))
+	require.Equal(t, "pachyderm/pachd:1.9.0", imageWithVersion("pachyderm/pachd"+digest, "1.9.0"))
+}
+
+func TestValidateWorkerVersion(t *testing.T) {
+	a := &apiServer{
+		workerImage:        "pachyderm/worker:" + version.PrettyVersion(),
+		workerSidecarImage: "pachyderm/pachd:" + version.PrettyVersion(),
+	}
+	v := version.PrettyVersion()
+	require.NoError(t, a.validateWorkerVersion(v))
+	require.YesError(t, a.validateWorkerVersion("not-a-version"))
+	// A digest-pinned image can't be switched to another version
+	a.workerSidecarImage += "@sha256:" + strings.Repeat("ab", 32)
+	err := a.validateWorkerVersion(v)
+	require.YesError(t, err)
+	require.Matches(t, "pinned by digest", err.Error())
+}
+
+func TestWorkerServiceAccount(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline: &pps.Pipeline{Name: "My_Pipeline"},
+		WorkloadIdentity: &pps.WorkloadIdentity{
+			GCPServiceAccount: "reader@project.iam.gserviceaccount.com",
+		},
+	}
+	serviceAccount := workerServiceAccount(pipelineInfo)
+	require.Equal(t, "pipeline-my-pipeline", serviceAccount.Name)
+	require.Equal(t, "My_Pipeline", serviceAccount.Labels["pipelineName"])
+	require.Equal(t, map[string]string{
+		"iam.gke.io/gcp-service-account": "reader@project.iam.gserviceaccount.com",
+	}, serviceAccount.Annotations)
+
+	pipelineInfo.WorkloadIdentity = &pps.WorkloadIdentity{
+		ServiceAccount: "reader",
+		AWSRoleARN:     "arn:aws:iam::123456789012:role/reader",
+	}
+	serviceAccount = workerServiceAccount(pipelineInfo)
+	require.Equal(t, "reader", serviceAccount.Name)
+	require.Equal(t, map[string]string{
+		"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/reader",
+	}, serviceAccount.Annotations)
+
+	// Workers can only use existing service accounts that pachyderm created
+	// for their pipeline
+	require.NoError(t, checkWorkerServiceAccount(serviceAccount, "My_Pipeline"))
+	require.YesError(t, checkWorkerServiceAccount(serviceAccount, "Other_Pipeline"))
+	require.YesError(t, checkWorkerServiceAccount(&v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+	}, "My_Pipeline"))
+	require.YesError(t, validateWorkloadIdentity(&pps.WorkloadIdentity{
+		ServiceAccount: "pachyderm",
+		AWSRoleARN:     "arn:aws:iam::123456789012:role/reader",
+	}))
+}
+
+func TestAnnotationLabels(t *testing.T) {
+	// Annotations that are valid labels are attached to workers, under a
+	// prefix that keeps them apart from pachyderm's own labels
+	labels := annotationLabels(map[string]string{
+		"owner":       "data-team",
+		"cost-center": "1234",
+		"description": "not a valid label value",
+		"bad key!":    "value",
+	})
+	require.Equal(t, map[string]string{
+		"annotations.pachyderm.io/owner":       "data-team",
+		"annotations.pachyderm.io/cost-center": "1234",
+	}, labels)
+
+	// They're added to the workers' labels, but not the RC's selector
+	options := (&apiServer{}).getWorkerOptions("pipeline", 1, 1, nil, nil, &pps.Transform{}, "", nil, "", nil, "", nil)
+	options.podLabels = labels
+	pod := podLabels(options)
+	require.Equal(t, "data-team", pod["annotations.pachyderm.io/owner"])
+	require.Equal(t, "pipeline", pod["pipelineName"])
+	_, ok := options.labels["annotations.pachyderm.io/owner"]
+	require.False(t, ok)
+}
+
+func TestWeightedResourceRequests(t *testing.T) {
+	a := &apiServer{workerCPUPerWeight: resource.MustParse("100m")}
+	workerCPU := func(schedulingSpec *pps.SchedulingSpec, requests v1.ResourceList) int64 {
+		cpu := a.weightedResourceRequests(requests, nil, schedulingSpec)[v1.ResourceCPU]
+		return cpu.MilliValue()
+	}
+	zeroRequests := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("0"),
+		v1.ResourceMemory: resource.MustParse("0M"),
+	}
+
+	// Workers request CPU in proportion to their pipeline's weight
+	require.Equal(t, int64(100), workerCPU(&pps.SchedulingSpec{Weight: 1}, zeroRequests))
+	require.Equal(t, int64(200), workerCPU(&pps.SchedulingSpec{Weight: 2}, zeroRequests))
+	require.Equal(t, int64(500), workerCPU(&pps.SchedulingSpec{Weight: 5}, zeroRequests))
+
+	// Pipelines without a weight have a weight of 1
+	require.Equal(t, int64(100), workerCPU(nil, zeroRequests))
+
+	// Pipelines that request CPU themselves keep their request, while those
+	// that only request memory get a weighted CPU request
+	require.Equal(t, int64(2000), workerCPU(&pps.SchedulingSpec{Weight: 5},
+		v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}))
+	requests := v1.ResourceList{v1.ResourceMemory: resource.MustParse("1G")}
+	require.Equal(t, int64(300), workerCPU(&pps.SchedulingSpec{Weight: 3}, requests))
+	_, ok := requests[v1.ResourceCPU]
+	require.False(t, ok)
+
+	// Weighted CPU requests are capped at the pipeline's CPU limit, if any
+	limits := v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m")}
+	cpu := a.weightedResourceRequests(zeroRequests, limits, &pps.SchedulingSpec{Weight: 5})[v1.ResourceCPU]
+	require.Equal(t, int64(250), cpu.MilliValue())
+	cpu = a.weightedResourceRequests(zeroRequests, limits, &pps.SchedulingSpec{Weight: 2})[v1.ResourceCPU]
+	require.Equal(t, int64(200), cpu.MilliValue())
+	limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse("1G")}
+	cpu = a.weightedResourceRequests(zeroRequests, limits, &pps.SchedulingSpec{Weight: 5})[v1.ResourceCPU]
+	require.Equal(t, int64(500), cpu.MilliValue())
+
+	// Weighted CPU requests are capped at the cluster's maximum, if any, and
+	// don't overflow
+	a.workerMaxWeightedCPU = resource.MustParse("400m")
+	require.Equal(t, int64(400), workerCPU(&pps.SchedulingSpec{Weight: 5}, zeroRequests))
+	require.Equal(t, int64(400), workerCPU(&pps.SchedulingSpec{Weight: math.MaxInt64}, zeroRequests))
+	require.Equal(t, int64(300), workerCPU(&pps.SchedulingSpec{Weight: 3}, zeroRequests))
+	a.workerMaxWeightedCPU = resource.Quantity{}
+
+	// Without a CPU per weight, workers request no CPU
+	a.workerCPUPerWeight = resource.Quantity{}
+	require.Equal(t, int64(0), workerCPU(&pps.SchedulingSpec{Weight: 5}, zeroRequests))
+}
+
+func TestValidateWeightedCPURequest(t *testing.T) {
+	a := &apiServer{
+		workerCPUPerWeight:   resource.MustParse("100m"),
+		workerMaxWeightedCPU: resource.MustParse("1"),
+	}
+	pipelineInfo := func(weight int64, cpu float32) *pps.PipelineInfo {
+		return &pps.PipelineInfo{
+			SchedulingSpec:   &pps.SchedulingSpec{Weight: weight},
+			ResourceRequests: &pps.ResourceSpec{Cpu: cpu},
+		}
+	}
+	require.NoError(t, a.validateWeightedCPURequest(pipelineInfo(10, 0)))
+	err := a.validateWeightedCPURequest(pipelineInfo(11, 0))
+	require.YesError(t, err)
+	require.Matches(t, "at most 10", err.Error())
+	require.YesError(t, a.validateWeightedCPURequest(pipelineInfo(math.MaxInt64, 0)))
+	// Pipelines that request CPU themselves aren't limited
+	require.NoError(t, a.validateWeightedCPURequest(pipelineInfo(11, 2)))
+	// Nor are pipelines without a maximum
+	a.workerMaxWeightedCPU = resource.Quantity{}
+	require.NoError(t, a.validateWeightedCPURequest(pipelineInfo(math.MaxInt64, 0)))
+}
+
+func TestHandlerTimeoutEnvVar(t *testing.T) {
+	// The worker parses the env var set from the pipeline's
+	// worker_handler_timeout, and cancels handlers that run past it
+	envVar, err := handlerTimeoutEnvVar(types.DurationProto(200 * time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, client.PPSWorkerHandlerTimeoutEnv, envVar.Name)
+	timeout, err := time.ParseDuration(envVar.Value)
+	require.NoError(t, err)
+	require.Equal(t, 200*time.Millisecond, timeout)
+
+	intercept := grpcutil.UnaryHandlerTimeoutInterceptor(timeout)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pachyderm.worker.Worker/Status"}
+	_, err = intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(50 * time.Millisecond)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	stuck := make(chan struct{})
+	defer close(stuck)
+	start := time.Now()
+	_, err = intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-stuck
+		return nil, nil
+	})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	elapsed := time.Since(start)
+	require.True(t, elapsed >= timeout && elapsed < 10*time.Second)
+
+	// A timeout of 0 becomes "0s", which disables it
+	envVar, err = handlerTimeoutEnvVar(types.DurationProto(0))
+	require.NoError(t, err)
+	timeout, err = time.ParseDuration(envVar.Value)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), timeout)
+}
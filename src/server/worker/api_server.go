@@ -24,6 +24,7 @@ import (
 
 	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"golang.org/x/net/context"
 	"golang.org/x/sync/errgroup"
@@ -41,6 +42,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pkg/pbutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pfs/fuse"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/exec"
@@ -61,12 +63,17 @@ const (
 	planPrefix        = "/plan"
 	chunkPrefix       = "/chunk"
 	mergePrefix       = "/merge"
+	crashPrefix       = "/crash"
 	parentTreeBufSize = 50 * (1 << (10 * 2))
 )
 
 var (
 	errSpecialFile = errors.New("cannot upload special file")
 	statsTagSuffix = "_stats"
+
+	// errDatumQuarantined is returned while processing a datum that has
+	// crashed workers too many times to be processed again
+	errDatumQuarantined = errors.New("datum quarantined")
 )
 
 // APIServer implements the worker API
@@ -99,6 +106,14 @@ type APIServer struct {
 	stats *pps.ProcessStats
 	// queueSize is the number of items enqueued
 	queueSize int64
+	// running holds the datums whose code is running, if the pipeline runs
+	// several datums per worker at once (see DatumsPerWorker), so that any of
+	// them can be cancelled. jobID, data, started and cancel describe the one
+	// that started most recently.
+	running map[*runningDatum]struct{}
+	// schedulingLatency counts the scheduling latencies of the chunks that
+	// this worker has picked up (see Plan.Dispatched)
+	schedulingLatency *pps.Histogram
 
 	// The total number of workers for this pipeline
 	numWorkers int
@@ -115,9 +130,42 @@ type APIServer struct {
 	// accessing /pfs, runMu enforces this
 	runMu sync.Mutex
 
+	// statsCompaction is the stats compaction of the job statsCompactionJob,
+	// which is computed once and used by all of the job's merges
+	statsCompactionMu  sync.Mutex
+	statsCompactionJob string
+	statsCompaction    *statsCompaction
+
 	// We only export application statistics if enterprise is enabled
 	exportStats bool
 
+	// verifyInputs is true if staged input files should be checked against
+	// their PFS hashes before user code runs
+	verifyInputs bool
+
+	// fuse is true if lazy inputs can be exposed through FUSE mounts, rather
+	// than named pipes
+	fuse bool
+
+	// secrets resolves the pipeline's secrets that are stored in an external
+	// secrets manager.
+	secrets *externalSecrets
+
+	// healthCheck runs the pipeline's health check before datums are
+	// processed. It's nil if the pipeline has none.
+	healthCheck *healthChecker
+
+	// datumEvents broadcasts the events in the processing of this worker's
+	// datums to StatusStream calls
+	datumEvents *datumEvents
+
+	// preemption records whether this worker's node is being preempted. It's
+	// nil unless the pipeline sets Preemption.
+	preemption *preemption
+	// drained is closed once the worker has been preempted, and its master
+	// and worker loops have stopped (see Drained)
+	drained chan struct{}
+
 	uid uint32
 	gid uint32
 
@@ -125,6 +173,13 @@ type APIServer struct {
 	hashtreeStorage string
 }
 
+// runningDatum is a datum whose code is running (see APIServer.running)
+type runningDatum struct {
+	jobID  string
+	data   []*Input
+	cancel func()
+}
+
 type putObjectResponse struct {
 	object *pfs.Object
 	size   int64
@@ -132,14 +187,13 @@ type putObjectResponse struct {
 }
 
 type taggedLogger struct {
-	template     pps.LogMessage
-	stderrLog    log.Logger
-	marshaler    *jsonpb.Marshaler
-	buffer       bytes.Buffer
-	putObjClient pfs.ObjectAPI_PutObjectClient
-	objSize      int64
-	msgCh        chan string
-	eg           errgroup.Group
+	template   pps.LogMessage
+	stderrLog  log.Logger
+	marshaler  *jsonpb.Marshaler
+	buffer     bytes.Buffer
+	logRotator *logRotator
+	msgCh      chan string
+	eg         errgroup.Group
 }
 
 // DatumID computes the id for a datum, this value is used in ListDatum and
@@ -149,13 +203,14 @@ func (a *APIServer) DatumID(data []*Input) string {
 	for _, d := range data {
 		hash.Write([]byte(d.FileInfo.File.Path))
 		hash.Write(d.FileInfo.Hash)
+		writeRange(hash, d)
 	}
 	// InputFileID is a single string id for the data from this input, it's used in logs and in
 	// the statsTree
 	return hex.EncodeToString(hash.Sum(nil))
 }
 
-func (a *APIServer) getTaggedLogger(pachClient *client.APIClient, jobID string, data []*Input, enableStats bool) (*taggedLogger, error) {
+func (a *APIServer) getTaggedLogger(pachClient *client.APIClient, jobID string, traceID string, data []*Input, enableStats bool) (*taggedLogger, error) {
 	result := &taggedLogger{
 		template:  a.logMsgTemplate, // Copy struct
 		stderrLog: log.Logger{},
@@ -165,8 +220,9 @@ func (a *APIServer) getTaggedLogger(pachClient *client.APIClient, jobID string,
 	result.stderrLog.SetOutput(os.Stderr)
 	result.stderrLog.SetFlags(log.LstdFlags | log.Llongfile) // Log file/line
 
-	// Add Job ID to log metadata
+	// Add Job ID and trace ID to log metadata
 	result.template.JobID = jobID
+	result.template.TraceID = traceID
 
 	// Add inputs' details to log metadata, so we can find these logs later
 	for _, d := range data {
@@ -179,21 +235,18 @@ func (a *APIServer) getTaggedLogger(pachClient *client.APIClient, jobID string,
 	// the statsTree
 	result.template.DatumID = a.DatumID(data)
 	if enableStats {
-		putObjClient, err := pachClient.ObjectAPIClient.PutObject(pachClient.Ctx())
+		logRotator, err := newLogRotator(func() (logObjectWriter, error) {
+			return pachClient.ObjectAPIClient.PutObject(pachClient.Ctx())
+		}, logRotateBytes, a.objectScope())
 		if err != nil {
 			return nil, err
 		}
-		result.putObjClient = putObjClient
+		result.logRotator = logRotator
 		result.eg.Go(func() error {
 			for msg := range result.msgCh {
-				for _, chunk := range grpcutil.Chunk([]byte(msg), grpcutil.MaxMsgSize/2) {
-					if err := result.putObjClient.Send(&pfs.PutObjectRequest{
-						Value: chunk,
-					}); err != nil && err != io.EOF {
-						return err
-					}
+				if err := logRotator.write(msg); err != nil {
+					return err
 				}
-				result.objSize += int64(len(msg))
 			}
 			return nil
 		})
@@ -219,7 +272,7 @@ func (logger *taggedLogger) Logf(formatString string, args ...interface{}) {
 		return
 	}
 	fmt.Println(msg)
-	if logger.putObjClient != nil {
+	if logger.logRotator != nil {
 		logger.msgCh <- msg + "\n"
 	}
 }
@@ -247,28 +300,30 @@ func (logger *taggedLogger) Write(p []byte) (_ int, retErr error) {
 	}
 }
 
-func (logger *taggedLogger) Close() (*pfs.Object, int64, error) {
+// Close returns the objects that hold the logs written to the stats branch
+// (several, if they've been rotated), and their sizes
+func (logger *taggedLogger) Close() ([]*pfs.Object, []int64, error) {
 	close(logger.msgCh)
-	if logger.putObjClient != nil {
+	if logger.logRotator != nil {
 		if err := logger.eg.Wait(); err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
-		object, err := logger.putObjClient.CloseAndRecv()
-		// we set putObjClient to nil so that future calls to Logf won't send
+		objects, sizes, err := logger.logRotator.close()
+		// we set logRotator to nil so that future calls to Logf won't send
 		// msg down logger.msgCh as we've just closed that channel.
-		logger.putObjClient = nil
-		return object, logger.objSize, err
+		logger.logRotator = nil
+		return objects, sizes, err
 	}
-	return nil, 0, nil
+	return nil, nil, nil
 }
 
 func (logger *taggedLogger) clone() *taggedLogger {
 	return &taggedLogger{
-		template:     logger.template, // Copy struct
-		stderrLog:    log.Logger{},
-		marshaler:    &jsonpb.Marshaler{},
-		putObjClient: logger.putObjClient,
-		msgCh:        logger.msgCh,
+		template:   logger.template, // Copy struct
+		stderrLog:  log.Logger{},
+		marshaler:  &jsonpb.Marshaler{},
+		logRotator: logger.logRotator,
+		msgCh:      logger.msgCh,
 	}
 }
 
@@ -290,6 +345,7 @@ func NewAPIServer(pachClient *client.APIClient, etcdClient *etcd.Client, etcdPre
 		return nil, err
 	}
 	server := &APIServer{
+		drained:      make(chan struct{}),
 		pachClient:   pachClient,
 		kubeClient:   kubeClient,
 		etcdClient:   etcdClient,
@@ -305,11 +361,36 @@ func NewAPIServer(pachClient *client.APIClient, etcdClient *etcd.Client, etcdPre
 		pipelines:       ppsdb.Pipelines(etcdClient, etcdPrefix),
 		plans:           col.NewCollection(etcdClient, path.Join(etcdPrefix, planPrefix), nil, &Plan{}, nil, nil),
 		hashtreeStorage: hashtreeStorage,
+		verifyInputs:    verifyInputsEnabled(),
+		fuse:            fuse.Available(),
+		datumEvents:     newDatumEvents(),
 	}
-	logger, err := server.getTaggedLogger(pachClient, "", nil, false)
+	if pipelineInfo.DatumsPerWorker > 1 {
+		server.running = make(map[*runningDatum]struct{})
+	}
+	server.healthCheck, err = newHealthChecker(pipelineInfo)
+	if err != nil {
+		return nil, err
+	}
+	server.preemption = newPreemption(pipelineInfo)
+	server.secrets, err = newExternalSecrets(pipelineInfo.Transform)
+	if err == nil {
+		err = server.secrets.refresh(true)
+	}
+	if err != nil {
+		// The user code can't run without its secrets, so fail the pipeline
+		// (rather than just crashing the worker) so that the reason is visible
+		err = fmt.Errorf("could not load external secrets: %v", err)
+		if err := ppsutil.FailPipeline(context.Background(), etcdClient, server.pipelines, pipelineInfo.Pipeline.Name, err.Error()); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	logger, err := server.getTaggedLogger(pachClient, "", "", nil, false)
 	if err != nil {
 		return nil, err
 	}
+	go server.secrets.keepFresh(context.Background(), logger.Logf)
 	resp, err := pachClient.Enterprise.GetState(context.Background(), &enterprise.GetStateRequest{})
 	if err != nil {
 		logger.Logf("failed to get enterprise state with error: %v\n", err)
@@ -341,7 +422,7 @@ func NewAPIServer(pachClient *client.APIClient, etcdClient *etcd.Client, etcdPre
 		if pipelineInfo.Transform.WorkingDir == "" {
 			pipelineInfo.Transform.WorkingDir = image.Config.WorkingDir
 		}
-		if server.pipelineInfo.Transform.Cmd == nil {
+		if server.pipelineInfo.Transform.Cmd == nil && server.pipelineInfo.Transform.Shell == nil {
 			server.pipelineInfo.Transform.Cmd = image.Config.Entrypoint
 		}
 	}
@@ -366,12 +447,25 @@ func NewAPIServer(pachClient *client.APIClient, etcdClient *etcd.Client, etcdPre
 			server.gid = uint32(gid)
 		}
 	}
-	if pipelineInfo.Service == nil {
-		go server.master()
-	} else {
-		go server.serviceMaster()
-	}
-	go server.worker()
+	// The master and worker loops only return once the worker is preempted
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if pipelineInfo.Service == nil {
+			server.master()
+		} else {
+			server.serviceMaster()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		server.worker()
+	}()
+	go func() {
+		wg.Wait()
+		close(server.drained)
+	}()
 	return server, nil
 }
 
@@ -469,6 +563,12 @@ func (a *APIServer) downloadData(pachClient *client.APIClient, logger *taggedLog
 			}
 			continue
 		}
+		if input.ObjectURL != "" {
+			if err := downloadObjectData(dir, input); err != nil {
+				return "", err
+			}
+			continue
+		}
 		file := input.FileInfo.File
 		root := filepath.Join(dir, input.Name, file.Path)
 		var statsRoot string
@@ -476,13 +576,49 @@ func (a *APIServer) downloadData(pachClient *client.APIClient, logger *taggedLog
 			statsTree.PutDir(input.Name)
 			statsRoot = path.Join(input.Name, file.Path)
 		}
+		if input.SizeBytes != 0 {
+			if err := downloadRange(pachClient, root, input); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if input.Lazy && a.fuse {
+			if err := puller.Mount(pachClient, filepath.Join(dir, input.Name), file.Commit.Repo.Name, file.Commit.ID, file.Path, statsTree, statsRoot); err != nil {
+				return "", err
+			}
+			continue
+		}
 		if err := puller.Pull(pachClient, root, file.Commit.Repo.Name, file.Commit.ID, file.Path, input.Lazy, input.EmptyFiles, concurrency, statsTree, statsRoot); err != nil {
 			return "", err
 		}
+		if a.verifyInputs && !input.Lazy && !input.EmptyFiles {
+			if err := verifyInput(pachClient, root, file); err != nil {
+				return "", err
+			}
+		}
 	}
 	return dir, nil
 }
 
+// downloadRange downloads the byte range of the file in the split input
+// 'input' to 'dst'.
+func downloadRange(pachClient *client.APIClient, dst string, input *Input) (retErr error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	file := input.FileInfo.File
+	return pachClient.GetFile(file.Commit.Repo.Name, file.Commit.ID, file.Path, input.OffsetBytes, input.SizeBytes, f)
+}
+
 func (a *APIServer) linkData(inputs []*Input, dir string) error {
 	for _, input := range inputs {
 		src := filepath.Join(dir, input.Name)
@@ -539,8 +675,9 @@ func (a *APIServer) reportDeferredUserCodeStats(err error, start time.Time, stat
 	}
 }
 
-// Run user code and return the combined output of stdout and stderr.
-func (a *APIServer) runUserCode(ctx context.Context, logger *taggedLogger, environ []string, stats *pps.ProcessStats, rawDatumTimeout *types.Duration) (retErr error) {
+// Run user code, in 'workingDir' and with 'extraArgs' appended to its
+// command, and return the combined output of stdout and stderr.
+func (a *APIServer) runUserCode(ctx context.Context, logger *taggedLogger, environ []string, workingDir string, extraArgs []string, stats *pps.ProcessStats, rawDatumTimeout *types.Duration, memoryLimit int64) (retErr error) {
 	a.reportUserCodeStats(logger)
 	defer func(start time.Time) { a.reportDeferredUserCodeStats(retErr, start, stats, logger) }(time.Now())
 	logger.Logf("beginning to run user code")
@@ -562,9 +699,10 @@ func (a *APIServer) runUserCode(ctx context.Context, logger *taggedLogger, envir
 	}
 
 	// Run user code
-	cmd := exec.CommandContext(ctx, a.pipelineInfo.Transform.Cmd[0], a.pipelineInfo.Transform.Cmd[1:]...)
-	if a.pipelineInfo.Transform.Stdin != nil {
-		cmd.Stdin = strings.NewReader(strings.Join(a.pipelineInfo.Transform.Stdin, "\n") + "\n")
+	name, args, stdin := transformCommand(a.pipelineInfo.Transform, extraArgs)
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = strings.NewReader(strings.Join(stdin, "\n") + "\n")
 	}
 	cmd.Stdout = logger.userLogger()
 	cmd.Stderr = logger.userLogger()
@@ -574,15 +712,27 @@ func (a *APIServer) runUserCode(ctx context.Context, logger *taggedLogger, envir
 			Uid: a.uid,
 			Gid: a.gid,
 		},
-	}
-	cmd.Dir = a.pipelineInfo.Transform.WorkingDir
+		// limitMemory kills the user code's whole process group
+		Setpgid: memoryLimit > 0,
+	}
+	cmd.Dir = workingDir
+	// The kernel's OOM kills are counted before the user code starts, so
+	// that it's only taken to have run out of memory when it's killed with
+	// SIGKILL if the count rose while it ran
+	oomKillsBefore, oomKillsCounted := oomKills()
 	err := cmd.Start()
 	if err != nil {
 		return fmt.Errorf("error cmd.Start: %v", err)
 	}
+	// If the user code's memory is limited, it's killed when it uses too much
+	stopLimit := func() bool { return false }
+	if memoryLimit > 0 {
+		stopLimit = limitMemory(ctx, cmd.Process, memoryLimit)
+	}
 	// A context w a deadline will successfully cancel/kill
 	// the running process (minus zombies)
 	state, err := cmd.Process.Wait()
+	overLimit := stopLimit()
 	if err != nil {
 		return fmt.Errorf("error cmd.Wait: %v", err)
 	}
@@ -598,6 +748,9 @@ func (a *APIServer) runUserCode(ctx context.Context, logger *taggedLogger, envir
 	// cmd.Process.Wait() then cmd.Wait() will produce an error. So instead we
 	// close the IO using this helper
 	err = cmd.WaitIO(state, err)
+	if memoryLimit > 0 && (overLimit || killedByOOMKiller(state, oomKillsBefore, oomKillsCounted)) {
+		return &oomError{limit: memoryLimit}
+	}
 	// We ignore broken pipe errors, these occur very occasionally if a user
 	// specifies Stdin but their process doesn't actually read everything from
 	// Stdin. This is a fairly common thing to do, bash by default ignores
@@ -645,7 +798,11 @@ func (a *APIServer) reportUploadStats(start time.Time, stats *pps.ProcessStats,
 	}
 }
 
-func (a *APIServer) uploadOutput(pachClient *client.APIClient, dir string, tag string, logger *taggedLogger, inputs []*Input, stats *pps.ProcessStats, statsTree *hashtree.Ordered) (retErr error) {
+// uploadOutput uploads the output of the datum with the inputs 'inputs',
+// which is in 'dir', under 'partitionDir' in the output commit (see
+// outputPartitionDir). The output is also added to 'statsTree' (if it's
+// non-nil), without the partition directory.
+func (a *APIServer) uploadOutput(pachClient *client.APIClient, dir string, tag string, logger *taggedLogger, inputs []*Input, partitionDir string, stats *pps.ProcessStats, statsTree *hashtree.Ordered) (retErr error) {
 	defer a.reportUploadStats(time.Now(), stats, logger)
 	logger.Logf("starting to upload output")
 	defer func(start time.Time) {
@@ -671,13 +828,26 @@ func (a *APIServer) uploadOutput(pachClient *client.APIClient, dir string, tag s
 	defer grpcutil.PutBuffer(buf)
 	var offset uint64
 	var tree *hashtree.Ordered
+	// Output files are given the pipeline's output permissions, if it has any
+	mode, err := ppsutil.OutputFileMode(a.pipelineInfo.OutputPermissions)
+	if err != nil {
+		return err
+	}
+	owner := a.pipelineInfo.OutputPermissions.GetOwner()
+	putFile := func(relPath string, hash []byte, size int64, n *hashtree.FileNodeProto) {
+		n.Mode, n.Owner = mode, owner
+		tree.PutFile(relPath, hash, size, n)
+		if statsTree != nil {
+			statsTree.PutFile(relPath, hash, size, n)
+		}
+	}
 	// Upload all files in output directory
 	if err := filepath.Walk(outputPath, func(filePath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		if filePath == outputPath {
-			tree = hashtree.NewOrdered("/")
+			tree = hashtree.NewOrdered(partitionDir)
 			return nil
 		}
 		relPath, err := filepath.Rel(outputPath, filePath)
@@ -733,7 +903,9 @@ func (a *APIServer) uploadOutput(pachClient *client.APIClient, dir string, tag s
 					}
 					// this changes realPath from `/pfs/input/...` to `/scratch/<id>/input/...`
 					realPath = filepath.Join(dir, pathWithInput)
-					if input != nil {
+					// Files from object inputs aren't in PFS, so they're
+					// uploaded like any other output file
+					if input != nil && input.ObjectURL == "" {
 						return filepath.Walk(realPath, func(filePath string, info os.FileInfo, err error) error {
 							if err != nil {
 								return err
@@ -770,13 +942,14 @@ func (a *APIServer) uploadOutput(pachClient *client.APIClient, dir string, tag s
 							}
 							blockRefs = append(blockRefs, fileInfo.BlockRefs...)
 							n := &hashtree.FileNodeProto{BlockRefs: blockRefs}
-							tree.PutFile(subRelPath, fileInfo.Hash, int64(fileInfo.SizeBytes), n)
-							if statsTree != nil {
-								statsTree.PutFile(subRelPath, fileInfo.Hash, int64(fileInfo.SizeBytes), n)
-							}
+							putFile(subRelPath, fileInfo.Hash, int64(fileInfo.SizeBytes), n)
 							return nil
 						})
 					}
+					// Read the target from this datum's scratch directory, rather
+					// than through /pfs, which may already be linked to the next
+					// datum's data
+					filePath = realPath
 				}
 			}
 		}
@@ -820,11 +993,7 @@ func (a *APIServer) uploadOutput(pachClient *client.APIClient, dir string, tag s
 				},
 			},
 		}
-		hash := h.Sum(nil)
-		tree.PutFile(relPath, hash, size, n)
-		if statsTree != nil {
-			statsTree.PutFile(relPath, hash, size, n)
-		}
+		putFile(relPath, h.Sum(nil), size, n)
 		offset += uint64(size)
 		stats.UploadBytes += uint64(size)
 		return nil
@@ -857,6 +1026,7 @@ func HashDatum(pipelineName string, pipelineSalt string, data []*Input) string {
 		hash.Write([]byte(datum.Name))
 		hash.Write([]byte(datum.FileInfo.File.Path))
 		hash.Write(datum.FileInfo.Hash)
+		writeRange(hash, datum)
 	}
 
 	hash.Write([]byte(pipelineName))
@@ -865,6 +1035,15 @@ func HashDatum(pipelineName string, pipelineSalt string, data []*Input) string {
 	return client.DatumTagPrefix(pipelineSalt) + hex.EncodeToString(hash.Sum(nil))
 }
 
+// writeRange writes the byte range of a split input to 'w', so that the
+// datums in a split file hash differently. It writes nothing for inputs that
+// aren't split, so their hashes are the same as before splitting existed.
+func writeRange(w io.Writer, input *Input) {
+	if input.SizeBytes != 0 {
+		fmt.Fprintf(w, "%d-%d", input.OffsetBytes, input.SizeBytes)
+	}
+}
+
 // HashDatum15 computes and returns the hash of datum + pipeline for version <= 1.5.0, with a
 // pipeline-specific prefix.
 func HashDatum15(pipelineInfo *pps.PipelineInfo, data []*Input) (string, error) {
@@ -905,11 +1084,17 @@ func (a *APIServer) Status(ctx context.Context, _ *types.Empty) (*pps.WorkerStat
 		return nil, err
 	}
 	result := &pps.WorkerStatus{
-		JobID:     a.jobID,
-		WorkerID:  a.workerName,
-		Started:   started,
-		Data:      a.datum(),
-		QueueSize: atomic.LoadInt64(&a.queueSize),
+		JobID:      a.jobID,
+		WorkerID:   a.workerName,
+		Started:    started,
+		Data:       a.datum(),
+		QueueSize:  atomic.LoadInt64(&a.queueSize),
+		SpecCommit: a.pipelineInfo.SpecCommit,
+		Version:    a.pipelineInfo.Version,
+		Unready:    a.healthCheck.status(),
+	}
+	if a.schedulingLatency != nil {
+		result.SchedulingLatency = proto.Clone(a.schedulingLatency).(*pps.Histogram)
 	}
 	return result, nil
 }
@@ -918,6 +1103,16 @@ func (a *APIServer) Status(ctx context.Context, _ *types.Empty) (*pps.WorkerStat
 func (a *APIServer) Cancel(ctx context.Context, request *CancelRequest) (*CancelResponse, error) {
 	a.statusMu.Lock()
 	defer a.statusMu.Unlock()
+	if a.running != nil {
+		var success bool
+		for d := range a.running {
+			if d.jobID == request.JobID && MatchDatum(request.DataFilters, inputFiles(d.data)) {
+				d.cancel()
+				success = true
+			}
+		}
+		return &CancelResponse{Success: success}, nil
+	}
 	if request.JobID != a.jobID {
 		return &CancelResponse{Success: false}, nil
 	}
@@ -934,8 +1129,12 @@ func (a *APIServer) Cancel(ctx context.Context, request *CancelRequest) (*Cancel
 }
 
 func (a *APIServer) datum() []*pps.InputFile {
+	return inputFiles(a.data)
+}
+
+func inputFiles(data []*Input) []*pps.InputFile {
 	var result []*pps.InputFile
-	for _, datum := range a.data {
+	for _, datum := range data {
 		result = append(result, &pps.InputFile{
 			Path: datum.FileInfo.File.Path,
 			Hash: datum.FileInfo.Hash,
@@ -944,13 +1143,18 @@ func (a *APIServer) datum() []*pps.InputFile {
 	return result
 }
 
-func (a *APIServer) userCodeEnv(jobID string, outputCommitID string, data []*Input) []string {
+// userCodeEnv returns the environment of the user code that processes
+// 'data', whose inputs and output directory are in 'pfsDir'
+func (a *APIServer) userCodeEnv(pfsDir string, jobID string, traceID string, outputCommitID string, data []*Input) []string {
 	result := os.Environ()
+	result = append(result, a.secrets.environ()...)
+	result = append(result, fmt.Sprintf("%s=%s", client.PFSDirEnv, pfsDir))
 	for _, input := range data {
-		result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(client.PPSInputPrefix, input.Name, input.FileInfo.File.Path)))
+		result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(pfsDir, input.Name, input.FileInfo.File.Path)))
 		result = append(result, fmt.Sprintf("%s_COMMIT=%s", input.Name, input.FileInfo.File.Commit.ID))
 	}
 	result = append(result, fmt.Sprintf("%s=%s", client.JobIDEnv, jobID))
+	result = append(result, fmt.Sprintf("%s=%s", client.TraceIDEnv, traceID))
 	result = append(result, fmt.Sprintf("%s=%s", client.OutputCommitIDEnv, outputCommitID))
 	return result
 }
@@ -975,10 +1179,12 @@ func (a *APIServer) deleteJob(stm col.STM, jobPtr *pps.EtcdJobInfo) error {
 }
 
 type processResult struct {
-	failedDatumID   string
-	datumsProcessed int64
-	datumsSkipped   int64
-	datumsFailed    int64
+	failedDatumID        string
+	quarantinedDatumIDs  []string
+	deadLetteredDatumIDs []string
+	datumsProcessed      int64
+	datumsSkipped        int64
+	datumsFailed         int64
 }
 
 type processFunc func(low, high int64) (*processResult, error)
@@ -986,6 +1192,14 @@ type processFunc func(low, high int64) (*processResult, error)
 func (a *APIServer) acquireDatums(ctx context.Context, jobID string, plan *Plan, logger *taggedLogger, process processFunc) error {
 	complete := false
 	for !complete {
+		if a.preemption.preempted() {
+			return errWorkerPreempted
+		}
+		// An unready worker doesn't acquire chunks, so that ready workers can
+		// process them instead
+		if err := a.healthCheck.wait(ctx, logger); err != nil {
+			return err
+		}
 		// func to defer cancel in
 		if err := func() error {
 			ctx, cancel := context.WithCancel(ctx)
@@ -1012,7 +1226,7 @@ func (a *APIServer) acquireDatums(ctx context.Context, jobID string, plan *Plan,
 						complete = false
 					}
 					if found {
-						return chunks.PutTTL(fmt.Sprint(high), &ChunkState{State: State_RUNNING}, ttl)
+						return chunks.PutTTL(fmt.Sprint(high), &ChunkState{State: State_RUNNING}, lockTTL())
 					}
 					return nil
 				}); err != nil {
@@ -1024,11 +1238,30 @@ func (a *APIServer) acquireDatums(ctx context.Context, jobID string, plan *Plan,
 				low = high
 			}
 			if found {
+				if dispatched, err := types.TimestampFromProto(plan.Dispatched); err == nil {
+					func() {
+						a.statusMu.Lock()
+						defer a.statusMu.Unlock()
+						if a.schedulingLatency == nil {
+							a.schedulingLatency = newHistogram(schedulingLatencyBuckets)
+						}
+						observe(a.schedulingLatency, time.Since(dispatched).Seconds())
+					}()
+				}
+				renewDone := make(chan struct{})
 				go func() {
+					defer close(renewDone)
 				Renew:
 					for {
 						select {
 						case <-time.After((time.Second * time.Duration(ttl)) / 2):
+						case <-a.preemption.notified():
+							// Let other workers take over the chunk now,
+							// while this worker finishes its running datums
+							if err := a.releaseChunk(ctx, jobID, high); err != nil {
+								logger.Logf("failed to release chunk: %v", err)
+							}
+							break Renew
 						case <-ctx.Done():
 							break Renew
 						}
@@ -1039,7 +1272,7 @@ func (a *APIServer) acquireDatums(ctx context.Context, jobID string, plan *Plan,
 								return err
 							}
 							if chunkState.State == State_RUNNING {
-								return chunks.PutTTL(fmt.Sprint(high), &chunkState, ttl)
+								return chunks.PutTTL(fmt.Sprint(high), &chunkState, lockTTL())
 							}
 							return nil
 						}); err != nil {
@@ -1050,6 +1283,13 @@ func (a *APIServer) acquireDatums(ctx context.Context, jobID string, plan *Plan,
 				}()
 				// process the datums in newRange
 				processResult, err := process(low, high)
+				if a.preemption.preempted() {
+					// The chunk has been given up, so its result is
+					// discarded. The worker that takes it over counts the
+					// datums that this one finished as skipped.
+					<-renewDone
+					return errWorkerPreempted
+				}
 				if err != nil {
 					return err
 				}
@@ -1061,6 +1301,8 @@ func (a *APIServer) acquireDatums(ctx context.Context, jobID string, plan *Plan,
 						jobPtr.DataProcessed += processResult.datumsProcessed
 						jobPtr.DataSkipped += processResult.datumsSkipped
 						jobPtr.DataFailed += processResult.datumsFailed
+						jobPtr.QuarantinedDatums = append(jobPtr.QuarantinedDatums, processResult.quarantinedDatumIDs...)
+						jobPtr.DeadLetteredDatums = append(jobPtr.DeadLetteredDatums, processResult.deadLetteredDatumIDs...)
 						return nil
 					}); err != nil {
 						return err
@@ -1119,7 +1361,7 @@ func (a *APIServer) mergeDatums(ctx context.Context, pachClient *client.APIClien
 					}
 				}
 				if found {
-					return merges.PutTTL(fmt.Sprint(merge), &MergeState{State: State_RUNNING}, ttl)
+					return merges.PutTTL(fmt.Sprint(merge), &MergeState{State: State_RUNNING}, lockTTL())
 				}
 				return nil
 			}); err != nil {
@@ -1141,7 +1383,7 @@ func (a *APIServer) mergeDatums(ctx context.Context, pachClient *client.APIClien
 								return err
 							}
 							if mergeState.State == State_RUNNING {
-								return merges.PutTTL(fmt.Sprint(merge), &mergeState, ttl)
+								return merges.PutTTL(fmt.Sprint(merge), &mergeState, lockTTL())
 							}
 							return nil
 						}); err != nil {
@@ -1155,6 +1397,12 @@ func (a *APIServer) mergeDatums(ctx context.Context, pachClient *client.APIClien
 				if err != nil {
 					return err
 				}
+				if objClient, err = obj.NewLayoutClientFromEnv(objClient); err != nil {
+					return err
+				}
+				if objClient, err = obj.NewCompressedClientFromEnv(objClient); err != nil {
+					return err
+				}
 				var tree *pfs.Object
 				var size uint64
 				if jobInfo.DataFailed == 0 {
@@ -1201,7 +1449,26 @@ func (a *APIServer) mergeDatums(ctx context.Context, pachClient *client.APIClien
 								retErr = err
 							}
 						}()
-						rs = append([]*hashtree.Reader{hashtree.NewReader(bufio.NewReaderSize(r, parentTreeBufSize), nil)}, rs...)
+						// Compact the parent's stats as they're merged, replacing
+						// datums that are past the pipeline's stats retention
+						// with summaries of their jobs, and pruning logs that
+						// are past its log retention
+						compaction, err := a.compactStats(ctx, pachClient, jobInfo)
+						if err != nil {
+							return err
+						}
+						if compaction != nil {
+							if len(compaction.summaries) > 0 {
+								summaries, err := compaction.summaryTree(pachClient, a.objectScope())
+								if err != nil {
+									return err
+								}
+								rs = append(rs, hashtree.NewReader(summaries, hashtree.NewFilter(plan.Merges, merge)))
+							}
+							rs = append([]*hashtree.Reader{hashtree.NewPruningReader(bufio.NewReaderSize(r, parentTreeBufSize), nil, compaction.prune)}, rs...)
+						} else {
+							rs = append([]*hashtree.Reader{hashtree.NewReader(bufio.NewReaderSize(r, parentTreeBufSize), nil)}, rs...)
+						}
 					}
 					statsTree, statsSize, err = a.merge(pachClient, objClient, nil, rs)
 					if err != nil {
@@ -1287,10 +1554,11 @@ func (a *APIServer) getParentCommitInfo(ctx context.Context, pachClient *client.
 func (a *APIServer) getHashtrees(ctx context.Context, pachClient *client.APIClient, objClient obj.Client, tags []*pfs.Tag, filter func(k []byte) (bool, error)) ([]*hashtree.Reader, error) {
 	limiter := limit.New(hashtree.DefaultMergeConcurrency)
 	var eg errgroup.Group
-	var mu sync.Mutex
-	var rs []*hashtree.Reader
-	for _, tag := range tags {
-		tag := tag
+	// The readers are in the order of 'tags', which is the order that the
+	// datums' output is merged in
+	rs := make([]*hashtree.Reader, len(tags))
+	for i, tag := range tags {
+		i, tag := i, tag
 		limiter.Acquire()
 		eg.Go(func() (retErr error) {
 			defer limiter.Release()
@@ -1324,10 +1592,7 @@ func (a *APIServer) getHashtrees(ctx context.Context, pachClient *client.APIClie
 			if err := w.Copy(r); err != nil {
 				return err
 			}
-			// Add it to the list of readers
-			mu.Lock()
-			defer mu.Unlock()
-			rs = append(rs, hashtree.NewReader(filteredTree, nil))
+			rs[i] = hashtree.NewReader(filteredTree, nil)
 			return nil
 		})
 	}
@@ -1463,10 +1728,10 @@ func (a *APIServer) cancelCtxIfJobFails(jobCtx context.Context, jobCancel func()
 }
 
 // worker does the following:
-//  - watches for new jobs (jobInfos in the jobs collection)
-//  - claims chunks from the chunk layout it finds in the chunks collection
-//  - claims those chunks with acquireDatums
-//  - processes the chunks with processDatums
+//   - watches for new jobs (jobInfos in the jobs collection)
+//   - claims chunks from the chunk layout it finds in the chunks collection
+//   - claims those chunks with acquireDatums
+//   - processes the chunks with processDatums
 func (a *APIServer) worker() {
 	logger := a.getWorkerLogger() // this worker's formatting logger
 
@@ -1474,6 +1739,7 @@ func (a *APIServer) worker() {
 	backoff.RetryNotify(func() (retErr error) {
 		retryCtx, retryCancel := context.WithCancel(a.pachClient.Ctx())
 		defer retryCancel()
+		go a.cancelOnPreemption(retryCtx, retryCancel)
 		watcher, err := a.jobs.ReadOnly(retryCtx).WatchByIndex(ppsdb.JobsPipelineIndex, a.pipelineInfo.Pipeline)
 		if err != nil {
 			return fmt.Errorf("error creating watch: %v", err)
@@ -1532,6 +1798,7 @@ func (a *APIServer) worker() {
 					"version (%d), this should automatically resolve when the worker "+
 					"is updated", jobID, jobInfo.PipelineVersion, a.pipelineInfo.Version)
 			}
+			jobLogger := logger.jobLogger(jobInfo)
 
 			// Read the chunks laid out by the master and create the datum factory
 			plan := &Plan{}
@@ -1542,6 +1809,10 @@ func (a *APIServer) worker() {
 			if err != nil {
 				return fmt.Errorf("error from NewDatumFactory: %v", err)
 			}
+			df, err = newPrioritizedDatumFactory(df, a.pipelineInfo.DatumPriority)
+			if err != nil {
+				return err
+			}
 
 			// Compute the datums to skip
 			skip := make(map[string]struct{})
@@ -1558,7 +1829,10 @@ func (a *APIServer) worker() {
 				}
 				var count int
 				for i := 0; i < df.Len(); i++ {
-					files := df.Datum(i)
+					files, err := df.Datum(i)
+					if err != nil {
+						return err
+					}
 					datumHash := HashDatum(a.pipelineInfo.Pipeline.Name, a.pipelineInfo.Salt, files)
 					if _, ok := skip[datumHash]; ok {
 						count++
@@ -1573,9 +1847,9 @@ func (a *APIServer) worker() {
 			// handled above in the JOB_FAILURE case). There's no need to
 			// handle failed datums here, just failed etcd writes.
 			if err := a.acquireDatums(
-				jobCtx, jobID, plan, logger,
+				jobCtx, jobID, plan, jobLogger,
 				func(low, high int64) (*processResult, error) {
-					processResult, err := a.processDatums(pachClient, logger, jobInfo, df, low, high, skip)
+					processResult, err := a.processDatums(pachClient, jobLogger, jobInfo, df, low, high, skip)
 					if err != nil {
 						return nil, err
 					}
@@ -1588,20 +1862,28 @@ func (a *APIServer) worker() {
 				return fmt.Errorf("acquire/process datums for job %s exited with err: %v", jobID, err)
 			}
 			var tags []*pfs.Tag
+			var inputPaths []string
 			for i := 0; i < df.Len(); i++ {
-				files := df.Datum(int(i))
+				files, err := df.Datum(int(i))
+				if err != nil {
+					return err
+				}
 				datumHash := HashDatum(a.pipelineInfo.Pipeline.Name, a.pipelineInfo.Salt, files)
 				if _, ok := skip[datumHash]; ok && useParentHashTree {
 					continue
 				}
 				tags = append(tags, client.NewTag(datumHash))
+				inputPaths = append(inputPaths, datumInputPath(files))
+			}
+			if a.pipelineInfo.OrderedMerge {
+				sortTagsByInputPath(tags, inputPaths)
 			}
 			skip = nil
 			jobInfo, err = pachClient.InspectJob(jobID, false)
 			if err != nil {
 				return err
 			}
-			if err := a.mergeDatums(jobCtx, pachClient, jobInfo, jobID, plan, logger, tags, useParentHashTree); err != nil {
+			if err := a.mergeDatums(jobCtx, pachClient, jobInfo, jobID, plan, jobLogger, tags, useParentHashTree); err != nil {
 				if jobCtx.Err() == context.Canceled {
 					continue NextJob // job cancelled--don't restart, just wait for next job
 				}
@@ -1610,6 +1892,10 @@ func (a *APIServer) worker() {
 		}
 		return fmt.Errorf("worker: jobs.WatchByIndex(pipeline = %s) closed unexpectedly", a.pipelineInfo.Pipeline.Name)
 	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+		if a.preemption.preempted() {
+			logger.Logf("worker: preempted, so not processing any more datums")
+			return err
+		}
 		logger.Logf("worker: watch closed or error running the worker process: %v; retrying in %v", err, d)
 		return nil
 	})
@@ -1624,22 +1910,65 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 	if err != nil {
 		return nil, err
 	}
+	if objClient, err = obj.NewLayoutClientFromEnv(objClient); err != nil {
+		return nil, err
+	}
+	if objClient, err = obj.NewCompressedClientFromEnv(objClient); err != nil {
+		return nil, err
+	}
 	stats := &pps.ProcessStats{}
 	var statsMu sync.Mutex
 	result := &processResult{}
+	var resultMu sync.Mutex
+	// If the job's output is capped, no more datums are started once this
+	// chunk's output, on top of the job's output when the chunk started,
+	// exceeds the cap. The job is then failed below.
+	maxOutputBytes := maxJobOutputBytes(a.pipelineInfo)
+	var prevOutputBytes uint64
+	if maxOutputBytes > 0 {
+		jobPtr := &pps.EtcdJobInfo{}
+		if err := a.jobs.ReadOnly(ctx).Get(jobInfo.Job.ID, jobPtr); err != nil {
+			return nil, err
+		}
+		prevOutputBytes = jobPtr.Stats.GetUploadBytes()
+	}
+	var overQuota int32
+	var healthErr error
 	var eg errgroup.Group
-	limiter := limit.New(int(a.pipelineInfo.MaxQueueSize))
+	stages := newDatumStages(&a.runMu, a.pipelineInfo.MaxQueueSize, a.pipelineInfo.StageConcurrency, a.pipelineInfo.DatumsPerWorker, low)
+	// If several datums' code runs at once, each sees its inputs in its own
+	// scratch directory, rather than at /pfs
+	concurrent := a.pipelineInfo.DatumsPerWorker > 1
+	limiter := limit.New(stages.queueSize)
+	end := high
 	for i := low; i < high; i++ {
 		i := i
 
 		limiter.Acquire()
+		if atomic.LoadInt32(&overQuota) != 0 || a.preemption.preempted() {
+			limiter.Release()
+			end = i
+			break
+		}
+		// Hold the next datum until the pipeline's health check passes
+		if healthErr = a.healthCheck.wait(ctx, logger); healthErr != nil {
+			limiter.Release()
+			end = i
+			break
+		}
 		atomic.AddInt64(&a.queueSize, 1)
 		eg.Go(func() (retErr error) {
 			defer limiter.Release()
 			defer atomic.AddInt64(&a.queueSize, -1)
+			// Once this datum is done, whether or not it reached each stage,
+			// the datums after it no longer wait for it
+			defer stages.skip(i)
 
-			data := df.Datum(int(i))
-			logger, err := a.getTaggedLogger(pachClient, jobInfo.Job.ID, data, a.pipelineInfo.EnableStats)
+			data, err := df.Datum(int(i))
+			if err != nil {
+				return err
+			}
+			logger, err := a.getTaggedLogger(pachClient, jobInfo.Job.ID, jobInfo.TraceID, data, a.pipelineInfo.EnableStats)
 			if err != nil {
 				return err
 			}
@@ -1655,6 +1984,8 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 				logger.Logf("skipping datum")
 				return nil
 			}
+			events := a.datumEvents.datum(jobInfo.Job.ID, logger.template.DatumID, data)
+			events.started()
 			subStats := &pps.ProcessStats{}
 			var inputTree, outputTree *hashtree.Ordered
 			var statsTree *hashtree.Unordered
@@ -1666,7 +1997,7 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 				// Write job id to stats tree
 				statsTree.PutFile(fmt.Sprintf("job:%s", jobInfo.Job.ID), nil, 0)
 				// Write index in datum factory to stats tree
-				object, size, err := pachClient.PutObject(strings.NewReader(fmt.Sprint(int(i))))
+				object, size, err := pachClient.PutObjectInScope(a.objectScope(), strings.NewReader(fmt.Sprint(int(i))))
 				if err != nil {
 					return err
 				}
@@ -1686,18 +2017,16 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 				}()
 			}
 
-			env := a.userCodeEnv(jobInfo.Job.ID, jobInfo.OutputCommit.ID, data)
-			var dir string
-			var failures int64
-			if err := backoff.RetryNotify(func() error {
-				if isDone(ctx) {
-					return ctx.Err() // timeout or cancelled job--don't run datum
-				}
-				// Download input data
+			memory, err := newDatumMemory(a.pipelineInfo.OOMRetry)
+			if err != nil {
+				return err
+			}
+			err = retryDatum(ctx, jobInfo.DatumTries, memory, logger.Logf, func(memoryLimit int64) error {
 				puller := filesync.NewPuller()
-				// TODO parent tag shouldn't be nil
-				var err error
-				dir, err = a.downloadData(pachClient, logger, data, puller, subStats, inputTree)
+				var dir string
+				// partitionDir is the directory that the datum's output is written
+				// under (see outputPartitionDir)
+				var partitionDir string
 				// We run these cleanup functions no matter what, so that if
 				// downloadData partially succeeded, we still clean up the resources.
 				defer func() {
@@ -1713,87 +2042,161 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 						retErr = err
 					}
 				}()
-				if err != nil {
-					return fmt.Errorf("error downloadData: %v", err)
-				}
-				a.runMu.Lock()
-				defer a.runMu.Unlock()
-				// shadow ctx and pachClient for the context of processing this one datum
-				ctx, cancel := context.WithCancel(ctx)
-				pachClient := pachClient.WithCtx(ctx)
-				func() {
-					a.statusMu.Lock()
-					defer a.statusMu.Unlock()
-					a.jobID = jobInfo.Job.ID
-					a.data = data
-					a.started = time.Now()
-					a.cancel = cancel
-					a.stats = stats
-				}()
-				if err := os.MkdirAll(client.PPSInputPrefix, 0777); err != nil {
-					return err
-				}
-				if err := a.linkData(data, dir); err != nil {
-					return fmt.Errorf("error linkData: %v", err)
-				}
-				defer func() {
-					if err := a.unlinkData(data); err != nil && retErr == nil {
-						retErr = fmt.Errorf("error unlinkData: %v", err)
+				return stages.run(i, func() error {
+					events.phase(DatumPhase_DOWNLOADING)
+					// Download input data
+					// TODO parent tag shouldn't be nil
+					var err error
+					dir, err = a.downloadData(pachClient, logger, data, puller, subStats, inputTree)
+					if err != nil {
+						return fmt.Errorf("error downloadData: %v", err)
 					}
-				}()
-				if a.pipelineInfo.Transform.User != "" {
-					filepath.Walk("/pfs", func(name string, info os.FileInfo, err error) error {
-						if err == nil {
-							err = os.Chown(name, int(a.uid), int(a.gid))
+					return nil
+				}, func() error {
+					events.phase(DatumPhase_PROCESSING)
+					// shadow ctx for the context of processing this one datum
+					ctx, cancel := context.WithCancel(ctx)
+					func() {
+						a.statusMu.Lock()
+						defer a.statusMu.Unlock()
+						a.jobID = jobInfo.Job.ID
+						a.data = data
+						a.started = time.Now()
+						a.cancel = cancel
+						a.stats = stats
+					}()
+					if concurrent {
+						d := &runningDatum{jobID: jobInfo.Job.ID, data: data, cancel: cancel}
+						func() {
+							a.statusMu.Lock()
+							defer a.statusMu.Unlock()
+							a.running[d] = struct{}{}
+						}()
+						defer func() {
+							a.statusMu.Lock()
+							defer a.statusMu.Unlock()
+							delete(a.running, d)
+						}()
+					}
+					if a.pipelineInfo.MaxDatumCrashes > 0 {
+						// Record that we're processing this datum, so that if the worker
+						// crashes before finishing, the crash is attributed to it
+						datumID := a.DatumID(data)
+						quarantine, err := a.startDatum(ctx, jobInfo.Job.ID, datumID)
+						if err != nil {
+							return err
 						}
+						if quarantine {
+							return errDatumQuarantined
+						}
+						defer func() {
+							if err := a.finishDatum(jobInfo.Job.ID, datumID); err != nil {
+								logger.Logf("could not record that datum finished: %v", err)
+							}
+						}()
+					}
+					// A datum whose output can't be partitioned, or whose
+					// command can't be derived from its input, fails before its
+					// code runs
+					var err error
+					if partitionDir, err = outputPartitionDir(a.pipelineInfo.OutputPartition, data); err != nil {
 						return err
-					})
-				}
-				if err := a.runUserCode(ctx, logger, env, subStats, jobInfo.DatumTimeout); err != nil {
-					return fmt.Errorf("error runUserCode: %v", err)
-				}
-				// CleanUp is idempotent so we can call it however many times we want.
-				// The reason we are calling it here is that the puller could've
-				// encountered an error as it was lazily loading files, in which case
-				// the output might be invalid since as far as the user's code is
-				// concerned, they might've just seen an empty or partially completed
-				// file.
-				downSize, err := puller.CleanUp()
-				if err != nil {
-					logger.Logf("puller encountered an error while cleaning up: %+v", err)
-					return err
-				}
-				atomic.AddUint64(&subStats.DownloadBytes, uint64(downSize))
-				a.reportDownloadSizeStats(float64(downSize), logger)
-				return a.uploadOutput(pachClient, dir, tag, logger, data, subStats, outputTree)
-			}, &backoff.ZeroBackOff{}, func(err error, d time.Duration) error {
-				if isDone(ctx) {
-					return ctx.Err() // timeout or cancelled job, err out and don't retry
-				}
-				failures++
-				if failures >= jobInfo.DatumTries {
-					logger.Logf("failed to process datum with error: %+v", err)
-					if statsTree != nil {
-						object, size, err := pachClient.PutObject(strings.NewReader(err.Error()))
-						if err != nil {
-							logger.stderrLog.Printf("could not put error object: %s\n", err)
-						} else {
-							objectInfo, err := pachClient.InspectObject(object.Hash)
-							if err != nil {
-								return err
+					}
+					workingDir, extraArgs, err := datumCommand(a.pipelineInfo.Transform, data)
+					if err != nil {
+						return err
+					}
+					pfsDir := dir
+					if !concurrent {
+						pfsDir = client.PPSInputPrefix
+						if err := os.MkdirAll(client.PPSInputPrefix, 0777); err != nil {
+							return err
+						}
+						if err := a.linkData(data, dir); err != nil {
+							return fmt.Errorf("error linkData: %v", err)
+						}
+						defer func() {
+							if err := a.unlinkData(data); err != nil && retErr == nil {
+								retErr = fmt.Errorf("error unlinkData: %v", err)
 							}
-							h, err := pfs.DecodeHash(object.Hash)
-							if err != nil {
-								return err
+						}()
+					}
+					env := a.userCodeEnv(pfsDir, jobInfo.Job.ID, jobInfo.TraceID, jobInfo.OutputCommit.ID, data)
+					if a.pipelineInfo.Transform.User != "" {
+						filepath.Walk(pfsDir, func(name string, info os.FileInfo, err error) error {
+							if err == nil {
+								err = os.Chown(name, int(a.uid), int(a.gid))
 							}
-							statsTree.PutFile("failure", h, size, objectInfo.BlockRef)
+							return err
+						})
+					}
+					if err := a.runUserCode(ctx, logger, env, workingDir, extraArgs, subStats, jobInfo.DatumTimeout, memoryLimit); err != nil {
+						if isOOMError(err) {
+							return err
 						}
+						return fmt.Errorf("error runUserCode: %v", err)
+					}
+					// CleanUp is idempotent so we can call it however many times we want.
+					// The reason we are calling it here is that the puller could've
+					// encountered an error as it was lazily loading files, in which case
+					// the output might be invalid since as far as the user's code is
+					// concerned, they might've just seen an empty or partially completed
+					// file.
+					downSize, err := puller.CleanUp()
+					if err != nil {
+						logger.Logf("puller encountered an error while cleaning up: %+v", err)
+						return err
+					}
+					atomic.AddUint64(&subStats.DownloadBytes, uint64(downSize))
+					a.reportDownloadSizeStats(float64(downSize), logger)
+					return checkOutput(a.pipelineInfo.OutputSchema, filepath.Join(dir, "out"))
+				}, func() error {
+					events.phase(DatumPhase_UPLOADING)
+					// The user code's output is uploaded separately from the datum's
+					// retries, so that an error writing to PFS doesn't rerun it
+					return retryUpload(ctx, a.pipelineInfo.UploadTries, logger.Logf, func() error {
+						if a.pipelineInfo.EnableStats {
+							outputTree = hashtree.NewOrdered(path.Join("/", logger.template.DatumID, "pfs", "out"))
+						}
+						subStats.UploadBytes = 0
+						return a.uploadOutput(pachClient, dir, tag, logger, data, partitionDir, subStats, outputTree)
+					})
+				})
+			})
+			if err != nil && err != errDatumQuarantined && !isDone(ctx) {
+				logger.Logf("failed to process datum with error: %+v", err)
+				if statsTree != nil {
+					if err := a.writeFailure(pachClient, logger, statsTree, err.Error()); err != nil {
+						return err
+					}
+				}
+			}
+			events.completed(err)
+			if err == errDatumQuarantined {
+				logger.Logf("quarantining datum, which crashed workers %d times", a.pipelineInfo.MaxDatumCrashes)
+				if statsTree != nil {
+					if err := a.writeFailure(pachClient, logger, statsTree, fmt.Sprintf("quarantined after crashing workers %d times", a.pipelineInfo.MaxDatumCrashes)); err != nil {
+						return err
 					}
-					return err
 				}
-				logger.Logf("failed processing datum: %v, retrying in %v", err, d)
+				resultMu.Lock()
+				defer resultMu.Unlock()
+				result.quarantinedDatumIDs = append(result.quarantinedDatumIDs, a.DatumID(data))
 				return nil
-			}); err != nil {
+			} else if err != nil {
+				if a.pipelineInfo.DeadLetterBranch != "" && !isDone(ctx) {
+					// Record the datum in the dead letter branch, rather than failing
+					// the job, unless it can't be recorded
+					if deadLetterErr := a.writeDeadLetter(pachClient, jobInfo, data, err); deadLetterErr != nil {
+						logger.Logf("could not write datum to dead letter branch %s: %v", a.pipelineInfo.DeadLetterBranch, deadLetterErr)
+					} else {
+						logger.Logf("wrote failed datum to dead letter branch %s", a.pipelineInfo.DeadLetterBranch)
+						resultMu.Lock()
+						defer resultMu.Unlock()
+						result.deadLetteredDatumIDs = append(result.deadLetteredDatumIDs, a.DatumID(data))
+						return nil
+					}
+				}
 				result.failedDatumID = a.DatumID(data)
 				atomic.AddInt64(&result.datumsFailed, 1)
 				return nil
@@ -1803,12 +2206,19 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 			if err := mergeStats(stats, subStats); err != nil {
 				logger.Logf("failed to merge Stats: %v", err)
 			}
+			if maxOutputBytes > 0 && prevOutputBytes+stats.UploadBytes > maxOutputBytes {
+				atomic.StoreInt32(&overQuota, 1)
+			}
 			return nil
 		})
 	}
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	if healthErr != nil {
+		return nil, healthErr
+	}
+	var jobOverQuota bool
 	if _, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
 		jobs := a.jobs.ReadWrite(stm)
 		jobID := jobInfo.Job.ID
@@ -1822,14 +2232,54 @@ func (a *APIServer) processDatums(pachClient *client.APIClient, logger *taggedLo
 		if err := mergeStats(jobPtr.Stats, stats); err != nil {
 			logger.Logf("failed to merge Stats: %v", err)
 		}
+		var err error
+		if jobOverQuota, err = a.checkOutputQuota(stm, jobPtr); err != nil || jobOverQuota {
+			return err
+		}
 		return jobs.Put(jobID, jobPtr)
 	}); err != nil {
 		return nil, err
 	}
-	result.datumsProcessed = high - low - result.datumsSkipped - result.datumsFailed
+	if jobOverQuota {
+		logger.Logf("job's output exceeded max_output_bytes (%d), stopping it", maxOutputBytes)
+		if err := stopOverQuotaJob(pachClient, jobInfo); err != nil {
+			return nil, err
+		}
+	}
+	result.datumsProcessed = end - low - result.datumsSkipped - result.datumsFailed - int64(len(result.quarantinedDatumIDs)) - int64(len(result.deadLetteredDatumIDs))
 	return result, nil
 }
 
+// objectScope returns the dedup scope (see pfs.PutObjectRequest.DedupScope)
+// of the file content that the worker puts in the pipeline's output repo, per
+// the dedup scope that pachd passed on in client.PFSDedupScopeEnv
+func (a *APIServer) objectScope() string {
+	if os.Getenv(client.PFSDedupScopeEnv) == client.PFSDedupScopeRepo {
+		return pfs.RepoScope(a.pipelineInfo.Pipeline.Name)
+	}
+	return ""
+}
+
+// writeFailure adds a "failure" file containing 'reason' to the stats of a
+// datum that failed. Failing to store 'reason' is logged rather than returned.
+func (a *APIServer) writeFailure(pachClient *client.APIClient, logger *taggedLogger, statsTree *hashtree.Unordered, reason string) error {
+	object, size, err := pachClient.PutObjectInScope(a.objectScope(), strings.NewReader(reason))
+	if err != nil {
+		logger.stderrLog.Printf("could not put error object: %s\n", err)
+		return nil
+	}
+	objectInfo, err := pachClient.InspectObject(object.Hash)
+	if err != nil {
+		return err
+	}
+	h, err := pfs.DecodeHash(object.Hash)
+	if err != nil {
+		return err
+	}
+	statsTree.PutFile("failure", h, size, objectInfo.BlockRef)
+	return nil
+}
+
 func (a *APIServer) writeStats(pachClient *client.APIClient, objClient obj.Client, tag string, stats *pps.ProcessStats, logger *taggedLogger, inputTree, outputTree *hashtree.Ordered, statsTree *hashtree.Unordered) error {
 	// Store stats and add stats file
 	marshaler := &jsonpb.Marshaler{}
@@ -1838,7 +2288,7 @@ func (a *APIServer) writeStats(pachClient *client.APIClient, objClient obj.Clien
 		logger.stderrLog.Printf("could not serialize stats: %s\n", err)
 		return err
 	}
-	object, size, err := pachClient.PutObject(strings.NewReader(statsString))
+	object, size, err := pachClient.PutObjectInScope(a.objectScope(), strings.NewReader(statsString))
 	if err != nil {
 		logger.stderrLog.Printf("could not put stats object: %s\n", err)
 		return err
@@ -1852,12 +2302,12 @@ func (a *APIServer) writeStats(pachClient *client.APIClient, objClient obj.Clien
 		return err
 	}
 	statsTree.PutFile("stats", h, size, objectInfo.BlockRef)
-	// Store logs and add logs file
-	object, size, err = logger.Close()
+	// Store logs and add logs files
+	objects, sizes, err := logger.Close()
 	if err != nil {
 		return err
 	}
-	if object != nil {
+	for i, object := range objects {
 		objectInfo, err := pachClient.InspectObject(object.Hash)
 		if err != nil {
 			return err
@@ -1866,7 +2316,7 @@ func (a *APIServer) writeStats(pachClient *client.APIClient, objClient obj.Clien
 		if err != nil {
 			return err
 		}
-		statsTree.PutFile("logs", h, size, objectInfo.BlockRef)
+		statsTree.PutFile(logsFileName(i), h, sizes[i], objectInfo.BlockRef)
 	}
 	// Merge stats trees (input, output, stats) and write out
 	inputBuf := &bytes.Buffer{}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/debug"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 
 	etcd "github.com/coreos/etcd/clientv3"
 	"google.golang.org/grpc"
@@ -39,6 +44,137 @@ func Status(ctx context.Context, pipelineRcName string, etcdClient *etcd.Client,
 	return result, nil
 }
 
+// PipelineStatus returns the statuses of all workers for the pipeline
+// pipelineName. Unlike Status, this includes workers from every version of
+// the pipeline, as the workers of an old version may still be running while
+// the pipeline is being updated. A worker whose status can't be retrieved
+// (e.g. because it's unreachable) is reported with its Error set, rather than
+// failing the whole call, so that the other workers' statuses are returned.
+func PipelineStatus(ctx context.Context, pipelineName string, etcdClient *etcd.Client, etcdPrefix string) ([]*pps.WorkerStatus, error) {
+	rcPrefix := ppsutil.PipelineRcNamePrefix(pipelineName)
+	resp, err := etcdClient.Get(ctx, path.Join(etcdPrefix, WorkerEtcdPrefix, rcPrefix), etcd.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	var result []*pps.WorkerStatus
+	var wg sync.WaitGroup
+	for _, kv := range resp.Kvs {
+		rcName, address, ok := parseWorkerKey(string(kv.Key), rcPrefix)
+		if !ok {
+			continue
+		}
+		// Workers are queried concurrently, so that unreachable workers'
+		// dial timeouts don't add up
+		result = append(result, nil)
+		i := len(result) - 1
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := workerStatus(ctx, address)
+			if err != nil {
+				version, _ := strconv.ParseUint(strings.TrimPrefix(rcName, rcPrefix), 10, 64)
+				status = &pps.WorkerStatus{
+					WorkerID: address,
+					Version:  version,
+					Error:    grpcutil.ScrubGRPC(err).Error(),
+				}
+			}
+			result[i] = status
+		}()
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// workerStatus returns the status of the worker at 'address'
+func workerStatus(ctx context.Context, address string) (*pps.WorkerStatus, error) {
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("%s:%d", address, client.PPSWorkerPort),
+		append(client.DefaultDialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return NewWorkerClient(conn).Status(ctx, &types.Empty{})
+}
+
+// parseWorkerKey returns the RC name and address of the worker registered at
+// the etcd key 'key', if it belongs to a version of the pipeline whose RC
+// names start with rcPrefix (and not some other pipeline whose RC names
+// share rcPrefix).
+func parseWorkerKey(key string, rcPrefix string) (string, string, bool) {
+	rcName := path.Base(path.Dir(key))
+	if !strings.HasPrefix(rcName, rcPrefix) {
+		return "", "", false
+	}
+	if _, err := strconv.ParseUint(strings.TrimPrefix(rcName, rcPrefix), 10, 64); err != nil {
+		return "", "", false
+	}
+	return rcName, path.Base(key), true
+}
+
+// WatchPipelineWorkers calls f with a WORKER_JOINED event for each worker that's
+// registered for any version of the pipeline pipelineName, and then with an
+// event each time one of the pipeline's workers registers or goes away,
+// until ctx is cancelled or f returns an error.
+func WatchPipelineWorkers(ctx context.Context, pipelineName string, etcdClient *etcd.Client, etcdPrefix string, f func(*pps.WorkerEvent) error) error {
+	rcPrefix := ppsutil.PipelineRcNamePrefix(pipelineName)
+	prefix := path.Join(etcdPrefix, WorkerEtcdPrefix, rcPrefix)
+	resp, err := etcdClient.Get(ctx, prefix, etcd.WithPrefix())
+	if err != nil {
+		return err
+	}
+	// Workers' keys are only ever created and deleted, but track which
+	// workers are registered anyway so that each join is reported once
+	registered := make(map[string]bool)
+	for _, kv := range resp.Kvs {
+		rcName, address, ok := parseWorkerKey(string(kv.Key), rcPrefix)
+		if !ok {
+			continue
+		}
+		registered[string(kv.Key)] = true
+		if err := f(&pps.WorkerEvent{Type: pps.WorkerEventType_WORKER_JOINED, RcName: rcName, Address: address}); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watchCh := etcdClient.Watch(ctx, prefix, etcd.WithPrefix(), etcd.WithRev(resp.Header.Revision+1))
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case watchResp, ok := <-watchCh:
+			if !ok {
+				return ctx.Err()
+			}
+			if err := watchResp.Err(); err != nil {
+				return err
+			}
+			for _, event := range watchResp.Events {
+				key := string(event.Kv.Key)
+				rcName, address, ok := parseWorkerKey(key, rcPrefix)
+				if !ok {
+					continue
+				}
+				workerEvent := &pps.WorkerEvent{RcName: rcName, Address: address}
+				switch {
+				case event.Type == etcd.EventTypePut && !registered[key]:
+					registered[key] = true
+					workerEvent.Type = pps.WorkerEventType_WORKER_JOINED
+				case event.Type == etcd.EventTypeDelete && registered[key]:
+					delete(registered, key)
+					workerEvent.Type = pps.WorkerEventType_WORKER_LEFT
+				default:
+					continue
+				}
+				if err := f(workerEvent); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
 // Cancel cancels a set of datums running on workers.
 // pipelineRcName is the name of the pipeline's RC and can be gotten with
 // ppsutil.PipelineRcName.
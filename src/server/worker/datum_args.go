@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// ValidateDatumArgs returns an error if 'datumArgs', the datum args of a
+// pipeline with the input 'input', are malformed.
+func ValidateDatumArgs(datumArgs *pps.DatumArgs, input *pps.Input) error {
+	if datumArgs == nil {
+		return nil
+	}
+	if datumArgs.Pattern == "" {
+		return fmt.Errorf("pattern must be set")
+	}
+	if _, err := regexp.Compile(datumArgs.Pattern); err != nil {
+		return fmt.Errorf("could not compile pattern %q: %v", datumArgs.Pattern, err)
+	}
+	if datumArgs.WorkingDir == "" && len(datumArgs.Args) == 0 {
+		return fmt.Errorf("at least one of working_dir and args must be set")
+	}
+	if input == nil {
+		return fmt.Errorf("pipelines without inputs can't have datum args")
+	}
+	return validatePathInput(datumArgs.Input, input)
+}
+
+// datumCommand returns the working directory of 'transform's command, and
+// the arguments that are appended to it, for the datum with the inputs
+// 'data' (see pps.DatumArgs)
+func datumCommand(transform *pps.Transform, data []*Input) (string, []string, error) {
+	datumArgs := transform.DatumArgs
+	if datumArgs == nil {
+		return transform.WorkingDir, nil, nil
+	}
+	re, err := regexp.Compile(datumArgs.Pattern)
+	if err != nil {
+		return "", nil, err
+	}
+	input, err := pathInput(datumArgs.Input, data)
+	if err != nil {
+		return "", nil, fmt.Errorf("%v to derive the command's arguments from", err)
+	}
+	inputPath := input.FileInfo.File.Path
+	match := re.FindStringSubmatchIndex(inputPath)
+	if match == nil {
+		if datumArgs.AllowUnmatched {
+			return transform.WorkingDir, nil, nil
+		}
+		return "", nil, fmt.Errorf("input path %s doesn't match the datum args pattern %q", inputPath, datumArgs.Pattern)
+	}
+	expand := func(template string) string {
+		return string(re.ExpandString(nil, template, inputPath, match))
+	}
+	workingDir := transform.WorkingDir
+	if datumArgs.WorkingDir != "" {
+		workingDir = expand(datumArgs.WorkingDir)
+		if !filepath.IsAbs(workingDir) {
+			workingDir = filepath.Join(transform.WorkingDir, workingDir)
+		}
+	}
+	var args []string
+	for _, arg := range datumArgs.Args {
+		args = append(args, expand(arg))
+	}
+	return workingDir, args, nil
+}
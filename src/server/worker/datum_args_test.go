@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestDatumCommand(t *testing.T) {
+	transform := &pps.Transform{
+		WorkingDir: "/app",
+		DatumArgs: &pps.DatumArgs{
+			Input:      "sales",
+			Pattern:    `^/customers/(?P<customer>[^/]+)/([^/]+)\.csv$`,
+			WorkingDir: "configs/${customer}",
+			Args:       []string{"--date", "$2"},
+		},
+	}
+	data := []*Input{
+		partitionInput("other", "/x"),
+		partitionInput("sales", "/customers/acme/2019-01-01.csv"),
+	}
+	workingDir, args, err := datumCommand(transform, data)
+	require.NoError(t, err)
+	require.Equal(t, "/app/configs/acme", workingDir)
+	require.Equal(t, []string{"--date", "2019-01-01"}, args)
+
+	// Datums that don't match fail, unless 'allow_unmatched' is set
+	data[1] = partitionInput("sales", "/summary.csv")
+	_, _, err = datumCommand(transform, data)
+	require.YesError(t, err)
+	transform.DatumArgs.AllowUnmatched = true
+	workingDir, args, err = datumCommand(transform, data)
+	require.NoError(t, err)
+	require.Equal(t, "/app", workingDir)
+	require.Equal(t, 0, len(args))
+
+	// Without datum args, the transform's working dir is used
+	workingDir, args, err = datumCommand(&pps.Transform{WorkingDir: "/app"}, data)
+	require.NoError(t, err)
+	require.Equal(t, "/app", workingDir)
+	require.Equal(t, 0, len(args))
+}
+
+func TestValidateDatumArgs(t *testing.T) {
+	input := client.NewCrossInput(
+		client.NewPFSInputOpts("sales", "sales", "master", "/*/*", false),
+		client.NewPFSInputOpts("config", "config", "master", "/", false),
+	)
+	require.NoError(t, ValidateDatumArgs(nil, input))
+	require.NoError(t, ValidateDatumArgs(&pps.DatumArgs{Input: "sales", Pattern: "^/(.*)", Args: []string{"$1"}}, input))
+	// The input must be set for crosses, and exist
+	require.YesError(t, ValidateDatumArgs(&pps.DatumArgs{Pattern: "^/(.*)", Args: []string{"$1"}}, input))
+	require.YesError(t, ValidateDatumArgs(&pps.DatumArgs{Input: "logs", Pattern: "^/(.*)", Args: []string{"$1"}}, input))
+	// The pattern must compile, and something must be derived from it
+	require.YesError(t, ValidateDatumArgs(&pps.DatumArgs{Input: "sales", Pattern: "(", Args: []string{"$1"}}, input))
+	require.YesError(t, ValidateDatumArgs(&pps.DatumArgs{Input: "sales", Pattern: "^/(.*)"}, input))
+}
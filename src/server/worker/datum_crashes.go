@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"path"
+	"time"
+
+	"golang.org/x/net/context"
+
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// datumCrashes is the collection that counts, for each datum of the job
+// 'jobID', the workers that started processing the datum and never finished.
+// A worker increments a datum's count before running the user code on it, and
+// decrements it afterwards (whether or not the user code succeeded), so the
+// count only stays incremented if the worker crashed while processing it.
+// The master deletes a job's counts once the job finishes.
+func (a *APIServer) datumCrashes(jobID string) col.Collection {
+	return col.NewCollection(a.etcdClient, path.Join(a.etcdPrefix, crashPrefix, jobID), nil, &DatumCrashes{}, nil, nil)
+}
+
+// startDatum records that this worker is about to process the datum
+// 'datumID'. If workers have already crashed while processing the datum
+// MaxDatumCrashes times, it records nothing and returns true, meaning that the
+// datum should be quarantined.
+func (a *APIServer) startDatum(ctx context.Context, jobID string, datumID string) (bool, error) {
+	var quarantine bool
+	_, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+		crashes := a.datumCrashes(jobID).ReadWrite(stm)
+		datumCrashes := &DatumCrashes{}
+		if err := crashes.Get(datumID, datumCrashes); err != nil && !col.IsErrNotFound(err) {
+			return err
+		}
+		quarantine = datumCrashes.Crashes >= a.pipelineInfo.MaxDatumCrashes
+		if quarantine {
+			return nil
+		}
+		datumCrashes.Crashes++
+		return crashes.Put(datumID, datumCrashes)
+	})
+	return quarantine, err
+}
+
+// finishDatumTimeout bounds how long finishDatum waits for etcd
+const finishDatumTimeout = 30 * time.Second
+
+// finishDatum records that this worker has finished processing the datum
+// 'datumID', undoing startDatum. It doesn't take the datum's context, which
+// is cancelled if the datum is cancelled or times out, as the worker hasn't
+// crashed in that case either.
+func (a *APIServer) finishDatum(jobID string, datumID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), finishDatumTimeout)
+	defer cancel()
+	_, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+		crashes := a.datumCrashes(jobID).ReadWrite(stm)
+		datumCrashes := &DatumCrashes{}
+		if err := crashes.Get(datumID, datumCrashes); err != nil {
+			if col.IsErrNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		datumCrashes.Crashes--
+		if datumCrashes.Crashes <= 0 {
+			return crashes.Delete(datumID)
+		}
+		return crashes.Put(datumID, datumCrashes)
+	})
+	return err
+}
@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// datumEventBuffer is how many events a StatusStream call may fall behind by
+// before it's ended, so that a slow client doesn't hold up datum processing
+const datumEventBuffer = 1024
+
+// datumEvents broadcasts the events in the processing of a worker's datums to
+// the StatusStream calls that are watching them. A nil datumEvents drops all
+// events.
+type datumEvents struct {
+	mu          sync.Mutex
+	subscribers map[chan *DatumEvent]bool
+}
+
+func newDatumEvents() *datumEvents {
+	return &datumEvents{subscribers: make(map[chan *DatumEvent]bool)}
+}
+
+// subscribe returns a channel that receives the events published after it's
+// called, and a function that unsubscribes it. The channel is closed if its
+// subscriber falls more than datumEventBuffer events behind.
+func (e *datumEvents) subscribe() (<-chan *DatumEvent, func()) {
+	ch := make(chan *DatumEvent, datumEventBuffer)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.subscribers[ch] = true
+	return ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if e.subscribers[ch] {
+			delete(e.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (e *datumEvents) publish(event *DatumEvent) {
+	if e == nil {
+		return
+	}
+	event.Time = types.TimestampNow()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(e.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// datum returns a datumEventPublisher for the datum 'data' of the job 'jobID'
+func (e *datumEvents) datum(jobID string, datumID string, data []*Input) *datumEventPublisher {
+	return &datumEventPublisher{events: e, jobID: jobID, datumID: datumID, data: inputFiles(data)}
+}
+
+// datumEventPublisher publishes the events of a single datum
+type datumEventPublisher struct {
+	events  *datumEvents
+	jobID   string
+	datumID string
+	data    []*pps.InputFile
+}
+
+func (p *datumEventPublisher) publish(event *DatumEvent) {
+	event.JobID = p.jobID
+	event.DatumID = p.datumID
+	event.Data = p.data
+	p.events.publish(event)
+}
+
+func (p *datumEventPublisher) started() {
+	p.publish(&DatumEvent{Type: DatumEventType_DATUM_STARTED})
+}
+
+func (p *datumEventPublisher) phase(phase DatumPhase) {
+	p.publish(&DatumEvent{Type: DatumEventType_DATUM_PHASE_CHANGED, Phase: phase})
+}
+
+// completed publishes that the datum is done, having failed with 'err' if
+// it's non-nil
+func (p *datumEventPublisher) completed(err error) {
+	event := &DatumEvent{Type: DatumEventType_DATUM_COMPLETED}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	p.publish(event)
+}
+
+// StatusStream streams the events in the processing of this worker's datums,
+// starting with the next event, until the call is cancelled.
+func (a *APIServer) StatusStream(_ *types.Empty, server Worker_StatusStreamServer) error {
+	events, unsubscribe := a.datumEvents.subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return fmt.Errorf("fell more than %d events behind", datumEventBuffer)
+			}
+			if err := server.Send(event); err != nil {
+				return err
+			}
+		case <-server.Context().Done():
+			return server.Context().Err()
+		}
+	}
+}
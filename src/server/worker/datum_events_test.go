@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestStatusStream(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	apiServer := &APIServer{datumEvents: newDatumEvents()}
+	server := grpc.NewServer()
+	RegisterWorkerServer(server, apiServer)
+	go server.Serve(listener)
+	defer server.Stop()
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := NewWorkerClient(conn).StatusStream(ctx, &types.Empty{})
+	require.NoError(t, err)
+	// Wait for the stream to be subscribed before publishing
+	require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+		apiServer.datumEvents.mu.Lock()
+		defer apiServer.datumEvents.mu.Unlock()
+		if len(apiServer.datumEvents.subscribers) == 0 {
+			return fmt.Errorf("not subscribed yet")
+		}
+		return nil
+	})
+
+	// A datum that's processed on its second try
+	data := []*Input{partitionInput("in", "/foo")}
+	events := apiServer.datumEvents.datum("job", "datum", data)
+	events.started()
+	events.phase(DatumPhase_DOWNLOADING)
+	events.phase(DatumPhase_PROCESSING)
+	events.phase(DatumPhase_DOWNLOADING)
+	events.phase(DatumPhase_PROCESSING)
+	events.phase(DatumPhase_UPLOADING)
+	events.completed(nil)
+	// A datum that fails
+	events = apiServer.datumEvents.datum("job", "datum2", data)
+	events.started()
+	events.phase(DatumPhase_DOWNLOADING)
+	events.completed(fmt.Errorf("error downloadData: not found"))
+
+	type step struct {
+		datumID string
+		typ     DatumEventType
+		phase   DatumPhase
+		err     string
+	}
+	expected := []step{
+		{"datum", DatumEventType_DATUM_STARTED, 0, ""},
+		{"datum", DatumEventType_DATUM_PHASE_CHANGED, DatumPhase_DOWNLOADING, ""},
+		{"datum", DatumEventType_DATUM_PHASE_CHANGED, DatumPhase_PROCESSING, ""},
+		{"datum", DatumEventType_DATUM_PHASE_CHANGED, DatumPhase_DOWNLOADING, ""},
+		{"datum", DatumEventType_DATUM_PHASE_CHANGED, DatumPhase_PROCESSING, ""},
+		{"datum", DatumEventType_DATUM_PHASE_CHANGED, DatumPhase_UPLOADING, ""},
+		{"datum", DatumEventType_DATUM_COMPLETED, 0, ""},
+		{"datum2", DatumEventType_DATUM_STARTED, 0, ""},
+		{"datum2", DatumEventType_DATUM_PHASE_CHANGED, DatumPhase_DOWNLOADING, ""},
+		{"datum2", DatumEventType_DATUM_COMPLETED, 0, "error downloadData: not found"},
+	}
+	for _, e := range expected {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, e, step{event.DatumID, event.Type, event.Phase, event.Error})
+		require.Equal(t, "job", event.JobID)
+		require.Equal(t, "/foo", event.Data[0].Path)
+		require.NotNil(t, event.Time)
+	}
+}
+
+func TestDatumEventsSlowSubscriber(t *testing.T) {
+	events := newDatumEvents()
+	ch, unsubscribe := events.subscribe()
+	defer unsubscribe()
+	publisher := events.datum("job", "datum", nil)
+	for i := 0; i < datumEventBuffer; i++ {
+		publisher.started()
+	}
+	// A subscriber that falls further behind is closed, rather than holding
+	// up the worker
+	publisher.started()
+	for i := 0; i < datumEventBuffer; i++ {
+		_, ok := <-ch
+		require.True(t, ok)
+	}
+	_, ok := <-ch
+	require.False(t, ok)
+
+	// Events without subscribers (or a broadcaster) are dropped
+	publisher.started()
+	(*datumEvents)(nil).datum("job", "datum", nil).completed(nil)
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	globlib "github.com/gobwas/glob"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+// datumPriorityRule is a compiled pps.DatumPriority. Exactly one of glob and
+// prefix is set.
+type datumPriorityRule struct {
+	glob     globlib.Glob
+	prefix   string
+	priority int64
+}
+
+func (r *datumPriorityRule) matches(p string) bool {
+	if r.glob != nil {
+		return r.glob.Match(p)
+	}
+	return r.prefix == "/" || p == r.prefix || strings.HasPrefix(p, r.prefix+"/")
+}
+
+func compileDatumPriority(priorities []*pps.DatumPriority) ([]*datumPriorityRule, error) {
+	var rules []*datumPriorityRule
+	for _, priority := range priorities {
+		if priority.Pattern == "" {
+			return nil, fmt.Errorf("datum priority must specify a pattern")
+		}
+		pattern := path.Clean("/" + priority.Pattern)
+		rule := &datumPriorityRule{priority: priority.Priority}
+		if hashtree.IsGlob(pattern) {
+			g, err := globlib.Compile(pattern, '/')
+			if err != nil {
+				return nil, fmt.Errorf("malformed glob %s: %v", priority.Pattern, err)
+			}
+			rule.glob = g
+		} else {
+			rule.prefix = pattern
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ValidateDatumPriority returns an error if any of a pipeline's datum
+// priorities has a malformed pattern.
+func ValidateDatumPriority(priorities []*pps.DatumPriority) error {
+	_, err := compileDatumPriority(priorities)
+	return err
+}
+
+// prioritizedDatumFactory reorders the datums of another DatumFactory so that
+// datums with a higher priority come first. Datums with the same priority
+// keep their original order, so that the master and every worker agree on
+// the order of a job's datums. The reordered datums are held in memory, as
+// reading the other DatumFactory's datums out of order may be slow (see
+// lazyUnionDatumFactory).
+type prioritizedDatumFactory struct {
+	datums     [][]*Input
+	priorities []int64
+}
+
+// newPrioritizedDatumFactory returns 'df' ordered by 'priorities'. If the
+// pipeline doesn't set any, 'df' is returned as is.
+func newPrioritizedDatumFactory(df DatumFactory, priorities []*pps.DatumPriority) (DatumFactory, error) {
+	if len(priorities) == 0 {
+		return df, nil
+	}
+	rules, err := compileDatumPriority(priorities)
+	if err != nil {
+		return nil, err
+	}
+	result := &prioritizedDatumFactory{
+		datums:     make([][]*Input, df.Len()),
+		priorities: make([]int64, df.Len()),
+	}
+	for i := 0; i < df.Len(); i++ {
+		datum, err := df.Datum(i)
+		if err != nil {
+			return nil, err
+		}
+		result.datums[i] = datum
+		result.priorities[i] = datumPriority(rules, result.datums[i])
+	}
+	sort.Stable(result)
+	return result, nil
+}
+
+// datumPriority returns the highest priority of the rules matching any of the
+// datum's files, or 0 if none do.
+func datumPriority(rules []*datumPriorityRule, data []*Input) int64 {
+	var result int64
+	var matched bool
+	for _, input := range data {
+		if input.FileInfo == nil || input.FileInfo.File == nil {
+			continue
+		}
+		p := path.Clean("/" + input.FileInfo.File.Path)
+		for _, rule := range rules {
+			if rule.matches(p) && (!matched || rule.priority > result) {
+				result = rule.priority
+				matched = true
+			}
+		}
+	}
+	return result
+}
+
+func (d *prioritizedDatumFactory) Len() int {
+	return len(d.datums)
+}
+
+func (d *prioritizedDatumFactory) Datum(i int) ([]*Input, error) {
+	return d.datums[i], nil
+}
+
+// Less and Swap, along with Len, implement sort.Interface, which orders the
+// datums by descending priority.
+func (d *prioritizedDatumFactory) Less(i, j int) bool {
+	return d.priorities[i] > d.priorities[j]
+}
+
+func (d *prioritizedDatumFactory) Swap(i, j int) {
+	d.datums[i], d.datums[j] = d.datums[j], d.datums[i]
+	d.priorities[i], d.priorities[j] = d.priorities[j], d.priorities[i]
+}
+
+// priorityBoundaries returns the indices at which the priority of the
+// datums in 'df' changes, so that chunks can be split there and datums with
+// a higher priority aren't held up by lower priority datums in their chunk.
+func priorityBoundaries(df DatumFactory) []int64 {
+	prioritized, ok := df.(*prioritizedDatumFactory)
+	if !ok {
+		return nil
+	}
+	var result []int64
+	for i := 1; i < len(prioritized.priorities); i++ {
+		if prioritized.priorities[i] != prioritized.priorities[i-1] {
+			result = append(result, int64(i))
+		}
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// testDatumFactory is a DatumFactory with one file per datum
+type testDatumFactory []string
+
+func (d testDatumFactory) Len() int {
+	return len(d)
+}
+
+func (d testDatumFactory) Datum(i int) ([]*Input, error) {
+	return []*Input{{
+		FileInfo: &pfs.FileInfo{File: client.NewFile("repo", "master", d[i])},
+		Name:     "repo",
+	}}, nil
+}
+
+// datum returns the i'th datum of 'df', which mustn't fail
+func datum(t testing.TB, df DatumFactory, i int) []*Input {
+	inputs, err := df.Datum(i)
+	require.NoError(t, err)
+	return inputs
+}
+
+func chunkPaths(t testing.TB, df DatumFactory, low, high int64) []string {
+	var result []string
+	for i := low; i < high; i++ {
+		result = append(result, datum(t, df, int(i))[0].FileInfo.File.Path)
+	}
+	return result
+}
+
+func TestDatumPriority(t *testing.T) {
+	df, err := newPrioritizedDatumFactory(testDatumFactory{
+		"/a", "/priority/b", "/c", "/urgent/d", "/priority/e/f", "/g",
+	}, []*pps.DatumPriority{
+		{Pattern: "/priority", Priority: 1},
+		{Pattern: "/urgent/*", Priority: 2},
+		{Pattern: "/g", Priority: -1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 6, df.Len())
+	// Higher priority datums come first, and datums with the same priority
+	// keep their order
+	require.Equal(t,
+		[]string{"/urgent/d", "/priority/b", "/priority/e/f", "/a", "/c", "/g"},
+		chunkPaths(t, df, 0, 6))
+
+	// Chunks are split where the priority changes, so workers (which acquire
+	// chunks in order) are dispatched the higher priority datums first
+	plan, err := newPlan(df, &pps.ChunkSpec{Number: 3}, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 3, 5, 6}, plan.Chunks)
+	require.Equal(t, []string{"/urgent/d"}, chunkPaths(t, df, 0, plan.Chunks[0]))
+	require.Equal(t, []string{"/priority/b", "/priority/e/f"}, chunkPaths(t, df, plan.Chunks[0], plan.Chunks[1]))
+}
+
+func TestDatumPriorityUnset(t *testing.T) {
+	datums := testDatumFactory{"/b", "/a"}
+	df, err := newPrioritizedDatumFactory(datums, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/b", "/a"}, chunkPaths(t, df, 0, 2))
+	plan, err := newPlan(df, &pps.ChunkSpec{Number: 1}, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, plan.Chunks)
+
+	require.YesError(t, ValidateDatumPriority([]*pps.DatumPriority{{Priority: 1}}))
+	require.YesError(t, ValidateDatumPriority([]*pps.DatumPriority{{Pattern: "/[a", Priority: 1}}))
+	require.NoError(t, ValidateDatumPriority([]*pps.DatumPriority{{Pattern: "/a/**", Priority: 1}}))
+}
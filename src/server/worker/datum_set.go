@@ -8,76 +8,42 @@ import (
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	datumpkg "github.com/pachyderm/pachyderm/src/server/pkg/datum"
 )
 
 // DatumFactory is an interface which allows you to iterate through the datums
 // for a job.
 type DatumFactory interface {
 	Len() int
-	Datum(i int) []*Input
+	// Datum returns the inputs of the i'th datum. It only errors if the
+	// datums are read lazily (see lazyUnionDatumFactory) and can't be read.
+	Datum(i int) ([]*Input, error)
 }
 
-type atomDatumFactory struct {
-	inputs []*Input
-}
-
-func newAtomDatumFactory(pachClient *client.APIClient, input *pps.AtomInput) (DatumFactory, error) {
-	result := &atomDatumFactory{}
-	if input.Commit == "" {
-		// this can happen if a pipeline with multiple inputs has been triggered
-		// before all commits have inputs
-		return result, nil
-	}
-	fs, err := pachClient.GlobFileStream(pachClient.Ctx(), &pfs.GlobFileRequest{
-		Commit:  client.NewCommit(input.Repo, input.Commit),
-		Pattern: input.Glob,
-	})
-	if err != nil {
-		return nil, err
-	}
-	for {
-		fileInfo, err := fs.Recv()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		result.inputs = append(result.inputs, &Input{
-			FileInfo:   fileInfo,
-			Name:       input.Name,
-			Lazy:       input.Lazy,
-			Branch:     input.Branch,
-			EmptyFiles: input.EmptyFiles,
-		})
-	}
-	// We sort the inputs so that the order is deterministic. Note that it's
-	// not possible for 2 inputs to have the same path so this is guaranteed to
-	// produce a deterministic order.
-	sort.Slice(result.inputs, func(i, j int) bool {
-		// We sort by descending size first because it can boost performance to
-		// process the biggest datums first.
-		if result.inputs[i].FileInfo.SizeBytes != result.inputs[j].FileInfo.SizeBytes {
-			return result.inputs[i].FileInfo.SizeBytes > result.inputs[j].FileInfo.SizeBytes
-		}
-		return result.inputs[i].FileInfo.File.Path < result.inputs[j].FileInfo.File.Path
-	})
-	return result, nil
-}
-
-func (d *atomDatumFactory) Len() int {
-	return len(d.inputs)
-}
-
-func (d *atomDatumFactory) Datum(i int) []*Input {
-	return []*Input{d.inputs[i]}
+func newAtomDatumFactory(pachClient *client.APIClient, input *pps.AtomInput, countOnly bool) (DatumFactory, error) {
+	return newPFSDatumFactory(pachClient, &pps.PFSInput{
+		Name:       input.Name,
+		Repo:       input.Repo,
+		Branch:     input.Branch,
+		Commit:     input.Commit,
+		Glob:       input.Glob,
+		Lazy:       input.Lazy,
+		EmptyFiles: input.EmptyFiles,
+	}, countOnly)
 }
 
 type pfsDatumFactory struct {
 	inputs []*Input
+	// If countOnly is set, only the number of datums, count, is known (see
+	// CountDatums)
+	countOnly bool
+	count     int
 }
 
-func newPFSDatumFactory(pachClient *client.APIClient, input *pps.PFSInput) (DatumFactory, error) {
-	result := &pfsDatumFactory{}
+func newPFSDatumFactory(pachClient *client.APIClient, input *pps.PFSInput, countOnly bool) (DatumFactory, error) {
+	// Counting the datums of a split input requires reading its files, so
+	// they're materialized anyway
+	result := &pfsDatumFactory{countOnly: countOnly && input.Split == nil}
 	if input.Commit == "" {
 		// this can happen if a pipeline with multiple inputs has been triggered
 		// before all commits have inputs
@@ -97,6 +63,10 @@ func newPFSDatumFactory(pachClient *client.APIClient, input *pps.PFSInput) (Datu
 		} else if err != nil {
 			return nil, err
 		}
+		if result.countOnly {
+			result.count++
+			continue
+		}
 		result.inputs = append(result.inputs, &Input{
 			FileInfo:   fileInfo,
 			Name:       input.Name,
@@ -105,36 +75,46 @@ func newPFSDatumFactory(pachClient *client.APIClient, input *pps.PFSInput) (Datu
 			EmptyFiles: input.EmptyFiles,
 		})
 	}
-	// We sort the inputs so that the order is deterministic. Note that it's
-	// not possible for 2 inputs to have the same path so this is guaranteed to
-	// produce a deterministic order.
-	sort.Slice(result.inputs, func(i, j int) bool {
-		// We sort by descending size first because it can boost performance to
-		// process the biggest datums first.
-		if result.inputs[i].FileInfo.SizeBytes != result.inputs[j].FileInfo.SizeBytes {
-			return result.inputs[i].FileInfo.SizeBytes > result.inputs[j].FileInfo.SizeBytes
+	if input.Split != nil {
+		var inputs []*Input
+		for _, in := range result.inputs {
+			chunks, err := splitInput(pachClient, in, input.Split)
+			if err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, chunks...)
 		}
-		return result.inputs[i].FileInfo.File.Path < result.inputs[j].FileInfo.File.Path
+		result.inputs = inputs
+	}
+	// We sort the inputs so that the order is deterministic
+	sort.Slice(result.inputs, func(i, j int) bool {
+		return datumpkg.Less(inputFile(result.inputs[i]), inputFile(result.inputs[j]))
 	})
 	return result, nil
 }
 
 func (d *pfsDatumFactory) Len() int {
+	if d.countOnly {
+		return d.count
+	}
 	return len(d.inputs)
 }
 
-func (d *pfsDatumFactory) Datum(i int) []*Input {
-	return []*Input{d.inputs[i]}
+func (d *pfsDatumFactory) Datum(i int) ([]*Input, error) {
+	if d.countOnly {
+		panic("datums aren't read when only counting them")
+	}
+	return []*Input{d.inputs[i]}, nil
 }
 
 type unionDatumFactory struct {
 	inputs []DatumFactory
 }
 
-func newUnionDatumFactory(pachClient *client.APIClient, union []*pps.Input) (DatumFactory, error) {
+func newUnionDatumFactory(union []*pps.Input, newDatumFactory func(*pps.Input) (DatumFactory, error)) (DatumFactory, error) {
 	result := &unionDatumFactory{}
 	for _, input := range union {
-		datumFactory, err := NewDatumFactory(pachClient, input)
+		datumFactory, err := newDatumFactory(input)
 		if err != nil {
 			return nil, err
 		}
@@ -151,7 +131,7 @@ func (d *unionDatumFactory) Len() int {
 	return result
 }
 
-func (d *unionDatumFactory) Datum(i int) []*Input {
+func (d *unionDatumFactory) Datum(i int) ([]*Input, error) {
 	for _, datumFactory := range d.inputs {
 		if i < datumFactory.Len() {
 			return datumFactory.Datum(i)
@@ -165,28 +145,29 @@ type crossDatumFactory struct {
 	inputs []DatumFactory
 }
 
-func (d *crossDatumFactory) Len() int {
-	if len(d.inputs) == 0 {
-		return 0
-	}
-	result := d.inputs[0].Len()
-	for i := 1; i < len(d.inputs); i++ {
-		result *= d.inputs[i].Len()
+func (d *crossDatumFactory) lens() []int {
+	var result []int
+	for _, datumFactory := range d.inputs {
+		result = append(result, datumFactory.Len())
 	}
 	return result
 }
 
-func (d *crossDatumFactory) Datum(i int) []*Input {
-	if i >= d.Len() {
-		panic("index out of bounds")
-	}
+func (d *crossDatumFactory) Len() int {
+	return datumpkg.CrossLen(d.lens())
+}
+
+func (d *crossDatumFactory) Datum(i int) ([]*Input, error) {
 	var result []*Input
-	for _, datumFactory := range d.inputs {
-		result = append(result, datumFactory.Datum(i%datumFactory.Len())...)
-		i /= datumFactory.Len()
+	for j, index := range datumpkg.CrossIndexes(i, d.lens()) {
+		inputs, err := d.inputs[j].Datum(index)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, inputs...)
 	}
 	sortInputs(result)
-	return result
+	return result, nil
 }
 
 type gitDatumFactory struct {
@@ -220,14 +201,14 @@ func (d *gitDatumFactory) Len() int {
 	return len(d.inputs)
 }
 
-func (d *gitDatumFactory) Datum(i int) []*Input {
-	return []*Input{d.inputs[i]}
+func (d *gitDatumFactory) Datum(i int) ([]*Input, error) {
+	return []*Input{d.inputs[i]}, nil
 }
 
-func newCrossDatumFactory(pachClient *client.APIClient, cross []*pps.Input) (DatumFactory, error) {
+func newCrossDatumFactory(cross []*pps.Input, newDatumFactory func(*pps.Input) (DatumFactory, error)) (DatumFactory, error) {
 	result := &crossDatumFactory{}
 	for _, input := range cross {
-		datumFactory, err := NewDatumFactory(pachClient, input)
+		datumFactory, err := newDatumFactory(input)
 		if err != nil {
 			return nil, err
 		}
@@ -236,35 +217,77 @@ func newCrossDatumFactory(pachClient *client.APIClient, cross []*pps.Input) (Dat
 	return result, nil
 }
 
-func newCronDatumFactory(pachClient *client.APIClient, input *pps.CronInput) (DatumFactory, error) {
+func newCronDatumFactory(pachClient *client.APIClient, input *pps.CronInput, countOnly bool) (DatumFactory, error) {
 	return newPFSDatumFactory(pachClient, &pps.PFSInput{
 		Name:   input.Name,
 		Repo:   input.Repo,
 		Branch: "master",
 		Commit: input.Commit,
 		Glob:   "time",
-	})
+	}, countOnly)
 }
 
-// NewDatumFactory creates a datumFactory for an input.
+// NewDatumFactory creates a datumFactory for an input. If the input is a
+// union, its inputs' datums are read lazily (see lazyUnionDatumFactory).
 func NewDatumFactory(pachClient *client.APIClient, input *pps.Input) (DatumFactory, error) {
+	if input.Union != nil {
+		return newLazyUnionDatumFactory(pachClient.Ctx(), input.Union, func(input *pps.Input) (DatumFactory, error) {
+			return NewDatumFactory(pachClient, input)
+		}, func(input *pps.Input) (int64, error) {
+			return CountDatums(pachClient, input)
+		})
+	}
+	return newEagerDatumFactory(pachClient, input, false)
+}
+
+// newEagerDatumFactory creates a datumFactory for an input, holding all of its
+// datums in memory. If 'countOnly' is set, the datums of PFS inputs (which
+// may be numerous) aren't read, only counted, so the datumFactory's Len is
+// correct but its Datum mustn't be called.
+func newEagerDatumFactory(pachClient *client.APIClient, input *pps.Input, countOnly bool) (DatumFactory, error) {
+	newDatumFactory := func(input *pps.Input) (DatumFactory, error) {
+		return newEagerDatumFactory(pachClient, input, countOnly)
+	}
 	switch {
 	case input.Atom != nil:
-		return newAtomDatumFactory(pachClient, input.Atom)
+		return newAtomDatumFactory(pachClient, input.Atom, countOnly)
 	case input.Pfs != nil:
-		return newPFSDatumFactory(pachClient, input.Pfs)
+		return newPFSDatumFactory(pachClient, input.Pfs, countOnly)
 	case input.Union != nil:
-		return newUnionDatumFactory(pachClient, input.Union)
+		return newUnionDatumFactory(input.Union, newDatumFactory)
 	case input.Cross != nil:
-		return newCrossDatumFactory(pachClient, input.Cross)
+		return newCrossDatumFactory(input.Cross, newDatumFactory)
 	case input.Cron != nil:
-		return newCronDatumFactory(pachClient, input.Cron)
+		return newCronDatumFactory(pachClient, input.Cron, countOnly)
 	case input.Git != nil:
 		return newGitDatumFactory(pachClient, input.Git)
+	case input.Object != nil:
+		return newObjectDatumFactory(pachClient, input.Object)
 	}
 	return nil, fmt.Errorf("unrecognized input type")
 }
 
+// CountDatums returns the number of datums in the DatumFactory that
+// NewDatumFactory would create for 'input', without holding every datum in
+// memory.
+func CountDatums(pachClient *client.APIClient, input *pps.Input) (int64, error) {
+	df, err := newEagerDatumFactory(pachClient, input, true)
+	if err != nil {
+		return 0, err
+	}
+	return int64(df.Len()), nil
+}
+
+// inputFile returns the datum.File that determines the order of the datum for
+// 'input'
+func inputFile(input *Input) datumpkg.File {
+	return datumpkg.File{
+		Path:        input.FileInfo.File.Path,
+		SizeBytes:   inputSize(input),
+		OffsetBytes: input.OffsetBytes,
+	}
+}
+
 func sortInputs(inputs []*Input) {
 	sort.Slice(inputs, func(i, j int) bool {
 		return inputs[i].Name < inputs[j].Name
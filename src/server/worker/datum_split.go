@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// maxSplitRecordSize is the size of the largest record that a file can be
+// split around. Records are buffered in memory while a file is split.
+const maxSplitRecordSize = 64 * 1024 * 1024
+
+// splitPoints reads a file from 'r' and returns the offsets at which it's
+// divided into datums according to 'split'. Each offset is the end of a datum
+// (so the last offset is the size of the file), and an empty file has no
+// datums.
+func splitPoints(r io.Reader, split *pps.Split) ([]int64, error) {
+	delimiter := []byte(split.Delimiter)
+	if len(delimiter) == 0 {
+		delimiter = []byte("\n")
+	}
+	records := split.Records
+	if records == 0 && split.Bytes == 0 {
+		records = 1
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxSplitRecordSize)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		// Records include their delimiter, so that the datums' content adds
+		// up to the whole file
+		if i := bytes.Index(data, delimiter); i >= 0 {
+			return i + len(delimiter), data[:i+len(delimiter)], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	})
+	var result []int64
+	var offset, start, n int64
+	for scanner.Scan() {
+		offset += int64(len(scanner.Bytes()))
+		n++
+		// A split point is only ever placed after a whole record, so if the
+		// target size is reached mid-record, the datum ends with that record
+		if (records > 0 && n >= records) || (split.Bytes > 0 && offset-start >= split.Bytes) {
+			result = append(result, offset)
+			start = offset
+			n = 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if err == bufio.ErrTooLong {
+			return nil, fmt.Errorf("cannot split a file with a record larger than %d bytes", maxSplitRecordSize)
+		}
+		return nil, err
+	}
+	if offset > start {
+		result = append(result, offset)
+	}
+	return result, nil
+}
+
+// splitInput divides the file in 'input' into one Input per datum according
+// to 'split'.
+func splitInput(pachClient *client.APIClient, input *Input, split *pps.Split) ([]*Input, error) {
+	file := input.FileInfo.File
+	if input.FileInfo.FileType != pfs.FileType_FILE {
+		return nil, fmt.Errorf("cannot split %s@%s:%s, as it's not a file", file.Commit.Repo.Name, file.Commit.ID, file.Path)
+	}
+	// The file's stream is closed by cancelling its context, whether or not
+	// it's read to the end
+	ctx, cancel := context.WithCancel(pachClient.Ctx())
+	defer cancel()
+	r, err := pachClient.WithCtx(ctx).GetFileReader(file.Commit.Repo.Name, file.Commit.ID, file.Path, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	points, err := splitPoints(r, split)
+	if err != nil {
+		return nil, fmt.Errorf("error splitting %s@%s:%s: %v", file.Commit.Repo.Name, file.Commit.ID, file.Path, err)
+	}
+	var result []*Input
+	var offset int64
+	for _, point := range points {
+		chunk := *input
+		chunk.OffsetBytes = offset
+		chunk.SizeBytes = point - offset
+		result = append(result, &chunk)
+		offset = point
+	}
+	return result, nil
+}
+
+// inputSize returns the number of bytes of data in 'input'.
+func inputSize(input *Input) int64 {
+	if input.SizeBytes != 0 {
+		return input.SizeBytes
+	}
+	return int64(input.FileInfo.SizeBytes)
+}
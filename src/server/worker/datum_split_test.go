@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestSplitPoints(t *testing.T) {
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("line%d\n", i)) // 6 bytes each
+	}
+	file := strings.Join(lines, "")
+
+	// By line count
+	points, err := splitPoints(strings.NewReader(file), &pps.Split{Records: 3})
+	require.NoError(t, err)
+	require.Equal(t, []int64{18, 36, 54, 60}, points)
+
+	// One record per datum by default
+	points, err = splitPoints(strings.NewReader(file), &pps.Split{})
+	require.NoError(t, err)
+	require.Equal(t, 10, len(points))
+
+	// By size: split points that land mid-line are moved to the end of the
+	// line
+	points, err = splitPoints(strings.NewReader(file), &pps.Split{Bytes: 10})
+	require.NoError(t, err)
+	require.Equal(t, []int64{12, 24, 36, 48, 60}, points)
+
+	// By delimiter, including a final record with no delimiter
+	file = "a;;bcd;;efghij;;k"
+	points, err = splitPoints(strings.NewReader(file), &pps.Split{Delimiter: ";;"})
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 8, 16, 17}, points)
+	var records []string
+	var offset int64
+	for _, point := range points {
+		records = append(records, file[offset:point])
+		offset = point
+	}
+	require.Equal(t, []string{"a;;", "bcd;;", "efghij;;", "k"}, records)
+
+	// By size with a delimiter: the split point at byte 4 lands in the
+	// middle of "bcd;;", so the first datum ends after it
+	points, err = splitPoints(strings.NewReader(file), &pps.Split{Delimiter: ";;", Bytes: 4})
+	require.NoError(t, err)
+	require.Equal(t, []int64{8, 16, 17}, points)
+
+	// An empty file has no datums
+	points, err = splitPoints(strings.NewReader(""), &pps.Split{Records: 3})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(points))
+}
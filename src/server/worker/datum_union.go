@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+// lazyUnionCacheSize is the number of a lazy union's inputs whose datums are
+// held in memory at once. It's more than one so that a chunk of datums that
+// spans two of the inputs doesn't alternate between them.
+const lazyUnionCacheSize = 2
+
+// lazyUnionReadTries is the number of times that a lazy union tries to read
+// the datums of one of its inputs before giving up
+const lazyUnionReadTries = 5
+
+// newLazyUnionBackOff returns the backoff between attempts to read the datums
+// of a lazy union's input. It's a variable so that tests don't wait.
+var newLazyUnionBackOff = func() backoff.BackOff {
+	return backoff.NewExponentialBackOff()
+}
+
+// lazyUnionDatumFactory is a DatumFactory for a union input that, unlike
+// unionDatumFactory, doesn't hold the datums of all of the union's inputs in
+// memory. Only the number of datums in each input is computed up front, and
+// each input's datums are read when one of them is first needed (and dropped
+// once other inputs' datums are needed). Its datums are in the same order as
+// unionDatumFactory's, but reading them out of order is slow, so it isn't used
+// under cross inputs.
+type lazyUnionDatumFactory struct {
+	// ctx bounds the retries of reading the inputs' datums
+	ctx             context.Context
+	inputs          []*pps.Input
+	newDatumFactory func(*pps.Input) (DatumFactory, error)
+	// offsets[i] is the index of the first datum of inputs[i], and the last
+	// element is the total number of datums
+	offsets []int
+
+	mu sync.Mutex
+	// cache holds the datum factories of the most recently used inputs, most
+	// recent first
+	cache []*lazyUnionCacheEntry
+}
+
+type lazyUnionCacheEntry struct {
+	input int
+	df    DatumFactory
+}
+
+func newLazyUnionDatumFactory(ctx context.Context, union []*pps.Input, newDatumFactory func(*pps.Input) (DatumFactory, error), countDatums func(*pps.Input) (int64, error)) (DatumFactory, error) {
+	result := &lazyUnionDatumFactory{
+		ctx:             ctx,
+		inputs:          union,
+		newDatumFactory: newDatumFactory,
+		offsets:         []int{0},
+	}
+	for _, input := range union {
+		count, err := countDatums(input)
+		if err != nil {
+			return nil, err
+		}
+		result.offsets = append(result.offsets, result.offsets[len(result.offsets)-1]+int(count))
+	}
+	return result, nil
+}
+
+func (d *lazyUnionDatumFactory) Len() int {
+	return d.offsets[len(d.offsets)-1]
+}
+
+func (d *lazyUnionDatumFactory) Datum(i int) ([]*Input, error) {
+	if i < 0 || i >= d.Len() {
+		panic("index out of bounds")
+	}
+	// Find the input containing datum i, skipping inputs with no datums
+	input := sort.Search(len(d.inputs), func(j int) bool {
+		return d.offsets[j+1] > i
+	})
+	df, err := d.inputDatumFactory(input)
+	if err != nil {
+		return nil, err
+	}
+	return df.Datum(i - d.offsets[input])
+}
+
+// inputDatumFactory returns the DatumFactory for d.inputs[input], creating it
+// if it isn't cached.
+func (d *lazyUnionDatumFactory) inputDatumFactory(input int) (DatumFactory, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, entry := range d.cache {
+		if entry.input == input {
+			copy(d.cache[1:i+1], d.cache[:i])
+			d.cache[0] = entry
+			return entry.df, nil
+		}
+	}
+	// The input's datums were already read (and counted) when the factory was
+	// created, so errors reading them again are likely transient, and retried
+	// a few times (unless d.ctx is cancelled).
+	var df DatumFactory
+	attempts := 1
+	if err := backoff.RetryNotify(func() error {
+		var err error
+		df, err = d.newDatumFactory(d.inputs[input])
+		return err
+	}, newLazyUnionBackOff(), func(err error, retryIn time.Duration) error {
+		if isDone(d.ctx) {
+			return d.ctx.Err()
+		}
+		if attempts >= lazyUnionReadTries {
+			return err
+		}
+		attempts++
+		log.Errorf("error reading datums of union input %d: %v; retrying in %v", input, err, retryIn)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error reading datums of union input %d: %v", input, err)
+	}
+	d.cache = append([]*lazyUnionCacheEntry{{input: input, df: df}}, d.cache...)
+	if len(d.cache) > lazyUnionCacheSize {
+		d.cache = d.cache[:lazyUnionCacheSize]
+	}
+	return df, nil
+}
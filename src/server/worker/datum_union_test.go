@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+// fakeUnion returns a union input with an input for each element of 'sizes',
+// which has that many datums, along with functions to create and count the
+// datums of its inputs without a cluster. 'created' counts the calls to the
+// returned newDatumFactory.
+func fakeUnion(sizes []int, created *int) ([]*pps.Input, func(*pps.Input) (DatumFactory, error), func(*pps.Input) (int64, error)) {
+	var union []*pps.Input
+	inputSizes := make(map[string]int)
+	for i, size := range sizes {
+		name := fmt.Sprintf("input%d", i)
+		union = append(union, client.NewPFSInput(name, "/*"))
+		inputSizes[name] = size
+	}
+	newDatumFactory := func(input *pps.Input) (DatumFactory, error) {
+		*created++
+		df := &pfsDatumFactory{}
+		for i := 0; i < inputSizes[input.Pfs.Repo]; i++ {
+			df.inputs = append(df.inputs, &Input{
+				Name: input.Pfs.Repo,
+				FileInfo: &pfs.FileInfo{
+					File: client.NewFile(input.Pfs.Repo, "master", fmt.Sprintf("/file%d", i)),
+				},
+			})
+		}
+		return df, nil
+	}
+	countDatums := func(input *pps.Input) (int64, error) {
+		return int64(inputSizes[input.Pfs.Repo]), nil
+	}
+	return union, newDatumFactory, countDatums
+}
+
+func TestLazyUnionDatumFactory(t *testing.T) {
+	var created int
+	union, newDatumFactory, countDatums := fakeUnion([]int{3, 0, 2, 4}, &created)
+	eager, err := newUnionDatumFactory(union, newDatumFactory)
+	require.NoError(t, err)
+	created = 0
+	lazy, err := newLazyUnionDatumFactory(context.Background(), union, newDatumFactory, countDatums)
+	require.NoError(t, err)
+	// Only the counts are read up front
+	require.Equal(t, 0, created)
+
+	// The datums are in the same order as the eager factory's
+	require.Equal(t, eager.Len(), lazy.Len())
+	for i := 0; i < lazy.Len(); i++ {
+		require.Equal(t, datum(t, eager, i), datum(t, lazy, i))
+	}
+	// Each input with datums was read once
+	require.Equal(t, 3, created)
+
+	// Recently used inputs are cached, but others are read again
+	datum(t, lazy, 0)
+	require.Equal(t, 4, created)
+	datum(t, lazy, lazy.Len()-1)
+	require.Equal(t, 4, created)
+	datum(t, lazy, 1)
+	require.Equal(t, 4, created)
+}
+
+func TestLazyUnionDatumFactoryErrors(t *testing.T) {
+	prevNewLazyUnionBackOff := newLazyUnionBackOff
+	newLazyUnionBackOff = func() backoff.BackOff { return &backoff.ZeroBackOff{} }
+	defer func() { newLazyUnionBackOff = prevNewLazyUnionBackOff }()
+	var created int
+	union, _, countDatums := fakeUnion([]int{3}, &created)
+	failing := func(*pps.Input) (DatumFactory, error) {
+		created++
+		return nil, errors.New("unavailable")
+	}
+
+	// Reading an input's datums is retried a bounded number of times
+	lazy, err := newLazyUnionDatumFactory(context.Background(), union, failing, countDatums)
+	require.NoError(t, err)
+	_, err = lazy.Datum(0)
+	require.YesError(t, err)
+	require.Equal(t, lazyUnionReadTries, created)
+
+	// ...and not at all once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	created = 0
+	lazy, err = newLazyUnionDatumFactory(ctx, union, failing, countDatums)
+	require.NoError(t, err)
+	_, err = lazy.Datum(0)
+	require.YesError(t, err)
+	require.Equal(t, 1, created)
+}
+
+func benchmarkUnionDatumFactory(b *testing.B, lazy bool) {
+	sizes := make([]int, 100)
+	for i := range sizes {
+		sizes[i] = 1000
+	}
+	var created int
+	union, newDatumFactory, countDatums := fakeUnion(sizes, &created)
+	b.ReportAllocs()
+	var memStats runtime.MemStats
+	var retained uint64
+	for n := 0; n < b.N; n++ {
+		runtime.GC()
+		runtime.ReadMemStats(&memStats)
+		before := memStats.HeapAlloc
+		var df DatumFactory
+		var err error
+		if lazy {
+			df, err = newLazyUnionDatumFactory(context.Background(), union, newDatumFactory, countDatums)
+		} else {
+			df, err = newUnionDatumFactory(union, newDatumFactory)
+		}
+		require.NoError(b, err)
+		for i := 0; i < df.Len(); i++ {
+			datum(b, df, i)
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&memStats)
+		if memStats.HeapAlloc > before {
+			retained = memStats.HeapAlloc - before
+		}
+		runtime.KeepAlive(df)
+	}
+	b.Logf("heap held by the datum factory: %d bytes", retained)
+}
+
+// The lazy factory allocates as much as the eager one in total, but only
+// holds the datums of lazyUnionCacheSize inputs at once, which is reported by
+// the benchmarks' logs
+func BenchmarkEagerUnionDatumFactory(b *testing.B) {
+	benchmarkUnionDatumFactory(b, false)
+}
+
+func BenchmarkLazyUnionDatumFactory(b *testing.B) {
+	benchmarkUnionDatumFactory(b, true)
+}
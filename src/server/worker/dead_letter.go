@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"path"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// DeadLetterPath returns the path, in a pipeline's dead letter branch, of the
+// record of the datum 'datumID' of the job 'jobID'
+func DeadLetterPath(jobID string, datumID string) string {
+	return path.Join("/", jobID, datumID)
+}
+
+// deadLetterTag returns the tag of the object that holds the dead letter
+// record of the datum 'datumID' of the job 'jobID'
+func deadLetterTag(jobID string, datumID string) string {
+	return fmt.Sprintf("dead-letter-%s-%s", jobID, datumID)
+}
+
+// newDeadLetterRecord returns the dead letter record of the datum 'data' of
+// the job 'job', which failed with 'datumErr'
+func newDeadLetterRecord(job *pps.Job, datumID string, data []*Input, datumErr error) *pps.DeadLetterRecord {
+	record := &pps.DeadLetterRecord{
+		Job:     job,
+		DatumID: datumID,
+		Error:   datumErr.Error(),
+		Failed:  types.TimestampNow(),
+	}
+	for _, input := range data {
+		if input.FileInfo == nil {
+			continue
+		}
+		record.Inputs = append(record.Inputs, &pps.DeadLetterInput{
+			Name: input.Name,
+			File: input.FileInfo.File,
+			Hash: hex.EncodeToString(input.FileInfo.Hash),
+		})
+	}
+	return record
+}
+
+// writeDeadLetter writes the record of the datum 'data' of the job 'jobInfo',
+// which failed with 'datumErr', to a tagged object. The master commits the
+// records of all of a job's failed datums to the pipeline's dead letter
+// branch at once, in commitDeadLetters. If the datum is recorded more than
+// once (e.g. because its chunk was retried), the last record is kept.
+func (a *APIServer) writeDeadLetter(pachClient *client.APIClient, jobInfo *pps.JobInfo, data []*Input, datumErr error) error {
+	datumID := a.DatumID(data)
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{Indent: "  "}
+	if err := marshaler.Marshal(&buf, newDeadLetterRecord(jobInfo.Job, datumID, data, datumErr)); err != nil {
+		return err
+	}
+	_, _, err := pachClient.PutObject(&buf, deadLetterTag(jobInfo.Job.ID, datumID))
+	return err
+}
+
+// commitDeadLetters commits the records of the job's dead lettered datums,
+// written by writeDeadLetter, to the pipeline's dead letter branch, in a
+// single commit. It's called by the master once all of the job's chunks are
+// done, and does nothing if no datums were dead lettered.
+func (a *APIServer) commitDeadLetters(pachClient *client.APIClient, jobInfo *pps.JobInfo) (retErr error) {
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(pachClient.Ctx()).Get(jobInfo.Job.ID, jobPtr); err != nil {
+		return err
+	}
+	if len(jobPtr.DeadLetteredDatums) == 0 {
+		return nil
+	}
+	repo, branch := a.pipelineInfo.Pipeline.Name, a.pipelineInfo.DeadLetterBranch
+	// The records were already committed if waitJob is being retried
+	if _, err := pachClient.InspectFile(repo, branch, DeadLetterPath(jobInfo.Job.ID, "")); err == nil {
+		return nil
+	}
+	commit, err := pachClient.StartCommit(repo, branch)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Don't leave a partial commit in the branch
+		if retErr != nil {
+			if err := pachClient.DeleteCommit(repo, commit.ID); err != nil {
+				retErr = fmt.Errorf("%v (and couldn't delete the dead letter commit: %v)", retErr, err)
+			}
+		}
+	}()
+	committed := make(map[string]bool)
+	for _, datumID := range jobPtr.DeadLetteredDatums {
+		if committed[datumID] {
+			continue
+		}
+		committed[datumID] = true
+		if err := func() (retErr error) {
+			r, err := pachClient.GetTagReader(deadLetterTag(jobInfo.Job.ID, datumID))
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := r.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+			_, err = pachClient.PutFileOverwrite(repo, commit.ID, DeadLetterPath(jobInfo.Job.ID, datumID), r, 0)
+			return err
+		}(); err != nil {
+			return err
+		}
+	}
+	return pachClient.FinishCommit(repo, commit.ID)
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestNewDeadLetterRecord(t *testing.T) {
+	file := client.NewFile("images", "0123456789abcdef0123456789abcdef", "/cat.png")
+	data := []*Input{
+		{Name: "images", FileInfo: &pfs.FileInfo{File: file, Hash: []byte{0xca, 0xfe}}},
+		{Name: "git"},
+	}
+	record := newDeadLetterRecord(client.NewJob("job"), "datum", data, errors.New("exit status 1"))
+	require.Equal(t, "job", record.Job.ID)
+	require.Equal(t, "datum", record.DatumID)
+	require.Equal(t, "exit status 1", record.Error)
+	require.NotNil(t, record.Failed)
+	// The record identifies the input file, down to its content
+	require.Equal(t, 1, len(record.Inputs))
+	require.Equal(t, "images", record.Inputs[0].Name)
+	require.Equal(t, file, record.Inputs[0].File)
+	require.Equal(t, "cafe", record.Inputs[0].Hash)
+	require.Equal(t, "/job/datum", DeadLetterPath("job", "datum"))
+}
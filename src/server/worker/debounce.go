@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// debounceWait returns how much longer a debounced pipeline must wait before
+// running a job, given that its newest output commit was started at
+// 'headStarted' and the current burst of commits began at 'burstStart'. The
+// job runs once the input has been quiet for 'quietPeriod' or, if 'maxWait'
+// is non-zero, once the burst has lasted 'maxWait' (so that input that never
+// goes quiet still gets processed).
+func debounceWait(now, headStarted, burstStart time.Time, quietPeriod, maxWait time.Duration) time.Duration {
+	wait := headStarted.Add(quietPeriod).Sub(now)
+	if maxWait > 0 {
+		if capWait := burstStart.Add(maxWait).Sub(now); capWait < wait {
+			wait = capWait
+		}
+	}
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// debounce waits until a job should be run for the output commit in
+// 'commitInfo', per the pipeline's debounce. It returns false if the commit
+// has been superseded by a newer commit in the output branch, in which case
+// the commit is finished without a job, with the same content as its parent
+// (the newer commit's job processes its input instead). 'burstStart' is when the current burst of commits began,
+// and is updated when a new burst begins.
+func (a *APIServer) debounce(pachClient *client.APIClient, commitInfo *pfs.CommitInfo, burstStart *time.Time) (bool, error) {
+	debounce := a.pipelineInfo.Debounce
+	quietPeriod, err := types.DurationFromProto(debounce.QuietPeriod)
+	if err != nil {
+		return false, err
+	}
+	var maxWait time.Duration
+	if debounce.MaxWait != nil {
+		if maxWait, err = types.DurationFromProto(debounce.MaxWait); err != nil {
+			return false, err
+		}
+	}
+	started, err := types.TimestampFromProto(commitInfo.Started)
+	if err != nil {
+		return false, err
+	}
+	if burstStart.IsZero() {
+		*burstStart = started
+	}
+	for {
+		head, err := pachClient.InspectCommit(a.pipelineInfo.Pipeline.Name, a.pipelineInfo.OutputBranch)
+		if err != nil {
+			return false, err
+		}
+		if head.Commit.ID != commitInfo.Commit.ID {
+			request := &pfs.FinishCommitRequest{
+				Commit:      commitInfo.Commit,
+				Empty:       true,
+				Description: fmt.Sprintf("skipped by debounce: superseded by %s", head.Commit.ID),
+			}
+			if _, err := copyParentOutput(pachClient, request); err != nil {
+				return false, err
+			}
+			if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), request); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+		headStarted, err := types.TimestampFromProto(head.Started)
+		if err != nil {
+			return false, err
+		}
+		wait := debounceWait(time.Now(), headStarted, *burstStart, quietPeriod, maxWait)
+		if wait == 0 {
+			*burstStart = time.Time{}
+			return true, nil
+		}
+		select {
+		case <-time.After(wait):
+		case <-pachClient.Ctx().Done():
+			return false, pachClient.Ctx().Err()
+		}
+	}
+}
+
+// isDebounced returns true if jobs of the pipeline in 'pipelineInfo' are
+// debounced
+func isDebounced(pipelineInfo *pps.PipelineInfo) bool {
+	return pipelineInfo.Debounce != nil && pipelineInfo.Debounce.QuietPeriod != nil
+}
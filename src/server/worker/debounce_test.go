@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestDebounceWait(t *testing.T) {
+	start := time.Unix(1000, 0)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	// Wait until the newest commit has been quiet for the quiet period
+	require.Equal(t, 10*time.Second, debounceWait(at(0), at(0), at(0), 10*time.Second, 0))
+	require.Equal(t, 4*time.Second, debounceWait(at(6), at(0), at(0), 10*time.Second, 0))
+	require.Equal(t, time.Duration(0), debounceWait(at(10), at(0), at(0), 10*time.Second, 0))
+	require.Equal(t, time.Duration(0), debounceWait(at(15), at(0), at(0), 10*time.Second, 0))
+
+	// A new commit resets the timer
+	require.Equal(t, 10*time.Second, debounceWait(at(8), at(8), at(0), 10*time.Second, 0))
+
+	// Without a max wait, commits that never go quiet delay the job forever
+	require.Equal(t, 10*time.Second, debounceWait(at(100), at(100), at(0), 10*time.Second, 0))
+
+	// With one, the job runs once the burst has lasted the max wait
+	require.Equal(t, 2*time.Second, debounceWait(at(28), at(28), at(0), 10*time.Second, 30*time.Second))
+	require.Equal(t, time.Duration(0), debounceWait(at(30), at(28), at(0), 10*time.Second, 30*time.Second))
+	require.Equal(t, 4*time.Second, debounceWait(at(6), at(0), at(0), 10*time.Second, 30*time.Second))
+}
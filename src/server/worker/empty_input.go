@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+)
+
+// The values of a pipeline's empty_input, which is what its jobs do when
+// their inputs have no datums. "" is the same as emptyInputEmpty.
+const (
+	// emptyInputEmpty finishes the job's output commit with no files
+	emptyInputEmpty = "empty"
+	// emptyInputSkip finishes the job's output commit as a copy of the
+	// pipeline's previous output, so that downstream pipelines don't run jobs
+	// for it (see hasUnchangedInputs)
+	emptyInputSkip = "skip"
+	// emptyInputError fails the job
+	emptyInputError = "error"
+)
+
+// noDatumsReason is the reason of jobs that fail because their inputs have
+// no datums
+const noDatumsReason = "inputs have no datums (empty_input is \"error\")"
+
+// ValidateEmptyInput returns an error if 'emptyInput' isn't a valid
+// empty_input for a pipeline
+func ValidateEmptyInput(emptyInput string) error {
+	switch emptyInput {
+	case "", emptyInputEmpty, emptyInputSkip, emptyInputError:
+		return nil
+	}
+	return fmt.Errorf("must be \"empty\", \"skip\" or \"error\", not %q", emptyInput)
+}
+
+// finishEmptyJob finishes the job in 'jobInfo', whose inputs have no datums,
+// as the pipeline's empty_input directs. 'numHashtrees' is the number of
+// trees that the job's output commit has (see Plan.Merges).
+func (a *APIServer) finishEmptyJob(pachClient *client.APIClient, jobInfo *pps.JobInfo, numHashtrees int64, logger *taggedLogger) error {
+	ctx := pachClient.Ctx()
+	if a.pipelineInfo.EmptyInput == emptyInputError {
+		logger.Logf("job %s has no datums, failing it", jobInfo.Job.ID)
+		if err := a.updateJobState(ctx, jobInfo, nil, pps.JobState_JOB_FAILURE, noDatumsReason); err != nil {
+			return err
+		}
+		_, err := pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
+			Commit: jobInfo.OutputCommit,
+			Empty:  true,
+		})
+		return err
+	}
+	var finishRequest *pfs.FinishCommitRequest
+	// Reruns' output commits aren't in the output branch, so there's no
+	// downstream job to skip
+	if a.pipelineInfo.EmptyInput == emptyInputSkip && jobInfo.RerunOf == nil {
+		parentInfo, err := previousOutput(pachClient, jobInfo.OutputCommit)
+		if err != nil {
+			return err
+		}
+		if parentInfo != nil {
+			logger.Logf("job %s has no datums, keeping the output of %s", jobInfo.Job.ID, parentInfo.Commit.ID)
+			finishRequest = &pfs.FinishCommitRequest{
+				Commit:      jobInfo.OutputCommit,
+				Trees:       parentInfo.Trees,
+				Datums:      parentInfo.Datums,
+				SizeBytes:   parentInfo.SizeBytes,
+				Description: fmt.Sprintf("skipped: inputs have no datums, output unchanged since %s", parentInfo.Commit.ID),
+				Labels:      unchangedOutputLabels,
+			}
+		}
+	}
+	// The pipeline has no previous output to keep, so its output is empty
+	if finishRequest == nil {
+		logger.Logf("job %s has no datums, finishing its output commit with no files", jobInfo.Job.ID)
+		trees, err := a.emptyOutputTrees(pachClient, numHashtrees)
+		if err != nil {
+			return err
+		}
+		// Like any job's, the output commit lists the job's datums (none)
+		datums, _, err := pachClient.PutObject(&bytes.Buffer{})
+		if err != nil {
+			return err
+		}
+		finishRequest = &pfs.FinishCommitRequest{
+			Commit: jobInfo.OutputCommit,
+			Trees:  trees,
+			Datums: datums,
+		}
+	}
+	// The commit may have been finished already, by a previous master that
+	// died before updating the job's state
+	if _, err := pachClient.PfsAPIClient.FinishCommit(ctx, finishRequest); err != nil && !pfsserver.IsCommitFinishedErr(err) {
+		return err
+	}
+	return a.updateJobState(ctx, jobInfo, nil, pps.JobState_JOB_SUCCESS, "")
+}
+
+// previousOutput returns the parent of the output commit 'commit', once it's
+// finished, if the parent holds its job's output. Otherwise (e.g. if the
+// parent's job failed, or it has no parent), it returns nil.
+func previousOutput(pachClient *client.APIClient, commit *pfs.Commit) (*pfs.CommitInfo, error) {
+	commitInfo, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+	if commitInfo.ParentCommit == nil {
+		return nil, nil
+	}
+	parentInfo, err := pachClient.PfsAPIClient.InspectCommit(pachClient.Ctx(), &pfs.InspectCommitRequest{
+		Commit:     commitInfo.ParentCommit,
+		BlockState: pfs.CommitState_FINISHED,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if parentInfo.Trees == nil {
+		return nil, nil
+	}
+	return parentInfo, nil
+}
+
+// emptyOutputTrees returns the 'numHashtrees' trees of an output commit with
+// no files. Unlike an output commit finished with 'Empty' set (that of a
+// failed job), such a commit is a valid input for downstream pipelines.
+func (a *APIServer) emptyOutputTrees(pachClient *client.APIClient, numHashtrees int64) ([]*pfs.Object, error) {
+	objClient, err := obj.NewClientFromEnv(pachClient.Ctx(), a.hashtreeStorage)
+	if err != nil {
+		return nil, err
+	}
+	if objClient, err = obj.NewLayoutClientFromEnv(objClient); err != nil {
+		return nil, err
+	}
+	if objClient, err = obj.NewCompressedClientFromEnv(objClient); err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := hashtree.NewOrdered("/").Serialize(buf); err != nil {
+		return nil, err
+	}
+	// Merging the tree (with nothing) writes its index, which is needed to
+	// read it
+	tree, _, err := a.merge(pachClient, objClient, nil, []*hashtree.Reader{hashtree.NewReader(buf, nil)})
+	if err != nil {
+		return nil, err
+	}
+	trees := make([]*pfs.Object, numHashtrees)
+	for i := range trees {
+		trees[i] = tree
+	}
+	return trees, nil
+}
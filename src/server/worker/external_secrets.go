@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/secrets"
+)
+
+// secretRefreshInterval is the longest that a worker uses the values of
+// external secrets before fetching them again, so that rotated secrets are
+// picked up. Secrets whose lease is shorter are fetched when it expires.
+const secretRefreshInterval = 5 * time.Minute
+
+// secretRetryInterval is how long a worker waits to fetch external secrets
+// again after failing to refresh them.
+const secretRetryInterval = 30 * time.Second
+
+// externalSecrets resolves the pipeline's secrets that are stored in an
+// external secrets manager (i.e. that have a pps.Secret.Provider) into
+// environment variables and files for the user code.
+type externalSecrets struct {
+	secrets   []*pps.Secret
+	providers map[string]secrets.Provider
+
+	mu      sync.Mutex
+	env     []string
+	expires time.Time
+}
+
+func newExternalSecrets(transform *pps.Transform) (*externalSecrets, error) {
+	result := &externalSecrets{
+		providers: make(map[string]secrets.Provider),
+	}
+	for _, secret := range transform.Secrets {
+		if secret.Provider == "" {
+			continue // mounted by kubernetes
+		}
+		result.secrets = append(result.secrets, secret)
+		if _, ok := result.providers[secret.Provider]; ok {
+			continue
+		}
+		provider, err := secrets.NewProvider(secret.Provider)
+		if err != nil {
+			return nil, err
+		}
+		result.providers[secret.Provider] = provider
+	}
+	return result, nil
+}
+
+// refresh fetches the secrets again if they may have changed since they were
+// last fetched (or if 'force' is set), writes those with a mount path to
+// disk and updates the environment variables returned by environ. If it
+// fails, the previous values are left in place (and refresh won't try again
+// for secretRetryInterval, unless forced).
+func (e *externalSecrets) refresh(force bool) error {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.secrets) == 0 || (!force && time.Now().Before(e.expires)) {
+		return nil
+	}
+	// Don't retry immediately if the refresh fails
+	e.expires = time.Now().Add(secretRetryInterval)
+	var env []string
+	files := make(map[string]map[string]string)
+	refreshInterval := secretRefreshInterval
+	for _, secret := range e.secrets {
+		data, ttl, err := e.providers[secret.Provider].GetSecret(secret.Path)
+		if err != nil {
+			return fmt.Errorf("could not fetch secret %s from %s: %v", secret.Path, secret.Provider, err)
+		}
+		if ttl > 0 && ttl < refreshInterval {
+			refreshInterval = ttl
+		}
+		if secret.EnvVar != "" {
+			value, ok := data[secret.Key]
+			if !ok {
+				return fmt.Errorf("secret %s from %s has no key %q", secret.Path, secret.Provider, secret.Key)
+			}
+			env = append(env, fmt.Sprintf("%s=%s", secret.EnvVar, value))
+		}
+		if secret.MountPath != "" {
+			files[secret.MountPath] = data
+		}
+	}
+	// Only write files once every secret has been fetched, so that a failed
+	// refresh doesn't leave a mix of old and new values
+	for mountPath, data := range files {
+		if err := writeSecretFiles(mountPath, data); err != nil {
+			return err
+		}
+	}
+	e.env = env
+	e.expires = time.Now().Add(refreshInterval)
+	return nil
+}
+
+// keepFresh refreshes the secrets each time their values may have expired,
+// until 'ctx' is done, so that datums use the values fetched most recently
+// rather than each fetching them. Failed refreshes are logged with 'logf' and
+// retried after secretRetryInterval; the previous values are kept meanwhile.
+func (e *externalSecrets) keepFresh(ctx context.Context, logf func(string, ...interface{})) {
+	if e == nil || len(e.secrets) == 0 {
+		return
+	}
+	for {
+		select {
+		case <-time.After(time.Until(e.nextRefresh())):
+		case <-ctx.Done():
+			return
+		}
+		if err := e.refresh(false); err != nil {
+			logf("error refreshing external secrets: %v", err)
+		}
+	}
+}
+
+// nextRefresh returns when the secrets are next due to be refreshed
+func (e *externalSecrets) nextRefresh() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.expires
+}
+
+// environ returns the environment variables that the external secrets were
+// most recently resolved into, as "key=value" strings.
+func (e *externalSecrets) environ() []string {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.env
+}
+
+// writeSecretFiles writes each key in 'data' to a file of the same name in
+// 'dir', like kubernetes does for secret volumes. Each file is replaced
+// atomically, so user code never sees a partially written secret.
+func writeSecretFiles(dir string, data map[string]string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for key, value := range data {
+		if key == "" || strings.Contains(key, "/") {
+			return fmt.Errorf("invalid secret key %q: keys are used as file names", key)
+		}
+		f, err := ioutil.TempFile(dir, ".tmp-")
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(value); err != nil {
+			f.Close()
+			os.Remove(f.Name())
+			return err
+		}
+		if err := f.Close(); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+		if err := os.Chmod(f.Name(), 0644); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+		if err := os.Rename(f.Name(), filepath.Join(dir, key)); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+	}
+	return nil
+}
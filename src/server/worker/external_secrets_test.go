@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/secrets"
+)
+
+// mockSecretProvider serves secrets from memory, and fails every read if err
+// is set
+type mockSecretProvider struct {
+	secrets map[string]map[string]string
+	ttl     time.Duration
+	err     error
+	reads   int
+}
+
+func (m *mockSecretProvider) GetSecret(path string) (map[string]string, time.Duration, error) {
+	m.reads++
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	data, ok := m.secrets[path]
+	if !ok {
+		return nil, 0, fmt.Errorf("no secret at %s", path)
+	}
+	return data, m.ttl, nil
+}
+
+func TestExternalSecrets(t *testing.T) {
+	provider := &mockSecretProvider{
+		secrets: map[string]map[string]string{
+			"secret/db": {"user": "admin", "password": "hunter2"},
+		},
+	}
+	secrets.Register("mock", func() (secrets.Provider, error) { return provider, nil })
+	dir, err := ioutil.TempDir("", "external-secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := newExternalSecrets(&pps.Transform{
+		Secrets: []*pps.Secret{
+			{Name: "k8s-secret", MountPath: "/k8s"}, // not external, so ignored
+			{Provider: "mock", Path: "secret/db", Key: "password", EnvVar: "DB_PASSWORD"},
+			{Provider: "mock", Path: "secret/db", MountPath: dir},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.refresh(true))
+	require.Equal(t, []string{"DB_PASSWORD=hunter2"}, s.environ())
+	password, err := ioutil.ReadFile(filepath.Join(dir, "password"))
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", string(password))
+	user, err := ioutil.ReadFile(filepath.Join(dir, "user"))
+	require.NoError(t, err)
+	require.Equal(t, "admin", string(user))
+
+	// Secrets aren't fetched again until the refresh interval has passed
+	reads := provider.reads
+	provider.secrets["secret/db"] = map[string]string{"user": "admin", "password": "rotated"}
+	require.NoError(t, s.refresh(false))
+	require.Equal(t, reads, provider.reads)
+	require.Equal(t, []string{"DB_PASSWORD=hunter2"}, s.environ())
+
+	// Once it has, rotated secrets are picked up
+	s.expires = time.Now()
+	require.NoError(t, s.refresh(false))
+	require.Equal(t, []string{"DB_PASSWORD=rotated"}, s.environ())
+	password, err = ioutil.ReadFile(filepath.Join(dir, "password"))
+	require.NoError(t, err)
+	require.Equal(t, "rotated", string(password))
+
+	// A failed refresh leaves the previous values in place
+	provider.err = fmt.Errorf("connection refused")
+	err = s.refresh(true)
+	require.YesError(t, err)
+	require.Matches(t, "secret/db", err.Error())
+	require.Equal(t, []string{"DB_PASSWORD=rotated"}, s.environ())
+
+	// Missing keys are errors
+	provider.err = nil
+	delete(provider.secrets["secret/db"], "password")
+	require.YesError(t, s.refresh(true))
+}
+
+func TestExternalSecretsLease(t *testing.T) {
+	provider := &mockSecretProvider{
+		secrets: map[string]map[string]string{"secret/token": {"token": "abc"}},
+		ttl:     time.Second,
+	}
+	secrets.Register("mock-lease", func() (secrets.Provider, error) { return provider, nil })
+	s, err := newExternalSecrets(&pps.Transform{
+		Secrets: []*pps.Secret{
+			{Provider: "mock-lease", Path: "secret/token", Key: "token", EnvVar: "TOKEN"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.refresh(true))
+	// Secrets with a short lease are refreshed when it expires
+	require.True(t, s.expires.Before(time.Now().Add(2*time.Second)))
+
+	_, err = newExternalSecrets(&pps.Transform{
+		Secrets: []*pps.Secret{{Provider: "unknown", Path: "secret/token"}},
+	})
+	require.YesError(t, err)
+}
+
+func TestExternalSecretsKeepFresh(t *testing.T) {
+	provider := &mockSecretProvider{
+		secrets: map[string]map[string]string{"secret/key": {"key": "v1"}},
+		ttl:     50 * time.Millisecond,
+	}
+	secrets.Register("mock-keep-fresh", func() (secrets.Provider, error) { return provider, nil })
+	s, err := newExternalSecrets(&pps.Transform{
+		Secrets: []*pps.Secret{
+			{Provider: "mock-keep-fresh", Path: "secret/key", Key: "key", EnvVar: "KEY"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.refresh(true))
+	require.Equal(t, []string{"KEY=v1"}, s.environ())
+
+	// The rotated secret is picked up in the background once its lease
+	// expires, without anything calling refresh
+	provider.secrets["secret/key"] = map[string]string{"key": "v2"}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.keepFresh(ctx, t.Logf)
+	}()
+	require.NoErrorWithinT(t, 10*time.Second, func() error {
+		for {
+			if env := s.environ(); len(env) == 1 && env[0] == "KEY=v2" {
+				return nil
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	cancel()
+	<-done
+}
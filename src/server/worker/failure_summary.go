@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// maxSummaryDatumIDs is the most failed datum IDs that are listed in an
+// output commit's description, so that jobs with many failed datums don't
+// produce huge commit records
+const maxSummaryDatumIDs = 100
+
+// failureSummary returns the labels and description that summarize the
+// failed datums of the job in 'jobPtr', whose failed datum (if the job
+// failed) is 'failedDatumID'. If none of the job's datums failed, it returns
+// nil labels.
+func failureSummary(jobPtr *pps.EtcdJobInfo, failedDatumID string) (map[string]string, string) {
+	var failedIDs []string
+	if failedDatumID != "" {
+		failedIDs = append(failedIDs, failedDatumID)
+	}
+	failedIDs = append(failedIDs, jobPtr.DeadLetteredDatums...)
+	failedIDs = append(failedIDs, jobPtr.QuarantinedDatums...)
+	// A failed datum is counted in DataFailed, and is also recorded as
+	// failedDatumID by its chunk
+	failed := jobPtr.DataFailed + int64(len(jobPtr.DeadLetteredDatums)+len(jobPtr.QuarantinedDatums))
+	if failed == 0 {
+		return nil, ""
+	}
+	labels := map[string]string{
+		client.PPSDatumsSucceededLabel: strconv.FormatInt(jobPtr.DataProcessed, 10),
+		client.PPSDatumsSkippedLabel:   strconv.FormatInt(jobPtr.DataSkipped, 10),
+		client.PPSDatumsFailedLabel:    strconv.FormatInt(failed, 10),
+	}
+	description := fmt.Sprintf("%d datums succeeded, %d skipped, %d failed", jobPtr.DataProcessed, jobPtr.DataSkipped, failed)
+	if len(failedIDs) > maxSummaryDatumIDs {
+		description += fmt.Sprintf(": %s (and %d more)", strings.Join(failedIDs[:maxSummaryDatumIDs], ", "), len(failedIDs)-maxSummaryDatumIDs)
+	} else if len(failedIDs) > 0 {
+		description += ": " + strings.Join(failedIDs, ", ")
+	}
+	return labels, description
+}
+
+// addFailureSummary makes 'request', which finishes the output commit of the
+// job in 'jobInfo', annotate the commit with a summary of the job's failed
+// datums (see failureSummary), if any of them failed. The summary is added to
+// any labels and description that 'request' already sets.
+func (a *APIServer) addFailureSummary(ctx context.Context, jobInfo *pps.JobInfo, failedDatumID string, request *pfs.FinishCommitRequest) error {
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := a.jobs.ReadOnly(ctx).Get(jobInfo.Job.ID, jobPtr); err != nil {
+		return err
+	}
+	summaryLabels, description := failureSummary(jobPtr, failedDatumID)
+	if summaryLabels == nil {
+		return nil
+	}
+	// request.Labels may be shared (e.g. unchangedOutputLabels), so it's
+	// copied rather than modified
+	labels := make(map[string]string)
+	for key, val := range request.Labels {
+		labels[key] = val
+	}
+	for key, val := range summaryLabels {
+		labels[key] = val
+	}
+	request.Labels = labels
+	if request.Description != "" {
+		description = request.Description + "; " + description
+	}
+	request.Description = description
+	return nil
+}
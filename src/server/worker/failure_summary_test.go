@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestFailureSummary(t *testing.T) {
+	// Jobs without failed datums have no summary
+	labels, _ := failureSummary(&pps.EtcdJobInfo{DataProcessed: 10, DataSkipped: 2}, "")
+	require.Equal(t, 0, len(labels))
+
+	labels, description := failureSummary(&pps.EtcdJobInfo{
+		DataProcessed:      7,
+		DataSkipped:        2,
+		DeadLetteredDatums: []string{"a", "b"},
+		QuarantinedDatums:  []string{"c"},
+	}, "")
+	require.Equal(t, map[string]string{
+		client.PPSDatumsSucceededLabel: "7",
+		client.PPSDatumsSkippedLabel:   "2",
+		client.PPSDatumsFailedLabel:    "3",
+	}, labels)
+	require.Equal(t, "7 datums succeeded, 2 skipped, 3 failed: a, b, c", description)
+
+	// The datum that failed the job is listed first
+	labels, description = failureSummary(&pps.EtcdJobInfo{DataProcessed: 4, DataFailed: 1}, "d")
+	require.Equal(t, "1", labels[client.PPSDatumsFailedLabel])
+	require.Equal(t, "4 datums succeeded, 0 skipped, 1 failed: d", description)
+
+	// Long lists of failed datums are truncated
+	var ids []string
+	for i := 0; i < maxSummaryDatumIDs+5; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	_, description = failureSummary(&pps.EtcdJobInfo{DeadLetteredDatums: ids}, "")
+	require.True(t, strings.HasSuffix(description, fmt.Sprintf(", %d (and 5 more)", maxSummaryDatumIDs-1)), description)
+}
@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pachyderm/pachyderm/src/client"
+)
+
+// lockTTL returns the TTL, in seconds, of the locks that a worker holds on the
+// chunks and merges that it's processing. Locks are renewed every ttl/2
+// seconds, and the cluster's grace period (see client.PPSWorkerGracePeriodEnv)
+// keeps them alive beyond ttl, so that a worker that's briefly paused can
+// still renew its locks before its datums are reassigned to other workers.
+func lockTTL() int64 {
+	grace, err := strconv.ParseInt(os.Getenv(client.PPSWorkerGracePeriodEnv), 10, 64)
+	if err != nil || grace < 0 {
+		return ttl
+	}
+	return ttl + grace
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+)
+
+func TestLockTTL(t *testing.T) {
+	defer os.Unsetenv(client.PPSWorkerGracePeriodEnv)
+
+	// Without a grace period, a lock expires once two renewals are missed
+	require.Equal(t, ttl, lockTTL())
+
+	// A grace period keeps a lock alive past missed renewals, so that a worker
+	// that's paused for less than ttl/2 plus the grace period keeps its datums
+	require.NoError(t, os.Setenv(client.PPSWorkerGracePeriodEnv, "60"))
+	require.Equal(t, ttl+60, lockTTL())
+
+	// Invalid grace periods are ignored
+	for _, grace := range []string{"-1", "1m", ""} {
+		require.NoError(t, os.Setenv(client.PPSWorkerGracePeriodEnv, grace))
+		require.Equal(t, ttl, lockTTL())
+	}
+}
+
+func TestLockExpiry(t *testing.T) {
+	defer func(prev int64) { ttl = prev }(ttl)
+	ttl = 2
+	defer os.Unsetenv(client.PPSWorkerGracePeriodEnv)
+	require.NoError(t, os.Setenv(client.PPSWorkerGracePeriodEnv, "2"))
+	etcdClient := getEtcdClient(t)
+	etcdPrefix := tu.UniqueString("TestLockExpiry")
+	jobID := tu.UniqueString("job")
+	paused := newTestAPIServer(nil, etcdClient, etcdPrefix, t)
+	other := newTestAPIServer(nil, etcdClient, etcdPrefix, t)
+	_, err := col.NewSTM(context.Background(), etcdClient, func(stm col.STM) error {
+		return paused.jobs.ReadWrite(stm).Put(jobID, &pps.EtcdJobInfo{
+			Job:          client.NewJob(jobID),
+			Pipeline:     client.NewPipeline("test"),
+			OutputCommit: client.NewCommit("test", "output"),
+		})
+	})
+	require.NoError(t, err)
+	plan := &Plan{Chunks: []int64{2, 4}}
+
+	// A worker that's paused holds the lock on its chunk, but doesn't renew
+	// it, so another worker takes the chunk over once the lock expires, after
+	// ttl plus the grace period
+	start := time.Now()
+	_, err = col.NewSTM(context.Background(), etcdClient, func(stm col.STM) error {
+		return paused.chunks(jobID).ReadWrite(stm).PutTTL("2", &ChunkState{State: State_RUNNING}, lockTTL())
+	})
+	require.NoError(t, err)
+	taken := make(map[int64]time.Duration)
+	require.NoError(t, other.acquireDatums(context.Background(), jobID, plan, other.getMasterLogger(), func(low, high int64) (*processResult, error) {
+		taken[high] = time.Since(start)
+		return &processResult{datumsProcessed: high - low}, nil
+	}))
+	require.Equal(t, 2, len(taken))
+	require.True(t, taken[4] < 2*time.Second, "the unlocked chunk was taken after %v", taken[4])
+	require.True(t, taken[2] >= 3*time.Second, "the locked chunk was taken after %v", taken[2])
+
+	// A worker that's running renews its lock, so its chunk isn't taken over
+	// even though processing it takes longer than the lock's TTL
+	jobID = tu.UniqueString("job")
+	_, err = col.NewSTM(context.Background(), etcdClient, func(stm col.STM) error {
+		return paused.jobs.ReadWrite(stm).Put(jobID, &pps.EtcdJobInfo{
+			Job:          client.NewJob(jobID),
+			Pipeline:     client.NewPipeline("test"),
+			OutputCommit: client.NewCommit("test", "output"),
+		})
+	})
+	require.NoError(t, err)
+	plan = &Plan{Chunks: []int64{2}}
+	running := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- paused.acquireDatums(context.Background(), jobID, plan, paused.getMasterLogger(), func(low, high int64) (*processResult, error) {
+			close(running)
+			time.Sleep(time.Duration(2*lockTTL()) * time.Second)
+			return &processResult{datumsProcessed: high - low}, nil
+		})
+	}()
+	<-running
+	var otherChunks []int64
+	require.NoError(t, other.acquireDatums(context.Background(), jobID, plan, other.getMasterLogger(), func(low, high int64) (*processResult, error) {
+		otherChunks = append(otherChunks, high)
+		return &processResult{datumsProcessed: high - low}, nil
+	}))
+	require.NoError(t, <-done)
+	require.Equal(t, 0, len(otherChunks))
+}
@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// healthCheckRetry is how often a failing health check without an interval
+// is retried
+const healthCheckRetry = 5 * time.Second
+
+// healthChecker runs a pipeline's health check (see pps.HealthCheck), and
+// holds datum dispatch until it passes
+type healthChecker struct {
+	cmd        []string
+	env        []string
+	workingDir string
+	// interval is how long a passing check is trusted for (0 means that the
+	// check is run before every datum), and how often a failing check is
+	// retried
+	interval time.Duration
+
+	// waitMu makes concurrent waits run the check one at a time
+	waitMu sync.Mutex
+	// lastPassed is when the check last passed
+	lastPassed time.Time
+
+	mu sync.Mutex
+	// unready is why the check last failed, or "" if it passed
+	unready string
+}
+
+// newHealthChecker returns a healthChecker of the health check of the
+// pipeline in 'pipelineInfo', or nil if it has none
+func newHealthChecker(pipelineInfo *pps.PipelineInfo) (*healthChecker, error) {
+	healthCheck := pipelineInfo.HealthCheck
+	if healthCheck == nil || len(healthCheck.Cmd) == 0 {
+		return nil, nil
+	}
+	h := &healthChecker{
+		cmd:        healthCheck.Cmd,
+		env:        os.Environ(),
+		workingDir: pipelineInfo.Transform.WorkingDir,
+	}
+	for name, value := range pipelineInfo.Transform.Env {
+		h.env = append(h.env, fmt.Sprintf("%s=%s", name, value))
+	}
+	if healthCheck.Interval != nil {
+		var err error
+		if h.interval, err = types.DurationFromProto(healthCheck.Interval); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// check runs the health check once, returning why it failed, or "" if it
+// passed
+func (h *healthChecker) check(ctx context.Context) string {
+	cmd := exec.CommandContext(ctx, h.cmd[0], h.cmd[1:]...)
+	cmd.Env = h.env
+	cmd.Dir = h.workingDir
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return ""
+	}
+	if output := strings.TrimSpace(string(output)); output != "" {
+		return fmt.Sprintf("health check failed: %v: %s", err, output)
+	}
+	return fmt.Sprintf("health check failed: %v", err)
+}
+
+// wait returns once the health check passes (or has passed within its
+// interval), retrying it while it fails. It only returns an error if 'ctx' is
+// done first. A nil healthChecker always passes.
+func (h *healthChecker) wait(ctx context.Context, logger *taggedLogger) error {
+	if h == nil {
+		return nil
+	}
+	h.waitMu.Lock()
+	defer h.waitMu.Unlock()
+	retry := healthCheckRetry
+	if h.interval > 0 {
+		if time.Since(h.lastPassed) < h.interval {
+			return nil
+		}
+		retry = h.interval
+	}
+	for {
+		reason := h.check(ctx)
+		if prev := h.setStatus(reason); reason == "" {
+			if prev != "" {
+				logger.Logf("health check passed, resuming datums")
+			}
+			h.lastPassed = time.Now()
+			return nil
+		} else if prev == "" {
+			logger.Logf("%s; pausing datums until it passes", reason)
+		}
+		select {
+		case <-time.After(retry):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// setStatus records why the worker is unready ("" if it's ready), and
+// returns the previous reason
+func (h *healthChecker) setStatus(unready string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	prev := h.unready
+	h.unready = unready
+	return prev
+}
+
+// status returns why the worker is unready, or "" if it's ready
+func (h *healthChecker) status() string {
+	if h == nil {
+		return ""
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.unready
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestHealthCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "health_check_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	up := filepath.Join(dir, "up")
+	h, err := newHealthChecker(&pps.PipelineInfo{
+		Transform: &pps.Transform{},
+		HealthCheck: &pps.HealthCheck{
+			Cmd:      []string{"test", "-e", up},
+			Interval: types.DurationProto(50 * time.Millisecond),
+		},
+	})
+	require.NoError(t, err)
+	logger := (&APIServer{}).getWorkerLogger()
+
+	// Datums are held while the check fails...
+	done := make(chan error, 1)
+	go func() { done <- h.wait(context.Background(), logger) }()
+	select {
+	case err := <-done:
+		t.Fatalf("wait returned while the health check was failing: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	require.NotEqual(t, "", h.status())
+
+	// ...and released once it passes
+	require.NoError(t, ioutil.WriteFile(up, nil, 0666))
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("wait didn't return after the health check passed")
+	}
+	require.Equal(t, "", h.status())
+
+	// A wait is abandoned if its context is done
+	require.NoError(t, os.Remove(up))
+	time.Sleep(100 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	require.YesError(t, h.wait(ctx, logger))
+
+	// Pipelines without a health check are always ready
+	h, err = newHealthChecker(&pps.PipelineInfo{Transform: &pps.Transform{}})
+	require.NoError(t, err)
+	require.NoError(t, h.wait(context.Background(), logger))
+	require.Equal(t, "", h.status())
+}
@@ -0,0 +1,154 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	etcd "github.com/coreos/etcd/clientv3"
+	etcdconcurrency "github.com/coreos/etcd/clientv3/concurrency"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// jobSlotsPrefix is the etcd prefix (under the PPS prefix) of the cluster's
+// job slot queue: a key for each job that holds or is waiting for a job slot
+// (see client.PPSMaxConcurrentJobsEnv). The first jobs in the queue, in order
+// of their keys' creation, hold the slots.
+const jobSlotsPrefix = "job_slots"
+
+// maxConcurrentJobs returns the most jobs that may run at once across all of
+// the cluster's pipelines, or 0 if there's no limit (see
+// client.PPSMaxConcurrentJobsEnv)
+func maxConcurrentJobs() int64 {
+	max, err := strconv.ParseInt(os.Getenv(client.PPSMaxConcurrentJobsEnv), 10, 64)
+	if err != nil || max < 0 {
+		return 0
+	}
+	return max
+}
+
+// waitingForJobSlotReason is the reason of a job that's waiting for one of
+// the 'max' job slots, with 'ahead' jobs queued ahead of it
+func waitingForJobSlotReason(ahead int64, max int64) string {
+	return fmt.Sprintf("waiting for job slot (%d jobs, the most that may run "+
+		"at once, are running, and %d more are queued ahead of this one)", max, ahead)
+}
+
+// waitForJobSlot blocks until the job in 'jobInfo' holds one of the cluster's
+// job slots, if the number of jobs that may run at once is limited, and sets
+// the job's reason while it waits. It returns a function that frees the slot.
+func (a *APIServer) waitForJobSlot(ctx context.Context, jobInfo *pps.JobInfo, logger *taggedLogger) (func(), error) {
+	max := maxConcurrentJobs()
+	if max == 0 {
+		return func() {}, nil
+	}
+	return acquireJobSlot(ctx, a.etcdClient, a.etcdPrefix, jobInfo.Job.ID, max, func(ahead int64) error {
+		reason := waitingForJobSlotReason(ahead, max)
+		logger.Logf("job %s is %s", jobInfo.Job.ID, reason)
+		_, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+			jobs := a.jobs.ReadWrite(stm)
+			jobPtr := &pps.EtcdJobInfo{}
+			if err := jobs.Get(jobInfo.Job.ID, jobPtr); err != nil {
+				return err
+			}
+			if jobPtr.State != pps.JobState_JOB_STARTING {
+				return nil
+			}
+			jobPtr.Reason = reason
+			return jobs.Put(jobInfo.Job.ID, jobPtr)
+		})
+		return err
+	})
+}
+
+// acquireJobSlot queues the job 'jobID' for one of the cluster's 'max' job
+// slots, and blocks until it holds one, calling 'waiting' with the number of
+// jobs queued ahead of it whenever that changes. Jobs get slots in the order
+// that they're queued, whichever pipeline they belong to. It returns a
+// function that frees the slot, which must be called once the job is done.
+// A job's place in the queue is kept if it's queued again (e.g. by a new
+// master, after the previous one died) before its key's lease expires.
+func acquireJobSlot(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string, jobID string, max int64, waiting func(ahead int64) error) (func(), error) {
+	// The key is deleted if this worker dies, so that its slot is freed
+	session, err := etcdconcurrency.NewSession(etcdClient, etcdconcurrency.WithContext(ctx), etcdconcurrency.WithTTL(15))
+	if err != nil {
+		return nil, err
+	}
+	release := func() {
+		// ctx may be done by the time that the slot is freed
+		session.Orphan()
+		etcdClient.Revoke(context.Background(), session.Lease())
+	}
+	prefix := path.Join(etcdPrefix, jobSlotsPrefix) + "/"
+	key := prefix + jobID
+	if _, err := etcdClient.Put(ctx, key, "", etcd.WithLease(session.Lease())); err != nil {
+		release()
+		return nil, err
+	}
+	lastAhead := int64(-1)
+	for {
+		resp, err := etcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly(),
+			etcd.WithSort(etcd.SortByCreateRevision, etcd.SortAscend))
+		if err != nil {
+			release()
+			return nil, err
+		}
+		position := int64(-1)
+		for i, kv := range resp.Kvs {
+			if string(kv.Key) == key {
+				position = int64(i)
+				break
+			}
+		}
+		if position < 0 {
+			release()
+			return nil, fmt.Errorf("job %s is no longer queued for a job slot", jobID)
+		}
+		if position < max {
+			return release, nil
+		}
+		if ahead := position - max; ahead != lastAhead {
+			if err := waiting(ahead); err != nil {
+				release()
+				return nil, err
+			}
+			lastAhead = ahead
+		}
+		// Wait for a job to leave the queue
+		if err := func() error {
+			watchCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			for watchResp := range etcdClient.Watch(watchCtx, prefix, etcd.WithPrefix(),
+				etcd.WithRev(resp.Header.Revision+1), etcd.WithFilterPut()) {
+				if err := watchResp.Err(); err != nil {
+					return err
+				}
+				if len(watchResp.Events) > 0 {
+					return nil
+				}
+			}
+			return ctx.Err()
+		}(); err != nil {
+			release()
+			return nil, err
+		}
+	}
+}
+
+// JobSlotQueueDepth returns the number of jobs waiting for one of the
+// cluster's 'max' job slots
+func JobSlotQueueDepth(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string, max int64) (int64, error) {
+	resp, err := etcdClient.Get(ctx, path.Join(etcdPrefix, jobSlotsPrefix)+"/", etcd.WithPrefix(), etcd.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	if resp.Count <= max {
+		return 0, nil
+	}
+	return resp.Count - max, nil
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+)
+
+func TestJobSlots(t *testing.T) {
+	etcdClient := getEtcdClient(t)
+	etcdPrefix := tu.UniqueString("TestJobSlots")
+	a := newTestAPIServer(nil, etcdClient, etcdPrefix, t)
+	logger := a.getMasterLogger()
+	var jobInfos []*pps.JobInfo
+	for i := 0; i < 4; i++ {
+		jobInfo := &pps.JobInfo{Job: client.NewJob(tu.UniqueString("job"))}
+		_, err := col.NewSTM(context.Background(), etcdClient, func(stm col.STM) error {
+			return a.jobs.ReadWrite(stm).Put(jobInfo.Job.ID, &pps.EtcdJobInfo{
+				Job:          jobInfo.Job,
+				Pipeline:     client.NewPipeline("test"),
+				OutputCommit: client.NewCommit("test", fmt.Sprint(i)),
+				State:        pps.JobState_JOB_STARTING,
+			})
+		})
+		require.NoError(t, err)
+		jobInfos = append(jobInfos, jobInfo)
+	}
+	checkReason := func(jobInfo *pps.JobInfo, reason string) {
+		require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+			jobPtr := &pps.EtcdJobInfo{}
+			if err := a.jobs.ReadOnly(context.Background()).Get(jobInfo.Job.ID, jobPtr); err != nil {
+				return err
+			}
+			if jobPtr.Reason != reason {
+				return fmt.Errorf("expected reason %q, got %q", reason, jobPtr.Reason)
+			}
+			return nil
+		})
+	}
+	checkDepth := func(expected int64) {
+		depth, err := JobSlotQueueDepth(context.Background(), etcdClient, etcdPrefix, 2)
+		require.NoError(t, err)
+		require.Equal(t, expected, depth)
+	}
+	// waitForJobSlot returns once the job has a slot
+	acquired := func(jobInfo *pps.JobInfo) (<-chan func(), <-chan error) {
+		releaseCh, errCh := make(chan func(), 1), make(chan error, 1)
+		go func() {
+			release, err := a.waitForJobSlot(context.Background(), jobInfo, logger)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			releaseCh <- release
+		}()
+		return releaseCh, errCh
+	}
+	receive := func(releaseCh <-chan func(), errCh <-chan error) func() {
+		select {
+		case release := <-releaseCh:
+			return release
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("job did not get a job slot")
+		}
+		return nil
+	}
+
+	// Without a limit, jobs don't queue
+	defer os.Unsetenv(client.PPSMaxConcurrentJobsEnv)
+	require.NoError(t, os.Unsetenv(client.PPSMaxConcurrentJobsEnv))
+	release, err := a.waitForJobSlot(context.Background(), jobInfos[0], logger)
+	require.NoError(t, err)
+	release()
+	checkDepth(0)
+
+	// The first two jobs start right away, and the others queue
+	require.NoError(t, os.Setenv(client.PPSMaxConcurrentJobsEnv, "2"))
+	release0 := receive(acquired(jobInfos[0]))
+	release1 := receive(acquired(jobInfos[1]))
+	releaseCh2, errCh2 := acquired(jobInfos[2])
+	checkReason(jobInfos[2], waitingForJobSlotReason(0, 2))
+	releaseCh3, errCh3 := acquired(jobInfos[3])
+	checkReason(jobInfos[3], waitingForJobSlotReason(1, 2))
+	checkDepth(2)
+
+	// Queued jobs start, in order, as running jobs finish
+	release0()
+	release2 := receive(releaseCh2, errCh2)
+	checkReason(jobInfos[3], waitingForJobSlotReason(0, 2))
+	checkDepth(1)
+	select {
+	case <-releaseCh3:
+		t.Fatal("job got a job slot while two jobs were running")
+	default:
+	}
+	release1()
+	release3 := receive(releaseCh3, errCh3)
+	checkDepth(0)
+	release2()
+	release3()
+}
@@ -0,0 +1,254 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+const (
+	// statsLogsFile is the name of the file in each datum's stats that holds
+	// the datum's logs. Once it holds logRotateBytes, the logs continue in
+	// statsLogsFile.1, statsLogsFile.2 and so on.
+	statsLogsFile = "logs"
+	// logRotateBytes is the size at which a datum's logs are rotated to a new
+	// file, so that no single logs file grows without bound
+	logRotateBytes = 64 * 1024 * 1024
+)
+
+// logsFileName returns the name of the i'th file of a datum's logs
+func logsFileName(i int) string {
+	if i == 0 {
+		return statsLogsFile
+	}
+	return fmt.Sprintf("%s.%d", statsLogsFile, i)
+}
+
+// logsFileIndex returns i if 'name' is logsFileName(i), and -1 if 'name'
+// isn't the name of a logs file
+func logsFileIndex(name string) int {
+	if name == statsLogsFile {
+		return 0
+	}
+	if !strings.HasPrefix(name, statsLogsFile+".") {
+		return -1
+	}
+	i, err := strconv.Atoi(strings.TrimPrefix(name, statsLogsFile+"."))
+	if err != nil || i <= 0 {
+		return -1
+	}
+	return i
+}
+
+// SortLogsFiles sorts 'fileInfos', the logs files of datums in a stats
+// commit, by datum, and then in the order that each datum's logs were written
+// (so "logs.10" comes after "logs.9")
+func SortLogsFiles(fileInfos []*pfs.FileInfo) {
+	sort.SliceStable(fileInfos, func(i, j int) bool {
+		pi, pj := fileInfos[i].File.Path, fileInfos[j].File.Path
+		if di, dj := path.Dir(pi), path.Dir(pj); di != dj {
+			return di < dj
+		}
+		return logsFileIndex(path.Base(pi)) < logsFileIndex(path.Base(pj))
+	})
+}
+
+// logObjectWriter writes the content of an object (it's implemented by
+// pfs.ObjectAPI_PutObjectClient)
+type logObjectWriter interface {
+	Send(*pfs.PutObjectRequest) error
+	CloseAndRecv() (*pfs.Object, error)
+}
+
+// logRotator writes a datum's logs to a sequence of objects, starting a new
+// object once the current one holds 'rotateBytes'
+type logRotator struct {
+	newObject   func() (logObjectWriter, error)
+	rotateBytes int64
+	// scope is the dedup scope of the objects (see
+	// pfs.PutObjectRequest.DedupScope)
+	scope string
+
+	current     logObjectWriter
+	currentSize int64
+	objects     []*pfs.Object
+	sizes       []int64
+}
+
+// newLogRotator returns a logRotator that writes objects created by
+// 'newObject', in the dedup scope 'scope'. The first object is created right
+// away, so that a datum without logs still has an (empty) logs file.
+func newLogRotator(newObject func() (logObjectWriter, error), rotateBytes int64, scope string) (*logRotator, error) {
+	current, err := newObject()
+	if err != nil {
+		return nil, err
+	}
+	return &logRotator{
+		newObject:   newObject,
+		rotateBytes: rotateBytes,
+		scope:       scope,
+		current:     current,
+	}, nil
+}
+
+// write writes the log message 'msg'. Messages aren't split across objects.
+func (r *logRotator) write(msg string) error {
+	if r.currentSize > 0 && r.currentSize+int64(len(msg)) > r.rotateBytes {
+		if err := r.finishObject(); err != nil {
+			return err
+		}
+		current, err := r.newObject()
+		if err != nil {
+			return err
+		}
+		r.current = current
+	}
+	for _, chunk := range grpcutil.Chunk([]byte(msg), grpcutil.MaxMsgSize/2) {
+		if err := r.current.Send(&pfs.PutObjectRequest{
+			Value:      chunk,
+			DedupScope: r.scope,
+		}); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	r.currentSize += int64(len(msg))
+	return nil
+}
+
+func (r *logRotator) finishObject() error {
+	if r.currentSize == 0 {
+		// Nothing has been sent, so send the scope of the empty object
+		if err := r.current.Send(&pfs.PutObjectRequest{DedupScope: r.scope}); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	object, err := r.current.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	r.objects = append(r.objects, object)
+	r.sizes = append(r.sizes, r.currentSize)
+	r.current, r.currentSize = nil, 0
+	return nil
+}
+
+// close finishes the current object, and returns the objects that hold the
+// logs, in order, and their sizes
+func (r *logRotator) close() ([]*pfs.Object, []int64, error) {
+	if r.current != nil {
+		if err := r.finishObject(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return r.objects, r.sizes, nil
+}
+
+// logRetention returns how long datums' logs are kept in the stats branch of
+// the pipeline in 'pipelineInfo', and the most bytes of logs that are kept (0
+// means no limit, for either). The pipeline's log_retention overrides the
+// cluster's defaults (see client.PPSLogRetentionMaxAgeEnv and
+// client.PPSLogRetentionMaxBytesEnv).
+func logRetention(pipelineInfo *pps.PipelineInfo) (time.Duration, int64) {
+	var maxAge time.Duration
+	if pipelineInfo.LogRetention.GetMaxAge() != nil {
+		maxAge, _ = types.DurationFromProto(pipelineInfo.LogRetention.MaxAge)
+	} else if d, err := time.ParseDuration(os.Getenv(client.PPSLogRetentionMaxAgeEnv)); err == nil {
+		maxAge = d
+	}
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	return maxAge, jobLimit(pipelineInfo.LogRetention.GetMaxBytes(), client.PPSLogRetentionMaxBytesEnv)
+}
+
+// pruneLogs adds the files in 'logFiles' (datums' logs files) to the
+// compaction's pruned paths if every job that processed their datum (per
+// 'jobFiles') finished before 'cutoff' or, keeping the logs of the most
+// recently processed datums first, they don't fit in 'maxBytes' (0 means no
+// limit). The logs of datums that the compaction already prunes are skipped,
+// and the logs of datums processed by jobs that aren't in 'jobInfos' (or
+// haven't finished) are always kept.
+func (c *statsCompaction) pruneLogs(logFiles, jobFiles []*pfs.FileInfo, jobInfos []*pps.JobInfo, cutoff time.Time, maxBytes int64) {
+	finished := make(map[string]time.Time)
+	for _, jobInfo := range jobInfos {
+		if jobInfo.Finished == nil {
+			continue
+		}
+		if t, err := types.TimestampFromProto(jobInfo.Finished); err == nil {
+			finished[jobInfo.Job.ID] = t
+		}
+	}
+	// processed is when the last job that processed each datum finished, and
+	// active is whether a datum's jobs include unknown or unfinished ones
+	processed := make(map[string]time.Time)
+	active := make(map[string]bool)
+	for _, jobFile := range jobFiles {
+		datum := path.Dir(jobFile.File.Path)
+		jobID := strings.TrimPrefix(path.Base(jobFile.File.Path), statsJobFilePrefix)
+		t, ok := finished[jobID]
+		if !ok {
+			active[datum] = true
+			continue
+		}
+		if t.After(processed[datum]) {
+			processed[datum] = t
+		}
+	}
+	var files []*pfs.FileInfo
+	for _, logFile := range logFiles {
+		datum := path.Dir(logFile.File.Path)
+		if _, ok := c.prune[datum]; ok {
+			continue
+		}
+		if _, ok := processed[datum]; !ok {
+			active[datum] = true // no job is known to have processed it
+		}
+		files = append(files, logFile)
+	}
+	// Sort the files from the most to the least recently processed (active
+	// datums first, and each datum's last logs file first), so that the most
+	// recent logs are kept
+	sort.SliceStable(files, func(i, j int) bool {
+		di, dj := path.Dir(files[i].File.Path), path.Dir(files[j].File.Path)
+		if active[di] != active[dj] {
+			return active[di]
+		}
+		if !processed[di].Equal(processed[dj]) {
+			return processed[di].After(processed[dj])
+		}
+		if di != dj {
+			return di > dj
+		}
+		return logsFileIndex(path.Base(files[i].File.Path)) > logsFileIndex(path.Base(files[j].File.Path))
+	})
+	var total int64
+	full := false
+	for _, file := range files {
+		datum := path.Dir(file.File.Path)
+		size := int64(file.SizeBytes)
+		if !active[datum] {
+			// Once a file doesn't fit, older ones are pruned too, so that the
+			// kept logs are the most recent ones
+			if maxBytes > 0 && total+size > maxBytes {
+				full = true
+			}
+			if full || processed[datum].Before(cutoff) {
+				c.prune[file.File.Path] = size
+				continue
+			}
+		}
+		total += size
+	}
+}
@@ -0,0 +1,124 @@
+package worker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// bufferObjectWriter is a logObjectWriter that writes to an in-memory buffer
+type bufferObjectWriter struct {
+	buf     *bytes.Buffer
+	objects *[]string
+}
+
+func (w *bufferObjectWriter) Send(request *pfs.PutObjectRequest) error {
+	_, err := w.buf.Write(request.Value)
+	return err
+}
+
+func (w *bufferObjectWriter) CloseAndRecv() (*pfs.Object, error) {
+	*w.objects = append(*w.objects, w.buf.String())
+	return &pfs.Object{Hash: w.buf.String()}, nil
+}
+
+func TestLogRotator(t *testing.T) {
+	var objects []string
+	r, err := newLogRotator(func() (logObjectWriter, error) {
+		return &bufferObjectWriter{buf: &bytes.Buffer{}, objects: &objects}, nil
+	}, 10, "")
+	require.NoError(t, err)
+	for _, msg := range []string{"aaaa\n", "bbbb\n", "cccc\n", strings.Repeat("d", 20) + "\n", "e\n"} {
+		require.NoError(t, r.write(msg))
+	}
+	objs, sizes, err := r.close()
+	require.NoError(t, err)
+	// Messages aren't split, so an oversized message gets an object of its own
+	require.Equal(t, []string{"aaaa\nbbbb\n", "cccc\n", strings.Repeat("d", 20) + "\n", "e\n"}, objects)
+	require.Equal(t, []int64{10, 5, 21, 2}, sizes)
+	require.Equal(t, 4, len(objs))
+
+	// A datum without logs still gets an (empty) logs file
+	objects = nil
+	r, err = newLogRotator(func() (logObjectWriter, error) {
+		return &bufferObjectWriter{buf: &bytes.Buffer{}, objects: &objects}, nil
+	}, 10, "")
+	require.NoError(t, err)
+	_, sizes, err = r.close()
+	require.NoError(t, err)
+	require.Equal(t, []string{""}, objects)
+	require.Equal(t, []int64{0}, sizes)
+
+	require.Equal(t, "logs", logsFileName(0))
+	require.Equal(t, "logs.2", logsFileName(2))
+}
+
+func TestPruneLogs(t *testing.T) {
+	now := time.Now()
+	jobInfos := []*pps.JobInfo{
+		finishedJob(t, "old", now.Add(-48*time.Hour), nil),
+		finishedJob(t, "recent", now.Add(-2*time.Hour), nil),
+		finishedJob(t, "latest", now.Add(-time.Hour), nil),
+		{Job: client.NewJob("running")},
+	}
+	jobFiles := statsFiles(map[string]uint64{
+		"/a/job:old":     0,
+		"/b/job:recent":  0,
+		"/c/job:latest":  0,
+		"/d/job:running": 0,
+		"/e/job:old":     0,
+	})
+	logFiles := statsFiles(map[string]uint64{
+		"/a/logs":    1,
+		"/b/logs":    10,
+		"/b/logs.1":  10,
+		"/b/logs.2":  10,
+		"/b/logs.10": 10,
+		"/c/logs":    10,
+		"/d/logs":    100,
+		"/e/logs":    1,
+	})
+	newCompaction := func() *statsCompaction {
+		return &statsCompaction{
+			prune:     map[string]int64{"/e": 1},
+			summaries: make(map[string]*JobStatsSummary),
+		}
+	}
+
+	// Logs of datums whose jobs finished before the cutoff are pruned, while
+	// the logs of datums that the compaction already prunes are skipped
+	c := newCompaction()
+	c.pruneLogs(logFiles, jobFiles, jobInfos, now.Add(-24*time.Hour), 0)
+	require.Equal(t, map[string]int64{"/e": 1, "/a/logs": 1}, c.prune)
+
+	// The most recently processed logs (and a datum's most recent logs files)
+	// are kept within the byte limit, and the logs of running jobs are always
+	// kept
+	c = newCompaction()
+	c.pruneLogs(logFiles, jobFiles, jobInfos, time.Time{}, 125)
+	require.Equal(t, map[string]int64{
+		"/e": 1, "/a/logs": 1, "/b/logs": 10, "/b/logs.1": 10, "/b/logs.2": 10,
+	}, c.prune)
+}
+
+func TestSortLogsFiles(t *testing.T) {
+	fileInfos := statsFiles(map[string]uint64{
+		"/b/logs":    0,
+		"/b/logs.10": 0,
+		"/b/logs.9":  0,
+		"/a/logs.1":  0,
+		"/a/logs":    0,
+	})
+	SortLogsFiles(fileInfos)
+	var paths []string
+	for _, fileInfo := range fileInfos {
+		paths = append(paths, fileInfo.File.Path)
+	}
+	require.Equal(t, []string{"/a/logs", "/a/logs.1", "/b/logs", "/b/logs.9", "/b/logs.10"}, paths)
+}
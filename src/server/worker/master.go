@@ -7,12 +7,14 @@ import (
 	"log"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/types"
 	"github.com/montanaflynn/stats"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
@@ -23,9 +25,12 @@ import (
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/dlock"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	filesync "github.com/pachyderm/pachyderm/src/server/pkg/sync"
 	pfs_sync "github.com/pachyderm/pachyderm/src/server/pkg/sync"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
 )
 
 const (
@@ -37,10 +42,13 @@ const (
 
 	// The number of datums the master caches
 	numCachedDatums = 1000000
-
-	ttl = int64(30)
 )
 
+// ttl is the TTL, in seconds, of the locks that workers hold on chunks and
+// merges, before any grace period (see lockTTL). It's a variable so that tests
+// don't wait for locks to expire.
+var ttl = int64(30)
+
 func (a *APIServer) getMasterLogger() *taggedLogger {
 	result := &taggedLogger{
 		template:  a.logMsgTemplate, // Copy struct
@@ -64,9 +72,12 @@ func (a *APIServer) getWorkerLogger() *taggedLogger {
 	return result
 }
 
-func (logger *taggedLogger) jobLogger(jobID string) *taggedLogger {
+// jobLogger returns a copy of 'logger' that tags its messages with the ID and
+// trace ID of the job 'jobInfo'.
+func (logger *taggedLogger) jobLogger(jobInfo *pps.JobInfo) *taggedLogger {
 	result := logger.clone()
-	result.template.JobID = jobID
+	result.template.JobID = jobInfo.Job.ID
+	result.template.TraceID = jobInfo.TraceID
 	return result
 }
 
@@ -87,20 +98,51 @@ func (a *APIServer) master() {
 		// We use a.pachClient.Ctx here because it contains auth information.
 		ctx, cancel := context.WithCancel(a.pachClient.Ctx())
 		defer cancel() // make sure that everything this loop might spawn gets cleaned up
+		go a.cancelOnPreemption(ctx, cancel)
 		ctx, err := masterLock.Lock(ctx)
 		pachClient := a.pachClient.WithCtx(ctx)
 		if err != nil {
 			return err
 		}
-		defer masterLock.Unlock(ctx)
+		defer unlockMaster(masterLock, logger)
 		logger.Logf("Launching worker master process")
-		return a.jobSpawner(pachClient)
+		eg, ctx := errgroup.WithContext(ctx)
+		pachClient = pachClient.WithCtx(ctx)
+		eg.Go(func() error { return a.jobSpawner(pachClient) })
+		eg.Go(func() error { return a.rerunSpawner(pachClient) })
+		return eg.Wait()
 	}, b, func(err error, d time.Duration) error {
+		if a.preemption.preempted() {
+			logger.Logf("master: preempted, so giving up the master lock")
+			return err
+		}
 		logger.Logf("master: error running the master process: %v; retrying in %v", err, d)
 		return nil
 	})
 }
 
+// cancelOnPreemption calls 'cancel' once the worker is preempted, unless
+// 'ctx' is done first, so that a preempted worker stops being (or waiting to
+// become) the master, and stops watching for jobs
+func (a *APIServer) cancelOnPreemption(ctx context.Context, cancel func()) {
+	select {
+	case <-a.preemption.notified():
+		cancel()
+	case <-ctx.Done():
+	}
+}
+
+// unlockMaster releases 'masterLock' right away, so that another worker can
+// become the master. It uses a new ctx, as the master's ctx is cancelled
+// when the worker is preempted.
+func unlockMaster(masterLock dlock.DLock, logger *taggedLogger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := masterLock.Unlock(ctx); err != nil {
+		logger.Logf("error releasing the master lock: %v", err)
+	}
+}
+
 func (a *APIServer) serviceMaster() {
 	masterLock := dlock.NewDLock(a.etcdClient, path.Join(a.etcdPrefix, masterLockPath, a.pipelineInfo.Pipeline.Name, a.pipelineInfo.Salt))
 	logger := a.getMasterLogger()
@@ -109,12 +151,13 @@ func (a *APIServer) serviceMaster() {
 		// We use pachClient.Ctx here because it contains auth information.
 		ctx, cancel := context.WithCancel(a.pachClient.Ctx())
 		defer cancel() // make sure that everything this loop might spawn gets cleaned up
+		go a.cancelOnPreemption(ctx, cancel)
 		ctx, err := masterLock.Lock(ctx)
 		pachClient := a.pachClient.WithCtx(ctx)
 		if err != nil {
 			return err
 		}
-		defer masterLock.Unlock(ctx)
+		defer unlockMaster(masterLock, logger)
 
 		logger.Logf("Launching master process")
 
@@ -141,6 +184,10 @@ func (a *APIServer) serviceMaster() {
 		}
 		return a.serviceSpawner(pachClient)
 	}, b, func(err error, d time.Duration) error {
+		if a.preemption.preempted() {
+			logger.Logf("master: preempted, so giving up the master lock")
+			return err
+		}
 		logger.Logf("master: error running the master process: %v; retrying in %v", err, d)
 		return nil
 	})
@@ -148,12 +195,20 @@ func (a *APIServer) serviceMaster() {
 
 func (a *APIServer) jobSpawner(pachClient *client.APIClient) error {
 	logger := a.getMasterLogger()
-	// Listen for new commits, and create jobs when they arrive
+	// Listen for new commits, and create jobs when they arrive. SubscribeCommit
+	// returns the output branch's commits in the order they were created, and
+	// waits for each one to be READY before returning the next, and each job
+	// is waited for before the next commit is read, so jobs run one at a time,
+	// in ancestry order, even if a commit's inputs are finished before its
+	// parent's are.
 	commitIter, err := pachClient.SubscribeCommit(a.pipelineInfo.Pipeline.Name, a.pipelineInfo.OutputBranch, "", pfs.CommitState_READY)
 	if err != nil {
 		return err
 	}
 	defer commitIter.Close()
+	// burstStart is when the current burst of input commits began, if the
+	// pipeline is debounced
+	var burstStart time.Time
 	for {
 		commitInfo, err := commitIter.Next()
 		if err != nil {
@@ -162,48 +217,190 @@ func (a *APIServer) jobSpawner(pachClient *client.APIClient) error {
 		if commitInfo.Finished != nil {
 			continue
 		}
-		// Inspect the commit and check again if it has been finished (it may have
-		// been closed since it was queued, e.g. by StopPipeline or StopJob)
-		commitInfo, err = pachClient.InspectCommit(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID)
-		if err != nil {
+		if err := a.spawnJob(pachClient, commitInfo, &burstStart, logger); err != nil {
 			return err
 		}
-		if commitInfo.Finished != nil {
-			continue // commit finished after queueing
+	}
+}
+
+// spawnJob creates the job of the output commit in 'commitInfo', unless one
+// has already been created, and waits for it to finish. 'burstStart' is when
+// the current burst of input commits began, if the pipeline is debounced.
+func (a *APIServer) spawnJob(pachClient *client.APIClient, commitInfo *pfs.CommitInfo, burstStart *time.Time, logger *taggedLogger) error {
+	// Inspect the commit and check again if it has been finished (it may have
+	// been closed since it was queued, e.g. by StopPipeline or StopJob)
+	commitInfo, err := pachClient.InspectCommit(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID)
+	if err != nil {
+		return err
+	}
+	if commitInfo.Finished != nil {
+		return nil // commit finished after queueing
+	}
+	// Check if a job was previously created for this commit. If not, make one
+	var jobInfo *pps.JobInfo
+	jobInfos, err := pachClient.ListJob("", nil, commitInfo.Commit)
+	if err != nil {
+		return err
+	}
+	if len(jobInfos) > 0 {
+		if len(jobInfos) > 1 {
+			return fmt.Errorf("multiple jobs found for commit: %s/%s", commitInfo.Commit.Repo.Name, commitInfo.Commit.ID)
 		}
-		// Check if a job was previously created for this commit. If not, make one
-		var jobInfo *pps.JobInfo
-		jobInfos, err := pachClient.ListJob("", nil, commitInfo.Commit)
+		jobInfo = jobInfos[0]
+	} else {
+		// Output commits whose inputs haven't changed are copies of their
+		// parent, and don't need a job
+		parentInfo, err := hasUnchangedInputs(pachClient, a.pipelineInfo, commitInfo)
 		if err != nil {
 			return err
 		}
-		if len(jobInfos) > 0 {
-			if len(jobInfos) > 1 {
-				return fmt.Errorf("multiple jobs found for commit: %s/%s", commitInfo.Commit.Repo.Name, commitInfo.Commit.ID)
-			}
-			jobInfo = jobInfos[0]
-		} else {
-			job, err := pachClient.CreateJob(a.pipelineInfo.Pipeline.Name, commitInfo.Commit)
+		if parentInfo != nil {
+			logger.Logf("inputs of commit %s are unchanged since %s, finishing it without a job", commitInfo.Commit.ID, parentInfo.Commit.ID)
+			_, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+				Commit:      commitInfo.Commit,
+				Trees:       parentInfo.Trees,
+				Datums:      parentInfo.Datums,
+				SizeBytes:   parentInfo.SizeBytes,
+				Description: fmt.Sprintf("skipped: inputs unchanged since %s", parentInfo.Commit.ID),
+				Labels:      unchangedOutputLabels,
+			})
+			return err
+		}
+		if isDebounced(a.pipelineInfo) {
+			run, err := a.debounce(pachClient, commitInfo, burstStart)
 			if err != nil {
 				return err
 			}
-			jobInfo, err = pachClient.InspectJob(job.ID, false)
-			if err != nil {
-				return err
+			if !run {
+				return nil
 			}
 		}
-		if ppsutil.IsTerminal(jobInfo.State) {
-			// previously-created job has finished, but commit has not been closed yet
+		job, err := pachClient.CreateJob(a.pipelineInfo.Pipeline.Name, commitInfo.Commit)
+		if err != nil {
+			return err
+		}
+		jobInfo, err = pachClient.InspectJob(job.ID, false)
+		if err != nil {
+			return err
+		}
+	}
+	if ppsutil.IsTerminal(jobInfo.State) {
+		// previously-created job has finished, but commit has not been closed yet
+		return nil
+	}
+
+	// Now that the jobInfo is persisted, wait until all input commits are
+	// ready, split the input datums into chunks and merge the results of
+	// chunks as they're processed
+	return a.waitJob(pachClient, jobInfo, logger)
+}
+
+// rerunSpawner runs the jobs created by RerunJob. Unlike the jobs run by
+// jobSpawner, their output commits aren't in the pipeline's output branch, so
+// they're found by watching the pipeline's jobs. Its errors are handled here,
+// rather than returned, so that reruns can't restart the master (and so
+// interrupt the pipeline's other jobs). It only returns once pachClient's
+// context is done.
+func (a *APIServer) rerunSpawner(pachClient *client.APIClient) error {
+	logger := a.getMasterLogger()
+	ctx := pachClient.Ctx()
+	backoff.RetryNotify(func() error {
+		return a.watchReruns(pachClient, logger)
+	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+		if isDone(ctx) {
+			return err
+		}
+		logger.Logf("error watching rerun jobs: %v; retrying in %v", err, d)
+		return nil
+	})
+	return nil
+}
+
+// watchReruns runs the jobs created by RerunJob (see rerunSpawner), and
+// deletes the output branches of those that have finished.
+func (a *APIServer) watchReruns(pachClient *client.APIClient, logger *taggedLogger) error {
+	watcher, err := a.jobs.ReadOnly(pachClient.Ctx()).WatchByIndex(ppsdb.JobsPipelineIndex, a.pipelineInfo.Pipeline)
+	if err != nil {
+		return fmt.Errorf("error creating watch: %v", err)
+	}
+	defer watcher.Close()
+	for e := range watcher.Watch() {
+		if e.Type == watch.EventError {
+			return fmt.Errorf("rerun watch error: %v", e.Err)
+		} else if e.Type != watch.EventPut {
 			continue
 		}
+		var jobID string
+		jobPtr := &pps.EtcdJobInfo{}
+		if err := e.Unmarshal(&jobID, jobPtr); err != nil {
+			return fmt.Errorf("error unmarshalling: %v", err)
+		}
+		if jobPtr.RerunOf == nil {
+			continue
+		}
+		if !ppsutil.IsTerminal(jobPtr.State) {
+			finished, err := a.runRerun(pachClient, jobID, logger)
+			if err != nil {
+				logger.Logf("error running rerun job %s: %v", jobID, err)
+				continue
+			}
+			if !finished {
+				continue
+			}
+		}
+		// The output commit stays in the output repo, and is found through the
+		// job, once the branch is deleted
+		if err := pachClient.DeleteBranch(jobPtr.OutputCommit.Repo.Name, ppsconsts.RerunBranchPrefix+jobID, false); err != nil && !isNotFoundErr(err) {
+			logger.Logf("error deleting the output branch of rerun job %s: %v", jobID, err)
+		}
+	}
+	return fmt.Errorf("rerun watch closed")
+}
 
-		// Now that the jobInfo is persisted, wait until all input commits are
-		// ready, split the input datums into chunks and merge the results of
-		// chunks as they're processed
+// runRerun runs the rerun job 'jobID' until it's finished, if it's for the
+// pipeline's current version, and returns whether it finished. If it can't be
+// run, even after a few retries, it's failed, so that its output branch can be
+// deleted.
+func (a *APIServer) runRerun(pachClient *client.APIClient, jobID string, logger *taggedLogger) (bool, error) {
+	var jobInfo *pps.JobInfo
+	var finished bool
+	if err := backoff.RetryNotify(func() error {
+		var err error
+		jobInfo, err = pachClient.InspectJob(jobID, false)
+		if err != nil {
+			return err
+		}
+		if jobInfo.PipelineVersion != a.pipelineInfo.Version {
+			return nil // the rerun's version of the pipeline has no workers
+		}
 		if err := a.waitJob(pachClient, jobInfo, logger); err != nil {
 			return err
 		}
+		finished = true
+		return nil
+	}, backoff.NewExponentialBackOff(), func(err error, d time.Duration) error {
+		if isDone(pachClient.Ctx()) || col.IsErrNotFound(err) {
+			return err
+		}
+		logger.Logf("error running rerun job %s: %v; retrying in %v", jobID, err, d)
+		return nil
+	}); err != nil {
+		if jobInfo == nil || isDone(pachClient.Ctx()) {
+			return false, err
+		}
+		reason := fmt.Sprintf("rerun failed: %v", err)
+		if err := a.updateJobState(pachClient.Ctx(), jobInfo, nil, pps.JobState_JOB_FAILURE, reason); err != nil {
+			return false, err
+		}
+		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+			Commit: jobInfo.OutputCommit,
+			Empty:  true,
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
 	}
+	return finished, nil
 }
 
 func (a *APIServer) serviceSpawner(pachClient *client.APIClient) error {
@@ -231,6 +428,10 @@ func (a *APIServer) serviceSpawner(pachClient *client.APIClient) error {
 		if err != nil {
 			return err
 		}
+		jobInfo, err := pachClient.InspectJob(job.ID, false)
+		if err != nil {
+			return err
+		}
 		df, err := NewDatumFactory(pachClient, jobInput)
 		if err != nil {
 			return err
@@ -238,8 +439,11 @@ func (a *APIServer) serviceSpawner(pachClient *client.APIClient) error {
 		if df.Len() != 1 {
 			return fmt.Errorf("services must have a single datum")
 		}
-		data := df.Datum(0)
-		logger, err := a.getTaggedLogger(pachClient, job.ID, data, false)
+		data, err := df.Datum(0)
+		if err != nil {
+			return err
+		}
+		logger, err := a.getTaggedLogger(pachClient, job.ID, jobInfo.TraceID, data, false)
 		puller := filesync.NewPuller()
 		// If this is our second time through the loop cleanup the old data.
 		if dir != "" {
@@ -329,7 +533,7 @@ func (a *APIServer) merges(jobID string) col.Collection {
 	return col.NewCollection(a.etcdClient, path.Join(a.etcdPrefix, mergePrefix, jobID), nil, &MergeState{}, nil, nil)
 }
 
-func newPlan(df DatumFactory, spec *pps.ChunkSpec, parallelism int, numHashtrees int64) *Plan {
+func newPlan(df DatumFactory, spec *pps.ChunkSpec, parallelism int, numHashtrees int64) (*Plan, error) {
 	if spec == nil {
 		spec = &pps.ChunkSpec{}
 	}
@@ -347,17 +551,34 @@ func newPlan(df DatumFactory, spec *pps.ChunkSpec, parallelism int, numHashtrees
 	} else {
 		size := int64(0)
 		for i := 0; i < df.Len(); i++ {
-			for _, input := range df.Datum(i) {
-				size += int64(input.FileInfo.SizeBytes)
+			inputs, err := df.Datum(i)
+			if err != nil {
+				return nil, err
+			}
+			for _, input := range inputs {
+				size += inputSize(input)
 			}
 			if size > spec.SizeBytes {
 				plan.Chunks = append(plan.Chunks, int64(i))
 			}
 		}
 	}
+	// Split chunks wherever the datums' priority changes, so that workers
+	// finish the higher priority datums before starting on the rest
+	if boundaries := priorityBoundaries(df); len(boundaries) > 0 {
+		plan.Chunks = append(plan.Chunks, boundaries...)
+		sort.Slice(plan.Chunks, func(i, j int) bool { return plan.Chunks[i] < plan.Chunks[j] })
+		chunks := plan.Chunks[:0]
+		for i, high := range plan.Chunks {
+			if i == 0 || high != plan.Chunks[i-1] {
+				chunks = append(chunks, high)
+			}
+		}
+		plan.Chunks = chunks
+	}
 	plan.Chunks = append(plan.Chunks, int64(df.Len()))
 	plan.Merges = numHashtrees
-	return plan
+	return plan, nil
 }
 
 func (a *APIServer) failedInputs(ctx context.Context, jobInfo *pps.JobInfo) ([]string, error) {
@@ -399,6 +620,7 @@ func (a *APIServer) failedInputs(ctx context.Context, jobInfo *pps.JobInfo) ([]s
 // output from the job's workers and merges it into a commit (and may merge
 // stats into a commit in the stats branch as well)
 func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo, logger *taggedLogger) (retErr error) {
+	logger = logger.jobLogger(jobInfo)
 	logger.Logf("waitJob: %s", jobInfo.Job.ID)
 	ctx, cancel := context.WithCancel(pachClient.Ctx())
 	pachClient = pachClient.WithCtx(ctx)
@@ -479,6 +701,14 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 		defer timer.Stop()
 	}
 
+	// releaseJobSlot frees the job's job slot, once it has one. The slot is
+	// held until the job is done, even if processing it is retried.
+	var releaseJobSlot func()
+	defer func() {
+		if releaseJobSlot != nil {
+			releaseJobSlot()
+		}
+	}()
 	backoff.RetryNotify(func() (retErr error) {
 		// block until job inputs are ready
 		// TODO(bryce) This should be removed because it is no longer applicable.
@@ -498,12 +728,45 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 			return err
 		}
 
+		// Refuse to start jobs with more datums than the pipeline allows. The
+		// datums are counted without holding them in memory, as there may be
+		// too many to.
+		if max := maxJobDatums(a.pipelineInfo); max > 0 {
+			count, err := CountDatums(pachClient, jobInfo.Input)
+			if err != nil {
+				return err
+			}
+			if count > max {
+				reason := tooManyDatumsReason(count, max, jobInfo.Input)
+				if err := a.updateJobState(ctx, jobInfo, nil, pps.JobState_JOB_FAILURE, reason); err != nil {
+					return err
+				}
+				_, err := pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
+					Commit: jobInfo.OutputCommit,
+					Empty:  true,
+				})
+				return err
+			}
+		}
+
+		// Wait for a job slot, if the number of jobs that may run at once
+		// across the cluster is limited
+		if releaseJobSlot == nil {
+			if releaseJobSlot, err = a.waitForJobSlot(ctx, jobInfo, logger); err != nil {
+				return err
+			}
+		}
+
 		// Create a datum factory pointing at the job's inputs and split up the
 		// input data into chunks
 		df, err := NewDatumFactory(pachClient, jobInfo.Input)
 		if err != nil {
 			return err
 		}
+		df, err = newPrioritizedDatumFactory(df, a.pipelineInfo.DatumPriority)
+		if err != nil {
+			return err
+		}
 		parallelism, err := ppsutil.GetExpectedNumWorkers(a.kubeClient, a.pipelineInfo.ParallelismSpec)
 		if err != nil {
 			return fmt.Errorf("error from GetExpectedNumWorkers: %v", err)
@@ -539,6 +802,11 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 			if jobPtr.State == pps.JobState_JOB_KILLED {
 				return nil
 			}
+			if int(jobPtr.Parallelism) > parallelism {
+				// The job runs with more workers than the pipeline has, and its
+				// chunks are planned for that many workers
+				parallelism = int(jobPtr.Parallelism)
+			}
 			jobPtr.DataTotal = int64(df.Len())
 			jobPtr.StatsCommit = statsCommit
 			if err := ppsutil.UpdateJobState(a.pipelines.ReadWrite(stm), a.jobs.ReadWrite(stm), jobPtr, pps.JobState_JOB_RUNNING, ""); err != nil {
@@ -548,7 +816,12 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 			if err := chunksCol.Get(jobID, plan); err == nil {
 				return nil
 			}
-			plan = newPlan(df, jobInfo.ChunkSpec, parallelism, numHashtrees)
+			var err error
+			plan, err = newPlan(df, jobInfo.ChunkSpec, parallelism, numHashtrees)
+			if err != nil {
+				return err
+			}
+			plan.Dispatched = types.TimestampNow()
 			return chunksCol.Put(jobID, plan)
 		}); err != nil {
 			return err
@@ -558,22 +831,17 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 				if _, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
 					chunks := a.chunks(jobID).ReadWrite(stm)
 					chunks.DeleteAll()
+					a.datumCrashes(jobID).ReadWrite(stm).DeleteAll()
 					return nil
 				}); err != nil {
 					retErr = err
 				}
 			}
 		}()
-		// Handle the case when there are no datums
+		// Handle the case when there are no datums, as the pipeline's
+		// empty_input directs
 		if df.Len() == 0 {
-			if err := a.updateJobState(ctx, jobInfo, nil, pps.JobState_JOB_SUCCESS, ""); err != nil {
-				return err
-			}
-			_, err := pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
-				Commit: jobInfo.OutputCommit,
-				Empty:  true,
-			})
-			return err
+			return a.finishEmptyJob(pachClient, jobInfo, plan.Merges, logger)
 		}
 		// Watch the chunks in order
 		chunks := a.chunks(jobInfo.Job.ID).ReadOnly(ctx)
@@ -614,6 +882,11 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 				return err
 			}
 		}
+		if a.pipelineInfo.DeadLetterBranch != "" {
+			if err := a.commitDeadLetters(pachClient, jobInfo); err != nil {
+				return err
+			}
+		}
 		if err := a.updateJobState(ctx, jobInfo, nil, pps.JobState_JOB_MERGING, ""); err != nil {
 			return err
 		}
@@ -658,7 +931,9 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 				}
 			}
 		}
-		if jobInfo.EnableStats {
+		// Reruns' output commits aren't in the output branch, so they have no
+		// stats commit
+		if jobInfo.EnableStats && statsCommit != nil {
 			if _, err = pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
 				Commit:    statsCommit,
 				Trees:     statsTrees,
@@ -675,17 +950,24 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 			if err := a.updateJobState(ctx, jobInfo, statsCommit, pps.JobState_JOB_FAILURE, reason); err != nil {
 				return err
 			}
-			_, err = pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
+			finishRequest := &pfs.FinishCommitRequest{
 				Commit: jobInfo.OutputCommit,
 				Empty:  true,
-			})
+			}
+			if err := a.addFailureSummary(ctx, jobInfo, failedDatumID, finishRequest); err != nil {
+				return err
+			}
+			_, err = pachClient.PfsAPIClient.FinishCommit(ctx, finishRequest)
 			return err
 		}
 		// Write out the datums processed/skipped and merged for this job
 		buf := &bytes.Buffer{}
 		pbw := pbutil.NewWriter(buf)
 		for i := 0; i < df.Len(); i++ {
-			files := df.Datum(i)
+			files, err := df.Datum(i)
+			if err != nil {
+				return err
+			}
 			datumHash := HashDatum(a.pipelineInfo.Pipeline.Name, a.pipelineInfo.Salt, files)
 			if _, err := pbw.WriteBytes([]byte(datumHash)); err != nil {
 				return err
@@ -695,13 +977,51 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 		if err != nil {
 			return err
 		}
-		// Finish the job's output commit
-		_, err = pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
+		finishRequest := &pfs.FinishCommitRequest{
 			Commit:    jobInfo.OutputCommit,
 			Trees:     trees,
 			SizeBytes: size,
 			Datums:    datums,
-		})
+		}
+		if a.pipelineInfo.SkipUnchangedOutput && jobInfo.RerunOf == nil {
+			if err := a.markUnchangedOutput(pachClient, finishRequest); err != nil {
+				return err
+			}
+		}
+		// Validate the output before it reaches the output branch. Reruns'
+		// output commits aren't in the output branch, and unchanged output
+		// was already validated.
+		if a.pipelineInfo.OutputValidation != nil && jobInfo.RerunOf == nil && finishRequest.Labels[client.PPSUnchangedOutputLabel] == "" {
+			reason, staging, err := a.validateOutput(pachClient, logger, jobInfo, finishRequest)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				quarantineRequest, err := quarantineOutput(pachClient, jobInfo, staging)
+				if err != nil {
+					return err
+				}
+				if err := a.updateJobState(ctx, jobInfo, statsCommit, pps.JobState_JOB_FAILURE, reason); err != nil {
+					return err
+				}
+				_, err = pachClient.PfsAPIClient.FinishCommit(ctx, quarantineRequest)
+				return err
+			}
+		}
+		if err := a.addFailureSummary(ctx, jobInfo, "", finishRequest); err != nil {
+			return err
+		}
+		// Finish the job's output commit. Errors that mean the commit can't be
+		// finished are handled below rather than retried.
+		if retryErr := retryUpload(ctx, a.pipelineInfo.UploadTries, logger.Logf, func() error {
+			_, err = pachClient.PfsAPIClient.FinishCommit(ctx, finishRequest)
+			if err != nil && (pfsserver.IsCommitFinishedErr(err) || pfsserver.IsCommitNotFoundErr(err) || pfsserver.IsCommitDeletedErr(err)) {
+				return nil
+			}
+			return err
+		}); retryErr != nil {
+			return retryErr
+		}
 		if err != nil && !pfsserver.IsCommitFinishedErr(err) {
 			if pfsserver.IsCommitNotFoundErr(err) || pfsserver.IsCommitDeletedErr(err) {
 				// output commit was deleted during e.g. FinishCommit, which means this job
@@ -712,7 +1032,11 @@ func (a *APIServer) waitJob(pachClient *client.APIClient, jobInfo *pps.JobInfo,
 			}
 			return err
 		}
-		// Handle egress
+		// Handle egress. Reruns don't egress, so that they don't overwrite
+		// the original job's egressed output.
+		if jobInfo.RerunOf != nil {
+			return a.updateJobState(ctx, jobInfo, statsCommit, pps.JobState_JOB_SUCCESS, "")
+		}
 		if err := a.egress(pachClient, logger, jobInfo); err != nil {
 			reason := fmt.Sprintf("egress error: %v", err)
 			return a.updateJobState(ctx, jobInfo, statsCommit, pps.JobState_JOB_FAILURE, reason)
@@ -820,7 +1144,7 @@ func (a *APIServer) egress(pachClient *client.APIClient, logger *taggedLogger, j
 
 func (a *APIServer) runService(ctx context.Context, logger *taggedLogger) error {
 	return backoff.RetryNotify(func() error {
-		return a.runUserCode(ctx, logger, nil, &pps.ProcessStats{}, nil)
+		return a.runUserCode(ctx, logger, nil, a.pipelineInfo.Transform.WorkingDir, nil, &pps.ProcessStats{}, nil, 0)
 	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
 		select {
 		case <-ctx.Done():
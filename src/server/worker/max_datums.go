@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// maxJobDatums returns the largest number of datums that a job of the
+// pipeline in 'pipelineInfo' may have, or 0 if there's no limit. The
+// pipeline's max_datums overrides the cluster's default (see
+// client.PPSMaxJobDatumsEnv).
+func maxJobDatums(pipelineInfo *pps.PipelineInfo) int64 {
+	return jobLimit(pipelineInfo.MaxDatums, client.PPSMaxJobDatumsEnv)
+}
+
+// jobLimit returns the limit that a pipeline's setting 'value' places on its
+// jobs, or 0 if there's no limit. A positive 'value' is the limit, -1 means
+// there's no limit, and 0 means the cluster's default, which is read from the
+// env var 'env'.
+func jobLimit(value int64, env string) int64 {
+	switch {
+	case value > 0:
+		return value
+	case value < 0:
+		return 0
+	}
+	max, err := strconv.ParseInt(os.Getenv(env), 10, 64)
+	if err != nil || max < 0 {
+		return 0
+	}
+	return max
+}
+
+// tooManyDatumsReason returns the reason that a job whose input 'input' has
+// 'count' datums, more than 'max', fails. Such jobs are usually caused by a
+// glob that divides an input into many more datums than intended (e.g. "/**"
+// instead of "/*"), so the reason points at the inputs' globs.
+func tooManyDatumsReason(count int64, max int64, input *pps.Input) string {
+	var globs []string
+	pps.VisitInput(input, func(input *pps.Input) {
+		switch {
+		case input.Atom != nil:
+			globs = append(globs, fmt.Sprintf("%s: %q", input.Atom.Name, input.Atom.Glob))
+		case input.Pfs != nil:
+			globs = append(globs, fmt.Sprintf("%s: %q", input.Pfs.Name, input.Pfs.Glob))
+		case input.Object != nil:
+			globs = append(globs, fmt.Sprintf("%s: %q", input.Object.Name, input.Object.Glob))
+		}
+	})
+	return fmt.Sprintf("the job has %d datums, which is more than the maximum "+
+		"of %d; check that the globs of its inputs (%s) divide them into as many "+
+		"datums as intended, or raise the pipeline's max_datums",
+		count, max, strings.Join(globs, ", "))
+}
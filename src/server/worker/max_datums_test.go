@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestMaxJobDatums(t *testing.T) {
+	defer os.Unsetenv(client.PPSMaxJobDatumsEnv)
+	require.NoError(t, os.Unsetenv(client.PPSMaxJobDatumsEnv))
+	require.Equal(t, int64(0), maxJobDatums(&pps.PipelineInfo{}))
+	require.Equal(t, int64(10), maxJobDatums(&pps.PipelineInfo{MaxDatums: 10}))
+
+	// The pipeline's max_datums overrides the cluster's default, and -1 removes
+	// the limit
+	require.NoError(t, os.Setenv(client.PPSMaxJobDatumsEnv, "1000"))
+	require.Equal(t, int64(1000), maxJobDatums(&pps.PipelineInfo{}))
+	require.Equal(t, int64(10), maxJobDatums(&pps.PipelineInfo{MaxDatums: 10}))
+	require.Equal(t, int64(5000), maxJobDatums(&pps.PipelineInfo{MaxDatums: 5000}))
+	require.Equal(t, int64(0), maxJobDatums(&pps.PipelineInfo{MaxDatums: -1}))
+}
+
+func TestTooManyDatumsReason(t *testing.T) {
+	input := client.NewCrossInput(
+		client.NewPFSInputOpts("images", "images", "master", "/**", false),
+		client.NewPFSInputOpts("models", "models", "master", "/*", false),
+	)
+	reason := tooManyDatumsReason(2000000, 1000, input)
+	require.True(t, strings.Contains(reason, "2000000 datums"), reason)
+	require.True(t, strings.Contains(reason, "maximum of 1000"), reason)
+	require.True(t, strings.Contains(reason, `images: "/**"`), reason)
+	require.True(t, strings.Contains(reason, `models: "/*"`), reason)
+}
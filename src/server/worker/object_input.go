@@ -0,0 +1,329 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	globlib "github.com/gobwas/glob"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+)
+
+// defaultObjectInputRegion is the region of the buckets of object inputs
+// without a secret, if the workers' environment doesn't specify one
+const defaultObjectInputRegion = "us-east-1"
+
+// newObjectInputClient returns a client of the bucket that the object input
+// with 'url' and 'secret' reads from. It's a variable so that tests can read
+// from a local bucket.
+var newObjectInputClient = func(url string, secret string) (obj.Client, error) {
+	u, err := obj.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	if u.Store != "s3" {
+		return nil, fmt.Errorf("object inputs can only read from s3 buckets, not %s", u.Store)
+	}
+	readSecret := func(key string) (string, error) {
+		if secret == "" {
+			return "", nil
+		}
+		value, err := ioutil.ReadFile(filepath.Join(client.PPSObjectSecretsPath, secret, key))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		return strings.TrimSpace(string(value)), nil
+	}
+	var creds obj.AmazonCreds
+	var region, endpoint string
+	for key, value := range map[string]*string{
+		"region":   &region,
+		"id":       &creds.ID,
+		"secret":   &creds.Secret,
+		"token":    &creds.Token,
+		"endpoint": &endpoint,
+	} {
+		if *value, err = readSecret(key); err != nil {
+			return nil, err
+		}
+	}
+	if endpoint != "" {
+		return obj.NewMinioClient(endpoint, u.Bucket, creds.ID, creds.Secret, true, false)
+	}
+	if region == "" {
+		if secret != "" {
+			return nil, fmt.Errorf("the secret of an s3 object input must specify a region (or an endpoint)")
+		}
+		// Without a secret, the workers' IAM role is used, in the workers' region
+		region = os.Getenv("AWS_REGION")
+		if region == "" {
+			region = defaultObjectInputRegion
+		}
+	}
+	// Objects in an external bucket are stored under their own names
+	return obj.NewAmazonClient(region, u.Bucket, &creds, "", false)
+}
+
+// objectInputPrefix returns the prefix, within its bucket, of the objects
+// that the object input with 'url' reads from ("" for the whole bucket)
+func objectInputPrefix(url string) (string, error) {
+	u, err := obj.ParseURL(url)
+	if err != nil {
+		return "", err
+	}
+	if u.Object == "" {
+		return "", nil
+	}
+	return u.Object + "/", nil
+}
+
+// listObjectInput lists the objects that 'input' reads from, with their
+// names relative to its URL, in sorted order.
+func listObjectInput(input *pps.ObjectInput) ([]*obj.ObjectInfo, error) {
+	c, err := newObjectInputClient(input.URL, input.Secret)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := objectInputPrefix(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	walkInfo := func(prefix string, fn func(info *obj.ObjectInfo) error) error {
+		return c.Walk(prefix, func(name string) error {
+			return fn(&obj.ObjectInfo{Name: name})
+		})
+	}
+	if w, ok := c.(obj.InfoWalker); ok {
+		walkInfo = w.WalkInfo
+	}
+	var objects []*obj.ObjectInfo
+	if err := walkInfo(prefix, func(info *obj.ObjectInfo) error {
+		// Walk matches prefixes of names, not of paths (e.g. "a/b" matches
+		// "a/bc"), which the trailing slash in 'prefix' rules out
+		if strings.HasPrefix(info.Name, prefix) && !strings.HasSuffix(info.Name, "/") {
+			info.Name = strings.TrimPrefix(info.Name, prefix)
+			objects = append(objects, info)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error listing %s: %v", input.URL, err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+	return objects, nil
+}
+
+// objectInputSnapshotTag returns the tag of the listing of 'input' that the
+// datums of the job with the output commit 'snapshot' are made from
+func objectInputSnapshotTag(input *pps.ObjectInput) string {
+	return fmt.Sprintf("object-input-%s-%s", input.Snapshot, input.Name)
+}
+
+// snapshotObjectInput returns the objects of 'input' that its job processes.
+// Objects in an external bucket aren't versioned like files in pfs, so the
+// bucket is listed once per job, when the job's datums are first computed
+// (by its master), and the listing is stored in object storage, so that every
+// worker sees the same datums even if the bucket changes while the job runs.
+// Inputs without a snapshot (e.g. when previewing a pipeline's datums) are
+// listed every time.
+func snapshotObjectInput(pachClient *client.APIClient, input *pps.ObjectInput) ([]*obj.ObjectInfo, error) {
+	if input.Snapshot == "" {
+		return listObjectInput(input)
+	}
+	tag := objectInputSnapshotTag(input)
+	_, err := pachClient.InspectTag(pachClient.Ctx(), client.NewTag(tag))
+	if err == nil {
+		var buf bytes.Buffer
+		if err := pachClient.GetTag(tag, &buf); err != nil {
+			return nil, err
+		}
+		return parseObjectInputSnapshot(&buf)
+	}
+	// Only list the bucket if there's no snapshot yet; any other error could
+	// hide an existing snapshot, which mustn't be replaced
+	if !isNotFoundErr(err) {
+		return nil, err
+	}
+	objects, err := listObjectInput(input)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, object := range objects {
+		fmt.Fprintf(&buf, "%s\t%d\t%s\n", object.ETag, object.Size, object.Name)
+	}
+	if _, _, err := pachClient.PutObject(&buf, tag); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// parseObjectInputSnapshot parses a listing stored by snapshotObjectInput,
+// which has a line with the etag, size and name of each object
+func parseObjectInputSnapshot(r io.Reader) ([]*obj.ObjectInfo, error) {
+	var objects []*obj.ObjectInfo
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed object input snapshot line %q", scanner.Text())
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed object input snapshot line %q: %v", scanner.Text(), err)
+		}
+		objects = append(objects, &obj.ObjectInfo{Name: fields[2], ETag: fields[0], Size: size})
+	}
+	return objects, scanner.Err()
+}
+
+type objectDatumFactory struct {
+	inputs []*Input
+}
+
+func newObjectDatumFactory(pachClient *client.APIClient, input *pps.ObjectInput) (DatumFactory, error) {
+	objects, err := snapshotObjectInput(pachClient, input)
+	if err != nil {
+		return nil, err
+	}
+	return newObjectDatumFactoryFromObjects(input, objects)
+}
+
+// newObjectDatumFactoryFromObjects returns a DatumFactory with a datum for
+// each path matching the glob of 'input', among 'objects' and their parent
+// directories, which includes the objects under that path. Paths match the
+// glob as they would in pfs, so e.g. "/*" makes a datum of each top-level
+// object or directory.
+func newObjectDatumFactoryFromObjects(input *pps.ObjectInput, objects []*obj.ObjectInfo) (DatumFactory, error) {
+	pattern := path.Clean("/" + input.Glob)
+	var g globlib.Glob
+	if hashtree.IsGlob(pattern) {
+		var err error
+		g, err = globlib.Compile(pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("malformed glob %s: %v", input.Glob, err)
+		}
+	}
+	// The root is only matched by a literal "/", as in pfs
+	match := func(p string) bool {
+		return (g == nil && p == pattern) || (g != nil && p != "/" && g.Match(p))
+	}
+	datums := make(map[string][]*obj.ObjectInfo)
+	var roots []string
+	for _, object := range objects {
+		objectPath := path.Clean("/" + object.Name)
+		// Find the shortest path, among the object and its parents, that
+		// matches the glob
+		root := ""
+		if match("/") {
+			root = "/"
+		}
+		for i := 1; root == "" && i <= len(objectPath); i++ {
+			if i == len(objectPath) || objectPath[i] == '/' {
+				if match(objectPath[:i]) {
+					root = objectPath[:i]
+				}
+			}
+		}
+		if root == "" {
+			continue
+		}
+		if _, ok := datums[root]; !ok {
+			roots = append(roots, root)
+		}
+		datums[root] = append(datums[root], object)
+	}
+	sort.Strings(roots)
+	result := &objectDatumFactory{}
+	for _, root := range roots {
+		fileType := pfs.FileType_DIR
+		if objects := datums[root]; len(objects) == 1 && path.Clean("/"+objects[0].Name) == root {
+			fileType = pfs.FileType_FILE
+		}
+		// There's no hash of the objects' content, so the datum is identified
+		// by the objects' names, etags and sizes
+		hash := sha256.New()
+		var names []string
+		for _, object := range datums[root] {
+			fmt.Fprintf(hash, "%s\t%d\t%s\n", object.ETag, object.Size, object.Name)
+			names = append(names, object.Name)
+		}
+		result.inputs = append(result.inputs, &Input{
+			FileInfo: &pfs.FileInfo{
+				File:     client.NewFile(input.Name, input.Snapshot, root),
+				FileType: fileType,
+				Hash:     hash.Sum(nil),
+			},
+			Name:         input.Name,
+			ObjectURL:    input.URL,
+			ObjectSecret: input.Secret,
+			Objects:      names,
+		})
+	}
+	return result, nil
+}
+
+func (d *objectDatumFactory) Len() int {
+	return len(d.inputs)
+}
+
+func (d *objectDatumFactory) Datum(i int) ([]*Input, error) {
+	return []*Input{d.inputs[i]}, nil
+}
+
+// downloadObjectData downloads the objects of the object input 'input' to
+// the input's directory in 'dir'
+func downloadObjectData(dir string, input *Input) error {
+	c, err := newObjectInputClient(input.ObjectURL, input.ObjectSecret)
+	if err != nil {
+		return err
+	}
+	prefix, err := objectInputPrefix(input.ObjectURL)
+	if err != nil {
+		return err
+	}
+	for _, object := range input.Objects {
+		if err := func() (retErr error) {
+			dst := filepath.Join(dir, input.Name, filepath.FromSlash(path.Clean("/"+object)))
+			if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+				return err
+			}
+			r, err := c.Reader(prefix+object, 0, 0)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := r.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+			f, err := os.Create(dst)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := f.Close(); err != nil && retErr == nil {
+					retErr = err
+				}
+			}()
+			_, err = io.Copy(f, r)
+			return err
+		}(); err != nil {
+			return fmt.Errorf("error downloading %s from %s: %v", object, input.ObjectURL, err)
+		}
+	}
+	return nil
+}
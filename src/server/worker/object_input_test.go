@@ -0,0 +1,144 @@
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+)
+
+// withLocalBucket makes object inputs read from a local bucket containing
+// 'objects' (a map from object name to content) until the returned function
+// is called
+func withLocalBucket(t *testing.T, objects map[string]string) func() {
+	root, err := ioutil.TempDir("", "object_input_test")
+	require.NoError(t, err)
+	for name, content := range objects {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0777))
+		require.NoError(t, ioutil.WriteFile(p, []byte(content), 0666))
+	}
+	prevNewObjectInputClient := newObjectInputClient
+	newObjectInputClient = func(url string, secret string) (obj.Client, error) {
+		return obj.NewLocalClient(root)
+	}
+	return func() {
+		newObjectInputClient = prevNewObjectInputClient
+		os.RemoveAll(root)
+	}
+}
+
+// objectDatums returns the path, type and objects of each datum of 'input'
+func objectDatums(t *testing.T, input *pps.ObjectInput) ([]string, []pfs.FileType, [][]string) {
+	df, err := newObjectDatumFactory(nil, input)
+	require.NoError(t, err)
+	var paths []string
+	var fileTypes []pfs.FileType
+	var objects [][]string
+	for i := 0; i < df.Len(); i++ {
+		inputs := datum(t, df, i)
+		require.Equal(t, 1, len(inputs))
+		require.Equal(t, input.Name, inputs[0].Name)
+		require.Equal(t, input.URL, inputs[0].ObjectURL)
+		paths = append(paths, inputs[0].FileInfo.File.Path)
+		fileTypes = append(fileTypes, inputs[0].FileInfo.FileType)
+		objects = append(objects, inputs[0].Objects)
+	}
+	return paths, fileTypes, objects
+}
+
+func TestObjectDatumFactory(t *testing.T) {
+	defer withLocalBucket(t, map[string]string{
+		"data/a.txt":   "a",
+		"data/b/1.txt": "1",
+		"data/b/2.txt": "2",
+		"database/c":   "c",
+		"other/d":      "d",
+	})()
+	input := &pps.ObjectInput{Name: "in", URL: "s3://bucket/data", Glob: "/*"}
+
+	// Each top-level object or directory under the URL is a datum
+	paths, fileTypes, objects := objectDatums(t, input)
+	require.Equal(t, []string{"/a.txt", "/b"}, paths)
+	require.Equal(t, []pfs.FileType{pfs.FileType_FILE, pfs.FileType_DIR}, fileTypes)
+	require.Equal(t, [][]string{{"a.txt"}, {"b/1.txt", "b/2.txt"}}, objects)
+
+	input.Glob = "/b/*"
+	paths, _, objects = objectDatums(t, input)
+	require.Equal(t, []string{"/b/1.txt", "/b/2.txt"}, paths)
+	require.Equal(t, [][]string{{"b/1.txt"}, {"b/2.txt"}}, objects)
+
+	// "/" makes a single datum of every object
+	input.Glob = "/"
+	paths, fileTypes, objects = objectDatums(t, input)
+	require.Equal(t, []string{"/"}, paths)
+	require.Equal(t, []pfs.FileType{pfs.FileType_DIR}, fileTypes)
+	require.Equal(t, [][]string{{"a.txt", "b/1.txt", "b/2.txt"}}, objects)
+
+	// Without a prefix, the whole bucket is read
+	input.URL, input.Glob = "s3://bucket", "/*"
+	paths, _, _ = objectDatums(t, input)
+	require.Equal(t, []string{"/data", "/database", "/other"}, paths)
+
+	input.URL, input.Glob = "s3://bucket/nothing", "/*"
+	paths, _, _ = objectDatums(t, input)
+	require.Equal(t, 0, len(paths))
+}
+
+func TestObjectDatumID(t *testing.T) {
+	input := &pps.ObjectInput{Name: "in", URL: "s3://bucket/data", Glob: "/"}
+	datumID := func(objects ...*obj.ObjectInfo) string {
+		df, err := newObjectDatumFactoryFromObjects(input, objects)
+		require.NoError(t, err)
+		require.Equal(t, 1, df.Len())
+		return (&APIServer{}).DatumID(datum(t, df, 0))
+	}
+	a := &obj.ObjectInfo{Name: "a", ETag: "1", Size: 1}
+	b := &obj.ObjectInfo{Name: "b", ETag: "2", Size: 2}
+	// A datum is identified by the names, etags and sizes of its objects
+	require.Equal(t, datumID(a, b), datumID(a, b))
+	require.NotEqual(t, datumID(a, b), datumID(a, b, &obj.ObjectInfo{Name: "c"}))
+	require.NotEqual(t, datumID(a, b), datumID(&obj.ObjectInfo{Name: "ab", ETag: "1", Size: 1}))
+	require.NotEqual(t, datumID(a, b), datumID(a, &obj.ObjectInfo{Name: "b", ETag: "3", Size: 2}))
+	require.NotEqual(t, datumID(a, b), datumID(a, &obj.ObjectInfo{Name: "b", ETag: "2", Size: 3}))
+}
+
+func TestParseObjectInputSnapshot(t *testing.T) {
+	objects, err := parseObjectInputSnapshot(strings.NewReader("\"e1\"\t1\ta\n\t0\tb/c d\n"))
+	require.NoError(t, err)
+	require.Equal(t, []*obj.ObjectInfo{
+		{Name: "a", ETag: `"e1"`, Size: 1},
+		{Name: "b/c d", ETag: "", Size: 0},
+	}, objects)
+	_, err = parseObjectInputSnapshot(strings.NewReader("a\n"))
+	require.YesError(t, err)
+}
+
+func TestDownloadObjectData(t *testing.T) {
+	defer withLocalBucket(t, map[string]string{
+		"data/a.txt":   "a",
+		"data/b/1.txt": "1",
+		"data/b/2.txt": "2",
+	})()
+	df, err := newObjectDatumFactory(nil, &pps.ObjectInput{Name: "in", URL: "s3://bucket/data", Glob: "/*"})
+	require.NoError(t, err)
+	require.Equal(t, 2, df.Len())
+
+	dir, err := ioutil.TempDir("", "object_input_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, downloadObjectData(dir, datum(t, df, 1)[0]))
+	for name, content := range map[string]string{"b/1.txt": "1", "b/2.txt": "2"} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "in", name))
+		require.NoError(t, err)
+		require.Equal(t, content, string(data))
+	}
+	_, err = os.Stat(filepath.Join(dir, "in", "a.txt"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,316 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	units "github.com/docker/go-units"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+const (
+	// defaultOOMRetryMultiplier is how much more memory each attempt of a
+	// datum that ran out of memory may use, if OOMRetry.Multiplier is unset
+	defaultOOMRetryMultiplier = 2
+	// memoryCheckInterval is how often the memory used by user code is
+	// checked against its limit
+	memoryCheckInterval = 100 * time.Millisecond
+)
+
+// oomKillCounters are the files, in the order they're tried, that report how
+// many processes the kernel's OOM killer has killed in the worker's cgroup:
+// memory.events under cgroup v2, and memory.oom_control under cgroup v1
+// (since Linux 4.13). Both have an "oom_kill <count>" line.
+var oomKillCounters = []string{
+	"/sys/fs/cgroup/memory.events",
+	"/sys/fs/cgroup/memory/memory.oom_control",
+}
+
+// oomError is returned by runUserCode when the user code was killed for
+// running out of memory, either by the worker (because it exceeded its
+// datum's limit) or by the kernel's OOM killer
+type oomError struct {
+	limit int64
+}
+
+func (e *oomError) Error() string {
+	if e.limit <= 0 {
+		return "user code was killed for running out of memory"
+	}
+	return fmt.Sprintf("user code was killed for running out of memory (limit %s)", units.BytesSize(float64(e.limit)))
+}
+
+// isOOMError returns true if 'err' is an oomError
+func isOOMError(err error) bool {
+	_, ok := err.(*oomError)
+	return ok
+}
+
+// datumMemory is the memory limit of the attempts of a datum
+type datumMemory struct {
+	limit      int64
+	max        int64
+	multiplier float64
+}
+
+// newDatumMemory returns the memory limit of the first attempt of a datum of
+// a pipeline with 'oomRetry'. If 'oomRetry' is nil, datums' memory isn't
+// limited.
+func newDatumMemory(oomRetry *pps.OOMRetry) (*datumMemory, error) {
+	if oomRetry == nil {
+		return &datumMemory{}, nil
+	}
+	limit, err := units.RAMInBytes(oomRetry.Memory)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse memory: %v", err)
+	}
+	max := limit
+	if oomRetry.MaxMemory != "" {
+		if max, err = units.RAMInBytes(oomRetry.MaxMemory); err != nil {
+			return nil, fmt.Errorf("could not parse max_memory: %v", err)
+		}
+	}
+	multiplier := oomRetry.Multiplier
+	if multiplier == 0 {
+		multiplier = defaultOOMRetryMultiplier
+	}
+	return &datumMemory{limit: limit, max: max, multiplier: multiplier}, nil
+}
+
+// failed updates the memory limit for the attempt after one that failed with
+// 'err': if it ran out of memory, the limit is raised (up to the max), and
+// otherwise it's unchanged. It returns true if the limit was raised.
+func (m *datumMemory) failed(err error) bool {
+	if m.limit <= 0 || !isOOMError(err) || m.limit >= m.max {
+		return false
+	}
+	limit := int64(float64(m.limit) * m.multiplier)
+	if limit > m.max {
+		limit = m.max
+	}
+	m.limit = limit
+	return true
+}
+
+// retryDatum calls 'attempt' with the memory limit of each attempt at a
+// datum, until an attempt succeeds, 'tries' attempts have failed, the datum is
+// quarantined, or 'ctx' is done. Each time an attempt runs out of memory, the
+// next one's limit is raised (see datumMemory.failed). It returns the last
+// attempt's error.
+func retryDatum(ctx context.Context, tries int64, memory *datumMemory, logf func(string, ...interface{}), attempt func(memoryLimit int64) error) error {
+	var failures int64
+	return backoff.RetryNotify(func() error {
+		if isDone(ctx) {
+			return ctx.Err() // timeout or cancelled job--don't run datum
+		}
+		return attempt(memory.limit)
+	}, &backoff.ZeroBackOff{}, func(err error, d time.Duration) error {
+		if isDone(ctx) {
+			return ctx.Err() // timeout or cancelled job, err out and don't retry
+		}
+		if err == errDatumQuarantined {
+			return err
+		}
+		failures++
+		if failures >= tries {
+			return err
+		}
+		logf("failed processing datum: %v, retrying in %v", err, d)
+		if memory.failed(err) {
+			logf("retrying datum with a memory limit of %s", units.BytesSize(float64(memory.limit)))
+		}
+		return nil
+	})
+}
+
+// ValidateOOMRetry returns an error if 'oomRetry' is malformed, or if its
+// max_memory doesn't fit in the memory limit of the pipeline's workers,
+// 'resourceLimits' (in which case the kernel would kill the user code before
+// it reached max_memory). A nil oomRetry is valid.
+func ValidateOOMRetry(oomRetry *pps.OOMRetry, resourceLimits *pps.ResourceSpec) error {
+	if oomRetry == nil {
+		return nil
+	}
+	if oomRetry.Memory == "" {
+		return fmt.Errorf("memory must be set")
+	}
+	m, err := newDatumMemory(oomRetry)
+	if err != nil {
+		return err
+	}
+	if m.limit <= 0 {
+		return fmt.Errorf("memory must be positive")
+	}
+	if m.max < m.limit {
+		return fmt.Errorf("max_memory must be at least memory")
+	}
+	if m.multiplier <= 1 {
+		return fmt.Errorf("multiplier must be greater than 1")
+	}
+	if resourceLimits != nil && resourceLimits.Memory != "" {
+		memoryLimit, err := resource.ParseQuantity(resourceLimits.Memory)
+		if err != nil {
+			return fmt.Errorf("could not parse resource_limits.memory: %v", err)
+		}
+		if m.max > memoryLimit.Value() {
+			return fmt.Errorf("max_memory (%s) must not be greater than resource_limits.memory (%s)", units.BytesSize(float64(m.max)), resourceLimits.Memory)
+		}
+	}
+	return nil
+}
+
+// killedWithSIGKILL returns true if the process that exited with 'state' was
+// killed with SIGKILL. Shells (e.g. a "bash -c" wrapper around the user's
+// program) report a child killed with SIGKILL by exiting with status
+// 128+SIGKILL, so that counts too. A process may be killed with SIGKILL for
+// reasons other than running out of memory, so this alone doesn't make a
+// failure an OOM (see oomKills).
+func killedWithSIGKILL(state *os.ProcessState) bool {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok {
+		return false
+	}
+	return (status.Signaled() && status.Signal() == syscall.SIGKILL) ||
+		(status.Exited() && status.ExitStatus() == 128+int(syscall.SIGKILL))
+}
+
+// oomKills returns how many processes the kernel's OOM killer has killed in
+// the worker's cgroup (see oomKillCounters). If the count is increased by a
+// run of the user code that was killed with SIGKILL, the kernel killed it for
+// running out of memory. It returns false if the count isn't available, in
+// which case only the worker's own kills are known to be OOMs.
+func oomKills() (int64, bool) {
+	for _, counter := range oomKillCounters {
+		data, err := ioutil.ReadFile(counter)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 || fields[0] != "oom_kill" {
+				continue
+			}
+			if count, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				return count, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// killedByOOMKiller returns true if the process that exited with 'state' was
+// killed by the kernel's OOM killer, where 'killsBefore' and 'counted' are
+// what oomKills returned before the process started. The count is the whole
+// cgroup's, so if several datums run at once and one is killed, another that
+// was killed with SIGKILL for a different reason at the same time may be
+// taken for an OOM too.
+func killedByOOMKiller(state *os.ProcessState, killsBefore int64, counted bool) bool {
+	if !counted || !killedWithSIGKILL(state) {
+		return false
+	}
+	killsAfter, ok := oomKills()
+	return ok && killsAfter > killsBefore
+}
+
+// limitMemory kills 'process' and its process group if it and its
+// descendants use more than 'limit' bytes of memory, until 'ctx' is done.
+// This is a soft cap on their resident memory (RSS), not a cgroup limit: it's
+// sampled every memoryCheckInterval, so the processes may briefly exceed it
+// between samples, and memory that isn't resident (e.g. the page cache or
+// swap) isn't counted.
+// 'process' must lead its own process group (see syscall.SysProcAttr.Setpgid)
+// so that its descendants, which may be what's using the memory, are killed
+// with it. It returns a function that stops it, which returns true if the
+// process was killed.
+func limitMemory(ctx context.Context, process *os.Process, limit int64) func() bool {
+	ctx, cancel := context.WithCancel(ctx)
+	killed := make(chan bool, 1)
+	go func() {
+		ticker := time.NewTicker(memoryCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// The process may exit (and its memory not be found) at any
+				// time, so errors are ignored
+				if used, err := processTreeMemory(process.Pid); err == nil && used > limit {
+					if syscall.Kill(-process.Pid, syscall.SIGKILL) == nil {
+						killed <- true
+						return
+					}
+				}
+			case <-ctx.Done():
+				killed <- false
+				return
+			}
+		}
+	}()
+	return func() bool {
+		cancel()
+		return <-killed
+	}
+}
+
+// processTreeMemory returns the resident memory, in bytes, of the process
+// 'pid' and its descendants
+func processTreeMemory(pid int) (int64, error) {
+	children := make(map[int][]int)
+	stats, err := filepath.Glob("/proc/[0-9]*/stat")
+	if err != nil {
+		return 0, err
+	}
+	for _, stat := range stats {
+		data, err := ioutil.ReadFile(stat)
+		if err != nil {
+			continue // the process exited
+		}
+		// The fields after the command (which may contain spaces) are
+		// "state ppid ..."
+		fields := strings.Fields(string(data[strings.LastIndexByte(string(data), ')')+1:]))
+		if len(fields) < 2 {
+			continue
+		}
+		child, err := strconv.Atoi(filepath.Base(filepath.Dir(stat)))
+		if err != nil {
+			continue
+		}
+		parent, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		children[parent] = append(children[parent], child)
+	}
+	var total int64
+	queue := []int{pid}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = append(queue[1:], children[p]...)
+		data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/statm", p))
+		if err != nil {
+			if p == pid {
+				return 0, err
+			}
+			continue
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) < 2 {
+			continue
+		}
+		pages, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		total += pages * int64(os.Getpagesize())
+	}
+	return total, nil
+}
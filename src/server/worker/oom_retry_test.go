@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+func TestDatumMemory(t *testing.T) {
+	memory, err := newDatumMemory(&pps.OOMRetry{Memory: "1M", MaxMemory: "3M"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<20), memory.limit)
+
+	// A generic failure is retried with the same limit
+	require.False(t, memory.failed(fmt.Errorf("exit status 1")))
+	require.Equal(t, int64(1<<20), memory.limit)
+
+	// Running out of memory raises the limit, up to the max
+	require.True(t, memory.failed(&oomError{limit: memory.limit}))
+	require.Equal(t, int64(2<<20), memory.limit)
+	require.True(t, memory.failed(&oomError{limit: memory.limit}))
+	require.Equal(t, int64(3<<20), memory.limit)
+	require.False(t, memory.failed(&oomError{limit: memory.limit}))
+	require.Equal(t, int64(3<<20), memory.limit)
+
+	// Without oom_retry, memory isn't limited
+	memory, err = newDatumMemory(nil)
+	require.NoError(t, err)
+	require.False(t, memory.failed(&oomError{}))
+	require.Equal(t, int64(0), memory.limit)
+}
+
+func TestValidateOOMRetry(t *testing.T) {
+	require.NoError(t, ValidateOOMRetry(nil, nil))
+	require.NoError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G"}, nil))
+	require.NoError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G", Multiplier: 1.5, MaxMemory: "4G"}, nil))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{}, nil))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "lots"}, nil))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "2G", MaxMemory: "1G"}, nil))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G", Multiplier: 0.5}, nil))
+
+	// max_memory must fit in the workers' memory limit
+	require.NoError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G", MaxMemory: "4G"}, &pps.ResourceSpec{Memory: "4Gi"}))
+	require.NoError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G"}, &pps.ResourceSpec{Cpu: 1}))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G", MaxMemory: "4G"}, &pps.ResourceSpec{Memory: "2Gi"}))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G"}, &pps.ResourceSpec{Memory: "1G"}))
+	require.YesError(t, ValidateOOMRetry(&pps.OOMRetry{Memory: "1G"}, &pps.ResourceSpec{Memory: "lots"}))
+}
+
+func TestRetryDatum(t *testing.T) {
+	logf := func(string, ...interface{}) {}
+	oom := func(limit int64) error { return &oomError{limit: limit} }
+
+	// Each attempt that runs out of memory is retried with a higher limit, up
+	// to the max, and other failures are retried with the same limit
+	memory, err := newDatumMemory(&pps.OOMRetry{Memory: "1M", MaxMemory: "3M"})
+	require.NoError(t, err)
+	var limits []int64
+	require.NoError(t, retryDatum(context.Background(), 5, memory, logf, func(limit int64) error {
+		limits = append(limits, limit)
+		switch len(limits) {
+		case 1, 3:
+			return oom(limit)
+		case 2:
+			return fmt.Errorf("exit status 1")
+		}
+		return nil
+	}))
+	require.Equal(t, []int64{1 << 20, 2 << 20, 2 << 20, 3 << 20}, limits)
+
+	// A datum that keeps running out of memory fails after 'tries' attempts
+	memory, err = newDatumMemory(&pps.OOMRetry{Memory: "1M", MaxMemory: "2M"})
+	require.NoError(t, err)
+	limits = nil
+	require.True(t, isOOMError(retryDatum(context.Background(), 3, memory, logf, func(limit int64) error {
+		limits = append(limits, limit)
+		return oom(limit)
+	})))
+	require.Equal(t, []int64{1 << 20, 2 << 20, 2 << 20}, limits)
+
+	// Quarantined datums aren't retried
+	limits = nil
+	require.Equal(t, errDatumQuarantined, retryDatum(context.Background(), 3, memory, logf, func(limit int64) error {
+		limits = append(limits, limit)
+		return errDatumQuarantined
+	}))
+	require.Equal(t, 1, len(limits))
+
+	// Nor are datums whose job is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	limits = nil
+	require.Equal(t, context.Canceled, retryDatum(ctx, 3, memory, logf, func(limit int64) error {
+		limits = append(limits, limit)
+		cancel()
+		return oom(limit)
+	}))
+	require.Equal(t, 1, len(limits))
+}
+
+func TestLimitMemory(t *testing.T) {
+	// A process that uses more than its limit is killed, along with its
+	// descendants
+	cmd := exec.Command("sh", "-c", "sleep 1000 & echo $!; wait")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	var child int
+	_, err = fmt.Fscan(stdout, &child)
+	require.NoError(t, err)
+	stop := limitMemory(context.Background(), cmd.Process, 1)
+	cmd.Wait()
+	require.True(t, stop())
+	require.True(t, killedWithSIGKILL(cmd.ProcessState))
+	require.NoError(t, backoff.Retry(func() error {
+		// The child is gone, or is a zombie that hasn't been reaped yet
+		stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", child))
+		if err == nil && !strings.Contains(string(stat), ") Z ") {
+			return fmt.Errorf("child %d is still running", child)
+		}
+		return nil
+	}, backoff.NewTestingBackOff()))
+
+	// A process within its limit isn't
+	cmd = exec.Command("sleep", "0.5")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	require.NoError(t, cmd.Start())
+	stop = limitMemory(context.Background(), cmd.Process, 1<<40)
+	require.NoError(t, cmd.Wait())
+	require.False(t, stop())
+}
+
+func TestKilledWithSIGKILL(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "kill -9 $$")
+	require.YesError(t, cmd.Run())
+	require.True(t, killedWithSIGKILL(cmd.ProcessState))
+
+	// Shells exit with 128+SIGKILL when their child is killed with SIGKILL
+	cmd = exec.Command("sh", "-c", "sh -c 'kill -9 $$'; exit $?")
+	require.YesError(t, cmd.Run())
+	require.True(t, killedWithSIGKILL(cmd.ProcessState))
+
+	cmd = exec.Command("sh", "-c", "exit 1")
+	require.YesError(t, cmd.Run())
+	require.False(t, killedWithSIGKILL(cmd.ProcessState))
+}
+
+func TestKilledByOOMKiller(t *testing.T) {
+	counter, err := ioutil.TempFile("", "memory.events")
+	require.NoError(t, err)
+	defer os.Remove(counter.Name())
+	require.NoError(t, counter.Close())
+	defer func(counters []string) { oomKillCounters = counters }(oomKillCounters)
+	oomKillCounters = []string{counter.Name()}
+	setKills := func(kills int) {
+		require.NoError(t, ioutil.WriteFile(counter.Name(), []byte(fmt.Sprintf("low 0\nhigh 0\nmax 3\noom 1\noom_kill %d\n", kills)), 0644))
+	}
+	killed := exec.Command("sh", "-c", "kill -9 $$")
+	require.YesError(t, killed.Run())
+	failed := exec.Command("sh", "-c", "exit 1")
+	require.YesError(t, failed.Run())
+
+	// A process killed with SIGKILL was killed by the OOM killer only if the
+	// OOM kill count rose while it ran
+	setKills(1)
+	before, counted := oomKills()
+	require.True(t, counted)
+	require.Equal(t, int64(1), before)
+	require.False(t, killedByOOMKiller(killed.ProcessState, before, counted))
+	setKills(2)
+	require.True(t, killedByOOMKiller(killed.ProcessState, before, counted))
+	require.False(t, killedByOOMKiller(failed.ProcessState, before, counted))
+
+	// Without an OOM kill count, no kill is taken for the OOM killer's
+	require.NoError(t, os.Remove(counter.Name()))
+	_, counted = oomKills()
+	require.False(t, counted)
+	require.False(t, killedByOOMKiller(killed.ProcessState, before, counted))
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+)
+
+// datumInputPath returns the paths of the files in 'data', the inputs of a
+// datum, which pipelines that set ordered_merge merge their datums' output
+// in the order of
+func datumInputPath(data []*Input) string {
+	var paths []string
+	for _, input := range data {
+		paths = append(paths, input.FileInfo.File.Path)
+	}
+	// "\x00" sorts before any character in a path, so datums are ordered by
+	// their first input's path, then their second's, and so on
+	return strings.Join(paths, "\x00")
+}
+
+// sortTagsByInputPath sorts 'tags', the tags of datums' output hashtrees, by
+// 'inputPaths', the input paths (see datumInputPath) of the same datums
+func sortTagsByInputPath(tags []*pfs.Tag, inputPaths []string) {
+	sort.Sort(&tagsByInputPath{tags: tags, inputPaths: inputPaths})
+}
+
+type tagsByInputPath struct {
+	tags       []*pfs.Tag
+	inputPaths []string
+}
+
+func (t *tagsByInputPath) Len() int {
+	return len(t.tags)
+}
+
+func (t *tagsByInputPath) Less(i, j int) bool {
+	return t.inputPaths[i] < t.inputPaths[j]
+}
+
+func (t *tagsByInputPath) Swap(i, j int) {
+	t.tags[i], t.tags[j] = t.tags[j], t.tags[i]
+	t.inputPaths[i], t.inputPaths[j] = t.inputPaths[j], t.inputPaths[i]
+}
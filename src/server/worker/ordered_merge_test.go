@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+// mergeInOrder merges the output of the datums in 'data', processed in
+// that order, as a pipeline that sets ordered_merge does. Each datum writes
+// its input path to the same output file.
+func mergeInOrder(t *testing.T, data [][]*Input) []byte {
+	trees := make(map[string]*bytes.Buffer)
+	var tags []*pfs.Tag
+	var inputPaths []string
+	for _, datum := range data {
+		inputPath := datumInputPath(datum)
+		o := hashtree.NewOrdered("")
+		o.PutFile("/out", []byte(inputPath), 1, &hashtree.FileNodeProto{
+			BlockRefs: []*pfs.BlockRef{{Block: &pfs.Block{Hash: inputPath}}},
+		})
+		buf := &bytes.Buffer{}
+		require.NoError(t, o.Serialize(buf))
+		trees[inputPath] = buf
+		tags = append(tags, client.NewTag(inputPath))
+		inputPaths = append(inputPaths, inputPath)
+	}
+	sortTagsByInputPath(tags, inputPaths)
+	var rs []*hashtree.Reader
+	for _, tag := range tags {
+		rs = append(rs, hashtree.NewReader(trees[tag.Name], nil))
+	}
+	out := &bytes.Buffer{}
+	_, err := hashtree.Merge(hashtree.NewWriter(out), rs)
+	require.NoError(t, err)
+	return out.Bytes()
+}
+
+func TestOrderedMerge(t *testing.T) {
+	datum := func(paths ...string) []*Input {
+		var data []*Input
+		for i, path := range paths {
+			data = append(data, partitionInput(fmt.Sprintf("in%d", i), path))
+		}
+		return data
+	}
+	first := mergeInOrder(t, [][]*Input{
+		datum("/b", "/x"),
+		datum("/a", "/y"),
+		datum("/a/b", "/x"),
+		datum("/a", "/x"),
+	})
+	second := mergeInOrder(t, [][]*Input{
+		datum("/a", "/x"),
+		datum("/a/b", "/x"),
+		datum("/b", "/x"),
+		datum("/a", "/y"),
+	})
+	require.Equal(t, first, second)
+
+	tags := []*pfs.Tag{client.NewTag("3"), client.NewTag("1"), client.NewTag("2")}
+	sortTagsByInputPath(tags, []string{
+		datumInputPath(datum("/b", "/x")),
+		datumInputPath(datum("/a", "/y")),
+		datumInputPath(datum("/a/b", "/x")),
+	})
+	require.Equal(t, []*pfs.Tag{client.NewTag("1"), client.NewTag("2"), client.NewTag("3")}, tags)
+}
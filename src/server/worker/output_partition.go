@@ -0,0 +1,139 @@
+package worker
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// compileOutputPartition compiles the pattern of 'partition', and checks that
+// it has at least one named capture group
+func compileOutputPartition(partition *pps.OutputPartition) (*regexp.Regexp, error) {
+	if partition.Pattern == "" {
+		return nil, fmt.Errorf("pattern must be set")
+	}
+	re, err := regexp.Compile(partition.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("could not compile pattern %q: %v", partition.Pattern, err)
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return re, nil
+		}
+	}
+	return nil, fmt.Errorf("pattern %q has no named capture groups (e.g. \"(?P<date>[^/]*)\")", partition.Pattern)
+}
+
+// leafInputName returns the name of 'input' if it's a leaf (i.e. not a cross
+// or union) input, and "" otherwise
+func leafInputName(input *pps.Input) string {
+	switch {
+	case input.Cross != nil || input.Union != nil:
+		return ""
+	case input.Cron != nil:
+		return input.Cron.Name
+	case input.Git != nil:
+		return input.Git.Name
+	}
+	return pps.InputName(input)
+}
+
+// ValidateOutputPartition returns an error if 'partition', the output
+// partition of a pipeline with the input 'input', is malformed.
+func ValidateOutputPartition(partition *pps.OutputPartition, input *pps.Input) error {
+	if partition == nil {
+		return nil
+	}
+	if _, err := compileOutputPartition(partition); err != nil {
+		return err
+	}
+	if partition.Unmatched != "" && path.Clean(path.Join("/", partition.Unmatched)) == "/" {
+		return fmt.Errorf("unmatched must be a directory other than /")
+	}
+	if input == nil {
+		return fmt.Errorf("pipelines without inputs can't have an output partition")
+	}
+	return validatePathInput(partition.Input, input)
+}
+
+// validatePathInput returns an error if 'inputName', the name of the input
+// whose path is matched against a pattern for each datum of a pipeline with
+// the input 'input', doesn't identify one of the datum's inputs. It may be
+// empty if the pipeline's datums only have one input.
+func validatePathInput(inputName string, input *pps.Input) error {
+	found, crossed := false, false
+	pps.VisitInput(input, func(input *pps.Input) {
+		if input.Cross != nil {
+			crossed = true
+		}
+		if inputName != "" && leafInputName(input) == inputName {
+			found = true
+		}
+	})
+	if inputName == "" && crossed {
+		return fmt.Errorf("input must be set, as the pipeline's datums have more than one input")
+	}
+	if inputName != "" && !found {
+		return fmt.Errorf("the pipeline has no input named %q", inputName)
+	}
+	return nil
+}
+
+// pathInput returns the input in 'data' named 'inputName', or the only input
+// in 'data' if 'inputName' is empty
+func pathInput(inputName string, data []*Input) (*Input, error) {
+	var input *Input
+	for _, i := range data {
+		if inputName == "" || i.Name == inputName {
+			input = i
+			break
+		}
+	}
+	if input == nil || (inputName == "" && len(data) > 1) {
+		return nil, fmt.Errorf("could not find the datum's %q input", inputName)
+	}
+	return input, nil
+}
+
+// outputPartitionDir returns the directory that the output of the datum with
+// the inputs 'data' is written under, according to 'partition'. If
+// 'partition' is nil, it's "/".
+func outputPartitionDir(partition *pps.OutputPartition, data []*Input) (string, error) {
+	if partition == nil {
+		return "/", nil
+	}
+	re, err := compileOutputPartition(partition)
+	if err != nil {
+		return "", err
+	}
+	input, err := pathInput(partition.Input, data)
+	if err != nil {
+		return "", fmt.Errorf("%v to partition its output by", err)
+	}
+	inputPath := input.FileInfo.File.Path
+	if match := re.FindStringSubmatch(inputPath); match != nil {
+		var dirs []string
+		for i, name := range re.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			// Values that are empty or contain slashes can't be partition
+			// directory names, so those paths are treated as unmatched
+			if match[i] == "" || strings.Contains(match[i], "/") {
+				dirs = nil
+				break
+			}
+			dirs = append(dirs, name+"="+match[i])
+		}
+		if len(dirs) > 0 {
+			return path.Join(append([]string{"/"}, dirs...)...), nil
+		}
+	}
+	if partition.Unmatched == "" {
+		return "", fmt.Errorf("input path %s doesn't match the output partition pattern %q", inputPath, partition.Pattern)
+	}
+	return path.Join("/", partition.Unmatched), nil
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func partitionInput(name string, path string) *Input {
+	return &Input{
+		Name: name,
+		FileInfo: &pfs.FileInfo{
+			File: client.NewFile(name, "master", path),
+		},
+	}
+}
+
+func TestOutputPartitionDir(t *testing.T) {
+	partition := &pps.OutputPartition{
+		Input:   "logs",
+		Pattern: `^/(?P<year>[0-9]{4})-(?P<month>[0-9]{2})-`,
+	}
+	data := []*Input{
+		partitionInput("other", "/2000-01-01.csv"),
+		partitionInput("logs", "/2019-03-01.csv"),
+	}
+	dir, err := outputPartitionDir(partition, data)
+	require.NoError(t, err)
+	require.Equal(t, "/year=2019/month=03", dir)
+
+	// Datums that don't match fail, unless 'unmatched' is set
+	data[1] = partitionInput("logs", "/summary.csv")
+	_, err = outputPartitionDir(partition, data)
+	require.YesError(t, err)
+	partition.Unmatched = "__unpartitioned__"
+	dir, err = outputPartitionDir(partition, data)
+	require.NoError(t, err)
+	require.Equal(t, "/__unpartitioned__", dir)
+
+	// Values that can't be directory names are unmatched
+	partition = &pps.OutputPartition{Pattern: `^/(?P<dir>.*)/`, Unmatched: "other"}
+	dir, err = outputPartitionDir(partition, []*Input{partitionInput("in", "/a/b/c")})
+	require.NoError(t, err)
+	require.Equal(t, "/other", dir)
+	dir, err = outputPartitionDir(partition, []*Input{partitionInput("in", "/a/c")})
+	require.NoError(t, err)
+	require.Equal(t, "/dir=a", dir)
+
+	// Output isn't partitioned without an output partition
+	dir, err = outputPartitionDir(nil, data)
+	require.NoError(t, err)
+	require.Equal(t, "/", dir)
+}
+
+func TestValidateOutputPartition(t *testing.T) {
+	input := client.NewCrossInput(
+		client.NewPFSInputOpts("logs", "logs", "master", "/*", false),
+		client.NewPFSInputOpts("other", "other", "master", "/*", false),
+	)
+	require.NoError(t, ValidateOutputPartition(&pps.OutputPartition{Input: "logs", Pattern: "(?P<a>.*)"}, input))
+	require.YesError(t, ValidateOutputPartition(&pps.OutputPartition{Input: "logs", Pattern: "(.*)"}, input))
+	require.YesError(t, ValidateOutputPartition(&pps.OutputPartition{Input: "logs", Pattern: "(?P<a>.*"}, input))
+	require.YesError(t, ValidateOutputPartition(&pps.OutputPartition{Input: "missing", Pattern: "(?P<a>.*)"}, input))
+	// The input must be named if datums have more than one
+	require.YesError(t, ValidateOutputPartition(&pps.OutputPartition{Pattern: "(?P<a>.*)"}, input))
+	require.NoError(t, ValidateOutputPartition(&pps.OutputPartition{Pattern: "(?P<a>.*)"}, client.NewPFSInputOpts("logs", "logs", "master", "/*", false)))
+	require.YesError(t, ValidateOutputPartition(&pps.OutputPartition{Input: "logs", Pattern: "(?P<a>.*)", Unmatched: "/"}, input))
+}
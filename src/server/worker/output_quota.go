@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+)
+
+// maxJobOutputBytes returns the largest number of bytes of output that a job
+// of the pipeline in 'pipelineInfo' may write, or 0 if there's no limit. The
+// pipeline's max_output_bytes overrides the cluster's default (see
+// client.PPSMaxJobOutputBytesEnv).
+func maxJobOutputBytes(pipelineInfo *pps.PipelineInfo) uint64 {
+	return uint64(jobLimit(pipelineInfo.MaxOutputBytes, client.PPSMaxJobOutputBytesEnv))
+}
+
+// overQuotaReason returns the reason that a job that wrote 'size' bytes of
+// output, more than 'max', fails
+func overQuotaReason(size uint64, max uint64) string {
+	return fmt.Sprintf("over quota: the job wrote %d bytes of output, which is "+
+		"more than the maximum of %d; raise the pipeline's max_output_bytes if "+
+		"this much output is expected", size, max)
+}
+
+// checkOutputQuota fails the job in 'jobPtr', marking it over quota, if its
+// output exceeds the pipeline's max_output_bytes. It returns true if it does.
+func (a *APIServer) checkOutputQuota(stm col.STM, jobPtr *pps.EtcdJobInfo) (bool, error) {
+	max := maxJobOutputBytes(a.pipelineInfo)
+	if max == 0 || jobPtr.Stats.GetUploadBytes() <= max || ppsutil.IsTerminal(jobPtr.State) {
+		return false, nil
+	}
+	jobPtr.OverQuota = true
+	reason := overQuotaReason(jobPtr.Stats.UploadBytes, max)
+	return true, ppsutil.UpdateJobState(a.pipelines.ReadWrite(stm), a.jobs.ReadWrite(stm), jobPtr, pps.JobState_JOB_FAILURE, reason)
+}
+
+// stopOverQuotaJob finishes the output commit of a job that has been failed
+// for being over quota, without a tree, which stops the job's master (as
+// StopJob does). Its workers stop when they see that it failed.
+func stopOverQuotaJob(pachClient *client.APIClient, jobInfo *pps.JobInfo) error {
+	if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+		Commit: jobInfo.OutputCommit,
+		Empty:  true,
+	}); err != nil && !pfsserver.IsCommitFinishedErr(err) {
+		return err
+	}
+	return nil
+}
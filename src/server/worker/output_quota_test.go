@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestMaxJobOutputBytes(t *testing.T) {
+	defer os.Unsetenv(client.PPSMaxJobOutputBytesEnv)
+	require.NoError(t, os.Unsetenv(client.PPSMaxJobOutputBytesEnv))
+	require.Equal(t, uint64(0), maxJobOutputBytes(&pps.PipelineInfo{}))
+	require.Equal(t, uint64(1024), maxJobOutputBytes(&pps.PipelineInfo{MaxOutputBytes: 1024}))
+
+	// The pipeline's max_output_bytes overrides the cluster's default, and -1
+	// removes the limit
+	require.NoError(t, os.Setenv(client.PPSMaxJobOutputBytesEnv, "1000000"))
+	require.Equal(t, uint64(1000000), maxJobOutputBytes(&pps.PipelineInfo{}))
+	require.Equal(t, uint64(1024), maxJobOutputBytes(&pps.PipelineInfo{MaxOutputBytes: 1024}))
+	require.Equal(t, uint64(0), maxJobOutputBytes(&pps.PipelineInfo{MaxOutputBytes: -1}))
+
+	reason := overQuotaReason(2048, 1024)
+	require.True(t, strings.HasPrefix(reason, "over quota"), reason)
+	require.True(t, strings.Contains(reason, "2048 bytes"), reason)
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	globlib "github.com/gobwas/glob"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+// outputRequirement is a compiled pps.OutputRequirement. Exactly one of glob
+// and prefix is set.
+type outputRequirement struct {
+	pattern  string
+	glob     globlib.Glob
+	prefix   string
+	minBytes int64
+	maxBytes int64
+}
+
+func (r *outputRequirement) matches(p string) bool {
+	if r.glob != nil {
+		return r.glob.Match(p)
+	}
+	return r.prefix == "/" || p == r.prefix || strings.HasPrefix(p, r.prefix+"/")
+}
+
+func compileOutputSchema(schema *pps.OutputSchema) ([]*outputRequirement, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	var requirements []*outputRequirement
+	for _, required := range schema.Required {
+		if required.Pattern == "" {
+			return nil, fmt.Errorf("output requirement must specify a pattern")
+		}
+		if required.MinBytes < 0 || required.MaxBytes < 0 {
+			return nil, fmt.Errorf("min_bytes and max_bytes of %s must be non-negative", required.Pattern)
+		}
+		if required.MaxBytes > 0 && required.MinBytes > required.MaxBytes {
+			return nil, fmt.Errorf("min_bytes of %s is greater than its max_bytes", required.Pattern)
+		}
+		pattern := path.Clean("/" + required.Pattern)
+		requirement := &outputRequirement{
+			pattern:  required.Pattern,
+			minBytes: required.MinBytes,
+			maxBytes: required.MaxBytes,
+		}
+		if hashtree.IsGlob(pattern) {
+			g, err := globlib.Compile(pattern, '/')
+			if err != nil {
+				return nil, fmt.Errorf("malformed glob %s: %v", required.Pattern, err)
+			}
+			requirement.glob = g
+		} else {
+			requirement.prefix = pattern
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// ValidateOutputSchema returns an error if a pipeline's output schema is
+// malformed.
+func ValidateOutputSchema(schema *pps.OutputSchema) error {
+	_, err := compileOutputSchema(schema)
+	return err
+}
+
+// checkOutput returns an error describing every requirement of 'schema' that
+// the output in 'outputPath' (a datum's /pfs/out) doesn't satisfy.
+func checkOutput(schema *pps.OutputSchema, outputPath string) error {
+	requirements, err := compileOutputSchema(schema)
+	if err != nil || len(requirements) == 0 {
+		return err
+	}
+	// files holds the output's files in lexical order (the order that Walk
+	// visits them), so that errors are deterministic
+	var files []string
+	sizes := make(map[string]int64)
+	if err := filepath.Walk(outputPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			// Output files may be symlinks to input files
+			if info, err = os.Stat(filePath); err != nil {
+				return err
+			}
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(outputPath, filePath)
+		if err != nil {
+			return err
+		}
+		p := path.Join("/", filepath.ToSlash(relPath))
+		files = append(files, p)
+		sizes[p] = info.Size()
+		return nil
+	}); err != nil {
+		return err
+	}
+	var problems []string
+	for _, requirement := range requirements {
+		matched := false
+		for _, p := range files {
+			if !requirement.matches(p) {
+				continue
+			}
+			matched = true
+			size := sizes[p]
+			if size < requirement.minBytes {
+				problems = append(problems, fmt.Sprintf("%s is %d bytes, which is less than the min_bytes (%d) of %s", p, size, requirement.minBytes, requirement.pattern))
+			}
+			if requirement.maxBytes > 0 && size > requirement.maxBytes {
+				problems = append(problems, fmt.Sprintf("%s is %d bytes, which is more than the max_bytes (%d) of %s", p, size, requirement.maxBytes, requirement.pattern))
+			}
+		}
+		if !matched {
+			problems = append(problems, fmt.Sprintf("no output file matches %s", requirement.pattern))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("output doesn't satisfy the pipeline's output_schema: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
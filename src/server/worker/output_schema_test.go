@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func writeOutputFile(t *testing.T, outputPath string, name string, content string) {
+	p := filepath.Join(outputPath, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0777))
+	require.NoError(t, ioutil.WriteFile(p, []byte(content), 0666))
+}
+
+func TestCheckOutput(t *testing.T) {
+	outputPath, err := ioutil.TempDir("", "TestCheckOutput")
+	require.NoError(t, err)
+	defer os.RemoveAll(outputPath)
+	schema := &pps.OutputSchema{
+		Required: []*pps.OutputRequirement{
+			{Pattern: "result.csv", MinBytes: 1},
+			{Pattern: "/images/*.png", MaxBytes: 4},
+		},
+	}
+
+	// A datum that produces no output fails every requirement
+	err = checkOutput(schema, outputPath)
+	require.YesError(t, err)
+	require.Equal(t, "output doesn't satisfy the pipeline's output_schema: no output file matches result.csv; no output file matches /images/*.png", err.Error())
+
+	// Files must be within the requirements' size bounds
+	writeOutputFile(t, outputPath, "result.csv", "")
+	writeOutputFile(t, outputPath, "images/a.png", "foo")
+	writeOutputFile(t, outputPath, "images/b.png", "foobar")
+	err = checkOutput(schema, outputPath)
+	require.YesError(t, err)
+	require.Equal(t, "output doesn't satisfy the pipeline's output_schema: /result.csv is 0 bytes, which is less than the min_bytes (1) of result.csv; /images/b.png is 6 bytes, which is more than the max_bytes (4) of /images/*.png", err.Error())
+
+	writeOutputFile(t, outputPath, "result.csv", "1,2\n")
+	require.NoError(t, os.Remove(filepath.Join(outputPath, "images/b.png")))
+	require.NoError(t, checkOutput(schema, outputPath))
+
+	// Pipelines without a schema accept any output
+	require.NoError(t, checkOutput(nil, outputPath))
+}
+
+func TestValidateOutputSchema(t *testing.T) {
+	require.NoError(t, ValidateOutputSchema(nil))
+	require.YesError(t, ValidateOutputSchema(&pps.OutputSchema{Required: []*pps.OutputRequirement{{}}}))
+	require.YesError(t, ValidateOutputSchema(&pps.OutputSchema{Required: []*pps.OutputRequirement{{Pattern: "/a", MinBytes: 5, MaxBytes: 2}}}))
+	require.YesError(t, ValidateOutputSchema(&pps.OutputSchema{Required: []*pps.OutputRequirement{{Pattern: "/[a"}}}))
+}
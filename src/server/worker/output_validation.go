@@ -0,0 +1,199 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/exec"
+	filesync "github.com/pachyderm/pachyderm/src/server/pkg/sync"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+const (
+	// defaultOutputValidationTimeout is how long an output validation command
+	// may run if the pipeline doesn't set a timeout
+	defaultOutputValidationTimeout = 10 * time.Minute
+	// maxValidationOutputBytes is the amount of a failed validation command's
+	// output that's included in the job's reason
+	maxValidationOutputBytes = 1024
+)
+
+// outputValidationTimeout returns how long the output validation command of
+// the pipeline in 'pipelineInfo' may run
+func outputValidationTimeout(pipelineInfo *pps.PipelineInfo) time.Duration {
+	if pipelineInfo.OutputValidation.Timeout != nil {
+		if timeout, err := types.DurationFromProto(pipelineInfo.OutputValidation.Timeout); err == nil && timeout > 0 {
+			return timeout
+		}
+	}
+	return defaultOutputValidationTimeout
+}
+
+// QuarantineBranch returns the branch, in a pipeline's output repo, that
+// holds the output of its jobs that failed validation (see
+// pps.OutputValidation), where 'outputBranch' is the pipeline's output branch
+func QuarantineBranch(outputBranch string) string {
+	return outputBranch + "-quarantine"
+}
+
+// validateOutput writes the output that 'request' would finish the output
+// commit of 'jobInfo' with to a staging commit on the pipeline's quarantine
+// branch (see QuarantineBranch), and runs the pipeline's output validation
+// command against it. If the command passes, the staging commit is deleted
+// (which moves the quarantine branch back to its parent) and "" is returned.
+// Otherwise, the staging commit is kept as the quarantine branch's head, and
+// why the output is invalid is returned along with the staging commit.
+func (a *APIServer) validateOutput(pachClient *client.APIClient, logger *taggedLogger, jobInfo *pps.JobInfo, request *pfs.FinishCommitRequest) (_ string, _ *pfs.Commit, retErr error) {
+	ctx := pachClient.Ctx()
+	branch := QuarantineBranch(a.pipelineInfo.OutputBranch)
+	staging, err := pachClient.PfsAPIClient.StartCommit(ctx, &pfs.StartCommitRequest{
+		Parent:      client.NewCommit(jobInfo.OutputCommit.Repo.Name, ""),
+		Branch:      branch,
+		Description: fmt.Sprintf("output of job %s, pending validation", jobInfo.Job.ID),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	keep := false
+	defer func() {
+		if keep {
+			return
+		}
+		if _, err := pachClient.PfsAPIClient.DeleteCommit(ctx, &pfs.DeleteCommitRequest{Commit: staging}); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	// The staging commit's size is left unset, as deleting a commit subtracts
+	// its size from its repo's, and the output is in the repo's size already
+	// (or will be, once it's in the output commit)
+	if _, err := pachClient.PfsAPIClient.FinishCommit(ctx, &pfs.FinishCommitRequest{
+		Commit: staging,
+		Trees:  request.Trees,
+		Datums: request.Datums,
+	}); err != nil {
+		return "", nil, err
+	}
+
+	dir := filepath.Join(client.PPSScratchSpace, uuid.NewWithoutDashes())
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return "", nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	// The output is exposed lazily, like lazy inputs, so that only the data
+	// that the command reads is downloaded
+	puller := filesync.NewPuller()
+	defer func() {
+		if _, err := puller.CleanUp(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	if a.fuse {
+		if err := puller.Mount(pachClient, dir, staging.Repo.Name, staging.ID, "/", nil, ""); err != nil {
+			return "", nil, err
+		}
+	} else if err := puller.Pull(pachClient, dir, staging.Repo.Name, staging.ID, "/", true, false, concurrency, nil, ""); err != nil {
+		return "", nil, err
+	}
+
+	logger.Logf("validating output of job %s (in staging commit %s)", jobInfo.Job.ID, staging.ID)
+	timeout := outputValidationTimeout(a.pipelineInfo)
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	validation := a.pipelineInfo.OutputValidation
+	cmd := exec.CommandContext(cmdCtx, validation.Cmd[0], validation.Cmd[1:]...)
+	cmd.Env = a.userCodeEnv(dir, jobInfo.Job.ID, jobInfo.TraceID, staging.ID, nil)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		logger.Logf("output of job %s passed validation", jobInfo.Job.ID)
+		return "", nil, nil
+	}
+	if ctx.Err() != nil {
+		return "", nil, ctx.Err() // the job was cancelled, rather than its output invalid
+	}
+	keep = true
+	if cmdCtx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %v", timeout)
+	}
+	reason := fmt.Sprintf("output failed validation (kept in commit %s on branch %s): %v", staging.ID, branch, err)
+	if out := strings.TrimSpace(string(output)); out != "" {
+		if len(out) > maxValidationOutputBytes {
+			out = "..." + out[len(out)-maxValidationOutputBytes:]
+		}
+		reason = fmt.Sprintf("%s: %s", reason, out)
+	}
+	logger.Logf("%s", reason)
+	return reason, staging, nil
+}
+
+// quarantineOutput returns a request that finishes the output commit of
+// 'jobInfo', whose output failed validation and is kept in 'staging' on the
+// quarantine branch, with the content of its parent instead. The output
+// commit must be finished, as the head of an output branch is always
+// provenant on the heads of its input branches, but none of the rejected
+// output reaches the output branch: its content stays that of the last output
+// that passed validation. The commit is marked as unchanged output, so
+// downstream pipelines don't run jobs for it. If the output commit has no
+// parent with output, it's finished empty, like the output commits of other
+// failed jobs.
+func quarantineOutput(pachClient *client.APIClient, jobInfo *pps.JobInfo, staging *pfs.Commit) (*pfs.FinishCommitRequest, error) {
+	request := &pfs.FinishCommitRequest{
+		Commit: jobInfo.OutputCommit,
+		Empty:  true,
+		Labels: map[string]string{client.PPSQuarantinedOutputLabel: staging.ID},
+	}
+	parent, err := copyParentOutput(pachClient, request)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil {
+		request.Description = fmt.Sprintf("output failed validation; unchanged since %s", parent.ID)
+	}
+	return request, nil
+}
+
+// copyParentOutput sets 'request' to finish its commit with the output of the
+// commit's parent, and marks the commit as unchanged output. It returns the
+// parent, or nil (leaving 'request' unchanged) if the commit has no parent
+// with output.
+func copyParentOutput(pachClient *client.APIClient, request *pfs.FinishCommitRequest) (*pfs.Commit, error) {
+	commitInfo, err := pachClient.InspectCommit(request.Commit.Repo.Name, request.Commit.ID)
+	if err != nil {
+		return nil, err
+	}
+	if commitInfo.ParentCommit == nil {
+		return nil, nil
+	}
+	parentInfo, err := pachClient.PfsAPIClient.InspectCommit(pachClient.Ctx(), &pfs.InspectCommitRequest{
+		Commit:     commitInfo.ParentCommit,
+		BlockState: pfs.CommitState_FINISHED,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if parentInfo.Trees == nil {
+		return nil, nil
+	}
+	request.Empty = false
+	request.Trees = parentInfo.Trees
+	request.Datums = parentInfo.Datums
+	request.SizeBytes = parentInfo.SizeBytes
+	if request.Labels == nil {
+		request.Labels = make(map[string]string)
+	}
+	request.Labels[client.PPSUnchangedOutputLabel] = "true"
+	return parentInfo.Commit, nil
+}
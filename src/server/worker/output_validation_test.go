@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestOutputValidationTimeout(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		OutputValidation: &pps.OutputValidation{Cmd: []string{"true"}},
+	}
+	require.Equal(t, defaultOutputValidationTimeout, outputValidationTimeout(pipelineInfo))
+
+	pipelineInfo.OutputValidation.Timeout = types.DurationProto(time.Minute)
+	require.Equal(t, time.Minute, outputValidationTimeout(pipelineInfo))
+
+	// Non-positive timeouts fall back to the default
+	pipelineInfo.OutputValidation.Timeout = types.DurationProto(0)
+	require.Equal(t, defaultOutputValidationTimeout, outputValidationTimeout(pipelineInfo))
+}
@@ -0,0 +1,261 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+const (
+	// defaultPreemptionGracePeriod is how long workers' pods get to drain
+	// after a preemption notice, if Preemption.GracePeriod is unset. It's
+	// about as long as the notice that spot and preemptible VMs get.
+	defaultPreemptionGracePeriod = 30 * time.Second
+	// preemptionPollInterval is how often the node's instance metadata is
+	// polled for a preemption notice
+	preemptionPollInterval = 5 * time.Second
+
+	awsMetadataURL = "http://169.254.169.254/latest"
+	gcpMetadataURL = "http://metadata.google.internal/computeMetadata/v1"
+)
+
+// errWorkerPreempted is returned by acquireDatums once the worker has been
+// preempted, and has given up its chunk
+var errWorkerPreempted = errors.New("worker was preempted")
+
+// ValidatePreemption returns an error if 'preemption' is malformed. A nil
+// preemption is valid.
+func ValidatePreemption(preemption *pps.Preemption) error {
+	if preemption == nil {
+		return nil
+	}
+	switch preemption.Notice {
+	case "", "signal", "aws", "gcp":
+	default:
+		return fmt.Errorf("notice must be \"signal\", \"aws\" or \"gcp\", not %q", preemption.Notice)
+	}
+	if _, err := PreemptionGracePeriod(preemption); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PreemptionGracePeriod returns how long the pods of workers with the
+// preemption settings 'preemption' get to drain before they're killed
+func PreemptionGracePeriod(preemption *pps.Preemption) (time.Duration, error) {
+	if preemption.GracePeriod == nil {
+		return defaultPreemptionGracePeriod, nil
+	}
+	gracePeriod, err := types.DurationFromProto(preemption.GracePeriod)
+	if err != nil {
+		return 0, err
+	}
+	if gracePeriod <= 0 {
+		return 0, fmt.Errorf("grace_period must be positive")
+	}
+	return gracePeriod, nil
+}
+
+// preemption records whether a worker's node is being preempted. A nil
+// preemption is never preempted.
+type preemption struct {
+	once   sync.Once
+	notice chan struct{}
+}
+
+func newPreemption(pipelineInfo *pps.PipelineInfo) *preemption {
+	if pipelineInfo.Preemption == nil {
+		return nil
+	}
+	return &preemption{notice: make(chan struct{})}
+}
+
+// preempt records that the worker's node is being preempted, for 'reason'
+func (p *preemption) preempt(reason string) {
+	if p == nil {
+		return
+	}
+	p.once.Do(func() {
+		log.Infof("worker is being preempted (%s); draining", reason)
+		close(p.notice)
+	})
+}
+
+// notified returns a channel that's closed once the worker is preempted
+func (p *preemption) notified() <-chan struct{} {
+	if p == nil {
+		return nil
+	}
+	return p.notice
+}
+
+// preempted returns true if the worker is being preempted
+func (p *preemption) preempted() bool {
+	select {
+	case <-p.notified():
+		return true
+	default:
+		return false
+	}
+}
+
+// Preempt makes the worker drain, because its node is about to be preempted:
+// it stops starting datums, and gives up the chunk that it's processing so
+// that other workers take it over. It does nothing unless the worker's
+// pipeline sets Preemption.
+func (a *APIServer) Preempt(reason string) {
+	a.preemption.preempt(reason)
+}
+
+// Preempted returns a channel that's closed once the worker is preempted
+// (see Preempt). It's never closed if the pipeline doesn't set Preemption.
+func (a *APIServer) Preempted() <-chan struct{} {
+	return a.preemption.notified()
+}
+
+// Drained returns a channel that's closed once the worker has been preempted
+// and has drained: it has given up its chunk, finished its running datums and
+// released the master lock (if it held it), so it can exit.
+func (a *APIServer) Drained() <-chan struct{} {
+	return a.drained
+}
+
+// WatchPreemptionNotice polls the node's instance metadata for a preemption
+// notice, if the pipeline's Preemption.Notice names a cloud, and calls Preempt
+// once there is one. It returns when 'ctx' is done, or the worker is
+// preempted.
+func (a *APIServer) WatchPreemptionNotice(ctx context.Context) {
+	if a.pipelineInfo.Preemption == nil {
+		return
+	}
+	var check func(context.Context) (bool, error)
+	switch a.pipelineInfo.Preemption.Notice {
+	case "aws":
+		check = func(ctx context.Context) (bool, error) { return awsPreemptionNotice(ctx, awsMetadataURL) }
+	case "gcp":
+		check = func(ctx context.Context) (bool, error) { return gcpPreemptionNotice(ctx, gcpMetadataURL) }
+	default:
+		return
+	}
+	pollPreemptionNotice(ctx, preemptionPollInterval, check, a.preemption)
+}
+
+// pollPreemptionNotice calls 'check' every 'interval' until it reports a
+// preemption notice, and then preempts 'p'
+func pollPreemptionNotice(ctx context.Context, interval time.Duration, check func(context.Context) (bool, error), p *preemption) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		noticed, err := check(ctx)
+		if err != nil {
+			log.Errorf("could not check for a preemption notice: %v", err)
+		}
+		if noticed {
+			p.preempt("the node's instance metadata has a preemption notice")
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		case <-p.notified():
+			return
+		}
+	}
+}
+
+// awsPreemptionNotice returns true if the EC2 instance metadata service at
+// 'baseURL' has a spot instance interruption notice. It uses an IMDSv2 token
+// if it can get one.
+func awsPreemptionNotice(ctx context.Context, baseURL string) (bool, error) {
+	req, err := http.NewRequest("GET", baseURL+"/meta-data/spot/instance-action", nil)
+	if err != nil {
+		return false, err
+	}
+	tokenReq, err := http.NewRequest("PUT", baseURL+"/api/token", nil)
+	if err != nil {
+		return false, err
+	}
+	tokenReq.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+	if token, err := metadataGet(ctx, tokenReq); err == nil && token != "" {
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+	}
+	// There's no instance action until the instance is about to be interrupted
+	body, err := metadataGet(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	return body != "", nil
+}
+
+// gcpPreemptionNotice returns true if the GCE metadata server at 'baseURL'
+// says that the instance is being preempted
+func gcpPreemptionNotice(ctx context.Context, baseURL string) (bool, error) {
+	req, err := http.NewRequest("GET", baseURL+"/instance/preempted", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	body, err := metadataGet(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	return body == "TRUE", nil
+}
+
+// metadataGet returns the body of the response to 'req', or "" if it's not
+// found
+func metadataGet(ctx context.Context, req *http.Request) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, preemptionPollInterval)
+	defer cancel()
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s %s: %s", req.Method, req.URL, resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// releaseChunk gives up this worker's lock on the chunk ending at 'high' in
+// the job 'jobID', so that other workers can process it right away, rather
+// than once the lock expires. Datums in it that this worker finished are
+// skipped by the worker that takes it over, as their output is already
+// stored.
+func (a *APIServer) releaseChunk(ctx context.Context, jobID string, high int64) error {
+	_, err := col.NewSTM(ctx, a.etcdClient, func(stm col.STM) error {
+		chunks := a.chunks(jobID).ReadWrite(stm)
+		var chunkState ChunkState
+		if err := chunks.Get(fmt.Sprint(high), &chunkState); err != nil {
+			if col.IsErrNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if chunkState.State != State_RUNNING {
+			return nil
+		}
+		return chunks.Delete(fmt.Sprint(high))
+	})
+	return err
+}
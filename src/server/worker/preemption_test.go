@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/dlock"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+)
+
+func TestValidatePreemption(t *testing.T) {
+	require.NoError(t, ValidatePreemption(nil))
+	require.NoError(t, ValidatePreemption(&pps.Preemption{}))
+	require.NoError(t, ValidatePreemption(&pps.Preemption{Notice: "aws", GracePeriod: types.DurationProto(2 * time.Minute)}))
+	require.YesError(t, ValidatePreemption(&pps.Preemption{Notice: "azure"}))
+	require.YesError(t, ValidatePreemption(&pps.Preemption{GracePeriod: types.DurationProto(0)}))
+
+	gracePeriod, err := PreemptionGracePeriod(&pps.Preemption{})
+	require.NoError(t, err)
+	require.Equal(t, defaultPreemptionGracePeriod, gracePeriod)
+}
+
+func TestPreemptionNotice(t *testing.T) {
+	// The metadata servers have no notice until their third poll
+	var awsPolls, gcpPolls int32
+	aws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/token":
+			fmt.Fprint(w, "token")
+		case "/meta-data/spot/instance-action":
+			require.Equal(t, "token", r.Header.Get("X-aws-ec2-metadata-token"))
+			if atomic.AddInt32(&awsPolls, 1) < 3 {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, `{"action": "terminate", "time": "2019-01-01T00:00:00Z"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer aws.Close()
+	gcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		if atomic.AddInt32(&gcpPolls, 1) < 3 {
+			fmt.Fprint(w, "FALSE")
+			return
+		}
+		fmt.Fprint(w, "TRUE")
+	}))
+	defer gcp.Close()
+
+	for _, check := range []func(context.Context) (bool, error){
+		func(ctx context.Context) (bool, error) { return awsPreemptionNotice(ctx, aws.URL) },
+		func(ctx context.Context) (bool, error) { return gcpPreemptionNotice(ctx, gcp.URL) },
+	} {
+		p := &preemption{notice: make(chan struct{})}
+		pollPreemptionNotice(context.Background(), time.Millisecond, check, p)
+		require.True(t, p.preempted())
+	}
+	require.Equal(t, int32(3), awsPolls)
+	require.Equal(t, int32(3), gcpPolls)
+
+	// Workers of pipelines that don't handle preemption are never preempted
+	var p *preemption
+	p.preempt("test")
+	require.False(t, p.preempted())
+}
+
+func TestPreemptionDrain(t *testing.T) {
+	etcdClient := getEtcdClient(t)
+	etcdPrefix := tu.UniqueString("TestPreemptionDrain")
+	jobID := tu.UniqueString("job")
+	preempted := newTestAPIServer(nil, etcdClient, etcdPrefix, t)
+	preempted.preemption = &preemption{notice: make(chan struct{})}
+	other := newTestAPIServer(nil, etcdClient, etcdPrefix, t)
+	_, err := col.NewSTM(context.Background(), etcdClient, func(stm col.STM) error {
+		return preempted.jobs.ReadWrite(stm).Put(jobID, &pps.EtcdJobInfo{
+			Job:          client.NewJob(jobID),
+			Pipeline:     client.NewPipeline("test"),
+			OutputCommit: client.NewCommit("test", "output"),
+		})
+	})
+	require.NoError(t, err)
+	plan := &Plan{Chunks: []int64{2, 4}}
+
+	// The worker is preempted while it processes its first chunk, so it gives
+	// the chunk up, without recording its result, and takes no more chunks
+	var preemptedChunks []int64
+	err = preempted.acquireDatums(context.Background(), jobID, plan, preempted.getMasterLogger(), func(low, high int64) (*processResult, error) {
+		preemptedChunks = append(preemptedChunks, high)
+		preempted.Preempt("simulated preemption")
+		return &processResult{datumsProcessed: high - low}, nil
+	})
+	require.Equal(t, errWorkerPreempted, err)
+	require.Equal(t, []int64{2}, preemptedChunks)
+	var chunkState ChunkState
+	err = preempted.chunks(jobID).ReadOnly(context.Background()).Get("2", &chunkState)
+	require.True(t, col.IsErrNotFound(err))
+
+	// Another worker processes both chunks right away
+	var otherChunks []int64
+	require.NoError(t, other.acquireDatums(context.Background(), jobID, plan, other.getMasterLogger(), func(low, high int64) (*processResult, error) {
+		otherChunks = append(otherChunks, high)
+		return &processResult{datumsProcessed: high - low}, nil
+	}))
+	require.Equal(t, []int64{2, 4}, otherChunks)
+	jobPtr := &pps.EtcdJobInfo{}
+	require.NoError(t, other.jobs.ReadOnly(context.Background()).Get(jobID, jobPtr))
+	require.Equal(t, int64(4), jobPtr.DataProcessed)
+}
+
+func TestPreemptionReleasesMasterLock(t *testing.T) {
+	etcdClient := getEtcdClient(t)
+	lockPath := tu.UniqueString("TestPreemptionReleasesMasterLock")
+	a := newTestAPIServer(nil, etcdClient, tu.UniqueString("TestPreemptionReleasesMasterLock"), t)
+	a.preemption = &preemption{notice: make(chan struct{})}
+
+	// The master's ctx is cancelled once the worker is preempted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.cancelOnPreemption(ctx, cancel)
+	masterLock := dlock.NewDLock(etcdClient, lockPath)
+	ctx, err := masterLock.Lock(ctx)
+	require.NoError(t, err)
+	a.Preempt("simulated preemption")
+	select {
+	case <-ctx.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("master ctx wasn't cancelled by the preemption")
+	}
+
+	// The lock is still released, so that another worker becomes the master
+	// right away rather than once the lock's session expires
+	unlockMaster(masterLock, a.getMasterLogger())
+	lockCtx, lockCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer lockCancel()
+	_, err = dlock.NewDLock(etcdClient, lockPath).Lock(lockCtx)
+	require.NoError(t, err)
+}
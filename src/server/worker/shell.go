@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+const (
+	// defaultShell runs the scripts of transforms whose shell doesn't set a
+	// path
+	defaultShell = "bash"
+	// strictMode is run at the start of the scripts of transforms whose shell
+	// is strict
+	strictMode = "set -euo pipefail"
+)
+
+// ValidateShell returns an error if 'transform' sets a shell (see
+// pps.Shell), but not a script for it to run.
+func ValidateShell(transform *pps.Transform) error {
+	if transform.Shell == nil {
+		return nil
+	}
+	if len(transform.Cmd) > 0 {
+		return fmt.Errorf("cmd can't be set along with shell, which runs stdin instead")
+	}
+	if len(transform.Stdin) == 0 {
+		return fmt.Errorf("stdin must be set, as it's the script that shell runs")
+	}
+	return nil
+}
+
+// transformCommand returns the command that runs 'transform', its
+// arguments, with 'extraArgs' (see datumCommand) appended, and the lines of
+// its stdin
+func transformCommand(transform *pps.Transform, extraArgs []string) (string, []string, []string) {
+	if transform.Shell == nil {
+		return transform.Cmd[0], append(append([]string{}, transform.Cmd[1:]...), extraArgs...), transform.Stdin
+	}
+	path := transform.Shell.Path
+	if path == "" {
+		path = defaultShell
+	}
+	stdin := transform.Stdin
+	if transform.Shell.Strict {
+		stdin = append([]string{strictMode}, stdin...)
+	}
+	// "-s" makes the shell read the script from stdin, and "--" passes
+	// 'extraArgs' to the script as $1, $2, etc. even if they look like flags
+	return path, append([]string{"-s", "--"}, extraArgs...), stdin
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// runTransform runs 'transform' the way the worker runs it for a datum,
+// and returns its output
+func runTransform(transform *pps.Transform, extraArgs ...string) (string, error) {
+	name, args, stdin := transformCommand(transform, extraArgs)
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(strings.Join(stdin, "\n") + "\n")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestShell(t *testing.T) {
+	script := []string{
+		"false | cat",
+		"echo \"$1\"",
+	}
+	// Without strict mode, the failed command is ignored
+	out, err := runTransform(&pps.Transform{
+		Stdin: script,
+		Shell: &pps.Shell{Path: "bash"},
+	}, "--date")
+	require.NoError(t, err)
+	require.Equal(t, "--date\n", out)
+
+	// With strict mode, it fails the datum
+	out, err = runTransform(&pps.Transform{
+		Stdin: script,
+		Shell: &pps.Shell{Strict: true},
+	}, "--date")
+	require.YesError(t, err)
+	require.Equal(t, "", out)
+
+	// As do undefined variables
+	_, err = runTransform(&pps.Transform{
+		Stdin: []string{"echo \"$UNDEFINED\""},
+		Shell: &pps.Shell{Strict: true},
+	})
+	require.YesError(t, err)
+
+	// Transforms without a shell run their cmd
+	out, err = runTransform(&pps.Transform{
+		Cmd: []string{"echo", "foo"},
+	}, "bar")
+	require.NoError(t, err)
+	require.Equal(t, "foo bar\n", out)
+}
+
+func TestValidateShell(t *testing.T) {
+	require.NoError(t, ValidateShell(&pps.Transform{Cmd: []string{"bash"}}))
+	require.NoError(t, ValidateShell(&pps.Transform{Stdin: []string{"true"}, Shell: &pps.Shell{Strict: true}}))
+	require.YesError(t, ValidateShell(&pps.Transform{Shell: &pps.Shell{Strict: true}}))
+	require.YesError(t, ValidateShell(&pps.Transform{Cmd: []string{"bash"}, Stdin: []string{"true"}, Shell: &pps.Shell{}}))
+}
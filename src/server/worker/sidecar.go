@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+// sidecarDialTimeout is how long each attempt to connect to the sidecar waits
+// for a connection
+const sidecarDialTimeout = 5 * time.Second
+
+// ConnectToSidecar returns a client of the pachd sidecar at 'address' once
+// the sidecar is serving. The sidecar starts alongside the worker, so it may
+// not be listening yet when the worker starts; rather than failing (and
+// crash-looping the pod until the two happen to line up), ConnectToSidecar
+// retries with backoff until the sidecar passes a health check, or until
+// 'timeout' has passed. It calls logf before each retry. The client only has a
+// circuit breaker if one is configured in the worker's env (see
+// client.CircuitBreakerFromEnv), as the worker can't make progress without its
+// sidecar anyway. Its requests are compressed as set by
+// client.WorkerCompressionEnv, regardless of how external clients compress
+// theirs.
+func ConnectToSidecar(address string, timeout time.Duration, logf func(string, ...interface{})) (*client.APIClient, error) {
+	circuitBreaker, err := client.CircuitBreakerFromEnv(0)
+	if err != nil {
+		return nil, err
+	}
+	compression := os.Getenv(client.WorkerCompressionEnv)
+	if err := grpcutil.ValidateCompression(compression); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", client.WorkerCompressionEnv, err)
+	}
+	var pachClient *client.APIClient
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 100 * time.Millisecond
+	b.MaxInterval = 5 * time.Second
+	b.MaxElapsedTime = timeout
+	if err := backoff.RetryNotify(func() error {
+		c, err := client.NewFromAddress(address, client.WithDialTimeout(sidecarDialTimeout), circuitBreaker, client.WithCompression(compression))
+		if err != nil {
+			return err
+		}
+		if err := c.Health(); err != nil {
+			c.Close()
+			return err
+		}
+		pachClient = c
+		return nil
+	}, b, func(err error, d time.Duration) error {
+		logf("sidecar at %s isn't ready: %v; retrying in %v", address, err, d)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("sidecar at %s wasn't ready within %v: %v", address, timeout, err)
+	}
+	return pachClient, nil
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	healthclient "github.com/pachyderm/pachyderm/src/client/health"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/health"
+)
+
+func TestConnectToSidecar(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	logf := func(format string, args ...interface{}) { t.Logf(format, args...) }
+
+	// Without a sidecar, the worker gives up once the timeout has passed
+	_, err = ConnectToSidecar(address, time.Second, logf)
+	require.YesError(t, err)
+
+	// The worker waits for a sidecar that starts late, and isn't ready at first
+	healthServer := health.NewHealthServer()
+	server := grpc.NewServer()
+	healthclient.RegisterHealthServer(server, healthServer)
+	defer server.Stop()
+	go func() {
+		time.Sleep(time.Second)
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			panic(fmt.Sprintf("could not listen on %s: %v", address, err))
+		}
+		go server.Serve(listener)
+		time.Sleep(time.Second)
+		healthServer.Ready()
+	}()
+	start := time.Now()
+	pachClient, err := ConnectToSidecar(address, time.Minute, logf)
+	require.NoError(t, err)
+	defer pachClient.Close()
+	require.True(t, time.Since(start) >= 2*time.Second)
+	require.NoError(t, pachClient.Health())
+}
+
+// compressionRecorder is a stats.Handler that records how the requests that
+// a server receives are compressed
+type compressionRecorder struct {
+	mu          sync.Mutex
+	compression []string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.compression = append(r.compression, header.Compression)
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+// last returns how the last request that the server received was compressed
+func (r *compressionRecorder) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.compression[len(r.compression)-1]
+}
+
+func setEnv(t *testing.T, name, value string) func() {
+	old, ok := os.LookupEnv(name)
+	require.NoError(t, os.Setenv(name, value))
+	return func() {
+		if ok {
+			os.Setenv(name, old)
+		} else {
+			os.Unsetenv(name)
+		}
+	}
+}
+
+func TestConnectToSidecarCompression(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	recorder := &compressionRecorder{}
+	healthServer := health.NewHealthServer()
+	healthServer.Ready()
+	server := grpc.NewServer(grpc.StatsHandler(recorder))
+	healthclient.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+	logf := func(format string, args ...interface{}) { t.Logf(format, args...) }
+	colon := strings.LastIndexByte(address, ':')
+	defer setEnv(t, "PACHD_SERVICE_HOST", address[:colon])()
+	defer setEnv(t, "PACHD_SERVICE_PORT", address[colon+1:])()
+
+	// External clients compress their requests, while the worker doesn't
+	defer setEnv(t, client.CompressionEnv, "gzip")()
+	defer setEnv(t, client.WorkerCompressionEnv, "none")()
+	externalClient, err := client.NewInCluster()
+	require.NoError(t, err)
+	defer externalClient.Close()
+	require.NoError(t, externalClient.Health())
+	require.Equal(t, "gzip", recorder.last())
+	pachClient, err := ConnectToSidecar(address, time.Minute, logf)
+	require.NoError(t, err)
+	defer pachClient.Close()
+	require.NoError(t, pachClient.Health())
+	require.Equal(t, "", recorder.last())
+
+	// The worker compresses its requests, while external clients don't
+	defer setEnv(t, client.CompressionEnv, "none")()
+	defer setEnv(t, client.WorkerCompressionEnv, "gzip")()
+	externalClient, err = client.NewInCluster()
+	require.NoError(t, err)
+	defer externalClient.Close()
+	require.NoError(t, externalClient.Health())
+	require.Equal(t, "", recorder.last())
+	pachClient, err = ConnectToSidecar(address, time.Minute, logf)
+	require.NoError(t, err)
+	defer pachClient.Close()
+	require.NoError(t, pachClient.Health())
+	require.Equal(t, "gzip", recorder.last())
+
+	// An invalid setting is rejected
+	defer setEnv(t, client.WorkerCompressionEnv, "lz4")()
+	_, err = ConnectToSidecar(address, time.Minute, logf)
+	require.YesError(t, err)
+}
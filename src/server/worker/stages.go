@@ -0,0 +1,220 @@
+package worker
+
+import (
+	"sync"
+
+	"github.com/pachyderm/pachyderm/src/client/limit"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// datumStages runs the stages of processing a datum (downloading its inputs,
+// running the pipeline's code on them, and uploading its output) for the
+// datums that a worker is processing concurrently. Only one datum's code runs
+// at a time, as it's exposed to the code at /pfs, unless the pipeline has
+// DatumsPerWorker, in which case each datum's code sees it in its own
+// directory and up to that many run at once. By default, a datum's output is
+// uploaded before the next datum's code runs; if the pipeline has a
+// StageConcurrency, the next datum's code runs while the output is uploaded.
+// If the StageConcurrency is ordered, datums are downloaded, and their code
+// run, in the order that they're processed (their index in the job's
+// DatumFactory), rather than in whichever order they reach each stage.
+type datumStages struct {
+	// runMu is held while a datum's code runs, unless 'runs' is set
+	runMu *sync.Mutex
+	// runs, if set, limits the datums whose code runs at once, instead of
+	// runMu
+	runs limit.ConcurrencyLimiter
+	// downloads limits the datums whose inputs are downloaded at once
+	downloads limit.ConcurrencyLimiter
+	// orderedDownloads and orderedRuns, if set, admit datums to the download
+	// stage (instead of 'downloads') and to the run stage (before runMu or
+	// 'runs') in processing order
+	orderedDownloads *orderedLimiter
+	orderedRuns      *orderedLimiter
+	// uploads limits the datums whose outputs are uploaded at once. If it's
+	// nil, outputs are uploaded while the datum's code still holds its place
+	// (runMu or 'runs').
+	uploads limit.ConcurrencyLimiter
+	// queueSize is the number of datums that may be in any stage at once
+	queueSize int
+}
+
+// newDatumStages returns the datumStages for processing the datums starting
+// at index 'first' in the job's datums
+func newDatumStages(runMu *sync.Mutex, maxQueueSize int64, stageConcurrency *pps.StageConcurrency, datumsPerWorker int64, first int64) *datumStages {
+	s := &datumStages{
+		runMu:     runMu,
+		downloads: limit.New(0),
+		queueSize: int(maxQueueSize),
+	}
+	runs := 1
+	if datumsPerWorker > 1 {
+		runs = int(datumsPerWorker)
+		s.runs = limit.New(runs)
+		// Leave room for every datum that may run at once
+		if s.queueSize < runs {
+			s.queueSize = runs
+		}
+	}
+	if stageConcurrency == nil {
+		return s
+	}
+	download, upload := int(stageConcurrency.Download), int(stageConcurrency.Upload)
+	if download == 0 {
+		download = 1
+	}
+	if upload == 0 {
+		upload = 1
+	}
+	s.downloads = limit.New(download)
+	s.uploads = limit.New(upload)
+	if stageConcurrency.Ordered {
+		s.orderedDownloads = newOrderedLimiter(download, first)
+		s.orderedRuns = newOrderedLimiter(runs, first)
+	}
+	// Leave room for every stage to be busy at once
+	if s.queueSize < download+runs+upload {
+		s.queueSize = download + runs + upload
+	}
+	return s
+}
+
+func (s *datumStages) lockRun(index int64) {
+	if s.orderedRuns != nil {
+		s.orderedRuns.acquire(index)
+	}
+	if s.runs != nil {
+		s.runs.Acquire()
+		return
+	}
+	s.runMu.Lock()
+}
+
+func (s *datumStages) unlockRun() {
+	if s.runs != nil {
+		s.runs.Release()
+	} else {
+		s.runMu.Unlock()
+	}
+	if s.orderedRuns != nil {
+		s.orderedRuns.release()
+	}
+}
+
+func (s *datumStages) acquireDownload(index int64) {
+	if s.orderedDownloads != nil {
+		s.orderedDownloads.acquire(index)
+		return
+	}
+	s.downloads.Acquire()
+}
+
+func (s *datumStages) releaseDownload() {
+	if s.orderedDownloads != nil {
+		s.orderedDownloads.release()
+		return
+	}
+	s.downloads.Release()
+}
+
+// skip records that the datum at 'index' won't enter any (more) stages, e.g.
+// because it's skipped or failed, so that if the stages are ordered, the
+// datums after it don't wait for it. It's a no-op for stages that the datum
+// already entered.
+func (s *datumStages) skip(index int64) {
+	if s.orderedDownloads != nil {
+		s.orderedDownloads.skip(index)
+		s.orderedRuns.skip(index)
+	}
+}
+
+// run runs the stages of the datum at 'index' in the job's datums. The stages
+// of different datums only share runMu (or 'runs'), so everything that a stage
+// needs from the previous one (e.g. the datum's scratch directory) must be
+// passed outside of /pfs.
+func (s *datumStages) run(index int64, download, process, upload func() error) error {
+	if err := func() error {
+		s.acquireDownload(index)
+		defer s.releaseDownload()
+		return download()
+	}(); err != nil {
+		return err
+	}
+	s.lockRun(index)
+	if err := process(); err != nil {
+		s.unlockRun()
+		return err
+	}
+	if s.uploads == nil {
+		defer s.unlockRun()
+		return upload()
+	}
+	s.unlockRun()
+	s.uploads.Acquire()
+	defer s.uploads.Release()
+	return upload()
+}
+
+// orderedLimiter limits the datums in a stage at once, like a
+// limit.ConcurrencyLimiter, but admits datums strictly in processing order:
+// the datum at index i only enters the stage once every datum before it has
+// entered it, or has been skipped (see skip), so that datums that never reach
+// the stage don't hold up the datums after them. A datum that enters the
+// stage again (e.g. because it's retried) only waits for a free place.
+type orderedLimiter struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	free int
+	// next is the index of the next datum to enter the stage
+	next int64
+	// passed holds the indexes after 'next' that have entered the stage or
+	// been skipped already
+	passed map[int64]bool
+}
+
+func newOrderedLimiter(n int, first int64) *orderedLimiter {
+	l := &orderedLimiter{free: n, next: first, passed: make(map[int64]bool)}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire blocks until the datum at 'index' may enter the stage
+func (l *orderedLimiter) acquire(index int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.free == 0 || index > l.next {
+		l.cond.Wait()
+	}
+	l.free--
+	l.pass(index)
+}
+
+// release frees the datum's place in the stage
+func (l *orderedLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.free++
+	l.cond.Broadcast()
+}
+
+// skip records that the datum at 'index' won't enter the stage (again), so
+// that the datums after it don't wait for it
+func (l *orderedLimiter) skip(index int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pass(index)
+}
+
+// pass records that the datum at 'index' has entered the stage or been
+// skipped, and advances 'next' past it. l.mu must be held.
+func (l *orderedLimiter) pass(index int64) {
+	if index < l.next {
+		return
+	}
+	l.passed[index] = true
+	for l.passed[l.next] {
+		delete(l.passed, l.next)
+		l.next++
+	}
+	l.cond.Broadcast()
+}
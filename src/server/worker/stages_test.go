@@ -0,0 +1,259 @@
+package worker
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/pachyderm/pachyderm/src/client/limit"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// stageCounter tracks how many datums are in a stage at once
+type stageCounter struct {
+	current, max, started int64
+}
+
+func (c *stageCounter) run(d time.Duration) {
+	atomic.AddInt64(&c.started, 1)
+	n := atomic.AddInt64(&c.current, 1)
+	for {
+		max := atomic.LoadInt64(&c.max)
+		if n <= max || atomic.CompareAndSwapInt64(&c.max, max, n) {
+			break
+		}
+	}
+	time.Sleep(d)
+	atomic.AddInt64(&c.current, -1)
+}
+
+// runDatums runs 'n' datums through 's' the way processDatums does, with each
+// stage taking 'd'. It returns the counters of the download, process, and
+// upload stages, and the number of datums that were uploading when another
+// datum's code started.
+func runDatums(t testing.TB, s *datumStages, n int, d time.Duration) (download, process, upload *stageCounter, overlapped int64) {
+	download, process, upload = &stageCounter{}, &stageCounter{}, &stageCounter{}
+	var eg errgroup.Group
+	limiter := limit.New(s.queueSize)
+	for i := 0; i < n; i++ {
+		i := i
+		limiter.Acquire()
+		eg.Go(func() error {
+			defer limiter.Release()
+			defer s.skip(int64(i))
+			return s.run(int64(i), func() error {
+				download.run(d)
+				return nil
+			}, func() error {
+				process.run(d)
+				return nil
+			}, func() error {
+				started := atomic.LoadInt64(&process.started)
+				upload.run(d)
+				if atomic.LoadInt64(&process.started) > started {
+					atomic.AddInt64(&overlapped, 1)
+				}
+				return nil
+			})
+		})
+	}
+	require.NoError(t, eg.Wait())
+	return download, process, upload, overlapped
+}
+
+func TestDatumStagesSerial(t *testing.T) {
+	s := newDatumStages(&sync.Mutex{}, 1, nil, 0, 0)
+	download, process, upload, overlapped := runDatums(t, s, 10, time.Millisecond)
+	require.Equal(t, int64(1), download.max)
+	require.Equal(t, int64(1), process.max)
+	require.Equal(t, int64(1), upload.max)
+	require.Equal(t, int64(0), overlapped)
+
+	// Without a StageConcurrency, max_queue_size still lets datums download
+	// concurrently, but uploads hold the lock that code runs under
+	s = newDatumStages(&sync.Mutex{}, 4, nil, 0, 0)
+	_, process, upload, overlapped = runDatums(t, s, 20, time.Millisecond)
+	require.Equal(t, int64(1), process.max)
+	require.Equal(t, int64(1), upload.max)
+	require.Equal(t, int64(0), overlapped)
+}
+
+func TestDatumStagesPipelined(t *testing.T) {
+	s := newDatumStages(&sync.Mutex{}, 1, &pps.StageConcurrency{Download: 2, Upload: 3}, 0, 0)
+	require.Equal(t, 6, s.queueSize)
+	download, process, upload, overlapped := runDatums(t, s, 30, 5*time.Millisecond)
+	require.True(t, download.max <= 2)
+	require.Equal(t, int64(1), process.max)
+	require.True(t, upload.max <= 3)
+	require.True(t, overlapped > 0)
+
+	// 0 means 1, and max_queue_size isn't reduced
+	s = newDatumStages(&sync.Mutex{}, 10, &pps.StageConcurrency{}, 0, 0)
+	require.Equal(t, 10, s.queueSize)
+	download, process, upload, _ = runDatums(t, s, 20, time.Millisecond)
+	require.Equal(t, int64(1), download.max)
+	require.Equal(t, int64(1), process.max)
+	require.Equal(t, int64(1), upload.max)
+}
+
+func TestDatumStagesConcurrent(t *testing.T) {
+	// With datums_per_worker, that many datums' code runs at once
+	s := newDatumStages(&sync.Mutex{}, 1, nil, 4, 0)
+	require.Equal(t, 4, s.queueSize)
+	_, process, _, _ := runDatums(t, s, 20, 5*time.Millisecond)
+	require.True(t, process.max > 1)
+	require.True(t, process.max <= 4)
+
+	// Every stage can be busy for each of them
+	s = newDatumStages(&sync.Mutex{}, 1, &pps.StageConcurrency{Download: 2, Upload: 3}, 4, 0)
+	require.Equal(t, 9, s.queueSize)
+	download, process, upload, _ := runDatums(t, s, 30, 5*time.Millisecond)
+	require.True(t, download.max <= 2)
+	require.True(t, process.max <= 4)
+	require.True(t, upload.max <= 3)
+}
+
+func TestDatumStagesOrdered(t *testing.T) {
+	s := newDatumStages(&sync.Mutex{}, 1, &pps.StageConcurrency{Download: 2, Upload: 1, Ordered: true}, 0, 0)
+	var mu sync.Mutex
+	var downloads, runs []int64
+	record := func(order *[]int64, index int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		*order = append(*order, index)
+	}
+	var eg errgroup.Group
+	runDatum := func(index int64, download func()) {
+		eg.Go(func() error {
+			defer s.skip(index)
+			return s.run(index, func() error {
+				record(&downloads, index)
+				download()
+				return nil
+			}, func() error {
+				record(&runs, index)
+				return nil
+			}, func() error {
+				return nil
+			})
+		})
+	}
+	waitForDownloads := func(n int) {
+		require.NoErrorWithinT(t, 10*time.Second, func() error {
+			for {
+				mu.Lock()
+				started := len(downloads)
+				mu.Unlock()
+				if started >= n {
+					return nil
+				}
+				time.Sleep(time.Millisecond)
+			}
+		})
+	}
+
+	// While datum 0 is downloading, later datums reach the download stage out
+	// of order. Though a download slot is free, they wait for datum 1, and
+	// datums 3 and 5 are skipped, so they never reach it.
+	unblock := make(chan struct{})
+	runDatum(0, func() { <-unblock })
+	waitForDownloads(1)
+	for _, index := range []int64{7, 2, 6, 4} {
+		runDatum(index, func() {})
+	}
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	require.Equal(t, []int64{0}, downloads)
+	mu.Unlock()
+	runDatum(1, func() {})
+	waitForDownloads(3)
+	s.skip(3)
+	s.skip(5)
+	close(unblock)
+	require.NoError(t, eg.Wait())
+
+	// They're downloaded, and their code runs, in processing order
+	require.Equal(t, []int64{0, 1, 2, 4, 6, 7}, downloads)
+	require.Equal(t, []int64{0, 1, 2, 4, 6, 7}, runs)
+}
+
+func TestOrderedLimiterRetry(t *testing.T) {
+	// A datum that enters the stage again doesn't wait for the datums after
+	// it, only for a free place
+	l := newOrderedLimiter(1, 0)
+	l.acquire(0)
+	l.release()
+	l.acquire(1)
+	acquired := make(chan struct{})
+	go func() {
+		l.acquire(0)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("datum entered a full stage")
+	case <-time.After(50 * time.Millisecond):
+	}
+	l.release()
+	<-acquired
+	l.release()
+}
+
+// BenchmarkDatumStages compares the time to process datums whose download,
+// code, and upload each take the same time, with and without pipelining
+func BenchmarkDatumStages(b *testing.B) {
+	for _, bm := range []struct {
+		name             string
+		stageConcurrency *pps.StageConcurrency
+	}{
+		{"serial", nil},
+		{"pipelined", &pps.StageConcurrency{Download: 1, Upload: 1}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				runDatums(b, newDatumStages(&sync.Mutex{}, 1, bm.stageConcurrency, 0, 0), 20, time.Millisecond)
+			}
+		})
+	}
+}
+
+// BenchmarkDatumsPerWorker compares the time to process datums whose code is
+// light on CPU (e.g. it waits on a remote service), running one or four
+// datums' code at once
+func BenchmarkDatumsPerWorker(b *testing.B) {
+	for _, bm := range []struct {
+		name            string
+		datumsPerWorker int64
+	}{
+		{"1", 1},
+		{"4", 4},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := newDatumStages(&sync.Mutex{}, 1, nil, bm.datumsPerWorker, 0)
+				var eg errgroup.Group
+				limiter := limit.New(s.queueSize)
+				for j := 0; j < 20; j++ {
+					j := j
+					limiter.Acquire()
+					eg.Go(func() error {
+						defer limiter.Release()
+						return s.run(int64(j), func() error {
+							return nil
+						}, func() error {
+							time.Sleep(time.Millisecond)
+							return nil
+						}, func() error {
+							return nil
+						})
+					})
+				}
+				require.NoError(b, eg.Wait())
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/statsd"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -187,4 +189,31 @@ func initPrometheus() {
 			fmt.Printf("error serving prometheus metrics: %v\n", err)
 		}
 	}()
+	// The same metrics are pushed to StatsD, if it's configured
+	if err := statsd.StartFromEnv(); err != nil {
+		fmt.Printf("error pushing metrics to statsd: %v\n", err)
+	}
+}
+
+// schedulingLatencyBuckets are the buckets of the scheduling latency
+// histogram that workers report in their status
+var schedulingLatencyBuckets = prometheus.ExponentialBuckets(0.1, 2.0, 20) // Which makes the max bucket 2^19/10 seconds or ~15 hours
+
+// newHistogram returns an empty histogram with the buckets 'upperBounds'
+func newHistogram(upperBounds []float64) *pps.Histogram {
+	return &pps.Histogram{
+		UpperBounds:  upperBounds,
+		BucketCounts: make([]uint64, len(upperBounds)),
+	}
+}
+
+// observe adds 'value' to the histogram 'h'
+func observe(h *pps.Histogram, value float64) {
+	for i, upperBound := range h.UpperBounds {
+		if value <= upperBound {
+			h.BucketCounts[i]++
+		}
+	}
+	h.Count++
+	h.Sum += value
 }
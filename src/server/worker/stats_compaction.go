@@ -0,0 +1,241 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+)
+
+const (
+	// statsSummaryDir is the directory in the stats branch that stats
+	// compaction writes job summaries to.
+	statsSummaryDir = "jobs"
+	// statsJobFilePrefix is the prefix of the file in each datum's stats that
+	// records the job that processed the datum.
+	statsJobFilePrefix = "job:"
+)
+
+// statsCompactionInterval is how often a pipeline's stats are compacted.
+// Compaction reads the whole of the previous stats commit, so rather than
+// every job compacting the stats, only the first job to start in each
+// interval does.
+var statsCompactionInterval = time.Hour
+
+// statsCompactionDue returns true if a job that started at 'started' should
+// compact the stats commit of the previous job, which started at
+// 'parentStarted', i.e. if they started in different intervals.
+func statsCompactionDue(started time.Time, parentStarted time.Time) bool {
+	return !started.Truncate(statsCompactionInterval).Equal(parentStarted.Truncate(statsCompactionInterval))
+}
+
+// statsCompaction describes the datums that stats compaction removes from a
+// stats commit, and the job summaries that replace them.
+type statsCompaction struct {
+	// prune maps the path of each pruned datum to the size of its stats
+	prune map[string]int64
+	// summaries maps the ID of each job with pruned datums to its summary
+	summaries map[string]*JobStatsSummary
+}
+
+// compactStats computes the compaction of the stats commit preceding
+// jobInfo's stats commit, i.e. the one that will be merged into it. The
+// retention windows end when jobInfo started (rather than now), so that every
+// merge for the job computes the same compaction. It returns nil if the
+// pipeline has no stats or log retention, if the stats were already compacted
+// in this statsCompactionInterval, or if there's nothing to compact. The
+// compaction is computed once per job, and reused by each of the job's
+// merges that this worker does.
+func (a *APIServer) compactStats(ctx context.Context, pachClient *client.APIClient, jobInfo *pps.JobInfo) (*statsCompaction, error) {
+	a.statsCompactionMu.Lock()
+	defer a.statsCompactionMu.Unlock()
+	if a.statsCompactionJob == jobInfo.Job.ID {
+		return a.statsCompaction, nil
+	}
+	compaction, err := a.computeStatsCompaction(ctx, pachClient, jobInfo)
+	if err != nil {
+		return nil, err
+	}
+	a.statsCompactionJob, a.statsCompaction = jobInfo.Job.ID, compaction
+	return compaction, nil
+}
+
+func (a *APIServer) computeStatsCompaction(ctx context.Context, pachClient *client.APIClient, jobInfo *pps.JobInfo) (*statsCompaction, error) {
+	maxLogAge, maxLogBytes := logRetention(a.pipelineInfo)
+	if a.pipelineInfo.StatsRetention == nil && maxLogAge == 0 && maxLogBytes == 0 {
+		return nil, nil
+	}
+	started, err := types.TimestampFromProto(jobInfo.Started)
+	if err != nil {
+		return nil, err
+	}
+	parentCommitInfo, err := a.getParentCommitInfo(ctx, pachClient, jobInfo.StatsCommit)
+	if err != nil {
+		return nil, err
+	}
+	if parentCommitInfo == nil {
+		return nil, nil
+	}
+	if parentStarted, err := types.TimestampFromProto(parentCommitInfo.Started); err == nil && !statsCompactionDue(started, parentStarted) {
+		return nil, nil
+	}
+	commit := parentCommitInfo.Commit
+	jobFiles, err := pachClient.GlobFile(commit.Repo.Name, commit.ID, path.Join("/*", statsJobFilePrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	jobInfos, err := pachClient.ListJob(a.pipelineInfo.Pipeline.Name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	compaction := &statsCompaction{
+		prune:     make(map[string]int64),
+		summaries: make(map[string]*JobStatsSummary),
+	}
+	if a.pipelineInfo.StatsRetention != nil {
+		retention, err := types.DurationFromProto(a.pipelineInfo.StatsRetention)
+		if err != nil {
+			return nil, err
+		}
+		datums, err := pachClient.GlobFile(commit.Repo.Name, commit.ID, "/*")
+		if err != nil {
+			return nil, err
+		}
+		failures, err := pachClient.GlobFile(commit.Repo.Name, commit.ID, "/*/failure")
+		if err != nil {
+			return nil, err
+		}
+		compaction = planStatsCompaction(datums, jobFiles, failures, jobInfos, started.Add(-retention))
+	}
+	if maxLogAge > 0 || maxLogBytes > 0 {
+		logFiles, err := pachClient.GlobFile(commit.Repo.Name, commit.ID, path.Join("/*", statsLogsFile+"*"))
+		if err != nil {
+			return nil, err
+		}
+		var cutoff time.Time
+		if maxLogAge > 0 {
+			cutoff = started.Add(-maxLogAge)
+		}
+		compaction.pruneLogs(logFiles, jobFiles, jobInfos, cutoff, maxLogBytes)
+	}
+	if len(compaction.prune) == 0 {
+		return nil, nil
+	}
+	return compaction, nil
+}
+
+// planStatsCompaction decides which datums to prune from a stats commit.
+// 'datums' are the top-level directories in the commit, 'jobFiles' the
+// job:<job ID> files in them and 'failures' their failure files. A datum is
+// pruned if every job that processed it finished before 'cutoff'. It's
+// summarized as part of the last of those jobs. Datums processed by jobs that
+// aren't in 'jobInfos' (or haven't finished) are never pruned.
+func planStatsCompaction(datums, jobFiles, failures []*pfs.FileInfo, jobInfos []*pps.JobInfo, cutoff time.Time) *statsCompaction {
+	jobs := make(map[string]*pps.JobInfo)
+	finished := make(map[string]time.Time)
+	for _, jobInfo := range jobInfos {
+		if jobInfo.Finished == nil {
+			continue
+		}
+		t, err := types.TimestampFromProto(jobInfo.Finished)
+		if err != nil {
+			continue
+		}
+		jobs[jobInfo.Job.ID] = jobInfo
+		finished[jobInfo.Job.ID] = t
+	}
+	latest := make(map[string]string)
+	keep := make(map[string]bool)
+	for _, jobFile := range jobFiles {
+		datum := path.Dir(jobFile.File.Path)
+		jobID := strings.TrimPrefix(path.Base(jobFile.File.Path), statsJobFilePrefix)
+		t, ok := finished[jobID]
+		if !ok || !t.Before(cutoff) {
+			keep[datum] = true
+			continue
+		}
+		if prev, ok := latest[datum]; !ok || finished[prev].Before(t) {
+			latest[datum] = jobID
+		}
+	}
+	failed := make(map[string]bool)
+	for _, failure := range failures {
+		failed[path.Dir(failure.File.Path)] = true
+	}
+	result := &statsCompaction{
+		prune:     make(map[string]int64),
+		summaries: make(map[string]*JobStatsSummary),
+	}
+	for _, datum := range datums {
+		jobID, ok := latest[datum.File.Path]
+		if !ok || keep[datum.File.Path] {
+			continue
+		}
+		result.prune[datum.File.Path] = int64(datum.SizeBytes)
+		summary, ok := result.summaries[jobID]
+		if !ok {
+			jobInfo := jobs[jobID]
+			summary = &JobStatsSummary{
+				JobID:    jobID,
+				Started:  jobInfo.Started,
+				Finished: jobInfo.Finished,
+				Stats:    jobInfo.Stats,
+			}
+			result.summaries[jobID] = summary
+		}
+		summary.Datums++
+		if failed[datum.File.Path] {
+			summary.Failed++
+		}
+	}
+	return result
+}
+
+// summaryTree writes the compaction's job summaries to a serialized hashtree,
+// with one file per job under statsSummaryDir. Each summary is a line of
+// JSON, so a job's file remains readable even if it's summarized twice. The
+// summaries are put in the dedup scope 'scope'.
+func (c *statsCompaction) summaryTree(pachClient *client.APIClient, scope string) (*bytes.Buffer, error) {
+	var jobIDs []string
+	for jobID := range c.summaries {
+		jobIDs = append(jobIDs, jobID)
+	}
+	// Files must be added to an ordered hashtree in order
+	sort.Strings(jobIDs)
+	tree := hashtree.NewOrdered(path.Join("/", statsSummaryDir))
+	marshaler := &jsonpb.Marshaler{}
+	for _, jobID := range jobIDs {
+		summary, err := marshaler.MarshalToString(c.summaries[jobID])
+		if err != nil {
+			return nil, err
+		}
+		object, size, err := pachClient.PutObjectInScope(scope, strings.NewReader(summary+"\n"))
+		if err != nil {
+			return nil, err
+		}
+		objectInfo, err := pachClient.InspectObject(object.Hash)
+		if err != nil {
+			return nil, err
+		}
+		h, err := pfs.DecodeHash(object.Hash)
+		if err != nil {
+			return nil, err
+		}
+		tree.PutFile(jobID, h, size, &hashtree.FileNodeProto{BlockRefs: []*pfs.BlockRef{objectInfo.BlockRef}})
+	}
+	buf := &bytes.Buffer{}
+	if err := tree.Serialize(buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func statsFiles(sizes map[string]uint64) []*pfs.FileInfo {
+	var result []*pfs.FileInfo
+	for p, size := range sizes {
+		result = append(result, &pfs.FileInfo{
+			File:      client.NewFile("stats", "commit", p),
+			SizeBytes: size,
+		})
+	}
+	return result
+}
+
+func finishedJob(t *testing.T, id string, finished time.Time, stats *pps.ProcessStats) *pps.JobInfo {
+	started, err := types.TimestampProto(finished.Add(-time.Minute))
+	require.NoError(t, err)
+	f, err := types.TimestampProto(finished)
+	require.NoError(t, err)
+	return &pps.JobInfo{Job: client.NewJob(id), Started: started, Finished: f, Stats: stats}
+}
+
+func TestPlanStatsCompaction(t *testing.T) {
+	now := time.Now()
+	oldStats := &pps.ProcessStats{DownloadBytes: 10, UploadBytes: 20}
+	jobInfos := []*pps.JobInfo{
+		finishedJob(t, "old", now.Add(-48*time.Hour), oldStats),
+		finishedJob(t, "older", now.Add(-72*time.Hour), nil),
+		finishedJob(t, "recent", now.Add(-time.Hour), nil),
+		{Job: client.NewJob("running")},
+	}
+	datums := statsFiles(map[string]uint64{
+		"/a":    1,  // processed by "old"
+		"/b":    2,  // processed by "old", failed
+		"/c":    4,  // processed by "older" and then "old"
+		"/d":    8,  // processed by "recent"
+		"/e":    16, // processed by "old" and then "recent"
+		"/f":    32, // processed by "running"
+		"/g":    64, // processed by a job that's been deleted
+		"/jobs": 128,
+	})
+	jobFiles := statsFiles(map[string]uint64{
+		"/a/job:old":     0,
+		"/b/job:old":     0,
+		"/c/job:older":   0,
+		"/c/job:old":     0,
+		"/d/job:recent":  0,
+		"/e/job:old":     0,
+		"/e/job:recent":  0,
+		"/f/job:running": 0,
+		"/g/job:deleted": 0,
+	})
+	failures := statsFiles(map[string]uint64{"/b/failure": 0})
+
+	compaction := planStatsCompaction(datums, jobFiles, failures, jobInfos, now.Add(-24*time.Hour))
+	// Only datums whose jobs all finished before the cutoff are pruned
+	require.Equal(t, map[string]int64{"/a": 1, "/b": 2, "/c": 4}, compaction.prune)
+	// Pruned datums are summarized as part of their most recent job, which
+	// preserves its aggregate stats
+	require.Equal(t, 1, len(compaction.summaries))
+	summary := compaction.summaries["old"]
+	require.Equal(t, "old", summary.JobID)
+	require.Equal(t, int64(3), summary.Datums)
+	require.Equal(t, int64(1), summary.Failed)
+	require.Equal(t, oldStats, summary.Stats)
+	require.Equal(t, jobInfos[0].Finished, summary.Finished)
+
+	// Nothing is pruned if every job is within the retention window
+	compaction = planStatsCompaction(datums, jobFiles, failures, jobInfos, now.Add(-96*time.Hour))
+	require.Equal(t, 0, len(compaction.prune))
+	require.Equal(t, 0, len(compaction.summaries))
+}
+
+func TestStatsCompactionDue(t *testing.T) {
+	hour := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	// Jobs that start in the same hour as the previous job don't compact
+	require.False(t, statsCompactionDue(hour.Add(30*time.Minute), hour.Add(5*time.Minute)))
+	// The first job of each hour does, however recently the previous job started
+	require.True(t, statsCompactionDue(hour.Add(time.Minute), hour.Add(-time.Minute)))
+	require.True(t, statsCompactionDue(hour.Add(3*time.Hour), hour))
+}
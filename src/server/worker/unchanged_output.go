@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+)
+
+// unchangedOutputLabels are the labels of output commits whose content is
+// identical to their parent's
+var unchangedOutputLabels = map[string]string{client.PPSUnchangedOutputLabel: "true"}
+
+// isUnchangedOutput returns true if the commit in 'commitInfo' is an output
+// commit whose content is identical to its parent's
+func isUnchangedOutput(commitInfo *pfs.CommitInfo) bool {
+	return commitInfo.Labels[client.PPSUnchangedOutputLabel] == "true"
+}
+
+// sameTreeContent returns true if the output trees 'trees' hold the same
+// files, with the same content, as 'parentTrees'. The trees of identical
+// output may still differ (e.g. if a datum was reprocessed, its output is
+// uploaded to new blocks), so files are compared by their paths and content
+// hashes rather than by their trees' hashes.
+func sameTreeContent(pachClient *client.APIClient, trees []*pfs.Object, parentTrees []*pfs.Object) (bool, error) {
+	if len(trees) != len(parentTrees) {
+		return false, nil
+	}
+	for i := range trees {
+		if trees[i].Hash == parentTrees[i].Hash {
+			continue
+		}
+		digest, err := treeContentDigest(pachClient, trees[i])
+		if err != nil {
+			return false, err
+		}
+		parentDigest, err := treeContentDigest(pachClient, parentTrees[i])
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(digest, parentDigest) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// treeContentDigest returns a hash of the paths and content hashes of the
+// files and directories in the tree 'tree'
+func treeContentDigest(pachClient *client.APIClient, tree *pfs.Object) (_ []byte, retErr error) {
+	r, err := pachClient.GetObjectReader(tree.Hash)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	hash := pfs.NewHash()
+	if err := hashtree.Walk([]io.ReadCloser{r}, "/", func(path string, node *hashtree.NodeProto) error {
+		if node.FileNode != nil {
+			fmt.Fprintf(hash, "%s:%x\n", path, node.Hash)
+		} else {
+			fmt.Fprintf(hash, "%s/\n", path)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// markUnchangedOutput makes 'request', which finishes a job's output commit,
+// mark the commit as unchanged if its output is identical to its parent's.
+// The commit is then a copy of its parent.
+func (a *APIServer) markUnchangedOutput(pachClient *client.APIClient, request *pfs.FinishCommitRequest) error {
+	commitInfo, err := pachClient.InspectCommit(request.Commit.Repo.Name, request.Commit.ID)
+	if err != nil {
+		return err
+	}
+	if commitInfo.ParentCommit == nil {
+		return nil
+	}
+	parentInfo, err := pachClient.PfsAPIClient.InspectCommit(pachClient.Ctx(), &pfs.InspectCommitRequest{
+		Commit:     commitInfo.ParentCommit,
+		BlockState: pfs.CommitState_FINISHED,
+	})
+	if err != nil {
+		return err
+	}
+	if parentInfo.Trees == nil {
+		return nil
+	}
+	same, err := sameTreeContent(pachClient, request.Trees, parentInfo.Trees)
+	if err != nil || !same {
+		return err
+	}
+	request.Trees = parentInfo.Trees
+	request.SizeBytes = parentInfo.SizeBytes
+	request.Description = fmt.Sprintf("output unchanged since %s", parentInfo.Commit.ID)
+	request.Labels = unchangedOutputLabels
+	return nil
+}
+
+// hasUnchangedInputs returns the parent of the output commit in
+// 'commitInfo' if the only inputs of the commit that differ from its
+// parent's are unchanged output commits (see isUnchangedOutput), in which
+// case the commit's output would be identical to its parent's. Otherwise, it
+// returns nil.
+func hasUnchangedInputs(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, commitInfo *pfs.CommitInfo) (*pfs.CommitInfo, error) {
+	if commitInfo.ParentCommit == nil {
+		return nil, nil
+	}
+	parentInfo, err := pachClient.PfsAPIClient.InspectCommit(pachClient.Ctx(), &pfs.InspectCommitRequest{
+		Commit:     commitInfo.ParentCommit,
+		BlockState: pfs.CommitState_FINISHED,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if parentInfo.Trees == nil {
+		// The parent's job failed or didn't run, so its output isn't that of
+		// its inputs
+		return nil, nil
+	}
+	parentProvenance := make(map[string]bool)
+	for _, commit := range parentInfo.Provenance {
+		parentProvenance[commit.ID] = true
+	}
+	// Only the pipeline's own inputs are checked, as commits further upstream
+	// only reach it through them
+	inputRepos := make(map[string]bool)
+	for _, branch := range pps.InputBranches(pipelineInfo.Input) {
+		inputRepos[branch.Repo.Name] = true
+	}
+	changed := false
+	for _, commit := range commitInfo.Provenance {
+		if parentProvenance[commit.ID] {
+			continue
+		}
+		if commit.Repo.Name == ppsconsts.SpecRepo {
+			return nil, nil // a new version of the pipeline always runs a job
+		}
+		if !inputRepos[commit.Repo.Name] {
+			continue
+		}
+		provInfo, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !isUnchangedOutput(provInfo) {
+			return nil, nil
+		}
+		changed = true
+	}
+	if !changed {
+		return nil, nil
+	}
+	return parentInfo, nil
+}
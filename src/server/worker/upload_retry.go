@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+// DefaultUploadTries is the default number of times workers try to write
+// output to PFS, for pipelines that don't set upload_tries (including those
+// created before upload_tries existed)
+const DefaultUploadTries = 3
+
+// newUploadBackOff returns the backoff between upload attempts. It's a
+// variable so that tests don't wait.
+var newUploadBackOff = func() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxElapsedTime = 0 // the number of attempts is bounded instead
+	return b
+}
+
+// retryUpload calls 'upload', which writes output to PFS once the work that
+// produced it is done, up to 'tries' times, so that transient PFS errors don't
+// discard the work. It stops early if ctx is cancelled, and calls logf before
+// each retry.
+func retryUpload(ctx context.Context, tries int64, logf func(string, ...interface{}), upload func() error) error {
+	if tries <= 0 {
+		tries = DefaultUploadTries
+	}
+	attempts := int64(1)
+	return backoff.RetryNotify(upload, newUploadBackOff(), func(err error, d time.Duration) error {
+		if isDone(ctx) {
+			return ctx.Err()
+		}
+		if attempts >= tries {
+			return err
+		}
+		attempts++
+		logf("error writing output to PFS (attempt %d of %d): %v; retrying in %v", attempts-1, tries, err, d)
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+)
+
+func TestRetryUpload(t *testing.T) {
+	defer func(f func() backoff.BackOff) { newUploadBackOff = f }(newUploadBackOff)
+	newUploadBackOff = func() backoff.BackOff { return &backoff.ZeroBackOff{} }
+	logf := func(string, ...interface{}) {}
+	errUpload := errors.New("upload failed")
+
+	// An upload that fails once is retried
+	var calls int
+	require.NoError(t, retryUpload(context.Background(), 3, logf, func() error {
+		calls++
+		if calls == 1 {
+			return errUpload
+		}
+		return nil
+	}))
+	require.Equal(t, 2, calls)
+
+	// An upload that keeps failing is tried 'tries' times
+	calls = 0
+	require.Equal(t, errUpload, retryUpload(context.Background(), 4, logf, func() error {
+		calls++
+		return errUpload
+	}))
+	require.Equal(t, 4, calls)
+
+	// Pipelines without upload_tries get the default
+	calls = 0
+	require.Equal(t, errUpload, retryUpload(context.Background(), 0, logf, func() error {
+		calls++
+		return errUpload
+	}))
+	require.Equal(t, DefaultUploadTries, calls)
+
+	// Uploads aren't retried once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	require.Equal(t, context.Canceled, retryUpload(ctx, 3, logf, func() error {
+		calls++
+		cancel()
+		return errUpload
+	}))
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+)
+
+// ErrInputHashMismatch is returned when the content of a staged input file
+// doesn't match the hash that PFS recorded for it, i.e. the file was corrupted
+// in storage or in transit.
+type ErrInputHashMismatch struct {
+	Path     string
+	Object   string
+	Expected string
+	Actual   string
+}
+
+func (e ErrInputHashMismatch) Error() string {
+	if e.Object == "" {
+		return fmt.Sprintf("staged input file %s is corrupt: it is larger than the file in PFS", e.Path)
+	}
+	return fmt.Sprintf("staged input file %s is corrupt: object %s has hash %s, expected %s", e.Path, e.Object, e.Actual, e.Expected)
+}
+
+// IsInputHashMismatchErr returns true if 'err' is an ErrInputHashMismatch
+func IsInputHashMismatchErr(err error) bool {
+	_, ok := err.(ErrInputHashMismatch)
+	return ok
+}
+
+// verifyInputsEnabled returns true if the user has asked for staged input
+// files to be checked against their PFS hashes (see client.PPSVerifyInputsEnv).
+// Verification is off by default, as it requires rereading every input file.
+func verifyInputsEnabled() bool {
+	verify, err := strconv.ParseBool(os.Getenv(client.PPSVerifyInputsEnv))
+	return err == nil && verify
+}
+
+// verifyInput checks that every file staged under 'root' (which holds
+// 'file' from PFS) has the same content as the objects that PFS recorded for
+// it.
+func verifyInput(pachClient *client.APIClient, root string, file *pfs.File) error {
+	return pachClient.Walk(file.Commit.Repo.Name, file.Commit.ID, file.Path, func(fileInfo *pfs.FileInfo) error {
+		if fileInfo.FileType == pfs.FileType_DIR {
+			return nil
+		}
+		if len(fileInfo.BlockRefs) > 0 {
+			// Files written as raw blocks don't carry content hashes
+			return nil
+		}
+		basepath, err := filepath.Rel(file.Path, fileInfo.File.Path)
+		if err != nil {
+			return err
+		}
+		var sizes []uint64
+		for _, object := range fileInfo.Objects {
+			objectInfo, err := pachClient.InspectObject(object.Hash)
+			if err != nil {
+				return err
+			}
+			sizes = append(sizes, objectInfo.BlockRef.Range.Upper-objectInfo.BlockRef.Range.Lower)
+		}
+		return verifyFile(filepath.Join(root, basepath), file.Commit.Repo.Name, fileInfo.Objects, sizes)
+	})
+}
+
+// verifyFile checks that the file at 'path' is the concatenation of 'objects',
+// where sizes[i] is the size of objects[i], and 'repo' is the repo of the file
+// in PFS (whose objects may be in its dedup scope).
+func verifyFile(path string, repo string, objects []*pfs.Object, sizes []uint64) (retErr error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	for i, object := range objects {
+		hash := pfs.NewHash()
+		if _, err := io.CopyN(hash, f, int64(sizes[i])); err != nil && err != io.EOF {
+			return err
+		}
+		if sum := hash.Sum(nil); !pfs.HasContent(object, repo, sum) {
+			return ErrInputHashMismatch{
+				Path:     path,
+				Object:   object.Hash,
+				Expected: object.Hash,
+				Actual:   pfs.EncodeHash(sum),
+			}
+		}
+	}
+	// Make sure there's no trailing data past the last object
+	if n, err := f.Read(make([]byte, 1)); n > 0 || (err != nil && err != io.EOF) {
+		if err != nil {
+			return err
+		}
+		return ErrInputHashMismatch{Path: path}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func objectFor(data string) *pfs.Object {
+	hash := pfs.NewHash()
+	hash.Write([]byte(data))
+	return &pfs.Object{Hash: pfs.EncodeHash(hash.Sum(nil))}
+}
+
+func TestVerifyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verify")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "file")
+	objects := []*pfs.Object{objectFor("foo\n"), objectFor("bar\n")}
+	sizes := []uint64{4, 4}
+
+	// Intact file
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo\nbar\n"), 0644))
+	require.NoError(t, verifyFile(path, "repo", objects, sizes))
+
+	// Corrupted file
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo\nbaz\n"), 0644))
+	err = verifyFile(path, "repo", objects, sizes)
+	require.YesError(t, err)
+	require.True(t, IsInputHashMismatchErr(err))
+
+	// Truncated file
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo\n"), 0644))
+	require.True(t, IsInputHashMismatchErr(verifyFile(path, "repo", objects, sizes)))
+
+	// File with trailing data
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo\nbar\nbaz\n"), 0644))
+	require.True(t, IsInputHashMismatchErr(verifyFile(path, "repo", objects, sizes)))
+
+	// Objects in the file's repo's dedup scope
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo\nbar\n"), 0644))
+	for _, object := range objects {
+		hash, err := pfs.DecodeHash(object.Hash)
+		require.NoError(t, err)
+		object.Hash = pfs.EncodeHash(pfs.ScopeHash(pfs.RepoScope("repo"), hash))
+	}
+	require.NoError(t, verifyFile(path, "repo", objects, sizes))
+	require.True(t, IsInputHashMismatchErr(verifyFile(path, "other", objects, sizes)))
+}
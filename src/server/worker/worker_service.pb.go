@@ -29,6 +29,63 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type DatumEventType int32
+
+const (
+	// DATUM_STARTED is sent when a worker starts processing a datum
+	DatumEventType_DATUM_STARTED DatumEventType = 0
+	// DATUM_PHASE_CHANGED is sent when a datum moves to a new phase. A datum
+	// that's retried goes through its phases again.
+	DatumEventType_DATUM_PHASE_CHANGED DatumEventType = 1
+	// DATUM_COMPLETED is sent when a worker is done with a datum, with the
+	// error that it failed with, if it failed
+	DatumEventType_DATUM_COMPLETED DatumEventType = 2
+)
+
+var DatumEventType_name = map[int32]string{
+	0: "DATUM_STARTED",
+	1: "DATUM_PHASE_CHANGED",
+	2: "DATUM_COMPLETED",
+}
+var DatumEventType_value = map[string]int32{
+	"DATUM_STARTED":       0,
+	"DATUM_PHASE_CHANGED": 1,
+	"DATUM_COMPLETED":     2,
+}
+
+func (x DatumEventType) String() string {
+	return proto.EnumName(DatumEventType_name, int32(x))
+}
+func (DatumEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{0}
+}
+
+type DatumPhase int32
+
+const (
+	DatumPhase_DOWNLOADING DatumPhase = 0
+	DatumPhase_PROCESSING  DatumPhase = 1
+	DatumPhase_UPLOADING   DatumPhase = 2
+)
+
+var DatumPhase_name = map[int32]string{
+	0: "DOWNLOADING",
+	1: "PROCESSING",
+	2: "UPLOADING",
+}
+var DatumPhase_value = map[string]int32{
+	"DOWNLOADING": 0,
+	"PROCESSING":  1,
+	"UPLOADING":   2,
+}
+
+func (x DatumPhase) String() string {
+	return proto.EnumName(DatumPhase_name, int32(x))
+}
+func (DatumPhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{1}
+}
+
 type State int32
 
 const (
@@ -52,27 +109,37 @@ func (x State) String() string {
 	return proto.EnumName(State_name, int32(x))
 }
 func (State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{0}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{2}
 }
 
 type Input struct {
-	FileInfo             *pfs.FileInfo `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
-	ParentCommit         *pfs.Commit   `protobuf:"bytes,5,opt,name=parent_commit,json=parentCommit,proto3" json:"parent_commit,omitempty"`
-	Name                 string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Lazy                 bool          `protobuf:"varint,3,opt,name=lazy,proto3" json:"lazy,omitempty"`
-	Branch               string        `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
-	GitURL               string        `protobuf:"bytes,6,opt,name=git_url,json=gitUrl,proto3" json:"git_url,omitempty"`
-	EmptyFiles           bool          `protobuf:"varint,7,opt,name=empty_files,json=emptyFiles,proto3" json:"empty_files,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	FileInfo     *pfs.FileInfo `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	ParentCommit *pfs.Commit   `protobuf:"bytes,5,opt,name=parent_commit,json=parentCommit,proto3" json:"parent_commit,omitempty"`
+	Name         string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Lazy         bool          `protobuf:"varint,3,opt,name=lazy,proto3" json:"lazy,omitempty"`
+	Branch       string        `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	GitURL       string        `protobuf:"bytes,6,opt,name=git_url,json=gitUrl,proto3" json:"git_url,omitempty"`
+	EmptyFiles   bool          `protobuf:"varint,7,opt,name=empty_files,json=emptyFiles,proto3" json:"empty_files,omitempty"`
+	// If size_bytes is non-zero, only the size_bytes bytes of the file starting
+	// at offset_bytes are part of the datum (see pps.Split).
+	OffsetBytes int64 `protobuf:"varint,8,opt,name=offset_bytes,json=offsetBytes,proto3" json:"offset_bytes,omitempty"`
+	SizeBytes   int64 `protobuf:"varint,9,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// If object_url is set, the input is read from the objects in 'objects'
+	// (which are relative to object_url) in an external bucket, rather than
+	// from pfs (see pps.ObjectInput).
+	ObjectURL            string   `protobuf:"bytes,10,opt,name=object_url,json=objectUrl,proto3" json:"object_url,omitempty"`
+	ObjectSecret         string   `protobuf:"bytes,11,opt,name=object_secret,json=objectSecret,proto3" json:"object_secret,omitempty"`
+	Objects              []string `protobuf:"bytes,12,rep,name=objects,proto3" json:"objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Input) Reset()         { *m = Input{} }
 func (m *Input) String() string { return proto.CompactTextString(m) }
 func (*Input) ProtoMessage()    {}
 func (*Input) Descriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{0}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{0}
 }
 func (m *Input) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -150,6 +217,41 @@ func (m *Input) GetEmptyFiles() bool {
 	return false
 }
 
+func (m *Input) GetOffsetBytes() int64 {
+	if m != nil {
+		return m.OffsetBytes
+	}
+	return 0
+}
+
+func (m *Input) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+func (m *Input) GetObjectURL() string {
+	if m != nil {
+		return m.ObjectURL
+	}
+	return ""
+}
+
+func (m *Input) GetObjectSecret() string {
+	if m != nil {
+		return m.ObjectSecret
+	}
+	return ""
+}
+
+func (m *Input) GetObjects() []string {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
 type CancelRequest struct {
 	JobID                string   `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	DataFilters          []string `protobuf:"bytes,1,rep,name=data_filters,json=dataFilters,proto3" json:"data_filters,omitempty"`
@@ -162,7 +264,7 @@ func (m *CancelRequest) Reset()         { *m = CancelRequest{} }
 func (m *CancelRequest) String() string { return proto.CompactTextString(m) }
 func (*CancelRequest) ProtoMessage()    {}
 func (*CancelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{1}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{1}
 }
 func (m *CancelRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -216,7 +318,7 @@ func (m *CancelResponse) Reset()         { *m = CancelResponse{} }
 func (m *CancelResponse) String() string { return proto.CompactTextString(m) }
 func (*CancelResponse) ProtoMessage()    {}
 func (*CancelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{2}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{2}
 }
 func (m *CancelResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -252,6 +354,104 @@ func (m *CancelResponse) GetSuccess() bool {
 	return false
 }
 
+// DatumEvent is an event in the processing of a datum by a worker
+type DatumEvent struct {
+	Type    DatumEventType   `protobuf:"varint,1,opt,name=type,proto3,enum=worker.DatumEventType" json:"type,omitempty"`
+	JobID   string           `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	DatumID string           `protobuf:"bytes,3,opt,name=datum_id,json=datumId,proto3" json:"datum_id,omitempty"`
+	Data    []*pps.InputFile `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	// phase is the datum's new phase, for DATUM_PHASE_CHANGED events
+	Phase DatumPhase `protobuf:"varint,5,opt,name=phase,proto3,enum=worker.DatumPhase" json:"phase,omitempty"`
+	// error is set for DATUM_COMPLETED events of datums that failed
+	Error                string           `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Time                 *types.Timestamp `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DatumEvent) Reset()         { *m = DatumEvent{} }
+func (m *DatumEvent) String() string { return proto.CompactTextString(m) }
+func (*DatumEvent) ProtoMessage()    {}
+func (*DatumEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{3}
+}
+func (m *DatumEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DatumEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DatumEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *DatumEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatumEvent.Merge(dst, src)
+}
+func (m *DatumEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *DatumEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatumEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatumEvent proto.InternalMessageInfo
+
+func (m *DatumEvent) GetType() DatumEventType {
+	if m != nil {
+		return m.Type
+	}
+	return DatumEventType_DATUM_STARTED
+}
+
+func (m *DatumEvent) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *DatumEvent) GetDatumID() string {
+	if m != nil {
+		return m.DatumID
+	}
+	return ""
+}
+
+func (m *DatumEvent) GetData() []*pps.InputFile {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *DatumEvent) GetPhase() DatumPhase {
+	if m != nil {
+		return m.Phase
+	}
+	return DatumPhase_DOWNLOADING
+}
+
+func (m *DatumEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DatumEvent) GetTime() *types.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
 type ChunkState struct {
 	State                State    `protobuf:"varint,1,opt,name=state,proto3,enum=worker.State" json:"state,omitempty"`
 	DatumID              string   `protobuf:"bytes,2,opt,name=datum_id,json=datumId,proto3" json:"datum_id,omitempty"`
@@ -264,7 +464,7 @@ func (m *ChunkState) Reset()         { *m = ChunkState{} }
 func (m *ChunkState) String() string { return proto.CompactTextString(m) }
 func (*ChunkState) ProtoMessage()    {}
 func (*ChunkState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{3}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{4}
 }
 func (m *ChunkState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -307,6 +507,55 @@ func (m *ChunkState) GetDatumID() string {
 	return ""
 }
 
+// DatumCrashes counts the workers that have started processing a datum
+// without finishing, because they crashed.
+type DatumCrashes struct {
+	Crashes              int64    `protobuf:"varint,1,opt,name=crashes,proto3" json:"crashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatumCrashes) Reset()         { *m = DatumCrashes{} }
+func (m *DatumCrashes) String() string { return proto.CompactTextString(m) }
+func (*DatumCrashes) ProtoMessage()    {}
+func (*DatumCrashes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{5}
+}
+func (m *DatumCrashes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DatumCrashes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DatumCrashes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *DatumCrashes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatumCrashes.Merge(dst, src)
+}
+func (m *DatumCrashes) XXX_Size() int {
+	return m.Size()
+}
+func (m *DatumCrashes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatumCrashes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatumCrashes proto.InternalMessageInfo
+
+func (m *DatumCrashes) GetCrashes() int64 {
+	if m != nil {
+		return m.Crashes
+	}
+	return 0
+}
+
 type MergeState struct {
 	State                State       `protobuf:"varint,1,opt,name=state,proto3,enum=worker.State" json:"state,omitempty"`
 	Tree                 *pfs.Object `protobuf:"bytes,2,opt,name=tree,proto3" json:"tree,omitempty"`
@@ -322,7 +571,7 @@ func (m *MergeState) Reset()         { *m = MergeState{} }
 func (m *MergeState) String() string { return proto.CompactTextString(m) }
 func (*MergeState) ProtoMessage()    {}
 func (*MergeState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{4}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{6}
 }
 func (m *MergeState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -387,18 +636,21 @@ func (m *MergeState) GetStatsSizeBytes() uint64 {
 }
 
 type Plan struct {
-	Chunks               []int64  `protobuf:"varint,1,rep,packed,name=chunks,proto3" json:"chunks,omitempty"`
-	Merges               int64    `protobuf:"varint,2,opt,name=merges,proto3" json:"merges,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Chunks []int64 `protobuf:"varint,1,rep,packed,name=chunks,proto3" json:"chunks,omitempty"`
+	Merges int64   `protobuf:"varint,2,opt,name=merges,proto3" json:"merges,omitempty"`
+	// dispatched is when the plan was written, making its chunks available to
+	// workers
+	Dispatched           *types.Timestamp `protobuf:"bytes,3,opt,name=dispatched,proto3" json:"dispatched,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *Plan) Reset()         { *m = Plan{} }
 func (m *Plan) String() string { return proto.CompactTextString(m) }
 func (*Plan) ProtoMessage()    {}
 func (*Plan) Descriptor() ([]byte, []int) {
-	return fileDescriptor_worker_service_43bc3c346f155e64, []int{5}
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{7}
 }
 func (m *Plan) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -441,13 +693,117 @@ func (m *Plan) GetMerges() int64 {
 	return 0
 }
 
+func (m *Plan) GetDispatched() *types.Timestamp {
+	if m != nil {
+		return m.Dispatched
+	}
+	return nil
+}
+
+// JobStatsSummary summarizes the stats of a job's datums. Stats compaction
+// writes it to the stats branch in place of the datums' detailed stats.
+type JobStatsSummary struct {
+	JobID    string           `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Started  *types.Timestamp `protobuf:"bytes,2,opt,name=started,proto3" json:"started,omitempty"`
+	Finished *types.Timestamp `protobuf:"bytes,3,opt,name=finished,proto3" json:"finished,omitempty"`
+	// Datums is the number of the job's datums whose stats were compacted, and
+	// Failed is the number of those that failed.
+	Datums int64 `protobuf:"varint,4,opt,name=datums,proto3" json:"datums,omitempty"`
+	Failed int64 `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Stats are the job's aggregate process stats.
+	Stats                *pps.ProcessStats `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *JobStatsSummary) Reset()         { *m = JobStatsSummary{} }
+func (m *JobStatsSummary) String() string { return proto.CompactTextString(m) }
+func (*JobStatsSummary) ProtoMessage()    {}
+func (*JobStatsSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_worker_service_b030e8e7e24d8719, []int{8}
+}
+func (m *JobStatsSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobStatsSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobStatsSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *JobStatsSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStatsSummary.Merge(dst, src)
+}
+func (m *JobStatsSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobStatsSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStatsSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobStatsSummary proto.InternalMessageInfo
+
+func (m *JobStatsSummary) GetJobID() string {
+	if m != nil {
+		return m.JobID
+	}
+	return ""
+}
+
+func (m *JobStatsSummary) GetStarted() *types.Timestamp {
+	if m != nil {
+		return m.Started
+	}
+	return nil
+}
+
+func (m *JobStatsSummary) GetFinished() *types.Timestamp {
+	if m != nil {
+		return m.Finished
+	}
+	return nil
+}
+
+func (m *JobStatsSummary) GetDatums() int64 {
+	if m != nil {
+		return m.Datums
+	}
+	return 0
+}
+
+func (m *JobStatsSummary) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *JobStatsSummary) GetStats() *pps.ProcessStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Input)(nil), "worker.Input")
 	proto.RegisterType((*CancelRequest)(nil), "worker.CancelRequest")
 	proto.RegisterType((*CancelResponse)(nil), "worker.CancelResponse")
+	proto.RegisterType((*DatumEvent)(nil), "worker.DatumEvent")
 	proto.RegisterType((*ChunkState)(nil), "worker.ChunkState")
+	proto.RegisterType((*DatumCrashes)(nil), "worker.DatumCrashes")
 	proto.RegisterType((*MergeState)(nil), "worker.MergeState")
 	proto.RegisterType((*Plan)(nil), "worker.Plan")
+	proto.RegisterType((*JobStatsSummary)(nil), "worker.JobStatsSummary")
+	proto.RegisterEnum("worker.DatumEventType", DatumEventType_name, DatumEventType_value)
+	proto.RegisterEnum("worker.DatumPhase", DatumPhase_name, DatumPhase_value)
 	proto.RegisterEnum("worker.State", State_name, State_value)
 }
 
@@ -464,6 +820,9 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type WorkerClient interface {
 	Status(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*pps.WorkerStatus, error)
+	// StatusStream streams events in the processing of the worker's datums as
+	// they happen, starting with the next event.
+	StatusStream(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (Worker_StatusStreamClient, error)
 	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
 }
 
@@ -484,6 +843,38 @@ func (c *workerClient) Status(ctx context.Context, in *types.Empty, opts ...grpc
 	return out, nil
 }
 
+func (c *workerClient) StatusStream(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (Worker_StatusStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker_serviceDesc.Streams[0], "/worker.Worker/StatusStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerStatusStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Worker_StatusStreamClient interface {
+	Recv() (*DatumEvent, error)
+	grpc.ClientStream
+}
+
+type workerStatusStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerStatusStreamClient) Recv() (*DatumEvent, error) {
+	m := new(DatumEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *workerClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
 	out := new(CancelResponse)
 	err := c.cc.Invoke(ctx, "/worker.Worker/Cancel", in, out, opts...)
@@ -496,6 +887,9 @@ func (c *workerClient) Cancel(ctx context.Context, in *CancelRequest, opts ...gr
 // WorkerServer is the server API for Worker service.
 type WorkerServer interface {
 	Status(context.Context, *types.Empty) (*pps.WorkerStatus, error)
+	// StatusStream streams events in the processing of the worker's datums as
+	// they happen, starting with the next event.
+	StatusStream(*types.Empty, Worker_StatusStreamServer) error
 	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
 }
 
@@ -521,6 +915,27 @@ func _Worker_Status_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Worker_StatusStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(types.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkerServer).StatusStream(m, &workerStatusStreamServer{stream})
+}
+
+type Worker_StatusStreamServer interface {
+	Send(*DatumEvent) error
+	grpc.ServerStream
+}
+
+type workerStatusStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerStatusStreamServer) Send(m *DatumEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Worker_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelRequest)
 	if err := dec(in); err != nil {
@@ -552,7 +967,13 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Worker_Cancel_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StatusStream",
+			Handler:       _Worker_StatusStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "server/worker/worker_service.proto",
 }
 
@@ -629,6 +1050,43 @@ func (m *Input) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.OffsetBytes != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.SizeBytes))
+	}
+	if len(m.ObjectURL) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.ObjectURL)))
+		i += copy(dAtA[i:], m.ObjectURL)
+	}
+	if len(m.ObjectSecret) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.ObjectSecret)))
+		i += copy(dAtA[i:], m.ObjectSecret)
+	}
+	if len(m.Objects) > 0 {
+		for _, s := range m.Objects {
+			dAtA[i] = 0x62
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -708,7 +1166,7 @@ func (m *CancelResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ChunkState) Marshal() (dAtA []byte, err error) {
+func (m *DatumEvent) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -718,24 +1176,121 @@ func (m *ChunkState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChunkState) MarshalTo(dAtA []byte) (int, error) {
+func (m *DatumEvent) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.State != 0 {
+	if m.Type != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintWorkerService(dAtA, i, uint64(m.State))
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Type))
 	}
-	if len(m.DatumID) > 0 {
+	if len(m.JobID) > 0 {
 		dAtA[i] = 0x12
 		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.JobID)))
+		i += copy(dAtA[i:], m.JobID)
+	}
+	if len(m.DatumID) > 0 {
+		dAtA[i] = 0x1a
+		i++
 		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.DatumID)))
 		i += copy(dAtA[i:], m.DatumID)
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Data) > 0 {
+		for _, msg := range m.Data {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintWorkerService(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Phase != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Phase))
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.Time != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Time.Size()))
+		n3, err := m.Time.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ChunkState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChunkState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.State))
+	}
+	if len(m.DatumID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.DatumID)))
+		i += copy(dAtA[i:], m.DatumID)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DatumCrashes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DatumCrashes) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Crashes != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Crashes))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
@@ -764,11 +1319,11 @@ func (m *MergeState) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintWorkerService(dAtA, i, uint64(m.Tree.Size()))
-		n3, err := m.Tree.MarshalTo(dAtA[i:])
+		n4, err := m.Tree.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	if m.SizeBytes != 0 {
 		dAtA[i] = 0x18
@@ -779,11 +1334,11 @@ func (m *MergeState) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintWorkerService(dAtA, i, uint64(m.StatsTree.Size()))
-		n4, err := m.StatsTree.MarshalTo(dAtA[i:])
+		n5, err := m.StatsTree.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
 	}
 	if m.StatsSizeBytes != 0 {
 		dAtA[i] = 0x28
@@ -812,28 +1367,105 @@ func (m *Plan) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Chunks) > 0 {
-		dAtA6 := make([]byte, len(m.Chunks)*10)
-		var j5 int
+		dAtA7 := make([]byte, len(m.Chunks)*10)
+		var j6 int
 		for _, num1 := range m.Chunks {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j5++
+				j6++
 			}
-			dAtA6[j5] = uint8(num)
-			j5++
+			dAtA7[j6] = uint8(num)
+			j6++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintWorkerService(dAtA, i, uint64(j5))
-		i += copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintWorkerService(dAtA, i, uint64(j6))
+		i += copy(dAtA[i:], dAtA7[:j6])
 	}
 	if m.Merges != 0 {
 		dAtA[i] = 0x10
 		i++
 		i = encodeVarintWorkerService(dAtA, i, uint64(m.Merges))
 	}
+	if m.Dispatched != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Dispatched.Size()))
+		n8, err := m.Dispatched.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *JobStatsSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobStatsSummary) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(len(m.JobID)))
+		i += copy(dAtA[i:], m.JobID)
+	}
+	if m.Started != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Started.Size()))
+		n9, err := m.Started.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.Finished != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Finished.Size()))
+		n10, err := m.Finished.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.Datums != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Datums))
+	}
+	if m.Failed != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Failed))
+	}
+	if m.Stats != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintWorkerService(dAtA, i, uint64(m.Stats.Size()))
+		n11, err := m.Stats.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -881,6 +1513,26 @@ func (m *Input) Size() (n int) {
 	if m.EmptyFiles {
 		n += 2
 	}
+	if m.OffsetBytes != 0 {
+		n += 1 + sovWorkerService(uint64(m.OffsetBytes))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovWorkerService(uint64(m.SizeBytes))
+	}
+	l = len(m.ObjectURL)
+	if l > 0 {
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	l = len(m.ObjectSecret)
+	if l > 0 {
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if len(m.Objects) > 0 {
+		for _, s := range m.Objects {
+			l = len(s)
+			n += 1 + l + sovWorkerService(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -924,6 +1576,46 @@ func (m *CancelResponse) Size() (n int) {
 	return n
 }
 
+func (m *DatumEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovWorkerService(uint64(m.Type))
+	}
+	l = len(m.JobID)
+	if l > 0 {
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	l = len(m.DatumID)
+	if l > 0 {
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovWorkerService(uint64(l))
+		}
+	}
+	if m.Phase != 0 {
+		n += 1 + sovWorkerService(uint64(m.Phase))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if m.Time != nil {
+		l = m.Time.Size()
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ChunkState) Size() (n int) {
 	if m == nil {
 		return 0
@@ -943,6 +1635,21 @@ func (m *ChunkState) Size() (n int) {
 	return n
 }
 
+func (m *DatumCrashes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Crashes != 0 {
+		n += 1 + sovWorkerService(uint64(m.Crashes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *MergeState) Size() (n int) {
 	if m == nil {
 		return 0
@@ -988,6 +1695,44 @@ func (m *Plan) Size() (n int) {
 	if m.Merges != 0 {
 		n += 1 + sovWorkerService(uint64(m.Merges))
 	}
+	if m.Dispatched != nil {
+		l = m.Dispatched.Size()
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *JobStatsSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobID)
+	if l > 0 {
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if m.Started != nil {
+		l = m.Started.Size()
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if m.Finished != nil {
+		l = m.Finished.Size()
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
+	if m.Datums != 0 {
+		n += 1 + sovWorkerService(uint64(m.Datums))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovWorkerService(uint64(m.Failed))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovWorkerService(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1229,46 +1974,171 @@ func (m *Input) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.EmptyFiles = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipWorkerService(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthWorkerService
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OffsetBytes", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CancelRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowWorkerService
+			m.OffsetBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OffsetBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeBytes", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.SizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObjectURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectSecret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObjectSecret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Objects = append(m.Objects, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkerService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkerService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
 		}
 		fieldNum := int32(wire >> 3)
@@ -1431,6 +2301,246 @@ func (m *CancelResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DatumEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkerService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DatumEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DatumEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (DatumEventType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatumID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, &pps.InputFile{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phase", wireType)
+			}
+			m.Phase = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Phase |= (DatumPhase(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Time == nil {
+				m.Time = &types.Timestamp{}
+			}
+			if err := m.Time.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkerService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ChunkState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1479,11 +2589,91 @@ func (m *ChunkState) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatumID", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatumID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkerService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DatumCrashes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkerService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var stringLen uint64
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DatumCrashes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DatumCrashes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Crashes", wireType)
+			}
+			m.Crashes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkerService
@@ -1493,21 +2683,11 @@ func (m *ChunkState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Crashes |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWorkerService
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DatumID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkerService(dAtA[iNdEx:])
@@ -1825,6 +3005,256 @@ func (m *Plan) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dispatched", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dispatched == nil {
+				m.Dispatched = &types.Timestamp{}
+			}
+			if err := m.Dispatched.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkerService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobStatsSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkerService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobStatsSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobStatsSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Started", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Started == nil {
+				m.Started = &types.Timestamp{}
+			}
+			if err := m.Started.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Finished == nil {
+				m.Finished = &types.Timestamp{}
+			}
+			if err := m.Finished.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Datums", wireType)
+			}
+			m.Datums = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Datums |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			m.Failed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failed |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkerService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkerService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &pps.ProcessStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkerService(dAtA[iNdEx:])
@@ -1953,51 +3383,78 @@ var (
 )
 
 func init() {
-	proto.RegisterFile("server/worker/worker_service.proto", fileDescriptor_worker_service_43bc3c346f155e64)
-}
-
-var fileDescriptor_worker_service_43bc3c346f155e64 = []byte{
-	// 669 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc7, 0x63, 0x92, 0x38, 0xc9, 0xa4, 0xa9, 0xc2, 0x0a, 0x2a, 0xab, 0x88, 0x24, 0xb8, 0x12,
-	0x8a, 0x72, 0x70, 0xaa, 0x22, 0x90, 0x38, 0x92, 0x8f, 0x56, 0x46, 0xfd, 0xd2, 0xb6, 0x15, 0x82,
-	0x8b, 0x65, 0x3b, 0x1b, 0xc7, 0xad, 0xed, 0x35, 0xde, 0x35, 0x28, 0x7d, 0x12, 0xde, 0x08, 0x8e,
-	0x3c, 0x41, 0x85, 0xc2, 0x91, 0x97, 0x40, 0xbb, 0x1b, 0xd3, 0x16, 0x4e, 0x1c, 0xac, 0xcc, 0xfc,
-	0x66, 0xfc, 0xf7, 0x7c, 0xec, 0x06, 0x4c, 0x46, 0xb2, 0x4f, 0x24, 0x1b, 0x7e, 0xa6, 0xd9, 0xd5,
-	0x9f, 0x1f, 0x47, 0xc0, 0xd0, 0x27, 0x56, 0x9a, 0x51, 0x4e, 0x91, 0xae, 0xe8, 0xf6, 0x23, 0x3f,
-	0x0a, 0x49, 0xc2, 0x87, 0xe9, 0x9c, 0x89, 0x47, 0x45, 0x6f, 0x69, 0xca, 0xc4, 0x53, 0xd0, 0x80,
-	0x06, 0x54, 0x9a, 0x43, 0x61, 0xad, 0xe9, 0x93, 0x80, 0xd2, 0x20, 0x22, 0x43, 0xe9, 0x79, 0xf9,
-	0x7c, 0x48, 0xe2, 0x94, 0x2f, 0x55, 0xd0, 0xfc, 0xa5, 0x41, 0xd5, 0x4e, 0xd2, 0x9c, 0xa3, 0x01,
-	0x34, 0xe6, 0x61, 0x44, 0x9c, 0x30, 0x99, 0x53, 0x43, 0xeb, 0x69, 0xfd, 0xe6, 0x5e, 0xcb, 0x12,
-	0x5f, 0xdc, 0x0f, 0x23, 0x62, 0x27, 0x73, 0x8a, 0xeb, 0xf3, 0xb5, 0x85, 0x10, 0x54, 0x12, 0x37,
-	0x26, 0xc6, 0x83, 0x9e, 0xd6, 0x6f, 0x60, 0x69, 0x0b, 0x16, 0xb9, 0xd7, 0x4b, 0xa3, 0xdc, 0xd3,
-	0xfa, 0x75, 0x2c, 0x6d, 0xb4, 0x05, 0xba, 0x97, 0xb9, 0x89, 0xbf, 0x30, 0x2a, 0x32, 0x73, 0xed,
-	0xa1, 0x5d, 0x68, 0xa5, 0x6e, 0x46, 0x12, 0xee, 0xf8, 0x34, 0x8e, 0x43, 0x6e, 0x54, 0xe5, 0xf7,
-	0x9a, 0xf2, 0x7b, 0x63, 0x89, 0xf0, 0x86, 0xca, 0x50, 0x1e, 0xda, 0x81, 0x5a, 0x10, 0x72, 0x27,
-	0xcf, 0x22, 0x43, 0x17, 0x52, 0x23, 0x58, 0xdd, 0x74, 0xf5, 0x83, 0x90, 0x5f, 0xe0, 0x43, 0xac,
-	0x07, 0x21, 0xbf, 0xc8, 0x22, 0xd4, 0x85, 0xa6, 0xec, 0xcd, 0x11, 0x85, 0x32, 0xa3, 0x26, 0x2b,
-	0x01, 0x89, 0x44, 0x13, 0xcc, 0x3c, 0x87, 0xd6, 0xd8, 0x4d, 0x7c, 0x12, 0x61, 0xf2, 0x31, 0x27,
-	0x8c, 0xa3, 0x67, 0xb0, 0x31, 0x73, 0xb9, 0x2b, 0x5e, 0xe0, 0x24, 0x63, 0x86, 0xd6, 0x2b, 0xf7,
-	0x1b, 0xb8, 0x29, 0xd8, 0xbe, 0x42, 0xa8, 0x07, 0xfa, 0x25, 0xf5, 0x9c, 0x70, 0xa6, 0xba, 0x1d,
-	0x35, 0x56, 0x37, 0xdd, 0xea, 0x5b, 0xea, 0xd9, 0x13, 0x5c, 0xbd, 0xa4, 0x9e, 0x3d, 0x33, 0x07,
-	0xb0, 0x59, 0xa8, 0xb2, 0x94, 0x26, 0x8c, 0x20, 0x03, 0x6a, 0x2c, 0xf7, 0x7d, 0xc2, 0x98, 0x9c,
-	0x64, 0x1d, 0x17, 0xae, 0xf9, 0x1e, 0x60, 0xbc, 0xc8, 0x93, 0xab, 0x33, 0xee, 0x72, 0x82, 0x76,
-	0xa0, 0xca, 0x84, 0x21, 0xb3, 0x36, 0xf7, 0x5a, 0x96, 0x5a, 0xba, 0x25, 0xa3, 0x58, 0xc5, 0xd0,
-	0x73, 0xa8, 0xcf, 0x5c, 0x9e, 0xc7, 0xb7, 0x25, 0x34, 0x57, 0x37, 0xdd, 0xda, 0x44, 0x30, 0x7b,
-	0x82, 0x6b, 0x32, 0x68, 0xcf, 0xcc, 0xaf, 0x1a, 0xc0, 0x11, 0xc9, 0x02, 0xf2, 0x1f, 0xda, 0x5d,
-	0xa8, 0xf0, 0x8c, 0xa8, 0x45, 0x16, 0xf3, 0x3f, 0xf1, 0x2e, 0x89, 0xcf, 0xb1, 0x0c, 0xa0, 0xa7,
-	0x00, 0x2c, 0xbc, 0x26, 0x8e, 0xb7, 0xe4, 0x84, 0xc9, 0xdd, 0x56, 0x70, 0x43, 0x90, 0x91, 0x00,
-	0x68, 0x00, 0x20, 0x84, 0x98, 0x23, 0x55, 0x2a, 0xff, 0xaa, 0x34, 0x64, 0xf8, 0x5c, 0x48, 0xf5,
-	0xa1, 0xad, 0x72, 0xef, 0x08, 0x56, 0xa5, 0xe0, 0xa6, 0xe4, 0x67, 0x85, 0xaa, 0xf9, 0x0a, 0x2a,
-	0xa7, 0x91, 0x9b, 0x88, 0xe3, 0xe3, 0x8b, 0x61, 0xa9, 0xbd, 0x94, 0xf1, 0xda, 0x13, 0x3c, 0x16,
-	0x8d, 0x32, 0x59, 0x77, 0x19, 0xaf, 0xbd, 0x81, 0x05, 0x55, 0xd5, 0x7b, 0x13, 0x6a, 0xf8, 0xe2,
-	0xf8, 0xd8, 0x3e, 0x3e, 0x68, 0x97, 0xd0, 0x06, 0xd4, 0xc7, 0x27, 0x47, 0xa7, 0x87, 0xd3, 0xf3,
-	0x69, 0x5b, 0x43, 0x00, 0xfa, 0xfe, 0x1b, 0xfb, 0x70, 0x3a, 0x69, 0x97, 0xf7, 0xae, 0x41, 0x7f,
-	0x27, 0x87, 0x82, 0x5e, 0x82, 0x2e, 0xde, 0xcc, 0x19, 0xda, 0xb2, 0xd4, 0x75, 0xb1, 0x8a, 0xeb,
-	0x62, 0x4d, 0xc5, 0xf9, 0xd9, 0x7e, 0x68, 0x89, 0x7b, 0xa6, 0xd2, 0x55, 0xaa, 0x59, 0x42, 0xaf,
-	0x41, 0x57, 0x9b, 0x47, 0x8f, 0x8b, 0xf1, 0xde, 0x3b, 0x5f, 0xdb, 0x5b, 0x7f, 0x63, 0x75, 0x40,
-	0xcc, 0xd2, 0x68, 0xf4, 0x6d, 0xd5, 0xd1, 0xbe, 0xaf, 0x3a, 0xda, 0x8f, 0x55, 0x47, 0xfb, 0xf2,
-	0xb3, 0x53, 0xfa, 0xb0, 0x1b, 0x84, 0x7c, 0x91, 0x7b, 0x96, 0x4f, 0xe3, 0x61, 0xea, 0xfa, 0x8b,
-	0xe5, 0x8c, 0x64, 0x77, 0x2d, 0x96, 0xf9, 0xc3, 0x7b, 0x7f, 0x1c, 0x9e, 0x2e, 0x6b, 0x7c, 0xf1,
-	0x3b, 0x00, 0x00, 0xff, 0xff, 0x2f, 0x29, 0x6d, 0xb9, 0x50, 0x04, 0x00, 0x00,
+	proto.RegisterFile("server/worker/worker_service.proto", fileDescriptor_worker_service_b030e8e7e24d8719)
+}
+
+var fileDescriptor_worker_service_b030e8e7e24d8719 = []byte{
+	// 1090 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdb, 0x6e, 0x22, 0x47,
+	0x13, 0x66, 0x18, 0x8e, 0x35, 0x80, 0xd9, 0xde, 0xfd, 0xfd, 0x8f, 0x1c, 0xc5, 0x90, 0xb1, 0x94,
+	0x20, 0x14, 0x81, 0x45, 0x0e, 0x52, 0xa2, 0x28, 0x12, 0x06, 0xec, 0x65, 0xe5, 0x03, 0x69, 0xb0,
+	0x56, 0xc9, 0x0d, 0x1a, 0x86, 0x06, 0xc6, 0xcb, 0x1c, 0x32, 0xdd, 0x6c, 0xc4, 0x3e, 0x48, 0x14,
+	0xe5, 0x51, 0xf2, 0x02, 0xc9, 0x65, 0x9e, 0xc0, 0x8a, 0xc8, 0x5b, 0xe4, 0x2a, 0xea, 0x6a, 0x66,
+	0x7d, 0x92, 0xb3, 0xc9, 0xc5, 0x88, 0xaa, 0xaf, 0xbe, 0xae, 0xae, 0xea, 0xfe, 0xaa, 0x05, 0x58,
+	0x9c, 0x45, 0xaf, 0x59, 0xd4, 0xfc, 0x21, 0x88, 0x5e, 0xbd, 0xfd, 0x19, 0x4b, 0xd0, 0x75, 0x58,
+	0x23, 0x8c, 0x02, 0x11, 0x90, 0x8c, 0x42, 0xf7, 0x9e, 0x39, 0x4b, 0x97, 0xf9, 0xa2, 0x19, 0xce,
+	0xb8, 0xfc, 0x54, 0xf4, 0x06, 0x0d, 0xb9, 0xfc, 0x62, 0x74, 0x1e, 0xcc, 0x03, 0x34, 0x9b, 0xd2,
+	0xda, 0xa2, 0xef, 0xcd, 0x83, 0x60, 0xbe, 0x64, 0x4d, 0xf4, 0x26, 0xab, 0x59, 0x93, 0x79, 0xa1,
+	0x58, 0x6f, 0x83, 0x95, 0xfb, 0x41, 0xe1, 0x7a, 0x8c, 0x0b, 0xdb, 0x0b, 0x15, 0xc1, 0xfa, 0x59,
+	0x87, 0x74, 0xdf, 0x0f, 0x57, 0x82, 0xd4, 0x21, 0x3f, 0x73, 0x97, 0x6c, 0xec, 0xfa, 0xb3, 0xc0,
+	0xd4, 0xaa, 0x5a, 0xcd, 0x68, 0x15, 0x1b, 0xb2, 0xa4, 0x63, 0x77, 0xc9, 0xfa, 0xfe, 0x2c, 0xa0,
+	0xb9, 0xd9, 0xd6, 0x22, 0x04, 0x52, 0xbe, 0xed, 0x31, 0x33, 0x59, 0xd5, 0x6a, 0x79, 0x8a, 0xb6,
+	0xc4, 0x96, 0xf6, 0x9b, 0xb5, 0xa9, 0x57, 0xb5, 0x5a, 0x8e, 0xa2, 0x4d, 0x76, 0x21, 0x33, 0x89,
+	0x6c, 0xdf, 0x59, 0x98, 0x29, 0x64, 0x6e, 0x3d, 0x72, 0x08, 0xc5, 0xd0, 0x8e, 0x98, 0x2f, 0xc6,
+	0x4e, 0xe0, 0x79, 0xae, 0x30, 0xd3, 0xb8, 0x9f, 0x81, 0xfb, 0x75, 0x10, 0xa2, 0x05, 0xc5, 0x50,
+	0x1e, 0x39, 0x80, 0xec, 0xdc, 0x15, 0xe3, 0x55, 0xb4, 0x34, 0x33, 0x32, 0xd5, 0x11, 0x6c, 0xae,
+	0x2b, 0x99, 0x13, 0x57, 0x5c, 0xd2, 0x53, 0x9a, 0x99, 0xbb, 0xe2, 0x32, 0x5a, 0x92, 0x0a, 0x18,
+	0xd8, 0xfc, 0x58, 0x16, 0xca, 0xcd, 0x2c, 0x56, 0x02, 0x08, 0xc9, 0x26, 0x38, 0xf9, 0x00, 0x0a,
+	0xc1, 0x6c, 0xc6, 0x99, 0x18, 0x4f, 0xd6, 0x82, 0x71, 0x33, 0x57, 0xd5, 0x6a, 0x3a, 0x35, 0x14,
+	0x76, 0x24, 0x21, 0xf2, 0x3e, 0x00, 0x77, 0xdf, 0xb0, 0x2d, 0x21, 0x8f, 0x84, 0xbc, 0x44, 0x54,
+	0xf8, 0x63, 0x80, 0x60, 0x72, 0xc5, 0x1c, 0x55, 0x0a, 0x60, 0x29, 0xc5, 0xcd, 0x75, 0x25, 0x7f,
+	0x81, 0xa8, 0xac, 0x26, 0xaf, 0x08, 0xb2, 0xa0, 0x03, 0x28, 0x6e, 0xd9, 0x9c, 0x39, 0x11, 0x13,
+	0xa6, 0x81, 0xc7, 0x50, 0x50, 0xe0, 0x10, 0x31, 0x62, 0x42, 0x56, 0xf9, 0xdc, 0x2c, 0x54, 0xf5,
+	0x5a, 0x9e, 0xc6, 0xae, 0x35, 0x82, 0x62, 0xc7, 0xf6, 0x1d, 0xb6, 0xa4, 0xec, 0xfb, 0x15, 0xe3,
+	0x42, 0xd6, 0x3f, 0xb5, 0x85, 0x2d, 0xfb, 0x13, 0x2c, 0xe2, 0xa6, 0x86, 0x7c, 0x43, 0x62, 0xc7,
+	0x0a, 0x22, 0x55, 0xc8, 0x5c, 0x05, 0x93, 0xb1, 0x3b, 0x55, 0x97, 0x73, 0x94, 0xdf, 0x5c, 0x57,
+	0xd2, 0x2f, 0x82, 0x49, 0xbf, 0x4b, 0xd3, 0x57, 0xc1, 0xa4, 0x3f, 0xb5, 0xea, 0x50, 0x8a, 0xb3,
+	0xf2, 0x30, 0xf0, 0x39, 0x93, 0x15, 0xf0, 0x95, 0xe3, 0x30, 0xce, 0xf1, 0xe2, 0x73, 0x34, 0x76,
+	0xad, 0x1f, 0x93, 0x00, 0x5d, 0x5b, 0xac, 0xbc, 0xde, 0x6b, 0xe6, 0x4b, 0x8d, 0xa4, 0xc4, 0x3a,
+	0x64, 0xc8, 0x2a, 0xb5, 0x76, 0x1b, 0x4a, 0xc4, 0x8d, 0x1b, 0xc6, 0x68, 0x1d, 0x32, 0x8a, 0x9c,
+	0x77, 0x17, 0x42, 0x3e, 0x84, 0xdc, 0x54, 0xae, 0x94, 0x1c, 0x1d, 0x39, 0xc6, 0xe6, 0xba, 0x92,
+	0xc5, 0x6c, 0xfd, 0x2e, 0xcd, 0x62, 0xb0, 0x3f, 0x25, 0x16, 0xa4, 0x64, 0x87, 0x66, 0xaa, 0xaa,
+	0xd7, 0x8c, 0x56, 0xa9, 0x21, 0x27, 0x02, 0x35, 0x2b, 0x2f, 0x95, 0x62, 0x8c, 0xd4, 0x20, 0x1d,
+	0x2e, 0x6c, 0xce, 0x50, 0x49, 0xa5, 0x16, 0xb9, 0x53, 0xda, 0x40, 0x46, 0xa8, 0x22, 0x90, 0x67,
+	0x90, 0x66, 0x51, 0x14, 0x44, 0x4a, 0x47, 0x54, 0x39, 0xa4, 0x01, 0x29, 0x39, 0x1a, 0xa8, 0x19,
+	0xa3, 0xb5, 0xd7, 0x50, 0x73, 0xd3, 0x88, 0xe7, 0xa6, 0x31, 0x8a, 0xe7, 0x86, 0x22, 0xcf, 0xfa,
+	0x16, 0xa0, 0xb3, 0x58, 0xf9, 0xaf, 0x86, 0xc2, 0x16, 0x8c, 0x1c, 0x40, 0x9a, 0x4b, 0x63, 0x7b,
+	0x30, 0xc5, 0x78, 0x77, 0x8c, 0x52, 0x15, 0xbb, 0xd3, 0x6e, 0xf2, 0xf1, 0x76, 0xad, 0x1a, 0x14,
+	0x10, 0xeb, 0x44, 0x36, 0x5f, 0x30, 0x2e, 0x6f, 0xc7, 0x51, 0x26, 0xa6, 0xd7, 0x69, 0xec, 0x5a,
+	0xbf, 0x6a, 0x00, 0x67, 0x2c, 0x9a, 0xb3, 0xff, 0x50, 0x45, 0x05, 0x52, 0x22, 0x62, 0x6a, 0x74,
+	0xe3, 0x89, 0x53, 0xda, 0xa5, 0x18, 0xb8, 0x37, 0x00, 0xf2, 0x5e, 0x52, 0xb7, 0x07, 0xa0, 0x0e,
+	0x20, 0x13, 0xf1, 0x31, 0x66, 0x49, 0x3d, 0xcc, 0x92, 0xc7, 0xf0, 0x48, 0xa6, 0xaa, 0x41, 0x59,
+	0x71, 0x6f, 0x25, 0x4c, 0x63, 0xc2, 0x12, 0xe2, 0xc3, 0x38, 0xab, 0x15, 0x41, 0x6a, 0xb0, 0xb4,
+	0x7d, 0xf9, 0x60, 0x38, 0xf2, 0x58, 0x95, 0xb4, 0x75, 0xba, 0xf5, 0x24, 0xee, 0xc9, 0x46, 0x39,
+	0xd6, 0xad, 0xd3, 0xad, 0x47, 0xbe, 0x04, 0x98, 0xba, 0x3c, 0xb4, 0x85, 0xb3, 0x60, 0x4a, 0x44,
+	0xff, 0x7c, 0x79, 0xb7, 0xd8, 0xd6, 0x5f, 0x1a, 0xec, 0xbc, 0x08, 0x26, 0x43, 0xac, 0x64, 0xe5,
+	0x79, 0x76, 0xb4, 0xbe, 0x25, 0x5a, 0xed, 0x11, 0xd1, 0x7e, 0x0a, 0x59, 0x2e, 0xec, 0x48, 0xb0,
+	0xa9, 0x99, 0x7c, 0xe7, 0x76, 0x31, 0x95, 0x7c, 0x0e, 0xb9, 0x99, 0xeb, 0xbb, 0xfc, 0xdf, 0x55,
+	0xf9, 0x96, 0x2b, 0xfb, 0x46, 0x59, 0x70, 0x3c, 0x69, 0x9d, 0x6e, 0x3d, 0x89, 0xcf, 0x6c, 0x77,
+	0xc9, 0xa6, 0x78, 0x9e, 0x3a, 0xdd, 0x7a, 0xe4, 0x23, 0x25, 0x01, 0x8e, 0xe2, 0x36, 0x5a, 0x4f,
+	0x70, 0x56, 0x06, 0x51, 0x20, 0x87, 0x19, 0x1b, 0x55, 0x32, 0xe0, 0xf5, 0x6f, 0xa0, 0x74, 0x77,
+	0x6a, 0xc9, 0x13, 0x28, 0x76, 0xdb, 0xa3, 0xcb, 0xb3, 0xf1, 0x70, 0xd4, 0xa6, 0xa3, 0x5e, 0xb7,
+	0x9c, 0x20, 0xff, 0x87, 0xa7, 0x0a, 0x1a, 0x3c, 0x6f, 0x0f, 0x7b, 0xe3, 0xce, 0xf3, 0xf6, 0xf9,
+	0x49, 0xaf, 0x5b, 0xd6, 0xc8, 0x53, 0xd8, 0x51, 0x81, 0xce, 0xc5, 0xd9, 0xe0, 0xb4, 0x27, 0xd9,
+	0xc9, 0xfa, 0x57, 0x00, 0x37, 0xd3, 0x46, 0x76, 0xc0, 0xe8, 0x5e, 0xbc, 0x3c, 0x3f, 0xbd, 0x68,
+	0x77, 0xfb, 0xe7, 0x27, 0xe5, 0x04, 0x29, 0x01, 0x0c, 0xe8, 0x45, 0xa7, 0x37, 0x1c, 0x4a, 0x5f,
+	0x23, 0x45, 0xc8, 0x5f, 0x0e, 0xe2, 0x70, 0xb2, 0xde, 0x80, 0xb4, 0x52, 0xb1, 0x01, 0x59, 0x7a,
+	0x79, 0x7e, 0xae, 0x16, 0x15, 0x20, 0x17, 0x6f, 0x51, 0xd6, 0x08, 0x40, 0xe6, 0xb8, 0xdd, 0x3f,
+	0xed, 0x75, 0xcb, 0x7a, 0xeb, 0x17, 0x0d, 0x32, 0x2f, 0x51, 0xdf, 0xe4, 0x33, 0xc8, 0xc8, 0xa5,
+	0x2b, 0x4e, 0x76, 0x1f, 0x1c, 0x6a, 0x4f, 0x3e, 0xfe, 0x7b, 0xea, 0x1c, 0x14, 0x5d, 0x51, 0xad,
+	0x04, 0xf9, 0x1a, 0x0a, 0xca, 0x1e, 0x8a, 0x88, 0xd9, 0xde, 0xa3, 0x8b, 0xc9, 0xc3, 0x67, 0xce,
+	0x4a, 0x1c, 0x6a, 0xe4, 0x0b, 0xc8, 0xa8, 0x77, 0x94, 0xfc, 0x2f, 0x66, 0xdc, 0x79, 0xad, 0xf7,
+	0x76, 0xef, 0xc3, 0xea, 0xb9, 0xb5, 0x12, 0x47, 0x47, 0xbf, 0x6d, 0xf6, 0xb5, 0xdf, 0x37, 0xfb,
+	0xda, 0x1f, 0x9b, 0x7d, 0xed, 0xa7, 0x3f, 0xf7, 0x13, 0xdf, 0x1d, 0xce, 0x5d, 0xb1, 0x58, 0x4d,
+	0x1a, 0x4e, 0xe0, 0x35, 0x43, 0xdb, 0x59, 0xac, 0xa7, 0x2c, 0xba, 0x6d, 0xf1, 0xc8, 0x69, 0xde,
+	0xf9, 0x5b, 0x31, 0xc9, 0x60, 0x99, 0x9f, 0xfc, 0x3d, 0x00, 0x25, 0x86, 0xcc, 0x4f, 0x6e, 0x08,
+	0x00, 0x00,
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 )
 
@@ -120,3 +121,16 @@ func newTestAPIServer(pachClient *client.APIClient, etcdClient *etcd.Client, etc
 		plans:     col.NewCollection(etcdClient, path.Join(etcdPrefix, planPrefix), nil, &Plan{}, nil, nil),
 	}
 }
+
+func TestParseWorkerKey(t *testing.T) {
+	rcPrefix := ppsutil.PipelineRcNamePrefix("foo")
+	rcName, address, ok := parseWorkerKey("/pachyderm_pps/workers/pipeline-foo-v3/10.0.0.1", rcPrefix)
+	require.True(t, ok)
+	require.Equal(t, "pipeline-foo-v3", rcName)
+	require.Equal(t, "10.0.0.1", address)
+	// Workers of pipelines whose names start with "foo" don't belong to "foo"
+	_, _, ok = parseWorkerKey("/pachyderm_pps/workers/pipeline-foo-v-bar-v1/10.0.0.2", rcPrefix)
+	require.False(t, ok)
+	_, _, ok = parseWorkerKey("/pachyderm_pps/workers/pipeline-foobar-v1/10.0.0.2", rcPrefix)
+	require.False(t, ok)
+}